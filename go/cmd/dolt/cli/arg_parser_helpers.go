@@ -93,6 +93,7 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsString(StrategyParam, "s", "strategy", "Merge every table with the given {{.LessThan}}strategy{{.GreaterThan}}, one of ours, theirs, union, manual or row, instead of the strategies configured in the dolt_merge_strategies table.")
 
 	return ap
 }
@@ -103,8 +104,8 @@ func CreateRebaseArgParser() *argparser.ArgParser {
 		return errors.New("rebase takes at most one positional argument.")
 	}
 	ap.SupportsString(EmptyParam, "", "empty", "How to handle commits that are not empty to start, but which become empty after rebasing. Valid values are: drop (default) or keep")
-	ap.SupportsFlag(AbortParam, "", "Abort an interactive rebase and return the working set to the pre-rebase state")
-	ap.SupportsFlag(ContinueFlag, "", "Continue an interactive rebase after adjusting the rebase plan")
+	ap.SupportsFlag(AbortParam, "", "Abort a rebase and return the working set to the pre-rebase state")
+	ap.SupportsFlag(ContinueFlag, "", "Continue a rebase after adjusting the rebase plan or resolving conflicts")
 	ap.SupportsFlag(InteractiveFlag, "i", "Start an interactive rebase")
 	return ap
 }
@@ -141,6 +142,8 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
+	ap.SupportsString(RefsFlag, "", "patterns", "Clone only the branches matching a comma separated list of patterns, such as {{.EmphasisLeft}}refs/heads/release/*{{.EmphasisRight}}, and fetch only those branches from the remote afterwards.")
+	ap.SupportsString(FilterFlag, "", "filter-spec", "Make a partial clone which skips the chunks of values larger than a limit, fetching them from the remote when they are first read. The filter is either {{.EmphasisLeft}}blob:none{{.EmphasisRight}} or {{.EmphasisLeft}}blob:limit=<n>[kmg]{{.EmphasisRight}}.")
 	return ap
 }
 
@@ -172,14 +175,14 @@ func CreateCheckoutArgParser() *argparser.ArgParser {
 }
 
 func CreateCherryPickArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs("cherrypick", 1)
+	ap := argparser.NewArgParserWithVariableArgs("cherrypick")
 	ap.SupportsFlag(AbortParam, "", "Abort the current conflict resolution process, and revert all changes from the in-process cherry-pick operation.")
+	ap.SupportsFlag(ContinueFlag, "", "Commit the in-progress cherry-pick after its conflicts and constraint violations have been resolved, and cherry-pick any remaining commits.")
 	ap.SupportsFlag(AllowEmptyFlag, "", "Allow empty commits to be cherry-picked. "+
 		"Note that use of this option only keeps commits that were initially empty. "+
 		"Commits which become empty, due to a previous commit, will cause cherry-pick to fail.")
-	ap.TooManyArgsErrorFunc = func(receivedArgs []string) error {
-		return errors.New("cherry-picking multiple commits is not supported yet.")
-	}
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit",
+		"The commits to cherry-pick, each a single commit or a range {{.LessThan}}from{{.GreaterThan}}..{{.LessThan}}to{{.GreaterThan}}. Commits are applied in the order given, and the commits of a range oldest first."})
 	return ap
 }
 
@@ -194,6 +197,8 @@ func CreateFetchArgParser() *argparser.ArgParser {
 func CreateRevertArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("revert")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(ContinueFlag, "", "Commit an in-progress revert after its conflicts and constraint violations have been resolved.")
+	ap.SupportsFlag(AbortParam, "", "Abort the in-progress revert, and restore the working set to its state before the revert.")
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision",
 		"The commit revisions. If multiple revisions are given, they're applied in the order given."})
 
@@ -247,6 +252,10 @@ func CreateTagArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(VerboseFlag, "v", "list tags along with their metadata.")
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a tag.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(AnnotateFlag, "a", "Make an annotated tag, which requires a tag message.")
+	ap.SupportsFlag(SignTagFlag, "s", "Make a GPG-signed tag, using the key from 'user.signingkey' in the configuration, or the default key of gpg.")
+	ap.SupportsString(LocalUserParam, "u", "key-id", "Make a GPG-signed tag, using the given key.")
+	ap.SupportsFlag(VerifyFlag, "", "Verify the GPG signatures of the given tags.")
 	return ap
 }
 
@@ -283,6 +292,7 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap.SupportsFlag(ShowSignatureFlag, "", "Shows the signature of each commit.")
 	if isTableFunction {
 		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables.")
+		ap.SupportsFlag(GraphFlag, "", "Shows the parents of each commit and the lane it is drawn in on the commit graph.")
 	} else {
 		ap.SupportsFlag(OneLineFlag, "", "Shows logs in a compact format.")
 		ap.SupportsFlag(StatFlag, "", "Shows the diffstat for each commit.")
@@ -305,6 +315,13 @@ func CreateCountCommitsArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateUpdateRefsArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("update_refs")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch or remote tracking branch to update, followed by the commit to point it at."})
+	ap.SupportsFlag(ForceFlag, "f", "Allow updates which aren't fast-forwards.")
+	return ap
+}
+
 func CreateReflogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("reflog", 1)
 	ap.SupportsFlag(AllFlag, "", "Show all refs, including hidden refs, such as DoltHub workspace refs")
@@ -31,6 +31,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	config "github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/diagnostics"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -221,6 +222,10 @@ func (hc SubCommandHandler) handleCommand(ctx context.Context, commandStr string
 		}
 	}
 
+	if _, ok := cmd.(SubCommandHandler); !ok {
+		diagnostics.RecordOp(commandStr, args)
+	}
+
 	var evt *events.Event
 	if evtCmd, ok := cmd.(EventMonitoredCommand); ok {
 		evt = events.NewEvent(evtCmd.EventType())
@@ -21,6 +21,7 @@ const (
 	AllFlag              = "all"
 	AllowEmptyFlag       = "allow-empty"
 	AmendFlag            = "amend"
+	AnnotateFlag         = "annotate"
 	AuthorParam          = "author"
 	BranchParam          = "branch"
 	CachedFlag           = "cached"
@@ -36,6 +37,7 @@ const (
 	DepthFlag            = "depth"
 	DryRunFlag           = "dry-run"
 	EmptyParam           = "empty"
+	FilterFlag           = "filter"
 	ForceFlag            = "force"
 	FullFlag             = "full"
 	GraphFlag            = "graph"
@@ -43,6 +45,7 @@ const (
 	HostFlag             = "host"
 	InteractiveFlag      = "interactive"
 	ListFlag             = "list"
+	LocalUserParam       = "local-user"
 	MergesFlag           = "merges"
 	MessageArg           = "message"
 	MinParentsFlag       = "min-parents"
@@ -64,12 +67,14 @@ const (
 	PortFlag             = "port"
 	PruneFlag            = "prune"
 	QuietFlag            = "quiet"
+	RefsFlag             = "refs"
 	RemoteParam          = "remote"
 	SetUpstreamFlag      = "set-upstream"
 	ShallowFlag          = "shallow"
 	ShowIgnoredFlag      = "ignored"
 	ShowSignatureFlag    = "show-signature"
 	SignFlag             = "gpg-sign"
+	SignTagFlag          = "sign"
 	SilentFlag           = "silent"
 	SingleBranchFlag     = "single-branch"
 	SkipEmptyFlag        = "skip-empty"
@@ -77,10 +82,12 @@ const (
 	SquashParam          = "squash"
 	StagedFlag           = "staged"
 	StatFlag             = "stat"
+	StrategyParam        = "strategy"
 	SystemFlag           = "system"
 	TablesFlag           = "tables"
 	TheirsFlag           = "theirs"
 	TrackFlag            = "track"
 	UpperCaseAllFlag     = "ALL"
 	UserFlag             = "user"
+	VerifyFlag           = "verify"
 )
@@ -20,7 +20,13 @@ import (
 
 var Commands = cli.NewHiddenSubCommandHandler("admin", "Commands for directly working with Dolt storage for purposes of testing or database recovery", []cli.Command{
 	SetRefCmd{},
+	UpdateRefCmd{},
 	ShowRootCmd{},
+	InspectCmd{},
+	CatChunkCmd{},
+	FindReferrersCmd{},
+	HashObjectCmd{},
+	FlushJournalCmd{},
 
 	ZstdCmd{},
 })
@@ -0,0 +1,114 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	catChunkHexFlag  = "hex"
+	catChunkSizeFlag = "size"
+)
+
+type CatChunkCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CatChunkCmd) Name() string {
+	return "cat-chunk"
+}
+
+// Description returns a description of the command
+func (cmd CatChunkCmd) Description() string {
+	return "Writes the raw bytes of the chunk with the given hash to stdout"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd CatChunkCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd CatChunkCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd CatChunkCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"hash", "the address of the chunk to print"})
+	ap.SupportsFlag(catChunkHexFlag, "", "print the chunk hex encoded")
+	ap.SupportsFlag(catChunkSizeFlag, "s", "print the size of the chunk in bytes instead of its contents")
+	return ap
+}
+
+func (cmd CatChunkCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd CatChunkCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("a chunk hash is required").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	h, ok := hash.MaybeParse(strings.TrimPrefix(apr.Arg(0), "#"))
+	if !ok {
+		verr := errhand.BuildDError("invalid hash: %s", apr.Arg(0)).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(dEnv.DoltDB))
+	c, err := cs.Get(ctx, h)
+	if err != nil {
+		verr := errhand.BuildDError("error reading chunk %s", h.String()).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if c.IsEmpty() {
+		verr := errhand.BuildDError("chunk %s not found", h.String()).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	switch {
+	case apr.Contains(catChunkSizeFlag):
+		cli.Println(len(c.Data()))
+	case apr.Contains(catChunkHexFlag):
+		cli.Println(hex.EncodeToString(c.Data()))
+	default:
+		_, err = cli.OutStream.Write(c.Data())
+		if err != nil {
+			verr := errhand.BuildDError("error writing chunk %s", h.String()).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const findReferrersTableFileFlag = "table-file"
+
+type FindReferrersCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FindReferrersCmd) Name() string {
+	return "find-referrers"
+}
+
+// Description returns a description of the command
+func (cmd FindReferrersCmd) Description() string {
+	return "Lists the commits and working sets which keep the given chunks, or the chunks of a table file, from being garbage collected"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd FindReferrersCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd FindReferrersCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd FindReferrersCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"hash", "the address of a chunk to find the referrers of"})
+	ap.SupportsString(findReferrersTableFileFlag, "", "file id", "find the referrers of every chunk in the table file with this id")
+	return ap
+}
+
+func (cmd FindReferrersCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd FindReferrersCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+	fileId, hasFileId := apr.GetValue(findReferrersTableFileFlag)
+	if apr.NArg() == 0 && !hasFileId {
+		verr := errhand.BuildDError("a chunk hash or --%s is required", findReferrersTableFileFlag).SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	targets := hash.NewHashSet()
+	for _, arg := range apr.Args {
+		h, ok := hash.MaybeParse(strings.TrimPrefix(arg, "#"))
+		if !ok {
+			verr := errhand.BuildDError("invalid hash: %s", arg).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		targets.Insert(h)
+	}
+
+	ddb := dEnv.DoltDB
+	if hasFileId {
+		addrs, ok, err := ddb.TableFileAddresses(ctx, fileId)
+		if err != nil {
+			verr := errhand.BuildDError("error reading table file %s", fileId).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		} else if !ok {
+			verr := errhand.BuildDError("table file %s not found", fileId).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		targets.InsertAll(addrs)
+	}
+
+	referrers, err := ddb.FindChunkReferrers(ctx, targets)
+	if err != nil {
+		verr := errhand.BuildDError("error finding referrers").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if len(referrers) == 0 {
+		cli.Println("no commits or working sets reference these chunks")
+		return 0
+	}
+
+	for _, referrer := range referrers {
+		refs := make([]string, len(referrer.Refs))
+		for i, r := range referrer.Refs {
+			refs[i] = r.String()
+		}
+		if referrer.WorkingSet {
+			cli.Printf("working set\t%s\n", strings.Join(refs, ", "))
+			continue
+		}
+		desc, _, _ := strings.Cut(referrer.Meta.Description, "\n")
+		cli.Printf("%s\t%s\t%s\t%s\n", referrer.Commit.String(), referrer.Meta.FormatTS(), strings.Join(refs, ", "), desc)
+	}
+
+	return 0
+}
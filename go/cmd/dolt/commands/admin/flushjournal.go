@@ -0,0 +1,70 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+type FlushJournalCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FlushJournalCmd) Name() string {
+	return "flush-journal"
+}
+
+// Description returns a description of the command
+func (cmd FlushJournalCmd) Description() string {
+	return "Rolls the chunk journal over into a table file, so it no longer needs to be read when the database is opened"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd FlushJournalCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd FlushJournalCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd FlushJournalCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+func (cmd FlushJournalCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd FlushJournalCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	cli.ParseArgsOrDie(ap, args, usage)
+
+	if err := dEnv.DoltDB.FlushJournal(ctx); err != nil {
+		verr := errhand.BuildDError("failed to flush the chunk journal").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const hashObjectWriteFlag = "write"
+
+type HashObjectCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd HashObjectCmd) Name() string {
+	return "hash-object"
+}
+
+// Description returns a description of the command
+func (cmd HashObjectCmd) Description() string {
+	return "Computes the chunk address of a file, or of stdin, and optionally writes it to the chunk store"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd HashObjectCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd HashObjectCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd HashObjectCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "the file to hash. Reads stdin if omitted"})
+	ap.SupportsFlag(hashObjectWriteFlag, "w", "write the contents to the chunk store as a chunk")
+	return ap
+}
+
+func (cmd HashObjectCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd HashObjectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	var data []byte
+	var err error
+	if apr.NArg() == 1 {
+		data, err = os.ReadFile(apr.Arg(0))
+	} else {
+		data, err = io.ReadAll(cli.InStream)
+	}
+	if err != nil {
+		verr := errhand.BuildDError("error reading input").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if len(data) == 0 {
+		verr := errhand.BuildDError("cannot hash an empty chunk").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	c := chunks.NewChunk(data)
+	if apr.Contains(hashObjectWriteFlag) {
+		if !dEnv.Valid() {
+			verr := errhand.BuildDError("--%s requires a dolt repository", hashObjectWriteFlag).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		err = writeRawChunk(ctx, dEnv.DoltDB, c)
+		if err != nil {
+			verr := errhand.BuildDError("error writing chunk %s", c.Hash().String()).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	cli.Println(c.Hash().String())
+	return 0
+}
+
+// writeRawChunk puts |c| into the chunk store backing |ddb| and persists it without moving the store's root.
+func writeRawChunk(ctx context.Context, ddb *doltdb.DoltDB, c chunks.Chunk) error {
+	nbf := ddb.Format()
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	err := cs.Put(ctx, c, func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			return types.AddrsFromNomsValue(c, nbf, addrs)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	root, err := cs.Root(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = cs.Commit(ctx, root, root)
+	return err
+}
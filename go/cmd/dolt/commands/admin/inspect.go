@@ -0,0 +1,129 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const noChildrenFlag = "no-children"
+
+type InspectCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd InspectCmd) Name() string {
+	return "inspect"
+}
+
+// Description returns a description of the command
+func (cmd InspectCmd) Description() string {
+	return "Decodes and pretty-prints the chunk with the given hash, along with the hashes of the chunks it references"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd InspectCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd InspectCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd InspectCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"hash", "the address of the chunk to inspect"})
+	ap.SupportsFlag(noChildrenFlag, "", "do not list the addresses referenced by the chunk")
+	return ap
+}
+
+func (cmd InspectCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd InspectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("a chunk hash is required").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	h, ok := hash.MaybeParse(strings.TrimPrefix(apr.Arg(0), "#"))
+	if !ok {
+		verr := errhand.BuildDError("invalid hash: %s", apr.Arg(0)).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	vrw := dEnv.DoltDB.ValueReadWriter()
+	value, err := vrw.ReadValue(ctx, h)
+	if err != nil {
+		verr := errhand.BuildDError("error reading chunk %s", h.String()).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if value == nil {
+		verr := errhand.BuildDError("chunk %s not found", h.String()).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Printf("Hash: #%s\n", h.String())
+	cli.Printf("Kind: %s\n", value.Kind().String())
+	if sm, ok := value.(types.SerialMessage); ok {
+		cli.Printf("File ID: %s\n", serial.GetFileID(sm))
+		cli.Printf("Size: %d\n", len(sm))
+	}
+	cli.Println(value.HumanReadableString())
+
+	if apr.Contains(noChildrenFlag) {
+		return 0
+	}
+
+	children, err := inspectChildren(dEnv.DoltDB.Format(), value)
+	if err != nil {
+		verr := errhand.BuildDError("error walking references of chunk %s", h.String()).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Printf("Children: %d\n", len(children))
+	for _, c := range children {
+		cli.Printf("\t#%s\n", c.String())
+	}
+
+	return 0
+}
+
+// inspectChildren returns the addresses referenced by |value|, in the order they are encountered.
+func inspectChildren(nbf *types.NomsBinFormat, value types.Value) ([]hash.Hash, error) {
+	var children []hash.Hash
+	err := types.WalkAddrs(value, nbf, func(h hash.Hash, _ bool) error {
+		children = append(children, h)
+		return nil
+	})
+	return children, err
+}
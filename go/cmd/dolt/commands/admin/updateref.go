@@ -0,0 +1,157 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	updateRefDeleteFlag = "delete"
+	updateRefOldParam   = "old"
+)
+
+type UpdateRefCmd struct {
+}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd UpdateRefCmd) Name() string {
+	return "update-ref"
+}
+
+// Description returns a description of the command
+func (cmd UpdateRefCmd) Description() string {
+	return "Updates or deletes any ref in the root refs map, optionally verifying its current value first"
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd UpdateRefCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd UpdateRefCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd UpdateRefCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "the fully qualified ref to update, e.g. refs/heads/main"})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"hash", "the hash to set the ref to"})
+	ap.SupportsFlag(updateRefDeleteFlag, "d", "delete the ref instead of updating it")
+	ap.SupportsString(updateRefOldParam, "", "old-hash", "only update the ref if it currently points at this hash")
+	return ap
+}
+
+func (cmd UpdateRefCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd UpdateRefCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	usage, _ := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+
+	apr := cli.ParseArgsOrDie(ap, args, usage)
+
+	del := apr.Contains(updateRefDeleteFlag)
+	if (del && apr.NArg() != 1) || (!del && apr.NArg() != 2) {
+		verr := errhand.BuildDError("update-ref requires a ref and a hash, or --delete and a ref").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	if !ref.IsRef(apr.Arg(0)) {
+		verr := errhand.BuildDError("'%s' is not a fully qualified ref", apr.Arg(0)).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	r, err := ref.Parse(apr.Arg(0))
+	if err != nil {
+		verr := errhand.BuildDError("invalid ref '%s'", apr.Arg(0)).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	db := doltdb.HackDatasDatabaseFromDoltDB(dEnv.DoltDB)
+	ds, err := db.GetDataset(ctx, r.String())
+	if err != nil {
+		verr := errhand.BuildDError("error reading ref %s", r.String()).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	if apr.Contains(updateRefOldParam) {
+		err = checkOldRefValue(ds, apr.MustGetValue(updateRefOldParam))
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
+	if del {
+		if !ds.HasHead() {
+			verr := errhand.BuildDError("ref %s does not exist", r.String()).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		_, err = db.Delete(ctx, ds, "")
+		if err != nil {
+			verr := errhand.BuildDError("error deleting %s", r.String()).AddCause(err).Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		return 0
+	}
+
+	h, ok := hash.MaybeParse(strings.TrimPrefix(apr.Arg(1), "#"))
+	if !ok {
+		verr := errhand.BuildDError("invalid hash: %s", apr.Arg(1)).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	// set the head of the dataset read above, so that the write fails if the ref moved after it was checked
+	_, err = db.SetHead(ctx, ds, h, "")
+	if err != nil {
+		verr := errhand.BuildDError("error setting %s to %s", r.String(), h.String()).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	return 0
+}
+
+// checkOldRefValue returns an error if the head of |ds| is not |expected|. An empty |expected| asserts that the
+// ref does not exist yet.
+func checkOldRefValue(ds datas.Dataset, expected string) error {
+	var want hash.Hash
+	if expected != "" {
+		var ok bool
+		want, ok = hash.MaybeParse(strings.TrimPrefix(expected, "#"))
+		if !ok {
+			return fmt.Errorf("invalid hash: %s", expected)
+		}
+	}
+
+	got, _ := ds.MaybeHeadAddr()
+	if got != want {
+		return fmt.Errorf("ref %s is at %s, expected %s", ds.ID(), got.String(), want.String())
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestUpdateRef(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	main := refHash(t, ctx, dEnv, "refs/heads/main")
+	other := "refs/heads/other"
+	otherHash := hash.Of([]byte("other"))
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+		expected *hash.Hash
+	}{
+		{
+			name:     "create a ref",
+			args:     []string{other, main.String()},
+			expected: &main,
+		},
+		{
+			name:     "stale old value",
+			args:     []string{"--old", otherHash.String(), other, otherHash.String()},
+			exitCode: 1,
+			expected: &main,
+		},
+		{
+			name:     "old value asserting the ref doesn't exist",
+			args:     []string{"--old", "", other, otherHash.String()},
+			exitCode: 1,
+			expected: &main,
+		},
+		{
+			name:     "matching old value",
+			args:     []string{"--old", main.String(), other, "#" + main.String()},
+			expected: &main,
+		},
+		{
+			name:     "invalid hash",
+			args:     []string{other, "not-a-hash"},
+			exitCode: 1,
+			expected: &main,
+		},
+		{
+			name:     "unqualified ref",
+			args:     []string{"other", main.String()},
+			exitCode: 1,
+			expected: &main,
+		},
+		{
+			name:     "delete with a stale old value",
+			args:     []string{"--delete", "--old", otherHash.String(), other},
+			exitCode: 1,
+			expected: &main,
+		},
+		{
+			name: "delete",
+			args: []string{"--delete", other},
+		},
+		{
+			name:     "delete a missing ref",
+			args:     []string{"--delete", other},
+			exitCode: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exitCode := UpdateRefCmd{}.Exec(ctx, "dolt admin update-ref", test.args, dEnv, nil)
+			assert.Equal(t, test.exitCode, exitCode)
+
+			if test.expected == nil {
+				_, err := dEnv.DoltDB.GetHashForRefStr(ctx, other)
+				assert.ErrorIs(t, err, doltdb.ErrBranchNotFound)
+			} else {
+				assert.Equal(t, *test.expected, refHash(t, ctx, dEnv, other))
+			}
+		})
+	}
+}
+
+func refHash(t *testing.T, ctx context.Context, dEnv *env.DoltEnv, r string) hash.Hash {
+	h, err := dEnv.DoltDB.GetHashForRefStr(ctx, r)
+	require.NoError(t, err)
+	return *h
+}
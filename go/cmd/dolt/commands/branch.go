@@ -36,13 +36,14 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
-	"github.com/dolthub/dolt/go/libraries/utils/set"
 )
 
 var branchDocs = cli.CommandDocumentationContent{
 	ShortDesc: `List, create, or delete branches`,
 	LongDesc: `If {{.EmphasisLeft}}--list{{.EmphasisRight}} is given, or if there are no non-option arguments, existing branches are listed. The current branch will be highlighted with an asterisk. With no options, only local branches are listed. With {{.EmphasisLeft}}-r{{.EmphasisRight}}, only remote branches are listed. With {{.EmphasisLeft}}-a{{.EmphasisRight}} both local and remote branches are listed. {{.EmphasisLeft}}-v{{.EmphasisRight}} causes the hash of the commit that the branches are at to be printed as well.
 
+Branch names may be hierarchical, e.g. {{.EmphasisLeft}}team/feature/x{{.EmphasisRight}}. If one or more {{.LessThan}}pattern{{.GreaterThan}}s are given with {{.EmphasisLeft}}--list{{.EmphasisRight}}, only the branches matching at least one of them are listed. In a pattern, {{.EmphasisLeft}}*{{.EmphasisRight}} matches any sequence of characters including {{.EmphasisLeft}}/{{.EmphasisRight}}, {{.EmphasisLeft}}?{{.EmphasisRight}} matches any one character, and {{.EmphasisLeft}}[...]{{.EmphasisRight}} matches one character of a class, so {{.EmphasisLeft}}dolt branch --list 'team/*'{{.EmphasisRight}} lists every branch in the team namespace. Remote branches are matched by their names including the remote, e.g. {{.EmphasisLeft}}origin/team/*{{.EmphasisRight}}.
+
 The command's second form creates a new branch head named {{.LessThan}}branchname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}start-point{{.GreaterThan}} if given.
 
 Note that this will create the new branch, but it will not switch the working tree to it; use {{.EmphasisLeft}}dolt checkout <newbranch>{{.EmphasisRight}} to switch to the new branch.
@@ -53,7 +54,7 @@ The {{.EmphasisLeft}}-c{{.EmphasisRight}} options have the exact same semantics
 
 With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}branchname{{.GreaterThan}} will be deleted. You may specify more than one branch for deletion.`,
 	Synopsis: []string{
-		`[--list] [-v] [-a] [-r]`,
+		`[--list] [-v] [-a] [-r] [{{.LessThan}}pattern{{.GreaterThan}}...]`,
 		`[-f] {{.LessThan}}branchname{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`-m [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
 		`-c [-f] [{{.LessThan}}oldbranch{{.GreaterThan}}] {{.LessThan}}newbranch{{.GreaterThan}}`,
@@ -189,7 +190,14 @@ func getBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, remote bool) ([]
 }
 
 func printBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser.ArgParseResults, _ cli.UsagePrinter) int {
-	branchSet := set.NewStrSet(apr.Args)
+	patterns := make([]ref.Glob, 0, len(apr.Args))
+	for _, arg := range apr.Args {
+		pattern, err := ref.NewGlob(arg)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: '%s' is not a valid branch pattern", arg).AddCause(err).Build(), nil)
+		}
+		patterns = append(patterns, pattern)
+	}
 
 	verbose := apr.Contains(cli.VerboseFlag)
 	printRemote := apr.Contains(cli.RemoteParam)
@@ -222,7 +230,7 @@ func printBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser
 	})
 
 	for _, branch := range branches {
-		if branchSet.Size() > 0 && !branchSet.Contains(branch.name) {
+		if len(patterns) > 0 && !branch.matchesAnyGlob(patterns) {
 			continue
 		}
 
@@ -249,6 +257,20 @@ func printBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser
 	return 0
 }
 
+// matchesAnyGlob returns whether the branch's name matches any of |patterns|. Remote branches are also matched by their
+// names without the remotes/ prefix, e.g. origin/main.
+func (bm branchMeta) matchesAnyGlob(patterns []ref.Glob) bool {
+	for _, pattern := range patterns {
+		if pattern.Matches(bm.name) {
+			return true
+		}
+		if bm.remote && pattern.Matches(strings.TrimPrefix(bm.name, "remotes/")) {
+			return true
+		}
+	}
+	return false
+}
+
 func printCurrentBranch(sqlCtx *sql.Context, queryEngine cli.Queryist) int {
 	currentBranchName, err := getActiveBranchName(sqlCtx, queryEngine)
 	if err != nil {
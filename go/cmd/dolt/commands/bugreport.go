@@ -0,0 +1,250 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/diagnostics"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	bugReportOutputFlag = "output"
+	bugReportClearFlag  = "clear"
+)
+
+var bugReportDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Package diagnostics for a bug report.",
+	LongDesc: `Packages the crash reports dolt has saved, along with a description of the environment dolt runs in, into a gzipped tar file which can be attached to a bug report.
+
+When dolt crashes, it saves a diagnostic report to the {{.EmphasisLeft}}.dolt/diagnostics{{.EmphasisRight}} directory of the repository it was run in, or of the user's home directory outside a repository. Reports contain the version of dolt, the stack trace of the crash and the names and flags of the commands run before it, but no user data: the arguments and flag values of commands are not recorded, and quoted strings and paths are removed from error messages. Only the latest 10 reports are kept.
+
+Reports are never uploaded. Review the file this command writes before sharing it.`,
+	Synopsis: []string{
+		`[-o {{.LessThan}}file{{.GreaterThan}}] [--clear]`,
+	},
+}
+
+type BugReportCmd struct {
+	VersionStr string
+}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BugReportCmd) Name() string {
+	return "bugreport"
+}
+
+// Description returns a description of the command
+func (cmd BugReportCmd) Description() string {
+	return bugReportDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd BugReportCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd BugReportCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bugReportDocs, ap)
+}
+
+func (cmd BugReportCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(bugReportOutputFlag, "o", "file", "The file to write the bug report to. Defaults to dolt-bugreport-{{.LessThan}}time{{.GreaterThan}}.tar.gz in the current directory.")
+	ap.SupportsFlag(bugReportClearFlag, "", "Delete the crash reports once they have been packaged.")
+	return ap
+}
+
+// bugReportEnvironment describes the environment dolt runs in. It contains no user data.
+type bugReportEnvironment struct {
+	Time          time.Time `json:"time"`
+	Version       string    `json:"version"`
+	GoVersion     string    `json:"go_version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	NumCPU        int       `json:"num_cpu"`
+	InRepo        bool      `json:"in_repo"`
+	StorageFormat string    `json:"storage_format,omitempty"`
+	ConfigKeys    []string  `json:"config_keys"`
+}
+
+// Exec executes the command
+func (cmd BugReportCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bugReportDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	dirs := map[string]string{}
+	if doltDir := dEnv.GetDoltDir(); doltDir != "" {
+		dirs["repo"] = filepath.Join(doltDir, diagnostics.DirName)
+	}
+	homeDir, err := dEnv.GetUserHomeDir()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get user home directory").AddCause(err).Build(), usage)
+	}
+	dirs["global"] = filepath.Join(homeDir, dbfactory.DoltDir, diagnostics.DirName)
+
+	reports := map[string][]string{}
+	numReports := 0
+	for name, dir := range dirs {
+		reports[name], err = diagnostics.ListReports(dEnv.FS, dir)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read crash reports in %s", dir).AddCause(err).Build(), usage)
+		}
+		numReports += len(reports[name])
+	}
+
+	now := time.Now().UTC()
+	outPath := apr.GetValueOrDefault(bugReportOutputFlag, "dolt-bugreport-"+now.Format("20060102-150405")+".tar.gz")
+	verr := writeBugReport(dEnv, outPath, cmd.newEnvironment(dEnv, now), reports)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	if numReports == 0 {
+		cli.Println("No crash reports were found. Only a description of the environment was packaged.")
+	} else {
+		cli.Printf("Packaged %d crash report(s).\n", numReports)
+	}
+	cli.Println(color.CyanString("Wrote %s. Nothing has been uploaded; review it, then attach it to an issue at https://github.com/dolthub/dolt/issues", outPath))
+
+	if apr.Contains(bugReportClearFlag) {
+		for _, paths := range reports {
+			for _, path := range paths {
+				if err = dEnv.FS.DeleteFile(path); err != nil {
+					return HandleVErrAndExitCode(errhand.BuildDError("error: failed to delete %s", path).AddCause(err).Build(), usage)
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func (cmd BugReportCmd) newEnvironment(dEnv *env.DoltEnv, now time.Time) bugReportEnvironment {
+	e := bugReportEnvironment{
+		Time:      now,
+		Version:   cmd.VersionStr,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		InRepo:    dEnv.HasDoltDir(),
+	}
+
+	if dEnv.HasDoltDir() && dEnv.RSLoadErr == nil && dEnv.DBLoadError == nil && dEnv.DoltDB != nil {
+		e.StorageFormat = dfunctions.GetStorageFormatDisplayString(dEnv.DoltDB.Format())
+	}
+
+	// only the names of the config keys which are set, since their values may identify the user
+	if dEnv.Config != nil {
+		for _, scope := range []env.ConfigScope{env.LocalConfig, env.GlobalConfig} {
+			if cfg, ok := dEnv.Config.GetConfig(scope); ok {
+				cfg.Iter(func(name, _ string) bool {
+					e.ConfigKeys = append(e.ConfigKeys, scope.String()+":"+name)
+					return false
+				})
+			}
+		}
+		sort.Strings(e.ConfigKeys)
+	}
+
+	return e
+}
+
+// writeBugReport writes a gzipped tar file to |outPath| containing |environment| as environment.json, and each of
+// the crash reports in |reports| in a directory named after its key.
+func writeBugReport(dEnv *env.DoltEnv, outPath string, environment bugReportEnvironment, reports map[string][]string) errhand.VerboseError {
+	envData, err := json.MarshalIndent(environment, "", "  ")
+	if err != nil {
+		return errhand.BuildDError("error: failed to describe the environment").AddCause(err).Build()
+	}
+
+	// the output path is relative to the current directory rather than the --data-dir
+	f, err := filesys.LocalFS.OpenForWrite(outPath, 0600)
+	if err != nil {
+		return errhand.BuildDError("error: failed to create %s", outPath).AddCause(err).Build()
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	err = writeBugReportFile(tw, "environment.json", envData, environment.Time)
+	names := make([]string, 0, len(reports))
+	for name := range reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, path := range reports[name] {
+			if err != nil {
+				break
+			}
+			var data []byte
+			data, err = dEnv.FS.ReadFile(path)
+			if err == nil {
+				err = writeBugReportFile(tw, name+"/"+filepath.Base(path), data, environment.Time)
+			}
+		}
+	}
+
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = filesys.LocalFS.DeleteFile(outPath)
+		return errhand.BuildDError("error: failed to write %s", outPath).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func writeBugReportFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
@@ -16,11 +16,9 @@ package commands
 
 import (
 	"context"
-	"os"
 	"path"
 	"strings"
 
-	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/store/types"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -104,11 +102,6 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 		return verr
 	}
 
-	dEnv.UserPassConfig, verr = getRemoteUserAndPassConfig(apr)
-	if verr != nil {
-		return verr
-	}
-
 	userDirExists, _ := dEnv.FS.Exists(dir)
 
 	// Check for a valid dolthub url and replace the urlStr with the parsed repoName.
@@ -122,11 +115,31 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if err != nil {
 		return errhand.BuildDError("error: '%s' is not valid.", urlStr).Build()
 	}
+
+	dEnv.UserPassConfig, verr = getRemoteUserAndPassConfig(apr, dEnv, remoteUrl)
+	if verr != nil {
+		return verr
+	}
 	var params map[string]string
 	params, verr = parseRemoteArgs(apr, scheme, remoteUrl)
 	if verr != nil {
 		return verr
 	}
+	if filterSpec, ok := apr.GetValue(cli.FilterFlag); ok {
+		filter, err := doltdb.ParseFetchFilter(filterSpec)
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		params[env.PartialCloneFilterParam] = filter.String()
+	}
+
+	var fetchSpecs []string
+	if patterns, ok := apr.GetValue(cli.RefsFlag); ok {
+		fetchSpecs, err = env.FetchSpecsForRefPatterns(remoteName, strings.Split(patterns, ","))
+		if err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+	}
 
 	var r env.Remote
 	var srcDB *doltdb.DoltDB
@@ -134,6 +147,9 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if verr != nil {
 		return verr
 	}
+	if fetchSpecs != nil {
+		r.FetchSpecs = fetchSpecs
+	}
 
 	// Create a new Dolt env for the clone
 	clonedEnv, err := actions.EnvForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version, env.GetCurrentUserHomeDir)
@@ -243,16 +259,24 @@ func validateAndParseDolthubUrl(urlStr string) (string, bool) {
 	return "", false
 }
 
-func getRemoteUserAndPassConfig(apr *argparser.ArgParseResults) (*creds.DoltCredsForPass, errhand.VerboseError) {
+func getRemoteUserAndPassConfig(apr *argparser.ArgParseResults, dEnv *env.DoltEnv, remoteUrl string) (*creds.DoltCredsForPass, errhand.VerboseError) {
 	if !apr.Contains(cli.UserFlag) {
 		return nil, nil
 	}
-	pass, found := os.LookupEnv(dconfig.EnvDoltRemotePassword)
-	if !found {
-		return nil, errhand.BuildDError("error: must set DOLT_REMOTE_PASSWORD environment variable to use --user param").Build()
+	user := apr.GetValueOrDefault(cli.UserFlag, "")
+
+	var protocol, host string
+	if u, err := earl.Parse(remoteUrl); err == nil {
+		protocol, host = u.Scheme, u.Host
+	}
+	pass, found, err := dEnv.RemotePassword(protocol, host, user)
+	if err != nil {
+		return nil, errhand.BuildDError("error: could not get the password of user %s", user).AddCause(err).Build()
+	} else if !found {
+		return nil, errhand.VerboseErrorFromError(env.ErrNoRemotePassword)
 	}
 	return &creds.DoltCredsForPass{
-		Username: apr.GetValueOrDefault(cli.UserFlag, ""),
+		Username: user,
 		Password: pass,
 	}, nil
 }
@@ -0,0 +1,167 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	// resolutionCol is the column of a conflicts file in which the resolution of each conflict is chosen
+	resolutionCol = "resolution"
+	// resolvedPrefix is the prefix of the columns of a conflicts file which hold a custom resolution's row
+	resolvedPrefix = "resolved_"
+)
+
+var exportDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Writes the conflicts of a table to a CSV file",
+	LongDesc: `Writes the data conflicts of a table to a CSV file, so that they can be resolved in a spreadsheet and applied back with {{.EmphasisLeft}}dolt conflicts import{{.EmphasisRight}}.
+
+The file has a row for each conflict, with the conflict's {{.EmphasisLeft}}dolt_conflict_id{{.EmphasisRight}}, an empty {{.EmphasisLeft}}resolution{{.EmphasisRight}} column, the type of our and their changes, the base, ours and theirs versions of the row, and a {{.EmphasisLeft}}resolved_{{.EmphasisRight}} column for each column of the table, which hold the version of the row in the working set. To resolve a conflict, set its resolution to one of:
+
+{{.EmphasisLeft}}ours{{.EmphasisRight}}: keep our version of the row.
+
+{{.EmphasisLeft}}theirs{{.EmphasisRight}}: take their version of the row.
+
+{{.EmphasisLeft}}custom{{.EmphasisRight}}: write the row in the {{.EmphasisLeft}}resolved_{{.EmphasisRight}} columns, which may be edited, except for the primary key.
+
+{{.EmphasisLeft}}delete{{.EmphasisRight}}: delete the row.
+
+Conflicts whose resolution is left empty are not resolved by the import.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type ExportCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ExportCmd) Name() string {
+	return "export"
+}
+
+// Description returns a description of the command
+func (cmd ExportCmd) Description() string {
+	return "Writes the conflicts of a table to a CSV file."
+}
+
+func (cmd ExportCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(exportDocs, ap)
+}
+
+func (cmd ExportCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table whose conflicts are exported."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The CSV file the conflicts are written to."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd ExportCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, exportDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 2 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a table and a file must be specified").SetPrintUsage().Build(), usage)
+	}
+	tableName, path := apr.Arg(0), apr.Arg(1)
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	wr, err := dEnv.FS.OpenForWrite(path, os.ModePerm)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to open %s for writing", path).AddCause(err).Build(), usage)
+	}
+	n, err := ExportConflicts(queryist, sqlCtx, tableName, wr)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to export conflicts of table %s", tableName).AddCause(err).Build(), usage)
+	}
+
+	cli.Printf("Exported %d conflicts of table %s to %s\n", n, tableName, path)
+	return 0
+}
+
+// ExportConflicts writes the data conflicts of |tableName| to |wr| as a CSV file, in the format read by
+// ImportResolutions, and returns the number of conflicts written. |wr| is closed when the conflicts are written.
+func ExportConflicts(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, wr io.WriteCloser) (n int, err error) {
+	defer func() {
+		if err != nil {
+			wr.Close()
+		}
+	}()
+
+	tblCols, err := getTableColumns(queryist, sqlCtx, tableName)
+	if err != nil {
+		return 0, err
+	}
+
+	projs := []string{"dolt_conflict_id", "NULL AS " + resolutionCol, "our_diff_type", "their_diff_type"}
+	for _, prefix := range []string{"base_", "our_", "their_"} {
+		for _, col := range tblCols.all {
+			projs = append(projs, quoteIdent(prefix+col))
+		}
+	}
+	// the resolved row starts as the row in the working set, which is theirs if we deleted it
+	for _, col := range tblCols.writable {
+		projs = append(projs, fmt.Sprintf("CASE WHEN our_diff_type = 'removed' THEN %s ELSE %s END AS %s",
+			quoteIdent("their_"+col), quoteIdent("our_"+col), quoteIdent(resolvedPrefix+col)))
+	}
+	q := fmt.Sprintf("SELECT %s FROM %s", strings.Join(projs, ", "), quoteIdent("dolt_conflicts_"+tableName))
+
+	sch, iter, _, err := queryist.Query(sqlCtx, q)
+	if err != nil {
+		return 0, err
+	}
+	csvWr, err := csv.NewCSVSqlWriter(wr, sch, csv.NewCSVInfo())
+	if err != nil {
+		return 0, err
+	}
+	for {
+		row, err := iter.Next(sqlCtx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		if err = csvWr.WriteSqlRow(sqlCtx, row); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	if err = iter.Close(sqlCtx); err != nil {
+		return 0, err
+	}
+	return n, csvWr.Close(sqlCtx)
+}
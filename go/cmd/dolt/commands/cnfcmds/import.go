@@ -0,0 +1,330 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	resolutionOurs   = "ours"
+	resolutionTheirs = "theirs"
+	resolutionCustom = "custom"
+	resolutionDelete = "delete"
+)
+
+var importDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Resolves the conflicts of a table with the resolutions in a CSV file",
+	LongDesc: `Resolves the data conflicts of a table with the resolutions chosen in a CSV file written by {{.EmphasisLeft}}dolt conflicts export{{.EmphasisRight}}. The {{.EmphasisLeft}}resolution{{.EmphasisRight}} column of each row of the file is one of {{.EmphasisLeft}}ours{{.EmphasisRight}}, {{.EmphasisLeft}}theirs{{.EmphasisRight}}, {{.EmphasisLeft}}custom{{.EmphasisRight}} or {{.EmphasisLeft}}delete{{.EmphasisRight}}, or is empty to leave the conflict unresolved. See {{.EmphasisLeft}}dolt conflicts export{{.EmphasisRight}} for what each resolution does.
+
+The resolutions are applied in a single transaction, so if any of them fails, none of the conflicts are resolved.`,
+	Synopsis: []string{
+		"{{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type ImportCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ImportCmd) Name() string {
+	return "import"
+}
+
+// Description returns a description of the command
+func (cmd ImportCmd) Description() string {
+	return "Resolves the conflicts of a table with the resolutions in a CSV file."
+}
+
+func (cmd ImportCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(importDocs, ap)
+}
+
+func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The table whose conflicts are resolved."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The CSV file of resolutions, as written by dolt conflicts export."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, importDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 2 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("a table and a file must be specified").SetPrintUsage().Build(), usage)
+	}
+	tableName, path := apr.Arg(0), apr.Arg(1)
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	rd, err := dEnv.FS.OpenForRead(path)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to open %s", path).AddCause(err).Build(), usage)
+	}
+	resolved, remaining, err := ImportResolutions(queryist, sqlCtx, tableName, rd)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to import resolutions of table %s", tableName).AddCause(err).Build(), usage)
+	}
+
+	cli.Printf("Resolved %d conflicts of table %s, %d remaining\n", resolved, tableName, remaining)
+	return 0
+}
+
+// conflictResolution is the resolution of a conflict read from a conflicts file.
+type conflictResolution struct {
+	// line is the line of the file the resolution was read from
+	line       int
+	id         string
+	resolution string
+	// resolved is the row of a custom resolution, in the order of the table's writable columns
+	resolved []interface{}
+}
+
+// ImportResolutions resolves the data conflicts of |tableName| with the resolutions read from |rd|, a CSV file in the
+// format written by ExportConflicts, and returns the number of conflicts resolved and the number left unresolved.
+// The resolutions are applied in a single transaction. |rd| is closed when the file is read.
+func ImportResolutions(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, rd io.ReadCloser) (resolved, remaining int, err error) {
+	tblCols, err := getTableColumns(queryist, sqlCtx, tableName)
+	if err != nil {
+		rd.Close()
+		return 0, 0, err
+	}
+	if len(tblCols.pks) == 0 {
+		rd.Close()
+		return 0, 0, fmt.Errorf("importing resolutions is not supported for keyless tables")
+	}
+
+	resolutions, err := readResolutions(sqlCtx, rd, tblCols)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the conflicts left unresolved must not prevent the transaction from committing
+	if _, err = commands.GetRowsForSql(queryist, sqlCtx, "set @@dolt_allow_commit_conflicts = 1"); err != nil {
+		return 0, 0, fmt.Errorf("failed to set @@dolt_allow_commit_conflicts: %w", err)
+	}
+	if _, err = commands.GetRowsForSql(queryist, sqlCtx, "START TRANSACTION"); err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = commands.GetRowsForSql(queryist, sqlCtx, "ROLLBACK")
+		}
+	}()
+
+	for _, r := range resolutions {
+		if r.resolution == "" {
+			remaining++
+			continue
+		}
+		if err = applyResolution(queryist, sqlCtx, tableName, tblCols, r); err != nil {
+			return 0, 0, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		resolved++
+	}
+	if _, err = commands.GetRowsForSql(queryist, sqlCtx, "COMMIT"); err != nil {
+		return 0, 0, err
+	}
+	return resolved, remaining, nil
+}
+
+// readResolutions reads the resolutions of a conflicts file from |rd|, and closes it.
+func readResolutions(sqlCtx *sql.Context, rd io.ReadCloser, tblCols tableColumns) ([]conflictResolution, error) {
+	csvRd, err := csv.NewCSVReader(types.Format_Default, rd, csv.NewCSVInfo())
+	if err != nil {
+		return nil, err
+	}
+	defer csvRd.Close(sqlCtx)
+
+	colIdx := make(map[string]int)
+	for i, col := range csvRd.GetSchema().GetAllCols().GetColumnNames() {
+		colIdx[strings.ToLower(col)] = i
+	}
+	idIdx, ok := colIdx["dolt_conflict_id"]
+	if !ok {
+		return nil, fmt.Errorf("conflicts file has no dolt_conflict_id column")
+	}
+	resIdx, ok := colIdx[resolutionCol]
+	if !ok {
+		return nil, fmt.Errorf("conflicts file has no %s column", resolutionCol)
+	}
+
+	var resolutions []conflictResolution
+	for line := 2; ; line++ {
+		row, err := csvRd.ReadSqlRow(sqlCtx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		r := conflictResolution{line: line}
+		if id, ok := row[idIdx].(string); ok {
+			r.id = id
+		}
+		if res, ok := row[resIdx].(string); ok {
+			r.resolution = strings.ToLower(strings.TrimSpace(res))
+		}
+		if r.id == "" && r.resolution != "" {
+			return nil, fmt.Errorf("line %d: missing dolt_conflict_id", line)
+		}
+
+		switch r.resolution {
+		case "", resolutionOurs, resolutionTheirs, resolutionDelete:
+		case resolutionCustom:
+			for _, col := range tblCols.writable {
+				i, ok := colIdx[strings.ToLower(resolvedPrefix+col)]
+				if !ok {
+					return nil, fmt.Errorf("conflicts file has no %s column for the custom resolution on line %d", resolvedPrefix+col, line)
+				}
+				r.resolved = append(r.resolved, row[i])
+			}
+		default:
+			return nil, fmt.Errorf("line %d: invalid resolution '%s', expected one of %s, %s, %s or %s",
+				line, r.resolution, resolutionOurs, resolutionTheirs, resolutionCustom, resolutionDelete)
+		}
+		resolutions = append(resolutions, r)
+	}
+	return resolutions, nil
+}
+
+// applyResolution writes the row resolving the conflict |r| of |tableName|, and removes the conflict.
+func applyResolution(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, tblCols tableColumns, r conflictResolution) error {
+	conflictsTable := quoteIdent("dolt_conflicts_" + tableName)
+
+	q, err := dbr.InterpolateForDialect("SELECT their_diff_type FROM "+conflictsTable+" WHERE dolt_conflict_id = ?", []interface{}{r.id}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	rows, err := commands.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("conflict %s not found, it may have been resolved already", r.id)
+	}
+	theirDiffType := fmt.Sprint(rows[0][0])
+
+	// the key of the conflicted row, as a list of conditions on the table's primary key columns
+	keyConds := make([]string, len(tblCols.pks))
+	for i, pk := range tblCols.pks {
+		keyConds[i] = fmt.Sprintf("%s = (SELECT COALESCE(%s, %s, %s) FROM %s WHERE dolt_conflict_id = ?)",
+			quoteIdent(pk), quoteIdent("our_"+pk), quoteIdent("their_"+pk), quoteIdent("base_"+pk), conflictsTable)
+	}
+	keyArgs := make([]interface{}, len(tblCols.pks))
+	for i := range keyArgs {
+		keyArgs[i] = r.id
+	}
+	deleteRow := "DELETE FROM " + quoteIdent(tableName) + " WHERE " + strings.Join(keyConds, " AND ")
+
+	quoted := make([]string, len(tblCols.writable))
+	for i, col := range tblCols.writable {
+		quoted[i] = quoteIdent(col)
+	}
+
+	var stmt string
+	var args []interface{}
+	switch r.resolution {
+	case resolutionOurs:
+	case resolutionDelete:
+		stmt, args = deleteRow, keyArgs
+	case resolutionTheirs:
+		if theirDiffType == "removed" {
+			stmt, args = deleteRow, keyArgs
+			break
+		}
+		theirs := make([]string, len(tblCols.writable))
+		for i, col := range tblCols.writable {
+			theirs[i] = quoteIdent("their_" + col)
+		}
+		stmt = fmt.Sprintf("REPLACE INTO %s (%s) SELECT %s FROM %s WHERE dolt_conflict_id = ?",
+			quoteIdent(tableName), strings.Join(quoted, ", "), strings.Join(theirs, ", "), conflictsTable)
+		args = []interface{}{r.id}
+	case resolutionCustom:
+		if err = checkResolvedKey(queryist, sqlCtx, tableName, tblCols, r); err != nil {
+			return err
+		}
+		stmt = fmt.Sprintf("REPLACE INTO %s (%s) VALUES (%s)",
+			quoteIdent(tableName), strings.Join(quoted, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(quoted)), ", "))
+		args = r.resolved
+	}
+
+	if stmt != "" {
+		q, err = dbr.InterpolateForDialect(stmt, args, dialect.MySQL)
+		if err != nil {
+			return err
+		}
+		if _, err = commands.GetRowsForSql(queryist, sqlCtx, q); err != nil {
+			return err
+		}
+	}
+
+	q, err = dbr.InterpolateForDialect("DELETE FROM "+conflictsTable+" WHERE dolt_conflict_id = ?", []interface{}{r.id}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	_, err = commands.GetRowsForSql(queryist, sqlCtx, q)
+	return err
+}
+
+// checkResolvedKey returns an error if the primary key of the row of the custom resolution |r| isn't the key of the
+// row in conflict, since the resolution of a conflict can't write a different row.
+func checkResolvedKey(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, tblCols tableColumns, r conflictResolution) error {
+	conds := []string{"dolt_conflict_id = ?"}
+	args := []interface{}{r.id}
+	for _, pk := range tblCols.pks {
+		for i, col := range tblCols.writable {
+			if strings.EqualFold(col, pk) {
+				conds = append(conds, fmt.Sprintf("COALESCE(%s, %s, %s) = ?", quoteIdent("our_"+pk), quoteIdent("their_"+pk), quoteIdent("base_"+pk)))
+				args = append(args, r.resolved[i])
+			}
+		}
+	}
+	q, err := dbr.InterpolateForDialect("SELECT COUNT(*) FROM "+quoteIdent("dolt_conflicts_"+tableName)+" WHERE "+strings.Join(conds, " AND "), args, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	rows, err := commands.GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(rows[0][0]) == "0" {
+		return fmt.Errorf("the primary key of the custom resolution of conflict %s must not be changed", r.id)
+	}
+	return nil
+}
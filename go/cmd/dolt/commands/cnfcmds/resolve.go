@@ -34,15 +34,21 @@ var resDocumentation = cli.CommandDocumentationContent{
 	When a merge finds conflicting changes, it documents them in the dolt_conflicts table. A conflict is between two versions: ours (the rows at the destination branch head) and theirs (the rows at the source branch head).
 
 	dolt conflicts resolve will automatically resolve the conflicts by taking either the ours or theirs versions for each row.
+
+	With {{.EmphasisLeft}}--expr{{.EmphasisRight}} or {{.EmphasisLeft}}--script{{.EmphasisRight}}, every conflicted row is instead resolved by a list of column assignments in the form of an UPDATE statement's SET clause. The expressions may refer to the base, ours and theirs versions of the row, eg. {{.EmphasisLeft}}amount = ours.amount + theirs.amount - base.amount{{.EmphasisRight}}. Columns that are not assigned keep our value, or their value if we deleted the row, and every conflicted row is kept. {{.EmphasisLeft}}--script{{.EmphasisRight}} reads the assignments from a file.
 `,
 	Synopsis: []string{
 		`--ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
+		`--expr {{.LessThan}}assignments{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}...`,
+		`--script {{.LessThan}}file{{.GreaterThan}} {{.LessThan}}table{{.GreaterThan}}...`,
 	},
 }
 
 const (
-	oursFlag   = "ours"
-	theirsFlag = "theirs"
+	oursFlag    = "ours"
+	theirsFlag  = "theirs"
+	exprParam   = "expr"
+	scriptParam = "script"
 )
 
 var autoResolveStrategies = map[string]AutoResolveStrategy{
@@ -86,6 +92,8 @@ func (cmd ResolveCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "List of tables to be resolved. '.' can be used to resolve all tables."})
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsString(exprParam, "", "assignments", "For all conflicts, compute the resolved row from these column assignments, which may refer to the base, ours and theirs versions of the row")
+	ap.SupportsString(scriptParam, "", "file", "For all conflicts, compute the resolved row from the column assignments in this file")
 	return ap
 }
 
@@ -104,10 +112,12 @@ func (cmd ResolveCmd) Exec(ctx context.Context, commandStr string, args []string
 	}
 
 	var verr errhand.VerboseError
-	if apr.ContainsAny(autoResolverParams...) {
+	if apr.ContainsAny(exprParam, scriptParam) {
+		verr = scriptResolve(queryist, sqlCtx, apr, dEnv)
+	} else if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(queryist, sqlCtx, apr)
 	} else {
-		verr = errhand.BuildDError("--ours or --theirs must be supplied").SetPrintUsage().Build()
+		verr = errhand.BuildDError("--ours, --theirs, --expr or --script must be supplied").SetPrintUsage().Build()
 	}
 
 	return commands.HandleVErrAndExitCode(verr, usage)
@@ -135,3 +145,33 @@ func autoResolve(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgP
 	}
 	return nil
 }
+
+func scriptResolve(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEnv) errhand.VerboseError {
+	if apr.ContainsAny(autoResolverParams...) || apr.ContainsAll(exprParam, scriptParam) {
+		ff := strings.Join(append(autoResolverParams, exprParam, scriptParam), ", ")
+		return errhand.BuildDError("specify only one from [ %s ]", ff).SetPrintUsage().Build()
+	} else if apr.NArg() == 0 {
+		return errhand.BuildDError("specify at least one table to resolve conflicts").SetPrintUsage().Build()
+	}
+
+	script, ok := apr.GetValue(exprParam)
+	if !ok {
+		path, _ := apr.GetValue(scriptParam)
+		b, err := dEnv.FS.ReadFile(path)
+		if err != nil {
+			return errhand.BuildDError("error: failed to read script %s", path).AddCause(err).Build()
+		}
+		script = string(b)
+	}
+
+	assignments, err := ParseResolutionScript(script)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	err = ScriptResolveTables(queryist, sqlCtx, assignments, apr.Args)
+	if err != nil {
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+	return nil
+}
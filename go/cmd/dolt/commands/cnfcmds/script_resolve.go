@@ -0,0 +1,243 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+)
+
+const (
+	// Aliases for the versions of a conflicted row available to resolution scripts
+	baseAlias   = "base"
+	oursAlias   = "ours"
+	theirsAlias = "theirs"
+)
+
+// ResolutionAssignment sets |Column| of a resolved row to the SQL expression |Expr|.
+type ResolutionAssignment struct {
+	Column string
+	Expr   string
+}
+
+// ParseResolutionScript parses a resolution script, a comma separated list
+// of column assignments in the form of an UPDATE statement's SET clause, eg.
+// "amount = ours.amount + theirs.amount - base.amount, note = theirs.note".
+// Expressions may refer to the base, ours and theirs versions of the row.
+func ParseResolutionScript(script string) ([]ResolutionAssignment, error) {
+	script = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(script), ";"))
+	if script == "" {
+		return nil, fmt.Errorf("resolution script is empty")
+	}
+
+	stmt, err := sqlparser.Parse("UPDATE t SET " + script)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolution script: %w", err)
+	}
+	upd, ok := stmt.(*sqlparser.Update)
+	if !ok || upd.Where != nil || upd.OrderBy != nil || upd.Limit != nil {
+		return nil, fmt.Errorf("invalid resolution script: expected a list of column assignments")
+	}
+
+	assignments := make([]ResolutionAssignment, len(upd.Exprs))
+	seen := make(map[string]struct{})
+	for i, e := range upd.Exprs {
+		if !e.Name.Qualifier.IsEmpty() {
+			return nil, fmt.Errorf("invalid resolution script: assigned column %s must not be qualified", sqlparser.String(e.Name))
+		}
+		col := strings.ToLower(e.Name.Name.String())
+		if _, ok := seen[col]; ok {
+			return nil, fmt.Errorf("invalid resolution script: column %s is assigned more than once", col)
+		}
+		seen[col] = struct{}{}
+		assignments[i] = ResolutionAssignment{Column: col, Expr: sqlparser.String(e.Expr)}
+	}
+	return assignments, nil
+}
+
+// ScriptResolveTables resolves all conflicts in the given tables by writing
+// a row computed from |assignments| for every conflicted row. Columns that
+// are not assigned keep our value, or their value when we deleted the row.
+// The resolved rows replace the rows in the working set, after which the
+// tables' conflicts are cleared.
+func ScriptResolveTables(queryist cli.Queryist, sqlCtx *sql.Context, assignments []ResolutionAssignment, tbls []string) error {
+	if len(tbls) == 1 && tbls[0] == "." {
+		rows, err := commands.GetRowsForSql(queryist, sqlCtx, "SELECT `table` FROM dolt_conflicts")
+		if err != nil {
+			return err
+		}
+		tbls = tbls[:0]
+		for _, row := range rows {
+			tbls = append(tbls, row[0].(string))
+		}
+	}
+
+	for _, tableName := range tbls {
+		q, err := scriptResolveQuery(queryist, sqlCtx, tableName, assignments)
+		if err != nil {
+			return fmt.Errorf("error resolving conflicts for table %s: %w", tableName, err)
+		}
+		if err = scriptResolveTable(queryist, sqlCtx, tableName, q); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scriptResolveTable writes the resolved rows of |tableName| with |query| and
+// clears its conflicts. Both happen in one transaction, since a transaction
+// that leaves the conflicts in place cannot be committed.
+func scriptResolveTable(queryist cli.Queryist, sqlCtx *sql.Context, tableName, query string) (err error) {
+	if _, err = commands.GetRowsForSql(queryist, sqlCtx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, _ = commands.GetRowsForSql(queryist, sqlCtx, "ROLLBACK")
+		}
+	}()
+
+	if _, err = commands.GetRowsForSql(queryist, sqlCtx, query); err != nil {
+		return fmt.Errorf("error resolving conflicts for table %s: %w", tableName, err)
+	}
+	if err = AutoResolveTables(queryist, sqlCtx, AutoResolveStrategyOurs, []string{tableName}); err != nil {
+		return err
+	}
+	_, err = commands.GetRowsForSql(queryist, sqlCtx, "COMMIT")
+	return err
+}
+
+// scriptResolveQuery builds a REPLACE statement that writes the resolved
+// version of every conflicted row in |tableName|. The base, ours and
+// theirs versions of each row are projected out of the table's
+// dolt_conflicts_ table under their aliases, with the table's column names.
+func scriptResolveQuery(queryist cli.Queryist, sqlCtx *sql.Context, tableName string, assignments []ResolutionAssignment) (string, error) {
+	tblCols, err := getTableColumns(queryist, sqlCtx, tableName)
+	if err != nil {
+		return "", err
+	}
+	if len(tblCols.pks) == 0 {
+		return "", fmt.Errorf("resolution scripts are not supported for keyless tables")
+	}
+	pks := make(map[string]struct{})
+	for _, pk := range tblCols.pks {
+		pks[strings.ToLower(pk)] = struct{}{}
+	}
+	cols, allCols := tblCols.writable, tblCols.all
+
+	exprs := make(map[string]string, len(assignments))
+	for _, a := range assignments {
+		if _, ok := pks[a.Column]; ok {
+			return "", fmt.Errorf("resolution scripts cannot assign primary key column %s", a.Column)
+		}
+		var found bool
+		for _, col := range cols {
+			if strings.EqualFold(col, a.Column) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("column %s does not exist or cannot be assigned", a.Column)
+		}
+		exprs[a.Column] = a.Expr
+	}
+
+	conflictsTable := quoteIdent("dolt_conflicts_" + tableName)
+	version := func(alias, prefix string) string {
+		projs := []string{"dolt_conflict_id", "our_diff_type"}
+		for _, col := range allCols {
+			projs = append(projs, fmt.Sprintf("%s AS %s", quoteIdent(prefix+col), quoteIdent(col)))
+		}
+		return fmt.Sprintf("(SELECT %s FROM %s) AS %s", strings.Join(projs, ", "), conflictsTable, alias)
+	}
+
+	projs := make([]string, len(cols))
+	for i, col := range cols {
+		if expr, ok := exprs[strings.ToLower(col)]; ok {
+			projs[i] = expr
+		} else {
+			projs[i] = fmt.Sprintf("CASE WHEN %s.our_diff_type = 'removed' THEN %s.%s ELSE %s.%s END",
+				oursAlias, theirsAlias, quoteIdent(col), oursAlias, quoteIdent(col))
+		}
+	}
+
+	quoted := make([]string, len(cols))
+	for i, col := range cols {
+		quoted[i] = quoteIdent(col)
+	}
+
+	return fmt.Sprintf("REPLACE INTO %s (%s) SELECT %s FROM %s JOIN %s ON %s.dolt_conflict_id = %s.dolt_conflict_id JOIN %s ON %s.dolt_conflict_id = %s.dolt_conflict_id",
+		quoteIdent(tableName),
+		strings.Join(quoted, ", "),
+		strings.Join(projs, ", "),
+		version(oursAlias, "our_"),
+		version(theirsAlias, "their_"), oursAlias, theirsAlias,
+		version(baseAlias, "base_"), oursAlias, baseAlias,
+	), nil
+}
+
+// tableColumns are the columns of a table, as given by its CREATE TABLE statement.
+type tableColumns struct {
+	// all is every column of the table
+	all []string
+	// writable is every column of the table which isn't generated
+	writable []string
+	// pks is the primary key columns of the table, in key order
+	pks []string
+}
+
+// getTableColumns returns the columns of |tableName|.
+func getTableColumns(queryist cli.Queryist, sqlCtx *sql.Context, tableName string) (tableColumns, error) {
+	rows, err := commands.GetRowsForSql(queryist, sqlCtx, "SHOW CREATE TABLE "+quoteIdent(tableName))
+	if err != nil {
+		return tableColumns{}, err
+	}
+	stmt, err := sqlparser.Parse(fmt.Sprint(rows[0][1]))
+	if err != nil {
+		return tableColumns{}, err
+	}
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil {
+		return tableColumns{}, fmt.Errorf("unexpected create statement for table %s", tableName)
+	}
+
+	var tc tableColumns
+	for _, idx := range ddl.TableSpec.Indexes {
+		if idx.Info.Primary {
+			for _, c := range idx.Columns {
+				tc.pks = append(tc.pks, c.Column.String())
+			}
+		}
+	}
+	// generated columns are read from each version, but are not written
+	for _, col := range ddl.TableSpec.Columns {
+		tc.all = append(tc.all, col.Name.String())
+		if col.Type.GeneratedExpr == nil {
+			tc.writable = append(tc.writable, col.Name.String())
+		}
+	}
+	return tc, nil
+}
+
+func quoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
@@ -16,12 +16,14 @@ package commands
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
@@ -35,6 +37,8 @@ const (
 	listOperationStr  = "list"
 	getOperationStr   = "get"
 	unsetOperationStr = "unset"
+
+	listMetricsOperationStr = "list-metrics"
 )
 
 var cfgDocs = cli.CommandDocumentationContent{
@@ -57,8 +61,16 @@ Valid configuration variables:
 
 	- metrics.disabled - boolean flag disables sending metrics when true.
 
+	- metrics.usage - boolean flag, when false disables sending which commands are run and their attributes. True by default.
+
+	- metrics.performance - boolean flag, when false disables sending the timing and size of operations. True by default.
+
+	- metrics.errors - boolean flag, when false disables sending the count of errors. True by default.
+
 	- user.creds - sets user keypairs for authenticating with doltremoteapi.
 
+	- creds.helper - stores the private keys of new credentials, and looks up the passwords of remotes accessed with --user, with a credential helper instead of in plaintext files. One of osxkeychain for the macOS Keychain, wincred for the Windows Credential Manager, the name of a dolt-credential-<name> command, the absolute path of a command, or a shell command prefixed with !. Commands follow the protocol of git credential helpers.
+
 	- user.email - sets name used in the author and committer field of commit objects.
 
 	- user.name - sets email used in the author and committer field of commit objects.
@@ -67,7 +79,21 @@ Valid configuration variables:
 
 	- remotes.default_port - sets default port for authenticating with doltremoteapi.
 
+	- remotes.max_download_rate - limits the bytes per second downloaded from remotes, e.g. 10MB. Unlimited by default.
+
+	- remotes.max_upload_rate - limits the bytes per second uploaded to remotes, e.g. 10MB. Unlimited by default.
+
+	- remotes.max_concurrent_downloads - limits the number of chunk ranges downloaded from a remote at once.
+
+	- remotes.ca_bundle - comma separated paths of PEM files of CA certificates which are trusted by remotes connections, in addition to the system's CA certificates.
+
+	- remotes.tls_min_version - the minimum TLS version of remotes connections, one of 1.0, 1.1, 1.2 or 1.3. 1.2 by default.
+
 	- push.autoSetupRemote - if set to "true" assume --set-upstream on default push when no upstream tracking exists for the current branch.
+
+	- upgrade.channel - the release channel {{.EmphasisLeft}}dolt upgrade{{.EmphasisRight}} installs releases from, either stable or prerelease. stable by default. Read from the global config.
+
+	- upgrade.signingkey - the full fingerprint of the gpg key release checksums must be signed with for {{.EmphasisLeft}}dolt upgrade{{.EmphasisRight}} to install a release. Required to install a release. Read from the global config.
 `,
 
 	Synopsis: []string{
@@ -76,6 +102,7 @@ Valid configuration variables:
 		`[--global|--local] --set {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}value{{.GreaterThan}}`,
 		`[--global|--local] --get {{.LessThan}}name{{.GreaterThan}}`,
 		`[--global|--local] --unset {{.LessThan}}name{{.GreaterThan}}...`,
+		`[--global|--local] --list-metrics`,
 	},
 }
 
@@ -111,6 +138,7 @@ func (cmd ConfigCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(listOperationStr, "", "List the values of all config parameters.")
 	ap.SupportsFlag(getOperationStr, "", "Get the value of one or more config parameters.")
 	ap.SupportsFlag(unsetOperationStr, "", "Unset the value of one or more config parameters.")
+	ap.SupportsFlag(listMetricsOperationStr, "", "List the categories of metrics, and whether sending each is enabled.")
 	return ap
 }
 
@@ -122,7 +150,7 @@ func (cmd ConfigCmd) Exec(ctx context.Context, commandStr string, args []string,
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
 	cfgTypes := apr.FlagsEqualTo([]string{globalParamName, localParamName}, true)
-	ops := apr.FlagsEqualTo([]string{addOperationStr, setOperationStr, listOperationStr, getOperationStr, unsetOperationStr}, true)
+	ops := apr.FlagsEqualTo([]string{addOperationStr, setOperationStr, listOperationStr, getOperationStr, unsetOperationStr, listMetricsOperationStr}, true)
 
 	if cfgTypes.Size() > 1 {
 		cli.PrintErrln(color.RedString("Specifying both -local and -global is not valid. Exactly one may be set"))
@@ -132,7 +160,7 @@ func (cmd ConfigCmd) Exec(ctx context.Context, commandStr string, args []string,
 		case 1:
 			return processConfigCommand(dEnv, cfgTypes, ops.AsSlice()[0], apr.Args, usage)
 		default:
-			cli.PrintErrln(color.RedString("Exactly one of the -add, -set, -get, -unset, -list flags must be set."))
+			cli.PrintErrln(color.RedString("Exactly one of the -add, -set, -get, -unset, -list, -list-metrics flags must be set."))
 			usage()
 		}
 	}
@@ -154,6 +182,8 @@ func processConfigCommand(dEnv *env.DoltEnv, setCfgTypes *set.StrSet, opName str
 		return listOperation(dEnv, setCfgTypes, args, usage, func(k string, v string) {
 			cli.Println(k, "=", v)
 		})
+	case listMetricsOperationStr:
+		return listMetricsOperation(dEnv, setCfgTypes, args, usage)
 	}
 
 	panic("New operation added but not implemented.")
@@ -329,6 +359,52 @@ func listOperation(dEnv *env.DoltEnv, setCfgTypes *set.StrSet, args []string, us
 	return 0
 }
 
+// Lists each category of metrics, whether sending it is enabled by the config, and what it collects.
+func listMetricsOperation(dEnv *env.DoltEnv, setCfgTypes *set.StrSet, args []string, usage cli.UsagePrinter) int {
+	if len(args) != 0 {
+		cli.Println("error: wrong number of arguments")
+		usage()
+		return 1
+	}
+
+	var cfg config.ReadableConfig
+	switch setCfgTypes.Size() {
+	case 0:
+		cfg = dEnv.Config
+	case 1:
+		configElement := newCfgElement(setCfgTypes.AsSlice()[0])
+		var ok bool
+		cfg, ok = dEnv.Config.GetConfig(configElement)
+		if !ok {
+			cli.Println(color.RedString("No config found for %s", configElement.String()))
+			return 1
+		}
+	default:
+		cli.Println(color.RedString("Cannot get more than one config scope at once"))
+		return 1
+	}
+
+	categories, err := events.EnabledCategories(cfg)
+	if err != nil {
+		cli.PrintErrln(color.RedString("error: %s", err.Error()))
+		return 1
+	}
+
+	if disabled, _ := strconv.ParseBool(cfg.GetStringOrDefault(config.MetricsDisabled, "false")); disabled {
+		cli.Println(color.YellowString("All metrics are disabled by %s", config.MetricsDisabled))
+	}
+
+	for _, c := range events.Categories {
+		status := color.GreenString("enabled")
+		if !categories[c] {
+			status = color.RedString("disabled")
+		}
+		cli.Printf("%s (%s) = %s\n\t%s\n", c, c.ConfigKey(), status, c.Description())
+	}
+
+	return 0
+}
+
 func newCfgElement(configFlag string) env.ConfigScope {
 	switch configFlag {
 	case localParamName:
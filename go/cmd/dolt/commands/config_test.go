@@ -419,3 +419,20 @@ func TestInvalidConfigArgs(t *testing.T) {
 		t.Error("Invalid commands should fail. Command is missing local/global")
 	}
 }
+
+func TestConfigListMetrics(t *testing.T) {
+	ctx := context.TODO()
+	dEnv := createTestEnv()
+	configCmd := ConfigCmd{}
+
+	ret := configCmd.Exec(ctx, "dolt config", []string{"--global", "--list-metrics"}, dEnv, nil)
+	assert.Equal(t, 0, ret)
+
+	ret = configCmd.Exec(ctx, "dolt config", []string{"--list-metrics", "metrics.usage"}, dEnv, nil)
+	assert.Equal(t, 1, ret)
+
+	ret = configCmd.Exec(ctx, "dolt config", []string{"--global", "--add", config.MetricsErrors, "sometimes"}, dEnv, nil)
+	assert.Equal(t, 0, ret)
+	ret = configCmd.Exec(ctx, "dolt config", []string{"--global", "--list-metrics"}, dEnv, nil)
+	assert.Equal(t, 1, ret)
+}
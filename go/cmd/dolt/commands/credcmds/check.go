@@ -129,7 +129,7 @@ func loadCred(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (creds.DoltCred
 			return creds.EmptyCreds, errhand.BuildDError("error: finding credential %s", keyIdOrPubKey).AddCause(err).Build()
 		}
 
-		dc, err := creds.JWKCredsReadFromFile(dEnv.FS, found)
+		dc, err := dEnv.ReadCreds(found)
 		if err != nil {
 			return creds.EmptyCreds, errhand.BuildDError("error: reading credentials").AddCause(err).Build()
 		}
@@ -42,7 +42,9 @@ var importDocs = cli.CommandDocumentationContent{
 Dolt credentials are stored in the creds subdirectory of the global dolt config
 directory as files with one key per file in JWK format. This command can import
 a JWK from a file or stdin and places the imported key in the correct place for
-dolt to find it as a valid credential.
+dolt to find it as a valid credential. If the creds.helper config is set, the
+private key of the imported credential is stored with the credential helper
+instead, and only its public key is written to the creds directory.
 
 This command will set the newly imported credential as the used credential if
 there are currently not credentials. If this command does use the new
@@ -122,7 +124,7 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
-	_, err = creds.JWKCredsWriteToDir(dEnv.FS, credsDir, c)
+	_, err = dEnv.WriteCreds(credsDir, c)
 	if err != nil {
 		verr = errhand.BuildDError("error: could not write credentials to file").AddCause(err).Build()
 		return commands.HandleVErrAndExitCode(verr, usage)
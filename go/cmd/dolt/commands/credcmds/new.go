@@ -32,7 +32,9 @@ var newDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Create a new public/private keypair for authenticating with doltremoteapi.",
 	LongDesc: `Creates a new keypair for authenticating with doltremoteapi.
 
-Prints the public portion of the keypair, which can entered into the credentials settings page of dolthub.`,
+Prints the public portion of the keypair, which can entered into the credentials settings page of dolthub.
+
+If the {{.EmphasisLeft}}creds.helper{{.EmphasisRight}} config is set, the private key is stored with the credential helper, such as the macOS Keychain or the Windows Credential Manager, and only the public key is written to the creds directory. See {{.EmphasisLeft}}dolt config{{.EmphasisRight}}.`,
 	Synopsis: []string{},
 }
 
@@ -80,7 +80,7 @@ func (cmd RmCmd) Exec(ctx context.Context, commandStr string, args []string, dEn
 			jwkFilePath, err := dEnv.FindCreds(credsDir, arg)
 
 			if err == nil {
-				err = dEnv.FS.DeleteFile(jwkFilePath)
+				err = dEnv.DeleteCreds(jwkFilePath)
 			}
 
 			if err != nil {
@@ -57,9 +57,10 @@ const (
 
 	SchemaAndDataDiff = SchemaOnlyDiff | DataOnlyDiff
 
-	TabularDiffOutput diffOutput = 1
-	SQLDiffOutput     diffOutput = 2
-	JsonDiffOutput    diffOutput = 3
+	TabularDiffOutput  diffOutput = 1
+	SQLDiffOutput      diffOutput = 2
+	JsonDiffOutput     diffOutput = 3
+	SQLPatchDiffOutput diffOutput = 4
 
 	DataFlag     = "data"
 	SchemaFlag   = "schema"
@@ -68,6 +69,7 @@ const (
 	SummaryFlag  = "summary"
 	whereParam   = "where"
 	limitParam   = "limit"
+	sampleParam  = "sample"
 	SkinnyFlag   = "skinny"
 	MergeBase    = "merge-base"
 	DiffMode     = "diff-mode"
@@ -92,10 +94,14 @@ Show changes between the working and staged tables, changes between the working
    This is synonymous to the above form (without the ..) to view the changes between two arbitrary {{.EmphasisLeft}}commit{{.EmphasisRight}}.
 
 {{.EmphasisLeft}}dolt diff [--options] <commit>...<commit> [<tables>...]{{.EmphasisRight}}
-   This is to view the changes on the branch containing and up to the second {{.LessThan}}commit{{.GreaterThan}}, starting at a common ancestor of both {{.LessThan}}commit{{.GreaterThan}}. {{.EmphasisLeft}}dolt diff A...B{{.EmphasisRight}} is equivalent to {{.EmphasisLeft}}dolt diff $(dolt merge-base A B) B{{.EmphasisRight}} and {{.EmphasisLeft}}dolt diff --merge-base A B{{.EmphasisRight}}. You can omit any one of {{.LessThan}}commit{{.GreaterThan}}, which has the same effect as using HEAD instead.
+   This is to view the changes on the branch containing and up to the second {{.LessThan}}commit{{.GreaterThan}}, starting at a common ancestor of both {{.LessThan}}commit{{.GreaterThan}}. {{.EmphasisLeft}}dolt diff A...B{{.EmphasisRight}} is equivalent to {{.EmphasisLeft}}dolt diff $(dolt merge-base A B) B{{.EmphasisRight}} and {{.EmphasisLeft}}dolt diff --merge-base A B{{.EmphasisRight}}. You can omit any one of {{.LessThan}}commit{{.GreaterThan}}, which has the same effect as using HEAD instead. WORKING and STAGED are based on HEAD, so {{.EmphasisLeft}}dolt diff main...WORKING{{.EmphasisRight}} shows every change on the current branch since it diverged from main, including uncommitted ones.
 
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
+To review a large diff, use {{.EmphasisLeft}}--sample N{{.EmphasisRight}} to show a random sample of N changed rows of each table instead of all of them. The sample is seeded by the hashes of the two revisions being compared, so the same diff always shows the same rows.
+
+To export changes to another system, use {{.EmphasisLeft}}--result-format sql-patch{{.EmphasisRight}} (or {{.EmphasisLeft}}--format sql-patch{{.EmphasisRight}}). This prints the statements of the {{.EmphasisLeft}}dolt_patch(){{.EmphasisRight}} table function as a single script which, when applied to the first revision, reproduces the second one. Unlike {{.EmphasisLeft}}sql{{.EmphasisRight}} output, the patch fails when a table's data changes cannot be expressed as statements.
+
 To filter which data rows are displayed, use {{.EmphasisLeft}}--where <SQL expression>{{.EmphasisRight}}. Table column names in the filter expression must be prefixed with {{.EmphasisLeft}}from_{{.EmphasisRight}} or {{.EmphasisLeft}}to_{{.EmphasisRight}}, e.g. {{.EmphasisLeft}}to_COLUMN_NAME > 100{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME + to_COLUMN_NAME = 0{{.EmphasisRight}}.
 
 The {{.EmphasisLeft}}--diff-mode{{.EmphasisRight}} argument controls how modified rows are presented when the format output is set to {{.EmphasisLeft}}tabular{{.EmphasisRight}}. When set to {{.EmphasisLeft}}row{{.EmphasisRight}}, modified rows are presented as old and new rows. When set to {{.EmphasisLeft}}line{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented using "+" and "-" within the column. When set to {{.EmphasisLeft}}in-place{{.EmphasisRight}}, modified rows are presented as a single row, and changes are presented side-by-side with a color distinction (requires a color-enabled terminal). When set to {{.EmphasisLeft}}context{{.EmphasisRight}}, rows that contain at least one column that spans multiple lines uses {{.EmphasisLeft}}line{{.EmphasisRight}}, while all other rows use {{.EmphasisLeft}}row{{.EmphasisRight}}. The default value is {{.EmphasisLeft}}context{{.EmphasisRight}}.
@@ -111,6 +117,7 @@ type diffDisplaySettings struct {
 	diffOutput diffOutput
 	diffMode   diff.Mode
 	limit      int
+	sample     int
 	where      string
 	skinny     bool
 }
@@ -169,9 +176,11 @@ func (cmd DiffCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(StatFlag, "", "Show stats of data changes")
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data and schema changes")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, sql-patch, json. Defaults to tabular.")
+	ap.SupportsAlias(OutputFormatFlag, FormatFlag)
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
+	ap.SupportsInt(sampleParam, "", "record_count", "shows a deterministic random sample of N changed rows of each table.")
 	ap.SupportsFlag(cli.StagedFlag, "", "Show only the staged data changes.")
 	ap.SupportsFlag(cli.CachedFlag, "c", "Synonym for --staged")
 	ap.SupportsFlag(SkinnyFlag, "sk", "Shows only primary key columns and any columns with data changes.")
@@ -230,9 +239,24 @@ func (cmd DiffCmd) validateArgs(apr *argparser.ArgParseResults) errhand.VerboseE
 		}
 	}
 
+	if apr.Contains(sampleParam) {
+		if apr.Contains(limitParam) {
+			return errhand.BuildDError("invalid Arguments: --sample cannot be combined with --limit").Build()
+		}
+		if n, ok := apr.GetInt(sampleParam); !ok || n < 0 {
+			return errhand.BuildDError("invalid Arguments: --sample must be a non-negative number of rows").Build()
+		}
+	}
+
 	f, _ := apr.GetValue(FormatFlag)
 	switch strings.ToLower(f) {
 	case "tabular", "sql", "json", "":
+	case sqlPatchFormat:
+		for _, param := range []string{StatFlag, SummaryFlag, NameOnlyFlag, whereParam, limitParam, sampleParam, SkinnyFlag} {
+			if apr.Contains(param) {
+				return errhand.BuildDError("invalid Arguments: --%s cannot be combined with %s output", param, sqlPatchFormat).Build()
+			}
+		}
 	default:
 		return errhand.BuildDError("invalid output format: %s", f).Build()
 	}
@@ -274,11 +298,14 @@ func parseDiffDisplaySettings(apr *argparser.ArgParseResults) *diffDisplaySettin
 		}
 	case "sql":
 		displaySettings.diffOutput = SQLDiffOutput
+	case sqlPatchFormat:
+		displaySettings.diffOutput = SQLPatchDiffOutput
 	case "json":
 		displaySettings.diffOutput = JsonDiffOutput
 	}
 
 	displaySettings.limit, _ = apr.GetInt(limitParam)
+	displaySettings.sample, _ = apr.GetInt(sampleParam)
 	displaySettings.where = apr.GetValueOrDefault(whereParam, "")
 
 	return displaySettings
@@ -506,6 +533,14 @@ func (dArgs *diffArgs) applyDiffRoots(queryist cli.Queryist, sqlCtx *sql.Context
 // applyMergeBase applies the merge base of two revisions to the |from| root
 // values.
 func (dArgs *diffArgs) applyMergeBase(queryist cli.Queryist, sqlCtx *sql.Context, leftStr, rightStr string) error {
+	// the working set and staging area are not commits, so use the merge base of the HEAD commit they are based on
+	if leftStr == doltdb.Working || leftStr == doltdb.Staged {
+		leftStr = "HEAD"
+	}
+	if rightStr == doltdb.Working || rightStr == doltdb.Staged {
+		rightStr = "HEAD"
+	}
+
 	mergeBaseStr, err := getCommonAncestor(queryist, sqlCtx, leftStr, rightStr)
 	if err != nil {
 		return err
@@ -539,22 +574,20 @@ func (dArgs *diffArgs) applyDotRevisions(queryist cli.Queryist, sqlCtx *sql.Cont
 	if strings.Contains(args[0], "...") {
 		refs := strings.Split(args[0], "...")
 
-		if len(refs[0]) > 0 {
-			right := refs[1]
-			// Use current HEAD if right side of `...` does not exist
-			if len(refs[1]) == 0 {
-				right = "HEAD"
-			}
-
-			err := dArgs.applyMergeBase(queryist, sqlCtx, refs[0], right)
-			if err != nil {
-				return err
-			}
+		// Use current HEAD for whichever side of `...` is omitted
+		left, right := refs[0], refs[1]
+		if len(left) == 0 {
+			left = "HEAD"
+		}
+		if len(right) == 0 {
+			right = "HEAD"
 		}
 
-		if len(refs[1]) > 0 {
-			dArgs.toRef = refs[1]
+		err := dArgs.applyMergeBase(queryist, sqlCtx, left, right)
+		if err != nil {
+			return err
 		}
+		dArgs.toRef = right
 
 		return nil
 	}
@@ -759,6 +792,10 @@ func getDiffSummariesBetweenRefs(queryist cli.Queryist, sqlCtx *sql.Context, fro
 func diffUserTables(queryist cli.Queryist, sqlCtx *sql.Context, dArgs *diffArgs) errhand.VerboseError {
 	var err error
 
+	if dArgs.diffOutput == SQLPatchDiffOutput {
+		return printSqlPatch(queryist, sqlCtx, dArgs)
+	}
+
 	deltas, err := getDeltasBetweenRefs(queryist, sqlCtx, dArgs.fromRef, dArgs.toRef)
 	if err != nil {
 		return errhand.BuildDError("error: unable to get diff summary").AddCause(err).Build()
@@ -1491,6 +1528,17 @@ func diffRows(
 	defer rowIter.Close(sqlCtx)
 	defer rowWriter.Close(sqlCtx)
 
+	var sampler *diffSampler
+	if dArgs.sample >= 0 {
+		sampler, err = newDiffSampler(queryist, sqlCtx, dArgs, tableName, fromTableInfo, toTableInfo, sch)
+		if err != nil {
+			return errhand.BuildDError("Error sampling diff of table %s", tableName).AddCause(err).Build()
+		}
+		if rowIter, err = sampler.sample(sqlCtx, rowIter); err != nil {
+			return errhand.BuildDError("Error running diff query:\n%s", interpolatedQuery).AddCause(err).Build()
+		}
+	}
+
 	var modifiedColNames map[string]bool
 	if dArgs.skinny {
 		modifiedColNames, err = getModifiedCols(sqlCtx, rowIter, unionSch, sch)
@@ -1527,6 +1575,11 @@ func diffRows(
 		} else if err != nil {
 			return errhand.BuildDError("Error running diff query:\n%s", interpolatedQuery).AddCause(err).Build()
 		}
+		if sampler != nil {
+			if rowIter, err = sampler.sample(sqlCtx, rowIter); err != nil {
+				return errhand.BuildDError("Error running diff query:\n%s", interpolatedQuery).AddCause(err).Build()
+			}
+		}
 	}
 
 	err = writeDiffResults(sqlCtx, sch, unionSch, rowIter, rowWriter, modifiedColNames, dArgs)
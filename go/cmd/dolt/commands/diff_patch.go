@@ -0,0 +1,118 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+const sqlPatchFormat = "sql-patch"
+
+// printSqlPatch prints the diff between the refs of |dArgs| as a SQL script which, when applied to the from
+// revision, reproduces the to revision. The statements are the ones produced by the dolt_patch() table function.
+// Unlike the sql output format, tables whose data changes cannot be expressed as statements fail the patch instead
+// of being skipped with a warning, since the script would otherwise not reproduce the to revision.
+func printSqlPatch(queryist cli.Queryist, sqlCtx *sql.Context, dArgs *diffArgs) errhand.VerboseError {
+	deltas, err := getDeltasBetweenRefs(queryist, sqlCtx, dArgs.fromRef, dArgs.toRef)
+	if err != nil {
+		return errhand.BuildDError("error: unable to get diff summary").AddCause(err).Build()
+	}
+
+	ignoredTablePatterns, err := getIgnoredTablePatternsFromSql(queryist, sqlCtx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(fmt.Errorf("couldn't get ignored table patterns, cause: %w", err))
+	}
+
+	tables := make(map[string]struct{})
+	for _, delta := range deltas {
+		if doltdb.IsFullTextTable(delta.TableName.Name) {
+			continue
+		}
+		if !shouldPrintTableDelta(dArgs.tableSet, delta.ToTableName.Name, delta.FromTableName.Name) {
+			continue
+		}
+		if len(delta.FromTableName.Name) == 0 {
+			ignoreResult, err := ignoredTablePatterns.IsTableNameIgnored(delta.ToTableName)
+			if err != nil {
+				return errhand.VerboseErrorFromError(err)
+			}
+			if ignoreResult == doltdb.Ignore {
+				continue
+			}
+		}
+
+		if delta.IsDrop() {
+			tables[delta.FromTableName.String()] = struct{}{}
+		} else {
+			tables[delta.ToTableName.String()] = struct{}{}
+		}
+	}
+
+	query := "select table_name, statement from dolt_patch(?, ?)"
+	switch dArgs.diffParts {
+	case SchemaOnlyDiff:
+		query += " where diff_type = 'schema'"
+	case DataOnlyDiff:
+		query += " where diff_type = 'data'"
+	}
+	q, err := dbr.InterpolateForDialect(query+" order by statement_order", []interface{}{dArgs.fromRef, dArgs.toRef}, dialect.MySQL)
+	if err != nil {
+		return errhand.VerboseErrorFromError(fmt.Errorf("error interpolating query: %w", err))
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return errhand.BuildDError("error: unable to generate sql patch").AddCause(err).Build()
+	}
+
+	// dolt_patch skips, with a warning, the data of tables whose primary key sets differ between the revisions
+	warnings, err := GetRowsForSql(queryist, sqlCtx, "show warnings")
+	if err != nil {
+		return errhand.BuildDError("error: unable to generate sql patch").AddCause(err).Build()
+	}
+	for _, warning := range warnings {
+		msg := fmt.Sprint(warning[2])
+		if !strings.HasPrefix(msg, "Primary key sets differ") {
+			continue
+		}
+		for tableName := range tables {
+			if strings.Contains(msg, fmt.Sprintf("'%s'", tableName)) {
+				return errhand.BuildDError("error: cannot generate a sql patch: %s", msg).Build()
+			}
+		}
+	}
+
+	// dolt_patch orders tables by name, so referential integrity is only restored once the whole script has run
+	cli.Println("SET FOREIGN_KEY_CHECKS=0;")
+	for _, row := range rows {
+		tableName := fmt.Sprint(row[0])
+		if _, ok := tables[tableName]; !ok && !strings.HasPrefix(tableName, diff.DBPrefix) {
+			continue
+		}
+		cli.Println(row[1])
+	}
+	cli.Println("SET FOREIGN_KEY_CHECKS=1;")
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+// diffSampler selects the rows of a table's diff shown by dolt diff --sample. Each row is ranked by a hash of its
+// primary key seeded by the hashes of the roots of the diff, and the rows of the lowest ranks are the sample, so
+// the sample of a diff is random but always the same.
+type diffSampler struct {
+	n    int
+	seed string
+	// keyCols are the pairs of indexes of the to_ and from_ columns of the primary key in the diff rows
+	keyCols [][2]int
+}
+
+func newDiffSampler(queryist cli.Queryist, sqlCtx *sql.Context, dArgs *diffArgs, tableName string, fromTableInfo, toTableInfo *diff.TableInfo, sch sql.Schema) (*diffSampler, error) {
+	q, err := dbr.InterpolateForDialect("select dolt_hashof_db(?), dolt_hashof_db(?)", []interface{}{dArgs.fromRef, dArgs.toRef}, dialect.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) != 2 {
+		return nil, fmt.Errorf("unexpected result of query: %s", q)
+	}
+
+	ds := &diffSampler{
+		n:    dArgs.sample,
+		seed: fmt.Sprintf("%v:%v:%s", rows[0][0], rows[0][1], tableName),
+	}
+
+	var tableSch schema.Schema
+	if toTableInfo != nil {
+		tableSch = toTableInfo.Sch
+	} else if fromTableInfo != nil {
+		tableSch = fromTableInfo.Sch
+	}
+	if tableSch != nil {
+		for _, col := range tableSch.GetPKCols().GetColumns() {
+			ds.keyCols = append(ds.keyCols, [2]int{sch.IndexOfColName("to_" + col.Name), sch.IndexOfColName("from_" + col.Name)})
+		}
+	}
+	return ds, nil
+}
+
+// rank returns the rank of |row| in the sample.
+func (ds *diffSampler) rank(row sql.Row) uint64 {
+	h := xxhash.New()
+	_, _ = io.WriteString(h, ds.seed)
+	if len(ds.keyCols) == 0 {
+		// a keyless row is identified by all of its values
+		for _, v := range row {
+			_, _ = fmt.Fprintf(h, ":%v", v)
+		}
+		return h.Sum64()
+	}
+	for _, cols := range ds.keyCols {
+		// a removed row has only from_ values
+		var v interface{}
+		if cols[0] >= 0 {
+			v = row[cols[0]]
+		}
+		if v == nil && cols[1] >= 0 {
+			v = row[cols[1]]
+		}
+		_, _ = fmt.Fprintf(h, ":%v", v)
+	}
+	return h.Sum64()
+}
+
+// sample returns an iterator of the sampled rows of |iter|, in the order of |iter|.
+func (ds *diffSampler) sample(ctx *sql.Context, iter sql.RowIter) (sql.RowIter, error) {
+	var sampled sampledRows
+	for i := 0; ; i++ {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if ds.n == 0 {
+			continue
+		}
+		sr := sampledRow{rank: ds.rank(row), order: i, row: row}
+		if len(sampled) < ds.n {
+			heap.Push(&sampled, sr)
+		} else if sr.rank < sampled[0].rank {
+			sampled[0] = sr
+			heap.Fix(&sampled, 0)
+		}
+	}
+
+	sort.Slice(sampled, func(i, j int) bool {
+		return sampled[i].order < sampled[j].order
+	})
+	rows := make([]sql.Row, len(sampled))
+	for i := range sampled {
+		rows[i] = sampled[i].row
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+type sampledRow struct {
+	rank  uint64
+	order int
+	row   sql.Row
+}
+
+// sampledRows is a max heap of rows by rank, whose top is the row to replace when a row of lower rank is found.
+type sampledRows []sampledRow
+
+func (s sampledRows) Len() int           { return len(s) }
+func (s sampledRows) Less(i, j int) bool { return s[i].rank > s[j].rank }
+func (s sampledRows) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func (s *sampledRows) Push(x interface{}) {
+	*s = append(*s, x.(sampledRow))
+}
+
+func (s *sampledRows) Pop() interface{} {
+	old := *s
+	x := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return x
+}
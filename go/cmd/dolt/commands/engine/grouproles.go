@@ -0,0 +1,152 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/sirupsen/logrus"
+)
+
+// groupRoleGranter grants users authenticated by an external identity provider the SQL roles mapped from the groups
+// the provider reports them to be members of, and revokes the roles mapped from groups they're no longer members of.
+// Roles granted by GRANT statements, rather than mapped from groups, are left alone.
+//
+// Plugins authenticate users while holding a read lock on the privileges, so they can't edit them. Instead, a plugin
+// records the groups of the user it authenticates with setGroups, and the connection grants the roles they map to with
+// grant before its session is created, so the session's first statement has the privileges of exactly those groups.
+type groupRoleGranter struct {
+	mu sync.Mutex
+	// pending holds the roles to grant and revoke for each user name authenticated since its roles were last granted
+	pending map[string]pendingRoles
+
+	// grantMu is held while roles are granted, and guards mapped
+	grantMu sync.Mutex
+	// mapped holds the roles granted to each user because of its groups, so that they're revoked once the user is
+	// no longer a member of a group mapped to them, even if the groups are no longer mapped to roles at all
+	mapped map[mysql_db.UserPrimaryKey]map[string]bool
+}
+
+// pendingRoles are the roles to grant a user, if true, or revoke from it, if false.
+type pendingRoles struct {
+	user  mysql_db.UserPrimaryKey
+	roles map[string]bool
+}
+
+func newGroupRoleGranter() *groupRoleGranter {
+	return &groupRoleGranter{
+		pending: make(map[string]pendingRoles),
+		mapped:  make(map[mysql_db.UserPrimaryKey]map[string]bool),
+	}
+}
+
+// setGroups records that |user| is a member of |groups|, so that it's granted the roles which |groupRoles| maps them
+// to, and the other roles it maps to are revoked, when grant is next called for it. If |foldCase| is true, groups are
+// matched without regard to case.
+func (g *groupRoleGranter) setGroups(user *mysql_db.User, groupRoles map[string]string, groups []string, foldCase bool) {
+	normalize := func(s string) string {
+		if foldCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[normalize(group)] = true
+	}
+	roles := make(map[string]bool, len(groupRoles))
+	for group, role := range groupRoles {
+		roles[role] = roles[role] || member[normalize(group)]
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending[user.User] = pendingRoles{user: mysql_db.UserPrimaryKey{Host: user.Host, User: user.User}, roles: roles}
+}
+
+// grant grants and revokes the roles recorded by the last authentication of |user|, if they haven't been already.
+func (g *groupRoleGranter) grant(db *mysql_db.MySQLDb, user string) {
+	g.mu.Lock()
+	p, ok := g.pending[user]
+	delete(g.pending, user)
+	g.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	g.grantMu.Lock()
+	defer g.grantMu.Unlock()
+	for role := range g.mapped[p.user] {
+		if _, ok := p.roles[role]; !ok {
+			// the role was mapped from a group which is no longer mapped to it
+			p.roles[role] = false
+		}
+	}
+	granted := syncRoleEdges(db, p.user, p.roles)
+	if len(granted) > 0 {
+		g.mapped[p.user] = granted
+	} else {
+		delete(g.mapped, p.user)
+	}
+}
+
+// syncRoleEdges grants |user| each role of |roles| which is true, and revokes each which is false. Returns the roles
+// the user has been granted.
+func syncRoleEdges(db *mysql_db.MySQLDb, user mysql_db.UserPrimaryKey, roles map[string]bool) map[string]bool {
+	ed := db.Editor()
+	defer ed.Close()
+
+	existing := make(map[mysql_db.RoleEdgesPrimaryKey]bool)
+	for _, edge := range ed.GetToUserRoleEdges(mysql_db.RoleEdgesToKey{ToHost: user.Host, ToUser: user.User}) {
+		existing[mysql_db.RoleEdgesPrimaryKey{FromHost: edge.FromHost, FromUser: edge.FromUser, ToHost: edge.ToHost, ToUser: edge.ToUser}] = true
+	}
+
+	names := make([]string, 0, len(roles))
+	for name := range roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	granted := make(map[string]bool)
+	for _, name := range names {
+		roleName, roleHost := parseRoleName(name)
+		key := mysql_db.RoleEdgesPrimaryKey{FromHost: roleHost, FromUser: roleName, ToHost: user.Host, ToUser: user.User}
+		if !roles[name] {
+			if existing[key] {
+				ed.RemoveRoleEdge(key)
+			}
+			continue
+		}
+		if _, ok := ed.GetUser(mysql_db.UserPrimaryKey{Host: roleHost, User: roleName}); !ok {
+			logrus.Warnf("role '%s'@'%s' mapped from a group of user '%s' does not exist", roleName, roleHost, user.User)
+			continue
+		}
+		if !existing[key] {
+			ed.PutRoleEdge(&mysql_db.RoleEdge{FromHost: roleHost, FromUser: roleName, ToHost: user.Host, ToUser: user.User})
+		}
+		granted[name] = true
+	}
+	return granted
+}
+
+// parseRoleName splits a role given as name@host into its name and host. The host defaults to %.
+func parseRoleName(role string) (string, string) {
+	if i := strings.LastIndex(role, "@"); i > 0 {
+		return role[:i], role[i+1:]
+	}
+	return role, "%"
+}
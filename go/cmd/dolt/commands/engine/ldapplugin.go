@@ -0,0 +1,111 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/ldapauth"
+)
+
+const (
+	ldapAuthPluginName   = "authentication_dolt_ldap"
+	ldapTimeout          = 10 * time.Second
+	ldapUserPlaceholder  = "{user}"
+	defaultLDAPGroupAttr = "memberOf"
+)
+
+// authenticateDoltLDAPPlugin authenticates users by binding to an LDAP server with their DN and password. A user's
+// account is created with IDENTIFIED WITH authentication_dolt_ldap, optionally followed by AS 'dn' to give the DN to
+// bind to, which otherwise is the configured template filled in with the user name.
+type authenticateDoltLDAPPlugin struct {
+	config    servercfg.LDAPConfig
+	tlsConfig *tls.Config
+	roles     *groupRoleGranter
+}
+
+// NewAuthenticateDoltLDAPPlugin returns the plugin which authenticates users against the LDAP server of |config|,
+// granting them the roles mapped from their groups with |roles|.
+func NewAuthenticateDoltLDAPPlugin(config servercfg.LDAPConfig, roles *groupRoleGranter) (mysql_db.PlaintextAuthPlugin, error) {
+	if config.URL == "" {
+		return nil, errors.New("ldap config requires a url")
+	}
+	if u, err := url.Parse(config.URL); err != nil {
+		return nil, err
+	} else if strings.EqualFold(u.Scheme, "ldap") && !config.StartTLS && !config.AllowCleartext {
+		return nil, errors.New("ldap config requires an ldaps:// url or start_tls, since passwords are sent to the server, unless allow_cleartext is set")
+	}
+	if config.GroupAttribute == "" {
+		config.GroupAttribute = defaultLDAPGroupAttr
+	}
+	var tlsConfig *tls.Config
+	if config.TLSCA != "" {
+		pem, err := os.ReadFile(config.TLSCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ldap tls_ca %s", config.TLSCA)
+		}
+		tlsConfig = &tls.Config{RootCAs: pool}
+	}
+	return &authenticateDoltLDAPPlugin{config: config, tlsConfig: tlsConfig, roles: roles}, nil
+}
+
+func (p *authenticateDoltLDAPPlugin) Authenticate(_ *mysql_db.MySQLDb, user string, userEntry *mysql_db.User, pass string) (bool, error) {
+	dn := userEntry.Identity
+	if dn == "" {
+		if p.config.BindDNTemplate == "" {
+			return false, errors.New("no LDAP DN for user, the account has no identity and ldap config has no bind_dn_template")
+		}
+		dn = strings.ReplaceAll(p.config.BindDNTemplate, ldapUserPlaceholder, ldapauth.EscapeDN(user))
+	}
+
+	c, err := ldapauth.Dial(p.config.URL, ldapauth.DialConfig{
+		Timeout:        ldapTimeout,
+		TLSConfig:      p.tlsConfig,
+		StartTLS:       p.config.StartTLS,
+		AllowCleartext: p.config.AllowCleartext,
+	})
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+	if err = c.Bind(dn, pass); errors.Is(err, ldapauth.ErrInvalidCredentials) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if len(p.config.GroupRoles) > 0 {
+		groups, err := c.SearchAttribute(dn, p.config.GroupAttribute)
+		if err != nil {
+			return false, err
+		}
+		p.roles.setGroups(userEntry, p.config.GroupRoles, groups, true)
+	}
+	return true, nil
+}
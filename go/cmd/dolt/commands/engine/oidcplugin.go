@@ -0,0 +1,158 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	josejwt "gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/utils/jwtauth"
+)
+
+const (
+	oidcAuthPluginName     = "authentication_dolt_oidc"
+	oidcDiscoveryPath      = "/.well-known/openid-configuration"
+	oidcDiscoveryTimeout   = 10 * time.Second
+	defaultOIDCUserClaim   = "sub"
+	defaultOIDCGroupsClaim = "groups"
+)
+
+// authenticateDoltOIDCPlugin authenticates users whose password is a token issued by an OpenID Connect provider.
+// A user's account is created with IDENTIFIED WITH authentication_dolt_oidc, and the token must be issued by the
+// configured issuer for the configured audience, and name the user in its username claim.
+type authenticateDoltOIDCPlugin struct {
+	config servercfg.OIDCConfig
+	roles  *groupRoleGranter
+
+	mu   sync.Mutex
+	keys jwtauth.KeyProvider
+}
+
+// NewAuthenticateDoltOIDCPlugin returns the plugin which authenticates users with tokens issued by the OpenID Connect
+// provider of |config|, granting them the roles mapped from their groups with |roles|.
+func NewAuthenticateDoltOIDCPlugin(config servercfg.OIDCConfig, roles *groupRoleGranter) (mysql_db.PlaintextAuthPlugin, error) {
+	if config.Issuer == "" {
+		return nil, errors.New("oidc config requires an issuer")
+	} else if config.Audience == "" {
+		return nil, errors.New("oidc config requires an audience")
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = defaultOIDCUserClaim
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = defaultOIDCGroupsClaim
+	}
+	return &authenticateDoltOIDCPlugin{config: config, roles: roles}, nil
+}
+
+func (p *authenticateDoltOIDCPlugin) Authenticate(_ *mysql_db.MySQLDb, user string, userEntry *mysql_db.User, pass string) (bool, error) {
+	groups, err := p.validateToken(user, pass, time.Now())
+	if err != nil {
+		return false, err
+	}
+	p.roles.setGroups(userEntry, p.config.GroupRoles, groups, false)
+	return true, nil
+}
+
+// validateToken validates that |token| was issued for |user| and returns the user's groups.
+func (p *authenticateDoltOIDCPlugin) validateToken(user, token string, reqTime time.Time) ([]string, error) {
+	keys, err := p.keyProvider()
+	if err != nil {
+		return nil, err
+	}
+	expected := josejwt.Expected{Issuer: p.config.Issuer, Audience: josejwt.Audience{p.config.Audience}}
+	claims := make(map[string]interface{})
+	if _, err = jwtauth.ValidateJWT(token, reqTime, keys, expected, &claims); err != nil {
+		return nil, err
+	}
+
+	if name, ok := claims[p.config.UsernameClaim].(string); !ok || name != user {
+		return nil, fmt.Errorf("token claim %s does not match user", p.config.UsernameClaim)
+	}
+	return stringsClaim(claims[p.config.GroupsClaim]), nil
+}
+
+// keyProvider returns the provider of the issuer's signing keys, discovering their location if it isn't configured.
+func (p *authenticateDoltOIDCPlugin) keyProvider() (jwtauth.KeyProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil {
+		return p.keys, nil
+	}
+
+	jwksURL := p.config.JwksURL
+	if jwksURL == "" {
+		var err error
+		jwksURL, err = discoverJwksURL(p.config.Issuer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys, err := jwtauth.NewFetchedJWKS(jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	return keys, nil
+}
+
+// discoverJwksURL returns the jwks_uri of the OpenID Connect discovery document of |issuer|.
+func discoverJwksURL(issuer string) (string, error) {
+	client := &http.Client{Timeout: oidcDiscoveryTimeout}
+	resp, err := client.Get(strings.TrimSuffix(issuer, "/") + oidcDiscoveryPath)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", fmt.Errorf("unable to discover the configuration of OpenID Connect issuer %s: %s", issuer, resp.Status)
+	}
+
+	var doc struct {
+		JwksURI string `json:"jwks_uri"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", err
+	} else if doc.JwksURI == "" {
+		return "", fmt.Errorf("the configuration of OpenID Connect issuer %s has no jwks_uri", issuer)
+	}
+	return doc.JwksURI, nil
+}
+
+// stringsClaim returns the strings of a claim which is a single string or an array of strings.
+func stringsClaim(claim interface{}) []string {
+	switch c := claim.(type) {
+	case string:
+		return []string{c}
+	case []interface{}:
+		res := make([]string, 0, len(c))
+		for _, v := range c {
+			if s, ok := v.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
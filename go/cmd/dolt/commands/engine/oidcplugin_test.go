@@ -0,0 +1,158 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/go-jose/go-jose.v2"
+	josejwt "gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestOIDCAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": srv.URL, "jwks_uri": srv.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "key1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "key1"))
+	require.NoError(t, err)
+	now := time.Now()
+	token := func(sub, aud string, expiry time.Time, groups ...string) string {
+		tok, err := josejwt.Signed(signer).Claims(josejwt.Claims{
+			Issuer:   srv.URL,
+			Subject:  sub,
+			Audience: josejwt.Audience{aud},
+			IssuedAt: josejwt.NewNumericDate(now.Add(-time.Minute)),
+			Expiry:   josejwt.NewNumericDate(expiry),
+		}).Claims(map[string]interface{}{"groups": groups}).CompactSerialize()
+		require.NoError(t, err)
+		return tok
+	}
+
+	_, err = NewAuthenticateDoltOIDCPlugin(servercfg.OIDCConfig{Issuer: srv.URL}, newGroupRoleGranter())
+	require.Error(t, err)
+
+	roles := newGroupRoleGranter()
+	plugin, err := NewAuthenticateDoltOIDCPlugin(servercfg.OIDCConfig{
+		Issuer:     srv.URL,
+		Audience:   "dolt",
+		GroupRoles: map[string]string{"readers": "reader", "writers": "writer", "admins": "admin@localhost"},
+	}, roles)
+	require.NoError(t, err)
+
+	db := mysql_db.CreateEmptyMySQLDb()
+	alice := &mysql_db.User{User: "alice", Host: "%", Plugin: oidcAuthPluginName, PrivilegeSet: mysql_db.NewPrivilegeSet()}
+	ed := db.Editor()
+	ed.PutUser(alice)
+	ed.PutUser(&mysql_db.User{User: "reader", Host: "%", IsRole: true, PrivilegeSet: mysql_db.NewPrivilegeSet()})
+	ed.PutUser(&mysql_db.User{User: "writer", Host: "%", IsRole: true, PrivilegeSet: mysql_db.NewPrivilegeSet()})
+	ed.PutUser(&mysql_db.User{User: "other", Host: "%", IsRole: true, PrivilegeSet: mysql_db.NewPrivilegeSet()})
+	ed.PutRoleEdge(&mysql_db.RoleEdge{FromHost: "%", FromUser: "writer", ToHost: "%", ToUser: "alice"})
+	ed.PutRoleEdge(&mysql_db.RoleEdge{FromHost: "%", FromUser: "other", ToHost: "%", ToUser: "alice"})
+	ed.Close()
+
+	grantedRoles := func() []string {
+		rd := db.Reader()
+		defer rd.Close()
+		var res []string
+		for _, edge := range rd.GetToUserRoleEdges(mysql_db.RoleEdgesToKey{ToHost: "%", ToUser: "alice"}) {
+			res = append(res, edge.FromUser)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	// users are authenticated with the privileges locked for reading, and their roles are granted once it's released
+	authenticate := func(plugin mysql_db.PlaintextAuthPlugin, token string) (bool, error) {
+		rd := db.Reader()
+		defer rd.Close()
+		return plugin.Authenticate(db, "alice", alice, token)
+	}
+
+	// the mapped roles of groups the user isn't a member of are revoked, and other roles are left alone
+	authed, err := authenticate(plugin, token("alice", "dolt", now.Add(time.Hour), "readers", "admins", "unmapped"))
+	require.NoError(t, err)
+	require.True(t, authed)
+	require.Equal(t, []string{"other", "writer"}, grantedRoles())
+	roles.grant(db, "alice")
+	require.Equal(t, []string{"other", "reader"}, grantedRoles())
+
+	authed, err = authenticate(plugin, token("alice", "dolt", now.Add(time.Hour), "writers"))
+	require.NoError(t, err)
+	require.True(t, authed)
+	roles.grant(db, "alice")
+	require.Equal(t, []string{"other", "writer"}, grantedRoles())
+
+	// roles granted from groups which are no longer mapped to them are revoked
+	remapped, err := NewAuthenticateDoltOIDCPlugin(servercfg.OIDCConfig{
+		Issuer:     srv.URL,
+		Audience:   "dolt",
+		GroupRoles: map[string]string{"readers": "reader"},
+	}, roles)
+	require.NoError(t, err)
+	authed, err = authenticate(remapped, token("alice", "dolt", now.Add(time.Hour), "readers", "writers"))
+	require.NoError(t, err)
+	require.True(t, authed)
+	roles.grant(db, "alice")
+	require.Equal(t, []string{"other", "reader"}, grantedRoles())
+
+	// token for another user
+	_, err = plugin.Authenticate(db, "alice", alice, token("bob", "dolt", now.Add(time.Hour)))
+	require.Error(t, err)
+
+	// token for another audience
+	_, err = plugin.Authenticate(db, "alice", alice, token("alice", "other", now.Add(time.Hour)))
+	require.Error(t, err)
+
+	// expired token
+	_, err = plugin.Authenticate(db, "alice", alice, token("alice", "dolt", now.Add(-time.Hour)))
+	require.Error(t, err)
+
+	// not a token
+	_, err = plugin.Authenticate(db, "alice", alice, "password")
+	require.Error(t, err)
+	roles.grant(db, "alice")
+	require.Equal(t, []string{"other", "reader"}, grantedRoles())
+}
+
+func TestParseRoleName(t *testing.T) {
+	name, host := parseRoleName("reader")
+	require.Equal(t, "reader", name)
+	require.Equal(t, "%", host)
+	name, host = parseRoleName("admin@localhost")
+	require.Equal(t, "admin", name)
+	require.Equal(t, "localhost", host)
+}
@@ -16,16 +16,20 @@ package engine
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	gms "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/eventscheduler"
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/binlogreplication"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/mysql_db"
 	"github.com/dolthub/go-mysql-server/sql/rowexec"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
@@ -40,7 +44,9 @@ import (
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	dblr "github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/kvexec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/mysql_file_handler"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/statsnoms"
@@ -55,6 +61,12 @@ type SqlEngine struct {
 	contextFactory contextFactory
 	dsessFactory   sessionFactory
 	engine         *gms.Engine
+	// diskFull is set once a write has failed because the disk is full, see |SetDiskFull|.
+	diskFull atomic.Bool
+	// groupRoles grants the roles of users authenticated by LDAP or OpenID Connect, see |GrantGroupRoles|.
+	groupRoles *groupRoleGranter
+	// udfs are the user-defined functions of the engine, which are closed with it.
+	udfs []*dfunctions.WasmFunction
 }
 
 type sessionFactory func(mysqlSess *sql.BaseSession, pro sql.DatabaseProvider) (*dsess.DoltSession, error)
@@ -67,6 +79,7 @@ type SqlEngineConfig struct {
 	IsServerLocked          bool
 	DoltCfgDirPath          string
 	PrivFilePath            string
+	PrivilegeDatabase       string
 	BranchCtrlFilePath      string
 	ServerUser              string
 	ServerPass              string
@@ -75,12 +88,63 @@ type SqlEngineConfig struct {
 	DoltTransactionCommit   bool
 	Bulk                    bool
 	JwksConfig              []servercfg.JwksConfig
+	LDAPConfig              *servercfg.LDAPConfig
+	OIDCConfig              *servercfg.OIDCConfig
+	RemoteDatabases         []servercfg.RemoteDatabaseConfig
+	UserDefinedFunctions    []servercfg.UserDefinedFunctionConfig
 	SystemVariables         SystemVariables
 	ClusterController       *cluster.Controller
 	BinlogReplicaController binlogreplication.BinlogReplicaController
 	EventSchedulerStatus    eventscheduler.SchedulerStatus
 }
 
+// newRemoteDatabase opens the database served from a remote configured by |rdCfg|, and starts refreshing it from the
+// remote in the background.
+func newRemoteDatabase(ctx context.Context, bThreads *sql.BackgroundThreads, mrEnv *env.MultiRepoEnv, rdCfg servercfg.RemoteDatabaseConfig) (dsess.SqlDatabase, error) {
+	defaultBranch := env.GetDefaultInitBranch(mrEnv.Config())
+	db, err := dsqle.NewRemoteDatabase(ctx, rdCfg.Name, rdCfg.RemoteURL, rdCfg.Params, mrEnv.RemoteDialProvider(), defaultBranch, rdCfg.CacheSize())
+	if err != nil {
+		return nil, err
+	}
+	interval := rdCfg.RefreshInterval()
+	err = bThreads.Add("remote_database_refresh_"+rdCfg.Name, func(ctx context.Context) {
+		dsqle.RunRemoteDatabaseRefresh(ctx, db, interval)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// newUserDefinedFunction loads the user-defined function configured by |udfCfg| from its WebAssembly module.
+func newUserDefinedFunction(ctx context.Context, udfCfg servercfg.UserDefinedFunctionConfig) (*dfunctions.WasmFunction, error) {
+	for _, fns := range [][]sql.Function{function.BuiltIns, dfunctions.DoltFunctions} {
+		for _, fn := range fns {
+			if strings.EqualFold(fn.FunctionName(), udfCfg.Name) {
+				return nil, fmt.Errorf("cannot define function %s, a built-in function has the same name", udfCfg.Name)
+			}
+		}
+	}
+	module, err := os.ReadFile(udfCfg.ModulePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the module of function %s: %w", udfCfg.Name, err)
+	}
+	if udfCfg.SHA256 != "" {
+		digest := sha256.Sum256(module)
+		if !strings.EqualFold(hex.EncodeToString(digest[:]), udfCfg.SHA256) {
+			return nil, fmt.Errorf("the module of function %s at %s does not match its sha256", udfCfg.Name, udfCfg.ModulePath)
+		}
+	}
+	return dfunctions.NewWasmFunction(ctx, module, dfunctions.WasmFunctionOptions{
+		Name:           udfCfg.Name,
+		Version:        udfCfg.Version,
+		Export:         udfCfg.ExportName(),
+		Text:           udfCfg.Text,
+		MaxMemoryBytes: udfCfg.MaxMemory(),
+		Timeout:        udfCfg.Timeout(),
+	})
+}
+
 // NewSqlEngine returns a SqlEngine
 func NewSqlEngine(
 	ctx context.Context,
@@ -112,6 +176,19 @@ func NewSqlEngine(
 
 	all := dbs[:]
 
+	// databases served from remotes have no location, and aren't replicated or given statistics
+	for _, rdCfg := range config.RemoteDatabases {
+		if mrEnv.GetEnv(rdCfg.Name) != nil {
+			return nil, fmt.Errorf("cannot serve database %s from remote %s, a database of the data directory has the same name", rdCfg.Name, rdCfg.RemoteURL)
+		}
+		db, err := newRemoteDatabase(ctx, bThreads, mrEnv, rdCfg)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, db)
+		locations = append(locations, nil)
+	}
+
 	// this is overwritten only for server sessions
 	for _, db := range dbs {
 		db.DbData().Ddb.SetCommitHookLogger(ctx, cli.CliOut)
@@ -130,6 +207,22 @@ func NewSqlEngine(
 	}
 	pro = pro.WithRemoteDialer(mrEnv.RemoteDialProvider())
 
+	sqlEngine := &SqlEngine{}
+
+	if len(config.UserDefinedFunctions) > 0 {
+		fns := append([]sql.Function{}, dfunctions.DoltFunctions...)
+		for _, udfCfg := range config.UserDefinedFunctions {
+			udf, err := newUserDefinedFunction(ctx, udfCfg)
+			if err != nil {
+				sqlEngine.closeUDFs()
+				return nil, err
+			}
+			sqlEngine.udfs = append(sqlEngine.udfs, udf)
+			fns = append(fns, udf.Function())
+		}
+		pro = pro.WithFunctions(fns)
+	}
+
 	config.ClusterController.RegisterStoredProcedures(pro)
 	if config.ClusterController != nil {
 		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, cluster.NewInitDatabaseHook(config.ClusterController, bThreads))
@@ -137,13 +230,16 @@ func NewSqlEngine(
 		config.ClusterController.SetDropDatabase(pro.DropDatabase)
 	}
 
-	sqlEngine := &SqlEngine{}
-
 	// Create the engine
 	engine := gms.New(analyzer.NewBuilder(pro).Build(), &gms.Config{
 		IsReadOnly:     config.IsReadOnly,
 		IsServerLocked: config.IsServerLocked,
 	}).WithBackgroundThreads(bThreads)
+	engine.Analyzer.Catalog.InfoSchema = dsqle.NewInformationSchemaDatabase()
+	dsqle.AddInvisibleIndexesRule(engine.Analyzer)
+	dsqle.AddZoneMapFiltersRule(engine.Analyzer)
+	dsqle.AddApproxCountDistinctRule(engine.Analyzer)
+	engine.Parser = dsqle.NewDoltParser(engine.Parser)
 
 	if err := configureBinlogPrimaryController(engine); err != nil {
 		return nil, err
@@ -154,21 +250,20 @@ func NewSqlEngine(
 
 		// Standbys are read only, primaries are not.
 		// We only change this here if the server was not forced read
-		// only by its startup config.
-		if !config.IsReadOnly {
+		// only by its startup config, or by running out of disk.
+		if !config.IsReadOnly && !sqlEngine.diskFull.Load() {
 			engine.ReadOnly.Store(isStandby)
 		}
 	})
 
-	// Load in privileges from file, if it exists
+	// Privileges are stored in the privilege file, or in a database if one is configured for them
 	var persister cluster.MySQLDbPersister
-	persister = mysql_file_handler.NewPersister(config.PrivFilePath, config.DoltCfgDirPath)
-
-	persister = config.ClusterController.HookMySQLDbPersister(persister, engine.Analyzer.Catalog.MySQLDb)
-	data, err := persister.LoadData(ctx)
-	if err != nil {
-		return nil, err
+	if config.PrivilegeDatabase != "" {
+		persister = dsqle.NewPrivilegesTablePersister(config.PrivilegeDatabase, sqlEngine.NewDefaultContext)
+	} else {
+		persister = mysql_file_handler.NewPersister(config.PrivFilePath, config.DoltCfgDirPath)
 	}
+	persister = config.ClusterController.HookMySQLDbPersister(persister, engine.Analyzer.Catalog.MySQLDb)
 
 	// Load the branch control permissions, if they exist
 	var bcController *branch_control.Controller
@@ -180,12 +275,25 @@ func NewSqlEngine(
 	// Setup the engine.
 	engine.Analyzer.Catalog.MySQLDb.SetPersister(persister)
 
-	engine.Analyzer.Catalog.MySQLDb.SetPlugins(map[string]mysql_db.PlaintextAuthPlugin{
+	plugins := map[string]mysql_db.PlaintextAuthPlugin{
 		"authentication_dolt_jwt": NewAuthenticateDoltJWTPlugin(config.JwksConfig),
-	})
+	}
+	sqlEngine.groupRoles = newGroupRoleGranter()
+	if config.LDAPConfig != nil {
+		if plugins[ldapAuthPluginName], err = NewAuthenticateDoltLDAPPlugin(*config.LDAPConfig, sqlEngine.groupRoles); err != nil {
+			return nil, err
+		}
+	}
+	if config.OIDCConfig != nil {
+		if plugins[oidcAuthPluginName], err = NewAuthenticateDoltOIDCPlugin(*config.OIDCConfig, sqlEngine.groupRoles); err != nil {
+			return nil, err
+		}
+	}
+	engine.Analyzer.Catalog.MySQLDb.SetPlugins(plugins)
 
 	statsPro := statspro.NewProvider(pro, statsnoms.NewNomsStatsFactory(mrEnv.RemoteDialProvider()))
 	engine.Analyzer.Catalog.StatsProvider = statsPro
+	engine.Analyzer.Coster = statspro.NewColumnGroupCoster()
 
 	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
 	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, config.Autocommit)
@@ -200,7 +308,11 @@ func NewSqlEngine(
 		fmt.Fprintln(cli.CliErr, err)
 	}
 
-	// Load MySQL Db information
+	// Load MySQL Db information, after the session factory is set so it can be read from a privilege database
+	data, err := persister.LoadData(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if err = engine.Analyzer.Catalog.MySQLDb.LoadData(sql.NewEmptyContext(), data); err != nil {
 		return nil, err
 	}
@@ -309,17 +421,88 @@ func (se *SqlEngine) Analyze(ctx *sql.Context, n sql.Node, qFlags *sql.QueryFlag
 	return se.engine.Analyzer.Analyze(ctx, n, nil, qFlags)
 }
 
+// BeginGCSafepoint marks the beginning of work holding references to the chunks of the database named, such as roots
+// kept by an application embedding this engine between queries, so that garbage collection doesn't remove them. Every
+// call must be matched by a call to EndGCSafepoint, which a garbage collection waits for. See
+// doltdb.DoltDB.BeginGCSafepoint.
+func (se *SqlEngine) BeginGCSafepoint(ctx *sql.Context, dbName string) error {
+	return dsess.DSessFromSess(ctx.Session).BeginGCSafepoint(ctx, dbName)
+}
+
+// EndGCSafepoint marks the end of work begun with BeginGCSafepoint for the database named.
+func (se *SqlEngine) EndGCSafepoint(ctx *sql.Context, dbName string) error {
+	return dsess.DSessFromSess(ctx.Session).EndGCSafepoint(ctx, dbName)
+}
+
+// GrantGroupRoles grants |user| the roles mapped from the groups it was a member of when it was last authenticated by
+// LDAP or OpenID Connect, and revokes the others mapped from groups, so that a new session of the user has the
+// privileges of their groups.
+func (se *SqlEngine) GrantGroupRoles(user string) {
+	if se.groupRoles != nil {
+		se.groupRoles.grant(se.engine.Analyzer.Catalog.MySQLDb, user)
+	}
+}
+
 func (se *SqlEngine) GetUnderlyingEngine() *gms.Engine {
 	return se.engine
 }
 
+// SetDiskFull makes the engine read only after a write has failed because the disk is full. The engine stays read
+// only until it is restarted, even if it becomes a cluster primary. Returns whether the engine was not already
+// marked as out of disk.
+func (se *SqlEngine) SetDiskFull() bool {
+	if !se.diskFull.CompareAndSwap(false, true) {
+		return false
+	}
+	se.engine.ReadOnly.Store(true)
+	return true
+}
+
 func (se *SqlEngine) Close() error {
+	se.closeUDFs()
+	se.releaseReservedValues()
 	if se.engine != nil {
 		return se.engine.Close()
 	}
 	return nil
 }
 
+// releaseReservedValues gives back the auto increment and sequence values each database reserved but didn't hand out,
+// so that they're handed out after a restart. Failing to do so only skips them, and is logged.
+func (se *SqlEngine) releaseReservedValues() {
+	if se.provider == nil {
+		return
+	}
+	ctx := sql.NewEmptyContext()
+	for _, db := range se.provider.AllDatabases(ctx) {
+		gsp, ok := db.(globalstate.GlobalStateProvider)
+		if !ok || gsp.GetGlobalState() == nil {
+			continue
+		}
+		gs := gsp.GetGlobalState()
+		tracker, err := gs.AutoIncrementTracker(ctx)
+		if err == nil {
+			err = tracker.Release(ctx)
+		}
+		if err != nil {
+			logrus.Warnf("failed to release auto increment values of database %s: %s", db.Name(), err.Error())
+		}
+		if st := gs.SequenceTracker(); st != nil {
+			if err := st.Release(ctx); err != nil {
+				logrus.Warnf("failed to release sequence values of database %s: %s", db.Name(), err.Error())
+			}
+		}
+	}
+}
+
+// closeUDFs releases the WebAssembly modules of the engine's user-defined functions.
+func (se *SqlEngine) closeUDFs() {
+	for _, udf := range se.udfs {
+		udf.Close(context.Background())
+	}
+	se.udfs = nil
+}
+
 // configureBinlogReplicaController configures the binlog replication controller with the |engine|.
 func configureBinlogReplicaController(config *SqlEngineConfig, engine *gms.Engine, session *dsess.DoltSession) error {
 	ctxFactory := sqlContextFactory()
@@ -0,0 +1,201 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+// OutputFormatFlag is the flag with which dolt table import, dolt merge and dolt pull print a machine-readable result
+// object, rather than their usual output.
+const OutputFormatFlag = "format"
+
+const jsonOutputFormat = "json"
+
+// OutputFormatHelp is the help text of OutputFormatFlag, which documents the exit codes used with it.
+var OutputFormatHelp = fmt.Sprintf("Print the result as a JSON object on stdout, and any other output on stderr. "+
+	"Valid values are json. The exit code is %d on success, %d on error, %d if the merge has conflicts, %d if it has "+
+	"constraint violations, and %d if rows were skipped.",
+	ExitCodeSuccess, ExitCodeError, ExitCodeConflicts, ExitCodeConstraintViolations, ExitCodeBadRows)
+
+// The exit codes of dolt table import, dolt merge and dolt pull when run with --format json. Without it, they exit
+// with 1 on any failure.
+const (
+	ExitCodeSuccess              = 0
+	ExitCodeError                = 1
+	ExitCodeConflicts            = 2
+	ExitCodeConstraintViolations = 3
+	ExitCodeBadRows              = 4
+)
+
+// The statuses of the result objects, which correspond to the exit codes.
+const (
+	ResultStatusSuccess              = "success"
+	ResultStatusError                = "error"
+	ResultStatusConflicts            = "conflicts"
+	ResultStatusConstraintViolations = "constraint_violations"
+	ResultStatusBadRows              = "bad_rows"
+)
+
+// ImportResult is the result object of dolt table import.
+type ImportResult struct {
+	Status        string `json:"status"`
+	Table         string `json:"table"`
+	RowsProcessed int64  `json:"rows_processed"`
+	RowsAdded     int64  `json:"rows_added"`
+	RowsModified  int64  `json:"rows_modified"`
+	RowsUnchanged int64  `json:"rows_unchanged"`
+	BadRows       int64  `json:"bad_rows"`
+}
+
+// MergeResult is the result object of dolt merge and dolt pull.
+type MergeResult struct {
+	Status               string             `json:"status"`
+	UpToDate             bool               `json:"up_to_date"`
+	FastForward          bool               `json:"fast_forward"`
+	Head                 string             `json:"head,omitempty"`
+	Merged               string             `json:"merged,omitempty"`
+	DataConflicts        int                `json:"data_conflicts"`
+	SchemaConflicts      int                `json:"schema_conflicts"`
+	ConstraintViolations int                `json:"constraint_violations"`
+	Tables               []MergeTableResult `json:"tables"`
+}
+
+// MergeTableResult is the change a merge made to one table.
+type MergeTableResult struct {
+	Table                string `json:"table"`
+	Operation            string `json:"operation"`
+	RowsAdded            int    `json:"rows_added"`
+	RowsModified         int    `json:"rows_modified"`
+	RowsDeleted          int    `json:"rows_deleted"`
+	DataConflicts        int    `json:"data_conflicts"`
+	SchemaConflicts      int    `json:"schema_conflicts"`
+	ConstraintViolations int    `json:"constraint_violations"`
+}
+
+var tableMergeOpNames = map[merge.TableMergeOp]string{
+	merge.TableUnmodified: "unmodified",
+	merge.TableAdded:      "added",
+	merge.TableRemoved:    "deleted",
+	merge.TableModified:   "modified",
+}
+
+// JsonOutputRequested returns whether |apr| has --format json, or an error if it has another format.
+func JsonOutputRequested(apr *argparser.ArgParseResults) (bool, errhand.VerboseError) {
+	format, ok := apr.GetValue(OutputFormatFlag)
+	if !ok {
+		return false, nil
+	} else if format != jsonOutputFormat {
+		return false, errhand.BuildDError("invalid --%s: %s, valid values are %s", OutputFormatFlag, format, jsonOutputFormat).SetPrintUsage().Build()
+	}
+	return true, nil
+}
+
+// RedirectOutputToStderr sends what the cli package prints to stdout to stderr until the returned function is called,
+// so that stdout holds only a result object.
+func RedirectOutputToStderr() (restore func()) {
+	out := cli.CliOut
+	cli.CliOut = cli.CliErr
+	return func() {
+		cli.CliOut = out
+	}
+}
+
+// PrintJsonResult prints |result| to stdout as a single line of JSON.
+func PrintJsonResult(result any) {
+	b, err := json.Marshal(result)
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return
+	}
+	cli.Println(string(b))
+}
+
+// Finish sets the status of the import from |exitCode|, the exit code of the import without --format json, and returns
+// the exit code for --format json.
+func (r *ImportResult) Finish(exitCode int) int {
+	switch {
+	case exitCode != 0:
+		r.Status = ResultStatusError
+		return ExitCodeError
+	case r.BadRows > 0:
+		r.Status = ResultStatusBadRows
+		return ExitCodeBadRows
+	default:
+		r.Status = ResultStatusSuccess
+		return ExitCodeSuccess
+	}
+}
+
+// addStats records the changes the merge made to each table in |tblToStats|.
+func (r *MergeResult) addStats(tblToStats map[string]*merge.MergeStats) {
+	if r == nil {
+		return
+	}
+	r.DataConflicts, r.SchemaConflicts, r.ConstraintViolations = 0, 0, 0
+	r.Tables = r.Tables[:0]
+	for tblName, stats := range tblToStats {
+		r.DataConflicts += stats.DataConflicts
+		r.SchemaConflicts += stats.SchemaConflicts
+		r.ConstraintViolations += stats.ConstraintViolations
+		op := stats.Operation
+		if op == merge.TableUnmodified && stats.HasArtifacts() {
+			// the stats of tables with conflicts or violations only count those
+			op = merge.TableModified
+		}
+		r.Tables = append(r.Tables, MergeTableResult{
+			Table:                tblName,
+			Operation:            tableMergeOpNames[op],
+			RowsAdded:            stats.Adds,
+			RowsModified:         stats.Modifications,
+			RowsDeleted:          stats.Deletes,
+			DataConflicts:        stats.DataConflicts,
+			SchemaConflicts:      stats.SchemaConflicts,
+			ConstraintViolations: stats.ConstraintViolations,
+		})
+	}
+	sort.Slice(r.Tables, func(i, j int) bool {
+		return r.Tables[i].Table < r.Tables[j].Table
+	})
+}
+
+// Finish sets the status of the merge from its conflicts and violations and |exitCode|, the exit code of the merge
+// without --format json, and returns the exit code for --format json. Conflicts take precedence over violations.
+func (r *MergeResult) Finish(exitCode int) int {
+	if r.Tables == nil {
+		r.Tables = []MergeTableResult{}
+	}
+	switch {
+	case r.DataConflicts > 0 || r.SchemaConflicts > 0:
+		r.Status = ResultStatusConflicts
+		return ExitCodeConflicts
+	case r.ConstraintViolations > 0:
+		r.Status = ResultStatusConstraintViolations
+		return ExitCodeConstraintViolations
+	case exitCode != 0:
+		r.Status = ResultStatusError
+		return ExitCodeError
+	default:
+		r.Status = ResultStatusSuccess
+		return ExitCodeSuccess
+	}
+}
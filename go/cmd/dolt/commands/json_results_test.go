@@ -0,0 +1,118 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+)
+
+func TestMergeResultFinish(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    map[string]*merge.MergeStats
+		exitCode int
+		status   string
+		expected int
+	}{
+		{
+			name:     "success",
+			stats:    map[string]*merge.MergeStats{"t": {Operation: merge.TableModified, Adds: 2}},
+			status:   ResultStatusSuccess,
+			expected: ExitCodeSuccess,
+		},
+		{
+			name:     "error",
+			exitCode: 1,
+			status:   ResultStatusError,
+			expected: ExitCodeError,
+		},
+		{
+			name:     "conflicts",
+			stats:    map[string]*merge.MergeStats{"t": {DataConflicts: 1}, "u": {ConstraintViolations: 1}},
+			exitCode: 1,
+			status:   ResultStatusConflicts,
+			expected: ExitCodeConflicts,
+		},
+		{
+			name:     "schema conflicts",
+			stats:    map[string]*merge.MergeStats{"t": {SchemaConflicts: 1}},
+			exitCode: 1,
+			status:   ResultStatusConflicts,
+			expected: ExitCodeConflicts,
+		},
+		{
+			name:     "constraint violations",
+			stats:    map[string]*merge.MergeStats{"t": {ConstraintViolations: 3}},
+			exitCode: 1,
+			status:   ResultStatusConstraintViolations,
+			expected: ExitCodeConstraintViolations,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &MergeResult{}
+			res.addStats(test.stats)
+			assert.Equal(t, test.expected, res.Finish(test.exitCode))
+			assert.Equal(t, test.status, res.Status)
+			assert.Len(t, res.Tables, len(test.stats))
+		})
+	}
+}
+
+func TestMergeResultTables(t *testing.T) {
+	res := &MergeResult{}
+	res.addStats(map[string]*merge.MergeStats{
+		"b": {DataConflicts: 2},
+		"a": {Operation: merge.TableAdded, Adds: 1},
+	})
+	res.Finish(1)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": "conflicts", "up_to_date": false, "fast_forward": false,
+		"data_conflicts": 2, "schema_conflicts": 0, "constraint_violations": 0,
+		"tables": [
+			{"table": "a", "operation": "added", "rows_added": 1, "rows_modified": 0, "rows_deleted": 0,
+			 "data_conflicts": 0, "schema_conflicts": 0, "constraint_violations": 0},
+			{"table": "b", "operation": "modified", "rows_added": 0, "rows_modified": 0, "rows_deleted": 0,
+			 "data_conflicts": 2, "schema_conflicts": 0, "constraint_violations": 0}
+		]}`, string(b))
+
+	res = &MergeResult{UpToDate: true}
+	assert.Equal(t, ExitCodeSuccess, res.Finish(0))
+	assert.NotNil(t, res.Tables)
+}
+
+func TestImportResultFinish(t *testing.T) {
+	res := &ImportResult{RowsProcessed: 2, RowsAdded: 2}
+	assert.Equal(t, ExitCodeSuccess, res.Finish(0))
+	assert.Equal(t, ResultStatusSuccess, res.Status)
+
+	res = &ImportResult{RowsProcessed: 1, RowsAdded: 1, BadRows: 1}
+	assert.Equal(t, ExitCodeBadRows, res.Finish(0))
+	assert.Equal(t, ResultStatusBadRows, res.Status)
+
+	res = &ImportResult{BadRows: 1}
+	assert.Equal(t, ExitCodeError, res.Finish(1))
+	assert.Equal(t, ResultStatusError, res.Status)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/util/outputpager"
 )
@@ -36,7 +37,7 @@ import (
 * - Calculate the positions of the commits in the graph.
 *   The vertical position of each commit is determined by the order of the commits, but should be adjusted to the length of the commit message.
 *
-*   The calculation of horizontal position is more complex, but mostly depends on the parent-child relationship of the commits. This is done in the function computeColumnEnds.
+*   The calculation of horizontal position is more complex, but mostly depends on the parent-child relationship of the commits. This is done in the function computeColumnEnds, which uses commitwalk.LaneAssigner.
 *
 *   Create a 2D matrix to store the branch paths, this matrix will help us find the available column for the commits.
 *   In each column, there will be multiple branch paths, and each path is represented by a pair of positions of start and end commits on the branch.
@@ -180,11 +181,6 @@ var branchColors = []*color.Color{
 	color.New(color.FgWhite),
 }
 
-type branchPath struct {
-	Start int
-	End   int
-}
-
 // mapCommitsWithChildrenAndPosition gets the children of commits, and initialize the x and y coordinates of the commits
 func mapCommitsWithChildrenAndPosition(commits []CommitInfo) []*commitInfoWithChildren {
 	childrenMap := make(map[string][]string)
@@ -207,113 +203,17 @@ func mapCommitsWithChildrenAndPosition(commits []CommitInfo) []*commitInfoWithCh
 	return commitsWithChildren
 }
 
-func minVal(values ...int) int {
-	if len(values) == 0 {
-		return math.MaxInt
-	}
-	minVal := values[0]
-	for _, v := range values {
-		if v < minVal {
-			minVal = v
-		}
-	}
-	return minVal
-}
-
 // computeColumnEnds compute the column coordinate of each commit
-func computeColumnEnds(commits []*commitInfoWithChildren, commitsMap map[string]*commitInfoWithChildren) ([]*commitInfoWithChildren, map[string]*commitInfoWithChildren) {
-	columns := [][]branchPath{}
-	colPositions := make(map[string]int)
+func computeColumnEnds(commits []*commitInfoWithChildren) ([]*commitInfoWithChildren, map[string]*commitInfoWithChildren) {
+	lanes := commitwalk.NewLaneAssigner()
 	newCommitMap := make(map[string]*commitInfoWithChildren)
 	commitsWithColPos := make([]*commitInfoWithChildren, len(commits))
 
-	updateColumns := func(col, end int) {
-		columns[col][len(columns[col])-1].End = end
-	}
-
 	for index, commit := range commits {
-		var branchChildren []string
-		for _, child := range commit.Children {
-			if commitsMap[child].Commit.parentHashes[0] == commit.Commit.commitHash {
-				branchChildren = append(branchChildren, child)
-			}
-		}
-
-		isLastCommitOnBranch := len(commit.Children) == 0
-		isBranchOutCommit := len(branchChildren) > 0
-
-		commitColInd := -1
-
-		if isLastCommitOnBranch {
-			columns = append(columns, []branchPath{
-				{
-					Start: index,
-					End:   index,
-				},
-			})
-			commitColInd = len(columns) - 1
-		} else if isBranchOutCommit {
-			// in the case of a branch out commit, the column index of the commit is the minimum column index of its children
-			var branchChildrenColIndexes []int
-			for _, childHash := range branchChildren {
-				if childColInd, ok := colPositions[childHash]; ok {
-					branchChildrenColIndexes = append(branchChildrenColIndexes, childColInd)
-				}
-			}
-
-			commitColInd = minVal(branchChildrenColIndexes...)
-
-			updateColumns(commitColInd, index)
-
-			// update the path that branches out from the current commit by setting their end to be one position before the current commit
-			for _, childColInd := range branchChildrenColIndexes {
-				if childColInd != commitColInd {
-					updateColumns(childColInd, index-1)
-				}
-			}
-		} else {
-			// minChildRowInd is the highest pos of child commit, maxChildColInd is the right most pos of child commit
-			minChildRowInd := math.MaxInt
-			maxChildColInd := -1
-			for _, child := range commit.Children {
-				if commitsMap[child].Row < minChildRowInd {
-					minChildRowInd = commitsMap[child].Row
-				}
-				if colPositions[child] > maxChildColInd {
-					maxChildColInd = colPositions[child]
-				}
-			}
-
-			// find the first column that has no branches that overlap with the current commit
-			// if no such column is found, put the commit in a new column
-			col := -1
-			for i := maxChildColInd + 1; i < len(columns); i++ {
-				if minChildRowInd >= columns[i][len(columns[i])-1].End {
-					col = i
-					break
-				}
-			}
-			if col == -1 {
-				columns = append(columns, []branchPath{
-					{
-						Start: minChildRowInd + 1,
-						End:   index,
-					},
-				})
-				commitColInd = len(columns) - 1
-			} else {
-				commitColInd = col
-				columns[col] = append(columns[col], branchPath{
-					Start: minChildRowInd + 1,
-					End:   index,
-				})
-			}
-		}
-		colPositions[commit.Commit.commitHash] = commitColInd
 		commitsWithColPos[index] = &commitInfoWithChildren{
 			Commit:   commit.Commit,
 			Children: commit.Children,
-			Col:      commitColInd,
+			Col:      lanes.Next(commit.Commit.commitHash, commit.Commit.parentHashes),
 			Row:      commit.Row,
 		}
 		newCommitMap[commit.Commit.commitHash] = commitsWithColPos[index]
@@ -570,13 +470,7 @@ func drawCommitDotsAndBranchPaths(commits []*commitInfoWithChildren, commitsMap
 func logGraph(pager *outputpager.Pager, apr *argparser.ArgParseResults, commitInfos []CommitInfo) {
 	color.NoColor = false
 
-	commits := mapCommitsWithChildrenAndPosition(commitInfos)
-	commitsMap := make(map[string]*commitInfoWithChildren)
-	for _, commit := range commits {
-		commitsMap[commit.Commit.commitHash] = commit
-	}
-
-	commits, commitsMap = computeColumnEnds(commits, commitsMap)
+	commits, commitsMap := computeColumnEnds(mapCommitsWithChildrenAndPosition(commitInfos))
 	oneLine := apr.Contains(cli.OneLineFlag)
 	if oneLine {
 		expandGraphBasedOnGraphShape(commits, commitsMap)
@@ -43,12 +43,8 @@ func TestComputeColumnEnds(t *testing.T) {
 		{Commit: CommitInfo{commitHash: "hash1", parentHashes: []string{"hash2"}}, Children: []string{}, Row: 0},
 		{Commit: CommitInfo{commitHash: "hash2", parentHashes: []string{}}, Children: []string{"hash1"}, Row: 1},
 	}
-	commitsMap := map[string]*commitInfoWithChildren{
-		"hash1": commits[0],
-		"hash2": commits[1],
-	}
 
-	result, _ := computeColumnEnds(commits, commitsMap)
+	result, _ := computeColumnEnds(commits)
 	require.Equal(t, 0, result[0].Col)
 	require.Equal(t, 0, result[1].Col)
 
@@ -64,13 +60,7 @@ func TestComputeColumnEnds(t *testing.T) {
 		{Commit: CommitInfo{commitHash: "2M", parentHashes: []string{"1M"}}, Children: []string{"1A", "3M"}, Row: 2},
 		{Commit: CommitInfo{commitHash: "1M", parentHashes: []string{}}, Children: []string{"2M"}, Row: 3},
 	}
-	commitsMap = map[string]*commitInfoWithChildren{
-		"1M": commits[3],
-		"2M": commits[2],
-		"1A": commits[1],
-		"3M": commits[0],
-	}
-	result, _ = computeColumnEnds(commits, commitsMap)
+	result, _ = computeColumnEnds(commits)
 	require.Equal(t, 0, result[0].Col)
 	require.Equal(t, 1, result[1].Col)
 	require.Equal(t, 0, result[2].Col)
@@ -84,12 +74,8 @@ func TestDrawCommitDotsAndBranchPaths(t *testing.T) {
 		{Commit: CommitInfo{commitHash: "hash1", parentHashes: []string{"hash2"}, commitMeta: &datas.CommitMeta{Description: "Commit 1"}}, Children: []string{}, Row: 0},
 		{Commit: CommitInfo{commitHash: "hash2", parentHashes: []string{}, commitMeta: &datas.CommitMeta{Description: "Commit 2"}}, Children: []string{"hash1"}, Row: 1},
 	}
-	commitsMap := map[string]*commitInfoWithChildren{
-		"hash1": commits[0],
-		"hash2": commits[1],
-	}
 
-	commits, commitsMap = computeColumnEnds(commits, commitsMap)
+	commits, commitsMap := computeColumnEnds(commits)
 	expandGraphBasedOnCommitMetaDataHeight(commits)
 
 	graph := drawCommitDotsAndBranchPaths(commits, commitsMap)
@@ -163,12 +149,8 @@ func TestExpandGraphBasedOnGraphShape(t *testing.T) {
 			Row:      1,
 		},
 	}
-	commitsMap := map[string]*commitInfoWithChildren{
-		"hash1": commits[0],
-		"hash2": commits[1],
-	}
 
-	commits, commitsMap = computeColumnEnds(commits, commitsMap)
+	commits, commitsMap := computeColumnEnds(commits)
 	expandGraphBasedOnGraphShape(commits, commitsMap)
 
 	require.Equal(t, 0, commits[0].Col)
@@ -213,11 +195,7 @@ func TestExpandGraphBasedOnGraphShape(t *testing.T) {
 		},
 	}
 
-	commitsMap = map[string]*commitInfoWithChildren{
-		"hash1": commits[0],
-		"hash2": commits[1],
-	}
-	commits, commitsMap = computeColumnEnds(commits, commitsMap)
+	commits, commitsMap = computeColumnEnds(commits)
 	expandGraphBasedOnGraphShape(commits, commitsMap)
 
 	require.Equal(t, 0, commits[0].Col)
@@ -187,7 +187,7 @@ func loginWithExistingCreds(ctx context.Context, dEnv *env.DoltEnv, idOrPubKey,
 		return errhand.BuildDError("error: failed to find creds '%s'", idOrPubKey).AddCause(err).Build()
 	}
 
-	dc, err := creds.JWKCredsReadFromFile(dEnv.FS, jwkFilePath)
+	dc, err := dEnv.ReadCreds(jwkFilePath)
 
 	if err != nil {
 		return errhand.BuildDError("error: failed to load creds from file").AddCause(err).Build()
@@ -48,13 +48,18 @@ var mergeDocs = cli.CommandDocumentationContent{
 
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
+The third syntax ({{.LessThan}}dolt merge --dry-run{{.GreaterThan}}) reports the rows each table would gain, change and lose, and the conflicts and constraint violations the merge would leave, without merging. Uncommitted changes are not part of the report. The same report is available in SQL from the {{.EmphasisLeft}}dolt_merge_preview(){{.EmphasisRight}} table function.
+
+Tables are merged with the strategies configured for them in the {{.EmphasisLeft}}dolt_merge_strategies{{.EmphasisRight}} table. {{.EmphasisLeft}}--strategy{{.EmphasisRight}} merges every table with the given strategy instead. A fast-forward merge with {{.EmphasisLeft}}--squash{{.EmphasisRight}} only stages the merged changes, unless a message is given with {{.EmphasisLeft}}-m{{.EmphasisRight}} to commit them with.
+
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
 `,
 
 	Synopsis: []string{
-		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
+		"[--squash] [-m message] [--strategy {{.LessThan}}strategy{{.GreaterThan}}] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
+		"--dry-run {{.LessThan}}branch{{.GreaterThan}}",
 	},
 }
 
@@ -94,11 +99,34 @@ func (cmd MergeCmd) RequiresRepo() bool {
 func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
 	ap := cli.CreateMergeArgParser()
 	ap.SupportsFlag(cli.NoJsonMergeFlag, "", "Do not attempt to automatically resolve multiple changes to the same JSON value, report a conflict instead.")
+	ap.SupportsFlag(cli.DryRunFlag, "", "Report what the merge would change without merging.")
+	ap.SupportsString(OutputFormatFlag, "", "format", OutputFormatHelp)
 	apr, usage, terminate, status := ParseArgsOrPrintHelp(ap, commandStr, args, mergeDocs)
 	if terminate {
 		return status
 	}
 
+	jsonOutput, verr := JsonOutputRequested(apr)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if !jsonOutput {
+		return runMerge(ctx, commandStr, apr, usage, cliCtx, nil)
+	}
+	if apr.Contains(cli.DryRunFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError(ErrConflictingFlags, OutputFormatFlag, cli.DryRunFlag).Build(), usage)
+	}
+
+	res := &MergeResult{}
+	restore := RedirectOutputToStderr()
+	exitCode := res.Finish(runMerge(ctx, commandStr, apr, usage, cliCtx, res))
+	restore()
+	PrintJsonResult(res)
+	return exitCode
+}
+
+// runMerge runs the merge described by |apr|, printing its outcome, and records the outcome in |res| if it's not nil.
+func runMerge(ctx context.Context, commandStr string, apr *argparser.ArgParseResults, usage cli.UsagePrinter, cliCtx cli.CliContext, res *MergeResult) int {
 	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
 		cli.Println(err.Error())
@@ -114,6 +142,10 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		}
 	}
 
+	if apr.Contains(cli.DryRunFlag) {
+		return dryRunMerge(sqlCtx, queryist, apr, usage)
+	}
+
 	ok := validateDoltMergeArgs(apr, usage, cliCtx)
 	if ok != 0 {
 		return 1
@@ -163,6 +195,9 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 	if upToDate {
 		// dolt uses "Everything up-to-date" message, but Git CLI uses "Already up to date".
 		cli.Println(doltdb.ErrUpToDate.Error())
+		if res != nil {
+			res.UpToDate = true
+		}
 		return 0
 	}
 
@@ -189,14 +224,60 @@ func (cmd MergeCmd) Exec(ctx context.Context, commandStr string, args []string,
 		fastFwd := getFastforward(mergeResultRow, dprocedures.MergeProcFFIndex)
 
 		if apr.Contains(cli.NoCommitFlag) {
-			return printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, mergeHash, "HEAD", "STAGED")
+			return printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, mergeHash, "HEAD", "STAGED", res)
 		}
-		return printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, mergeHash, "HEAD^1", "HEAD")
+		return printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, mergeHash, "HEAD^1", "HEAD", res)
 	}
 
 	return 0
 }
 
+// dryRunMerge prints the dolt_merge_preview of merging the branch in |apr| into the current branch. It returns
+// a non-zero status if the merge would leave conflicts or constraint violations.
+func dryRunMerge(sqlCtx *sql.Context, queryist cli.Queryist, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if apr.NArg() != 1 || apr.Contains(cli.AbortParam) {
+		usage()
+		return 1
+	}
+
+	q, err := dbr.InterpolateForDialect("select * from dolt_merge_preview(?)", []interface{}{apr.Arg(0)}, dialect.MySQL)
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+	sch, rowIter, _, err := queryist.Query(sqlCtx, q)
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+	if len(rows) == 0 {
+		cli.Println("Merge would not change any tables.")
+		return 0
+	}
+
+	err = engine.PrettyPrintResults(sqlCtx, engine.FormatTabular, sch, sql.RowsToRowIter(rows...))
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+
+	// data_conflicts, schema_conflicts and constraint_violations are the last three columns
+	for _, row := range rows {
+		for _, v := range row[len(row)-3:] {
+			if n, err := getInt64ColAsInt64(v); err == nil && n > 0 {
+				cli.Println("Merge would leave conflicts or constraint violations.")
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
 // validateDoltMergeArgs checks if the arguments passed to 'dolt merge' are valid
 func validateDoltMergeArgs(apr *argparser.ArgParseResults, usage cli.UsagePrinter, cliCtx cli.CliContext) int {
 	if apr.ContainsAll(cli.SquashParam, cli.NoFFParam) {
@@ -284,6 +365,11 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 	if apr.Contains(cli.NoEditFlag) {
 		writeToBuffer("--no-edit", false)
 	}
+	if strategy, ok := apr.GetValue(cli.StrategyParam); ok {
+		writeToBuffer("--strategy", false)
+		writeToBuffer("?", true)
+		params = append(params, strategy)
+	}
 
 	writeToBuffer("--author", false)
 	var author string
@@ -342,7 +428,12 @@ func printMergeStats(fastForward bool,
 	headHash string,
 	mergeHash string,
 	fromRef string,
-	toRef string) int {
+	toRef string,
+	res *MergeResult) int {
+
+	if res != nil {
+		res.FastForward, res.Head, res.Merged = fastForward, headHash, mergeHash
+	}
 
 	if fastForward {
 		cli.Println("Fast-forward")
@@ -374,6 +465,9 @@ func printMergeStats(fastForward bool,
 		if err != nil {
 			if err.Error() == "error: unable to get diff summary from HEAD^1 to HEAD: invalid ancestor spec" {
 				cli.Println(doltdb.ErrUpToDate.Error())
+				if res != nil {
+					res.UpToDate = true
+				}
 				return 0
 			}
 			cli.Println("merge successful, but could not calculate stats")
@@ -382,11 +476,16 @@ func printMergeStats(fastForward bool,
 		}
 		if upToDate {
 			cli.Println(doltdb.ErrUpToDate.Error())
+			if res != nil {
+				res.UpToDate = true
+			}
 			return 0
 		}
 	}
+	res.addStats(mergeStats)
 
-	if !apr.Contains(cli.NoCommitFlag) && !apr.Contains(cli.NoFFParam) && !fastForward && noConflicts {
+	// the commit is printed with a pager on stdout, which only holds the result object when there is one
+	if !apr.Contains(cli.NoCommitFlag) && !apr.Contains(cli.NoFFParam) && !fastForward && noConflicts && res == nil {
 		commit, err := getCommitInfo(queryist, sqlCtx, "HEAD")
 		if err != nil {
 			cli.Println("merge finished, but failed to get commit info")
@@ -0,0 +1,378 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	promoteFFOnlyFlag   = "ff-only"
+	promoteValidateFlag = "validate"
+	promoteTagFlag      = "tag"
+)
+
+var promoteDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Promote the changes of one branch to another, such as from staging to production.`,
+	LongDesc: `Merges {{.LessThan}}from{{.GreaterThan}} into the branch {{.LessThan}}to{{.GreaterThan}}, following the policy for promoting a dataset between the branches of its environments, such as from dev to staging to prod:
+
+1. The merge is fast-forwarded when possible, and otherwise is a merge commit. With {{.EmphasisLeft}}--ff-only{{.EmphasisRight}}, the promotion fails unless it can be fast-forwarded.
+2. The merge must be clean. If it has conflicts or constraint violations, the promotion fails.
+3. The validation queries of the {{.EmphasisLeft}}--validate{{.EmphasisRight}} file are run against the merged data. Each of them must return no rows, like a query for the rows violating a business rule. If any of them returns rows, the promotion fails.
+4. The result is tagged, with a tag message recording what was promoted, the commits of {{.LessThan}}to{{.GreaterThan}} before and after, and the validation queries which passed.
+
+The merge is done and validated on a temporary branch, so {{.LessThan}}to{{.GreaterThan}} is only updated if the promotion succeeds. {{.LessThan}}to{{.GreaterThan}} must not have uncommitted changes, and the promotion fails if it changes while it's being promoted.
+`,
+	Synopsis: []string{
+		`[--ff-only] [--validate {{.LessThan}}file{{.GreaterThan}}] [--tag {{.LessThan}}name{{.GreaterThan}}] [-m {{.LessThan}}msg{{.GreaterThan}}] {{.LessThan}}from{{.GreaterThan}} {{.LessThan}}to{{.GreaterThan}}`,
+	},
+}
+
+type PromoteCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd PromoteCmd) Name() string {
+	return "promote"
+}
+
+// Description returns a description of the command
+func (cmd PromoteCmd) Description() string {
+	return promoteDocs.ShortDesc
+}
+
+func (cmd PromoteCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(promoteDocs, ap)
+}
+
+func (cmd PromoteCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"from", "The branch or commit to promote."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"to", "The branch to promote it to."})
+	ap.SupportsFlag(promoteFFOnlyFlag, "", "Fail unless {{.LessThan}}to{{.GreaterThan}} can be fast-forwarded to {{.LessThan}}from{{.GreaterThan}}.")
+	ap.SupportsString(promoteValidateFlag, "", "file", "A file of SQL queries which must each return no rows for the promotion to succeed.")
+	ap.SupportsString(promoteTagFlag, "", "name", "The name of the tag of the result. Defaults to promote-{{.LessThan}}to{{.GreaterThan}}-{{.LessThan}}UTC timestamp{{.GreaterThan}}.")
+	ap.SupportsString(cli.MessageArg, "m", "msg", "Use the given {{.LessThan}}msg{{.GreaterThan}} as the message of the merge commit and tag.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd PromoteCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd PromoteCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, promoteDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 2 {
+		return HandleVErrAndExitCode(errhand.BuildDError("%s takes exactly 2 args", cmd.Name()).Build(), usage)
+	}
+
+	p := promotion{
+		from:    apr.Arg(0),
+		to:      apr.Arg(1),
+		ffOnly:  apr.Contains(promoteFFOnlyFlag),
+		tagName: apr.GetValueOrDefault(promoteTagFlag, fmt.Sprintf("promote-%s-%s", apr.Arg(1), time.Now().UTC().Format("20060102T150405Z"))),
+		message: apr.GetValueOrDefault(cli.MessageArg, fmt.Sprintf("Promote %s into %s", apr.Arg(0), apr.Arg(1))),
+	}
+	name, email, err := env.GetNameAndEmail(cliCtx.Config())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	p.author = name + " <" + email + ">"
+	if validateFile, ok := apr.GetValue(promoteValidateFlag); ok {
+		queries, err := readValidationQueries(validateFile)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read validation queries from %s", validateFile).AddCause(err).Build(), usage)
+		}
+		p.validateFile, p.validations = validateFile, queries
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	result, err := p.run(queryist, sqlCtx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to promote %s into %s", p.from, p.to).AddCause(err).Build(), usage)
+	}
+
+	kind := "merge"
+	if result.fastForward {
+		kind = "fast-forward"
+	}
+	cli.Printf("Promoted %s into %s (%s): %s -> %s\n", p.from, p.to, kind, result.before, result.after)
+	if len(p.validations) > 0 {
+		cli.Printf("%d validation queries passed\n", len(p.validations))
+	}
+	cli.Printf("Tagged %s\n", p.tagName)
+	return 0
+}
+
+// promotion is a promotion of |from| into the branch |to|.
+type promotion struct {
+	from, to     string
+	ffOnly       bool
+	validateFile string
+	validations  []string
+	tagName      string
+	message      string
+	author       string
+}
+
+type promotionResult struct {
+	before, after string
+	fastForward   bool
+}
+
+// readValidationQueries returns the queries of the file at |path|.
+func readValidationQueries(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var queries []string
+	scanner := newStreamScanner(f)
+	for scanner.Scan() {
+		if query := strings.TrimSpace(scanner.Text()); query != "" {
+			queries = append(queries, query)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// run promotes |p.from| into |p.to| by merging it on a temporary branch created from |p.to|, validating the merged
+// branch, and then fast-forwarding |p.to| to it. The temporary branch is deleted afterward, whether or not the
+// promotion succeeded.
+func (p promotion) run(queryist cli.Queryist, sqlCtx *sql.Context) (res promotionResult, err error) {
+	rows, err := GetRowsForSql(queryist, sqlCtx, "select database()")
+	if err != nil {
+		return res, err
+	}
+	dbName, _ := rows[0][0].(string)
+	if dbName == "" {
+		return res, fmt.Errorf("no database selected")
+	}
+	// the promotion switches between the revision databases of the branches, and returns to the base database
+	dbName, _, _ = strings.Cut(dbName, "/")
+
+	isBranch, err := promoteQueryCount(queryist, sqlCtx, "select count(*) from dolt_branches where name = ?", p.to)
+	if err != nil {
+		return res, err
+	}
+	if isBranch == 0 {
+		return res, fmt.Errorf("branch not found: %s", p.to)
+	}
+	res.before, err = getHashOf(queryist, sqlCtx, p.to)
+	if err != nil {
+		return res, err
+	}
+	fromHash, err := getHashOf(queryist, sqlCtx, p.from)
+	if err != nil {
+		return res, err
+	}
+
+	base, err := promoteQueryString(queryist, sqlCtx, "select dolt_merge_base(?, ?)", p.to, fromHash)
+	if err != nil {
+		return res, err
+	}
+	if base == fromHash {
+		return res, fmt.Errorf("nothing to promote, %s already contains %s", p.to, p.from)
+	}
+	res.fastForward = base == res.before
+	if p.ffOnly && !res.fastForward {
+		return res, fmt.Errorf("%s can't be fast-forwarded to %s", p.to, p.from)
+	}
+
+	changes, err := promoteQueryCount(queryist, sqlCtx, fmt.Sprintf("select count(*) from %s.dolt_status", sql.QuoteIdentifier(dbName+"/"+p.to)))
+	if err != nil {
+		return res, err
+	}
+	if changes > 0 {
+		return res, fmt.Errorf("%s has uncommitted changes", p.to)
+	}
+
+	scratch := fmt.Sprintf("dolt_promote/%s/%d", p.to, time.Now().UnixNano())
+	if err = promoteCall(queryist, sqlCtx, "dolt_branch", scratch, res.before); err != nil {
+		return res, err
+	}
+	defer func() {
+		cleanupErr := promoteUse(queryist, sqlCtx, dbName)
+		if cleanupErr == nil {
+			cleanupErr = promoteCall(queryist, sqlCtx, "dolt_branch", "-D", scratch)
+		}
+		if cleanupErr != nil && err == nil {
+			err = fmt.Errorf("failed to delete temporary branch %s: %w", scratch, cleanupErr)
+		}
+	}()
+
+	if err = promoteUse(queryist, sqlCtx, dbName+"/"+scratch); err != nil {
+		return res, err
+	}
+	if err = p.merge(queryist, sqlCtx); err != nil {
+		return res, err
+	}
+	for _, query := range p.validations {
+		rows, err := GetRowsForSql(queryist, sqlCtx, query)
+		if err != nil {
+			return res, fmt.Errorf("validation query failed: %s: %w", query, err)
+		}
+		if len(rows) > 0 {
+			return res, fmt.Errorf("validation query returned %d rows: %s", len(rows), query)
+		}
+	}
+	res.after, err = getHashOf(queryist, sqlCtx, "HEAD")
+	if err != nil {
+		return res, err
+	}
+
+	// |p.to| is only fast-forwarded to the validated result if it hasn't changed since the result was merged
+	current, err := getHashOf(queryist, sqlCtx, p.to)
+	if err != nil {
+		return res, err
+	}
+	if current != res.before {
+		return res, fmt.Errorf("%s changed during the promotion, from %s to %s", p.to, res.before, current)
+	}
+	if err = promoteUse(queryist, sqlCtx, dbName+"/"+p.to); err != nil {
+		return res, err
+	}
+	if err = promoteCall(queryist, sqlCtx, "dolt_merge", scratch); err != nil {
+		return res, err
+	}
+
+	err = promoteCall(queryist, sqlCtx, "dolt_tag", "-m", p.auditMessage(fromHash, res), "--author", p.author, p.tagName, res.after)
+	return res, err
+}
+
+// merge merges |p.from| into the current branch, and returns an error if the merge isn't clean.
+func (p promotion) merge(queryist cli.Queryist, sqlCtx *sql.Context) error {
+	// conflicts are reported by the result of dolt_merge rather than failing the statement, so that the temporary
+	// branch can be deleted
+	if _, err := GetRowsForSql(queryist, sqlCtx, "set @@dolt_allow_commit_conflicts = 1"); err != nil {
+		return err
+	}
+	q, err := interpolateStoredProcedureCall("DOLT_MERGE", []string{"-m", p.message, "--author", p.author, p.from})
+	if err != nil {
+		return err
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 {
+		return fmt.Errorf("unexpected number of rows returned from dolt_merge: %d", len(rows))
+	}
+	conflicts, err := getInt64ColAsInt64(rows[0][2])
+	if err != nil {
+		return fmt.Errorf("unable to parse conflicts column: %w", err)
+	}
+	if conflicts != 0 {
+		return fmt.Errorf("merging %s into %s has conflicts or constraint violations", p.from, p.to)
+	}
+	return nil
+}
+
+// auditMessage returns the message of the tag of a successful promotion, recording what was promoted and how.
+func (p promotion) auditMessage(fromHash string, res promotionResult) string {
+	var sb strings.Builder
+	sb.WriteString(p.message)
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "from: %s (%s)\n", p.from, fromHash)
+	fmt.Fprintf(&sb, "to: %s (%s -> %s)\n", p.to, res.before, res.after)
+	if res.fastForward {
+		sb.WriteString("merge: fast-forward\n")
+	} else {
+		sb.WriteString("merge: merge commit\n")
+	}
+	if len(p.validations) > 0 {
+		fmt.Fprintf(&sb, "validated by %d queries of %s:\n", len(p.validations), p.validateFile)
+		for _, query := range p.validations {
+			fmt.Fprintf(&sb, "  %s\n", strings.Join(strings.Fields(query), " "))
+		}
+	}
+	return sb.String()
+}
+
+func promoteQueryString(queryist cli.Queryist, sqlCtx *sql.Context, query string, args ...interface{}) (string, error) {
+	q, err := dbr.InterpolateForDialect(query, args, dialect.MySQL)
+	if err != nil {
+		return "", err
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return "", err
+	}
+	if len(rows) != 1 {
+		return "", fmt.Errorf("unexpected number of rows returned from %s: %d", query, len(rows))
+	}
+	s, _ := rows[0][0].(string)
+	return s, nil
+}
+
+func promoteQueryCount(queryist cli.Queryist, sqlCtx *sql.Context, query string, args ...interface{}) (int64, error) {
+	q, err := dbr.InterpolateForDialect(query, args, dialect.MySQL)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, q)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("unexpected number of rows returned from %s: %d", query, len(rows))
+	}
+	return getInt64ColAsInt64(rows[0][0])
+}
+
+func promoteCall(queryist cli.Queryist, sqlCtx *sql.Context, procedure string, args ...string) error {
+	q, err := interpolateStoredProcedureCall(procedure, args)
+	if err != nil {
+		return err
+	}
+	_, err = GetRowsForSql(queryist, sqlCtx, q)
+	return err
+}
+
+func promoteUse(queryist cli.Queryist, sqlCtx *sql.Context, dbName string) error {
+	_, err := GetRowsForSql(queryist, sqlCtx, "use "+sql.QuoteIdentifier(dbName))
+	return err
+}
@@ -76,9 +76,28 @@ func (cmd PullCmd) EventType() eventsapi.ClientEventType {
 // Exec executes the command
 func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
 	ap := cli.CreatePullArgParser()
+	ap.SupportsString(OutputFormatFlag, "", "format", OutputFormatHelp)
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, pullDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
+	jsonOutput, verr := JsonOutputRequested(apr)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if !jsonOutput {
+		return runPull(ctx, apr, usage, cliCtx, nil)
+	}
+
+	res := &MergeResult{}
+	restore := RedirectOutputToStderr()
+	exitCode := res.Finish(runPull(ctx, apr, usage, cliCtx, res))
+	restore()
+	PrintJsonResult(res)
+	return exitCode
+}
+
+// runPull runs the pull described by |apr|, printing its outcome, and records the outcome in |res| if it's not nil.
+func runPull(ctx context.Context, apr *argparser.ArgParseResults, usage cli.UsagePrinter, cliCtx cli.CliContext, res *MergeResult) int {
 	if apr.NArg() > 2 {
 		verr := errhand.VerboseErrorFromError(actions.ErrInvalidPullArgs)
 		return HandleVErrAndExitCode(verr, usage)
@@ -169,7 +188,9 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 				cli.Println(err.Error())
 				return
 			}
-			if cli.ExecuteWithStdioRestored != nil {
+			if res != nil {
+				res.Head, res.Merged = headHash, remoteHash
+			} else if cli.ExecuteWithStdioRestored != nil {
 				cli.ExecuteWithStdioRestored(func() {
 					pager := outputpager.Start()
 					defer pager.Stop()
@@ -180,12 +201,18 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		} else {
 			fastFwd := getFastforward(row, dprocedures.PullProcFFIndex)
 
-			var success int
+			fromRef, toRef := "HEAD", remoteRef
 			if apr.Contains(cli.NoCommitFlag) {
-				success = printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, remoteHash, "HEAD", "STAGED")
-			} else {
-				success = printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, remoteHash, "HEAD", remoteRef)
+				toRef = "STAGED"
+			}
+			if res != nil && headHash != "" {
+				// the result object reports what the pull changed, from the HEAD before it
+				fromRef = headHash
+				if toRef == remoteRef {
+					toRef = "HEAD"
+				}
 			}
+			success := printMergeStats(fastFwd, apr, queryist, sqlCtx, usage, headHash, remoteHash, fromRef, toRef, res)
 			if success == 1 {
 				errChan <- errors.New(" ") //return a non-nil error for the correct exit code but no further messages to print
 				return
@@ -194,7 +221,7 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}()
 
 	spinner := TextSpinner{}
-	if !apr.Contains(cli.SilentFlag) {
+	if !apr.Contains(cli.SilentFlag) && res == nil {
 		cli.Print(spinner.next() + " Pulling...")
 		defer func() {
 			cli.DeleteAndPrint(len(" Pulling...")+1, "")
@@ -218,7 +245,7 @@ func (cmd PullCmd) Exec(ctx context.Context, commandStr string, args []string, d
 			}
 			return HandleVErrAndExitCode(nil, usage)
 		case <-time.After(time.Millisecond * 50):
-			if !apr.Contains(cli.SilentFlag) {
+			if !apr.Contains(cli.SilentFlag) && res == nil {
 				cli.DeleteAndPrint(len(" Pulling...")+1, spinner.next()+" Pulling...")
 			}
 		}
@@ -47,9 +47,14 @@ the same as Git and Dolt's "two dot log" syntax, or |upstreamBranch|..|currentBr
 Rebasing is useful to clean and organize your commit history, especially before merging a feature branch back to a shared 
 branch. For example, you can drop commits that contain debugging or test changes, or squash or fixup small commits into a 
 single commit, or reorder commits so that related changes are adjacent in the new commit history.
+
+Without {{.EmphasisLeft}}--interactive{{.EmphasisRight}}, every commit in the rebase plan is picked, which replays the 
+current branch's commits on top of the upstream branch. If a commit can't be applied cleanly, the rebase stops so the 
+conflicts can be resolved, and is then resumed with {{.EmphasisLeft}}--continue{{.EmphasisRight}} or abandoned with 
+{{.EmphasisLeft}}--abort{{.EmphasisRight}}.
 `,
 	Synopsis: []string{
-		`(-i | --interactive) [--empty=drop|keep] {{.LessThan}}upstream{{.GreaterThan}}`,
+		`[-i | --interactive] [--empty=drop|keep] {{.LessThan}}upstream{{.GreaterThan}}`,
 		`(--continue | --abort)`,
 	},
 }
@@ -114,6 +119,12 @@ func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	rows, err := GetRowsForSql(queryist, sqlCtx, query)
 	if err != nil {
+		// A non-interactive rebase stops on data conflicts with the rebase working branch checked out
+		if dprocedures.ErrRebaseDataConflict.Is(err) || strings.Contains(err.Error(), dprocedures.ErrRebaseDataConflict.Message[:40]) {
+			if checkoutErr := syncCliBranchToSqlSessionBranch(sqlCtx, dEnv); checkoutErr != nil {
+				return HandleVErrAndExitCode(errhand.VerboseErrorFromError(checkoutErr), usage)
+			}
+		}
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
@@ -18,10 +18,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dustin/go-humanize"
 	"github.com/gocraft/dbr/v2"
 	"github.com/gocraft/dbr/v2/dialect"
 
@@ -31,6 +33,7 @@ import (
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
@@ -43,7 +46,7 @@ var remoteDocs = cli.CommandDocumentationContent{
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a remote named {{.LessThan}}name{{.GreaterThan}} for the repository at {{.LessThan}}url{{.GreaterThan}}. The command dolt fetch {{.LessThan}}name{{.GreaterThan}} can then be used to create and update remote-tracking branches {{.EmphasisLeft}}<name>/<branch>{{.EmphasisRight}}.
 
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, oci+https, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 
 AWS cloud remote urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}.  You may configure your aws cloud remote using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
 
@@ -55,15 +58,23 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
+OCI registry remote urls should be of the form {{.EmphasisLeft}}oci+https://registry/repository[:tag]{{.EmphasisRight}}, such as oci+https://ghcr.io/org/database. The database is stored as an artifact under the tag, which defaults to latest. Credentials are read from the DOLT_OCI_REGISTRY_USER and DOLT_OCI_REGISTRY_PASSWORD environment variables, or from those saved by docker login.
+
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+Connections to http and https remotes go through the proxy given by the HTTPS_PROXY and HTTP_PROXY environment variables, except for hosts listed in NO_PROXY. Additional trusted CA certificates and the minimum TLS version of these connections are configured with {{.EmphasisLeft}}remotes.ca_bundle{{.EmphasisRight}} and {{.EmphasisLeft}}remotes.tls_min_version{{.EmphasisRight}}. See {{.EmphasisLeft}}dolt config{{.EmphasisRight}}.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
-Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
+Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.
+
+{{.EmphasisLeft}}check{{.EmphasisRight}}
+Checks the remote named {{.LessThan}}name{{.GreaterThan}}, or every remote when no name is given. Each remote is checked for connectivity, valid credentials, a storage format matching the local database, and support for fetch and push. Its round trip time and download bandwidth are measured, along with the download protocol version of remotesapi servers. With {{.EmphasisLeft}}--format json{{.EmphasisRight}}, the results are printed as a JSON array for monitoring. The exit code is 1 if any remote fails a check.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"check [--format json] [{{.LessThan}}name{{.GreaterThan}}]",
 	},
 }
 
@@ -71,6 +82,7 @@ const (
 	addRemoteId         = "add"
 	removeRemoteId      = "remove"
 	removeRemoteShortId = "rm"
+	checkRemoteId       = "check"
 )
 
 type RemoteCmd struct{}
@@ -101,6 +113,8 @@ func (cmd RemoteCmd) ArgParser() *argparser.ArgParser {
 
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
+
+	ap.SupportsString(OutputFormatFlag, "", "format", "Print the results of remote check as a JSON array on stdout. Valid values are json.")
 	return ap
 }
 
@@ -115,6 +129,10 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, remoteDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
+	if apr.NArg() > 0 && apr.Arg(0) == checkRemoteId {
+		return checkRemotes(ctx, dEnv, apr, usage)
+	}
+
 	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -324,3 +342,75 @@ func printRemotes(sqlCtx *sql.Context, queryist cli.Queryist, apr *argparser.Arg
 
 	return nil
 }
+
+func checkRemotes(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	jsonOut, verr := JsonOutputRequested(apr)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if apr.NArg() > 2 {
+		return HandleVErrAndExitCode(errhand.BuildDError("").SetPrintUsage().Build(), usage)
+	}
+
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("Unable to get remotes from the local directory").AddCause(err).Build(), usage)
+	}
+	var toCheck []env.Remote
+	if apr.NArg() == 2 {
+		r, ok := remotes.Get(apr.Arg(1))
+		if !ok {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: unknown remote: '%s'", apr.Arg(1)).Build(), usage)
+		}
+		toCheck = append(toCheck, r)
+	} else {
+		remotes.Iter(func(_ string, r env.Remote) bool {
+			toCheck = append(toCheck, r)
+			return true
+		})
+		sort.Slice(toCheck, func(i, j int) bool {
+			return toCheck[i].Name < toCheck[j].Name
+		})
+	}
+
+	nbf := dEnv.DoltDB.Format()
+	results := make([]actions.RemoteCheck, 0, len(toCheck))
+	exitCode := 0
+	for _, r := range toCheck {
+		rc := actions.CheckRemote(ctx, r, nbf, dEnv)
+		if !rc.OK() {
+			exitCode = 1
+		}
+		if !jsonOut {
+			printRemoteCheck(rc)
+		}
+		results = append(results, rc)
+	}
+	if jsonOut {
+		PrintJsonResult(results)
+	}
+	return exitCode
+}
+
+func printRemoteCheck(rc actions.RemoteCheck) {
+	cli.Printf("%s: %s\n", rc.Name, rc.Status)
+	cli.Printf("\turl: %s\n", rc.Url)
+	if rc.Error != "" {
+		cli.Printf("\terror: %s\n", rc.Error)
+	}
+	if rc.Status == actions.RemoteCheckUnreachable || rc.Status == actions.RemoteCheckUnauthorized {
+		return
+	}
+	cli.Printf("\tformat: %s\n", rc.Format)
+	if rc.ProtocolVersion > 0 {
+		cli.Printf("\tprotocol version: %d\n", rc.ProtocolVersion)
+	}
+	cli.Printf("\tround trip: %.1fms\n", rc.RoundTripMillis)
+	if rc.BytesPerSecond > 0 {
+		cli.Printf("\tbandwidth: %s/s\n", humanize.Bytes(uint64(rc.BytesPerSecond)))
+	}
+	cli.Printf("\tcapabilities: %s\n", strings.Join(rc.Capabilities, ", "))
+	if len(rc.MissingCapabilities) > 0 {
+		cli.Printf("\tmissing capabilities: %s\n", strings.Join(rc.MissingCapabilities, ", "))
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/gocraft/dbr/v2/dialect"
+	"gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/util/outputpager"
@@ -37,12 +39,23 @@ var revertDocs = cli.CommandDocumentationContent{
 		"{{.EmphasisLeft}}HEAD~1..HEAD~2{{.EmphasisRight}}, giving us a patch of what to remove to effectively remove the " +
 		"influence of the specified commit. If multiple commits are specified, then this process is repeated for each " +
 		"commit in the order specified. This requires a clean working set." +
-		"\n\nAny conflicts or constraint violations caused by the merge cause the command to fail.",
+		"\n\nIf reverting a single commit causes conflicts or constraint violations, the revert is left in progress. " +
+		"Resolve them, then run {{.EmphasisLeft}}dolt revert --continue{{.EmphasisRight}} to commit the revert, or run " +
+		"{{.EmphasisLeft}}dolt revert --abort{{.EmphasisRight}} to return to the state before the revert. Conflicts or " +
+		"constraint violations caused by reverting multiple commits at once cause the command to fail.",
 	Synopsis: []string{
 		"<revision>...",
+		"--continue",
+		"--abort",
 	},
 }
 
+var ErrRevertConflictsOrViolations = errors.NewKind("error: Unable to revert commit cleanly due to conflicts " +
+	"or constraint violations. Please resolve the conflicts and/or constraint violations, then use " +
+	"`dolt revert --continue` to commit the revert. \n" +
+	"To undo all changes from this revert operation, use `dolt revert --abort`.\n" +
+	"For more information on handling conflicts, see: https://docs.dolthub.com/concepts/dolt/git/conflicts")
+
 type RevertCmd struct{}
 
 var _ cli.Command = RevertCmd{}
@@ -81,7 +94,12 @@ func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
-	if apr.NArg() < 1 {
+	if apr.Contains(cli.AbortParam) || apr.Contains(cli.ContinueFlag) {
+		if apr.NArg() > 0 {
+			usage()
+			return 1
+		}
+	} else if apr.NArg() < 1 {
 		usage()
 		return 1
 	}
@@ -107,11 +125,31 @@ func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string,
 		author = fmt.Sprintf("%s <%s>", name, email)
 	}
 
+	if apr.Contains(cli.AbortParam) {
+		_, err = GetRowsForSql(queryist, sqlCtx, "CALL DOLT_REVERT('--abort')")
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	// An in-progress revert leaves its conflicts in the working set, which must be allowed to be persisted
+	_, err = GetRowsForSql(queryist, sqlCtx, "set @@dolt_allow_commit_conflicts = 1")
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+	_, err = GetRowsForSql(queryist, sqlCtx, "set @@dolt_force_transaction_commit = 1")
+	if err != nil {
+		cli.Println(err.Error())
+		return 1
+	}
+
 	var params []interface{}
 	params = append(params, author)
 
 	var buffer bytes.Buffer
 	buffer.WriteString("CALL DOLT_REVERT('--author', ?")
+	if apr.Contains(cli.ContinueFlag) {
+		buffer.WriteString(", '--continue'")
+	}
 	// Loop over args and add them to the query
 	for _, input := range apr.Args {
 		buffer.WriteString(", ?")
@@ -129,11 +167,21 @@ func (cmd RevertCmd) Exec(ctx context.Context, commandStr string, args []string,
 		cli.Printf("Failure to execute '%s': %s\n", query, err.Error())
 		return 1
 	}
-	_, err = sql.RowIterToRows(sqlCtx, rowIter)
+	rows, err := sql.RowIterToRows(sqlCtx, rowIter)
 	if err != nil {
 		cli.Println(err.Error())
 		return 1
 	}
+	if len(rows) == 1 {
+		status, err := getInt64ColAsInt64(rows[0][0])
+		if err != nil {
+			cli.Println(err.Error())
+			return 1
+		}
+		if status != 0 {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(ErrRevertConflictsOrViolations.New()), usage)
+		}
+	}
 
 	commit, err := getCommitInfo(queryist, sqlCtx, "HEAD")
 	if err != nil {
@@ -92,14 +92,12 @@ func (cmd SendMetricsCmd) Exec(ctx context.Context, commandStr string, args []st
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{ShortDesc: sendMetricsShortDesc}, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
-	metricsDisabled := dEnv.Config.GetStringOrDefault(config.MetricsDisabled, "false")
-
-	disabled, err := strconv.ParseBool(metricsDisabled)
+	categories, err := events.EnabledCategories(dEnv.Config)
 	if err != nil {
 		return 1
 	}
 
-	if disabled {
+	if categories.Empty() {
 		cli.Println(color.CyanString("Sending metrics is currently disabled\n"))
 		return 0
 	}
@@ -113,7 +111,7 @@ func (cmd SendMetricsCmd) Exec(ctx context.Context, commandStr string, args []st
 	}
 
 	output := apr.GetValueOrDefault(EventsOutputFormat, events.EmitterTypeGrpc)
-	err = FlushLoggedEvents(ctx, dEnv, userHomeDir, output)
+	err = FlushLoggedEvents(ctx, dEnv, userHomeDir, output, categories)
 
 	if err != nil {
 		cli.PrintErrf("Error flushing events: %s\n", err.Error())
@@ -128,14 +126,15 @@ func (cmd SendMetricsCmd) Exec(ctx context.Context, commandStr string, args []st
 	return 0
 }
 
-// FlushLoggedEvents flushes any logged events in the directory given to an appropriate event emitter
-func FlushLoggedEvents(ctx context.Context, dEnv *env.DoltEnv, userHomeDir string, outputType string) error {
+// FlushLoggedEvents flushes any logged events in the directory given to an appropriate event emitter. Only the metrics
+// in |categories| are emitted; metrics logged before their category was disabled are dropped.
+func FlushLoggedEvents(ctx context.Context, dEnv *env.DoltEnv, userHomeDir string, outputType string, categories events.CategorySet) error {
 	emitter, closer, err := NewEmitter(outputType, dEnv)
 	if err != nil {
 		return err
 	}
 	defer closer()
-	flusher := events.NewFileFlusher(dEnv.FS, userHomeDir, dbfactory.DoltDir, emitter)
+	flusher := events.NewFileFlusher(dEnv.FS, userHomeDir, dbfactory.DoltDir, events.NewFilteringEmitter(emitter, categories))
 	return flusher.Flush(ctx)
 }
 
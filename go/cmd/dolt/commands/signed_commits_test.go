@@ -111,6 +111,13 @@ func TestSignAndVerifyCommit(t *testing.T) {
 }
 
 func execCommand(ctx context.Context, wd string, cmd cli.Command, args []string, apr *argparser.ArgParseResults, local, global map[string]string) (output string, err error) {
+	initialWd, err := os.Getwd()
+	if err != nil {
+		err = fmt.Errorf("error getting working directory: %w", err)
+		return
+	}
+	defer os.Chdir(initialWd)
+
 	err = os.Chdir(wd)
 	if err != nil {
 		err = fmt.Errorf("error changing directory to %s: %w", wd, err)
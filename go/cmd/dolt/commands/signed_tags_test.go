@@ -0,0 +1,112 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/gpg"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+func TestSignAndVerifyTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tagArgs   []string
+		expectErr bool
+		signed    bool
+	}{
+		{
+			name:    "sign tag with command line key id",
+			tagArgs: []string{"-u", keyId, "-m", "signed release", "v1"},
+			signed:  true,
+		},
+		{
+			name:    "annotated tag",
+			tagArgs: []string{"-a", "-m", "annotated release", "v2"},
+		},
+		{
+			name:      "sign no key id, no keyid in config",
+			tagArgs:   []string{"-s", "-m", "signed release", "v3"},
+			expectErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	importKey(t, ctx)
+	verifyTagSignatureContent(t, ctx)
+	dbDir := setupTestDB(t, ctx, filesys.LocalFS)
+
+	global := map[string]string{
+		"user.name":  "First Last",
+		"user.email": "test@dolthub.com",
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			apr, err := cli.CreateTagArgParser().Parse(test.tagArgs)
+			require.NoError(t, err)
+
+			_, err = execCommand(ctx, dbDir, TagCmd{}, test.tagArgs, apr, map[string]string{}, global)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			} else {
+				require.NoError(t, err)
+			}
+
+			args := []string{"--verify", test.tagArgs[len(test.tagArgs)-1]}
+			apr, err = cli.CreateTagArgParser().Parse(args)
+			require.NoError(t, err)
+
+			_, err = execCommand(ctx, dbDir, TagCmd{}, args, apr, map[string]string{}, global)
+			if test.signed {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+// verifyTagSignatureContent checks that the signature of a tag only verifies for the tag which was signed.
+func verifyTagSignatureContent(t *testing.T, ctx context.Context) {
+	const commitHash = "9j41r0jkm689o94icmf4t6tjd4lko6a4"
+	meta := datas.NewTagMeta("First Last", "test@dolthub.com", "signed release  \nsecond line")
+	signature, err := gpg.Sign(ctx, keyId, []byte(actions.TagSignaturePayload("v1", commitHash, meta)))
+	require.NoError(t, err)
+	meta.Signature = string(signature)
+
+	_, err = actions.VerifyTagSignature(ctx, "v1", commitHash, meta)
+	require.NoError(t, err)
+
+	// a signature can't be moved to another tag, or be kept when the tag is changed
+	_, err = actions.VerifyTagSignature(ctx, "v2", commitHash, meta)
+	require.Error(t, err)
+	changed := *meta
+	changed.Description = "another release"
+	_, err = actions.VerifyTagSignature(ctx, "v1", commitHash, &changed)
+	require.Error(t, err)
+
+	meta.Signature = ""
+	_, err = actions.VerifyTagSignature(ctx, "v1", commitHash, meta)
+	require.ErrorIs(t, err, actions.ErrTagNotSigned)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"crypto/x509"
+
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// checkClientCert returns an access denied error unless |user|, authenticated on a connection which presented the
+// verified client certificates |certs|, may use the connection under |config|. A connection whose certificate's
+// subject common name maps to its user is always allowed, so that mapped users can be created without a password.
+// Otherwise, the connection is allowed only if client certificates aren't required and its user isn't a mapped user,
+// which must always connect with its certificate.
+func checkClientCert(config *servercfg.ClientCertConfig, user string, certs []*x509.Certificate) error {
+	// the TLS handshake verified the leaf certificate against the configured certificate authorities
+	if len(certs) > 0 {
+		cn := certs[0].Subject.CommonName
+		if mapped, ok := config.Users[cn]; ok && cn != "" && mapped == user {
+			return nil
+		}
+	}
+
+	if config.Required {
+		return mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError,
+			"Access denied for user '%v': a client certificate mapped to the user is required", user)
+	}
+	for _, mapped := range config.Users {
+		if mapped == user {
+			return mysql.NewSQLError(mysql.ERAccessDeniedError, mysql.SSAccessDeniedError,
+				"Access denied for user '%v': the user must connect with its client certificate", user)
+		}
+	}
+	return nil
+}
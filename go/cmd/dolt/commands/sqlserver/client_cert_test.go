@@ -0,0 +1,47 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestCheckClientCert(t *testing.T) {
+	certFor := func(cn string) []*x509.Certificate {
+		return []*x509.Certificate{{Subject: pkix.Name{CommonName: cn}}}
+	}
+	config := &servercfg.ClientCertConfig{
+		Users: map[string]string{"orders.mesh.local": "orders"},
+	}
+
+	assert.NoError(t, checkClientCert(config, "orders", certFor("orders.mesh.local")))
+	assert.Error(t, checkClientCert(config, "orders", nil))
+	assert.Error(t, checkClientCert(config, "orders", certFor("billing.mesh.local")))
+	assert.NoError(t, checkClientCert(config, "root", certFor("orders.mesh.local")))
+	assert.NoError(t, checkClientCert(config, "root", nil))
+	assert.NoError(t, checkClientCert(config, "root", certFor("billing.mesh.local")))
+
+	config.Required = true
+	assert.NoError(t, checkClientCert(config, "orders", certFor("orders.mesh.local")))
+	assert.Error(t, checkClientCert(config, "root", nil))
+	assert.Error(t, checkClientCert(config, "root", certFor("billing.mesh.local")))
+	assert.Error(t, checkClientCert(config, "root", certFor("orders.mesh.local")))
+}
@@ -260,6 +260,12 @@ func (cfg *commandLineServerConfig) DisableClientMultiStatements() bool {
 	return false
 }
 
+// AutoCommitConflictRetries is the number of times the server reruns autocommit statements which fail with a
+// transaction conflict.
+func (cfg *commandLineServerConfig) AutoCommitConflictRetries() int {
+	return servercfg.DefaultAutoCommitConflictRetries
+}
+
 // MetricsLabels returns labels that are applied to all prometheus metrics
 func (cfg *commandLineServerConfig) MetricsLabels() map[string]string {
 	return nil
@@ -273,6 +279,10 @@ func (cfg *commandLineServerConfig) MetricsPort() int {
 	return servercfg.DefaultMetricsPort
 }
 
+func (cfg *commandLineServerConfig) MetricsPprof() bool {
+	return servercfg.DefaultMetricsPprof
+}
+
 func (cfg *commandLineServerConfig) RemotesapiPort() *int {
 	return cfg.remotesapiPort
 }
@@ -291,6 +301,12 @@ func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
 	return cfg.privilegeFilePath
 }
 
+// PrivilegeDatabase returns the name of the database which stores users and grants. Servers configured on the command
+// line store them in the privilege file.
+func (cfg *commandLineServerConfig) PrivilegeDatabase() string {
+	return ""
+}
+
 // BranchControlFilePath returns the path to the file which contains the branch control permissions.
 func (cfg *commandLineServerConfig) BranchControlFilePath() string {
 	return cfg.branchControlFilePath
@@ -309,6 +325,51 @@ func (cfg *commandLineServerConfig) JwksConfig() []servercfg.JwksConfig {
 	return nil
 }
 
+// UserLimits returns nil, since the limits of users can only be configured in a config file.
+func (cfg *commandLineServerConfig) UserLimits() []servercfg.UserLimits {
+	return nil
+}
+
+// RemoteDatabases returns nil, since databases served from remotes can only be configured in a config file.
+func (cfg *commandLineServerConfig) RemoteDatabases() []servercfg.RemoteDatabaseConfig {
+	return nil
+}
+
+// UserDefinedFunctions returns nil, since user-defined functions can only be configured in a config file.
+func (cfg *commandLineServerConfig) UserDefinedFunctions() []servercfg.UserDefinedFunctionConfig {
+	return nil
+}
+
+// EdgeSync returns nil, since edge sync mode can only be configured in a config file.
+func (cfg *commandLineServerConfig) EdgeSync() *servercfg.EdgeSyncConfig {
+	return nil
+}
+
+// ScheduledPulls returns nil, since scheduled pulls can only be configured in a config file.
+func (cfg *commandLineServerConfig) ScheduledPulls() []servercfg.ScheduledPullConfig {
+	return nil
+}
+
+// ExportJobs returns nil, since what export jobs can write to and notify can only be configured in a config file.
+func (cfg *commandLineServerConfig) ExportJobs() *servercfg.ExportJobsConfig {
+	return nil
+}
+
+// ClientCertConfig returns nil, since client certificate authentication can only be configured in a config file.
+func (cfg *commandLineServerConfig) ClientCertConfig() *servercfg.ClientCertConfig {
+	return nil
+}
+
+// LDAPConfig returns nil, since LDAP authentication can only be configured in a config file.
+func (cfg *commandLineServerConfig) LDAPConfig() *servercfg.LDAPConfig {
+	return nil
+}
+
+// OIDCConfig returns nil, since OpenID Connect authentication can only be configured in a config file.
+func (cfg *commandLineServerConfig) OIDCConfig() *servercfg.OIDCConfig {
+	return nil
+}
+
 func (cfg *commandLineServerConfig) AllowCleartextPasswords() bool {
 	return cfg.allowCleartextPasswords
 }
@@ -0,0 +1,122 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+)
+
+// conflictRetryingHandler is a server handler which reruns statements whose transactions fail to commit because they
+// conflict with a transaction which committed concurrently, up to |retries| times, rather than returning the error
+// to the client. Only statements run in autocommit mode outside of an explicit transaction are rerun, since they are
+// the whole of their transaction, and only if no results have been sent to the client.
+//
+// It embeds the server's handler, rather than a mysql.Handler, so that the optional interfaces it implements are kept.
+type conflictRetryingHandler struct {
+	*server.Handler
+	retries int
+}
+
+// newConflictRetryingHandler returns a server.HandlerWrapper which wraps the server's handler in a
+// conflictRetryingHandler which retries statements |retries| times.
+func newConflictRetryingHandler(retries int) server.HandlerWrapper {
+	return func(h mysql.Handler) (mysql.Handler, error) {
+		sh, ok := h.(*server.Handler)
+		if !ok {
+			return nil, fmt.Errorf("cannot retry conflicting statements with handler of type %T", h)
+		}
+		return conflictRetryingHandler{Handler: sh, retries: retries}, nil
+	}
+}
+
+// ComQuery implements mysql.Handler
+func (h conflictRetryingHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	_, err := h.retry(ctx, c, callback, func(callback mysql.ResultSpoolFn) (string, error) {
+		return "", h.Handler.ComQuery(ctx, c, query, callback)
+	})
+	return err
+}
+
+// ComMultiQuery implements mysql.Handler
+func (h conflictRetryingHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	return h.retry(ctx, c, callback, func(callback mysql.ResultSpoolFn) (string, error) {
+		return h.Handler.ComMultiQuery(ctx, c, query, callback)
+	})
+}
+
+// ComParsedQuery implements mysql.ExtendedHandler
+func (h conflictRetryingHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	_, err := h.retry(ctx, c, callback, func(callback mysql.ResultSpoolFn) (string, error) {
+		return "", h.Handler.ComParsedQuery(ctx, c, query, parsed, callback)
+	})
+	return err
+}
+
+// retry runs |run|, which runs a statement, and reruns it while it fails with a transaction conflict, if the
+// statement is its own transaction and hasn't sent any results to |callback|.
+func (h conflictRetryingHandler) retry(ctx context.Context, c *mysql.Conn, callback mysql.ResultSpoolFn, run func(mysql.ResultSpoolFn) (string, error)) (string, error) {
+	retriable, err := h.startsOwnTransaction(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	sent := false
+	spool := func(res *sqltypes.Result, more bool) error {
+		sent = true
+		return callback(res, more)
+	}
+
+	for attempt := 0; ; attempt++ {
+		remainder, err := run(spool)
+		if err == nil || !retriable || sent || attempt >= h.retries || !isTransactionConflict(err) {
+			return remainder, err
+		}
+		logrus.WithField(sql.ConnectionIdLogField, c.ConnectionID).WithError(err).Debugf("retrying statement after transaction conflict, attempt %d", attempt+1)
+	}
+}
+
+// startsOwnTransaction returns whether the session of |c| is in autocommit mode and not in a transaction, so the
+// next statement it runs is its own transaction.
+func (h conflictRetryingHandler) startsOwnTransaction(ctx context.Context, c *mysql.Conn) (bool, error) {
+	sqlCtx, err := h.Handler.NewContext(ctx, c, "")
+	if err != nil {
+		return false, err
+	}
+	defer sqlCtx.RootSpan().End()
+	if sqlCtx.GetTransaction() != nil {
+		return false, nil
+	}
+	autocommit, err := sqlCtx.GetSessionVariable(sqlCtx, sql.AutoCommitSessionVar)
+	if err != nil {
+		return false, err
+	}
+	return sql.ConvertToBool(sqlCtx, autocommit)
+}
+
+// isTransactionConflict returns whether |err| is the error with which a transaction fails to commit because of a
+// concurrent transaction, after which the transaction has been rolled back and may be retried.
+func isTransactionConflict(err error) bool {
+	var sqlErr *mysql.SQLError
+	return errors.As(err, &sqlErr) && sqlErr.Number() == mysql.ERLockDeadlock
+}
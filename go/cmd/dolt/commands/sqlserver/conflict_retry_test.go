@@ -0,0 +1,130 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+)
+
+type conflictRetriesConfig struct {
+	servercfg.ServerConfig
+	retries int
+}
+
+func (cfg conflictRetriesConfig) AutoCommitConflictRetries() int {
+	return cfg.retries
+}
+
+// TestAutoCommitConflictRetries makes an autocommit statement fail with a transaction conflict by having it wait for
+// a row lock held by a transaction which then commits a change to a row the statement wrote already, having written
+// it without locking it, and checks that the server reruns the statement when configured to.
+func TestAutoCommitConflictRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		retries  int
+		port     int
+		expected int
+	}{
+		{name: "no retries", retries: 0, port: 15320, expected: 2},
+		{name: "retries", retries: 3, port: 15321, expected: 12},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dEnv, err := sqle.CreateEnvWithSeedData()
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, dEnv.DoltDB.Close())
+			}()
+
+			serverConfig := conflictRetriesConfig{
+				ServerConfig: DefaultCommandLineServerConfig().withLogLevel(servercfg.LogLevel_Fatal).WithPort(test.port),
+				retries:      test.retries,
+			}
+			sc := svcs.NewController()
+			defer sc.Stop()
+			go func() {
+				_, _ = Serve(context.Background(), "0.0.0", serverConfig, sc, dEnv)
+			}()
+			require.NoError(t, sc.WaitForStart())
+
+			db, err := dbr.Open("mysql", servercfg.ConnectionString(serverConfig, "dolt"), nil)
+			require.NoError(t, err)
+			defer db.Close()
+
+			ctx := context.Background()
+			_, err = db.ExecContext(ctx, "create table t (pk int primary key, v int)")
+			require.NoError(t, err)
+			_, err = db.ExecContext(ctx, "insert into t values (1, 1), (2, 1)")
+			require.NoError(t, err)
+
+			a, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer a.Close()
+			b, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer b.Close()
+
+			_, err = a.ExecContext(ctx, "set @@dolt_pessimistic_locking = 1")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "start transaction")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "update t set v = 2 where pk = 1")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "set @@dolt_pessimistic_locking = 1")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "update t set v = 2 where pk = 2")
+			require.NoError(t, err)
+
+			updated := make(chan error)
+			go func() {
+				_, err := a.ExecContext(ctx, "update t set v = v + 10 where pk in (1, 2)")
+				updated <- err
+			}()
+
+			require.Eventually(t, func() bool {
+				var waiting int
+				err := db.QueryRowContext(ctx, "select count(*) from information_schema.processlist where info like 'update t set v = v + 10%'").Scan(&waiting)
+				return err == nil && waiting == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			time.Sleep(100 * time.Millisecond)
+			_, err = b.ExecContext(ctx, "commit")
+			require.NoError(t, err)
+
+			err = <-updated
+			if test.retries == 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "1213")
+			} else {
+				require.NoError(t, err)
+			}
+
+			var v int
+			require.NoError(t, db.QueryRowContext(ctx, "select v from t where pk = 1").Scan(&v))
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+)
+
+// pprofAuth serves the pprof endpoints to SQL users that have been granted SUPER. Users authenticate with
+// HTTP basic auth, using the same credentials as for the SQL server. The metrics listener serves plain HTTP, which
+// would send those credentials in the clear, so requests without TLS are only served to clients on the loopback
+// interface.
+type pprofAuth struct {
+	// ctxFactory is a function that returns a new sql.Context. It is called once per request.
+	ctxFactory func(context.Context) (*sql.Context, error)
+	rawDb      *mysql_db.MySQLDb
+	handler    http.Handler
+}
+
+var _ http.Handler = (*pprofAuth)(nil)
+
+// newPprofHandler returns a handler for the net/http/pprof endpoints under /debug/pprof/.
+func newPprofHandler(ctxFactory func(context.Context) (*sql.Context, error), rawDb *mysql_db.MySQLDb) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return &pprofAuth{ctxFactory: ctxFactory, rawDb: rawDb, handler: mux}
+}
+
+// ServeHTTP implements http.Handler.
+func (p *pprofAuth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	address := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+	if r.TLS == nil && !isLoopback(address) {
+		http.Error(w, "pprof is only served to local clients without TLS", http.StatusForbidden)
+		return
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="dolt"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+	if err := commands.ValidatePasswordWithAuthResponse(p.rawDb, user, password); err != nil {
+		w.Header().Set("WWW-Authenticate", `Basic realm="dolt"`)
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+
+	sqlCtx, err := p.ctxFactory(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sqlCtx.Session.SetClient(sql.Client{User: user, Address: address, Capabilities: 0})
+
+	privOp := sql.NewPrivilegedOperation(sql.PrivilegeCheckSubject{}, sql.PrivilegeType_Super)
+	if !p.rawDb.UserHasPrivileges(sqlCtx, privOp) {
+		http.Error(w, user+" has not been granted SUPER access", http.StatusForbidden)
+		return
+	}
+
+	p.handler.ServeHTTP(w, r)
+}
+
+// isLoopback returns whether |host| is a loopback address.
+func isLoopback(host string) bool {
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPprofHandlerAuth(t *testing.T) {
+	rawDb := mysql_db.CreateEmptyMySQLDb()
+	ed := rawDb.Editor()
+	rawDb.AddSuperUser(ed, "root", "localhost", "pass")
+	rawDb.AddSuperUser(ed, "bob", "localhost", "pass")
+	bob, ok := ed.GetUser(mysql_db.UserPrimaryKey{Host: "localhost", User: "bob"})
+	require.True(t, ok)
+	bob.PrivilegeSet = mysql_db.NewPrivilegeSet()
+	bob.IsSuperUser = false
+	ed.PutUser(bob)
+	ed.Close()
+
+	ctxFactory := func(ctx context.Context) (*sql.Context, error) {
+		return sql.NewContext(ctx, sql.WithSession(sql.NewBaseSession())), nil
+	}
+	handler := newPprofHandler(ctxFactory, rawDb)
+
+	remoteAddr := "127.0.0.1:50000"
+	get := func(user, password string) int {
+		req := httptest.NewRequest(http.MethodGet, "/debug/pprof/cmdline", nil)
+		req.RemoteAddr = remoteAddr
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, get("", ""))
+	assert.Equal(t, http.StatusUnauthorized, get("root", "wrong"))
+	assert.Equal(t, http.StatusUnauthorized, get("nobody", "pass"))
+	assert.Equal(t, http.StatusForbidden, get("bob", "pass"))
+	assert.Equal(t, http.StatusOK, get("root", "pass"))
+
+	// remote clients aren't served without TLS, so their credentials aren't sent in the clear
+	remoteAddr = "10.0.0.1:50000"
+	assert.Equal(t, http.StatusForbidden, get("root", "pass"))
+	remoteAddr = "[::1]:50000"
+	assert.Equal(t, http.StatusOK, get("root", "pass"))
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// reloadableProvider is the interface of the database provider used to reload the replication config of its
+// databases and to load the databases added to its data directory.
+type reloadableProvider interface {
+	ReloadReplicationConfig(ctx *sql.Context) error
+	LoadNewDatabases(ctx *sql.Context) ([]string, error)
+}
+
+// configReloader re-reads the config file of a running server and applies the settings which can change without
+// restarting the server and dropping its connections: the log level, the system variables, which include the
+// replication settings, the user limits, and the databases of the data directory.
+type configReloader struct {
+	// load reads the server's config file
+	load    func() (servercfg.ServerConfig, error)
+	limiter *userLimiter
+
+	mu      sync.Mutex
+	current servercfg.ServerConfig
+}
+
+// reload re-reads the server's config file and applies it. If the new config can't be read or is invalid, none of it
+// is applied.
+func (r *configReloader) reload(ctx *sql.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cfg, err := r.load()
+	if err != nil {
+		return fmt.Errorf("bad configuration: %w", err)
+	}
+	if err = servercfg.ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("bad configuration: %w", err)
+	}
+	level, err := logrus.ParseLevel(cfg.LogLevel().String())
+	if err != nil {
+		return err
+	}
+
+	logrus.SetLevel(level)
+	if err = sql.SystemVariables.SetGlobal(dsess.DoltLogLevel, level.String()); err != nil {
+		return err
+	}
+	if vars := cfg.SystemVars(); vars != nil {
+		if err = sql.SystemVariables.AssignValues(vars); err != nil {
+			return err
+		}
+	}
+	if err = servercfg.ApplySystemVariables(cfg, sql.SystemVariables); err != nil {
+		return err
+	}
+	if r.limiter != nil {
+		r.limiter.setLimits(cfg.UserLimits())
+	}
+
+	if pro, ok := dsess.DSessFromSess(ctx.Session).Provider().(reloadableProvider); ok {
+		if err = pro.ReloadReplicationConfig(ctx); err != nil {
+			return err
+		}
+		loaded, err := pro.LoadNewDatabases(ctx)
+		if err != nil {
+			return err
+		}
+		if len(loaded) > 0 {
+			logrus.Infof("loaded new databases: %s", strings.Join(loaded, ", "))
+		}
+	}
+
+	if changed := restartRequiredChanges(r.current, cfg); len(changed) > 0 {
+		logrus.Warnf("server must be restarted to apply the changes to: %s", strings.Join(changed, ", "))
+	}
+	r.current = cfg
+	logrus.Info("reloaded server config")
+	return nil
+}
+
+// restartRequiredChanges returns the config settings changed from |prev| to |cfg| which are only applied when the
+// server starts.
+func restartRequiredChanges(prev, cfg servercfg.ServerConfig) []string {
+	var changed []string
+	if prev.Host() != cfg.Host() {
+		changed = append(changed, "listener.host")
+	}
+	if prev.Port() != cfg.Port() {
+		changed = append(changed, "listener.port")
+	}
+	if prev.Socket() != cfg.Socket() {
+		changed = append(changed, "listener.socket")
+	}
+	if prev.MaxConnections() != cfg.MaxConnections() {
+		changed = append(changed, "listener.max_connections")
+	}
+	if prev.ReadTimeout() != cfg.ReadTimeout() {
+		changed = append(changed, "listener.read_timeout_millis")
+	}
+	if prev.WriteTimeout() != cfg.WriteTimeout() {
+		changed = append(changed, "listener.write_timeout_millis")
+	}
+	if prev.DataDir() != cfg.DataDir() {
+		changed = append(changed, "data_dir")
+	}
+	return changed
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestRestartRequiredChanges(t *testing.T) {
+	prev, err := servercfg.NewYamlConfig([]byte(`
+log_level: info
+listener:
+  host: 127.0.0.1
+  port: 3306
+  max_connections: 100
+`))
+	require.NoError(t, err)
+
+	cfg, err := servercfg.NewYamlConfig([]byte(`
+log_level: debug
+listener:
+  host: 127.0.0.1
+  port: 3307
+  max_connections: 100
+user_limits:
+  - name: root
+    max_rows_per_query: 10
+`))
+	require.NoError(t, err)
+
+	assert.Empty(t, restartRequiredChanges(prev, prev))
+	assert.Equal(t, []string{"listener.port"}, restartRequiredChanges(prev, cfg))
+}
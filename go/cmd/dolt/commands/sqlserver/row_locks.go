@@ -0,0 +1,87 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// rowLockSessions are the sessions of a server's connections, whose row locks are released when their connections
+// close, see dsess.DoltSession.LockRow. A session's transaction is never committed or rolled back once its connection
+// closes, so its locks would otherwise be held for as long as the server runs.
+type rowLockSessions struct {
+	mu       sync.Mutex
+	sessions map[uint32]*dsess.DoltSession
+}
+
+func newRowLockSessions() *rowLockSessions {
+	return &rowLockSessions{sessions: make(map[uint32]*dsess.DoltSession)}
+}
+
+// add records |sess| as the session of the connection |connID|, replacing the session it had before, if any.
+func (s *rowLockSessions) add(connID uint32, sess *dsess.DoltSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[connID] = sess
+}
+
+// release releases the row locks of the session of the connection |connID|, and forgets the session.
+func (s *rowLockSessions) release(connID uint32) {
+	s.mu.Lock()
+	sess, ok := s.sessions[connID]
+	delete(s.sessions, connID)
+	s.mu.Unlock()
+	if ok {
+		sess.ReleaseRowLocks()
+	}
+}
+
+// rowLockReleasingHandler is a server handler which releases the row locks of the sessions of connections which close
+// or reset.
+type rowLockReleasingHandler struct {
+	limitedHandler
+	sessions *rowLockSessions
+}
+
+// newRowLockReleasingHandler returns a server.HandlerWrapper which wraps the server's handler in a
+// rowLockReleasingHandler releasing the row locks of |sessions|.
+func newRowLockReleasingHandler(sessions *rowLockSessions) server.HandlerWrapper {
+	return func(h mysql.Handler) (mysql.Handler, error) {
+		lh, ok := h.(limitedHandler)
+		if !ok {
+			return nil, fmt.Errorf("cannot release row locks with handler of type %T", h)
+		}
+		return rowLockReleasingHandler{limitedHandler: lh, sessions: sessions}, nil
+	}
+}
+
+// ConnectionClosed implements mysql.Handler
+func (h rowLockReleasingHandler) ConnectionClosed(c *mysql.Conn) {
+	defer h.sessions.release(c.ConnectionID)
+	h.limitedHandler.ConnectionClosed(c)
+}
+
+// ComResetConnection implements mysql.Handler
+func (h rowLockReleasingHandler) ComResetConnection(c *mysql.Conn) error {
+	// the connection's session is replaced by a new one, which is added to |h.sessions| when it's built
+	h.sessions.release(c.ConnectionID)
+	return h.limitedHandler.ComResetConnection(c)
+}
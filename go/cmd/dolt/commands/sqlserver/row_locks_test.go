@@ -0,0 +1,134 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+)
+
+// TestRowLocks has a statement wait for a row lock held by another connection's transaction, and checks that it
+// proceeds once the transaction commits, or once the connection closes without ending it.
+func TestRowLocks(t *testing.T) {
+	tests := []struct {
+		name string
+		port int
+		// end ends the transaction of the connection holding the lock
+		end      func(t *testing.T, ctx context.Context, c *sql.Conn)
+		expected int
+	}{
+		{
+			name: "commit",
+			port: 15322,
+			end: func(t *testing.T, ctx context.Context, c *sql.Conn) {
+				_, err := c.ExecContext(ctx, "commit")
+				require.NoError(t, err)
+			},
+			expected: 12,
+		},
+		{
+			name: "disconnect",
+			port: 15323,
+			end: func(t *testing.T, ctx context.Context, c *sql.Conn) {
+				require.NoError(t, c.Raw(func(driverConn any) error {
+					// closes the connection, rather than returning it to the pool
+					return driverConn.(interface{ Close() error }).Close()
+				}))
+			},
+			expected: 11,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dEnv, err := sqle.CreateEnvWithSeedData()
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, dEnv.DoltDB.Close())
+			}()
+
+			serverConfig := DefaultCommandLineServerConfig().withLogLevel(servercfg.LogLevel_Fatal).WithPort(test.port)
+			sc := svcs.NewController()
+			defer sc.Stop()
+			go func() {
+				_, _ = Serve(context.Background(), "0.0.0", serverConfig, sc, dEnv)
+			}()
+			require.NoError(t, sc.WaitForStart())
+
+			db, err := dbr.Open("mysql", servercfg.ConnectionString(serverConfig, "dolt"), nil)
+			require.NoError(t, err)
+			defer db.Close()
+
+			ctx := context.Background()
+			_, err = db.ExecContext(ctx, "create table t (pk varchar(10) collate utf8mb4_0900_ai_ci primary key, v int)")
+			require.NoError(t, err)
+			_, err = db.ExecContext(ctx, "insert into t values ('a', 1)")
+			require.NoError(t, err)
+
+			a, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer a.Close()
+			b, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer b.Close()
+
+			_, err = a.ExecContext(ctx, "set @@dolt_pessimistic_locking = 1")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "set @@dolt_pessimistic_locking = 1")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "start transaction")
+			require.NoError(t, err)
+			_, err = b.ExecContext(ctx, "update t set v = v + 1 where pk = 'a'")
+			require.NoError(t, err)
+
+			// keys equal under the column's collation lock the same row
+			updated := make(chan error)
+			go func() {
+				_, err := a.ExecContext(ctx, "update t set v = v + 10 where pk = 'A'")
+				updated <- err
+			}()
+
+			require.Eventually(t, func() bool {
+				var waiting int
+				err := db.QueryRowContext(ctx, "select count(*) from information_schema.processlist where info like 'update t set v = v + 10%'").Scan(&waiting)
+				return err == nil && waiting == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			time.Sleep(100 * time.Millisecond)
+			test.end(t, ctx, b)
+
+			select {
+			case err = <-updated:
+				require.NoError(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("update still waiting for the row lock")
+			}
+
+			var v int
+			require.NoError(t, db.QueryRowContext(ctx, "select v from t where pk = 'a'").Scan(&v))
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
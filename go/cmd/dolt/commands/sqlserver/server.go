@@ -22,8 +22,11 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/eventscheduler"
@@ -53,10 +56,13 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/edgesync"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/exportjobs"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/scheduledpulls"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/events"
-	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+	"github.com/dolthub/dolt/go/store/nbs"
 )
 
 const (
@@ -80,13 +86,26 @@ func Serve(
 	serverConfig servercfg.ServerConfig,
 	controller *svcs.Controller,
 	dEnv *env.DoltEnv,
+) (startError error, closeError error) {
+	return serve(ctx, version, serverConfig, nil, controller, dEnv)
+}
+
+// serve starts a MySQL-compatible server like Serve, which reloads its config with |reloadConfig| when the server
+// receives a SIGHUP or dolt_reload_config() is called, unless it's nil.
+func serve(
+	ctx context.Context,
+	version string,
+	serverConfig servercfg.ServerConfig,
+	reloadConfig func() (servercfg.ServerConfig, error),
+	controller *svcs.Controller,
+	dEnv *env.DoltEnv,
 ) (startError error, closeError error) {
 	// Code is easier to work through if we assume that serverController is never nil
 	if controller == nil {
 		controller = svcs.NewController()
 	}
 
-	ConfigureServices(serverConfig, controller, version, dEnv)
+	configureServices(serverConfig, reloadConfig, controller, version, dEnv)
 
 	go controller.Start(ctx)
 	err := controller.WaitForStart()
@@ -101,6 +120,16 @@ func ConfigureServices(
 	controller *svcs.Controller,
 	version string,
 	dEnv *env.DoltEnv,
+) {
+	configureServices(serverConfig, nil, controller, version, dEnv)
+}
+
+func configureServices(
+	serverConfig servercfg.ServerConfig,
+	reloadConfig func() (servercfg.ServerConfig, error),
+	controller *svcs.Controller,
+	version string,
+	dEnv *env.DoltEnv,
 ) {
 	ValidateConfigStep := &svcs.AnonService{
 		InitF: func(context.Context) error {
@@ -238,6 +267,7 @@ func ConfigureServices(
 			config = &engine.SqlEngineConfig{
 				IsReadOnly:              serverConfig.ReadOnly(),
 				PrivFilePath:            serverConfig.PrivilegeFilePath(),
+				PrivilegeDatabase:       serverConfig.PrivilegeDatabase(),
 				BranchCtrlFilePath:      serverConfig.BranchControlFilePath(),
 				DoltCfgDirPath:          serverConfig.CfgDir(),
 				ServerUser:              serverConfig.User(),
@@ -246,6 +276,10 @@ func ConfigureServices(
 				Autocommit:              serverConfig.AutoCommit(),
 				DoltTransactionCommit:   serverConfig.DoltTransactionCommit(),
 				JwksConfig:              serverConfig.JwksConfig(),
+				LDAPConfig:              serverConfig.LDAPConfig(),
+				OIDCConfig:              serverConfig.OIDCConfig(),
+				RemoteDatabases:         serverConfig.RemoteDatabases(),
+				UserDefinedFunctions:    serverConfig.UserDefinedFunctions(),
 				SystemVariables:         serverConfig.SystemVars(),
 				ClusterController:       clusterController,
 				BinlogReplicaController: binlogreplication.DoltBinlogReplicaController,
@@ -297,6 +331,49 @@ func ConfigureServices(
 	}
 	controller.Register(InitSqlEngine)
 
+	// Switch the server to read only if a write fails because the disk is full, so that clients get a clean error
+	// instead of a string of failed commits.
+	var stopWatchingDiskFull func()
+	WatchDiskFull := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			stopWatchingDiskFull = nbs.OnDiskFull(func(err error) {
+				if sqlEngine.SetDiskFull() {
+					lgr.WithField("event", "disk_full").WithError(err).
+						Error("a write failed because the disk is full, the server is now read only. Free up disk space and restart the server to accept writes again")
+				}
+			})
+			return nil
+		},
+		StopF: func() error {
+			stopWatchingDiskFull()
+			return nil
+		},
+	}
+	controller.Register(WatchDiskFull)
+
+	// Run the export jobs configured in the dolt_export_jobs tables of the databases on their schedules. Jobs are read
+	// as the local superuser, and run as their definers.
+	var exportJobsCtx context.Context
+	var stopExportJobs context.CancelFunc
+	RunExportJobs := &svcs.AnonService{
+		InitF: func(ctx context.Context) error {
+			exportJobsCtx, stopExportJobs = context.WithCancel(ctx)
+			return nil
+		},
+		RunF: func(context.Context) {
+			var config exportjobs.Config
+			if ej := serverConfig.ExportJobs(); ej != nil {
+				config = exportjobs.Config{Directory: ej.Directory, NotificationURLs: ej.NotificationURLs}
+			}
+			exportjobs.NewScheduler(localUserEngine{sqlEngine}, config).Run(exportJobsCtx)
+		},
+		StopF: func() error {
+			stopExportJobs()
+			return nil
+		},
+	}
+	controller.Register(RunExportJobs)
+
 	// Persist any system variables that have a non-deterministic default value (i.e. @@server_uuid)
 	// We only do this on sql-server startup initially since we want to keep the persisted server_uuid
 	// in the configuration files for a sql-server, and not global for the whole host.
@@ -441,6 +518,75 @@ func ConfigureServices(
 	}
 	controller.Register(DisableMySQLDbIfRequired)
 
+	// In edge sync mode, make the device branch of each database its default branch and periodically sync it with
+	// the central branch of the remote. This runs as the local superuser, so it must come after it is created.
+	var edgeSyncCtx context.Context
+	var stopEdgeSync context.CancelFunc
+	var edgeSyncer *edgesync.Syncer
+	RunEdgeSync := &svcs.AnonService{
+		InitF: func(ctx context.Context) error {
+			es := serverConfig.EdgeSync()
+			if es == nil {
+				return nil
+			}
+			edgeSyncer = edgesync.NewSyncer(localUserEngine{sqlEngine}, edgesync.Config{
+				DeviceBranch:  es.DeviceBranch,
+				CentralBranch: es.CentralBranchName(),
+				Remote:        es.RemoteName(),
+				Interval:      es.Interval(),
+			})
+			edgeSyncCtx, stopEdgeSync = context.WithCancel(ctx)
+			return edgeSyncer.Prepare(edgeSyncCtx)
+		},
+		RunF: func(context.Context) {
+			if edgeSyncer != nil {
+				edgeSyncer.Run(edgeSyncCtx)
+			}
+		},
+		StopF: func() error {
+			if stopEdgeSync != nil {
+				stopEdgeSync()
+			}
+			return nil
+		},
+	}
+	controller.Register(RunEdgeSync)
+
+	// Periodically pull the branches of databases configured in scheduled_pulls from their remotes. This runs as the
+	// local superuser, so it must come after it is created.
+	var scheduledPullsCtx context.Context
+	var stopScheduledPulls context.CancelFunc
+	RunScheduledPulls := &svcs.AnonService{
+		InitF: func(ctx context.Context) error {
+			scheduledPullsCtx, stopScheduledPulls = context.WithCancel(ctx)
+			return nil
+		},
+		RunF: func(context.Context) {
+			wg := &sync.WaitGroup{}
+			for _, sp := range serverConfig.ScheduledPulls() {
+				puller := scheduledpulls.NewPuller(localUserEngine{sqlEngine}, scheduledpulls.Config{
+					Database:       sp.Database,
+					Remote:         sp.RemoteName(),
+					Branches:       sp.Branches,
+					Interval:       sp.Interval(),
+					ConflictPolicy: scheduledpulls.ConflictPolicy(sp.ConflictPolicyName()),
+					OnFailure:      sp.OnFailure,
+				})
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					puller.Run(scheduledPullsCtx)
+				}()
+			}
+			wg.Wait()
+		},
+		StopF: func() error {
+			stopScheduledPulls()
+			return nil
+		},
+	}
+	controller.Register(RunScheduledPulls)
+
 	type SQLMetricsService struct {
 		state svcs.ServiceState
 		lis   net.Listener
@@ -462,6 +608,12 @@ func ConfigureServices(
 
 				mux := http.NewServeMux()
 				mux.Handle("/metrics", promhttp.Handler())
+				if serverConfig.MetricsPprof() {
+					if host := serverConfig.MetricsHost(); host != "localhost" && !isLoopback(host) {
+						logrus.Warnf("pprof is only served to clients on the loopback interface, since the metrics listener on %s doesn't use TLS", addr)
+					}
+					mux.Handle("/debug/pprof/", newPprofHandler(sqlEngine.NewDefaultContext, sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb))
+				}
 				metSrv.srv = &http.Server{
 					Addr:    addr,
 					Handler: mux,
@@ -619,24 +771,51 @@ func ConfigureServices(
 
 	var sqlServerClosed bool
 	var mySQLServer *server.Server
+	var limiter *userLimiter
 	InitSQLServer := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
+			if reloadConfig != nil {
+				limiter = newReloadableUserLimiter(serverConfig.UserLimits())
+			} else {
+				limiter = newUserLimiter(serverConfig.UserLimits())
+			}
+			// the golden validating handler doesn't implement all the handler interfaces the row locks handler keeps
+			var rowLocks *rowLockSessions
 			v, ok := serverConfig.(servercfg.ValidatingServerConfig)
-			if ok && v.GoldenMysqlConnectionString() != "" {
+			validating := ok && v.GoldenMysqlConnectionString() != ""
+			if !validating {
+				rowLocks = newRowLockSessions()
+			}
+			sessionBuilder := newSessionBuilder(sqlEngine, serverConfig, limiter, rowLocks)
+
+			var wrappers []server.HandlerWrapper
+			if validating {
+				wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+					return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
+				})
+			} else {
+				if retries := serverConfig.AutoCommitConflictRetries(); retries > 0 {
+					wrappers = append(wrappers, newConflictRetryingHandler(retries))
+				}
+				wrappers = append(wrappers, newRowLockReleasingHandler(rowLocks))
+			}
+			if limiter != nil {
+				wrappers = append(wrappers, newUserLimitsHandler(limiter))
+			}
+
+			if len(wrappers) > 0 {
 				mySQLServer, err = server.NewServerWithHandler(
 					serverConf,
 					sqlEngine.GetUnderlyingEngine(),
-					newSessionBuilder(sqlEngine, serverConfig),
+					sessionBuilder,
 					metListener,
-					func(h mysql.Handler) (mysql.Handler, error) {
-						return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
-					},
+					chainHandlerWrappers(wrappers),
 				)
 			} else {
 				mySQLServer, err = server.NewServer(
 					serverConf,
 					sqlEngine.GetUnderlyingEngine(),
-					newSessionBuilder(sqlEngine, serverConfig),
+					sessionBuilder,
 					metListener,
 				)
 			}
@@ -656,6 +835,45 @@ func ConfigureServices(
 	}
 	controller.Register(InitSQLServer)
 
+	var sighup chan os.Signal
+	var reloader *configReloader
+	RunConfigReloader := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if reloadConfig == nil {
+				return nil
+			}
+			reloader = &configReloader{load: reloadConfig, limiter: limiter, current: serverConfig}
+			sqlserver.SetConfigReloader(reloader.reload)
+			sighup = make(chan os.Signal, 1)
+			signal.Notify(sighup, syscall.SIGHUP)
+			return nil
+		},
+		RunF: func(ctx context.Context) {
+			if sighup == nil {
+				return
+			}
+			for range sighup {
+				sqlCtx, err := sqlEngine.NewLocalContext(ctx)
+				if err == nil {
+					err = reloader.reload(sqlCtx)
+				}
+				if err != nil {
+					lgr.Errorf("failed to reload config: %v", err)
+				}
+			}
+		},
+		StopF: func() error {
+			if sighup == nil {
+				return nil
+			}
+			sqlserver.UnsetConfigReloader()
+			signal.Stop(sighup)
+			close(sighup)
+			return nil
+		},
+	}
+	controller.Register(RunConfigReloader)
+
 	// Automatically restart binlog replication if replication was enabled when the server was last shut down
 	AutoStartBinlogReplica := &svcs.AnonService{
 		InitF: func(ctx context.Context) error {
@@ -719,9 +937,9 @@ type heartbeatService struct {
 }
 
 func newHeartbeatService(version string, dEnv *env.DoltEnv) *heartbeatService {
-	metricsDisabled := dEnv.Config.GetStringOrDefault(config.MetricsDisabled, "false")
-	disabled, err := strconv.ParseBool(metricsDisabled)
-	if err != nil || disabled {
+	// the heartbeat is a usage event
+	categories, err := events.EnabledCategories(dEnv.Config)
+	if err != nil || !categories[events.CategoryUsage] {
 		return &heartbeatService{} // will be defunct on Run()
 	}
 
@@ -745,7 +963,7 @@ func newHeartbeatService(version string, dEnv *env.DoltEnv) *heartbeatService {
 		return &heartbeatService{} // will be defunct on Run()
 	}
 
-	events.SetGlobalCollector(events.NewCollector(version, emitter))
+	events.SetGlobalCollector(events.NewCollector(version, events.NewFilteringEmitter(emitter, categories)))
 
 	return &heartbeatService{
 		version:      version,
@@ -902,14 +1120,47 @@ func portInUse(hostPort string) bool {
 	return false
 }
 
-func newSessionBuilder(se *engine.SqlEngine, config servercfg.ServerConfig) server.SessionBuilder {
+// chainHandlerWrappers returns a server.HandlerWrapper which wraps the server's handler with each of |wrappers| in
+// turn, so that the last of them is the outermost.
+func chainHandlerWrappers(wrappers []server.HandlerWrapper) server.HandlerWrapper {
+	return func(h mysql.Handler) (mysql.Handler, error) {
+		var err error
+		for _, wrap := range wrappers {
+			if h, err = wrap(h); err != nil {
+				return nil, err
+			}
+		}
+		return h, nil
+	}
+}
+
+// newSessionBuilder returns the server.SessionBuilder of the server, which counts each new session's connection
+// against the connection limit of its user with |limiter|, if it isn't nil, and adds each new session to |rowLocks|, if it isn't nil.
+func newSessionBuilder(se *engine.SqlEngine, config servercfg.ServerConfig, limiter *userLimiter, rowLocks *rowLockSessions) server.SessionBuilder {
 	userToSessionVars := make(map[string]map[string]interface{})
 	userVars := config.UserVars()
 	for _, curr := range userVars {
 		userToSessionVars[curr.Name] = curr.Vars
 	}
+	clientCerts := config.ClientCertConfig()
 
 	return func(ctx context.Context, conn *mysql.Conn, addr string) (sql.Session, error) {
+		if clientCerts != nil {
+			if err := checkClientCert(clientCerts, conn.User, conn.GetTLSClientCerts()); err != nil {
+				return nil, err
+			}
+		}
+
+		if limiter != nil {
+			if err := limiter.admit(conn.ConnectionID, conn.User); err != nil {
+				return nil, err
+			}
+		}
+
+		// the roles of a user authenticated by LDAP or OpenID Connect can't be granted while it's authenticated, since
+		// privileges are locked for reading, so they're granted before its session is created
+		se.GrantGroupRoles(conn.User)
+
 		baseSession, err := sql.BaseSessionFromConnection(ctx, conn, addr)
 		if err != nil {
 			return nil, err
@@ -919,6 +1170,9 @@ func newSessionBuilder(se *engine.SqlEngine, config servercfg.ServerConfig) serv
 		if err != nil {
 			return nil, err
 		}
+		if rowLocks != nil {
+			rowLocks.add(conn.ConnectionID, dsess)
+		}
 
 		varsForUser := userToSessionVars[conn.User]
 		if len(varsForUser) > 0 {
@@ -968,6 +1222,9 @@ func getConfigFromServerConfig(serverConfig servercfg.ServerConfig) (server.Conf
 	serverConf.MaxConnections = serverConfig.MaxConnections()
 	serverConf.TLSConfig = tlsConfig
 	serverConf.RequireSecureTransport = serverConfig.RequireSecureTransport()
+	if cc := serverConfig.ClientCertConfig(); cc != nil && cc.Required {
+		serverConf.RequireSecureTransport = true
+	}
 	serverConf.MaxLoggedQueryLen = serverConfig.MaxLoggedQueryLen()
 	serverConf.EncodeLoggedQuery = serverConfig.ShouldEncodeLoggedQuery()
 
@@ -1009,3 +1266,18 @@ func getEventSchedulerStatus(status string) (eventscheduler.SchedulerStatus, err
 		return eventscheduler.SchedulerDisabled, fmt.Errorf("Error while setting value '%s' to 'event_scheduler'.", status)
 	}
 }
+
+// localUserEngine is a SqlEngine whose local contexts are for the server's local superuser, which exists whichever
+// users are configured.
+type localUserEngine struct {
+	*engine.SqlEngine
+}
+
+func (e localUserEngine) NewLocalContext(ctx context.Context) (*sql.Context, error) {
+	sqlCtx, err := e.SqlEngine.NewLocalContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sqlCtx.Session.SetClient(sql.Client{User: LocalConnectionUser, Address: "localhost", Capabilities: 0})
+	return sqlCtx, nil
+}
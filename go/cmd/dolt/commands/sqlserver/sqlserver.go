@@ -83,6 +83,8 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}privilege_file{{.EmphasisRight}}: "Path to a file to load and store users and grants. Defaults to {{.EmphasisLeft}}$doltcfg-dir/privileges.db{{.EmphasisRight}}. Will be created as needed.
 
+{{.EmphasisLeft}}privilege_database{{.EmphasisRight}}: Name of a database to load and store users and grants in, in its {{.EmphasisLeft}}dolt_privileges{{.EmphasisRight}} table, in place of the privilege file. Changes to users and grants are then versioned, and copied by clone, push and pull, like the rest of the database. They are loaded from the database's default branch when the server starts.
+
 {{.EmphasisLeft}}branch_control_file{{.EmphasisRight}}: Path to a file to load and store branch control permissions. Defaults to {{.EmphasisLeft}}$doltcfg-dir/branch_control.db{{.EmphasisRight}}. Will be created as needed.
 
 {{.EmphasisLeft}}max_logged_query_len{{.EmphasisRight}}: If greater than zero, truncates query strings in logging to the number of characters given.
@@ -113,6 +115,8 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}}: The path to the TLS key used for secure transport
 
+{{.EmphasisLeft}}listener.client_cert{{.EmphasisRight}}: Settings for authenticating clients with TLS client certificates, which must be issued by the certificate authorities in {{.EmphasisLeft}}listener.client_cert.ca{{.EmphasisRight}}. {{.EmphasisLeft}}listener.client_cert.users{{.EmphasisRight}} maps the subject common names of certificates to the users they authenticate as. A mapped user must connect with its certificate, and can be created without a password to authenticate with the certificate alone. If {{.EmphasisLeft}}listener.client_cert.required{{.EmphasisRight}} is true, every connection must use TLS with a certificate mapped to its user.
+
 {{.EmphasisLeft}}remotesapi.port{{.EmphasisRight}}: A port to listen for remote API operations on. If set to a positive integer, this server will accept connections from clients to clone, pull, etc. databases being served.
 
 {{.EmphasisLeft}}remotesapi.read_only{{.EmphasisRight}}: Boolean flag which disables the ability to perform pushes against the server.
@@ -121,9 +125,29 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}user_session_vars{{.EmphasisRight}}: A map of user name to a map of session variables to set on connection for each session.
 
+{{.EmphasisLeft}}user_limits{{.EmphasisRight}}: A list of limits on the resources of users, each applying to the user given by {{.EmphasisLeft}}name{{.EmphasisRight}}, or to every user without limits of its own if the name is {{.EmphasisLeft}}%{{.EmphasisRight}}. {{.EmphasisLeft}}max_connections{{.EmphasisRight}} limits the connections the user may have open at once, {{.EmphasisLeft}}max_statements_per_second{{.EmphasisRight}} the statements it may run per second across all its connections, and {{.EmphasisLeft}}max_rows_per_query{{.EmphasisRight}} the rows a query it runs may return. A limit of 0 is no limit.
+
+{{.EmphasisLeft}}remote_databases{{.EmphasisRight}}: A list of read-only databases served directly from remotes, without local copies of them. Each is served as the database given by {{.EmphasisLeft}}name{{.EmphasisRight}} from the remote at {{.EmphasisLeft}}remote_url{{.EmphasisRight}}, such as a DoltHub, aws:// or gs:// remote, whose parameters, such as {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, may be given in {{.EmphasisLeft}}params{{.EmphasisRight}}. Chunks of the database are read from the remote as queries need them, and the most recently read are kept in a cache of {{.EmphasisLeft}}cache_size_mb{{.EmphasisRight}} (256 by default). The database's branches are refreshed from the remote every {{.EmphasisLeft}}refresh_interval_millis{{.EmphasisRight}} (10000 by default).
+
+{{.EmphasisLeft}}user_defined_functions{{.EmphasisRight}}: A list of SQL functions implemented by functions exported from WebAssembly modules. Each defines the function given by {{.EmphasisLeft}}name{{.EmphasisRight}}, implemented by the function named {{.EmphasisLeft}}export{{.EmphasisRight}} (the name of the SQL function by default) of the module at {{.EmphasisLeft}}module_path{{.EmphasisRight}}. The module's {{.EmphasisLeft}}version{{.EmphasisRight}} is reported in the function's errors, and if {{.EmphasisLeft}}sha256{{.EmphasisRight}} is given, the function is only loaded from a module with that digest. Modules may not import anything, and so have no access to the server or its host. Each may use at most {{.EmphasisLeft}}max_memory_mb{{.EmphasisRight}} of memory (16 by default), and each call may run for at most {{.EmphasisLeft}}timeout_millis{{.EmphasisRight}} (1000 by default). The parameters and result of a function must be i32, i64, f32 or f64, unless {{.EmphasisLeft}}text{{.EmphasisRight}} is true, in which case the module must export its memory, an {{.EmphasisLeft}}alloc(i32) i32{{.EmphasisRight}} function and a {{.EmphasisLeft}}dealloc(i32, i32){{.EmphasisRight}} function, and the function takes an i32 pointer and length for each text argument and returns an i64 holding the pointer and length of its text result in its upper and lower 32 bits. The memory of the arguments and the result is freed with {{.EmphasisLeft}}dealloc{{.EmphasisRight}} after each call.
+
+{{.EmphasisLeft}}edge_sync{{.EmphasisRight}}: Turns on edge sync mode, for servers which may be offline. Sessions write to the branch {{.EmphasisLeft}}device_branch{{.EmphasisRight}} of each database, which is created from {{.EmphasisLeft}}central_branch{{.EmphasisRight}} ({{.EmphasisLeft}}main{{.EmphasisRight}} by default) if it doesn't exist. Every {{.EmphasisLeft}}interval_millis{{.EmphasisRight}} (60000 by default), the changes of the device branch are committed, merged into the central branch of {{.EmphasisLeft}}remote{{.EmphasisRight}} ({{.EmphasisLeft}}origin{{.EmphasisRight}} by default) and pushed, and the central branch is merged back into the device branch. Columns changed on both branches are resolved with the strategies in {{.EmphasisLeft}}dolt_column_merge_strategies{{.EmphasisRight}}. A sync which fails, for example because the remote can't be reached or because of conflicts, is retried at the next interval.
+
+{{.EmphasisLeft}}scheduled_pulls{{.EmphasisRight}}: A list of scheduled pulls, for mirrors which must track an upstream database. Every {{.EmphasisLeft}}interval_millis{{.EmphasisRight}} (300000 by default), the {{.EmphasisLeft}}branches{{.EmphasisRight}} of {{.EmphasisLeft}}database{{.EmphasisRight}} are fetched from {{.EmphasisLeft}}remote{{.EmphasisRight}} ({{.EmphasisLeft}}origin{{.EmphasisRight}} by default) and merged into the local branches, which are created from the remote's branches if they don't exist. {{.EmphasisLeft}}conflict_policy{{.EmphasisRight}} is how a pull with conflicts is handled: {{.EmphasisLeft}}abort{{.EmphasisRight}} (the default) abandons it, leaving the branch as it was, while {{.EmphasisLeft}}prefer-remote{{.EmphasisRight}} and {{.EmphasisLeft}}prefer-local{{.EmphasisRight}} resolve the conflicts with the remote's or the local rows. A pull which fails is logged, posted as JSON to the {{.EmphasisLeft}}on_failure{{.EmphasisRight}} URL if one is given, and retried at the next interval.
+
+{{.EmphasisLeft}}export_jobs{{.EmphasisRight}}: Limits what the export jobs of the {{.EmphasisLeft}}dolt_export_jobs{{.EmphasisRight}} tables of databases can write to and notify. Snapshots with local destinations are written in {{.EmphasisLeft}}directory{{.EmphasisRight}}, which their destinations are relative to and can't leave. Without it, jobs can only write to object storage. Notifications of failed runs are only posted to the {{.EmphasisLeft}}on_failure{{.EmphasisRight}} URLs of jobs which are one of {{.EmphasisLeft}}notification_urls{{.EmphasisRight}}.
+
+{{.EmphasisLeft}}ldap{{.EmphasisRight}}: Settings for authenticating users against an LDAP server. Users created with {{.EmphasisLeft}}IDENTIFIED WITH authentication_dolt_ldap{{.EmphasisRight}} log in with their LDAP password, which is checked by binding to {{.EmphasisLeft}}ldap.url{{.EmphasisRight}} as the DN given by {{.EmphasisLeft}}AS 'dn'{{.EmphasisRight}}, or else by {{.EmphasisLeft}}ldap.bind_dn_template{{.EmphasisRight}} with {{.EmphasisLeft}}{user}{{.EmphasisRight}} replaced by the user name. The connection to the LDAP server must be encrypted, by an {{.EmphasisLeft}}ldaps://{{.EmphasisRight}} URL or by setting {{.EmphasisLeft}}ldap.start_tls{{.EmphasisRight}}, unless {{.EmphasisLeft}}ldap.allow_cleartext{{.EmphasisRight}} is set; {{.EmphasisLeft}}ldap.tls_ca{{.EmphasisRight}} names the certificate authorities trusted for it. {{.EmphasisLeft}}ldap.group_roles{{.EmphasisRight}} maps the DNs of groups, listed in the user's {{.EmphasisLeft}}ldap.group_attribute{{.EmphasisRight}} ({{.EmphasisLeft}}memberOf{{.EmphasisRight}} by default), to SQL roles which are granted to the user when they log in, and revoked when they log in without being a member of any group mapped to them.
+
+{{.EmphasisLeft}}oidc{{.EmphasisRight}}: Settings for authenticating users with tokens issued by an OpenID Connect provider. Users created with {{.EmphasisLeft}}IDENTIFIED WITH authentication_dolt_oidc{{.EmphasisRight}} log in with a token, issued by {{.EmphasisLeft}}oidc.issuer{{.EmphasisRight}} for {{.EmphasisLeft}}oidc.audience{{.EmphasisRight}}, whose {{.EmphasisLeft}}oidc.username_claim{{.EmphasisRight}} ({{.EmphasisLeft}}sub{{.EmphasisRight}} by default) is the user name, as their password. {{.EmphasisLeft}}oidc.group_roles{{.EmphasisRight}} maps the groups in the token's {{.EmphasisLeft}}oidc.groups_claim{{.EmphasisRight}} ({{.EmphasisLeft}}groups{{.EmphasisRight}} by default) to SQL roles which are granted and revoked as for LDAP.
+
+LDAP and OpenID Connect passwords are sent in cleartext, so they require TLS or {{.EmphasisLeft}}listener.allow_cleartext_passwords{{.EmphasisRight}}.
+
 {{.EmphasisLeft}}cluster{{.EmphasisRight}}: Settings related to running this server in a replicated cluster. For information on setting these values, see https://docs.dolthub.com/sql-reference/server/replication
 
-If a config file is not provided many of these settings may be configured on the command line.`,
+If a config file is not provided many of these settings may be configured on the command line.
+
+A server started with a config file re-reads it when the server process receives a SIGHUP, or when {{.EmphasisLeft}}CALL dolt_reload_config(){{.EmphasisRight}} is run, and applies {{.EmphasisLeft}}log_level{{.EmphasisRight}}, {{.EmphasisLeft}}system_variables{{.EmphasisRight}}, including the replication settings, and {{.EmphasisLeft}}user_limits{{.EmphasisRight}} without dropping client connections. Databases added to the data directory since the server started are loaded too. Changes to other settings, such as the listener's, are applied when the server is restarted.`,
 	Synopsis: []string{
 		"--config {{.LessThan}}file{{.GreaterThan}}",
 		"[-H {{.LessThan}}host{{.GreaterThan}}] [-P {{.LessThan}}port{{.GreaterThan}}] [-u {{.LessThan}}user{{.GreaterThan}}] [-p {{.LessThan}}password{{.GreaterThan}}] [-t {{.LessThan}}timeout{{.GreaterThan}}] [-l {{.LessThan}}loglevel{{.GreaterThan}}] [--data-dir {{.LessThan}}directory{{.GreaterThan}}] [-r]",
@@ -248,9 +272,17 @@ func StartServer(ctx context.Context, versionStr, commandStr string, args []stri
 		return err
 	}
 
+	// a server started with a config file re-reads it when it's reloaded
+	var reloadConfig func() (servercfg.ServerConfig, error)
+	if apr, err := ap.Parse(args); err == nil && apr.Contains(configFileFlag) {
+		reloadConfig = func() (servercfg.ServerConfig, error) {
+			return getServerConfig(dEnv.FS, apr, DoltServerConfigReader{})
+		}
+	}
+
 	cli.PrintErrf("Starting server with Config %v\n", servercfg.ConfigInfo(serverConfig))
 
-	startError, closeError := Serve(ctx, versionStr, serverConfig, controller, dEnv)
+	startError, closeError := serve(ctx, versionStr, serverConfig, reloadConfig, controller, dEnv)
 	if startError != nil {
 		return startError
 	}
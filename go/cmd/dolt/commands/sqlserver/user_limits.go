@@ -0,0 +1,262 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+// defaultUserLimitsName is the name of the servercfg.UserLimits which apply to users without limits of their own.
+const defaultUserLimitsName = "%"
+
+// userLimiter enforces the servercfg.UserLimits of a server's users. Connections are counted against a user's limit
+// when their session is created, and statements are rate limited with a token bucket shared by all of a user's
+// connections.
+type userLimiter struct {
+	// limitsMu guards |limits|, which are replaced when the server's config is reloaded
+	limitsMu sync.RWMutex
+	limits   map[string]servercfg.UserLimits
+	// now returns the current time, and is replaced in tests
+	now func() time.Time
+
+	mu sync.Mutex
+	// conns is the number of open connections of each user
+	conns map[string]int
+	// connUsers is the user of each connection counted in |conns|
+	connUsers map[uint32]string
+	// buckets is the statement rate limiting token bucket of each user
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket holds the statements a user may run before the bucket refills.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newUserLimiter returns a userLimiter enforcing |limits|, or nil if there are none.
+func newUserLimiter(limits []servercfg.UserLimits) *userLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	return newReloadableUserLimiter(limits)
+}
+
+// newReloadableUserLimiter returns a userLimiter enforcing |limits|, even if there are none, so that limits can be
+// added when the server's config is reloaded.
+func newReloadableUserLimiter(limits []servercfg.UserLimits) *userLimiter {
+	l := &userLimiter{
+		now:       time.Now,
+		conns:     make(map[string]int),
+		connUsers: make(map[uint32]string),
+		buckets:   make(map[string]*tokenBucket),
+	}
+	l.setLimits(limits)
+	return l
+}
+
+// setLimits replaces the limits enforced by |l| with |limits|. Connections which are open already are only counted
+// against a connection limit if they were counted when they were opened.
+func (l *userLimiter) setLimits(limits []servercfg.UserLimits) {
+	m := make(map[string]servercfg.UserLimits, len(limits))
+	for _, lim := range limits {
+		m[lim.Name] = lim
+	}
+	l.limitsMu.Lock()
+	defer l.limitsMu.Unlock()
+	l.limits = m
+}
+
+// limitsFor returns the limits of |user|, and whether it has any.
+func (l *userLimiter) limitsFor(user string) (servercfg.UserLimits, bool) {
+	l.limitsMu.RLock()
+	defer l.limitsMu.RUnlock()
+	if lim, ok := l.limits[user]; ok {
+		return lim, true
+	}
+	lim, ok := l.limits[defaultUserLimitsName]
+	return lim, ok
+}
+
+// admit counts the connection |connID| of |user| against the user's connection limit, returning an error if the user
+// already has as many connections open as it may. Admitting a connection again is a no-op.
+func (l *userLimiter) admit(connID uint32, user string) error {
+	lim, ok := l.limitsFor(user)
+	if !ok || lim.MaxConnections == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.connUsers[connID]; ok {
+		return nil
+	}
+	if l.conns[user] >= lim.MaxConnections {
+		return mysql.NewSQLError(mysql.ERTooManyUserConnections, mysql.SSClientError,
+			"User %s already has more than 'max_connections' active connections", user)
+	}
+	l.conns[user]++
+	l.connUsers[connID] = user
+	return nil
+}
+
+// release stops counting the closed connection |connID| against the connection limit of its user.
+func (l *userLimiter) release(connID uint32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	user, ok := l.connUsers[connID]
+	if !ok {
+		return
+	}
+	delete(l.connUsers, connID)
+	if l.conns[user]--; l.conns[user] <= 0 {
+		delete(l.conns, user)
+	}
+}
+
+// allowStatement returns an error if |user| has run as many statements as it may for now.
+func (l *userLimiter) allowStatement(user string) error {
+	lim, ok := l.limitsFor(user)
+	if !ok || lim.MaxStatementsPerSecond == 0 {
+		return nil
+	}
+	rate := float64(lim.MaxStatementsPerSecond)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b, ok := l.buckets[user]
+	if !ok {
+		b = &tokenBucket{tokens: rate, last: now}
+		l.buckets[user] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > rate {
+		b.tokens = rate
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return mysql.NewSQLError(mysql.ERUserLimitReached, mysql.SSClientError,
+			"User '%s' has exceeded the 'max_statements_per_second' resource (current value: %d)", user, lim.MaxStatementsPerSecond)
+	}
+	b.tokens--
+	return nil
+}
+
+// limitRows returns a callback which sends the results of a query run by |user| to |callback|, failing the query once
+// it returns more rows than the user's queries may.
+func (l *userLimiter) limitRows(user string, callback mysql.ResultSpoolFn) mysql.ResultSpoolFn {
+	lim, ok := l.limitsFor(user)
+	if !ok || lim.MaxRowsPerQuery == 0 {
+		return callback
+	}
+	var rows int64
+	return func(res *sqltypes.Result, more bool) error {
+		rows += int64(len(res.Rows))
+		if rows > lim.MaxRowsPerQuery {
+			return mysql.NewSQLError(mysql.ERUserLimitReached, mysql.SSClientError,
+				"User '%s' has exceeded the 'max_rows_per_query' resource (current value: %d)", user, lim.MaxRowsPerQuery)
+		}
+		return callback(res, more)
+	}
+}
+
+// limitedHandler is the interface of the server's handler which userLimitsHandler embeds, so that the optional
+// interfaces it implements are kept.
+type limitedHandler interface {
+	mysql.Handler
+	mysql.ExtendedHandler
+	mysql.BinlogReplicaHandler
+}
+
+// userLimitsHandler is a server handler which enforces the statement rate and query result limits of users, and
+// stops counting closed connections against their connection limits.
+type userLimitsHandler struct {
+	limitedHandler
+	limiter *userLimiter
+}
+
+// newUserLimitsHandler returns a server.HandlerWrapper which wraps the server's handler in a userLimitsHandler
+// enforcing the limits of |limiter|.
+func newUserLimitsHandler(limiter *userLimiter) server.HandlerWrapper {
+	return func(h mysql.Handler) (mysql.Handler, error) {
+		lh, ok := h.(limitedHandler)
+		if !ok {
+			return nil, fmt.Errorf("cannot limit users with handler of type %T", h)
+		}
+		return userLimitsHandler{limitedHandler: lh, limiter: limiter}, nil
+	}
+}
+
+// ConnectionClosed implements mysql.Handler
+func (h userLimitsHandler) ConnectionClosed(c *mysql.Conn) {
+	h.limiter.release(c.ConnectionID)
+	h.limitedHandler.ConnectionClosed(c)
+}
+
+// ComQuery implements mysql.Handler
+func (h userLimitsHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	if err := h.limiter.allowStatement(c.User); err != nil {
+		return err
+	}
+	return h.limitedHandler.ComQuery(ctx, c, query, h.limiter.limitRows(c.User, callback))
+}
+
+// ComMultiQuery implements mysql.Handler
+func (h userLimitsHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	if err := h.limiter.allowStatement(c.User); err != nil {
+		return "", err
+	}
+	return h.limitedHandler.ComMultiQuery(ctx, c, query, h.limiter.limitRows(c.User, callback))
+}
+
+// ComStmtExecute implements mysql.Handler
+func (h userLimitsHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	if err := h.limiter.allowStatement(c.User); err != nil {
+		return err
+	}
+	limited := h.limiter.limitRows(c.User, func(res *sqltypes.Result, _ bool) error {
+		return callback(res)
+	})
+	return h.limitedHandler.ComStmtExecute(ctx, c, prepare, func(res *sqltypes.Result) error {
+		return limited(res, false)
+	})
+}
+
+// ComParsedQuery implements mysql.ExtendedHandler
+func (h userLimitsHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	if err := h.limiter.allowStatement(c.User); err != nil {
+		return err
+	}
+	return h.limitedHandler.ComParsedQuery(ctx, c, query, parsed, h.limiter.limitRows(c.User, callback))
+}
+
+// ComExecuteBound implements mysql.ExtendedHandler
+func (h userLimitsHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	if err := h.limiter.allowStatement(c.User); err != nil {
+		return err
+	}
+	return h.limitedHandler.ComExecuteBound(ctx, c, query, boundQuery, h.limiter.limitRows(c.User, callback))
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func TestUserLimiter(t *testing.T) {
+	assert.Nil(t, newUserLimiter(nil))
+
+	l := newUserLimiter([]servercfg.UserLimits{
+		{Name: "orders", MaxConnections: 2, MaxStatementsPerSecond: 2, MaxRowsPerQuery: 3},
+		{Name: "%", MaxConnections: 1},
+	})
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+
+	t.Run("connections", func(t *testing.T) {
+		require.NoError(t, l.admit(1, "orders"))
+		require.NoError(t, l.admit(1, "orders"))
+		require.NoError(t, l.admit(2, "orders"))
+		assert.Error(t, l.admit(3, "orders"))
+		l.release(1)
+		require.NoError(t, l.admit(3, "orders"))
+
+		// users without limits of their own have the default limits, each counted separately
+		require.NoError(t, l.admit(4, "billing"))
+		assert.Error(t, l.admit(5, "billing"))
+		require.NoError(t, l.admit(6, "root"))
+		l.release(4)
+		require.NoError(t, l.admit(5, "billing"))
+	})
+
+	t.Run("statements", func(t *testing.T) {
+		require.NoError(t, l.allowStatement("orders"))
+		require.NoError(t, l.allowStatement("orders"))
+		assert.Error(t, l.allowStatement("orders"))
+		now = now.Add(500 * time.Millisecond)
+		require.NoError(t, l.allowStatement("orders"))
+		assert.Error(t, l.allowStatement("orders"))
+		now = now.Add(time.Minute)
+		require.NoError(t, l.allowStatement("orders"))
+		require.NoError(t, l.allowStatement("orders"))
+		assert.Error(t, l.allowStatement("orders"))
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, l.allowStatement("billing"))
+		}
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		var sent int
+		callback := func(res *sqltypes.Result, more bool) error {
+			sent += len(res.Rows)
+			return nil
+		}
+		limited := l.limitRows("orders", callback)
+		require.NoError(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 2)}, true))
+		require.NoError(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 1)}, true))
+		assert.Error(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 1)}, false))
+		assert.Equal(t, 3, sent)
+
+		limited = l.limitRows("billing", callback)
+		require.NoError(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 100)}, false))
+	})
+}
+
+func TestReloadableUserLimiter(t *testing.T) {
+	l := newReloadableUserLimiter(nil)
+	require.NotNil(t, l)
+	require.NoError(t, l.admit(1, "orders"))
+	require.NoError(t, l.admit(2, "orders"))
+
+	l.setLimits([]servercfg.UserLimits{{Name: "orders", MaxConnections: 1, MaxRowsPerQuery: 1}})
+	// connections opened before the limit was set aren't counted against it
+	require.NoError(t, l.admit(3, "orders"))
+	assert.Error(t, l.admit(4, "orders"))
+	limited := l.limitRows("orders", func(res *sqltypes.Result, more bool) error { return nil })
+	assert.Error(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 2)}, false))
+
+	l.setLimits(nil)
+	require.NoError(t, l.admit(4, "orders"))
+	limited = l.limitRows("orders", func(res *sqltypes.Result, more bool) error { return nil })
+	require.NoError(t, limited(&sqltypes.Result{Rows: make([][]sqltypes.Value, 2)}, false))
+}
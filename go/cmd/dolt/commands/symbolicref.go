@@ -0,0 +1,188 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const symbolicRefShortFlag = "short"
+
+var symbolicRefDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Read, modify and delete the symbolic refs of a database.",
+	LongDesc: `A symbolic ref refers to another ref rather than to a commit. Symbolic refs are stored in the database, so they are shared with its remotes and clones.
+
+The {{.EmphasisLeft}}HEAD{{.EmphasisRight}} symbolic ref names the default branch of a database, e.g. {{.EmphasisLeft}}refs/heads/main{{.EmphasisRight}}. {{.EmphasisLeft}}dolt clone{{.EmphasisRight}} checks out the default branch of the database it clones unless a branch is given with {{.EmphasisLeft}}--branch{{.EmphasisRight}}. Without a HEAD, or if HEAD refers to a branch which doesn't exist, clone checks out {{.EmphasisLeft}}main{{.EmphasisRight}} or {{.EmphasisLeft}}master{{.EmphasisRight}} if they exist.
+
+Setting the HEAD of a database doesn't change the branch checked out in the current directory; use {{.EmphasisLeft}}dolt checkout{{.EmphasisRight}} for that.
+
+With one argument, prints the ref the symbolic ref refers to. With two arguments, points the symbolic ref at the branch or tag given, which must exist. Use {{.EmphasisLeft}}--remote{{.EmphasisRight}} to read or modify the symbolic refs of a remote's database instead of the local one, e.g. to set the default branch of a remote before others clone it.`,
+	Synopsis: []string{
+		`[--remote {{.LessThan}}name{{.GreaterThan}}] [-q] [--short] {{.LessThan}}name{{.GreaterThan}}`,
+		`[--remote {{.LessThan}}name{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}ref{{.GreaterThan}}`,
+		`[--remote {{.LessThan}}name{{.GreaterThan}}] -d {{.LessThan}}name{{.GreaterThan}}`,
+	},
+}
+
+type SymbolicRefCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd SymbolicRefCmd) Name() string {
+	return "symbolic-ref"
+}
+
+// Description returns a description of the command
+func (cmd SymbolicRefCmd) Description() string {
+	return symbolicRefDocs.ShortDesc
+}
+
+func (cmd SymbolicRefCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(symbolicRefDocs, ap)
+}
+
+func (cmd SymbolicRefCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"name", "The name of the symbolic ref, e.g. HEAD."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "The branch or tag the symbolic ref should refer to, e.g. main or refs/heads/main."})
+	ap.SupportsString(cli.RemoteParam, "", "name", "Read or modify the symbolic refs of the remote's database.")
+	ap.SupportsFlag(cli.DeleteFlag, "d", "Delete the symbolic ref.")
+	ap.SupportsFlag(cli.QuietFlag, "q", "Don't print an error if the symbolic ref doesn't exist.")
+	ap.SupportsFlag(symbolicRefShortFlag, "", "Print the short name of the ref the symbolic ref refers to, e.g. main instead of refs/heads/main.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd SymbolicRefCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, symbolicRefDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: the name of a symbolic ref is required").SetPrintUsage().Build(), usage)
+	}
+	if apr.Contains(cli.DeleteFlag) && apr.NArg() != 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: --%s takes exactly one symbolic ref", cli.DeleteFlag).SetPrintUsage().Build(), usage)
+	}
+
+	ddb := dEnv.DoltDB
+	if remoteName, ok := apr.GetValue(cli.RemoteParam); ok {
+		var verr errhand.VerboseError
+		ddb, verr = getSymbolicRefRemoteDB(ctx, dEnv, remoteName)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	sym := ref.NewSymbolicRef(strings.TrimPrefix(apr.Arg(0), ref.PrefixForType(ref.SymbolicRefType)))
+
+	var verr errhand.VerboseError
+	switch {
+	case apr.Contains(cli.DeleteFlag):
+		verr = deleteSymbolicRef(ctx, ddb, sym)
+	case apr.NArg() == 2:
+		verr = setSymbolicRef(ctx, ddb, sym, apr.Arg(1))
+	default:
+		return printSymbolicRef(ctx, ddb, sym, apr.Contains(symbolicRefShortFlag), apr.Contains(cli.QuietFlag), usage)
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func getSymbolicRefRemoteDB(ctx context.Context, dEnv *env.DoltEnv, remoteName string) (*doltdb.DoltDB, errhand.VerboseError) {
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return nil, errhand.BuildDError("error: unable to get remotes from the local directory").AddCause(err).Build()
+	}
+	r, ok := remotes.Get(remoteName)
+	if !ok {
+		return nil, errhand.BuildDError("error: unknown remote: '%s'", remoteName).Build()
+	}
+
+	ddb, err := r.GetRemoteDB(ctx, dEnv.DoltDB.Format(), dEnv)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to get remote database for '%s'", remoteName).AddCause(err).Build()
+	}
+	return ddb, nil
+}
+
+func printSymbolicRef(ctx context.Context, ddb *doltdb.DoltDB, sym ref.SymbolicRef, short, quiet bool, usage cli.UsagePrinter) int {
+	target, ok, err := ddb.ResolveSymbolicRef(ctx, sym)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to read %s", sym.String()).AddCause(err).Build(), usage)
+	}
+	if !ok {
+		if quiet {
+			return 1
+		}
+		return HandleVErrAndExitCode(errhand.BuildDError("fatal: ref %s is not a symbolic ref", sym.GetPath()).Build(), usage)
+	}
+
+	if short {
+		cli.Println(target.GetPath())
+	} else {
+		cli.Println(target.String())
+	}
+	return 0
+}
+
+func setSymbolicRef(ctx context.Context, ddb *doltdb.DoltDB, sym ref.SymbolicRef, targetStr string) errhand.VerboseError {
+	target, err := ref.Parse(targetStr)
+	if err != nil {
+		return errhand.BuildDError("error: invalid ref '%s'", targetStr).AddCause(err).Build()
+	}
+
+	switch target.GetType() {
+	case ref.BranchRefType:
+	case ref.TagRefType:
+		if sym.GetPath() == ref.HeadSymbolicRefName {
+			return errhand.BuildDError("error: %s can only refer to a branch, got %s", sym.GetPath(), target.String()).Build()
+		}
+	default:
+		return errhand.BuildDError("error: a symbolic ref can only refer to a branch or a tag, got %s", target.String()).Build()
+	}
+
+	ok, err := ddb.HasRef(ctx, target)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read %s", target.String()).AddCause(err).Build()
+	}
+	if !ok {
+		return errhand.BuildDError("error: ref %s does not exist", target.String()).Build()
+	}
+
+	err = ddb.SetSymbolicRef(ctx, sym, target)
+	if err != nil {
+		return errhand.BuildDError("error: failed to set %s to %s", sym.GetPath(), target.String()).AddCause(err).Build()
+	}
+	return nil
+}
+
+func deleteSymbolicRef(ctx context.Context, ddb *doltdb.DoltDB, sym ref.SymbolicRef) errhand.VerboseError {
+	err := ddb.DeleteSymbolicRef(ctx, sym)
+	if err == doltdb.ErrSymbolicRefNotFound {
+		return errhand.BuildDError("error: symbolic ref %s does not exist", sym.GetPath()).Build()
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to delete %s", sym.GetPath()).AddCause(err).Build()
+	}
+	return nil
+}
@@ -23,11 +23,14 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/fatih/color"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/datas"
 )
@@ -47,10 +50,15 @@ var tagDocs = cli.CommandDocumentationContent{
 
 The command's second form creates a new tag named {{.LessThan}}tagname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}ref{{.GreaterThan}} if given. Optionally, a tag message can be passed using the {{.EmphasisLeft}}-m{{.EmphasisRight}} option. 
 
+With {{.EmphasisLeft}}-a{{.EmphasisRight}}, {{.EmphasisLeft}}-s{{.EmphasisRight}} or {{.EmphasisLeft}}-u{{.EmphasisRight}}, an annotated tag is created, which requires a tag message. If no message is given with {{.EmphasisLeft}}-m{{.EmphasisRight}}, an editor is opened to write one. With {{.EmphasisLeft}}-s{{.EmphasisRight}} or {{.EmphasisLeft}}-u{{.EmphasisRight}}, the tag is also signed with GPG, attesting to its name, commit, tagger, date and message. {{.EmphasisLeft}}-s{{.EmphasisRight}} signs with the key of the {{.EmphasisLeft}}signingkey{{.EmphasisRight}} system variable, which can be set with {{.EmphasisLeft}}dolt config --global --add sqlserver.global.signingkey {{.LessThan}}key-id{{.GreaterThan}}{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--verify{{.EmphasisRight}}, the GPG signatures of the given tags are verified with the local keyring.
+
 With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted.`,
 	Synopsis: []string{
 		`[-v]`,
-		`[-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
+		`[-a] [-s | -u {{.LessThan}}key-id{{.GreaterThan}}] [-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
+		`--verify {{.LessThan}}tagname{{.GreaterThan}}...`,
 		`-d {{.LessThan}}tagname{{.GreaterThan}}`,
 	},
 }
@@ -107,12 +115,18 @@ func (cmd TagCmd) Exec(ctx context.Context, commandStr string, args []string, dE
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	// verify tags
+	if apr.Contains(cli.VerifyFlag) {
+		err = verifyTags(queryist, sqlCtx, apr)
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
 	// create tag
-	err = createTag(queryist, sqlCtx, apr)
+	err = createTag(queryist, sqlCtx, apr, cliCtx)
 	return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 }
 
-func createTag(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
+func createTag(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults, cliCtx cli.CliContext) error {
 	if apr.Contains(cli.VerboseFlag) {
 		return errors.New("verbose flag can only be used with tag listing")
 	} else if len(apr.Args) > 2 {
@@ -126,27 +140,44 @@ func createTag(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPar
 	}
 	message, _ := apr.GetValue(cli.MessageArg)
 	author, _ := apr.GetValue(cli.AuthorParam)
+	keyId, hasKeyId := apr.GetValue(cli.LocalUserParam)
+	sign := apr.Contains(cli.SignTagFlag) || hasKeyId
 
-	var query string
-	var params []interface{}
-	if len(message) == 0 {
-		if len(author) == 0 {
-			query = "call dolt_tag(?, ?)"
-			params = []interface{}{tagName, startPoint}
-		} else {
-			query = "call dolt_tag(?, ?, '--author', ?)"
-			params = []interface{}{tagName, startPoint, author}
+	if len(message) == 0 && (apr.Contains(cli.AnnotateFlag) || sign) {
+		initialMsg := fmt.Sprintf("\n#\n# Write a message for tag:\n#   %s\n# Lines starting with '#' will be ignored.\n", tagName)
+		edited, err := execEditor(initialMsg, "", cliCtx)
+		if err != nil {
+			return err
 		}
-	} else {
-		if len(author) == 0 {
-			query = "call dolt_tag('-m', ?, ?, ?)"
-			params = []interface{}{message, tagName, startPoint}
-		} else {
-			query = "call dolt_tag('-m', ?, ?, ?, '--author', ?)"
-			params = []interface{}{message, tagName, startPoint, author}
+		message = strings.TrimSpace(parseCommitMessage(edited))
+		if len(message) == 0 {
+			return errors.New("Aborting tag due to empty tag message.")
 		}
 	}
 
+	var args []string
+	var params []interface{}
+	if len(message) != 0 {
+		args = append(args, "'-m'", "?")
+		params = append(params, message)
+	}
+	args = append(args, "?", "?")
+	params = append(params, tagName, startPoint)
+	if len(author) != 0 {
+		args = append(args, "'--author'", "?")
+		params = append(params, author)
+	}
+	if apr.Contains(cli.AnnotateFlag) {
+		args = append(args, "'--annotate'")
+	}
+	if hasKeyId {
+		args = append(args, "'--local-user'", "?")
+		params = append(params, keyId)
+	} else if sign {
+		args = append(args, "'--sign'")
+	}
+
+	query := fmt.Sprintf("call dolt_tag(%s)", strings.Join(args, ", "))
 	_, err := InterpolateAndRunQuery(queryist, sqlCtx, query, params...)
 	if err != nil {
 		return fmt.Errorf("error: failed to create tag %s: %w", tagName, err)
@@ -170,6 +201,52 @@ func deleteTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPa
 	return nil
 }
 
+// verifyTags verifies the GPG signatures of the tags given as args with the local keyring, printing the output of gpg
+// for each of them.
+func verifyTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
+	if apr.Contains(cli.MessageArg) || apr.Contains(cli.AnnotateFlag) || apr.Contains(cli.SignTagFlag) || apr.Contains(cli.LocalUserParam) {
+		return errors.New("verify and tag creation options are incompatible")
+	}
+
+	for _, tagName := range apr.Args {
+		q, err := dbr.InterpolateForDialect("select tag_hash, tagger, email, date, message, signature from dolt_tags where tag_name = ?", []interface{}{tagName}, dialect.MySQL)
+		if err != nil {
+			return err
+		}
+		rows, err := GetRowsForSql(queryist, sqlCtx, q)
+		if err != nil {
+			return err
+		} else if len(rows) == 0 {
+			return fmt.Errorf("error: tag %s not found", tagName)
+		}
+
+		row := rows[0]
+		timestamp, err := getTimestampColAsUint64(row[3])
+		if err != nil {
+			return fmt.Errorf("failed to parse tag timestamp: %w", err)
+		}
+		meta := &datas.TagMeta{
+			Name:        row[1].(string),
+			Email:       row[2].(string),
+			Timestamp:   timestamp,
+			Description: row[4].(string),
+		}
+		if signature, ok := row[5].(string); ok {
+			meta.Signature = signature
+		}
+
+		out, err := actions.VerifyTagSignature(sqlCtx, tagName, row[0].(string), meta)
+		cli.Print(string(out))
+		if errors.Is(err, actions.ErrTagNotSigned) {
+			return fmt.Errorf("error: tag %s is not signed", tagName)
+		} else if err != nil {
+			return fmt.Errorf("error: failed to verify tag %s: %w", tagName, err)
+		}
+	}
+
+	return nil
+}
+
 func listTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
 	if apr.Contains(cli.DeleteFlag) {
 		return errors.New("must specify a tag name to delete")
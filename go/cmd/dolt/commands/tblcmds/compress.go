@@ -0,0 +1,94 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tblcmds
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/gozstd"
+)
+
+// The compressions of exports.
+const (
+	noCompression   = ""
+	gzipCompression = "gzip"
+	zstdCompression = "zstd"
+)
+
+// compressionExts are the file extensions of the compressions of exports.
+var compressionExts = map[string]string{
+	".gz":  gzipCompression,
+	".zst": zstdCompression,
+}
+
+// validateCompression returns an error if |compression| isn't a compression of exports.
+func validateCompression(compression string) error {
+	switch compression {
+	case noCompression, gzipCompression, zstdCompression:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid compression, must be one of %s or %s", compression, gzipCompression, zstdCompression)
+}
+
+// compressionFromPath returns the compression given by the extension of |path|, such as zstd for table.csv.zst, along
+// with |path| without that extension, from which the format of the file can be inferred.
+func compressionFromPath(path string) (compression string, trimmed string) {
+	ext := filepath.Ext(path)
+	if c, ok := compressionExts[strings.ToLower(ext)]; ok {
+		return c, strings.TrimSuffix(path, ext)
+	}
+	return noCompression, path
+}
+
+// compressingWriter compresses what is written to it, and closes its underlying writer when it is closed.
+type compressingWriter struct {
+	io.Writer
+	close func() error
+}
+
+func (w compressingWriter) Close() error {
+	return w.close()
+}
+
+// newCompressingWriter returns a writer which compresses to |wr| with |compression|. Closing it flushes the compressed
+// stream and closes |wr|.
+func newCompressingWriter(wr io.WriteCloser, compression string) io.WriteCloser {
+	switch compression {
+	case gzipCompression:
+		gw := gzip.NewWriter(wr)
+		return compressingWriter{Writer: gw, close: func() error {
+			err := gw.Close()
+			if cerr := wr.Close(); err == nil {
+				err = cerr
+			}
+			return err
+		}}
+	case zstdCompression:
+		zw := gozstd.NewWriter(wr)
+		return compressingWriter{Writer: zw, close: func() error {
+			err := zw.Close()
+			zw.Release()
+			if cerr := wr.Close(); err == nil {
+				err = cerr
+			}
+			return err
+		}}
+	}
+	return wr
+}
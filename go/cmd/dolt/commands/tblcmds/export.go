@@ -15,9 +15,13 @@
 package tblcmds
 
 import (
+	"archive/tar"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 
@@ -41,19 +45,46 @@ var exportDocs = cli.CommandDocumentationContent{
 	LongDesc: `{{.EmphasisLeft}}dolt table export{{.EmphasisRight}} will export the contents of {{.LessThan}}table{{.GreaterThan}} to {{.LessThan}}|file{{.GreaterThan}}
 
 See the help for {{.EmphasisLeft}}dolt table import{{.EmphasisRight}} as the options are the same.
+
+If {{.LessThan}}file{{.GreaterThan}} is {{.EmphasisLeft}}-{{.EmphasisRight}} or is omitted, the table is written to stdout, as csv unless another format is given with {{.EmphasisLeft}}--format{{.EmphasisRight}}. Csv, psv, json, jsonl and sql can be written to stdout.
+
+With {{.EmphasisLeft}}--compress{{.EmphasisRight}}, the output is compressed with gzip or zstd. When exporting to a file whose name ends with .gz or .zst, it is compressed accordingly, and its format is inferred from the rest of its name, e.g. table.csv.zst.
+
+Csv and psv exports can be written so that they open correctly in Excel and other spreadsheet programs whatever their locale: {{.EmphasisLeft}}--delim{{.EmphasisRight}} sets the field delimiter, {{.EmphasisLeft}}--decimal-separator{{.EmphasisRight}} the separator written in decimal and floating point numbers, and {{.EmphasisLeft}}--date-format{{.EmphasisRight}} and {{.EmphasisLeft}}--datetime-format{{.EmphasisRight}} how dates, and datetimes and timestamps, are written, using the format specifiers of {{.EmphasisLeft}}DATE_FORMAT(){{.EmphasisRight}}. {{.EmphasisLeft}}--bom{{.EmphasisRight}} writes a UTF-8 byte order mark at the start of the file and {{.EmphasisLeft}}--crlf{{.EmphasisRight}} ends lines with CRLF. For example, {{.EmphasisLeft}}dolt table export --delim ';' --decimal-separator , --date-format %d.%m.%Y --bom --crlf orders orders.csv{{.EmphasisRight}} writes a csv for a German Excel.
+
+With {{.EmphasisLeft}}--tar{{.EmphasisRight}}, {{.LessThan}}table{{.GreaterThan}} is a comma separated list of tables, which are written as the files of a tar stream, e.g. {{.EmphasisLeft}}dolt table export --tar --format csv --compress zstd customers,orders - | aws s3 cp - s3://bucket/export.tar.zst{{.EmphasisRight}}. Each file is named after its table, with the extension of the format.
 `,
 	Synopsis: []string{
 		"[-f] [-pk {{.LessThan}}field{{.GreaterThan}}] [-schema {{.LessThan}}file{{.GreaterThan}}] [-map {{.LessThan}}file{{.GreaterThan}}] [-continue] [-file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
+		"[--format {{.LessThan}}type{{.GreaterThan}}] [--compress gzip|zstd] {{.LessThan}}table{{.GreaterThan}} [-]",
+		"[--delim {{.LessThan}}delimiter{{.GreaterThan}}] [--decimal-separator {{.LessThan}}separator{{.GreaterThan}}] [--date-format {{.LessThan}}format{{.GreaterThan}}] [--datetime-format {{.LessThan}}format{{.GreaterThan}}] [--bom] [--crlf] {{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}file{{.GreaterThan}}|-]",
+		"--tar [-f] [--format {{.LessThan}}type{{.GreaterThan}}] [--compress gzip|zstd] {{.LessThan}}table{{.GreaterThan}}[,{{.LessThan}}table{{.GreaterThan}}...] [{{.LessThan}}file{{.GreaterThan}}|-]",
 	},
 }
 
+const (
+	decimalSepParam     = "decimal-separator"
+	dateFormatParam     = "date-format"
+	datetimeFormatParam = "datetime-format"
+	bomParam            = "bom"
+	crlfParam           = "crlf"
+)
+
 type exportOptions struct {
 	tableName  string
 	force      bool
 	dest       mvdata.DataLocation
 	srcOptions interface{}
+	// compression is the compression of the output, if any
+	compression string
+	// tarTables are the tables exported as the files of a tar stream, if the export is a tar stream
+	tarTables []string
+	// csvOpts are the options for writing csv and psv files
+	csvOpts mvdata.CsvOptions
 }
 
+var _ mvdata.CsvDestOptions = exportOptions{}
+
 func (m exportOptions) checkOverwrite(ctx context.Context, root doltdb.RootValue, fs filesys.ReadableFS) (bool, error) {
 	if _, isStream := m.dest.(mvdata.StreamDataLocation); isStream {
 		return false, nil
@@ -80,6 +111,10 @@ func (m exportOptions) SrcName() string {
 	return m.tableName
 }
 
+func (m exportOptions) CsvDestOptions() mvdata.CsvOptions {
+	return m.csvOpts
+}
+
 func (m exportOptions) DestName() string {
 	if f, fileDest := m.dest.(mvdata.FileDataLocation); fileDest {
 		return f.Path
@@ -87,36 +122,117 @@ func (m exportOptions) DestName() string {
 	return m.dest.String()
 }
 
-// getExportDestination returns an export destination corresponding to the input parameters
-func getExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
+// getExportDestination returns an export destination corresponding to the input parameters, along with the compression
+// of the export. In tar mode, the format of the destination is that of the files of the tar stream.
+func getExportDestination(apr *argparser.ArgParseResults) (mvdata.DataLocation, string, errhand.VerboseError) {
 	path := ""
 	if apr.NArg() > 1 {
 		path = apr.Arg(1)
 	}
 
-	fType, _ := apr.GetValue(fileTypeParam)
-	destLoc := mvdata.NewDataLocation(path, fType)
+	fType, hasFileType := apr.GetValue(fileTypeParam)
+	if format, ok := apr.GetValue(formatParam); ok {
+		if hasFileType && format != fType {
+			return nil, "", errhand.BuildDError("parameters %s and %s are mutually exclusive", fileTypeParam, formatParam).Build()
+		}
+		fType = format
+	}
+	if fType != "" && mvdata.DFFromString(fType) == mvdata.InvalidDataFormat {
+		return nil, "", errhand.BuildDError("'%s' is not a valid file type.", fType).Build()
+	}
 
+	compression, inferPath := compressionFromPath(path)
+	if c, ok := apr.GetValue(compressParam); ok {
+		if err := validateCompression(c); err != nil {
+			return nil, "", errhand.VerboseErrorFromError(err)
+		}
+		compression = c
+	}
+
+	isTar := apr.Contains(tarParam)
+	if isTar && fType == "" {
+		fType = "csv"
+	}
+
+	destLoc := mvdata.NewDataLocation(inferPath, fType)
 	switch val := destLoc.(type) {
 	case mvdata.FileDataLocation:
 		if val.Format == mvdata.InvalidDataFormat {
-			cli.PrintErrln(
-				color.RedString("Could not infer type file '%s'\n", path),
-				"File extensions should match supported file types, or should be explicitly defined via the file-type parameter")
-			return nil
+			return nil, "", errhand.BuildDError("Could not infer type file '%s'\n%s", path,
+				"File extensions should match supported file types, or should be explicitly defined via the file-type parameter").Build()
 		}
+		destLoc = mvdata.FileDataLocation{Path: path, Format: val.Format}
 
 	case mvdata.StreamDataLocation:
 		if val.Format == mvdata.InvalidDataFormat {
 			val = mvdata.StreamDataLocation{Format: mvdata.CsvFile, Reader: os.Stdin, Writer: iohelp.NopWrCloser(cli.CliOut)}
 			destLoc = val
-		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile {
-			cli.PrintErrln(color.RedString("Cannot export this format to stdout"))
-			return nil
+		} else if !isTar && !streamableExportFormats[val.Format] {
+			return nil, "", errhand.BuildDError("Cannot export this format to stdout").Build()
+		}
+	}
+
+	var format mvdata.DataFormat
+	switch val := destLoc.(type) {
+	case mvdata.FileDataLocation:
+		format = val.Format
+	case mvdata.StreamDataLocation:
+		format = val.Format
+	}
+	if format == mvdata.XlsxFile {
+		return nil, "", errhand.BuildDError("Cannot export to %s files", format).Build()
+	} else if !isTar && compression != noCompression && format == mvdata.ParquetFile {
+		return nil, "", errhand.BuildDError("%s files can't be compressed", format).Build()
+	}
+
+	return destLoc, compression, nil
+}
+
+// getExportCsvOptions returns the options for writing the csv or psv files of an export in |format|. It's an error
+// to give them for any other format.
+func getExportCsvOptions(apr *argparser.ArgParseResults, format mvdata.DataFormat) (mvdata.CsvOptions, errhand.VerboseError) {
+	delim, _ := apr.GetValue(delimParam)
+	decimalSep, _ := apr.GetValue(decimalSepParam)
+	dateFormat, _ := apr.GetValue(dateFormatParam)
+	datetimeFormat, _ := apr.GetValue(datetimeFormatParam)
+	opts := mvdata.CsvOptions{
+		Delim:          delim,
+		DecimalSep:     decimalSep,
+		DateFormat:     dateFormat,
+		DatetimeFormat: datetimeFormat,
+		BOM:            apr.Contains(bomParam),
+		CRLF:           apr.Contains(crlfParam),
+	}
+	if opts == (mvdata.CsvOptions{}) {
+		return opts, nil
+	}
+
+	if format != mvdata.CsvFile && format != mvdata.PsvFile {
+		for _, param := range []string{delimParam, decimalSepParam, dateFormatParam, datetimeFormatParam, bomParam, crlfParam} {
+			if apr.Contains(param) {
+				return opts, errhand.BuildDError("--%s is only supported when exporting to csv or psv", param).Build()
+			}
 		}
 	}
+	if apr.Contains(delimParam) && delim == "" {
+		return opts, errhand.BuildDError("--%s can't be empty", delimParam).Build()
+	}
+	if apr.Contains(decimalSepParam) && utf8.RuneCountInString(decimalSep) != 1 {
+		return opts, errhand.BuildDError("--%s must be a single character", decimalSepParam).Build()
+	}
+	if decimalSep != "" && decimalSep == opts.Delim {
+		return opts, errhand.BuildDError("--%s and --%s must be different", decimalSepParam, delimParam).Build()
+	}
+	return opts, nil
+}
 
-	return destLoc
+// streamableExportFormats are the formats which can be exported to stdout.
+var streamableExportFormats = map[mvdata.DataFormat]bool{
+	mvdata.CsvFile:   true,
+	mvdata.PsvFile:   true,
+	mvdata.JsonFile:  true,
+	mvdata.JsonlFile: true,
+	mvdata.SqlFile:   true,
 }
 
 func parseExportArgs(ap *argparser.ArgParser, commandStr string, args []string) (*exportOptions, errhand.VerboseError) {
@@ -131,24 +247,46 @@ func parseExportArgs(ap *argparser.ArgParser, commandStr string, args []string)
 		return nil, errhand.BuildDError("too many arguments").Build()
 	}
 
-	tableName := apr.Arg(0)
-	if !doltdb.IsValidTableName(tableName) {
-		usage()
-		cli.PrintErrln(color.RedString("'%s' is not a valid table name", tableName))
-		return nil, errhand.BuildDError("invalid table name").Build()
+	tableNames := []string{apr.Arg(0)}
+	if apr.Contains(tarParam) {
+		tableNames = strings.Split(apr.Arg(0), ",")
+	}
+	for _, tableName := range tableNames {
+		if !doltdb.IsValidTableName(tableName) {
+			usage()
+			cli.PrintErrln(color.RedString("'%s' is not a valid table name", tableName))
+			return nil, errhand.BuildDError("invalid table name").Build()
+		}
 	}
 
-	fileLoc := getExportDestination(apr)
+	fileLoc, compression, verr := getExportDestination(apr)
+	if verr != nil {
+		return nil, verr
+	}
 
-	if fileLoc == nil {
-		return nil, errhand.BuildDError("could not validate table export args").Build()
+	var format mvdata.DataFormat
+	switch dest := fileLoc.(type) {
+	case mvdata.FileDataLocation:
+		format = dest.Format
+	case mvdata.StreamDataLocation:
+		format = dest.Format
+	}
+	csvOpts, verr := getExportCsvOptions(apr, format)
+	if verr != nil {
+		return nil, verr
 	}
 
-	return &exportOptions{
-		tableName: tableName,
-		force:     apr.Contains(forceParam),
-		dest:      fileLoc,
-	}, nil
+	exOpts := &exportOptions{
+		tableName:   tableNames[0],
+		force:       apr.Contains(forceParam),
+		dest:        fileLoc,
+		compression: compression,
+		csvOpts:     csvOpts,
+	}
+	if apr.Contains(tarParam) {
+		exOpts.tarTables = tableNames
+	}
+	return exOpts, nil
 }
 
 type ExportCmd struct{}
@@ -174,6 +312,15 @@ func (cmd ExportCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The file being output to."})
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
+	ap.SupportsString(formatParam, "", "format", "The format of the exported data, such as csv or jsonl. The same as --file-type.")
+	ap.SupportsString(compressParam, "", "compression", "Compress the exported data with gzip or zstd.")
+	ap.SupportsFlag(tarParam, "", "Export each of a comma separated list of tables as a file of a tar stream.")
+	ap.SupportsString(delimParam, "", "delimiter", "The field delimiter of an exported csv or psv.")
+	ap.SupportsString(decimalSepParam, "", "separator", "The decimal separator written in decimal and floating point values of an exported csv or psv, such as ','.")
+	ap.SupportsString(dateFormatParam, "", "format", "The DATE_FORMAT() format string used to write dates to an exported csv or psv, such as '%d/%m/%Y'.")
+	ap.SupportsString(datetimeFormatParam, "", "format", "The DATE_FORMAT() format string used to write datetimes and timestamps to an exported csv or psv.")
+	ap.SupportsFlag(bomParam, "", "Write a UTF-8 byte order mark at the start of an exported csv or psv.")
+	ap.SupportsFlag(crlfParam, "", "End the lines of an exported csv or psv with CRLF.")
 	return ap
 }
 
@@ -192,60 +339,187 @@ func (cmd ExportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
-	root, verr := commands.GetWorkingWithVErr(dEnv)
+	if exOpts.tarTables != nil {
+		verr = exportTar(ctx, dEnv, exOpts)
+	} else {
+		verr = exportWithOptions(ctx, dEnv, exOpts)
+	}
 	if verr != nil {
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
+	cli.PrintErrln(color.CyanString("Successfully exported data."))
+	return 0
+}
+
+// exportWithOptions exports from the working set as described by |exOpts|.
+func exportWithOptions(ctx context.Context, dEnv *env.DoltEnv, exOpts *exportOptions) errhand.VerboseError {
+	root, verr := commands.GetWorkingWithVErr(dEnv)
+	if verr != nil {
+		return verr
+	}
+
 	rd, err := mvdata.NewSqlEngineReader(ctx, dEnv, exOpts.tableName)
 	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error creating reader for %s.", exOpts.SrcName()).AddCause(err).Build(), usage)
+		return errhand.BuildDError("Error creating reader for %s.", exOpts.SrcName()).AddCause(err).Build()
 	}
 
-	wr, verr := getTableWriter(ctx, root, dEnv, rd.GetSchema(), exOpts)
+	wr, closeOut, verr := getTableWriter(ctx, root, dEnv, rd.GetSchema(), exOpts)
 	if verr != nil {
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return verr
 	}
 
 	pipeline := mvdata.NewDataMoverPipeline(ctx, rd, wr)
 
 	err = pipeline.Execute()
+	if err == nil {
+		err = closeOut()
+	}
 	if err != nil {
-		return commands.HandleVErrAndExitCode(errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build(), usage)
+		return errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
 	}
 
-	cli.PrintErrln(color.CyanString("Successfully exported data."))
-	return 0
+	return nil
 }
 
-func getTableWriter(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, rdSchema schema.Schema, exOpts *exportOptions) (table.SqlRowWriter, errhand.VerboseError) {
+// exportTar exports each of the tar tables of |exOpts| in the format of its destination, and writes them as the files
+// of a tar stream to the destination. Each table is exported to a temporary file first, since the size of each file
+// of a tar stream precedes its contents.
+func exportTar(ctx context.Context, dEnv *env.DoltEnv, exOpts *exportOptions) errhand.VerboseError {
+	var format mvdata.DataFormat
+	var out io.WriteCloser
+	switch dest := exOpts.dest.(type) {
+	case mvdata.StreamDataLocation:
+		format, out = dest.Format, iohelp.NopWrCloser(dest.Writer)
+	case mvdata.FileDataLocation:
+		format = dest.Format
+		if exists, _ := dEnv.FS.Exists(dest.Path); exists && !exOpts.force {
+			return errhand.BuildDError("%s already exists. Use -f to overwrite.", dest.Path).Build()
+		}
+		if err := dEnv.FS.MkDirs(filepath.Dir(dest.Path)); err != nil {
+			return errhand.VerboseErrorFromError(err)
+		}
+		wr, err := dEnv.FS.OpenForWrite(dest.Path, os.ModePerm)
+		if err != nil {
+			return errhand.BuildDError("Error opening writer for %s.", dest.Path).AddCause(err).Build()
+		}
+		out = wr
+	}
+	out = newCompressingWriter(out, exOpts.compression)
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err == nil {
+		err = dEnv.FS.MkDirs(tmpDir)
+	}
+	if err != nil {
+		out.Close()
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	tw := tar.NewWriter(out)
+	for _, tableName := range exOpts.tarTables {
+		if verr := exportTarFile(ctx, dEnv, tw, tmpDir, tableName, format, exOpts.csvOpts); verr != nil {
+			out.Close()
+			return verr
+		}
+	}
+	err = tw.Close()
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return errhand.BuildDError("Error writing tar stream to %s.", exOpts.DestName()).AddCause(err).Build()
+	}
+	return nil
+}
+
+// exportTarFile exports |tableName| in |format| as a file of the tar stream |tw|, through a temporary file in |tmpDir|.
+func exportTarFile(ctx context.Context, dEnv *env.DoltEnv, tw *tar.Writer, tmpDir, tableName string, format mvdata.DataFormat, csvOpts mvdata.CsvOptions) errhand.VerboseError {
+	f, err := os.CreateTemp(tmpDir, "export-*"+string(format))
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	verr := exportWithOptions(ctx, dEnv, &exportOptions{
+		tableName: tableName,
+		force:     true,
+		dest:      mvdata.FileDataLocation{Path: path, Format: format},
+		csvOpts:   csvOpts,
+	})
+	if verr != nil {
+		return verr
+	}
+
+	f, err = os.Open(path)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     tableName + string(format),
+		Mode:     0644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	})
+	if err == nil {
+		_, err = io.Copy(tw, f)
+	}
+	if err != nil {
+		return errhand.BuildDError("Error writing %s to tar stream.", tableName).AddCause(err).Build()
+	}
+	return nil
+}
+
+// getTableWriter returns the writer of the rows of an export, along with a function which closes the output of a
+// stream export once the writer is closed.
+func getTableWriter(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, rdSchema schema.Schema, exOpts *exportOptions) (table.SqlRowWriter, func() error, errhand.VerboseError) {
+	if stream, ok := exOpts.dest.(mvdata.StreamDataLocation); ok {
+		// the rows writer of a stream doesn't close it, so a compressed stream is closed after the rows are written
+		out := newCompressingWriter(iohelp.NopWrCloser(stream.Writer), exOpts.compression)
+		stream.Writer = out
+		wr, err := stream.NewCreatingWriter(ctx, exOpts, root, rdSchema, editor.Options{Deaf: dEnv.DbEaFactory()}, out)
+		if err != nil {
+			return nil, nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
+		}
+		return wr, out.Close, nil
+	}
+
 	ow, err := exOpts.checkOverwrite(ctx, root, dEnv.FS)
 	if err != nil {
-		return nil, errhand.VerboseErrorFromError(err)
+		return nil, nil, errhand.VerboseErrorFromError(err)
 	}
 	if ow {
-		return nil, errhand.BuildDError("%s already exists. Use -f to overwrite.", exOpts.DestName()).Build()
+		return nil, nil, errhand.BuildDError("%s already exists. Use -f to overwrite.", exOpts.DestName()).Build()
 	}
 
 	err = dEnv.FS.MkDirs(filepath.Dir(exOpts.DestName()))
 	if err != nil {
-		return nil, errhand.VerboseErrorFromError(err)
+		return nil, nil, errhand.VerboseErrorFromError(err)
 	}
 
 	filePath, err := dEnv.FS.Abs(exOpts.DestName())
 	if err != nil {
-		return nil, errhand.VerboseErrorFromError(err)
+		return nil, nil, errhand.VerboseErrorFromError(err)
 	}
 
 	writer, err := dEnv.FS.OpenForWrite(filePath, os.ModePerm)
 	if err != nil {
-		return nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
+		return nil, nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
 	}
 
-	wr, err := exOpts.dest.NewCreatingWriter(ctx, exOpts, root, rdSchema, editor.Options{Deaf: dEnv.DbEaFactory()}, writer)
+	wr, err := exOpts.dest.NewCreatingWriter(ctx, exOpts, root, rdSchema, editor.Options{Deaf: dEnv.DbEaFactory()}, newCompressingWriter(writer, exOpts.compression))
 	if err != nil {
-		return nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
+		return nil, nil, errhand.BuildDError("Error opening writer for %s.", exOpts.DestName()).AddCause(err).Build()
 	}
 
-	return wr, nil
+	return wr, func() error { return nil }, nil
 }
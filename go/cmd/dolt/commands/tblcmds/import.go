@@ -71,6 +71,9 @@ const (
 	ignoreSkippedRows = "ignore-skipped-rows" // alias for quiet
 	disableFkChecks   = "disable-fk-checks"
 	allTextParam      = "all-text"
+	formatParam       = "format"
+	compressParam     = "compress"
+	tarParam          = "tar"
 )
 
 var jsonInputFileHelp = "The expected JSON input file format is:" + `
@@ -85,6 +88,11 @@ var jsonInputFileHelp = "The expected JSON input file format is:" + `
 	}
 
 where column_name is the name of a column of the table being imported and value is the data for that column in the table.
+
+The expected JSON lines (jsonl) input file format has a JSON object of each row on its own line:
+
+	{"column_name":"value", ...}
+	{"column_name":"value", ...}
 `
 
 var importDocs = cli.CommandDocumentationContent{
@@ -109,7 +117,9 @@ During import, if there is an error importing any row, the import will be aborte
 		`
 ` + jsonInputFileHelp +
 		`
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, xlsx).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter.
+
+If {{.LessThan}}file{{.GreaterThan}} is {{.EmphasisLeft}}-{{.EmphasisRight}} or is omitted, rows are read from stdin as they arrive, so that the output of another command can be imported without a temporary file, e.g. {{.EmphasisLeft}}curl https://example.com/rows.jsonl | dolt table import -u --file-type jsonl mytable -{{.EmphasisRight}}. The format of stdin is csv unless given with {{.EmphasisLeft}}--file-type{{.EmphasisRight}}. Csv, psv, json and jsonl are streamed with bounded memory. A parquet file can't be read from a pipe, since its metadata is at its end, but it can be redirected to stdin. Xlsx files can't be read from stdin.`,
 
 	Synopsis: []string{
 		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--all-text] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue]  [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
@@ -224,7 +234,7 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		if val.Format == mvdata.XlsxFile {
 			// table name must match sheet name currently
 			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
-		} else if val.Format == mvdata.JsonFile {
+		} else if val.Format == mvdata.JsonFile || val.Format == mvdata.JsonlFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
 		} else if val.Format == mvdata.ParquetFile {
 			srcOpts = mvdata.ParquetOptions{TableName: tableName, SchFile: schemaFile}
@@ -239,6 +249,12 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
 		}
+
+		if val.Format == mvdata.JsonFile || val.Format == mvdata.JsonlFile {
+			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.ParquetFile {
+			srcOpts = mvdata.ParquetOptions{TableName: tableName, SchFile: schemaFile}
+		}
 	}
 
 	var moveOp mvdata.TableImportOp
@@ -338,17 +354,24 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		}
 	}
 
-	if srcFileLoc, isFileType := srcLoc.(mvdata.FileDataLocation); isFileType {
-		if srcFileLoc.Format == mvdata.SqlFile {
-			return errhand.BuildDError("For SQL import, please pipe SQL input files to `dolt sql`").Build()
+	var srcFormat mvdata.DataFormat
+	switch val := srcLoc.(type) {
+	case mvdata.FileDataLocation:
+		srcFormat = val.Format
+	case mvdata.StreamDataLocation:
+		srcFormat = val.Format
+		if srcFormat == mvdata.XlsxFile {
+			return errhand.BuildDError("xlsx files can't be imported from stdin").Build()
 		}
+	}
 
-		_, hasSchema := apr.GetValue(schemaParam)
-		if srcFileLoc.Format == mvdata.JsonFile && apr.Contains(createParam) && !hasSchema {
-			return errhand.BuildDError("Please specify schema file for .json tables.").Build()
-		} else if srcFileLoc.Format == mvdata.ParquetFile && apr.Contains(createParam) && !hasSchema {
-			return errhand.BuildDError("Please specify schema file for .parquet tables.").Build()
-		}
+	if srcFormat == mvdata.SqlFile {
+		return errhand.BuildDError("For SQL import, please pipe SQL input files to `dolt sql`").Build()
+	}
+
+	_, hasSchema := apr.GetValue(schemaParam)
+	if (srcFormat == mvdata.JsonFile || srcFormat == mvdata.JsonlFile || srcFormat == mvdata.ParquetFile) && apr.Contains(createParam) && !hasSchema {
+		return errhand.BuildDError("Please specify schema file for %s tables.", srcFormat).Build()
 	}
 
 	return nil
@@ -390,6 +413,7 @@ func (cmd ImportCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(fileTypeParam, "", "file_type", "Explicitly define the type of the file if it can't be inferred from the file extension.")
 	ap.SupportsString(delimParam, "", "delimiter", "Specify a delimiter for a csv style file with a non-comma delimiter.")
 	ap.SupportsFlag(allTextParam, "", "Treats all fields as text. Can only be used when creating a table.")
+	ap.SupportsString(commands.OutputFormatFlag, "", "format", commands.OutputFormatHelp)
 	return ap
 }
 
@@ -404,6 +428,26 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, importDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	jsonOutput, verr := commands.JsonOutputRequested(apr)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if !jsonOutput {
+		return importTable(ctx, apr, usage, dEnv, nil)
+	}
+
+	res := &commands.ImportResult{}
+	restore := commands.RedirectOutputToStderr()
+	exitCode := res.Finish(importTable(ctx, apr, usage, dEnv, res))
+	restore()
+	commands.PrintJsonResult(res)
+	return exitCode
+}
+
+// importTable runs the import described by |apr|, printing its outcome, and records the outcome in |res| if it's not
+// nil.
+func importTable(ctx context.Context, apr *argparser.ArgParseResults, usage cli.UsagePrinter, dEnv *env.DoltEnv, res *commands.ImportResult) int {
 	var verr errhand.VerboseError
 
 	dEnv, err := commands.MaybeMigrateEnv(ctx, dEnv)
@@ -422,40 +466,56 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return commands.HandleVErrAndExitCode(verr, usage)
 	}
 
+	skipped, verr := importWithOptions(ctx, dEnv, mvOpts, res)
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.PrintErrln()
+
+	if skipped > 0 {
+		cli.PrintErrln(color.YellowString("Lines skipped: %d", skipped))
+	}
+	cli.Println(color.CyanString("Import completed successfully."))
+
+	return 0
+}
+
+// importWithOptions imports into the working set as described by |mvOpts|, returning the number of rows skipped, and
+// records the outcome in |res| if it's not nil.
+func importWithOptions(ctx context.Context, dEnv *env.DoltEnv, mvOpts *importOptions, res *commands.ImportResult) (int64, errhand.VerboseError) {
 	root, err := dEnv.WorkingRoot(ctx)
 	if err != nil {
-		verr = errhand.BuildDError("Unable to get the working root value for this data repository.").AddCause(err).Build()
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return 0, errhand.BuildDError("Unable to get the working root value for this data repository.").AddCause(err).Build()
 	}
 
 	rd, nDMErr := newImportDataReader(ctx, root, dEnv, mvOpts)
 	if nDMErr != nil {
-		verr = newDataMoverErrToVerr(mvOpts, nDMErr)
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return 0, newDataMoverErrToVerr(mvOpts, nDMErr)
 	}
 
 	wr, nDMErr := newImportSqlEngineMover(ctx, dEnv, rd.GetSchema(), mvOpts)
 	if nDMErr != nil {
-		verr = newDataMoverErrToVerr(mvOpts, nDMErr)
-		return commands.HandleVErrAndExitCode(verr, usage)
+		return 0, newDataMoverErrToVerr(mvOpts, nDMErr)
 	}
 
 	skipped, err := move(ctx, rd, wr, mvOpts)
+	if res != nil {
+		stats := wr.Stats()
+		res.Table = mvOpts.destTableName
+		res.RowsAdded, res.RowsModified = stats.Additions, stats.Modifications
+		res.RowsUnchanged = stats.SameVal + stats.NonExistentDeletes
+		res.RowsProcessed = res.RowsAdded + res.RowsModified + res.RowsUnchanged
+		res.BadRows = skipped
+	}
 	if err != nil {
 		bdr := errhand.BuildDError("\nAn error occurred while moving data")
 		bdr.AddCause(err)
 		bdr.AddDetails("Errors during import can be ignored using '--continue'")
-		return commands.HandleVErrAndExitCode(bdr.Build(), usage)
-	}
-
-	cli.PrintErrln()
-
-	if skipped > 0 {
-		cli.PrintErrln(color.YellowString("Lines skipped: %d", skipped))
+		return skipped, bdr.Build()
 	}
-	cli.Println(color.CyanString("Import completed successfully."))
 
-	return 0
+	return skipped, nil
 }
 
 var displayStrLen int
@@ -545,18 +605,19 @@ func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema sc
 	return mv, nil
 }
 
-type badRowFn func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool)
+type badRowFn func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, loc table.SourceLocation, err error) (quit bool)
 
 func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableWriter, options *importOptions) (int64, error) {
 	g, ctx := errgroup.WithContext(ctx)
 
 	// Set up the necessary data points for the import job
 	parsedRowChan := make(chan sql.Row)
+	locations := &table.RowLocations{}
 	var rowErr error
 	var printBadRowsStarted bool
 	var badCount int64
 
-	badRowCB := func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool) {
+	badRowCB := func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, loc table.SourceLocation, err error) (quit bool) {
 		// record the first error encountered unless asked to ignore it
 		if row != nil && rowErr == nil && !options.contOnErr {
 			var sqlRowWithColumns []string
@@ -569,7 +630,7 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 			}
 			formattedSqlRow := strings.Join(sqlRowWithColumns, "")
 
-			rowErr = fmt.Errorf("A bad row was encountered inserting into table %s (on line %d):\n%s", tableName, lineNumber, formattedSqlRow)
+			rowErr = fmt.Errorf("A bad row was encountered inserting into table %s (on %s):\n%s", tableName, loc, formattedSqlRow)
 			if wie, ok := err.(sql.WrappedInsertError); ok {
 				if e, ok := wie.Cause.(*errors.Error); ok {
 					if ue, ok := e.Cause().(sql.UniqueKeyError); ok {
@@ -596,7 +657,7 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 			printBadRowsStarted = true
 		}
 
-		cli.PrintErrln(sql.FormatRow(row))
+		cli.PrintErrln(fmt.Sprintf("%s (%s)", sql.FormatRow(row), loc))
 
 		return false
 	}
@@ -605,12 +666,12 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 	g.Go(func() error {
 		defer close(parsedRowChan)
 
-		return moveRows(ctx, wr, rd, options, parsedRowChan, badRowCB)
+		return moveRows(ctx, wr, rd, options, parsedRowChan, locations, badRowCB)
 	})
 
 	// Start the group that writes rows
 	g.Go(func() error {
-		err := wr.WriteRows(ctx, parsedRowChan, badRowCB)
+		err := wr.WriteRows(ctx, parsedRowChan, locations, badRowCB)
 		if err != nil {
 			return err
 		}
@@ -645,6 +706,7 @@ func moveRows(
 	rd table.SqlRowReader,
 	options *importOptions,
 	parsedRowChan chan sql.Row,
+	locations *table.RowLocations,
 	badRowCb badRowFn,
 ) error {
 	rdSqlSch, err := sqlutil.FromDoltSchema("", options.destTableName, rd.GetSchema())
@@ -652,6 +714,7 @@ func moveRows(
 		return err
 	}
 
+	locator, _ := rd.(table.SourceLocator)
 	line := 1
 
 	for {
@@ -661,9 +724,17 @@ func moveRows(
 		}
 		line += 1
 
+		// without a locator, assume each row is a line following a header line
+		loc := table.SourceLocation{Line: line, Record: line - 1}
+		if l, ok := table.GetBadRowLocation(err); ok {
+			loc = l
+		} else if locator != nil {
+			loc = locator.Location()
+		}
+
 		if err != nil {
 			if table.IsBadRow(err) {
-				quit := badRowCb(sqlRow, rdSqlSch, options.destTableName, line, err)
+				quit := badRowCb(sqlRow, rdSqlSch, options.destTableName, loc, err)
 				if quit {
 					return err
 				}
@@ -676,6 +747,7 @@ func moveRows(
 				return err
 			}
 
+			locations.Push(loc)
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
@@ -0,0 +1,483 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tblcmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/mvdata"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+const (
+	messageParam    = "message"
+	skipCommitParam = "skip-commit"
+)
+
+var syncDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Imports and exports the tables described by a manifest in one commit`,
+	LongDesc: `{{.EmphasisLeft}}dolt table sync{{.EmphasisRight}} runs the table imports and exports described by the YAML manifest {{.LessThan}}manifest{{.GreaterThan}}, in order, and commits the imported tables in a single dolt commit.
+
+If any import or export fails, the working set is restored to what it was before the sync, so no table is changed. Files already written by exports earlier in the manifest are not removed.
+
+The manifest has the form:
+
+	message: Nightly sync
+	tables:
+	  - table: people
+	    import: data/people.csv
+	    mode: update
+	    mappings:
+	      first: first_name
+	  - table: orders
+	    import: data/orders.psv
+	    mode: create
+	    pk: [id]
+	    force: true
+	  - table: totals
+	    export: out/totals.json
+	    force: true
+
+Each entry imports into or exports from {{.EmphasisLeft}}table{{.EmphasisRight}}, and has exactly one of {{.EmphasisLeft}}import{{.EmphasisRight}}, the file to import, or {{.EmphasisLeft}}export{{.EmphasisRight}}, the file to export to. Relative paths are relative to the directory of the manifest. The options of an import are those of {{.EmphasisLeft}}dolt table import{{.EmphasisRight}}:
+
+	mode               create, update, replace or append. Defaults to update.
+	file_type          The type of the file, if it can't be inferred from its extension.
+	delim              The delimiter of a csv style file with a non-comma delimiter.
+	schema             A schema file for the table, when creating it.
+	pk                 The primary key columns, when creating the table.
+	map                A mapping file from the fields of the file to the columns of the table.
+	mappings           A mapping from the fields of the file to the columns of the table, applied after the mapping file.
+	force              Overwrite the table if it exists, when creating it.
+	continue           Skip rows which can't be imported.
+	quiet              Don't print the rows skipped.
+	disable_fk_checks  Disable foreign key checks.
+	all_text           Create all columns as text, when creating the table.
+
+An export supports {{.EmphasisLeft}}file_type{{.EmphasisRight}} and {{.EmphasisLeft}}force{{.EmphasisRight}}, which overwrites the file if it exists.
+
+The commit message is the manifest's {{.EmphasisLeft}}message{{.EmphasisRight}}, unless {{.EmphasisLeft}}--message{{.EmphasisRight}} is given. Only the imported tables are staged, but anything already staged is committed too. Use {{.EmphasisLeft}}--skip-commit{{.EmphasisRight}} to leave the imported tables in the working set instead.`,
+
+	Synopsis: []string{
+		"[-m {{.LessThan}}message{{.GreaterThan}}] [--skip-commit] {{.LessThan}}manifest{{.GreaterThan}}",
+	},
+}
+
+// syncManifest is the manifest of dolt table sync.
+type syncManifest struct {
+	Message string      `yaml:"message,omitempty"`
+	Tables  []syncTable `yaml:"tables"`
+}
+
+// syncTable is one import or export of a sync manifest.
+type syncTable struct {
+	Table           string            `yaml:"table"`
+	Import          string            `yaml:"import,omitempty"`
+	Export          string            `yaml:"export,omitempty"`
+	Mode            string            `yaml:"mode,omitempty"`
+	FileType        string            `yaml:"file_type,omitempty"`
+	Delim           string            `yaml:"delim,omitempty"`
+	Schema          string            `yaml:"schema,omitempty"`
+	PrimaryKeys     []string          `yaml:"pk,omitempty"`
+	MappingFile     string            `yaml:"map,omitempty"`
+	Mappings        map[string]string `yaml:"mappings,omitempty"`
+	Force           bool              `yaml:"force,omitempty"`
+	Continue        bool              `yaml:"continue,omitempty"`
+	Quiet           bool              `yaml:"quiet,omitempty"`
+	DisableFkChecks bool              `yaml:"disable_fk_checks,omitempty"`
+	AllText         bool              `yaml:"all_text,omitempty"`
+}
+
+var syncImportModes = map[string]string{
+	"create":  createParam,
+	"update":  updateParam,
+	"replace": replaceParam,
+	"append":  appendParam,
+}
+
+// syncStep is a validated import or export of a sync manifest.
+type syncStep struct {
+	table    string
+	path     string
+	isImport bool
+	// the arguments of the import or export, as parsed by the parser of dolt table import or dolt table export
+	apr      *argparser.ArgParseResults
+	mappings map[string]string
+	dest     mvdata.DataLocation
+	// the compression of an export
+	compression string
+}
+
+// parseSyncManifest reads the sync manifest in |r|, rejecting unknown fields.
+func parseSyncManifest(r io.Reader) (*syncManifest, error) {
+	manifest := &syncManifest{}
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(manifest); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("manifest is empty")
+		}
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// steps validates the imports and exports of the manifest, whose relative paths are relative to |dir|, and returns
+// them in order.
+func (m *syncManifest) steps(dir string) ([]syncStep, error) {
+	if len(m.Tables) == 0 {
+		return nil, fmt.Errorf("manifest has no tables")
+	}
+	steps := make([]syncStep, len(m.Tables))
+	for i, st := range m.Tables {
+		step, err := st.step(dir)
+		if err != nil {
+			return nil, fmt.Errorf("tables[%d]: %w", i, err)
+		}
+		steps[i] = step
+	}
+	return steps, nil
+}
+
+// step validates the import or export and returns it as a syncStep.
+func (st syncTable) step(dir string) (syncStep, error) {
+	if st.Table == "" {
+		return syncStep{}, fmt.Errorf("table is required")
+	}
+	if (st.Import == "") == (st.Export == "") {
+		return syncStep{}, fmt.Errorf("exactly one of import and export is required for table %s", st.Table)
+	}
+
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(dir, path)
+	}
+
+	if st.Export != "" {
+		importOnly := []struct {
+			opt string
+			set bool
+		}{
+			{"mode", st.Mode != ""},
+			{"delim", st.Delim != ""},
+			{"schema", st.Schema != ""},
+			{"pk", len(st.PrimaryKeys) > 0},
+			{"map", st.MappingFile != ""},
+			{"mappings", len(st.Mappings) > 0},
+			{"continue", st.Continue},
+			{"quiet", st.Quiet},
+			{"disable_fk_checks", st.DisableFkChecks},
+			{"all_text", st.AllText},
+		}
+		for _, o := range importOnly {
+			if o.set {
+				return syncStep{}, fmt.Errorf("%s is not supported for the export of table %s", o.opt, st.Table)
+			}
+		}
+
+		path := resolve(st.Export)
+		args := []string{}
+		if st.Force {
+			args = append(args, "--"+forceParam)
+		}
+		if st.FileType != "" {
+			args = append(args, "--"+fileTypeParam, st.FileType)
+		}
+		args = append(args, st.Table, path)
+
+		apr, err := ExportCmd{}.ArgParser().Parse(args)
+		if err != nil {
+			return syncStep{}, err
+		}
+		if !doltdb.IsValidTableName(st.Table) {
+			return syncStep{}, fmt.Errorf("'%s' is not a valid table name", st.Table)
+		}
+		dest, compression, verr := getExportDestination(apr)
+		if verr != nil {
+			return syncStep{}, fmt.Errorf("cannot export table %s to %s: %w", st.Table, st.Export, verr)
+		}
+		return syncStep{table: st.Table, path: path, apr: apr, dest: dest, compression: compression}, nil
+	}
+
+	mode := st.Mode
+	if mode == "" {
+		mode = "update"
+	}
+	modeParam, ok := syncImportModes[strings.ToLower(mode)]
+	if !ok {
+		return syncStep{}, fmt.Errorf("invalid mode %s for the import of table %s, valid modes are create, update, replace and append", mode, st.Table)
+	}
+
+	path := resolve(st.Import)
+	args := []string{"--" + modeParam}
+	values := [][2]string{
+		{fileTypeParam, st.FileType},
+		{delimParam, st.Delim},
+		{schemaParam, resolve(st.Schema)},
+		{primaryKeyParam, strings.Join(st.PrimaryKeys, ",")},
+		{mappingFileParam, resolve(st.MappingFile)},
+	}
+	for _, v := range values {
+		if v[1] != "" {
+			args = append(args, "--"+v[0], v[1])
+		}
+	}
+	flags := []struct {
+		param string
+		set   bool
+	}{
+		{forceParam, st.Force},
+		{contOnErrParam, st.Continue},
+		{quiet, st.Quiet},
+		{disableFkChecks, st.DisableFkChecks},
+		{allTextParam, st.AllText},
+	}
+	for _, f := range flags {
+		if f.set {
+			args = append(args, "--"+f.param)
+		}
+	}
+	args = append(args, st.Table, path)
+
+	apr, err := ImportCmd{}.ArgParser().Parse(args)
+	if err != nil {
+		return syncStep{}, err
+	}
+	if verr := validateImportArgs(apr); verr != nil {
+		return syncStep{}, verr
+	}
+	return syncStep{table: st.Table, path: path, isImport: true, apr: apr, mappings: st.Mappings}, nil
+}
+
+// run runs the import or export.
+func (s syncStep) run(ctx context.Context, dEnv *env.DoltEnv) errhand.VerboseError {
+	if !s.isImport {
+		cli.PrintErrf("Exporting %s to %s\n", s.table, s.path)
+		return exportWithOptions(ctx, dEnv, &exportOptions{
+			tableName:   s.table,
+			force:       s.apr.Contains(forceParam),
+			dest:        s.dest,
+			compression: s.compression,
+		})
+	}
+
+	cli.PrintErrf("Importing %s from %s\n", s.table, s.path)
+	mvOpts, verr := getImportMoveOptions(ctx, s.apr, dEnv)
+	if verr != nil {
+		return verr
+	}
+	for from, to := range s.mappings {
+		mvOpts.nameMapper[from] = to
+	}
+	skipped, verr := importWithOptions(ctx, dEnv, mvOpts, nil)
+	if verr != nil {
+		return verr
+	}
+	cli.PrintErrln()
+	if skipped > 0 {
+		cli.PrintErrln(color.YellowString("Lines skipped: %d", skipped))
+	}
+	return nil
+}
+
+type SyncCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd SyncCmd) Name() string {
+	return "sync"
+}
+
+// Description returns a description of the command
+func (cmd SyncCmd) Description() string {
+	return "Imports and exports the tables described by a manifest in one commit."
+}
+
+func (cmd SyncCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(syncDocs, ap)
+}
+
+func (cmd SyncCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"manifest", "The YAML manifest of the imports and exports to run."})
+	ap.SupportsString(messageParam, "m", "msg", "The commit message, instead of the message of the manifest.")
+	ap.SupportsFlag(skipCommitParam, "", "Leave the imported tables in the working set, rather than committing them.")
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd SyncCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TABLE_IMPORT
+}
+
+// Exec executes the command
+func (cmd SyncCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, syncDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("expected a manifest").SetPrintUsage().Build(), usage)
+	}
+
+	dEnv, err := commands.MaybeMigrateEnv(ctx, dEnv)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("could not load manifest for gc").AddCause(err).Build(), usage)
+	}
+
+	manifestPath, err := dEnv.FS.Abs(apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	manifest, steps, err := readSyncManifest(manifestPath)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("invalid manifest %s", apr.Arg(0)).AddCause(err).Build(), usage)
+	}
+
+	message := apr.GetValueOrDefault(messageParam, manifest.Message)
+	if message == "" {
+		message = fmt.Sprintf("Sync tables from %s", filepath.Base(manifestPath))
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("Unable to get the working set for this data repository.").AddCause(err).Build(), usage)
+	}
+	if ws.MergeActive() {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: cannot sync tables while merging").Build(), usage)
+	}
+
+	var imported []doltdb.TableName
+	for _, step := range steps {
+		if verr := step.run(ctx, dEnv); verr != nil {
+			return commands.HandleVErrAndExitCode(restoreWorkingSet(ctx, dEnv, ws, verr), usage)
+		}
+		if step.isImport {
+			imported = append(imported, doltdb.TableName{Name: step.table})
+		}
+	}
+
+	if len(imported) > 0 && !apr.Contains(skipCommitParam) {
+		if verr := commitSyncedTables(ctx, dEnv, imported, message); verr != nil {
+			return commands.HandleVErrAndExitCode(restoreWorkingSet(ctx, dEnv, ws, verr), usage)
+		}
+	}
+
+	cli.Println(color.CyanString("Sync completed successfully."))
+	return 0
+}
+
+// readSyncManifest reads and validates the manifest at |path|.
+func readSyncManifest(path string) (*syncManifest, []syncStep, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	manifest, err := parseSyncManifest(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	steps, err := manifest.steps(filepath.Dir(path))
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, steps, nil
+}
+
+// restoreWorkingSet restores the working set to |ws|, its value before the sync, after the sync failed with |verr|.
+func restoreWorkingSet(ctx context.Context, dEnv *env.DoltEnv, ws *doltdb.WorkingSet, verr errhand.VerboseError) errhand.VerboseError {
+	if err := dEnv.UpdateWorkingSet(ctx, ws); err != nil {
+		return errhand.BuildDError("error: sync failed, and the working set could not be restored").AddCause(verr).AddDetails("restore failed: %s", err.Error()).Build()
+	}
+	return errhand.BuildDError("error: sync failed, no tables were changed").AddCause(verr).Build()
+}
+
+// commitSyncedTables stages |tables| and commits them with |message|.
+func commitSyncedTables(ctx context.Context, dEnv *env.DoltEnv, tables []doltdb.TableName, message string) errhand.VerboseError {
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	roots, err = actions.StageTables(ctx, roots, tables, false)
+	if err != nil {
+		return errhand.BuildDError("error: failed to stage the imported tables").AddCause(err).Build()
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	prevHash, err := ws.HashOf()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	name, email, err := env.GetNameAndEmail(dEnv.Config)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	pendingCommit, err := actions.GetCommitStaged(ctx, roots, ws, nil, dEnv.DoltDB, actions.CommitStagedProps{
+		Message: message,
+		Date:    datas.CommitterDate(),
+		Name:    name,
+		Email:   email,
+	})
+	if actions.IsNothingStaged(err) {
+		cli.PrintErrln("The imports changed no tables, nothing to commit.")
+		return nil
+	} else if err != nil {
+		return errhand.BuildDError("error: failed to commit the imported tables").AddCause(err).Build()
+	}
+
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+
+	_, err = dEnv.DoltDB.CommitWithWorkingSet(
+		ctx,
+		headRef,
+		ws.Ref(),
+		pendingCommit,
+		ws.WithStagedRoot(pendingCommit.Roots.Staged).WithWorkingRoot(pendingCommit.Roots.Working),
+		prevHash,
+		dEnv.NewWorkingSetMeta("dolt table sync"),
+		nil,
+	)
+	if err != nil {
+		return errhand.BuildDError("error: failed to commit the imported tables").AddCause(err).Build()
+	}
+	return nil
+}
@@ -24,4 +24,5 @@ var Commands = cli.NewSubCommandHandler("table", "Commands for copying, renaming
 	RmCmd{},
 	MvCmd{},
 	CpCmd{},
+	SyncCmd{},
 })
@@ -0,0 +1,289 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v57/github"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/gpg"
+	"github.com/dolthub/dolt/go/libraries/utils/selfupdate"
+)
+
+const upgradeCheckFlag = "check"
+
+var upgradeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Upgrade dolt to the latest release.",
+	LongDesc: `Downloads the latest release of dolt for this platform from GitHub and replaces the running dolt binary with it.
+
+Releases are installed from the release channel set by {{.EmphasisLeft}}upgrade.channel{{.EmphasisRight}} in the global config: {{.EmphasisLeft}}stable{{.EmphasisRight}}, the default, for the latest full release, or {{.EmphasisLeft}}prerelease{{.EmphasisRight}} to include pre-releases.
+
+A release is only installed if it's verified: its {{.EmphasisLeft}}dolt-checksums.txt.asc{{.EmphasisRight}} asset must have a valid gpg signature by the release signing key, and the SHA-256 checksum of the downloaded archive must match the one it lists. The release signing key must be in your gpg keyring, and {{.EmphasisLeft}}upgrade.signingkey{{.EmphasisRight}} in the global config must be set to its full fingerprint. Signatures by any other key, including other keys in your keyring, are rejected. The binary is swapped atomically, so an interrupted upgrade leaves the old binary in place.
+
+With {{.EmphasisLeft}}--check{{.EmphasisRight}}, nothing is installed. The command prints whether a newer release is available, and exits with status 1 if one is, e.g. to fail a CI job building an image with an old version of dolt.`,
+	Synopsis: []string{
+		`[--check]`,
+	},
+}
+
+type UpgradeCmd struct {
+	VersionStr string
+}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd UpgradeCmd) Name() string {
+	return "upgrade"
+}
+
+// Description returns a description of the command
+func (cmd UpgradeCmd) Description() string {
+	return upgradeDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd UpgradeCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd UpgradeCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(upgradeDocs, ap)
+}
+
+func (cmd UpgradeCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(upgradeCheckFlag, "", "Only check whether a newer release is available, exiting with status 1 if one is.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd UpgradeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, upgradeDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	channel, signingKey := selfupdate.StableChannel, ""
+	if globalCfg, ok := dEnv.Config.GetConfig(env.GlobalConfig); ok {
+		channel = env.GetStringOrDefault(globalCfg, config.UpgradeChannel, selfupdate.StableChannel)
+		signingKey = env.GetStringOrDefault(globalCfg, config.UpgradeSigningKey, "")
+	}
+	if !selfupdate.IsValidChannel(channel) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: invalid %s '%s', must be %s or %s", config.UpgradeChannel, channel, selfupdate.StableChannel, selfupdate.PrereleaseChannel).Build(), usage)
+	}
+
+	release, err := latestDoltRelease(ctx, channel)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to find the latest %s release of dolt", channel).AddCause(err).Build(), usage)
+	}
+	latest := strings.TrimPrefix(release.GetTagName(), "v")
+
+	outOfDate, verr := isOutOfDate(cmd.VersionStr, latest)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if !outOfDate {
+		cli.Printf("dolt is up to date. Version %s is the latest %s release.\n", cmd.VersionStr, channel)
+		return 0
+	}
+	if apr.Contains(upgradeCheckFlag) {
+		cli.Println(color.YellowString("dolt version %s is available on the %s channel. This is version %s.", latest, channel, cmd.VersionStr))
+		return 1
+	}
+
+	fingerprint, ok := normalizeFingerprint(signingKey)
+	if !ok {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: %s must be set to the full fingerprint of the dolt release signing key, to verify the release with", config.UpgradeSigningKey).Build(), usage)
+	}
+
+	verr = upgradeToRelease(ctx, release, fingerprint)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	cli.Println(color.GreenString("Upgraded dolt from version %s to %s.", cmd.VersionStr, latest))
+	return 0
+}
+
+// latestDoltRelease returns the newest release of dolt on |channel|.
+func latestDoltRelease(ctx context.Context, channel string) (*github.RepositoryRelease, error) {
+	client := github.NewClient(nil)
+	if channel == selfupdate.StableChannel {
+		release, _, err := client.Repositories.GetLatestRelease(ctx, "dolthub", "dolt")
+		return release, err
+	}
+
+	releases, _, err := client.Repositories.ListReleases(ctx, "dolthub", "dolt", &github.ListOptions{PerPage: 20})
+	if err != nil {
+		return nil, err
+	}
+	for _, release := range releases {
+		if !release.GetDraft() {
+			return release, nil
+		}
+	}
+	return nil, fmt.Errorf("no releases found")
+}
+
+// upgradeToRelease verifies the archive of |release| for this platform, with the signing key whose fingerprint is
+// |fingerprint|, and replaces the running binary with the one it contains.
+func upgradeToRelease(ctx context.Context, release *github.RepositoryRelease, fingerprint string) errhand.VerboseError {
+	archiveName := selfupdate.ArchiveName(runtime.GOOS, runtime.GOARCH)
+	var archiveURL, checksumsURL string
+	for _, asset := range release.Assets {
+		switch asset.GetName() {
+		case archiveName:
+			archiveURL = asset.GetBrowserDownloadURL()
+		case selfupdate.ChecksumsAssetName:
+			checksumsURL = asset.GetBrowserDownloadURL()
+		}
+	}
+	if archiveURL == "" {
+		return errhand.BuildDError("error: release %s has no build for %s/%s", release.GetTagName(), runtime.GOOS, runtime.GOARCH).Build()
+	}
+	if checksumsURL == "" {
+		return errhand.BuildDError("error: release %s has no %s, so it can't be verified", release.GetTagName(), selfupdate.ChecksumsAssetName).Build()
+	}
+
+	signed, err := downloadToMemory(ctx, checksumsURL)
+	if err != nil {
+		return errhand.BuildDError("error: failed to download %s", selfupdate.ChecksumsAssetName).AddCause(err).Build()
+	}
+	checksumsContent, signers, err := gpg.VerifyContentSigners(ctx, signed)
+	if err != nil {
+		return errhand.BuildDError("error: failed to verify the signature of %s. Is the release signing key in your gpg keyring?", selfupdate.ChecksumsAssetName).AddCause(err).Build()
+	}
+	if !signedByKey(signers, fingerprint) {
+		return errhand.BuildDError("error: %s isn't signed by %s, the key set by %s", selfupdate.ChecksumsAssetName, fingerprint, config.UpgradeSigningKey).Build()
+	}
+	checksums, err := selfupdate.ParseChecksums(checksumsContent)
+	if err != nil {
+		return errhand.BuildDError("error: failed to parse %s", selfupdate.ChecksumsAssetName).AddCause(err).Build()
+	}
+	checksum, ok := checksums[archiveName]
+	if !ok {
+		return errhand.BuildDError("error: %s has no checksum for %s", selfupdate.ChecksumsAssetName, archiveName).Build()
+	}
+
+	exePath, err := os.Executable()
+	if err == nil {
+		exePath, err = filepath.EvalSymlinks(exePath)
+	}
+	if err != nil {
+		return errhand.BuildDError("error: failed to find the dolt binary").AddCause(err).Build()
+	}
+
+	archivePath, err := downloadToTempFile(ctx, archiveURL, archiveName)
+	if err != nil {
+		return errhand.BuildDError("error: failed to download %s", archiveName).AddCause(err).Build()
+	}
+	defer os.Remove(archivePath)
+
+	if err = selfupdate.VerifyChecksum(archivePath, checksum); err != nil {
+		return errhand.BuildDError("error: failed to verify %s", archiveName).AddCause(err).Build()
+	}
+	if err = selfupdate.Install(archivePath, exePath); err != nil {
+		return errhand.BuildDError("error: failed to replace %s", exePath).AddCause(err).Build()
+	}
+	return nil
+}
+
+// normalizeFingerprint returns the key fingerprint |key| in upper case without spaces or a 0x prefix, and whether it's
+// a full fingerprint. Short key ids aren't accepted, as other keys can be made to have the same id.
+func normalizeFingerprint(key string) (string, bool) {
+	fingerprint := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(key), " ", ""))
+	fingerprint = strings.TrimPrefix(fingerprint, "0X")
+	if len(fingerprint) != 40 && len(fingerprint) != 64 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(fingerprint); err != nil {
+		return "", false
+	}
+	return fingerprint, true
+}
+
+// signedByKey returns whether |fingerprint|, a normalized key fingerprint, is one of the fingerprints of the keys
+// which made valid signatures, |signers|.
+func signedByKey(signers []string, fingerprint string) bool {
+	for _, signer := range signers {
+		if strings.EqualFold(signer, fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+func download(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return resp.Body, nil
+}
+
+func downloadToMemory(ctx context.Context, url string) ([]byte, error) {
+	body, err := download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// downloadToTempFile downloads |url| to a new temporary file whose name ends with |name|, and returns its path.
+func downloadToTempFile(ctx context.Context, url, name string) (string, error) {
+	body, err := download(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	f, err := os.CreateTemp("", "*-"+name)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeFingerprint(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+		ok       bool
+	}{
+		{"573DA8C6366D04E35CDB1A44E09A0B208F666373", "573DA8C6366D04E35CDB1A44E09A0B208F666373", true},
+		{"0x573da8c6366d04e35cdb1a44e09a0b208f666373", "573DA8C6366D04E35CDB1A44E09A0B208F666373", true},
+		{"573D A8C6 366D 04E3 5CDB  1A44 E09A 0B20 8F66 6373", "573DA8C6366D04E35CDB1A44E09A0B208F666373", true},
+		{"", "", false},
+		{"E09A0B208F666373", "", false},
+		{"8F666373", "", false},
+		{"573DA8C6366D04E35CDB1A44E09A0B208F66637Z", "", false},
+	}
+	for _, test := range tests {
+		fingerprint, ok := normalizeFingerprint(test.key)
+		assert.Equal(t, test.ok, ok, test.key)
+		assert.Equal(t, test.expected, fingerprint, test.key)
+	}
+}
+
+func TestSignedByKey(t *testing.T) {
+	const fingerprint = "573DA8C6366D04E35CDB1A44E09A0B208F666373"
+	assert.True(t, signedByKey([]string{"47A5B2B3D0E1F6C2A7B8C9D0E1F2A3B4C5D6E7F8", fingerprint}, fingerprint))
+	assert.True(t, signedByKey([]string{"573da8c6366d04e35cdb1a44e09a0b208f666373"}, fingerprint))
+	assert.False(t, signedByKey(nil, fingerprint))
+	// a key whose fingerprint merely ends with, or contains, the pinned key's doesn't match
+	assert.False(t, signedByKey([]string{"FFFF" + fingerprint}, fingerprint))
+	assert.False(t, signedByKey([]string{"47A5B2B3D0E1F6C2A7B8C9D0E1F2A3B4C5D6E7F8"}, fingerprint))
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
@@ -63,6 +64,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/diagnostics"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/util/tempfiles"
@@ -113,8 +115,12 @@ var doltSubCommands = []cli.Command{
 	commands.FsckCmd{},
 	commands.FilterBranchCmd{},
 	commands.MergeBaseCmd{},
+	commands.PromoteCmd{},
 	commands.RootsCmd{},
+	commands.SymbolicRefCmd{},
 	commands.VersionCmd{VersionStr: doltversion.Version},
+	commands.BugReportCmd{VersionStr: doltversion.Version},
+	commands.UpgradeCmd{VersionStr: doltversion.Version},
 	commands.DumpCmd{},
 	commands.InspectCmd{},
 	dumpDocsCommand,
@@ -145,7 +151,10 @@ var commandsWithoutCliCtx = []cli.Command{
 	commands.ReadTablesCmd{},
 	commands.FilterBranchCmd{},
 	commands.RootsCmd{},
+	commands.SymbolicRefCmd{},
 	commands.VersionCmd{VersionStr: doltversion.Version},
+	commands.BugReportCmd{VersionStr: doltversion.Version},
+	commands.UpgradeCmd{VersionStr: doltversion.Version},
 	commands.DumpCmd{},
 	commands.InspectCmd{},
 	dumpDocsCommand,
@@ -167,6 +176,8 @@ var commandsWithoutGlobalArgSupport = []cli.Command{
 	credcmds.Commands,
 	sqlserver.SqlServerCmd{VersionStr: doltversion.Version},
 	commands.VersionCmd{VersionStr: doltversion.Version},
+	commands.BugReportCmd{VersionStr: doltversion.Version},
+	commands.UpgradeCmd{VersionStr: doltversion.Version},
 	commands.ConfigCmd{},
 	ci.Commands,
 }
@@ -254,6 +265,13 @@ func main() {
 }
 
 func runMain() int {
+	defer func() {
+		if r := recover(); r != nil {
+			reportCrash(r, debug.Stack())
+			panic(r)
+		}
+	}()
+
 	args := os.Args[1:]
 
 	start := time.Now()
@@ -520,12 +538,16 @@ func runMain() int {
 		return 1
 	}
 
-	strMetricsDisabled := dEnv.Config.GetStringOrDefault(config.MetricsDisabled, "false")
+	crashReportDir = filepath.Join(homeDir, dbfactory.DoltDir, diagnostics.DirName)
+	if doltDir := dEnv.GetDoltDir(); doltDir != "" {
+		crashReportDir = filepath.Join(doltDir, diagnostics.DirName)
+	}
+
 	var metricsEmitter events.Emitter
-	metricsEmitter = events.NewFileEmitter(homeDir, dbfactory.DoltDir)
-	metricsDisabled, err := strconv.ParseBool(strMetricsDisabled)
-	if err != nil || metricsDisabled {
-		metricsEmitter = events.NullEmitter{}
+	metricsEmitter = events.NullEmitter{}
+	if categories, err := events.EnabledCategories(dEnv.Config); err == nil {
+		// only the metrics of the enabled categories are logged to be sent
+		metricsEmitter = events.NewFilteringEmitter(events.NewFileEmitter(homeDir, dbfactory.DoltDir), categories)
 	}
 
 	events.SetGlobalCollector(events.NewCollector(doltversion.Version, metricsEmitter))
@@ -671,6 +693,30 @@ or check the docs for questions about usage.`)
 	return res
 }
 
+// crashReportDir is the directory crash reports are written to, which is in the .dolt directory of the repository dolt
+// is run in, or of the user's home directory outside a repository.
+var crashReportDir string
+
+// reportCrash writes a redacted diagnostic report of the panic |recovered|, raised at |stack|, to crashReportDir. The
+// report is never uploaded; `dolt bugreport` packages it for users to attach to an issue themselves.
+func reportCrash(recovered interface{}, stack []byte) {
+	dir := crashReportDir
+	if dir == "" {
+		homeDir, err := env.GetCurrentUserHomeDir()
+		if err != nil {
+			return
+		}
+		dir = filepath.Join(homeDir, dbfactory.DoltDir, diagnostics.DirName)
+	}
+
+	path, err := diagnostics.WriteReport(filesys.LocalFS, dir, diagnostics.NewReport(doltversion.Version, recovered, stack))
+	if err != nil {
+		return
+	}
+	cli.PrintErrln(color.RedString("dolt crashed. A diagnostic report was saved to %s\n"+
+		"Run `dolt bugreport` to package it for a bug report. Nothing is uploaded automatically.", path))
+}
+
 // buildLateBinder builds a LateBindQueryist for which is used to obtain the Queryist used for the length of the
 // command execution.
 func buildLateBinder(ctx context.Context, cwdFS filesys.Filesys, rootEnv *env.DoltEnv, mrEnv *env.MultiRepoEnv, creds *cli.UserPassword, apr *argparser.ArgParseResults, subcommandName string, verbose bool) (cli.LateBindQueryist, error) {
@@ -824,7 +870,9 @@ func seedGlobalRand() {
 
 // emitUsageEvents is called after a command is run to emit usage events and send them to metrics servers.
 // Two controls of this behavior are possible:
-//  1. The config key |metrics.disabled|, when set to |true|, disables all metrics emission
+//  1. The config key |metrics.disabled|, when set to |true|, disables all metrics emission, and the config keys
+//     |metrics.usage|, |metrics.performance| and |metrics.errors|, when set to |false|, disable the emission of the
+//     metrics of each category
 //  2. The environment key |DOLT_DISABLE_EVENT_FLUSH| allows writing events to disk but not sending them to the server.
 //     This is mostly used for testing.
 func emitUsageEvents(emitter events.Emitter, args []string) {
@@ -42,6 +42,7 @@ const StashFileID = "STSH"
 const StatisticFileID = "STAT"
 const DoltgresRootValueFileID = "DGRV"
 const TupleFileID = "TUPL"
+const ColumnSegmentFileID = "CSEG"
 
 const MessageTypesKind int = 27
 
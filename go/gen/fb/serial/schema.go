@@ -169,7 +169,57 @@ func (rcv *TableSchema) Comment() []byte {
 	return nil
 }
 
-const TableSchemaNumFields = 7
+func (rcv *TableSchema) TargetNodeSize() uint32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.GetUint32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *TableSchema) MutateTargetNodeSize(n uint32) bool {
+	return rcv._tab.MutateUint32Slot(18, n)
+}
+
+func (rcv *TableSchema) ZoneMapColumns(j int) uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetUint64(a + flatbuffers.UOffsetT(j*8))
+	}
+	return 0
+}
+
+func (rcv *TableSchema) ZoneMapColumnsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *TableSchema) MutateZoneMapColumns(j int, n uint64) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateUint64(a+flatbuffers.UOffsetT(j*8), n)
+	}
+	return false
+}
+
+func (rcv *TableSchema) Columnar() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(22))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *TableSchema) MutateColumnar(n bool) bool {
+	return rcv._tab.MutateBoolSlot(22, n)
+}
+
+const TableSchemaNumFields = 10
 
 func TableSchemaStart(builder *flatbuffers.Builder) {
 	builder.StartObject(TableSchemaNumFields)
@@ -204,6 +254,18 @@ func TableSchemaAddHasFeaturesAfterTryAccessors(builder *flatbuffers.Builder, ha
 func TableSchemaAddComment(builder *flatbuffers.Builder, comment flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(comment), 0)
 }
+func TableSchemaAddTargetNodeSize(builder *flatbuffers.Builder, targetNodeSize uint32) {
+	builder.PrependUint32Slot(7, targetNodeSize, 0)
+}
+func TableSchemaAddZoneMapColumns(builder *flatbuffers.Builder, zoneMapColumns flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(8, flatbuffers.UOffsetT(zoneMapColumns), 0)
+}
+func TableSchemaStartZoneMapColumnsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(8, numElems, 8)
+}
+func TableSchemaAddColumnar(builder *flatbuffers.Builder, columnar bool) {
+	builder.PrependBoolSlot(9, columnar, false)
+}
 func TableSchemaEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -667,7 +729,19 @@ func (rcv *Index) TryFulltextInfo(obj *FulltextInfo) (*FulltextInfo, error) {
 	return nil, nil
 }
 
-const IndexNumFields = 12
+func (rcv *Index) Invisible() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(28))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *Index) MutateInvisible(n bool) bool {
+	return rcv._tab.MutateBoolSlot(28, n)
+}
+
+const IndexNumFields = 13
 
 func IndexStart(builder *flatbuffers.Builder) {
 	builder.StartObject(IndexNumFields)
@@ -720,6 +794,9 @@ func IndexAddFulltextKey(builder *flatbuffers.Builder, fulltextKey bool) {
 func IndexAddFulltextInfo(builder *flatbuffers.Builder, fulltextInfo flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(11, flatbuffers.UOffsetT(fulltextInfo), 0)
 }
+func IndexAddInvisible(builder *flatbuffers.Builder, invisible bool) {
+	builder.PrependBoolSlot(12, invisible, false)
+}
 func IndexEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
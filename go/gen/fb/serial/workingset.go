@@ -364,7 +364,53 @@ func (rcv *MergeState) MutateIsCherryPick(n bool) bool {
 	return rcv._tab.MutateBoolSlot(12, n)
 }
 
-const MergeStateNumFields = 5
+func (rcv *MergeState) IsRevert() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *MergeState) MutateIsRevert(n bool) bool {
+	return rcv._tab.MutateBoolSlot(14, n)
+}
+
+func (rcv *MergeState) PendingCherryPickAddrs(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *MergeState) PendingCherryPickAddrsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *MergeState) PendingCherryPickAddrsBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *MergeState) MutatePendingCherryPickAddrs(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+const MergeStateNumFields = 7
 
 func MergeStateStart(builder *flatbuffers.Builder) {
 	builder.StartObject(MergeStateNumFields)
@@ -393,6 +439,15 @@ func MergeStateStartUnmergableTablesVector(builder *flatbuffers.Builder, numElem
 func MergeStateAddIsCherryPick(builder *flatbuffers.Builder, isCherryPick bool) {
 	builder.PrependBoolSlot(4, isCherryPick, false)
 }
+func MergeStateAddIsRevert(builder *flatbuffers.Builder, isRevert bool) {
+	builder.PrependBoolSlot(5, isRevert, false)
+}
+func MergeStateAddPendingCherryPickAddrs(builder *flatbuffers.Builder, pendingCherryPickAddrs flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(pendingCherryPickAddrs), 0)
+}
+func MergeStateStartPendingCherryPickAddrsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
 func MergeStateEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -55,6 +55,15 @@ type ChunkStoreServiceClient interface {
 	ListTableFiles(ctx context.Context, in *ListTableFilesRequest, opts ...grpc.CallOption) (*ListTableFilesResponse, error)
 	RefreshTableFileUrl(ctx context.Context, in *RefreshTableFileUrlRequest, opts ...grpc.CallOption) (*RefreshTableFileUrlResponse, error)
 	AddTableFiles(ctx context.Context, in *AddTableFilesRequest, opts ...grpc.CallOption) (*AddTableFilesResponse, error)
+	// Get the signature of a table file the remote already has, so that a
+	// client can upload new table files as deltas against it. Servers which
+	// do not support delta uploads return UNIMPLEMENTED.
+	GetTableFileSignature(ctx context.Context, in *GetTableFileSignatureRequest, opts ...grpc.CallOption) (*GetTableFileSignatureResponse, error)
+	// List the refs of a repository whose names match any of a list of
+	// patterns, so that a client fetching a few refs does not have to read the
+	// whole ref map of the repository. Servers which do not support filtering
+	// refs return UNIMPLEMENTED.
+	ListRefs(ctx context.Context, in *ListRefsRequest, opts ...grpc.CallOption) (*ListRefsResponse, error)
 }
 
 type chunkStoreServiceClient struct {
@@ -186,6 +195,24 @@ func (c *chunkStoreServiceClient) AddTableFiles(ctx context.Context, in *AddTabl
 	return out, nil
 }
 
+func (c *chunkStoreServiceClient) GetTableFileSignature(ctx context.Context, in *GetTableFileSignatureRequest, opts ...grpc.CallOption) (*GetTableFileSignatureResponse, error) {
+	out := new(GetTableFileSignatureResponse)
+	err := c.cc.Invoke(ctx, "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetTableFileSignature", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chunkStoreServiceClient) ListRefs(ctx context.Context, in *ListRefsRequest, opts ...grpc.CallOption) (*ListRefsResponse, error) {
+	out := new(ListRefsResponse)
+	err := c.cc.Invoke(ctx, "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/ListRefs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChunkStoreServiceServer is the server API for ChunkStoreService service.
 // All implementations must embed UnimplementedChunkStoreServiceServer
 // for forward compatibility
@@ -208,6 +235,15 @@ type ChunkStoreServiceServer interface {
 	ListTableFiles(context.Context, *ListTableFilesRequest) (*ListTableFilesResponse, error)
 	RefreshTableFileUrl(context.Context, *RefreshTableFileUrlRequest) (*RefreshTableFileUrlResponse, error)
 	AddTableFiles(context.Context, *AddTableFilesRequest) (*AddTableFilesResponse, error)
+	// Get the signature of a table file the remote already has, so that a
+	// client can upload new table files as deltas against it. Servers which
+	// do not support delta uploads return UNIMPLEMENTED.
+	GetTableFileSignature(context.Context, *GetTableFileSignatureRequest) (*GetTableFileSignatureResponse, error)
+	// List the refs of a repository whose names match any of a list of
+	// patterns, so that a client fetching a few refs does not have to read the
+	// whole ref map of the repository. Servers which do not support filtering
+	// refs return UNIMPLEMENTED.
+	ListRefs(context.Context, *ListRefsRequest) (*ListRefsResponse, error)
 	mustEmbedUnimplementedChunkStoreServiceServer()
 }
 
@@ -248,6 +284,12 @@ func (UnimplementedChunkStoreServiceServer) RefreshTableFileUrl(context.Context,
 func (UnimplementedChunkStoreServiceServer) AddTableFiles(context.Context, *AddTableFilesRequest) (*AddTableFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTableFiles not implemented")
 }
+func (UnimplementedChunkStoreServiceServer) GetTableFileSignature(context.Context, *GetTableFileSignatureRequest) (*GetTableFileSignatureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTableFileSignature not implemented")
+}
+func (UnimplementedChunkStoreServiceServer) ListRefs(context.Context, *ListRefsRequest) (*ListRefsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefs not implemented")
+}
 func (UnimplementedChunkStoreServiceServer) mustEmbedUnimplementedChunkStoreServiceServer() {}
 
 // UnsafeChunkStoreServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -467,6 +509,42 @@ func _ChunkStoreService_AddTableFiles_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChunkStoreService_GetTableFileSignature_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTableFileSignatureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChunkStoreServiceServer).GetTableFileSignature(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetTableFileSignature",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChunkStoreServiceServer).GetTableFileSignature(ctx, req.(*GetTableFileSignatureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChunkStoreService_ListRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChunkStoreServiceServer).ListRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/ListRefs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChunkStoreServiceServer).ListRefs(ctx, req.(*ListRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChunkStoreService_ServiceDesc is the grpc.ServiceDesc for ChunkStoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -514,6 +592,14 @@ var ChunkStoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTableFiles",
 			Handler:    _ChunkStoreService_AddTableFiles_Handler,
 		},
+		{
+			MethodName: "GetTableFileSignature",
+			Handler:    _ChunkStoreService_GetTableFileSignature_Handler,
+		},
+		{
+			MethodName: "ListRefs",
+			Handler:    _ChunkStoreService_ListRefs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
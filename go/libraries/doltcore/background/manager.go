@@ -0,0 +1,363 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package background schedules maintenance work, such as garbage collection, statistics refreshes and replication,
+// so that it does not starve foreground queries. Work runs as jobs through a |Manager|, which admits a bounded
+// number of jobs at once in order of priority, and throttles the CPU time and IO of the jobs it admits.
+package background
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is the kind of work a background job does. The priority of a job is configured by its kind.
+type Kind string
+
+const (
+	GC          Kind = "gc"
+	Stats       Kind = "stats"
+	Replication Kind = "replication"
+	Export      Kind = "export"
+)
+
+// Priority orders the admission of background jobs. Jobs of higher priority are admitted first, and jobs of
+// |High| priority are exempt from the CPU and IO throttles.
+type Priority int
+
+const (
+	Low Priority = iota
+	Normal
+	High
+)
+
+func (p Priority) String() string {
+	switch p {
+	case Low:
+		return "low"
+	case Normal:
+		return "normal"
+	case High:
+		return "high"
+	default:
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+}
+
+// ParsePriorities parses a comma separated list of |kind=priority| entries, such as "gc=low,replication=high".
+func ParsePriorities(s string) (map[Kind]Priority, error) {
+	res := make(map[Kind]Priority)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, priority, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid background job priority '%s', expected <kind>=<priority>", entry)
+		}
+		var p Priority
+		switch strings.ToLower(strings.TrimSpace(priority)) {
+		case "low":
+			p = Low
+		case "normal":
+			p = Normal
+		case "high":
+			p = High
+		default:
+			return nil, fmt.Errorf("invalid background job priority '%s', expected low, normal or high", priority)
+		}
+		res[Kind(strings.ToLower(strings.TrimSpace(kind)))] = p
+	}
+	return res, nil
+}
+
+// Config configures the admission and throttling of background jobs.
+type Config struct {
+	// MaxJobs is the number of jobs that may run at once. Zero means no limit.
+	MaxJobs int
+	// CPULimit is the percentage of wall clock time, between 1 and 100, that a throttled job may spend working.
+	CPULimit int
+	// IOLimit is the number of bytes per second that throttled jobs may read or write in total. Zero means no limit.
+	IOLimit int64
+	// Priorities overrides the default priority of kinds of jobs.
+	Priorities map[Kind]Priority
+}
+
+// DefaultConfig returns the configuration a |Manager| starts with.
+func DefaultConfig() Config {
+	return Config{
+		MaxJobs:  4,
+		CPULimit: 100,
+	}
+}
+
+var defaultPriorities = map[Kind]Priority{
+	GC:          Low,
+	Stats:       Normal,
+	Replication: High,
+	Export:      Low,
+}
+
+// priority returns the priority of jobs of kind |kind|.
+func (c Config) priority(kind Kind) Priority {
+	if p, ok := c.Priorities[kind]; ok {
+		return p
+	}
+	if p, ok := defaultPriorities[kind]; ok {
+		return p
+	}
+	return Normal
+}
+
+// A Manager runs background jobs. It is safe for concurrent use, and its configuration can be changed while jobs
+// are running.
+type Manager struct {
+	cfg atomic.Pointer[Config]
+
+	mu      sync.Mutex
+	running int
+	waiters []*waiter
+	seq     uint64
+
+	ioMu     sync.Mutex
+	ioTokens float64
+	ioLast   time.Time
+}
+
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ch       chan struct{}
+}
+
+// NewManager returns a Manager with the configuration |cfg|.
+func NewManager(cfg Config) *Manager {
+	m := &Manager{}
+	m.cfg.Store(&cfg)
+	return m
+}
+
+var defaultManager = NewManager(DefaultConfig())
+
+// Default returns the Manager of the process, which runs the server's background jobs.
+func Default() *Manager {
+	return defaultManager
+}
+
+// Config returns the current configuration of |m|.
+func (m *Manager) Config() Config {
+	return *m.cfg.Load()
+}
+
+// SetConfig changes the configuration of |m|. Jobs which are already running are throttled with the new
+// configuration from their next call to |Yield| or |ReportIO|.
+func (m *Manager) SetConfig(cfg Config) {
+	m.cfg.Store(&cfg)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.admitWaiters()
+}
+
+// UpdateConfig changes the configuration of |m| by applying |f| to it.
+func (m *Manager) UpdateConfig(f func(cfg *Config)) {
+	cfg := m.Config()
+	f(&cfg)
+	m.SetConfig(cfg)
+}
+
+// Run runs |f| as a background job of kind |kind|, once the job is admitted. |f| should call |Yield| between units
+// of work, and |ReportIO| with the bytes it reads or writes, passing along the context it is given, so that the job
+// can be throttled. Run returns the error of |f|, or the error of |ctx| if it is done before the job is admitted.
+func (m *Manager) Run(ctx context.Context, kind Kind, f func(ctx context.Context) error) error {
+	priority := m.Config().priority(kind)
+	if err := m.admit(ctx, priority); err != nil {
+		return err
+	}
+	defer m.finish()
+
+	j := &job{kind: kind, priority: priority, m: m, lastYield: time.Now()}
+	return f(context.WithValue(ctx, jobKey{}, j))
+}
+
+// Run runs |f| as a background job with the default Manager, see |Manager.Run|.
+func Run(ctx context.Context, kind Kind, f func(ctx context.Context) error) error {
+	return defaultManager.Run(ctx, kind, f)
+}
+
+func (m *Manager) admit(ctx context.Context, priority Priority) error {
+	m.mu.Lock()
+	if len(m.waiters) == 0 && m.hasCapacity() {
+		m.running++
+		m.mu.Unlock()
+		return nil
+	}
+	m.seq++
+	w := &waiter{priority: priority, seq: m.seq, ch: make(chan struct{})}
+	m.waiters = append(m.waiters, w)
+	sort.SliceStable(m.waiters, func(i, j int) bool {
+		if m.waiters[i].priority != m.waiters[j].priority {
+			return m.waiters[i].priority > m.waiters[j].priority
+		}
+		return m.waiters[i].seq < m.waiters[j].seq
+	})
+	m.mu.Unlock()
+
+	select {
+	case <-w.ch:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		select {
+		case <-w.ch:
+			// We were admitted concurrently with the cancellation; give the slot back.
+			m.running--
+			m.admitWaiters()
+		default:
+			for i := range m.waiters {
+				if m.waiters[i] == w {
+					m.waiters = append(m.waiters[:i], m.waiters[i+1:]...)
+					break
+				}
+			}
+		}
+		return context.Cause(ctx)
+	}
+}
+
+func (m *Manager) finish() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running--
+	m.admitWaiters()
+}
+
+// hasCapacity returns whether another job may start. Must be called with |mu| held.
+func (m *Manager) hasCapacity() bool {
+	maxJobs := m.Config().MaxJobs
+	return maxJobs <= 0 || m.running < maxJobs
+}
+
+// admitWaiters admits waiting jobs, highest priority first, while there is capacity. Must be called with |mu| held.
+func (m *Manager) admitWaiters() {
+	for len(m.waiters) > 0 && m.hasCapacity() {
+		w := m.waiters[0]
+		m.waiters = m.waiters[1:]
+		m.running++
+		close(w.ch)
+	}
+}
+
+// Stats returns the number of jobs which are running and waiting to be admitted.
+func (m *Manager) Stats() (running, waiting int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.running, len(m.waiters)
+}
+
+type jobKey struct{}
+
+type job struct {
+	kind     Kind
+	priority Priority
+	m        *Manager
+
+	mu        sync.Mutex
+	lastYield time.Time
+}
+
+// Yield throttles the CPU time of the background job running with |ctx|, if any. The job pauses for long enough
+// that the time it has worked since its last call to Yield stays within the configured CPU limit. It returns an
+// error if |ctx| is done while the job is paused.
+func Yield(ctx context.Context) error {
+	j, ok := ctx.Value(jobKey{}).(*job)
+	if !ok {
+		return nil
+	}
+	cpuLimit := j.m.Config().CPULimit
+
+	j.mu.Lock()
+	now := time.Now()
+	worked := now.Sub(j.lastYield)
+	j.lastYield = now
+	j.mu.Unlock()
+
+	if j.priority >= High || cpuLimit <= 0 || cpuLimit >= 100 {
+		return nil
+	}
+	pause := worked * time.Duration(100-cpuLimit) / time.Duration(cpuLimit)
+	if err := sleep(ctx, pause); err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	j.lastYield = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+// ReportIO throttles the IO of the background job running with |ctx|, if any, which has read or written |n| bytes.
+// The job pauses for as long as the throttled jobs have used more than the configured IO limit. It returns an
+// error if |ctx| is done while the job is paused.
+func ReportIO(ctx context.Context, n int64) error {
+	j, ok := ctx.Value(jobKey{}).(*job)
+	if !ok || j.priority >= High {
+		return nil
+	}
+	return sleep(ctx, j.m.takeIO(n))
+}
+
+// takeIO takes |n| bytes from the IO budget of the throttled jobs, and returns how long the caller must wait for
+// the budget to be repaid. The budget refills at the IO limit, and holds at most one second's worth of bytes.
+func (m *Manager) takeIO(n int64) time.Duration {
+	limit := m.Config().IOLimit
+	if limit <= 0 {
+		return 0
+	}
+	m.ioMu.Lock()
+	defer m.ioMu.Unlock()
+	now := time.Now()
+	if !m.ioLast.IsZero() {
+		m.ioTokens += now.Sub(m.ioLast).Seconds() * float64(limit)
+	}
+	if m.ioTokens > float64(limit) {
+		m.ioTokens = float64(limit)
+	}
+	m.ioLast = now
+	m.ioTokens -= float64(n)
+	if m.ioTokens >= 0 {
+		return 0
+	}
+	return time.Duration(-m.ioTokens / float64(limit) * float64(time.Second))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
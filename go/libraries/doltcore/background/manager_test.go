@@ -0,0 +1,176 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriorities(t *testing.T) {
+	p, err := ParsePriorities("gc=high, Stats=LOW,replication=normal,")
+	require.NoError(t, err)
+	assert.Equal(t, map[Kind]Priority{GC: High, Stats: Low, Replication: Normal}, p)
+
+	p, err = ParsePriorities("")
+	require.NoError(t, err)
+	assert.Empty(t, p)
+
+	_, err = ParsePriorities("gc")
+	assert.Error(t, err)
+	_, err = ParsePriorities("gc=urgent")
+	assert.Error(t, err)
+}
+
+func TestConfigPriority(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, Low, cfg.priority(GC))
+	assert.Equal(t, Normal, cfg.priority(Stats))
+	assert.Equal(t, High, cfg.priority(Replication))
+	assert.Equal(t, Normal, cfg.priority(Kind("other")))
+
+	cfg.Priorities = map[Kind]Priority{GC: High}
+	assert.Equal(t, High, cfg.priority(GC))
+	assert.Equal(t, Normal, cfg.priority(Stats))
+}
+
+func TestRunAdmitsByPriority(t *testing.T) {
+	m := NewManager(Config{MaxJobs: 1, CPULimit: 100})
+	ctx := context.Background()
+
+	// Hold the only slot while other jobs queue up behind it.
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = m.Run(ctx, Stats, func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	order := make(chan Kind, 3)
+	queue := func(kind Kind, waiting int) {
+		go func() {
+			_ = m.Run(ctx, kind, func(ctx context.Context) error {
+				order <- kind
+				return nil
+			})
+		}()
+		require.Eventually(t, func() bool {
+			_, n := m.Stats()
+			return n == waiting
+		}, time.Second, time.Millisecond)
+	}
+	queue(GC, 1)
+	queue(Stats, 2)
+	queue(Replication, 3)
+
+	close(release)
+	assert.Equal(t, Replication, <-order)
+	assert.Equal(t, Stats, <-order)
+	assert.Equal(t, GC, <-order)
+
+	require.Eventually(t, func() bool {
+		running, waiting := m.Stats()
+		return running == 0 && waiting == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestRunCanceledWhileWaiting(t *testing.T) {
+	m := NewManager(Config{MaxJobs: 1, CPULimit: 100})
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- m.Run(ctx, GC, func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err := m.Run(cctx, GC, func(ctx context.Context) error {
+		t.Fatal("job should not have run")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, waiting := m.Stats()
+	assert.Equal(t, 0, waiting)
+
+	// Raising the limit admits waiting jobs.
+	admitted := make(chan error)
+	go func() {
+		admitted <- m.Run(ctx, GC, func(ctx context.Context) error { return nil })
+	}()
+	require.Eventually(t, func() bool {
+		_, waiting := m.Stats()
+		return waiting == 1
+	}, time.Second, time.Millisecond)
+	m.UpdateConfig(func(cfg *Config) {
+		cfg.MaxJobs = 2
+	})
+	assert.NoError(t, <-admitted)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestYield(t *testing.T) {
+	// Outside of a job, Yield does nothing.
+	require.NoError(t, Yield(context.Background()))
+
+	m := NewManager(Config{CPULimit: 50})
+	err := m.Run(context.Background(), GC, func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		start := time.Now()
+		require.NoError(t, Yield(ctx))
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// High priority jobs are not throttled.
+	err = m.Run(context.Background(), Replication, func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		start := time.Now()
+		require.NoError(t, Yield(ctx))
+		assert.Less(t, time.Since(start), 10*time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestTakeIO(t *testing.T) {
+	m := NewManager(Config{})
+	assert.Equal(t, time.Duration(0), m.takeIO(1<<30))
+
+	m.SetConfig(Config{IOLimit: 1000})
+	// The budget starts empty, so the first bytes are paid for in time.
+	d := m.takeIO(500)
+	assert.InDelta(t, float64(500*time.Millisecond), float64(d), float64(10*time.Millisecond))
+	d = m.takeIO(500)
+	assert.InDelta(t, float64(time.Second), float64(d), float64(20*time.Millisecond))
+}
@@ -17,19 +17,26 @@ package cherry_pick
 import (
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 // ErrCherryPickUncommittedChanges is returned when a cherry-pick is attempted without a clean working set.
 var ErrCherryPickUncommittedChanges = errors.New("cannot cherry-pick with uncommitted changes")
 
+// ErrNoCherryPickInProgress is returned when a cherry-pick is continued, but there is no cherry-pick in progress.
+var ErrNoCherryPickInProgress = errors.New("error: There is no cherry-pick in progress")
+
 // CherryPickOptions specifies optional parameters specifying how a cherry-pick is performed.
 type CherryPickOptions struct {
 	// Amend controls whether the commit at HEAD is amended and combined with the commit to be cherry-picked.
@@ -70,15 +77,131 @@ func NewCherryPickOptions() CherryPickOptions {
 // If the cherry-pick results in merge conflicts, the merge result is returned. If the operation is not successful for
 // any reason, then the error return parameter will be populated.
 func CherryPick(ctx *sql.Context, commit string, options CherryPickOptions) (string, *merge.Result, error) {
+	return cherryPickWithPending(ctx, commit, nil, options)
+}
+
+// CherryPickCommits replays the commits specified by |commits| in order, applying each as a new commit to the
+// current HEAD. Each element of |commits| is either a single commit, or a range of the form A..B, which specifies
+// the commits reachable from B but not from A, oldest first. All the commits are resolved before any are applied.
+// If a commit results in merge conflicts, the merge result is returned and the cherry-pick is left in progress, with
+// the remaining commits recorded so that ContinueCherryPick can apply them once the conflicts are resolved. If
+// successful, the hash of the last new commit is returned.
+func CherryPickCommits(ctx *sql.Context, commits []string, options CherryPickOptions) (string, *merge.Result, error) {
+	hashes, err := resolveCherryPicks(ctx, commits)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(commits) == 1 && len(hashes) == 1 && !isCommitRange(commits[0]) {
+		// Keep the spec the commit was given as, so that it is reported in dolt_merge_status
+		return CherryPick(ctx, commits[0], options)
+	}
+	return cherryPickHashes(ctx, hashes, options)
+}
+
+// ContinueCherryPick commits the in-progress cherry-pick, once all of its conflicts and constraint violations have
+// been resolved, and then cherry-picks the commits that remain from the same CherryPickCommits call. It returns the
+// same results as CherryPickCommits.
+func ContinueCherryPick(ctx *sql.Context, options CherryPickOptions) (string, *merge.Result, error) {
 	doltSession := dsess.DSessFromSess(ctx.Session)
 	dbName := ctx.GetCurrentDatabase()
 
+	ws, err := doltSession.WorkingSet(ctx, dbName)
+	if err != nil {
+		return "", nil, err
+	}
+	if !ws.MergeActive() || !ws.MergeState().IsCherryPick() {
+		return "", nil, ErrNoCherryPickInProgress
+	}
+	if ws.MergeState().HasSchemaConflicts() {
+		return "", nil, fmt.Errorf("error: cannot continue the cherry-pick with unresolved schema conflicts")
+	}
+	if ok, err := doltdb.HasConflicts(ctx, ws.WorkingRoot()); err != nil {
+		return "", nil, err
+	} else if ok {
+		return "", nil, fmt.Errorf("error: cannot continue the cherry-pick with unresolved conflicts")
+	}
+	if ok, err := doltdb.HasConstraintViolations(ctx, ws.WorkingRoot()); err != nil {
+		return "", nil, err
+	} else if ok {
+		return "", nil, fmt.Errorf("error: cannot continue the cherry-pick with unresolved constraint violations")
+	}
+
+	pending := ws.MergeState().PendingCherryPicks()
+	commitMeta, err := ws.MergeState().Commit().GetCommitMeta(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
 	roots, ok := doltSession.GetRoots(ctx, dbName)
 	if !ok {
 		return "", nil, fmt.Errorf("failed to get roots for current session")
 	}
+	roots, err = actions.StageModifiedAndDeletedTables(ctx, roots)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = doltSession.SetRoots(ctx, dbName, roots); err != nil {
+		return "", nil, err
+	}
 
-	mergeResult, commitMsg, err := cherryPick(ctx, doltSession, roots, dbName, commit, options.EmptyCommitHandling)
+	commitHash, err := commitCherryPick(ctx, doltSession, dbName, commitMeta.Description, options)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pending) == 0 {
+		return commitHash, nil, nil
+	}
+
+	lastHash, mergeResult, err := cherryPickHashes(ctx, pending, options)
+	if err != nil || mergeResult != nil {
+		return "", mergeResult, err
+	}
+	if lastHash == "" {
+		lastHash = commitHash
+	}
+	return lastHash, nil, nil
+}
+
+// cherryPickHashes cherry-picks the commits |hashes| in order. If one of them results in merge conflicts, the
+// remaining commits are recorded in the working set's merge state.
+func cherryPickHashes(ctx *sql.Context, hashes []hash.Hash, options CherryPickOptions) (string, *merge.Result, error) {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+
+	var lastHash string
+	for i, h := range hashes {
+		// Cherry-picks commit as they go, so make sure there is a transaction for each of them
+		if doltSession.GetTransaction() == nil {
+			if _, err := doltSession.StartTransaction(ctx, sql.ReadWrite); err != nil {
+				return "", nil, err
+			}
+		}
+
+		commitHash, mergeResult, err := cherryPickWithPending(ctx, h.String(), hashes[i+1:], options)
+		if err != nil {
+			return "", mergeResult, err
+		}
+		if mergeResult != nil {
+			return "", mergeResult, nil
+		}
+		if commitHash != "" {
+			lastHash = commitHash
+		}
+	}
+	return lastHash, nil, nil
+}
+
+// cherryPickWithPending cherry-picks |commit| like CherryPick. If the cherry-pick results in merge conflicts, the
+// commits |pending| are recorded as remaining to be cherry-picked once the conflicts are resolved.
+func cherryPickWithPending(ctx *sql.Context, commit string, pending []hash.Hash, options CherryPickOptions) (string, *merge.Result, error) {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	dbName := ctx.GetCurrentDatabase()
+
+	roots, ok := doltSession.GetRoots(ctx, dbName)
+	if !ok {
+		return "", nil, fmt.Errorf("failed to get roots for current session")
+	}
+
+	mergeResult, commitMsg, err := cherryPick(ctx, doltSession, roots, dbName, commit, pending, options.EmptyCommitHandling)
 	if err != nil {
 		return "", mergeResult, err
 	}
@@ -108,9 +231,16 @@ func CherryPick(ctx *sql.Context, commit string, options CherryPickOptions) (str
 		return "", mergeResult, nil
 	}
 
+	commitHash, err := commitCherryPick(ctx, doltSession, dbName, commitMsg, options)
+	return commitHash, nil, err
+}
+
+// commitCherryPick commits the staged changes of a cherry-pick, using |commitMsg| unless |options| specifies a
+// commit message, and returns the hash of the new commit, or the empty string if no commit was created.
+func commitCherryPick(ctx *sql.Context, doltSession *dsess.DoltSession, dbName, commitMsg string, options CherryPickOptions) (string, error) {
 	commitProps, err := CreateCommitStagedPropsFromCherryPickOptions(ctx, options)
 	if err != nil {
-		return "", nil, err
+		return "", err
 	}
 
 	// If no commit message was explicitly provided in the cherry-pick options,
@@ -120,34 +250,34 @@ func CherryPick(ctx *sql.Context, commit string, options CherryPickOptions) (str
 	}
 
 	// NOTE: roots are old here (after staging the tables) and need to be refreshed
-	roots, ok = doltSession.GetRoots(ctx, dbName)
+	roots, ok := doltSession.GetRoots(ctx, dbName)
 	if !ok {
-		return "", nil, fmt.Errorf("failed to get roots for current session")
+		return "", fmt.Errorf("failed to get roots for current session")
 	}
 
 	pendingCommit, err := doltSession.NewPendingCommit(ctx, dbName, roots, *commitProps)
 	if err != nil {
-		return "", nil, err
+		return "", err
 	}
 	if pendingCommit == nil {
 		if commitProps.SkipEmpty {
-			return "", nil, nil
+			return "", nil
 		} else if !commitProps.AllowEmpty {
-			return "", nil, errors.New("nothing to commit")
+			return "", errors.New("nothing to commit")
 		}
 	}
 
 	newCommit, err := doltSession.DoltCommit(ctx, dbName, doltSession.GetTransaction(), pendingCommit)
 	if err != nil {
-		return "", nil, err
+		return "", err
 	}
 
 	h, err := newCommit.HashOf()
 	if err != nil {
-		return "", nil, err
+		return "", err
 	}
 
-	return h.String(), nil, nil
+	return h.String(), nil
 }
 
 // CreateCommitStagedPropsFromCherryPickOptions converts the specified cherry-pick |options| into a CommitStagedProps
@@ -224,7 +354,7 @@ func AbortCherryPick(ctx *sql.Context, dbName string) error {
 // cherryPick checks that the current working set is clean, verifies the cherry-pick commit is not a merge commit
 // or a commit without parent commit, performs merge and returns the new working set root value and
 // the commit message of cherry-picked commit as the commit message of the new commit created during this command.
-func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots, dbName, cherryStr string, emptyCommitHandling doltdb.EmptyCommitHandling) (*merge.Result, string, error) {
+func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots, dbName, cherryStr string, pending []hash.Hash, emptyCommitHandling doltdb.EmptyCommitHandling) (*merge.Result, string, error) {
 	// check for clean working set
 	wsOnlyHasIgnoredTables, err := diff.WorkingSetContainsOnlyIgnoredTables(ctx, roots)
 	if err != nil {
@@ -362,7 +492,7 @@ func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots,
 			if err != nil {
 				return nil, "", err
 			}
-			newWorkingSet := ws.StartCherryPick(cherryCommit, cherryStr)
+			newWorkingSet := ws.StartCherryPick(cherryCommit, cherryStr, pending)
 			err = dSess.SetWorkingSet(ctx, dbName, newWorkingSet)
 			if err != nil {
 				return nil, "", err
@@ -375,6 +505,124 @@ func cherryPick(ctx *sql.Context, dSess *dsess.DoltSession, roots doltdb.Roots,
 	return result, cherryCommitMeta.Description, nil
 }
 
+// isCommitRange returns whether |commit| specifies a range of commits, of the form A..B.
+func isCommitRange(commit string) bool {
+	return strings.Contains(commit, "..")
+}
+
+// resolveCherryPicks resolves |commits|, each a single commit or a range of commits, to the hashes of the commits to
+// cherry-pick, in the order they should be applied. It returns an error if any of the commits is a merge commit or
+// a commit without parents, so that no commit is applied unless they all can be.
+func resolveCherryPicks(ctx *sql.Context, commits []string) ([]hash.Hash, error) {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	dbName := ctx.GetCurrentDatabase()
+
+	dbData, ok := doltSession.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("failed to get dbData")
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef()
+	if err != nil {
+		return nil, err
+	}
+	ddb := dbData.Ddb
+
+	resolve := func(spec string) (*doltdb.Commit, error) {
+		cs, err := doltdb.NewCommitSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		return cm, nil
+	}
+
+	var hashes []hash.Hash
+	add := func(cm *doltdb.Commit) error {
+		if cm.NumParents() > 1 {
+			return fmt.Errorf("cherry-picking a merge commit is not supported")
+		} else if cm.NumParents() == 0 {
+			return fmt.Errorf("cherry-picking a commit without parents is not supported")
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, h)
+		return nil
+	}
+
+	for _, commit := range commits {
+		if !isCommitRange(commit) {
+			cm, err := resolve(commit)
+			if err != nil {
+				return nil, err
+			}
+			if err = add(cm); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		from, to, _ := strings.Cut(commit, "..")
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("invalid commit range '%s', expected <from>..<to>", commit)
+		}
+		fromCm, err := resolve(from)
+		if err != nil {
+			return nil, err
+		}
+		toCm, err := resolve(to)
+		if err != nil {
+			return nil, err
+		}
+		fromHash, err := fromCm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		toHash, err := toCm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		itr, err := commitwalk.GetDotDotRevisionsIterator(ctx, ddb, []hash.Hash{toHash}, ddb, []hash.Hash{fromHash}, nil)
+		if err != nil {
+			return nil, err
+		}
+		// The iterator returns the newest commits first, but they are applied oldest first
+		var rangeCommits []*doltdb.Commit
+		for {
+			_, optCmt, err := itr.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			cm, ok := optCmt.ToCommit()
+			if !ok {
+				return nil, doltdb.ErrGhostCommitEncountered
+			}
+			rangeCommits = append(rangeCommits, cm)
+		}
+		for i := len(rangeCommits) - 1; i >= 0; i-- {
+			if err = add(rangeCommits[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(hashes) == 0 {
+		return nil, fmt.Errorf("error: no commits to cherry-pick")
+	}
+	return hashes, nil
+}
+
 func rootsEqual(root1, root2 doltdb.RootValue) (bool, error) {
 	root1Hash, err := root1.HashOf()
 	if err != nil {
@@ -0,0 +1,241 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// KeychainHelperName is the name of the credential helper which stores secrets in the macOS Keychain.
+	KeychainHelperName = "osxkeychain"
+	// WinCredHelperName is the name of the credential helper which stores secrets in the Windows Credential Manager.
+	WinCredHelperName = "wincred"
+
+	// helperCommandPrefix is the prefix of the command of a credential helper given by name.
+	helperCommandPrefix = "dolt-credential-"
+
+	// jwkProtocol and jwkHost identify the private keys of JWK credentials stored by a Helper.
+	jwkProtocol = "dolt"
+	jwkHost     = "creds"
+)
+
+// Credential is a secret stored by a Helper. Its fields are those of the git credential helper protocol, so that
+// credential helpers written for git can be used by dolt.
+type Credential struct {
+	Protocol string
+	Host     string
+	Username string
+	Password string
+}
+
+// Helper stores secrets outside of the creds dir, such as in an OS keychain.
+type Helper interface {
+	// Get returns the password of |c|, and whether it was found.
+	Get(c Credential) (string, bool, error)
+	// Store stores the password of |c|.
+	Store(c Credential) error
+	// Erase removes the password of |c|, if it is stored.
+	Erase(c Credential) error
+}
+
+// NewHelper returns the Helper given by |spec|, the value of the creds.helper config. The spec is one of:
+//   - osxkeychain, for the macOS Keychain
+//   - wincred, for the Windows Credential Manager
+//   - the name of a dolt-credential-<name> command on the PATH, or the absolute path of a command, with optional args
+//   - a shell command prefixed with !
+//
+// Commands are run with an argument of get, store or erase, and are passed the credential on stdin, in the format of
+// git credential helpers.
+func NewHelper(spec string) (Helper, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "":
+		return nil, errors.New("credential helper must not be empty")
+	case spec == KeychainHelperName:
+		if runtime.GOOS != "darwin" {
+			return nil, fmt.Errorf("credential helper %s is only supported on macOS", KeychainHelperName)
+		}
+		return keychainHelper{}, nil
+	case spec == WinCredHelperName:
+		return newWinCredHelper()
+	case strings.HasPrefix(spec, "!"):
+		return commandHelper{shell: spec[1:]}, nil
+	}
+
+	args := strings.Fields(spec)
+	if !filepath.IsAbs(args[0]) {
+		args[0] = helperCommandPrefix + args[0]
+	}
+	return commandHelper{args: args}, nil
+}
+
+// JWKCredential returns the Credential under which the private key of |dc| is stored by a Helper.
+func JWKCredential(dc DoltCreds) Credential {
+	c := Credential{Protocol: jwkProtocol, Host: jwkHost, Username: dc.KeyIDBase32Str()}
+	if dc.HasPrivKey() {
+		c.Password = base64.URLEncoding.EncodeToString(dc.PrivKey)
+	}
+	return c
+}
+
+// LoadPrivKey sets the private key of |dc|, which has only a public key, to the one stored by |h|. It returns
+// ErrCredsNotFound if |h| doesn't have the private key.
+func LoadPrivKey(h Helper, dc DoltCreds) (DoltCreds, error) {
+	pass, ok, err := h.Get(JWKCredential(dc))
+	if err != nil {
+		return DoltCreds{}, err
+	} else if !ok {
+		return DoltCreds{}, ErrCredsNotFound
+	}
+	priv, err := base64.URLEncoding.DecodeString(pass)
+	if err != nil {
+		return DoltCreds{}, fmt.Errorf("invalid private key stored by credential helper: %w", err)
+	}
+	dc.PrivKey = priv
+	if !dc.IsPrivKeyValid() {
+		return DoltCreds{}, errors.New("invalid private key stored by credential helper")
+	}
+	return dc, nil
+}
+
+// commandHelper is a Helper which runs an external command, such as a git credential helper.
+type commandHelper struct {
+	// args are the command and its args, to which the action is appended
+	args []string
+	// shell is a shell command, to which the action is appended
+	shell string
+}
+
+var _ Helper = commandHelper{}
+
+func (h commandHelper) Get(c Credential) (string, bool, error) {
+	out, err := h.run("get", c)
+	if err != nil {
+		return "", false, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if key, val, ok := strings.Cut(scanner.Text(), "="); ok && key == "password" {
+			return val, true, nil
+		}
+	}
+	return "", false, scanner.Err()
+}
+
+func (h commandHelper) Store(c Credential) error {
+	_, err := h.run("store", c)
+	return err
+}
+
+func (h commandHelper) Erase(c Credential) error {
+	_, err := h.run("erase", c)
+	return err
+}
+
+func (h commandHelper) run(action string, c Credential) ([]byte, error) {
+	var cmd *exec.Cmd
+	if h.shell != "" {
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", h.shell+" "+action)
+		} else {
+			cmd = exec.Command("sh", "-c", h.shell+" "+action)
+		}
+	} else {
+		cmd = exec.Command(h.args[0], append(h.args[1:], action)...)
+	}
+
+	var in, stderr bytes.Buffer
+	for _, kv := range [][2]string{{"protocol", c.Protocol}, {"host", c.Host}, {"username", c.Username}, {"password", c.Password}} {
+		if kv[1] != "" {
+			fmt.Fprintf(&in, "%s=%s\n", kv[0], kv[1])
+		}
+	}
+	in.WriteString("\n")
+	cmd.Stdin = &in
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return nil, fmt.Errorf("credential helper %s failed: %w: %s", action, err, msg)
+		}
+		return nil, fmt.Errorf("credential helper %s failed: %w", action, err)
+	}
+	return out, nil
+}
+
+// keychainHelper is a Helper which stores secrets in the macOS Keychain, using the security command. Each secret is a
+// generic password, whose service is the protocol and host of its credential, and whose account is its username.
+type keychainHelper struct{}
+
+var _ Helper = keychainHelper{}
+
+// errSecItemNotFound is the exit code of the security command when an item isn't found.
+const errSecItemNotFound = 44
+
+func (keychainHelper) Get(c Credential) (string, bool, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", keychainService(c), "-a", c.Username, "-w").Output()
+	if exitCode(err) == errSecItemNotFound {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("failed to read from keychain: %w", err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), true, nil
+}
+
+func (keychainHelper) Store(c Credential) error {
+	// the password is passed on stdin, in interactive mode, so that it isn't visible in the args of the process
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		keychainQuote(keychainService(c)), keychainQuote(c.Username), keychainQuote(c.Password)))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write to keychain: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (keychainHelper) Erase(c Credential) error {
+	err := exec.Command("security", "delete-generic-password", "-s", keychainService(c), "-a", c.Username).Run()
+	if err != nil && exitCode(err) != errSecItemNotFound {
+		return fmt.Errorf("failed to delete from keychain: %w", err)
+	}
+	return nil
+}
+
+func keychainService(c Credential) string {
+	return c.Protocol + "://" + c.Host
+}
+
+func keychainQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileHelperScript is a credential helper which stores each password in a file of the directory given by its first
+// argument, named after the username of the credential.
+const fileHelperScript = `dir="$1"
+while IFS='=' read -r key val; do
+	[ -z "$key" ] && break
+	case "$key" in
+		username) user="$val" ;;
+		password) pass="$val" ;;
+	esac
+done
+case "$2" in
+	get) [ -f "$dir/$user" ] && printf 'password=%s\n' "$(cat "$dir/$user")" ;;
+	store) printf '%s' "$pass" > "$dir/$user" ;;
+	erase) rm -f "$dir/$user" ;;
+esac
+exit 0
+`
+
+func TestCommandHelper(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper script requires sh")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "helper.sh")
+	require.NoError(t, os.WriteFile(script, []byte(fileHelperScript), 0755))
+
+	h, err := NewHelper("!sh " + script + " " + dir)
+	require.NoError(t, err)
+
+	dc, err := GenerateCredentials()
+	require.NoError(t, err)
+	require.NoError(t, h.Store(JWKCredential(dc)))
+
+	pubOnly := DoltCreds{PubKey: dc.PubKey, KeyID: dc.KeyID}
+	loaded, err := LoadPrivKey(h, pubOnly)
+	require.NoError(t, err)
+	assert.Equal(t, dc.PrivKey, loaded.PrivKey)
+
+	pass, ok, err := h.Get(Credential{Protocol: "https", Host: "example.com", Username: "nobody"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Empty(t, pass)
+
+	require.NoError(t, h.Erase(JWKCredential(dc)))
+	_, err = LoadPrivKey(h, pubOnly)
+	assert.ErrorIs(t, err, ErrCredsNotFound)
+
+	failing, err := NewHelper("!echo broken >&2; exit 1;")
+	require.NoError(t, err)
+	_, _, err = failing.Get(JWKCredential(dc))
+	assert.ErrorContains(t, err, "broken")
+}
+
+func TestNewHelper(t *testing.T) {
+	_, err := NewHelper(" ")
+	assert.Error(t, err)
+
+	h, err := NewHelper("store --file creds")
+	require.NoError(t, err)
+	assert.Equal(t, commandHelper{args: []string{"dolt-credential-store", "--file", "creds"}}, h)
+
+	h, err = NewHelper("!git credential-store")
+	require.NoError(t, err)
+	assert.Equal(t, commandHelper{shell: "git credential-store"}, h)
+
+	if runtime.GOOS != "darwin" {
+		_, err = NewHelper(KeychainHelperName)
+		assert.Error(t, err)
+	}
+	if runtime.GOOS != "windows" {
+		_, err = NewHelper(WinCredHelperName)
+		assert.Error(t, err)
+	}
+}
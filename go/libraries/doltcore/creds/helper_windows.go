@@ -0,0 +1,132 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+// +build windows
+
+package creds
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	advapi32        = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+// winCredential is the CREDENTIALW struct of the Windows Credential Manager.
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// winCredHelper is a Helper which stores secrets as generic credentials of the Windows Credential Manager.
+type winCredHelper struct{}
+
+var _ Helper = winCredHelper{}
+
+// winCredTarget returns the target name of the credential of |c|.
+func winCredTarget(c Credential) string {
+	return "dolt:" + c.Protocol + "://" + c.Username + "@" + c.Host
+}
+
+func newWinCredHelper() (Helper, error) {
+	if err := procCredReadW.Find(); err != nil {
+		return nil, fmt.Errorf("credential helper %s is unavailable: %w", WinCredHelperName, err)
+	}
+	return winCredHelper{}, nil
+}
+
+func (winCredHelper) Get(c Credential) (string, bool, error) {
+	target, err := windows.UTF16PtrFromString(winCredTarget(c))
+	if err != nil {
+		return "", false, err
+	}
+	var cred *winCredential
+	r, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		if errors.Is(err, windows.ERROR_NOT_FOUND) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read from credential manager: %w", err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.CredentialBlobSize == 0 {
+		return "", true, nil
+	}
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), true, nil
+}
+
+func (winCredHelper) Store(c Credential) error {
+	target, err := windows.UTF16PtrFromString(winCredTarget(c))
+	if err != nil {
+		return err
+	}
+	user, err := windows.UTF16PtrFromString(c.Username)
+	if err != nil {
+		return err
+	}
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(c.Password)),
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	if len(c.Password) > 0 {
+		blob := []byte(c.Password)
+		cred.CredentialBlob = &blob[0]
+	}
+	r, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if r == 0 {
+		return fmt.Errorf("failed to write to credential manager: %w", err)
+	}
+	return nil
+}
+
+func (winCredHelper) Erase(c Credential) error {
+	target, err := windows.UTF16PtrFromString(winCredTarget(c))
+	if err != nil {
+		return err
+	}
+	r, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 && !errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return fmt.Errorf("failed to delete from credential manager: %w", err)
+	}
+	return nil
+}
@@ -53,6 +53,12 @@ const (
 
 	OSSScheme = "oss"
 
+	// OCIRegistryScheme is the scheme of OCI artifact registry urls, such as oci+https://ghcr.io/org/database
+	OCIRegistryScheme = "oci+https"
+
+	// OCIRegistryInsecureScheme is the scheme of OCI artifact registries which are accessed over plain http
+	OCIRegistryInsecureScheme = "oci+http"
+
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
 )
@@ -69,15 +75,17 @@ type DBFactory interface {
 // DBFactories is a map from url scheme name to DBFactory.  Additional factories can be added to the DBFactories map
 // from external packages.
 var DBFactories = map[string]DBFactory{
-	AWSScheme:     AWSFactory{},
-	OSSScheme:     OSSFactory{},
-	GSScheme:      GSFactory{},
-	OCIScheme:     OCIFactory{},
-	FileScheme:    FileFactory{},
-	MemScheme:     MemFactory{},
-	LocalBSScheme: LocalBSFactory{},
-	HTTPScheme:    NewDoltRemoteFactory(true),
-	HTTPSScheme:   NewDoltRemoteFactory(false),
+	AWSScheme:                 AWSFactory{},
+	OSSScheme:                 OSSFactory{},
+	GSScheme:                  GSFactory{},
+	OCIScheme:                 OCIFactory{},
+	FileScheme:                FileFactory{},
+	MemScheme:                 MemFactory{},
+	LocalBSScheme:             LocalBSFactory{},
+	OCIRegistryScheme:         OCIRegistryFactory{},
+	OCIRegistryInsecureScheme: OCIRegistryFactory{insecure: true},
+	HTTPScheme:                NewDoltRemoteFactory(true),
+	HTTPSScheme:               NewDoltRemoteFactory(false),
 }
 
 // CreateDB creates a database based on the supplied urlStr, and creation params.  The DBFactory used for creation is
@@ -39,6 +39,8 @@ type GRPCRemoteConfig struct {
 	Endpoint    string
 	DialOptions []grpc.DialOption
 	HTTPFetcher grpcendpoint.HTTPFetcher
+	// TransferLimits bounds the bandwidth and concurrency of the transfers to and from the remote.
+	TransferLimits remotestorage.TransferLimits
 }
 
 // GRPCDialProvider is an interface for getting a concrete Endpoint,
@@ -131,7 +133,7 @@ func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.Noms
 		conn.Close()
 		return nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), err)
 	}
-	cs = cs.WithHTTPFetcher(cfg.HTTPFetcher)
+	cs = cs.WithHTTPFetcher(cfg.HTTPFetcher).WithTransferLimits(cfg.TransferLimits)
 	cs.SetFinalizer(conn.Close)
 
 	if _, ok := params[NoCachingParameter]; ok {
@@ -0,0 +1,143 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// dockerHubIndexKey is the key of the Docker Hub credentials in a docker config file.
+const dockerHubIndexKey = "https://index.docker.io/v1/"
+
+// OCIRegistryFactory is a DBFactory implementation for creating databases backed by a repository of an OCI artifact
+// registry, such as ghcr.io or Docker Hub
+type OCIRegistryFactory struct {
+	insecure bool
+}
+
+// PrepareDB prepares an OCI registry backed database
+func (fact OCIRegistryFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// nothing to prepare, the repository is created by the first push
+	return nil
+}
+
+// CreateDB creates an OCI registry backed database
+func (fact OCIRegistryFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	// oci+https://[registry]/[repository][:tag]
+	creds, err := ociRegistryCredentials(urlObj.Host, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bs, err := blobstore.NewOCIRegistryBlobstore(nil, urlObj.Host, urlObj.Path, fact.insecure, creds)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	registryStore, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(registryStore)
+	ns := tree.NewNodeStore(registryStore)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+// ociRegistryCredentials returns the credentials for the registry at |host|. They come from, in order of precedence:
+// the user of the --user flag with the password in DOLT_REMOTE_PASSWORD, the DOLT_OCI_REGISTRY_USER and
+// DOLT_OCI_REGISTRY_PASSWORD environment variables, and the credentials stored by `docker login`. Without credentials,
+// the registry is accessed anonymously.
+func ociRegistryCredentials(host string, params map[string]interface{}) (blobstore.OCIRegistryCredentials, error) {
+	if user, ok := params[GRPCUsernameAuthParam].(string); ok && user != "" {
+		pass, ok := os.LookupEnv(dconfig.EnvDoltRemotePassword)
+		if !ok {
+			return blobstore.OCIRegistryCredentials{}, errors.New("must set DOLT_REMOTE_PASSWORD environment variable to use --user param")
+		}
+		return blobstore.OCIRegistryCredentials{Username: user, Password: pass}, nil
+	}
+	if user, ok := os.LookupEnv(dconfig.EnvOCIRegistryUser); ok {
+		return blobstore.OCIRegistryCredentials{Username: user, Password: os.Getenv(dconfig.EnvOCIRegistryPassword)}, nil
+	}
+	return dockerConfigCredentials(host), nil
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// dockerConfigCredentials returns the credentials for |host| stored in the docker config file, or no credentials if
+// there aren't any. Credentials kept by a credential helper aren't supported.
+func dockerConfigCredentials(host string) blobstore.OCIRegistryCredentials {
+	dir := os.Getenv(dconfig.EnvDockerConfig)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return blobstore.OCIRegistryCredentials{}
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return blobstore.OCIRegistryCredentials{}
+	}
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return blobstore.OCIRegistryCredentials{}
+	}
+
+	keys := []string{host, "https://" + host, "http://" + host}
+	if host == "docker.io" || host == "registry-1.docker.io" || host == "index.docker.io" {
+		keys = append(keys, dockerHubIndexKey)
+	}
+	for _, k := range keys {
+		auth, ok := cfg.Auths[k]
+		if !ok {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				continue
+			}
+			user, pass, _ := strings.Cut(string(decoded), ":")
+			return blobstore.OCIRegistryCredentials{Username: user, Password: pass}
+		}
+		if auth.Username != "" {
+			return blobstore.OCIRegistryCredentials{Username: auth.Username, Password: auth.Password}
+		}
+	}
+	return blobstore.OCIRegistryCredentials{}
+}
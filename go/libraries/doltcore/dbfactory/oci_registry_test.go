@@ -0,0 +1,70 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+func TestOCIRegistryCredentials(t *testing.T) {
+	dir := t.TempDir()
+	config := `{"auths": {
+		"ghcr.io": {"auth": "Z2hjcnVzZXI6Z2hjcnBhc3M="},
+		"https://localhost:5000": {"username": "localuser", "password": "localpass"},
+		"https://index.docker.io/v1/": {"auth": "aHVidXNlcjpodWJwYXNz"}
+	}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600))
+	t.Setenv(dconfig.EnvDockerConfig, dir)
+
+	tests := []struct {
+		host string
+		want blobstore.OCIRegistryCredentials
+	}{
+		{"ghcr.io", blobstore.OCIRegistryCredentials{Username: "ghcruser", Password: "ghcrpass"}},
+		{"localhost:5000", blobstore.OCIRegistryCredentials{Username: "localuser", Password: "localpass"}},
+		{"docker.io", blobstore.OCIRegistryCredentials{Username: "hubuser", Password: "hubpass"}},
+		{"quay.io", blobstore.OCIRegistryCredentials{}},
+	}
+	for _, test := range tests {
+		t.Run(test.host, func(t *testing.T) {
+			creds, err := ociRegistryCredentials(test.host, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, creds)
+		})
+	}
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(dconfig.EnvOCIRegistryUser, "envuser")
+		t.Setenv(dconfig.EnvOCIRegistryPassword, "envpass")
+		creds, err := ociRegistryCredentials("ghcr.io", nil)
+		require.NoError(t, err)
+		assert.Equal(t, blobstore.OCIRegistryCredentials{Username: "envuser", Password: "envpass"}, creds)
+	})
+
+	t.Run("user param", func(t *testing.T) {
+		t.Setenv(dconfig.EnvDoltRemotePassword, "remotepass")
+		creds, err := ociRegistryCredentials("ghcr.io", map[string]interface{}{GRPCUsernameAuthParam: "remoteuser"})
+		require.NoError(t, err)
+		assert.Equal(t, blobstore.OCIRegistryCredentials{Username: "remoteuser", Password: "remotepass"}, creds)
+	})
+}
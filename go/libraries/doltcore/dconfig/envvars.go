@@ -32,6 +32,8 @@ const (
 	EnvDisableChunkJournal           = "DOLT_DISABLE_CHUNK_JOURNAL"
 	EnvDisableReflog                 = "DOLT_DISABLE_REFLOG"
 	EnvReflogRecordLimit             = "DOLT_REFLOG_RECORD_LIMIT"
+	EnvJournalRollOverSize           = "DOLT_JOURNAL_ROLL_OVER_SIZE"
+	EnvJournalRollOverAge            = "DOLT_JOURNAL_ROLL_OVER_AGE"
 	EnvOssEndpoint                   = "OSS_ENDPOINT"
 	EnvOssAccessKeyID                = "OSS_ACCESS_KEY_ID"
 	EnvOssAccessKeySecret            = "OSS_ACCESS_KEY_SECRET"
@@ -43,4 +45,9 @@ const (
 	EnvDoltAuthorDate                = "DOLT_AUTHOR_DATE"
 	EnvDoltCommitterDate             = "DOLT_COMMITTER_DATE"
 	EnvDbNameReplace                 = "DOLT_DBNAME_REPLACE"
+	EnvWorkerConcurrency             = "DOLT_WORKER_CONCURRENCY"
+	EnvOCIRegistryUser               = "DOLT_OCI_REGISTRY_USER"
+	EnvOCIRegistryPassword           = "DOLT_OCI_REGISTRY_PASSWORD"
+	EnvDockerConfig                  = "DOCKER_CONFIG"
+	EnvFederatedPasswordPrefix       = "DOLT_FEDERATED_PASSWORD_"
 )
@@ -0,0 +1,149 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var ErrKeylessKeyRanges = errors.New("key ranges are only defined for tables with a primary key")
+
+// KeyRangeBucket counts the changed rows of a table whose primary keys fall in a range of its keys.
+type KeyRangeBucket struct {
+	// Start and End are the first and last keys of the rows of the bucket, which are the rows of the table in the
+	// to root unless the table has no rows there, in which case they're its rows in the from root.
+	Start, End val.Tuple
+	// Rows is the number of rows of the bucket.
+	Rows uint64
+
+	Adds, Removes, Changes uint64
+}
+
+// KeyRangeBuckets divides the rows of the table of |td| into at most |n| buckets of contiguous primary keys with
+// equal numbers of rows, and counts the rows added, removed and modified in each, for visualizing where in a table's
+// key space its changes are. A removed key that sorts before the first row of the table is counted in the first
+// bucket, and a removed key after its last row in the last. The key descriptor of the buckets' keys is returned with
+// them.
+func KeyRangeBuckets(ctx context.Context, td TableDelta, n int) ([]KeyRangeBucket, val.TupleDesc, error) {
+	if n < 1 {
+		return nil, val.TupleDesc{}, fmt.Errorf("invalid number of key ranges: %d", n)
+	}
+	if !types.IsFormat_DOLT(td.Format()) {
+		return nil, val.TupleDesc{}, fmt.Errorf("key ranges are not supported for the storage format %s", td.Format().VersionString())
+	}
+
+	fromSch, toSch, err := td.GetSchemas(ctx)
+	if err != nil {
+		return nil, val.TupleDesc{}, err
+	}
+	if !schema.ArePrimaryKeySetsDiffable(td.Format(), fromSch, toSch) {
+		return nil, val.TupleDesc{}, fmt.Errorf("failed to compute key ranges for table %s: %w", td.CurName(), ErrPrimaryKeySetChanged)
+	}
+	if keyless, err := td.IsKeyless(ctx); err != nil {
+		return nil, val.TupleDesc{}, err
+	} else if keyless {
+		return nil, val.TupleDesc{}, fmt.Errorf("failed to compute key ranges for table %s: %w", td.CurName(), ErrKeylessKeyRanges)
+	}
+
+	fromRows, toRows, err := td.GetRowData(ctx)
+	if err != nil {
+		return nil, val.TupleDesc{}, err
+	}
+	var from, to prolly.Map
+	if fromRows != nil {
+		from = durable.ProllyMapFromIndex(fromRows)
+	}
+	if toRows != nil {
+		to = durable.ProllyMapFromIndex(toRows)
+	}
+
+	// the buckets divide the rows of the to root, or of the from root if the table was emptied or dropped
+	ref := to
+	count := 0
+	if toRows != nil {
+		if count, err = to.Count(); err != nil {
+			return nil, val.TupleDesc{}, err
+		}
+	}
+	if count == 0 && fromRows != nil {
+		ref = from
+		if count, err = from.Count(); err != nil {
+			return nil, val.TupleDesc{}, err
+		}
+	}
+	if count == 0 {
+		return nil, val.TupleDesc{}, nil
+	}
+	keyDesc, _ := ref.Descriptors()
+
+	if n > count {
+		n = count
+	}
+	buckets := make([]KeyRangeBucket, n)
+	for i := range buckets {
+		start, end := uint64(i*count/n), uint64((i+1)*count/n)
+		buckets[i].Rows = end - start
+		if buckets[i].Start, err = keyAtOrdinal(ctx, ref, start); err != nil {
+			return nil, val.TupleDesc{}, err
+		}
+		if buckets[i].End, err = keyAtOrdinal(ctx, ref, end-1); err != nil {
+			return nil, val.TupleDesc{}, err
+		}
+	}
+
+	// the diff is in key order, so the bucket of each change follows the bucket of the change before it
+	i := 0
+	err = prolly.DiffMaps(ctx, from, to, false, func(ctx context.Context, diff tree.Diff) error {
+		key := val.Tuple(diff.Key)
+		for i+1 < len(buckets) && keyDesc.Compare(buckets[i+1].Start, key) <= 0 {
+			i++
+		}
+		switch diff.Type {
+		case tree.AddedDiff:
+			buckets[i].Adds++
+		case tree.RemovedDiff:
+			buckets[i].Removes++
+		case tree.ModifiedDiff:
+			buckets[i].Changes++
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, val.TupleDesc{}, err
+	}
+	return buckets, keyDesc, nil
+}
+
+func keyAtOrdinal(ctx context.Context, m prolly.Map, ord uint64) (val.Tuple, error) {
+	iter, err := m.IterOrdinalRange(ctx, ord, ord+1)
+	if err != nil {
+		return nil, err
+	}
+	k, _, err := iter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
@@ -0,0 +1,418 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// AppendOnlyTable is a row of the dolt_append_only_tables system table: the tables whose names match a pattern are
+// append-only on the branches whose names match another pattern. The rows of an append-only table can be inserted,
+// but not updated or deleted.
+type AppendOnlyTable struct {
+	BranchPattern string
+	TablePattern  string
+}
+
+// AppendOnlyTables are the rows of the dolt_append_only_tables system table.
+type AppendOnlyTables []AppendOnlyTable
+
+// GetAppendOnlyTables returns the rows of the dolt_append_only_tables table of |root| for the schema named
+// |schemaName|. If the table doesn't exist, no rows are returned.
+func GetAppendOnlyTables(ctx context.Context, root RootValue, schemaName string) (AppendOnlyTables, error) {
+	tname := TableName{Name: AppendOnlyTablesTableName, Schema: schemaName}
+	table, found, err := root.GetTable(ctx, tname)
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_append_only_tables is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc}, val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("%s had unexpected key type, this should never happen", AppendOnlyTablesTableName)
+	}
+	if valueDesc.Count() != 0 {
+		return nil, fmt.Errorf("%s had unexpected value type, this should never happen", AppendOnlyTablesTableName)
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var tables AppendOnlyTables
+	for {
+		keyTuple, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		branchPattern, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read branch pattern")
+		}
+		tablePattern, ok := keyDesc.GetString(1, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read table pattern for branch pattern %s", branchPattern)
+		}
+		tables = append(tables, AppendOnlyTable{BranchPattern: branchPattern, TablePattern: tablePattern})
+	}
+
+	return tables, nil
+}
+
+// ForBranch returns the rows whose branch pattern matches |branch|. Patterns are matched the same way as dolt_ignore
+// patterns.
+func (ts AppendOnlyTables) ForBranch(branch string) (AppendOnlyTables, error) {
+	var matches AppendOnlyTables
+	for _, t := range ts {
+		patternRegExp, err := compilePattern(t.BranchPattern)
+		if err != nil {
+			return nil, err
+		}
+		if patternRegExp.MatchString(branch) {
+			matches = append(matches, t)
+		}
+	}
+	return matches, nil
+}
+
+// Contains returns whether any row's table pattern matches |tableName|.
+func (ts AppendOnlyTables) Contains(tableName TableName) (bool, error) {
+	for _, t := range ts {
+		patternRegExp, err := compilePattern(t.TablePattern)
+		if err != nil {
+			return false, err
+		}
+		if patternRegExp.MatchString(tableName.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsAppendOnlyTable returns whether the dolt_append_only_tables table of |root| makes |tableName| append-only on
+// |branch|.
+func IsAppendOnlyTable(ctx context.Context, root RootValue, branch string, tableName TableName) (bool, error) {
+	if HasDoltPrefix(tableName.Name) {
+		return false, nil
+	}
+	tables, err := GetAppendOnlyTables(ctx, root, tableName.Schema)
+	if err != nil {
+		return false, err
+	}
+	tables, err = tables.ForBranch(branch)
+	if err != nil || len(tables) == 0 {
+		return false, err
+	}
+	return tables.Contains(tableName)
+}
+
+// ErrAppendOnlyTable is returned when a statement tries to update or delete the rows of a table that is append-only
+// on the current branch.
+type ErrAppendOnlyTable struct {
+	Table  string
+	Branch string
+}
+
+func (e ErrAppendOnlyTable) Error() string {
+	return fmt.Sprintf("table %s is append-only on branch %s, its rows can't be updated or deleted", e.Table, e.Branch)
+}
+
+// AppendOnlyViolation is a change to the rows of an append-only table other than inserting new rows.
+type AppendOnlyViolation struct {
+	Table  TableName
+	Detail string
+}
+
+func (v AppendOnlyViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Table, v.Detail)
+}
+
+// ErrAppendOnlyViolations is returned when the changes made to a branch update or delete the rows of tables that
+// dolt_append_only_tables makes append-only on it. Its message reports every violation.
+type ErrAppendOnlyViolations struct {
+	Branch     string
+	Violations []AppendOnlyViolation
+}
+
+func (e ErrAppendOnlyViolations) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = "\t" + v.String()
+	}
+	return fmt.Sprintf("changes to branch %s violate %s:\n%s", e.Branch, AppendOnlyTablesTableName, strings.Join(lines, "\n"))
+}
+
+// CheckAppendOnlyTables checks that every row of the tables that the dolt_append_only_tables tables of |before| make
+// append-only on |branch| is still in |after|, unchanged, and returns an ErrAppendOnlyViolations reporting any tables
+// whose rows were updated or deleted. The rows of dolt_append_only_tables are read from both |before| and |after|, in
+// each schema, so that a change can't lift the restrictions it is checked against by removing them along with the
+// rows they protect. When the columns of a table changed, its rows are
+// compared by key through the columns the two schemas share, and dropping a column of a table with rows updates them.
+func CheckAppendOnlyTables(ctx context.Context, branch string, before, after RootValue) error {
+	schemas, err := schemaNames(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	var violations []AppendOnlyViolation
+	for _, schemaName := range schemas {
+		tables, err := GetAppendOnlyTables(ctx, before, schemaName)
+		if err != nil {
+			return err
+		}
+		afterTables, err := GetAppendOnlyTables(ctx, after, schemaName)
+		if err != nil {
+			return err
+		}
+		tables, err = append(tables, afterTables...).ForBranch(branch)
+		if err != nil {
+			return err
+		} else if len(tables) == 0 {
+			continue
+		}
+
+		tableNames, err := before.GetTableNames(ctx, schemaName)
+		if err != nil {
+			return err
+		}
+		sort.Strings(tableNames)
+
+		for _, name := range tableNames {
+			if HasDoltPrefix(name) {
+				continue
+			}
+			tableName := TableName{Name: name, Schema: schemaName}
+			if ok, err := tables.Contains(tableName); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+
+			detail, err := checkAppendOnlyTable(ctx, before, after, tableName)
+			if err != nil {
+				return err
+			}
+			if detail != "" {
+				violations = append(violations, AppendOnlyViolation{Table: tableName, Detail: detail})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return ErrAppendOnlyViolations{Branch: branch, Violations: violations}
+	}
+	return nil
+}
+
+// checkAppendOnlyTable returns a description of the changes to the rows of |tableName| from |before| to |after| other
+// than inserts, or an empty string if rows were only inserted.
+func checkAppendOnlyTable(ctx context.Context, before, after RootValue, tableName TableName) (string, error) {
+	beforeTable, _, err := before.GetTable(ctx, tableName)
+	if err != nil {
+		return "", err
+	}
+	afterTable, ok, err := after.GetTable(ctx, tableName)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "table dropped", nil
+	}
+	if beforeTable.Format() == types.Format_LD_1 {
+		return "", nil
+	}
+	beforeHash, err := beforeTable.HashOf()
+	if err != nil {
+		return "", err
+	}
+	afterHash, err := afterTable.HashOf()
+	if err != nil {
+		return "", err
+	}
+	if beforeHash == afterHash {
+		return "", nil
+	}
+
+	beforeSch, err := beforeTable.GetSchema(ctx)
+	if err != nil {
+		return "", err
+	}
+	afterSch, err := afterTable.GetSchema(ctx)
+	if err != nil {
+		return "", err
+	}
+	beforeKeyDesc, _ := beforeSch.GetMapDescriptors()
+	afterKeyDesc, _ := afterSch.GetMapDescriptors()
+	if !beforeKeyDesc.Equals(afterKeyDesc) {
+		return "primary key changed", nil
+	}
+	keyless := schema.IsKeyless(beforeSch)
+
+	beforeIdx, err := beforeTable.GetRowData(ctx)
+	if err != nil {
+		return "", err
+	}
+	afterIdx, err := afterTable.GetRowData(ctx)
+	if err != nil {
+		return "", err
+	}
+	beforeRows := durable.ProllyMapFromIndex(beforeIdx)
+	afterRows := durable.ProllyMapFromIndex(afterIdx)
+
+	var changes []string
+	values := newAppendOnlyValueMapping(beforeSch, afterSch)
+	if dropped := values.droppedColumns(); len(dropped) > 0 {
+		if cnt, err := beforeRows.Count(); err != nil {
+			return "", err
+		} else if cnt > 0 {
+			changes = append(changes, fmt.Sprintf("dropped columns: %s", strings.Join(dropped, ", ")))
+		}
+	}
+
+	var deleted, updated int
+	err = prolly.DiffMaps(ctx, beforeRows, afterRows, false, func(ctx context.Context, diff tree.Diff) error {
+		switch diff.Type {
+		case tree.RemovedDiff:
+			deleted++
+		case tree.ModifiedDiff:
+			if keyless {
+				// the value of a keyless row is its count, which only grows when duplicates of the row are inserted
+				if val.ReadKeylessCardinality(val.Tuple(diff.To)) < val.ReadKeylessCardinality(val.Tuple(diff.From)) {
+					deleted++
+				}
+				return nil
+			}
+			changed, err := values.changed(ctx, val.Tuple(diff.From), val.Tuple(diff.To), beforeRows.NodeStore(), afterRows.NodeStore())
+			if err != nil {
+				return err
+			}
+			if changed {
+				updated++
+			}
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	if deleted > 0 {
+		changes = append(changes, fmt.Sprintf("deleted rows: %d", deleted))
+	}
+	if updated > 0 {
+		changes = append(changes, fmt.Sprintf("updated rows: %d", updated))
+	}
+	return strings.Join(changes, ", "), nil
+}
+
+// appendOnlyValueMapping maps the stored non-key columns of a table's schema before a change to those of its schema
+// after it, by tag, so that the values of a row can be compared across the change.
+type appendOnlyValueMapping struct {
+	beforeDesc, afterDesc val.TupleDesc
+	// ordinals holds the field of each field of |beforeDesc| in |afterDesc|, or -1 if its column was dropped
+	ordinals []int
+	names    []string
+}
+
+func newAppendOnlyValueMapping(before, after schema.Schema) appendOnlyValueMapping {
+	afterOrdinals := make(map[uint64]int)
+	_ = after.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if !col.Virtual {
+			afterOrdinals[tag] = len(afterOrdinals)
+		}
+		return false, nil
+	})
+
+	m := appendOnlyValueMapping{beforeDesc: before.GetValueDescriptor(), afterDesc: after.GetValueDescriptor()}
+	_ = before.GetNonPKCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if col.Virtual {
+			return false, nil
+		}
+		ord, ok := afterOrdinals[tag]
+		if !ok {
+			ord = -1
+		}
+		m.ordinals = append(m.ordinals, ord)
+		m.names = append(m.names, col.Name)
+		return false, nil
+	})
+	return m
+}
+
+// droppedColumns returns the names of the columns which aren't in the schema after the change.
+func (m appendOnlyValueMapping) droppedColumns() []string {
+	var dropped []string
+	for i, ord := range m.ordinals {
+		if ord < 0 {
+			dropped = append(dropped, m.names[i])
+		}
+	}
+	return dropped
+}
+
+// changed returns whether any column in both schemas has a different value in |after| than in |before|. Values of
+// columns whose type changed are compared after reading them as SQL values.
+func (m appendOnlyValueMapping) changed(ctx context.Context, before, after val.Tuple, beforeNs, afterNs tree.NodeStore) (bool, error) {
+	for i, ord := range m.ordinals {
+		if ord < 0 {
+			continue
+		}
+		if m.beforeDesc.Types[i].Enc == m.afterDesc.Types[ord].Enc {
+			if !bytes.Equal(m.beforeDesc.GetField(i, before), m.afterDesc.GetField(ord, after)) {
+				return true, nil
+			}
+			continue
+		}
+		beforeVal, err := tree.GetField(ctx, m.beforeDesc, i, before, beforeNs)
+		if err != nil {
+			return false, err
+		}
+		afterVal, err := tree.GetField(ctx, m.afterDesc, ord, after, afterNs)
+		if err != nil {
+			return false, err
+		}
+		if fmt.Sprint(beforeVal) != fmt.Sprint(afterVal) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
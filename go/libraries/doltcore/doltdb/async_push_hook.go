@@ -0,0 +1,355 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	asyncPushInterval    = 500 * time.Millisecond
+	asyncPushSyncReplica = "async_push_sync_replica"
+)
+
+// AsyncPushOptions configures the replication of an AsyncPushOnWriteHook.
+type AsyncPushOptions struct {
+	// Remote is the name of the remote pushed to, as reported by ReplicationStatus.
+	Remote string
+	// QueueFile is the path of the file the head updates waiting to be pushed are kept in, so that they are pushed
+	// after a restart. They are only kept in memory if it's empty.
+	QueueFile string
+	// MaxLag bounds the replication lag, which is how long the oldest head update waiting to be pushed has waited.
+	// Commits wait while the lag is greater, so the remote being down makes commits wait until it's back. The lag is
+	// unbounded if it's zero.
+	MaxLag time.Duration
+}
+
+// asyncPush is a head update waiting to be pushed. |seq| orders the updates of a dataset, and |queuedAt| is when the
+// oldest update of the dataset which wasn't pushed yet was queued.
+type asyncPush struct {
+	PushArg
+	seq      uint64
+	queuedAt time.Time
+}
+
+// AsyncPushOnWriteHook replicates head updates to a remote in the background, so that commits don't wait for the
+// pushes. Updates are queued, and the most recent update of each dataset is pushed periodically. The queue is kept
+// in a file, so that updates which weren't pushed before a restart are pushed after it, and updates which fail to
+// push are retried with exponential backoff.
+type AsyncPushOnWriteHook struct {
+	out    io.Writer
+	srcDB  datas.Database
+	destDB datas.Database
+	tmpDir string
+	opts   AsyncPushOptions
+
+	mu      sync.Mutex
+	seq     uint64
+	pending map[string]asyncPush
+	// pushed are the heads last pushed for each dataset, so that a head isn't pushed again.
+	pushed  map[string]hash.Hash
+	status  ReplicationStatus
+	backoff *backoff.ExponentialBackOff
+	// flushed is closed and replaced after every attempt to push the queued updates.
+	flushed chan struct{}
+}
+
+var _ CommitHook = (*AsyncPushOnWriteHook)(nil)
+var _ ReplicationStatusReporter = (*AsyncPushOnWriteHook)(nil)
+
+// NewAsyncPushOnWriteHook creates an AsyncPushOnWriteHook which pushes head updates of |srcDB| to |destDB| on a
+// thread of |bThreads|. Updates queued in |opts.QueueFile| before a restart are queued again.
+func NewAsyncPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, srcDB, destDB *DoltDB, tmpDir string, logger io.Writer, opts AsyncPushOptions) (*AsyncPushOnWriteHook, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = replicationRetryInitialInterval
+	b.MaxInterval = replicationRetryMaxInterval
+	b.MaxElapsedTime = 0
+	ah := &AsyncPushOnWriteHook{
+		out:     logger,
+		srcDB:   srcDB.db,
+		destDB:  destDB.db,
+		tmpDir:  tmpDir,
+		opts:    opts,
+		pending: make(map[string]asyncPush),
+		pushed:  make(map[string]hash.Hash),
+		status:  ReplicationStatus{Remote: opts.Remote},
+		backoff: b,
+		flushed: make(chan struct{}),
+	}
+	if err := ah.loadQueue(ctx); err != nil {
+		return nil, err
+	}
+
+	err := bThreads.Add(asyncPushSyncReplica, func(ctx context.Context) {
+		ticker := time.NewTicker(asyncPushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				// push what was queued before shutting down, anything left is pushed after a restart
+				ah.flush()
+				return
+			case <-ticker.C:
+				ah.flush()
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ah, nil
+}
+
+func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
+	return false
+}
+
+// Execute implements CommitHook, queues head updates to be pushed to the remote. If the replication lag is bounded,
+// waits for it to fall within the bound.
+func (ah *AsyncPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) (func(context.Context) error, error) {
+	addr, _ := ds.MaybeHeadAddr()
+
+	ah.mu.Lock()
+	ah.seq++
+	ah.queueLocked(asyncPush{PushArg: PushArg{ds: ds, db: db, hash: addr}, seq: ah.seq, queuedAt: time.Now()})
+	err := ah.saveQueueLocked()
+	ah.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("error queueing %s to push to remote %s: %w", ds.ID(), ah.opts.Remote, err)
+	}
+
+	return nil, ah.waitForLag(ctx)
+}
+
+// queueLocked adds |p| to the pending updates, unless a more recent update of its dataset is pending.
+func (ah *AsyncPushOnWriteHook) queueLocked(p asyncPush) {
+	if queued, ok := ah.pending[p.ds.ID()]; ok {
+		if queued.seq >= p.seq {
+			return
+		}
+		if queued.queuedAt.Before(p.queuedAt) {
+			p.queuedAt = queued.queuedAt
+		}
+	}
+	ah.pending[p.ds.ID()] = p
+}
+
+// waitForLag waits until the replication lag is within |ah.opts.MaxLag|, or |ctx| is done.
+func (ah *AsyncPushOnWriteHook) waitForLag(ctx context.Context) error {
+	if ah.opts.MaxLag <= 0 {
+		return nil
+	}
+	for {
+		ah.mu.Lock()
+		lag := ah.lagLocked()
+		flushed := ah.flushed
+		ah.mu.Unlock()
+		if lag <= ah.opts.MaxLag {
+			return nil
+		}
+
+		select {
+		case <-flushed:
+		case <-ctx.Done():
+			return fmt.Errorf("replication lag to remote %s is %s, more than the maximum of %s: %w", ah.opts.Remote, lag.Round(time.Millisecond), ah.opts.MaxLag, ctx.Err())
+		}
+	}
+}
+
+// lagLocked returns how long the oldest pending update has waited to be pushed.
+func (ah *AsyncPushOnWriteHook) lagLocked() time.Duration {
+	var lag time.Duration
+	now := time.Now()
+	for _, p := range ah.pending {
+		if l := now.Sub(p.queuedAt); l > lag {
+			lag = l
+		}
+	}
+	return lag
+}
+
+// flush pushes the pending updates, unless the next attempt after a failed push isn't due yet.
+func (ah *AsyncPushOnWriteHook) flush() {
+	ah.mu.Lock()
+	var queued []asyncPush
+	if !time.Now().Before(ah.status.NextAttempt) {
+		queued = make([]asyncPush, 0, len(ah.pending))
+		for _, p := range ah.pending {
+			queued = append(queued, p)
+		}
+	}
+	ah.mu.Unlock()
+
+	for _, p := range queued {
+		if err := ah.push(p); err != nil {
+			ah.HandleError(context.Background(), err)
+			break
+		}
+	}
+
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+	if len(queued) > 0 {
+		if err := ah.saveQueueLocked(); err != nil {
+			ah.HandleError(context.Background(), fmt.Errorf("error saving replication queue for remote %s: %w", ah.opts.Remote, err))
+		}
+	}
+	close(ah.flushed)
+	ah.flushed = make(chan struct{})
+}
+
+// push pushes |p| to the remote, unless its head was pushed already, and records the outcome.
+func (ah *AsyncPushOnWriteHook) push(p asyncPush) error {
+	id := p.ds.ID()
+	ah.mu.Lock()
+	pushed, ok := ah.pushed[id]
+	ah.mu.Unlock()
+
+	var err error
+	alreadyPushed := ok && pushed == p.hash
+	if !alreadyPushed {
+		// use background context to drain after sql context is canceled
+		err = background.Run(context.Background(), background.Replication, func(ctx context.Context) error {
+			return pushDataset(ctx, ah.destDB, p.db, p.ds, ah.tmpDir)
+		})
+	}
+
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+	if err != nil {
+		ah.status.FailedAttempts++
+		ah.status.LastError = err.Error()
+		ah.status.NextAttempt = time.Now().Add(ah.backoff.NextBackOff())
+		return fmt.Errorf("replication to remote %s failed: %w", ah.opts.Remote, err)
+	}
+
+	if queued, ok := ah.pending[id]; ok && queued.seq <= p.seq {
+		delete(ah.pending, id)
+	}
+	if p.hash.IsEmpty() {
+		delete(ah.pushed, id)
+	} else {
+		ah.pushed[id] = p.hash
+	}
+	ah.backoff.Reset()
+	ah.status.FailedAttempts = 0
+	ah.status.LastError = ""
+	ah.status.NextAttempt = time.Time{}
+	if !alreadyPushed {
+		ah.status.LastPushedRef = id
+		ah.status.LastPushedCommit = p.hash
+		ah.status.LastPushTime = time.Now()
+	}
+	return nil
+}
+
+// loadQueue queues the updates kept in |ah.opts.QueueFile|. Their datasets are pushed at their current heads.
+func (ah *AsyncPushOnWriteHook) loadQueue(ctx context.Context) error {
+	if ah.opts.QueueFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(ah.opts.QueueFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var queued map[string]time.Time
+	if err = json.Unmarshal(data, &queued); err != nil {
+		return fmt.Errorf("error reading replication queue %s: %w", ah.opts.QueueFile, err)
+	}
+	for id, queuedAt := range queued {
+		ds, err := ah.srcDB.GetDataset(ctx, id)
+		if err != nil {
+			return err
+		}
+		addr, _ := ds.MaybeHeadAddr()
+		ah.seq++
+		ah.pending[id] = asyncPush{PushArg: PushArg{ds: ds, db: ah.srcDB, hash: addr}, seq: ah.seq, queuedAt: queuedAt}
+	}
+	return nil
+}
+
+// saveQueueLocked writes the pending updates to |ah.opts.QueueFile|, or removes it if there are none.
+func (ah *AsyncPushOnWriteHook) saveQueueLocked() error {
+	if ah.opts.QueueFile == "" {
+		return nil
+	}
+	if len(ah.pending) == 0 {
+		err := os.Remove(ah.opts.QueueFile)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	queued := make(map[string]time.Time, len(ah.pending))
+	for id, p := range ah.pending {
+		queued[id] = p.queuedAt
+	}
+	data, err := json.Marshal(queued)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(ah.opts.QueueFile), os.ModePerm); err != nil {
+		return err
+	}
+	tmp := ah.opts.QueueFile + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ah.opts.QueueFile)
+}
+
+// ReplicationStatus implements ReplicationStatusReporter
+func (ah *AsyncPushOnWriteHook) ReplicationStatus() ReplicationStatus {
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+	status := ah.status
+	status.Pending = len(ah.pending)
+	status.Lag = ah.lagLocked()
+	return status
+}
+
+// HandleError implements CommitHook
+func (ah *AsyncPushOnWriteHook) HandleError(ctx context.Context, err error) error {
+	if ah.out != nil {
+		ah.out.Write([]byte(err.Error() + "\n"))
+	}
+	return nil
+}
+
+// SetLogger implements CommitHook
+func (ah *AsyncPushOnWriteHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	ah.out = wr
+	return nil
+}
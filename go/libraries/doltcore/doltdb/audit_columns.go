@@ -0,0 +1,123 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// AuditColumnRole is the value that is written to an audit column, as configured in the dolt_audit_columns system
+// table.
+type AuditColumnRole string
+
+const (
+	// AuditColumnCreatedAt is set to the time of the statement that inserts a row, and is kept when the row is
+	// updated.
+	AuditColumnCreatedAt AuditColumnRole = "created_at"
+	// AuditColumnUpdatedAt is set to the time of the statement that inserts or last updated a row.
+	AuditColumnUpdatedAt AuditColumnRole = "updated_at"
+	// AuditColumnUpdatedBy is set to the name of the user of the session that inserted or last updated a row.
+	AuditColumnUpdatedBy AuditColumnRole = "updated_by"
+)
+
+// AuditColumnRoleNames are the values of the role column of the dolt_audit_columns system table, in order.
+var AuditColumnRoleNames = []string{
+	string(AuditColumnCreatedAt),
+	string(AuditColumnUpdatedAt),
+	string(AuditColumnUpdatedBy),
+}
+
+// AuditColumns are the rows of the dolt_audit_columns system table: the roles of the audit columns, by lower case
+// table name and then lower case column name.
+type AuditColumns map[string]map[string]AuditColumnRole
+
+// GetAuditColumns returns the audit columns in the dolt_audit_columns table of |root| for the schema named
+// |schemaName|. If the table doesn't exist, no audit columns are returned.
+func GetAuditColumns(ctx context.Context, root RootValue, schemaName string) (AuditColumns, error) {
+	tname := TableName{Name: AuditColumnsTableName, Schema: schemaName}
+	table, found, err := root.GetTable(ctx, tname)
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_audit_columns is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc}, val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("%s had unexpected key type, this should never happen", AuditColumnsTableName)
+	}
+	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.EnumEnc, Nullable: false})) {
+		return nil, fmt.Errorf("%s had unexpected value type, this should never happen", AuditColumnsTableName)
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(AuditColumns)
+	for {
+		keyTuple, valueTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tableName, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name")
+		}
+		columnName, ok := keyDesc.GetString(1, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read column name")
+		}
+		// enum values are 1-indexed
+		role, ok := valueDesc.GetEnum(0, valueTuple)
+		if !ok || role == 0 || int(role) > len(AuditColumnRoleNames) {
+			return nil, fmt.Errorf("could not read audit column role for column %s.%s", tableName, columnName)
+		}
+
+		tableName = strings.ToLower(tableName)
+		if columns[tableName] == nil {
+			columns[tableName] = make(map[string]AuditColumnRole)
+		}
+		columns[tableName][strings.ToLower(columnName)] = AuditColumnRole(AuditColumnRoleNames[role-1])
+	}
+
+	return columns, nil
+}
+
+// ForTable returns the roles of the audit columns of |tableName|, by lower case column name.
+func (a AuditColumns) ForTable(tableName TableName) map[string]AuditColumnRole {
+	return a[strings.ToLower(tableName.Name)]
+}
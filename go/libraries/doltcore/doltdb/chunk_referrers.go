@@ -0,0 +1,258 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ChunkReferrer is a commit, or a working set, whose root values reference some chunks, directly or through the
+// chunks they reference. See FindChunkReferrers.
+type ChunkReferrer struct {
+	// Commit is the hash of the commit, or empty for a working set.
+	Commit hash.Hash
+	// Meta is the metadata of the commit, or nil for a working set.
+	Meta *datas.CommitMeta
+	// Refs are the refs whose history includes the commit, or the branch of the working set.
+	Refs []ref.DoltRef
+	// WorkingSet is true if the working or staged root of the working set of the branch in |Refs| references the
+	// chunks, rather than a commit.
+	WorkingSet bool
+}
+
+// TableFileAddresses returns the addresses of the chunks in the table file with the id |fileId|. Returns false if the
+// chunk store has no such table file.
+func (ddb *DoltDB) TableFileAddresses(ctx context.Context, fileId string) (hash.HashSet, bool, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	tfa, ok := cs.(interface {
+		TableFileAddresses(ctx context.Context, fileId string) (hash.HashSet, bool, error)
+	})
+	if !ok {
+		return nil, false, errors.New("unsupported operation, DoltDB.TableFileAddresses on a chunk store without table files")
+	}
+	return tfa.TableFileAddresses(ctx, fileId)
+}
+
+// FindChunkReferrers returns the commits reachable from any head ref of this database, and the working sets of its
+// branches, which reference any of the chunks in |targets|. Each of these keeps the chunks from being collected by
+// garbage collection, so they must all be deleted, or rewritten, to reclaim the space the chunks use. Working sets are
+// returned first, then commits from newest to oldest.
+func (ddb *DoltDB) FindChunkReferrers(ctx context.Context, targets hash.HashSet) ([]ChunkReferrer, error) {
+	r := &chunkReachability{
+		cs:      datas.ChunkStoreFromDatabase(ddb.db),
+		walk:    types.WalkAddrsForNBF(ddb.Format(), nil),
+		targets: targets,
+		memo:    make(map[hash.Hash]bool),
+	}
+
+	var heads []ref.DoltRef
+	var headAddrs []hash.Hash
+	err := ddb.VisitRefsOfType(ctx, ref.HeadRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
+		heads = append(heads, r)
+		headAddrs = append(headAddrs, addr)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var workingSets []ChunkReferrer
+	commits := make(map[hash.Hash]*ChunkReferrer)
+	for i, head := range heads {
+		wsRef, err := ref.WorkingSetRefForHead(head)
+		if err == nil {
+			found, err := ddb.workingSetReferences(ctx, r, wsRef)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				workingSets = append(workingSets, ChunkReferrer{Refs: []ref.DoltRef{head}, WorkingSet: true})
+			}
+		}
+
+		cm, err := ddb.resolveHeadCommit(ctx, head, headAddrs[i])
+		if err != nil {
+			return nil, err
+		}
+		if err = ddb.findCommitReferrers(ctx, r, head, cm, commits); err != nil {
+			return nil, err
+		}
+	}
+
+	referrers := make([]ChunkReferrer, 0, len(commits))
+	for _, c := range commits {
+		referrers = append(referrers, *c)
+	}
+	sort.Slice(referrers, func(i, j int) bool {
+		ti, tj := referrers[i].Meta.Time(), referrers[j].Meta.Time()
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return referrers[i].Commit.Less(referrers[j].Commit)
+	})
+	return append(workingSets, referrers...), nil
+}
+
+// resolveHeadCommit returns the commit pointed to by |head|, whose dataset's head is at |addr|.
+func (ddb *DoltDB) resolveHeadCommit(ctx context.Context, head ref.DoltRef, addr hash.Hash) (*Commit, error) {
+	if tagRef, ok := head.(ref.TagRef); ok {
+		tag, err := ddb.ResolveTag(ctx, tagRef)
+		if err != nil {
+			return nil, err
+		}
+		return tag.Commit, nil
+	}
+	optCmt, err := ddb.ReadCommit(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// findCommitReferrers walks the history of |head|, starting at |start|, and records each commit whose root value
+// reaches a target chunk in |referrers|, along with |head|. Ghost commits of shallow clones are skipped.
+func (ddb *DoltDB) findCommitReferrers(ctx context.Context, r *chunkReachability, head ref.DoltRef, start *Commit, referrers map[hash.Hash]*ChunkReferrer) error {
+	startHash, err := start.HashOf()
+	if err != nil {
+		return err
+	}
+	visited := hash.NewHashSet(startHash)
+	queue := []hash.Hash{startHash}
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+
+		optCmt, err := ddb.ReadCommit(ctx, h)
+		if err != nil {
+			return err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			continue
+		}
+
+		if referrer, ok := referrers[h]; ok {
+			referrer.Refs = append(referrer.Refs, head)
+		} else {
+			root, err := cm.GetRootValue(ctx)
+			if err != nil {
+				return err
+			}
+			rootHash, err := root.HashOf()
+			if err != nil {
+				return err
+			}
+			found, err := r.reaches(ctx, rootHash)
+			if err != nil {
+				return err
+			}
+			if found {
+				meta, err := cm.GetCommitMeta(ctx)
+				if err != nil {
+					return err
+				}
+				referrers[h] = &ChunkReferrer{Commit: h, Meta: meta, Refs: []ref.DoltRef{head}}
+			}
+		}
+
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range parents {
+			if !visited.Has(p) {
+				visited.Insert(p)
+				queue = append(queue, p)
+			}
+		}
+	}
+	return nil
+}
+
+// workingSetReferences returns whether the working or staged root of the working set |wsRef| reaches a target chunk.
+// Returns false if there is no such working set.
+func (ddb *DoltDB) workingSetReferences(ctx context.Context, r *chunkReachability, wsRef ref.WorkingSetRef) (bool, error) {
+	ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+	if err == ErrWorkingSetNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, root := range []RootValue{ws.WorkingRoot(), ws.StagedRoot()} {
+		if root == nil {
+			continue
+		}
+		h, err := root.HashOf()
+		if err != nil {
+			return false, err
+		}
+		found, err := r.reaches(ctx, h)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// chunkReachability answers whether any of a set of target chunks can be reached from a chunk, remembering the
+// answer for every chunk it reads, so that the chunks shared by many root values are read only once.
+type chunkReachability struct {
+	cs      chunks.ChunkStore
+	walk    func(chunks.Chunk, func(h hash.Hash, isleaf bool) error) error
+	targets hash.HashSet
+	memo    map[hash.Hash]bool
+}
+
+func (r *chunkReachability) reaches(ctx context.Context, h hash.Hash) (bool, error) {
+	if r.targets.Has(h) {
+		return true, nil
+	}
+	if found, ok := r.memo[h]; ok {
+		return found, nil
+	}
+
+	c, err := r.cs.Get(ctx, h)
+	if err != nil {
+		return false, err
+	}
+	found := false
+	if !c.IsEmpty() {
+		err = r.walk(c, func(addr hash.Hash, _ bool) error {
+			if found {
+				return nil
+			}
+			var err error
+			found, err = r.reaches(ctx, addr)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	r.memo[h] = found
+	return found, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestFindChunkReferrers(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	main := ref.NewBranchRef("main")
+	commitRoot := func(root RootValue, msg string) *Commit {
+		_, valHash, err := ddb.WriteRootValue(ctx, root)
+		require.NoError(t, err)
+		meta, err := datas.NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", msg)
+		require.NoError(t, err)
+		cm, err := ddb.Commit(ctx, valHash, main, meta)
+		require.NoError(t, err)
+		return cm
+	}
+
+	init, err := ddb.ResolveCommitRef(ctx, main)
+	require.NoError(t, err)
+	root, err := init.GetRootValue(ctx)
+	require.NoError(t, err)
+
+	sch := createTestSchema(t)
+	rowData, err := durable.NewEmptyIndex(ctx, ddb.vrw, ddb.ns, sch, false)
+	require.NoError(t, err)
+	tbl, err := CreateTestTable(ddb.vrw, ddb.ns, sch, rowData)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, TableName{Name: "test"}, tbl)
+	require.NoError(t, err)
+	added := commitRoot(root, "add test")
+	addedHash, err := added.HashOf()
+	require.NoError(t, err)
+
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, ref.NewBranchRef("old"), added, nil))
+	require.NoError(t, ddb.NewTagAtCommit(ctx, ref.NewTagRef("v1"), added, datas.NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "v1")))
+
+	root, err = root.RemoveTables(ctx, false, false, TableName{Name: "test"})
+	require.NoError(t, err)
+	commitRoot(root, "drop test")
+
+	tblHash, err := tbl.HashOf()
+	require.NoError(t, err)
+	referrers, err := ddb.FindChunkReferrers(ctx, hash.NewHashSet(tblHash))
+	require.NoError(t, err)
+	require.Len(t, referrers, 2)
+	// the working set of the new branch still has the table
+	assert.True(t, referrers[0].WorkingSet)
+	assert.Equal(t, []ref.DoltRef{ref.NewBranchRef("old")}, referrers[0].Refs)
+	assert.Equal(t, addedHash, referrers[1].Commit)
+	assert.Equal(t, "add test", referrers[1].Meta.Description)
+	assert.False(t, referrers[1].WorkingSet)
+	assert.ElementsMatch(t, []ref.DoltRef{main, ref.NewBranchRef("old"), ref.NewTagRef("v1")}, referrers[1].Refs)
+
+	referrers, err = ddb.FindChunkReferrers(ctx, hash.NewHashSet(hash.Of([]byte("not a chunk"))))
+	require.NoError(t, err)
+	assert.Empty(t, referrers)
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ColumnMergeStrategy is the strategy used to resolve a column of a row modified differently on both sides of a
+// merge, as configured in the dolt_column_merge_strategies system table.
+type ColumnMergeStrategy string
+
+const (
+	// ColumnMergeStrategyOurs keeps our value of the column.
+	ColumnMergeStrategyOurs ColumnMergeStrategy = "ours"
+	// ColumnMergeStrategyTheirs takes their value of the column.
+	ColumnMergeStrategyTheirs ColumnMergeStrategy = "theirs"
+	// ColumnMergeStrategyMax takes the greater of the two values. A NULL value is only taken if both are NULL.
+	ColumnMergeStrategyMax ColumnMergeStrategy = "max"
+	// ColumnMergeStrategyMin takes the lesser of the two values. A NULL value is only taken if both are NULL.
+	ColumnMergeStrategyMin ColumnMergeStrategy = "min"
+	// ColumnMergeStrategySum adds the changes made on both sides to the ancestor's value of a numeric column, as for
+	// a counter incremented on both sides. NULL values count as zero.
+	ColumnMergeStrategySum ColumnMergeStrategy = "sum"
+)
+
+// ColumnMergeStrategyNames are the values of the strategy column of the dolt_column_merge_strategies system table, in
+// order.
+var ColumnMergeStrategyNames = []string{
+	string(ColumnMergeStrategyOurs),
+	string(ColumnMergeStrategyTheirs),
+	string(ColumnMergeStrategyMax),
+	string(ColumnMergeStrategyMin),
+	string(ColumnMergeStrategySum),
+}
+
+// ColumnMergeStrategies are the rows of the dolt_column_merge_strategies system table: the strategies of columns, by
+// lower case table name and then lower case column name.
+type ColumnMergeStrategies map[string]map[string]ColumnMergeStrategy
+
+// GetColumnMergeStrategies returns the strategies in the dolt_column_merge_strategies table of |root| for the schema
+// named |schemaName|. If the table doesn't exist, no strategies are returned.
+func GetColumnMergeStrategies(ctx context.Context, root RootValue, schemaName string) (ColumnMergeStrategies, error) {
+	tname := TableName{Name: ColumnMergeStrategiesTableName, Schema: schemaName}
+	table, found, err := root.GetTable(ctx, tname)
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_column_merge_strategies is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc}, val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("%s had unexpected key type, this should never happen", ColumnMergeStrategiesTableName)
+	}
+	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.EnumEnc, Nullable: false})) {
+		return nil, fmt.Errorf("%s had unexpected value type, this should never happen", ColumnMergeStrategiesTableName)
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	strategies := make(ColumnMergeStrategies)
+	for {
+		keyTuple, valueTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tableName, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read table name")
+		}
+		columnName, ok := keyDesc.GetString(1, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read column name")
+		}
+		// enum values are 1-indexed
+		strategy, ok := valueDesc.GetEnum(0, valueTuple)
+		if !ok || strategy == 0 || int(strategy) > len(ColumnMergeStrategyNames) {
+			return nil, fmt.Errorf("could not read merge strategy for column %s.%s", tableName, columnName)
+		}
+
+		tableName = strings.ToLower(tableName)
+		if strategies[tableName] == nil {
+			strategies[tableName] = make(map[string]ColumnMergeStrategy)
+		}
+		strategies[tableName][strings.ToLower(columnName)] = ColumnMergeStrategy(ColumnMergeStrategyNames[strategy-1])
+	}
+
+	return strategies, nil
+}
+
+// ForTable returns the strategies of the columns of |tableName|, by lower case column name.
+func (s ColumnMergeStrategies) ForTable(tableName TableName) map[string]ColumnMergeStrategy {
+	return s[strings.ToLower(tableName.Name)]
+}
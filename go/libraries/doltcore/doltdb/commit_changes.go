@@ -0,0 +1,109 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// commitChangesCacheBytes bounds the total size of the row keys kept by each DoltDB's commit changes, see
+// CommitChanges.
+const commitChangesCacheBytes = 64 * 1024 * 1024
+
+// commitChangesOverhead is the size charged for each entry of the commit changes cache in addition to its keys.
+const commitChangesOverhead = 128
+
+// CommitChanges are the rows of a table that a commit changed relative to its first parent.
+type CommitChanges struct {
+	// All is true when every row of the table must be attributed to the commit: the table was created by it, the
+	// commit has no parent, or the primary key set changed so the rows cannot be compared with the parent's.
+	All bool
+	// Overflow is true when the commit touched more rows than were recorded, in which case Keys is nil.
+	Overflow bool
+	// Keys are the primary key tuples of the changed rows.
+	Keys map[string]struct{}
+	// Parent is the first parent of the commit, or nil when All is set.
+	Parent *Commit
+}
+
+func (c *CommitChanges) size() int {
+	size := commitChangesOverhead
+	for k := range c.Keys {
+		size += len(k)
+	}
+	return size
+}
+
+type commitChangesKey struct {
+	commit hash.Hash
+	table  string
+}
+
+// commitChangesCache is a least recently used cache of CommitChanges bounded by the total size of their keys.
+type commitChangesCache struct {
+	mu       sync.Mutex
+	entries  *lru.Cache[commitChangesKey, *CommitChanges]
+	bytes    int
+	maxBytes int
+}
+
+func newCommitChangesCache(maxBytes int) *commitChangesCache {
+	c := &commitChangesCache{maxBytes: maxBytes}
+	// the cache is bounded by |maxBytes|, so the bound on its number of entries is never reached
+	c.entries, _ = lru.NewWithEvict[commitChangesKey, *CommitChanges](math.MaxInt32, func(_ commitChangesKey, changes *CommitChanges) {
+		c.bytes -= changes.size()
+	})
+	return c
+}
+
+func (c *commitChangesCache) get(key commitChangesKey) (*CommitChanges, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Get(key)
+}
+
+func (c *commitChangesCache) add(key commitChangesKey, changes *CommitChanges) {
+	size := changes.size()
+	if size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Remove(key)
+	for c.bytes+size > c.maxBytes {
+		c.entries.RemoveOldest()
+	}
+	c.entries.Add(key, changes)
+	c.bytes += size
+}
+
+// CommitChanges returns the changes to the table |table| made by the commit with hash |commit|, if they were added
+// with AddCommitChanges and haven't been evicted since. Commits are immutable, so the changes of a commit never need
+// to be invalidated.
+func (ddb *DoltDB) CommitChanges(commit hash.Hash, table string) (*CommitChanges, bool) {
+	return ddb.commitChanges.get(commitChangesKey{commit: commit, table: strings.ToLower(table)})
+}
+
+// AddCommitChanges keeps |changes| as the changes to the table |table| made by the commit with hash |commit|. The
+// changes of the least recently used commits are evicted to keep the total size of their keys bounded.
+func (ddb *DoltDB) AddCommitChanges(commit hash.Hash, table string, changes *CommitChanges) {
+	ddb.commitChanges.add(commitChangesKey{commit: commit, table: strings.ToLower(table)}, changes)
+}
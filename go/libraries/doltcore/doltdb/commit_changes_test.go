@@ -0,0 +1,62 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestCommitChangesCache(t *testing.T) {
+	changesOfSize := func(n int) *CommitChanges {
+		return &CommitChanges{Keys: map[string]struct{}{strings.Repeat("k", n): {}}}
+	}
+	key := func(s string) commitChangesKey {
+		return commitChangesKey{commit: hash.Of([]byte(s)), table: "t"}
+	}
+
+	c := newCommitChangesCache(3 * (commitChangesOverhead + 100))
+	c.add(key("a"), changesOfSize(100))
+	c.add(key("b"), changesOfSize(100))
+	c.add(key("c"), changesOfSize(100))
+	assert.Equal(t, 3*(commitChangesOverhead+100), c.bytes)
+
+	// using a makes b the least recently used
+	_, ok := c.get(key("a"))
+	assert.True(t, ok)
+	c.add(key("d"), changesOfSize(150))
+	_, ok = c.get(key("b"))
+	assert.False(t, ok)
+	_, ok = c.get(key("c"))
+	assert.False(t, ok)
+	_, ok = c.get(key("a"))
+	assert.True(t, ok)
+	assert.Equal(t, 2*commitChangesOverhead+250, c.bytes)
+
+	// re-adding an entry replaces it
+	c.add(key("a"), changesOfSize(10))
+	assert.Equal(t, 2*commitChangesOverhead+160, c.bytes)
+
+	// changes larger than the cache aren't kept
+	c.add(key("e"), changesOfSize(10_000))
+	_, ok = c.get(key("e"))
+	assert.False(t, ok)
+	_, ok = c.get(key("d"))
+	assert.True(t, ok)
+}
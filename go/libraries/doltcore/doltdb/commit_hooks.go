@@ -18,10 +18,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"sync"
-	"time"
-
-	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -60,7 +56,7 @@ func pushDataset(ctx context.Context, destDB, srcDB datas.Database, ds datas.Dat
 		return err
 	}
 
-	err := pullHash(ctx, destDB, srcDB, []hash.Hash{addr}, tmpDir, nil, nil)
+	err := pullHash(ctx, destDB, srcDB, []hash.Hash{addr}, tmpDir, nil, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -106,61 +102,6 @@ type PushArg struct {
 	hash hash.Hash
 }
 
-type AsyncPushOnWriteHook struct {
-	out io.Writer
-	ch  chan PushArg
-}
-
-const (
-	asyncPushBufferSize    = 2048
-	asyncPushInterval      = 500 * time.Millisecond
-	asyncPushProcessCommit = "async_push_process_commit"
-	asyncPushSyncReplica   = "async_push_sync_replica"
-)
-
-var _ CommitHook = (*AsyncPushOnWriteHook)(nil)
-
-// NewAsyncPushOnWriteHook creates a AsyncReplicateHook
-func NewAsyncPushOnWriteHook(bThreads *sql.BackgroundThreads, destDB *DoltDB, tmpDir string, logger io.Writer) (*AsyncPushOnWriteHook, error) {
-	ch := make(chan PushArg, asyncPushBufferSize)
-	err := RunAsyncReplicationThreads(bThreads, ch, destDB, tmpDir, logger)
-	if err != nil {
-		return nil, err
-	}
-	return &AsyncPushOnWriteHook{ch: ch}, nil
-}
-
-func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
-	return false
-}
-
-// Execute implements CommitHook, replicates head updates to the destDb field
-func (ah *AsyncPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) (func(context.Context) error, error) {
-	addr, _ := ds.MaybeHeadAddr()
-
-	select {
-	case ah.ch <- PushArg{ds: ds, db: db, hash: addr}:
-	case <-ctx.Done():
-		ah.ch <- PushArg{ds: ds, db: db, hash: addr}
-		return nil, ctx.Err()
-	}
-	return nil, nil
-}
-
-// HandleError implements CommitHook
-func (ah *AsyncPushOnWriteHook) HandleError(ctx context.Context, err error) error {
-	if ah.out != nil {
-		ah.out.Write([]byte(err.Error()))
-	}
-	return nil
-}
-
-// SetLogger implements CommitHook
-func (ah *AsyncPushOnWriteHook) SetLogger(ctx context.Context, wr io.Writer) error {
-	ah.out = wr
-	return nil
-}
-
 type LogHook struct {
 	msg []byte
 	out io.Writer
@@ -199,99 +140,3 @@ func (lh *LogHook) SetLogger(ctx context.Context, wr io.Writer) error {
 func (*LogHook) ExecuteForWorkingSets() bool {
 	return false
 }
-
-func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ch chan PushArg, destDB *DoltDB, tmpDir string, logger io.Writer) error {
-	mu := &sync.Mutex{}
-	var newHeads = make(map[string]PushArg, asyncPushBufferSize)
-
-	updateHead := func(p PushArg) {
-		mu.Lock()
-		newHeads[p.ds.ID()] = p
-		mu.Unlock()
-	}
-
-	// newCtx lets first goroutine drain before the second goroutine finalizes
-	newCtx, stop := context.WithCancel(context.Background())
-
-	// The first goroutine amortizes commits into a map keyed by dataset id.
-	// When the parent context cancels, this goroutine drains and kills its
-	// dependent goroutine.
-	//
-	// We do not track sequential commits because push follows historical
-	// dependencies. This does not account for reset --force, which
-	// breaks historical dependence.
-	err := bThreads.Add(asyncPushProcessCommit, func(ctx context.Context) {
-		for {
-			select {
-			case p, ok := <-ch:
-				if !ok {
-					return
-				}
-				updateHead(p)
-			case <-ctx.Done():
-				stop()
-				return
-			}
-		}
-	})
-	if err != nil {
-		return err
-	}
-
-	getHeadsCopy := func() map[string]PushArg {
-		mu.Lock()
-		defer mu.Unlock()
-		if len(newHeads) == 0 {
-			return nil
-		}
-
-		toRet := newHeads
-		newHeads = make(map[string]PushArg, asyncPushBufferSize)
-
-		return toRet
-	}
-
-	flush := func(newHeads map[string]PushArg, latestHeads map[string]hash.Hash) {
-		newHeadsCopy := getHeadsCopy()
-		if len(newHeadsCopy) == 0 {
-			return
-		}
-		for id, newCm := range newHeadsCopy {
-			if latest, ok := latestHeads[id]; !ok || latest != newCm.hash {
-				// use background context to drain after sql context is canceled
-				err := pushDataset(context.Background(), destDB.db, newCm.db, newCm.ds, tmpDir)
-				if err != nil {
-					logger.Write([]byte("replication failed: " + err.Error()))
-				}
-				if newCm.hash.IsEmpty() {
-					delete(latestHeads, id)
-				} else {
-					latestHeads[id] = newCm.hash
-				}
-			}
-		}
-	}
-
-	// The second goroutine pushes updates to a remote chunkstore.
-	// This goroutine waits for first goroutine to drain before closing
-	// the channel and exiting.
-	err = bThreads.Add(asyncPushSyncReplica, func(ctx context.Context) {
-		defer close(ch)
-		var latestHeads = make(map[string]hash.Hash, asyncPushBufferSize)
-		ticker := time.NewTicker(asyncPushInterval)
-		for {
-			select {
-			case <-newCtx.Done():
-				flush(newHeads, latestHeads)
-				return
-			case <-ticker.C:
-				flush(newHeads, latestHeads)
-			}
-		}
-	})
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -225,7 +226,7 @@ func TestAsyncPushOnWrite(t *testing.T) {
 	t.Run("replicate to remote", func(t *testing.T) {
 		bThreads := sql.NewBackgroundThreads()
 		defer bThreads.Shutdown()
-		hook, err := NewAsyncPushOnWriteHook(bThreads, destDB, tmpDir, &buffer.Buffer{})
+		hook, err := NewAsyncPushOnWriteHook(ctx, bThreads, ddb, destDB, tmpDir, &buffer.Buffer{}, AsyncPushOptions{})
 		if err != nil {
 			t.Fatal("Unexpected error creating push hook", err)
 		}
@@ -296,7 +297,7 @@ func TestAsyncPushOnWrite(t *testing.T) {
 		destDB.SetCommitHooks(context.Background(), []CommitHook{counts})
 
 		bThreads := sql.NewBackgroundThreads()
-		hook, err := NewAsyncPushOnWriteHook(bThreads, destDB, tmpDir, &buffer.Buffer{})
+		hook, err := NewAsyncPushOnWriteHook(ctx, bThreads, ddb, destDB, tmpDir, &buffer.Buffer{}, AsyncPushOptions{})
 		require.NoError(t, err, "create push on write hook without an error")
 
 		// Pretend we replicate a HEAD which does exist.
@@ -349,3 +350,165 @@ func (c *countingCommitHook) SetLogger(ctx context.Context, wr io.Writer) error
 func (c *countingCommitHook) ExecuteForWorkingSets() bool {
 	return false
 }
+
+func TestRetryingPushOnWriteHook(t *testing.T) {
+	ctx := context.Background()
+
+	testDir, err := test.ChangeToTestDir("TestRetryingReplicationDest")
+	require.NoError(t, err)
+	require.NoError(t, filesys.LocalFS.MkDirs(filepath.Join(testDir, dbfactory.DoltDataDir)))
+	destDB, err := LoadDoltDB(ctx, types.Format_Default, LocalDirDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+
+	testDir, err = test.ChangeToTestDir("TestRetryingReplicationSource")
+	require.NoError(t, err)
+	tmpDir := filepath.Join(testDir, dbfactory.DoltDataDir)
+	require.NoError(t, filesys.LocalFS.MkDirs(tmpDir))
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, LocalDirDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, defaultBranch, "Bill Billerson", "bigbillieb@fake.horse"))
+
+	bThreads := sql.NewBackgroundThreads()
+	defer bThreads.Shutdown()
+	hook, err := NewRetryingPushOnWriteHook(bThreads, "origin", destDB, tmpDir)
+	require.NoError(t, err)
+	ddb.SetCommitHooks(ctx, []CommitHook{hook})
+
+	main := ref.NewBranchRef(defaultBranch)
+	t.Run("push as committed", func(t *testing.T) {
+		ds, err := ddb.db.GetDataset(ctx, main.String())
+		require.NoError(t, err)
+		_, err = hook.Execute(ctx, ds, ddb.db)
+		require.NoError(t, err)
+
+		statuses := ddb.ReplicationStatuses()
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "origin", statuses[0].Remote)
+		assert.Equal(t, main.String(), statuses[0].LastPushedRef)
+		addr, _ := ds.MaybeHeadAddr()
+		assert.Equal(t, addr, statuses[0].LastPushedCommit)
+		assert.Zero(t, statuses[0].Pending)
+		assert.Zero(t, statuses[0].FailedAttempts)
+
+		ok, err := destDB.HasRef(ctx, main)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("queue while failing", func(t *testing.T) {
+		hook.mu.Lock()
+		hook.status.FailedAttempts = 1
+		hook.status.NextAttempt = time.Now().Add(time.Hour)
+		hook.mu.Unlock()
+
+		commit, err := ddb.ResolveCommitRef(ctx, main)
+		require.NoError(t, err)
+		other := ref.NewBranchRef("other")
+		require.NoError(t, ddb.NewBranchAtCommit(ctx, other, commit, nil))
+		assert.Equal(t, 1, hook.ReplicationStatus().Pending)
+		ok, err := destDB.HasRef(ctx, other)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		// once the retry is due, the queued update is pushed
+		hook.mu.Lock()
+		hook.status.NextAttempt = time.Now()
+		hook.mu.Unlock()
+		hook.wake <- struct{}{}
+		require.Eventually(t, func() bool {
+			status := hook.ReplicationStatus()
+			return status.Pending == 0 && status.FailedAttempts == 0
+		}, 10*time.Second, 10*time.Millisecond)
+		assert.Equal(t, other.String(), hook.ReplicationStatus().LastPushedRef)
+		ok, err = destDB.HasRef(ctx, other)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func TestAsyncPushOnWriteQueue(t *testing.T) {
+	ctx := context.Background()
+
+	testDir, err := test.ChangeToTestDir("TestAsyncQueueReplicationDest")
+	require.NoError(t, err)
+	require.NoError(t, filesys.LocalFS.MkDirs(filepath.Join(testDir, dbfactory.DoltDataDir)))
+	destDB, err := LoadDoltDB(ctx, types.Format_Default, LocalDirDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+
+	testDir, err = test.ChangeToTestDir("TestAsyncQueueReplicationSource")
+	require.NoError(t, err)
+	tmpDir := filepath.Join(testDir, dbfactory.DoltDataDir)
+	require.NoError(t, filesys.LocalFS.MkDirs(tmpDir))
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, LocalDirDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, defaultBranch, "Bill Billerson", "bigbillieb@fake.horse"))
+
+	// a queue left by a previous run
+	main := ref.NewBranchRef(defaultBranch)
+	queueFile := filepath.Join(testDir, "replication_queue", "origin.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(queueFile), os.ModePerm))
+	require.NoError(t, os.WriteFile(queueFile, []byte(`{"refs/heads/main":"2024-01-01T00:00:00Z"}`), 0644))
+
+	bThreads := sql.NewBackgroundThreads()
+	defer bThreads.Shutdown()
+	hook, err := NewAsyncPushOnWriteHook(ctx, bThreads, ddb, destDB, tmpDir, nil, AsyncPushOptions{
+		Remote:    "origin",
+		QueueFile: queueFile,
+		MaxLag:    50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	ddb.SetCommitHooks(ctx, []CommitHook{hook})
+
+	t.Run("push queue of previous run", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return hook.ReplicationStatus().Pending == 0
+		}, 10*time.Second, 10*time.Millisecond)
+		status := hook.ReplicationStatus()
+		assert.Equal(t, "origin", status.Remote)
+		assert.Equal(t, main.String(), status.LastPushedRef)
+		ok, err := destDB.HasRef(ctx, main)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		_, err = os.Stat(queueFile)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("bounded lag", func(t *testing.T) {
+		hook.mu.Lock()
+		hook.status.FailedAttempts = 1
+		hook.status.NextAttempt = time.Now().Add(time.Hour)
+		hook.mu.Unlock()
+
+		other := ref.NewBranchRef("other")
+		commit, err := ddb.ResolveCommitRef(ctx, main)
+		require.NoError(t, err)
+		require.NoError(t, ddb.NewBranchAtCommit(ctx, other, commit, nil))
+		status := hook.ReplicationStatus()
+		assert.Equal(t, 1, status.Pending)
+		data, err := os.ReadFile(queueFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), other.String())
+
+		// the queued update is older than the maximum lag, so commits wait for it to be pushed
+		time.Sleep(100 * time.Millisecond)
+		ds, err := ddb.db.GetDataset(ctx, other.String())
+		require.NoError(t, err)
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err = hook.Execute(timeoutCtx, ds, ddb.db)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Greater(t, hook.ReplicationStatus().Lag, 50*time.Millisecond)
+
+		hook.mu.Lock()
+		hook.status.NextAttempt = time.Now()
+		hook.mu.Unlock()
+		_, err = hook.Execute(ctx, ds, ddb.db)
+		require.NoError(t, err)
+		status = hook.ReplicationStatus()
+		assert.Zero(t, status.Pending)
+		assert.Zero(t, status.FailedAttempts)
+		ok, err := destDB.HasRef(ctx, other)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
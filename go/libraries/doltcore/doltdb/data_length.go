@@ -0,0 +1,61 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrSampleUnsupportedFormat is returned when sampling the size of an index that is not in the __DOLT__ format.
+var ErrSampleUnsupportedFormat = errors.New("sampling index sizes is only supported for the __DOLT__ storage format")
+
+// dataLengthCacheSize is the number of index sizes kept by each DoltDB.
+const dataLengthCacheSize = 4096
+
+func newDataLengths() *lru.Cache[hash.Hash, uint64] {
+	dataLengths, _ := lru.New[hash.Hash, uint64](dataLengthCacheSize)
+	return dataLengths
+}
+
+// SampleDataLength returns an estimate of the bytes of storage used by |idx|, read from its chunks by
+// tree.SizeEstimator. It reads every internal node of the index and a sample of its leaves, so it's only used when
+// asked for; TableInfo.EstimatedDataLength is the estimate from the schema. Estimates are cached by the address of the root of
+// the index, so each version of an index is only sampled once.
+func (ddb *DoltDB) SampleDataLength(ctx context.Context, idx durable.Index) (uint64, error) {
+	if !types.IsFormat_DOLT(idx.Format()) {
+		return 0, ErrSampleUnsupportedFormat
+	}
+	m := durable.ProllyMapFromIndex(idx)
+	root := m.Node()
+	addr := root.HashOf()
+	if size, ok := ddb.dataLengths.Get(addr); ok {
+		return size, nil
+	}
+	est := tree.NewSizeEstimator(m.NodeStore())
+	if err := est.Add(ctx, root); err != nil {
+		return 0, err
+	}
+	size := est.Bytes()
+	ddb.dataLengths.Add(addr, size)
+	return size, nil
+}
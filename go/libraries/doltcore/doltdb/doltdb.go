@@ -22,12 +22,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
@@ -86,6 +88,18 @@ type DoltDB struct {
 	// parent directory as the database name. For non-filesystem based databases, the database name will not
 	// currently be populated.
 	databaseName string
+
+	// checksums holds the last checksummed version of each table on each branch, see TableChecksum.
+	checksums *tableChecksums
+	// dataLengths caches the sampled sizes of indexes by the address of their root, see SampleDataLength.
+	dataLengths *lru.Cache[hash.Hash, uint64]
+	// commitChanges holds the rows of tables changed by recently used commits, see CommitChanges.
+	commitChanges *commitChangesCache
+
+	// gcSafepoints coordinates garbage collection with holders of references to chunks, see BeginGCSafepoint.
+	gcSafepoints *gcSafepoints
+	// snapshots tracks the snapshot of the chunk store in progress, see BeginSnapshot.
+	snapshots *storeSnapshots
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
@@ -94,7 +108,7 @@ func DoltDBFromCS(cs chunks.ChunkStore, databaseName string) *DoltDB {
 	ns := tree.NewNodeStore(cs)
 	db := datas.NewTypesDatabase(vrw, ns)
 
-	return &DoltDB{db: hooksDatabase{Database: db}, vrw: vrw, ns: ns, databaseName: databaseName}
+	return &DoltDB{db: hooksDatabase{Database: db, headChanges: newHeadChanges()}, vrw: vrw, ns: ns, databaseName: databaseName, checksums: newTableChecksums(), dataLengths: newDataLengths(), commitChanges: newCommitChangesCache(commitChangesCacheBytes), gcSafepoints: newGCSafepoints(), snapshots: newStoreSnapshots()}
 }
 
 // GetDatabaseName returns the name of the database.
@@ -148,7 +162,7 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db: hooksDatabase{Database: db}, vrw: vrw, ns: ns, databaseName: name}, nil
+	return &DoltDB{db: hooksDatabase{Database: db, headChanges: newHeadChanges()}, vrw: vrw, ns: ns, databaseName: name, checksums: newTableChecksums(), dataLengths: newDataLengths(), commitChanges: newCommitChangesCache(commitChangesCacheBytes), gcSafepoints: newGCSafepoints(), snapshots: newStoreSnapshots()}, nil
 }
 
 // NomsRoot returns the hash of the noms dataset map
@@ -1143,6 +1157,77 @@ func (ddb *DoltDB) GetTuple(ctx context.Context, key string) ([]byte, bool, erro
 	return tup.Bytes(), true, nil
 }
 
+// SetSymbolicRef points the symbolic ref |sym| at the ref |target|.
+func (ddb *DoltDB) SetSymbolicRef(ctx context.Context, sym ref.SymbolicRef, target ref.DoltRef) error {
+	if target.GetType() == ref.SymbolicRefType {
+		return fmt.Errorf("symbolic ref %s cannot refer to another symbolic ref", sym.String())
+	}
+	if err := datas.ValidateDatasetId(sym.String()); err != nil {
+		return err
+	}
+
+	ds, err := ddb.db.GetDataset(ctx, sym.String())
+	if err != nil {
+		return err
+	}
+	_, err = ddb.db.SetTuple(ctx, ds, []byte(target.String()))
+	return err
+}
+
+// ResolveSymbolicRef returns the ref the symbolic ref |sym| points at, and whether |sym| exists.
+func (ddb *DoltDB) ResolveSymbolicRef(ctx context.Context, sym ref.SymbolicRef) (ref.DoltRef, bool, error) {
+	ds, err := ddb.db.GetDataset(ctx, sym.String())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ds.HasHead() {
+		return nil, false, nil
+	}
+
+	tup, err := datas.LoadTuple(ctx, ddb.Format(), ddb.NodeStore(), ddb.ValueReadWriter(), ds)
+	if err != nil {
+		return nil, false, err
+	}
+
+	target, err := ref.Parse(string(tup.Bytes()))
+	if err != nil {
+		return nil, false, fmt.Errorf("symbolic ref %s has an invalid target: %w", sym.String(), err)
+	}
+
+	return target, true, nil
+}
+
+// DeleteSymbolicRef deletes the symbolic ref |sym|, returning ErrSymbolicRefNotFound if it doesn't exist.
+func (ddb *DoltDB) DeleteSymbolicRef(ctx context.Context, sym ref.SymbolicRef) error {
+	err := ddb.deleteRef(ctx, sym, nil, "")
+	if err == ErrBranchNotFound {
+		return ErrSymbolicRefNotFound
+	}
+	return err
+}
+
+// GetDefaultBranch returns the branch the HEAD symbolic ref of the database points at, which is the branch clones of
+// the database check out. It returns false if HEAD isn't set, or if it points at a branch which doesn't exist.
+func (ddb *DoltDB) GetDefaultBranch(ctx context.Context) (ref.BranchRef, bool, error) {
+	target, ok, err := ddb.ResolveSymbolicRef(ctx, ref.NewHeadSymbolicRef())
+	if err != nil || !ok {
+		return ref.BranchRef{}, false, err
+	}
+
+	br, ok := target.(ref.BranchRef)
+	if !ok {
+		return ref.BranchRef{}, false, nil
+	}
+
+	ok, err = ddb.HasRef(ctx, br)
+	if err != nil || !ok {
+		return ref.BranchRef{}, false, err
+	}
+
+	return br, true, nil
+}
+
 var workspacesRefFilter = map[ref.RefType]struct{}{ref.WorkspaceRefType: {}}
 
 // GetWorkspaces returns a list of all workspaces in the database.
@@ -1185,6 +1270,135 @@ func (ddb *DoltDB) VisitRefsOfType(ctx context.Context, refTypeFilter map[ref.Re
 	return visitDatasets(ctx, refTypeFilter, visit, dss)
 }
 
+// VisitRefsWithPrefix calls |visit| for each ref whose string representation starts with |prefix|, e.g.
+// "refs/heads/team/". Refs outside of the prefix aren't read, which for a remote database avoids downloading the
+// portions of its ref map that other namespaces are stored in.
+func (ddb *DoltDB) VisitRefsWithPrefix(ctx context.Context, prefix string, visit func(r ref.DoltRef, addr hash.Hash) error) error {
+	dss, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return err
+	}
+
+	return dss.IterPrefix(ctx, prefix, func(key string, addr hash.Hash) error {
+		if !ref.IsRef(key) {
+			return nil
+		}
+
+		dref, err := ref.Parse(key)
+		if err != nil {
+			return err
+		}
+		return visit(dref, addr)
+	})
+}
+
+// RefLister is implemented by the chunk stores of remote databases whose servers can list the refs matching a set of
+// patterns themselves, so that a client doesn't need to read the ref map of the database to find them.
+type RefLister interface {
+	// ListRefs calls |cb| with the name and address of each ref whose name matches any of the Glob |patterns|. It
+	// returns chunks.ErrUnsupportedOperation if the server can't list refs.
+	ListRefs(ctx context.Context, patterns []string, cb func(name string, addr hash.Hash) error) error
+}
+
+// VisitRefsMatching calls |visit| for each ref whose string representation matches any of the ref.Glob |patterns|,
+// such as "refs/heads/release/*". For a remote database whose server supports it, the refs are filtered by the server.
+// Otherwise, only the portions of the ref map under the literal prefixes of the patterns are read.
+func (ddb *DoltDB) VisitRefsMatching(ctx context.Context, patterns []string, visit func(r ref.DoltRef, addr hash.Hash) error) error {
+	globs, err := parseGlobs(patterns)
+	if err != nil {
+		return err
+	}
+
+	if lister, ok := datas.ChunkStoreFromDatabase(ddb.db).(RefLister); ok {
+		err = lister.ListRefs(ctx, patterns, func(name string, addr hash.Hash) error {
+			return visitRefName(name, addr, visit)
+		})
+		if !errors.Is(err, chunks.ErrUnsupportedOperation) {
+			return err
+		}
+	}
+
+	dss, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return err
+	}
+	return visitDatasetsMatching(ctx, globs, visit, dss)
+}
+
+// VisitRefsMatchingByNomsRoot calls |visit| for each ref of the root |nomsRoot| whose string representation matches
+// any of the ref.Glob |patterns|. Only the portions of the ref map under the literal prefixes of the patterns are read.
+func (ddb *DoltDB) VisitRefsMatchingByNomsRoot(ctx context.Context, patterns []string, nomsRoot hash.Hash, visit func(r ref.DoltRef, addr hash.Hash) error) error {
+	globs, err := parseGlobs(patterns)
+	if err != nil {
+		return err
+	}
+
+	dss, err := ddb.db.DatasetsByRootHash(ctx, nomsRoot)
+	if err != nil {
+		return err
+	}
+	return visitDatasetsMatching(ctx, globs, visit, dss)
+}
+
+func parseGlobs(patterns []string) ([]ref.Glob, error) {
+	globs := make([]ref.Glob, len(patterns))
+	for i, pattern := range patterns {
+		g, err := ref.NewGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", err, pattern)
+		}
+		globs[i] = g
+	}
+	return globs, nil
+}
+
+func visitRefName(name string, addr hash.Hash, visit func(r ref.DoltRef, addr hash.Hash) error) error {
+	if !ref.IsRef(name) {
+		return nil
+	}
+	dref, err := ref.Parse(name)
+	if err != nil {
+		return err
+	}
+	return visit(dref, addr)
+}
+
+// visitDatasetsMatching visits the refs of |dss| which match any of |globs|, in order, reading only the datasets under
+// the literal prefixes of the globs. All refs are visited if there are no globs.
+func visitDatasetsMatching(ctx context.Context, globs []ref.Glob, visit func(r ref.DoltRef, addr hash.Hash) error, dss datas.DatasetsMap) error {
+	if len(globs) == 0 {
+		return dss.IterAll(ctx, func(name string, addr hash.Hash) error {
+			return visitRefName(name, addr, visit)
+		})
+	}
+
+	prefixes := make([]string, len(globs))
+	for i, g := range globs {
+		prefixes[i] = g.Prefix()
+	}
+	sort.Strings(prefixes)
+	for i, prefix := range prefixes {
+		// a namespace nested in one which was already read has nothing new
+		if i > 0 && strings.HasPrefix(prefix, prefixes[i-1]) {
+			prefixes[i] = prefixes[i-1]
+			continue
+		}
+
+		err := dss.IterPrefix(ctx, prefix, func(name string, addr hash.Hash) error {
+			for _, g := range globs {
+				if g.Matches(name) {
+					return visitRefName(name, addr, visit)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ddb *DoltDB) VisitRefsOfTypeByNomsRoot(ctx context.Context, refTypeFilter map[ref.RefType]struct{}, nomsRoot hash.Hash, visit func(r ref.DoltRef, addr hash.Hash) error) error {
 	dss, err := ddb.db.DatasetsByRootHash(ctx, nomsRoot)
 	if err != nil {
@@ -1677,7 +1891,8 @@ func (ddb *DoltDB) Rebase(ctx context.Context) error {
 // against this DoltDB. Examples of doing this include, for example, blocking
 // until no possibly-stale ChunkStore state is retained in memory, or failing
 // certain in-progress operations which cannot be finalized in a timely manner,
-// etc.
+// etc. Before |safepointF| is called, the GC waits for every caller of
+// BeginGCSafepoint to call EndGCSafepoint.
 func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, safepointF func() error) error {
 	collector, ok := ddb.db.Database.(datas.GarbageCollector)
 	if !ok {
@@ -1722,7 +1937,40 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, safepointF func()
 		return err
 	}
 
-	return collector.GC(ctx, mode, oldGen, newGen, safepointF)
+	var endSafepoint func()
+	defer func() {
+		if endSafepoint != nil {
+			endSafepoint()
+		}
+	}()
+	return collector.GC(ctx, mode, oldGen, newGen, func() error {
+		var err error
+		endSafepoint, err = ddb.gcSafepoints.establish(ctx)
+		if err != nil {
+			return err
+		}
+		// the kept checksums reference versions of tables that may not be reachable
+		ddb.checksums.clear()
+		if safepointF != nil {
+			return safepointF()
+		}
+		return nil
+	})
+}
+
+// BeginGCSafepoint marks the beginning of work which holds references to the chunks of this database outside of the
+// SQL sessions which GC coordinates with itself, such as an application embedding dolt which keeps a RootValue between
+// calls. Until the matching call to EndGCSafepoint, a GC won't pass its safepoint, after which the chunks it didn't
+// find to be reachable are removed. While a GC is past its safepoint, BeginGCSafepoint blocks until it ends or |ctx|
+// is done. References held from before a call to EndGCSafepoint must be resolved again after the next call to
+// BeginGCSafepoint, since a GC may have run in between.
+func (ddb *DoltDB) BeginGCSafepoint(ctx context.Context) error {
+	return ddb.gcSafepoints.begin(ctx)
+}
+
+// EndGCSafepoint marks the end of work begun with BeginGCSafepoint.
+func (ddb *DoltDB) EndGCSafepoint() {
+	ddb.gcSafepoints.end()
 }
 
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
@@ -1774,7 +2022,20 @@ func (ddb *DoltDB) PullChunks(
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
 ) error {
-	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes)
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes, nil)
+}
+
+// PullChunksWithFilter is PullChunks for a partial clone. The chunks which |filter| selects are skipped.
+func (ddb *DoltDB) PullChunksWithFilter(
+	ctx context.Context,
+	tempDir string,
+	srcDB *DoltDB,
+	targetHashes []hash.Hash,
+	statsCh chan pull.Stats,
+	skipHashes hash.HashSet,
+	filter *FetchFilter,
+) error {
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes, filter)
 }
 
 func pullHash(
@@ -1784,10 +2045,11 @@ func pullHash(
 	tempDir string,
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
+	filter *FetchFilter,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
-	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
+	waf := filter.walkAddrs(types.WalkAddrsForNBF(srcDB.Format(), skipHashes))
 
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
 		puller, err := pull.NewPuller(ctx, tempDir, defaultChunksPerTF, srcCS, destCS, waf, targetHashes, statsCh)
@@ -1815,6 +2077,26 @@ func (ddb *DoltDB) IsTableFileStore() bool {
 
 // ChunkJournal returns the ChunkJournal for this DoltDB, if one is in use.
 func (ddb *DoltDB) ChunkJournal() *nbs.ChunkJournal {
+	newGen := ddb.newGenNBS()
+	if newGen == nil {
+		return nil
+	}
+	return newGen.ChunkJournal()
+}
+
+// FlushJournal rolls the chunk journal of this DoltDB over into a table file, so that the chunks written to it no
+// longer need to be read from the journal when the database is opened. Returns nbs.ErrNoChunkJournal if this DoltDB
+// doesn't write a chunk journal.
+func (ddb *DoltDB) FlushJournal(ctx context.Context) error {
+	newGen := ddb.newGenNBS()
+	if newGen == nil {
+		return nbs.ErrNoChunkJournal
+	}
+	return newGen.FlushJournal(ctx)
+}
+
+// newGenNBS returns the new generation NomsBlockStore of this DoltDB, or nil if its chunk store isn't generational.
+func (ddb *DoltDB) newGenNBS() *nbs.NomsBlockStore {
 	tableFileStore, ok := datas.ChunkStoreFromDatabase(ddb.db).(chunks.TableFileStore)
 	if !ok {
 		return nil
@@ -1825,13 +2107,30 @@ func (ddb *DoltDB) ChunkJournal() *nbs.ChunkJournal {
 		return nil
 	}
 
-	newGen := generationalNbs.NewGen()
-	nbs, ok := newGen.(*nbs.NomsBlockStore)
+	newGen, ok := generationalNbs.NewGen().(*nbs.NomsBlockStore)
 	if !ok {
 		return nil
 	}
+	return newGen
+}
 
-	return nbs.ChunkJournal()
+// StoreSize returns the number of bytes used by the chunk store of this DoltDB, as the bytes of its table files and
+// journal, and the bytes of chunks which have been written but are not yet persisted. Returns an error if the
+// chunk store is not a TableFileStore.
+func (ddb *DoltDB) StoreSize(ctx context.Context) (persisted, pending uint64, err error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	tableFileStore, ok := cs.(chunks.TableFileStore)
+	if !ok {
+		return 0, 0, errors.New("unsupported operation, DoltDB.StoreSize on non-TableFileStore")
+	}
+	persisted, err = tableFileStore.Size(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ps, ok := cs.(interface{ PendingSize() uint64 }); ok {
+		pending = ps.PendingSize()
+	}
+	return persisted, pending, nil
 }
 
 func (ddb *DoltDB) TableFileStoreHasJournal(ctx context.Context) (bool, error) {
@@ -1861,6 +2160,11 @@ func (ddb *DoltDB) SetCommitHooks(ctx context.Context, postHooks []CommitHook) *
 	return ddb
 }
 
+// PostCommitHooks returns the hooks run after a commit to any dataset of |ddb|.
+func (ddb *DoltDB) PostCommitHooks() []CommitHook {
+	return ddb.db.PostCommitHooks()
+}
+
 func (ddb *DoltDB) PrependCommitHook(ctx context.Context, hook CommitHook) *DoltDB {
 	ddb.db = ddb.db.SetCommitHooks(ctx, append([]CommitHook{hook}, ddb.db.PostCommitHooks()...))
 	return ddb
@@ -218,6 +218,55 @@ func TestEmptyInMemoryRepoCreation(t *testing.T) {
 	}
 }
 
+func TestSymbolicRefs(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	head := ref.NewHeadSymbolicRef()
+	_, ok, err := ddb.ResolveSymbolicRef(ctx, head)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, ok, err = ddb.GetDefaultBranch(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	cs, _ := NewCommitSpec("main")
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	cm, _ := optCmt.ToCommit()
+	trunk := ref.NewBranchRef("trunk")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, trunk, cm, nil))
+
+	require.NoError(t, ddb.SetSymbolicRef(ctx, head, trunk))
+	target, ok, err := ddb.ResolveSymbolicRef(ctx, head)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "refs/heads/trunk", target.String())
+	br, ok, err := ddb.GetDefaultBranch(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "trunk", br.GetPath())
+
+	// symbolic refs aren't commit refs
+	refs, err := ddb.GetRefsWithHashes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, refs, 2)
+
+	// a HEAD referring to a deleted branch isn't a default branch
+	require.NoError(t, ddb.DeleteBranch(ctx, trunk, nil))
+	_, ok, err = ddb.GetDefaultBranch(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Error(t, ddb.SetSymbolicRef(ctx, head, ref.NewSymbolicRef("other")))
+
+	require.NoError(t, ddb.DeleteSymbolicRef(ctx, head))
+	assert.Equal(t, ErrSymbolicRefNotFound, ddb.DeleteSymbolicRef(ctx, head))
+}
+
 func TestLoadNonExistentLocalFSRepo(t *testing.T) {
 	_, err := test.ChangeToTestDir("TestLoadRepo")
 
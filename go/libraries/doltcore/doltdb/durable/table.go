@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/pool"
 	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/message"
 	"github.com/dolthub/dolt/go/store/prolly/shim"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
@@ -763,6 +765,11 @@ func newDoltDevTable(ctx context.Context, vrw types.ValueReadWriter, ns tree.Nod
 		return nil, err
 	}
 
+	rows, err = withRowLayout(ctx, rows, sch)
+	if err != nil {
+		return nil, err
+	}
+
 	schemaRef, err := refFromNomsValue(ctx, vrw, schVal)
 	if err != nil {
 		return nil, err
@@ -806,6 +813,68 @@ func newDoltDevTable(ctx context.Context, vrw types.ValueReadWriter, ns tree.Nod
 	return doltDevTable{vrw, ns, msg}, nil
 }
 
+// withRowLayout returns |rows| chunked to the target node size of |sch|, with zone maps for its zone map columns and
+// in its storage layout, rechunking them if they were chunked to another size, written with other zone maps or
+// written in another layout.
+func withRowLayout(ctx context.Context, rows Index, sch schema.Schema) (Index, error) {
+	m := ProllyMapFromIndex(rows)
+	sameSize := tree.EffectiveTargetNodeSize(m.TargetNodeSize()) == tree.EffectiveTargetNodeSize(sch.GetTargetNodeSize())
+	// a single node stores its values row-wise in either layout
+	sameLayout := m.Columnar() == isColumnar(sch) || m.Node().IsLeaf()
+	m, err := m.WithTargetNodeSize(sch.GetTargetNodeSize()).WithZoneMapFields(ZoneMapFields(sch)...)
+	if err != nil {
+		return nil, err
+	}
+	m = m.WithColumnar(isColumnar(sch))
+	keepsZones, err := m.KeepsZoneMaps()
+	if err != nil {
+		return nil, err
+	}
+	if sameSize && keepsZones && sameLayout {
+		return IndexFromProllyMap(m), nil
+	}
+	m, err = prolly.RechunkMap(ctx, m, sch.GetTargetNodeSize())
+	if err != nil {
+		return nil, err
+	}
+	return IndexFromProllyMap(m), nil
+}
+
+// isColumnar returns whether the primary index of a table with schema |sch| stores its rows column-wise.
+func isColumnar(sch schema.Schema) bool {
+	return sch.GetStorageLayout() == schema.ColumnarStorageLayout
+}
+
+// ZoneMapFields returns the value fields of the primary index of a table with schema |sch| that keep zone maps, in
+// the order of its zone map columns. Columns that were dropped, virtual columns and columns whose encoding doesn't
+// support zone maps are skipped, as are the columns of keyless tables.
+func ZoneMapFields(sch schema.Schema) []int {
+	tags := sch.GetZoneMapColumns()
+	if len(tags) == 0 || schema.IsKeyless(sch) {
+		return nil
+	}
+	vd := sch.GetValueDescriptor()
+	var fields []int
+	for _, tag := range tags {
+		field, i := -1, 0
+		for _, col := range sch.GetNonPKCols().GetColumns() {
+			if col.Virtual {
+				continue
+			}
+			if col.Tag == tag {
+				field = i
+				break
+			}
+			i++
+		}
+		if field < 0 || message.ValidateZoneMapFields(vd, append(slices.Clone(fields), field)) != nil {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
 func (t doltDevTable) nomsValue() types.Value {
 	return types.SerialMessage(t.msg.Table().Bytes)
 }
@@ -827,7 +896,34 @@ func (t doltDevTable) GetSchema(ctx context.Context) (schema.Schema, error) {
 	return schemaFromAddr(ctx, t.vrw, addr)
 }
 
+// SetSchema implements Table. A schema without a target node size keeps the table's, as does a schema with nil zone
+// map columns or an unspecified storage layout, and the table's rows are rechunked if the size, the zone map fields or
+// the layout change.
 func (t doltDevTable) SetSchema(ctx context.Context, sch schema.Schema) (Table, error) {
+	oldSch, err := t.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sch.GetTargetNodeSize() == 0 && oldSch.GetTargetNodeSize() != 0 {
+		sch = sch.Copy()
+		sch.SetTargetNodeSize(oldSch.GetTargetNodeSize())
+	}
+	if sch.GetZoneMapColumns() == nil && oldSch.GetZoneMapColumns() != nil {
+		sch = sch.Copy()
+		// columns dropped from the table drop out of its zone maps
+		var tags []uint64
+		for _, tag := range oldSch.GetZoneMapColumns() {
+			if _, ok := sch.GetAllCols().GetByTag(tag); ok {
+				tags = append(tags, tag)
+			}
+		}
+		sch.SetZoneMapColumns(append([]uint64{}, tags...))
+	}
+	if sch.GetStorageLayout() == schema.UnspecifiedStorageLayout && oldSch.GetStorageLayout() != schema.UnspecifiedStorageLayout {
+		sch = sch.Copy()
+		sch.SetStorageLayout(oldSch.GetStorageLayout())
+	}
+
 	newSchemaVal, err := encoding.MarshalSchema(ctx, t.vrw, sch)
 	if err != nil {
 		return nil, err
@@ -841,7 +937,20 @@ func (t doltDevTable) SetSchema(ctx context.Context, sch schema.Schema) (Table,
 	addr := schRef.TargetHash()
 	msg := t.clone()
 	copy(msg.SchemaBytes(), addr[:])
-	return doltDevTable{t.vrw, t.ns, msg}, nil
+	tbl := doltDevTable{t.vrw, t.ns, msg}
+	if tree.EffectiveTargetNodeSize(sch.GetTargetNodeSize()) == tree.EffectiveTargetNodeSize(oldSch.GetTargetNodeSize()) &&
+		slices.Equal(ZoneMapFields(sch), ZoneMapFields(oldSch)) && isColumnar(sch) == isColumnar(oldSch) {
+		return tbl, nil
+	}
+
+	rows, err := tbl.GetTableRows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the rows are still chunked to the old size, keep the old zone maps and are stored in the old layout, so setting
+	// them rechunks them
+	rows = IndexFromProllyMap(ProllyMapFromIndex(rows).WithTargetNodeSize(oldSch.GetTargetNodeSize()).WithColumnar(isColumnar(oldSch)))
+	return tbl.SetTableRows(ctx, rows)
 }
 
 func (t doltDevTable) GetTableRows(ctx context.Context) (Index, error) {
@@ -854,7 +963,11 @@ func (t doltDevTable) GetTableRows(ctx context.Context) (Index, error) {
 	if err != nil {
 		return nil, err
 	}
-	return IndexFromMapInterface(m), nil
+	pm, err := m.(prolly.Map).WithTargetNodeSize(sch.GetTargetNodeSize()).WithZoneMapFields(ZoneMapFields(sch)...)
+	if err != nil {
+		return nil, err
+	}
+	return IndexFromProllyMap(pm.WithColumnar(isColumnar(sch))), nil
 }
 
 func (t doltDevTable) GetTableRowsWithDescriptors(ctx context.Context, kd, vd val.TupleDesc) (Index, error) {
@@ -866,7 +979,19 @@ func (t doltDevTable) GetTableRowsWithDescriptors(ctx context.Context, kd, vd va
 	return IndexFromMapInterface(m), nil
 }
 
+// SetTableRows implements Table. Rows that weren't chunked to the target node size of the table's schema, that don't
+// keep the zone maps of its zone map columns, or that aren't stored in its storage layout, are rechunked, so that the
+// layout of a table's rows only depends on its rows and its schema.
 func (t doltDevTable) SetTableRows(ctx context.Context, rows Index) (Table, error) {
+	sch, err := t.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err = withRowLayout(ctx, rows, sch)
+	if err != nil {
+		return nil, err
+	}
+
 	rowsbytes, err := rows.bytes()
 	if err != nil {
 		return nil, err
@@ -34,6 +34,7 @@ var ErrHashNotFound = errors.New("could not find a value for this hash")
 var ErrBranchNotFound = errors.New("branch not found")
 var ErrTagNotFound = errors.New("tag not found")
 var ErrTupleNotFound = errors.New("tuple not found")
+var ErrSymbolicRefNotFound = errors.New("symbolic ref not found")
 var ErrWorkingSetNotFound = errors.New("working set not found")
 var ErrWorkspaceNotFound = errors.New("workspace not found")
 var ErrTableNotFound = errors.New("table not found")
@@ -0,0 +1,178 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// FetchFilter selects chunks which a fetch skips, as with the --filter of a partial clone. Skipped chunks are fetched
+// from the remote when they are first read.
+type FetchFilter struct {
+	// BlobLimit is the size in bytes of the largest value whose chunks are fetched. Only the root chunk of each larger
+	// value is fetched.
+	BlobLimit uint64
+}
+
+// ParseFetchFilter parses a filter spec of the form "blob:none" or "blob:limit=<n>[kmg]".
+func ParseFetchFilter(spec string) (*FetchFilter, error) {
+	if spec == "blob:none" {
+		return &FetchFilter{BlobLimit: 0}, nil
+	}
+	limit, ok := strings.CutPrefix(spec, "blob:limit=")
+	if !ok {
+		return nil, fmt.Errorf("invalid filter '%s': expected 'blob:none' or 'blob:limit=<n>[kmg]'", spec)
+	}
+
+	mult := uint64(1)
+	if len(limit) > 0 {
+		switch limit[len(limit)-1] {
+		case 'k', 'K':
+			mult = 1 << 10
+		case 'm', 'M':
+			mult = 1 << 20
+		case 'g', 'G':
+			mult = 1 << 30
+		}
+		if mult != 1 {
+			limit = limit[:len(limit)-1]
+		}
+	}
+	n, err := strconv.ParseUint(limit, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter '%s': %w", spec, err)
+	}
+	if n > math.MaxUint64/mult {
+		return nil, fmt.Errorf("invalid filter '%s': limit is too large", spec)
+	}
+	return &FetchFilter{BlobLimit: n * mult}, nil
+}
+
+// String returns the filter spec of |f|.
+func (f *FetchFilter) String() string {
+	if f.BlobLimit == 0 {
+		return "blob:none"
+	}
+	return "blob:limit=" + strconv.FormatUint(f.BlobLimit, 10)
+}
+
+// skipsChildren returns true if |f| skips the chunks referenced by |c|. Blob leaves hold a fixed number of bytes, so
+// the size of a value is computed from the number of leaves under its root.
+func (f *FetchFilter) skipsChildren(c chunks.Chunk) bool {
+	data := c.Data()
+	if serial.GetFileID(data) != serial.BlobFileID {
+		return false
+	}
+	blob, err := serial.TryGetRootAsBlob(data, serial.MessagePrefixSz)
+	if err != nil || blob.TreeLevel() == 0 {
+		return false
+	}
+	return blob.TreeSize()*tree.DefaultFixedChunkLength > f.BlobLimit
+}
+
+// walkAddrs returns |waf| filtered by |f|.
+func (f *FetchFilter) walkAddrs(waf pull.WalkAddrs) pull.WalkAddrs {
+	if f == nil {
+		return waf
+	}
+	return func(c chunks.Chunk, cb func(hash.Hash, bool) error) error {
+		if f.skipsChildren(c) {
+			return nil
+		}
+		return waf(c, cb)
+	}
+}
+
+// FetchChunkTrees fetches the chunks of |hashes| from |srcDB|, along with the chunks they reference which are absent
+// from |destCS|. It fetches the chunks a partial clone skipped.
+func FetchChunkTrees(ctx context.Context, srcDB *DoltDB, destCS chunks.ChunkStore, hashes hash.HashSet) ([]chunks.Chunk, error) {
+	srcCS := datas.ChunkStoreFromDatabase(srcDB.db)
+	waf := types.WalkAddrsForNBF(srcDB.Format(), nil)
+
+	var mu sync.Mutex
+	var fetched []chunks.Chunk
+	seen := hashes.Copy()
+	for len(hashes) > 0 {
+		next := hash.NewHashSet()
+		var walkErr error
+		err := srcCS.GetMany(ctx, hashes, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			fetched = append(fetched, *c)
+			err := waf(*c, func(h hash.Hash, _ bool) error {
+				if !seen.Has(h) {
+					seen.Insert(h)
+					next.Insert(h)
+				}
+				return nil
+			})
+			if err != nil && walkErr == nil {
+				walkErr = err
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		if walkErr != nil {
+			return nil, walkErr
+		}
+
+		hashes, err = destCS.HasMany(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fetched, nil
+}
+
+// SetPartialCloneSource makes |ddb| fetch the chunks skipped by a partial clone from the database returned by |open|
+// when they are first read. |open| is called when the first missing chunk is read. It does nothing if |ddb| isn't a
+// local database.
+func (ddb *DoltDB) SetPartialCloneSource(open func(ctx context.Context) (*DoltDB, error)) {
+	gcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(*nbs.GenerationalNBS)
+	if !ok {
+		return
+	}
+
+	var mu sync.Mutex
+	var srcDB *DoltDB
+	gcs.SetLazyChunkFetcher(func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+		mu.Lock()
+		if srcDB == nil {
+			db, err := open(ctx)
+			if err != nil {
+				mu.Unlock()
+				return nil, fmt.Errorf("failed to fetch chunks skipped by a partial clone: %w", err)
+			}
+			srcDB = db
+		}
+		mu.Unlock()
+		return FetchChunkTrees(ctx, srcDB, gcs, hashes)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+func TestParseFetchFilter(t *testing.T) {
+	tests := []struct {
+		spec  string
+		limit uint64
+		err   bool
+	}{
+		{"blob:none", 0, false},
+		{"blob:limit=0", 0, false},
+		{"blob:limit=512", 512, false},
+		{"blob:limit=4k", 4 << 10, false},
+		{"blob:limit=1M", 1 << 20, false},
+		{"blob:limit=2g", 2 << 30, false},
+		{"blob:limit=", 0, true},
+		{"blob:limit=k", 0, true},
+		{"blob:limit=-1", 0, true},
+		{"blob:limit=1t", 0, true},
+		{"blob:limit=18446744073709551615k", 0, true},
+		{"tree:0", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			f, err := ParseFetchFilter(test.spec)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.limit, f.BlobLimit)
+
+			roundTrip, err := ParseFetchFilter(f.String())
+			require.NoError(t, err)
+			assert.Equal(t, f, roundTrip)
+		})
+	}
+}
+
+func TestFetchFilterSkipsChildren(t *testing.T) {
+	ctx := context.Background()
+	cs := (&chunks.TestStorage{}).NewView()
+	ns := tree.NewNodeStore(cs)
+
+	blobRoot := func(size int) chunks.Chunk {
+		bb, err := tree.NewBlobBuilder(tree.DefaultFixedChunkLength)
+		require.NoError(t, err)
+		bb.SetNodeStore(ns)
+		bb.Init(size)
+		_, addr, err := bb.Chunk(ctx, bytes.NewReader(make([]byte, size)))
+		require.NoError(t, err)
+		c, err := cs.Get(ctx, addr)
+		require.NoError(t, err)
+		require.False(t, c.IsEmpty())
+		return c
+	}
+
+	small := blobRoot(100)
+	large := blobRoot(100 * tree.DefaultFixedChunkLength)
+
+	none := &FetchFilter{BlobLimit: 0}
+	assert.False(t, none.skipsChildren(small), "a blob with a single chunk has no children")
+	assert.True(t, none.skipsChildren(large))
+
+	limit := &FetchFilter{BlobLimit: 1 << 20}
+	assert.False(t, limit.skipsChildren(large))
+	limit = &FetchFilter{BlobLimit: 64 << 10}
+	assert.True(t, limit.skipsChildren(large))
+}
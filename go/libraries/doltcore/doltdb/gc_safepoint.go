@@ -0,0 +1,104 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sync"
+)
+
+// gcSafepoints coordinates the garbage collection of a DoltDB with work holding references to its chunks, such as
+// RootValues kept by an application embedding dolt between calls. See DoltDB.BeginGCSafepoint.
+type gcSafepoints struct {
+	mu sync.Mutex
+	// held is the number of callers between BeginGCSafepoint and EndGCSafepoint
+	held int
+	// drained is closed when |held| reaches 0 while a GC waits for it
+	drained chan struct{}
+	// finished is non-nil from the time a GC establishes its safepoint until it ends, when it's closed
+	finished chan struct{}
+}
+
+func newGCSafepoints() *gcSafepoints {
+	return &gcSafepoints{}
+}
+
+// begin waits for any GC past its safepoint to end, then records a new holder of references.
+func (s *gcSafepoints) begin(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		finished := s.finished
+		if finished == nil {
+			s.held++
+			s.mu.Unlock()
+			return nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// end records that a holder of references no longer holds them.
+func (s *gcSafepoints) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.held == 0 {
+		panic("EndGCSafepoint called without a matching BeginGCSafepoint")
+	}
+	s.held--
+	if s.held == 0 && s.drained != nil {
+		close(s.drained)
+		s.drained = nil
+	}
+}
+
+// establish blocks new holders of references and waits for the current ones to end, returning a function which
+// unblocks them once the GC has ended.
+func (s *gcSafepoints) establish(ctx context.Context) (func(), error) {
+	s.mu.Lock()
+	finished := make(chan struct{})
+	s.finished = finished
+	var drained chan struct{}
+	if s.held > 0 {
+		drained = make(chan struct{})
+		s.drained = drained
+	}
+	s.mu.Unlock()
+
+	done := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.finished == finished {
+			s.finished = nil
+			s.drained = nil
+		}
+		close(finished)
+	}
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			done()
+			return nil, ctx.Err()
+		}
+	}
+	return done, nil
+}
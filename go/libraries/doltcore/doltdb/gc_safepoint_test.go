@@ -0,0 +1,85 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCSafepoints(t *testing.T) {
+	ctx := context.Background()
+	s := newGCSafepoints()
+
+	t.Run("establish waits for holders", func(t *testing.T) {
+		require.NoError(t, s.begin(ctx))
+		require.NoError(t, s.begin(ctx))
+
+		established := make(chan func())
+		go func() {
+			done, err := s.establish(ctx)
+			assert.NoError(t, err)
+			established <- done
+		}()
+
+		s.end()
+		select {
+		case <-established:
+			t.Fatal("safepoint established with a holder remaining")
+		case <-time.After(50 * time.Millisecond):
+		}
+		s.end()
+		done := <-established
+
+		// holders wait for the gc to end
+		began := make(chan struct{})
+		go func() {
+			assert.NoError(t, s.begin(ctx))
+			close(began)
+		}()
+		select {
+		case <-began:
+			t.Fatal("began while a gc was past its safepoint")
+		case <-time.After(50 * time.Millisecond):
+		}
+		done()
+		<-began
+		s.end()
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		require.NoError(t, s.begin(ctx))
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := s.establish(cancelCtx)
+		assert.ErrorIs(t, err, context.Canceled)
+		s.end()
+
+		done, err := s.establish(ctx)
+		require.NoError(t, err)
+		assert.ErrorIs(t, s.begin(cancelCtx), context.Canceled)
+		done()
+		require.NoError(t, s.begin(ctx))
+		s.end()
+	})
+
+	t.Run("unmatched end", func(t *testing.T) {
+		assert.Panics(t, s.end)
+	})
+}
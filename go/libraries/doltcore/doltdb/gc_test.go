@@ -19,6 +19,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,7 @@ func TestGarbageCollection(t *testing.T) {
 	}
 
 	t.Run("HasCacheDataCorruption", testGarbageCollectionHasCacheDataCorruptionBugFix)
+	t.Run("WaitsForSafepoints", testGarbageCollectionWaitsForSafepoints)
 }
 
 type stage struct {
@@ -265,3 +267,32 @@ func newAddrMap(t *testing.T, ctx context.Context, ns tree.NodeStore, key string
 
 	return m
 }
+
+func testGarbageCollectionWaitsForSafepoints(t *testing.T) {
+	ctx := context.Background()
+
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_DOLT, "file://"+t.TempDir(), filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+
+	err = ddb.WriteEmptyRepo(ctx, "main", "Aaron Son", "aaron@dolthub.com")
+	require.NoError(t, err)
+
+	require.NoError(t, ddb.BeginGCSafepoint(ctx))
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	err = ddb.GC(timeoutCtx, types.GCModeDefault, func() error {
+		t.Fatal("safepoint established while references were held")
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	ddb.EndGCSafepoint()
+
+	var called bool
+	err = ddb.GC(ctx, types.GCModeDefault, func() error {
+		called = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
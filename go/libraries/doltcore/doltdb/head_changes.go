@@ -0,0 +1,49 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import "sync"
+
+// headChanges notifies waiters of the updates of the heads and working sets of a database.
+type headChanges struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newHeadChanges() *headChanges {
+	return &headChanges{ch: make(chan struct{})}
+}
+
+func (c *headChanges) changed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ch
+}
+
+func (c *headChanges) notify() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	close(c.ch)
+	c.ch = make(chan struct{})
+}
+
+// HeadsChanged returns a channel which is closed at the next update of a head or working set of |ddb| made through
+// this DoltDB. Updates made by other processes are not noticed.
+func (ddb *DoltDB) HeadsChanged() <-chan struct{} {
+	if ddb.db.headChanges == nil {
+		return nil
+	}
+	return ddb.db.headChanges.changed()
+}
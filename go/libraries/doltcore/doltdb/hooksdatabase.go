@@ -28,6 +28,7 @@ type hooksDatabase struct {
 	datas.Database
 	postCommitHooks []CommitHook
 	rsc             *ReplicationStatusController
+	headChanges     *headChanges
 }
 
 // CommitHook is an abstraction for executing arbitrary commands after atomic database commits
@@ -75,6 +76,9 @@ func (db hooksDatabase) PostCommitHooks() []CommitHook {
 }
 
 func (db hooksDatabase) ExecuteCommitHooks(ctx context.Context, ds datas.Dataset, onlyWS bool) {
+	if db.headChanges != nil {
+		db.headChanges.notify()
+	}
 	var wg sync.WaitGroup
 	rsc := db.rsc
 	var ioff int
@@ -172,6 +176,20 @@ func (db hooksDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHe
 	return ds, err
 }
 
+func (db hooksDatabase) UpdateHeads(ctx context.Context, updates []datas.HeadUpdate) error {
+	err := db.Database.UpdateHeads(ctx, updates)
+	if err == nil {
+		for _, u := range updates {
+			ds, err := db.GetDataset(ctx, u.DatasetID)
+			if err != nil {
+				return err
+			}
+			db.ExecuteCommitHooks(ctx, ds, false)
+		}
+	}
+	return err
+}
+
 func (db hooksDatabase) Delete(ctx context.Context, ds datas.Dataset, workingSetPath string) (datas.Dataset, error) {
 	ds, err := db.Database.Delete(ctx, ds, workingSetPath)
 	if err == nil {
@@ -285,6 +285,11 @@ func (ip *IgnorePatterns) IsTableNameIgnored(tableName TableName) (IgnoreResult,
 			}
 		}
 	}
+	// The dolt_privileges table holds the password hashes of a server's users, so it's ignored unless a pattern which
+	// matches it doesn't ignore it.
+	if strings.EqualFold(tableName.Name, PrivilegesTableName) && len(falseMatches) == 0 {
+		return Ignore, nil
+	}
 	if len(trueMatches) == 0 {
 		return DontIgnore, nil
 	}
@@ -0,0 +1,57 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivilegesTableIgnored(t *testing.T) {
+	tests := []struct {
+		patterns IgnorePatterns
+		expected IgnoreResult
+	}{
+		{
+			patterns: IgnorePatterns{},
+			expected: Ignore,
+		},
+		{
+			patterns: IgnorePatterns{NewIgnorePattern("dolt_*", true)},
+			expected: Ignore,
+		},
+		{
+			patterns: IgnorePatterns{NewIgnorePattern(PrivilegesTableName, false)},
+			expected: DontIgnore,
+		},
+		{
+			patterns: IgnorePatterns{NewIgnorePattern("dolt_*", true), NewIgnorePattern(PrivilegesTableName, false)},
+			expected: DontIgnore,
+		},
+	}
+
+	for _, test := range tests {
+		ignored, err := test.patterns.IsTableNameIgnored(TableName{Name: PrivilegesTableName})
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, ignored)
+	}
+
+	var none IgnorePatterns
+	ignored, err := none.IsTableNameIgnored(TableName{Name: "t"})
+	require.NoError(t, err)
+	assert.Equal(t, DontIgnore, ignored)
+}
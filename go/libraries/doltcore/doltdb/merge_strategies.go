@@ -0,0 +1,197 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeStrategy is the strategy used to merge a table, as configured in the dolt_merge_strategies system table.
+type MergeStrategy string
+
+const (
+	// MergeStrategyOurs keeps our version of the table, discarding their changes to it.
+	MergeStrategyOurs MergeStrategy = "ours"
+	// MergeStrategyTheirs takes their version of the table, discarding our changes to it.
+	MergeStrategyTheirs MergeStrategy = "theirs"
+	// MergeStrategyUnion merges the rows of the table, resolving conflicting rows instead of recording conflicts: a
+	// row deleted on one side and modified on the other is kept with its modification, and a row modified differently
+	// on both sides keeps our version.
+	MergeStrategyUnion MergeStrategy = "union"
+	// MergeStrategyManual merges the rows of the table, recording conflicting rows as conflicts to be resolved by the
+	// user. A row modified on both sides is merged column by column, and is only a conflict when both sides changed
+	// the same column to different values. This is the strategy used for tables that don't match any pattern.
+	MergeStrategyManual MergeStrategy = "manual"
+	// MergeStrategyRow merges the rows of the table like MergeStrategyManual, except that a row modified on both
+	// sides is always a conflict, even when the sides modified different columns.
+	MergeStrategyRow MergeStrategy = "row"
+)
+
+// MergeStrategyNames are the values of the strategy column of the dolt_merge_strategies system table, in order.
+var MergeStrategyNames = []string{
+	string(MergeStrategyOurs),
+	string(MergeStrategyTheirs),
+	string(MergeStrategyUnion),
+	string(MergeStrategyManual),
+	string(MergeStrategyRow),
+}
+
+// ParseMergeStrategy returns the MergeStrategy named |name|, case-insensitively.
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	for _, n := range MergeStrategyNames {
+		if strings.EqualFold(n, name) {
+			return MergeStrategy(n), nil
+		}
+	}
+	return "", fmt.Errorf("unknown merge strategy '%s', expected one of %s", name, strings.Join(MergeStrategyNames, ", "))
+}
+
+// MergeStrategyPattern is a row of the dolt_merge_strategies system table: the merge strategy for tables whose names
+// match a pattern.
+type MergeStrategyPattern struct {
+	Pattern  string
+	Strategy MergeStrategy
+}
+
+// MergeStrategyPatterns are the rows of the dolt_merge_strategies system table.
+type MergeStrategyPatterns []MergeStrategyPattern
+
+// GetMergeStrategyPatterns returns the patterns in the dolt_merge_strategies table of |root| for the schema named
+// |schemaName|. If the table doesn't exist, no patterns are returned.
+func GetMergeStrategyPatterns(ctx context.Context, root RootValue, schemaName string) (MergeStrategyPatterns, error) {
+	tname := TableName{Name: MergeStrategiesTableName, Schema: schemaName}
+	table, found, err := root.GetTable(ctx, tname)
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_merge_strategies is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("%s had unexpected key type, this should never happen", MergeStrategiesTableName)
+	}
+	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.EnumEnc, Nullable: false})) {
+		return nil, fmt.Errorf("%s had unexpected value type, this should never happen", MergeStrategiesTableName)
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var patterns MergeStrategyPatterns
+	for {
+		keyTuple, valueTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pattern, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read pattern")
+		}
+		// enum values are 1-indexed
+		strategy, ok := valueDesc.GetEnum(0, valueTuple)
+		if !ok || strategy == 0 || int(strategy) > len(MergeStrategyNames) {
+			return nil, fmt.Errorf("could not read merge strategy for pattern %s", pattern)
+		}
+		patterns = append(patterns, MergeStrategyPattern{Pattern: pattern, Strategy: MergeStrategy(MergeStrategyNames[strategy-1])})
+	}
+
+	return patterns, nil
+}
+
+// StrategyForTable returns the merge strategy for |tableName|. Patterns are matched the same way as dolt_ignore
+// patterns, and when a table matches several patterns, more specific patterns override less specific ones. Tables
+// that don't match any pattern are merged with MergeStrategyManual.
+func (ps MergeStrategyPatterns) StrategyForTable(tableName TableName) (MergeStrategy, error) {
+	var matches MergeStrategyPatterns
+	for _, p := range ps {
+		patternRegExp, err := compilePattern(p.Pattern)
+		if err != nil {
+			return "", err
+		}
+		if patternRegExp.MatchString(tableName.Name) {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) == 0 {
+		return MergeStrategyManual, nil
+	}
+
+	// Discard every match that is less specific than another match.
+	var mostSpecific MergeStrategyPatterns
+	for _, p := range matches {
+		moreSpecific, err := getMoreSpecificPatterns(p.Pattern)
+		if err != nil {
+			return "", err
+		}
+		overridden := false
+		for _, other := range matches {
+			if normalizePattern(other.Pattern) != normalizePattern(p.Pattern) && moreSpecific.MatchString(other.Pattern) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			mostSpecific = append(mostSpecific, p)
+		}
+	}
+
+	strategy := mostSpecific[0].Strategy
+	for _, p := range mostSpecific[1:] {
+		if p.Strategy != strategy {
+			return "", DoltMergeStrategyConflictError{Table: tableName, Patterns: mostSpecific}
+		}
+	}
+	return strategy, nil
+}
+
+// DoltMergeStrategyConflictError is returned when a table being merged matches patterns in dolt_merge_strategies that
+// name different strategies, none of which is more specific than the others.
+type DoltMergeStrategyConflictError struct {
+	Table    TableName
+	Patterns MergeStrategyPatterns
+}
+
+func (e DoltMergeStrategyConflictError) Error() string {
+	patterns := make([]string, len(e.Patterns))
+	for i, p := range e.Patterns {
+		patterns[i] = fmt.Sprintf("%s (%s)", p.Pattern, p.Strategy)
+	}
+	sort.Strings(patterns)
+	return fmt.Sprintf("the table %s matches conflicting patterns in %s: %s", e.Table, MergeStrategiesTableName, strings.Join(patterns, ", "))
+}
@@ -0,0 +1,273 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	replicationRetryInitialInterval = time.Second
+	replicationRetryMaxInterval     = time.Minute
+	replicationRetryThread          = "replication_retry"
+)
+
+// ReplicationStatus is the status of the push-on-write replication of a database to one of its remotes.
+type ReplicationStatus struct {
+	// Remote is the name of the remote.
+	Remote string
+	// LastPushedRef is the ref most recently pushed to the remote, or empty if nothing was pushed yet.
+	LastPushedRef string
+	// LastPushedCommit is the commit the ref was pushed at, which is empty if the push deleted the ref.
+	LastPushedCommit hash.Hash
+	// LastPushTime is the time of the most recent successful push.
+	LastPushTime time.Time
+	// Pending is the number of refs waiting to be pushed to the remote.
+	Pending int
+	// Lag is how long the oldest update of the pending refs has waited to be pushed.
+	Lag time.Duration
+	// FailedAttempts is the number of consecutive pushes to the remote which failed.
+	FailedAttempts int
+	// LastError is the error of the most recent push if it failed, or empty if it succeeded.
+	LastError string
+	// NextAttempt is when the pending refs will next be pushed, if the most recent push failed.
+	NextAttempt time.Time
+}
+
+// ReplicationStatusReporter is implemented by commit hooks which replicate to a remote, and report the status of
+// their replication.
+type ReplicationStatusReporter interface {
+	ReplicationStatus() ReplicationStatus
+}
+
+// ReplicationStatuses returns the status of the replication of |ddb| by each of its commit hooks which replicate to
+// a remote.
+func (ddb *DoltDB) ReplicationStatuses() []ReplicationStatus {
+	var statuses []ReplicationStatus
+	for _, hook := range ddb.PostCommitHooks() {
+		if r, ok := hook.(ReplicationStatusReporter); ok {
+			statuses = append(statuses, r.ReplicationStatus())
+		}
+	}
+	return statuses
+}
+
+// queuedPush is a head update waiting to be pushed. |seq| orders the updates of a dataset, so that an update which
+// failed to push never replaces a more recent one. |queuedAt| is when the update was made.
+type queuedPush struct {
+	PushArg
+	seq      uint64
+	queuedAt time.Time
+}
+
+// RetryingPushOnWriteHook replicates head updates to a remote as they are committed, like PushOnWriteHook. Updates
+// which fail to push are queued and retried in the background with exponential backoff. While the remote is failing,
+// updates are queued rather than pushed as they are committed, so that a remote which is down does not slow down
+// commits. Every remote is replicated to by its own hook, so one failing remote does not delay the others.
+type RetryingPushOnWriteHook struct {
+	remote string
+	destDB datas.Database
+	tmpDir string
+	out    io.Writer
+
+	// pushMu serializes pushes, so that an older head is never pushed over a newer one.
+	pushMu sync.Mutex
+
+	mu      sync.Mutex
+	seq     uint64
+	pending map[string]queuedPush
+	status  ReplicationStatus
+	backoff *backoff.ExponentialBackOff
+	wake    chan struct{}
+}
+
+var _ CommitHook = (*RetryingPushOnWriteHook)(nil)
+var _ ReplicationStatusReporter = (*RetryingPushOnWriteHook)(nil)
+
+// NewRetryingPushOnWriteHook creates a RetryingPushOnWriteHook which pushes to |destDB|, the database of the remote
+// named |remote|, and retries failed pushes on a thread of |bThreads|.
+func NewRetryingPushOnWriteHook(bThreads *sql.BackgroundThreads, remote string, destDB *DoltDB, tmpDir string) (*RetryingPushOnWriteHook, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = replicationRetryInitialInterval
+	b.MaxInterval = replicationRetryMaxInterval
+	b.MaxElapsedTime = 0
+	h := &RetryingPushOnWriteHook{
+		remote:  remote,
+		destDB:  destDB.db,
+		tmpDir:  tmpDir,
+		pending: make(map[string]queuedPush),
+		status:  ReplicationStatus{Remote: remote},
+		backoff: b,
+		wake:    make(chan struct{}, 1),
+	}
+	if err := bThreads.Add(replicationRetryThread+"_"+remote, h.retryPending); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Execute implements CommitHook, replicates head updates to the remote
+func (h *RetryingPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) (func(context.Context) error, error) {
+	addr, _ := ds.MaybeHeadAddr()
+	h.mu.Lock()
+	h.seq++
+	p := queuedPush{PushArg: PushArg{ds: ds, db: db, hash: addr}, seq: h.seq, queuedAt: time.Now()}
+	if h.status.FailedAttempts > 0 {
+		// the remote is failing, so leave the push to the retries
+		h.queueLocked(p)
+		h.mu.Unlock()
+		return nil, nil
+	}
+	h.mu.Unlock()
+	return nil, h.push(ctx, p)
+}
+
+// push pushes |p| to the remote and records the outcome. A failed push is queued to be retried.
+func (h *RetryingPushOnWriteHook) push(ctx context.Context, p queuedPush) error {
+	h.pushMu.Lock()
+	defer h.pushMu.Unlock()
+	err := pushDataset(ctx, h.destDB, p.db, p.ds, h.tmpDir)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id := p.ds.ID()
+	queued, isQueued := h.pending[id]
+	if err != nil {
+		h.queueLocked(p)
+		h.status.FailedAttempts++
+		h.status.LastError = err.Error()
+		h.status.NextAttempt = time.Now().Add(h.backoff.NextBackOff())
+		select {
+		case h.wake <- struct{}{}:
+		default:
+		}
+		return fmt.Errorf("error pushing %s to remote %s: %w", id, h.remote, err)
+	}
+
+	if isQueued && queued.seq <= p.seq {
+		delete(h.pending, id)
+	}
+	h.backoff.Reset()
+	h.status.FailedAttempts = 0
+	h.status.LastError = ""
+	h.status.NextAttempt = time.Time{}
+	h.status.LastPushedRef = id
+	h.status.LastPushedCommit = p.hash
+	h.status.LastPushTime = time.Now()
+	return nil
+}
+
+// queueLocked queues |p| to be retried, unless a more recent update of its dataset is queued. The lag is measured
+// from the oldest update of the dataset which is queued.
+func (h *RetryingPushOnWriteHook) queueLocked(p queuedPush) {
+	if queued, ok := h.pending[p.ds.ID()]; ok {
+		if queued.seq >= p.seq {
+			return
+		}
+		if queued.queuedAt.Before(p.queuedAt) {
+			p.queuedAt = queued.queuedAt
+		}
+	}
+	h.pending[p.ds.ID()] = p
+}
+
+// retryPending pushes the queued head updates whenever their next attempt is due, until |ctx| is done.
+func (h *RetryingPushOnWriteHook) retryPending(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		h.mu.Lock()
+		queued := make([]queuedPush, 0, len(h.pending))
+		for _, p := range h.pending {
+			queued = append(queued, p)
+		}
+		wait := time.Until(h.status.NextAttempt)
+		h.mu.Unlock()
+
+		if len(queued) > 0 && wait <= 0 {
+			for _, p := range queued {
+				err := background.Run(ctx, background.Replication, func(ctx context.Context) error {
+					return h.push(ctx, p)
+				})
+				if err != nil {
+					h.HandleError(ctx, err)
+					break
+				}
+			}
+			continue
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var due <-chan time.Time
+		if len(queued) > 0 {
+			timer.Reset(wait)
+			due = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.wake:
+		case <-due:
+		}
+	}
+}
+
+// ReplicationStatus implements ReplicationStatusReporter
+func (h *RetryingPushOnWriteHook) ReplicationStatus() ReplicationStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := h.status
+	status.Pending = len(h.pending)
+	for _, p := range h.pending {
+		if lag := time.Since(p.queuedAt); lag > status.Lag {
+			status.Lag = lag
+		}
+	}
+	return status
+}
+
+// HandleError implements CommitHook
+func (h *RetryingPushOnWriteHook) HandleError(ctx context.Context, err error) error {
+	if h.out != nil {
+		h.out.Write([]byte(err.Error() + "\n"))
+	}
+	return nil
+}
+
+// SetLogger implements CommitHook
+func (h *RetryingPushOnWriteHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	h.out = wr
+	return nil
+}
+
+func (*RetryingPushOnWriteHook) ExecuteForWorkingSets() bool {
+	return false
+}
@@ -472,6 +472,78 @@ func GetAllSchemas(ctx context.Context, root RootValue) (map[TableName]schema.Sc
 	return m, nil
 }
 
+// TableInfo summarizes a single table in a RootValue.
+type TableInfo struct {
+	Name       TableName
+	TableHash  hash.Hash
+	SchemaHash hash.Hash
+	Schema     schema.Schema
+	// RowCount is the number of rows in the table's primary index.
+	RowCount uint64
+	// EstimatedDataLength is an estimate of the bytes of the table's primary index, the row count times the average
+	// row width of the schema. It isn't measured from storage, so it doesn't account for compression, out-of-band
+	// values or the chunks the index shares with other tables and versions. It's what SHOW TABLE STATUS and
+	// information_schema.tables report as DATA_LENGTH unless @@dolt_sample_data_length is set, in which case
+	// DoltDB.SampleDataLength reads the size from the index's chunks instead.
+	EstimatedDataLength uint64
+}
+
+// GetTableInfo returns the TableInfo for |tbl|, which is stored as |name| and has the schema |sch|.
+func GetTableInfo(ctx context.Context, name TableName, tbl *Table, sch schema.Schema) (TableInfo, error) {
+	tblHash, err := tbl.HashOf()
+	if err != nil {
+		return TableInfo{}, err
+	}
+	schHash, err := tbl.GetSchemaHash(ctx)
+	if err != nil {
+		return TableInfo{}, err
+	}
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return TableInfo{}, err
+	}
+	cnt, err := rows.Count()
+	if err != nil {
+		return TableInfo{}, err
+	}
+
+	return TableInfo{
+		Name:                name,
+		TableHash:           tblHash,
+		SchemaHash:          schHash,
+		Schema:              sch,
+		RowCount:            cnt,
+		EstimatedDataLength: cnt * schemaAvgRowLength(sch),
+	}, nil
+}
+
+// GetTableInfos returns a TableInfo for every table in |root|, across all database schemas, in a single pass over
+// the root's table maps.
+func GetTableInfos(ctx context.Context, root RootValue) ([]TableInfo, error) {
+	var infos []TableInfo
+	err := root.IterTables(ctx, func(name TableName, table *Table, sch schema.Schema) (stop bool, err error) {
+		info, err := GetTableInfo(ctx, name, table, sch)
+		if err != nil {
+			return true, err
+		}
+		infos = append(infos, info)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func schemaAvgRowLength(sch schema.Schema) uint64 {
+	cols := sch.GetAllCols().GetColumns()
+	sqlSch := make(sql.Schema, len(cols))
+	for i, col := range cols {
+		sqlSch[i] = &sql.Column{Name: col.Name, Type: col.TypeInfo.ToSqlType()}
+	}
+	return schema.SchemaAvgLength(sqlSch)
+}
+
 func (root *rootValue) TableListHash() uint64 {
 	return root.tablesHash
 }
@@ -0,0 +1,92 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestGetTableInfos(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cs, _ := NewCommitSpec("main")
+	optCmt, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	cm, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	root, err := cm.GetRootValue(ctx)
+	require.NoError(t, err)
+
+	root, err = CreateEmptyTable(ctx, root, TableName{Name: "b"}, createTestSchema(t))
+	require.NoError(t, err)
+	otherSch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 100, types.IntKind, true, schema.NotNullConstraint{}),
+	))
+	root, err = CreateEmptyTable(ctx, root, TableName{Name: "a"}, otherSch)
+	require.NoError(t, err)
+
+	infos, err := GetTableInfos(ctx, root)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name.Less(infos[j].Name)
+	})
+
+	for i, name := range []string{"a", "b"} {
+		info := infos[i]
+		assert.Equal(t, name, info.Name.Name)
+
+		tblHash, ok, err := root.GetTableHash(ctx, info.Name)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, tblHash, info.TableHash)
+
+		schHash, err := root.GetTableSchemaHash(ctx, info.Name)
+		require.NoError(t, err)
+		assert.Equal(t, schHash, info.SchemaHash)
+
+		assert.Equal(t, uint64(0), info.RowCount)
+		assert.Equal(t, uint64(0), info.EstimatedDataLength)
+
+		// the sampled data length of an empty table is the size of its empty primary index
+		tbl, ok, err := root.GetTable(ctx, info.Name)
+		require.NoError(t, err)
+		require.True(t, ok)
+		rows, err := tbl.GetRowData(ctx)
+		require.NoError(t, err)
+		m := durable.ProllyMapFromIndex(rows)
+		est := tree.NewSizeEstimator(m.NodeStore())
+		require.NoError(t, est.Add(ctx, m.Node()))
+		sampled, err := ddb.SampleDataLength(ctx, rows)
+		require.NoError(t, err)
+		assert.Equal(t, est.Bytes(), sampled)
+		assert.NotZero(t, sampled)
+	}
+}
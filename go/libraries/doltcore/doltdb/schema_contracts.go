@@ -0,0 +1,431 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// SchemaPolicy is a schema compatibility policy, as configured in the dolt_schema_contracts system table.
+type SchemaPolicy string
+
+const (
+	// SchemaPolicyNoColumnDrops forbids dropping the columns of a table, or the table itself. Columns are matched by
+	// name, so renaming a column drops it.
+	SchemaPolicyNoColumnDrops SchemaPolicy = "no_column_drops"
+	// SchemaPolicyNoTypeNarrowing forbids changing the type of a column to a type that can't hold all the values of
+	// the old type, such as a smaller integer, a shorter string or an enum without some of the old members.
+	SchemaPolicyNoTypeNarrowing SchemaPolicy = "no_type_narrowing"
+	// SchemaPolicyEnumAdditionsOnly forbids any change to an enum or set column other than adding members after the
+	// existing ones.
+	SchemaPolicyEnumAdditionsOnly SchemaPolicy = "enum_additions_only"
+)
+
+// SchemaPolicyNames are the values of the policy column of the dolt_schema_contracts system table, in order.
+var SchemaPolicyNames = []string{
+	string(SchemaPolicyNoColumnDrops),
+	string(SchemaPolicyNoTypeNarrowing),
+	string(SchemaPolicyEnumAdditionsOnly),
+}
+
+// SchemaContract is a row of the dolt_schema_contracts system table: a policy that schema changes to the tables whose
+// names match a pattern must follow on the branches whose names match another pattern.
+type SchemaContract struct {
+	BranchPattern string
+	TablePattern  string
+	Policy        SchemaPolicy
+}
+
+// SchemaContracts are the rows of the dolt_schema_contracts system table.
+type SchemaContracts []SchemaContract
+
+// GetSchemaContracts returns the contracts in the dolt_schema_contracts table of |root| for the schema named
+// |schemaName|. If the table doesn't exist, no contracts are returned.
+func GetSchemaContracts(ctx context.Context, root RootValue, schemaName string) (SchemaContracts, error) {
+	tname := TableName{Name: SchemaContractsTableName, Schema: schemaName}
+	table, found, err := root.GetTable(ctx, tname)
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_schema_contracts is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc}, val.Type{Enc: val.StringEnc}, val.Type{Enc: val.EnumEnc})) {
+		return nil, fmt.Errorf("%s had unexpected key type, this should never happen", SchemaContractsTableName)
+	}
+	if valueDesc.Count() != 0 {
+		return nil, fmt.Errorf("%s had unexpected value type, this should never happen", SchemaContractsTableName)
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var contracts SchemaContracts
+	for {
+		keyTuple, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		branchPattern, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read branch pattern")
+		}
+		tablePattern, ok := keyDesc.GetString(1, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read table pattern")
+		}
+		// enum values are 1-indexed
+		policy, ok := keyDesc.GetEnum(2, keyTuple)
+		if !ok || policy == 0 || int(policy) > len(SchemaPolicyNames) {
+			return nil, fmt.Errorf("could not read schema policy for branch pattern %s and table pattern %s", branchPattern, tablePattern)
+		}
+		contracts = append(contracts, SchemaContract{
+			BranchPattern: branchPattern,
+			TablePattern:  tablePattern,
+			Policy:        SchemaPolicy(SchemaPolicyNames[policy-1]),
+		})
+	}
+
+	return contracts, nil
+}
+
+// ForBranch returns the contracts whose branch pattern matches |branch|. Patterns are matched the same way as
+// dolt_ignore patterns.
+func (cs SchemaContracts) ForBranch(branch string) (SchemaContracts, error) {
+	var matches SchemaContracts
+	for _, c := range cs {
+		patternRegExp, err := compilePattern(c.BranchPattern)
+		if err != nil {
+			return nil, err
+		}
+		if patternRegExp.MatchString(branch) {
+			matches = append(matches, c)
+		}
+	}
+	return matches, nil
+}
+
+// PoliciesForTable returns the policies of the contracts whose table pattern matches |tableName|. Unlike
+// dolt_merge_strategies, every matching pattern applies, since policies only ever add restrictions.
+func (cs SchemaContracts) PoliciesForTable(tableName TableName) (map[SchemaPolicy]bool, error) {
+	policies := make(map[SchemaPolicy]bool)
+	for _, c := range cs {
+		patternRegExp, err := compilePattern(c.TablePattern)
+		if err != nil {
+			return nil, err
+		}
+		if patternRegExp.MatchString(tableName.Name) {
+			policies[c.Policy] = true
+		}
+	}
+	return policies, nil
+}
+
+// SchemaContractViolation is a schema change that breaks a policy of dolt_schema_contracts.
+type SchemaContractViolation struct {
+	Table  TableName
+	Column string
+	Policy SchemaPolicy
+	Detail string
+}
+
+func (v SchemaContractViolation) String() string {
+	if v.Column == "" {
+		return fmt.Sprintf("%s: %s (%s)", v.Table, v.Detail, v.Policy)
+	}
+	return fmt.Sprintf("%s.%s: %s (%s)", v.Table, v.Column, v.Detail, v.Policy)
+}
+
+// ErrSchemaContractViolations is returned when the schema changes made to a branch break the policies that
+// dolt_schema_contracts configures for it. Its message reports every violation.
+type ErrSchemaContractViolations struct {
+	Branch     string
+	Violations []SchemaContractViolation
+}
+
+func (e ErrSchemaContractViolations) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = "\t" + v.String()
+	}
+	return fmt.Sprintf("schema changes to branch %s violate %s:\n%s", e.Branch, SchemaContractsTableName, strings.Join(lines, "\n"))
+}
+
+// CheckSchemaContracts checks the schema changes from |before| to |after| against the policies that the
+// dolt_schema_contracts table of |before| configures for |branch|, and returns an ErrSchemaContractViolations
+// reporting any violations. The contracts are read from |before| so that a change can't loosen the contracts it is
+// checked against.
+func CheckSchemaContracts(ctx context.Context, branch string, before, after RootValue) error {
+	contracts, err := GetSchemaContracts(ctx, before, DefaultSchemaName)
+	if err != nil {
+		return err
+	}
+	contracts, err = contracts.ForBranch(branch)
+	if err != nil || len(contracts) == 0 {
+		return err
+	}
+
+	tableNames, err := before.GetTableNames(ctx, DefaultSchemaName)
+	if err != nil {
+		return err
+	}
+	sort.Strings(tableNames)
+
+	var violations []SchemaContractViolation
+	for _, name := range tableNames {
+		if HasDoltPrefix(name) {
+			continue
+		}
+		tableName := TableName{Name: name, Schema: DefaultSchemaName}
+		policies, err := contracts.PoliciesForTable(tableName)
+		if err != nil {
+			return err
+		}
+		if len(policies) == 0 {
+			continue
+		}
+
+		beforeTable, _, err := before.GetTable(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		beforeSch, err := beforeTable.GetSchema(ctx)
+		if err != nil {
+			return err
+		}
+		afterTable, ok, err := after.GetTable(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if policies[SchemaPolicyNoColumnDrops] {
+				violations = append(violations, SchemaContractViolation{Table: tableName, Policy: SchemaPolicyNoColumnDrops, Detail: "table dropped"})
+			}
+			continue
+		}
+		afterSch, err := afterTable.GetSchema(ctx)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, checkTableSchemaContract(tableName, policies, beforeSch, afterSch)...)
+	}
+
+	if len(violations) > 0 {
+		return ErrSchemaContractViolations{Branch: branch, Violations: violations}
+	}
+	return nil
+}
+
+func checkTableSchemaContract(tableName TableName, policies map[SchemaPolicy]bool, before, after schema.Schema) []SchemaContractViolation {
+	var violations []SchemaContractViolation
+	_ = before.GetAllCols().Iter(func(_ uint64, col schema.Column) (stop bool, err error) {
+		afterCol, ok := after.GetAllCols().GetByName(col.Name)
+		if !ok {
+			if policies[SchemaPolicyNoColumnDrops] {
+				violations = append(violations, SchemaContractViolation{Table: tableName, Column: col.Name, Policy: SchemaPolicyNoColumnDrops, Detail: "column dropped"})
+			}
+			return false, nil
+		}
+
+		from, to := col.TypeInfo.ToSqlType(), afterCol.TypeInfo.ToSqlType()
+		if from.Equals(to) {
+			return false, nil
+		}
+		change := fmt.Sprintf("type changed from %s to %s", from.String(), to.String())
+		if policies[SchemaPolicyEnumAdditionsOnly] && (isEnumOrSet(from) || isEnumOrSet(to)) && !membersAppended(from, to) {
+			violations = append(violations, SchemaContractViolation{Table: tableName, Column: col.Name, Policy: SchemaPolicyEnumAdditionsOnly, Detail: change})
+		}
+		if policies[SchemaPolicyNoTypeNarrowing] && !isWideningTypeChange(from, to) {
+			violations = append(violations, SchemaContractViolation{Table: tableName, Column: col.Name, Policy: SchemaPolicyNoTypeNarrowing, Detail: change})
+		}
+		return false, nil
+	})
+	return violations
+}
+
+func isEnumOrSet(t sql.Type) bool {
+	switch t.(type) {
+	case sql.EnumType, sql.SetType:
+		return true
+	default:
+		return false
+	}
+}
+
+// enumOrSetMembers returns the members of |from| and |to| if they are both enums or both sets with the same collation.
+func enumOrSetMembers(from, to sql.Type) (fromValues, toValues []string, ok bool) {
+	switch from := from.(type) {
+	case sql.EnumType:
+		to, ok := to.(sql.EnumType)
+		if !ok || from.Collation() != to.Collation() {
+			return nil, nil, false
+		}
+		return from.Values(), to.Values(), true
+	case sql.SetType:
+		to, ok := to.(sql.SetType)
+		if !ok || from.Collation() != to.Collation() {
+			return nil, nil, false
+		}
+		return from.Values(), to.Values(), true
+	default:
+		return nil, nil, false
+	}
+}
+
+// membersAppended returns whether |to| only adds members after the members of |from|.
+func membersAppended(from, to sql.Type) bool {
+	fromValues, toValues, ok := enumOrSetMembers(from, to)
+	if !ok || len(fromValues) > len(toValues) {
+		return false
+	}
+	for i := range fromValues {
+		if fromValues[i] != toValues[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// membersKept returns whether |to| has every member of |from|, in any order.
+func membersKept(from, to sql.Type) bool {
+	fromValues, toValues, ok := enumOrSetMembers(from, to)
+	if !ok {
+		return false
+	}
+	kept := make(map[string]bool, len(toValues))
+	for _, v := range toValues {
+		kept[v] = true
+	}
+	for _, v := range fromValues {
+		if !kept[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// isWideningTypeChange returns whether every value of the type |from| is also a value of the type |to|. Changes
+// between different kinds of types, such as from an integer to a string, are never widening.
+func isWideningTypeChange(from, to sql.Type) bool {
+	switch from := from.(type) {
+	case sql.NumberType:
+		to, ok := to.(sql.NumberType)
+		if !ok {
+			return false
+		}
+		if from.IsFloat() || to.IsFloat() {
+			return from.IsFloat() && to.IsFloat() && floatBits(to) >= floatBits(from)
+		}
+		fromBits, toBits := integerBits(from), integerBits(to)
+		switch {
+		case from.IsSigned() == to.IsSigned():
+			return toBits >= fromBits
+		case to.IsSigned():
+			// an unsigned integer fits in a signed integer with more bits
+			return toBits > fromBits
+		default:
+			return false
+		}
+	case sql.DecimalType:
+		to, ok := to.(sql.DecimalType)
+		if !ok {
+			return false
+		}
+		return to.Scale() >= from.Scale() && int(to.Precision())-int(to.Scale()) >= int(from.Precision())-int(from.Scale())
+	case sql.StringType:
+		to, ok := to.(sql.StringType)
+		if !ok || isBinaryString(from) != isBinaryString(to) {
+			return false
+		}
+		return to.CharacterSet() == from.CharacterSet() && to.MaxCharacterLength() >= from.MaxCharacterLength()
+	case sql.EnumType, sql.SetType:
+		return membersKept(from, to)
+	case sql.DatetimeType:
+		to, ok := to.(sql.DatetimeType)
+		if !ok || to.Precision() < from.Precision() {
+			return false
+		}
+		switch {
+		case from.Type() == to.Type():
+			return true
+		case to.Type() == query.Type_DATETIME:
+			// dates and timestamps are all valid datetimes
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func integerBits(t sql.NumberType) int {
+	switch t.Type() {
+	case query.Type_INT8, query.Type_UINT8:
+		return 8
+	case query.Type_INT16, query.Type_UINT16:
+		return 16
+	case query.Type_INT24, query.Type_UINT24:
+		return 24
+	case query.Type_INT32, query.Type_UINT32:
+		return 32
+	default:
+		return 64
+	}
+}
+
+func floatBits(t sql.NumberType) int {
+	if t.Type() == query.Type_FLOAT32 {
+		return 32
+	}
+	return 64
+}
+
+func isBinaryString(t sql.StringType) bool {
+	switch t.Type() {
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// storeSnapshots tracks the snapshot in progress of the chunk store of a DoltDB, which is ended when it times out so
+// that a backup which fails to end it doesn't block commits indefinitely.
+type storeSnapshots struct {
+	mu sync.Mutex
+	// timer ends the snapshot in progress when it times out, and is nil when there is none
+	timer *time.Timer
+}
+
+func newStoreSnapshots() *storeSnapshots {
+	return &storeSnapshots{}
+}
+
+// BeginSnapshot begins a snapshot of the files of this DoltDB for a filesystem-level backup, such as an LVM or ZFS
+// snapshot, or a copy with rsync, and returns the files to back up. Until the snapshot is ended by EndSnapshot, or
+// after |timeout| if it isn't, the files aren't changed by commits or garbage collection, which wait for its end, and
+// so can be copied consistently while the database is in use. Returns nbs.ErrSnapshotUnsupported if the database
+// isn't stored on the local filesystem.
+func (ddb *DoltDB) BeginSnapshot(ctx context.Context, timeout time.Duration) ([]nbs.SnapshotFile, error) {
+	store, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.SnapshotStore)
+	if !ok {
+		return nil, nbs.ErrSnapshotUnsupported
+	}
+
+	ddb.snapshots.mu.Lock()
+	defer ddb.snapshots.mu.Unlock()
+	if ddb.snapshots.timer != nil {
+		return nil, nbs.ErrSnapshotInProgress
+	}
+	files, err := store.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(timeout, func() {
+		ddb.snapshots.mu.Lock()
+		defer ddb.snapshots.mu.Unlock()
+		if ddb.snapshots.timer != timer {
+			return
+		}
+		logrus.Warnf("ending snapshot of database %s after it timed out after %s", ddb.databaseName, timeout)
+		ddb.snapshots.timer = nil
+		if err := store.EndSnapshot(); err != nil {
+			logrus.Errorf("failed to end snapshot of database %s: %s", ddb.databaseName, err.Error())
+		}
+	})
+	ddb.snapshots.timer = timer
+	return files, nil
+}
+
+// EndSnapshot ends the snapshot begun by BeginSnapshot. Returns nbs.ErrNoSnapshotInProgress if there is none, which
+// is the case if it timed out.
+func (ddb *DoltDB) EndSnapshot() error {
+	store, ok := datas.ChunkStoreFromDatabase(ddb.db).(nbs.SnapshotStore)
+	if !ok {
+		return nbs.ErrSnapshotUnsupported
+	}
+
+	ddb.snapshots.mu.Lock()
+	defer ddb.snapshots.mu.Unlock()
+	if ddb.snapshots.timer == nil {
+		return nbs.ErrNoSnapshotInProgress
+	}
+	ddb.snapshots.timer.Stop()
+	ddb.snapshots.timer = nil
+	return store.EndSnapshot()
+}
@@ -121,7 +121,8 @@ func IsReadOnlySystemTable(name TableName) bool {
 // IsNonAlterableSystemTable returns whether the table name given is a system table that cannot be dropped or altered
 // by the user.
 func IsNonAlterableSystemTable(name TableName) bool {
-	return (IsReadOnlySystemTable(name) && !IsFullTextTable(name.Name)) || strings.EqualFold(name.Name, SchemasTableName)
+	return (IsReadOnlySystemTable(name) && !IsFullTextTable(name.Name)) || strings.EqualFold(name.Name, SchemasTableName) ||
+		strings.EqualFold(name.Name, PrivilegesTableName)
 }
 
 // GetNonSystemTableNames gets non-system table names
@@ -191,6 +192,15 @@ var getWriteableSystemTables = func() []string {
 		SchemasTableName,
 		ProceduresTableName,
 		IgnoreTableName,
+		MergeStrategiesTableName,
+		ColumnMergeStrategiesTableName,
+		SchemaContractsTableName,
+		AppendOnlyTablesTableName,
+		AuditColumnsTableName,
+		ExportJobsTableName,
+		SequencesTableName,
+		PrivilegesTableName,
+		FederatedTablesTableName,
 		GetRebaseTableName(),
 
 		// TODO: find way to make these writable by the dolt process
@@ -217,6 +227,12 @@ var getGeneratedSystemTables = func() []string {
 		GetCommitAncestorsTableName(),
 		GetStatusTableName(),
 		GetRemotesTableName(),
+		StatisticsColumnGroupsTableName,
+		TableChecksumsTableName,
+		QuotasTableName,
+		ExportJobRunsTableName,
+		ReplicationStatusTableName,
+		JobsTableName,
 	}
 }
 
@@ -426,11 +442,57 @@ const (
 	// IgnoreTableName is the ignore table name
 	IgnoreTableName = "dolt_ignore"
 
+	// MergeStrategiesTableName is the merge strategies table name
+	MergeStrategiesTableName = "dolt_merge_strategies"
+
+	// ColumnMergeStrategiesTableName is the column merge strategies table name
+	ColumnMergeStrategiesTableName = "dolt_column_merge_strategies"
+
+	// SchemaContractsTableName is the schema contracts table name
+	SchemaContractsTableName = "dolt_schema_contracts"
+
+	// AppendOnlyTablesTableName is the append-only tables table name
+	AppendOnlyTablesTableName = "dolt_append_only_tables"
+
+	// AuditColumnsTableName is the audit columns table name
+	AuditColumnsTableName = "dolt_audit_columns"
+
 	// RebaseTableName is the rebase system table name.
 	RebaseTableName = "dolt_rebase"
 
 	// StatisticsTableName is the statistics system table name
 	StatisticsTableName = "dolt_statistics"
+
+	// StatisticsColumnGroupsTableName is the column group statistics system table name
+	StatisticsColumnGroupsTableName = "dolt_statistics_column_groups"
+
+	// TableChecksumsTableName is the table checksums system table name
+	TableChecksumsTableName = "dolt_table_checksums"
+
+	// QuotasTableName is the database quotas system table name
+	QuotasTableName = "dolt_quotas"
+
+	// ExportJobsTableName is the scheduled export jobs system table name
+	ExportJobsTableName = "dolt_export_jobs"
+
+	// ExportJobRunsTableName is the export job run history system table name
+	ExportJobRunsTableName = "dolt_export_job_runs"
+
+	// ReplicationStatusTableName is the push-on-write replication status system table name
+	ReplicationStatusTableName = "dolt_replication_status"
+
+	// JobsTableName is the system table name of the jobs of the server, such as clones
+	JobsTableName = "dolt_jobs"
+
+	// SequencesTableName is the sequences system table name
+	SequencesTableName = "dolt_sequences"
+
+	// FederatedTablesTableName is the system table name of the tables whose reads are proxied to external MySQL servers
+	FederatedTablesTableName = "dolt_federated_tables"
+
+	// PrivilegesTableName is the name of the system table which stores the users, roles and grants of a server
+	// configured with a privilege database
+	PrivilegesTableName = "dolt_privileges"
 )
 
 const (
@@ -18,8 +18,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"unicode"
 
+	"github.com/dolthub/go-mysql-server/sql"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/conflict"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -102,6 +106,33 @@ func NewEmptyTable(ctx context.Context, vrw types.ValueReadWriter, ns tree.NodeS
 	return &Table{table: dt}, nil
 }
 
+// TargetNodeSizeForSchema returns the target size of the leaf nodes of the primary index of a table with schema |sch|,
+// chosen from its average row width, or zero if the table should use the default size. Tables only use it once they
+// opt in with ALTER TABLE ... KEY_BLOCK_SIZE = 0, new tables use the default size. TEXT and BLOB values are
+// stored outside of the index, so they only count for the size of their address.
+func TargetNodeSizeForSchema(sch schema.Schema) uint32 {
+	var inline sql.Schema
+	var width uint64
+	for _, col := range sch.GetAllCols().GetColumns() {
+		typ := col.TypeInfo.ToSqlType()
+		if sqltypes.IsTextBlob(typ) {
+			width += hash.ByteLen
+			continue
+		}
+		inline = append(inline, &sql.Column{Type: typ})
+	}
+	width += schema.SchemaAvgLength(inline)
+	if width > math.MaxUint32 {
+		width = math.MaxUint32
+	}
+
+	size := tree.TargetNodeSizeForRowWidth(uint32(width))
+	if size == tree.EffectiveTargetNodeSize(0) {
+		return 0
+	}
+	return size
+}
+
 // Format returns the NomsBinFormat for this table.
 func (t *Table) Format() *types.NomsBinFormat {
 	return t.ValueReadWriter().Format()
@@ -290,11 +321,13 @@ func (t *Table) getProllyConflictSchemas(ctx context.Context, tblName TableName)
 		return nil, nil, nil, err
 	}
 
-	baseTbl, baseOk, err := tableFromRootIsh(ctx, t.ValueReadWriter(), t.NodeStore(), art.Metadata.BaseRootIsh, tblName)
+	baseName := TableName{Name: art.Metadata.BaseTableName(tblName.Name), Schema: tblName.Schema}
+	baseTbl, baseOk, err := tableFromRootIsh(ctx, t.ValueReadWriter(), t.NodeStore(), art.Metadata.BaseRootIsh, baseName)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	theirTbl, theirOK, err := tableFromRootIsh(ctx, t.ValueReadWriter(), t.NodeStore(), art.TheirRootIsh, tblName)
+	theirName := TableName{Name: art.Metadata.TheirTableName(tblName.Name), Schema: tblName.Schema}
+	theirTbl, theirOK, err := tableFromRootIsh(ctx, t.ValueReadWriter(), t.NodeStore(), art.TheirRootIsh, theirName)
 	if err != nil {
 		return nil, nil, nil, err
 	}
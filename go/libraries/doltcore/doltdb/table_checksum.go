@@ -0,0 +1,194 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrChecksumUnsupportedFormat is returned when checksumming a table that is not in the __DOLT__ format.
+var ErrChecksumUnsupportedFormat = errors.New("table checksums are only supported for the __DOLT__ storage format")
+
+// TableChecksum is an order-independent checksum of the rows of a table. Each of its
+// lanes is the sum of a hash of every row, so it does not depend on how the rows are
+// chunked, and rows can be added to or removed from it without reading the others.
+// Two tables with the same schema and the same rows have the same checksum.
+type TableChecksum [2]uint64
+
+// String returns the checksum as a hex string.
+func (c TableChecksum) String() string {
+	return fmt.Sprintf("%016x%016x", c[0], c[1])
+}
+
+func (c *TableChecksum) add(k, v val.Tuple) {
+	h0, h1 := rowChecksum(k, v)
+	c[0] += h0
+	c[1] += h1
+}
+
+func (c *TableChecksum) remove(k, v val.Tuple) {
+	h0, h1 := rowChecksum(k, v)
+	c[0] -= h0
+	c[1] -= h1
+}
+
+func rowChecksum(k, v val.Tuple) (uint64, uint64) {
+	buf := make([]byte, 0, binary.MaxVarintLen64+len(k)+len(v))
+	buf = binary.AppendUvarint(buf, uint64(len(k)))
+	buf = append(buf, k...)
+	buf = append(buf, v...)
+	h := hash.Of(buf)
+	return binary.BigEndian.Uint64(h[:8]), binary.BigEndian.Uint64(h[8:16])
+}
+
+// ChecksumRows computes the checksum of every row of |rows|.
+func ChecksumRows(ctx context.Context, rows prolly.Map) (TableChecksum, error) {
+	var sum TableChecksum
+	iter, err := rows.IterAll(ctx)
+	if err != nil {
+		return sum, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return sum, nil
+		} else if err != nil {
+			return sum, err
+		}
+		sum.add(k, v)
+	}
+}
+
+// UpdateChecksum returns the checksum of |to| given that |sum| is the checksum of |from|,
+// by applying the rows that differ between them. Both maps must share a schema.
+func UpdateChecksum(ctx context.Context, from prolly.Map, sum TableChecksum, to prolly.Map) (TableChecksum, error) {
+	err := prolly.DiffMaps(ctx, from, to, false, func(ctx context.Context, d tree.Diff) error {
+		switch d.Type {
+		case tree.AddedDiff:
+			sum.add(val.Tuple(d.Key), val.Tuple(d.To))
+		case tree.RemovedDiff:
+			sum.remove(val.Tuple(d.Key), val.Tuple(d.From))
+		case tree.ModifiedDiff:
+			sum.remove(val.Tuple(d.Key), val.Tuple(d.From))
+			sum.add(val.Tuple(d.Key), val.Tuple(d.To))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return TableChecksum{}, err
+	}
+	return sum, nil
+}
+
+// tableChecksums keeps the checksum of the last checksummed version of each table on
+// each branch, so that the next version can be checksummed from the rows changed since.
+// The versions it keeps hold references to chunks, so GC clears it, see clear.
+type tableChecksums struct {
+	mu   sync.Mutex
+	last map[checksumKey]checksummedRows
+	// gen is incremented each time the checksums are cleared, so that a checksum
+	// computed from rows read before a GC isn't kept after it
+	gen uint64
+}
+
+type checksumKey struct {
+	revision string
+	table    TableName
+}
+
+type checksummedRows struct {
+	schHash hash.Hash
+	rows    prolly.Map
+	sum     TableChecksum
+}
+
+func newTableChecksums() *tableChecksums {
+	return &tableChecksums{last: make(map[checksumKey]checksummedRows)}
+}
+
+func (c *tableChecksums) get(key checksumKey) (checksummedRows, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prev, ok := c.last[key]
+	return prev, c.gen, ok
+}
+
+func (c *tableChecksums) put(key checksumKey, gen uint64, rows checksummedRows) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen == c.gen {
+		c.last[key] = rows
+	}
+}
+
+// clear drops every kept version, so that none of their chunks are referenced
+// after a GC removes them.
+func (c *tableChecksums) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last = make(map[checksumKey]checksummedRows)
+	c.gen++
+}
+
+// TableChecksum returns the checksum of the rows of |tbl|, named |name|, on the branch or
+// other revision |revision|. The checksum of the last version of each table on each
+// revision is kept in memory until the next GC, and a later version of a table with the
+// same schema is checksummed by diffing it against that version, so the cost of
+// checksumming a table after a write is proportional to the rows the write changed.
+func (ddb *DoltDB) TableChecksum(ctx context.Context, revision string, name TableName, tbl *Table) (TableChecksum, error) {
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return TableChecksum{}, ErrChecksumUnsupportedFormat
+	}
+	schHash, err := tbl.GetSchemaHash(ctx)
+	if err != nil {
+		return TableChecksum{}, err
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return TableChecksum{}, err
+	}
+	rows := durable.ProllyMapFromIndex(idx)
+
+	key := checksumKey{revision: revision, table: name}
+	prev, gen, ok := ddb.checksums.get(key)
+
+	var sum TableChecksum
+	if ok && prev.schHash == schHash {
+		if prev.rows.HashOf() == rows.HashOf() {
+			return prev.sum, nil
+		}
+		sum, err = UpdateChecksum(ctx, prev.rows, prev.sum, rows)
+	} else {
+		sum, err = ChecksumRows(ctx, rows)
+	}
+	if err != nil {
+		return TableChecksum{}, err
+	}
+
+	ddb.checksums.put(key, gen, checksummedRows{schHash: schHash, rows: rows, sum: sum})
+	return sum, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+func TestTableChecksum(t *testing.T) {
+	ctx := context.Background()
+	ns := tree.NewTestNodeStore()
+	kd := val.NewTupleDescriptor(val.Type{Enc: val.Int64Enc})
+	vd := val.NewTupleDescriptor(val.Type{Enc: val.Int64Enc, Nullable: true})
+	kb, vb := val.NewTupleBuilder(kd), val.NewTupleBuilder(vd)
+
+	put := func(mut *prolly.MutableMap, k, v int64) {
+		kb.PutInt64(0, k)
+		vb.PutInt64(0, v)
+		require.NoError(t, mut.Put(ctx, kb.Build(ns.Pool()), vb.Build(ns.Pool())))
+	}
+	del := func(mut *prolly.MutableMap, k int64) {
+		kb.PutInt64(0, k)
+		require.NoError(t, mut.Delete(ctx, kb.Build(ns.Pool())))
+	}
+
+	empty, err := prolly.NewMapFromTuples(ctx, ns, kd, vd)
+	require.NoError(t, err)
+	emptySum, err := ChecksumRows(ctx, empty)
+	require.NoError(t, err)
+	assert.Equal(t, TableChecksum{}, emptySum)
+
+	// the same rows chunked differently have the same checksum
+	small := empty.WithTargetNodeSize(512).Mutate()
+	large := empty.Mutate()
+	for i := int64(0); i < 10_000; i++ {
+		put(small, i, i*2)
+		put(large, 9_999-i, (9_999-i)*2)
+	}
+	smallMap, err := small.Map(ctx)
+	require.NoError(t, err)
+	largeMap, err := large.Map(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, smallMap.HashOf(), largeMap.HashOf())
+	sum, err := ChecksumRows(ctx, smallMap)
+	require.NoError(t, err)
+	largeSum, err := ChecksumRows(ctx, largeMap)
+	require.NoError(t, err)
+	assert.Equal(t, sum, largeSum)
+	assert.NotEqual(t, emptySum, sum)
+
+	// an incremental checksum matches a full one
+	mut := smallMap.Mutate()
+	put(mut, 10_000, 1)
+	put(mut, 5, 6)
+	del(mut, 42)
+	edited, err := mut.Map(ctx)
+	require.NoError(t, err)
+	fullSum, err := ChecksumRows(ctx, edited)
+	require.NoError(t, err)
+	incSum, err := UpdateChecksum(ctx, smallMap, sum, edited)
+	require.NoError(t, err)
+	assert.Equal(t, fullSum, incSum)
+	assert.NotEqual(t, sum, incSum)
+
+	// undoing the edits restores the checksum
+	mut = edited.Mutate()
+	del(mut, 10_000)
+	put(mut, 5, 10)
+	put(mut, 42, 84)
+	restored, err := mut.Map(ctx)
+	require.NoError(t, err)
+	restoredSum, err := UpdateChecksum(ctx, edited, incSum, restored)
+	require.NoError(t, err)
+	assert.Equal(t, sum, restoredSum)
+}
+
+func TestTableChecksumsClear(t *testing.T) {
+	c := newTableChecksums()
+	main := checksumKey{revision: "main", table: TableName{Name: "t"}}
+	feat := checksumKey{revision: "feat", table: TableName{Name: "t"}}
+
+	_, gen, ok := c.get(main)
+	require.False(t, ok)
+	c.put(main, gen, checksummedRows{sum: TableChecksum{1, 2}})
+	c.put(feat, gen, checksummedRows{sum: TableChecksum{3, 4}})
+	prev, _, ok := c.get(main)
+	require.True(t, ok)
+	assert.Equal(t, TableChecksum{1, 2}, prev.sum)
+	prev, _, ok = c.get(feat)
+	require.True(t, ok)
+	assert.Equal(t, TableChecksum{3, 4}, prev.sum)
+
+	// a checksum computed before a clear isn't kept after it
+	c.clear()
+	_, _, ok = c.get(main)
+	assert.False(t, ok)
+	c.put(main, gen, checksummedRows{sum: TableChecksum{1, 2}})
+	_, _, ok = c.get(main)
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var ErrRefsUpdatedConcurrently = errors.New("refs were updated concurrently; no refs were updated")
+var ErrNotFastForward = errors.New("update is not a fast-forward")
+
+// RefUpdate is the update of one ref made by UpdateRefs.
+type RefUpdate struct {
+	// Ref is the branch or remote tracking branch to update. It's created if it doesn't exist.
+	Ref ref.DoltRef
+	// Commit is the commit the ref will point at.
+	Commit *Commit
+	// Force allows the update even when the ref's current commit isn't an ancestor of |Commit|.
+	Force bool
+}
+
+// UpdateRefs points each of the refs in |updates| at its commit atomically: either every ref is updated or none of
+// them are. Unless an update is forced, it must be a fast-forward. The working sets of updated branches are reset to
+// their new commits, so they must not have uncommitted changes.
+func (ddb *DoltDB) UpdateRefs(ctx context.Context, updates []RefUpdate, replicationStatus *ReplicationStatusController) error {
+	headUpdates := make([]datas.HeadUpdate, len(updates))
+	for i, u := range updates {
+		var wsPath string
+		switch u.Ref.GetType() {
+		case ref.BranchRefType:
+			wsRef, err := ref.WorkingSetRefForHead(u.Ref)
+			if err != nil {
+				return err
+			}
+			wsPath = wsRef.String()
+		case ref.RemoteRefType:
+		default:
+			return fmt.Errorf("cannot update %s: only branches and remote tracking branches can be updated", u.Ref.String())
+		}
+
+		ds, err := ddb.db.GetDataset(ctx, u.Ref.String())
+		if err != nil {
+			return err
+		}
+		newAddr, err := u.Commit.HashOf()
+		if err != nil {
+			return err
+		}
+
+		prevAddr, exists := ds.MaybeHeadAddr()
+		if exists && !u.Force {
+			curr, err := ddb.ResolveCommitRef(ctx, u.Ref)
+			if err != nil {
+				return err
+			}
+			canFF, err := curr.CanFastForwardTo(ctx, u.Commit)
+			if err != nil && !errors.Is(err, ErrUpToDate) && !errors.Is(err, ErrIsAhead) {
+				return err
+			}
+			if !canFF {
+				return fmt.Errorf("%w: %s; use force to update it anyway", ErrNotFastForward, u.Ref.String())
+			}
+		}
+
+		headUpdates[i] = datas.HeadUpdate{
+			DatasetID:      u.Ref.String(),
+			PrevHeadAddr:   prevAddr,
+			NewHeadAddr:    newAddr,
+			WorkingSetPath: wsPath,
+		}
+	}
+
+	err := ddb.db.withReplicationStatusController(replicationStatus).UpdateHeads(ctx, headUpdates)
+	if errors.Is(err, datas.ErrMergeNeeded) {
+		return ErrRefsUpdatedConcurrently
+	} else if errors.Is(err, datas.ErrDirtyWorkspace) {
+		return errors.New("cannot update a branch with uncommitted changes; no refs were updated")
+	}
+	return err
+}
@@ -131,6 +131,12 @@ type MergeState struct {
 	// isCherryPick is set to true when the in-progress merge is a cherry-pick. This is needed so that
 	// commit knows to NOT create a commit with multiple parents when creating a commit for a cherry-pick.
 	isCherryPick bool
+	// isRevert is set to true when the in-progress merge is a revert. Like cherry-picks, reverts create a
+	// commit with a single parent.
+	isRevert bool
+	// pendingCherryPicks are the commits that a cherry-pick of several commits still has to apply, in order,
+	// after |commit|.
+	pendingCherryPicks []hash.Hash
 }
 
 // todo(andy): this might make more sense in pkg merge
@@ -177,6 +183,18 @@ func (m MergeState) IsCherryPick() bool {
 	return m.isCherryPick
 }
 
+// IsRevert returns true if the current merge state is for a revert operation. In that case |Commit| is the commit
+// being reverted.
+func (m MergeState) IsRevert() bool {
+	return m.isRevert
+}
+
+// PendingCherryPicks returns the commits that remain to be cherry-picked, in order, once the in-progress
+// cherry-pick of Commit() is finished.
+func (m MergeState) PendingCherryPicks() []hash.Hash {
+	return m.pendingCherryPicks
+}
+
 func (m MergeState) PreMergeWorkingRoot() RootValue {
 	return m.preMergeWorking
 }
@@ -342,15 +360,31 @@ func (ws WorkingSet) StartRebase(ctx *sql.Context, ontoCommit *Commit, branch st
 }
 
 // StartCherryPick creates and returns a new working set based off of the current |ws| with the specified |commit|
-// and |commitSpecStr| referring to the commit being cherry-picked. The returned WorkingSet records that a cherry-pick
+// and |commitSpecStr| referring to the commit being cherry-picked, and |pending| the commits to cherry-pick after it.
+// The returned WorkingSet records that a cherry-pick operation is in progress (i.e. conflicts being resolved). Note
+// that this function does not update the current session – the returned WorkingSet must still be set using
+// DoltSession.SetWorkingSet().
+func (ws WorkingSet) StartCherryPick(commit *Commit, commitSpecStr string, pending []hash.Hash) *WorkingSet {
+	ws.mergeState = &MergeState{
+		commit:             commit,
+		commitSpecStr:      commitSpecStr,
+		preMergeWorking:    ws.workingRoot,
+		isCherryPick:       true,
+		pendingCherryPicks: pending,
+	}
+	return &ws
+}
+
+// StartRevert creates and returns a new working set based off of the current |ws| with the specified |commit|
+// and |commitSpecStr| referring to the commit being reverted. The returned WorkingSet records that a revert
 // operation is in progress (i.e. conflicts being resolved). Note that this function does not update the current
 // session – the returned WorkingSet must still be set using DoltSession.SetWorkingSet().
-func (ws WorkingSet) StartCherryPick(commit *Commit, commitSpecStr string) *WorkingSet {
+func (ws WorkingSet) StartRevert(commit *Commit, commitSpecStr string) *WorkingSet {
 	ws.mergeState = &MergeState{
 		commit:          commit,
 		commitSpecStr:   commitSpecStr,
 		preMergeWorking: ws.workingRoot,
-		isCherryPick:    true,
+		isRevert:        true,
 	}
 	return &ws
 }
@@ -412,7 +446,7 @@ func (ws *WorkingSet) MergeCommitParents() bool {
 	if !ws.MergeActive() {
 		return false
 	}
-	return ws.MergeState().IsCherryPick() == false
+	return ws.MergeState().IsCherryPick() == false && ws.MergeState().IsRevert() == false
 }
 
 func (ws WorkingSet) Meta() *datas.WorkingSetMeta {
@@ -502,14 +536,26 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 			return nil, err
 		}
 
+		isRevert, err := dsws.MergeState.IsRevert(ctx, vrw)
+		if err != nil {
+			return nil, err
+		}
+
+		pendingCherryPicks, err := dsws.MergeState.PendingCherryPickAddrs(ctx, vrw)
+		if err != nil {
+			return nil, err
+		}
+
 		unmergableTableNames := ToTableNames(unmergableTables, DefaultSchemaName)
 
 		mergeState = &MergeState{
-			commit:           commit,
-			commitSpecStr:    commitSpec,
-			preMergeWorking:  preMergeWorkingRoot,
-			unmergableTables: unmergableTableNames,
-			isCherryPick:     isCherryPick,
+			commit:             commit,
+			commitSpecStr:      commitSpec,
+			preMergeWorking:    preMergeWorkingRoot,
+			unmergableTables:   unmergableTableNames,
+			isCherryPick:       isCherryPick,
+			isRevert:           isRevert,
+			pendingCherryPicks: pendingCherryPicks,
 		}
 	}
 
@@ -621,7 +667,7 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 		}
 
 		// TODO: Serialize the full TableName
-		mergeState, err = datas.NewMergeState(ctx, db.vrw, preMergeWorking, dCommit, ws.mergeState.commitSpecStr, FlattenTableNames(ws.mergeState.unmergableTables), ws.mergeState.isCherryPick)
+		mergeState, err = datas.NewMergeState(ctx, db.vrw, preMergeWorking, dCommit, ws.mergeState.commitSpecStr, FlattenTableNames(ws.mergeState.unmergableTables), ws.mergeState.isCherryPick, ws.mergeState.isRevert, ws.mergeState.pendingCherryPicks)
 		if err != nil {
 			return nil, err
 		}
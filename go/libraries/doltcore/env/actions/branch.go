@@ -65,6 +65,19 @@ func RenameBranch(ctx context.Context, dbData env.DbData, oldBranch, newBranch s
 
 	// todo: update default branch variable
 
+	// a HEAD referring to the renamed branch follows it, like in git
+	head := ref.NewHeadSymbolicRef()
+	target, ok, err := dbData.Ddb.ResolveSymbolicRef(ctx, head)
+	if err != nil {
+		return err
+	}
+	if ok && ref.Equals(target, oldRef) {
+		err = dbData.Ddb.SetSymbolicRef(ctx, head, newRef)
+		if err != nil {
+			return err
+		}
+	}
+
 	return DeleteBranch(ctx, dbData, oldBranch, DeleteOptions{Force: true, AllowDeletingCurrentBranch: true}, remoteDbPro, rsc)
 }
 
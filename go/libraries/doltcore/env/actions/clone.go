@@ -37,6 +37,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -91,11 +92,30 @@ func EnvForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 	return dEnv, nil
 }
 
-func clonePrint(eventCh <-chan pull.TableFileEvent) {
+// CloneProgress is the progress of the table files fetched by a clone.
+type CloneProgress struct {
+	// BytesFetched is the number of bytes of table files fetched so far
+	BytesFetched uint64
+	// TableFiles is the number of table files to fetch
+	TableFiles int
+	// TableFilesRemaining is the number of table files which haven't been fetched yet
+	TableFilesRemaining int
+}
+
+type cloneProgressKey struct{}
+
+// WithCloneProgress returns a context with which CloneRemote reports its progress to |f| as table files are fetched.
+func WithCloneProgress(ctx context.Context, f func(CloneProgress)) context.Context {
+	return context.WithValue(ctx, cloneProgressKey{}, f)
+}
+
+func clonePrint(eventCh <-chan pull.TableFileEvent, progress func(CloneProgress)) {
 	var (
 		chunksC           int64
 		chunksDownloading int64
 		chunksDownloaded  int64
+		bytesDownloaded   uint64
+		filesDownloaded   int
 		currStats         = make(map[string]iohelp.ReadStats)
 		tableFiles        = make(map[string]*chunks.TableFile)
 	)
@@ -123,9 +143,15 @@ func clonePrint(eventCh <-chan pull.TableFileEvent) {
 				currStats[tf.FileID()] = s
 			}
 		case pull.DownloadSuccess:
-			for _, tf := range tblFEvt.TableFiles {
+			for i, tf := range tblFEvt.TableFiles {
 				chunksDownloading -= int64(tf.NumChunks())
 				chunksDownloaded += int64(tf.NumChunks())
+				if i < len(tblFEvt.Stats) {
+					bytesDownloaded += tblFEvt.Stats[i].Read
+				} else {
+					bytesDownloaded += currStats[tf.FileID()].Read
+				}
+				filesDownloaded++
 				delete(currStats, tf.FileID())
 			}
 		case pull.DownloadFailed:
@@ -135,6 +161,18 @@ func clonePrint(eventCh <-chan pull.TableFileEvent) {
 			}
 		}
 
+		if progress != nil {
+			fetched := bytesDownloaded
+			for _, s := range currStats {
+				fetched += s.Read
+			}
+			progress(CloneProgress{
+				BytesFetched:        fetched,
+				TableFiles:          len(tableFiles),
+				TableFilesRemaining: len(tableFiles) - filesDownloaded,
+			})
+		}
+
 		p.Printf("%s of %s chunks complete. %s chunks being downloaded currently.\n",
 			strhelp.CommaIfy(chunksDownloaded), strhelp.CommaIfy(chunksC), strhelp.CommaIfy(chunksDownloading))
 		for _, fileId := range sortedKeys(currStats) {
@@ -166,7 +204,10 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	// We support two forms of cloning: full and shallow. These two approaches have little in common, with the exception
 	// of the first and last steps. Determining the branch to check out and setting the working set to the checked out commit.
 
-	srcRefHashes, branch, err := getSrcRefs(ctx, branch, srcDB, dEnv)
+	if remoteName == "" {
+		remoteName = "origin"
+	}
+	srcRefHashes, branch, err := getSrcRefs(ctx, branch, remoteName, srcDB, dEnv)
 	if err != nil {
 		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
 	}
@@ -176,15 +217,22 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 			return doltdb.ErrOperationNotSupportedInDetachedHead
 		}
 	}
-	if remoteName == "" {
-		remoteName = "origin"
+
+	var filter *doltdb.FetchFilter
+	if remotes, err := dEnv.GetRemotes(); err == nil {
+		if remote, ok := remotes.Get(remoteName); ok {
+			filter, err = remote.FetchFilter()
+			if err != nil {
+				return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+			}
+		}
 	}
 
 	var checkedOutCommit *doltdb.Commit
 
 	// Step 1) Pull the remote information we care about to a local disk.
 	if depth <= 0 {
-		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch, filter)
 	} else {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(), srcDB, remoteName, branch, depth)
 	}
@@ -225,13 +273,35 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		return err
 	}
 
+	if filter != nil {
+		dEnv.ConfigurePartialClone()
+	}
+
 	return nil
 }
 
-// getSrcRefs returns the refs from the source database and the branch to check out. The input branch is used if it is
-// not empty, otherwise the default branch is determined and returned.
-func getSrcRefs(ctx context.Context, branch string, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv) ([]doltdb.RefWithHash, string, error) {
-	srcRefHashes, err := srcDB.GetRefsWithHashes(ctx)
+// getSrcRefs returns the branches and tags of the source database that are cloned, which are the branches matched by
+// the fetch specs of the remote |remoteName|, and the branch to check out. The input branch is used if it is not empty,
+// otherwise the default branch is determined and returned.
+func getSrcRefs(ctx context.Context, branch, remoteName string, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv) ([]doltdb.RefWithHash, string, error) {
+	refSpecs, err := env.GetRefSpecs(dEnv.RepoStateReader(), remoteName)
+	if err != nil {
+		return nil, "", err
+	}
+	patterns := []string{ref.PrefixForType(ref.TagRefType) + "*"}
+	for _, rs := range refSpecs {
+		patterns = append(patterns, rs.SrcRefGlob())
+	}
+
+	var srcRefHashes []doltdb.RefWithHash
+	var branches []ref.DoltRef
+	err = srcDB.VisitRefsMatching(ctx, patterns, func(r ref.DoltRef, addr hash.Hash) error {
+		srcRefHashes = append(srcRefHashes, doltdb.RefWithHash{Ref: r, Hash: addr})
+		if r.GetType() == ref.BranchRefType {
+			branches = append(branches, r)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, "", err
 	}
@@ -240,47 +310,42 @@ func getSrcRefs(ctx context.Context, branch string, srcDB *doltdb.DoltDB, dEnv *
 		return nil, "", ErrNoDataAtRemote
 	}
 
-	branches := make([]ref.DoltRef, 0, len(srcRefHashes))
-	for _, refHash := range srcRefHashes {
-		if refHash.Ref.GetType() == ref.BranchRefType {
-			br := refHash.Ref.(ref.BranchRef)
-			branches = append(branches, br)
-		}
-	}
 	if branch == "" {
-		branch = env.GetDefaultBranch(dEnv, branches)
+		// prefer the default branch the remote advertises with its HEAD symbolic ref, if it's cloned
+		defaultBranch, ok, err := srcDB.GetDefaultBranch(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		if ok && containsRef(branches, defaultBranch) {
+			branch = defaultBranch.GetPath()
+		} else {
+			branch = env.GetDefaultBranch(dEnv, branches)
+		}
 	}
 
 	return srcRefHashes, branch, nil
 }
 
-func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) (*doltdb.Commit, error) {
-	eventCh := make(chan pull.TableFileEvent, 128)
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		clonePrint(eventCh)
-	}()
-
-	err := srcDB.Clone(ctx, dEnv.DoltDB, eventCh)
-
-	close(eventCh)
-	wg.Wait()
-
-	if err != nil {
-		return nil, err
+func containsRef(refs []ref.DoltRef, r ref.DoltRef) bool {
+	for _, other := range refs {
+		if ref.Equals(other, r) {
+			return true
+		}
 	}
+	return false
+}
 
-	cs, _ := doltdb.NewCommitSpec(branch)
-	optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool, filter *doltdb.FetchFilter) (*doltdb.Commit, error) {
+	var cm *doltdb.Commit
+	var err error
+	if filter != nil {
+		cm, err = partialCloneDataPull(ctx, srcDB, dEnv, srcRefHashes, branch, singleBranch, filter)
+	} else {
+		cm, err = cloneTableFiles(ctx, srcDB, dEnv, branch)
+	}
 	if err != nil {
 		return nil, err
 	}
-	cm, ok := optCmt.ToCommit()
-	if !ok {
-		return nil, doltdb.ErrGhostCommitEncountered
-	}
 
 	err = dEnv.DoltDB.DeleteAllRefs(ctx)
 	if err != nil {
@@ -318,6 +383,82 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 	return cm, nil
 }
 
+// cloneTableFiles copies all the table files of |srcDB| to the database of |dEnv|, and returns the commit of |branch|.
+func cloneTableFiles(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, branch string) (*doltdb.Commit, error) {
+	progress, _ := ctx.Value(cloneProgressKey{}).(func(CloneProgress))
+	eventCh := make(chan pull.TableFileEvent, 128)
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		clonePrint(eventCh, progress)
+	}()
+
+	err := srcDB.Clone(ctx, dEnv.DoltDB, eventCh)
+
+	close(eventCh)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs, _ := doltdb.NewCommitSpec(branch)
+	optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// partialCloneDataPull pulls the chunks of the branches and tags of |srcDB| which |filter| doesn't skip to the
+// database of |dEnv|, and returns the commit of |branch|. Table files can't be copied as they are for a partial clone,
+// so the chunks are pulled one by one.
+func partialCloneDataPull(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch string, singleBranch bool, filter *doltdb.FetchFilter) (*doltdb.Commit, error) {
+	var branchHash hash.Hash
+	var toFetch []hash.Hash
+	for _, refHash := range srcRefHashes {
+		switch refHash.Ref.GetType() {
+		case ref.BranchRefType:
+			if refHash.Ref.GetPath() == branch {
+				branchHash = refHash.Hash
+			} else if singleBranch {
+				continue
+			}
+		case ref.TagRefType:
+		default:
+			continue
+		}
+		toFetch = append(toFetch, refHash.Hash)
+	}
+	if branchHash.IsEmpty() {
+		return nil, fmt.Errorf("%w: %s", doltdb.ErrBranchNotFound, branch)
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	err = dEnv.DoltDB.PullChunksWithFilter(ctx, tmpDir, srcDB, toFetch, nil, nil, filter)
+	if err != nil && err != pull.ErrDBUpToDate {
+		return nil, err
+	}
+
+	optCmt, err := dEnv.DoltDB.ReadCommit(ctx, branchHash)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
 // shallowCloneDataPull is a shallow clone specific helper function to pull only the data required to show the given branch
 // at the depth given.
 func shallowCloneDataPull(ctx context.Context, destData env.DbData, srcDB *doltdb.DoltDB, remoteName, branch string, depth int) (*doltdb.Commit, error) {
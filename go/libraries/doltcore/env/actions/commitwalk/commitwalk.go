@@ -235,7 +235,9 @@ func newCommiterator(ctx context.Context, ddb *doltdb.DoltDB, startCommitHashes
 
 // Next implements doltdb.CommitItr
 func (iter *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.OptionalCommit, error) {
-	if iter.q.NumVisiblePending() > 0 {
+	// commits which don't match are skipped in a loop, rather than by recursing, so that a selective |matchFn| can
+	// walk a long history without growing the stack
+	for iter.q.NumVisiblePending() > 0 {
 		nextC := iter.q.PopPending()
 
 		var err error
@@ -266,8 +268,6 @@ func (iter *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Optional
 		if matches {
 			return nextC.hash, &doltdb.OptionalCommit{Commit: commit, Addr: nextC.hash}, nil
 		}
-
-		return iter.Next(ctx)
 	}
 
 	return hash.Hash{}, nil, io.EOF
@@ -0,0 +1,125 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitwalk
+
+import "math"
+
+// LaneAssigner assigns each commit of a log to a lane, the column the commit is drawn in when the log is rendered as
+// a graph. Commits must be given to Next in topological order, children before their parents, which is the order
+// the commit iterators in this package produce. Lanes are assigned as follows:
+//  1. A commit with no children starts a new lane.
+//  2. A commit which is the first parent of one of its children continues the left most lane of those children.
+//  3. A commit which is only a merge parent of its children goes in the first lane to the right of its children
+//     that is free from the row after its first child down to the commit, or in a new lane if there is no such lane.
+type LaneAssigner struct {
+	// paths holds the row spans of the branch paths drawn in each lane
+	paths    [][]lanePath
+	commits  map[string]laneCommit
+	children map[string][]string
+	row      int
+}
+
+type lanePath struct {
+	start int
+	end   int
+}
+
+type laneCommit struct {
+	row         int
+	lane        int
+	firstParent string
+}
+
+// NewLaneAssigner returns a LaneAssigner for a new log.
+func NewLaneAssigner() *LaneAssigner {
+	return &LaneAssigner{
+		commits:  make(map[string]laneCommit),
+		children: make(map[string][]string),
+	}
+}
+
+// Next returns the lane of the next commit in the log, the commit |h| with the parents |parents|.
+func (la *LaneAssigner) Next(h string, parents []string) int {
+	row := la.row
+	la.row++
+
+	children := la.children[h]
+	delete(la.children, h)
+	for _, parent := range parents {
+		la.children[parent] = append(la.children[parent], h)
+	}
+
+	var branchLanes []int
+	for _, child := range children {
+		if cm := la.commits[child]; cm.firstParent == h {
+			branchLanes = append(branchLanes, cm.lane)
+		}
+	}
+
+	var lane int
+	if len(children) == 0 {
+		la.paths = append(la.paths, []lanePath{{start: row, end: row}})
+		lane = len(la.paths) - 1
+	} else if len(branchLanes) > 0 {
+		lane = branchLanes[0]
+		for _, l := range branchLanes[1:] {
+			lane = min(lane, l)
+		}
+
+		la.setEnd(lane, row)
+		// the paths branching out of this commit end on the row before it
+		for _, l := range branchLanes {
+			if l != lane {
+				la.setEnd(l, row-1)
+			}
+		}
+	} else {
+		minChildRow := math.MaxInt
+		maxChildLane := -1
+		for _, child := range children {
+			cm := la.commits[child]
+			minChildRow = min(minChildRow, cm.row)
+			maxChildLane = max(maxChildLane, cm.lane)
+		}
+
+		lane = -1
+		for i := maxChildLane + 1; i < len(la.paths); i++ {
+			if minChildRow >= la.paths[i][len(la.paths[i])-1].end {
+				lane = i
+				break
+			}
+		}
+
+		path := lanePath{start: minChildRow + 1, end: row}
+		if lane == -1 {
+			la.paths = append(la.paths, []lanePath{path})
+			lane = len(la.paths) - 1
+		} else {
+			la.paths[lane] = append(la.paths[lane], path)
+		}
+	}
+
+	var firstParent string
+	if len(parents) > 0 {
+		firstParent = parents[0]
+	}
+	la.commits[h] = laneCommit{row: row, lane: lane, firstParent: firstParent}
+
+	return lane
+}
+
+func (la *LaneAssigner) setEnd(lane, end int) {
+	la.paths[lane][len(la.paths[lane])-1].end = end
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitwalk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaneAssigner(t *testing.T) {
+	type commit struct {
+		hash    string
+		parents []string
+		lane    int
+	}
+
+	tests := []struct {
+		name    string
+		commits []commit
+	}{
+		{
+			name: "linear history",
+			commits: []commit{
+				{"c3", []string{"c2"}, 0},
+				{"c2", []string{"c1"}, 0},
+				{"c1", nil, 0},
+			},
+		},
+		{
+			// 1M - 2M - 3M (main)
+			//       \  /
+			//        1A (branchA)
+			name: "merged branch",
+			commits: []commit{
+				{"3M", []string{"2M", "1A"}, 0},
+				{"1A", []string{"2M"}, 1},
+				{"2M", []string{"1M"}, 0},
+				{"1M", nil, 0},
+			},
+		},
+		{
+			// two branch heads, the second of which merges in a third branch
+			name: "branch heads",
+			commits: []commit{
+				{"b1", []string{"m1"}, 0},
+				{"m2", []string{"m1", "a1"}, 1},
+				{"a1", []string{"m1"}, 2},
+				{"m1", []string{"m0"}, 0},
+				{"m0", nil, 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			la := NewLaneAssigner()
+			for _, c := range test.commits {
+				assert.Equal(t, c.lane, la.Next(c.hash, c.parents), c.hash)
+			}
+		})
+	}
+}
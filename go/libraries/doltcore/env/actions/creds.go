@@ -32,7 +32,7 @@ func NewCredsFile(dEnv *env.DoltEnv) (string, creds.DoltCreds, errhand.VerboseEr
 		return "", creds.EmptyCreds, verr
 	}
 
-	credsPath, err := creds.JWKCredsWriteToDir(dEnv.FS, credsDir, dCreds)
+	credsPath, err := dEnv.WriteCreds(credsDir, dCreds)
 
 	if err != nil {
 		return "", creds.EmptyCreds, errhand.BuildDError("failed to create new key.").AddCause(err).Build()
@@ -0,0 +1,197 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// RemoteCheckTimeout is how long CheckRemote waits for a remote before reporting it unreachable.
+	RemoteCheckTimeout = 30 * time.Second
+
+	// remoteCheckRoundTrips is the number of root refreshes whose mean duration is the round trip time of a remote.
+	remoteCheckRoundTrips = 3
+
+	// remoteCheckDownloadBytes is the most bytes of a table file which are downloaded to estimate bandwidth.
+	remoteCheckDownloadBytes = 4 * 1024 * 1024
+)
+
+// The statuses of a RemoteCheck.
+const (
+	RemoteCheckOK                  = "ok"
+	RemoteCheckUnreachable         = "unreachable"
+	RemoteCheckUnauthorized        = "unauthorized"
+	RemoteCheckIncompatible        = "incompatible"
+	RemoteCheckMissingCapabilities = "missing_capabilities"
+)
+
+// The capabilities which a remote needs for fetch and push.
+const (
+	RemoteCapabilityRead  = "read"
+	RemoteCapabilityWrite = "write"
+)
+
+// RemoteCheck is the result of checking the health of a remote with CheckRemote.
+type RemoteCheck struct {
+	Name   string `json:"name"`
+	Url    string `json:"url"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Format is the storage format of the remote, which must match the format of the local database.
+	Format string `json:"format,omitempty"`
+	// ProtocolVersion is the download protocol version of a remotesapi server, or 0 for other remotes, or for an
+	// empty remotesapi remote.
+	ProtocolVersion int `json:"protocol_version,omitempty"`
+	// RoundTripMillis is the mean time taken to refresh the root of the remote.
+	RoundTripMillis float64 `json:"round_trip_ms"`
+	// BytesPerSecond is the rate at which a table file was downloaded, or 0 if the remote has no table files.
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	// Capabilities are the capabilities the remote supports, and MissingCapabilities are those needed for fetch and
+	// push which it doesn't.
+	Capabilities        []string `json:"capabilities"`
+	MissingCapabilities []string `json:"missing_capabilities"`
+}
+
+// OK returns whether the remote passed every check.
+func (rc RemoteCheck) OK() bool {
+	return rc.Status == RemoteCheckOK
+}
+
+// CheckRemote checks that |r| can be reached and authenticated with, that its format is |nbf|, and that it supports
+// fetch and push, and measures its round trip time and download bandwidth. Failures are reported in the returned
+// RemoteCheck rather than as an error, so that a monitor can check every remote.
+func CheckRemote(ctx context.Context, r env.Remote, nbf *types.NomsBinFormat, dialer dbfactory.GRPCDialProvider) RemoteCheck {
+	ctx, cancel := context.WithTimeout(ctx, RemoteCheckTimeout)
+	defer cancel()
+
+	rc := RemoteCheck{
+		Name:                r.Name,
+		Url:                 r.Url,
+		Capabilities:        []string{},
+		MissingCapabilities: []string{},
+	}
+
+	ddb, err := r.GetRemoteDBWithoutCaching(ctx, nbf, dialer)
+	if err != nil {
+		return rc.failed(err)
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	var elapsed time.Duration
+	for i := 0; i < remoteCheckRoundTrips; i++ {
+		start := time.Now()
+		if err = cs.Rebase(ctx); err != nil {
+			return rc.failed(err)
+		}
+		elapsed += time.Since(start)
+	}
+	rc.RoundTripMillis = float64(elapsed.Microseconds()) / 1000 / remoteCheckRoundTrips
+
+	rc.Format = cs.Version()
+	if dcs, ok := cs.(*remotestorage.DoltChunkStore); ok {
+		if rc.ProtocolVersion, err = dcs.ServerDownloadProtocolVersion(ctx); err != nil {
+			return rc.failed(err)
+		}
+	}
+
+	tfs, ok := cs.(chunks.TableFileStore)
+	if ok {
+		ops := tfs.SupportedOperations()
+		if ops.CanRead {
+			rc.Capabilities = append(rc.Capabilities, RemoteCapabilityRead)
+		}
+		if ops.CanWrite {
+			rc.Capabilities = append(rc.Capabilities, RemoteCapabilityWrite)
+		}
+		if rc.BytesPerSecond, err = measureDownloadRate(ctx, tfs); err != nil {
+			return rc.failed(err)
+		}
+	}
+	for _, c := range []string{RemoteCapabilityRead, RemoteCapabilityWrite} {
+		if !slices.Contains(rc.Capabilities, c) {
+			rc.MissingCapabilities = append(rc.MissingCapabilities, c)
+		}
+	}
+
+	switch {
+	case rc.Format != "" && rc.Format != nbf.VersionString():
+		rc.Status = RemoteCheckIncompatible
+		rc.Error = "remote format " + rc.Format + " does not match local format " + nbf.VersionString()
+	case len(rc.MissingCapabilities) > 0:
+		rc.Status = RemoteCheckMissingCapabilities
+	default:
+		rc.Status = RemoteCheckOK
+	}
+	return rc
+}
+
+// failed records that the remote couldn't be reached, or rejected our credentials, with |err|.
+func (rc RemoteCheck) failed(err error) RemoteCheck {
+	rc.Status = RemoteCheckUnreachable
+	if code := status.Code(err); code == codes.Unauthenticated || code == codes.PermissionDenied {
+		rc.Status = RemoteCheckUnauthorized
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		err = errors.New("timed out after " + RemoteCheckTimeout.String())
+	}
+	rc.Error = err.Error()
+	return rc
+}
+
+// measureDownloadRate downloads up to remoteCheckDownloadBytes of the largest table file of |tfs| and returns the
+// rate in bytes per second, or 0 if |tfs| has no table files.
+func measureDownloadRate(ctx context.Context, tfs chunks.TableFileStore) (float64, error) {
+	_, tableFiles, _, err := tfs.Sources(ctx)
+	if err != nil || len(tableFiles) == 0 {
+		return 0, err
+	}
+	largest := tableFiles[0]
+	for _, tf := range tableFiles[1:] {
+		if tf.NumChunks() > largest.NumChunks() {
+			largest = tf
+		}
+	}
+
+	start := time.Now()
+	rd, _, err := largest.Open(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer rd.Close()
+	n, err := io.Copy(io.Discard, io.LimitReader(rd, remoteCheckDownloadBytes))
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(start)
+	if n == 0 || elapsed <= 0 {
+		return 0, nil
+	}
+	return float64(n) / elapsed.Seconds(), nil
+}
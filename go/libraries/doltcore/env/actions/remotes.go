@@ -527,21 +527,17 @@ func fetchRefSpecsWithDepth(
 	progStarter ProgStarter,
 	progStopper ProgStopper,
 ) error {
-	var branchRefs []doltdb.RefWithHash
-	err := srcDB.VisitRefsOfType(ctx, ref.HeadRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
-		branchRefs = append(branchRefs, doltdb.RefWithHash{Ref: r, Hash: addr})
-		return nil
-	})
+	// The remote may have been written to since it was opened, for example a file remote pushed to by another process
+	err := srcDB.Rebase(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
 	}
 
-	if len(branchRefs) == 0 {
-		if defaultRefSpecs {
-			// The remote has no branches. Nothing to do. Git exits silently, so we do too.
-			return nil
-		}
-		return fmt.Errorf("no branches found in remote '%s'", remote.Name)
+	// Only the refs matched by the ref specs are read from the remote, so that fetching a few branches of a remote with
+	// many doesn't need to read all of its refs.
+	branchRefs, err := remoteRefsForRefSpecs(ctx, srcDB, refSpecs)
+	if err != nil {
+		return fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
 	}
 
 	// We build up two structures:
@@ -565,6 +561,17 @@ func fetchRefSpecsWithDepth(
 			}
 		}
 		if !rsSeen {
+			hasBranches, err := remoteHasBranches(ctx, srcDB)
+			if err != nil {
+				return fmt.Errorf("%w: %s", env.ErrFailedToReadDb, err.Error())
+			}
+			if !hasBranches {
+				if defaultRefSpecs {
+					// The remote has no branches. Nothing to do. Git exits silently, so we do too.
+					return nil
+				}
+				return fmt.Errorf("no branches found in remote '%s'", remote.Name)
+			}
 			return fmt.Errorf("%w: '%s'", ref.ErrInvalidRefSpec, rs.GetRemRefToLocal())
 		}
 	}
@@ -593,6 +600,11 @@ func fetchRefSpecsWithDepth(
 	}
 	toFetch = allToFetch
 
+	filter, err := remote.FetchFilter()
+	if err != nil {
+		return err
+	}
+
 	// Now we fetch all the new HEADs we need.
 	tmpDir, err := dbData.Rsw.TempTableFilesDir()
 	if err != nil {
@@ -618,7 +630,7 @@ func fetchRefSpecsWithDepth(
 			defer progStopper(cancelFunc, wg, statsCh)
 		}
 
-		err = dbData.Ddb.PullChunks(ctx, tmpDir, srcDB, toFetch, statsCh, skipCmts)
+		err = dbData.Ddb.PullChunksWithFilter(ctx, tmpDir, srcDB, toFetch, statsCh, skipCmts, filter)
 		if err == pull.ErrDBUpToDate {
 			err = nil
 		}
@@ -691,6 +703,39 @@ func fetchRefSpecsWithDepth(
 	return nil
 }
 
+// remoteRefsForRefSpecs returns the refs of |srcDB| which are matched by the sources of |refSpecs|. Each ref is
+// returned once, even if it's matched by more than one ref spec.
+func remoteRefsForRefSpecs(ctx context.Context, srcDB *doltdb.DoltDB, refSpecs []ref.RemoteRefSpec) ([]doltdb.RefWithHash, error) {
+	patterns := make([]string, 0, len(refSpecs))
+	for _, rs := range refSpecs {
+		patterns = append(patterns, rs.SrcRefGlob())
+	}
+
+	var refs []doltdb.RefWithHash
+	err := srcDB.VisitRefsMatching(ctx, patterns, func(r ref.DoltRef, addr hash.Hash) error {
+		refs = append(refs, doltdb.RefWithHash{Ref: r, Hash: addr})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+var errStopVisiting = errors.New("stop visiting")
+
+// remoteHasBranches returns whether |srcDB| has any branches, reading as few of its refs as possible.
+func remoteHasBranches(ctx context.Context, srcDB *doltdb.DoltDB) (bool, error) {
+	err := srcDB.VisitRefsWithPrefix(ctx, ref.PrefixForType(ref.BranchRefType), func(ref.DoltRef, hash.Hash) error {
+		return errStopVisiting
+	})
+	if err == errStopVisiting {
+		return true, nil
+	}
+	return false, err
+}
+
 func buildInitialSkipList(ctx context.Context, srcDB *doltdb.DoltDB, toFetch []hash.Hash) (hash.HashSet, error) {
 	if len(toFetch) > 1 {
 		return hash.HashSet{}, fmt.Errorf("runtime error: multiple refspecs not supported in shallow clone")
@@ -16,19 +16,27 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/gpg"
 	"github.com/dolthub/dolt/go/store/datas"
 )
 
+var ErrTagNotSigned = errors.New("tag is not signed")
+
 type TagProps struct {
 	TaggerName  string
 	TaggerEmail string
 	Description string
+	// Sign is whether the tag is signed with GPG, using SigningKey, or the default key of gpg if it is empty
+	Sign       bool
+	SigningKey string
 }
 
 func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint string, props TagProps) error {
@@ -72,9 +80,67 @@ func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint
 
 	meta := datas.NewTagMeta(props.TaggerName, props.TaggerEmail, props.Description)
 
+	if props.Sign {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+
+		signature, err := gpg.Sign(ctx, props.SigningKey, []byte(TagSignaturePayload(tagName, h.String(), meta)))
+		if err != nil {
+			return err
+		}
+
+		meta.Signature = string(signature)
+	}
+
 	return ddb.NewTagAtCommit(ctx, tagRef, cm, meta)
 }
 
+// TagSignaturePayload returns the content signed by the signature of the tag |tagName| of the commit |commitHash|. It
+// is made up of the fields of the tag which are attested by its signature.
+func TagSignaturePayload(tagName, commitHash string, meta *datas.TagMeta) string {
+	var lines []string
+	lines = append(lines, fmt.Sprint("Tag: ", tagName))
+	lines = append(lines, fmt.Sprint("Commit: ", commitHash))
+	lines = append(lines, fmt.Sprint("Tagger: ", meta.Name))
+	lines = append(lines, fmt.Sprint("Email: ", meta.Email))
+	// the date is signed to the second, the precision with which it is read from the dolt_tags table by clients
+	lines = append(lines, fmt.Sprint("Date: ", meta.Timestamp/1000))
+	lines = append(lines, fmt.Sprint("Message: ", meta.Description))
+	return strings.Join(lines, "\n")
+}
+
+// VerifyTagSignature verifies the signature of the tag |tagName| of the commit |commitHash| with GPG, and that it signs
+// the fields of the tag, so that a signature can't be moved to another tag or commit. It returns the output of gpg
+// describing the signature, or ErrTagNotSigned if the tag doesn't have a signature.
+func VerifyTagSignature(ctx context.Context, tagName, commitHash string, meta *datas.TagMeta) ([]byte, error) {
+	if meta.Signature == "" {
+		return nil, ErrTagNotSigned
+	}
+
+	content, out, err := gpg.VerifyContent(ctx, []byte(meta.Signature))
+	if err != nil {
+		return nil, err
+	}
+
+	if trimSignedLines(string(content)) != trimSignedLines(TagSignaturePayload(tagName, commitHash, meta)) {
+		return out, fmt.Errorf("signature of tag %s doesn't match the tag", tagName)
+	}
+
+	return out, nil
+}
+
+// trimSignedLines trims the trailing whitespace of each line of |s|, and its trailing newlines, as is done to the
+// content of a clear-signed message.
+func trimSignedLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
 func DeleteTagsOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagNames ...string) error {
 	for _, tn := range tagNames {
 		dref := ref.NewTagRef(tn)
@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
@@ -217,6 +218,10 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr s
 		}
 	}
 
+	if dEnv.RSLoadErr == nil && dbLoadErr == nil {
+		dEnv.ConfigurePartialClone()
+	}
+
 	return dEnv
 }
 
@@ -871,13 +876,92 @@ func (dEnv *DoltEnv) UserDoltCreds() (creds.DoltCreds, bool, error) {
 			panic(err)
 		}
 
-		c, err := creds.JWKCredsReadFromFile(dEnv.FS, filepath.Join(dir, kid+".jwk"))
+		c, err := dEnv.ReadCreds(filepath.Join(dir, kid+".jwk"))
 		return c, c.IsPrivKeyValid() && c.IsPubKeyValid(), err
 	}
 
 	return creds.DoltCreds{}, false, nil
 }
 
+// CredsHelper returns the credential helper of the creds.helper config, or nil if there isn't one.
+func (dEnv *DoltEnv) CredsHelper() (creds.Helper, error) {
+	if dEnv.Config == nil {
+		return nil, nil
+	}
+	spec := strings.TrimSpace(dEnv.Config.GetStringOrDefault(config.CredsHelper, ""))
+	if spec == "" {
+		return nil, nil
+	}
+	return creds.NewHelper(spec)
+}
+
+// RemotePassword returns the password of |username| for the remote at |host|. It is given by the DOLT_REMOTE_PASSWORD
+// environment variable, or is otherwise stored by the credential helper under |protocol|, such as https, and |host|.
+func (dEnv *DoltEnv) RemotePassword(protocol, host, username string) (string, bool, error) {
+	if pass, found := os.LookupEnv(dconfig.EnvDoltRemotePassword); found {
+		return pass, true, nil
+	}
+	if dEnv == nil {
+		return "", false, nil
+	}
+	helper, err := dEnv.CredsHelper()
+	if err != nil || helper == nil {
+		return "", false, err
+	}
+	return helper.Get(creds.Credential{Protocol: protocol, Host: host, Username: username})
+}
+
+// ReadCreds reads the JWK credentials at |path|. Credentials whose private key is stored by the credential helper
+// have only a public key in their file, and their private key is loaded from the helper.
+func (dEnv *DoltEnv) ReadCreds(path string) (creds.DoltCreds, error) {
+	dc, err := creds.JWKCredsReadFromFile(dEnv.FS, path)
+	if err != nil || dc.HasPrivKey() {
+		return dc, err
+	}
+
+	helper, err := dEnv.CredsHelper()
+	if err != nil || helper == nil {
+		return dc, err
+	}
+	return creds.LoadPrivKey(helper, dc)
+}
+
+// WriteCreds writes the JWK credentials |dc| to |credsDir|, returning the path of their file. If there is a credential
+// helper, the private key is stored by it, and only the public key is written to the file.
+func (dEnv *DoltEnv) WriteCreds(credsDir string, dc creds.DoltCreds) (string, error) {
+	helper, err := dEnv.CredsHelper()
+	if err != nil {
+		return "", err
+	}
+	if helper != nil {
+		if err := helper.Store(creds.JWKCredential(dc)); err != nil {
+			return "", err
+		}
+		dc.PrivKey = nil
+	}
+	return creds.JWKCredsWriteToDir(dEnv.FS, credsDir, dc)
+}
+
+// DeleteCreds deletes the JWK credentials at |path|, and their private key from the credential helper, if any.
+func (dEnv *DoltEnv) DeleteCreds(path string) error {
+	dc, err := creds.JWKCredsReadFromFile(dEnv.FS, path)
+	if err != nil {
+		return err
+	}
+	if !dc.HasPrivKey() {
+		helper, err := dEnv.CredsHelper()
+		if err != nil {
+			return err
+		}
+		if helper != nil {
+			if err := helper.Erase(creds.JWKCredential(dc)); err != nil {
+				return err
+			}
+		}
+	}
+	return dEnv.FS.DeleteFile(path)
+}
+
 // GetGRPCDialParams implements dbfactory.GRPCDialProvider
 func (dEnv *DoltEnv) GetGRPCDialParams(config grpcendpoint.Config) (dbfactory.GRPCRemoteConfig, error) {
 	return NewGRPCDialProviderFromDoltEnv(dEnv).GetGRPCDialParams(config)
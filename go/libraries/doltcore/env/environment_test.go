@@ -16,7 +16,11 @@ package env
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -26,8 +30,10 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -259,3 +265,79 @@ func TestBestEffortDelete(t *testing.T) {
 		t.Error("Dir should be empty after delete.")
 	}
 }
+
+func TestGetTransferLimits(t *testing.T) {
+	dEnv, _ := createTestEnv(true, true)
+	limits, err := NewGRPCDialProviderFromDoltEnv(dEnv).getTransferLimits()
+	require.NoError(t, err)
+	assert.True(t, limits.IsZero())
+
+	cfg := dEnv.Config.WriteableConfig()
+	require.NoError(t, cfg.SetStrings(map[string]string{
+		config.RemotesMaxDownloadRate:        "10MB",
+		config.RemotesMaxUploadRate:          "512KiB/s",
+		config.RemotesMaxConcurrentDownloads: "8",
+	}))
+	limits, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTransferLimits()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000_000), limits.MaxDownloadBytesPerSec)
+	assert.Equal(t, int64(512*1024), limits.MaxUploadBytesPerSec)
+	assert.Equal(t, 8, limits.MaxConcurrentDownloads)
+
+	require.NoError(t, cfg.SetStrings(map[string]string{config.RemotesMaxUploadRate: "fast"}))
+	_, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTransferLimits()
+	assert.ErrorContains(t, err, config.RemotesMaxUploadRate)
+
+	require.NoError(t, cfg.SetStrings(map[string]string{config.RemotesMaxUploadRate: "1MB", config.RemotesMaxConcurrentDownloads: "0"}))
+	_, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTransferLimits()
+	assert.ErrorContains(t, err, config.RemotesMaxConcurrentDownloads)
+}
+
+func TestGetTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dEnv, _ := createTestEnv(true, true)
+	tlsConfig, err := NewGRPCDialProviderFromDoltEnv(dEnv).getTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, certPEM, 0644))
+
+	cfg := dEnv.Config.WriteableConfig()
+	require.NoError(t, cfg.SetStrings(map[string]string{
+		config.RemotesCABundle:      bundle,
+		config.RemotesTLSMinVersion: "1.3",
+	}))
+	tlsConfig, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	assert.NotNil(t, tlsConfig.RootCAs)
+
+	// the HTTP fetcher of a remote trusts the certificates of the CA bundle
+	params, err := NewGRPCDialProviderFromDoltEnv(dEnv).GetGRPCDialParams(grpcendpoint.Config{Endpoint: "localhost"})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := params.HTTPFetcher.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	resp, err = defaultHttpFetcher.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	assert.Error(t, err)
+
+	require.NoError(t, cfg.SetStrings(map[string]string{config.RemotesTLSMinVersion: "1.4"}))
+	_, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTLSConfig()
+	assert.ErrorContains(t, err, config.RemotesTLSMinVersion)
+
+	require.NoError(t, cfg.SetStrings(map[string]string{
+		config.RemotesTLSMinVersion: "1.2",
+		config.RemotesCABundle:      filepath.Join(t.TempDir(), "missing.pem"),
+	}))
+	_, err = NewGRPCDialProviderFromDoltEnv(dEnv).getTLSConfig()
+	assert.ErrorContains(t, err, config.RemotesCABundle)
+}
@@ -16,22 +16,27 @@ package env
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/dustin/go-humanize"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
-	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
 )
 
 var defaultDialer = &net.Dialer{
@@ -54,8 +59,21 @@ var defaultHttpFetcher grpcendpoint.HTTPFetcher = &http.Client{
 	Transport: defaultTransport,
 }
 
+// newHttpFetcher returns an HTTPFetcher like the default one, which uses |tlsConfig| for its connections.
+func newHttpFetcher(tlsConfig *tls.Config) grpcendpoint.HTTPFetcher {
+	transport := defaultTransport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// ErrNoRemotePassword is returned when a remote is accessed as a user whose password can't be found.
+var ErrNoRemotePassword = errors.New("error: must set DOLT_REMOTE_PASSWORD environment variable, or store the password with the credential helper, to use --user param")
+
 // GRPCDialProvider implements dbfactory.GRPCDialProvider. By default, it is not able to use custom user credentials, but
-// if it is initialized with a DoltEnv, it will load custom user credentials from it.
+// if it is initialized with a DoltEnv, it will load custom user credentials from it. Both gRPC and HTTP connections to
+// remotes honor the HTTPS_PROXY and NO_PROXY environment variables.
 type GRPCDialProvider struct {
 	dEnv *DoltEnv
 }
@@ -93,26 +111,24 @@ func (p GRPCDialProvider) GetGRPCDialParams(config grpcendpoint.Config) (dbfacto
 	if config.TLSConfig != nil {
 		tc := credentials.NewTLS(config.TLSConfig)
 		opts = append(opts, grpc.WithTransportCredentials(tc))
-
-		transport := &http.Transport{
-			Proxy:                 http.ProxyFromEnvironment,
-			DialContext:           defaultDialer.DialContext,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          1024,
-			MaxIdleConnsPerHost:   256,
-			IdleConnTimeout:       90 * time.Second,
-			TLSClientConfig:       config.TLSConfig,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
+		httpfetcher = newHttpFetcher(config.TLSConfig)
+	} else {
+		tlsConfig, err := p.getTLSConfig()
+		if err != nil {
+			return dbfactory.GRPCRemoteConfig{}, err
 		}
-		httpfetcher = &http.Client{
-			Transport: transport,
+		if tlsConfig != nil {
+			httpfetcher = newHttpFetcher(tlsConfig)
+		} else {
+			tlsConfig = &tls.Config{}
+		}
+
+		if config.Insecure {
+			opts = append(opts, grpc.WithInsecure())
+		} else {
+			tc := credentials.NewTLS(tlsConfig)
+			opts = append(opts, grpc.WithTransportCredentials(tc))
 		}
-	} else if config.Insecure {
-		opts = append(opts, grpc.WithInsecure())
-	} else {
-		tc := credentials.NewTLS(&tls.Config{})
-		opts = append(opts, grpc.WithTransportCredentials(tc))
 	}
 
 	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(128*1024*1024)))
@@ -124,7 +140,7 @@ func (p GRPCDialProvider) GetGRPCDialParams(config grpcendpoint.Config) (dbfacto
 		var rpcCreds credentials.PerRPCCredentials
 		var err error
 		if config.UserIdForOsEnvAuth != "" {
-			rpcCreds, err = p.getRPCCredsFromOSEnv(config.UserIdForOsEnvAuth)
+			rpcCreds, err = p.getRPCCredsForUser(config.UserIdForOsEnvAuth, config.Endpoint, config.Insecure)
 			if err != nil {
 				return dbfactory.GRPCRemoteConfig{}, err
 			}
@@ -139,22 +155,127 @@ func (p GRPCDialProvider) GetGRPCDialParams(config grpcendpoint.Config) (dbfacto
 		}
 	}
 
+	limits, err := p.getTransferLimits()
+	if err != nil {
+		return dbfactory.GRPCRemoteConfig{}, err
+	}
+
 	return dbfactory.GRPCRemoteConfig{
-		Endpoint:    endpoint,
-		DialOptions: opts,
-		HTTPFetcher: httpfetcher,
+		Endpoint:       endpoint,
+		DialOptions:    opts,
+		HTTPFetcher:    httpfetcher,
+		TransferLimits: limits,
 	}, nil
 }
 
-// getRPCCredsFromOSEnv returns RPC Credentials for the specified username, using the DOLT_REMOTE_PASSWORD
-func (p GRPCDialProvider) getRPCCredsFromOSEnv(username string) (credentials.PerRPCCredentials, error) {
+// getTransferLimits returns the limits of transfers to and from remotes which are set in the config of the DoltEnv.
+func (p GRPCDialProvider) getTransferLimits() (remotestorage.TransferLimits, error) {
+	var limits remotestorage.TransferLimits
+	if p.dEnv == nil || p.dEnv.Config == nil {
+		return limits, nil
+	}
+
+	var err error
+	limits.MaxDownloadBytesPerSec, err = getByteRateConfig(p.dEnv.Config, config.RemotesMaxDownloadRate)
+	if err != nil {
+		return limits, err
+	}
+	limits.MaxUploadBytesPerSec, err = getByteRateConfig(p.dEnv.Config, config.RemotesMaxUploadRate)
+	if err != nil {
+		return limits, err
+	}
+	if str := p.dEnv.Config.GetStringOrDefault(config.RemotesMaxConcurrentDownloads, ""); str != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(str))
+		if err != nil || n < 1 {
+			return limits, fmt.Errorf("invalid value '%s' for config '%s': must be a positive number", str, config.RemotesMaxConcurrentDownloads)
+		}
+		limits.MaxConcurrentDownloads = n
+	}
+	return limits, nil
+}
+
+// tlsVersions are the values of the remotes.tls_min_version config.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// getTLSConfig returns the TLS config of connections to remotes, which trusts the CA certificates of the
+// remotes.ca_bundle config in addition to the system's, and has the minimum version of the remotes.tls_min_version
+// config. It returns nil if neither is set in the config of the DoltEnv.
+func (p GRPCDialProvider) getTLSConfig() (*tls.Config, error) {
+	if p.dEnv == nil || p.dEnv.Config == nil {
+		return nil, nil
+	}
+	bundles := strings.TrimSpace(p.dEnv.Config.GetStringOrDefault(config.RemotesCABundle, ""))
+	minVersion := strings.TrimSpace(p.dEnv.Config.GetStringOrDefault(config.RemotesTLSMinVersion, ""))
+	if bundles == "" && minVersion == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if minVersion != "" {
+		v, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid value '%s' for config '%s': must be one of 1.0, 1.1, 1.2 or 1.3", minVersion, config.RemotesTLSMinVersion)
+		}
+		tlsConfig.MinVersion = v
+	}
+
+	if bundles != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		for _, path := range strings.Split(bundles, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			pem, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading CA bundle '%s' of config '%s': %w", path, config.RemotesCABundle, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("invalid CA bundle '%s' of config '%s': no PEM encoded certificates found", path, config.RemotesCABundle)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// getByteRateConfig returns the bytes per second of config |key|, such as 10MB or 512KiB/s, or zero if it isn't set.
+func getByteRateConfig(cfg *DoltCliConfig, key string) (int64, error) {
+	str := strings.TrimSpace(cfg.GetStringOrDefault(key, ""))
+	if str == "" {
+		return 0, nil
+	}
+	n, err := humanize.ParseBytes(strings.TrimSuffix(str, "/s"))
+	if err != nil || n == 0 || n > uint64(1<<62) {
+		return 0, fmt.Errorf("invalid value '%s' for config '%s': must be a positive number of bytes per second, such as 10MB", str, key)
+	}
+	return int64(n), nil
+}
+
+// getRPCCredsForUser returns RPC Credentials for the specified username, using the DOLT_REMOTE_PASSWORD environment
+// variable, or the password stored by the credential helper for the host of |endpoint|.
+func (p GRPCDialProvider) getRPCCredsForUser(username, endpoint string, insecure bool) (credentials.PerRPCCredentials, error) {
 	if username == "" {
-		return nil, errors.New("Runtime error: username must be provided to getRPCCredsFromOSEnv")
+		return nil, errors.New("Runtime error: username must be provided to getRPCCredsForUser")
 	}
 
-	pass, found := os.LookupEnv(dconfig.EnvDoltRemotePassword)
-	if !found {
-		return nil, errors.New("error: must set DOLT_REMOTE_PASSWORD environment variable to use --user param")
+	protocol := "https"
+	if insecure {
+		protocol = "http"
+	}
+	pass, found, err := p.dEnv.RemotePassword(protocol, endpoint, username)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, ErrNoRemotePassword
 	}
 	c := creds.DoltCredsForPass{
 		Username: username,
@@ -233,7 +233,7 @@ func (m MemoryRepoState) TempTableFilesDir() (string, error) {
 }
 
 func (m MemoryRepoState) GetBackups() (*concurrentmap.Map[string, Remote], error) {
-	panic("cannot get backups on in memory database")
+	return concurrentmap.New[string, Remote](), nil
 }
 
 func (m MemoryRepoState) AddBackup(r Remote) error {
@@ -0,0 +1,72 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// PartialCloneFilterParam is the remote param which holds the fetch filter of a partial clone. Fetches from a
+// remote with this param skip the chunks selected by its filter.
+const PartialCloneFilterParam = "partial_clone_filter"
+
+// FetchFilter returns the fetch filter of |r|, or nil if fetches from |r| aren't filtered.
+func (r *Remote) FetchFilter() (*doltdb.FetchFilter, error) {
+	spec, ok := r.GetParam(PartialCloneFilterParam)
+	if !ok || spec == "" {
+		return nil, nil
+	}
+	return doltdb.ParseFetchFilter(spec)
+}
+
+// partialCloneRemote returns the remote a partial clone skipped chunks of, preferring origin when several remotes
+// have fetch filters.
+func (dEnv *DoltEnv) partialCloneRemote() (Remote, bool) {
+	remotes, err := dEnv.GetRemotes()
+	if err != nil || remotes == nil {
+		return NoRemote, false
+	}
+	if r, ok := remotes.Get("origin"); ok {
+		if _, ok := r.GetParam(PartialCloneFilterParam); ok {
+			return r, true
+		}
+	}
+	var found Remote
+	remotes.Iter(func(_ string, r Remote) bool {
+		if _, ok := r.GetParam(PartialCloneFilterParam); ok {
+			found = r
+			return false
+		}
+		return true
+	})
+	return found, !IsEmptyRemote(found)
+}
+
+// ConfigurePartialClone makes the DoltDB of |dEnv| fetch the chunks skipped by a partial clone from the remote they
+// were skipped on when they are first read. It does nothing if |dEnv| isn't a partial clone.
+func (dEnv *DoltEnv) ConfigurePartialClone() {
+	if dEnv.DoltDB == nil {
+		return
+	}
+	r, ok := dEnv.partialCloneRemote()
+	if !ok {
+		return
+	}
+	dEnv.DoltDB.SetPartialCloneSource(func(ctx context.Context) (*doltdb.DoltDB, error) {
+		return r.GetRemoteDB(ctx, dEnv.DoltDB.Format(), dEnv)
+	})
+}
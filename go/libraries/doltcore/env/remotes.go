@@ -438,9 +438,11 @@ func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error)
 			return nil, fmt.Errorf("%w: '%s'", ErrInvalidFetchSpec, rsStr)
 		}
 
-		if _, ok := rs.(ref.BranchToBranchRefSpec); ok {
-			local := "refs/heads/" + rsStr
-			remTracking := "remotes/" + remName + "/" + rsStr
+		if bs, ok := rs.(ref.BranchToBranchRefSpec); ok && !strings.Contains(rsStr, ":") {
+			// a branch name or a full branch ref, like refs/heads/release/*, is fetched into its remote tracking branch
+			branch := bs.SrcRef(nil).GetPath()
+			local := "refs/heads/" + branch
+			remTracking := "remotes/" + remName + "/" + branch
 			rs2, err := ref.ParseRefSpec(local + ":" + remTracking)
 
 			if err == nil {
@@ -459,6 +461,25 @@ func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error)
 	return refSpecs, nil
 }
 
+// FetchSpecsForRefPatterns returns the fetch specs of remote |remName| which fetch the remote branches matched by
+// |patterns| into their remote tracking branches. Each pattern is a branch name or a full branch ref, optionally with
+// a '*' wildcard, e.g. refs/heads/release/*.
+func FetchSpecsForRefPatterns(remName string, patterns []string) ([]string, error) {
+	fetchSpecs := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		r, err := ref.Parse(pattern)
+		if err != nil || r.GetType() != ref.BranchRefType {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidFetchSpec, pattern)
+		}
+		fetchSpec := fmt.Sprintf("refs/heads/%s:refs/remotes/%s/%s", r.GetPath(), remName, r.GetPath())
+		if _, err = ref.ParseRefSpecForRemote(remName, fetchSpec); err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidFetchSpec, pattern)
+		}
+		fetchSpecs = append(fetchSpecs, fetchSpec)
+	}
+	return fetchSpecs, nil
+}
+
 // if possible, convert refs to full spec names. prefer branches over tags.
 // eg "main" -> "refs/heads/main", "v1" -> "refs/tags/v1"
 func disambiguateRefSpecStr(ctx context.Context, ddb *doltdb.DoltDB, refSpecStr string) (string, error) {
@@ -43,6 +43,7 @@ type MergeSpec struct {
 	NoCommit        bool
 	NoEdit          bool
 	Force           bool
+	Strategy        doltdb.MergeStrategy
 	Email           string
 	Name            string
 	Date            time.Time
@@ -80,6 +81,14 @@ func WithSquash(squash bool) MergeSpecOpt {
 	}
 }
 
+// WithStrategy merges every table with |strategy| instead of the strategies configured in the dolt_merge_strategies
+// table, unless |strategy| is empty.
+func WithStrategy(strategy doltdb.MergeStrategy) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.Strategy = strategy
+	}
+}
+
 // NewMergeSpec returns a MergeSpec with the arguments provided.
 func NewMergeSpec(
 	ctx context.Context,
@@ -48,7 +48,9 @@ var ErrMultipleViolationsForRow = errors.New("multiple violations for row not su
 
 var ErrSameTblAddedTwice = goerrors.NewKind("table with same name '%s' added in 2 commits can't be merged")
 
-func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+// MergeCommits merges |mergeCommit| into |commit|. Tables are merged with |strategy| when it is set, and otherwise with
+// the strategies configured for them in the dolt_merge_strategies table of |commit|.
+func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts editor.Options, strategy doltdb.MergeStrategy) (*Result, error) {
 	optCmt, err := doltdb.GetCommitAncestor(ctx, commit, mergeCommit)
 	if err != nil {
 		return nil, err
@@ -75,8 +77,10 @@ func MergeCommits(ctx *sql.Context, commit, mergeCommit *doltdb.Commit, opts edi
 	}
 
 	mo := MergeOpts{
-		IsCherryPick:        false,
-		KeepSchemaConflicts: true,
+		IsCherryPick:         false,
+		KeepSchemaConflicts:  true,
+		ApplyMergeStrategies: true,
+		Strategy:             strategy,
 	}
 	return MergeRoots(ctx, ourRoot, theirRoot, ancRoot, mergeCommit, ancCommit, opts, mo)
 }
@@ -220,7 +224,8 @@ func MergeRoots(
 	}
 
 	// Make sure to pass in ourRoot as the first RootValue so that ourRoot's table names will be merged first.
-	// This helps to avoid non-deterministic error result for table rename cases. Renaming a table creates two changes:
+	// This helps to avoid non-deterministic error result for table rename cases that can't be merged. Renaming a
+	// table creates two changes:
 	// 1. dropping the old name table
 	// 2. adding the new name table
 	// Dropping the old name table will trigger delete/modify conflict, which is the preferred error case over
@@ -233,14 +238,31 @@ func MergeRoots(
 
 	tblToStats := make(map[doltdb.TableName]*MergeStats)
 
-	// Merge tables one at a time. This is done based on name. With table names from ourRoot being merged first,
-	// renaming a table will return delete/modify conflict error consistently.
-	// TODO: merge based on a more durable table identity that persists across renames
+	// Merge tables one at a time. This is done based on name, except for tables renamed on either side, which are
+	// matched to their ancestor by identity and merged under their new name.
 	merger, err := NewMerger(ourRoot, theirRoot, ancRoot, theirs, ancestor, ourRoot.VRW(), ourRoot.NodeStore())
 	if err != nil {
 		return nil, err
 	}
 
+	renamedFrom, err := merger.resolveTableRenames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skipTables := make(map[doltdb.TableName]struct{}, len(renamedFrom))
+	for _, oldName := range renamedFrom {
+		skipTables[oldName] = struct{}{}
+		if ok, err := mergedRoot.HasTable(ctx, oldName); err != nil {
+			return nil, err
+		} else if ok {
+			// Foreign keys are merged after the tables, so the renamed table's foreign keys are left alone here
+			mergedRoot, err = mergedRoot.RemoveTables(ctx, true, false, oldName)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	destSchemaNames, err := getDatabaseSchemaNames(ctx, ourRoot)
 	if err != nil {
 		return nil, err
@@ -250,6 +272,9 @@ func MergeRoots(
 	visitedTables := make(map[string]struct{})
 	var schConflicts []SchemaConflict
 	for _, tblName := range tblNames {
+		if _, ok := skipTables[tblName]; ok {
+			continue
+		}
 		mergedTable, stats, err := merger.MergeTable(ctx, tblName, opts, mergeOpts)
 
 		if errors.Is(ErrTableDeletedAndModified, err) && doltdb.IsFullTextTable(tblName.Name) {
@@ -372,6 +397,11 @@ func MergeRoots(
 		}
 	}
 
+	mergedRoot, err = ApplyForeignKeyReferentialActions(ctx, mergedRoot, ancRoot, tableSet)
+	if err != nil {
+		return nil, err
+	}
+
 	mergedRoot, _, err = AddForeignKeyViolations(ctx, mergedRoot, ancRoot, tableSet, h)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,188 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// columnMergeStrategyFurthest takes the value further from the ancestor's value, in the direction both sides moved it,
+// or the greater of the two if there's no ancestor value. Returns a conflict if the sides moved it in opposite
+// directions. It can't be configured in dolt_column_merge_strategies, and resolves the current values of sequences,
+// which are advanced concurrently by transactions and branches, see sequencesColumnStrategies.
+const columnMergeStrategyFurthest doltdb.ColumnMergeStrategy = "furthest"
+
+// sequencesColumnStrategies are the strategies of the columns of the dolt_sequences system table. A sequence's values
+// are handed out across transactions and branches without repeating, so its current value is the one furthest along.
+var sequencesColumnStrategies = map[string]doltdb.ColumnMergeStrategy{
+	"current_value": columnMergeStrategyFurthest,
+}
+
+// columnStrategies returns the |strategies| of the non-primary-key columns of the merged schema |sch|, by their
+// indexes.
+func columnStrategies(strategies map[string]doltdb.ColumnMergeStrategy, sch schema.Schema) map[int]doltdb.ColumnMergeStrategy {
+	if len(strategies) == 0 {
+		return nil
+	}
+	cols := make(map[int]doltdb.ColumnMergeStrategy)
+	i := 0
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		if strategy, ok := strategies[strings.ToLower(col.Name)]; ok {
+			cols[i] = strategy
+		}
+		i++
+	}
+	return cols
+}
+
+// resolveColumnConflict resolves the values of column |i| modified differently on both sides of the merge with the
+// strategy configured for the column, if any. The values have already been converted to the merged schema, and
+// |baseCol| is nil if both sides inserted the row. Returns a conflict if the column has no strategy, or if its
+// strategy doesn't apply to the values.
+func (m *valueMerger) resolveColumnConflict(ctx context.Context, i int, baseCol, leftCol, rightCol []byte) (result []byte, conflict bool, err error) {
+	strategy, ok := m.columnStrategies[i]
+	if !ok {
+		return nil, true, nil
+	}
+
+	resultType := m.resultVD.Types[i]
+	switch strategy {
+	case doltdb.ColumnMergeStrategyOurs:
+		return leftCol, false, nil
+	case doltdb.ColumnMergeStrategyTheirs:
+		return rightCol, false, nil
+	case doltdb.ColumnMergeStrategyMax, doltdb.ColumnMergeStrategyMin:
+		if leftCol == nil {
+			return rightCol, false, nil
+		} else if rightCol == nil {
+			return leftCol, false, nil
+		}
+		cmp := m.resultVD.Comparator().CompareValues(i, leftCol, rightCol, resultType)
+		if (cmp > 0) == (strategy == doltdb.ColumnMergeStrategyMax) {
+			return leftCol, false, nil
+		}
+		return rightCol, false, nil
+	case doltdb.ColumnMergeStrategySum:
+		return m.sumColumn(ctx, i, baseCol, leftCol, rightCol)
+	case columnMergeStrategyFurthest:
+		if leftCol == nil {
+			return rightCol, false, nil
+		} else if rightCol == nil {
+			return leftCol, false, nil
+		}
+		cmp := m.resultVD.Comparator().CompareValues(i, leftCol, rightCol, resultType)
+		if baseCol == nil {
+			if cmp > 0 {
+				return leftCol, false, nil
+			}
+			return rightCol, false, nil
+		}
+		leftCmp := m.resultVD.Comparator().CompareValues(i, leftCol, baseCol, resultType)
+		rightCmp := m.resultVD.Comparator().CompareValues(i, rightCol, baseCol, resultType)
+		if (leftCmp > 0) != (rightCmp > 0) {
+			return nil, true, nil
+		}
+		if (cmp > 0) == (leftCmp > 0) {
+			return leftCol, false, nil
+		}
+		return rightCol, false, nil
+	default:
+		return nil, true, nil
+	}
+}
+
+// sumColumn adds the changes made to the numeric column |i| on both sides of the merge to its base value. NULL
+// values count as zero. Returns a conflict if the column isn't numeric, or if the sum is out of the range of its type.
+func (m *valueMerger) sumColumn(ctx context.Context, i int, baseCol, leftCol, rightCol []byte) (result []byte, conflict bool, err error) {
+	desc := val.NewTupleDescriptor(m.resultVD.Types[i])
+	sqlType := m.resultSchema.GetNonPKCols().GetByIndex(i).TypeInfo.ToSqlType()
+	if _, ok := sqlType.(sql.NumberType); !ok {
+		if _, ok := sqlType.(sql.DecimalType); !ok {
+			return nil, true, nil
+		}
+	}
+
+	read := func(col []byte) (decimal.Decimal, bool, error) {
+		if col == nil {
+			return decimal.Zero, true, nil
+		}
+		v, err := tree.GetField(ctx, desc, 0, val.NewTuple(m.syncPool, col), m.ns)
+		if err != nil || v == nil {
+			return decimal.Zero, err == nil, err
+		}
+		switch v := v.(type) {
+		case int8:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case int16:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case int32:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case int64:
+			return decimal.NewFromInt(v), true, nil
+		case uint8:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case uint16:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case uint32:
+			return decimal.NewFromInt(int64(v)), true, nil
+		case uint64:
+			return decimal.NewFromBigInt(new(big.Int).SetUint64(v), 0), true, nil
+		case float32:
+			return decimal.NewFromFloat32(v), true, nil
+		case float64:
+			return decimal.NewFromFloat(v), true, nil
+		case decimal.Decimal:
+			return v, true, nil
+		default:
+			return decimal.Zero, false, nil
+		}
+	}
+
+	base, ok, err := read(baseCol)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+	left, ok, err := read(leftCol)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+	right, ok, err := read(rightCol)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+
+	sum, inRange, err := sqlType.Convert(left.Add(right).Sub(base))
+	if err != nil || inRange != sql.InRange {
+		return nil, true, nil
+	}
+	tb := val.NewTupleBuilder(desc)
+	if err = tree.PutField(ctx, m.ns, tb, 0, sum); err != nil {
+		return nil, true, err
+	}
+	return tb.Build(m.syncPool).GetField(0), false, nil
+}
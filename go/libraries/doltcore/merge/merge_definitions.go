@@ -0,0 +1,176 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// definitionColumns returns the columns of |tableName| that store the definitions of views, triggers, events and
+// stored procedures, which are merged line by line when both sides of a merge modify them, and the columns that store
+// metadata about those definitions, which never conflict. The indexes are those of the non-primary-key columns of the
+// merged schema |sch|. Other tables have no such columns.
+func definitionColumns(tableName doltdb.TableName, sch schema.Schema) (textCols, metadataCols map[int]bool) {
+	var textNames, metadataNames []string
+	switch strings.ToLower(tableName.Name) {
+	case doltdb.SchemasTableName:
+		textNames = []string{doltdb.SchemasTablesFragmentCol}
+		metadataNames = []string{doltdb.SchemasTablesExtraCol}
+	case doltdb.ProceduresTableName:
+		textNames = []string{doltdb.ProceduresTableCreateStmtCol}
+		metadataNames = []string{doltdb.ProceduresTableCreatedAtCol, doltdb.ProceduresTableModifiedAtCol}
+	default:
+		return nil, nil
+	}
+
+	indexes := func(names []string) map[int]bool {
+		cols := make(map[int]bool)
+		for _, name := range names {
+			if idx := findNonPKColumnMappingByName(sch, name); idx >= 0 {
+				cols[idx] = true
+			}
+		}
+		return cols
+	}
+	return indexes(textNames), indexes(metadataNames)
+}
+
+// mergeTextColumn merges the values of the text column |i| that were modified on both sides of the merge with
+// mergeText. The values have already been converted to the merged schema. Returns a conflict if the values can't be
+// merged.
+func (m *valueMerger) mergeTextColumn(ctx context.Context, i int, baseCol, leftCol, rightCol []byte) (result []byte, conflict bool, err error) {
+	desc := val.NewTupleDescriptor(m.resultVD.Types[i])
+	read := func(col []byte) (string, bool, error) {
+		if col == nil {
+			return "", false, nil
+		}
+		v, err := tree.GetField(ctx, desc, 0, val.NewTuple(m.syncPool, col), m.ns)
+		if err != nil || v == nil {
+			return "", false, err
+		}
+		s, ok := v.(string)
+		return s, ok, nil
+	}
+
+	base, _, err := read(baseCol)
+	if err != nil {
+		return nil, true, err
+	}
+	left, ok, err := read(leftCol)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+	right, ok, err := read(rightCol)
+	if err != nil || !ok {
+		return nil, true, err
+	}
+
+	merged, ok := mergeText(base, left, right)
+	if !ok {
+		return nil, true, nil
+	}
+
+	tb := val.NewTupleBuilder(desc)
+	if err = tree.PutField(ctx, m.ns, tb, 0, merged); err != nil {
+		return nil, true, err
+	}
+	return tb.Build(m.syncPool).GetField(0), false, nil
+}
+
+// mergeText performs a line-based three-way merge of the texts |left| and |right| with their common ancestor |base|,
+// in the manner of diff3. Regions changed on only one side take that side's lines, and regions changed the same way
+// on both sides are taken once. Returns false if both sides changed the same region in different ways.
+func mergeText(base, left, right string) (string, bool) {
+	baseLines := strings.Split(base, "\n")
+	leftLines := strings.Split(left, "\n")
+	rightLines := strings.Split(right, "\n")
+	toLeft := matchLines(baseLines, leftLines)
+	toRight := matchLines(baseLines, rightLines)
+
+	var merged []string
+	b, l, r := 0, 0, 0
+	for b < len(baseLines) || l < len(leftLines) || r < len(rightLines) {
+		if b < len(baseLines) && toLeft[b] == l && toRight[b] == r {
+			// a line unchanged on both sides
+			merged = append(merged, baseLines[b])
+			b, l, r = b+1, l+1, r+1
+			continue
+		}
+
+		// Find the end of the changed region: the next base line that is unchanged on both sides.
+		next := b
+		for next < len(baseLines) && (toLeft[next] < 0 || toRight[next] < 0) {
+			next++
+		}
+		nextLeft, nextRight := len(leftLines), len(rightLines)
+		if next < len(baseLines) {
+			nextLeft, nextRight = toLeft[next], toRight[next]
+		}
+
+		baseChunk, leftChunk, rightChunk := baseLines[b:next], leftLines[l:nextLeft], rightLines[r:nextRight]
+		switch {
+		case slices.Equal(leftChunk, baseChunk):
+			merged = append(merged, rightChunk...)
+		case slices.Equal(rightChunk, baseChunk), slices.Equal(leftChunk, rightChunk):
+			merged = append(merged, leftChunk...)
+		default:
+			return "", false
+		}
+		b, l, r = next, nextLeft, nextRight
+	}
+	return strings.Join(merged, "\n"), true
+}
+
+// matchLines returns, for each line of |base|, the index of the line of |other| it is matched to by a longest common
+// subsequence of the two, or -1 if it isn't matched.
+func matchLines(base, other []string) []int {
+	// lengths[i][j] is the length of the longest common subsequence of base[i:] and other[j:]
+	lengths := make([][]int, len(base)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(other)+1)
+	}
+	for i := len(base) - 1; i >= 0; i-- {
+		for j := len(other) - 1; j >= 0; j-- {
+			if base[i] == other[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	matches := make([]int, len(base))
+	i, j := 0, 0
+	for i < len(base) {
+		switch {
+		case j < len(other) && base[i] == other[j]:
+			matches[i] = j
+			i, j = i+1, j+1
+		case j < len(other) && lengths[i][j+1] >= lengths[i+1][j]:
+			j++
+		default:
+			matches[i] = -1
+			i++
+		}
+	}
+	return matches
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeText(t *testing.T) {
+	const base = "BEGIN\n  SET x = 1;\n  SET y = 2;\n  SET z = 3;\nEND"
+	tests := []struct {
+		name        string
+		left, right string
+		merged      string
+		conflict    bool
+	}{
+		{
+			name:   "changes to different lines",
+			left:   "BEGIN\n  SET x = 10;\n  SET y = 2;\n  SET z = 3;\nEND",
+			right:  "BEGIN\n  SET x = 1;\n  SET y = 2;\n  SET z = 30;\nEND",
+			merged: "BEGIN\n  SET x = 10;\n  SET y = 2;\n  SET z = 30;\nEND",
+		},
+		{
+			name:   "insertions and deletions",
+			left:   "BEGIN\n  SET w = 0;\n  SET x = 1;\n  SET y = 2;\n  SET z = 3;\nEND",
+			right:  "BEGIN\n  SET x = 1;\n  SET z = 3;\nEND",
+			merged: "BEGIN\n  SET w = 0;\n  SET x = 1;\n  SET z = 3;\nEND",
+		},
+		{
+			name:   "changes on one side",
+			left:   base,
+			right:  "BEGIN\n  SET x = 1;\nEND",
+			merged: "BEGIN\n  SET x = 1;\nEND",
+		},
+		{
+			name:   "identical changes",
+			left:   "BEGIN\n  SET y = 20;\n  SET x = 1;\nEND",
+			right:  "BEGIN\n  SET y = 20;\n  SET x = 1;\nEND",
+			merged: "BEGIN\n  SET y = 20;\n  SET x = 1;\nEND",
+		},
+		{
+			name:     "different changes to the same line",
+			left:     "BEGIN\n  SET x = 1;\n  SET y = 20;\n  SET z = 3;\nEND",
+			right:    "BEGIN\n  SET x = 1;\n  SET y = 200;\n  SET z = 3;\nEND",
+			conflict: true,
+		},
+		{
+			name:     "different insertions at the same place",
+			left:     "BEGIN\n  SET x = 1;\n  SET a = 1;\n  SET y = 2;\n  SET z = 3;\nEND",
+			right:    "BEGIN\n  SET x = 1;\n  SET b = 1;\n  SET y = 2;\n  SET z = 3;\nEND",
+			conflict: true,
+		},
+		{
+			name:     "different changes to a single line",
+			left:     "create view v as select 1",
+			right:    "create view v as select 2",
+			conflict: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, ok := mergeText(base, test.left, test.right)
+			if test.conflict {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, test.merged, merged)
+
+			// the merge doesn't depend on its direction
+			merged, ok = mergeText(base, test.right, test.left)
+			assert.True(t, ok)
+			assert.Equal(t, test.merged, merged)
+		})
+	}
+}
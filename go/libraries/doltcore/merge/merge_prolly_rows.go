@@ -75,6 +75,9 @@ func mergeProllyTable(
 	}
 	leftRows := durable.ProllyMapFromIndex(lr)
 	valueMerger := newValueMerger(mergedSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), tm.ns)
+	valueMerger.rowLevel = tm.strategy == doltdb.MergeStrategyRow
+	valueMerger.textCols, valueMerger.metadataCols = definitionColumns(tm.name, mergedSch)
+	valueMerger.columnStrategies = columnStrategies(tm.columnStrategies, mergedSch)
 
 	if !valueMerger.leftMapping.IsIdentityMapping() {
 		mergeInfo.LeftNeedsRewrite = true
@@ -156,7 +159,7 @@ func mergeProllyTableData(ctx *sql.Context, tm *TableMerger, finalSch schema.Sch
 		return nil, nil, err
 	}
 
-	checkValidator, err := newCheckValidator(ctx, tm, valueMerger, finalSch, artEditor)
+	checkValidator, err := newCheckValidator(ctx, tm, valueMerger, finalSch, mergeInfo, artEditor)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -182,6 +185,9 @@ func mergeProllyTableData(ctx *sql.Context, tm *TableMerger, finalSch schema.Sch
 		} else if err != nil {
 			return nil, nil, err
 		}
+		if tm.strategy == doltdb.MergeStrategyUnion {
+			diff = resolveUnionConflict(diff)
+		}
 		cnt, err := uniq.validateDiff(ctx, diff)
 		if err != nil {
 			return nil, nil, err
@@ -269,7 +275,7 @@ func mergeProllyTableData(ctx *sql.Context, tm *TableMerger, finalSch schema.Sch
 			}
 		case tree.DiffOpConvergentAdd, tree.DiffOpConvergentModify, tree.DiffOpConvergentDelete:
 			// In this case, both sides of the merge have made the same change, so no additional changes are needed.
-			if keyless {
+			if keyless && tm.strategy != doltdb.MergeStrategyUnion {
 				s.DataConflicts++
 				err = conflicts.merge(ctx, diff, nil)
 				if err != nil {
@@ -363,15 +369,19 @@ type checkValidator struct {
 	valueMerger      *valueMerger
 	tableMerger      *TableMerger
 	sch              schema.Schema
+	mergeInfo        MergeInfo
+	leftDefaults     []sql.Expression
+	rightDefaults    []sql.Expression
 	edits            *prolly.ArtifactsEditor
 	srcHash          hash.Hash
 }
 
 // newCheckValidator creates a new checkValidator, ready to validate diff events. |tm| provides the overall information
 // about the table being merged, |vm| provides the details on how the value tuples are being merged between the ancestor,
-// right and left sides of the merge, |sch| provides the final schema of the merge, and |edits| is used to write
-// constraint validation artifacts.
-func newCheckValidator(ctx *sql.Context, tm *TableMerger, vm *valueMerger, sch schema.Schema, edits *prolly.ArtifactsEditor) (checkValidator, error) {
+// right and left sides of the merge, |sch| provides the final schema of the merge, |mergeInfo| describes which sides
+// of the merge need their rows migrated to the final schema, and |edits| is used to write constraint validation
+// artifacts.
+func newCheckValidator(ctx *sql.Context, tm *TableMerger, vm *valueMerger, sch schema.Schema, mergeInfo MergeInfo, edits *prolly.ArtifactsEditor) (checkValidator, error) {
 	checkExpressions := make(map[string]sql.Expression)
 
 	checks := sch.Checks()
@@ -392,11 +402,32 @@ func newCheckValidator(ctx *sql.Context, tm *TableMerger, vm *valueMerger, sch s
 		return checkValidator{}, err
 	}
 
+	// Rows migrated to the final schema pick up default and generated values for columns that only exist on the
+	// other side of the merge, so checks must be evaluated against those values rather than NULLs.
+	var leftDefaults, rightDefaults []sql.Expression
+	if len(checkExpressions) > 0 && !vm.keyless {
+		if mergeInfo.LeftNeedsRewrite {
+			leftDefaults, err = resolveDefaults(ctx, tm.name.Name, sch, tm.leftSch)
+			if err != nil {
+				return checkValidator{}, err
+			}
+		}
+		if mergeInfo.RightNeedsRewrite {
+			rightDefaults, err = resolveDefaults(ctx, tm.name.Name, sch, tm.rightSch)
+			if err != nil {
+				return checkValidator{}, err
+			}
+		}
+	}
+
 	return checkValidator{
 		checkExpressions: checkExpressions,
 		valueMerger:      vm,
 		tableMerger:      tm,
 		sch:              sch,
+		mergeInfo:        mergeInfo,
+		leftDefaults:     leftDefaults,
+		rightDefaults:    rightDefaults,
 		edits:            edits,
 		srcHash:          srcHash,
 	}, nil
@@ -406,6 +437,9 @@ func newCheckValidator(ctx *sql.Context, tm *TableMerger, vm *valueMerger, sch s
 // be rechecked after the merge. If any check constraint violations are detected, the violation count is returned as
 // the first return parameter and the violations are also written to the artifact editor passed in on creation.
 func (cv checkValidator) validateDiff(ctx *sql.Context, diff tree.ThreeWayDiff) (int, error) {
+	if len(cv.checkExpressions) == 0 {
+		return 0, nil
+	}
 	conflictCount := 0
 
 	var valueTuple val.Tuple
@@ -432,21 +466,12 @@ func (cv checkValidator) validateDiff(ctx *sql.Context, diff tree.ThreeWayDiff)
 		valueDesc = cv.tableMerger.leftSch.GetValueDescriptor()
 	}
 
-	for checkName, checkExpression := range cv.checkExpressions {
-		// Remap the value to the final schema before checking.
-		// We skip keyless tables, since their value tuples require different mapping
-		// logic and we don't currently support merges to keyless tables that contain schema changes anyway.
-		newTuple := valueTuple
-		if !cv.valueMerger.keyless {
-			if diff.Op == tree.DiffOpRightAdd || diff.Op == tree.DiffOpRightModify {
-				newTupleBytes := remapTuple(valueTuple, valueDesc, cv.valueMerger.rightMapping)
-				newTuple = val.NewTuple(cv.valueMerger.syncPool, newTupleBytes...)
-			} else if diff.Op == tree.DiffOpLeftAdd || diff.Op == tree.DiffOpLeftModify {
-				newTupleBytes := remapTuple(valueTuple, valueDesc, cv.valueMerger.leftMapping)
-				newTuple = val.NewTuple(cv.valueMerger.syncPool, newTupleBytes...)
-			}
-		}
+	newTuple, err := cv.remapToFinalSchema(ctx, diff, valueTuple, valueDesc)
+	if err != nil {
+		return 0, err
+	}
 
+	for checkName, checkExpression := range cv.checkExpressions {
 		row, err := index.BuildRow(ctx, diff.Key, newTuple, cv.sch, cv.valueMerger.ns)
 		if err != nil {
 			return 0, err
@@ -492,6 +517,34 @@ func (cv checkValidator) validateDiff(ctx *sql.Context, diff tree.ThreeWayDiff)
 	return conflictCount, nil
 }
 
+// remapToFinalSchema maps |value|, the value tuple of |diff| described by |valueDesc|, to the final schema of the
+// merge. When a side of the merge needs to be migrated, the value is built the same way the primary index merge will
+// build it, including any default or generated values for columns the source side doesn't have. Keyless tables are
+// skipped, since their value tuples require different mapping logic and we don't currently support merges to keyless
+// tables that contain schema changes anyway.
+func (cv checkValidator) remapToFinalSchema(ctx *sql.Context, diff tree.ThreeWayDiff, value val.Tuple, valueDesc val.TupleDesc) (val.Tuple, error) {
+	if cv.valueMerger.keyless {
+		return value, nil
+	}
+
+	switch diff.Op {
+	case tree.DiffOpRightAdd, tree.DiffOpRightModify:
+		if cv.mergeInfo.RightNeedsRewrite {
+			return remapTupleWithColumnDefaults(ctx, diff.Key, value, valueDesc, cv.valueMerger.rightMapping, cv.tableMerger,
+				cv.tableMerger.rightSch, cv.sch, cv.rightDefaults, cv.valueMerger.syncPool, true)
+		}
+		return val.NewTuple(cv.valueMerger.syncPool, remapTuple(value, valueDesc, cv.valueMerger.rightMapping)...), nil
+	case tree.DiffOpLeftAdd, tree.DiffOpLeftModify:
+		if cv.mergeInfo.LeftNeedsRewrite {
+			return remapTupleWithColumnDefaults(ctx, diff.Key, value, valueDesc, cv.valueMerger.leftMapping, cv.tableMerger,
+				cv.tableMerger.leftSch, cv.sch, cv.leftDefaults, cv.valueMerger.syncPool, false)
+		}
+		return val.NewTuple(cv.valueMerger.syncPool, remapTuple(value, valueDesc, cv.valueMerger.leftMapping)...), nil
+	default:
+		return value, nil
+	}
+}
+
 // insertArtifact records a check constraint violation, as described by |meta|, for the row with the specified
 // |key| and |value|.
 func (cv checkValidator) insertArtifact(ctx context.Context, key, value val.Tuple, meta CheckCVMeta) error {
@@ -1021,6 +1074,12 @@ func newConflictMerger(ctx context.Context, tm *TableMerger, ae *prolly.Artifact
 	m := prolly.ConflictMetadata{
 		BaseRootIsh: baseHash,
 	}
+	if tm.ancName != tm.name {
+		m.BaseTable = tm.ancName.Name
+	}
+	if tm.rightName != tm.name {
+		m.TheirTable = tm.rightName.Name
+	}
 	meta, err := json.Marshal(m)
 	if err != nil {
 		return nil, err
@@ -1073,6 +1132,23 @@ func newPrimaryMerger(leftEditor *prolly.MutableMap, tableMerger *TableMerger, v
 	}, nil
 }
 
+// resolveUnionConflict resolves a conflicting |diff| for a table merged with doltdb.MergeStrategyUnion. A row deleted
+// on one side and modified on the other keeps its modification, and a row modified differently on both sides keeps
+// the left side's version. Diffs that aren't conflicts are returned unchanged.
+func resolveUnionConflict(diff tree.ThreeWayDiff) tree.ThreeWayDiff {
+	switch diff.Op {
+	case tree.DiffOpDivergentDeleteConflict:
+		if diff.Left == nil {
+			// The left side deleted the row, so it is added back with the right side's modification.
+			return tree.ThreeWayDiff{Op: tree.DiffOpRightAdd, Key: diff.Key, Right: diff.Right}
+		}
+		diff.Op = tree.DiffOpLeftModify
+	case tree.DiffOpDivergentModifyConflict:
+		diff.Op = tree.DiffOpLeftModify
+	}
+	return diff
+}
+
 // merge applies the specified |diff| to the primary index of this primaryMerger. The given |sourceSch|
 // specifies the schema of the source of the diff, which is used to map the diff to the post-merge
 // schema. |sourceSch| may be nil when no mapping from the source schema is needed (i.e. DiffOpRightDelete,
@@ -1622,6 +1698,15 @@ type valueMerger struct {
 	syncPool                               pool.BuffPool
 	keyless                                bool
 	ns                                     tree.NodeStore
+	// rowLevel is set when a row modified on both sides of the merge is a conflict, even if the sides modified
+	// different columns.
+	rowLevel bool
+	// textCols are the columns merged line by line when both sides modify them, and metadataCols the columns that
+	// take the greater value when both sides modify them. See definitionColumns.
+	textCols, metadataCols map[int]bool
+	// columnStrategies are the strategies resolving the columns modified differently on both sides, see
+	// resolveColumnConflict.
+	columnStrategies map[int]doltdb.ColumnMergeStrategy
 }
 
 func newValueMerger(merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -1724,6 +1809,20 @@ func (m *valueMerger) tryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 		return nil, true, nil
 	}
 
+	if m.rowLevel && base != nil {
+		leftModified, err := m.rowModified(ctx, left, m.leftVD, m.leftMapping, base)
+		if err != nil {
+			return nil, false, err
+		}
+		rightModified, err := m.rowModified(ctx, right, m.rightVD, m.rightMapping, base)
+		if err != nil {
+			return nil, false, err
+		}
+		if leftModified && rightModified {
+			return nil, false, nil
+		}
+	}
+
 	mergedValues := make([][]byte, m.numCols)
 	for i := 0; i < m.numCols; i++ {
 		v, isConflict, err := m.processColumn(ctx, i, left, right, base)
@@ -1739,6 +1838,37 @@ func (m *valueMerger) tryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 	return val.NewTuple(m.syncPool, mergedValues...), true, nil
 }
 
+// rowModified returns whether |row|, from one side of the merge, has a different value than |base| for any column
+// of the merged schema. Values are converted to the merged schema's types before they are compared, so a value whose
+// representation changed only because of a schema change is not a modification. Columns that only exist on one of
+// the two rows, and generated columns, are skipped.
+func (m *valueMerger) rowModified(ctx *sql.Context, row val.Tuple, vd val.TupleDesc, mapping val.OrdinalMapping, base val.Tuple) (bool, error) {
+	for i := 0; i < m.numCols; i++ {
+		if m.resultSchema.GetNonPKCols().GetByIndex(i).Generated != "" {
+			continue
+		}
+		col, colIdx, colExists := getColumn(&row, &mapping, i)
+		baseCol, baseColIdx, baseColExists := getColumn(&base, &m.baseMapping, i)
+		if !colExists || !baseColExists {
+			continue
+		}
+
+		var err error
+		col, err = convert(ctx, vd, m.resultVD, m.resultSchema, colIdx, i, row, col, m.ns)
+		if err != nil {
+			return false, err
+		}
+		baseCol, err = convert(ctx, m.baseVD, m.resultVD, m.resultSchema, baseColIdx, i, base, baseCol, m.ns)
+		if err != nil {
+			return false, err
+		}
+		if !isEqual(m.resultVD.Comparator(), i, col, baseCol, m.resultVD.Types[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // processBaseColumn returns whether column |i| of the base schema,
 // if removed on one side, causes a conflict when merged with the other side.
 func (m *valueMerger) processBaseColumn(ctx context.Context, i int, left, right, base val.Tuple) (conflict bool, err error) {
@@ -1893,8 +2023,8 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftCol, false, nil
 		}
 
-		// conflicting inserts
-		return nil, true, nil
+		// conflicting inserts, unless the column has a strategy resolving them
+		return m.resolveColumnConflict(ctx, i, nil, leftCol, rightCol)
 	}
 
 	// We can now assume that both left and right contain byte-level changes to an existing column.
@@ -1957,6 +2087,18 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		if generatedColumn {
 			return leftCol, false, nil
 		}
+		// the metadata of view, trigger and procedure definitions doesn't conflict, take the greater value so
+		// that the result doesn't depend on the merge direction
+		if m.metadataCols[i] {
+			if m.resultVD.Comparator().CompareValues(i, leftCol, rightCol, resultType) > 0 {
+				return leftCol, false, nil
+			}
+			return rightCol, false, nil
+		}
+		// view, trigger and procedure definitions are merged line by line
+		if m.textCols[i] {
+			return m.mergeTextColumn(ctx, i, baseCol, leftCol, rightCol)
+		}
 		// concurrent modification
 		// if the result type is JSON, we can attempt to merge the JSON changes.
 		dontMergeJsonVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_dont_merge_json")
@@ -1970,8 +2112,8 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		if _, ok := sqlType.(types.JsonType); ok && !disallowJsonMerge {
 			return m.mergeJSONAddr(ctx, baseCol, leftCol, rightCol)
 		}
-		// otherwise, this is a conflict.
-		return nil, true, nil
+		// otherwise, this is a conflict, unless the column has a strategy resolving it.
+		return m.resolveColumnConflict(ctx, i, baseCol, leftCol, rightCol)
 	case leftModified:
 		return leftCol, false, nil
 	default:
@@ -16,6 +16,7 @@ package merge
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -52,10 +53,23 @@ type MergeOpts struct {
 	// dolt_verify_constraints() stored procedure to allow callers to verify constraints for a
 	// subset of tables.
 	RecordViolationsForTables map[doltdb.TableName]struct{}
+	// ApplyMergeStrategies is set to merge tables with the strategies configured for them in the
+	// dolt_merge_strategies table of the left side of the merge, and to resolve columns modified
+	// differently on both sides with the strategies configured for them in its
+	// dolt_column_merge_strategies table. When this option is not set, all tables are merged with
+	// doltdb.MergeStrategyManual.
+	ApplyMergeStrategies bool
+	// Strategy, when set, is the strategy used to merge every table, overriding the strategies configured in the
+	// dolt_merge_strategies table.
+	Strategy doltdb.MergeStrategy
 }
 
 type TableMerger struct {
 	name doltdb.TableName
+	// rightName and ancName are the names of the table in the right root and the ancestor, which differ from name
+	// when the table was renamed.
+	rightName doltdb.TableName
+	ancName   doltdb.TableName
 
 	leftTbl  *doltdb.Table
 	rightTbl *doltdb.Table
@@ -76,6 +90,12 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// strategy is the strategy used to merge this table's rows.
+	strategy doltdb.MergeStrategy
+	// columnStrategies are the strategies used to resolve this table's columns modified differently
+	// on both sides, by lower case column name.
+	columnStrategies map[string]doltdb.ColumnMergeStrategy
 }
 
 func (tm TableMerger) tableHashes() (left, right, anc hash.Hash, err error) {
@@ -107,6 +127,14 @@ type RootMerger struct {
 
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
+
+	// strategies caches the dolt_merge_strategies patterns of |left|, by schema name.
+	strategies map[string]doltdb.MergeStrategyPatterns
+	// columnStrategies caches the dolt_column_merge_strategies of |left|, by schema name.
+	columnStrategies map[string]doltdb.ColumnMergeStrategies
+
+	// sources holds the names of the tables renamed on either side of the merge, by their merged name.
+	sources map[doltdb.TableName]tableSources
 }
 
 // NewMerger creates a new merger utility object.
@@ -157,6 +185,11 @@ func (rm *RootMerger) MergeTable(
 		return nil, nil, err
 	}
 
+	if tm.strategy == doltdb.MergeStrategyOurs || tm.strategy == doltdb.MergeStrategyTheirs {
+		tbl, stats, err := tm.mergeWholeTable(tm.strategy)
+		return &MergedTable{table: tbl}, stats, err
+	}
+
 	// short-circuit here if we can
 	finished, stats, err := rm.maybeShortCircuit(ctx, tm, mergeOpts)
 	if finished != nil || stats != nil || err != nil {
@@ -204,6 +237,27 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
+	strategy := doltdb.MergeStrategyManual
+	if mergeOpts.Strategy != "" {
+		strategy = mergeOpts.Strategy
+	} else if mergeOpts.ApplyMergeStrategies {
+		var err error
+		if strategy, err = rm.mergeStrategy(ctx, tblName); err != nil {
+			return nil, err
+		}
+	}
+
+	var columnStrategies map[string]doltdb.ColumnMergeStrategy
+	if strings.EqualFold(tblName.Name, doltdb.SequencesTableName) {
+		// sequences are merged this way by every merge, including those of concurrent transactions
+		columnStrategies = sequencesColumnStrategies
+	} else if mergeOpts.ApplyMergeStrategies {
+		var err error
+		if columnStrategies, err = rm.columnMergeStrategies(ctx, tblName); err != nil {
+			return nil, err
+		}
+	}
+
 	tm := TableMerger{
 		name:             tblName,
 		rightSrc:         rm.rightSrc,
@@ -211,12 +265,16 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 		vrw:              rm.vrw,
 		ns:               rm.ns,
 		recordViolations: recordViolations,
+		strategy:         strategy,
+		columnStrategies: columnStrategies,
 	}
 
 	var err error
 	var leftSideTableExists, rightSideTableExists, ancTableExists bool
+	names := rm.sourceNames(tblName)
+	tm.rightName, tm.ancName = names.right, names.anc
 
-	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, tblName)
+	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, names.left)
 	if err != nil {
 		return nil, err
 	}
@@ -226,7 +284,7 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
-	tm.rightTbl, rightSideTableExists, err = rm.right.GetTable(ctx, tblName)
+	tm.rightTbl, rightSideTableExists, err = rm.right.GetTable(ctx, names.right)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +316,7 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 		}
 	}
 
-	tm.ancTbl, ancTableExists, err = rm.anc.GetTable(ctx, tblName)
+	tm.ancTbl, ancTableExists, err = rm.anc.GetTable(ctx, names.anc)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +336,68 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 	return &tm, nil
 }
 
+// mergeStrategy returns the strategy configured for |tblName| in the dolt_merge_strategies table of the left side of
+// the merge.
+func (rm *RootMerger) mergeStrategy(ctx context.Context, tblName doltdb.TableName) (doltdb.MergeStrategy, error) {
+	patterns, ok := rm.strategies[tblName.Schema]
+	if !ok {
+		var err error
+		patterns, err = doltdb.GetMergeStrategyPatterns(ctx, rm.left, tblName.Schema)
+		if err != nil {
+			return "", err
+		}
+		if rm.strategies == nil {
+			rm.strategies = make(map[string]doltdb.MergeStrategyPatterns)
+		}
+		rm.strategies[tblName.Schema] = patterns
+	}
+	return patterns.StrategyForTable(tblName)
+}
+
+// columnMergeStrategies returns the strategies configured for the columns of |tblName| in the
+// dolt_column_merge_strategies table of the left side of the merge.
+func (rm *RootMerger) columnMergeStrategies(ctx context.Context, tblName doltdb.TableName) (map[string]doltdb.ColumnMergeStrategy, error) {
+	strategies, ok := rm.columnStrategies[tblName.Schema]
+	if !ok {
+		var err error
+		strategies, err = doltdb.GetColumnMergeStrategies(ctx, rm.left, tblName.Schema)
+		if err != nil {
+			return nil, err
+		}
+		if rm.columnStrategies == nil {
+			rm.columnStrategies = make(map[string]doltdb.ColumnMergeStrategies)
+		}
+		rm.columnStrategies[tblName.Schema] = strategies
+	}
+	return strategies.ForTable(tblName), nil
+}
+
+// mergeWholeTable merges the table by taking one side's version of it in its entirety, as the ours and theirs merge
+// strategies do. A nil table is returned if the chosen side doesn't have the table.
+func (tm *TableMerger) mergeWholeTable(strategy doltdb.MergeStrategy) (*doltdb.Table, *MergeStats, error) {
+	if strategy == doltdb.MergeStrategyOurs {
+		if tm.leftTbl == nil {
+			return nil, &MergeStats{Operation: TableRemoved}, nil
+		}
+		return tm.leftTbl, &MergeStats{Operation: TableUnmodified}, nil
+	}
+
+	if tm.rightTbl == nil {
+		return nil, &MergeStats{Operation: TableRemoved}, nil
+	}
+	if tm.leftTbl == nil {
+		return tm.rightTbl, &MergeStats{Operation: TableAdded}, nil
+	}
+	leftHash, rightHash, _, err := tm.tableHashes()
+	if err != nil {
+		return nil, nil, err
+	}
+	if leftHash == rightHash {
+		return tm.leftTbl, &MergeStats{Operation: TableUnmodified}, nil
+	}
+	return tm.rightTbl, &MergeStats{Operation: TableModified}, nil
+}
+
 func (rm *RootMerger) maybeShortCircuit(ctx context.Context, tm *TableMerger, opts MergeOpts) (*doltdb.Table, *MergeStats, error) {
 	// If we need to re-verify all constraints as part of this merge, then we can't short
 	// circuit considering any tables, so return immediately
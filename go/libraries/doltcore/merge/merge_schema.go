@@ -17,6 +17,7 @@ package merge
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -41,12 +42,17 @@ const (
 	// DuplicateIndexColumnSet represent a schema conflict where multiple indexes cover the same set of columns, and
 	// we're unable to accurately match them up on each side of the merge, so the user has to manually resolve.
 	DuplicateIndexColumnSet
+	// ColumnAttributeCollision represents a schema conflict where both sides of the merge changed the same attribute
+	// of a column, such as its type or default value, in different ways.
+	ColumnAttributeCollision
 )
 
 var ErrUnmergeableNewColumn = errorkinds.NewKind("Unable to merge new column `%s` in table `%s` because it is not-nullable and has no default value, so existing rows can't be updated automatically. To complete this merge, either manually add this new column to the target branch of the merge and update any existing rows, or change the column's definition on the other branch of the merge so that it is nullable or has a default value.")
 
 var ErrDefaultCollationConflict = errorkinds.NewKind("Unable to merge table '%s', because its default collation setting has changed on both sides of the merge. Manually change the table's default collation setting on one of the sides of the merge and retry this merge.")
 
+var ErrZoneMapColumnsConflict = errorkinds.NewKind("Unable to merge table '%s', because its zone map columns have changed on both sides of the merge. Manually change the table's zone map columns on one of the sides of the merge and retry this merge.")
+
 type SchemaConflict struct {
 	TableName            doltdb.TableName
 	ColConflicts         []ColConflict
@@ -104,6 +110,8 @@ func (sc SchemaConflict) messages() (mm []string) {
 type ColConflict struct {
 	Kind         conflictKind
 	Ours, Theirs schema.Column
+	// Attribute is the column attribute changed on both sides of a ColumnAttributeCollision
+	Attribute string
 }
 
 func (c ColConflict) String() string {
@@ -112,6 +120,8 @@ func (c ColConflict) String() string {
 		return fmt.Sprintf("incompatible column types for column '%s': %s and %s", c.Ours.Name, c.Ours.TypeInfo, c.Theirs.TypeInfo)
 	case TagCollision:
 		return fmt.Sprintf("different column definitions for our column %s and their column %s", c.Ours.Name, c.Theirs.Name)
+	case ColumnAttributeCollision:
+		return fmt.Sprintf("different %s for our column %s and their column %s", c.Attribute, c.Ours.Name, c.Theirs.Name)
 	}
 	return ""
 }
@@ -208,6 +218,12 @@ func SchemaMerge(
 	if err != nil {
 		return nil, sc, mergeInfo, diffInfo, err
 	}
+	sch = mergeTargetNodeSize(ancSch, ourSch, theirSch, sch)
+	sch = mergeStorageLayout(ancSch, ourSch, theirSch, sch)
+	sch, err = mergeZoneMapColumns(tblName.Name, ancSch, ourSch, theirSch, sch)
+	if err != nil {
+		return nil, sc, mergeInfo, diffInfo, err
+	}
 
 	// TODO: Merge conflict should have blocked any primary key ordinal changes
 	err = sch.SetPkOrdinals(ourSch.GetPkOrdinals())
@@ -455,15 +471,44 @@ func mergeColumns(tblName string, format *storetypes.NomsBinFormat, ourCC, their
 			if anc != nil {
 				oursChanged := !anc.Equals(*ours)
 				theirsChanged := !anc.Equals(*theirs)
+				// attributes that don't affect column equality, like comments, are merged from both sides
+				merged, conflict := mergeColumnAttributes(*anc, *ours, *theirs)
+				if conflict != "" {
+					// this case is already handled in checkSchemaConflicts
+					continue
+				}
 				if oursChanged && theirsChanged {
 					diffInfo.LeftSchemaChange = true
 					diffInfo.RightSchemaChange = true
 					// If both columns changed in the same way, the modifications converge, so accept the column.
-					// If not, don't report a conflict, since this case is already handled in checkForColumnConflicts.
 					if ours.Equals(*theirs) {
-						mergedColumns = append(mergedColumns, *theirs)
+						mergedColumns = append(mergedColumns, merged)
+						continue
+					}
+					diffInfo.LeftAndRightSchemasDiffer = true
+					// Otherwise the sides changed different attributes of the column. The rows of a side need
+					// to be rewritten if the other side changed the column's type.
+					mergeInfo.RightNeedsRewrite = true
+					typeFromTheirs := !ours.TypeInfo.Equals(merged.TypeInfo)
+					from, to := *theirs, *ours
+					if typeFromTheirs {
+						from, to = *ours, *theirs
+					}
+					compatibilityInfo := compatChecker.IsTypeChangeCompatible(from.TypeInfo, to.TypeInfo)
+					if compatibilityInfo.invalidateSecondaryIndexes {
+						mergeInfo.InvalidateSecondaryIndexes = true
+					}
+					if compatibilityInfo.rewriteRows && typeFromTheirs {
+						mergeInfo.LeftNeedsRewrite = true
+					}
+					if compatibilityInfo.compatible {
+						mergedColumns = append(mergedColumns, merged)
 					} else {
-						diffInfo.LeftAndRightSchemasDiffer = true
+						conflicts = append(conflicts, ColConflict{
+							Kind:   NameCollision,
+							Ours:   *ours,
+							Theirs: *theirs,
+						})
 					}
 				} else if theirsChanged {
 					diffInfo.LeftAndRightSchemasDiffer = true
@@ -478,7 +523,7 @@ func mergeColumns(tblName string, format *storetypes.NomsBinFormat, ourCC, their
 						diffInfo.RightSchemaChange = true
 					}
 					if compatibilityInfo.compatible {
-						mergedColumns = append(mergedColumns, *theirs)
+						mergedColumns = append(mergedColumns, merged)
 					} else {
 						conflicts = append(conflicts, ColConflict{
 							Kind:   NameCollision,
@@ -500,7 +545,7 @@ func mergeColumns(tblName string, format *storetypes.NomsBinFormat, ourCC, their
 						diffInfo.LeftSchemaChange = true
 					}
 					if compatibilityInfo.compatible {
-						mergedColumns = append(mergedColumns, *ours)
+						mergedColumns = append(mergedColumns, merged)
 					} else {
 						conflicts = append(conflicts, ColConflict{
 							Kind:   NameCollision,
@@ -510,7 +555,7 @@ func mergeColumns(tblName string, format *storetypes.NomsBinFormat, ourCC, their
 					}
 				} else {
 					// if neither side changed, just use ours
-					mergedColumns = append(mergedColumns, *ours)
+					mergedColumns = append(mergedColumns, merged)
 				}
 			} else {
 				// The column was added on both branches.
@@ -580,7 +625,7 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 		if ours != nil {
 			// If the column is identical on both sides, no need to check any more conflict cases,
 			// just move on to the next column
-			if theirs != nil && theirs.Equals(*ours) {
+			if theirs != nil && theirs.Equals(*ours) && !attributesConflict(anc, ours, theirs) {
 				continue
 			}
 
@@ -597,12 +642,13 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 				}
 			case theirs != nil && anc != nil:
 				// Column exists on their side and in ancestor
-				// If the column differs from the ancestor on both sides, then we have a conflict
-				if !anc.Equals(*ours) && !anc.Equals(*theirs) {
+				// If both sides changed the same attribute of the column in different ways, then we have a conflict
+				if _, attr := mergeColumnAttributes(*anc, *ours, *theirs); attr != "" {
 					conflicts = append(conflicts, ColConflict{
-						Kind:   TagCollision,
-						Ours:   *ours,
-						Theirs: *theirs,
+						Kind:      ColumnAttributeCollision,
+						Ours:      *ours,
+						Theirs:    *theirs,
+						Attribute: attr,
 					})
 				}
 			case theirs != nil && anc == nil:
@@ -649,6 +695,83 @@ func checkSchemaConflicts(columnMappings columnMappings) ([]ColConflict, error)
 	return conflicts, nil
 }
 
+// mergeColumnAttributes performs a three-way merge of a column that exists on both sides of a merge and in their
+// ancestor. Each attribute of the column is merged independently: an attribute changed on only one side takes that
+// side's value. If both sides changed the same attribute in different ways, the merged column is invalid and the
+// name of the first such attribute is returned.
+func mergeColumnAttributes(anc, ours, theirs schema.Column) (schema.Column, string) {
+	merged := ours
+	var conflict string
+	mergeAttr := func(name string, equal func(a, b schema.Column) bool, take func(dest *schema.Column, src schema.Column)) {
+		if equal(anc, ours) {
+			take(&merged, theirs)
+		} else if !equal(anc, theirs) && !equal(ours, theirs) && conflict == "" {
+			conflict = name
+		}
+	}
+
+	mergeAttr("tags", func(a, b schema.Column) bool {
+		return a.Tag == b.Tag
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Tag = src.Tag
+	})
+	mergeAttr("primary key membership", func(a, b schema.Column) bool {
+		return a.IsPartOfPK == b.IsPartOfPK
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.IsPartOfPK = src.IsPartOfPK
+	})
+	mergeAttr("names", func(a, b schema.Column) bool {
+		return a.Name == b.Name
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Name = src.Name
+	})
+	mergeAttr("types", func(a, b schema.Column) bool {
+		return a.Kind == b.Kind && a.TypeInfo.Equals(b.TypeInfo)
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Kind, dest.TypeInfo = src.Kind, src.TypeInfo
+	})
+	mergeAttr("default values", func(a, b schema.Column) bool {
+		return a.Default == b.Default
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Default = src.Default
+	})
+	mergeAttr("constraints", func(a, b schema.Column) bool {
+		return schema.ColConstraintsAreEqual(a.Constraints, b.Constraints)
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Constraints = src.Constraints
+	})
+	mergeAttr("generated expressions", func(a, b schema.Column) bool {
+		return a.Generated == b.Generated && a.Virtual == b.Virtual
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Generated, dest.Virtual = src.Generated, src.Virtual
+	})
+	mergeAttr("on update expressions", func(a, b schema.Column) bool {
+		return a.OnUpdate == b.OnUpdate
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.OnUpdate = src.OnUpdate
+	})
+	mergeAttr("auto increment settings", func(a, b schema.Column) bool {
+		return a.AutoIncrement == b.AutoIncrement
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.AutoIncrement = src.AutoIncrement
+	})
+	mergeAttr("comments", func(a, b schema.Column) bool {
+		return a.Comment == b.Comment
+	}, func(dest *schema.Column, src schema.Column) {
+		dest.Comment = src.Comment
+	})
+	return merged, conflict
+}
+
+// attributesConflict returns whether both sides of a merge changed the same attribute of a column in different ways.
+func attributesConflict(anc, ours, theirs *schema.Column) bool {
+	if anc == nil || ours == nil || theirs == nil {
+		return false
+	}
+	_, attr := mergeColumnAttributes(*anc, *ours, *theirs)
+	return attr != ""
+}
+
 // columnMapping describes the mapping for a column being merged between the two sides of the merge as well as the ancestor.
 type columnMapping struct {
 	anc    *schema.Column
@@ -1196,6 +1319,64 @@ func mergeTableCollation(_ context.Context, tblName string, ancSch, ourSch, thei
 	return mergedSch, nil
 }
 
+// mergeTargetNodeSize sets the target node size of |mergedSch| to the size of the side of the merge which changed it
+// from |ancSch|, and returns it. If both sides changed it to different sizes, the smaller size is used, so that the
+// merged table doesn't depend on the direction of the merge.
+func mergeTargetNodeSize(ancSch, ourSch, theirSch, mergedSch schema.Schema) schema.Schema {
+	ourSize, theirSize := ourSch.GetTargetNodeSize(), theirSch.GetTargetNodeSize()
+	ourSizeChanged := ancSch != nil && ancSch.GetTargetNodeSize() != ourSize
+	theirSizeChanged := ancSch != nil && ancSch.GetTargetNodeSize() != theirSize
+
+	mergedSch.SetTargetNodeSize(ourSize)
+	if theirSizeChanged && (!ourSizeChanged || tree.EffectiveTargetNodeSize(theirSize) < tree.EffectiveTargetNodeSize(ourSize)) {
+		mergedSch.SetTargetNodeSize(theirSize)
+	}
+	return mergedSch
+}
+
+// mergeStorageLayout sets the storage layout of |mergedSch| to the layout of the side of the merge which changed it
+// from |ancSch|, and returns it. Both sides can only change it to the same layout.
+func mergeStorageLayout(ancSch, ourSch, theirSch, mergedSch schema.Schema) schema.Schema {
+	ourColumnar := ourSch.GetStorageLayout() == schema.ColumnarStorageLayout
+	theirColumnar := theirSch.GetStorageLayout() == schema.ColumnarStorageLayout
+	columnar := ourColumnar
+	if ancSch != nil && (ancSch.GetStorageLayout() == schema.ColumnarStorageLayout) != theirColumnar {
+		columnar = theirColumnar
+	}
+	if columnar {
+		mergedSch.SetStorageLayout(schema.ColumnarStorageLayout)
+	} else if ourColumnar {
+		// an unspecified layout would keep the layout of our table
+		mergedSch.SetStorageLayout(schema.RowStorageLayout)
+	}
+	return mergedSch
+}
+
+// mergeZoneMapColumns sets the zone map columns of |mergedSch| to the columns of the side of the merge which changed
+// them from |ancSch|, and returns it. If both sides changed them to different columns, an error is returned.
+func mergeZoneMapColumns(tblName string, ancSch, ourSch, theirSch, mergedSch schema.Schema) (schema.Schema, error) {
+	ourCols, theirCols := ourSch.GetZoneMapColumns(), theirSch.GetZoneMapColumns()
+	ourColsChanged := ancSch != nil && !slices.Equal(ancSch.GetZoneMapColumns(), ourCols)
+	theirColsChanged := ancSch != nil && !slices.Equal(ancSch.GetZoneMapColumns(), theirCols)
+
+	if ourColsChanged && theirColsChanged && !slices.Equal(ourCols, theirCols) {
+		return nil, ErrZoneMapColumnsConflict.New(tblName)
+	}
+	cols := ourCols
+	if theirColsChanged {
+		cols = theirCols
+	}
+	// columns that were dropped by the merge drop out of the zone maps
+	tags := []uint64{}
+	for _, tag := range cols {
+		if _, ok := mergedSch.GetAllCols().GetByTag(tag); ok {
+			tags = append(tags, tag)
+		}
+	}
+	mergedSch.SetZoneMapColumns(tags)
+	return mergedSch, nil
+}
+
 // mergeChecks attempts to combine ourChks, theirChks, and ancChks into a single collection, or gathers the conflicts
 func mergeChecks(ctx context.Context, ourChks, theirChks, ancChks schema.CheckCollection) ([]schema.Check, []ChkConflict, error) {
 	// Handles modifications
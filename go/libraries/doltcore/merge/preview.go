@@ -0,0 +1,133 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// TablePreview describes what merging a commit would do to one table. The row
+// counts in Stats are relative to the table before the merge.
+type TablePreview struct {
+	Name  doltdb.TableName
+	Stats MergeStats
+}
+
+// PreviewMerge computes the result of merging |mergeCommit| into |headCommit|
+// without updating any working set or ref, and returns a TablePreview for each
+// table the merge would change or leave conflicts or constraint violations in.
+// It returns no previews if |headCommit| is up to date with |mergeCommit|.
+func PreviewMerge(ctx *sql.Context, headCommit, mergeCommit *doltdb.Commit, opts editor.Options) ([]TablePreview, error) {
+	canFF, err := headCommit.CanFastForwardTo(ctx, mergeCommit)
+	if err == doltdb.ErrUpToDate || err == doltdb.ErrIsAhead {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ourRoot, err := headCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mergedRoot doltdb.RootValue
+	var artifacts map[doltdb.TableName]*MergeStats
+	if canFF {
+		mergedRoot, err = mergeCommit.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result, err := MergeCommits(ctx, headCommit, mergeCommit, opts, "")
+		if err != nil {
+			return nil, err
+		}
+		mergedRoot, artifacts = result.Root, result.Stats
+	}
+
+	tblNames, err := doltdb.UnionTableNames(ctx, ourRoot, mergedRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []TablePreview
+	for _, name := range tblNames {
+		stats, err := previewTable(ctx, ourRoot, mergedRoot, name)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := artifacts[name]; ok {
+			stats.DataConflicts = s.DataConflicts
+			stats.SchemaConflicts = s.SchemaConflicts
+			stats.ConstraintViolations = s.ConstraintViolations
+		}
+		if stats.Operation == TableUnmodified && !stats.HasArtifacts() {
+			continue
+		}
+		previews = append(previews, TablePreview{Name: name, Stats: stats})
+	}
+	return previews, nil
+}
+
+// previewTable returns the row changes between the table |name| in |ourRoot| and in |mergedRoot|.
+func previewTable(ctx *sql.Context, ourRoot, mergedRoot doltdb.RootValue, name doltdb.TableName) (MergeStats, error) {
+	ourTbl, ourOk, err := ourRoot.GetTable(ctx, name)
+	if err != nil {
+		return MergeStats{}, err
+	}
+	mergedTbl, mergedOk, err := mergedRoot.GetTable(ctx, name)
+	if err != nil {
+		return MergeStats{}, err
+	}
+
+	switch {
+	case ourOk && mergedOk:
+		ourHash, err := ourTbl.HashOf()
+		if err != nil {
+			return MergeStats{}, err
+		}
+		mergedHash, err := mergedTbl.HashOf()
+		if err != nil {
+			return MergeStats{}, err
+		}
+		if ourHash == mergedHash {
+			return MergeStats{Operation: TableUnmodified}, nil
+		}
+		return calcTableMergeStats(ctx, ourTbl, mergedTbl)
+	case mergedOk:
+		n, err := mergedTbl.GetRowData(ctx)
+		if err != nil {
+			return MergeStats{}, err
+		}
+		cnt, err := n.Count()
+		if err != nil {
+			return MergeStats{}, err
+		}
+		return MergeStats{Operation: TableAdded, Adds: int(cnt)}, nil
+	default:
+		n, err := ourTbl.GetRowData(ctx)
+		if err != nil {
+			return MergeStats{}, err
+		}
+		cnt, err := n.Count()
+		if err != nil {
+			return MergeStats{}, err
+		}
+		return MergeStats{Operation: TableRemoved, Deletes: int(cnt)}, nil
+	}
+}
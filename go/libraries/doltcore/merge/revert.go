@@ -15,6 +15,7 @@
 package merge
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -32,17 +33,14 @@ import (
 // Theirs: HEAD~2
 //
 // The root is updated with the merged result, and this process is repeated for each commit given, in the order given.
-// Currently, we error on conflicts or constraint violations generated by the merge.
+// Currently, we error on conflicts or constraint violations generated by the merge. Use RevertCommit to revert a single
+// commit and surface its conflicts instead.
 func Revert(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, commits []*doltdb.Commit, opts editor.Options) (doltdb.RootValue, string, error) {
 	revertMessage := "Revert"
 
 	for _, cm := range commits {
-		if len(cm.DatasParents()) == 0 {
-			h, err := cm.HashOf()
-			if err != nil {
-				return nil, "", err
-			}
-			return nil, "", fmt.Errorf("cannot revert commit with no parents (%s)", h.String())
+		if err := checkRevertable(cm); err != nil {
+			return nil, "", err
 		}
 	}
 
@@ -50,32 +48,13 @@ func Revert(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, commits
 		if i > 0 {
 			revertMessage += " and"
 		}
-		baseRoot, err := baseCommit.GetRootValue(ctx)
-		if err != nil {
-			return nil, "", err
-		}
 		baseMeta, err := baseCommit.GetCommitMeta(ctx)
 		if err != nil {
 			return nil, "", err
 		}
 		revertMessage = fmt.Sprintf(`%s "%s"`, revertMessage, baseMeta.Description)
 
-		optCmt, err := ddb.ResolveParent(ctx, baseCommit, 0)
-		if err != nil {
-			return nil, "", err
-		}
-		parentCM, ok := optCmt.ToCommit()
-		if !ok {
-			return nil, "", doltdb.ErrGhostCommitEncountered
-		}
-
-		theirRoot, err := parentCM.GetRootValue(ctx)
-		if err != nil {
-			return nil, "", err
-		}
-
-		var result *Result
-		result, err = MergeRoots(ctx, root, theirRoot, baseRoot, parentCM, baseCommit, opts, MergeOpts{IsCherryPick: false})
+		result, err := revertCommit(ctx, ddb, root, baseCommit, opts)
 		if err != nil {
 			return nil, "", err
 		}
@@ -95,3 +74,66 @@ func Revert(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, commits
 
 	return root, revertMessage, nil
 }
+
+// RevertCommit reverts a single |commit| from |root| with the same three-way merge as Revert. Unlike Revert, conflicts
+// and constraint violations do not cause an error; they are recorded in the returned result's root, the same way a
+// merge records them, so that they can be resolved before the revert is committed. The commit message for the revert
+// is returned along with the result.
+func RevertCommit(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, commit *doltdb.Commit, opts editor.Options) (*Result, string, error) {
+	if err := checkRevertable(commit); err != nil {
+		return nil, "", err
+	}
+	msg, err := RevertCommitMessage(ctx, commit)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := revertCommit(ctx, ddb, root, commit, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, msg, nil
+}
+
+// RevertCommitMessage returns the commit message for a revert of the single commit |commit|.
+func RevertCommitMessage(ctx context.Context, commit *doltdb.Commit) (string, error) {
+	meta, err := commit.GetCommitMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`Revert "%s"`, meta.Description), nil
+}
+
+func checkRevertable(cm *doltdb.Commit) error {
+	if len(cm.DatasParents()) == 0 {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("cannot revert commit with no parents (%s)", h.String())
+	}
+	return nil
+}
+
+// revertCommit merges the parent of |baseCommit| into |root|, using |baseCommit| as the merge base.
+func revertCommit(ctx *sql.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, baseCommit *doltdb.Commit, opts editor.Options) (*Result, error) {
+	baseRoot, err := baseCommit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	optCmt, err := ddb.ResolveParent(ctx, baseCommit, 0)
+	if err != nil {
+		return nil, err
+	}
+	parentCM, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+
+	theirRoot, err := parentCM.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeRoots(ctx, root, theirRoot, baseRoot, parentCM, baseCommit, opts, MergeOpts{IsCherryPick: false})
+}
@@ -737,6 +737,31 @@ var columnDefaultTests = []schemaMergeTest{
 		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 19)"), row(1, 19)),
 		merged:   *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 19)"), row(1, 19)),
 	},
+	// both sides change different attributes of the same column
+	{
+		name:                "left side add default, right side add not null constraint",
+		ancestor:            *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)                    "), row(1, 1)),
+		left:                tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 42)         "), row(1, 1)),
+		right:               tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int NOT NULL)           "), row(1, 1), row(2, 2)),
+		merged:              *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int NOT NULL DEFAULT 42)"), row(1, 1), row(2, 2)),
+		skipOldFmt:          true,
+		skipFlipOnOldFormat: true,
+	},
+	{
+		name:       "left side add default, right side widen type",
+		ancestor:   *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a varchar(10))                "), row(1, "a")),
+		left:       tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a varchar(10) DEFAULT 'x')    "), row(1, "a"), row(2, "x")),
+		right:      tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a varchar(20))                "), row(1, "a"), row(3, "b")),
+		merged:     *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a varchar(20) DEFAULT 'x')   "), row(1, "a"), row(2, "x"), row(3, "b")),
+		skipOldFmt: true,
+	},
+	{
+		name:     "divergent defaults",
+		ancestor: *tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int)           ")),
+		left:     tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 19)")),
+		right:    tbl(sch("CREATE TABLE t (id int PRIMARY KEY, a int DEFAULT 17)")),
+		conflict: true,
+	},
 }
 
 var nullabilityTests = []schemaMergeTest{
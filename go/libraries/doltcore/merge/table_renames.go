@@ -0,0 +1,208 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+var ErrTableRenamedDifferently = goerrors.NewKind("conflict: table '%s' was renamed to '%s' in one commit and to '%s' in the other")
+
+// tableSources are the names a merged table has on each side of a merge and in the ancestor. They differ when the
+// table was renamed on one or both sides.
+type tableSources struct {
+	left, right, anc doltdb.TableName
+}
+
+// resolveTableRenames detects the tables renamed on either side of the merge, and records the names that each renamed
+// table is merged from. The merged table takes its new name. The returned names are the old names of the renamed
+// tables, which must not be merged on their own, and must be removed from the left side's root if it still has them.
+//
+// A rename is only honored when the other side still has the table under its old name (or renamed it the same way),
+// and doesn't have an unrelated table with the new name. Otherwise the tables are merged by name, as before.
+func (rm *RootMerger) resolveTableRenames(ctx context.Context) ([]doltdb.TableName, error) {
+	leftRenames, err := detectTableRenames(ctx, rm.anc, rm.left)
+	if err != nil {
+		return nil, err
+	}
+	rightRenames, err := detectTableRenames(ctx, rm.anc, rm.right)
+	if err != nil {
+		return nil, err
+	}
+	if len(leftRenames) == 0 && len(rightRenames) == 0 {
+		return nil, nil
+	}
+
+	rm.sources = make(map[doltdb.TableName]tableSources)
+	var oldNames []doltdb.TableName
+	for oldName, newName := range leftRenames {
+		if rightName, ok := rightRenames[oldName]; ok {
+			if rightName != newName {
+				return nil, ErrTableRenamedDifferently.New(oldName, newName, rightName)
+			}
+			rm.sources[newName] = tableSources{left: newName, right: newName, anc: oldName}
+			oldNames = append(oldNames, oldName)
+			continue
+		}
+		ok, err := canMergeRename(ctx, rm.right, oldName, newName)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rm.sources[newName] = tableSources{left: newName, right: oldName, anc: oldName}
+			oldNames = append(oldNames, oldName)
+		}
+	}
+	for oldName, newName := range rightRenames {
+		if _, ok := leftRenames[oldName]; ok {
+			continue
+		}
+		ok, err := canMergeRename(ctx, rm.left, oldName, newName)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rm.sources[newName] = tableSources{left: oldName, right: newName, anc: oldName}
+			oldNames = append(oldNames, oldName)
+		}
+	}
+	return oldNames, nil
+}
+
+// sourceNames returns the names that the merged table |tblName| has on each side of the merge and in the ancestor.
+func (rm *RootMerger) sourceNames(tblName doltdb.TableName) tableSources {
+	if src, ok := rm.sources[tblName]; ok {
+		return src
+	}
+	return tableSources{left: tblName, right: tblName, anc: tblName}
+}
+
+// canMergeRename returns whether a rename from |oldName| to |newName| on one side of a merge can be merged with
+// |other|, the other side, which must still have the table under |oldName| and must not have a table named |newName|.
+func canMergeRename(ctx context.Context, other doltdb.RootValue, oldName, newName doltdb.TableName) (bool, error) {
+	hasOld, err := other.HasTable(ctx, oldName)
+	if err != nil || !hasOld {
+		return false, err
+	}
+	hasNew, err := other.HasTable(ctx, newName)
+	if err != nil {
+		return false, err
+	}
+	return !hasNew, nil
+}
+
+// detectTableRenames returns the tables of |anc| that were renamed in |root|, mapped to their new names. A table was
+// renamed when its name is missing from |root|, and exactly one table added in |root| has the same identity. Column
+// tags survive renames, so a table's identity is the tags of its primary key, or for keyless tables, of any of its
+// columns. Full-Text tables are never matched, since they are rebuilt from their parent tables.
+func detectTableRenames(ctx context.Context, anc, root doltdb.RootValue) (map[doltdb.TableName]doltdb.TableName, error) {
+	ancNames, err := doltdb.UnionTableNames(ctx, anc)
+	if err != nil {
+		return nil, err
+	}
+	names, err := doltdb.UnionTableNames(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := tableSchemasNotIn(ctx, anc, ancNames, names)
+	if err != nil || len(removed) == 0 {
+		return nil, err
+	}
+	added, err := tableSchemasNotIn(ctx, root, names, ancNames)
+	if err != nil || len(added) == 0 {
+		return nil, err
+	}
+
+	matches := make(map[doltdb.TableName][]doltdb.TableName)
+	matchedBy := make(map[doltdb.TableName]int)
+	for oldName, oldSch := range removed {
+		for newName, newSch := range added {
+			if oldName.Schema == newName.Schema && sameTableIdentity(oldSch, newSch) {
+				matches[oldName] = append(matches[oldName], newName)
+				matchedBy[newName]++
+			}
+		}
+	}
+
+	renames := make(map[doltdb.TableName]doltdb.TableName)
+	for oldName, newNames := range matches {
+		if len(newNames) == 1 && matchedBy[newNames[0]] == 1 {
+			renames[oldName] = newNames[0]
+		}
+	}
+	return renames, nil
+}
+
+// tableSchemasNotIn loads the schemas of the tables of |root| named in |names| that are not named in |others|.
+func tableSchemasNotIn(ctx context.Context, root doltdb.RootValue, names, others []doltdb.TableName) (map[doltdb.TableName]schema.Schema, error) {
+	exclude := make(map[doltdb.TableName]struct{}, len(others))
+	for _, name := range others {
+		exclude[name] = struct{}{}
+	}
+
+	schemas := make(map[doltdb.TableName]schema.Schema)
+	for _, name := range names {
+		if _, ok := exclude[name]; ok || doltdb.IsFullTextTable(name.Name) {
+			continue
+		}
+		tbl, ok, err := root.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if sch.Indexes().ContainsFullTextIndex() {
+			continue
+		}
+		schemas[name] = sch
+	}
+	return schemas, nil
+}
+
+// sameTableIdentity returns whether |a| and |b| are schemas of the same table, which may have been renamed or had
+// other columns added, dropped or renamed.
+func sameTableIdentity(a, b schema.Schema) bool {
+	aPks, bPks := a.GetPKCols().Tags, b.GetPKCols().Tags
+	if schema.IsKeyless(a) != schema.IsKeyless(b) {
+		return false
+	}
+	if !schema.IsKeyless(a) {
+		if len(aPks) != len(bPks) {
+			return false
+		}
+		for _, tag := range aPks {
+			if _, ok := b.GetPKCols().GetByTag(tag); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	for _, tag := range a.GetAllCols().Tags {
+		if _, ok := b.GetAllCols().GetByTag(tag); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -77,80 +77,85 @@ func GetForeignKeyViolations(ctx context.Context, newRoot, baseRoot doltdb.RootV
 		if !foreignKey.IsResolved() || (tables.Size() != 0 && !tables.Contains(foreignKey.TableName)) {
 			continue
 		}
-
-		err = receiver.StartFK(ctx, foreignKey)
+		err = getForeignKeyViolations(ctx, foreignKey, newRoot, baseRoot, receiver)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		postParent, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.ReferencedTableName, foreignKey.ReferencedTableIndex, newRoot)
-		if err != nil {
+// getForeignKeyViolations passes the violations of |foreignKey| in |newRoot| to |receiver|, based on the diff between
+// |newRoot| and |baseRoot|.
+func getForeignKeyViolations(ctx context.Context, foreignKey doltdb.ForeignKey, newRoot, baseRoot doltdb.RootValue, receiver FKViolationReceiver) error {
+	err := receiver.StartFK(ctx, foreignKey)
+	if err != nil {
+		return err
+	}
+
+	postParent, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.ReferencedTableName, foreignKey.ReferencedTableIndex, newRoot)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
+			foreignKey.Name, foreignKey.ReferencedTableIndex, foreignKey.ReferencedTableName)
+	}
+
+	postChild, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.TableName, foreignKey.TableIndex, newRoot)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
+			foreignKey.Name, foreignKey.TableIndex, foreignKey.TableName)
+	}
+
+	preParent, _, err := newConstraintViolationsLoadedTable(ctx, foreignKey.ReferencedTableName, foreignKey.ReferencedTableIndex, baseRoot)
+	if err != nil {
+		if err != doltdb.ErrTableNotFound {
 			return err
 		}
-		if !ok {
-			return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
-				foreignKey.Name, foreignKey.ReferencedTableIndex, foreignKey.ReferencedTableName)
+		// Parent does not exist in the ancestor so we use an empty map
+		emptyIdx, err := durable.NewEmptyIndex(ctx, postParent.Table.ValueReadWriter(), postParent.Table.NodeStore(), postParent.Schema, false)
+		if err != nil {
+			return err
 		}
-
-		postChild, ok, err := newConstraintViolationsLoadedTable(ctx, foreignKey.TableName, foreignKey.TableIndex, newRoot)
+		err = parentFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postParent, postChild, emptyIdx, receiver)
 		if err != nil {
 			return err
 		}
-		if !ok {
-			return fmt.Errorf("foreign key %s should have index %s on table %s but it cannot be found",
-				foreignKey.Name, foreignKey.TableIndex, foreignKey.TableName)
+	} else {
+		// Parent exists in the ancestor
+		err = parentFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, preParent, postParent, postChild, preParent.RowData, receiver)
+		if err != nil {
+			return err
 		}
+	}
 
-		preParent, _, err := newConstraintViolationsLoadedTable(ctx, foreignKey.ReferencedTableName, foreignKey.ReferencedTableIndex, baseRoot)
+	preChild, _, err := newConstraintViolationsLoadedTable(ctx, foreignKey.TableName, foreignKey.TableIndex, baseRoot)
+	if err != nil {
+		if err != doltdb.ErrTableNotFound {
+			return err
+		}
+		// Child does not exist in the ancestor so we use an empty map
+		emptyIdx, err := durable.NewEmptyIndex(ctx, postChild.Table.ValueReadWriter(), postChild.Table.NodeStore(), postChild.Schema, false)
 		if err != nil {
-			if err != doltdb.ErrTableNotFound {
-				return err
-			}
-			// Parent does not exist in the ancestor so we use an empty map
-			emptyIdx, err := durable.NewEmptyIndex(ctx, postParent.Table.ValueReadWriter(), postParent.Table.NodeStore(), postParent.Schema, false)
-			if err != nil {
-				return err
-			}
-			err = parentFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postParent, postChild, emptyIdx, receiver)
-			if err != nil {
-				return err
-			}
-		} else {
-			// Parent exists in the ancestor
-			err = parentFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, preParent, postParent, postChild, preParent.RowData, receiver)
-			if err != nil {
-				return err
-			}
+			return err
 		}
 
-		preChild, _, err := newConstraintViolationsLoadedTable(ctx, foreignKey.TableName, foreignKey.TableIndex, baseRoot)
+		err = childFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postChild, postChild, emptyIdx, receiver)
 		if err != nil {
-			if err != doltdb.ErrTableNotFound {
-				return err
-			}
-			// Child does not exist in the ancestor so we use an empty map
-			emptyIdx, err := durable.NewEmptyIndex(ctx, postChild.Table.ValueReadWriter(), postChild.Table.NodeStore(), postChild.Schema, false)
-			if err != nil {
-				return err
-			}
-
-			err = childFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postChild, postChild, emptyIdx, receiver)
-			if err != nil {
-				return err
-			}
-		} else {
-			err = childFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postChild, preChild, preChild.RowData, receiver)
-			if err != nil {
-				return err
-			}
+			return err
 		}
-
-		err = receiver.EndCurrFK(ctx)
+	} else {
+		err = childFkConstraintViolations(ctx, baseRoot.VRW(), foreignKey, postParent, postChild, preChild, preChild.RowData, receiver)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+
+	return receiver.EndCurrFK(ctx)
 }
 
 // AddForeignKeyViolations adds foreign key constraint violations to each table.
@@ -0,0 +1,628 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// maxForeignKeyCascadeDepth bounds the number of passes made when resolving foreign key referential actions, matching
+// the depth limit MySQL places on cascading foreign key operations.
+const maxForeignKeyCascadeDepth = 15
+
+// ApplyForeignKeyReferentialActions resolves foreign key violations in |newRoot| using the ON DELETE and ON UPDATE
+// referential actions of each foreign key, as if the changes that produced |newRoot| from |baseRoot| had been made
+// through SQL. A child row whose parent row was removed is deleted (CASCADE) or has its referencing columns set to
+// NULL (SET NULL). A child row whose parent row had its referenced columns changed takes on the new values (CASCADE)
+// or has its referencing columns set to NULL (SET NULL). Violations of foreign keys with RESTRICT or NO ACTION
+// semantics, foreign keys that did not exist in |baseRoot|, and violations that can't be traced to a parent row in
+// |baseRoot| are left in place to be recorded by AddForeignKeyViolations. Only tables in the __DOLT__ format with
+// primary keys are modified.
+func ApplyForeignKeyReferentialActions(ctx *sql.Context, newRoot, baseRoot doltdb.RootValue, tables *doltdb.TableNameSet) (doltdb.RootValue, error) {
+	if !types.IsFormat_DOLT(newRoot.VRW().Format()) {
+		return newRoot, nil
+	}
+
+	baseFkc, err := baseRoot.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fkColl, err := newRoot.GetForeignKeyCollection(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Parent rows changed on a branch that didn't have the foreign key weren't subject to its referential actions, so
+	// their violations are reported rather than resolved.
+	var fks []doltdb.ForeignKey
+	for _, fk := range fkColl.AllKeys() {
+		if fk.IsResolved() && hasReferentialAction(fk) && existsInAncestor(baseFkc, fk) &&
+			(tables.Size() == 0 || tables.Contains(fk.TableName)) {
+			fks = append(fks, fk)
+		}
+	}
+	if len(fks) == 0 {
+		return newRoot, nil
+	}
+
+	// Only a parent row which was removed or changed can be resolved by a referential action, so each pass only checks
+	// the foreign keys whose parent table changed: in the first pass between |baseRoot| and |newRoot|, and after that
+	// in the previous pass.
+	changed, err := changedParentTables(ctx, fks, newRoot, baseRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parent rows removed by an earlier pass only exist in the root that pass started from, so every intermediate
+	// root is kept around to resolve cascades that span more than one foreign key.
+	prevRoots := []doltdb.RootValue{baseRoot}
+	for i := 0; i < maxForeignKeyCascadeDepth && changed.Size() > 0; i++ {
+		collector := &foreignKeyViolationCollector{}
+		for _, fk := range fks {
+			if !changed.Contains(fk.ReferencedTableName) {
+				continue
+			}
+			if err = getForeignKeyViolations(ctx, fk, newRoot, baseRoot, collector); err != nil {
+				return nil, err
+			}
+		}
+
+		passRoot := newRoot
+		changed = doltdb.NewCaseInsensitiveTableNameSet(nil)
+		for _, violations := range collector.violations {
+			var applied bool
+			newRoot, applied, err = applyReferentialActions(ctx, newRoot, prevRoots, violations)
+			if err != nil {
+				return nil, err
+			}
+			if applied {
+				changed.Add(violations.fk.TableName)
+			}
+		}
+		prevRoots = append(prevRoots, passRoot)
+	}
+
+	return newRoot, nil
+}
+
+// changedParentTables returns the parent tables of |fks| which differ between |newRoot| and |baseRoot|.
+func changedParentTables(ctx context.Context, fks []doltdb.ForeignKey, newRoot, baseRoot doltdb.RootValue) (*doltdb.TableNameSet, error) {
+	changed := doltdb.NewCaseInsensitiveTableNameSet(nil)
+	for _, fk := range fks {
+		if changed.Contains(fk.ReferencedTableName) {
+			continue
+		}
+		newHash, err := tableHashInsensitive(ctx, newRoot, fk.ReferencedTableName)
+		if err != nil {
+			return nil, err
+		}
+		baseHash, err := tableHashInsensitive(ctx, baseRoot, fk.ReferencedTableName)
+		if err != nil {
+			return nil, err
+		}
+		if newHash != baseHash {
+			changed.Add(fk.ReferencedTableName)
+		}
+	}
+	return changed, nil
+}
+
+// tableHashInsensitive returns the hash of the table |name| in |root|, matched case-insensitively, or an empty hash if
+// there is no such table.
+func tableHashInsensitive(ctx context.Context, root doltdb.RootValue, name doltdb.TableName) (hash.Hash, error) {
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, name)
+	if err != nil || !ok {
+		return hash.Hash{}, err
+	}
+	return tbl.HashOf()
+}
+
+// hasReferentialAction returns whether |fk| has an ON DELETE or ON UPDATE action that modifies child rows.
+func hasReferentialAction(fk doltdb.ForeignKey) bool {
+	return modifiesChildRows(fk.OnDelete) || modifiesChildRows(fk.OnUpdate)
+}
+
+// existsInAncestor returns whether |fk| is defined identically in the ancestor's foreign key collection |baseFkc|.
+func existsInAncestor(baseFkc *doltdb.ForeignKeyCollection, fk doltdb.ForeignKey) bool {
+	baseFk, ok := baseFkc.GetByNameCaseInsensitive(fk.Name)
+	return ok && baseFk.EqualDefs(fk)
+}
+
+func modifiesChildRows(action doltdb.ForeignKeyReferentialAction) bool {
+	return action == doltdb.ForeignKeyReferentialAction_Cascade || action == doltdb.ForeignKeyReferentialAction_SetNull
+}
+
+// foreignKeyViolationCollector gathers the child rows violating each foreign key.
+type foreignKeyViolationCollector struct {
+	violations []*collectedFKViolations
+	curr       *collectedFKViolations
+}
+
+// collectedFKViolations holds the keys of the child rows violating a single foreign key.
+type collectedFKViolations struct {
+	fk   doltdb.ForeignKey
+	keys []val.Tuple
+	seen map[string]struct{}
+}
+
+var _ FKViolationReceiver = (*foreignKeyViolationCollector)(nil)
+
+func (f *foreignKeyViolationCollector) StartFK(ctx context.Context, fk doltdb.ForeignKey) error {
+	f.curr = &collectedFKViolations{fk: fk, seen: make(map[string]struct{})}
+	return nil
+}
+
+func (f *foreignKeyViolationCollector) EndCurrFK(ctx context.Context) error {
+	if len(f.curr.keys) > 0 {
+		f.violations = append(f.violations, f.curr)
+	}
+	f.curr = nil
+	return nil
+}
+
+func (f *foreignKeyViolationCollector) NomsFKViolationFound(ctx context.Context, rowKey, rowValue types.Tuple) error {
+	return nil
+}
+
+func (f *foreignKeyViolationCollector) ProllyFKViolationFound(ctx context.Context, rowKey, rowValue val.Tuple) error {
+	if _, ok := f.curr.seen[string(rowKey)]; ok {
+		return nil
+	}
+	f.curr.seen[string(rowKey)] = struct{}{}
+	f.curr.keys = append(f.curr.keys, rowKey)
+	return nil
+}
+
+// applyReferentialActions applies the referential actions of a single foreign key to the violating child rows in
+// |violations|, returning the updated root and whether any row was changed.
+func applyReferentialActions(ctx *sql.Context, root doltdb.RootValue, prevRoots []doltdb.RootValue, violations *collectedFKViolations) (doltdb.RootValue, bool, error) {
+	fk := violations.fk
+
+	tbl, trueName, ok, err := doltdb.GetTableInsensitive(ctx, root, fk.TableName)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return root, false, nil
+	}
+	tblName := doltdb.TableName{Name: trueName, Schema: fk.TableName.Schema}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if schema.IsKeyless(sch) || sch.Indexes().ContainsFullTextIndex() {
+		return root, false, nil
+	}
+
+	postParent, ok, err := newConstraintViolationsLoadedTable(ctx, fk.ReferencedTableName, fk.ReferencedTableIndex, root)
+	if err != nil || !ok {
+		return root, false, err
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	rows := durable.ProllyMapFromIndex(rowData)
+	mut := rows.Mutate()
+
+	indexes, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	secondary, err := GetMutableSecondaryIdxs(ctx, sch, sch, tblName.Name, indexes)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed, warnedNotNull := false, false
+	for _, k := range violations.keys {
+		var v val.Tuple
+		err = mut.Get(ctx, k, func(_, value val.Tuple) error {
+			v = value
+			return nil
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		if v == nil {
+			// removed by an earlier action
+			continue
+		}
+
+		oldRefs, hasNulls := fieldsForTags(fk.TableColumns, sch, k, v)
+		if hasNulls {
+			continue
+		}
+
+		newRefs, deleted, found, err := findReferencedParent(ctx, fk, prevRoots, postParent, oldRefs)
+		if err != nil {
+			return nil, false, err
+		}
+		if !found {
+			continue
+		}
+		bypassed, err := referentialActionsBypassed(ctx, fk, prevRoots[0], mut, sch, oldRefs)
+		if err != nil {
+			return nil, false, err
+		}
+		if bypassed {
+			continue
+		}
+
+		action := fk.OnUpdate
+		if deleted {
+			action = fk.OnDelete
+		}
+
+		switch action {
+		case doltdb.ForeignKeyReferentialAction_Cascade:
+			if deleted {
+				if err = mut.Delete(ctx, k); err != nil {
+					return nil, false, err
+				}
+				for _, idx := range secondary {
+					if err = idx.DeleteEntry(ctx, k, v); err != nil {
+						return nil, false, err
+					}
+				}
+				changed = true
+				continue
+			}
+		case doltdb.ForeignKeyReferentialAction_SetNull:
+			newRefs = make([][]byte, len(fk.TableColumns))
+		default:
+			continue
+		}
+
+		newK, newV, notNull, ok := rewriteChildRow(sch, rows, k, v, fk.TableColumns, newRefs)
+		if !ok {
+			if notNull != "" && !warnedNotNull {
+				// MySQL doesn't allow SET NULL on a NOT NULL column, but a merge can combine the two
+				ctx.Warn(1830, "Column '%s' cannot be NOT NULL: needed in a foreign key constraint '%s' SET NULL; "+
+					"rows of table %s referencing removed or changed rows of table %s were left unchanged",
+					notNull, fk.Name, fk.TableName.String(), fk.ReferencedTableName.String())
+				warnedNotNull = true
+			}
+			continue
+		}
+		// A rewritten child row that would break a unique index is left alone, and its violation is recorded instead.
+		duplicate, err := duplicatesUniqueKey(ctx, sch, secondary, rows, k, newK, newV)
+		if err != nil {
+			return nil, false, err
+		}
+		if duplicate {
+			continue
+		}
+		if !bytes.Equal(k, newK) {
+			exists, err := mut.Has(ctx, newK)
+			if err != nil {
+				return nil, false, err
+			}
+			if exists {
+				continue
+			}
+			if err = mut.Delete(ctx, k); err != nil {
+				return nil, false, err
+			}
+			for _, idx := range secondary {
+				if err = idx.DeleteEntry(ctx, k, v); err != nil {
+					return nil, false, err
+				}
+			}
+			if err = mut.Put(ctx, newK, newV); err != nil {
+				return nil, false, err
+			}
+			for _, idx := range secondary {
+				if err = idx.InsertEntry(ctx, newK, newV); err != nil {
+					return nil, false, err
+				}
+			}
+		} else {
+			if err = mut.Put(ctx, k, newV); err != nil {
+				return nil, false, err
+			}
+			for _, idx := range secondary {
+				if err = idx.UpdateEntry(ctx, k, v, newV); err != nil {
+					return nil, false, err
+				}
+			}
+		}
+		changed = true
+	}
+
+	if !changed {
+		return root, false, nil
+	}
+
+	m, err := mut.Map(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m))
+	if err != nil {
+		return nil, false, err
+	}
+	for _, idx := range secondary {
+		idxMap, err := idx.Map(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		indexes, err = indexes.PutIndex(ctx, idx.Name, durable.IndexFromProllyMap(idxMap))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	tbl, err = tbl.SetIndexSet(ctx, indexes)
+	if err != nil {
+		return nil, false, err
+	}
+
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return nil, false, err
+	}
+	return root, true, nil
+}
+
+// findReferencedParent finds the parent row that child rows referencing |refs| pointed to before the merge, searching
+// |prevRoots| from the most recent root backwards. If one is found, it returns whether that parent row has been
+// removed from |postParent| and, if it has not, the current values of its referenced columns.
+func findReferencedParent(
+	ctx context.Context,
+	fk doltdb.ForeignKey,
+	prevRoots []doltdb.RootValue,
+	postParent *constraintViolationsLoadedTable,
+	refs [][]byte,
+) (newRefs [][]byte, deleted, found bool, err error) {
+	for i := len(prevRoots) - 1; i >= 0; i-- {
+		preParent, ok, err := newConstraintViolationsLoadedTable(ctx, fk.ReferencedTableName, fk.ReferencedTableIndex, prevRoots[i])
+		if err == doltdb.ErrTableNotFound || (err == nil && !ok) {
+			continue
+		} else if err != nil {
+			return nil, false, false, err
+		}
+
+		preIdx := durable.ProllyMapFromIndex(preParent.IndexData)
+		idxKD, _ := preIdx.Descriptors()
+		prefixKD := idxKD.PrefixDesc(len(refs))
+		prefix := buildTuple(prefixKD, refs, preIdx.Pool())
+
+		itr, err := creation.NewPrefixItr(ctx, prefix, prefixKD, preIdx)
+		if err != nil {
+			return nil, false, false, err
+		}
+		idxKey, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return nil, false, false, err
+		}
+
+		// secondary index keys end with the primary key of the row they index
+		postRows := durable.ProllyMapFromIndex(postParent.RowData)
+		pkKD, _ := postRows.Descriptors()
+		pkFields := make([][]byte, pkKD.Count())
+		o := idxKey.Count() - pkKD.Count()
+		for j := range pkFields {
+			pkFields[j] = idxKey.GetField(o + j)
+		}
+		pk := buildTuple(pkKD, pkFields, postRows.Pool())
+
+		var parentVal val.Tuple
+		err = postRows.Get(ctx, pk, func(_, v val.Tuple) error {
+			parentVal = v
+			return nil
+		})
+		if err != nil {
+			return nil, false, false, err
+		}
+		if parentVal == nil {
+			return nil, true, true, nil
+		}
+
+		newRefs, hasNulls := fieldsForTags(fk.ReferencedTableColumns, postParent.Schema, pk, parentVal)
+		if hasNulls || equalFields(refs, newRefs) {
+			return nil, false, false, nil
+		}
+		return newRefs, false, true, nil
+	}
+
+	return nil, false, false, nil
+}
+
+// referentialActionsBypassed returns whether a parent row referenced by |refs| was changed without its referential
+// actions being applied, such as when it was deleted with foreign_key_checks disabled. This is the case when a child
+// row that referenced the parent in |baseRoot| still references it unchanged in |childRows|. Violations resulting from
+// such changes are left in place rather than resolved.
+func referentialActionsBypassed(ctx context.Context, fk doltdb.ForeignKey, baseRoot doltdb.RootValue, childRows *prolly.MutableMap, sch schema.Schema, refs [][]byte) (bool, error) {
+	preChild, ok, err := newConstraintViolationsLoadedTable(ctx, fk.TableName, fk.TableIndex, baseRoot)
+	if err == doltdb.ErrTableNotFound || (err == nil && !ok) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	preIdx := durable.ProllyMapFromIndex(preChild.IndexData)
+	idxKD, _ := preIdx.Descriptors()
+	prefixKD := idxKD.PrefixDesc(len(refs))
+	prefix := buildTuple(prefixKD, refs, preIdx.Pool())
+
+	itr, err := creation.NewPrefixItr(ctx, prefix, prefixKD, preIdx)
+	if err != nil {
+		return false, err
+	}
+
+	pkKD, _ := childRows.Descriptors()
+	for {
+		idxKey, _, err := itr.Next(ctx)
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		pkFields := make([][]byte, pkKD.Count())
+		o := idxKey.Count() - pkKD.Count()
+		for j := range pkFields {
+			pkFields[j] = idxKey.GetField(o + j)
+		}
+		pk := buildTuple(pkKD, pkFields, preIdx.Pool())
+
+		var v val.Tuple
+		err = childRows.Get(ctx, pk, func(_, value val.Tuple) error {
+			v = value
+			return nil
+		})
+		if err != nil {
+			return false, err
+		}
+		if v == nil {
+			continue
+		}
+		curr, hasNulls := fieldsForTags(fk.TableColumns, sch, pk, v)
+		if !hasNulls && equalFields(refs, curr) {
+			return true, nil
+		}
+	}
+}
+
+// fieldsForTags returns the raw fields of the row |k|, |v| for the columns with |tags|, and whether any of them are
+// NULL.
+func fieldsForTags(tags []uint64, sch schema.Schema, k, v val.Tuple) ([][]byte, bool) {
+	fields := make([][]byte, len(tags))
+	for i, tag := range tags {
+		var field []byte
+		if j, ok := sch.GetPKCols().TagToIdx[tag]; ok {
+			field = k.GetField(j)
+		} else if j, ok := sch.GetNonPKCols().StoredIndexByTag(tag); ok {
+			field = v.GetField(j)
+		}
+		if field == nil {
+			return nil, true
+		}
+		fields[i] = field
+	}
+	return fields, false
+}
+
+func equalFields(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// rewriteChildRow returns a copy of the row |k|, |v| with the columns for |tags| set to |fields|, where a nil field
+// is NULL. Returns false if the new values can't be written, along with the name of the column if it's because the
+// column can't be NULL.
+func rewriteChildRow(sch schema.Schema, rows prolly.Map, k, v val.Tuple, tags []uint64, fields [][]byte) (val.Tuple, val.Tuple, string, bool) {
+	keyFields := make([][]byte, k.Count())
+	for i := range keyFields {
+		keyFields[i] = k.GetField(i)
+	}
+	valFields := make([][]byte, v.Count())
+	for i := range valFields {
+		valFields[i] = v.GetField(i)
+	}
+
+	for i, tag := range tags {
+		col, ok := sch.GetAllCols().GetByTag(tag)
+		if !ok {
+			return nil, nil, "", false
+		}
+		if fields[i] == nil && !col.IsNullable() {
+			return nil, nil, col.Name, false
+		}
+		if j, ok := sch.GetPKCols().TagToIdx[tag]; ok {
+			if fields[i] == nil {
+				return nil, nil, col.Name, false
+			}
+			keyFields[j] = fields[i]
+		} else if j, ok := sch.GetNonPKCols().StoredIndexByTag(tag); ok {
+			valFields[j] = fields[i]
+		} else {
+			return nil, nil, "", false
+		}
+	}
+
+	kd, vd := rows.Descriptors()
+	return buildTuple(kd, keyFields, rows.Pool()), buildTuple(vd, valFields, rows.Pool()), "", true
+}
+
+// duplicatesUniqueKey returns whether the child row (|newK|, |newV|), written in place of the row with key |k|, would
+// have the same values as another row for the columns of any unique index in |sch|. |secondary| holds the mutable
+// secondary indexes of |sch|, in the order of sch.Indexes().AllIndexes().
+func duplicatesUniqueKey(ctx context.Context, sch schema.Schema, secondary []MutableSecondaryIdx, rows prolly.Map, k, newK, newV val.Tuple) (bool, error) {
+	for i, def := range sch.Indexes().AllIndexes() {
+		if !def.IsUnique() {
+			continue
+		}
+		idx := secondary[i]
+		indexKey, err := idx.leftBuilder.SecondaryKeyFromRow(ctx, newK, newV)
+		if err != nil {
+			return false, err
+		}
+		idxKeyDesc, _ := idx.mut.Descriptors()
+		prefixDesc := idxKeyDesc.PrefixDesc(def.Count())
+		if prefixDesc.HasNulls(indexKey) {
+			// NULLs cannot cause unique violations
+			continue
+		}
+
+		clusteredBld := index.NewClusteredKeyBuilder(def, sch, rows.KeyDesc(), rows.Pool())
+		duplicate := false
+		err = idx.mut.GetPrefix(ctx, indexKey, prefixDesc, func(ik, _ val.Tuple) error {
+			if ik != nil && !bytes.Equal(clusteredBld.ClusteredKeyFromIndexKey(ik), k) {
+				duplicate = true
+			}
+			return nil
+		})
+		if err != nil || duplicate {
+			return duplicate, err
+		}
+	}
+	return false, nil
+}
+
+// buildTuple builds a tuple from the raw |fields|, where a nil field is NULL.
+func buildTuple(desc val.TupleDesc, fields [][]byte, pool pool.BuffPool) val.Tuple {
+	tb := val.NewTupleBuilder(desc)
+	for i, f := range fields {
+		if f != nil {
+			tb.PutRaw(i, f)
+		}
+	}
+	return tb.Build(pool)
+}
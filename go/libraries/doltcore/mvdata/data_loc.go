@@ -52,6 +52,9 @@ const (
 	// JsonFile is the format of a data location that is a json file
 	JsonFile DataFormat = ".json"
 
+	// JsonlFile is the format of a data location that is a json lines file, with a json object on each line
+	JsonlFile DataFormat = ".jsonl"
+
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
 
@@ -72,6 +75,8 @@ func (df DataFormat) ReadableStr() string {
 		return "xlsx file"
 	case JsonFile:
 		return "json file"
+	case JsonlFile:
+		return "jsonl file"
 	case SqlFile:
 		return "sql file"
 	case ParquetFile:
@@ -96,15 +101,18 @@ type DataLocation interface {
 	NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error)
 }
 
+// StreamPath is the path of a StreamDataLocation, such as stdin for an import.
+const StreamPath = "-"
+
 // NewDataLocation creates a DataLocation object from a path and a format string.  If the path is the name of a table
-// then a TableDataLocation will be returned.  If the path is empty a StreamDataLocation is returned.  Otherwise a
+// then a TableDataLocation will be returned.  If the path is empty or StreamPath a StreamDataLocation is returned.  Otherwise a
 // FileDataLocation is returned.  For FileDataLocations and StreamDataLocations, if a file format is provided explicitly
 // then it is used as the format, otherwise, when it can be, it is inferred from the path for files.  Inference is based
 // on the file's extension.
 func NewDataLocation(path, fileFmtStr string) DataLocation {
 	dataFmt := DFFromString(fileFmtStr)
 
-	if len(path) == 0 {
+	if len(path) == 0 || path == StreamPath {
 		return StreamDataLocation{Format: dataFmt, Reader: cli.InStream, Writer: cli.OutStream}
 	} else if fileFmtStr == "" {
 		switch strings.ToLower(filepath.Ext(path)) {
@@ -116,6 +124,8 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 			dataFmt = XlsxFile
 		case string(JsonFile):
 			dataFmt = JsonFile
+		case string(JsonlFile):
+			dataFmt = JsonlFile
 		case string(SqlFile):
 			dataFmt = SqlFile
 		case string(ParquetFile):
@@ -85,6 +85,8 @@ func TestBasics(t *testing.T) {
 		{NewDataLocation("file.csv", ""), CsvFile.ReadableStr() + ":file.csv", true},
 		{NewDataLocation("file.psv", ""), PsvFile.ReadableStr() + ":file.psv", true},
 		{NewDataLocation("file.json", ""), JsonFile.ReadableStr() + ":file.json", true},
+		{NewDataLocation("file.jsonl", ""), JsonlFile.ReadableStr() + ":file.jsonl", true},
+		{NewDataLocation(StreamPath, "jsonl"), "stream", false},
 		//{NewDataLocation("file.nbf", ""), NbfFile, "file.nbf", true},
 	}
 
@@ -27,11 +27,40 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 )
 
 type CsvOptions struct {
 	Delim string
+	// DecimalSep, DateFormat, DatetimeFormat, BOM and CRLF only apply when writing csv files. They make exported csv
+	// files open correctly in spreadsheet programs that expect a locale's conventions.
+	DecimalSep     string
+	DateFormat     string
+	DatetimeFormat string
+	BOM            bool
+	CRLF           bool
+}
+
+// CsvDestOptions is implemented by DataMoverOptions which write csv or psv files with non-default CsvOptions.
+type CsvDestOptions interface {
+	CsvDestOptions() CsvOptions
+}
+
+// newCSVWriterInfo returns the CSVFileInfo used to write a csv file with the delimiter |delim|, unless |mvOpts| gives
+// another one, and any other CsvOptions of |mvOpts|.
+func newCSVWriterInfo(mvOpts DataMoverOptions, delim string) *csv.CSVFileInfo {
+	info := csv.NewCSVInfo().SetDelim(delim)
+	csvOpts, ok := mvOpts.(CsvDestOptions)
+	if !ok {
+		return info
+	}
+	opts := csvOpts.CsvDestOptions()
+	if opts.Delim != "" {
+		info.SetDelim(opts.Delim)
+	}
+	return info.SetDecimalSep(opts.DecimalSep).SetDateFormat(opts.DateFormat).SetDatetimeFormat(opts.DatetimeFormat).
+		SetWriteBOM(opts.BOM).SetUseCRLF(opts.CRLF)
 }
 
 type XlsxOptions struct {
@@ -31,6 +31,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -125,7 +126,15 @@ func NewSqlEngineTableWriter(ctx context.Context, dEnv *env.DoltEnv, createTable
 	}, nil
 }
 
-func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan sql.Row, badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) bool) (err error) {
+// WriteRows writes the rows received from |inputChannel| to the table, calling |badRowCb| with each row which can't be
+// written and where it was read from. If |locations| is non-nil, it must hold the location of each row sent to
+// |inputChannel|, in order; otherwise rows are located only by their number.
+func (s *SqlEngineTableWriter) WriteRows(
+	ctx context.Context,
+	inputChannel chan sql.Row,
+	locations *table.RowLocations,
+	badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, loc table.SourceLocation, err error) bool,
+) (err error) {
 	err = s.forceDropTableIfNeeded()
 	if err != nil {
 		return err
@@ -187,7 +196,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		}
 	}()
 
-	line := 1
+	record := 0
 
 	for {
 		if s.statsCB != nil && atomic.LoadInt32(&s.statOps) >= tableWriterStatUpdateRate {
@@ -196,7 +205,17 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		}
 
 		row, err := iter.Next(s.sqlCtx)
-		line += 1
+
+		// every row received, whether it's written or not, is returned by one call to Next
+		var loc table.SourceLocation
+		if err != io.EOF {
+			record++
+			// without locations, assume each row is a line following a header line
+			loc = table.SourceLocation{Line: record + 1, Record: record}
+			if l, ok := locations.Pop(); ok {
+				loc = l
+			}
+		}
 
 		// All other errors are handled by the errorHandler
 		if err == nil {
@@ -219,7 +238,7 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 				offendingRow = n.OffendingRow
 			}
 
-			quit := badRowCb(offendingRow, s.tableSchema, s.tableName, line, err)
+			quit := badRowCb(offendingRow, s.tableSchema, s.tableName, loc, err)
 			if quit {
 				return err
 			}
@@ -232,6 +251,11 @@ func (s *SqlEngineTableWriter) Commit(ctx context.Context) error {
 	return err
 }
 
+// Stats returns the changes made by the rows written so far.
+func (s *SqlEngineTableWriter) Stats() types.AppliedEditStats {
+	return s.stats
+}
+
 func (s *SqlEngineTableWriter) RowOperationSchema() sql.PrimaryKeySchema {
 	return s.rowOperationSchema
 }
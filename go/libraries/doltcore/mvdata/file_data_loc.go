@@ -47,6 +47,8 @@ func DFFromString(dfStr string) DataFormat {
 		return XlsxFile
 	case "json", ".json":
 		return JsonFile
+	case "jsonl", ".jsonl":
+		return JsonlFile
 	case "sql", ".sql":
 		return SqlFile
 	case "parquet", ".parquet":
@@ -117,65 +119,24 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 		rd, err := xlsx.OpenXLSXReader(ctx, root.VRW(), dl.Path, fs, &xlsx.XLSXFileInfo{SheetName: xlsxOpts.SheetName})
 		return rd, false, err
 
-	case JsonFile:
-		var sch schema.Schema
+	case JsonFile, JsonlFile:
 		jsonOpts, _ := opts.(JSONOptions)
-		if jsonOpts.SchFile != "" {
-			tn, s, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, dEnv)
-			if err != nil {
-				return nil, false, err
-			}
-			if tn != jsonOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
-			}
-			sch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: jsonOpts.TableName})
-			if !exists {
-				return nil, false, fmt.Errorf("The following table could not be found:\n%v", jsonOpts.TableName)
-			}
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
-			}
-			sch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
-			}
+		sch, err := readerSchema(ctx, dEnv, root, jsonOpts.TableName, jsonOpts.SchFile)
+		if err != nil {
+			return nil, false, err
+		}
+		if dl.Format == JsonlFile {
+			rd, err := json.OpenJSONLReader(root.VRW(), dl.Path, fs, sch)
+			return rd, false, err
 		}
-
 		rd, err := json.OpenJSONReader(root.VRW(), dl.Path, fs, sch)
 		return rd, false, err
 
 	case ParquetFile:
-		var tableSch schema.Schema
 		parquetOpts, _ := opts.(ParquetOptions)
-		if parquetOpts.SchFile != "" {
-			tn, s, tnErr := SchAndTableNameFromFile(ctx, parquetOpts.SchFile, dEnv)
-			if tnErr != nil {
-				return nil, false, tnErr
-			}
-			if tn != parquetOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, parquetOpts.SchFile, parquetOpts.TableName)
-			}
-			tableSch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, tableExists, tErr := root.GetTable(context.TODO(), doltdb.TableName{Name: parquetOpts.TableName})
-			if !tableExists {
-				return nil, false, fmt.Errorf("The following table could not be found:\n%v", parquetOpts.TableName)
-			}
-			if tErr != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
-			}
-			tableSch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
-			}
+		tableSch, err := readerSchema(ctx, dEnv, root, parquetOpts.TableName, parquetOpts.SchFile)
+		if err != nil {
+			return nil, false, err
 		}
 		rd, rErr := parquet.OpenParquetReader(root.VRW(), dl.Path, tableSch)
 		return rd, false, rErr
@@ -184,18 +145,51 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 	return nil, false, errors.New("unsupported format")
 }
 
+// readerSchema returns the schema of the rows of a json or parquet import into |tableName|, which is read from the
+// schema file |schFile| if there is one, or is otherwise the schema of the existing table.
+func readerSchema(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, tableName, schFile string) (schema.Schema, error) {
+	if schFile != "" {
+		tn, sch, err := SchAndTableNameFromFile(ctx, schFile, dEnv)
+		if err != nil {
+			return nil, err
+		}
+		if tn != tableName {
+			return nil, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, schFile, tableName)
+		}
+		return sch, nil
+	}
+
+	if tableName == "" {
+		return nil, errors.New("Unable to determine table name on import")
+	}
+	tbl, exists, err := root.GetTable(ctx, doltdb.TableName{Name: tableName})
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
+	}
+	if !exists {
+		return nil, fmt.Errorf("The following table could not be found:\n%v", tableName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
+	}
+	return sch, nil
+}
+
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
 // an existing table.
 func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error) {
 	switch dl.Format {
 	case CsvFile:
-		return csv.NewCSVWriter(wr, outSch, csv.NewCSVInfo())
+		return csv.NewCSVWriter(wr, outSch, newCSVWriterInfo(mvOpts, ","))
 	case PsvFile:
-		return csv.NewCSVWriter(wr, outSch, csv.NewCSVInfo().SetDelim("|"))
+		return csv.NewCSVWriter(wr, outSch, newCSVWriterInfo(mvOpts, "|"))
 	case XlsxFile:
 		panic("writing to xlsx files is not supported yet")
 	case JsonFile:
 		return json.NewJSONWriter(wr, outSch)
+	case JsonlFile:
+		return json.NewJSONLWriter(wr, outSch)
 	case SqlFile:
 		if mvOpts.IsBatched() {
 			return sqlexport.OpenBatchedSQLExportWriter(ctx, wr, root, mvOpts.SrcName(), mvOpts.IsAutocommitOff(), outSch, opts)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 
@@ -25,11 +26,16 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/sqlexport"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 )
 
+var errParquetStream = errors.New("parquet files can't be read from a pipe; redirect the file to stdin, or import it by its path")
+
 // StreamDataLocation is a process stream that that can be imported from or exported to.
 type StreamDataLocation struct {
 	Format DataFormat
@@ -73,9 +79,40 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, o
 	case PsvFile:
 		rd, err := csv.NewCSVReader(root.VRW().Format(), io.NopCloser(dl.Reader), csv.NewCSVInfo().SetDelim("|"))
 		return rd, false, err
+
+	case JsonFile, JsonlFile:
+		jsonOpts, _ := opts.(JSONOptions)
+		sch, err := readerSchema(ctx, dEnv, root, jsonOpts.TableName, jsonOpts.SchFile)
+		if err != nil {
+			return nil, false, err
+		}
+		if dl.Format == JsonlFile {
+			rd, err := json.NewJSONLReader(root.VRW(), io.NopCloser(dl.Reader), sch)
+			return rd, false, err
+		}
+		rd, err := json.NewJSONReader(root.VRW(), io.NopCloser(dl.Reader), sch)
+		return rd, false, err
+
+	case ParquetFile:
+		// the footer of a parquet file is at its end, so it can only be read from a stream which is a regular file
+		f, ok := dl.Reader.(*os.File)
+		if !ok {
+			return nil, false, errParquetStream
+		}
+		info, err := f.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return nil, false, errParquetStream
+		}
+		parquetOpts, _ := opts.(ParquetOptions)
+		sch, err := readerSchema(ctx, dEnv, root, parquetOpts.TableName, parquetOpts.SchFile)
+		if err != nil {
+			return nil, false, err
+		}
+		rd, err := parquet.NewParquetReaderFromReaderAt(root.VRW(), f, info.Size(), sch)
+		return rd, false, err
 	}
 
-	return nil, false, errors.New(string(dl.Format) + "is an unsupported format to read from stdin")
+	return nil, false, errors.New(string(dl.Format) + " is an unsupported format to read from stdin")
 }
 
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
@@ -83,10 +120,19 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, o
 func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error) {
 	switch dl.Format {
 	case CsvFile:
-		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo())
+		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, newCSVWriterInfo(mvOpts, ","))
 
 	case PsvFile:
-		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo().SetDelim("|"))
+		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, newCSVWriterInfo(mvOpts, "|"))
+
+	case JsonFile:
+		return json.NewJSONWriter(iohelp.NopWrCloser(dl.Writer), outSch)
+
+	case JsonlFile:
+		return json.NewJSONLWriter(iohelp.NopWrCloser(dl.Writer), outSch)
+
+	case SqlFile:
+		return sqlexport.OpenSQLExportWriter(ctx, iohelp.NopWrCloser(dl.Writer), root, mvOpts.SrcName(), mvOpts.IsAutocommitOff(), outSch, opts)
 	}
 
 	return nil, errors.New(string(dl.Format) + "is an unsupported format to write to stdout")
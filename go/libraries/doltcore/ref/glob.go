@@ -0,0 +1,134 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidGlob is the error returned when a glob pattern isn't syntactically valid
+var ErrInvalidGlob = errors.New("invalid pattern")
+
+// Glob is a shell style pattern matched against ref names, such as the branch names given to `dolt branch --list`.
+// Like git, a '*' matches any sequence of characters including '/', so team/* matches every branch in the team
+// namespace at any depth. A '?' matches any one character, and [...] matches one character of a class, which is
+// negated by a leading '!' or '^'. A '\' matches the character following it literally.
+type Glob struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// IsGlob returns whether |s| contains any of the special characters of a Glob.
+func IsGlob(s string) bool {
+	return strings.ContainsAny(s, `*?[\`)
+}
+
+// NewGlob parses |pattern| into a Glob.
+func NewGlob(pattern string) (Glob, error) {
+	var sb strings.Builder
+	sb.WriteString(`\A`)
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			sb.WriteString(`.*`)
+		case '?':
+			sb.WriteString(`.`)
+		case '\\':
+			if i+1 == len(pattern) {
+				return Glob{}, ErrInvalidGlob
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := classEnd(pattern, i)
+			if end < 0 {
+				return Glob{}, ErrInvalidGlob
+			}
+			class := pattern[i+1 : end]
+			sb.WriteByte('[')
+			if class[0] == '!' || class[0] == '^' {
+				sb.WriteByte('^')
+				class = class[1:]
+			}
+			for j := 0; j < len(class); j++ {
+				if strings.IndexByte(`\[]^`, class[j]) >= 0 {
+					sb.WriteByte('\\')
+				}
+				sb.WriteByte(class[j])
+			}
+			sb.WriteByte(']')
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	sb.WriteString(`\z`)
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return Glob{}, ErrInvalidGlob
+	}
+
+	return Glob{pattern: pattern, re: re}, nil
+}
+
+// classEnd returns the index of the ']' which closes the character class opened at |start|, or -1 if it's not closed.
+// A ']' immediately after the opening '[', or after its negation, is part of the class.
+func classEnd(pattern string, start int) int {
+	i := start + 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		i++
+	}
+	if i < len(pattern) && pattern[i] == ']' {
+		i++
+	}
+	for ; i < len(pattern); i++ {
+		if pattern[i] == ']' {
+			return i
+		}
+	}
+	return -1
+}
+
+// Matches returns whether the whole of |name| matches the glob.
+func (g Glob) Matches(name string) bool {
+	return g.re.MatchString(name)
+}
+
+// String returns the pattern the glob was parsed from.
+func (g Glob) String() string {
+	return g.pattern
+}
+
+// Prefix returns the literal prefix of the glob, which every name it matches starts with, e.g. refs/heads/release/
+// for refs/heads/release/*.
+func (g Glob) Prefix() string {
+	var sb strings.Builder
+	for i := 0; i < len(g.pattern); i++ {
+		switch c := g.pattern[i]; c {
+		case '*', '?', '[':
+			return sb.String()
+		case '\\':
+			i++
+			sb.WriteByte(g.pattern[i])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
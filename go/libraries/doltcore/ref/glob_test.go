@@ -0,0 +1,120 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlob(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		matches    []string
+		notMatches []string
+	}{
+		{
+			"main",
+			[]string{"main"},
+			[]string{"mains", "team/main", "Main"},
+		},
+		{
+			"team/*",
+			[]string{"team/x", "team/feature/x"},
+			[]string{"team", "teams/x", "other/team/x"},
+		},
+		{
+			"*/feature/*",
+			[]string{"team/feature/x", "a/b/feature/c/d"},
+			[]string{"feature/x", "team/features/x"},
+		},
+		{
+			"v?.0",
+			[]string{"v1.0", "v2.0"},
+			[]string{"v10.0", "v1x0", "v.0"},
+		},
+		{
+			"release-[0-9]",
+			[]string{"release-1", "release-9"},
+			[]string{"release-a", "release-10"},
+		},
+		{
+			"[!a-m]*",
+			[]string{"nope", "zeta"},
+			[]string{"main", "alpha"},
+		},
+		{
+			"[]x]",
+			[]string{"]", "x"},
+			[]string{"[", "y"},
+		},
+		{
+			`team/\*`,
+			[]string{"team/*"},
+			[]string{"team/x"},
+		},
+		{
+			"a.b+c(d)",
+			[]string{"a.b+c(d)"},
+			[]string{"aXb+c(d)", "a.bbc(d)"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			g, err := NewGlob(test.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, test.pattern, g.String())
+			for _, name := range test.matches {
+				assert.True(t, g.Matches(name), "%s should match %s", test.pattern, name)
+			}
+			for _, name := range test.notMatches {
+				assert.False(t, g.Matches(name), "%s should not match %s", test.pattern, name)
+			}
+		})
+	}
+}
+
+func TestInvalidGlob(t *testing.T) {
+	for _, pattern := range []string{"team/[", "[!]", `trailing\`, "[z-a]"} {
+		_, err := NewGlob(pattern)
+		assert.ErrorIs(t, err, ErrInvalidGlob, pattern)
+	}
+}
+
+func TestIsGlob(t *testing.T) {
+	assert.False(t, IsGlob("team/feature/x"))
+	assert.True(t, IsGlob("team/*"))
+	assert.True(t, IsGlob("v?"))
+	assert.True(t, IsGlob("[ab]"))
+}
+
+func TestGlobPrefix(t *testing.T) {
+	tests := map[string]string{
+		"refs/heads/release/*": "refs/heads/release/",
+		"refs/heads/v?.0":      "refs/heads/v",
+		"refs/heads/[ab]*":     "refs/heads/",
+		`refs/heads/a\*b/*`:    "refs/heads/a*b/",
+		"refs/heads/main":      "refs/heads/main",
+		"*":                    "",
+	}
+	for pattern, expected := range tests {
+		g, err := NewGlob(pattern)
+		require.NoError(t, err)
+		assert.Equal(t, expected, g.Prefix(), pattern)
+	}
+}
@@ -60,6 +60,9 @@ const (
 
 	// TupleRefType is a reference to a statistics table
 	TupleRefType RefType = "tuples"
+
+	// SymbolicRefType is a reference to another ref, such as the HEAD of a database naming its default branch
+	SymbolicRefType RefType = "symbolic"
 )
 
 // HeadRefTypes are the ref types that point to a HEAD and contain a Commit struct. These are the types that are
@@ -212,5 +215,9 @@ func Parse(str string) (DoltRef, error) {
 		return NewTupleRef(str[len(prefix):]), nil
 	}
 
+	if prefix := PrefixForType(SymbolicRefType); strings.HasPrefix(str, prefix) {
+		return NewSymbolicRef(str[len(prefix):]), nil
+	}
+
 	return nil, ErrUnknownRefType
 }
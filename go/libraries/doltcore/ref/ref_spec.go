@@ -49,6 +49,9 @@ type RemoteRefSpec interface {
 	RefSpec
 	GetRemote() string
 	GetRemRefToLocal() branchMapper
+	// SrcRefGlob returns a Glob pattern matching every source ref string the ref spec matches, e.g. refs/heads/team/*
+	// for the ref spec refs/heads/team/*:refs/remotes/origin/team/*
+	SrcRefGlob() string
 }
 
 // ParseRefSpec parses a RefSpec from a string.
@@ -286,3 +289,8 @@ func (rs BranchToTrackingBranchRefSpec) GetRemote() string {
 func (rs BranchToTrackingBranchRefSpec) GetRemRefToLocal() branchMapper {
 	return rs.remRefToLocal
 }
+
+// SrcRefGlob returns a Glob pattern matching every local branch ref string matched by the ref spec.
+func (rs BranchToTrackingBranchRefSpec) SrcRefGlob() string {
+	return PrefixForType(BranchRefType) + rs.localPattern.glob()
+}
@@ -18,6 +18,8 @@ import "strings"
 
 type pattern interface {
 	matches(string) (string, bool)
+	// glob returns a Glob pattern matching the same strings as the pattern
+	glob() string
 }
 
 type strPattern string
@@ -26,6 +28,10 @@ func (sp strPattern) matches(s string) (string, bool) {
 	return "", s == string(sp)
 }
 
+func (sp strPattern) glob() string {
+	return string(sp)
+}
+
 type wcPattern struct {
 	prefixStr string
 	suffixStr string
@@ -51,3 +57,7 @@ func (wp wcPattern) matches(s string) (string, bool) {
 
 	return "", false
 }
+
+func (wp wcPattern) glob() string {
+	return wp.prefixStr + "*" + wp.suffixStr
+}
@@ -123,3 +123,17 @@ func TestRefSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestSrcRefGlob(t *testing.T) {
+	tests := map[string]string{
+		"refs/heads/*:refs/remotes/origin/*":           "refs/heads/*",
+		"refs/heads/team/*:refs/remotes/origin/team/*": "refs/heads/team/*",
+		"refs/heads/main:refs/remotes/origin/main":     "refs/heads/main",
+	}
+
+	for refSpecStr, expected := range tests {
+		refSpec, err := ParseRefSpecForRemote("origin", refSpecStr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, refSpec.(RemoteRefSpec).SrcRefGlob(), refSpecStr)
+	}
+}
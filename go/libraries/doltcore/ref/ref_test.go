@@ -46,6 +46,10 @@ func TestJsonMarshalAndUnmarshal(t *testing.T) {
 			NewWorkspaceRef("newworkspace"),
 			`{"test":"refs/workspaces/newworkspace"}`,
 		},
+		{
+			NewHeadSymbolicRef(),
+			`{"test":"refs/symbolic/HEAD"}`,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,51 @@
+// Copyright 2023 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ref
+
+// HeadSymbolicRefName is the name of the symbolic ref naming the default branch of a database, which is the branch
+// that clones of it check out.
+const HeadSymbolicRefName = "HEAD"
+
+// SymbolicRef is a reference to another ref, rather than to a commit, e.g. refs/symbolic/HEAD -> refs/heads/main.
+type SymbolicRef struct {
+	name string
+}
+
+var _ DoltRef = SymbolicRef{}
+
+// NewSymbolicRef creates a reference to the symbolic ref |name|.
+func NewSymbolicRef(name string) SymbolicRef {
+	return SymbolicRef{name}
+}
+
+// NewHeadSymbolicRef creates a reference to the symbolic ref naming the default branch of a database.
+func NewHeadSymbolicRef() SymbolicRef {
+	return SymbolicRef{HeadSymbolicRefName}
+}
+
+// GetType will return SymbolicRefType
+func (sr SymbolicRef) GetType() RefType {
+	return SymbolicRefType
+}
+
+// GetPath returns the name of the symbolic ref
+func (sr SymbolicRef) GetPath() string {
+	return sr.name
+}
+
+// String returns the fully qualified reference name e.g. refs/symbolic/HEAD
+func (sr SymbolicRef) String() string {
+	return String(sr)
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/rsync"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+// maxCachedSignatures is the number of table file signatures a signatureCache keeps. Table files are immutable, so
+// a signature stays valid for as long as its table file exists.
+const maxCachedSignatures = 16
+
+type signatureKey struct {
+	path      string
+	blockSize int
+}
+
+// signatureCache keeps the signatures of the table files most recently used as delta bases, so that each push
+// doesn't have to read the whole base table file again.
+type signatureCache struct {
+	mu   sync.Mutex
+	sigs map[signatureKey]*rsync.Signature
+}
+
+func newSignatureCache() *signatureCache {
+	return &signatureCache{sigs: make(map[signatureKey]*rsync.Signature)}
+}
+
+// get returns the signature of the table file at |path| with blocks of |blockSize|, computing it if it isn't cached.
+func (c *signatureCache) get(path string, blockSize int) (*rsync.Signature, error) {
+	key := signatureKey{path, blockSize}
+	c.mu.Lock()
+	sig, ok := c.sigs[key]
+	c.mu.Unlock()
+	if ok {
+		return sig, nil
+	}
+
+	f, _, err := openFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sig, err = rsync.ComputeSignature(bufio.NewReader(f), blockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.sigs) >= maxCachedSignatures {
+		for k := range c.sigs {
+			delete(c.sigs, k)
+			break
+		}
+	}
+	c.sigs[key] = sig
+	return sig, nil
+}
+
+func (rs *RemoteChunkStore) GetTableFileSignature(ctx context.Context, req *remotesapi.GetTableFileSignatureRequest) (*remotesapi.GetTableFileSignatureResponse, error) {
+	logger := getReqLogger(rs.lgr, "GetTableFileSignature")
+	if err := ValidateGetTableFileSignatureRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	repoPath := getRepoPath(req)
+	logger = logger.WithField(RepoPathField, repoPath)
+	defer func() { logger.Info("finished") }()
+
+	cs, err := rs.getStore(ctx, logger, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	blockSize := int(req.BlockSize)
+	if blockSize == 0 {
+		blockSize = rsync.DefaultBlockSize
+	}
+
+	// The largest table file is the most likely to share content with the table files of a push.
+	base, path, err := rs.getDeltaBase(ctx, cs, func(tables []chunks.TableFile, paths []string) int {
+		largest, largestSize := -1, int64(blockSize)
+		for i := range tables {
+			info, err := os.Stat(paths[i])
+			if err == nil && info.Size() >= largestSize {
+				largest, largestSize = i, info.Size()
+			}
+		}
+		return largest
+	})
+	if err != nil {
+		logger.WithError(err).Error("error finding delta base")
+		return nil, err
+	}
+	if base == nil {
+		return &remotesapi.GetTableFileSignatureResponse{}, nil
+	}
+
+	sig, err := rs.signatures.get(path, blockSize)
+	if err != nil {
+		logger.WithError(err).Error("error computing table file signature")
+		return nil, status.Error(codes.Internal, "failed to compute table file signature")
+	}
+	weak, strong := sig.Checksums()
+
+	logger = logger.WithFields(logrus.Fields{
+		"table_file": base.FileID(),
+		"block_size": blockSize,
+		"num_blocks": len(sig.Weak),
+	})
+
+	return &remotesapi.GetTableFileSignatureResponse{
+		TableFileId:     base.FileID(),
+		BlockSize:       uint32(blockSize),
+		WeakChecksums:   weak,
+		StrongChecksums: strong,
+	}, nil
+}
+
+// getDeltaBase returns the table file of |cs| picked by |pick| as a delta base, along with its absolute path. The
+// chunk journal is never a delta base, since it changes as it is appended to. |pick| returns -1 to pick nothing.
+func (rs *RemoteChunkStore) getDeltaBase(ctx context.Context, cs RemoteSrvStore, pick func(tables []chunks.TableFile, paths []string) int) (chunks.TableFile, string, error) {
+	_, sources, _, err := cs.Sources(ctx)
+	if err != nil {
+		return nil, "", status.Error(codes.Internal, "failed to get sources")
+	}
+	prefix, err := rs.getRelativeStorePath(cs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tables []chunks.TableFile
+	var paths []string
+	for _, t := range sources {
+		if t.FileID() == chunks.JournalFileID {
+			continue
+		}
+		path, err := rs.fs.Abs(prefix + "/" + t.LocationPrefix() + t.FileID())
+		if err != nil {
+			return nil, "", err
+		}
+		tables = append(tables, t)
+		paths = append(paths, path)
+	}
+
+	i := pick(tables, paths)
+	if i < 0 {
+		return nil, "", nil
+	}
+	return tables[i], paths[i], nil
+}
+
+// getDeltaBasePath returns the path of the table file |fileID| of |cs|, relative to the root of the file server.
+func (rs *RemoteChunkStore) getDeltaBasePath(ctx context.Context, cs RemoteSrvStore, fileID string) (string, error) {
+	base, _, err := rs.getDeltaBase(ctx, cs, func(tables []chunks.TableFile, paths []string) int {
+		for i, t := range tables {
+			if t.FileID() == fileID {
+				return i
+			}
+		}
+		return -1
+	})
+	if err != nil {
+		return "", err
+	}
+	if base == nil {
+		return "", status.Error(codes.FailedPrecondition, fmt.Sprintf("delta base table file %s not found", fileID))
+	}
+	prefix, err := rs.getRelativeStorePath(cs)
+	if err != nil {
+		return "", err
+	}
+	return prefix + "/" + base.LocationPrefix() + base.FileID(), nil
+}
+
+// deltaReader reconstructs a table file from a delta upload against a base table file. The delta is read from
+// |body|, which must hold exactly |deltaLength| bytes. |base| is closed once the delta is applied.
+func deltaReader(base *os.File, body io.Reader, deltaLength uint64) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		defer base.Close()
+		delta := &countingReader{r: body}
+		_, err := rsync.ApplyDelta(base, delta, pw)
+		if err == nil {
+			_, err = io.Copy(io.Discard, delta)
+		}
+		if err == nil && delta.n != deltaLength {
+			err = fmt.Errorf("%w: expected %d bytes of delta, got %d", rsync.ErrInvalidDelta, deltaLength, delta.n)
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+type countingReader struct {
+	r io.Reader
+	n uint64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += uint64(n)
+	return n, err
+}
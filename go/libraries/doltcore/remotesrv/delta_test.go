@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/rsync"
+)
+
+func TestDeltaReader(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	baseData := make([]byte, 64*rsync.MinBlockSize)
+	r.Read(baseData)
+	basePath := filepath.Join(t.TempDir(), "base")
+	require.NoError(t, os.WriteFile(basePath, baseData, 0644))
+
+	target := append([]byte("new header"), baseData[100:]...)
+	cache := newSignatureCache()
+	sig, err := cache.get(basePath, rsync.MinBlockSize)
+	require.NoError(t, err)
+	var delta bytes.Buffer
+	require.NoError(t, rsync.ComputeDelta(sig, bytes.NewReader(target), &delta))
+	require.Less(t, delta.Len(), len(target)/4)
+
+	base, _, err := openFile(basePath)
+	require.NoError(t, err)
+	rd := deltaReader(base, bytes.NewReader(delta.Bytes()), uint64(delta.Len()))
+	got, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(target, got))
+	require.NoError(t, rd.Close())
+
+	base, _, err = openFile(basePath)
+	require.NoError(t, err)
+	rd = deltaReader(base, bytes.NewReader(append(delta.Bytes(), 0)), uint64(delta.Len()))
+	_, err = io.ReadAll(rd)
+	assert.ErrorIs(t, err, rsync.ErrInvalidDelta)
+}
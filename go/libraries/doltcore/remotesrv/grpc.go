@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/url"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -54,6 +55,8 @@ type RemoteChunkStore struct {
 	fs      filesys.Filesys
 	lgr     *logrus.Entry
 	sealer  Sealer
+
+	signatures *signatureCache
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
@@ -71,7 +74,8 @@ func NewHttpFSBackedChunkStore(lgr *logrus.Entry, httpHost string, csCache DBCac
 		lgr: lgr.WithFields(logrus.Fields{
 			"service": "dolt.services.remotesapi.v1alpha1.ChunkStoreServiceServer",
 		}),
-		sealer: sealer,
+		sealer:     sealer,
+		signatures: newSignatureCache(),
 	}
 }
 
@@ -209,6 +213,9 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 		}).Trace("generated sealed url")
 
 		getRange := &remotesapi.HttpGetRange{Url: url.String(), Ranges: ranges}
+		if req.ProtocolVersion >= remotestorage.MinimalSpansProtocolVersion {
+			getRange.Spans = getRangeSpans(ranges)
+		}
 		locs = append(locs, &remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: getRange}})
 	}
 
@@ -312,6 +319,9 @@ func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStore
 			}).Trace("generated sealed url")
 
 			getRange := &remotesapi.HttpGetRange{Url: url.String(), Ranges: ranges}
+			if req.ProtocolVersion >= remotestorage.MinimalSpansProtocolVersion {
+				getRange.Spans = getRangeSpans(ranges)
+			}
 			locs = append(locs, &remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: getRange}})
 		}
 
@@ -321,6 +331,32 @@ func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStore
 	}
 }
 
+// getRangeSpans returns the minimal byte ranges which cover |ranges|, in order of offset. Only adjacent or
+// overlapping ranges are merged, so the spans contain no bytes besides the bytes of |ranges|.
+func getRangeSpans(ranges []*remotesapi.RangeChunk) []*remotesapi.ByteRange {
+	sorted := make([]*remotesapi.RangeChunk, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	var spans []*remotesapi.ByteRange
+	for _, r := range sorted {
+		end := r.Offset + uint64(r.Length)
+		if len(spans) > 0 {
+			last := spans[len(spans)-1]
+			if r.Offset <= last.Offset+last.Length {
+				if end > last.Offset+last.Length {
+					last.Length = end - last.Offset
+				}
+				continue
+			}
+		}
+		spans = append(spans, &remotesapi.ByteRange{Offset: r.Offset, Length: uint64(r.Length)})
+	}
+	return spans
+}
+
 func (rs *RemoteChunkStore) getHost(md metadata.MD) string {
 	host := rs.HttpHost
 	if strings.HasPrefix(rs.HttpHost, ":") {
@@ -384,7 +420,7 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	logger = logger.WithField(RepoPathField, repoPath)
 	defer func() { logger.Info("finished") }()
 
-	_, err := rs.getStore(ctx, logger, repoPath)
+	cs, err := rs.getStore(ctx, logger, repoPath)
 	if err != nil {
 		return nil, err
 	}
@@ -396,7 +432,15 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	var locs []*remotesapi.UploadLoc
 	for _, tfd := range tfds {
 		h := hash.New(tfd.Id)
-		url := rs.getUploadUrl(md, repoPath, tfd)
+		var deltaBase string
+		if tfd.DeltaBaseId != "" {
+			deltaBase, err = rs.getDeltaBasePath(ctx, cs, tfd.DeltaBaseId)
+			if err != nil {
+				logger.WithError(err).Warn("error getting delta base")
+				return nil, err
+			}
+		}
+		url := rs.getUploadUrl(md, repoPath, tfd, deltaBase)
 		url, err = rs.sealer.Seal(url)
 		if err != nil {
 			logger.WithError(err).Error("error sealing upload url")
@@ -419,12 +463,16 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	return &remotesapi.GetUploadLocsResponse{Locs: locs}, nil
 }
 
-func (rs *RemoteChunkStore) getUploadUrl(md metadata.MD, repoPath string, tfd *remotesapi.TableFileDetails) *url.URL {
+func (rs *RemoteChunkStore) getUploadUrl(md metadata.MD, repoPath string, tfd *remotesapi.TableFileDetails, deltaBase string) *url.URL {
 	fileID := hash.New(tfd.Id).String()
 	params := url.Values{}
 	params.Add("num_chunks", strconv.Itoa(int(tfd.NumChunks)))
 	params.Add("content_length", strconv.Itoa(int(tfd.ContentLength)))
 	params.Add("content_hash", base64.RawURLEncoding.EncodeToString(tfd.ContentHash))
+	if deltaBase != "" {
+		params.Add("delta_base", deltaBase)
+		params.Add("delta_length", strconv.FormatUint(tfd.DeltaLength, 10))
+	}
 	return &url.URL{
 		Scheme:   rs.httpScheme,
 		Host:     rs.getHost(md),
@@ -697,6 +745,10 @@ func (rs ReadOnlyChunkStore) GetUploadLocations(ctx context.Context, req *remote
 	return nil, status.Error(codes.PermissionDenied, "this server only provides read-only access")
 }
 
+func (rs ReadOnlyChunkStore) GetTableFileSignature(ctx context.Context, req *remotesapi.GetTableFileSignatureRequest) (*remotesapi.GetTableFileSignatureResponse, error) {
+	return nil, status.Error(codes.PermissionDenied, "this server only provides read-only access")
+}
+
 func (rs ReadOnlyChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.AddTableFilesRequest) (*remotesapi.AddTableFilesResponse, error) {
 	return nil, status.Error(codes.PermissionDenied, "this server only provides read-only access")
 }
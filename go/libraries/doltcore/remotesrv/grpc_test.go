@@ -19,6 +19,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/metadata"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 )
 
 func TestGRPCSchemeSelection(t *testing.T) {
@@ -34,3 +36,21 @@ func TestGRPCSchemeSelection(t *testing.T) {
 	scheme = rs.getScheme(md)
 	assert.Equal(t, scheme, "https")
 }
+
+func TestGetRangeSpans(t *testing.T) {
+	ranges := []*remotesapi.RangeChunk{
+		{Offset: 300, Length: 50},
+		{Offset: 0, Length: 100},
+		{Offset: 100, Length: 20},
+		{Offset: 1000, Length: 10},
+		{Offset: 350, Length: 10},
+	}
+	spans := getRangeSpans(ranges)
+	assert.Equal(t, []*remotesapi.ByteRange{
+		{Offset: 0, Length: 120},
+		{Offset: 300, Length: 60},
+		{Offset: 1000, Length: 10},
+	}, spans)
+	assert.Equal(t, uint64(300), ranges[0].Offset)
+	assert.Nil(t, getRangeSpans(nil))
+}
@@ -34,6 +34,7 @@ import (
 	"google.golang.org/grpc/peer"
 
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/rsync"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -170,7 +171,16 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 			return
 		}
 
-		logger, statusCode = writeTableFile(req.Context(), logger, fh.dbCache, filepath, file, num_chunks, content_hash, uint64(content_length), req.Body)
+		body := req.Body
+		if deltaBase := q.Get("delta_base"); deltaBase != "" {
+			logger, body, statusCode = fh.openDeltaUpload(logger, deltaBase, q.Get("delta_length"), req.Body)
+			if statusCode != -1 {
+				respWr.WriteHeader(statusCode)
+				return
+			}
+		}
+
+		logger, statusCode = writeTableFile(req.Context(), logger, fh.dbCache, filepath, file, num_chunks, content_hash, uint64(content_length), body)
 	}
 
 	if statusCode != -1 {
@@ -178,6 +188,47 @@ func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// openDeltaUpload returns a reader of the table file reconstructed from the delta in |body| against the table file at
+// |deltaBase|, or a status code other than -1 if the delta upload is invalid.
+func (fh filehandler) openDeltaUpload(logger *logrus.Entry, deltaBase, deltaLengthStr string, body io.Reader) (*logrus.Entry, io.ReadCloser, int) {
+	logger = logger.WithField("delta_base", deltaBase)
+	deltaLength, err := strconv.ParseUint(deltaLengthStr, 10, 64)
+	if err != nil {
+		logger = logger.WithField("status", http.StatusBadRequest)
+		logger.WithError(err).Warn("bad request: delta_length parameter did not parse")
+		return logger, nil, http.StatusBadRequest
+	}
+	deltaBase = filepath.Clean(deltaBase)
+	if strings.HasPrefix(deltaBase, "../") || strings.Contains(deltaBase, "/../") || strings.HasSuffix(deltaBase, "/..") {
+		logger = logger.WithField("status", http.StatusBadRequest)
+		logger.Warn("bad request with .. in delta_base")
+		return logger, nil, http.StatusBadRequest
+	}
+	if _, ok := hash.MaybeParse(filepath.Base(deltaBase)); !ok {
+		logger = logger.WithField("status", http.StatusBadRequest)
+		logger.Warn("bad request: delta_base is not a table file")
+		return logger, nil, http.StatusBadRequest
+	}
+	abs, err := fh.fs.Abs(deltaBase)
+	if err != nil {
+		logger = logger.WithField("status", http.StatusInternalServerError)
+		logger.WithError(err).Error("could not get absolute path")
+		return logger, nil, http.StatusInternalServerError
+	}
+	base, _, err := openFile(abs)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger = logger.WithField("status", http.StatusNotFound)
+			logger.Warn("delta base not found")
+			return logger, nil, http.StatusNotFound
+		}
+		logger = logger.WithField("status", http.StatusInternalServerError)
+		logger.WithError(err).Error("could not open delta base")
+		return logger, nil, http.StatusInternalServerError
+	}
+	return logger, deltaReader(base, body, deltaLength), -1
+}
+
 func readTableFile(logger *logrus.Entry, path string, respWr http.ResponseWriter, rangeStr string) (*logrus.Entry, int) {
 	var r io.ReadCloser
 	var readSize int64
@@ -318,6 +369,11 @@ func writeTableFile(ctx context.Context, logger *logrus.Entry, dbCache DBCache,
 			logger.Warn("bad request: body hash mismatch")
 			return logger, http.StatusBadRequest
 		}
+		if errors.Is(err, rsync.ErrInvalidDelta) {
+			logger = logger.WithField("status", http.StatusBadRequest)
+			logger.WithError(err).Warn("bad request: invalid delta")
+			return logger, http.StatusBadRequest
+		}
 		logger = logger.WithField("status", http.StatusInternalServerError)
 		logger.WithError(err).Error("failed to write upload to table file")
 		return logger, http.StatusInternalServerError
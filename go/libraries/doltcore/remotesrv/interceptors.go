@@ -36,15 +36,17 @@ type ServerInterceptor struct {
 }
 
 var SUPER_USER_RPC_METHODS = map[string]bool{
-	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/AddTableFiles":      true,
-	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Commit":             true,
-	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetUploadLocations": true,
+	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/AddTableFiles":         true,
+	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Commit":                true,
+	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetTableFileSignature": true,
+	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetUploadLocations":    true,
 }
 
 var CLONE_ADMIN_RPC_METHODS = map[string]bool{
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetDownloadLocations":    true,
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetRepoMetadata":         true,
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/HasChunks":               true,
+	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/ListRefs":                true,
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/ListTableFiles":          true,
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/RefreshTableFileUrl":     true,
 	"/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Root":                    true,
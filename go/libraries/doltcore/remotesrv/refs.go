@@ -0,0 +1,78 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func (rs *RemoteChunkStore) ListRefs(ctx context.Context, req *remotesapi.ListRefsRequest) (*remotesapi.ListRefsResponse, error) {
+	logger := getReqLogger(rs.lgr, "ListRefs")
+	if err := ValidateListRefsRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	repoPath := getRepoPath(req)
+	logger = logger.WithField(RepoPathField, repoPath)
+	defer func() { logger.Info("finished") }()
+
+	cs, err := rs.getStore(ctx, logger, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var root hash.Hash
+	if len(req.RootHash) > 0 {
+		root = hash.New(req.RootHash)
+		ok, err := cs.Has(ctx, root)
+		if err != nil {
+			logger.WithError(err).Error("error calling Has on chunk store.")
+			return nil, status.Error(codes.Internal, "Failed to find root")
+		}
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "root %s not found", root.String())
+		}
+	} else {
+		root, err = cs.Root(ctx)
+		if err != nil {
+			logger.WithError(err).Error("error calling Root on chunk store.")
+			return nil, status.Error(codes.Internal, "Failed to get root")
+		}
+	}
+	if root.IsEmpty() {
+		return &remotesapi.ListRefsResponse{}, nil
+	}
+
+	var refs []*remotesapi.RefInfo
+	ddb := doltdb.DoltDBFromCS(cs, "")
+	err = ddb.VisitRefsMatchingByNomsRoot(ctx, req.Patterns, root, func(r ref.DoltRef, addr hash.Hash) error {
+		refs = append(refs, &remotesapi.RefInfo{Name: r.String(), Hash: addr[:]})
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error("error listing refs")
+		return nil, status.Error(codes.Internal, "Failed to list refs")
+	}
+	logger = logger.WithField("num_refs", len(refs))
+
+	return &remotesapi.ListRefsResponse{Refs: refs}, nil
+}
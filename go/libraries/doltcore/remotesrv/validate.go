@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/rsync"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -96,7 +98,25 @@ func ValidateGetUploadLocsRequest(req *remotesapi.GetUploadLocsRequest) error {
 	if err := validateRepoRequest(req); err != nil {
 		return err
 	}
-	return validateHashes("table_file_hashes", req.TableFileHashes)
+	if err := validateHashes("table_file_hashes", req.TableFileHashes); err != nil {
+		return err
+	}
+	return validateTableFileDetails("table_file_details", req.TableFileDetails)
+}
+
+func validateTableFileDetails(field string, tfds []*remotesapi.TableFileDetails) error {
+	for i, tfd := range tfds {
+		if tfd.DeltaBaseId == "" {
+			continue
+		}
+		if _, ok := hash.MaybeParse(tfd.DeltaBaseId); !ok {
+			return fmt.Errorf("expected %s[%d].DeltaBaseId to be a table file id, was %q", field, i, tfd.DeltaBaseId)
+		}
+		if tfd.DeltaLength == 0 {
+			return fmt.Errorf("expected %s[%d].DeltaLength to be non-zero", field, i)
+		}
+	}
+	return nil
 }
 
 func ValidateRebaseRequest(req *remotesapi.RebaseRequest) error {
@@ -155,3 +175,32 @@ func ValidateAddTableFilesRequest(req *remotesapi.AddTableFilesRequest) error {
 	}
 	return nil
 }
+
+func ValidateGetTableFileSignatureRequest(req *remotesapi.GetTableFileSignatureRequest) error {
+	if err := validateRepoRequest(req); err != nil {
+		return err
+	}
+	if req.BlockSize != 0 {
+		if err := rsync.ValidateBlockSize(int(req.BlockSize)); err != nil {
+			return fmt.Errorf("unsupported value for block_size: %w", err)
+		}
+	}
+	return nil
+}
+
+func ValidateListRefsRequest(req *remotesapi.ListRefsRequest) error {
+	if err := validateRepoRequest(req); err != nil {
+		return err
+	}
+	if len(req.RootHash) > 0 {
+		if err := validateHash("root", req.RootHash); err != nil {
+			return err
+		}
+	}
+	for i, pattern := range req.Patterns {
+		if _, err := ref.NewGlob(pattern); err != nil {
+			return fmt.Errorf("invalid patterns[%d] '%s': %w", i, pattern, err)
+		}
+	}
+	return nil
+}
@@ -256,6 +256,18 @@ func TestValidateGetUploadLocsRequest(t *testing.T) {
 			RepoPath:        GoodRepoPath,
 			TableFileHashes: [][]byte{GoodHash, GoodHash, LongHash, GoodHash},
 		},
+		{
+			RepoPath: GoodRepoPath,
+			TableFileDetails: []*remotesapi.TableFileDetails{
+				{Id: GoodHash, DeltaBaseId: "not a file id", DeltaLength: 10},
+			},
+		},
+		{
+			RepoPath: GoodRepoPath,
+			TableFileDetails: []*remotesapi.TableFileDetails{
+				{Id: GoodHash, DeltaBaseId: hash.Of([]byte("base")).String()},
+			},
+		},
 	} {
 		t.Run(fmt.Sprintf("Error #%02d", i), func(t *testing.T) {
 			assert.Error(t, ValidateGetUploadLocsRequest(errMsg), "%v should not validate", errMsg)
@@ -272,6 +284,12 @@ func TestValidateGetUploadLocsRequest(t *testing.T) {
 			RepoPath:        GoodRepoPath,
 			TableFileHashes: [][]byte{GoodHash},
 		},
+		{
+			RepoPath: GoodRepoPath,
+			TableFileDetails: []*remotesapi.TableFileDetails{
+				{Id: GoodHash, DeltaBaseId: hash.Of([]byte("base")).String(), DeltaLength: 10},
+			},
+		},
 	} {
 		t.Run(fmt.Sprintf("NoError #%02d", i), func(t *testing.T) {
 			assert.NoError(t, ValidateGetUploadLocsRequest(msg), "%v should validate", msg)
@@ -534,3 +552,67 @@ func TestValidateAddTableFilesRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGetTableFileSignatureRequest(t *testing.T) {
+	for i, errMsg := range []*remotesapi.GetTableFileSignatureRequest{
+		{},
+		{
+			RepoId: &remotesapi.RepoId{
+				Org: "dolthub",
+			},
+		},
+		{
+			RepoPath:  GoodRepoPath,
+			BlockSize: 10,
+		},
+	} {
+		t.Run(fmt.Sprintf("Error #%02d", i), func(t *testing.T) {
+			assert.Error(t, ValidateGetTableFileSignatureRequest(errMsg), "%v should not validate", errMsg)
+		})
+	}
+	for i, msg := range []*remotesapi.GetTableFileSignatureRequest{
+		{
+			RepoPath: GoodRepoPath,
+		},
+		{
+			RepoId:    GoodRepoId,
+			BlockSize: 64 * 1024,
+		},
+	} {
+		t.Run(fmt.Sprintf("NoError #%02d", i), func(t *testing.T) {
+			assert.NoError(t, ValidateGetTableFileSignatureRequest(msg), "%v should validate", msg)
+		})
+	}
+}
+
+func TestValidateListRefsRequest(t *testing.T) {
+	for i, errMsg := range []*remotesapi.ListRefsRequest{
+		{},
+		{
+			RepoPath: GoodRepoPath,
+			RootHash: ShortHash,
+		},
+		{
+			RepoPath: GoodRepoPath,
+			Patterns: []string{"refs/heads/[release"},
+		},
+	} {
+		t.Run(fmt.Sprintf("Error #%02d", i), func(t *testing.T) {
+			assert.Error(t, ValidateListRefsRequest(errMsg), "%v should not validate", errMsg)
+		})
+	}
+	for i, msg := range []*remotesapi.ListRefsRequest{
+		{
+			RepoPath: GoodRepoPath,
+		},
+		{
+			RepoId:   GoodRepoId,
+			RootHash: GoodHash,
+			Patterns: []string{"refs/heads/release/*", "refs/tags/v1.?"},
+		},
+	} {
+		t.Run(fmt.Sprintf("NoError #%02d", i), func(t *testing.T) {
+			assert.NoError(t, ValidateListRefsRequest(msg), "%v should validate", msg)
+		})
+	}
+}
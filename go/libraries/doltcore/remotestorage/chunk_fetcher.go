@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage/internal/pool"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage/internal/ranges"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage/internal/reliable"
+	"github.com/dolthub/dolt/go/libraries/utils/concurrency"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 )
@@ -187,7 +188,7 @@ func fetcherHashSetToGetDlLocsReqsThread(ctx context.Context, reqCh chan hash.Ha
 			}
 			outbound = append(outbound[:0], addrs[st:end]...)
 			id, token := idFunc()
-			thisRes = &remotesapi.GetDownloadLocsRequest{RepoId: id, RepoPath: repoPath, RepoToken: token, ChunkHashes: outbound[:]}
+			thisRes = &remotesapi.GetDownloadLocsRequest{RepoId: id, RepoPath: repoPath, RepoToken: token, ChunkHashes: outbound[:], ProtocolVersion: DownloadProtocolVersion}
 			thisResCh = resCh
 		}
 
@@ -338,9 +339,12 @@ type fetchReq struct {
 }
 
 // A simple structure to keep track of *GetRange requests along with
-// |locationRefreshes| for the URL paths we have seen.
+// |locationRefreshes| for the URL paths we have seen. Locations with
+// minimal |Spans| are downloaded span by span, and the ranges of other
+// locations are coalesced in |ranges|.
 type downloads struct {
 	ranges    *ranges.Tree
+	spans     []*GetRange
 	refreshes map[string]*locationRefresh
 }
 
@@ -351,7 +355,7 @@ func newDownloads() downloads {
 	}
 }
 
-func (d downloads) Add(resp *remotesapi.DownloadLoc) {
+func (d *downloads) Add(resp *remotesapi.DownloadLoc) {
 	gr := (*GetRange)(resp.Location.(*remotesapi.DownloadLoc_HttpGetRange).HttpGetRange)
 	path := gr.ResourcePath()
 	if v, ok := d.refreshes[path]; ok {
@@ -361,11 +365,64 @@ func (d downloads) Add(resp *remotesapi.DownloadLoc) {
 		refresh.Add(resp)
 		d.refreshes[path] = refresh
 	}
+	if len(gr.Spans) > 0 {
+		d.spans = append(d.spans, spanGetRanges(gr)...)
+		return
+	}
 	for _, r := range gr.Ranges {
 		d.ranges.Insert(gr.Url, r.Hash, r.Offset, r.Length)
 	}
 }
 
+// Len returns the number of chunks left to download.
+func (d *downloads) Len() int {
+	n := d.ranges.Len()
+	for _, gr := range d.spans {
+		n += len(gr.Ranges)
+	}
+	return n
+}
+
+// Next returns the next download to start, or |nil| if there is none.
+func (d *downloads) Next() *GetRange {
+	if len(d.spans) > 0 {
+		gr := d.spans[0]
+		d.spans = d.spans[1:]
+		return gr
+	}
+	max := d.ranges.DeleteMaxRegion()
+	if len(max) == 0 {
+		return nil
+	}
+	return toGetRange(max)
+}
+
+// spanGetRanges splits |gr| into one *GetRange for each of its |Spans|,
+// with the chunk ranges which fall in the span.
+func spanGetRanges(gr *GetRange) []*GetRange {
+	sorted := &GetRange{Url: gr.Url, Ranges: make([]*remotesapi.RangeChunk, len(gr.Ranges))}
+	copy(sorted.Ranges, gr.Ranges)
+	sorted.Sort()
+
+	ret := make([]*GetRange, 0, len(gr.Spans))
+	i := 0
+	for _, span := range gr.Spans {
+		spanGr := &GetRange{Url: gr.Url}
+		for ; i < len(sorted.Ranges) && sorted.Ranges[i].Offset < span.Offset+span.Length; i++ {
+			spanGr.Ranges = append(spanGr.Ranges, sorted.Ranges[i])
+		}
+		if len(spanGr.Ranges) > 0 {
+			ret = append(ret, spanGr)
+		}
+	}
+	// Ranges beyond the last span, which a well behaved server never returns,
+	// are still downloaded.
+	if i < len(sorted.Ranges) {
+		ret = append(ret, &GetRange{Url: gr.Url, Ranges: sorted.Ranges[i:]})
+	}
+	return ret
+}
+
 func toGetRange(rs []*ranges.GetRange) *GetRange {
 	ret := new(GetRange)
 	for _, r := range rs {
@@ -392,13 +449,12 @@ func fetcherDownloadRangesThread(ctx context.Context, locCh chan []*remotesapi.D
 			// |toSend| could have come from a previous iteration
 			// of this loop or the outer loop. If it's |nil|, we
 			// can get the next range to download from
-			// |downloads.ranges|.
+			// |downloads|.
 			if toSend == nil {
-				max := downloads.ranges.DeleteMaxRegion()
-				if len(max) == 0 {
+				toSend = downloads.Next()
+				if toSend == nil {
 					break
 				}
-				toSend = toGetRange(max)
 			}
 			path := toSend.ResourcePath()
 			refresh := downloads.refreshes[path]
@@ -443,7 +499,7 @@ func fetcherDownloadRangesThread(ctx context.Context, locCh chan []*remotesapi.D
 		// nil and our ranges Tree is empty, then we have delivered
 		// every download we will ever see to a download thread. We can
 		// close |doneCh| and return nil.
-		if locCh == nil && downloads.ranges.Len() == 0 {
+		if locCh == nil && downloads.Len() == 0 {
 			close(doneCh)
 			return nil
 		}
@@ -466,6 +522,7 @@ func fetcherDownloadRangesThread(ctx context.Context, locCh chan []*remotesapi.D
 }
 
 type ConcurrencyControl struct {
+	MinConcurrency int
 	MaxConcurrency int
 
 	failures  atomic.Int64
@@ -490,7 +547,8 @@ type SizeSetter interface {
 // it will call |SetSize| with a new size that's 1/2 the current size. If there
 // have been no failures in the last update window, but there has been at least
 // one success, it will call |SetSize| with a size 1 greater than the current
-// size. Will not scale size greater than |MaxConcurrency|.
+// size. Will not scale size greater than |MaxConcurrency| or less than
+// |MinConcurrency|.
 func (cc *ConcurrencyControl) Run(ctx context.Context, done <-chan struct{}, ss SizeSetter, sz int) error {
 	var justDecreased bool
 	const (
@@ -503,8 +561,11 @@ func (cc *ConcurrencyControl) Run(ctx context.Context, done <-chan struct{}, ss
 		select {
 		case <-time.After(next):
 			f := cc.failures.Load()
-			if f > 0 && !justDecreased {
+			if f > 0 && !justDecreased && sz > cc.MinConcurrency {
 				sz = (sz + 1) / 2
+				if sz < cc.MinConcurrency {
+					sz = cc.MinConcurrency
+				}
 				ss.SetSize(sz)
 				justDecreased = true
 				next = backoffConcurrentAdjustmentDuration
@@ -529,13 +590,19 @@ func (cc *ConcurrencyControl) Run(ctx context.Context, done <-chan struct{}, ss
 
 func fetcherDownloadURLThreads(ctx context.Context, fetchReqCh chan fetchReq, doneCh chan struct{}, chunkCh chan nbs.CompressedChunk, client remotesapi.ChunkStoreServiceClient, stats StatsRecorder, fetcher HTTPFetcher, params NetworkRequestParams) error {
 	eg, ctx := errgroup.WithContext(ctx)
+	limits, fixed := concurrency.LimitsFor(concurrency.Fetch, concurrency.Limits{
+		Min:     1,
+		Max:     params.MaximumConcurrentDownloads,
+		Initial: params.StartingConcurrentDownloads,
+	})
 	cc := &ConcurrencyControl{
-		MaxConcurrency: params.MaximumConcurrentDownloads,
+		MinConcurrency: limits.Min,
+		MaxConcurrency: limits.Max,
 	}
 	f := func(ctx context.Context, shutdownCh <-chan struct{}) error {
 		return fetcherDownloadURLThread(ctx, fetchReqCh, shutdownCh, chunkCh, client, stats, cc, fetcher, params)
 	}
-	threads := pool.NewDynamic(ctx, f, params.StartingConcurrentDownloads)
+	threads := pool.NewDynamic(ctx, f, limits.Initial)
 	eg.Go(func() error {
 		return threads.Run()
 	})
@@ -548,9 +615,11 @@ func fetcherDownloadURLThreads(ctx context.Context, fetchReqCh chan fetchReq, do
 		}
 		return nil
 	})
-	eg.Go(func() error {
-		return cc.Run(ctx, doneCh, threads, params.StartingConcurrentDownloads)
-	})
+	if !fixed {
+		eg.Go(func() error {
+			return cc.Run(ctx, doneCh, threads, limits.Initial)
+		})
+	}
 	err := eg.Wait()
 	if err != nil {
 		return err
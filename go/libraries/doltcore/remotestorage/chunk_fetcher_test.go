@@ -114,6 +114,43 @@ func TestFetcherHashSetToGetDlLocsReqsThread(t *testing.T) {
 	})
 }
 
+func TestDownloadsSpans(t *testing.T) {
+	d := newDownloads()
+	d.Add(&remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: &remotesapi.HttpGetRange{
+		Url: "http://localhost/a",
+		Ranges: []*remotesapi.RangeChunk{
+			{Hash: []byte{3}, Offset: 4096, Length: 10},
+			{Hash: []byte{1}, Offset: 0, Length: 10},
+			{Hash: []byte{2}, Offset: 10, Length: 10},
+		},
+		Spans: []*remotesapi.ByteRange{{Offset: 0, Length: 20}, {Offset: 4096, Length: 10}},
+	}}})
+	d.Add(&remotesapi.DownloadLoc{Location: &remotesapi.DownloadLoc_HttpGetRange{HttpGetRange: &remotesapi.HttpGetRange{
+		Url: "http://localhost/b",
+		Ranges: []*remotesapi.RangeChunk{
+			{Hash: []byte{4}, Offset: 0, Length: 10},
+			{Hash: []byte{5}, Offset: 4096, Length: 10},
+		},
+	}}})
+	assert.Equal(t, 5, d.Len())
+
+	// The spans are downloaded as they are...
+	gr := d.Next()
+	assert.Equal(t, "http://localhost/a", gr.Url)
+	assert.Equal(t, uint64(20), gr.RangeLen())
+	assert.Equal(t, 2, gr.NumChunks())
+	gr = d.Next()
+	assert.Equal(t, uint64(4096), gr.ChunkStartOffset(0))
+	assert.Equal(t, uint64(10), gr.RangeLen())
+
+	// and ranges without spans are coalesced.
+	gr = d.Next()
+	assert.Equal(t, "http://localhost/b", gr.Url)
+	assert.Equal(t, 2, gr.NumChunks())
+	assert.Equal(t, 0, d.Len())
+	assert.Nil(t, d.Next())
+}
+
 func testIdFunc() (*remotesapi.RepoId, string) {
 	return new(remotesapi.RepoId), ""
 }
@@ -36,6 +36,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage/internal/reliable"
@@ -803,6 +805,29 @@ func (dcs *DoltChunkStore) PushConcurrencyControl() chunks.PushConcurrencyContro
 	return chunks.PushConcurrencyControl_IgnoreWorkingSet
 }
 
+// ListRefs calls |cb| with the name and address of each ref of the remote's current root whose name matches any of the
+// glob |patterns|, as filtered by the server. It returns chunks.ErrUnsupportedOperation if the server can't list refs.
+func (dcs *DoltChunkStore) ListRefs(ctx context.Context, patterns []string, cb func(name string, addr hash.Hash) error) error {
+	id, token := dcs.getRepoId()
+	req := &remotesapi.ListRefsRequest{RepoId: id, RepoToken: token, RepoPath: dcs.repoPath, RootHash: dcs.root[:], Patterns: patterns}
+	resp, err := dcs.csClient.ListRefs(ctx, req)
+	if status.Code(err) == codes.Unimplemented {
+		return chunks.ErrUnsupportedOperation
+	} else if err != nil {
+		return NewRpcError(err, "ListRefs", dcs.host, req)
+	}
+	if resp.RepoToken != "" {
+		dcs.repoToken.Store(resp.RepoToken)
+	}
+
+	for _, r := range resp.Refs {
+		if err := cb(r.Name, hash.New(r.Hash)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (dcs *DoltChunkStore) loadRoot(ctx context.Context) error {
 	id, token := dcs.getRepoId()
 	req := &remotesapi.RootRequest{RepoId: id, RepoToken: token, RepoPath: dcs.repoPath}
@@ -936,6 +961,13 @@ func (dcs *DoltChunkStore) uploadChunks(ctx context.Context) (map[hash.Hash]int,
 }
 
 func (dcs *DoltChunkStore) uploadTableFileWithRetries(ctx context.Context, tableFileId hash.Hash, numChunks uint64, tableFileContentHash []byte, getContent func() (io.ReadCloser, uint64, error)) error {
+	uploaded, err := dcs.tryDeltaUpload(ctx, tableFileId, numChunks, tableFileContentHash, getContent)
+	if err != nil {
+		return err
+	} else if uploaded {
+		return nil
+	}
+
 	op := func() error {
 		body, contentLength, err := getContent()
 		if err != nil {
@@ -1040,6 +1072,49 @@ const (
 	chunkAggDistance = 8 * 1024
 )
 
+const (
+	// MinimalSpansProtocolVersion is the first version of the download
+	// protocol in which servers return the minimal byte spans of each
+	// HttpGetRange. Clients fetch the spans as they are, instead of
+	// coalescing nearby ranges and fetching the chunks in between.
+	MinimalSpansProtocolVersion = 2
+
+	// DownloadProtocolVersion is the version of the download protocol
+	// which DoltChunkStore speaks.
+	DownloadProtocolVersion = MinimalSpansProtocolVersion
+)
+
+// ServerDownloadProtocolVersion returns the version of the download protocol
+// which the server speaks, which it finds by asking for the download location
+// of the root chunk. It returns 0 when the remote is empty, since there is no
+// chunk to ask for.
+func (dcs *DoltChunkStore) ServerDownloadProtocolVersion(ctx context.Context) (int, error) {
+	if dcs.root.IsEmpty() {
+		return 0, nil
+	}
+	id, token := dcs.getRepoId()
+	req := &remotesapi.GetDownloadLocsRequest{
+		RepoId:          id,
+		RepoPath:        dcs.repoPath,
+		RepoToken:       token,
+		ChunkHashes:     [][]byte{dcs.root[:]},
+		ProtocolVersion: DownloadProtocolVersion,
+	}
+	resp, err := dcs.csClient.GetDownloadLocations(ctx, req)
+	if err != nil {
+		return 0, NewRpcError(err, "GetDownloadLocations", dcs.host, req)
+	}
+	for _, loc := range resp.Locs {
+		if getRange := loc.GetHttpGetRange(); getRange != nil {
+			if len(getRange.Spans) > 0 {
+				return MinimalSpansProtocolVersion, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
 func (dcs *DoltChunkStore) SupportedOperations() chunks.TableFileStoreOps {
 	return chunks.TableFileStoreOps{
 		CanRead:  true,
@@ -0,0 +1,146 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/rsync"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// minDeltaUploadSize is the size of the smallest table file uploaded as a delta. Smaller table files are uploaded
+// as they are, since the signature of the delta base would be about as large as they are.
+const minDeltaUploadSize = 4 * 1024 * 1024
+
+// deltaUploadBlockSize is the block size of the delta base signatures requested by a DoltChunkStore.
+const deltaUploadBlockSize = 16 * 1024
+
+// isDeltaWorthwhile returns true if a delta of |deltaLength| bytes is sufficiently smaller than the |contentLength|
+// bytes of the table file it encodes to be uploaded instead of the table file.
+func isDeltaWorthwhile(deltaLength, contentLength uint64) bool {
+	return deltaLength < contentLength-contentLength/4
+}
+
+// tryDeltaUpload attempts to upload the table file |tableFileId| as a delta against a table file the remote already
+// has. It returns false, and no error, if the table file should be uploaded as it is instead, because the remote
+// doesn't support delta uploads, because the delta isn't much smaller than the table file, or because the delta
+// upload failed.
+func (dcs *DoltChunkStore) tryDeltaUpload(ctx context.Context, tableFileId hash.Hash, numChunks uint64, tableFileContentHash []byte, getContent func() (io.ReadCloser, uint64, error)) (bool, error) {
+	body, contentLength, err := getContent()
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+	if contentLength < minDeltaUploadSize {
+		return false, nil
+	}
+
+	id, token := dcs.getRepoId()
+	sigReq := &remotesapi.GetTableFileSignatureRequest{RepoId: id, RepoToken: token, RepoPath: dcs.repoPath, BlockSize: deltaUploadBlockSize}
+	sigResp, err := dcs.csClient.GetTableFileSignature(ctx, sigReq)
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			dcs.logf("failed to get a delta base for file %s, err: %v", tableFileId.String(), err)
+		}
+		return false, nil
+	}
+	if sigResp.RepoToken != "" {
+		dcs.repoToken.Store(sigResp.RepoToken)
+	}
+	if sigResp.TableFileId == "" {
+		return false, nil
+	}
+	sig, err := rsync.NewSignature(int(sigResp.BlockSize), sigResp.WeakChecksums, sigResp.StrongChecksums)
+	if err != nil {
+		dcs.logf("invalid signature of delta base %s, err: %v", sigResp.TableFileId, err)
+		return false, nil
+	}
+
+	f, err := tempfiles.MovableTempFileProvider.NewFile("", "dolt_delta_upload_*")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+	if err := rsync.ComputeDelta(sig, body, f); err != nil {
+		return false, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	deltaLength := uint64(info.Size())
+	if !isDeltaWorthwhile(deltaLength, contentLength) {
+		dcs.logf("delta of file %s against %s is %d bytes of %d, uploading the file instead", tableFileId.String(), sigResp.TableFileId, deltaLength, contentLength)
+		return false, nil
+	}
+
+	op := func() error {
+		tbfd := &remotesapi.TableFileDetails{
+			Id:            tableFileId[:],
+			ContentLength: contentLength,
+			ContentHash:   tableFileContentHash,
+			NumChunks:     numChunks,
+			DeltaBaseId:   sigResp.TableFileId,
+			DeltaLength:   deltaLength,
+		}
+		id, token := dcs.getRepoId()
+		req := &remotesapi.GetUploadLocsRequest{RepoId: id, RepoToken: token, RepoPath: dcs.repoPath, TableFileDetails: []*remotesapi.TableFileDetails{tbfd}}
+		resp, err := dcs.csClient.GetUploadLocations(ctx, req)
+		if err != nil {
+			err := NewRpcError(err, "GetUploadLocations", dcs.host, req)
+			if err.IsPermanent() {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		if resp.RepoToken != "" {
+			dcs.repoToken.Store(resp.RepoToken)
+		}
+		if len(resp.Locs) != 1 {
+			return NewRpcError(errors.New("unexpected upload location count"), "GetUploadLocations", dcs.host, req)
+		}
+		post, ok := resp.Locs[0].Location.(*remotesapi.UploadLoc_HttpPost)
+		if !ok {
+			return backoff.Permanent(errors.New("unexpected upload location type"))
+		}
+
+		// The Content-MD5 of a delta body isn't the content hash of the table file, so it is left out.
+		delta := io.NopCloser(io.NewSectionReader(f, 0, int64(deltaLength)))
+		return dcs.httpPostUpload(ctx, post.HttpPost, nil, int64(deltaLength), delta)
+	}
+	dcs.logf("uploading file %s as a delta of %d bytes against %s", tableFileId.String(), deltaLength, sigResp.TableFileId)
+	if err := backoff.Retry(op, uploadBackOff(ctx, dcs.params.UploadRetryCount)); err != nil {
+		if ctx.Err() != nil {
+			return false, context.Cause(ctx)
+		}
+		dcs.logf("failed to upload file %s as a delta, err: %v", tableFileId.String(), err)
+		return false, nil
+	}
+	dcs.logf("successfully uploaded file %s as a delta", tableFileId.String())
+	return true, nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransferLimits bounds the network usage of the transfers of a DoltChunkStore, so that pushes and pulls don't
+// saturate the network link of the host.
+type TransferLimits struct {
+	// MaxDownloadBytesPerSec bounds the combined rate of all downloads. Zero is unlimited.
+	MaxDownloadBytesPerSec int64
+	// MaxUploadBytesPerSec bounds the combined rate of all uploads. Zero is unlimited.
+	MaxUploadBytesPerSec int64
+	// MaxConcurrentDownloads bounds the number of chunk ranges downloaded at once. Zero keeps the default.
+	MaxConcurrentDownloads int
+}
+
+// IsZero returns true if |l| doesn't limit anything.
+func (l TransferLimits) IsZero() bool {
+	return l == TransferLimits{}
+}
+
+// WithTransferLimits returns a copy of |dcs| whose transfers are bounded by |limits|.
+func (dcs *DoltChunkStore) WithTransferLimits(limits TransferLimits) *DoltChunkStore {
+	if limits.IsZero() {
+		return dcs
+	}
+
+	params := dcs.params
+	if limits.MaxConcurrentDownloads > 0 {
+		params.MaximumConcurrentDownloads = limits.MaxConcurrentDownloads
+		if params.StartingConcurrentDownloads > limits.MaxConcurrentDownloads {
+			params.StartingConcurrentDownloads = limits.MaxConcurrentDownloads
+		}
+	}
+	if limits.MaxDownloadBytesPerSec > 0 {
+		// A throttled download is slow on purpose, so it must not be retried for being too slow.
+		params.ThroughputMinimumBytesPerCheck = 0
+	}
+
+	fetcher := dcs.httpFetcher
+	if fetcher == nil {
+		fetcher = globalHttpFetcher
+	}
+	fetcher = &throttledFetcher{
+		fetcher:  fetcher,
+		download: newRateLimiter(limits.MaxDownloadBytesPerSec),
+		upload:   newRateLimiter(limits.MaxUploadBytesPerSec),
+	}
+	return dcs.WithNetworkRequestParams(params).WithHTTPFetcher(fetcher)
+}
+
+// throttledFetcher is an HTTPFetcher which throttles the bodies of requests with |upload| and the bodies of responses
+// with |download|.
+type throttledFetcher struct {
+	fetcher  HTTPFetcher
+	download *rateLimiter
+	upload   *rateLimiter
+}
+
+func (f *throttledFetcher) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if f.upload != nil && req.Body != nil && req.Body != http.NoBody {
+		req.Body = throttledReadCloser{ReadCloser: req.Body, ctx: ctx, limiter: f.upload}
+	}
+	resp, err := f.fetcher.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if f.download != nil && resp.Body != nil {
+		resp.Body = throttledReadCloser{ReadCloser: resp.Body, ctx: ctx, limiter: f.download}
+	}
+	return resp, nil
+}
+
+// throttleReadSize is the most bytes read at once by a throttledReadCloser, so that a single read doesn't exceed the
+// rate for long.
+const throttleReadSize = 16 * 1024
+
+type throttledReadCloser struct {
+	io.ReadCloser
+	ctx     context.Context
+	limiter *rateLimiter
+}
+
+func (r throttledReadCloser) Read(p []byte) (int, error) {
+	if len(p) > throttleReadSize {
+		p = p[:throttleReadSize]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		if werr := r.limiter.wait(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// rateLimiter spaces out transfers so that they don't exceed |bytesPerSec| on average. It is shared by every transfer
+// in one direction, so concurrent transfers split the rate between them.
+type rateLimiter struct {
+	bytesPerSec int64
+
+	mu sync.Mutex
+	// next is the time at which the bytes transferred so far are paid for.
+	next time.Time
+}
+
+// newRateLimiter returns a rateLimiter of |bytesPerSec|, or nil if |bytesPerSec| is not positive.
+func newRateLimiter(bytesPerSec int64) *rateLimiter {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &rateLimiter{bytesPerSec: bytesPerSec}
+}
+
+// wait blocks until the |n| bytes just transferred are within the rate of |l|, or |ctx| is done.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	l.next = l.next.Add(time.Duration(int64(n) * int64(time.Second) / l.bytesPerSec))
+	delay := l.next.Sub(now)
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, newRateLimiter(0))
+
+	l := newRateLimiter(64 * 1024)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, l.wait(context.Background(), 8*1024))
+	}
+	// 32KiB at 64KiB/s takes half a second.
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, l.wait(ctx, 64*1024))
+}
+
+func TestThrottledFetcher(t *testing.T) {
+	body := bytes.Repeat([]byte{'x'}, 48*1024)
+	var uploaded int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		uploaded = len(data)
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	f := &throttledFetcher{fetcher: srv.Client(), download: newRateLimiter(96 * 1024)}
+	req, err := http.NewRequest(http.MethodPut, srv.URL, bytes.NewReader(body[:1024]))
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := f.Do(req)
+	require.NoError(t, err)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, body, data)
+	assert.Equal(t, 1024, uploaded)
+	// 48KiB at 96KiB/s takes half a second.
+	assert.GreaterOrEqual(t, time.Since(start), 450*time.Millisecond)
+}
+
+func TestWithTransferLimits(t *testing.T) {
+	dcs := &DoltChunkStore{params: defaultRequestParams}
+	assert.Same(t, dcs, dcs.WithTransferLimits(TransferLimits{}))
+
+	limited := dcs.WithTransferLimits(TransferLimits{MaxDownloadBytesPerSec: 1024, MaxConcurrentDownloads: 4})
+	assert.Equal(t, 4, limited.params.MaximumConcurrentDownloads)
+	assert.Equal(t, 4, limited.params.StartingConcurrentDownloads)
+	assert.Equal(t, 0, limited.params.ThroughputMinimumBytesPerCheck)
+	f, ok := limited.httpFetcher.(*throttledFetcher)
+	require.True(t, ok)
+	assert.NotNil(t, f.download)
+	assert.Nil(t, f.upload)
+}
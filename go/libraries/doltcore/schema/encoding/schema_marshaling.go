@@ -156,6 +156,7 @@ type encodedIndex struct {
 	FullText        bool                `noms:"fulltext,omitempty" json:"fulltext,omitempty"`
 	IsSystemDefined bool                `noms:"hidden,omitempty" json:"hidden,omitempty"` // Was previously named Hidden, do not change noms name
 	PrefixLengths   []uint16            `noms:"prefixLengths,omitempty" json:"prefixLengths,omitempty"`
+	Invisible       bool                `noms:"invisible,omitempty" json:"invisible,omitempty"`
 	FullTextInfo    encodedFullTextInfo `noms:"fulltext_info,omitempty" json:"fulltext_info,omitempty"`
 }
 
@@ -264,6 +265,7 @@ func toSchemaData(sch schema.Schema) (encodedSchemaData, error) {
 			FullText:        index.IsFullText(),
 			IsSystemDefined: !index.IsUserDefined(),
 			PrefixLengths:   index.PrefixLengths(),
+			Invisible:       index.IsInvisible(),
 			FullTextInfo: encodedFullTextInfo{
 				ConfigTable:      props.ConfigTable,
 				PositionTable:    props.PositionTable,
@@ -333,6 +335,7 @@ func (sd encodedSchemaData) decodeSchema() (schema.Schema, error) {
 				IsSpatial:     encodedIndex.Spatial,
 				IsFullText:    encodedIndex.FullText,
 				IsUserDefined: !encodedIndex.IsSystemDefined,
+				IsInvisible:   encodedIndex.Invisible,
 				Comment:       encodedIndex.Comment,
 				FullTextProperties: schema.FullTextProperties{
 					ConfigTable:      encodedIndex.FullTextInfo.ConfigTable,
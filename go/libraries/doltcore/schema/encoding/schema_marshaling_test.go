@@ -279,6 +279,108 @@ func TestSchemaMarshalling(t *testing.T) {
 	}
 }
 
+func TestInvisibleIndexMarshalling(t *testing.T) {
+	ctx := context.Background()
+	for _, nbf := range []*types.NomsBinFormat{types.Format_LD_1, types.Format_DOLT} {
+		t.Run(nbf.VersionString(), func(t *testing.T) {
+			vrw := getTestVRW(nbf)
+			sch := schema.MustSchemaFromCols(schema.NewColCollection(
+				schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+				schema.NewColumn("v1", 1, types.IntKind, false),
+				schema.NewColumn("v2", 2, types.IntKind, false),
+			))
+			_, err := sch.Indexes().AddIndexByColTags("visible_idx", []uint64{1}, nil, schema.IndexProperties{IsUserDefined: true})
+			require.NoError(t, err)
+			_, err = sch.Indexes().AddIndexByColTags("invisible_idx", []uint64{2}, nil, schema.IndexProperties{IsUserDefined: true, IsInvisible: true})
+			require.NoError(t, err)
+
+			v, err := MarshalSchema(ctx, vrw, sch)
+			require.NoError(t, err)
+			s, err := UnmarshalSchema(ctx, nbf, v)
+			require.NoError(t, err)
+			assert.False(t, s.Indexes().GetByName("visible_idx").IsInvisible())
+			assert.True(t, s.Indexes().GetByName("invisible_idx").IsInvisible())
+		})
+	}
+}
+
+func TestTargetNodeSizeMarshalling(t *testing.T) {
+	ctx := context.Background()
+	vrw := getTestVRW(types.Format_DOLT)
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	))
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, types.Format_DOLT, v)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), s.GetTargetNodeSize())
+
+	sch.SetTargetNodeSize(8192)
+	sized, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err = UnmarshalSchema(ctx, types.Format_DOLT, sized)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(8192), s.GetTargetNodeSize())
+}
+
+func TestZoneMapColumnsMarshalling(t *testing.T) {
+	ctx := context.Background()
+	vrw := getTestVRW(types.Format_DOLT)
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("a", 1, types.IntKind, false),
+		schema.NewColumn("b", 2, types.IntKind, false),
+	))
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, types.Format_DOLT, v)
+	require.NoError(t, err)
+	assert.Nil(t, s.GetZoneMapColumns())
+
+	sch.SetZoneMapColumns([]uint64{2, 1})
+	zoned, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	assert.False(t, v.Equals(zoned))
+	s, err = UnmarshalSchema(ctx, types.Format_DOLT, zoned)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 1}, s.GetZoneMapColumns())
+}
+
+func TestStorageLayoutMarshalling(t *testing.T) {
+	ctx := context.Background()
+	vrw := getTestVRW(types.Format_DOLT)
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("a", 1, types.IntKind, false),
+	))
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, types.Format_DOLT, v)
+	require.NoError(t, err)
+	assert.Equal(t, schema.UnspecifiedStorageLayout, s.GetStorageLayout())
+
+	sch.SetStorageLayout(schema.ColumnarStorageLayout)
+	columnar, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	assert.False(t, v.Equals(columnar))
+	s, err = UnmarshalSchema(ctx, types.Format_DOLT, columnar)
+	require.NoError(t, err)
+	assert.Equal(t, schema.ColumnarStorageLayout, s.GetStorageLayout())
+
+	// the row layout is written like an unspecified one
+	sch.SetStorageLayout(schema.RowStorageLayout)
+	rows, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	assert.True(t, v.Equals(rows))
+	s, err = UnmarshalSchema(ctx, types.Format_DOLT, rows)
+	require.NoError(t, err)
+	assert.Equal(t, schema.UnspecifiedStorageLayout, s.GetStorageLayout())
+}
+
 func getTypeinfo(t *testing.T) (ti []typeinfo.TypeInfo) {
 	st := getSqlTypes()
 	ti = make([]typeinfo.TypeInfo, len(st))
@@ -57,6 +57,14 @@ func serializeSchemaAsFlatbuffer(sch schema.Schema) ([]byte, error) {
 	indexes := serializeSecondaryIndexes(b, sch, sch.Indexes().AllIndexes())
 	checks := serializeChecks(b, sch.Checks().AllChecks())
 	comment := b.CreateString(sch.GetComment())
+	var zoneMapColumns fb.UOffsetT
+	if tags := sch.GetZoneMapColumns(); len(tags) > 0 {
+		serial.TableSchemaStartZoneMapColumnsVector(b, len(tags))
+		for i := len(tags) - 1; i >= 0; i-- {
+			b.PrependUint64(tags[i])
+		}
+		zoneMapColumns = b.EndVector(len(tags))
+	}
 
 	var hasFeaturesAfterTryAccessors bool
 	for _, col := range sch.GetAllCols().GetColumns() {
@@ -76,6 +84,19 @@ func serializeSchemaAsFlatbuffer(sch schema.Schema) ([]byte, error) {
 		serial.TableSchemaAddComment(b, comment)
 		hasFeaturesAfterTryAccessors = true
 	}
+	if sch.GetTargetNodeSize() != 0 {
+		// as with zone maps below, only tables with a KEY_BLOCK_SIZE are unreadable to older clients
+		serial.TableSchemaAddTargetNodeSize(b, sch.GetTargetNodeSize())
+	}
+	if zoneMapColumns != 0 {
+		// older clients can't read schemas with fields they don't know, so only tables with zone maps are
+		// unreadable to them
+		serial.TableSchemaAddZoneMapColumns(b, zoneMapColumns)
+	}
+	if sch.GetStorageLayout() == schema.ColumnarStorageLayout {
+		// as with zone maps above, only tables stored column-wise are unreadable to older clients
+		serial.TableSchemaAddColumnar(b, true)
+	}
 	if hasFeaturesAfterTryAccessors {
 		serial.TableSchemaAddHasFeaturesAfterTryAccessors(b, hasFeaturesAfterTryAccessors)
 	}
@@ -129,6 +150,17 @@ func deserializeSchemaFromFlatbuffer(ctx context.Context, buf []byte) (schema.Sc
 
 	sch.SetCollation(schema.Collation(s.Collation()))
 	sch.SetComment(string(s.Comment()))
+	sch.SetTargetNodeSize(s.TargetNodeSize())
+	if n := s.ZoneMapColumnsLength(); n > 0 {
+		tags := make([]uint64, n)
+		for i := range tags {
+			tags[i] = s.ZoneMapColumns(i)
+		}
+		sch.SetZoneMapColumns(tags)
+	}
+	if s.Columnar() {
+		sch.SetStorageLayout(schema.ColumnarStorageLayout)
+	}
 
 	return sch, nil
 }
@@ -429,6 +461,7 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 		if idx.IsFullText() {
 			serial.IndexAddFulltextInfo(b, ftInfo)
 		}
+		serial.IndexAddInvisible(b, idx.IsInvisible())
 		offs[i] = serial.IndexEnd(b)
 	}
 
@@ -460,6 +493,7 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			IsSpatial:          idx.SpatialKey(),
 			IsFullText:         idx.FulltextKey(),
 			IsUserDefined:      !idx.SystemDefined(),
+			IsInvisible:        idx.Invisible(),
 			Comment:            string(idx.Comment()),
 			FullTextProperties: fti,
 		}
@@ -49,6 +49,9 @@ type Index interface {
 	IsFullText() bool
 	// IsUserDefined returns whether the given index was created by a user or automatically generated.
 	IsUserDefined() bool
+	// IsInvisible returns whether the given index is hidden from the query planner. Invisible indexes are still
+	// maintained on writes.
+	IsInvisible() bool
 	// Name returns the name of the index.
 	Name() string
 	// PrimaryKeyTags returns the primary keys of the indexed table, in the order that they're stored for that table.
@@ -75,6 +78,7 @@ type indexImpl struct {
 	isSpatial     bool
 	isFullText    bool
 	isUserDefined bool
+	isInvisible   bool
 	comment       string
 	prefixLengths []uint16
 	fullTextProps FullTextProperties
@@ -95,6 +99,7 @@ func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, pr
 		isSpatial:     props.IsSpatial,
 		isFullText:    props.IsFullText,
 		isUserDefined: props.IsUserDefined,
+		isInvisible:   props.IsInvisible,
 		comment:       props.Comment,
 		fullTextProps: props.FullTextProperties,
 	}
@@ -143,6 +148,7 @@ func (ix *indexImpl) Equals(other Index) bool {
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
 		ix.Comment() == other.Comment() &&
+		ix.IsInvisible() == other.IsInvisible() &&
 		ix.Name() == other.Name()
 }
 
@@ -165,6 +171,7 @@ func (ix *indexImpl) DeepEquals(other Index) bool {
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
 		ix.Comment() == other.Comment() &&
+		ix.IsInvisible() == other.IsInvisible() &&
 		ix.Name() == other.Name()
 }
 
@@ -214,6 +221,11 @@ func (ix *indexImpl) IsUserDefined() bool {
 	return ix.isUserDefined
 }
 
+// IsInvisible implements Index.
+func (ix *indexImpl) IsInvisible() bool {
+	return ix.isInvisible
+}
+
 // Name implements Index.
 func (ix *indexImpl) Name() string {
 	return ix.name
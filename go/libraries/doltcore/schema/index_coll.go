@@ -68,6 +68,8 @@ type IndexCollection interface {
 	RemoveIndex(indexName string) (Index, error)
 	// RenameIndex renames an index in the table metadata.
 	RenameIndex(oldName, newName string) (Index, error)
+	// SetIndexInvisible sets whether the named index is hidden from the query planner.
+	SetIndexInvisible(indexName string, invisible bool) (Index, error)
 	//SetPks changes the pks or pk ordinals
 	SetPks([]uint64) error
 	// ContainsFullTextIndex returns whether the collection contains at least one Full-Text index.
@@ -81,6 +83,7 @@ type IndexProperties struct {
 	IsSpatial     bool
 	IsFullText    bool
 	IsUserDefined bool
+	IsInvisible   bool
 	Comment       string
 	FullTextProperties
 }
@@ -218,6 +221,7 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 		isSpatial:     props.IsSpatial,
 		isFullText:    props.IsFullText,
 		isUserDefined: props.IsUserDefined,
+		isInvisible:   props.IsInvisible,
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
 		fullTextProps: props.FullTextProperties,
@@ -244,6 +248,7 @@ func (ixc *indexCollectionImpl) UnsafeAddIndexByColTags(indexName string, tags [
 		isSpatial:     props.IsSpatial,
 		isFullText:    props.IsFullText,
 		isUserDefined: props.IsUserDefined,
+		isInvisible:   props.IsInvisible,
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
 		fullTextProps: props.FullTextProperties,
@@ -411,6 +416,7 @@ func (ixc *indexCollectionImpl) Merge(indexes ...Index) {
 				isSpatial:     index.IsSpatial(),
 				isFullText:    index.IsFullText(),
 				isUserDefined: index.IsUserDefined(),
+				isInvisible:   index.IsInvisible(),
 				comment:       index.Comment(),
 				prefixLengths: index.PrefixLengths(),
 				fullTextProps: index.FullTextProperties(),
@@ -456,6 +462,29 @@ func (ixc *indexCollectionImpl) RenameIndex(oldName, newName string) (Index, err
 	return index, nil
 }
 
+func (ixc *indexCollectionImpl) SetIndexInvisible(indexName string, invisible bool) (Index, error) {
+	lowerName := strings.ToLower(indexName)
+	if !ixc.Contains(lowerName) {
+		return nil, fmt.Errorf("`%s` does not exist as an index for this table", indexName)
+	}
+	// the index may be shared with other schemas, so it's replaced with a copy rather than changed
+	oldIndex := ixc.indexes[lowerName]
+	index := oldIndex.copy()
+	index.isInvisible = invisible
+	ixc.indexes[lowerName] = index
+	for _, tag := range index.tags {
+		newReferences := make([]*indexImpl, len(ixc.colTagToIndex[tag]))
+		for i, referencedIndex := range ixc.colTagToIndex[tag] {
+			if referencedIndex == oldIndex {
+				referencedIndex = index
+			}
+			newReferences[i] = referencedIndex
+		}
+		ixc.colTagToIndex[tag] = newReferences
+	}
+	return index, nil
+}
+
 func (ixc *indexCollectionImpl) columnNamesToTags(cols []string) ([]uint64, bool) {
 	tags := make([]uint64, len(cols))
 	for i, colName := range cols {
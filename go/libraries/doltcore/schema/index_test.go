@@ -430,6 +430,39 @@ func TestIndexCollectionRenameIndex(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIndexCollectionSetIndexInvisible(t *testing.T) {
+	colColl := NewColCollection(
+		NewColumn("pk1", 1, types.IntKind, true, NotNullConstraint{}),
+		NewColumn("v1", 2, types.IntKind, false),
+	)
+	indexColl := NewIndexCollection(colColl, nil).(*indexCollectionImpl)
+	index := &indexImpl{
+		name:      "idx_a",
+		tags:      []uint64{2},
+		allTags:   []uint64{2, 1},
+		indexColl: indexColl,
+	}
+	indexColl.AddIndex(index)
+	visible := index.copy()
+
+	resIndex, err := indexColl.SetIndexInvisible("IDX_A", true)
+	require.NoError(t, err)
+	assert.True(t, resIndex.IsInvisible())
+	assert.True(t, indexColl.GetByName("idx_a").IsInvisible())
+	assert.True(t, indexColl.IndexesWithTag(2)[0].IsInvisible())
+	assert.False(t, resIndex.Equals(visible))
+	// the index is replaced rather than changed, since it may be shared with other schemas
+	assert.False(t, index.IsInvisible())
+
+	resIndex, err = indexColl.SetIndexInvisible("idx_a", false)
+	require.NoError(t, err)
+	assert.False(t, resIndex.IsInvisible())
+	assert.True(t, resIndex.Equals(visible))
+
+	_, err = indexColl.SetIndexInvisible("idx_missing", true)
+	assert.Error(t, err)
+}
+
 func TestIndexCollectionDuplicateIndexes(t *testing.T) {
 	colColl := NewColCollection(
 		NewColumn("pk1", 1, types.IntKind, true, NotNullConstraint{}),
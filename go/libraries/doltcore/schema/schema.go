@@ -101,10 +101,49 @@ type Schema interface {
 	// SetComment sets the table's comment.
 	SetComment(comment string)
 
+	// GetTargetNodeSize returns the target size, in bytes, of the leaf nodes of the table's primary index, or zero if
+	// the table uses the default size.
+	GetTargetNodeSize() uint32
+
+	// SetTargetNodeSize sets the target size of the leaf nodes of the table's primary index. Changing it rechunks the
+	// table's rows when the schema is written.
+	SetTargetNodeSize(size uint32)
+
+	// GetZoneMapColumns returns the tags of the columns whose min and max values are kept in the nodes of the table's
+	// primary index, or nil if the table has no zone maps.
+	GetZoneMapColumns() []uint64
+
+	// SetZoneMapColumns sets the tags of the columns whose min and max values are kept in the nodes of the table's
+	// primary index. Changing them rechunks the table's rows when the schema is written. A nil |tags| keeps the zone
+	// map columns of the table the schema is written to, and an empty |tags| removes them.
+	SetZoneMapColumns(tags []uint64)
+
+	// GetStorageLayout returns the layout of the rows of the table's primary index.
+	GetStorageLayout() StorageLayout
+
+	// SetStorageLayout sets the layout of the rows of the table's primary index. Changing it rewrites the table's rows
+	// when the schema is written, and UnspecifiedStorageLayout keeps the layout of the table the schema is written to.
+	SetStorageLayout(layout StorageLayout)
+
 	// Copy returns a copy of this Schema that can be safely modified independently.
 	Copy() Schema
 }
 
+// StorageLayout is the layout of the rows of a table's primary index.
+type StorageLayout uint8
+
+const (
+	// UnspecifiedStorageLayout is the layout of new schemas, and of the stored schemas of tables whose rows aren't
+	// stored column-wise. It stores rows like RowStorageLayout, except that a schema with it keeps the layout of the
+	// table it's written to.
+	UnspecifiedStorageLayout StorageLayout = iota
+	// RowStorageLayout stores the values of each row together in the leaf nodes of the primary index.
+	RowStorageLayout
+	// ColumnarStorageLayout stores each column of the rows of a leaf node of the primary index in a column segment of
+	// its own, so that reading some of the columns of a table only reads their segments.
+	ColumnarStorageLayout
+)
+
 // ColumnOrder is used in ALTER TABLE statements to change the order of inserted / modified columns.
 type ColumnOrder struct {
 	First       bool   // True if this column should come first
@@ -17,6 +17,7 @@ package schema
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -47,6 +48,9 @@ type schemaImpl struct {
 	collation                  Collation
 	contentHashedFields        []uint64
 	comment                    string
+	targetNodeSize             uint32
+	zoneMapColumns             []uint64
+	storageLayout              StorageLayout
 }
 
 var _ Schema = (*schemaImpl)(nil)
@@ -324,6 +328,30 @@ func (si *schemaImpl) SetComment(comment string) {
 	si.comment = comment
 }
 
+func (si *schemaImpl) GetTargetNodeSize() uint32 {
+	return si.targetNodeSize
+}
+
+func (si *schemaImpl) SetTargetNodeSize(size uint32) {
+	si.targetNodeSize = size
+}
+
+func (si *schemaImpl) GetZoneMapColumns() []uint64 {
+	return si.zoneMapColumns
+}
+
+func (si *schemaImpl) SetZoneMapColumns(tags []uint64) {
+	si.zoneMapColumns = slices.Clone(tags)
+}
+
+func (si *schemaImpl) GetStorageLayout() StorageLayout {
+	return si.storageLayout
+}
+
+func (si *schemaImpl) SetStorageLayout(layout StorageLayout) {
+	si.storageLayout = layout
+}
+
 // GetAllCols gets the collection of all columns (pk and non-pk)
 func (si *schemaImpl) GetAllCols() *ColCollection {
 	return si.allCols
@@ -664,6 +692,7 @@ func (si schemaImpl) Copy() Schema {
 
 	si.indexCollection = si.indexCollection.Copy()
 	si.checkCollection = si.checkCollection.Copy()
+	si.zoneMapColumns = slices.Clone(si.zoneMapColumns)
 
 	return &si
 }
@@ -43,6 +43,7 @@ const (
 	StatsMcv3ColName          = "mcv3"
 	StatsMcv4ColName          = "mcv4"
 	StatsMcvCountsColName     = "mcvCounts"
+	StatsSketchColName        = "sketch"
 	StatsVersionColName       = "version"
 )
 
@@ -66,6 +67,7 @@ const (
 	StatsMcv3Tag
 	StatsMcv4Tag
 	StatsMcvCountsTag
+	StatsSketchTag
 )
 
 func StatsTableSqlSchema(dbName string) sql.PrimaryKeySchema {
@@ -115,6 +117,7 @@ func StatsTableDoltSchemaGen() Schema {
 		NewColumn(StatsMcv3ColName, StatsMcv3Tag, stypes.StringKind, false),
 		NewColumn(StatsMcv4ColName, StatsMcv4Tag, stypes.StringKind, false),
 		NewColumn(StatsMcvCountsColName, StatsMcvCountsTag, stypes.StringKind, false, NotNullConstraint{}),
+		NewColumn(StatsSketchColName, StatsSketchTag, stypes.StringKind, false),
 	)
 	return MustSchemaFromCols(colColl)
 }
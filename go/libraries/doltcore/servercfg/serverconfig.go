@@ -15,13 +15,20 @@
 package servercfg
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 )
 
 var DefaultUnixSocketFilePath = DefaultMySQLUnixSocketFilePath
@@ -40,28 +47,67 @@ const (
 )
 
 const (
-	DefaultHost                    = "localhost"
-	DefaultPort                    = 3306
-	DefaultUser                    = "root"
-	DefaultPass                    = ""
-	DefaultTimeout                 = 8 * 60 * 60 * 1000 // 8 hours, same as MySQL
-	DefaultReadOnly                = false
-	DefaultLogLevel                = LogLevel_Info
-	DefaultAutoCommit              = true
-	DefaultDoltTransactionCommit   = false
-	DefaultMaxConnections          = 100
-	DefaultDataDir                 = "."
-	DefaultCfgDir                  = ".doltcfg"
-	DefaultPrivilegeFilePath       = "privileges.db"
-	DefaultBranchControlFilePath   = "branch_control.db"
-	DefaultMetricsHost             = ""
-	DefaultMetricsPort             = -1
-	DefaultAllowCleartextPasswords = false
-	DefaultMySQLUnixSocketFilePath = "/tmp/mysql.sock"
-	DefaultMaxLoggedQueryLen       = 0
-	DefaultEncodeLoggedQuery       = false
+	DefaultHost                      = "localhost"
+	DefaultPort                      = 3306
+	DefaultUser                      = "root"
+	DefaultPass                      = ""
+	DefaultTimeout                   = 8 * 60 * 60 * 1000 // 8 hours, same as MySQL
+	DefaultReadOnly                  = false
+	DefaultLogLevel                  = LogLevel_Info
+	DefaultAutoCommit                = true
+	DefaultDoltTransactionCommit     = false
+	DefaultAutoCommitConflictRetries = 0
+	DefaultMaxConnections            = 100
+	DefaultDataDir                   = "."
+	DefaultCfgDir                    = ".doltcfg"
+	DefaultPrivilegeFilePath         = "privileges.db"
+	DefaultBranchControlFilePath     = "branch_control.db"
+	DefaultMetricsHost               = ""
+	DefaultMetricsPort               = -1
+	DefaultMetricsPprof              = false
+	DefaultAllowCleartextPasswords   = false
+	DefaultMySQLUnixSocketFilePath   = "/tmp/mysql.sock"
+	DefaultMaxLoggedQueryLen         = 0
+	DefaultEncodeLoggedQuery         = false
+	DefaultRemoteDatabaseCacheSizeMB = 256
+	DefaultUDFMaxMemoryMB            = 16
 )
 
+// DefaultRemoteDatabaseRefreshInterval is how often a database served from a remote is refreshed by default.
+const DefaultRemoteDatabaseRefreshInterval = 10 * time.Second
+
+// DefaultUDFTimeout is how long a call of a user-defined function may run by default.
+const DefaultUDFTimeout = time.Second
+
+const (
+	// DefaultEdgeSyncCentralBranch is the branch the device branch is merged into by default in edge sync mode.
+	DefaultEdgeSyncCentralBranch = "main"
+	// DefaultEdgeSyncRemote is the remote the central branch is synced with by default in edge sync mode.
+	DefaultEdgeSyncRemote = "origin"
+	// DefaultEdgeSyncInterval is how often the device branch is synced by default in edge sync mode.
+	DefaultEdgeSyncInterval = time.Minute
+)
+
+const (
+	// DefaultScheduledPullRemote is the remote scheduled pulls pull from by default.
+	DefaultScheduledPullRemote = "origin"
+	// DefaultScheduledPullInterval is how often scheduled pulls run by default.
+	DefaultScheduledPullInterval = 5 * time.Minute
+)
+
+// The conflict policies of scheduled pulls.
+const (
+	// ScheduledPullConflictAbort abandons a pull with conflicts, leaving the branch as it was.
+	ScheduledPullConflictAbort = "abort"
+	// ScheduledPullConflictPreferRemote resolves the conflicts of a pull with the remote's rows.
+	ScheduledPullConflictPreferRemote = "prefer-remote"
+	// ScheduledPullConflictPreferLocal resolves the conflicts of a pull with the local rows.
+	ScheduledPullConflictPreferLocal = "prefer-local"
+)
+
+// ScheduledPullConflictPolicies are the valid conflict policies of scheduled pulls.
+var ScheduledPullConflictPolicies = []string{ScheduledPullConflictAbort, ScheduledPullConflictPreferRemote, ScheduledPullConflictPreferLocal}
+
 func ptr[T any](t T) *T {
 	return &t
 }
@@ -117,6 +163,57 @@ type JwksConfig struct {
 	FieldsToLog []string          `yaml:"fields_to_log"`
 }
 
+// LDAPConfig configures the authentication of users with the authentication_dolt_ldap plugin, whose passwords are
+// validated by binding to an LDAP server.
+type LDAPConfig struct {
+	// URL is the ldap:// or ldaps:// URL of the server. An ldap:// URL requires StartTLS or AllowCleartext.
+	URL string `yaml:"url"`
+	// BindDNTemplate is the DN bound to for a user, in which {user} is replaced by the escaped user name. A user's
+	// account can name its DN with IDENTIFIED WITH authentication_dolt_ldap AS 'dn' instead.
+	BindDNTemplate string `yaml:"bind_dn_template,omitempty"`
+	// GroupAttribute is the attribute of a user's entry listing the DNs of their groups. Defaults to memberOf.
+	GroupAttribute string `yaml:"group_attribute,omitempty"`
+	// TLSCA is the path to a PEM file of the certificate authorities trusted for ldaps URLs and StartTLS, in place of
+	// the system's.
+	TLSCA string `yaml:"tls_ca,omitempty"`
+	// StartTLS upgrades connections to an ldap:// URL to TLS with StartTLS before users' passwords are sent.
+	StartTLS bool `yaml:"start_tls,omitempty"`
+	// AllowCleartext allows connecting to an ldap:// URL without StartTLS, sending users' passwords in cleartext.
+	AllowCleartext bool `yaml:"allow_cleartext,omitempty"`
+	// GroupRoles maps the DNs of groups to the SQL roles granted to their members when they log in.
+	GroupRoles map[string]string `yaml:"group_roles,omitempty"`
+}
+
+// OIDCConfig configures the authentication of users with the authentication_dolt_oidc plugin, whose passwords are ID
+// or access tokens issued by an OpenID Connect provider.
+type OIDCConfig struct {
+	// Issuer is the issuer URL of the provider, which tokens must be issued by.
+	Issuer string `yaml:"issuer"`
+	// Audience is the audience tokens must be issued for.
+	Audience string `yaml:"audience"`
+	// JwksURL is the URL of the provider's signing keys. Defaults to the jwks_uri of the provider's discovery document.
+	JwksURL string `yaml:"jwks_url,omitempty"`
+	// UsernameClaim is the claim of a token which must match the user name. Defaults to sub.
+	UsernameClaim string `yaml:"username_claim,omitempty"`
+	// GroupsClaim is the claim of a token listing the user's groups. Defaults to groups.
+	GroupsClaim string `yaml:"groups_claim,omitempty"`
+	// GroupRoles maps groups to the SQL roles granted to their members when they log in.
+	GroupRoles map[string]string `yaml:"group_roles,omitempty"`
+}
+
+// ClientCertConfig configures the authentication of clients with TLS client certificates, which are verified against
+// a certificate authority and map to SQL users by the common name of their subject. A mapped user must connect with a
+// certificate mapped to it, and can be created without a password to authenticate with its certificate alone.
+type ClientCertConfig struct {
+	// CA is the path to a PEM file of the certificate authorities which client certificates must be issued by.
+	CA string `yaml:"ca"`
+	// Required turns away every connection without a certificate mapped to its user, rather than only those of the
+	// mapped users.
+	Required bool `yaml:"required,omitempty"`
+	// Users maps the subject common names of client certificates to the SQL users they authenticate as.
+	Users map[string]string `yaml:"users,omitempty"`
+}
+
 // ServerConfig contains all of the configurable options for the MySQL-compatible server.
 type ServerConfig interface {
 	// Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -152,6 +249,9 @@ type ServerConfig interface {
 	TLSCert() string
 	// RequireSecureTransport is true if the server should reject non-TLS connections.
 	RequireSecureTransport() bool
+	// ClientCertConfig configures the authentication of clients with TLS client certificates, or is nil if it isn't
+	// configured.
+	ClientCertConfig() *ClientCertConfig
 	// MaxLoggedQueryLen is the max length of queries written to the logs.  Queries longer than this number are truncated.
 	// If this value is 0 then the query is not truncated and will be written to the logs in its entirety.  If the value
 	// is less than 0 then the queries will be omitted from the logs completely
@@ -164,21 +264,50 @@ type ServerConfig interface {
 	// process incoming ComQuery packets as if they had multiple queries in
 	// them, even if the client advertises support for MULTI_STATEMENTS.
 	DisableClientMultiStatements() bool
+	// AutoCommitConflictRetries is the number of times the server reruns a statement run in autocommit mode, outside of
+	// an explicit transaction, whose transaction conflicts with a concurrently committed transaction, before returning
+	// the error to the client.
+	AutoCommitConflictRetries() int
 	// MetricsLabels returns labels that are applied to all prometheus metrics
 	MetricsLabels() map[string]string
 	MetricsHost() string
 	MetricsPort() int
+	// MetricsPprof returns whether the metrics listener also serves the Go runtime's pprof endpoints under
+	// /debug/pprof/. These require HTTP basic auth as a SQL user that has been granted SUPER, and are only served to
+	// clients on the loopback interface, since the metrics listener doesn't use TLS.
+	MetricsPprof() bool
 	// PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 	// JSON string.
 	PrivilegeFilePath() string
+	// PrivilegeDatabase returns the name of the database whose dolt_privileges table stores users and grants, in
+	// place of the privilege file, or the empty string if they're stored in the privilege file.
+	PrivilegeDatabase() string
 	// BranchControlFilePath returns the path to the file which contains the branch control permissions.
 	BranchControlFilePath() string
 	// UserVars is an array containing user specific session variables
 	UserVars() []UserSessionVars
+	// UserLimits limits the connections, statement rate and query results of users.
+	UserLimits() []UserLimits
+	// RemoteDatabases are the read-only databases served directly from remotes, without local copies of them.
+	RemoteDatabases() []RemoteDatabaseConfig
+	// UserDefinedFunctions are the SQL functions implemented by WebAssembly modules.
+	UserDefinedFunctions() []UserDefinedFunctionConfig
+	// EdgeSync configures edge sync mode, in which writes are made to a device branch which is periodically merged
+	// into a central branch of a remote. Returns nil if edge sync mode is off.
+	EdgeSync() *EdgeSyncConfig
+	// ScheduledPulls are the branches of databases which are periodically pulled from remotes.
+	ScheduledPulls() []ScheduledPullConfig
+	// ExportJobs configures what the export jobs of the dolt_export_jobs tables of databases can write to and notify.
+	// Returns nil if it isn't configured, in which case jobs can only write to object storage and notify no URLs.
+	ExportJobs() *ExportJobsConfig
 	// SystemVars is a map setting global SQL system variables. For example, `secure_file_priv`.
 	SystemVars() map[string]interface{}
 	// JwksConfig is an array containing jwks config
 	JwksConfig() []JwksConfig
+	// LDAPConfig configures authentication against an LDAP server, or is nil if it isn't configured.
+	LDAPConfig() *LDAPConfig
+	// OIDCConfig configures authentication with OpenID Connect tokens, or is nil if it isn't configured.
+	OIDCConfig() *OIDCConfig
 	// AllowCleartextPasswords is true if the server should accept cleartext passwords.
 	AllowCleartextPasswords() bool
 	// Socket is a path to the unix socket file
@@ -261,9 +390,173 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := validateUserLimits(config.UserLimits()); err != nil {
+		return err
+	}
+	if err := validateRemoteDatabases(config.RemoteDatabases()); err != nil {
+		return err
+	}
+	if err := validateUserDefinedFunctions(config.UserDefinedFunctions()); err != nil {
+		return err
+	}
+	if err := validateEdgeSync(config.EdgeSync()); err != nil {
+		return err
+	}
+	if err := validateScheduledPulls(config.ScheduledPulls()); err != nil {
+		return err
+	}
+	if err := validateExportJobs(config.ExportJobs()); err != nil {
+		return err
+	}
+	if cc := config.ClientCertConfig(); cc != nil {
+		if config.TLSCert() == "" && config.TLSKey() == "" {
+			return fmt.Errorf("client_cert can only be configured when a tls_key and tls_cert are provided.")
+		}
+		if cc.CA == "" {
+			return fmt.Errorf("client_cert: ca: must supply the certificate authorities of client certificates")
+		}
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
+// validateUserLimits returns an error if |limits| has a negative limit, or more than one entry for a user.
+func validateUserLimits(limits []UserLimits) error {
+	names := make(map[string]struct{}, len(limits))
+	for _, l := range limits {
+		if l.Name == "" {
+			return fmt.Errorf("user_limits: name: must supply the user the limits apply to")
+		}
+		if _, ok := names[l.Name]; ok {
+			return fmt.Errorf("user_limits: more than one entry for user %s", l.Name)
+		}
+		names[l.Name] = struct{}{}
+		if l.MaxConnections < 0 || l.MaxStatementsPerSecond < 0 || l.MaxRowsPerQuery < 0 {
+			return fmt.Errorf("user_limits: limits of user %s must not be negative", l.Name)
+		}
+	}
+	return nil
+}
+
+// validateRemoteDatabases returns an error if a database of |dbs| has no name or URL, a name used by another, or a
+// refresh interval or cache size which isn't positive.
+func validateRemoteDatabases(dbs []RemoteDatabaseConfig) error {
+	names := make(map[string]struct{}, len(dbs))
+	for _, db := range dbs {
+		if db.Name == "" {
+			return fmt.Errorf("remote_databases: name: must supply the name of the database")
+		}
+		name := strings.ToLower(db.Name)
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("remote_databases: more than one database named %s", db.Name)
+		}
+		names[name] = struct{}{}
+		if db.RemoteURL == "" {
+			return fmt.Errorf("remote_databases: remote_url: must supply the remote of database %s", db.Name)
+		}
+		if db.RefreshIntervalMillis != nil && *db.RefreshIntervalMillis <= 0 {
+			return fmt.Errorf("remote_databases: refresh_interval_millis of database %s must be positive", db.Name)
+		}
+		if db.CacheSizeMB != nil && *db.CacheSizeMB <= 0 {
+			return fmt.Errorf("remote_databases: cache_size_mb of database %s must be positive", db.Name)
+		}
+	}
+	return nil
+}
+
+// validateUserDefinedFunctions returns an error if a function of |fns| has no name or module, a name used by another,
+// a sha256 which isn't a hex SHA-256 digest, or a memory limit or timeout which isn't positive.
+func validateUserDefinedFunctions(fns []UserDefinedFunctionConfig) error {
+	names := make(map[string]struct{}, len(fns))
+	for _, fn := range fns {
+		if fn.Name == "" {
+			return fmt.Errorf("user_defined_functions: name: must supply the name of the function")
+		}
+		name := strings.ToLower(fn.Name)
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("user_defined_functions: more than one function named %s", fn.Name)
+		}
+		names[name] = struct{}{}
+		if fn.ModulePath == "" {
+			return fmt.Errorf("user_defined_functions: module_path: must supply the module of function %s", fn.Name)
+		}
+		if fn.SHA256 != "" {
+			if b, err := hex.DecodeString(fn.SHA256); err != nil || len(b) != sha256.Size {
+				return fmt.Errorf("user_defined_functions: sha256 of function %s must be a hex encoded SHA-256 digest", fn.Name)
+			}
+		}
+		if fn.MaxMemoryMB != nil && *fn.MaxMemoryMB <= 0 {
+			return fmt.Errorf("user_defined_functions: max_memory_mb of function %s must be positive", fn.Name)
+		}
+		if fn.TimeoutMillis != nil && *fn.TimeoutMillis <= 0 {
+			return fmt.Errorf("user_defined_functions: timeout_millis of function %s must be positive", fn.Name)
+		}
+	}
+	return nil
+}
+
+// validateEdgeSync returns an error if |es| has no device branch, a device branch which is its central branch, or an
+// interval which isn't positive.
+func validateEdgeSync(es *EdgeSyncConfig) error {
+	if es == nil {
+		return nil
+	}
+	if es.DeviceBranch == "" {
+		return fmt.Errorf("edge_sync: device_branch: must supply the branch writes are made to")
+	}
+	if es.DeviceBranch == es.CentralBranchName() {
+		return fmt.Errorf("edge_sync: device_branch must not be the central branch %s", es.CentralBranchName())
+	}
+	if es.RemoteName() == "" {
+		return fmt.Errorf("edge_sync: remote: must not be empty")
+	}
+	if es.IntervalMillis != nil && *es.IntervalMillis <= 0 {
+		return fmt.Errorf("edge_sync: interval_millis must be positive")
+	}
+	return nil
+}
+
+// validateScheduledPulls returns an error if a scheduled pull has no database or branches, an empty remote, an
+// interval which isn't positive, or an unknown conflict policy.
+func validateScheduledPulls(pulls []ScheduledPullConfig) error {
+	for _, sp := range pulls {
+		if sp.Database == "" {
+			return fmt.Errorf("scheduled_pulls: database: must supply the database whose branches are pulled")
+		}
+		if len(sp.Branches) == 0 {
+			return fmt.Errorf("scheduled_pulls: branches: must supply the branches of database %s to pull", sp.Database)
+		}
+		for _, branch := range sp.Branches {
+			if branch == "" {
+				return fmt.Errorf("scheduled_pulls: branches of database %s must not be empty", sp.Database)
+			}
+		}
+		if sp.RemoteName() == "" {
+			return fmt.Errorf("scheduled_pulls: remote of database %s must not be empty", sp.Database)
+		}
+		if sp.IntervalMillis != nil && *sp.IntervalMillis <= 0 {
+			return fmt.Errorf("scheduled_pulls: interval_millis of database %s must be positive", sp.Database)
+		}
+		if !slices.Contains(ScheduledPullConflictPolicies, sp.ConflictPolicyName()) {
+			return fmt.Errorf("scheduled_pulls: conflict_policy of database %s must be one of %s", sp.Database, strings.Join(ScheduledPullConflictPolicies, ", "))
+		}
+	}
+	return nil
+}
+
+// validateExportJobs returns an error if the notification URLs of |ej| aren't http or https URLs.
+func validateExportJobs(ej *ExportJobsConfig) error {
+	if ej == nil {
+		return nil
+	}
+	for _, notificationURL := range ej.NotificationURLs {
+		u, err := url.Parse(notificationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("export_jobs: notification_urls: '%s' must be an http or https URL", notificationURL)
+		}
+	}
+	return nil
+}
+
 const (
 	MaxConnectionsKey = "max_connections"
 	ReadTimeoutKey    = "net_read_timeout"
@@ -376,7 +669,8 @@ func ConfigInfo(config ServerConfig) string {
 }
 
 // LoadTLSConfig loads the certificate chain from config.TLSKey() and config.TLSCert() and returns
-// a *tls.Config configured for its use. Returns `nil` if key and cert are `""`.
+// a *tls.Config configured for its use, and for the verification of client certificates if
+// config.ClientCertConfig() is set. Returns `nil` if key and cert are `""`.
 func LoadTLSConfig(cfg ServerConfig) (*tls.Config, error) {
 	if cfg.TLSKey() == "" && cfg.TLSCert() == "" {
 		return nil, nil
@@ -385,11 +679,29 @@ func LoadTLSConfig(cfg ServerConfig) (*tls.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &tls.Config{
+	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{
 			c,
 		},
-	}, nil
+	}
+	if cc := cfg.ClientCertConfig(); cc != nil {
+		pem, err := os.ReadFile(cc.CA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client_cert ca %s", cc.CA)
+		}
+		// certificates are required of every connection only when client_cert is required, but a certificate which
+		// is given must always be verified, since it's mapped to a user
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		if cc.Required {
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return tlsConfig, nil
 }
 
 // CheckForUnixSocket evaluates ServerConfig for whether the unix socket is to be used or not.
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -63,6 +64,9 @@ type BehaviorYAMLConfig struct {
 	// DoltTransactionCommit enables the @@dolt_transaction_commit system variable, which
 	// automatically creates a Dolt commit when any SQL transaction is committed.
 	DoltTransactionCommit *bool `yaml:"dolt_transaction_commit"`
+	// AutoCommitConflictRetries is the number of times to rerun autocommit statements which fail with a transaction
+	// conflict.
+	AutoCommitConflictRetries *int `yaml:"autocommit_conflict_retries,omitempty" minver:"TBD"`
 
 	EventSchedulerStatus *string `yaml:"event_scheduler,omitempty" minver:"1.17.0"`
 }
@@ -86,6 +90,8 @@ type ListenerYAMLConfig struct {
 	TLSCert *string `yaml:"tls_cert"`
 	// RequireSecureTransport can enable a mode where non-TLS connections are turned away.
 	RequireSecureTransport *bool `yaml:"require_secure_transport"`
+	// ClientCert configures the authentication of clients with TLS client certificates.
+	ClientCert *ClientCertConfig `yaml:"client_cert,omitempty" minver:"TBD"`
 	// AllowCleartextPasswords enables use of cleartext passwords.
 	AllowCleartextPasswords *bool `yaml:"allow_cleartext_passwords"`
 	// Socket is unix socket file path
@@ -102,6 +108,7 @@ type MetricsYAMLConfig struct {
 	Labels map[string]string `yaml:"labels"`
 	Host   *string           `yaml:"host"`
 	Port   *int              `yaml:"port"`
+	Pprof  *bool             `yaml:"pprof,omitempty" minver:"TBD"`
 }
 
 type RemotesapiYAMLConfig struct {
@@ -122,27 +129,214 @@ type UserSessionVars struct {
 	Vars map[string]interface{} `yaml:"vars"`
 }
 
+// UserLimits limits the resources a user's connections may use, so that one user can't starve the others of a shared
+// server. A limit of 0 is no limit.
+type UserLimits struct {
+	// Name is the user name the limits apply to, or % for the users without limits of their own.
+	Name string `yaml:"name"`
+	// MaxConnections is the number of connections the user may have open at once.
+	MaxConnections int `yaml:"max_connections,omitempty"`
+	// MaxStatementsPerSecond is the number of statements per second the user may run, across all its connections.
+	// Bursts of up to a second's worth of statements are allowed.
+	MaxStatementsPerSecond int `yaml:"max_statements_per_second,omitempty"`
+	// MaxRowsPerQuery is the number of rows a query run by the user may return.
+	MaxRowsPerQuery int64 `yaml:"max_rows_per_query,omitempty"`
+}
+
+// RemoteDatabaseConfig configures a read-only database served directly from a remote, without a local copy of it.
+type RemoteDatabaseConfig struct {
+	// Name is the name the database is served as.
+	Name string `yaml:"name"`
+	// RemoteURL is the URL of the remote, such as a DoltHub, aws:// or gs:// remote.
+	RemoteURL string `yaml:"remote_url"`
+	// Params are the parameters of the remote, such as the aws-region and aws-creds-type of an aws:// remote.
+	Params map[string]string `yaml:"params,omitempty"`
+	// RefreshIntervalMillis is how often the database's branches are refreshed from the remote.
+	RefreshIntervalMillis *int `yaml:"refresh_interval_millis,omitempty"`
+	// CacheSizeMB is the size of the cache of the chunks read from the remote.
+	CacheSizeMB *int `yaml:"cache_size_mb,omitempty"`
+}
+
+// RefreshInterval returns how often the database is refreshed from the remote.
+func (rd RemoteDatabaseConfig) RefreshInterval() time.Duration {
+	if rd.RefreshIntervalMillis == nil {
+		return DefaultRemoteDatabaseRefreshInterval
+	}
+	return time.Duration(*rd.RefreshIntervalMillis) * time.Millisecond
+}
+
+// CacheSize returns the size in bytes of the cache of the chunks read from the remote.
+func (rd RemoteDatabaseConfig) CacheSize() uint64 {
+	if rd.CacheSizeMB == nil {
+		return DefaultRemoteDatabaseCacheSizeMB << 20
+	}
+	return uint64(*rd.CacheSizeMB) << 20
+}
+
+// UserDefinedFunctionConfig configures a SQL function implemented by a function exported from a WebAssembly module.
+type UserDefinedFunctionConfig struct {
+	// Name is the name of the SQL function.
+	Name string `yaml:"name"`
+	// ModulePath is the path of the WebAssembly module.
+	ModulePath string `yaml:"module_path"`
+	// Export is the name of the function exported by the module, which is the name of the SQL function by default.
+	Export string `yaml:"export,omitempty"`
+	// Version is the version of the module.
+	Version string `yaml:"version,omitempty"`
+	// SHA256 is the hex encoded SHA-256 digest of the module. When given, the function isn't loaded from a module
+	// which doesn't match it.
+	SHA256 string `yaml:"sha256,omitempty"`
+	// Text is true if the function takes and returns text, rather than numbers.
+	Text bool `yaml:"text,omitempty"`
+	// MaxMemoryMB limits the memory of the module.
+	MaxMemoryMB *int `yaml:"max_memory_mb,omitempty"`
+	// TimeoutMillis limits how long a single call of the function may run.
+	TimeoutMillis *int `yaml:"timeout_millis,omitempty"`
+}
+
+// ExportName returns the name of the function exported by the module.
+func (udf UserDefinedFunctionConfig) ExportName() string {
+	if udf.Export == "" {
+		return udf.Name
+	}
+	return udf.Export
+}
+
+// MaxMemory returns the limit in bytes of the memory of the module.
+func (udf UserDefinedFunctionConfig) MaxMemory() uint64 {
+	if udf.MaxMemoryMB == nil {
+		return DefaultUDFMaxMemoryMB << 20
+	}
+	return uint64(*udf.MaxMemoryMB) << 20
+}
+
+// Timeout returns how long a single call of the function may run.
+func (udf UserDefinedFunctionConfig) Timeout() time.Duration {
+	if udf.TimeoutMillis == nil {
+		return DefaultUDFTimeout
+	}
+	return time.Duration(*udf.TimeoutMillis) * time.Millisecond
+}
+
+// EdgeSyncConfig configures edge sync mode, for servers which may be offline. Sessions write to a device branch of
+// each database, which is periodically merged into a central branch of a remote, resolving conflicting changes of
+// columns with the strategies in dolt_column_merge_strategies.
+type EdgeSyncConfig struct {
+	// DeviceBranch is the branch writes are made to, which is created from the central branch if it doesn't exist.
+	DeviceBranch string `yaml:"device_branch"`
+	// CentralBranch is the branch of the remote the device branch is merged into.
+	CentralBranch *string `yaml:"central_branch,omitempty"`
+	// Remote is the remote the central branch is fetched from and pushed to.
+	Remote *string `yaml:"remote,omitempty"`
+	// IntervalMillis is how often the device branch is synced.
+	IntervalMillis *int `yaml:"interval_millis,omitempty"`
+}
+
+// CentralBranchName returns the branch the device branch is merged into.
+func (es EdgeSyncConfig) CentralBranchName() string {
+	if es.CentralBranch == nil {
+		return DefaultEdgeSyncCentralBranch
+	}
+	return *es.CentralBranch
+}
+
+// RemoteName returns the remote the central branch is synced with.
+func (es EdgeSyncConfig) RemoteName() string {
+	if es.Remote == nil {
+		return DefaultEdgeSyncRemote
+	}
+	return *es.Remote
+}
+
+// Interval returns how often the device branch is synced.
+func (es EdgeSyncConfig) Interval() time.Duration {
+	if es.IntervalMillis == nil {
+		return DefaultEdgeSyncInterval
+	}
+	return time.Duration(*es.IntervalMillis) * time.Millisecond
+}
+
+// ScheduledPullConfig configures the periodic pull of branches of a database from a remote, for mirrors which must
+// track an upstream database.
+type ScheduledPullConfig struct {
+	// Database is the database whose branches are pulled.
+	Database string `yaml:"database"`
+	// Remote is the remote the branches are pulled from.
+	Remote *string `yaml:"remote,omitempty"`
+	// Branches are the branches pulled, each from the branch of the same name of the remote.
+	Branches []string `yaml:"branches"`
+	// IntervalMillis is how often the branches are pulled.
+	IntervalMillis *int `yaml:"interval_millis,omitempty"`
+	// ConflictPolicy is how a pull with conflicts is handled, one of ScheduledPullConflictPolicies.
+	ConflictPolicy *string `yaml:"conflict_policy,omitempty"`
+	// OnFailure is a URL to which a notification of a failed pull is posted, if any.
+	OnFailure string `yaml:"on_failure,omitempty"`
+}
+
+// RemoteName returns the remote the branches are pulled from.
+func (sp ScheduledPullConfig) RemoteName() string {
+	if sp.Remote == nil {
+		return DefaultScheduledPullRemote
+	}
+	return *sp.Remote
+}
+
+// Interval returns how often the branches are pulled.
+func (sp ScheduledPullConfig) Interval() time.Duration {
+	if sp.IntervalMillis == nil {
+		return DefaultScheduledPullInterval
+	}
+	return time.Duration(*sp.IntervalMillis) * time.Millisecond
+}
+
+// ConflictPolicyName returns how a pull with conflicts is handled.
+func (sp ScheduledPullConfig) ConflictPolicyName() string {
+	if sp.ConflictPolicy == nil {
+		return ScheduledPullConflictAbort
+	}
+	return *sp.ConflictPolicy
+}
+
+// ExportJobsConfig configures what the export jobs of the dolt_export_jobs tables of databases can write to and
+// notify, since they are run by the server.
+type ExportJobsConfig struct {
+	// Directory is the directory snapshots with local destinations are written in. Local destinations are relative to
+	// it and can't leave it. Without it, jobs can only write to object storage.
+	Directory string `yaml:"directory,omitempty"`
+	// NotificationURLs are the URLs notifications of failed runs of jobs can be posted to.
+	NotificationURLs []string `yaml:"notification_urls,omitempty"`
+}
+
 // YAMLConfig is a ServerConfig implementation which is read from a yaml file
 type YAMLConfig struct {
-	LogLevelStr       *string                `yaml:"log_level,omitempty"`
-	MaxQueryLenInLogs *int                   `yaml:"max_logged_query_len,omitempty"`
-	EncodeLoggedQuery *bool                  `yaml:"encode_logged_query,omitempty"`
-	BehaviorConfig    BehaviorYAMLConfig     `yaml:"behavior"`
-	UserConfig        UserYAMLConfig         `yaml:"user"`
-	ListenerConfig    ListenerYAMLConfig     `yaml:"listener"`
-	PerformanceConfig *PerformanceYAMLConfig `yaml:"performance,omitempty"`
-	DataDirStr        *string                `yaml:"data_dir,omitempty"`
-	CfgDirStr         *string                `yaml:"cfg_dir,omitempty"`
-	MetricsConfig     MetricsYAMLConfig      `yaml:"metrics"`
-	RemotesapiConfig  RemotesapiYAMLConfig   `yaml:"remotesapi"`
-	ClusterCfg        *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
-	PrivilegeFile     *string                `yaml:"privilege_file,omitempty"`
-	BranchControlFile *string                `yaml:"branch_control_file,omitempty"`
+	LogLevelStr        *string                `yaml:"log_level,omitempty"`
+	MaxQueryLenInLogs  *int                   `yaml:"max_logged_query_len,omitempty"`
+	EncodeLoggedQuery  *bool                  `yaml:"encode_logged_query,omitempty"`
+	BehaviorConfig     BehaviorYAMLConfig     `yaml:"behavior"`
+	UserConfig         UserYAMLConfig         `yaml:"user"`
+	ListenerConfig     ListenerYAMLConfig     `yaml:"listener"`
+	PerformanceConfig  *PerformanceYAMLConfig `yaml:"performance,omitempty"`
+	DataDirStr         *string                `yaml:"data_dir,omitempty"`
+	CfgDirStr          *string                `yaml:"cfg_dir,omitempty"`
+	MetricsConfig      MetricsYAMLConfig      `yaml:"metrics"`
+	RemotesapiConfig   RemotesapiYAMLConfig   `yaml:"remotesapi"`
+	ClusterCfg         *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+	PrivilegeFile      *string                `yaml:"privilege_file,omitempty"`
+	PrivilegeDatabase_ *string                `yaml:"privilege_database,omitempty" minver:"TBD"`
+	BranchControlFile  *string                `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
-	Vars            []UserSessionVars      `yaml:"user_session_vars"`
-	SystemVars_     map[string]interface{} `yaml:"system_variables,omitempty" minver:"1.11.1"`
-	Jwks            []JwksConfig           `yaml:"jwks"`
-	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
+	Vars            []UserSessionVars           `yaml:"user_session_vars"`
+	SystemVars_     map[string]interface{}      `yaml:"system_variables,omitempty" minver:"1.11.1"`
+	Jwks            []JwksConfig                `yaml:"jwks"`
+	UserLimits_     []UserLimits                `yaml:"user_limits,omitempty" minver:"TBD"`
+	RemoteDBs       []RemoteDatabaseConfig      `yaml:"remote_databases,omitempty" minver:"TBD"`
+	UDFs            []UserDefinedFunctionConfig `yaml:"user_defined_functions,omitempty" minver:"TBD"`
+	EdgeSync_       *EdgeSyncConfig             `yaml:"edge_sync,omitempty" minver:"TBD"`
+	ScheduledPulls_ []ScheduledPullConfig       `yaml:"scheduled_pulls,omitempty" minver:"TBD"`
+	ExportJobs_     *ExportJobsConfig           `yaml:"export_jobs,omitempty" minver:"TBD"`
+	LDAP_           *LDAPConfig                 `yaml:"ldap,omitempty" minver:"TBD"`
+	OIDC_           *OIDCConfig                 `yaml:"oidc,omitempty" minver:"TBD"`
+	GoldenMysqlConn *string                     `yaml:"golden_mysql_conn,omitempty"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -185,6 +379,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			AutoCommit:                   ptr(cfg.AutoCommit()),
 			DisableClientMultiStatements: ptr(cfg.DisableClientMultiStatements()),
 			DoltTransactionCommit:        ptr(cfg.DoltTransactionCommit()),
+			AutoCommitConflictRetries:    nillableIntPtr(cfg.AutoCommitConflictRetries()),
 			EventSchedulerStatus:         ptr(cfg.EventSchedulerStatus()),
 		},
 		UserConfig: UserYAMLConfig{
@@ -200,6 +395,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			TLSKey:                  nillableStrPtr(cfg.TLSKey()),
 			TLSCert:                 nillableStrPtr(cfg.TLSCert()),
 			RequireSecureTransport:  nillableBoolPtr(cfg.RequireSecureTransport()),
+			ClientCert:              cfg.ClientCertConfig(),
 			AllowCleartextPasswords: nillableBoolPtr(cfg.AllowCleartextPasswords()),
 			Socket:                  nillableStrPtr(cfg.Socket()),
 		},
@@ -209,17 +405,27 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			Labels: cfg.MetricsLabels(),
 			Host:   nillableStrPtr(cfg.MetricsHost()),
 			Port:   ptr(cfg.MetricsPort()),
+			Pprof:  nillableBoolPtr(cfg.MetricsPprof()),
 		},
 		RemotesapiConfig: RemotesapiYAMLConfig{
 			Port_:     cfg.RemotesapiPort(),
 			ReadOnly_: cfg.RemotesapiReadOnly(),
 		},
-		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
-		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
-		BranchControlFile: ptr(cfg.BranchControlFilePath()),
-		SystemVars_:       systemVars,
-		Vars:              cfg.UserVars(),
-		Jwks:              cfg.JwksConfig(),
+		ClusterCfg:         clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
+		PrivilegeFile:      ptr(cfg.PrivilegeFilePath()),
+		PrivilegeDatabase_: nillableStrPtr(cfg.PrivilegeDatabase()),
+		BranchControlFile:  ptr(cfg.BranchControlFilePath()),
+		SystemVars_:        systemVars,
+		Vars:               cfg.UserVars(),
+		Jwks:               cfg.JwksConfig(),
+		UserLimits_:        cfg.UserLimits(),
+		RemoteDBs:          cfg.RemoteDatabases(),
+		UDFs:               cfg.UserDefinedFunctions(),
+		EdgeSync_:          cfg.EdgeSync(),
+		ScheduledPulls_:    cfg.ScheduledPulls(),
+		ExportJobs_:        cfg.ExportJobs(),
+		LDAP_:              cfg.LDAPConfig(),
+		OIDC_:              cfg.OIDCConfig(),
 	}
 }
 
@@ -395,6 +601,16 @@ func (cfg YAMLConfig) DisableClientMultiStatements() bool {
 	return *cfg.BehaviorConfig.DisableClientMultiStatements
 }
 
+// AutoCommitConflictRetries returns the number of times the server reruns autocommit statements which fail with a
+// transaction conflict.
+func (cfg YAMLConfig) AutoCommitConflictRetries() int {
+	if cfg.BehaviorConfig.AutoCommitConflictRetries == nil {
+		return DefaultAutoCommitConflictRetries
+	}
+
+	return *cfg.BehaviorConfig.AutoCommitConflictRetries
+}
+
 // MetricsLabels returns labels that are applied to all prometheus metrics
 func (cfg YAMLConfig) MetricsLabels() map[string]string {
 	return cfg.MetricsConfig.Labels
@@ -419,6 +635,14 @@ func (cfg YAMLConfig) MetricsPort() int {
 	return *cfg.MetricsConfig.Port
 }
 
+func (cfg YAMLConfig) MetricsPprof() bool {
+	if cfg.MetricsConfig.Pprof == nil {
+		return DefaultMetricsPprof
+	}
+
+	return *cfg.MetricsConfig.Pprof
+}
+
 func (cfg YAMLConfig) RemotesapiPort() *int {
 	return cfg.RemotesapiConfig.Port_
 }
@@ -436,6 +660,15 @@ func (cfg YAMLConfig) PrivilegeFilePath() string {
 	return filepath.Join(cfg.CfgDir(), DefaultPrivilegeFilePath)
 }
 
+// PrivilegeDatabase returns the name of the database whose dolt_privileges table stores users and grants, or the
+// empty string if they're stored in the privilege file.
+func (cfg YAMLConfig) PrivilegeDatabase() string {
+	if cfg.PrivilegeDatabase_ == nil {
+		return ""
+	}
+	return *cfg.PrivilegeDatabase_
+}
+
 // BranchControlFilePath returns the path to the file which contains the branch control permissions.
 func (cfg YAMLConfig) BranchControlFilePath() string {
 	if cfg.BranchControlFile != nil {
@@ -453,6 +686,36 @@ func (cfg YAMLConfig) UserVars() []UserSessionVars {
 	return nil
 }
 
+// UserLimits limits the resources of users' connections.
+func (cfg YAMLConfig) UserLimits() []UserLimits {
+	return cfg.UserLimits_
+}
+
+// RemoteDatabases returns the read-only databases served directly from remotes.
+func (cfg YAMLConfig) RemoteDatabases() []RemoteDatabaseConfig {
+	return cfg.RemoteDBs
+}
+
+// UserDefinedFunctions returns the SQL functions implemented by WebAssembly modules.
+func (cfg YAMLConfig) UserDefinedFunctions() []UserDefinedFunctionConfig {
+	return cfg.UDFs
+}
+
+// EdgeSync returns the configuration of edge sync mode, or nil if it's off.
+func (cfg YAMLConfig) EdgeSync() *EdgeSyncConfig {
+	return cfg.EdgeSync_
+}
+
+// ScheduledPulls returns the periodic pulls of branches from remotes.
+func (cfg YAMLConfig) ScheduledPulls() []ScheduledPullConfig {
+	return cfg.ScheduledPulls_
+}
+
+// ExportJobs returns what export jobs can write to and notify.
+func (cfg YAMLConfig) ExportJobs() *ExportJobsConfig {
+	return cfg.ExportJobs_
+}
+
 func (cfg YAMLConfig) SystemVars() map[string]interface{} {
 	if cfg.SystemVars_ == nil {
 		return map[string]interface{}{}
@@ -469,6 +732,16 @@ func (cfg YAMLConfig) JwksConfig() []JwksConfig {
 	return nil
 }
 
+// LDAPConfig configures the authentication of users against an LDAP server.
+func (cfg YAMLConfig) LDAPConfig() *LDAPConfig {
+	return cfg.LDAP_
+}
+
+// OIDCConfig configures the authentication of users with OpenID Connect tokens.
+func (cfg YAMLConfig) OIDCConfig() *OIDCConfig {
+	return cfg.OIDC_
+}
+
 func (cfg YAMLConfig) AllowCleartextPasswords() bool {
 	if cfg.ListenerConfig.AllowCleartextPasswords == nil {
 		return DefaultAllowCleartextPasswords
@@ -500,6 +773,11 @@ func (cfg YAMLConfig) RequireSecureTransport() bool {
 	return *cfg.ListenerConfig.RequireSecureTransport
 }
 
+// ClientCertConfig configures the authentication of clients with TLS client certificates.
+func (cfg YAMLConfig) ClientCertConfig() *ClientCertConfig {
+	return cfg.ListenerConfig.ClientCert
+}
+
 // MaxLoggedQueryLen is the max length of queries written to the logs.  Queries longer than this number are truncated.
 // If this value is 0 then the query is not truncated and will be written to the logs in its entirety.  If the value
 // is less than 0 then the queries will be omitted from the logs completely
@@ -15,7 +15,9 @@
 package servercfg
 
 import (
+	"crypto/tls"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,6 +35,7 @@ behavior:
     autocommit: true
     dolt_transaction_commit: true
     disable_client_multi_statements: false
+    autocommit_conflict_retries: 3
     event_scheduler: ON
 
 user:
@@ -51,6 +54,7 @@ data_dir: some nonsense
 metrics:
     host: 123.45.67.89
     port: 9091
+    pprof: true
     labels:
         label1: value1
         label2: 2
@@ -70,6 +74,8 @@ user_session_vars:
 
 privilege_file: some other nonsense
 
+privilege_database: users_db
+
 branch_control_file: third nonsense
 
 jwks:
@@ -84,17 +90,73 @@ jwks:
     claims: 
       field1: a
     fields_to_log:
+
+user_limits:
+  - name: orders
+    max_connections: 10
+    max_statements_per_second: 100
+    max_rows_per_query: 5000
+  - name: "%"
+    max_connections: 2
+
+remote_databases:
+  - name: replica
+    remote_url: aws://[table:bucket]/db
+    params:
+      aws-region: us-west-2
+    refresh_interval_millis: 5000
+    cache_size_mb: 64
+
+user_defined_functions:
+  - name: normalize_phone
+    module_path: /udfs/phone.wasm
+    export: normalize
+    version: 1.2.0
+    text: true
+    timeout_millis: 250
+
+edge_sync:
+  device_branch: device1
+  remote: hub
+  interval_millis: 30000
+
+scheduled_pulls:
+  - database: mirror
+    branches: [main, release]
+    interval_millis: 600000
+    conflict_policy: prefer-remote
+    on_failure: https://hooks.example.com/pulls
+
+export_jobs:
+  directory: /var/lib/dolt/exports
+  notification_urls: [https://hooks.example.com/exports]
+
+ldap:
+  url: ldaps://ldap.example.com
+  bind_dn_template: uid={user},ou=people,dc=example,dc=com
+  group_roles:
+    cn=admins,ou=groups,dc=example,dc=com: admin
+
+oidc:
+  issuer: https://issuer.example.com
+  audience: dolt
+  groups_claim: roles
+  group_roles:
+    readers: reader
 `
 	expected := ServerConfigAsYAMLConfig(DefaultServerConfig())
 
 	expected.BehaviorConfig.DoltTransactionCommit = &trueValue
+	expected.BehaviorConfig.AutoCommitConflictRetries = ptr(3)
 	expected.CfgDirStr = nillableStrPtr("")
 	expected.PrivilegeFile = ptr("some other nonsense")
+	expected.PrivilegeDatabase_ = ptr("users_db")
 	expected.BranchControlFile = ptr("third nonsense")
 
 	expected.MetricsConfig = MetricsYAMLConfig{
-		Host: ptr("123.45.67.89"),
-		Port: ptr(9091),
+		Host:  ptr("123.45.67.89"),
+		Port:  ptr(9091),
+		Pprof: ptr(true),
 		Labels: map[string]string{
 			"label1": "value1",
 			"label2": "2",
@@ -140,6 +202,66 @@ jwks:
 			FieldsToLog: nil,
 		},
 	}
+	expected.UserLimits_ = []UserLimits{
+		{
+			Name:                   "orders",
+			MaxConnections:         10,
+			MaxStatementsPerSecond: 100,
+			MaxRowsPerQuery:        5000,
+		},
+		{
+			Name:           "%",
+			MaxConnections: 2,
+		},
+	}
+	expected.RemoteDBs = []RemoteDatabaseConfig{
+		{
+			Name:                  "replica",
+			RemoteURL:             "aws://[table:bucket]/db",
+			Params:                map[string]string{"aws-region": "us-west-2"},
+			RefreshIntervalMillis: ptr(5000),
+			CacheSizeMB:           ptr(64),
+		},
+	}
+	expected.UDFs = []UserDefinedFunctionConfig{
+		{
+			Name:          "normalize_phone",
+			ModulePath:    "/udfs/phone.wasm",
+			Export:        "normalize",
+			Version:       "1.2.0",
+			Text:          true,
+			TimeoutMillis: ptr(250),
+		},
+	}
+	expected.EdgeSync_ = &EdgeSyncConfig{
+		DeviceBranch:   "device1",
+		Remote:         ptr("hub"),
+		IntervalMillis: ptr(30000),
+	}
+	expected.ScheduledPulls_ = []ScheduledPullConfig{
+		{
+			Database:       "mirror",
+			Branches:       []string{"main", "release"},
+			IntervalMillis: ptr(600000),
+			ConflictPolicy: ptr("prefer-remote"),
+			OnFailure:      "https://hooks.example.com/pulls",
+		},
+	}
+	expected.ExportJobs_ = &ExportJobsConfig{
+		Directory:        "/var/lib/dolt/exports",
+		NotificationURLs: []string{"https://hooks.example.com/exports"},
+	}
+	expected.LDAP_ = &LDAPConfig{
+		URL:            "ldaps://ldap.example.com",
+		BindDNTemplate: "uid={user},ou=people,dc=example,dc=com",
+		GroupRoles:     map[string]string{"cn=admins,ou=groups,dc=example,dc=com": "admin"},
+	}
+	expected.OIDC_ = &OIDCConfig{
+		Issuer:      "https://issuer.example.com",
+		Audience:    "dolt",
+		GroupsClaim: "roles",
+		GroupRoles:  map[string]string{"readers": "reader"},
+	}
 
 	config, err := NewYamlConfig([]byte(testStr))
 	require.NoError(t, err)
@@ -413,6 +535,133 @@ listener:
 	require.NoError(t, err)
 	err = ValidateConfig(cfg)
 	assert.Error(t, err)
+
+	cfg = YAMLConfig{}
+	err = yaml.Unmarshal([]byte(`
+listener:
+  tls_key: testdata/selfsigned_key.pem
+  tls_cert: testdata/selfsigned_cert.pem
+  client_cert:
+    ca: testdata/chain_cert.pem
+    users:
+      orders.mesh.local: orders
+`), &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"orders.mesh.local": "orders"}, cfg.ClientCertConfig().Users)
+	c, err = LoadTLSConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, c.ClientAuth)
+	assert.NotNil(t, c.ClientCAs)
+
+	cfg.ListenerConfig.ClientCert.Required = true
+	c, err = LoadTLSConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, c.ClientAuth)
+
+	cfg.ListenerConfig.ClientCert.CA = "testdata/chain_key.pem"
+	_, err = LoadTLSConfig(cfg)
+	assert.Error(t, err)
+
+	cfg = YAMLConfig{}
+	err = yaml.Unmarshal([]byte(`
+listener:
+  client_cert:
+    ca: testdata/chain_cert.pem
+`), &cfg)
+	require.NoError(t, err)
+	err = ValidateConfig(cfg)
+	assert.Error(t, err)
+}
+
+func TestRemoteDatabases(t *testing.T) {
+	db := RemoteDatabaseConfig{Name: "replica", RemoteURL: "file:///remote"}
+	assert.Equal(t, DefaultRemoteDatabaseRefreshInterval, db.RefreshInterval())
+	assert.Equal(t, uint64(DefaultRemoteDatabaseCacheSizeMB<<20), db.CacheSize())
+	db.RefreshIntervalMillis, db.CacheSizeMB = ptr(500), ptr(1)
+	assert.Equal(t, 500*time.Millisecond, db.RefreshInterval())
+	assert.Equal(t, uint64(1<<20), db.CacheSize())
+
+	assert.NoError(t, validateRemoteDatabases(nil))
+	assert.NoError(t, validateRemoteDatabases([]RemoteDatabaseConfig{db, {Name: "other", RemoteURL: "file:///other"}}))
+	assert.Error(t, validateRemoteDatabases([]RemoteDatabaseConfig{{RemoteURL: "file:///remote"}}))
+	assert.Error(t, validateRemoteDatabases([]RemoteDatabaseConfig{{Name: "replica"}}))
+	assert.Error(t, validateRemoteDatabases([]RemoteDatabaseConfig{db, {Name: "REPLICA", RemoteURL: "file:///other"}}))
+	assert.Error(t, validateRemoteDatabases([]RemoteDatabaseConfig{{Name: "replica", RemoteURL: "file:///remote", RefreshIntervalMillis: ptr(0)}}))
+	assert.Error(t, validateRemoteDatabases([]RemoteDatabaseConfig{{Name: "replica", RemoteURL: "file:///remote", CacheSizeMB: ptr(-1)}}))
+}
+
+func TestUserDefinedFunctions(t *testing.T) {
+	fn := UserDefinedFunctionConfig{Name: "normalize_phone", ModulePath: "/udfs/phone.wasm"}
+	assert.Equal(t, "normalize_phone", fn.ExportName())
+	assert.Equal(t, uint64(DefaultUDFMaxMemoryMB<<20), fn.MaxMemory())
+	assert.Equal(t, DefaultUDFTimeout, fn.Timeout())
+	fn.Export, fn.MaxMemoryMB, fn.TimeoutMillis = "normalize", ptr(2), ptr(250)
+	assert.Equal(t, "normalize", fn.ExportName())
+	assert.Equal(t, uint64(2<<20), fn.MaxMemory())
+	assert.Equal(t, 250*time.Millisecond, fn.Timeout())
+
+	digest := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	assert.NoError(t, validateUserDefinedFunctions(nil))
+	assert.NoError(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{fn, {Name: "other", ModulePath: "/udfs/other.wasm", SHA256: digest}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{{ModulePath: "/udfs/phone.wasm"}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{{Name: "normalize_phone"}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{fn, {Name: "NORMALIZE_PHONE", ModulePath: "/udfs/other.wasm"}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{{Name: "f", ModulePath: "/f.wasm", SHA256: "abc"}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{{Name: "f", ModulePath: "/f.wasm", MaxMemoryMB: ptr(0)}}))
+	assert.Error(t, validateUserDefinedFunctions([]UserDefinedFunctionConfig{{Name: "f", ModulePath: "/f.wasm", TimeoutMillis: ptr(-1)}}))
+}
+
+func TestEdgeSync(t *testing.T) {
+	es := EdgeSyncConfig{DeviceBranch: "device1"}
+	assert.Equal(t, DefaultEdgeSyncCentralBranch, es.CentralBranchName())
+	assert.Equal(t, DefaultEdgeSyncRemote, es.RemoteName())
+	assert.Equal(t, DefaultEdgeSyncInterval, es.Interval())
+	es.CentralBranch, es.Remote, es.IntervalMillis = ptr("central"), ptr("hub"), ptr(5000)
+	assert.Equal(t, "central", es.CentralBranchName())
+	assert.Equal(t, "hub", es.RemoteName())
+	assert.Equal(t, 5*time.Second, es.Interval())
+
+	assert.NoError(t, validateEdgeSync(nil))
+	assert.NoError(t, validateEdgeSync(&es))
+	assert.Error(t, validateEdgeSync(&EdgeSyncConfig{}))
+	assert.Error(t, validateEdgeSync(&EdgeSyncConfig{DeviceBranch: "main"}))
+	assert.Error(t, validateEdgeSync(&EdgeSyncConfig{DeviceBranch: "device1", Remote: ptr("")}))
+	assert.Error(t, validateEdgeSync(&EdgeSyncConfig{DeviceBranch: "device1", IntervalMillis: ptr(0)}))
+}
+
+func TestScheduledPulls(t *testing.T) {
+	sp := ScheduledPullConfig{Database: "mirror", Branches: []string{"main"}}
+	assert.Equal(t, DefaultScheduledPullRemote, sp.RemoteName())
+	assert.Equal(t, DefaultScheduledPullInterval, sp.Interval())
+	assert.Equal(t, ScheduledPullConflictAbort, sp.ConflictPolicyName())
+	sp.Remote, sp.IntervalMillis, sp.ConflictPolicy = ptr("upstream"), ptr(5000), ptr(ScheduledPullConflictPreferLocal)
+	assert.Equal(t, "upstream", sp.RemoteName())
+	assert.Equal(t, 5*time.Second, sp.Interval())
+	assert.Equal(t, ScheduledPullConflictPreferLocal, sp.ConflictPolicyName())
+
+	assert.NoError(t, validateScheduledPulls(nil))
+	assert.NoError(t, validateScheduledPulls([]ScheduledPullConfig{sp}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Branches: []string{"main"}}}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Database: "mirror"}}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Database: "mirror", Branches: []string{""}}}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Database: "mirror", Branches: []string{"main"}, Remote: ptr("")}}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Database: "mirror", Branches: []string{"main"}, IntervalMillis: ptr(0)}}))
+	assert.Error(t, validateScheduledPulls([]ScheduledPullConfig{{Database: "mirror", Branches: []string{"main"}, ConflictPolicy: ptr("theirs")}}))
+}
+
+func TestValidateExportJobs(t *testing.T) {
+	assert.NoError(t, validateExportJobs(nil))
+	assert.NoError(t, validateExportJobs(&ExportJobsConfig{Directory: "exports", NotificationURLs: []string{"https://hooks.example.com/exports"}}))
+	assert.Error(t, validateExportJobs(&ExportJobsConfig{NotificationURLs: []string{"file:///etc/passwd"}}))
+	assert.Error(t, validateExportJobs(&ExportJobsConfig{NotificationURLs: []string{"hooks.example.com/exports"}}))
+}
+
+func TestValidateUserLimits(t *testing.T) {
+	assert.NoError(t, validateUserLimits(nil))
+	assert.NoError(t, validateUserLimits([]UserLimits{{Name: "orders", MaxConnections: 1}, {Name: "%", MaxRowsPerQuery: 10}}))
+	assert.Error(t, validateUserLimits([]UserLimits{{MaxConnections: 1}}))
+	assert.Error(t, validateUserLimits([]UserLimits{{Name: "orders"}, {Name: "orders"}}))
+	assert.Error(t, validateUserLimits([]UserLimits{{Name: "orders", MaxStatementsPerSecond: -1}}))
 }
 
 func TestYAMLConfigMetrics(t *testing.T) {
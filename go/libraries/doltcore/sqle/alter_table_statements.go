@@ -0,0 +1,369 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// ignoresAlterTableChange returns whether |stmt| is an ALTER TABLE statement with a change that the parser accepted
+// but didn't record, which may be one of the changes that alterTableStorage makes.
+func ignoresAlterTableChange(stmt ast.Statement) bool {
+	alter, ok := stmt.(*ast.AlterTable)
+	if !ok {
+		return false
+	}
+	for _, ddl := range alter.Statements {
+		if ddl.Action == ast.AlterStr && ddl.ColumnAction == "" && ddl.ConstraintAction == "" && ddl.TableSpec == nil &&
+			ddl.IndexSpec == nil && ddl.AutoIncSpec == nil && ddl.DefaultSpec == nil && ddl.NotNullSpec == nil &&
+			ddl.ColumnTypeSpec == nil && ddl.AlterCollationSpec == nil && ddl.PartitionSpec == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// alterTableChange is a change made by an ALTER TABLE statement that the parser accepts but doesn't record:
+//
+//	ALTER INDEX index {VISIBLE | INVISIBLE}
+//	KEY_BLOCK_SIZE [=] size
+//	ENGINE [=] engine
+type alterTableChange struct {
+	// index is the index whose visibility ALTER INDEX changes, and is empty for the other changes
+	index     string
+	invisible bool
+	// engine is the engine of ENGINE, and is empty for the other changes
+	engine string
+	// keyBlockSize is the size of KEY_BLOCK_SIZE in kilobytes
+	keyBlockSize uint64
+}
+
+// String returns the SQL of the change.
+func (c alterTableChange) String() string {
+	switch {
+	case c.engine != "":
+		return fmt.Sprintf("ENGINE = %s", c.engine)
+	case c.index == "":
+		return fmt.Sprintf("KEY_BLOCK_SIZE = %d", c.keyBlockSize)
+	}
+	visibility := "VISIBLE"
+	if c.invisible {
+		visibility = "INVISIBLE"
+	}
+	return fmt.Sprintf("ALTER INDEX %s %s", sql.QuoteIdentifier(c.index), visibility)
+}
+
+// name returns the name of the kind of the change, for errors.
+func (c alterTableChange) name() string {
+	switch {
+	case c.engine != "":
+		return "ENGINE"
+	case c.index == "":
+		return "KEY_BLOCK_SIZE"
+	case c.invisible:
+		return "ALTER INDEX ... INVISIBLE"
+	}
+	return "ALTER INDEX ... VISIBLE"
+}
+
+// alterTableStorage is the node of an ALTER TABLE statement whose changes are all alterTableChanges. They change how a
+// table's indexes are stored and used rather than its columns, so the parser accepts them without recording them, and
+// a DoltParser replaces the statement with an ast.InjectedStatement of this node, see newAlterTableStorage. It needs
+// the ALTER privilege on the table, as other ALTER TABLE statements do.
+type alterTableStorage struct {
+	db, table string
+	changes   []alterTableChange
+}
+
+var _ sql.ExecSourceRel = (*alterTableStorage)(nil)
+var _ ast.Injectable = (*alterTableStorage)(nil)
+
+// newAlterTableStorage returns the statement to run for |alter|, which was parsed from |query|. It returns |alter| if
+// none of its changes are alterTableChanges, and an error if only some of them are, because alterTableChanges can't be
+// combined with the changes that the parser records.
+func newAlterTableStorage(alter *ast.AlterTable, query string, options ast.ParserOptions) (ast.Statement, error) {
+	changes, others, err := scanAlterTableChanges(query, options)
+	if err != nil {
+		return nil, err
+	} else if len(changes) == 0 {
+		return alter, nil
+	} else if others {
+		return nil, fmt.Errorf("%s can't be combined with other changes to a table", changes[0].name())
+	}
+	db, table := alter.Table.DbQualifier.String(), alter.Table.Name.String()
+	return ast.InjectedStatement{
+		Statement: &alterTableStorage{db: db, table: table, changes: changes},
+		Auth: ast.AuthInformation{
+			AuthType:    ast.AuthType_ALTER,
+			TargetType:  ast.AuthTargetType_SingleTableIdentifier,
+			TargetNames: []string{db, table},
+		},
+	}, nil
+}
+
+// scanAlterTableChanges returns the alterTableChanges of the ALTER TABLE statement |query|, and whether the statement
+// makes any other changes.
+func scanAlterTableChanges(query string, options ast.ParserOptions) ([]alterTableChange, bool, error) {
+	tokenizer := ast.NewStringTokenizer(query)
+	if options.AnsiQuotes {
+		tokenizer = ast.NewStringTokenizerForAnsiQuotes(query)
+	}
+	s := &sequenceStatementScanner{tokenizer: tokenizer}
+	s.next()
+	if s.tok != ast.ALTER {
+		return nil, false, nil
+	}
+	s.next()
+	if s.tok != ast.TABLE {
+		return nil, false, nil
+	}
+	s.next()
+	// the statement was parsed, so its table name is [db.]table
+	s.next()
+	if s.tok == '.' {
+		s.next()
+		s.next()
+	}
+
+	var changes []alterTableChange
+	var others bool
+	for s.tok != 0 && s.tok != ';' && s.tok != ast.LEX_ERROR {
+		change, ok, err := s.alterTableChange()
+		if err != nil {
+			return nil, false, err
+		} else if ok {
+			changes = append(changes, change)
+		} else {
+			others = true
+			s.skipAlterTableChange()
+		}
+		if s.tok == ',' {
+			s.next()
+		} else if s.tok != 0 && s.tok != ';' {
+			others = true
+			s.skipAlterTableChange()
+		}
+	}
+	return changes, others, nil
+}
+
+// alterTableChange scans the current change of an ALTER TABLE statement, and returns false if it isn't an
+// alterTableChange, in which case the rest of the change is left to be skipped.
+func (s *sequenceStatementScanner) alterTableChange() (alterTableChange, bool, error) {
+	switch {
+	case s.tok == ast.ALTER:
+		s.next()
+		if s.tok != ast.INDEX {
+			return alterTableChange{}, false, nil
+		}
+		s.next()
+		if s.tok != ast.ID {
+			return alterTableChange{}, false, nil
+		}
+		change := alterTableChange{index: s.val}
+		s.next()
+		switch {
+		case s.skipWord("visible"):
+		case s.skipWord("invisible"):
+			change.invisible = true
+		default:
+			return alterTableChange{}, false, nil
+		}
+		return change, true, nil
+
+	case s.isWord("key_block_size"):
+		s.next()
+		if s.tok == '=' {
+			s.next()
+		}
+		kb, err := strconv.ParseUint(s.val, 10, 64)
+		if s.tok != ast.INTEGRAL || err != nil {
+			return alterTableChange{}, false, fmt.Errorf("invalid KEY_BLOCK_SIZE '%s', it must be 0, 1, 2, 4 or 8", s.val)
+		}
+		s.next()
+		return alterTableChange{keyBlockSize: kb}, true, nil
+
+	case s.isWord("engine"):
+		s.next()
+		if s.tok == '=' {
+			s.next()
+		}
+		if s.tok == 0 || s.tok == ';' || s.tok == ',' || s.tok == ast.LEX_ERROR {
+			return alterTableChange{}, false, fmt.Errorf("missing ENGINE")
+		}
+		engine := s.val
+		s.next()
+		return alterTableChange{engine: engine}, true, nil
+	}
+	return alterTableChange{}, false, nil
+}
+
+// skipAlterTableChange advances to the end of the current change of an ALTER TABLE statement, which is the next comma
+// outside of parentheses or the end of the statement.
+func (s *sequenceStatementScanner) skipAlterTableChange() {
+	depth := 0
+	for s.tok != 0 && s.tok != ';' && s.tok != ast.LEX_ERROR {
+		switch {
+		case s.tok == '(':
+			depth++
+		case s.tok == ')':
+			depth--
+		case s.tok == ',' && depth == 0:
+			return
+		}
+		s.next()
+	}
+}
+
+// WithResolvedChildren implements ast.Injectable.
+func (a *alterTableStorage) WithResolvedChildren(children []any) (any, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("invalid child count, expected 0 but got %d", len(children))
+	}
+	return a, nil
+}
+
+// Resolved implements sql.Node.
+func (a *alterTableStorage) Resolved() bool {
+	return true
+}
+
+// String implements sql.Node.
+func (a *alterTableStorage) String() string {
+	name := sql.QuoteIdentifier(a.table)
+	if a.db != "" {
+		name = sql.QuoteIdentifier(a.db) + "." + name
+	}
+	changes := make([]string, len(a.changes))
+	for i, change := range a.changes {
+		changes[i] = change.String()
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s", name, strings.Join(changes, ", "))
+}
+
+// Schema implements sql.Node.
+func (a *alterTableStorage) Schema() sql.Schema {
+	return types.OkResultSchema
+}
+
+// Children implements sql.Node.
+func (a *alterTableStorage) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements sql.Node.
+func (a *alterTableStorage) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(a, len(children), 0)
+	}
+	return a, nil
+}
+
+// IsReadOnly implements sql.Node.
+func (a *alterTableStorage) IsReadOnly() bool {
+	return false
+}
+
+// indexVisibilityAlterableTable is a table whose indexes can be made invisible to the query planner.
+type indexVisibilityAlterableTable interface {
+	sql.Table
+	SetIndexInvisible(ctx *sql.Context, indexName string, invisible bool) error
+}
+
+// nodeSizeAlterableTable is a table whose rows can be chunked to a target node size.
+type nodeSizeAlterableTable interface {
+	sql.Table
+	SetTargetNodeSize(ctx *sql.Context, size uint32) error
+}
+
+// storageLayoutAlterableTable is a table whose rows can be stored column-wise.
+type storageLayoutAlterableTable interface {
+	sql.Table
+	SetStorageLayout(ctx *sql.Context, layout schema.StorageLayout) error
+}
+
+// RowIter implements sql.ExecSourceRel. KEY_BLOCK_SIZE sets the target size of the leaf nodes of the table's primary
+// index in kilobytes, which is 1, 2, 4 or 8, or 0 to opt in to a size chosen from the table's row width. ENGINE sets
+// the layout of the rows of the table's primary index: COLUMNAR stores them column-wise, and any other engine, such
+// as InnoDB, stores them row-wise.
+func (a *alterTableStorage) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	dbName := a.db
+	if dbName == "" {
+		dbName = ctx.GetCurrentDatabase()
+	}
+	if dbName == "" {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+	db, err := dsess.DSessFromSess(ctx.Session).Provider().Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	table, ok, err := db.GetTableInsensitive(ctx, a.table)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(a.table)
+	}
+
+	for _, change := range a.changes {
+		if change.index != "" {
+			alterable, ok := table.(indexVisibilityAlterableTable)
+			if !ok {
+				return nil, fmt.Errorf("the indexes of table %s can't be made invisible", a.table)
+			}
+			if err := alterable.SetIndexInvisible(ctx, change.index, change.invisible); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if change.engine != "" {
+			layout := schema.RowStorageLayout
+			if strings.EqualFold(change.engine, "columnar") {
+				layout = schema.ColumnarStorageLayout
+			}
+			alterable, ok := table.(storageLayoutAlterableTable)
+			if !ok {
+				return nil, fmt.Errorf("the ENGINE of table %s can't be changed", a.table)
+			}
+			if err := alterable.SetStorageLayout(ctx, layout); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		kb := change.keyBlockSize
+		if kb != 0 && kb != 1 && kb != 2 && kb != 4 && kb != 8 {
+			return nil, fmt.Errorf("invalid KEY_BLOCK_SIZE '%d', it must be 0, 1, 2, 4 or 8", kb)
+		}
+		alterable, ok := table.(nodeSizeAlterableTable)
+		if !ok {
+			return nil, fmt.Errorf("the KEY_BLOCK_SIZE of table %s can't be changed", a.table)
+		}
+		if err := alterable.SetTargetNodeSize(ctx, uint32(kb)*1024); err != nil {
+			return nil, err
+		}
+	}
+	return sql.RowsToRowIter(sql.NewRow(types.NewOkResult(0))), nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlterTableParser(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		auth     []string
+		err      string
+	}{
+		{
+			query:    "alter table t alter column c set default 1",
+			expected: "alter table t alter column c set default 1",
+		},
+		{
+			query:    "alter table t engine = innodb",
+			expected: "ALTER TABLE `t` ENGINE = innodb",
+			auth:     []string{"", "t"},
+		},
+		{
+			query:    "ALTER TABLE db.t ENGINE COLUMNAR;",
+			expected: "ALTER TABLE `db`.`t` ENGINE = COLUMNAR",
+			auth:     []string{"db", "t"},
+		},
+		{
+			query:    "alter table t engine = columnar, key_block_size = 4",
+			expected: "ALTER TABLE `t` ENGINE = columnar, KEY_BLOCK_SIZE = 4",
+			auth:     []string{"", "t"},
+		},
+		{
+			query: "alter table t engine = columnar, add column c int",
+			err:   "ENGINE can't be combined with other changes to a table",
+		},
+		{
+			query:    "alter table t alter index i invisible",
+			expected: "ALTER TABLE `t` ALTER INDEX `i` INVISIBLE",
+			auth:     []string{"", "t"},
+		},
+		{
+			query:    "/* comment */ ALTER TABLE db.`my t` ALTER INDEX `my i` VISIBLE;",
+			expected: "ALTER TABLE `db`.`my t` ALTER INDEX `my i` VISIBLE",
+			auth:     []string{"db", "my t"},
+		},
+		{
+			query: "alter table t alter index i invisible, add column c int",
+			err:   "ALTER INDEX ... INVISIBLE can't be combined with other changes to a table",
+		},
+		{
+			query: "alter table t add column c int, alter index i visible",
+			err:   "ALTER INDEX ... VISIBLE can't be combined with other changes to a table",
+		},
+		{
+			query:    "alter table t key_block_size = 2",
+			expected: "ALTER TABLE `t` KEY_BLOCK_SIZE = 2",
+			auth:     []string{"", "t"},
+		},
+		{
+			query:    "ALTER TABLE db.t KEY_BLOCK_SIZE 8;",
+			expected: "ALTER TABLE `db`.`t` KEY_BLOCK_SIZE = 8",
+			auth:     []string{"db", "t"},
+		},
+		{
+			query:    "alter table t alter index i invisible, key_block_size = 1, alter index j visible",
+			expected: "ALTER TABLE `t` ALTER INDEX `i` INVISIBLE, KEY_BLOCK_SIZE = 1, ALTER INDEX `j` VISIBLE",
+			auth:     []string{"", "t"},
+		},
+		{
+			query: "alter table t key_block_size = 2, add column c int",
+			err:   "KEY_BLOCK_SIZE can't be combined with other changes to a table",
+		},
+		{
+			query: "alter table t key_block_size = 'big'",
+			err:   "invalid KEY_BLOCK_SIZE 'big', it must be 0, 1, 2, 4 or 8",
+		},
+	}
+
+	parser := NewDoltParser(sql.GlobalParser)
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := parser.ParseSimple(test.query)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ast.String(stmt))
+			if test.auth != nil {
+				injected := stmt.(ast.InjectedStatement)
+				assert.Equal(t, ast.AuthType_ALTER, injected.Auth.AuthType)
+				assert.Equal(t, test.auth, injected.Auth.TargetNames)
+			}
+		})
+	}
+}
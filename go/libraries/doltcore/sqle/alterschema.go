@@ -269,6 +269,7 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 				IsSpatial:          index.IsSpatial(),
 				IsFullText:         index.IsFullText(),
 				IsUserDefined:      index.IsUserDefined(),
+				IsInvisible:        index.IsInvisible(),
 				Comment:            index.Comment(),
 				FullTextProperties: index.FullTextProperties(),
 			})
@@ -0,0 +1,81 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// appendOnlyTableWriter is a dsess.TableWriter which rejects updates and deletes, for tables that
+// dolt_append_only_tables makes append-only on the branch being written. See doltdb.AppendOnlyTables.
+type appendOnlyTableWriter struct {
+	dsess.TableWriter
+	err error
+}
+
+var _ dsess.TableWriter = appendOnlyTableWriter{}
+
+// withAppendOnly returns |te| wrapped in an appendOnlyTableWriter if this table is append-only on the branch of the
+// session. The rows of dolt_append_only_tables are read from the head of the branch, so that a transaction can't
+// lift the restriction before updating or deleting rows.
+func (t *WritableDoltTable) withAppendOnly(ctx *sql.Context, te dsess.TableWriter) (dsess.TableWriter, error) {
+	err := t.checkAppendOnly(ctx)
+	if _, ok := err.(doltdb.ErrAppendOnlyTable); ok {
+		return appendOnlyTableWriter{TableWriter: te, err: err}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return te, nil
+}
+
+// checkAppendOnly returns a doltdb.ErrAppendOnlyTable if this table is append-only on the branch of the session.
+func (t *WritableDoltTable) checkAppendOnly(ctx *sql.Context) error {
+	ds := dsess.DSessFromSess(ctx.Session)
+	state, ok, err := ds.LookupDbState(ctx, t.db.RevisionQualifiedName())
+	if err != nil || !ok || state.WorkingSet() == nil {
+		// detached heads can't be written to anyway
+		return err
+	}
+	branch, err := state.WorkingSet().Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+	roots, ok := ds.GetRoots(ctx, t.db.RevisionQualifiedName())
+	if !ok || roots.Head == nil {
+		return nil
+	}
+
+	appendOnly, err := doltdb.IsAppendOnlyTable(ctx, roots.Head, branch.GetPath(), t.TableName())
+	if err != nil {
+		return err
+	}
+	if appendOnly {
+		return doltdb.ErrAppendOnlyTable{Table: t.Name(), Branch: branch.GetPath()}
+	}
+	return nil
+}
+
+// Update implements sql.RowUpdater
+func (w appendOnlyTableWriter) Update(*sql.Context, sql.Row, sql.Row) error {
+	return w.err
+}
+
+// Delete implements sql.RowDeleter
+func (w appendOnlyTableWriter) Delete(*sql.Context, sql.Row) error {
+	return w.err
+}
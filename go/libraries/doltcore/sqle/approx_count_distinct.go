@@ -0,0 +1,333 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/hll"
+)
+
+// planApproxCountDistinctId is the id of the planApproxCountDistinct rule. The ids of go-mysql-server's rules are
+// never negative.
+const planApproxCountDistinctId analyzer.RuleId = -3
+
+// AddApproxCountDistinctRule adds the rule planning approx_count_distinct as an aggregation, see
+// planApproxCountDistinct, to the rules |a| runs before it analyzes a query.
+func AddApproxCountDistinctRule(a *analyzer.Analyzer) {
+	for _, batch := range a.Batches {
+		if batch.Desc == "pre-analyzer" {
+			batch.Rules = append(batch.Rules, analyzer.Rule{Id: planApproxCountDistinctId, Apply: planApproxCountDistinct})
+			return
+		}
+	}
+}
+
+// planApproxCountDistinct is an analyzer rule which plans the approx_count_distinct functions in the select list of a
+// query as aggregations, as go-mysql-server plans its own aggregate functions: they're computed by the query's
+// GroupBy, which is added if the query has none, and the projection reads their results. go-mysql-server plans
+// functions it doesn't know to be aggregate functions as scalar functions of each row.
+//
+// When the query reads every row of a Dolt table, and only reads it to compute approx_count_distinct of columns that
+// the table has statistics over, the estimates are read from the sketches of the statistics instead, without reading
+// the table. They're as fresh as the statistics.
+func planApproxCountDistinct(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *plan.Scope, sel analyzer.RuleSelector, qFlags *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	if plan.IsNoRowNode(n) || !hasApproxCountDistinct(n) {
+		return n, transform.SameTree, nil
+	}
+	nextId := maxColumnId(n) + 1
+	return transform.NodeWithOpaque(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		p, ok := n.(*plan.Project)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		var aggs []sql.Expression
+		projections := make([]sql.Expression, len(p.Projections))
+		for i, e := range p.Projections {
+			ne, _, err := transform.Expr(e, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+				acd, ok := e.(*dfunctions.ApproxCountDistinct)
+				if !ok {
+					return e, transform.SameTree, nil
+				}
+				agg := acd.WithId(nextId)
+				nextId++
+				aggs = append(aggs, agg)
+				return expression.NewGetFieldWithTable(int(agg.Id()), 0, types.Int64, "", "", agg.String(), false), transform.NewTree, nil
+			})
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			if _, ok := e.(*dfunctions.ApproxCountDistinct); ok {
+				ne = expression.NewAlias(e.String(), ne)
+			}
+			projections[i] = ne
+		}
+		if len(aggs) == 0 {
+			return n, transform.SameTree, nil
+		}
+
+		child, ok, err := withAggregations(p.Child, aggs)
+		if err != nil {
+			return nil, transform.SameTree, err
+		} else if !ok {
+			estimates, ok, err := approxCountDistinctFromStats(ctx, p.Child, projections, aggs)
+			if err != nil {
+				return nil, transform.SameTree, err
+			} else if ok {
+				return projectEstimates(projections, estimates)
+			}
+			// the group's other columns are read from its first row, as by a GroupBy without grouping expressions
+			child = plan.NewGroupBy(append(projectedColumns(projections), aggs...), nil, p.Child)
+		}
+		np, err := p.WithExpressions(projections...)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		np, err = np.WithChildren(child)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return np, transform.NewTree, nil
+	})
+}
+
+// withAggregations returns |n| with |aggs| added to the aggregations of its GroupBy, if the rows of |n| are the groups
+// of a GroupBy, perhaps filtered or sorted.
+func withAggregations(n sql.Node, aggs []sql.Expression) (sql.Node, bool, error) {
+	switch n := n.(type) {
+	case *plan.GroupBy:
+		return plan.NewGroupBy(append(n.SelectedExprs[:len(n.SelectedExprs):len(n.SelectedExprs)], aggs...), n.GroupByExprs, n.Child), true, nil
+	case *plan.Having, *plan.Sort:
+		child, ok, err := withAggregations(n.Children()[0], aggs)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		nn, err := n.WithChildren(child)
+		if err != nil {
+			return nil, false, err
+		}
+		return nn, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// hasApproxCountDistinct returns whether the projection of |n| or of one of its descendants computes
+// approx_count_distinct.
+func hasApproxCountDistinct(n sql.Node) bool {
+	var found bool
+	transform.Inspect(n, func(n sql.Node) bool {
+		if p, ok := n.(*plan.Project); ok {
+			for _, e := range p.Projections {
+				if transform.InspectExpr(e, func(e sql.Expression) bool {
+					_, ok := e.(*dfunctions.ApproxCountDistinct)
+					return ok
+				}) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// maxColumnId returns the greatest id of a column of |n|, so that the columns added to |n| can be given ids which
+// aren't already used.
+func maxColumnId(n sql.Node) sql.ColumnId {
+	var max sql.ColumnId
+	transform.Inspect(n, func(n sql.Node) bool {
+		if tn, ok := n.(plan.TableIdNode); ok {
+			tn.Columns().ForEach(func(id sql.ColumnId) {
+				if id > max {
+					max = id
+				}
+			})
+		}
+		if ne, ok := n.(sql.Expressioner); ok {
+			for _, e := range ne.Expressions() {
+				transform.InspectExpr(e, func(e sql.Expression) bool {
+					switch e := e.(type) {
+					case sql.IdExpression:
+						if e.Id() > max {
+							max = e.Id()
+						}
+					case *plan.Subquery:
+						if id := maxColumnId(e.Query); id > max {
+							max = id
+						}
+					}
+					return false
+				})
+			}
+		}
+		return true
+	})
+	return max
+}
+
+// projectedColumns returns the columns read by |projections|.
+func projectedColumns(projections []sql.Expression) []sql.Expression {
+	var cols []sql.Expression
+	seen := make(map[sql.ColumnId]bool)
+	for _, e := range projections {
+		transform.InspectExpr(e, func(e sql.Expression) bool {
+			if gf, ok := e.(*expression.GetField); ok && gf.TableId() != 0 && !seen[gf.Id()] {
+				seen[gf.Id()] = true
+				cols = append(cols, gf)
+			}
+			return false
+		})
+	}
+	return cols
+}
+
+// approxCountDistinctFromStats returns the estimates of |aggs|, the approx_count_distinct aggregations of a projection
+// of |child|, by id, read from the sketches of the statistics of a table, if |child| reads every row of a Dolt table,
+// |projections|, which read |aggs| by id, read nothing else of the table, and the table has statistics over the
+// columns of each of |aggs|.
+func approxCountDistinctFromStats(ctx *sql.Context, child sql.Node, projections, aggs []sql.Expression) (map[sql.ColumnId]int64, bool, error) {
+	if ta, ok := child.(*plan.TableAlias); ok {
+		child = ta.Child
+	}
+	rt, ok := child.(*plan.ResolvedTable)
+	if !ok {
+		return nil, false, nil
+	}
+	dt := doltTableOf(rt.Table)
+	if dt == nil || dt.lockedToRoot != nil || dt.overriddenSchema != nil || dt.db.RevisionType() != dsess.RevisionTypeBranch {
+		return nil, false, nil
+	}
+	for _, e := range projections {
+		if transform.InspectExpr(e, func(e sql.Expression) bool {
+			switch e.(type) {
+			case *expression.GetField:
+				return e.(*expression.GetField).TableId() != 0
+			case *plan.Subquery:
+				return true
+			}
+			return false
+		}) {
+			return nil, false, nil
+		}
+	}
+
+	pro, ok := dsess.DSessFromSess(ctx.Session).StatsProvider().(doltStatsProvider)
+	if !ok {
+		return nil, false, nil
+	}
+	dbName, _ := dsess.SplitRevisionDbName(dt.db.RevisionQualifiedName())
+	stats, err := pro.GetTableDoltStats(ctx, dt.db.Revision(), dbName, dt.db.Schema(), dt.Name())
+	if err != nil {
+		return nil, false, err
+	}
+	estimates := make(map[sql.ColumnId]int64, len(aggs))
+	for _, agg := range aggs {
+		cols := make([]string, len(agg.Children()))
+		for i, arg := range agg.Children() {
+			gf, ok := arg.(*expression.GetField)
+			if !ok {
+				return nil, false, nil
+			}
+			cols[i] = gf.Name()
+		}
+		est, ok, err := estimateFromStats(stats, cols)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+		estimates[agg.(sql.IdExpression).Id()] = est
+	}
+	return estimates, true, nil
+}
+
+// projectEstimates returns a projection of |projections| with the approx_count_distinct aggregations they read
+// replaced by their |estimates|.
+func projectEstimates(projections []sql.Expression, estimates map[sql.ColumnId]int64) (sql.Node, transform.TreeIdentity, error) {
+	for i, e := range projections {
+		var err error
+		projections[i], _, err = transform.Expr(e, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+			if gf, ok := e.(*expression.GetField); ok {
+				if est, ok := estimates[gf.Id()]; ok {
+					return expression.NewLiteral(est, types.Int64), transform.NewTree, nil
+				}
+			}
+			return e, transform.SameTree, nil
+		})
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+	}
+	return plan.NewProject(projections, plan.NewResolvedDualTable()), transform.NewTree, nil
+}
+
+// distinctSketcher is a sql.Statistic that keeps a sketch of its distinct keys.
+type distinctSketcher interface {
+	DistinctSketch() (*hll.Sketch, bool, error)
+}
+
+// doltStatsProvider is a sql.StatsProvider that can list the statistics of a table by name.
+type doltStatsProvider interface {
+	GetTableDoltStats(ctx *sql.Context, branch, db, schema, table string) ([]sql.Statistic, error)
+}
+
+// estimateFromStats estimates the distinct values of the columns |cols| from the sketches of the one of |stats| over
+// exactly |cols|, if there is one.
+func estimateFromStats(stats []sql.Statistic, cols []string) (int64, bool, error) {
+	for _, stat := range stats {
+		if !sameColumns(stat.Columns(), cols) {
+			continue
+		}
+		sketcher, ok := stat.(distinctSketcher)
+		if !ok {
+			continue
+		}
+		sketch, ok, err := sketcher.DistinctSketch()
+		if err != nil {
+			return 0, false, err
+		} else if ok {
+			return int64(sketch.Estimate()), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// sameColumns returns whether |a| and |b| are the same column names in any order.
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, x := range a {
+		var found bool
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// NewAtomicDDLIter returns the row iterator for the DDL statement |n|, built with |b|, which undoes the statement's
+// changes to the working sets of the session if it fails. DDL statements change the working set as they go, so
+// without this a statement that fails part way through, such as an ALTER TABLE with several clauses or a CREATE
+// TABLE ... SELECT, would leave its partial changes in an explicit transaction, to be committed along with the rest
+// of it. The changes are undone on every branch the statement touched.
+//
+// Returns nil if |n| is not a DDL statement, or if it changes databases rather than the tables within them.
+func NewAtomicDDLIter(ctx *sql.Context, n sql.Node, r sql.Row, b sql.NodeExecBuilder) (sql.RowIter, error) {
+	switch n.(type) {
+	case *plan.CreateDB, *plan.DropDB, *plan.AlterDB, *plan.CreateSchema:
+		return nil, nil
+	}
+	if !plan.IsDDLNode(n) {
+		return nil, nil
+	}
+	// Blocks are only DDL statements at the top level, where they hold the clauses of an ALTER TABLE.
+	block, isBlock := n.(*plan.Block)
+	if isBlock && block.Pref != nil {
+		return nil, nil
+	}
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok || dsess.TransactionsDisabled(ctx) {
+		return nil, nil
+	}
+
+	snap := sess.SnapshotWorkingSets()
+	var iter sql.RowIter
+	var err error
+	if isBlock {
+		iter, err = buildAlterBlock(ctx, block, r, b)
+	} else {
+		iter, err = b.Build(ctx, n, r)
+	}
+	if err != nil {
+		if rerr := sess.RestoreWorkingSets(ctx, snap); rerr != nil {
+			ctx.GetLogger().Warnf("unable to undo failed statement: %s", rerr.Error())
+		}
+		return nil, err
+	}
+	return &atomicDDLIter{child: iter, sess: sess, snap: snap}, nil
+}
+
+// buildAlterBlock runs each clause of an ALTER TABLE statement in turn, stopping at the first which fails. The engine
+// runs blocks as stored procedure bodies do, which requires a scope for error handlers that these blocks don't have.
+func buildAlterBlock(ctx *sql.Context, block *plan.Block, r sql.Row, b sql.NodeExecBuilder) (sql.RowIter, error) {
+	var rows []sql.Row
+	for _, child := range block.Children() {
+		iter, err := b.Build(ctx, child, r)
+		if err != nil {
+			return nil, err
+		}
+		childRows, err := sql.RowIterToRows(ctx, iter)
+		if err != nil {
+			return nil, err
+		}
+		// Some clauses, such as AUTO_INCREMENT = N, return no result of their own.
+		if len(childRows) > 0 {
+			rows = childRows
+		}
+	}
+	block.SetSchema(types.OkResultSchema)
+	return sql.RowsToRowIter(rows...), nil
+}
+
+type atomicDDLIter struct {
+	child  sql.RowIter
+	sess   *dsess.DoltSession
+	snap   dsess.WorkingSetSnapshot
+	failed bool
+}
+
+var _ sql.RowIter = (*atomicDDLIter)(nil)
+
+func (i *atomicDDLIter) Next(ctx *sql.Context) (sql.Row, error) {
+	row, err := i.child.Next(ctx)
+	if err != nil && err != io.EOF {
+		i.failed = true
+	}
+	return row, err
+}
+
+func (i *atomicDDLIter) Close(ctx *sql.Context) error {
+	err := i.child.Close(ctx)
+	if i.failed || err != nil {
+		// Undo the statement only once its iterators are closed, so they can't write anything more.
+		if rerr := i.sess.RestoreWorkingSets(ctx, i.snap); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
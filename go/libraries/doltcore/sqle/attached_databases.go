@@ -0,0 +1,184 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// attachedDatabase is a database of another dolt repository, mounted read-only by dolt_attach so that it can be
+// queried alongside the databases of this provider until it's detached.
+type attachedDatabase struct {
+	// source is the path or URL the database was attached from
+	source string
+	// noms is the absolute path of the database's chunk store, for a database attached from a local path
+	noms string
+}
+
+// AttachDatabase mounts the dolt database at |source|, either a local path or a remote URL, read-only as a database
+// named |name|. A database at a local path is served as it is on the default branch of its repository, including its
+// working changes. A remote database is served as of the head of its default branch when it was attached.
+func (p *DoltDatabaseProvider) AttachDatabase(ctx *sql.Context, name, source string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if strings.Contains(name, dsess.DbRevisionDelimiter) {
+		return fmt.Errorf("invalid database name: %s", name)
+	}
+	dbKey := formatDbMapKeyName(name)
+	if _, ok := p.databases[dbKey]; ok {
+		return sql.ErrDatabaseExists.New(name)
+	}
+
+	var db dsess.SqlDatabase
+	var attached attachedDatabase
+	var err error
+	if strings.Contains(source, "://") {
+		db, err = p.attachRemoteDatabase(ctx, name, source)
+	} else {
+		db, attached.noms, err = p.attachLocalDatabase(ctx, name, source)
+	}
+	if err != nil {
+		return err
+	}
+	attached.source = source
+
+	p.databases[dbKey] = db
+	p.attached[dbKey] = attached
+	return nil
+}
+
+func (p *DoltDatabaseProvider) attachLocalDatabase(ctx *sql.Context, name, path string) (dsess.SqlDatabase, string, error) {
+	absPath, err := p.fs.Abs(path)
+	if err != nil {
+		return nil, "", err
+	}
+	for dbName, loc := range p.dbLocations {
+		if dbPath, err := loc.Abs(""); err == nil && filepath.Clean(dbPath) == filepath.Clean(absPath) {
+			return nil, "", fmt.Errorf("cannot attach %s, it is already served as database %s", path, dbName)
+		}
+	}
+	fs, err := p.fs.WithWorkingDir(absPath)
+	if err != nil {
+		return nil, "", err
+	}
+	dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, p.dbFactoryUrl, "TODO")
+	if !dEnv.Valid() {
+		if dEnv.DBLoadError != nil {
+			return nil, "", fmt.Errorf("cannot attach %s: %w", path, dEnv.DBLoadError)
+		}
+		return nil, "", fmt.Errorf("cannot attach %s, it is not a dolt database", path)
+	}
+
+	db, err := NewDatabase(ctx, name, dEnv.DbData(), editor.Options{Deaf: dEnv.DbEaFactory()})
+	if err != nil {
+		return nil, "", err
+	}
+	return ReadOnlyDatabase{Database: db}, filepath.ToSlash(filepath.Join(absPath, dbfactory.DoltDataDir)), nil
+}
+
+func (p *DoltDatabaseProvider) attachRemoteDatabase(ctx *sql.Context, name, url string) (dsess.SqlDatabase, error) {
+	ddb, err := p.GetRemoteDB(ctx, types.Format_Default, env.NewRemote("origin", url, nil), false)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := attachedBranch(ctx, ddb, p.defaultBranch)
+	if err != nil {
+		_ = ddb.Close()
+		return nil, err
+	}
+	cm, err := ddb.ResolveCommitRef(ctx, branch)
+	if err != nil {
+		_ = ddb.Close()
+		return nil, err
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		_ = ddb.Close()
+		return nil, err
+	}
+
+	rs := env.MemoryRepoState{DoltDB: ddb, Head: branch}
+	db, err := NewDatabase(ctx, name, env.DbData{Ddb: ddb, Rsw: rs, Rsr: rs}, editor.Options{})
+	if err != nil {
+		_ = ddb.Close()
+		return nil, err
+	}
+	return revisionDbForCommit(ctx, db, h.String(), name)
+}
+
+// attachedBranch returns the branch of an attached remote database to serve: |defaultBranch| if it exists, or else
+// the first of its branches.
+func attachedBranch(ctx context.Context, ddb *doltdb.DoltDB, defaultBranch string) (ref.DoltRef, error) {
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(branches) == 0 {
+		return nil, fmt.Errorf("cannot attach a database with no branches")
+	}
+	for _, b := range branches {
+		if b.GetPath() == defaultBranch {
+			return b, nil
+		}
+	}
+	return branches[0], nil
+}
+
+// DetachDatabase unmounts the database |name| mounted by AttachDatabase, leaving its source untouched.
+func (p *DoltDatabaseProvider) DetachDatabase(ctx *sql.Context, name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	dbKey := formatDbMapKeyName(name)
+	attached, ok := p.attached[dbKey]
+	if !ok {
+		return fmt.Errorf("database %s is not attached", name)
+	}
+	db := p.databases[dbKey]
+	delete(p.databases, dbKey)
+	delete(p.attached, dbKey)
+
+	if err := p.invalidateDbStateInAllSessions(ctx, name); err != nil {
+		return err
+	}
+	if err := db.DbData().Ddb.Close(); err != nil {
+		return err
+	}
+	if attached.noms != "" {
+		// the database may be attached again, or served, after it's been closed
+		return dbfactory.DeleteFromSingletonCache(attached.noms)
+	}
+	return nil
+}
+
+// isAttachedDatabase returns whether the database |name| was mounted by AttachDatabase. Must be called with the
+// provider's mutex locked.
+func (p *DoltDatabaseProvider) isAttachedDatabase(name string) bool {
+	_, ok := p.attached[formatDbMapKeyName(name)]
+	return ok
+}
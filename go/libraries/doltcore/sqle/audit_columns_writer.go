@@ -0,0 +1,116 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// auditColumnsTableWriter is a dsess.TableWriter which sets the audit columns that dolt_audit_columns configures for
+// a table on every row it inserts or updates, overwriting any values given for them. Since both SQL statements and
+// table imports write rows through the table writers, the audit columns are maintained the same way for both. See
+// doltdb.AuditColumns.
+type auditColumnsTableWriter struct {
+	dsess.TableWriter
+	sch       sql.Schema
+	createdAt []int
+	updatedAt []int
+	updatedBy []int
+}
+
+var _ dsess.TableWriter = auditColumnsTableWriter{}
+
+// withAuditColumns returns |te| wrapped in an auditColumnsTableWriter if dolt_audit_columns configures audit columns
+// for this table.
+func (t *WritableDoltTable) withAuditColumns(ctx *sql.Context, te dsess.TableWriter) (dsess.TableWriter, error) {
+	if doltdb.HasDoltPrefix(t.tableName) {
+		return te, nil
+	}
+	root, err := t.workingRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auditColumns, err := doltdb.GetAuditColumns(ctx, root, t.TableName().Schema)
+	if err != nil {
+		return nil, err
+	}
+	roles := auditColumns.ForTable(t.TableName())
+	if len(roles) == 0 {
+		return te, nil
+	}
+
+	w := auditColumnsTableWriter{TableWriter: te, sch: t.sqlSch.Schema}
+	for i, col := range w.sch {
+		switch roles[strings.ToLower(col.Name)] {
+		case doltdb.AuditColumnCreatedAt:
+			w.createdAt = append(w.createdAt, i)
+		case doltdb.AuditColumnUpdatedAt:
+			w.updatedAt = append(w.updatedAt, i)
+		case doltdb.AuditColumnUpdatedBy:
+			w.updatedBy = append(w.updatedBy, i)
+		}
+	}
+	return w, nil
+}
+
+// Insert implements sql.RowInserter
+func (w auditColumnsTableWriter) Insert(ctx *sql.Context, row sql.Row) error {
+	row = row.Copy()
+	if err := w.set(ctx, row, w.createdAt, ctx.QueryTime()); err != nil {
+		return err
+	}
+	if err := w.setUpdated(ctx, row); err != nil {
+		return err
+	}
+	return w.TableWriter.Insert(ctx, row)
+}
+
+// Update implements sql.RowUpdater
+func (w auditColumnsTableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	new = new.Copy()
+	// the time a row was created can't be changed by updating it
+	for _, i := range w.createdAt {
+		new[i] = old[i]
+	}
+	if err := w.setUpdated(ctx, new); err != nil {
+		return err
+	}
+	return w.TableWriter.Update(ctx, old, new)
+}
+
+func (w auditColumnsTableWriter) setUpdated(ctx *sql.Context, row sql.Row) error {
+	if err := w.set(ctx, row, w.updatedAt, ctx.QueryTime()); err != nil {
+		return err
+	}
+	return w.set(ctx, row, w.updatedBy, ctx.Client().User)
+}
+
+// set sets the columns |cols| of |row| to |v|, converted to the type of each column.
+func (w auditColumnsTableWriter) set(ctx *sql.Context, row sql.Row, cols []int, v interface{}) error {
+	for _, i := range cols {
+		converted, _, err := w.sch[i].Type.Convert(v)
+		if err != nil {
+			return fmt.Errorf("could not set audit column %s: %w", w.sch[i].Name, err)
+		}
+		row[i] = converted
+	}
+	return nil
+}
@@ -36,6 +36,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
@@ -65,6 +66,11 @@ type binlogReplicaApplier struct {
 	running                   atomic.Bool
 	engine                    *gms.Engine
 	dbsWithUncommittedChanges map[string]struct{}
+	// uncommittedTransactions is the number of replicated transactions since the last Dolt commits were created
+	uncommittedTransactions int
+	lastDoltCommit          time.Time
+	// transactionOpen is true while the events of a replicated transaction are being applied
+	transactionOpen bool
 }
 
 func newBinlogReplicaApplier(filters *filterConfiguration) *binlogReplicaApplier {
@@ -276,6 +282,7 @@ func (a *binlogReplicaApplier) replicaBinlogEventHandler(ctx *sql.Context) error
 
 			var err error
 			if conn, err = a.connectAndStartReplicationEventStream(ctx); err == ErrReplicationStopped {
+				a.createDoltCommits(ctx, engine)
 				return nil
 			} else if err != nil {
 				return err
@@ -314,9 +321,13 @@ func (a *binlogReplicaApplier) replicaBinlogEventHandler(ctx *sql.Context) error
 				DoltBinlogReplicaController.setIoError(mysql.ERUnknownError, err.Error())
 			}
 
+		case <-a.doltCommitTimer():
+			a.createDoltCommits(ctx, engine)
+
 		case <-a.stopReplicationChan:
 			ctx.GetLogger().Trace("received stop replication signal")
 			eventProducer.Stop()
+			a.createDoltCommits(ctx, engine)
 			return nil
 		}
 	}
@@ -459,6 +470,7 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			"isBegin": isBegin,
 		}).Trace("Received binlog event: GTID")
 		a.currentGtid = gtid
+		a.transactionOpen = true
 		// if the source's UUID hasn't been set yet, set it and persist it
 		if a.replicationSourceUuid == "" {
 			uuid := fmt.Sprintf("%v", gtid.SourceServer())
@@ -550,23 +562,66 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			return fmt.Errorf("unable to store GTID executed metadata to disk: %s", err.Error())
 		}
 
-		// For now, create a Dolt commit from every data update. Eventually, we'll want to make this configurable.
 		// We commit to every database that we saw had a dirty session – these identify the databases where we have
 		// run DML commands through the engine. We also commit to every database that was modified through a RowEvent,
 		// which is all tracked through the applier's databasesWithUncommitedChanges property – these don't show up
 		// as dirty in our session, since we used TableWriter to update them.
 		a.addDatabasesWithUncommittedChanges(databasesToCommit...)
-		for _, database := range a.databasesWithUncommittedChanges() {
-			executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
-			executeQueryWithEngine(ctx, engine,
-				fmt.Sprintf("call dolt_commit('-Am', 'Dolt binlog replica commit: GTID %s');", a.currentGtid))
+		a.uncommittedTransactions++
+		a.transactionOpen = false
+		interval := doltCommitInterval()
+		if interval == 0 || time.Since(a.lastDoltCommit) >= interval {
+			a.createDoltCommits(ctx, engine)
 		}
-		a.dbsWithUncommittedChanges = nil
 	}
 
 	return nil
 }
 
+// doltCommitInterval returns the minimum time between the Dolt commits created by the applier, from the
+// @@dolt_binlog_replica_commit_interval system variable. If it's zero, a Dolt commit is created for every replicated
+// transaction.
+func doltCommitInterval() time.Duration {
+	_, value, ok := sql.SystemVariables.GetGlobal(dsess.DoltBinlogReplicaCommitInterval)
+	if !ok {
+		return 0
+	}
+	seconds, _, err := types.Int64.Convert(value)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds.(int64)) * time.Second
+}
+
+// doltCommitTimer returns a channel that receives when the changes of replicated transactions which haven't been
+// Dolt committed yet are due to be, or nil if there are no such changes. The changes of a transaction which is still
+// being applied are never Dolt committed.
+func (a *binlogReplicaApplier) doltCommitTimer() <-chan time.Time {
+	if a.uncommittedTransactions == 0 || a.transactionOpen {
+		return nil
+	}
+	return time.After(time.Until(a.lastDoltCommit.Add(doltCommitInterval())))
+}
+
+// createDoltCommits creates a Dolt commit in every database changed by the replicated transactions since the last
+// Dolt commits were created.
+func (a *binlogReplicaApplier) createDoltCommits(ctx *sql.Context, engine *gms.Engine) {
+	if a.uncommittedTransactions == 0 || a.transactionOpen {
+		return
+	}
+	message := fmt.Sprintf("Dolt binlog replica commit: GTID %s", a.currentGtid)
+	if a.uncommittedTransactions > 1 {
+		message = fmt.Sprintf("Dolt binlog replica commit: %d transactions through GTID %s", a.uncommittedTransactions, a.currentGtid)
+	}
+	for _, database := range a.databasesWithUncommittedChanges() {
+		executeQueryWithEngine(ctx, engine, "use `"+database+"`;")
+		executeQueryWithEngine(ctx, engine, fmt.Sprintf("call dolt_commit('-Am', '%s');", message))
+	}
+	a.dbsWithUncommittedChanges = nil
+	a.uncommittedTransactions = 0
+	a.lastDoltCommit = time.Now()
+}
+
 // addDatabasesWithUncommittedChanges marks the specifeid |dbNames| as databases with uncommitted changes so that
 // the replica applier knows which databases need to have Dolt commits created.
 func (a *binlogReplicaApplier) addDatabasesWithUncommittedChanges(dbNames ...string) {
@@ -693,7 +748,7 @@ func (a *binlogReplicaApplier) processRowEvent(ctx *sql.Context, event mysql.Bin
 
 	}
 
-	err = closeWriteSession(ctx, engine, tableMap.Database, writeSession)
+	err = closeWriteSession(ctx, engine, tableMap.Database, writeSession, foreignKeyChecksDisabled)
 	if err != nil {
 		return err
 	}
@@ -705,13 +760,25 @@ func (a *binlogReplicaApplier) processRowEvent(ctx *sql.Context, event mysql.Bin
 // Helper functions
 //
 
-// closeWriteSession flushes and closes the specified |writeSession| and returns an error if anything failed.
-func closeWriteSession(ctx *sql.Context, engine *gms.Engine, databaseName string, writeSession dsess.WriteSession) error {
+// closeWriteSession flushes and closes the specified |writeSession| and returns an error if anything failed. Unless
+// |foreignKeyChecksDisabled| is set, the ON DELETE and ON UPDATE referential actions of foreign keys are applied to
+// the flushed rows. Row events don't include the rows a primary's storage engine changed through cascading foreign
+// key actions, so the replica has to apply those actions itself.
+func closeWriteSession(ctx *sql.Context, engine *gms.Engine, databaseName string, writeSession dsess.WriteSession, foreignKeyChecksDisabled bool) error {
+	baseRoot := writeSession.GetWorkingSet().WorkingRoot()
 	newWorkingSet, err := writeSession.Flush(ctx)
 	if err != nil {
 		return err
 	}
 
+	if !foreignKeyChecksDisabled {
+		newRoot, err := merge.ApplyForeignKeyReferentialActions(ctx, newWorkingSet.WorkingRoot(), baseRoot, doltdb.NewTableNameSet(nil))
+		if err != nil {
+			return err
+		}
+		newWorkingSet = newWorkingSet.WithWorkingRoot(newRoot)
+	}
+
 	database, err := engine.Analyzer.Catalog.Database(ctx, databaseName)
 	if err != nil {
 		return err
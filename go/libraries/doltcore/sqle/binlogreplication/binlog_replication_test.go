@@ -501,6 +501,52 @@ func TestDoltCommits(t *testing.T) {
 	require.Equal(t, 5, len(allRows)) // 4 transactions + 1 initial commit
 }
 
+// TestDoltCommitInterval tests that when @@dolt_binlog_replica_commit_interval is set, the transactions replicated
+// during the interval are Dolt committed together.
+func TestDoltCommitInterval(t *testing.T) {
+	defer teardown(t)
+	startSqlServersWithDoltSystemVars(t, map[string]string{
+		"server_id":                           "42",
+		"dolt_binlog_replica_commit_interval": "3600",
+	})
+	startReplicationAndCreateTestDb(t, mySqlPort)
+
+	primaryDatabase.MustExec("create table t1 (pk int primary key);")
+	primaryDatabase.MustExec("insert into t1 values (1);")
+	primaryDatabase.MustExec("insert into t1 values (2);")
+	waitForReplicaToCatchUp(t)
+
+	// The transactions are applied to the replica's working set, but the interval hasn't passed since the Dolt commit
+	// of the database's creation
+	rows, err := replicaDatabase.Queryx("select count(*) as count from db01.t1;")
+	require.NoError(t, err)
+	row := convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "2", row["count"])
+	require.NoError(t, rows.Close())
+
+	rows, err = replicaDatabase.Queryx("select count(*) as count from db01.dolt_log;")
+	require.NoError(t, err)
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "1", row["count"])
+	require.NoError(t, rows.Close())
+
+	// Stopping replication Dolt commits the transactions which haven't been yet
+	replicaDatabase.MustExec("STOP REPLICA;")
+	require.Eventually(t, func() bool {
+		rows, err := replicaDatabase.Queryx("select count(*) as count from db01.dolt_log;")
+		require.NoError(t, err)
+		row := convertMapScanResultToStrings(readNextRow(t, rows))
+		require.NoError(t, rows.Close())
+		return row["count"] == "2"
+	}, 10*time.Second, 100*time.Millisecond)
+
+	rows, err = replicaDatabase.Queryx("select message from db01.dolt_log limit 1;")
+	require.NoError(t, err)
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Contains(t, row["message"], "Dolt binlog replica commit: 3 transactions through GTID ")
+	require.NoError(t, rows.Close())
+}
+
 // TestForeignKeyChecks tests that foreign key constraints replicate correctly when foreign key checks are
 // enabled and disabled.
 func TestForeignKeyChecks(t *testing.T) {
@@ -559,6 +605,48 @@ func TestForeignKeyChecks(t *testing.T) {
 	require.NoError(t, rows.Close())
 }
 
+// TestForeignKeyCascades tests that the ON DELETE and ON UPDATE referential actions of foreign keys are applied on the
+// replica, since row events don't include the child rows changed by cascading foreign key actions.
+func TestForeignKeyCascades(t *testing.T) {
+	defer teardown(t)
+	startSqlServersWithDoltSystemVars(t, doltReplicaSystemVars)
+	startReplicationAndCreateTestDb(t, mySqlPort)
+
+	primaryDatabase.MustExec("CREATE TABLE colors (name varchar(100) primary key);")
+	primaryDatabase.MustExec("CREATE TABLE t1 (pk int primary key, color varchar(100), FOREIGN KEY (color) REFERENCES colors(name) ON DELETE CASCADE ON UPDATE CASCADE);")
+	primaryDatabase.MustExec("CREATE TABLE t2 (pk int primary key, color varchar(100), FOREIGN KEY (color) REFERENCES colors(name) ON DELETE SET NULL);")
+	primaryDatabase.MustExec("INSERT INTO colors VALUES ('green'), ('red'), ('blue');")
+	primaryDatabase.MustExec("INSERT INTO t1 VALUES (1, 'red'), (2, 'green'), (3, 'blue');")
+	primaryDatabase.MustExec("INSERT INTO t2 VALUES (1, 'red'), (2, 'green');")
+
+	primaryDatabase.MustExec("DELETE FROM colors WHERE name='red';")
+	primaryDatabase.MustExec("UPDATE colors SET name='navy' WHERE name='blue';")
+
+	// Verify the changes on the replica
+	waitForReplicaToCatchUp(t)
+	rows, err := replicaDatabase.Queryx("select * from db01.t1 order by pk;")
+	require.NoError(t, err)
+	row := convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "2", row["pk"])
+	require.Equal(t, "green", row["color"])
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "3", row["pk"])
+	require.Equal(t, "navy", row["color"])
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Close())
+
+	rows, err = replicaDatabase.Queryx("select * from db01.t2 order by pk;")
+	require.NoError(t, err)
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "1", row["pk"])
+	require.Nil(t, row["color"])
+	row = convertMapScanResultToStrings(readNextRow(t, rows))
+	require.Equal(t, "2", row["pk"])
+	require.Equal(t, "green", row["color"])
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Close())
+}
+
 // TestCharsetsAndCollations tests that we can successfully replicate data using various charsets and collations.
 func TestCharsetsAndCollations(t *testing.T) {
 	defer teardown(t)
@@ -28,38 +28,65 @@ var ErrServerTransitionedRolesErr = errors.New("this server transitioned cluster
 
 func newAssumeRoleProcedure(controller *Controller) sql.ExternalStoredProcedureDetails {
 	return sql.ExternalStoredProcedureDetails{
-		Name: "dolt_assume_cluster_role",
-		Schema: sql.Schema{
-			&sql.Column{
-				Name:     "status",
-				Type:     types.Int64,
-				Nullable: false,
-			},
-		},
+		Name:   "dolt_assume_cluster_role",
+		Schema: assumeRoleSchema,
 		Function: func(ctx *sql.Context, role string, epoch int) (sql.RowIter, error) {
-			if role == string(RoleDetectedBrokenConfig) {
-				return nil, errors.New("cannot set role to detected_broken_config; valid values are 'primary' and 'standby'")
-			}
-			saveConnID := int(ctx.Session.ID())
-			res, err := controller.setRoleAndEpoch(role, epoch, roleTransitionOptions{
-				graceful:   true,
-				saveConnID: &saveConnID,
-			})
-			if err != nil {
-				// We did not transition, no need to set our session to read-only, etc.
-				return nil, err
-			}
-			if res.changedRole {
-				// We transitioned, make sure we do not run anymore queries on this session.
-				ctx.Session.SetTransaction(nil)
-				dsess.DSessFromSess(ctx.Session).SetValidateErr(ErrServerTransitionedRolesErr)
+			return assumeRole(ctx, controller, role, epoch)
+		},
+		ReadOnly: true,
+	}
+}
+
+// newAssumeRoleNextEpochProcedure is the variant of dolt_assume_cluster_role
+// which takes no epoch. It assumes the role at the epoch following this
+// server's current epoch, so that failover scripts don't need to track epochs.
+// It does nothing if the server already has the role. The other servers of the
+// cluster learn about the new epoch when they next communicate with this
+// server, and a primary at an older epoch is fenced and transitions to standby.
+func newAssumeRoleNextEpochProcedure(controller *Controller) sql.ExternalStoredProcedureDetails {
+	return sql.ExternalStoredProcedureDetails{
+		Name:   "dolt_assume_cluster_role",
+		Schema: assumeRoleSchema,
+		Function: func(ctx *sql.Context, role string) (sql.RowIter, error) {
+			currentRole, epoch := controller.roleAndEpoch()
+			if role != string(currentRole) {
+				epoch++
 			}
-			return sql.RowsToRowIter(sql.Row{0}), nil
+			return assumeRole(ctx, controller, role, epoch)
 		},
 		ReadOnly: true,
 	}
 }
 
+var assumeRoleSchema = sql.Schema{
+	&sql.Column{
+		Name:     "status",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+}
+
+func assumeRole(ctx *sql.Context, controller *Controller, role string, epoch int) (sql.RowIter, error) {
+	if role == string(RoleDetectedBrokenConfig) {
+		return nil, errors.New("cannot set role to detected_broken_config; valid values are 'primary' and 'standby'")
+	}
+	saveConnID := int(ctx.Session.ID())
+	res, err := controller.setRoleAndEpoch(role, epoch, roleTransitionOptions{
+		graceful:   true,
+		saveConnID: &saveConnID,
+	})
+	if err != nil {
+		// We did not transition, no need to set our session to read-only, etc.
+		return nil, err
+	}
+	if res.changedRole {
+		// We transitioned, make sure we do not run anymore queries on this session.
+		ctx.Session.SetTransaction(nil)
+		dsess.DSessFromSess(ctx.Session).SetValidateErr(ErrServerTransitionedRolesErr)
+	}
+	return sql.RowsToRowIter(sql.Row{0}), nil
+}
+
 func newTransitionToStandbyProcedure(controller *Controller) sql.ExternalStoredProcedureDetails {
 	return sql.ExternalStoredProcedureDetails{
 		Name: "dolt_cluster_transition_to_standby",
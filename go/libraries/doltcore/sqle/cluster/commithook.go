@@ -19,12 +19,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/store/datas"
@@ -52,6 +54,10 @@ type commithook struct {
 	currentError         *string
 	cancelReplicate      func()
 
+	// As a standby, the apply lag of the last root update we received.
+	// nil if we have not received one with a known commit time.
+	applyLag *time.Duration
+
 	// waitNotify is set by controller when it needs to track whether the
 	// commithooks are caught up with replicating to the standby.
 	waitNotify func()
@@ -315,7 +321,8 @@ func (h *commithook) attemptReplicate(ctx context.Context) {
 		if err = cs.Rebase(ctx); err == nil {
 			if curRootHash, err = cs.Root(ctx); err == nil {
 				var ok bool
-				ok, err = cs.Commit(ctx, toPush, curRootHash)
+				commitCtx := metadata.AppendToOutgoingContext(ctx, clusterHeadTimeHeader, strconv.FormatInt(incomingTime.UnixMilli(), 10))
+				ok, err = cs.Commit(commitCtx, toPush, curRootHash)
 				if err == nil && !ok {
 					err = errDestDBRootHashMoved
 				}
@@ -363,7 +370,9 @@ func (h *commithook) status() (replicationLag *time.Duration, lastUpdate *time.T
 				*replicationLag = time.Now().Sub(h.lastSuccess)
 			}
 		}
-
+	} else if h.role == RoleStandby && h.applyLag != nil {
+		replicationLag = new(time.Duration)
+		*replicationLag = *h.applyLag
 	}
 
 	if h.lastSuccess != (time.Time{}) {
@@ -406,7 +415,10 @@ func (h *commithook) databaseWasDropped() {
 	h.cond.Signal()
 }
 
-func (h *commithook) recordSuccessfulRemoteSrvCommit() {
+// recordSuccessfulRemoteSrvCommit is called on a standby when the primary
+// updated our root. |applyLag| is the time between the primary committing the
+// root and us applying it, or nil if it is not known.
+func (h *commithook) recordSuccessfulRemoteSrvCommit(applyLag *time.Duration) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	if h.role != RoleStandby {
@@ -414,6 +426,9 @@ func (h *commithook) recordSuccessfulRemoteSrvCommit() {
 	}
 	h.lastSuccess = time.Now()
 	h.currentError = nil
+	if applyLag != nil {
+		h.applyLag = applyLag
+	}
 }
 
 func (h *commithook) setRole(role Role) {
@@ -425,6 +440,7 @@ func (h *commithook) setRole(role Role) {
 	h.nextHead = hash.Hash{}
 	h.lastPushedHead = hash.Hash{}
 	h.lastSuccess = time.Time{}
+	h.applyLag = nil
 	h.nextPushAttempt = time.Time{}
 	h.role = role
 	h.lgr.Store(h.rootLgr.WithField(logFieldRole, string(role)))
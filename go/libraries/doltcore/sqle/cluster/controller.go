@@ -333,6 +333,7 @@ func (c *Controller) RegisterStoredProcedures(store procedurestore) {
 		return
 	}
 	store.Register(newAssumeRoleProcedure(c))
+	store.Register(newAssumeRoleNextEpochProcedure(c))
 	store.Register(newTransitionToStandbyProcedure(c))
 }
 
@@ -669,7 +670,7 @@ func (c *Controller) GetClusterStatus() []clusterdb.ReplicaStatus {
 	return ret
 }
 
-func (c *Controller) recordSuccessfulRemoteSrvCommit(name string) {
+func (c *Controller) recordSuccessfulRemoteSrvCommit(name string, applyLag *time.Duration) {
 	c.lgr.Tracef("standby replica received push and updated database %s", name)
 	c.mu.Lock()
 	commithooks := make([]*commithook, len(c.commithooks))
@@ -677,7 +678,7 @@ func (c *Controller) recordSuccessfulRemoteSrvCommit(name string) {
 	c.mu.Unlock()
 	for _, c := range commithooks {
 		if c.dbname == name {
-			c.recordSuccessfulRemoteSrvCommit()
+			c.recordSuccessfulRemoteSrvCommit(applyLag)
 		}
 	}
 }
@@ -699,7 +700,11 @@ func (c *Controller) RemoteSrvServerArgs(ctxFactory func(context.Context) (*sql.
 
 	keyID := creds.PubKeyToKID(c.pub)
 	keyIDStr := creds.B32CredsEncoding.EncodeToString(keyID)
-	args.HttpInterceptor = JWKSHandlerInterceptor(keyIDStr, c.pub)
+	jwksInterceptor := JWKSHandlerInterceptor(keyIDStr, c.pub)
+	healthInterceptor := c.healthHandlerInterceptor()
+	args.HttpInterceptor = func(h http.Handler) http.Handler {
+		return healthInterceptor(jwksInterceptor(h))
+	}
 
 	return args, nil
 }
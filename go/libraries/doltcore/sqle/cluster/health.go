@@ -0,0 +1,96 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/clusterdb"
+)
+
+// The path of the health endpoint on the cluster remotesapi server. It is
+// served without authentication so that load balancers and failover tooling
+// can poll it.
+const healthPath = "/dolt_cluster/health"
+
+type healthResponse struct {
+	Role      string           `json:"role"`
+	Epoch     int              `json:"epoch"`
+	Healthy   bool             `json:"healthy"`
+	Databases []databaseHealth `json:"databases"`
+}
+
+type databaseHealth struct {
+	Database             string     `json:"database"`
+	StandbyRemote        string     `json:"standby_remote"`
+	ReplicationLagMillis *int64     `json:"replication_lag_millis"`
+	LastUpdate           *time.Time `json:"last_update"`
+	CurrentError         *string    `json:"current_error"`
+}
+
+// newHealthResponse summarizes |statuses|. The server is healthy unless it is
+// in detected_broken_config or replication of one of its databases is failing.
+func newHealthResponse(role Role, epoch int, statuses []clusterdb.ReplicaStatus) healthResponse {
+	res := healthResponse{
+		Role:      string(role),
+		Epoch:     epoch,
+		Healthy:   role != RoleDetectedBrokenConfig,
+		Databases: make([]databaseHealth, len(statuses)),
+	}
+	for i, s := range statuses {
+		res.Databases[i] = databaseHealth{
+			Database:      s.Database,
+			StandbyRemote: s.Remote,
+			LastUpdate:    s.LastUpdate,
+			CurrentError:  s.CurrentError,
+		}
+		if s.ReplicationLag != nil {
+			millis := s.ReplicationLag.Milliseconds()
+			res.Databases[i].ReplicationLagMillis = &millis
+		}
+		if s.CurrentError != nil {
+			res.Healthy = false
+		}
+	}
+	return res
+}
+
+// healthHandlerInterceptor serves the role, epoch and replication status of
+// this server as JSON at |healthPath|. It responds with
+// http.StatusServiceUnavailable when the server is not healthy.
+func (c *Controller) healthHandlerInterceptor() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != healthPath {
+				h.ServeHTTP(w, r)
+				return
+			}
+			role, epoch := c.roleAndEpoch()
+			res := newHealthResponse(role, epoch, c.GetClusterStatus())
+			b, err := json.Marshal(res)
+			if err != nil {
+				http.Error(w, "error marshaling json", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if !res.Healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			w.Write(b)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/clusterdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestNewHealthResponse(t *testing.T) {
+	lag := 1500 * time.Millisecond
+	errStr := "failed to commit chunks on destDB"
+	statuses := []clusterdb.ReplicaStatus{
+		{Database: "a", Remote: "standby", ReplicationLag: &lag},
+		{Database: "b", Remote: "standby"},
+	}
+
+	res := newHealthResponse(RolePrimary, 3, statuses)
+	assert.True(t, res.Healthy)
+	assert.Equal(t, "primary", res.Role)
+	assert.Equal(t, 3, res.Epoch)
+	require.Len(t, res.Databases, 2)
+	require.NotNil(t, res.Databases[0].ReplicationLagMillis)
+	assert.Equal(t, int64(1500), *res.Databases[0].ReplicationLagMillis)
+	assert.Nil(t, res.Databases[1].ReplicationLagMillis)
+
+	statuses[1].CurrentError = &errStr
+	assert.False(t, newHealthResponse(RolePrimary, 3, statuses).Healthy)
+	assert.False(t, newHealthResponse(RoleDetectedBrokenConfig, 3, nil).Healthy)
+}
+
+func TestApplyLag(t *testing.T) {
+	current, last := hash.Of([]byte("current")), hash.Of([]byte("last"))
+
+	lag := applyLag(context.Background(), current, current)
+	require.NotNil(t, lag)
+	assert.Equal(t, time.Duration(0), *lag)
+
+	assert.Nil(t, applyLag(context.Background(), current, last))
+
+	committed := time.Now().Add(-2 * time.Second)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(clusterHeadTimeHeader, strconv.FormatInt(committed.UnixMilli(), 10)))
+	lag = applyLag(ctx, current, last)
+	require.NotNil(t, lag)
+	assert.GreaterOrEqual(t, *lag, time.Second)
+
+	future := time.Now().Add(time.Minute)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(clusterHeadTimeHeader, strconv.FormatInt(future.UnixMilli(), 10)))
+	lag = applyLag(ctx, current, last)
+	require.NotNil(t, lag)
+	assert.Equal(t, time.Duration(0), *lag)
+}
@@ -34,6 +34,11 @@ import (
 const clusterRoleHeader = "x-dolt-cluster-role"
 const clusterRoleEpochHeader = "x-dolt-cluster-role-epoch"
 
+// Sent by the primary along with the Commit of a new root to a standby. It
+// carries the time, in unix milliseconds, at which the primary committed the
+// root, and lets the standby compute its apply lag.
+const clusterHeadTimeHeader = "x-dolt-cluster-head-time"
+
 var writeEndpoints map[string]bool
 
 func init() {
@@ -41,6 +46,7 @@ func init() {
 	writeEndpoints["/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Commit"] = true
 	writeEndpoints["/dolt.services.remotesapi.v1alpha1.ChunkStoreService/AddTableFiles"] = true
 	writeEndpoints["/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetUploadLocations"] = true
+	writeEndpoints["/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetTableFileSignature"] = true
 }
 
 func isLikelyServerResponse(err error) bool {
@@ -16,6 +16,10 @@ package cluster
 
 import (
 	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/metadata"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -43,7 +47,36 @@ type remotesrvStore struct {
 func (rss remotesrvStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
 	res, err := rss.RemoteSrvStore.Commit(ctx, current, last)
 	if err == nil && res {
-		rss.controller.recordSuccessfulRemoteSrvCommit(rss.path)
+		rss.controller.recordSuccessfulRemoteSrvCommit(rss.path, applyLag(ctx, current, last))
 	}
 	return res, err
 }
+
+// applyLag returns how long it took for the root |current| to be applied on
+// this standby after it was committed on the primary, based on the commit time
+// the primary sent along with the request. A heartbeat, which doesn't move the
+// root, means that we are caught up. Returns nil if the primary didn't send
+// the commit time. The lag is computed across the clocks of both servers, so
+// it is never reported as negative.
+func applyLag(ctx context.Context, current, last hash.Hash) *time.Duration {
+	lag := new(time.Duration)
+	if current == last {
+		return lag
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	times := md.Get(clusterHeadTimeHeader)
+	if len(times) == 0 {
+		return nil
+	}
+	millis, err := strconv.ParseInt(times[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	if d := time.Since(time.UnixMilli(millis)); d > 0 {
+		*lag = d
+	}
+	return lag
+}
@@ -30,7 +30,9 @@ type ReplicaStatus struct {
 	Epoch int
 	// The standby remote that this replica status represents.
 	Remote string
-	// The current replication lag. NULL when we are a standby.
+	// As a primary, the current replication lag.
+	// As a standby, the apply lag of the last root update we received, or
+	// NULL if we have not received one.
 	ReplicationLag *time.Duration
 	// As a standby, the last time we received a root update.
 	// As a primary, the last time we pushed a root update to the standby.
@@ -46,6 +46,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/federated"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -249,6 +250,12 @@ func (db Database) GetGlobalState() globalstate.GlobalState {
 	return db.gs
 }
 
+// sequenceTracker returns the tracker of the values handed out by the sequences of this database, or nil if it has no
+// global state.
+func (db Database) sequenceTracker() globalstate.SequenceTracker {
+	return db.gs.SequenceTracker()
+}
+
 // GetTableInsensitive is used when resolving tables in queries. It returns a best-effort case-insensitive match for
 // the table name given.
 func (db Database) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
@@ -264,7 +271,11 @@ func (db Database) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Ta
 		return nil, false, err
 	}
 
-	return db.getTableInsensitive(ctx, nil, ds, root, tblName, "")
+	table, ok, err := db.getTableInsensitive(ctx, nil, ds, root, tblName, "")
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return restrictPrivilegesTable(tblName, table), true, nil
 }
 
 // GetTableInsensitiveAsOf implements sql.VersionedDatabase
@@ -272,6 +283,23 @@ func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 	if asOf == nil {
 		return db.GetTableInsensitive(ctx, tableName)
 	}
+	table, ok, err := db.getTableInsensitiveAsOf(ctx, tableName, asOf)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return restrictPrivilegesTable(tableName, table), true, nil
+}
+
+// restrictPrivilegesTable returns |table|, which |tableName| resolved to, as a dtables.PrivilegesTable if it's
+// dolt_privileges or one of the system tables which read its rows, so that only admins can read it.
+func restrictPrivilegesTable(tableName string, table sql.Table) sql.Table {
+	if dtables.IsPrivilegesTable(tableName) {
+		return dtables.NewPrivilegesTable(tableName, table)
+	}
+	return table
+}
+
+func (db Database) getTableInsensitiveAsOf(ctx *sql.Context, tableName string, asOf interface{}) (sql.Table, bool, error) {
 	head, root, err := resolveAsOf(ctx, db, asOf)
 	if err != nil {
 		return nil, false, err
@@ -346,6 +374,31 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		}
 		return dt, true, nil
 
+	case strings.HasPrefix(lwrName, doltdb.DoltBlameViewPrefix):
+		if head == nil {
+			var err error
+			head, err = ds.GetHeadCommit(ctx, db.RevisionQualifiedName())
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		baseTableName := tblName[len(doltdb.DoltBlameViewPrefix):]
+		tname := doltdb.TableName{Name: baseTableName, Schema: db.schemaName}
+		if resolve.UseSearchPath && db.schemaName == "" {
+			var err error
+			tname, _, _, err = resolve.Table(ctx, root, baseTableName)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		bt, err := dtables.NewBlameTable(ctx, db.Name(), tname, db.ddb, root, head)
+		if err != nil {
+			return nil, false, err
+		}
+		return bt, true, nil
+
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
 		baseTableName := tblName[len(doltdb.DoltCommitDiffTablePrefix):]
 		tname := doltdb.TableName{Name: baseTableName, Schema: db.schemaName}
@@ -613,6 +666,16 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewMergeStatusTable(db.RevisionQualifiedName(), lwrName), true
 		}
+	case doltdb.TableChecksumsTableName:
+		dt, found = dtables.NewTableChecksumsTable(ctx, db.RevisionQualifiedName(), lwrName, db.schemaName, db.Revision(), db.ddb, root), true
+	case doltdb.QuotasTableName:
+		dt, found = dtables.NewQuotasTable(ctx, db.RevisionQualifiedName(), lwrName, db.ddb), true
+	case doltdb.ExportJobRunsTableName:
+		dt, found = dtables.NewExportJobRunsTable(ctx, db.RevisionQualifiedName(), lwrName), true
+	case doltdb.ReplicationStatusTableName:
+		dt, found = dtables.NewReplicationStatusTable(ctx, db.RevisionQualifiedName(), lwrName, db.ddb), true
+	case doltdb.JobsTableName:
+		dt, found = dtables.NewJobsTable(ctx, db.RevisionQualifiedName(), lwrName), true
 	case doltdb.GetTagsTableName(), doltdb.TagsTableName:
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -654,6 +717,158 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewIgnoreTable(ctx, versionableTable, db.schemaName), true
 		}
+	case doltdb.MergeStrategiesTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.MergeStrategiesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeStrategiesTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeStrategiesTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.ColumnMergeStrategiesTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.ColumnMergeStrategiesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyColumnMergeStrategiesTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewColumnMergeStrategiesTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.AuditColumnsTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.AuditColumnsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyAuditColumnsTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewAuditColumnsTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.SchemaContractsTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.SchemaContractsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptySchemaContractsTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewSchemaContractsTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.AppendOnlyTablesTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.AppendOnlyTablesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyAppendOnlyTablesTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewAppendOnlyTablesTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.FederatedTablesTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.FederatedTablesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyFederatedTablesTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewFederatedTablesTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.ExportJobsTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.ExportJobsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyExportJobsTable(ctx, db.RevisionQualifiedName(), db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewExportJobsTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName), true
+		}
+	case doltdb.SequencesTableName:
+		if resolve.UseSearchPath && db.schemaName == "" {
+			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
+			if err != nil {
+				return nil, false, err
+			}
+			db.schemaName = schemaName
+		}
+
+		backingTable, _, err := db.getTable(ctx, root, doltdb.SequencesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptySequencesTable(ctx, db.RevisionQualifiedName(), db.schemaName, db.sequenceTracker()), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewSequencesTable(ctx, db.RevisionQualifiedName(), versionableTable, db.schemaName, db.sequenceTracker()), true
+		}
 	case doltdb.GetDocTableName(), doltdb.DocTableName:
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -674,7 +889,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 				dt, found = dtables.NewDocsTable(ctx, versionableTable), true
 			}
 		}
-	case doltdb.StatisticsTableName:
+	case doltdb.StatisticsTableName, doltdb.StatisticsColumnGroupsTableName:
 		if resolve.UseSearchPath && db.schemaName == "" {
 			schemaName, err := resolve.FirstExistingSchemaOnSearchPath(ctx, root)
 			if err != nil {
@@ -694,7 +909,11 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if err != nil {
 			return nil, false, err
 		}
-		dt, found = dtables.NewStatisticsTable(ctx, db.Name(), db.schemaName, branch, tables), true
+		if lwrName == doltdb.StatisticsColumnGroupsTableName {
+			dt, found = dtables.NewStatisticsColumnGroupsTable(ctx, db.Name(), db.schemaName, branch, tables), true
+		} else {
+			dt, found = dtables.NewStatisticsTable(ctx, db.Name(), db.schemaName, branch, tables), true
+		}
 	case doltdb.ProceduresTableName:
 		found = true
 		backingTable, _, err := db.getTable(ctx, root, doltdb.ProceduresTableName)
@@ -735,11 +954,74 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		return table, found, err
 	}
 
+	table, found, err = db.getFederatedTable(ctx, root, tblName)
+	if err != nil || found {
+		return table, found, err
+	}
+
 	// If the table wasn't found in the specified data root, check if there is an overridden
 	// schema commit that contains it and return an empty table if so.
 	return resolveOverriddenNonexistentTable(ctx, tblName, db)
 }
 
+// getFederatedTable returns the federated table |tblName| defined in the dolt_federated_tables system table of |root|,
+// if there is one.
+func (db Database) getFederatedTable(ctx *sql.Context, root doltdb.RootValue, tblName string) (sql.Table, bool, error) {
+	if doltdb.HasDoltPrefix(tblName) {
+		return nil, false, nil
+	}
+	backingTable, ok, err := db.getTable(ctx, root, doltdb.FederatedTablesTableName)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	iter, err := backingTable.Partitions(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer iter.Close(ctx)
+	for {
+		part, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		rows, err := backingTable.PartitionRows(ctx, part)
+		if err != nil {
+			return nil, false, err
+		}
+		for {
+			row, err := rows.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				rows.Close(ctx)
+				return nil, false, err
+			}
+			name, _ := row[0].(string)
+			if !strings.EqualFold(name, tblName) {
+				continue
+			}
+			rows.Close(ctx)
+			connection, _ := row[1].(string)
+			conn, err := federated.ParseConnection(connection)
+			if err != nil {
+				return nil, false, fmt.Errorf("federated table %s has an %w", name, err)
+			}
+			table, err := federated.NewTable(ctx, name, conn)
+			if err != nil {
+				return nil, false, err
+			}
+			return table, true, nil
+		}
+		if err := rows.Close(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+}
+
 // workingSetStagedRoot returns the staged root for the current session in the database
 // named |dbName|. If a working set is not available (e.g. if a commit or tag is checked
 // out), this function returns an ErrOperationNotSupportedInDetachedHead error.
@@ -1069,6 +1351,9 @@ func (db Database) newDoltTable(tableName string, sch schema.Schema, tbl *doltdb
 	var table sql.Table
 	if doltdb.IsReadOnlySystemTable(tname) {
 		table = readonlyTable
+	} else if strings.EqualFold(tableName, doltdb.PrivilegesTableName) {
+		// dolt_privileges is written by the statements which change users and grants, see PrivilegesTablePersister
+		table = readonlyTable
 	} else if doltdb.IsDoltCITable(tableName) && !doltdb.IsFullTextTable(tableName) {
 		table = &AlterableDoltTable{WritableDoltTable{DoltTable: readonlyTable, db: db}}
 	} else if doltdb.IsSystemTable(tname) && !doltdb.IsFullTextTable(tableName) {
@@ -1556,6 +1841,38 @@ func (db Database) createDoltTable(ctx *sql.Context, tableName string, schemaNam
 	return db.SetRoot(ctx, newRoot)
 }
 
+// setTableComment sets the comment of the existing table named |tableName|.
+func (db Database) setTableComment(ctx *sql.Context, tableName string, comment string) error {
+	root, err := db.GetRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	tblName := doltdb.TableName{Name: tableName, Schema: db.schemaName}
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	sch.SetComment(comment)
+
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return err
+	}
+	return db.SetRoot(ctx, root)
+}
+
 // CreateTemporaryTable creates a table that only exists the length of a session.
 func (db Database) CreateTemporaryTable(ctx *sql.Context, tableName string, pkSch sql.PrimaryKeySchema, collation sql.CollationID) error {
 	if doltdb.IsSystemTable(doltdb.TableName{Name: tableName, Schema: db.schemaName}) {
@@ -1763,18 +2080,6 @@ func (db Database) GetViewDefinition(ctx *sql.Context, viewName string) (sql.Vie
 		}
 	}
 
-	lwrViewName := strings.ToLower(viewName)
-	switch {
-	case strings.HasPrefix(lwrViewName, doltdb.DoltBlameViewPrefix):
-		tableName := lwrViewName[len(doltdb.DoltBlameViewPrefix):]
-
-		blameViewTextDef, err := dtables.NewBlameView(ctx, doltdb.TableName{Name: tableName, Schema: db.schemaName}, root)
-		if err != nil {
-			return sql.ViewDefinition{}, false, err
-		}
-		return sql.ViewDefinition{Name: viewName, TextDefinition: blameViewTextDef, CreateViewStatement: fmt.Sprintf("CREATE VIEW `%s` AS %s", viewName, blameViewTextDef)}, true, nil
-	}
-
 	schTblHash, ok, err := root.GetTableHash(ctx, doltdb.TableName{Name: doltdb.SchemasTableName, Schema: db.schemaName})
 	if err != nil {
 		return sql.ViewDefinition{}, false, err
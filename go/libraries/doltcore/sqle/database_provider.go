@@ -49,8 +49,12 @@ import (
 
 type DoltDatabaseProvider struct {
 	// dbLocations maps a database name to its file system root
-	dbLocations        map[string]filesys.Filesys
-	databases          map[string]dsess.SqlDatabase
+	dbLocations map[string]filesys.Filesys
+	databases   map[string]dsess.SqlDatabase
+	// attached holds the databases mounted by AttachDatabase, which are also in |databases|
+	attached map[string]attachedDatabase
+	// cloning holds the names of the databases being cloned, which are registered once their clones finish
+	cloning            map[string]struct{}
 	functions          map[string]sql.Function
 	tableFunctions     map[string]sql.TableFunction
 	externalProcedures sql.ExternalStoredProcedureRegistry
@@ -147,6 +151,8 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 	return &DoltDatabaseProvider{
 		dbLocations:            dbLocations,
 		databases:              dbs,
+		attached:               make(map[string]attachedDatabase),
+		cloning:                make(map[string]struct{}),
 		functions:              funcs,
 		tableFunctions:         tableFuncs,
 		externalProcedures:     externalProcedures,
@@ -613,8 +619,8 @@ func ConfigureReplicationDatabaseHook(ctx *sql.Context, p *DoltDatabaseProvider,
 		return nil
 	}
 
-	remoteName, ok := replicationRemoteName.(string)
-	if !ok {
+	remoteNames, err := ReplicationRemoteNames(replicationRemoteName)
+	if err != nil || len(remoteNames) == 0 {
 		return nil
 	}
 
@@ -623,6 +629,13 @@ func ConfigureReplicationDatabaseHook(ctx *sql.Context, p *DoltDatabaseProvider,
 		return nil
 	}
 
+	// the template gives the URL of a single remote, so it can't configure several
+	if len(remoteNames) > 1 {
+		return fmt.Errorf("cannot configure replication of database %s: %s can only be used with a single remote in %s",
+			name, dsess.ReplicationRemoteURLTemplate, dsess.ReplicateToRemote)
+	}
+	remoteName := remoteNames[0]
+
 	urlTemplate, ok := remoteUrlTemplate.(string)
 	if !ok {
 		return nil
@@ -633,7 +646,7 @@ func ConfigureReplicationDatabaseHook(ctx *sql.Context, p *DoltDatabaseProvider,
 
 	// TODO: params for AWS, others that need them
 	r := env.NewRemote(remoteName, remoteUrl, nil)
-	err := r.Prepare(ctx, newEnv.DoltDB.Format(), p.remoteDialer)
+	err = r.Prepare(ctx, newEnv.DoltDB.Format(), p.remoteDialer)
 	if err != nil {
 		return err
 	}
@@ -663,15 +676,29 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 	depth int,
 	remoteParams map[string]string,
 ) error {
+	// The lock isn't held while the database is fetched, which may take a long time, so that other sessions can use
+	// the provider meanwhile. The name of the database is reserved until the clone finishes.
+	dbKey := formatDbMapKeyName(dbName)
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
+	if _, ok := p.cloning[dbKey]; ok {
+		p.mu.Unlock()
+		return sql.ErrDatabaseExists.New(dbName)
+	}
 	exists, isDir := p.fs.Exists(dbName)
 	if exists && isDir {
+		p.mu.Unlock()
 		return sql.ErrDatabaseExists.New(dbName)
 	} else if exists {
+		p.mu.Unlock()
 		return fmt.Errorf("cannot create DB, file exists at %s", dbName)
 	}
+	p.cloning[dbKey] = struct{}{}
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.cloning, dbKey)
+	}()
 
 	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, remoteParams)
 	if err != nil {
@@ -725,6 +752,8 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 		Remote: remoteName,
 	})
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.registerNewDatabase(ctx, dbName, dEnv)
 }
 
@@ -738,6 +767,10 @@ func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.isAttachedDatabase(name) {
+		return fmt.Errorf("unable to drop attached database %s, use dolt_detach to detach it", name)
+	}
+
 	// get the case-sensitive name for case-sensitive file systems
 	dbKey := formatDbMapKeyName(name)
 	db := p.databases[dbKey]
@@ -832,6 +865,100 @@ func (p *DoltDatabaseProvider) PurgeDroppedDatabases(ctx *sql.Context) error {
 	return p.droppedDatabaseManager.PurgeAllDroppedDatabases(ctx)
 }
 
+// LoadNewDatabases registers every database in the provider's data directory which isn't one of its databases yet,
+// such as a database copied into the directory while the server is running, and returns the names of the databases
+// registered.
+func (p *DoltDatabaseProvider) LoadNewDatabases(ctx *sql.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var dirs []string
+	err := p.fs.Iter(".", false, func(path string, size int64, isDir bool) (stop bool) {
+		if isDir {
+			dirs = append(dirs, filepath.Base(path))
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	var loaded []string
+	for _, dir := range dirs {
+		name := dbfactory.DirToDBName(dir)
+		if _, ok := p.databases[formatDbMapKeyName(name)]; ok {
+			continue
+		}
+		newFs, err := p.fs.WithWorkingDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		newEnv := env.Load(ctx, env.GetCurrentUserHomeDir, newFs, p.dbFactoryUrl, "TODO")
+		if !newEnv.Valid() {
+			if newEnv.DBLoadError != nil && !errors.Is(newEnv.DBLoadError, doltdb.ErrMissingDoltDataDir) {
+				ctx.GetLogger().Warnf("failed to load database at %s with error: %s", dir, newEnv.DBLoadError.Error())
+			}
+			continue
+		}
+		if newEnv.IsAccessModeReadOnly() {
+			// the database is locked by another dolt process, so it's left to be loaded by a later call
+			ctx.GetLogger().Warnf("database %s is locked by another dolt process and was not loaded", name)
+			if err = newEnv.DoltDB.Close(); err != nil {
+				return nil, err
+			}
+			path, err := newFs.Abs("")
+			if err != nil {
+				return nil, err
+			}
+			if err = dbfactory.DeleteFromSingletonCache(filepath.ToSlash(path + "/.dolt/noms")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err = p.registerNewDatabase(ctx, name, newEnv); err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, name)
+	}
+	return loaded, nil
+}
+
+// ReloadReplicationConfig replaces the push replication hooks of the provider's databases with the hooks configured
+// by the current values of the replication system variables, keeping any other commit hooks of the databases.
+func (p *DoltDatabaseProvider) ReloadReplicationConfig(ctx *sql.Context) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for name, db := range p.databases {
+		fs, ok := p.dbLocations[name]
+		if !ok || fs == nil || p.isAttachedDatabase(name) {
+			continue
+		}
+		// the env is only needed for the remotes of the database, its DoltDB is not the one of |db|
+		dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, p.dbFactoryUrl, "TODO")
+		if !dEnv.Valid() {
+			continue
+		}
+		hooks, err := GetCommitHooks(ctx, sql.NewBackgroundThreads(), dEnv, cli.CliErr)
+		if err != nil {
+			return err
+		}
+
+		ddb := db.DbData().Ddb
+		var kept []doltdb.CommitHook
+		for _, h := range ddb.PostCommitHooks() {
+			switch h.(type) {
+			case *doltdb.PushOnWriteHook, *doltdb.RetryingPushOnWriteHook, *doltdb.AsyncPushOnWriteHook, *doltdb.LogHook:
+			default:
+				kept = append(kept, h)
+			}
+		}
+		ddb.SetCommitHooks(ctx, append(kept, hooks...))
+	}
+	return nil
+}
+
 // registerNewDatabase registers the specified DoltEnv, |newEnv|, as a new database named |name|. This
 // function is responsible for instantiating the new Database instance and updating the tracking metadata
 // in this provider. If any problems are encountered while registering the new database, an error is returned.
@@ -994,6 +1121,11 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 		if ok {
 			srcDb = replicaDb.Database
 		}
+		// an attached remote database is itself a read-only database of a commit
+		roDb, ok := srcDb.(ReadOnlyDatabase)
+		if ok {
+			srcDb = roDb.Database
+		}
 
 		srcDb, ok = srcDb.(Database)
 		if !ok {
@@ -1534,6 +1666,15 @@ func initialStateForBranchDb(ctx *sql.Context, srcDb dsess.SqlDatabase) (dsess.I
 	}
 
 	ws, err := srcDb.DbData().Ddb.ResolveWorkingSetAtRoot(ctx, wsRef, rootHash)
+	if ro, ok := srcDb.(sql.ReadOnlyDatabase); ok && ro.IsReadOnly() && errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+		// A read-only database, such as one served from a remote, can't create the working sets it doesn't have, so
+		// the branches without one are served as of their heads
+		var root doltdb.RootValue
+		root, err = cm.GetRootValue(ctx)
+		if err == nil {
+			ws = doltdb.EmptyWorkingSet(wsRef).WithWorkingRoot(root).WithStagedRoot(root)
+		}
+	}
 	if err != nil {
 		return dsess.InitialDbState{}, err
 	}
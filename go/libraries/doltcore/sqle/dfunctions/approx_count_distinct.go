@@ -0,0 +1,181 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/utils/hll"
+)
+
+const ApproxCountDistinctFuncName = "approx_count_distinct"
+
+// ApproxCountDistinct is an aggregate function which estimates the number
+// of distinct non-NULL values of its arguments, like COUNT(DISTINCT ...),
+// by adding the values of each row to a HyperLogLog sketch.
+//
+// go-mysql-server only plans its own aggregate functions as aggregations,
+// so queries using this one are planned by a Dolt analyzer rule, which
+// also reads the estimate from the sketches kept in a table's statistics
+// instead of scanning the table when possible, see
+// sqle.AddApproxCountDistinctRule.
+type ApproxCountDistinct struct {
+	expression.NaryExpression
+	id sql.ColumnId
+}
+
+var _ sql.FunctionExpression = (*ApproxCountDistinct)(nil)
+var _ sql.Aggregation = (*ApproxCountDistinct)(nil)
+
+// NewApproxCountDistinct creates a new ApproxCountDistinct expression.
+func NewApproxCountDistinct(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(ApproxCountDistinctFuncName, "1 or more", 0)
+	}
+	return &ApproxCountDistinct{NaryExpression: expression.NaryExpression{ChildExpressions: args}}, nil
+}
+
+// Id implements the sql.IdExpression interface.
+func (a *ApproxCountDistinct) Id() sql.ColumnId {
+	return a.id
+}
+
+// WithId implements the sql.IdExpression interface.
+func (a *ApproxCountDistinct) WithId(id sql.ColumnId) sql.IdExpression {
+	ret := *a
+	ret.id = id
+	return &ret
+}
+
+// Eval implements the Expression interface. It's only called when the
+// function is used where it can't be planned as an aggregation.
+func (a *ApproxCountDistinct) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, fmt.Errorf("%s is only supported in the select list of a query", ApproxCountDistinctFuncName)
+}
+
+// NewBuffer implements the sql.Aggregation interface.
+func (a *ApproxCountDistinct) NewBuffer() (sql.AggregationBuffer, error) {
+	exprs := make([]sql.Expression, len(a.ChildExpressions))
+	for i, expr := range a.ChildExpressions {
+		child, err := transform.Clone(expr)
+		if err != nil {
+			return nil, err
+		}
+		exprs[i] = child
+	}
+	return &approxCountDistinctBuffer{sketch: hll.New(), exprs: exprs}, nil
+}
+
+// NewWindowFunction implements the sql.WindowAdaptableExpression interface.
+func (a *ApproxCountDistinct) NewWindowFunction() (sql.WindowFunction, error) {
+	return nil, fmt.Errorf("%s is not supported as a window function", ApproxCountDistinctFuncName)
+}
+
+// WithWindow implements the sql.WindowAdaptableExpression interface.
+func (a *ApproxCountDistinct) WithWindow(*sql.WindowDefinition) sql.WindowAdaptableExpression {
+	return a
+}
+
+// Window implements the sql.WindowAdaptableExpression interface.
+func (a *ApproxCountDistinct) Window() *sql.WindowDefinition {
+	return nil
+}
+
+// String implements the Stringer interface.
+func (a *ApproxCountDistinct) String() string {
+	args := make([]string, len(a.ChildExpressions))
+	for i, child := range a.ChildExpressions {
+		args[i] = child.String()
+	}
+	return fmt.Sprintf("%s(%s)", ApproxCountDistinctFuncName, strings.Join(args, ", "))
+}
+
+// FunctionName implements the FunctionExpression interface
+func (a *ApproxCountDistinct) FunctionName() string {
+	return ApproxCountDistinctFuncName
+}
+
+// Description implements the FunctionExpression interface
+func (a *ApproxCountDistinct) Description() string {
+	return "returns an estimate of the number of distinct non-NULL values of the arguments in a result set."
+}
+
+// IsNullable implements the Expression interface.
+func (a *ApproxCountDistinct) IsNullable() bool {
+	return false
+}
+
+// WithChildren implements the Expression interface.
+func (a *ApproxCountDistinct) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	na, err := NewApproxCountDistinct(children...)
+	if err != nil {
+		return nil, err
+	}
+	return na.(*ApproxCountDistinct).WithId(a.id), nil
+}
+
+// Type implements the Expression interface.
+func (a *ApproxCountDistinct) Type() sql.Type {
+	return types.Int64
+}
+
+// approxCountDistinctBuffer adds the values of the arguments of an
+// ApproxCountDistinct in each row to a sketch. Values are added as text,
+// as COUNT(DISTINCT ...) compares them.
+type approxCountDistinctBuffer struct {
+	sketch *hll.Sketch
+	exprs  []sql.Expression
+	buf    []byte
+}
+
+// Update implements the sql.AggregationBuffer interface.
+func (b *approxCountDistinctBuffer) Update(ctx *sql.Context, row sql.Row) error {
+	b.buf = b.buf[:0]
+	for _, expr := range b.exprs {
+		v, err := expr.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return nil
+		}
+		s, _, err := types.Text.Convert(v)
+		if err != nil {
+			return err
+		}
+		b.buf = append(b.buf, s.(string)...)
+		b.buf = append(b.buf, 0)
+	}
+	b.sketch.Add(b.buf)
+	return nil
+}
+
+// Eval implements the sql.AggregationBuffer interface.
+func (b *approxCountDistinctBuffer) Eval(*sql.Context) (interface{}, error) {
+	return int64(b.sketch.Estimate()), nil
+}
+
+// Dispose implements the sql.Disposable interface.
+func (b *approxCountDistinctBuffer) Dispose() {
+	for _, e := range b.exprs {
+		expression.Dispose(e)
+	}
+}
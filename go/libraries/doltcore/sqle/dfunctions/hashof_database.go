@@ -89,20 +89,9 @@ func (t *HashOfDatabase) Eval(ctx *sql.Context, row sql.Row) (interface{}, error
 		h, err = roots.Head.HashOf()
 
 	default:
-		dbData, ok := ds.GetDbData(ctx, dbName)
-		if !ok {
-			return nil, sql.ErrDatabaseNotFound.New(dbName)
-		}
-
-		ddb := dbData.Ddb
-		r, err := ddb.GetRefByNameInsensitive(ctx, refStr)
+		cm, err := resolveHashOfDatabaseCommit(ctx, ds, dbName, refStr)
 		if err != nil {
-			return nil, fmt.Errorf("error getting ref '%s' from database '%s': %w", refStr, dbName, err)
-		}
-
-		cm, err := ddb.ResolveCommitRef(ctx, r)
-		if err != nil {
-			return nil, fmt.Errorf("error resolving ref '%s' from database '%s': %w", refStr, dbName, err)
+			return nil, err
 		}
 
 		root, err := cm.GetRootValue(ctx)
@@ -120,6 +109,43 @@ func (t *HashOfDatabase) Eval(ctx *sql.Context, row sql.Row) (interface{}, error
 	return h.String(), nil
 }
 
+// resolveHashOfDatabaseCommit resolves |refStr|, the name of a ref or a commit spec such as a commit hash or HEAD~1,
+// to its commit.
+func resolveHashOfDatabaseCommit(ctx *sql.Context, ds *dsess.DoltSession, dbName, refStr string) (*doltdb.Commit, error) {
+	dbData, ok := ds.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	ddb := dbData.Ddb
+	r, refErr := ddb.GetRefByNameInsensitive(ctx, refStr)
+	if refErr == nil {
+		cm, err := ddb.ResolveCommitRef(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving ref '%s' from database '%s': %w", refStr, dbName, err)
+		}
+		return cm, nil
+	}
+
+	cs, err := doltdb.NewCommitSpec(refStr)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ref '%s' from database '%s': %w", refStr, dbName, refErr)
+	}
+	headRef, err := ds.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ref '%s' from database '%s': %w", refStr, dbName, refErr)
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
 // String implements the Stringer interface.
 func (t *HashOfDatabase) String() string {
 	args := make([]string, 0, len(t.children))
@@ -26,6 +26,12 @@ var DoltFunctions = []sql.Function{
 	sql.Function2{Name: HasAncestorFuncName, Fn: NewHasAncestor},
 	sql.Function1{Name: HashOfTableFuncName, Fn: NewHashOfTable},
 	sql.FunctionN{Name: HashOfDatabaseFuncName, Fn: NewHashOfDatabase},
+	sql.FunctionN{Name: ApproxCountDistinctFuncName, Fn: NewApproxCountDistinct},
+	sql.FunctionN{Name: SizeOfTableFuncName, Fn: NewSizeOfFunc(SizeOfTableFuncName)},
+	sql.FunctionN{Name: SizeOfIndexFuncName, Fn: NewSizeOfFunc(SizeOfIndexFuncName)},
+	sql.FunctionN{Name: SizeOfBranchFuncName, Fn: NewSizeOfFunc(SizeOfBranchFuncName)},
+	sql.FunctionN{Name: NextValFuncName, Fn: NewSequenceFunc(NextValFuncName)},
+	sql.FunctionN{Name: SetValFuncName, Fn: NewSequenceFunc(SetValFuncName)},
 }
 
 // DolthubApiFunctions are the DoltFunctions that get exposed to Dolthub Api.
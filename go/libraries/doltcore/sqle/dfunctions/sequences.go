@@ -0,0 +1,165 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+const (
+	NextValFuncName = "nextval"
+	SetValFuncName  = "setval"
+)
+
+// ErrSequenceNotFound is returned by the sequence functions for a sequence not in the dolt_sequences table.
+var ErrSequenceNotFound = dtables.ErrSequenceNotFound
+
+// Sequence advances or sets a sequence of the current database, a row of its dolt_sequences table. nextval returns
+// the next value of a sequence, and setval sets the current value of a sequence, which the next value follows. Both
+// write the sequence in the working set, so their changes are part of the transaction that makes them, while the
+// values nextval hands out are never handed out again, even by other transactions or if the transaction rolls back.
+type Sequence struct {
+	name     string
+	children []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*Sequence)(nil)
+var _ sql.NonDeterministicExpression = (*Sequence)(nil)
+
+// NewSequenceFunc creates a constructor for the sequence function named |name|.
+func NewSequenceFunc(name string) sql.CreateFuncNArgs {
+	return func(args ...sql.Expression) (sql.Expression, error) {
+		expected := 1
+		if name == SetValFuncName {
+			expected = 2
+		}
+		if len(args) != expected {
+			return nil, sql.ErrInvalidArgumentNumber.New(name, expected, len(args))
+		}
+		return &Sequence{name: name, children: args}, nil
+	}
+}
+
+// Eval implements the Expression interface.
+func (s *Sequence) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	v, err := s.children[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	seqName, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s requires a sequence name", s.name)
+	}
+
+	var setTo int64
+	if s.name == SetValFuncName {
+		v, err := s.children[1].Eval(ctx, row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		converted, _, err := types.Int64.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		setTo = converted.(int64)
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	if dbName == "" {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+	db, err := dsess.DSessFromSess(ctx.Session).Provider().Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	tbl, ok, err := db.GetTableInsensitive(ctx, doltdb.SequencesTableName)
+	if err != nil {
+		return nil, err
+	}
+	sequences, isSequences := tbl.(*dtables.SequencesTable)
+	if !ok || !isSequences {
+		return nil, fmt.Errorf("%w: %s", ErrSequenceNotFound, seqName)
+	}
+
+	if s.name == SetValFuncName {
+		return setTo, sequences.SetValue(ctx, seqName, setTo)
+	}
+	return sequences.NextValue(ctx, seqName)
+}
+
+// Children implements the Expression interface.
+func (s *Sequence) Children() []sql.Expression {
+	return s.children
+}
+
+// Resolved implements the Expression interface.
+func (s *Sequence) Resolved() bool {
+	for _, child := range s.children {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface.
+func (s *Sequence) String() string {
+	args := make([]string, len(s.children))
+	for i, child := range s.children {
+		args[i] = child.String()
+	}
+	return fmt.Sprintf("%s(%s)", s.name, strings.Join(args, ", "))
+}
+
+// FunctionName implements the FunctionExpression interface
+func (s *Sequence) FunctionName() string {
+	return s.name
+}
+
+// Description implements the FunctionExpression interface
+func (s *Sequence) Description() string {
+	if s.name == SetValFuncName {
+		return "sets the current value of a sequence in dolt_sequences, returning it"
+	}
+	return "advances a sequence in dolt_sequences, returning its next value"
+}
+
+// IsNullable implements the Expression interface.
+func (s *Sequence) IsNullable() bool {
+	return true
+}
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+func (s *Sequence) IsNonDeterministic() bool {
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (s *Sequence) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewSequenceFunc(s.name)(children...)
+}
+
+// Type implements the Expression interface.
+func (s *Sequence) Type() sql.Type {
+	return types.Int64
+}
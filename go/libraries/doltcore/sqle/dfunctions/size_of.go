@@ -0,0 +1,270 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	stypes "github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	SizeOfTableFuncName  = "dolt_size_of_table"
+	SizeOfIndexFuncName  = "dolt_size_of_index"
+	SizeOfBranchFuncName = "dolt_size_of_branch"
+)
+
+// primaryIndexName names the primary index of a table, which holds its rows, in dolt_size_of_index.
+const primaryIndexName = "PRIMARY"
+
+// SizeOf estimates the bytes of storage used by a table, by one index of a table, or by the tables of a branch which
+// aren't shared with any other branch, see tree.SizeEstimator. Tables and indexes are read from the working set of
+// the current database, and branches from their HEAD commits.
+type SizeOf struct {
+	name     string
+	children []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*SizeOf)(nil)
+
+// NewSizeOfFunc creates a constructor for the dolt_size_of function named |name|.
+func NewSizeOfFunc(name string) sql.CreateFuncNArgs {
+	return func(args ...sql.Expression) (sql.Expression, error) {
+		expected := 1
+		if name == SizeOfIndexFuncName {
+			expected = 2
+		}
+		if len(args) != expected {
+			return nil, sql.ErrInvalidArgumentNumber.New(name, expected, len(args))
+		}
+		return &SizeOf{name: name, children: args}, nil
+	}
+}
+
+// Eval implements the Expression interface.
+func (s *SizeOf) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args := make([]string, len(s.children))
+	for i, child := range s.children {
+		v, err := child.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s arguments must be strings", s.name)
+		}
+		args[i] = str
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	ds := dsess.DSessFromSess(ctx.Session)
+	ddb, ok := ds.GetDoltDB(ctx, dbName)
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	if !stypes.IsFormat_DOLT(ddb.Format()) {
+		return nil, fmt.Errorf("%s is not supported for this storage format", s.name)
+	}
+	est := tree.NewSizeEstimator(ddb.NodeStore())
+
+	switch s.name {
+	case SizeOfTableFuncName, SizeOfIndexFuncName:
+		roots, ok := ds.GetRoots(ctx, dbName)
+		if !ok {
+			return nil, sql.ErrDatabaseNotFound.New(dbName)
+		}
+		tbl, tableName, ok, err := doltdb.GetTableInsensitive(ctx, roots.Working, doltdb.TableName{Name: args[0]})
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, sql.ErrTableNotFound.New(args[0])
+		}
+		indexName := ""
+		if s.name == SizeOfIndexFuncName {
+			indexName = args[1]
+		}
+		found, err := sizeOfTable(ctx, tbl, indexName, est.Add)
+		if err != nil {
+			return nil, err
+		} else if !found {
+			return nil, sql.ErrIndexNotFound.New(fmt.Sprintf("%s on table %s", indexName, tableName))
+		}
+	case SizeOfBranchFuncName:
+		if err := sizeOfBranch(ctx, est, ddb, args[0]); err != nil {
+			return nil, err
+		}
+	}
+	return est.Bytes(), nil
+}
+
+// sizeOfTable applies |fn| to the trees of the index named |indexName| of |tbl|, or of all of its indexes if
+// |indexName| is empty. Returns false if there is no such index.
+func sizeOfTable(ctx *sql.Context, tbl *doltdb.Table, indexName string, fn func(ctx context.Context, nd tree.Node) error) (bool, error) {
+	found := false
+	if indexName == "" || strings.EqualFold(indexName, primaryIndexName) {
+		idx, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return false, err
+		}
+		if err = fn(ctx, durable.ProllyMapFromIndex(idx).Node()); err != nil {
+			return false, err
+		}
+		found = true
+	}
+	if indexName != "" && found {
+		return true, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	set, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return false, err
+	}
+	err = durable.IterAllIndexes(ctx, sch, set, func(name string, idx durable.Index) error {
+		if indexName != "" && !strings.EqualFold(name, indexName) {
+			return nil
+		}
+		found = true
+		return fn(ctx, durable.ProllyMapFromIndex(idx).Node())
+	})
+	return found, err
+}
+
+// sizeOfBranch counts the tables of the HEAD of the branch named |branch|, excluding the chunks they share with the
+// HEADs of other branches.
+func sizeOfBranch(ctx *sql.Context, est *tree.SizeEstimator, ddb *doltdb.DoltDB, branch string) error {
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return err
+	}
+	var target doltdb.RootValue
+	var others []doltdb.RootValue
+	for _, br := range branches {
+		cm, err := ddb.ResolveCommitRef(ctx, br)
+		if err != nil {
+			return err
+		}
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		if target == nil && strings.EqualFold(br.GetPath(), branch) {
+			target = root
+		} else {
+			others = append(others, root)
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("%w: %s", doltdb.ErrBranchNotFound, branch)
+	}
+
+	for _, root := range others {
+		if err = sizeOfRoot(ctx, root, est.Exclude); err != nil {
+			return err
+		}
+	}
+	return sizeOfRoot(ctx, target, est.Add)
+}
+
+// sizeOfRoot applies |fn| to the trees of every index of every table of |root|.
+func sizeOfRoot(ctx *sql.Context, root doltdb.RootValue, fn func(ctx context.Context, nd tree.Node) error) error {
+	names, err := root.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+		if _, err = sizeOfTable(ctx, tbl, "", fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Children implements the Expression interface.
+func (s *SizeOf) Children() []sql.Expression {
+	return s.children
+}
+
+// Resolved implements the Expression interface.
+func (s *SizeOf) Resolved() bool {
+	for _, child := range s.children {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface.
+func (s *SizeOf) String() string {
+	args := make([]string, len(s.children))
+	for i, child := range s.children {
+		args[i] = child.String()
+	}
+	return fmt.Sprintf("%s(%s)", s.name, strings.Join(args, ", "))
+}
+
+// FunctionName implements the FunctionExpression interface
+func (s *SizeOf) FunctionName() string {
+	return s.name
+}
+
+// Description implements the FunctionExpression interface
+func (s *SizeOf) Description() string {
+	switch s.name {
+	case SizeOfIndexFuncName:
+		return "returns an estimate of the bytes of storage used by an index of a table, or by its rows for the PRIMARY index"
+	case SizeOfBranchFuncName:
+		return "returns an estimate of the bytes of storage used by the tables of a branch which aren't shared with any other branch"
+	default:
+		return "returns an estimate of the bytes of storage used by the rows and indexes of a table"
+	}
+}
+
+// IsNullable implements the Expression interface.
+func (s *SizeOf) IsNullable() bool {
+	return true
+}
+
+// WithChildren implements the Expression interface.
+func (s *SizeOf) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewSizeOfFunc(s.name)(children...)
+}
+
+// Type implements the Expression interface.
+func (s *SizeOf) Type() sql.Type {
+	return types.Uint64
+}
@@ -0,0 +1,407 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// wasmAllocExport is the function which modules of text functions export to allocate the memory their arguments are
+// written to.
+const wasmAllocExport = "alloc"
+
+// wasmDeallocExport is the function which modules of text functions export to free the memory of their arguments and
+// results once the host is done with it.
+const wasmDeallocExport = "dealloc"
+
+// wasmMaxIdleInstances is the number of instances of a module kept for reuse once they are no longer evaluating a call.
+const wasmMaxIdleInstances = 8
+
+// WasmFunctionOptions configure a function implemented by a WebAssembly module, see NewWasmFunction.
+type WasmFunctionOptions struct {
+	// Name is the name of the SQL function.
+	Name string
+	// Version is the version of the module, which is reported in the function's errors.
+	Version string
+	// Export is the name of the function exported by the module which implements the SQL function.
+	Export string
+	// Text is true if the function takes and returns text, rather than numbers.
+	Text bool
+	// MaxMemoryBytes limits the memory of each instance of the module.
+	MaxMemoryBytes uint64
+	// Timeout limits how long a single call of the function may run.
+	Timeout time.Duration
+}
+
+// WasmFunction is a SQL function implemented by a function exported from a WebAssembly module. Modules are sandboxed:
+// they may not import anything, so they have no access to the host, their memory is limited, and calls which run
+// longer than their timeout are aborted.
+//
+// The parameters and result of a numeric function must be i32, i64, f32 or f64. Its arguments are converted to
+// integers or floats to match its parameters, and it returns a BIGINT or a DOUBLE. A text function takes each of its
+// arguments as an i32 pointer and an i32 length of a string written to memory the module allocates with its exported
+// alloc(i32) i32 function, and returns an i64 holding the pointer to its result in its upper 32 bits and the length
+// of its result in its lower 32 bits. Instances are reused across calls, so the module must also export
+// dealloc(i32, i32), which is called with the pointer and length of each argument and of the result once the call
+// returns and the result has been read. The result must be memory of its own, not one of the arguments. Both kinds of
+// function return NULL if any argument is NULL.
+type WasmFunction struct {
+	opts     WasmFunctionOptions
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	params   []api.ValueType
+	result   api.ValueType
+	idle     chan api.Module
+}
+
+// NewWasmFunction compiles |module| and returns the SQL function it implements. The function must be closed once it
+// is no longer used.
+func NewWasmFunction(ctx context.Context, module []byte, opts WasmFunctionOptions) (*WasmFunction, error) {
+	pages := opts.MaxMemoryBytes / (64 << 10)
+	if pages == 0 || pages > 65536 {
+		return nil, fmt.Errorf("function %s: memory limit must be between 64KB and 4GB", opts.Name)
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(pages)).
+		WithCloseOnContextDone(true))
+	fn := &WasmFunction{
+		opts:    opts,
+		runtime: runtime,
+		idle:    make(chan api.Module, wasmMaxIdleInstances),
+	}
+	if err := fn.compile(ctx, module); err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("function %s: %w", opts.Name, err)
+	}
+	return fn, nil
+}
+
+// compile compiles |module| and validates the signature of the function it exports.
+func (f *WasmFunction) compile(ctx context.Context, module []byte) (err error) {
+	f.compiled, err = f.runtime.CompileModule(ctx, module)
+	if err != nil {
+		return err
+	}
+	if imports := f.compiled.ImportedFunctions(); len(imports) > 0 {
+		mod, name, _ := imports[0].Import()
+		return fmt.Errorf("module imports %s.%s, modules may not import functions", mod, name)
+	}
+	if len(f.compiled.ImportedMemories()) > 0 {
+		return errors.New("modules may not import memory")
+	}
+
+	def, ok := f.compiled.ExportedFunctions()[f.opts.Export]
+	if !ok {
+		return fmt.Errorf("module does not export a function named %s", f.opts.Export)
+	}
+	f.params = def.ParamTypes()
+	results := def.ResultTypes()
+	if len(results) != 1 {
+		return fmt.Errorf("%s must return exactly one value", f.opts.Export)
+	}
+	f.result = results[0]
+
+	if !f.opts.Text {
+		for _, t := range append(f.params, f.result) {
+			if !isWasmNumber(t) {
+				return fmt.Errorf("%s has a parameter or result of type %s, numeric functions only support i32, i64, f32 and f64", f.opts.Export, api.ValueTypeName(t))
+			}
+		}
+		return nil
+	}
+
+	if len(f.params)%2 != 0 || f.result != api.ValueTypeI64 {
+		return fmt.Errorf("%s must take an i32 pointer and an i32 length for each of its arguments and return an i64", f.opts.Export)
+	}
+	for _, t := range f.params {
+		if t != api.ValueTypeI32 {
+			return fmt.Errorf("%s must take an i32 pointer and an i32 length for each of its arguments", f.opts.Export)
+		}
+	}
+	alloc, ok := f.compiled.ExportedFunctions()[wasmAllocExport]
+	if !ok || len(alloc.ParamTypes()) != 1 || alloc.ParamTypes()[0] != api.ValueTypeI32 ||
+		len(alloc.ResultTypes()) != 1 || alloc.ResultTypes()[0] != api.ValueTypeI32 {
+		return fmt.Errorf("modules of text functions must export %s(i32) i32", wasmAllocExport)
+	}
+	dealloc, ok := f.compiled.ExportedFunctions()[wasmDeallocExport]
+	if !ok || len(dealloc.ParamTypes()) != 2 || dealloc.ParamTypes()[0] != api.ValueTypeI32 ||
+		dealloc.ParamTypes()[1] != api.ValueTypeI32 || len(dealloc.ResultTypes()) != 0 {
+		return fmt.Errorf("modules of text functions must export %s(i32, i32)", wasmDeallocExport)
+	}
+	if len(f.compiled.ExportedMemories()) == 0 {
+		return errors.New("modules of text functions must export their memory")
+	}
+	return nil
+}
+
+// Close releases the module and all of its instances.
+func (f *WasmFunction) Close(ctx context.Context) error {
+	return f.runtime.Close(ctx)
+}
+
+// Function returns the SQL function.
+func (f *WasmFunction) Function() sql.Function {
+	return sql.FunctionN{Name: f.opts.Name, Fn: f.newExpression}
+}
+
+// NumArgs returns the number of arguments of the SQL function.
+func (f *WasmFunction) NumArgs() int {
+	if f.opts.Text {
+		return len(f.params) / 2
+	}
+	return len(f.params)
+}
+
+func (f *WasmFunction) newExpression(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != f.NumArgs() {
+		return nil, sql.ErrInvalidArgumentNumber.New(f.opts.Name, f.NumArgs(), len(args))
+	}
+	return &WasmFunctionExpression{fn: f, children: args}, nil
+}
+
+// call calls the function with |args|, which have already been converted to match its parameters.
+func (f *WasmFunction) call(ctx context.Context, args []interface{}) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.opts.Timeout)
+	defer cancel()
+
+	mod, err := f.instance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := f.callInstance(ctx, mod, args)
+	if err != nil {
+		// the instance may have been left in any state, so it isn't reused
+		mod.Close(context.Background())
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("function %s (version %s) did not return within %s", f.opts.Name, f.opts.Version, f.opts.Timeout)
+		}
+		return nil, fmt.Errorf("function %s (version %s): %w", f.opts.Name, f.opts.Version, err)
+	}
+	select {
+	case f.idle <- mod:
+	default:
+		mod.Close(context.Background())
+	}
+	return res, nil
+}
+
+// instance returns an idle instance of the module, or a new one if none are idle. Instances are not safe for
+// concurrent use, so each is used by one call at a time.
+func (f *WasmFunction) instance(ctx context.Context) (api.Module, error) {
+	select {
+	case mod := <-f.idle:
+		return mod, nil
+	default:
+	}
+	// an anonymous module may be instantiated any number of times
+	return f.runtime.InstantiateModule(ctx, f.compiled, wazero.NewModuleConfig().WithName(""))
+}
+
+func (f *WasmFunction) callInstance(ctx context.Context, mod api.Module, args []interface{}) (interface{}, error) {
+	params := make([]uint64, 0, len(f.params))
+	if f.opts.Text {
+		alloc := mod.ExportedFunction(wasmAllocExport)
+		for _, arg := range args {
+			s := arg.(string)
+			res, err := alloc.Call(ctx, api.EncodeU32(uint32(len(s))))
+			if err != nil {
+				return nil, err
+			}
+			ptr := api.DecodeU32(res[0])
+			if !mod.Memory().Write(ptr, []byte(s)) {
+				return nil, fmt.Errorf("%s returned memory out of range", wasmAllocExport)
+			}
+			params = append(params, api.EncodeU32(ptr), api.EncodeU32(uint32(len(s))))
+		}
+	} else {
+		for i, arg := range args {
+			params = append(params, encodeWasmNumber(f.params[i], arg))
+		}
+	}
+
+	res, err := mod.ExportedFunction(f.opts.Export).Call(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	if !f.opts.Text {
+		return decodeWasmNumber(f.result, res[0]), nil
+	}
+	ptr, length := uint32(res[0]>>32), uint32(res[0])
+	b, ok := mod.Memory().Read(ptr, length)
+	if !ok {
+		return nil, fmt.Errorf("%s returned memory out of range", f.opts.Export)
+	}
+	// |b| is a view of the module's memory, which is freed below
+	result := string(b)
+
+	// the instance is reused by later calls, so the memory of the arguments and the result is freed
+	dealloc := mod.ExportedFunction(wasmDeallocExport)
+	for i := 0; i < len(params); i += 2 {
+		if _, err = dealloc.Call(ctx, params[i], params[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = dealloc.Call(ctx, api.EncodeU32(ptr), api.EncodeU32(length)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sqlType returns the SQL type of the function's result.
+func (f *WasmFunction) sqlType() sql.Type {
+	switch {
+	case f.opts.Text:
+		return types.LongText
+	case isWasmFloat(f.result):
+		return types.Float64
+	default:
+		return types.Int64
+	}
+}
+
+// convertArg converts the SQL argument |v| at index |i| to the value passed to the function.
+func (f *WasmFunction) convertArg(i int, v interface{}) (interface{}, error) {
+	var t sql.Type = types.Int64
+	if f.opts.Text {
+		t = types.LongText
+	} else if isWasmFloat(f.params[i]) {
+		t = types.Float64
+	}
+	v, _, err := t.Convert(v)
+	return v, err
+}
+
+func isWasmNumber(t api.ValueType) bool {
+	return t == api.ValueTypeI32 || t == api.ValueTypeI64 || isWasmFloat(t)
+}
+
+func isWasmFloat(t api.ValueType) bool {
+	return t == api.ValueTypeF32 || t == api.ValueTypeF64
+}
+
+func encodeWasmNumber(t api.ValueType, v interface{}) uint64 {
+	switch t {
+	case api.ValueTypeI32:
+		return api.EncodeI32(int32(v.(int64)))
+	case api.ValueTypeI64:
+		return api.EncodeI64(v.(int64))
+	case api.ValueTypeF32:
+		return api.EncodeF32(float32(v.(float64)))
+	default:
+		return api.EncodeF64(v.(float64))
+	}
+}
+
+func decodeWasmNumber(t api.ValueType, v uint64) interface{} {
+	switch t {
+	case api.ValueTypeI32:
+		return int64(api.DecodeI32(v))
+	case api.ValueTypeI64:
+		return int64(v)
+	case api.ValueTypeF32:
+		return float64(api.DecodeF32(v))
+	default:
+		return api.DecodeF64(v)
+	}
+}
+
+// WasmFunctionExpression is an expression evaluating a WasmFunction.
+type WasmFunctionExpression struct {
+	fn       *WasmFunction
+	children []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*WasmFunctionExpression)(nil)
+
+// Eval implements the Expression interface.
+func (e *WasmFunctionExpression) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args := make([]interface{}, len(e.children))
+	for i, child := range e.children {
+		v, err := child.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		if args[i], err = e.fn.convertArg(i, v); err != nil {
+			return nil, err
+		}
+	}
+	return e.fn.call(ctx, args)
+}
+
+// FunctionName implements the FunctionExpression interface.
+func (e *WasmFunctionExpression) FunctionName() string {
+	return e.fn.opts.Name
+}
+
+// Description implements the FunctionExpression interface.
+func (e *WasmFunctionExpression) Description() string {
+	return fmt.Sprintf("user-defined function implemented by version %s of a WebAssembly module", e.fn.opts.Version)
+}
+
+// Resolved implements the Expression interface.
+func (e *WasmFunctionExpression) Resolved() bool {
+	for _, child := range e.children {
+		if !child.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface.
+func (e *WasmFunctionExpression) String() string {
+	args := make([]string, len(e.children))
+	for i, child := range e.children {
+		args[i] = child.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.fn.opts.Name, strings.Join(args, ","))
+}
+
+// Type implements the Expression interface.
+func (e *WasmFunctionExpression) Type() sql.Type {
+	return e.fn.sqlType()
+}
+
+// IsNullable implements the Expression interface.
+func (e *WasmFunctionExpression) IsNullable() bool {
+	for _, child := range e.children {
+		if child.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Children implements the Expression interface.
+func (e *WasmFunctionExpression) Children() []sql.Expression {
+	return e.children
+}
+
+// WithChildren implements the Expression interface.
+func (e *WasmFunctionExpression) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return e.fn.newExpression(children...)
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// numericWasmModule exports add(i64, i64) i64, and spin() i64, which never returns.
+var numericWasmModule = append(append([]byte{}, wasmHeader...),
+	// types: (i64, i64) -> i64, () -> i64
+	0x01, 0x0b, 0x02, 0x60, 0x02, 0x7e, 0x7e, 0x01, 0x7e, 0x60, 0x00, 0x01, 0x7e,
+	// functions
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	// exports
+	0x07, 0x0e, 0x02, 0x03, 'a', 'd', 'd', 0x00, 0x00, 0x04, 's', 'p', 'i', 'n', 0x00, 0x01,
+	// code: local.get 0, local.get 1, i64.add; loop br 0 end, i64.const 0
+	0x0a, 0x13, 0x02,
+	0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x7c, 0x0b,
+	0x09, 0x00, 0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00, 0x0b,
+)
+
+// textWasmModule exports its memory, alloc(i32) i32, dealloc(i32, i32), and echo(i32, i32) i64, which returns a copy
+// of its argument. alloc bumps a pointer, traps once the page of memory is used up, and dealloc resets the pointer once
+// everything allocated has been freed, so calls only keep succeeding if their memory is freed.
+var textWasmModule = append(append([]byte{}, wasmHeader...),
+	// types: (i32) -> i32, (i32, i32) -> (), (i32, i32) -> i64
+	0x01, 0x11, 0x03, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x00, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e,
+	// functions
+	0x03, 0x04, 0x03, 0x00, 0x01, 0x02,
+	// memory of one page
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// globals: the next pointer, which starts at 1024, and the number of live allocations
+	0x06, 0x0c, 0x02, 0x7f, 0x01, 0x41, 0x80, 0x08, 0x0b, 0x7f, 0x01, 0x41, 0x00, 0x0b,
+	// exports
+	0x07, 0x23, 0x04,
+	0x05, 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+	0x07, 'd', 'e', 'a', 'l', 'l', 'o', 'c', 0x00, 0x01,
+	0x04, 'e', 'c', 'h', 'o', 0x00, 0x02,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	0x0a, 0x53, 0x03,
+	// alloc: global.get 0; global.get 0, local.get 0, i32.add, global.set 0; global.get 1, i32.const 1, i32.add,
+	// global.set 1; global.get 0, i32.const 65536, i32.gt_u, if unreachable end
+	0x1d, 0x00, 0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00, 0x23, 0x01, 0x41, 0x01, 0x6a, 0x24, 0x01,
+	0x23, 0x00, 0x41, 0x80, 0x80, 0x04, 0x4b, 0x04, 0x40, 0x00, 0x0b, 0x0b,
+	// dealloc: global.get 1, i32.const 1, i32.sub, global.set 1; global.get 1, i32.eqz, if i32.const 1024,
+	// global.set 0 end
+	0x14, 0x00, 0x23, 0x01, 0x41, 0x01, 0x6b, 0x24, 0x01, 0x23, 0x01, 0x45, 0x04, 0x40, 0x41, 0x80, 0x08, 0x24, 0x00,
+	0x0b, 0x0b,
+	// echo: local.get 1, call alloc, local.set 2; local.get 2, local.get 0, local.get 1, memory.copy; local.get 2,
+	// i64.extend_i32_u, i64.const 32, i64.shl, local.get 1, i64.extend_i32_u, i64.or
+	0x1e, 0x01, 0x01, 0x7f, 0x20, 0x01, 0x10, 0x00, 0x21, 0x02, 0x20, 0x02, 0x20, 0x00, 0x20, 0x01, 0xfc, 0x0a, 0x00,
+	0x00, 0x20, 0x02, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84, 0x0b,
+)
+
+// noDeallocWasmModule exports its memory, alloc(i32) i32, which always returns 1024, and echo(i32, i32) i64, which
+// returns its argument, but no dealloc function.
+var noDeallocWasmModule = append(append([]byte{}, wasmHeader...),
+	// types: (i32) -> i32, (i32, i32) -> i64
+	0x01, 0x0c, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e,
+	// functions
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	// memory of one page
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// exports
+	0x07, 0x19, 0x03,
+	0x05, 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+	0x04, 'e', 'c', 'h', 'o', 0x00, 0x01,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	// code: i32.const 1024; local.get 0, i64.extend_i32_u, i64.const 32, i64.shl, local.get 1, i64.extend_i32_u, i64.or
+	0x0a, 0x14, 0x02,
+	0x05, 0x00, 0x41, 0x80, 0x08, 0x0b,
+	0x0c, 0x00, 0x20, 0x00, 0xad, 0x42, 0x20, 0x86, 0x20, 0x01, 0xad, 0x84, 0x0b,
+)
+
+// importingWasmModule imports env.f.
+var importingWasmModule = append(append([]byte{}, wasmHeader...),
+	0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+	0x02, 0x09, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00,
+)
+
+func newTestWasmFunction(t *testing.T, module []byte, export string, text bool) *WasmFunction {
+	fn, err := NewWasmFunction(context.Background(), module, WasmFunctionOptions{
+		Name:           "udf",
+		Version:        "1.0.0",
+		Export:         export,
+		Text:           text,
+		MaxMemoryBytes: 1 << 20,
+		Timeout:        100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { fn.Close(context.Background()) })
+	return fn
+}
+
+func evalWasmFunction(t *testing.T, fn *WasmFunction, args ...interface{}) (interface{}, error) {
+	exprs := make([]sql.Expression, len(args))
+	for i, arg := range args {
+		exprs[i] = expression.NewLiteral(arg, types.ApproximateTypeFromValue(arg))
+	}
+	e, err := fn.Function().NewInstance(exprs)
+	require.NoError(t, err)
+	return e.Eval(sql.NewEmptyContext(), nil)
+}
+
+func TestWasmFunction(t *testing.T) {
+	t.Run("numeric", func(t *testing.T) {
+		fn := newTestWasmFunction(t, numericWasmModule, "add", false)
+		assert.Equal(t, 2, fn.NumArgs())
+		for i := 0; i < 20; i++ {
+			v, err := evalWasmFunction(t, fn, int64(i), "40")
+			require.NoError(t, err)
+			assert.Equal(t, int64(i+40), v)
+		}
+		v, err := evalWasmFunction(t, fn, nil, 1)
+		require.NoError(t, err)
+		assert.Nil(t, v)
+
+		_, err = fn.Function().NewInstance([]sql.Expression{expression.NewLiteral(1, types.Int64)})
+		assert.Error(t, err)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		fn := newTestWasmFunction(t, textWasmModule, "echo", true)
+		assert.Equal(t, 1, fn.NumArgs())
+		v, err := evalWasmFunction(t, fn, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", v)
+		v, err = evalWasmFunction(t, fn, 42)
+		require.NoError(t, err)
+		assert.Equal(t, "42", v)
+
+		// the memory of each call is freed, so many calls don't run the instances out of memory
+		arg := strings.Repeat("x", 1000)
+		for i := 0; i < 1000; i++ {
+			v, err = evalWasmFunction(t, fn, arg)
+			require.NoError(t, err)
+			require.Equal(t, arg, v)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		fn := newTestWasmFunction(t, numericWasmModule, "spin", false)
+		_, err := evalWasmFunction(t, fn)
+		assert.ErrorContains(t, err, "did not return within")
+		v, err := evalWasmFunction(t, newTestWasmFunction(t, numericWasmModule, "add", false), 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), v)
+	})
+
+	t.Run("invalid modules", func(t *testing.T) {
+		opts := WasmFunctionOptions{Name: "udf", Export: "add", MaxMemoryBytes: 1 << 20, Timeout: time.Second}
+		_, err := NewWasmFunction(context.Background(), importingWasmModule, opts)
+		assert.ErrorContains(t, err, "may not import")
+		_, err = NewWasmFunction(context.Background(), []byte("not wasm"), opts)
+		assert.Error(t, err)
+
+		opts.Export = "missing"
+		_, err = NewWasmFunction(context.Background(), numericWasmModule, opts)
+		assert.ErrorContains(t, err, "does not export")
+
+		opts.Export, opts.Text = "add", true
+		_, err = NewWasmFunction(context.Background(), numericWasmModule, opts)
+		assert.Error(t, err)
+
+		opts.Export = "echo"
+		_, err = NewWasmFunction(context.Background(), noDeallocWasmModule, opts)
+		assert.ErrorContains(t, err, "must export dealloc(i32, i32)")
+
+		opts.Export, opts.Text, opts.MaxMemoryBytes = "add", false, 0
+		_, err = NewWasmFunction(context.Background(), numericWasmModule, opts)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltAttach mounts another dolt database read-only, so that it can be queried, and joined with other databases,
+// without restarting the server. The first argument is the path of the database's directory, or a remote URL such
+// as file:///path/to/remote or https://doltremoteapi.dolthub.com/org/repo, and the second is the name to serve it as.
+func doltAttach(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("error: dolt_attach requires two arguments, the path or URL of a database and the name to attach it as")
+	} else if args[0] == "" || args[1] == "" {
+		return nil, fmt.Errorf("error: dolt_attach requires a non-empty path and name")
+	}
+	if err := dsess.DSessFromSess(ctx.Session).Provider().AttachDatabase(ctx, args[1], args[0]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltDetach unmounts a database mounted by dolt_attach.
+func doltDetach(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("error: dolt_detach requires one argument, the name of the attached database")
+	}
+	if err := dsess.DSessFromSess(ctx.Session).Provider().DetachDatabase(ctx, args[0]); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+const (
+	defaultCPUProfileSeconds = 30
+	maxCPUProfileSeconds     = 600
+)
+
+// doltCaptureProfile writes a Go runtime profile of the server process to a new file in the server's temporary
+// directory and returns the file's path, for inspection with `go tool pprof`. The first argument is the kind of
+// profile: cpu, or the name of any runtime/pprof profile such as heap, allocs, goroutine, block or mutex. A cpu
+// profile samples the process for the number of seconds given as the second argument, 30 by default, while every
+// other kind of profile is a snapshot taken immediately.
+func doltCaptureProfile(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("error: dolt_capture_profile requires the kind of profile to capture")
+	} else if len(args) > 2 {
+		return nil, fmt.Errorf("error: dolt_capture_profile takes at most two arguments, the kind of profile and a duration in seconds")
+	}
+
+	kind := strings.ToLower(args[0])
+	if kind == "cpu" {
+		seconds := defaultCPUProfileSeconds
+		if len(args) == 2 {
+			var err error
+			seconds, err = strconv.Atoi(args[1])
+			if err != nil || seconds <= 0 || seconds > maxCPUProfileSeconds {
+				return nil, fmt.Errorf("error: invalid duration '%s', expected between 1 and %d seconds", args[1], maxCPUProfileSeconds)
+			}
+		}
+		path, err := captureCPUProfile(ctx, time.Duration(seconds)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		return rowToIter(path), nil
+	}
+
+	if len(args) == 2 {
+		return nil, fmt.Errorf("error: a duration can only be given for cpu profiles")
+	}
+	profile := pprof.Lookup(kind)
+	if profile == nil {
+		return nil, fmt.Errorf("error: unknown profile '%s'", args[0])
+	}
+	f, err := os.CreateTemp("", "dolt-"+kind+"-*.pprof")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err = profile.WriteTo(f, 0); err != nil {
+		return nil, err
+	}
+	return rowToIter(f.Name()), nil
+}
+
+// captureCPUProfile profiles the process for |d|, or until |ctx| is cancelled, and returns the path of the profile.
+func captureCPUProfile(ctx *sql.Context, d time.Duration) (string, error) {
+	f, err := os.CreateTemp("", "dolt-cpu-*.pprof")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err = pprof.StartCPUProfile(f); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("error: unable to start cpu profile: %w", err)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	pprof.StopCPUProfile()
+
+	if ctx.Err() != nil {
+		_ = os.Remove(f.Name())
+		return "", ctx.Err()
+	}
+	return f.Name(), nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/cherry_pick"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 )
 
 var ErrEmptyCherryPick = errors.New("cannot cherry-pick empty string")
@@ -62,7 +63,7 @@ func doltCherryPick(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 }
 
 // doDoltCherryPick attempts to perform a cherry-pick merge based on the arguments specified in |args| and returns
-// the new, created commit hash (if it was successful created), a count of the number of tables with data conflicts,
+// the new, created commit hash (if it was successful created; the last one when several commits are cherry-picked), a count of the number of tables with data conflicts,
 // a count of the number of tables with schema conflicts, and a count of the number of tables with constraint violations.
 func doDoltCherryPick(ctx *sql.Context, args []string) (string, int, int, int, error) {
 	// Get the information for the sql context.
@@ -84,18 +85,6 @@ func doDoltCherryPick(ctx *sql.Context, args []string) (string, int, int, int, e
 		return "", 0, 0, 0, cherry_pick.AbortCherryPick(ctx, dbName)
 	}
 
-	// we only support cherry-picking a single commit for now.
-	if apr.NArg() == 0 {
-		return "", 0, 0, 0, ErrEmptyCherryPick
-	} else if apr.NArg() > 1 {
-		return "", 0, 0, 0, fmt.Errorf("cherry-picking multiple commits is not supported yet")
-	}
-
-	cherryStr := apr.Arg(0)
-	if len(cherryStr) == 0 {
-		return "", 0, 0, 0, ErrEmptyCherryPick
-	}
-
 	cherryPickOptions := cherry_pick.NewCherryPickOptions()
 
 	// If --allow-empty is specified, then empty commits are allowed to be cherry-picked
@@ -103,7 +92,24 @@ func doDoltCherryPick(ctx *sql.Context, args []string) (string, int, int, int, e
 		cherryPickOptions.EmptyCommitHandling = doltdb.KeepEmptyCommit
 	}
 
-	commit, mergeResult, err := cherry_pick.CherryPick(ctx, cherryStr, cherryPickOptions)
+	var commit string
+	var mergeResult *merge.Result
+	if apr.Contains(cli.ContinueFlag) {
+		if apr.NArg() > 0 {
+			return "", 0, 0, 0, fmt.Errorf("error: --continue does not take any commits")
+		}
+		commit, mergeResult, err = cherry_pick.ContinueCherryPick(ctx, cherryPickOptions)
+	} else {
+		if apr.NArg() == 0 {
+			return "", 0, 0, 0, ErrEmptyCherryPick
+		}
+		for _, cherryStr := range apr.Args {
+			if len(cherryStr) == 0 {
+				return "", 0, 0, 0, ErrEmptyCherryPick
+			}
+		}
+		commit, mergeResult, err = cherry_pick.CherryPickCommits(ctx, apr.Args, cherryPickOptions)
+	}
 	if err != nil {
 		return "", 0, 0, 0, err
 	}
@@ -15,6 +15,7 @@
 package dprocedures
 
 import (
+	"context"
 	"path"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -22,15 +23,41 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/jobs"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 )
 
-// doltClone is the stored procedure version for the CLI command `dolt clone`.
+// doltClone is the stored procedure version for the CLI command `dolt clone`. The clone runs as a job, which is listed
+// with its progress in dolt_jobs and can be cancelled with dolt_job_cancel.
 func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	job, err := startClone(ctx, false, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err = job.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltCloneAsync is dolt_clone, except that it returns the id of the clone's job in dolt_jobs without waiting for the
+// clone to finish.
+func doltCloneAsync(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	job, err := startClone(ctx, true, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(job.ID())), nil
+}
+
+// startClone starts the job of the clone given by |args|. An |async| clone outlives the query which started it.
+func startClone(ctx *sql.Context, async bool, args ...string) (*jobs.Job, error) {
 	ap := cli.CreateCloneArgParser()
 	apr, err := ap.Parse(args)
 	if err != nil {
@@ -56,18 +83,30 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		remoteParms[dbfactory.GRPCUsernameAuthParam] = user
 	}
+	if filterSpec, ok := apr.GetValue(cli.FilterFlag); ok {
+		filter, err := doltdb.ParseFetchFilter(filterSpec)
+		if err != nil {
+			return nil, err
+		}
+		remoteParms[env.PartialCloneFilterParam] = filter.String()
+	}
 
 	depth, ok := apr.GetInt(cli.DepthFlag)
 	if !ok {
 		depth = -1
 	}
 
-	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, remoteParms)
-	if err != nil {
-		return nil, err
+	// An asynchronous clone outlives the query which started it, so it isn't cancelled with it
+	parent := context.Context(ctx)
+	if async {
+		parent = context.WithoutCancel(ctx)
 	}
-
-	return rowToIter(int64(0)), nil
+	return jobs.Default().Start(parent, jobs.Clone, dir, func(jobCtx context.Context, j *jobs.Job) error {
+		jobCtx = actions.WithCloneProgress(jobCtx, func(p actions.CloneProgress) {
+			j.SetProgress(jobs.Progress(p))
+		})
+		return sess.Provider().CloneDatabaseFromRemote(ctx.WithContext(jobCtx), dir, branch, remoteName, remoteUrl, depth, remoteParms)
+	}), nil
 }
 
 func emptyConfig() config.ReadableConfig {
@@ -120,7 +120,7 @@ func resolveProllyConflicts(ctx *sql.Context, tbl *doltdb.Table, tblName string,
 
 		// reload if their root hash changes
 		if theirRoot != cnfArt.TheirRootIsh {
-			theirMap, err = getProllyRowMaps(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), cnfArt.TheirRootIsh, tblName)
+			theirMap, err = getProllyRowMaps(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), cnfArt.TheirRootIsh, cnfArt.Metadata.TheirTableName(tblName))
 			if err != nil {
 				return nil, err
 			}
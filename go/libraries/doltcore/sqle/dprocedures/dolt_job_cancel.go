@@ -0,0 +1,40 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/jobs"
+)
+
+// doltJobCancel cancels the running job whose id is given as the argument, such as a clone started with dolt_clone.
+// Jobs are listed in dolt_jobs.
+func doltJobCancel(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("error: dolt_job_cancel takes the id of a job")
+	}
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid job id '%s'", args[0])
+	}
+	if err = jobs.Default().Cancel(id); err != nil {
+		return nil, fmt.Errorf("error: %w", err)
+	}
+	return rowToIter(int64(0)), nil
+}
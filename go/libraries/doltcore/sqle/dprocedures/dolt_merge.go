@@ -170,7 +170,8 @@ func doDoltMerge(ctx *sql.Context, args []string) (string, int, int, string, err
 		return "", noConflictsOrViolations, threeWayMerge, "", err
 	}
 	msg := fmt.Sprintf("Merge branch '%s' into %s", branchName, headRef.GetPath())
-	if userMsg, mOk := apr.GetValue(cli.MessageArg); mOk {
+	userMsg, hasUserMsg := apr.GetValue(cli.MessageArg)
+	if hasUserMsg {
 		msg = userMsg
 	}
 
@@ -182,6 +183,16 @@ func doDoltMerge(ctx *sql.Context, args []string) (string, int, int, string, err
 		return commit, conflicts, fastForward, "conflicts found", nil
 	}
 
+	// A squashed fast-forward only stages the changes, unless it's given a message to commit them with
+	if fastForward == fastForwardMerge && mergeSpec.Squash && hasUserMsg && !apr.Contains(cli.NoCommitFlag) {
+		author := fmt.Sprintf("%s <%s>", mergeSpec.Name, mergeSpec.Email)
+		commit, _, err = doDoltCommit(ctx, []string{"-m", msg, "--author", author})
+		if err != nil {
+			return "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		return commit, noConflictsOrViolations, threeWayMerge, message, nil
+	}
+
 	return commit, conflicts, fastForward, message, nil
 }
 
@@ -226,6 +237,14 @@ func performMerge(
 	}
 
 	if canFF {
+		mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+		if err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		if err = checkMergedRoot(ctx, ws, spec.HeadC, mergeRoot); err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+
 		if spec.NoFF {
 			var commit *doltdb.Commit
 			ws, commit, err = executeNoFFMerge(ctx, sess, spec, msg, dbName, ws, noCommit)
@@ -271,7 +290,14 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.Strategy, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	if err == nil || err == doltdb.ErrUnresolvedConflictsOrViolations {
+		// Conflicts don't stop the merged root from being committed once they are resolved, so the merged root is
+		// checked either way.
+		if checkErr := checkMergedRoot(ctx, ws, spec.HeadC, ws.WorkingRoot()); checkErr != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", checkErr
+		}
+	}
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -307,19 +333,37 @@ func performMerge(
 	return ws, commit, noConflictsOrViolations, threeWayMerge, "merge successful", nil
 }
 
+// checkMergedRoot returns an error reporting the changes that break the dolt_schema_contracts or the
+// dolt_append_only_tables of the branch of |ws| if merging into it would change |head| to |merged|.
+func checkMergedRoot(ctx *sql.Context, ws *doltdb.WorkingSet, head *doltdb.Commit, merged doltdb.RootValue) error {
+	branch, err := ws.Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+	headRoot, err := head.GetRootValue(ctx)
+	if err != nil {
+		return err
+	}
+	if err = doltdb.CheckSchemaContracts(ctx, branch.GetPath(), headRoot, merged); err != nil {
+		return err
+	}
+	return doltdb.CheckAppendOnlyTables(ctx, branch.GetPath(), headRoot, merged)
+}
+
 func executeMerge(
 	ctx *sql.Context,
 	sess *dsess.DoltSession,
 	dbName string,
 	squash bool,
 	force bool,
+	strategy doltdb.MergeStrategy,
 	head, cm *doltdb.Commit,
 	cmSpec string,
 	ws *doltdb.WorkingSet,
 	opts editor.Options,
 	workingDiffs map[doltdb.TableName]hash.Hash,
 ) (*doltdb.WorkingSet, error) {
-	result, err := merge.MergeCommits(ctx, head, cm, opts)
+	result, err := merge.MergeCommits(ctx, head, cm, opts, strategy)
 	if err != nil {
 		switch err {
 		case doltdb.ErrUpToDate:
@@ -392,11 +436,10 @@ func executeNoFFMerge(
 	ws *doltdb.WorkingSet,
 	noCommit bool,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
-	mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+	result, err := noFFMergeResult(ctx, dSess, dbName, spec)
 	if err != nil {
 		return nil, nil, err
 	}
-	result := &merge.Result{Root: mergeRoot, Stats: make(map[doltdb.TableName]*merge.MergeStats)}
 
 	ws, err = mergeRootToWorking(ctx, dSess, dbName, false, spec.Force, ws, result, spec.WorkingDiffs, spec.MergeC, spec.MergeCSpecStr)
 	if err != nil {
@@ -452,6 +495,27 @@ func executeNoFFMerge(
 	return ws, commit, nil
 }
 
+// noFFMergeResult returns the result of merging a commit that could be fast-forwarded to. Without a merge strategy,
+// that's the merged commit's root. A strategy decides the contents of each table instead, as it does for a three-way
+// merge.
+func noFFMergeResult(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, spec *merge.MergeSpec) (*merge.Result, error) {
+	if spec.Strategy == "" {
+		mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &merge.Result{Root: mergeRoot, Stats: make(map[doltdb.TableName]*merge.MergeStats)}, nil
+	}
+
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	return merge.MergeCommits(ctx, spec.HeadC, spec.MergeC, dbState.EditOpts(), spec.Strategy)
+}
+
 func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults, commitSpecStr string) (*merge.MergeSpec, error) {
 	ddb, ok := sess.GetDoltDB(ctx, dbName)
 
@@ -478,6 +542,14 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 	if apr.Contains(cli.NoCommitFlag) && apr.Contains(cli.CommitFlag) {
 		return nil, errors.New("cannot define both 'commit' and 'no-commit' flags at the same time")
 	}
+
+	var strategy doltdb.MergeStrategy
+	if strategyName, ok := apr.GetValue(cli.StrategyParam); ok {
+		strategy, err = doltdb.ParseMergeStrategy(strategyName)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return merge.NewMergeSpec(
 		ctx,
 		dbData.Rsr,
@@ -492,6 +564,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithStrategy(strategy),
 	)
 }
 
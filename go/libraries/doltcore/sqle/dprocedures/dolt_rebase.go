@@ -179,14 +179,21 @@ func doDoltRebase(ctx *sql.Context, args []string) (int, string, error) {
 		} else if apr.NArg() > 1 {
 			return 1, "", fmt.Errorf("too many args")
 		}
-		if !apr.Contains(cli.InteractiveFlag) {
-			return 1, "", fmt.Errorf("non-interactive rebases not currently supported")
-		}
 		err = startRebase(ctx, apr.Arg(0), commitBecomesEmptyHandling, emptyCommitHandling)
 		if err != nil {
 			return 1, "", err
 		}
 
+		// A non-interactive rebase executes the default rebase plan right away. If a step hits a data conflict,
+		// the rebase stops the same way as an interactive one and is resumed with --continue or --abort.
+		if !apr.Contains(cli.InteractiveFlag) {
+			rebaseBranch, err := continueRebase(ctx)
+			if err != nil {
+				return 1, "", err
+			}
+			return 0, SuccessfulRebaseMessage + rebaseBranch, nil
+		}
+
 		currentBranch, err := currentBranch(ctx)
 		if err != nil {
 			return 1, "", err
@@ -711,6 +718,12 @@ func continueRebase(ctx *sql.Context) (string, error) {
 		return "", err
 	}
 
+	// the rebased commits replace the branch's history, so they may only add rows to its append-only tables
+	err = doltdb.CheckAppendOnlyTables(ctx, rebaseBranch, rebaseBranchWorkingSet.RebaseState().PreRebaseWorkingRoot(), rebaseBranchWorkingSet.WorkingRoot())
+	if err != nil {
+		return "", err
+	}
+
 	// TODO: copyABranch (and the underlying call to doltdb.NewBranchAtCommit) has a race condition
 	//       where another session can set the branch head AFTER doltdb.NewBranchAtCommit updates
 	//       the branch head, but BEFORE doltdb.NewBranchAtCommit retrieves the working set for the
@@ -0,0 +1,35 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
+)
+
+// doltReloadConfig re-reads the config file of the running sql-server and applies the settings which can be changed
+// without a restart, the same as sending the server process a SIGHUP.
+func doltReloadConfig(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("error: dolt_reload_config does not take any arguments")
+	}
+	if err := sqlserver.ReloadConfig(ctx); err != nil {
+		return nil, fmt.Errorf("error: %w", err)
+	}
+	return rowToIter(int64(cmdSuccess)), nil
+}
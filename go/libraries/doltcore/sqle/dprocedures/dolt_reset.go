@@ -250,7 +250,7 @@ func resetHard(
 	}
 
 	var newHead *doltdb.Commit
-	newHead, roots, err := actions.ResetHardTables(ctx, dbData, arg, roots)
+	newHead, newRoots, err := actions.ResetHardTables(ctx, dbData, arg, roots)
 
 	if err != nil {
 		return err
@@ -262,6 +262,10 @@ func resetHard(
 		if err != nil {
 			return err
 		}
+		// the head moves before the working set is set, so check the reset against dolt_append_only_tables first
+		if err := doltdb.CheckAppendOnlyTables(ctx, headRef.GetPath(), roots.Working, newRoots.Working); err != nil {
+			return err
+		}
 		if err := dbData.Ddb.SetHeadToCommit(ctx, headRef, newHead); err != nil {
 			return err
 		}
@@ -272,11 +276,11 @@ func resetHard(
 	if err != nil {
 		return err
 	}
-	err = dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged).ClearMerge().ClearRebase())
+	err = dSess.SetWorkingSet(ctx, dbName, ws.WithWorkingRoot(newRoots.Working).WithStagedRoot(newRoots.Staged).ClearMerge().ClearRebase())
 	if err != nil {
 		return err
 	}
-	err = dSess.ResetGlobals(ctx, dbName, roots.Working)
+	err = dSess.ResetGlobals(ctx, dbName, newRoots.Working)
 	if err != nil {
 		return err
 	}
@@ -15,6 +15,7 @@
 package dprocedures
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -24,11 +25,16 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
+// ErrNoRevertInProgress is returned by --continue and --abort when there is no revert in progress.
+var ErrNoRevertInProgress = errors.New("error: There is no revert in progress")
+
 // doltRevert is the stored procedure version for the CLI command `dolt revert`.
 func doltRevert(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	res, err := doDoltRevert(ctx, args)
@@ -38,6 +44,9 @@ func doltRevert(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(int64(res)), nil
 }
 
+// doDoltRevert reverts the commits given in |args| and commits the result. A single commit whose revert produces
+// conflicts or constraint violations leaves the revert in progress, in which case 1 is returned. The revert can then be
+// finished with --continue once its conflicts are resolved, or undone with --abort.
 func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 	dbName := ctx.GetCurrentDatabase()
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -49,6 +58,19 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 		return 1, err
 	}
 
+	apr, err := cli.CreateRevertArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.Contains(cli.AbortParam) && apr.Contains(cli.ContinueFlag) {
+		return 1, fmt.Errorf("--abort and --continue cannot be used together")
+	} else if apr.Contains(cli.AbortParam) {
+		return 0, abortRevert(ctx, dSess, dbName)
+	} else if apr.Contains(cli.ContinueFlag) {
+		return continueRevert(ctx, dSess, dbName, apr)
+	}
+
 	roots, ok := dSess.GetRoots(ctx, dbName)
 	if !ok {
 		return 1, fmt.Errorf("Could not load session roots")
@@ -64,6 +86,9 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 	if err != nil {
 		return 1, err
 	}
+	if workingSet.MergeActive() {
+		return 1, doltdb.ErrMergeActive
+	}
 	workingRoot := workingSet.WorkingRoot()
 	headCommit, err := dSess.GetHeadCommit(ctx, dbName)
 	if err != nil {
@@ -87,11 +112,6 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 		return 1, err
 	}
 
-	apr, err := cli.CreateRevertArgParser().Parse(args)
-	if err != nil {
-		return 1, err
-	}
-
 	commits := make([]*doltdb.Commit, apr.NArg())
 	for i, revisionStr := range apr.Args {
 		commitSpec, err := doltdb.NewCommitSpec(revisionStr)
@@ -117,9 +137,23 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("Could not load database %s", dbName)
 	}
 
-	workingRoot, revertMessage, err := merge.Revert(ctx, ddb, workingRoot, commits, dbState.EditOpts())
-	if err != nil {
-		return 1, err
+	var revertMessage string
+	if len(commits) == 1 {
+		// A single commit can be left in progress when its revert doesn't apply cleanly
+		var result *merge.Result
+		result, revertMessage, err = merge.RevertCommit(ctx, ddb, workingRoot, commits[0], dbState.EditOpts())
+		if err != nil {
+			return 1, err
+		}
+		if result.HasMergeArtifacts() {
+			return 1, startRevert(ctx, dSess, dbName, workingSet, result, commits[0], apr.Arg(0))
+		}
+		workingRoot = result.Root
+	} else {
+		workingRoot, revertMessage, err = merge.Revert(ctx, ddb, workingRoot, commits, dbState.EditOpts())
+		if err != nil {
+			return 1, err
+		}
 	}
 	workingHash, err = workingRoot.HashOf()
 	if err != nil {
@@ -130,23 +164,105 @@ func doDoltRevert(ctx *sql.Context, args []string) (int, error) {
 		if err != nil {
 			return 1, err
 		}
-		stringType := typeinfo.StringDefaultType.ToSqlType()
-
-		expressions := []sql.Expression{expression.NewLiteral("-a", stringType), expression.NewLiteral("-m", stringType), expression.NewLiteral(revertMessage, stringType)}
-
-		author, hasAuthor := apr.GetValue(cli.AuthorParam)
-		if hasAuthor {
-			expressions = append(expressions, expression.NewLiteral("--author", stringType), expression.NewLiteral(author, stringType))
-		}
-
-		commitArgs, err := getDoltArgs(ctx, nil, expressions)
-		if err != nil {
+		if err = commitRevert(ctx, apr, revertMessage); err != nil {
 			return 1, err
 		}
-		_, _, err = doDoltCommit(ctx, commitArgs)
-		if err != nil {
-			return 1, err
+	}
+	return 0, nil
+}
+
+// startRevert records the revert of |commit| as in progress, with the conflicts and constraint violations in |result|
+// left in the working set for the user to resolve. Tables without any are staged.
+func startRevert(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, ws *doltdb.WorkingSet, result *merge.Result, commit *doltdb.Commit, commitSpecStr string) error {
+	var tablesToAdd []doltdb.TableName
+	for tableName, stats := range result.Stats {
+		if !stats.HasArtifacts() {
+			tablesToAdd = append(tablesToAdd, tableName)
 		}
 	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load session roots")
+	}
+	roots.Working = result.Root
+	roots, err := actions.StageTables(ctx, roots, tablesToAdd, true)
+	if err != nil {
+		return err
+	}
+
+	ws = ws.StartRevert(commit, commitSpecStr).WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
+	return dSess.SetWorkingSet(ctx, dbName, ws)
+}
+
+// continueRevert commits the in-progress revert, once all of its conflicts and constraint violations are resolved.
+func continueRevert(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults) (int, error) {
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	if !ws.MergeActive() || !ws.MergeState().IsRevert() {
+		return 1, ErrNoRevertInProgress
+	}
+
+	if ok, err := doltdb.HasConflicts(ctx, ws.WorkingRoot()); err != nil {
+		return 1, err
+	} else if ok {
+		return 1, fmt.Errorf("error: cannot continue the revert with unresolved conflicts")
+	}
+	if ok, err := doltdb.HasConstraintViolations(ctx, ws.WorkingRoot()); err != nil {
+		return 1, err
+	} else if ok {
+		return 1, fmt.Errorf("error: cannot continue the revert with unresolved constraint violations")
+	}
+
+	revertMessage, err := merge.RevertCommitMessage(ctx, ws.MergeState().Commit())
+	if err != nil {
+		return 1, err
+	}
+	if err = commitRevert(ctx, apr, revertMessage); err != nil {
+		return 1, err
+	}
 	return 0, nil
 }
+
+// abortRevert restores the working set to its state before the in-progress revert.
+func abortRevert(ctx *sql.Context, dSess *dsess.DoltSession, dbName string) error {
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return fmt.Errorf("fatal: unable to load working set: %v", err)
+	}
+	if !ws.MergeActive() || !ws.MergeState().IsRevert() {
+		return ErrNoRevertInProgress
+	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("fatal: unable to load roots for %s", dbName)
+	}
+
+	newWs, err := merge.AbortMerge(ctx, ws, roots)
+	if err != nil {
+		return fmt.Errorf("fatal: unable to abort revert: %v", err)
+	}
+	return dSess.SetWorkingSet(ctx, dbName, newWs)
+}
+
+// commitRevert commits the working set with |revertMessage|, using the author given in |apr| if there is one.
+func commitRevert(ctx *sql.Context, apr *argparser.ArgParseResults, revertMessage string) error {
+	stringType := typeinfo.StringDefaultType.ToSqlType()
+
+	expressions := []sql.Expression{expression.NewLiteral("-a", stringType), expression.NewLiteral("-m", stringType), expression.NewLiteral(revertMessage, stringType)}
+
+	author, hasAuthor := apr.GetValue(cli.AuthorParam)
+	if hasAuthor {
+		expressions = append(expressions, expression.NewLiteral("--author", stringType), expression.NewLiteral(author, stringType))
+	}
+
+	commitArgs, err := getDoltArgs(ctx, nil, expressions)
+	if err != nil {
+		return err
+	}
+	_, _, err = doDoltCommit(ctx, commitArgs)
+	return err
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	defaultSnapshotTimeoutSeconds = 600
+	maxSnapshotTimeoutSeconds     = 24 * 60 * 60
+)
+
+var doltSnapshotBeginSchema = []*sql.Column{
+	{Name: "path", Type: types.LongText, Nullable: false},
+	{Name: "size", Type: types.Int64, Nullable: false},
+}
+
+// doltSnapshotBegin begins a snapshot of the files of the current database, for a filesystem-level backup of a running
+// server, and returns a row for each file to copy with the number of its bytes to copy. Until dolt_snapshot_end is
+// called, the files aren't changed by commits or garbage collection, which wait for the end of the snapshot. The
+// snapshot is ended automatically after the number of seconds given as the argument, 600 by default, so that a
+// backup which fails doesn't block commits indefinitely.
+func doltSnapshotBegin(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("error: dolt_snapshot_begin takes at most one argument, a timeout in seconds")
+	}
+	seconds := defaultSnapshotTimeoutSeconds
+	if len(args) == 1 {
+		var err error
+		seconds, err = strconv.Atoi(args[0])
+		if err != nil || seconds <= 0 || seconds > maxSnapshotTimeoutSeconds {
+			return nil, fmt.Errorf("error: invalid timeout '%s', expected between 1 and %d seconds", args[0], maxSnapshotTimeoutSeconds)
+		}
+	}
+
+	dbData, err := snapshotDbData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, err := dbData.Ddb.BeginSnapshot(ctx, time.Duration(seconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(files))
+	for i, f := range files {
+		rows[i] = sql.Row{f.Path, f.Size}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// doltSnapshotEnd ends the snapshot of the current database begun by dolt_snapshot_begin.
+func doltSnapshotEnd(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("error: dolt_snapshot_end takes no arguments")
+	}
+	dbData, err := snapshotDbData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = dbData.Ddb.EndSnapshot(); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+func snapshotDbData(ctx *sql.Context) (env.DbData, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return env.DbData{}, fmt.Errorf("empty database name")
+	}
+	dbData, ok := dsess.DSessFromSess(ctx.Session).GetDbData(ctx, dbName)
+	if !ok {
+		return env.DbData{}, fmt.Errorf("Could not load database %s", dbName)
+	}
+	return dbData, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	defaultWaitForChangesSeconds = 30
+	maxWaitForChangesSeconds     = 60 * 60
+)
+
+var doltWaitForChangesSchema = []*sql.Column{
+	{Name: "database", Type: types.LongText, Nullable: false},
+	{Name: "branch", Type: types.LongText, Nullable: false},
+	{Name: "table_name", Type: types.LongText, Nullable: true},
+	{Name: "hash", Type: types.LongText, Nullable: false},
+}
+
+// doltSubscribe subscribes the session to the changes of the head of the branch of the current database given as the
+// first argument, or to the changes of the table given as the second argument in the working set of the branch.
+// Changes are waited for with dolt_wait_for_changes.
+func doltSubscribe(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("error: dolt_subscribe takes a branch and an optional table")
+	}
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	var table string
+	if len(args) == 2 {
+		table = args[1]
+	}
+	if err := dsess.DSessFromSess(ctx.Session).Subscribe(ctx, dbName, args[0], table); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltUnsubscribe ends the subscription of the session to the branch and optional table given as arguments, or every
+// subscription of the session if no arguments are given.
+func doltUnsubscribe(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) > 2 {
+		return nil, fmt.Errorf("error: dolt_unsubscribe takes an optional branch and table")
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	if len(args) == 0 {
+		sess.UnsubscribeAll()
+		return rowToIter(int64(0)), nil
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	var table string
+	if len(args) == 2 {
+		table = args[1]
+	}
+	if !sess.Unsubscribe(dbName, args[0], table) {
+		return nil, fmt.Errorf("error: not subscribed to %s", subscriptionName(args[0], table))
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltWaitForChanges waits for changes of the branches and tables the session is subscribed to with dolt_subscribe,
+// and returns a row for each of them. Returns no rows if there were no changes within the number of seconds given as
+// the argument, 30 by default.
+func doltWaitForChanges(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("error: dolt_wait_for_changes takes at most one argument, a timeout in seconds")
+	}
+	seconds := defaultWaitForChangesSeconds
+	if len(args) == 1 {
+		var err error
+		seconds, err = strconv.Atoi(args[0])
+		if err != nil || seconds < 0 || seconds > maxWaitForChangesSeconds {
+			return nil, fmt.Errorf("error: invalid timeout '%s', expected between 0 and %d seconds", args[0], maxWaitForChangesSeconds)
+		}
+	}
+
+	changes, err := dsess.DSessFromSess(ctx.Session).WaitForChanges(ctx, time.Duration(seconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, len(changes))
+	for i, c := range changes {
+		var table interface{}
+		if c.Table != "" {
+			table = c.Table
+		}
+		h := ""
+		if !c.Hash.IsEmpty() {
+			h = c.Hash.String()
+		}
+		rows[i] = sql.Row{c.Database, c.Branch, table, h}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func subscriptionName(branch, table string) string {
+	if table == "" {
+		return "branch " + branch
+	}
+	return fmt.Sprintf("table %s on branch %s", table, branch)
+}
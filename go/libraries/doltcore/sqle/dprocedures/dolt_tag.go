@@ -56,10 +56,19 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 		return 1, fmt.Errorf("error: invalid argument, use 'dolt_tags' system table to list tags")
 	}
 
+	// verify tags
+	if apr.Contains(cli.VerifyFlag) {
+		return 1, fmt.Errorf("error: invalid argument, use 'dolt tag --verify' to verify tags")
+	}
+
+	sign := apr.Contains(cli.SignTagFlag) || apr.Contains(cli.LocalUserParam)
+
 	// delete tag
 	if apr.Contains(cli.DeleteFlag) {
 		if apr.Contains(cli.MessageArg) {
 			return 1, fmt.Errorf("delete and tag message options are incompatible")
+		} else if apr.Contains(cli.AnnotateFlag) || sign {
+			return 1, fmt.Errorf("delete and annotate or sign options are incompatible")
 		}
 		err = actions.DeleteTagsOnDB(ctx, dbData.Ddb, apr.Args...)
 		if err != nil {
@@ -85,11 +94,30 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 	}
 
 	msg, _ := apr.GetValue(cli.MessageArg)
+	if (apr.Contains(cli.AnnotateFlag) || sign) && len(msg) == 0 {
+		return 1, fmt.Errorf("annotated and signed tags require a tag message")
+	}
 
 	props := actions.TagProps{
 		TaggerName:  name,
 		TaggerEmail: email,
 		Description: msg,
+		Sign:        sign,
+	}
+
+	if sign {
+		props.SigningKey = apr.GetValueOrDefault(cli.LocalUserParam, "")
+		if props.SigningKey == "" {
+			v, err := ctx.GetSessionVariable(ctx, "signingkey")
+			if err != nil && !sql.ErrUnknownSystemVariable.Is(err) {
+				return 1, fmt.Errorf("failed to get signingkey: %w", err)
+			} else if err == nil {
+				props.SigningKey = v.(string)
+			}
+		}
+		if props.SigningKey == "" {
+			return 1, fmt.Errorf("no signing key, use -u or set the signingkey system variable")
+		}
 	}
 
 	tagName := apr.Arg(0)
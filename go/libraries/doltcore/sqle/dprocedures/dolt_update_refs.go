@@ -0,0 +1,123 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltUpdateRefs is the stored procedure which points several refs at new commits atomically, e.g.
+// CALL dolt_update_refs('main', 'release', 'prod', 'main~1'). Either every ref is updated or none are.
+func doltUpdateRefs(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltUpdateRefs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func doDoltUpdateRefs(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	apr, err := cli.CreateUpdateRefsArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+	if apr.NArg() == 0 || apr.NArg()%2 != 0 {
+		return 1, fmt.Errorf("error: invalid usage; dolt_update_refs takes pairs of a ref and a commit")
+	}
+	force := apr.Contains(cli.ForceFlag)
+
+	headRef, err := dbData.Rsr.CWBHeadRef()
+	if err != nil {
+		return 1, err
+	}
+
+	updates := make([]doltdb.RefUpdate, 0, apr.NArg()/2)
+	var created []string
+	for i := 0; i < apr.NArg(); i += 2 {
+		refStr, commitStr := apr.Arg(i), apr.Arg(i+1)
+		if len(refStr) == 0 {
+			return 1, EmptyBranchNameErr
+		}
+
+		dref, err := ref.Parse(refStr)
+		if err != nil {
+			return 1, fmt.Errorf("error: invalid ref '%s': %w", refStr, err)
+		}
+		if br, ok := dref.(ref.BranchRef); ok {
+			if !doltdb.IsValidUserBranchName(br.GetPath()) {
+				return 1, fmt.Errorf("fatal: '%s' is an invalid branch name.", br.GetPath())
+			}
+			exists, err := dbData.Ddb.HasRef(ctx, br)
+			if err != nil {
+				return 1, err
+			}
+			if exists {
+				err = branch_control.CanDeleteBranch(ctx, br.GetPath())
+			} else {
+				err = branch_control.CanCreateBranch(ctx, br.GetPath())
+				created = append(created, br.GetPath())
+			}
+			if err != nil {
+				return 1, err
+			}
+		}
+
+		cs, err := doltdb.NewCommitSpec(commitStr)
+		if err != nil {
+			return 1, err
+		}
+		optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return 1, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return 1, doltdb.ErrGhostCommitEncountered
+		}
+
+		updates = append(updates, doltdb.RefUpdate{Ref: dref, Commit: cm, Force: force})
+	}
+
+	var rsc doltdb.ReplicationStatusController
+	err = dbData.Ddb.UpdateRefs(ctx, updates, &rsc)
+	if err != nil {
+		return 1, err
+	}
+	for _, branchName := range created {
+		if err = branch_control.AddAdminForContext(ctx, branchName); err != nil {
+			return 1, err
+		}
+	}
+
+	return 0, commitTransaction(ctx, dSess, &rsc)
+}
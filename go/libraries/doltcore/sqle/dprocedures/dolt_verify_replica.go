@@ -0,0 +1,229 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	divergenceMissingOnReplica      = "branch missing on replica"
+	divergenceMissingOnSource       = "branch missing on source"
+	divergenceHeadDiffers           = "head differs"
+	divergenceTableMissingOnReplica = "table missing on replica"
+	divergenceTableMissingOnSource  = "table missing on source"
+	divergenceSchemaDiffers         = "schema differs"
+	divergenceChecksumDiffers       = "checksum differs"
+)
+
+var doltVerifyReplicaSchema = []*sql.Column{
+	{Name: "branch", Type: types.LongText, Nullable: false},
+	{Name: "table_name", Type: types.LongText, Nullable: true},
+	{Name: "divergence", Type: types.LongText, Nullable: false},
+	{Name: "replica", Type: types.LongText, Nullable: true},
+	{Name: "source", Type: types.LongText, Nullable: true},
+}
+
+// doltVerifyReplica compares the branches of the current database with those of its source remote and returns
+// a row for each divergence, so an empty result means the replica is in sync. The remote is the one named in
+// the arguments, or else the read replica remote, or else origin. For every branch whose head commits differ,
+// each table is compared by its schema and by its order-independent row checksum, see doltdb.TableChecksum.
+func doltVerifyReplica(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("empty database name")
+	}
+	if len(args) > 1 {
+		return nil, fmt.Errorf("error: dolt_verify_replica takes at most one argument, the name of the source remote")
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(ctx, dbName)
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	if len(args) == 0 {
+		if _, remoteName, ok := sql.SystemVariables.GetGlobal(dsess.ReadReplicaRemote); ok && remoteName != "" {
+			args = []string{remoteName.(string)}
+		}
+	}
+	remote, _, err := env.RemoteForFetchArgs(args, dbData.Rsr)
+	if err != nil {
+		return nil, err
+	}
+	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := verifyReplicaBranches(ctx, dbData.Ddb, srcDB)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func verifyReplicaBranches(ctx *sql.Context, replica, source *doltdb.DoltDB) ([]sql.Row, error) {
+	replicaHeads, err := branchHeads(ctx, replica)
+	if err != nil {
+		return nil, err
+	}
+	sourceHeads, err := branchHeads(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]string, 0, len(sourceHeads))
+	for b := range sourceHeads {
+		branches = append(branches, b)
+	}
+	for b := range replicaHeads {
+		if _, ok := sourceHeads[b]; !ok {
+			branches = append(branches, b)
+		}
+	}
+	sort.Strings(branches)
+
+	var rows []sql.Row
+	for _, b := range branches {
+		replicaHead, onReplica := replicaHeads[b]
+		sourceHead, onSource := sourceHeads[b]
+		switch {
+		case !onReplica:
+			rows = append(rows, sql.Row{b, nil, divergenceMissingOnReplica, nil, sourceHead.String()})
+		case !onSource:
+			rows = append(rows, sql.Row{b, nil, divergenceMissingOnSource, replicaHead.String(), nil})
+		case replicaHead != sourceHead:
+			rows = append(rows, sql.Row{b, nil, divergenceHeadDiffers, replicaHead.String(), sourceHead.String()})
+			tableRows, err := verifyReplicaTables(ctx, b, replica, replicaHead, source, sourceHead)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, tableRows...)
+		}
+	}
+	return rows, nil
+}
+
+func branchHeads(ctx *sql.Context, ddb *doltdb.DoltDB) (map[string]hash.Hash, error) {
+	refs, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(map[string]hash.Hash, len(refs))
+	for _, r := range refs {
+		heads[r.Ref.GetPath()] = r.Hash
+	}
+	return heads, nil
+}
+
+// verifyReplicaTables compares the tables of the commits |replicaHead| and |sourceHead| of the branch |branch|.
+func verifyReplicaTables(ctx *sql.Context, branch string, replica *doltdb.DoltDB, replicaHead hash.Hash, source *doltdb.DoltDB, sourceHead hash.Hash) ([]sql.Row, error) {
+	replicaRoot, err := commitRoot(ctx, replica, replicaHead)
+	if err != nil {
+		return nil, err
+	}
+	sourceRoot, err := commitRoot(ctx, source, sourceHead)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := doltdb.UnionTableNames(ctx, replicaRoot, sourceRoot)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].Less(names[j])
+	})
+
+	var rows []sql.Row
+	for _, name := range names {
+		replicaTbl, onReplica, err := replicaRoot.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		sourceTbl, onSource, err := sourceRoot.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if !onReplica {
+			rows = append(rows, sql.Row{branch, name.String(), divergenceTableMissingOnReplica, nil, nil})
+			continue
+		} else if !onSource {
+			rows = append(rows, sql.Row{branch, name.String(), divergenceTableMissingOnSource, nil, nil})
+			continue
+		}
+
+		replicaHash, err := replicaTbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		sourceHash, err := sourceTbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if replicaHash == sourceHash {
+			continue
+		}
+
+		replicaSch, err := replicaTbl.GetSchemaHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sourceSch, err := sourceTbl.GetSchemaHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if replicaSch != sourceSch {
+			rows = append(rows, sql.Row{branch, name.String(), divergenceSchemaDiffers, replicaSch.String(), sourceSch.String()})
+			continue
+		}
+
+		replicaSum, err := replica.TableChecksum(ctx, branch, name, replicaTbl)
+		if err != nil {
+			return nil, err
+		}
+		sourceSum, err := source.TableChecksum(ctx, branch, name, sourceTbl)
+		if err != nil {
+			return nil, err
+		}
+		if replicaSum != sourceSum {
+			rows = append(rows, sql.Row{branch, name.String(), divergenceChecksumDiffers, replicaSum.String(), sourceSum.String()})
+		}
+	}
+	return rows, nil
+}
+
+func commitRoot(ctx *sql.Context, ddb *doltdb.DoltDB, h hash.Hash) (doltdb.RootValue, error) {
+	optCmt, err := ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm.GetRootValue(ctx)
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// zoneMapAlterableTable is a table that can keep zone maps for some of its columns.
+type zoneMapAlterableTable interface {
+	sql.Table
+	SetZoneMapColumns(ctx *sql.Context, columns []string) error
+}
+
+// doltZoneMaps sets the columns of a table in the current database whose min and max values are kept in the nodes of
+// its primary index, so that range scans filtering on them can skip nodes. It takes the table followed by up to four
+// columns with fixed-width numeric or temporal types, and no columns removes the table's zone maps.
+func doltZoneMaps(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("dolt_zone_maps takes the table followed by the zone map columns")
+	}
+	tableName := args[0]
+
+	table, err := tableToAlter(ctx, "dolt_zone_maps", "", tableName)
+	if err != nil {
+		return nil, err
+	}
+	alterable, ok := table.(zoneMapAlterableTable)
+	if !ok {
+		return nil, fmt.Errorf("the zone maps of table %s can't be changed", tableName)
+	}
+	if err := alterable.SetZoneMapColumns(ctx, args[1:]); err != nil {
+		return nil, err
+	}
+	return rowToIter(types.NewOkResult(0)), nil
+}
+
+// tableToAlter returns the table |tableName| of the database |dbName|, which is the current database if it's empty, for
+// the procedure |procName| to change. It returns an error if the user of |ctx| can't alter the table.
+func tableToAlter(ctx *sql.Context, procName, dbName, tableName string) (sql.Table, error) {
+	if dbName == "" {
+		dbName = ctx.GetCurrentDatabase()
+	}
+	if dbName == "" {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+	if err := checkAlterPrivilege(ctx, procName, dbName, tableName); err != nil {
+		return nil, err
+	}
+
+	db, err := dsess.DSessFromSess(ctx.Session).Provider().Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	table, ok, err := db.GetTableInsensitive(ctx, tableName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(tableName)
+	}
+	return table, nil
+}
+
+// checkAlterPrivilege returns an error if the user of |ctx| doesn't have the ALTER privilege on |tableName|, which the
+// procedure |procName| changes.
+func checkAlterPrivilege(ctx *sql.Context, procName, dbName, tableName string) error {
+	privs, counter := ctx.GetPrivilegeSet()
+	if counter == 0 {
+		return fmt.Errorf("unable to check user privileges for %s procedure", procName)
+	}
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	dbPrivs := privs.Database(baseName)
+	if !privs.Has(sql.PrivilegeType_Alter) && !dbPrivs.Has(sql.PrivilegeType_Alter) && !dbPrivs.Table(tableName).Has(sql.PrivilegeType_Alter) {
+		return sql.ErrPrivilegeCheckFailed.New(ctx.Session.Client().User)
+	}
+	return nil
+}
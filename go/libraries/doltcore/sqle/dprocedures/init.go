@@ -21,17 +21,22 @@ import (
 
 var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_add", Schema: int64Schema("status"), Function: doltAdd},
+	{Name: "dolt_attach", Schema: int64Schema("status"), Function: doltAttach, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_backup", Schema: int64Schema("status"), Function: doltBackup, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_branch", Schema: int64Schema("status"), Function: doltBranch},
+	{Name: "dolt_capture_profile", Schema: stringSchema("path"), Function: doltCaptureProfile, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_checkout", Schema: doltCheckoutSchema, Function: doltCheckout, ReadOnly: true},
 	{Name: "dolt_cherry_pick", Schema: cherryPickSchema, Function: doltCherryPick},
 	{Name: "dolt_clean", Schema: int64Schema("status"), Function: doltClean},
 	{Name: "dolt_clone", Schema: int64Schema("status"), Function: doltClone, AdminOnly: true},
+	{Name: "dolt_clone_async", Schema: int64Schema("job_id"), Function: doltCloneAsync, AdminOnly: true},
 	{Name: "dolt_commit", Schema: stringSchema("hash"), Function: doltCommit},
 	{Name: "dolt_commit_hash_out", Schema: stringSchema("hash"), Function: doltCommitHashOut},
 	{Name: "dolt_conflicts_resolve", Schema: int64Schema("status"), Function: doltConflictsResolve},
 	{Name: "dolt_count_commits", Schema: int64Schema("ahead", "behind"), Function: doltCountCommits, ReadOnly: true},
+	{Name: "dolt_detach", Schema: int64Schema("status"), Function: doltDetach, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_fetch", Schema: int64Schema("status"), Function: doltFetch, AdminOnly: true},
+	{Name: "dolt_job_cancel", Schema: int64Schema("status"), Function: doltJobCancel, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_undrop", Schema: int64Schema("status"), Function: doltUndrop, AdminOnly: true},
 	{Name: "dolt_purge_dropped_databases", Schema: int64Schema("status"), Function: doltPurgeDroppedDatabases, AdminOnly: true},
 	{Name: "dolt_rebase", Schema: doltRebaseProcedureSchema, Function: doltRebase},
@@ -43,10 +48,19 @@ var DoltProcedures = []sql.ExternalStoredProcedureDetails{
 	{Name: "dolt_pull", Schema: doltPullSchema, Function: doltPull, AdminOnly: true},
 	{Name: "dolt_push", Schema: doltPushSchema, Function: doltPush, AdminOnly: true},
 	{Name: "dolt_remote", Schema: int64Schema("status"), Function: doltRemote, AdminOnly: true},
+	{Name: "dolt_reload_config", Schema: int64Schema("status"), Function: doltReloadConfig, ReadOnly: true, AdminOnly: true},
 	{Name: "dolt_reset", Schema: int64Schema("status"), Function: doltReset},
 	{Name: "dolt_revert", Schema: int64Schema("status"), Function: doltRevert},
+	{Name: "dolt_snapshot_begin", Schema: doltSnapshotBeginSchema, Function: doltSnapshotBegin, ReadOnly: true, AdminOnly: true},
+	{Name: "dolt_snapshot_end", Schema: int64Schema("status"), Function: doltSnapshotEnd, ReadOnly: true, AdminOnly: true},
+	{Name: "dolt_subscribe", Schema: int64Schema("status"), Function: doltSubscribe, ReadOnly: true},
 	{Name: "dolt_tag", Schema: int64Schema("status"), Function: doltTag},
 	{Name: "dolt_verify_constraints", Schema: int64Schema("violations"), Function: doltVerifyConstraints},
+	{Name: "dolt_update_refs", Schema: int64Schema("status"), Function: doltUpdateRefs},
+	{Name: "dolt_unsubscribe", Schema: int64Schema("status"), Function: doltUnsubscribe, ReadOnly: true},
+	{Name: "dolt_verify_replica", Schema: doltVerifyReplicaSchema, Function: doltVerifyReplica, ReadOnly: true, AdminOnly: true},
+	{Name: "dolt_wait_for_changes", Schema: doltWaitForChangesSchema, Function: doltWaitForChanges, ReadOnly: true},
+	{Name: "dolt_zone_maps", Schema: types.OkResultSchema, Function: doltZoneMaps},
 
 	{Name: "dolt_stats_drop", Schema: statsFuncSchema, Function: statsFunc(statsDrop)},
 	{Name: "dolt_stats_restart", Schema: statsFuncSchema, Function: statsFunc(statsRestart)},
@@ -0,0 +1,37 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// checkAppendOnlyWorkingRoot returns an error if replacing the working set |existing| with |ws| updates or deletes the
+// rows of tables that dolt_append_only_tables makes append-only on its branch. Every change to a session's working
+// root goes through SetWorkingSet, so this covers the statements and procedures which replace tables or roots
+// wholesale, such as DROP TABLE, ALTER TABLE, dolt_reset, dolt_revert and dolt_cherry_pick, as well as writes. Writes
+// to append-only tables are rejected sooner, by their table writers.
+func checkAppendOnlyWorkingRoot(ctx *sql.Context, existing, ws *doltdb.WorkingSet) error {
+	if existing == nil || rootsEqual(existing.WorkingRoot(), ws.WorkingRoot()) {
+		return nil
+	}
+	branch, err := ws.Ref().ToHeadRef()
+	if err != nil {
+		return err
+	}
+	return doltdb.CheckAppendOnlyTables(ctx, branch.GetPath(), existing.WorkingRoot(), ws.WorkingRoot())
+}
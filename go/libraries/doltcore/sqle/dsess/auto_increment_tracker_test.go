@@ -16,9 +16,16 @@ package dsess
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 func TestCoerceAutoIncrementValue(t *testing.T) {
@@ -70,3 +77,60 @@ func TestCoerceAutoIncrementValue(t *testing.T) {
 		})
 	}
 }
+
+func TestReserveAutoIncrementValues(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ait, err := NewAutoIncrementTracker(ctx, "db")
+	require.NoError(t, err)
+	ait.AddNewTable("t")
+
+	first, err := ait.Reserve(ctx, "T", 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first)
+	assert.Equal(t, uint64(11), ait.Current("t"))
+
+	next, err := ait.Next(ctx, "t", nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), next)
+
+	_, err = ait.Reserve(ctx, "t", 0)
+	assert.Error(t, err)
+	_, err = ait.Reserve(ctx, "t", math.MaxUint64)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(12), ait.Current("t"))
+}
+
+func TestPersistedAutoIncrementValues(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+
+	ait, err := NewPersistedAutoIncrementTracker(ctx, "db", ddb)
+	require.NoError(t, err)
+	ait.AddNewTable("t")
+
+	// handing out the first value reserves a batch of values past it
+	for i := uint64(1); i <= 3; i++ {
+		v, err := ait.Next(ctx, "t", nil)
+		require.NoError(t, err)
+		require.Equal(t, i, v)
+	}
+	persisted, err := loadPersistedSequences(ctx, ddb)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"t": 2 + autoIncrementReserveBatch}, persisted)
+
+	// the next batch is reserved once the values handed out pass the last one
+	first, err := ait.Reserve(ctx, "t", autoIncrementReserveBatch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), first)
+	persisted, err = loadPersistedSequences(ctx, ddb)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"t": 4 + 2*autoIncrementReserveBatch}, persisted)
+
+	// releasing the tracker gives back the values reserved but not handed out
+	require.NoError(t, ait.Release(ctx))
+	persisted, err = loadPersistedSequences(ctx, ddb)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"t": 4 + autoIncrementReserveBatch}, persisted)
+}
@@ -16,6 +16,8 @@ package dsess
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"math"
 	"strings"
@@ -42,11 +44,29 @@ var (
 	LockMode_Interleaved LockMode = 2
 )
 
+// autoIncrementTupleKey is the key of the tuple in which the reserved sequences of a database are persisted
+const autoIncrementTupleKey = "auto_increment"
+
+// autoIncrementReserveBatch is the number of values reserved past those handed out each time the reserved sequence of
+// a table is persisted. See AutoIncrementTracker.
+const autoIncrementReserveBatch = 1024
+
+// AutoIncrementTracker tracks the auto increment sequences of the tables of a database across its branches. When it's
+// persisted, values are handed out from batches reserved in the database before any of them are handed out, so that
+// values generated by transactions which never commit aren't generated again after a restart, while the database is
+// only written once per batch. Release gives back the values reserved but not handed out when the database is closed,
+// and a database which isn't closed cleanly skips them, at most one batch per table.
 type AutoIncrementTracker struct {
 	dbName    string
 	sequences *sync.Map // map[string]uint64
 	mm        *mutexmap.MutexMap
 	lockMode  LockMode
+
+	// ddb is the database the reserved sequences are persisted in, or nil if they aren't. See reserve.
+	ddb *doltdb.DoltDB
+	// reserved is the persisted sequence of each table, below which values may be handed out without persisting it
+	reserved  *sync.Map // map[string]uint64
+	persistMu sync.Mutex
 }
 
 var _ globalstate.AutoIncrementTracker = &AutoIncrementTracker{}
@@ -60,11 +80,134 @@ func NewAutoIncrementTracker(ctx context.Context, dbName string, roots ...doltdb
 		dbName:    dbName,
 		sequences: &sync.Map{},
 		mm:        mutexmap.NewMutexMap(),
+		reserved:  &sync.Map{},
 	}
 	ait.InitWithRoots(ctx, roots...)
 	return &ait, nil
 }
 
+// NewPersistedAutoIncrementTracker returns a new autoincrement tracker for the roots given, as NewAutoIncrementTracker,
+// whose sequences are reserved in |ddb|. Sequences previously reserved are loaded, so that values generated before a
+// restart by transactions which never committed aren't generated again.
+func NewPersistedAutoIncrementTracker(ctx context.Context, dbName string, ddb *doltdb.DoltDB, roots ...doltdb.Rootish) (*AutoIncrementTracker, error) {
+	ait, err := NewAutoIncrementTracker(ctx, dbName, roots...)
+	if err != nil {
+		return nil, err
+	}
+	ait.ddb = ddb
+
+	persisted, err := loadPersistedSequences(ctx, ddb)
+	if err != nil {
+		return nil, err
+	}
+	for tableName, seq := range persisted {
+		// tables which no longer exist on any branch start over, as when they're dropped
+		if current, ok := ait.sequences.Load(tableName); ok {
+			if seq > current.(uint64) {
+				ait.sequences.Store(tableName, seq)
+			}
+			ait.reserved.Store(tableName, seq)
+		}
+	}
+	return ait, nil
+}
+
+// loadPersistedSequences returns the sequences persisted in |ddb|, if any.
+func loadPersistedSequences(ctx context.Context, ddb *doltdb.DoltDB) (map[string]uint64, error) {
+	val, ok, err := ddb.GetTuple(ctx, autoIncrementTupleKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var sequences map[string]uint64
+	if err := json.Unmarshal(val, &sequences); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
+
+// advance sets the sequence of the table named to |seq|, once the values below it are reserved. Values are reserved
+// in batches, so the database is only written when |seq| passes the sequence last reserved for the table.
+func (a *AutoIncrementTracker) advance(ctx context.Context, tableName string, seq uint64) error {
+	if a.ddb != nil && seq > loadAutoIncValue(a.reserved, tableName) {
+		reserved := seq + autoIncrementReserveBatch
+		if seq > math.MaxUint64-autoIncrementReserveBatch {
+			reserved = math.MaxUint64
+		}
+		if err := a.reserve(ctx, tableName, reserved, false); err != nil {
+			return err
+		}
+	}
+	a.sequences.Store(tableName, seq)
+	return nil
+}
+
+// reserve persists |seq| as the reserved sequence of the table named, if it's greater than the one reserved already,
+// or if |lower| is set, which it is when the sequence has been set back and values below the reserved sequence may be
+// handed out again.
+func (a *AutoIncrementTracker) reserve(ctx context.Context, tableName string, seq uint64, lower bool) error {
+	if a.ddb == nil {
+		return nil
+	}
+
+	a.persistMu.Lock()
+	defer a.persistMu.Unlock()
+
+	if !lower && seq <= loadAutoIncValue(a.reserved, tableName) {
+		// another writer reserved these values first
+		return nil
+	}
+
+	reserved := make(map[string]uint64)
+	a.reserved.Range(func(key, value any) bool {
+		reserved[key.(string)] = value.(uint64)
+		return true
+	})
+	reserved[tableName] = seq
+	if err := a.persist(ctx, reserved); err != nil {
+		return err
+	}
+	a.reserved.Store(tableName, seq)
+	return nil
+}
+
+func (a *AutoIncrementTracker) persist(ctx context.Context, sequences map[string]uint64) error {
+	val, err := json.Marshal(sequences)
+	if err != nil {
+		return err
+	}
+	return a.ddb.SetTuple(ctx, autoIncrementTupleKey, val)
+}
+
+// Release persists the sequences of this tracker as they are, giving back the values reserved but not handed out, so
+// that they're generated after a restart. It's called when the database is closed.
+func (a *AutoIncrementTracker) Release(ctx context.Context) error {
+	if a.ddb == nil {
+		return nil
+	}
+
+	a.persistMu.Lock()
+	defer a.persistMu.Unlock()
+
+	sequences := make(map[string]uint64)
+	changed := false
+	a.reserved.Range(func(key, value any) bool {
+		seq := loadAutoIncValue(a.sequences, key.(string))
+		sequences[key.(string)] = seq
+		changed = changed || seq != value.(uint64)
+		return true
+	})
+	if !changed {
+		return nil
+	}
+	if err := a.persist(ctx, sequences); err != nil {
+		return err
+	}
+	for tableName, seq := range sequences {
+		a.reserved.Store(tableName, seq)
+	}
+	return nil
+}
+
 func loadAutoIncValue(sequences *sync.Map, tableName string) uint64 {
 	tableName = strings.ToLower(tableName)
 	current, hasCurrent := sequences.Load(tableName)
@@ -81,7 +224,7 @@ func (a *AutoIncrementTracker) Current(tableName string) uint64 {
 
 // Next returns the next auto increment value for the table named using the provided value from an insert (which may
 // be null or 0, in which case it will be generated from the sequence).
-func (a *AutoIncrementTracker) Next(tbl string, insertVal interface{}) (uint64, error) {
+func (a *AutoIncrementTracker) Next(ctx *sql.Context, tbl string, insertVal interface{}) (uint64, error) {
 	tbl = strings.ToLower(tbl)
 
 	given, err := CoerceAutoIncrementValue(insertVal)
@@ -98,19 +241,37 @@ func (a *AutoIncrementTracker) Next(tbl string, insertVal interface{}) (uint64,
 
 	if given == 0 {
 		// |given| is 0 or NULL
-		a.sequences.Store(tbl, curr+1)
-		return curr, nil
+		return curr, a.advance(ctx, tbl, curr+1)
 	}
 
 	if given >= curr {
-		a.sequences.Store(tbl, given+1)
-		return given, nil
+		return given, a.advance(ctx, tbl, given+1)
 	}
 
 	// |given| < curr
 	return given, nil
 }
 
+// Reserve reserves the next |count| values of the auto increment sequence for the table named, returning the first of
+// them, so that writers inserting many rows take the lock on the sequence once per batch rather than once per row.
+func (a *AutoIncrementTracker) Reserve(ctx *sql.Context, tbl string, count uint64) (uint64, error) {
+	tbl = strings.ToLower(tbl)
+	if count == 0 {
+		return 0, fmt.Errorf("cannot reserve 0 auto increment values")
+	}
+
+	if a.lockMode == LockMode_Interleaved {
+		release := a.mm.Lock(tbl)
+		defer release()
+	}
+
+	curr := loadAutoIncValue(a.sequences, tbl)
+	if curr > math.MaxUint64-count {
+		return 0, fmt.Errorf("cannot reserve %d auto increment values for table %s: out of range", count, tbl)
+	}
+	return curr, a.advance(ctx, tbl, curr+count)
+}
+
 func (a *AutoIncrementTracker) CoerceAutoIncrementValue(val interface{}) (uint64, error) {
 	return CoerceAutoIncrementValue(val)
 }
@@ -298,6 +459,11 @@ func (a *AutoIncrementTracker) deepSet(ctx *sql.Context, tableName string, table
 	}
 
 	a.sequences.Store(tableName, maxAutoInc)
+
+	// the sequence may have been lowered below the one reserved, which would otherwise be loaded after a restart
+	if err := a.lowerReserved(ctx, tableName, maxAutoInc); err != nil {
+		return nil, err
+	}
 	return table, nil
 }
 
@@ -384,7 +550,16 @@ func (a *AutoIncrementTracker) DropTable(ctx *sql.Context, tableName string, wse
 
 	a.sequences.Store(tableName, newHighestValue)
 
-	return nil
+	// the sequence may now be lower than the one reserved, which would otherwise be loaded after a restart
+	return a.lowerReserved(ctx, tableName, newHighestValue)
+}
+
+// lowerReserved sets the reserved sequence of the table named back to |seq|, if it's reserved past it.
+func (a *AutoIncrementTracker) lowerReserved(ctx context.Context, tableName string, seq uint64) error {
+	if reserved, ok := a.reserved.Load(tableName); !ok || reserved.(uint64) <= seq {
+		return nil
+	}
+	return a.reserve(ctx, tableName, seq, true)
 }
 
 func (a *AutoIncrementTracker) AcquireTableLock(ctx *sql.Context, tableName string) (func(), error) {
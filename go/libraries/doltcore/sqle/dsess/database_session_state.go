@@ -16,6 +16,7 @@ package dsess
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -124,6 +125,8 @@ type branchState struct {
 	readOnly bool
 	// dirty is true if this branch state has uncommitted changes
 	dirty bool
+	// rowLocks is the state of the row locks this session's transaction takes on this branch, see DoltSession.LockRow
+	rowLocks branchRowLocks
 }
 
 // NewEmptyBranchState creates a new branch state for the given head name with the head provided, adds it to the db
@@ -133,6 +136,7 @@ func (dbState *DatabaseSessionState) NewEmptyBranchState(head string, revisionTy
 		dbState:      dbState,
 		head:         head,
 		revisionType: revisionType,
+		rowLocks:     branchRowLocks{mu: &sync.Mutex{}},
 	}
 
 	lowerHead := strings.ToLower(head)
@@ -267,6 +267,14 @@ func (e emptyRevisionDatabaseProvider) PurgeDroppedDatabases(ctx *sql.Context) e
 	return nil
 }
 
+func (e emptyRevisionDatabaseProvider) AttachDatabase(ctx *sql.Context, dbName, source string) error {
+	return nil
+}
+
+func (e emptyRevisionDatabaseProvider) DetachDatabase(ctx *sql.Context, dbName string) error {
+	return nil
+}
+
 func (e emptyRevisionDatabaseProvider) BaseDatabase(ctx *sql.Context, dbName string) (SqlDatabase, bool) {
 	return nil, false
 }
@@ -71,20 +71,28 @@ func NewGlobalStateStoreForDb(ctx context.Context, dbName string, db *doltdb.Dol
 		}
 	}
 
-	tracker, err := NewAutoIncrementTracker(ctx, dbName, roots...)
+	tracker, err := NewPersistedAutoIncrementTracker(ctx, dbName, db, roots...)
+	if err != nil {
+		return GlobalStateImpl{}, err
+	}
+	seqTracker, err := NewSequenceTracker(ctx, db)
 	if err != nil {
 		return GlobalStateImpl{}, err
 	}
 
 	return GlobalStateImpl{
-		aiTracker: tracker,
-		mu:        &sync.Mutex{},
+		aiTracker:  tracker,
+		seqTracker: seqTracker,
+		rowLocks:   globalstate.NewRowLocks(),
+		mu:         &sync.Mutex{},
 	}, nil
 }
 
 type GlobalStateImpl struct {
-	aiTracker globalstate.AutoIncrementTracker
-	mu        *sync.Mutex
+	aiTracker  globalstate.AutoIncrementTracker
+	seqTracker globalstate.SequenceTracker
+	rowLocks   *globalstate.RowLocks
+	mu         *sync.Mutex
 }
 
 var _ globalstate.GlobalState = GlobalStateImpl{}
@@ -92,3 +100,11 @@ var _ globalstate.GlobalState = GlobalStateImpl{}
 func (g GlobalStateImpl) AutoIncrementTracker(ctx *sql.Context) (globalstate.AutoIncrementTracker, error) {
 	return g.aiTracker, nil
 }
+
+func (g GlobalStateImpl) SequenceTracker() globalstate.SequenceTracker {
+	return g.seqTracker
+}
+
+func (g GlobalStateImpl) RowLocks() *globalstate.RowLocks {
+	return g.rowLocks
+}
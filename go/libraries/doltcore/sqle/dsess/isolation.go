@@ -0,0 +1,141 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// IsolationLevel is the isolation level of a transaction, as set with SET TRANSACTION ISOLATION LEVEL or the
+// @@transaction_isolation system variable.
+//
+// Every transaction reads from a view of each database pinned to the root hash of the database when the transaction
+// began, so READ UNCOMMITTED and READ COMMITTED transactions run as REPEATABLE READ ones, and setting a session's
+// isolation level to one of them warns that it does. Concurrent changes to the same branch are merged when a REPEATABLE READ transaction commits. A SERIALIZABLE
+// transaction fails to commit instead if any branch it accessed was changed after it began.
+type IsolationLevel string
+
+const (
+	ReadUncommitted IsolationLevel = "READ-UNCOMMITTED"
+	ReadCommitted   IsolationLevel = "READ-COMMITTED"
+	RepeatableRead  IsolationLevel = "REPEATABLE-READ"
+	Serializable    IsolationLevel = "SERIALIZABLE"
+)
+
+const transactionIsolationSysVar = "transaction_isolation"
+
+var ErrSerializationFailure = errors.New("this serializable transaction accessed data changed by another transaction that committed after it began")
+
+// isolationWarningCode is the code of the warning for isolation levels which run as REPEATABLE READ.
+const isolationWarningCode = 1105
+
+// sessionIsolationLevel returns the isolation level for transactions started by the session of |ctx|.
+func sessionIsolationLevel(ctx *sql.Context) IsolationLevel {
+	val, err := ctx.GetSessionVariable(ctx, transactionIsolationSysVar)
+	if err != nil {
+		return RepeatableRead
+	}
+	s, ok := val.(string)
+	if !ok {
+		return RepeatableRead
+	}
+	switch level := IsolationLevel(strings.ToUpper(s)); level {
+	case ReadUncommitted, ReadCommitted, RepeatableRead, Serializable:
+		return level
+	default:
+		return RepeatableRead
+	}
+}
+
+// warnIsolationLevel warns that transactions at |level| run as REPEATABLE READ ones, if they do. It's called when the
+// isolation level of a session is set, rather than for each of its transactions.
+func warnIsolationLevel(ctx *sql.Context, level IsolationLevel) {
+	if level == ReadUncommitted || level == ReadCommitted {
+		ctx.Warn(isolationWarningCode, "transaction isolation level %s runs as %s", level, RepeatableRead)
+	}
+}
+
+// accessedBranches are the branches a SERIALIZABLE transaction accessed, which are validated when it commits.
+type accessedBranches struct {
+	mu    sync.Mutex
+	heads map[*branchState]struct{}
+}
+
+func newAccessedBranches() *accessedBranches {
+	return &accessedBranches{heads: make(map[*branchState]struct{})}
+}
+
+// recordAccess records that |tx| accessed the branch of |bs|, if it's a SERIALIZABLE transaction.
+func (tx *DoltTransaction) recordAccess(bs *branchState) {
+	if tx.isolation != Serializable {
+		return
+	}
+	tx.accessed.mu.Lock()
+	defer tx.accessed.mu.Unlock()
+	tx.accessed.heads[bs] = struct{}{}
+}
+
+// validateSerializable returns an error if the working set of any branch accessed during the transaction has changed
+// since the transaction began. Must be called with |txLock| held, so that no other transaction commits until this one
+// does.
+func (tx *DoltTransaction) validateSerializable(ctx *sql.Context, sess *DoltSession) error {
+	var accessed []*branchState
+	sess.mu.Lock()
+	tx.accessed.mu.Lock()
+	for bs := range tx.accessed.heads {
+		if bs.workingSet != nil {
+			accessed = append(accessed, bs)
+		}
+	}
+	tx.accessed.mu.Unlock()
+	sess.mu.Unlock()
+
+	for _, bs := range accessed {
+		startPoint, ok := tx.dbStartPoints[strings.ToLower(bs.dbState.dbName)]
+		if !ok {
+			continue
+		}
+		wsRef := bs.workingSet.Ref()
+		startHash, err := workingSetHash(startPoint.db.ResolveWorkingSetAtRoot(ctx, wsRef, startPoint.rootHash))
+		if err != nil {
+			return err
+		}
+		currHash, err := workingSetHash(startPoint.db.ResolveWorkingSet(ctx, wsRef))
+		if err != nil {
+			return err
+		}
+		if startHash != currHash {
+			return sql.ErrLockDeadlock.New(ErrSerializationFailure.Error())
+		}
+	}
+	return nil
+}
+
+// workingSetHash returns the hash of |ws|, or the empty hash if it doesn't exist.
+func workingSetHash(ws *doltdb.WorkingSet, err error) (hash.Hash, error) {
+	if err == doltdb.ErrWorkingSetNotFound {
+		return hash.Hash{}, nil
+	} else if err != nil {
+		return hash.Hash{}, err
+	}
+	return ws.HashOf()
+}
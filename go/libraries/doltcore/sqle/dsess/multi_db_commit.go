@@ -0,0 +1,302 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrInconsistentDatabases is returned when writing the working set of one of the databases of a transaction which
+// wrote to more than one fails, and the working sets of the databases already written can't be restored. Those
+// databases keep the transaction's changes and the others don't, so they're inconsistent with each other until the
+// changes are repaired by hand.
+var ErrInconsistentDatabases = goerrors.NewKind("transaction partially committed, databases are now inconsistent: " +
+	"failed to commit database %s: %s; %s")
+
+// multiDbCommitFault is the error of the last write of every commit of more than one database while it's set, see
+// FailMultiDbCommitsForTesting.
+var multiDbCommitFault atomic.Pointer[error]
+
+// FailMultiDbCommitsForTesting makes the working set of the last database written by every commit of more than one
+// database fail to be written with |err|, after the others are written, until the returned function is called. It
+// lets tests check that the working sets already written are restored.
+func FailMultiDbCommitsForTesting(err error) (reset func()) {
+	multiDbCommitFault.Store(&err)
+	return func() {
+		multiDbCommitFault.Store(nil)
+	}
+}
+
+// txStartLocks keeps transactions from beginning while a transaction which wrote to more than one database writes
+// their working sets, which can't be written atomically, so that every transaction sees all of those writes or none
+// of them. Each database has its own lock, so that only transactions beginning with the databases being written wait
+// for them, and commits of different databases don't wait for each other. Transactions beginning take the locks of
+// their databases for reading, and commits of more than one database take the locks of those databases for writing.
+var txStartLocks = newDbLocks()
+
+// dbLocks are read-write locks of databases, by lower case base name. Locks are always taken in the order of their
+// databases' names, so that transactions taking the locks of overlapping sets of databases can't deadlock.
+type dbLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.RWMutex
+}
+
+func newDbLocks() *dbLocks {
+	return &dbLocks{locks: make(map[string]*sync.RWMutex)}
+}
+
+// get returns the locks of |dbNames|, in the order they must be taken.
+func (l *dbLocks) get(dbNames []string) []*sync.RWMutex {
+	names := make([]string, 0, len(dbNames))
+	seen := make(map[string]struct{}, len(dbNames))
+	for _, name := range dbNames {
+		name = strings.ToLower(name)
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	locks := make([]*sync.RWMutex, len(names))
+	for i, name := range names {
+		lock, ok := l.locks[name]
+		if !ok {
+			lock = &sync.RWMutex{}
+			l.locks[name] = lock
+		}
+		locks[i] = lock
+	}
+	return locks
+}
+
+// rLock locks the databases |dbNames| for reading, and returns a function which unlocks them.
+func (l *dbLocks) rLock(dbNames []string) func() {
+	locks := l.get(dbNames)
+	for _, lock := range locks {
+		lock.RLock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].RUnlock()
+		}
+	}
+}
+
+// lock locks the databases |dbNames| for writing, and returns a function which unlocks them.
+func (l *dbLocks) lock(dbNames []string) func() {
+	locks := l.get(dbNames)
+	for _, lock := range locks {
+		lock.Lock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// dbCommit is the commit of one of the databases written by a transaction which wrote to more than one.
+type dbCommit struct {
+	dbName     string
+	startPoint dbRoot
+	startState *doltdb.WorkingSet
+	workingSet *doltdb.WorkingSet
+	mergeOpts  editor.Options
+
+	// the working set written, and the one it replaced, once written
+	written  *doltdb.WorkingSet
+	replaced *doltdb.WorkingSet
+}
+
+// writesOneBranchPerDatabase returns whether |branchStates| are each of a different database.
+func writesOneBranchPerDatabase(branchStates []*branchState) bool {
+	dbs := make(map[string]struct{}, len(branchStates))
+	for _, bs := range branchStates {
+		name := strings.ToLower(bs.dbState.dbName)
+		if _, ok := dbs[name]; ok {
+			return false
+		}
+		dbs[name] = struct{}{}
+	}
+	return true
+}
+
+// commitWorkingSets commits the working sets of |branchStates|, each of a different database, as one transaction.
+func (d *DoltSession) commitWorkingSets(ctx *sql.Context, branchStates []*branchState, tx sql.Transaction) error {
+	dtx, ok := tx.(*DoltTransaction)
+	if !ok {
+		return fmt.Errorf("expected a DoltTransaction")
+	}
+
+	workingSets := make([]*doltdb.WorkingSet, len(branchStates))
+	dbNames := make([]string, len(branchStates))
+	for i, bs := range branchStates {
+		workingSets[i], dbNames[i] = bs.WorkingSet(), bs.RevisionDbName()
+	}
+	if err := dtx.CommitWorkingSets(ctx, workingSets, dbNames); err != nil {
+		return err
+	}
+
+	// See commitBranchState
+	ctx.SetTransaction(nil)
+	d.ReleaseRowLocks()
+	return nil
+}
+
+// CommitWorkingSets commits |workingSets|, of the databases |dbNames|, which must be different databases, such that
+// either all of them are written or none are, as far as possible. Each is merged with the working set committed since
+// the transaction began as by Commit, and the commit fails without writing any of them if any can't be merged.
+//
+// The working sets are written one after another, with commits and the beginning of transactions with these databases
+// excluded, and those already written are restored if writing one fails. Transactions beginning after the commit see
+// all of them. The commit isn't atomic, though: if the working sets already written can't be restored, or the server
+// crashes while the working sets are written, those already written keep the transaction's changes and the others
+// don't. The former returns ErrInconsistentDatabases.
+func (tx *DoltTransaction) CommitWorkingSets(ctx *sql.Context, workingSets []*doltdb.WorkingSet, dbNames []string) error {
+	sess := DSessFromSess(ctx.Session)
+
+	commits := make([]*dbCommit, len(workingSets))
+	for i, ws := range workingSets {
+		startPoint, startState, mergeOpts, err := tx.startOfCommit(ctx, sess, ws, dbNames[i])
+		if err != nil {
+			return err
+		}
+		commits[i] = &dbCommit{
+			dbName:     dbNames[i],
+			startPoint: startPoint,
+			startState: startState,
+			workingSet: ws,
+			mergeOpts:  mergeOpts,
+		}
+	}
+
+	for i := 0; i < maxTxCommitRetries; i++ {
+		done, err := func() (bool, error) {
+			txLock.Lock()
+			defer txLock.Unlock()
+
+			if tx.isolation == Serializable {
+				if err := tx.validateSerializable(ctx, sess); err != nil {
+					if rollbackErr := tx.rollback(ctx); rollbackErr != nil {
+						return false, rollbackErr
+					}
+					return false, err
+				}
+			}
+
+			// prepare every working set before writing any, so that a conflict in one fails the commit of all
+			toWrite := make([]*doltdb.WorkingSet, len(commits))
+			prevHashes := make([]hash.Hash, len(commits))
+			for i, c := range commits {
+				var err error
+				toWrite[i], c.replaced, prevHashes[i], err = tx.prepareWrite(ctx, c.startPoint, c.startState, c.workingSet, c.mergeOpts)
+				if err != nil {
+					return false, err
+				}
+			}
+
+			dbNames := make([]string, len(commits))
+			for i, c := range commits {
+				dbNames[i] = c.startPoint.dbName
+			}
+			defer txStartLocks.lock(dbNames)()
+
+			for i, c := range commits {
+				var err error
+				if fault := multiDbCommitFault.Load(); fault != nil && i == len(commits)-1 {
+					err = *fault
+				} else {
+					_, _, err = txCommit(ctx, tx, c.startPoint.db, c.startState, nil, toWrite[i], prevHashes[i], c.mergeOpts)
+				}
+				if err != nil {
+					if restoreErr := tx.restoreWorkingSets(ctx, commits[:i]); restoreErr != nil {
+						return false, ErrInconsistentDatabases.New(c.dbName, err.Error(), restoreErr.Error())
+					}
+					if err == datas.ErrOptimisticLockFailed {
+						return false, nil
+					}
+					return false, err
+				}
+				c.written = toWrite[i]
+			}
+			return true, nil
+		}()
+
+		if err != nil {
+			return err
+		} else if done {
+			return nil
+		}
+	}
+
+	return datas.ErrOptimisticLockFailed
+}
+
+// restoreWorkingSets restores the working sets replaced by |commits|, which have been written, after writing the
+// working set of another database failed. Working sets which didn't exist before the commit are deleted. Returns an
+// error naming every database which couldn't be restored, whose working set is left as written by the commit. Must
+// be called with txLock held.
+func (tx *DoltTransaction) restoreWorkingSets(ctx *sql.Context, commits []*dbCommit) error {
+	var failed []string
+	for _, c := range commits {
+		if c.written == nil {
+			continue
+		}
+		if err := tx.restoreWorkingSet(ctx, c); err != nil {
+			logrus.Errorf("failed to restore the working set of %s after failing to commit another database: %s", c.dbName, err.Error())
+			failed = append(failed, fmt.Sprintf("%s: %s", c.dbName, err.Error()))
+			continue
+		}
+		c.written = nil
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to roll back the databases already committed, which keep this transaction's changes: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// restoreWorkingSet restores the working set replaced by |c|, or deletes the working set written by |c| if there
+// wasn't one before, see prepareWrite.
+func (tx *DoltTransaction) restoreWorkingSet(ctx *sql.Context, c *dbCommit) error {
+	db := c.startPoint.db
+	if c.replaced.WorkingRoot() == nil {
+		return db.DeleteWorkingSet(ctx, c.written.Ref())
+	}
+	current, err := db.ResolveWorkingSet(ctx, c.written.Ref())
+	if err != nil {
+		return err
+	}
+	currentHash, err := current.HashOf()
+	if err != nil {
+		return err
+	}
+	return db.UpdateWorkingSet(ctx, c.replaced.Ref(), c.replaced, currentHash, tx.WorkingSetMeta(ctx), nil)
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestRestoreWorkingSets(t *testing.T) {
+	ctx := sql.NewContext(context.Background(), sql.WithSession(DefaultSession(emptyDatabaseProvider(), nil)))
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	cm, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+	root, err := cm.GetRootValue(ctx)
+	require.NoError(t, err)
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	))
+	changed, err := doltdb.CreateEmptyTable(ctx, root, doltdb.TableName{Name: "t"}, sch)
+	require.NoError(t, err)
+
+	tx := &DoltTransaction{}
+	writeWorkingSet := func(ws *doltdb.WorkingSet, prev hash.Hash) {
+		require.NoError(t, ddb.UpdateWorkingSet(ctx, ws.Ref(), ws, prev, tx.WorkingSetMeta(ctx), nil))
+	}
+	workingSetHash := func(wsRef ref.WorkingSetRef) hash.Hash {
+		ws, err := ddb.ResolveWorkingSet(ctx, wsRef)
+		require.NoError(t, err)
+		h, err := ws.HashOf()
+		require.NoError(t, err)
+		return h
+	}
+
+	// a working set which existed before the commit is restored
+	existingRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef("existing"))
+	require.NoError(t, err)
+	replaced := doltdb.EmptyWorkingSet(existingRef).WithWorkingRoot(root).WithStagedRoot(root)
+	writeWorkingSet(replaced, hash.Hash{})
+	replacedHash := workingSetHash(existingRef)
+	written := replaced.WithWorkingRoot(changed)
+	writeWorkingSet(written, replacedHash)
+
+	// a working set which didn't exist before the commit is deleted
+	newRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef("new"))
+	require.NoError(t, err)
+	created := doltdb.EmptyWorkingSet(newRef).WithWorkingRoot(changed).WithStagedRoot(root)
+	writeWorkingSet(created, hash.Hash{})
+
+	commits := []*dbCommit{
+		{dbName: "existing", startPoint: dbRoot{db: ddb}, written: written, replaced: replaced},
+		{dbName: "new", startPoint: dbRoot{db: ddb}, written: created, replaced: doltdb.EmptyWorkingSet(newRef)},
+	}
+	require.NoError(t, tx.restoreWorkingSets(ctx, commits))
+
+	restored, err := ddb.ResolveWorkingSet(ctx, existingRef)
+	require.NoError(t, err)
+	restoredHash, err := restored.WorkingRoot().HashOf()
+	require.NoError(t, err)
+	rootHash, err := root.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, rootHash, restoredHash)
+
+	_, err = ddb.ResolveWorkingSet(ctx, newRef)
+	assert.ErrorIs(t, err, doltdb.ErrWorkingSetNotFound)
+	for _, c := range commits {
+		assert.Nil(t, c.written)
+	}
+
+	// a restore which fails is reported
+	writeWorkingSet(created, hash.Hash{})
+	missingRef, err := ref.WorkingSetRefForHead(ref.NewBranchRef("missing"))
+	require.NoError(t, err)
+	commits = []*dbCommit{
+		{dbName: "new", startPoint: dbRoot{db: ddb}, written: created, replaced: doltdb.EmptyWorkingSet(newRef)},
+		{dbName: "missing", startPoint: dbRoot{db: ddb}, written: doltdb.EmptyWorkingSet(missingRef).WithWorkingRoot(changed), replaced: doltdb.EmptyWorkingSet(missingRef).WithWorkingRoot(root)},
+	}
+	err = tx.restoreWorkingSets(ctx, commits)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing:")
+	assert.NotContains(t, err.Error(), "new:")
+	assert.Nil(t, commits[0].written)
+	assert.NotNil(t, commits[1].written)
+}
+
+func TestDbLocks(t *testing.T) {
+	l := newDbLocks()
+
+	// locking different databases doesn't wait, and neither does reading databases being read
+	unlockAB := l.lock([]string{"a", "B"})
+	unlockC := l.rLock([]string{"c"})
+	unlockC2 := l.rLock([]string{"C", "c"})
+	unlockC()
+	unlockC2()
+
+	// reading a database being written waits for the write
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		defer l.rLock([]string{"c", "b"})()
+	}()
+	select {
+	case <-read:
+		assert.Fail(t, "read a database being written")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlockAB()
+	<-read
+
+	// locks of overlapping databases, taken in any order, don't deadlock
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		names := []string{"x", "y", "z"}
+		if i%2 == 0 {
+			names = []string{"z", "y", "x"}
+		}
+		go func(i int) {
+			for j := 0; j < 100; j++ {
+				if i%3 == 0 {
+					l.lock(names)()
+				} else {
+					l.rLock(names)()
+				}
+			}
+			done <- struct{}{}
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			assert.FailNow(t, "locks deadlocked")
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dustin/go-humanize"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// DefaultQuotaKey is the name which sets the quota of every database without a quota of its own in the
+// dolt_database_quotas system variable.
+const DefaultQuotaKey = "*"
+
+// ErrQuotaExceeded is returned when committing a transaction would grow a database beyond its quota.
+var ErrQuotaExceeded = goerrors.NewKind("database quota exceeded, transaction rolled back: database %s has a quota of %s and already " +
+	"uses %s, and this transaction would add %s. Run dolt_gc() to reclaim unused space, or raise the quota with @@" +
+	DoltDatabaseQuotas)
+
+// ParseQuotas parses a comma separated list of |database=size| entries, such as "db1=10GB,*=1GB", where the size is
+// a number of bytes with an optional unit. The database name "*" sets the quota of every other database. Database
+// names are returned lower case.
+func ParseQuotas(s string) (map[string]uint64, error) {
+	res := make(map[string]uint64)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, size, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid database quota '%s', expected <database>=<size>", entry)
+		}
+		bytes, err := humanize.ParseBytes(strings.TrimSpace(size))
+		if err != nil {
+			return nil, fmt.Errorf("invalid database quota '%s': %w", entry, err)
+		}
+		res[name] = bytes
+	}
+	return res, nil
+}
+
+// QuotaFor returns the quota in bytes of the database named |dbName|, as configured by the dolt_database_quotas
+// system variable, and whether it has one.
+func QuotaFor(dbName string) (uint64, bool) {
+	_, val, ok := sql.SystemVariables.GetGlobal(DoltDatabaseQuotas)
+	if !ok {
+		return 0, false
+	}
+	s, ok := val.(string)
+	if !ok || s == "" {
+		return 0, false
+	}
+	quotas, err := ParseQuotas(s)
+	if err != nil {
+		// The variable is validated when it is set.
+		return 0, false
+	}
+	baseName, _ := SplitRevisionDbName(dbName)
+	if quota, ok := quotas[strings.ToLower(baseName)]; ok {
+		return quota, true
+	}
+	quota, ok := quotas[DefaultQuotaKey]
+	return quota, ok
+}
+
+// checkQuota returns an error if committing |workingSet|, which began as |startState|, would grow the database
+// |startPoint| beyond its quota. Only the chunks of the tables this transaction changed are counted, so the writes of
+// other transactions to the same chunk store aren't charged to it. A transaction which doesn't grow the data of the
+// database, such as one which only deletes rows, is always allowed, so that a database over its quota can be brought
+// back under it.
+func checkQuota(ctx *sql.Context, startPoint dbRoot, startState, workingSet *doltdb.WorkingSet) error {
+	quota, ok := QuotaFor(startPoint.dbName)
+	if !ok || !types.IsFormat_DOLT(startPoint.db.Format()) {
+		return nil
+	}
+	added, removed, err := workingSetGrowth(ctx, startPoint.db.NodeStore(), startState.WorkingRoot(), workingSet.WorkingRoot())
+	if err != nil {
+		return err
+	}
+	if added <= removed {
+		return nil
+	}
+	persisted, _, err := startPoint.db.StoreSize(ctx)
+	if err != nil {
+		// Only table file stores report their size, and there's nothing to enforce for the others.
+		return nil
+	}
+	newBytes := added - removed
+	if persisted+newBytes <= quota {
+		return nil
+	}
+	return ErrQuotaExceeded.New(startPoint.dbName, humanize.Bytes(quota), humanize.Bytes(persisted), humanize.Bytes(newBytes))
+}
+
+// workingSetGrowth returns the bytes of the chunks of the tables of |to| which aren't chunks of the tables of |from|,
+// and the bytes of those of |from| which aren't chunks of |to|. Only tables whose hashes differ are read.
+func workingSetGrowth(ctx *sql.Context, ns tree.NodeStore, from, to doltdb.RootValue) (added, removed uint64, err error) {
+	var fromTables, toTables []*doltdb.Table
+	names, err := to.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, name := range names {
+		tblName := doltdb.TableName{Name: name}
+		toHash, _, err := to.GetTableHash(ctx, tblName)
+		if err != nil {
+			return 0, 0, err
+		}
+		fromHash, ok, err := from.GetTableHash(ctx, tblName)
+		if err != nil {
+			return 0, 0, err
+		} else if ok && fromHash == toHash {
+			continue
+		}
+		tbl, _, err := to.GetTable(ctx, tblName)
+		if err != nil {
+			return 0, 0, err
+		}
+		toTables = append(toTables, tbl)
+		if ok {
+			tbl, _, err = from.GetTable(ctx, tblName)
+			if err != nil {
+				return 0, 0, err
+			}
+			fromTables = append(fromTables, tbl)
+		}
+	}
+	names, err = from.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, name := range names {
+		tbl, ok, err := to.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return 0, 0, err
+		} else if ok {
+			continue
+		}
+		if tbl, _, err = from.GetTable(ctx, doltdb.TableName{Name: name}); err != nil {
+			return 0, 0, err
+		}
+		fromTables = append(fromTables, tbl)
+	}
+
+	if added, err = uniqueTableBytes(ctx, ns, toTables, fromTables); err != nil {
+		return 0, 0, err
+	}
+	if removed, err = uniqueTableBytes(ctx, ns, fromTables, toTables); err != nil {
+		return 0, 0, err
+	}
+	return added, removed, nil
+}
+
+// uniqueTableBytes returns the bytes of the chunks of the indexes of |tables| which aren't chunks of those of |others|.
+func uniqueTableBytes(ctx *sql.Context, ns tree.NodeStore, tables, others []*doltdb.Table) (uint64, error) {
+	est := tree.NewExactSizeEstimator(ns)
+	for _, tbl := range others {
+		if err := walkTableIndexes(ctx, tbl, est.Exclude); err != nil {
+			return 0, err
+		}
+	}
+	for _, tbl := range tables {
+		if err := walkTableIndexes(ctx, tbl, est.Add); err != nil {
+			return 0, err
+		}
+	}
+	return est.Bytes(), nil
+}
+
+// walkTableIndexes applies |fn| to the tree of the primary index of |tbl| and of each of its secondary indexes.
+func walkTableIndexes(ctx *sql.Context, tbl *doltdb.Table, fn func(ctx context.Context, nd tree.Node) error) error {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	if err = fn(ctx, durable.ProllyMapFromIndex(idx).Node()); err != nil {
+		return err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	set, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	return durable.IterAllIndexes(ctx, sch, set, func(_ string, idx durable.Index) error {
+		return fn(ctx, durable.ProllyMapFromIndex(idx).Node())
+	})
+}
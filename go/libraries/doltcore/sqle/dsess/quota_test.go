@@ -0,0 +1,39 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuotas(t *testing.T) {
+	quotas, err := ParseQuotas("MyDB=10GB, *=512KiB,other=1024,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"mydb": 10_000_000_000, "*": 512 * 1024, "other": 1024}, quotas)
+
+	quotas, err = ParseQuotas("")
+	require.NoError(t, err)
+	assert.Empty(t, quotas)
+
+	_, err = ParseQuotas("mydb")
+	assert.Error(t, err)
+	_, err = ParseQuotas("=1GB")
+	assert.Error(t, err)
+	_, err = ParseQuotas("mydb=lots")
+	assert.Error(t, err)
+}
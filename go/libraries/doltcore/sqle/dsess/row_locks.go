@@ -0,0 +1,271 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const lockWaitTimeoutSysVar = "innodb_lock_wait_timeout"
+
+// branchRowLocks is the state of the row locks a session's transaction takes on a branch.
+type branchRowLocks struct {
+	mu *sync.Mutex
+	// checkedRoot is the root of the database when the branch's working set was last checked for commits by other
+	// transactions
+	checkedRoot hash.Hash
+	// rebases is the number of times the session's working set has been rebased onto a committed one
+	rebases uint64
+	// statementStart is the working set the current statement began with, rebased the same way as the session's
+	// working set, if that was rebased during the statement
+	statementStart *doltdb.WorkingSet
+	// lockedReads are the tables, by lower case name, whose rows the current statement locks as it reads them
+	lockedReads map[string]struct{}
+}
+
+// PessimisticLockingEnabled returns whether @@dolt_pessimistic_locking is enabled for the session of |ctx|, in which
+// case transactions lock the rows they write, see DoltSession.LockRow.
+func PessimisticLockingEnabled(ctx *sql.Context) bool {
+	enabled, err := GetBooleanSystemVar(ctx, DoltPessimisticLocking)
+	return err == nil && enabled
+}
+
+// LockRow locks the row with the key |key| of the table |tableName| on the branch of |dbName| until this session's
+// transaction ends, so that concurrent transactions writing the row wait for it to end rather than conflict when they
+// commit. If another transaction holds the lock, waits for up to @@innodb_lock_wait_timeout seconds for it to end.
+//
+// Once the lock is held, this session's working set is rebased onto the branch's latest committed working set if
+// another transaction has committed one since, so that the row is read, and written, as the last transaction to hold
+// its lock left it. Callers which read the row before locking it must read it again if the working set was rebased,
+// see RowLockRebases. Fails with a retryable error, and rolls back this session's transaction, if the lock can't be
+// acquired without a deadlock, or if the rebase conflicts with rows this session wrote without locking them.
+func (d *DoltSession) LockRow(ctx *sql.Context, dbName, tableName, key string) error {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	gs := bs.dbState.globalState
+	if gs == nil || bs.workingSet == nil {
+		return nil
+	}
+
+	timeout := time.Second
+	if val, err := ctx.GetSessionVariable(ctx, lockWaitTimeoutSysVar); err == nil {
+		if secs, ok := val.(int64); ok {
+			timeout = time.Duration(secs) * time.Second
+		}
+	}
+
+	bs.rowLocks.mu.Lock()
+	defer bs.rowLocks.mu.Unlock()
+
+	lockKey := strings.Join([]string{bs.workingSet.Ref().String(), strings.ToLower(tableName), key}, "/")
+	err = gs.RowLocks().Lock(ctx, d, lockKey, timeout)
+	switch err {
+	case nil:
+		return d.rebaseOnCommitted(ctx, dbName, bs)
+	case globalstate.ErrLockWaitTimeout:
+		return mysql.NewSQLError(mysql.ERLockWaitTimeout, mysql.SSUnknownSQLState, "%s", err.Error())
+	case globalstate.ErrLockDeadlock:
+		d.clear()
+		d.ReleaseRowLocks()
+		return sql.ErrLockDeadlock.New(err.Error())
+	default:
+		return err
+	}
+}
+
+// rebaseOnCommitted rebases the working set of the branch |bs| of |dbName| onto the branch's latest committed working
+// set, if a transaction other than this session's has committed one since this session's transaction began, or since
+// it was last rebased. Must be called with |bs.rowLocks.mu| held.
+func (d *DoltSession) rebaseOnCommitted(ctx *sql.Context, dbName string, bs *branchState) error {
+	tx, ok := ctx.GetTransaction().(*DoltTransaction)
+	if !ok {
+		return nil
+	}
+	startPoint, ok := tx.dbStartPoints[strings.ToLower(bs.dbState.dbName)]
+	if !ok {
+		return nil
+	}
+
+	root, err := startPoint.db.NomsRoot(ctx)
+	if err != nil {
+		return err
+	} else if root == bs.rowLocks.checkedRoot {
+		return nil
+	}
+
+	wsRef := bs.workingSet.Ref()
+	startState, err := tx.startState(ctx, startPoint, wsRef)
+	if err == doltdb.ErrWorkingSetNotFound {
+		bs.rowLocks.checkedRoot = root
+		return nil
+	} else if err != nil {
+		return err
+	}
+	committed, err := startPoint.db.ResolveWorkingSet(ctx, wsRef)
+	if err == doltdb.ErrWorkingSetNotFound {
+		bs.rowLocks.checkedRoot = root
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if workingAndStagedEqual(committed, startState) {
+		bs.rowLocks.checkedRoot = root
+		return nil
+	}
+
+	// the session's working set only includes the changes made by statements which have completed, while its write
+	// session includes those of the current statement as well
+	statementStart := bs.workingSet
+	current := statementStart
+	if bs.writeSession != nil {
+		if current, err = bs.writeSession.Flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	rebase := func(ws *doltdb.WorkingSet) (*doltdb.WorkingSet, error) {
+		return tx.mergeRoots(ctx, startState, committed, ws, bs.EditOpts())
+	}
+	rebased, err := rebase(current)
+	if err != nil {
+		return err
+	}
+	if conflicts, err := newConflicts(ctx, current, rebased); err != nil {
+		return err
+	} else if conflicts {
+		// this session wrote a row the other transaction wrote as well without locking it, since pessimistic locking
+		// isn't enabled for every session, which is a conflict as when the transactions commit
+		if err = tx.rollback(ctx); err != nil {
+			return err
+		}
+		return sql.ErrLockDeadlock.New(ErrRetryTransaction.Error())
+	}
+	rebasedStatementStart := rebased
+	if !workingAndStagedEqual(statementStart, current) {
+		if rebasedStatementStart, err = rebase(statementStart); err != nil {
+			return err
+		}
+	}
+	if err = tx.rebaseStartState(startPoint, committed, bs, rebase); err != nil {
+		return err
+	}
+	if err = d.SetWorkingSet(ctx, dbName, rebased); err != nil {
+		return err
+	}
+
+	bs.rowLocks.checkedRoot = root
+	bs.rowLocks.rebases++
+	bs.rowLocks.statementStart = rebasedStatementStart
+	return nil
+}
+
+// newConflicts returns whether the working root of |rebased| has conflicts which that of |ws| doesn't.
+func newConflicts(ctx *sql.Context, ws, rebased *doltdb.WorkingSet) (bool, error) {
+	if has, err := doltdb.HasConflicts(ctx, rebased.WorkingRoot()); err != nil || !has {
+		return false, err
+	}
+	had, err := doltdb.HasConflicts(ctx, ws.WorkingRoot())
+	return !had, err
+}
+
+// RowLockRebases returns the number of times this session's working set of the branch of |dbName| has been rebased
+// onto one committed by another transaction since this session's transaction began, see LockRow.
+func (d *DoltSession) RowLockRebases(ctx *sql.Context, dbName string) (uint64, error) {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil {
+		return 0, err
+	} else if !ok {
+		return 0, sql.ErrDatabaseNotFound.New(dbName)
+	}
+	bs.rowLocks.mu.Lock()
+	defer bs.rowLocks.mu.Unlock()
+	return bs.rowLocks.rebases, nil
+}
+
+// BeginRowLockingStatement begins a statement which locks the rows it writes to the table |tableName| of |dbName|,
+// and those it reads from it as well if |lockReads| is true, as the rows an UPDATE or DELETE reads are those it
+// writes. See LocksReads.
+func (d *DoltSession) BeginRowLockingStatement(ctx *sql.Context, dbName, tableName string, lockReads bool) error {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil || !ok {
+		return err
+	}
+	bs.rowLocks.mu.Lock()
+	defer bs.rowLocks.mu.Unlock()
+	bs.rowLocks.statementStart = nil
+	if lockReads {
+		if bs.rowLocks.lockedReads == nil {
+			bs.rowLocks.lockedReads = make(map[string]struct{})
+		}
+		bs.rowLocks.lockedReads[strings.ToLower(tableName)] = struct{}{}
+	}
+	return nil
+}
+
+// EndRowLockingStatement ends a statement begun with BeginRowLockingStatement. If the statement failed, and this
+// session's working set was rebased during it, the working set is restored to the one the statement began with,
+// rebased the same way, since the changes the statement made before the rebase are part of the rebased working set.
+func (d *DoltSession) EndRowLockingStatement(ctx *sql.Context, dbName, tableName string, failed bool) error {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil || !ok {
+		return err
+	}
+	bs.rowLocks.mu.Lock()
+	defer bs.rowLocks.mu.Unlock()
+	delete(bs.rowLocks.lockedReads, strings.ToLower(tableName))
+	statementStart := bs.rowLocks.statementStart
+	bs.rowLocks.statementStart = nil
+	if failed && statementStart != nil {
+		return d.SetWorkingSet(ctx, dbName, statementStart)
+	}
+	return nil
+}
+
+// LocksReads returns whether the current statement of this session locks the rows it reads from the table
+// |tableName| of |dbName|, see BeginRowLockingStatement.
+func (d *DoltSession) LocksReads(ctx *sql.Context, dbName, tableName string) bool {
+	bs, ok, err := d.lookupDbState(ctx, dbName)
+	if err != nil || !ok {
+		return false
+	}
+	bs.rowLocks.mu.Lock()
+	defer bs.rowLocks.mu.Unlock()
+	_, ok = bs.rowLocks.lockedReads[strings.ToLower(tableName)]
+	return ok
+}
+
+// ReleaseRowLocks releases the row locks held by this session, when its transaction ends or its connection closes.
+func (d *DoltSession) ReleaseRowLocks() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, dbState := range d.dbStates {
+		if dbState.globalState != nil {
+			dbState.globalState.RowLocks().ReleaseAll(d)
+		}
+	}
+}
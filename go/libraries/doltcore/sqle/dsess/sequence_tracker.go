@@ -0,0 +1,222 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess/mutexmap"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
+)
+
+// sequencesTupleKey is the key of the tuple in which the values reserved by the sequences of a database are persisted
+const sequencesTupleKey = "sequences"
+
+// sequenceReserveBatch is the number of values a sequence reserves past the one handed out each time it persists
+// the values reserved. See SequenceTracker.
+const sequenceReserveBatch = 1024
+
+// SequenceTracker is the globalstate.SequenceTracker of a database. As AutoIncrementTracker does for auto increment
+// columns, it hands out values from batches reserved in the database before any of them are handed out, so that values
+// handed out by transactions which never commit aren't handed out again after a restart, while the database is only
+// written once per batch. Release gives back the values reserved but not handed out when the database is closed.
+type SequenceTracker struct {
+	// last is the last value handed out by each sequence, by lower case name
+	last *sync.Map // map[string]int64
+	mm   *mutexmap.MutexMap
+
+	// ddb is the database the values are persisted in, or nil if they aren't. See reserve.
+	ddb *doltdb.DoltDB
+	// reserved is the last value reserved by each sequence, which is the one persisted
+	reserved *sync.Map // map[string]int64
+	// remaining is the number of values each sequence has reserved but not handed out
+	remaining *sync.Map // map[string]int
+	persistMu sync.Mutex
+}
+
+var _ globalstate.SequenceTracker = &SequenceTracker{}
+
+// NewSequenceTracker returns a new SequenceTracker whose values are reserved in |ddb|, if it isn't nil. Values
+// previously reserved are loaded, so that values handed out before a restart by transactions which never committed
+// aren't handed out again.
+func NewSequenceTracker(ctx context.Context, ddb *doltdb.DoltDB) (*SequenceTracker, error) {
+	st := &SequenceTracker{
+		last:      &sync.Map{},
+		mm:        mutexmap.NewMutexMap(),
+		ddb:       ddb,
+		reserved:  &sync.Map{},
+		remaining: &sync.Map{},
+	}
+	if ddb == nil {
+		return st, nil
+	}
+
+	val, ok, err := ddb.GetTuple(ctx, sequencesTupleKey)
+	if err != nil || !ok {
+		return st, err
+	}
+	var persisted map[string]int64
+	if err := json.Unmarshal(val, &persisted); err != nil {
+		return nil, err
+	}
+	for name, last := range persisted {
+		st.last.Store(name, last)
+		st.reserved.Store(name, last)
+	}
+	return st, nil
+}
+
+// Next implements globalstate.SequenceTracker
+func (s *SequenceTracker) Next(ctx context.Context, name string, next func(last int64, ok bool) (int64, error)) (int64, error) {
+	name = strings.ToLower(name)
+	release := s.mm.Lock(name)
+	defer release()
+
+	var last int64
+	v, ok := s.last.Load(name)
+	if ok {
+		last = v.(int64)
+	}
+	value, err := next(last, ok)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.ddb != nil {
+		if remaining, _ := s.remaining.Load(name); remaining != nil && remaining.(int) > 0 {
+			s.remaining.Store(name, remaining.(int)-1)
+		} else {
+			// reserve the values up to a batch past this one, or up to where the sequence runs out or cycles
+			reserved, count := value, 0
+			for ; count < sequenceReserveBatch; count++ {
+				v, err := next(reserved, true)
+				if err != nil || count > 0 && (v > reserved) != (reserved > value) {
+					break
+				}
+				reserved = v
+			}
+			if err = s.reserve(ctx, name, reserved, true); err != nil {
+				return 0, err
+			}
+			s.remaining.Store(name, count)
+		}
+	}
+
+	s.last.Store(name, value)
+	return value, nil
+}
+
+// Set implements globalstate.SequenceTracker
+func (s *SequenceTracker) Set(ctx context.Context, name string, value int64) error {
+	name = strings.ToLower(name)
+	release := s.mm.Lock(name)
+	defer release()
+
+	// the sequence may have been set back, which must be persisted now, since the values it skips back over may
+	// otherwise be handed out again after a restart
+	if err := s.reserve(ctx, name, value, true); err != nil {
+		return err
+	}
+	s.remaining.Delete(name)
+	s.last.Store(name, value)
+	return nil
+}
+
+// Drop implements globalstate.SequenceTracker
+func (s *SequenceTracker) Drop(ctx context.Context, name string) error {
+	name = strings.ToLower(name)
+	release := s.mm.Lock(name)
+	defer release()
+
+	if err := s.reserve(ctx, name, 0, false); err != nil {
+		return err
+	}
+	s.remaining.Delete(name)
+	s.last.Delete(name)
+	return nil
+}
+
+// reserve persists |value| as the value reserved by the sequence |name|, or forgets the sequence if |ok| is false.
+func (s *SequenceTracker) reserve(ctx context.Context, name string, value int64, ok bool) error {
+	if s.ddb == nil {
+		return nil
+	}
+
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	reserved := make(map[string]int64)
+	s.reserved.Range(func(key, value any) bool {
+		reserved[key.(string)] = value.(int64)
+		return true
+	})
+	if ok {
+		reserved[name] = value
+	} else {
+		delete(reserved, name)
+	}
+	if err := s.persist(ctx, reserved); err != nil {
+		return err
+	}
+	if ok {
+		s.reserved.Store(name, value)
+	} else {
+		s.reserved.Delete(name)
+	}
+	return nil
+}
+
+func (s *SequenceTracker) persist(ctx context.Context, values map[string]int64) error {
+	val, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return s.ddb.SetTuple(ctx, sequencesTupleKey, val)
+}
+
+// Release implements globalstate.SequenceTracker. It persists the last values handed out, giving back the values
+// reserved after them, so that they're handed out after a restart.
+func (s *SequenceTracker) Release(ctx context.Context) error {
+	if s.ddb == nil {
+		return nil
+	}
+
+	s.persistMu.Lock()
+	defer s.persistMu.Unlock()
+
+	last := make(map[string]int64)
+	changed := false
+	s.last.Range(func(key, value any) bool {
+		last[key.(string)] = value.(int64)
+		reserved, ok := s.reserved.Load(key)
+		changed = changed || !ok || reserved.(int64) != value.(int64)
+		return true
+	})
+	if !changed {
+		return nil
+	}
+	if err := s.persist(ctx, last); err != nil {
+		return err
+	}
+	for name, value := range last {
+		s.reserved.Store(name, value)
+		s.remaining.Delete(name)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// increment hands out the value after the last, starting at 1
+func increment(last int64, ok bool) (int64, error) {
+	if !ok {
+		return 1, nil
+	}
+	return last + 1, nil
+}
+
+func TestSequenceTrackerNext(t *testing.T) {
+	st, err := NewSequenceTracker(context.Background(), nil)
+	require.NoError(t, err)
+
+	const goroutines, perGoroutine = 8, 100
+	values := make(chan int64, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				v, err := st.Next(context.Background(), "S", increment)
+				assert.NoError(t, err)
+				values <- v
+			}
+		}()
+	}
+	wg.Wait()
+	close(values)
+
+	seen := make(map[int64]bool)
+	for v := range values {
+		assert.False(t, seen[v], "value %d handed out twice", v)
+		seen[v] = true
+	}
+	assert.Len(t, seen, goroutines*perGoroutine)
+
+	v, err := st.Next(context.Background(), "s", increment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(goroutines*perGoroutine+1), v)
+}
+
+func TestSequenceTrackerRelease(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, doltdb.InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	defer ddb.Close()
+
+	st, err := NewSequenceTracker(ctx, ddb)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = st.Next(ctx, "a", increment)
+		require.NoError(t, err)
+	}
+	require.NoError(t, st.Set(ctx, "b", 10))
+	require.NoError(t, st.Set(ctx, "c", 20))
+	require.NoError(t, st.Drop(ctx, "c"))
+
+	// without a release, the values reserved past the last one handed out are skipped after a restart
+	loaded, err := NewSequenceTracker(ctx, ddb)
+	require.NoError(t, err)
+	v, err := loaded.Next(ctx, "a", increment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1+sequenceReserveBatch+1), v)
+
+	require.NoError(t, st.Release(ctx))
+
+	loaded, err = NewSequenceTracker(ctx, ddb)
+	require.NoError(t, err)
+	v, err = loaded.Next(ctx, "a", increment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), v)
+	v, err = loaded.Next(ctx, "b", increment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), v)
+	v, err = loaded.Next(ctx, "c", increment)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), v)
+}
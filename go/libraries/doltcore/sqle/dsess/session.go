@@ -67,6 +67,9 @@ type DoltSession struct {
 	// If non-nil, this will be returned from ValidateSession.
 	// Used by sqle/cluster to put a session into a terminal err state.
 	validateErr error
+
+	// subscriptions are the changes this session waits for with WaitForChanges, see Subscribe.
+	subscriptions []*subscription
 }
 
 var _ sql.Session = (*DoltSession)(nil)
@@ -164,6 +167,7 @@ func (d *DoltSession) lookupDbState(ctx *sql.Context, dbName string) (*branchSta
 				return nil, false, dbState.Err
 			}
 
+			d.recordAccess(branchState)
 			return branchState, ok, nil
 		}
 	}
@@ -197,7 +201,16 @@ func (d *DoltSession) lookupDbState(ctx *sql.Context, dbName string) (*branchSta
 		return nil, false, sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	return dbState.heads[strings.ToLower(database.Revision())], true, nil
+	branchState := dbState.heads[strings.ToLower(database.Revision())]
+	d.recordAccess(branchState)
+	return branchState, true, nil
+}
+
+// recordAccess records that the session's transaction accessed the branch of |bs|.
+func (d *DoltSession) recordAccess(bs *branchState) {
+	if tx, ok := d.GetTransaction().(*DoltTransaction); ok && bs != nil {
+		tx.recordAccess(bs)
+	}
 }
 
 // RevisionDbName returns the name of the revision db for the base name and revision string given
@@ -329,8 +342,9 @@ func (d *DoltSession) StartTransaction(ctx *sql.Context, tCharacteristic sql.Tra
 		return DisabledTransaction{}, nil
 	}
 
-	// New transaction, clear all session state
+	// New transaction, clear all session state and release any row locks the last one didn't
 	d.clear()
+	d.ReleaseRowLocks()
 
 	// Take a snapshot of the current noms root for every database under management
 	doltDatabases := d.provider.DoltDatabases()
@@ -432,7 +446,8 @@ func (d *DoltSession) newWorkingSetForHead(ctx *sql.Context, wsRef ref.WorkingSe
 
 // CommitTransaction commits the in-progress transaction. Depending on session settings, this may write only a new
 // working set, or may additionally create a new dolt commit for the current HEAD. If more than one branch head has
-// changes, the transaction is rejected.
+// changes, the transaction is rejected, unless each is of a different database and no dolt commit is to be created, in
+// which case their working sets are committed together.
 func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (err error) {
 	// Any non-error path must set the ctx's transaction to nil even if no work was done, because the engine only clears
 	// out transaction state in some cases. Changes to only branch heads (creating a new branch, reset, etc.) have no
@@ -453,10 +468,6 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 		return nil
 	}
 
-	if len(dirties) > 1 {
-		return ErrDirtyWorkingSets
-	}
-
 	performDoltCommitVar, err := d.Session.GetSessionVariable(ctx, DoltCommitOnTransactionCommit)
 	if err != nil {
 		return err
@@ -467,6 +478,15 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 		return fmt.Errorf(fmt.Sprintf("Unexpected type for var %s: %T", DoltCommitOnTransactionCommit, performDoltCommitVar))
 	}
 
+	if len(dirties) > 1 {
+		// Working sets of different databases can be committed together, but not those of different branches of one
+		// database, or with a dolt commit
+		if peformDoltCommitInt == 1 || !writesOneBranchPerDatabase(dirties) {
+			return ErrDirtyWorkingSets
+		}
+		return d.commitWorkingSets(ctx, dirties, tx)
+	}
+
 	dirtyBranchState := dirties[0]
 	if peformDoltCommitInt == 1 {
 		// if the dirty working set doesn't belong to the currently checked out branch, that's an error
@@ -651,6 +671,7 @@ func (d *DoltSession) commitBranchState(
 	// COMMIT statements. Any other statements that commit a transaction, including stored procedures, needs to do this
 	// themselves.
 	ctx.SetTransaction(nil)
+	d.ReleaseRowLocks()
 	return newCommit, nil
 }
 
@@ -787,11 +808,12 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, branchState *branchStat
 func (d *DoltSession) Rollback(ctx *sql.Context, tx sql.Transaction) error {
 	// Nothing to do here, we just throw away all our work and let a new transaction begin next statement
 	d.clear()
+	d.ReleaseRowLocks()
 	return nil
 }
 
-// CreateSavepoint creates a new savepoint for this transaction with the name given. A previously created savepoint
-// with the same name will be overwritten.
+// CreateSavepoint creates a new savepoint for this transaction with the name given, which records the working sets
+// of every branch this session has accessed. A previously created savepoint with the same name will be overwritten.
 func (d *DoltSession) CreateSavepoint(ctx *sql.Context, tx sql.Transaction, savepointName string) error {
 	if TransactionsDisabled(ctx) {
 		return nil
@@ -802,25 +824,12 @@ func (d *DoltSession) CreateSavepoint(ctx *sql.Context, tx sql.Transaction, save
 		return fmt.Errorf("expected a DoltTransaction")
 	}
 
-	roots := make(map[string]doltdb.RootValue)
-	for _, db := range d.provider.DoltDatabases() {
-		branchState, ok, err := d.lookupDbState(ctx, db.Name())
-		if err != nil {
-			return err
-		}
-		if !ok {
-			return fmt.Errorf("session state for database %s not found", db.Name())
-		}
-		baseName, _ := SplitRevisionDbName(db.Name())
-		roots[strings.ToLower(baseName)] = branchState.WorkingSet().WorkingRoot()
-	}
-
-	dtx.CreateSavepoint(savepointName, roots)
+	dtx.CreateSavepoint(savepointName, d.SnapshotWorkingSets())
 	return nil
 }
 
-// RollbackToSavepoint sets this session's root to the one saved in the savepoint name. It's an error if no savepoint
-// with that name exists.
+// RollbackToSavepoint restores this session's working sets, on every branch of every database, to the ones saved in
+// the savepoint name. It's an error if no savepoint with that name exists.
 func (d *DoltSession) RollbackToSavepoint(ctx *sql.Context, tx sql.Transaction, savepointName string) error {
 	if TransactionsDisabled(ctx) {
 		return nil
@@ -831,23 +840,16 @@ func (d *DoltSession) RollbackToSavepoint(ctx *sql.Context, tx sql.Transaction,
 		return fmt.Errorf("expected a DoltTransaction")
 	}
 
-	roots := dtx.RollbackToSavepoint(savepointName)
-	if roots == nil {
+	snapshot, ok := dtx.RollbackToSavepoint(savepointName)
+	if !ok {
 		return sql.ErrSavepointDoesNotExist.New(savepointName)
 	}
 
-	for dbName, root := range roots {
-		err := d.SetWorkingRoot(ctx, dbName, root)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return d.RestoreWorkingSets(ctx, snapshot)
 }
 
-// ReleaseSavepoint removes the savepoint name from the transaction. It's an error if no savepoint with that name
-// exists.
+// ReleaseSavepoint removes the savepoint name, and any savepoints created after it, from the transaction. It's an
+// error if no savepoint with that name exists.
 func (d *DoltSession) ReleaseSavepoint(ctx *sql.Context, tx sql.Transaction, savepointName string) error {
 	if TransactionsDisabled(ctx) {
 		return nil
@@ -866,6 +868,75 @@ func (d *DoltSession) ReleaseSavepoint(ctx *sql.Context, tx sql.Transaction, sav
 	return nil
 }
 
+// WorkingSetSnapshot records the working sets of every branch a session has accessed, so that the changes made by a
+// statement which fails part way through can be undone. See |SnapshotWorkingSets|.
+type WorkingSetSnapshot struct {
+	states map[*branchState]branchSnapshot
+}
+
+type branchSnapshot struct {
+	workingSet *doltdb.WorkingSet
+	dirty      bool
+}
+
+// SnapshotWorkingSets returns a snapshot of the working sets of every branch this session has accessed, in every
+// database.
+func (d *DoltSession) SnapshotWorkingSets() WorkingSetSnapshot {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	snap := WorkingSetSnapshot{states: make(map[*branchState]branchSnapshot)}
+	for _, dbState := range d.dbStates {
+		for _, bs := range dbState.heads {
+			snap.states[bs] = branchSnapshot{workingSet: bs.workingSet, dirty: bs.dirty}
+		}
+	}
+	return snap
+}
+
+// RestoreWorkingSets undoes every change made to this session's working sets since |snap| was taken. Branches which
+// were first accessed, and changed, after the snapshot are dropped from the session, so that they are loaded again
+// from the database when they are next used.
+func (d *DoltSession) RestoreWorkingSets(ctx *sql.Context, snap WorkingSetSnapshot) error {
+	var restored []*branchState
+	dropped := false
+	d.mu.Lock()
+	for _, dbState := range d.dbStates {
+		for head, bs := range dbState.heads {
+			prev, ok := snap.states[bs]
+			if !ok {
+				if bs.dirty {
+					delete(dbState.heads, head)
+					dropped = true
+				}
+				continue
+			}
+			if bs.workingSet != prev.workingSet || bs.dirty != prev.dirty {
+				bs.workingSet, bs.dirty = prev.workingSet, prev.dirty
+				restored = append(restored, bs)
+			}
+		}
+	}
+	d.mu.Unlock()
+
+	if dropped {
+		d.dbCache.Clear()
+	}
+	for _, bs := range restored {
+		if bs.workingSet == nil {
+			continue
+		}
+		if bs.writeSession != nil {
+			if err := bs.writeSession.SetWorkingSet(ctx, bs.workingSet); err != nil {
+				return err
+			}
+		}
+		if err := d.setDbSessionVars(ctx, bs, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetDoltDB returns the *DoltDB for a given database by name
 func (d *DoltSession) GetDoltDB(ctx *sql.Context, dbName string) (*doltdb.DoltDB, bool) {
 	branchState, ok, err := d.lookupDbState(ctx, dbName)
@@ -879,6 +950,27 @@ func (d *DoltSession) GetDoltDB(ctx *sql.Context, dbName string) (*doltdb.DoltDB
 	return branchState.dbData.Ddb, true
 }
 
+// BeginGCSafepoint marks the beginning of work in this session which holds references to the chunks of the database
+// named outside of its transactions, such as roots kept by an application embedding dolt between queries. See
+// doltdb.DoltDB.BeginGCSafepoint.
+func (d *DoltSession) BeginGCSafepoint(ctx *sql.Context, dbName string) error {
+	ddb, ok := d.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	return ddb.BeginGCSafepoint(ctx)
+}
+
+// EndGCSafepoint marks the end of work begun with BeginGCSafepoint for the database named.
+func (d *DoltSession) EndGCSafepoint(ctx *sql.Context, dbName string) error {
+	ddb, ok := d.GetDoltDB(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	ddb.EndGCSafepoint()
+	return nil
+}
+
 func (d *DoltSession) GetDbData(ctx *sql.Context, dbName string) (env.DbData, bool) {
 	branchState, ok, err := d.lookupDbState(ctx, dbName)
 	if err != nil {
@@ -1076,6 +1168,9 @@ func (d *DoltSession) SetWorkingSet(ctx *sql.Context, dbName string, ws *doltdb.
 	if ws.Ref() != branchState.WorkingSet().Ref() {
 		return fmt.Errorf("must switch working sets with SwitchWorkingSet")
 	}
+	if err = checkAppendOnlyWorkingRoot(ctx, branchState.WorkingSet(), ws); err != nil {
+		return err
+	}
 	branchState.workingSet = ws
 
 	err = d.setDbSessionVars(ctx, branchState, true)
@@ -1179,7 +1274,13 @@ func (d *DoltSession) SetSessionVariable(ctx *sql.Context, key string, value int
 		return d.setForeignKeyChecksSessionVar(ctx, key, value)
 	}
 
-	return d.Session.SetSessionVariable(ctx, key, value)
+	if err := d.Session.SetSessionVariable(ctx, key, value); err != nil {
+		return err
+	}
+	if strings.EqualFold(key, transactionIsolationSysVar) {
+		warnIsolationLevel(ctx, sessionIsolationLevel(ctx))
+	}
+	return nil
 }
 
 func (d *DoltSession) setHeadRefSessionVar(ctx *sql.Context, db, value string) error {
@@ -1901,6 +2002,11 @@ func TransactionRoot(ctx *sql.Context, db SqlDatabase) (hash.Hash, error) {
 
 // DefaultHead returns the head for the database given when one isn't specified
 func DefaultHead(baseName string, db SqlDatabase) (string, error) {
+	// A database pinned to a revision, such as an attached remote database, has no other head
+	if db.Revision() != "" {
+		return db.Revision(), nil
+	}
+
 	head := ""
 
 	// First check the global variable for the default branch
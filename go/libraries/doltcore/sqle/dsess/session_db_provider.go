@@ -111,6 +111,11 @@ type DoltDatabaseProvider interface {
 	// PurgeDroppedDatabases permanently deletes any dropped databases that are being held in temporary storage
 	// in case they need to be restored. This operation is not reversible, so use with caution!
 	PurgeDroppedDatabases(ctx *sql.Context) error
+	// AttachDatabase mounts the dolt database at |source|, a local path or a remote URL, read-only as the database
+	// |dbName|, until it's detached with DetachDatabase.
+	AttachDatabase(ctx *sql.Context, dbName, source string) error
+	// DetachDatabase unmounts the database |dbName| mounted by AttachDatabase.
+	DetachDatabase(ctx *sql.Context, dbName string) error
 }
 
 type SessionDatabaseBranchSpec struct {
@@ -0,0 +1,201 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// subscription is a subscription of a session to the changes of the head of a branch, or of a table in the working
+// set of a branch.
+type subscription struct {
+	database string
+	branch   string
+	table    string
+	ddb      *doltdb.DoltDB
+	// last is the hash of the branch's head commit, or of the table, when the session last saw it. It is empty if the
+	// table doesn't exist.
+	last hash.Hash
+}
+
+// SubscriptionChange is a change of the subject of a subscription, as returned by WaitForChanges.
+type SubscriptionChange struct {
+	Database string
+	Branch   string
+	// Table is empty for a change of the head of the branch.
+	Table string
+	// Hash is the hash of the new head commit of the branch, or of the table, which is empty if the branch was deleted
+	// or the table was dropped.
+	Hash hash.Hash
+}
+
+func (s *subscription) matches(database, branch, table string) bool {
+	return strings.EqualFold(s.database, database) && s.branch == branch && strings.EqualFold(s.table, table)
+}
+
+// current returns the current hash of the subject of |s|, which is empty if the branch or table doesn't exist.
+func (s *subscription) current(ctx *sql.Context) (hash.Hash, error) {
+	branchRef := ref.NewBranchRef(s.branch)
+	if s.table == "" {
+		cm, err := s.ddb.ResolveCommitRef(ctx, branchRef)
+		if errors.Is(err, doltdb.ErrBranchNotFound) {
+			return hash.Hash{}, nil
+		} else if err != nil {
+			return hash.Hash{}, err
+		}
+		return cm.HashOf()
+	}
+
+	wsRef, err := ref.WorkingSetRefForHead(branchRef)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	ws, err := s.ddb.ResolveWorkingSet(ctx, wsRef)
+	if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+		return hash.Hash{}, nil
+	} else if err != nil {
+		return hash.Hash{}, err
+	}
+	tbl, ok, err := ws.WorkingRoot().GetTable(ctx, doltdb.TableName{Name: s.table})
+	if err != nil || !ok {
+		return hash.Hash{}, err
+	}
+	return tbl.HashOf()
+}
+
+// Subscribe subscribes this session to the changes of the head of |branch| of the database named, or to the changes
+// of |table| in the working set of the branch if |table| isn't empty. Changes are waited for with WaitForChanges.
+func (d *DoltSession) Subscribe(ctx *sql.Context, dbName, branch, table string) error {
+	baseName, _ := SplitRevisionDbName(dbName)
+	for _, s := range d.subscriptions {
+		if s.matches(baseName, branch, table) {
+			return nil
+		}
+	}
+
+	ddb, ok := d.GetDoltDB(ctx, baseName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	if _, ok, err := ddb.HasBranch(ctx, branch); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%w: %s", doltdb.ErrBranchNotFound, branch)
+	}
+
+	s := &subscription{database: baseName, branch: branch, table: table, ddb: ddb}
+	last, err := s.current(ctx)
+	if err != nil {
+		return err
+	}
+	s.last = last
+	d.subscriptions = append(d.subscriptions, s)
+	return nil
+}
+
+// Unsubscribe ends the subscription of this session to the changes of |branch| or of |table|, as begun by
+// Subscribe. Returns false if the session isn't subscribed to them.
+func (d *DoltSession) Unsubscribe(dbName, branch, table string) bool {
+	baseName, _ := SplitRevisionDbName(dbName)
+	for i, s := range d.subscriptions {
+		if s.matches(baseName, branch, table) {
+			d.subscriptions = append(d.subscriptions[:i], d.subscriptions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// UnsubscribeAll ends every subscription of this session.
+func (d *DoltSession) UnsubscribeAll() {
+	d.subscriptions = nil
+}
+
+// WaitForChanges waits for changes of the subjects of the subscriptions of this session since it last saw them, and
+// returns them. Returns no changes if there were none within |timeout|, or if the session has no subscriptions.
+func (d *DoltSession) WaitForChanges(ctx *sql.Context, timeout time.Duration) ([]SubscriptionChange, error) {
+	if len(d.subscriptions) == 0 {
+		return nil, nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		// take the channels before looking for changes, so that no change is missed in between
+		changed := make(chan struct{}, 1)
+		done := make(chan struct{})
+		seen := make(map[*doltdb.DoltDB]struct{})
+		for _, s := range d.subscriptions {
+			if _, ok := seen[s.ddb]; ok {
+				continue
+			}
+			seen[s.ddb] = struct{}{}
+			go func(ch <-chan struct{}) {
+				select {
+				case <-ch:
+					select {
+					case changed <- struct{}{}:
+					default:
+					}
+				case <-done:
+				}
+			}(s.ddb.HeadsChanged())
+		}
+
+		changes, err := d.subscriptionChanges(ctx)
+		if err != nil || len(changes) > 0 {
+			close(done)
+			return changes, err
+		}
+
+		select {
+		case <-changed:
+			close(done)
+		case <-timer.C:
+			close(done)
+			return nil, nil
+		case <-ctx.Done():
+			close(done)
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// subscriptionChanges returns the changes of the subjects of the subscriptions of this session since it last saw
+// them, and marks them seen.
+func (d *DoltSession) subscriptionChanges(ctx *sql.Context) ([]SubscriptionChange, error) {
+	var changes []SubscriptionChange
+	for _, s := range d.subscriptions {
+		h, err := s.current(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if h == s.last {
+			continue
+		}
+		s.last = h
+		changes = append(changes, SubscriptionChange{Database: s.database, Branch: s.branch, Table: s.table, Hash: h})
+	}
+	return changes, nil
+}
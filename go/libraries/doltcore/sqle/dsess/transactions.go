@@ -30,6 +30,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -80,9 +81,16 @@ func (d DisabledTransaction) IsReadOnly() bool {
 }
 
 type DoltTransaction struct {
-	dbStartPoints   map[string]dbRoot
+	dbStartPoints map[string]dbRoot
+	// rebasedStarts are the working sets which this transaction's working sets were rebased onto after they began, by
+	// database and working set ref, see rebaseKey. They replace the working sets of the start point as the ancestors
+	// the transaction's working sets are merged with when they're committed.
+	rebasedStarts   map[string]*doltdb.WorkingSet
 	savepoints      []savepoint
 	tCharacteristic sql.TransactionCharacteristic
+	isolation       IsolationLevel
+	// accessed are the branches the transaction accessed, recorded for SERIALIZABLE transactions, see recordAccess
+	accessed *accessedBranches
 }
 
 type dbRoot struct {
@@ -91,10 +99,22 @@ type dbRoot struct {
 	db       *doltdb.DoltDB
 }
 
+// newDbRoot returns the start point of a transaction for the database |dbName|.
+func newDbRoot(ctx *sql.Context, dbName string, ddb *doltdb.DoltDB) (dbRoot, error) {
+	nomsRoot, err := ddb.NomsRoot(ctx)
+	if err != nil {
+		return dbRoot{}, err
+	}
+	return dbRoot{
+		dbName:   dbName,
+		rootHash: nomsRoot,
+		db:       ddb,
+	}, nil
+}
+
 type savepoint struct {
-	name string
-	// TODO: we need a root value per DB here
-	roots map[string]doltdb.RootValue
+	name     string
+	snapshot WorkingSetSnapshot
 }
 
 func NewDoltTransaction(
@@ -103,40 +123,40 @@ func NewDoltTransaction(
 	tCharacteristic sql.TransactionCharacteristic,
 ) (*DoltTransaction, error) {
 
+	// see txStartLocks
+	baseNames := make([]string, len(dbs))
+	for i, db := range dbs {
+		baseNames[i], _ = SplitRevisionDbName(db.Name())
+	}
+	defer txStartLocks.rLock(baseNames)()
+
 	startPoints := make(map[string]dbRoot)
-	for _, db := range dbs {
-		nomsRoot, err := db.DbData().Ddb.NomsRoot(ctx)
+	for i, db := range dbs {
+		baseName := baseNames[i]
+		startPoint, err := newDbRoot(ctx, baseName, db.DbData().Ddb)
 		if err != nil {
 			return nil, err
 		}
-
-		baseName, _ := SplitRevisionDbName(db.Name())
-		startPoints[strings.ToLower(baseName)] = dbRoot{
-			dbName:   baseName,
-			rootHash: nomsRoot,
-			db:       db.DbData().Ddb,
-		}
+		startPoints[strings.ToLower(baseName)] = startPoint
 	}
 
 	return &DoltTransaction{
 		dbStartPoints:   startPoints,
 		tCharacteristic: tCharacteristic,
+		isolation:       sessionIsolationLevel(ctx),
+		accessed:        newAccessedBranches(),
 	}, nil
 }
 
 // AddDb adds the database named to the transaction. Only necessary in the case when new databases are added to an
 // existing transaction (as when cloning a database on a read replica when it is first referenced).
 func (tx DoltTransaction) AddDb(ctx *sql.Context, db SqlDatabase) error {
-	nomsRoot, err := db.DbData().Ddb.NomsRoot(ctx)
+	startPoint, err := newDbRoot(ctx, db.Name(), db.DbData().Ddb)
 	if err != nil {
 		return err
 	}
 
-	tx.dbStartPoints[strings.ToLower(db.Name())] = dbRoot{
-		dbName:   db.Name(),
-		rootHash: nomsRoot,
-		db:       db.DbData().Ddb,
-	}
+	tx.dbStartPoints[strings.ToLower(db.Name())] = startPoint
 
 	return nil
 }
@@ -150,6 +170,11 @@ func (tx DoltTransaction) IsReadOnly() bool {
 	return tx.tCharacteristic == sql.ReadOnly
 }
 
+// IsolationLevel returns the isolation level of this transaction, fixed when it began.
+func (tx DoltTransaction) IsolationLevel() IsolationLevel {
+	return tx.isolation
+}
+
 // GetInitialRoot returns the noms root hash for the db named, established when the transaction began. The dbName here
 // is always the base name of the database, not the revision qualified one.
 func (tx DoltTransaction) GetInitialRoot(dbName string) (hash.Hash, bool) {
@@ -371,87 +396,35 @@ func (tx *DoltTransaction) doCommit(
 	dbName string,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
 	sess := DSessFromSess(ctx.Session)
-	branchState, ok, err := sess.lookupDbState(ctx, dbName)
-	if err != nil {
-		return nil, nil, err
-	}
-	if !ok {
-		return nil, nil, fmt.Errorf("database %s unknown to transaction, this is a bug", dbName)
-	}
-
-	// Load the start state for this working set from the noms root at tx start
-	// Get the base DB name from the db state, not the branch state
-	startPoint, ok := tx.dbStartPoints[strings.ToLower(branchState.dbState.dbName)]
-	if !ok {
-		return nil, nil, fmt.Errorf("database %s unknown to transaction, this is a bug", dbName)
-	}
-
-	startState, err := startPoint.db.ResolveWorkingSetAtRoot(ctx, workingSet.Ref(), startPoint.rootHash)
+	startPoint, startState, mergeOpts, err := tx.startOfCommit(ctx, sess, workingSet, dbName)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// TODO: no-op if the working set hasn't changed since the transaction started
 
-	mergeOpts := branchState.EditOpts()
-
 	for i := 0; i < maxTxCommitRetries; i++ {
 		updatedWs, newCommit, err := func() (*doltdb.WorkingSet, *doltdb.Commit, error) {
 			// Serialize commits, since only one can possibly succeed at a time anyway
 			txLock.Lock()
 			defer txLock.Unlock()
 
-			newWorkingSet := false
-
-			existingWs, err := startPoint.db.ResolveWorkingSet(ctx, workingSet.Ref())
-			if err == doltdb.ErrWorkingSetNotFound {
-				// This is to handle the case where an existing DB pre working sets is committing to this HEAD for the
-				// first time. Can be removed and called an error post 1.0
-				existingWs = doltdb.EmptyWorkingSet(workingSet.Ref())
-				newWorkingSet = true
-			} else if err != nil {
-				return nil, nil, err
-			}
-
-			existingWSHash, err := existingWs.HashOf()
-			if err != nil {
-				return nil, nil, err
-			}
-
-			if newWorkingSet || workingAndStagedEqual(existingWs, startState) {
-				// ff merge
-				err = tx.validateWorkingSetForCommit(ctx, workingSet, isFfMerge)
-				if err != nil {
-					return nil, nil, err
-				}
-
-				var newCommit *doltdb.Commit
-				workingSet, newCommit, err = writeFn(ctx, tx, startPoint.db, startState, commit, workingSet, existingWSHash, mergeOpts)
-				if err == datas.ErrOptimisticLockFailed {
-					// this is effectively a `continue` in the loop
-					return nil, nil, nil
-				} else if err != nil {
+			if tx.isolation == Serializable {
+				if err := tx.validateSerializable(ctx, sess); err != nil {
+					if rollbackErr := tx.rollback(ctx); rollbackErr != nil {
+						return nil, nil, rollbackErr
+					}
 					return nil, nil, err
 				}
-
-				return workingSet, newCommit, nil
 			}
 
-			// otherwise (not a ff), merge the working sets together
-			start := time.Now()
-			mergedWorkingSet, err := tx.mergeRoots(ctx, startState, existingWs, workingSet, mergeOpts)
-			if err != nil {
-				return nil, nil, err
-			}
-			logrus.Tracef("working set merge took %s", time.Since(start))
-
-			err = tx.validateWorkingSetForCommit(ctx, mergedWorkingSet, notFfMerge)
+			toWrite, _, existingWSHash, err := tx.prepareWrite(ctx, startPoint, startState, workingSet, mergeOpts)
 			if err != nil {
 				return nil, nil, err
 			}
 
 			var newCommit *doltdb.Commit
-			mergedWorkingSet, newCommit, err = writeFn(ctx, tx, startPoint.db, startState, commit, mergedWorkingSet, existingWSHash, mergeOpts)
+			toWrite, newCommit, err = writeFn(ctx, tx, startPoint.db, startState, commit, toWrite, existingWSHash, mergeOpts)
 			if err == datas.ErrOptimisticLockFailed {
 				// this is effectively a `continue` in the loop
 				return nil, nil, nil
@@ -459,7 +432,7 @@ func (tx *DoltTransaction) doCommit(
 				return nil, nil, err
 			}
 
-			return mergedWorkingSet, newCommit, nil
+			return toWrite, newCommit, nil
 		}()
 
 		if err != nil {
@@ -473,6 +446,133 @@ func (tx *DoltTransaction) doCommit(
 	return nil, nil, datas.ErrOptimisticLockFailed
 }
 
+// startOfCommit returns the start point of this transaction for the database |dbName|, the working set |workingSet|
+// was at when it began, and the editor options to merge it with, failing if the database is over its quota.
+func (tx *DoltTransaction) startOfCommit(
+	ctx *sql.Context,
+	sess *DoltSession,
+	workingSet *doltdb.WorkingSet,
+	dbName string,
+) (dbRoot, *doltdb.WorkingSet, editor.Options, error) {
+	branchState, ok, err := sess.lookupDbState(ctx, dbName)
+	if err != nil {
+		return dbRoot{}, nil, editor.Options{}, err
+	}
+	if !ok {
+		return dbRoot{}, nil, editor.Options{}, fmt.Errorf("database %s unknown to transaction, this is a bug", dbName)
+	}
+
+	// Load the start state for this working set from the noms root at tx start
+	// Get the base DB name from the db state, not the branch state
+	startPoint, ok := tx.dbStartPoints[strings.ToLower(branchState.dbState.dbName)]
+	if !ok {
+		return dbRoot{}, nil, editor.Options{}, fmt.Errorf("database %s unknown to transaction, this is a bug", dbName)
+	}
+
+	startState, err := tx.startState(ctx, startPoint, workingSet.Ref())
+	if err != nil {
+		return dbRoot{}, nil, editor.Options{}, err
+	}
+
+	if err = checkQuota(ctx, startPoint, startState, workingSet); err != nil {
+		if rollbackErr := tx.rollback(ctx); rollbackErr != nil {
+			return dbRoot{}, nil, editor.Options{}, rollbackErr
+		}
+		return dbRoot{}, nil, editor.Options{}, err
+	}
+
+	return startPoint, startState, branchState.EditOpts(), nil
+}
+
+// startState returns the working set |wsRef| of the database of |startPoint| was at when this transaction began, or
+// the one it was last rebased onto, see rebaseStartState.
+func (tx *DoltTransaction) startState(ctx *sql.Context, startPoint dbRoot, wsRef ref.WorkingSetRef) (*doltdb.WorkingSet, error) {
+	if ws, ok := tx.rebasedStarts[rebaseKey(startPoint.dbName, wsRef)]; ok {
+		return ws, nil
+	}
+	return startPoint.db.ResolveWorkingSetAtRoot(ctx, wsRef, startPoint.rootHash)
+}
+
+// rebaseStartState records that the working set |ws| of the database of |startPoint| was rebased onto it, and merges
+// every savepoint's working set of the branch |bs| with it with |rebase|, so that rolling back to a savepoint doesn't
+// undo the rebase.
+func (tx *DoltTransaction) rebaseStartState(startPoint dbRoot, ws *doltdb.WorkingSet, bs *branchState, rebase func(*doltdb.WorkingSet) (*doltdb.WorkingSet, error)) error {
+	for _, sp := range tx.savepoints {
+		snap, ok := sp.snapshot.states[bs]
+		if !ok || snap.workingSet == nil {
+			continue
+		}
+		rebased, err := rebase(snap.workingSet)
+		if err != nil {
+			return err
+		}
+		snap.workingSet = rebased
+		sp.snapshot.states[bs] = snap
+	}
+
+	if tx.rebasedStarts == nil {
+		tx.rebasedStarts = make(map[string]*doltdb.WorkingSet)
+	}
+	tx.rebasedStarts[rebaseKey(startPoint.dbName, ws.Ref())] = ws
+	return nil
+}
+
+func rebaseKey(dbName string, wsRef ref.WorkingSetRef) string {
+	return strings.ToLower(dbName) + "/" + wsRef.String()
+}
+
+// prepareWrite returns the working set to write to commit |workingSet|, which is |workingSet| itself if the working
+// set it replaces hasn't changed since |startState|, or else the two merged, along with the working set it replaces
+// and that working set's hash. Must be called with txLock held.
+func (tx *DoltTransaction) prepareWrite(
+	ctx *sql.Context,
+	startPoint dbRoot,
+	startState *doltdb.WorkingSet,
+	workingSet *doltdb.WorkingSet,
+	mergeOpts editor.Options,
+) (*doltdb.WorkingSet, *doltdb.WorkingSet, hash.Hash, error) {
+	newWorkingSet := false
+
+	existingWs, err := startPoint.db.ResolveWorkingSet(ctx, workingSet.Ref())
+	if err == doltdb.ErrWorkingSetNotFound {
+		// This is to handle the case where an existing DB pre working sets is committing to this HEAD for the
+		// first time. Can be removed and called an error post 1.0
+		existingWs = doltdb.EmptyWorkingSet(workingSet.Ref())
+		newWorkingSet = true
+	} else if err != nil {
+		return nil, nil, hash.Hash{}, err
+	}
+
+	existingWSHash, err := existingWs.HashOf()
+	if err != nil {
+		return nil, nil, hash.Hash{}, err
+	}
+
+	if newWorkingSet || workingAndStagedEqual(existingWs, startState) {
+		// ff merge
+		err = tx.validateWorkingSetForCommit(ctx, workingSet, isFfMerge)
+		if err != nil {
+			return nil, nil, hash.Hash{}, err
+		}
+		return workingSet, existingWs, existingWSHash, nil
+	}
+
+	// otherwise (not a ff), merge the working sets together
+	start := time.Now()
+	mergedWorkingSet, err := tx.mergeRoots(ctx, startState, existingWs, workingSet, mergeOpts)
+	if err != nil {
+		return nil, nil, hash.Hash{}, err
+	}
+	logrus.Tracef("working set merge took %s", time.Since(start))
+
+	err = tx.validateWorkingSetForCommit(ctx, mergedWorkingSet, notFfMerge)
+	if err != nil {
+		return nil, nil, hash.Hash{}, err
+	}
+
+	return mergedWorkingSet, existingWs, existingWSHash, nil
+}
+
 // mergeRoots merges the roots in the existing working set with the one being committed and returns the resulting
 // working set. Conflicts are automatically resolved with "accept ours" if the session settings dictate it.
 // Currently merges working and staged roots as necessary. HEAD root is only handled by the DoltCommit function.
@@ -742,14 +842,14 @@ func (tx *DoltTransaction) validateWorkingSetForCommit(ctx *sql.Context, working
 	return nil
 }
 
-// CreateSavepoint creates a new savepoint with the name and roots given. If a savepoint with the name given
-// already exists, it's overwritten.
-func (tx *DoltTransaction) CreateSavepoint(name string, roots map[string]doltdb.RootValue) {
+// CreateSavepoint creates a new savepoint with the name and working set snapshot given. If a savepoint with the name
+// given already exists, it's overwritten.
+func (tx *DoltTransaction) CreateSavepoint(name string, snapshot WorkingSetSnapshot) {
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		tx.savepoints = append(tx.savepoints[:existing], tx.savepoints[existing+1:]...)
 	}
-	tx.savepoints = append(tx.savepoints, savepoint{name, roots})
+	tx.savepoints = append(tx.savepoints, savepoint{name, snapshot})
 }
 
 // findSavepoint returns the index of the savepoint with the name given, or -1 if it doesn't exist
@@ -762,23 +862,24 @@ func (tx *DoltTransaction) findSavepoint(name string) int {
 	return -1
 }
 
-// RollbackToSavepoint returns the root values for all applicable databases associated with the savepoint name given, or nil if no such savepoint can
-// be found. All savepoints created after the one being rolled back to are no longer accessible.
-func (tx *DoltTransaction) RollbackToSavepoint(name string) map[string]doltdb.RootValue {
+// RollbackToSavepoint returns the working set snapshot of the savepoint name given, and whether such a savepoint
+// exists. All savepoints created after the one being rolled back to are no longer accessible.
+func (tx *DoltTransaction) RollbackToSavepoint(name string) (WorkingSetSnapshot, bool) {
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
 		// Clear out any savepoints past this one
 		tx.savepoints = tx.savepoints[:existing+1]
-		return tx.savepoints[existing].roots
+		return tx.savepoints[existing].snapshot, true
 	}
-	return nil
+	return WorkingSetSnapshot{}, false
 }
 
-// ClearSavepoint removes the savepoint with the name given and returns whether a savepoint had that name
+// ClearSavepoint removes the savepoint with the name given, along with any savepoints created after it, and returns
+// whether a savepoint had that name
 func (tx *DoltTransaction) ClearSavepoint(name string) bool {
 	existing := tx.findSavepoint(name)
 	if existing >= 0 {
-		tx.savepoints = append(tx.savepoints[:existing], tx.savepoints[existing+1:]...)
+		tx.savepoints = tx.savepoints[:existing]
 		return true
 	}
 	return false
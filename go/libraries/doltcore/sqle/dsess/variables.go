@@ -48,12 +48,14 @@ const (
 	ReplicateHeads                       = "dolt_replicate_heads"
 	ReplicateAllHeads                    = "dolt_replicate_all_heads"
 	AsyncReplication                     = "dolt_async_replication"
+	AsyncReplicationMaxLagSecs           = "dolt_async_replication_max_lag_secs"
 	AwsCredsFile                         = "aws_credentials_file"
 	AwsCredsProfile                      = "aws_credentials_profile"
 	AwsCredsRegion                       = "aws_credentials_region"
 	ShowBranchDatabases                  = "dolt_show_branch_databases"
 	DoltLogLevel                         = "dolt_log_level"
 	ShowSystemTables                     = "dolt_show_system_tables"
+	SampleDataLength                     = "dolt_sample_data_length"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
@@ -65,6 +67,20 @@ const (
 	DoltStatsAutoRefreshInterval  = "dolt_stats_auto_refresh_interval"
 	DoltStatsMemoryOnly           = "dolt_stats_memory_only"
 	DoltStatsBranches             = "dolt_stats_branches"
+	DoltStatsColumnGroups         = "dolt_stats_column_groups"
+
+	DoltBackgroundMaxJobs    = "dolt_background_max_jobs"
+	DoltBackgroundCPULimit   = "dolt_background_cpu_limit"
+	DoltBackgroundIOLimit    = "dolt_background_io_limit"
+	DoltBackgroundPriorities = "dolt_background_priorities"
+
+	DoltDatabaseQuotas = "dolt_database_quotas"
+
+	DoltPessimisticLocking = "dolt_pessimistic_locking"
+
+	DoltAutoIncrementBatchSize = "dolt_auto_increment_batch_size"
+
+	DoltBinlogReplicaCommitInterval = "dolt_binlog_replica_commit_interval"
 )
 
 const URLTemplateDatabasePlaceholder = "{database}"
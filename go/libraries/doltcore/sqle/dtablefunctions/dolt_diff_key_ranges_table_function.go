@@ -0,0 +1,360 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const diffKeyRangesDefaultBuckets = 10
+
+var _ sql.TableFunction = (*DiffKeyRangesTableFunction)(nil)
+var _ sql.ExecSourceRel = (*DiffKeyRangesTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*DiffKeyRangesTableFunction)(nil)
+
+// DiffKeyRangesTableFunction implements the dolt_diff_key_ranges table function, which divides the rows of a table
+// into buckets of contiguous primary keys and counts the rows changed in each between two refs, the data of a
+// heatmap of where in a table's key space the changes are. It takes the same refs and table as dolt_diff, followed
+// by an optional number of buckets.
+type DiffKeyRangesTableFunction struct {
+	ctx *sql.Context
+
+	fromCommitExpr sql.Expression
+	toCommitExpr   sql.Expression
+	dotCommitExpr  sql.Expression
+	tableNameExpr  sql.Expression
+	bucketsExpr    sql.Expression
+	database       sql.Database
+}
+
+var diffKeyRangesTableSchema = sql.Schema{
+	&sql.Column{Name: "bucket", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "start_key", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "end_key", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "row_count", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_added", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_deleted", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_modified", Type: types.Int64, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dk *DiffKeyRangesTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &DiffKeyRangesTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (dk *DiffKeyRangesTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(dk.Schema())
+	numRows, _, err := dk.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (dk *DiffKeyRangesTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return diffKeyRangesDefaultBuckets, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (dk *DiffKeyRangesTableFunction) Database() sql.Database {
+	return dk.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dk *DiffKeyRangesTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ndk := *dk
+	ndk.database = database
+	return &ndk, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (dk *DiffKeyRangesTableFunction) Name() string {
+	return "dolt_diff_key_ranges"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dk *DiffKeyRangesTableFunction) Resolved() bool {
+	for _, expr := range dk.Expressions() {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+func (dk *DiffKeyRangesTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (dk *DiffKeyRangesTableFunction) String() string {
+	args := make([]string, 0, 4)
+	for _, expr := range dk.Expressions() {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_DIFF_KEY_RANGES(%s)", strings.Join(args, ", "))
+}
+
+// Schema implements the sql.Node interface.
+func (dk *DiffKeyRangesTableFunction) Schema() sql.Schema {
+	return diffKeyRangesTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (dk *DiffKeyRangesTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (dk *DiffKeyRangesTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dk, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (dk *DiffKeyRangesTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	if !types.IsText(dk.tableNameExpr.Type()) {
+		return ExpressionIsDeferred(dk.tableNameExpr)
+	}
+
+	tableNameVal, err := dk.tableNameExpr.Eval(dk.ctx, nil)
+	if err != nil {
+		return false
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return false
+	}
+
+	subject := sql.PrivilegeCheckSubject{Database: dk.database.Name(), Table: tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (dk *DiffKeyRangesTableFunction) Expressions() []sql.Expression {
+	exprs := []sql.Expression{}
+	if dk.dotCommitExpr != nil {
+		exprs = append(exprs, dk.dotCommitExpr)
+	} else {
+		exprs = append(exprs, dk.fromCommitExpr, dk.toCommitExpr)
+	}
+	exprs = append(exprs, dk.tableNameExpr)
+	if dk.bucketsExpr != nil {
+		exprs = append(exprs, dk.bucketsExpr)
+	}
+	return exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (dk *DiffKeyRangesTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(dk.Name(), "2 to 4", len(exprs))
+	}
+
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(dk.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(dk.Name(), expr.String())
+		}
+	}
+
+	ndk := *dk
+	ndk.dotCommitExpr, ndk.fromCommitExpr, ndk.toCommitExpr, ndk.bucketsExpr = nil, nil, nil, nil
+	var rest []sql.Expression
+	if strings.Contains(exprs[0].String(), "..") {
+		if len(exprs) > 3 {
+			return nil, sql.ErrInvalidArgumentNumber.New(ndk.Name(), "2 or 3", len(exprs))
+		}
+		ndk.dotCommitExpr = exprs[0]
+		rest = exprs[1:]
+	} else {
+		if len(exprs) < 3 || len(exprs) > 4 {
+			return nil, sql.ErrInvalidArgumentNumber.New(ndk.Name(), "3 or 4", len(exprs))
+		}
+		ndk.fromCommitExpr = exprs[0]
+		ndk.toCommitExpr = exprs[1]
+		rest = exprs[2:]
+	}
+	ndk.tableNameExpr = rest[0]
+	if len(rest) == 2 {
+		ndk.bucketsExpr = rest[1]
+	}
+
+	// validate the expressions
+	for _, expr := range []sql.Expression{ndk.dotCommitExpr, ndk.fromCommitExpr, ndk.toCommitExpr, ndk.tableNameExpr} {
+		if expr != nil && !types.IsText(expr.Type()) && !expression.IsBindVar(expr) {
+			return nil, sql.ErrInvalidArgumentDetails.New(ndk.Name(), expr.String())
+		}
+	}
+	if ndk.bucketsExpr != nil && !types.IsInteger(ndk.bucketsExpr.Type()) && !expression.IsBindVar(ndk.bucketsExpr) {
+		return nil, sql.ErrInvalidArgumentDetails.New(ndk.Name(), ndk.bucketsExpr.String())
+	}
+
+	return &ndk, nil
+}
+
+// RowIter implements the sql.Node interface
+func (dk *DiffKeyRangesTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	fromCommitVal, toCommitVal, dotCommitVal, tableName, buckets, err := dk.evaluateArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	sqledb, ok := dk.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", dk.database)
+	}
+
+	fromRefDetails, toRefDetails, err := loadDetailsForRefs(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
+	if err != nil {
+		return nil, err
+	}
+
+	delta, err := dk.tableDelta(ctx, fromRefDetails.root, toRefDetails.root, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyRanges, keyDesc, err := diff.KeyRangeBuckets(ctx, delta, buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(keyRanges))
+	for i, kr := range keyRanges {
+		rows[i] = sql.Row{
+			int64(i),
+			formatKeyRangeKey(keyDesc, kr.Start),
+			formatKeyRangeKey(keyDesc, kr.End),
+			int64(kr.Rows),
+			int64(kr.Adds),
+			int64(kr.Removes),
+			int64(kr.Changes),
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// tableDelta returns the delta of the table |tableName| between |fromRoot| and |toRoot|, which is a delta of the
+// table to itself if it's unchanged.
+func (dk *DiffKeyRangesTableFunction) tableDelta(ctx *sql.Context, fromRoot, toRoot doltdb.RootValue, tableName string) (diff.TableDelta, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+	delta := findMatchingDelta(deltas, tableName)
+	if delta.FromTable != nil || delta.ToTable != nil {
+		return delta, nil
+	}
+
+	tbl, name, ok, err := doltdb.GetTableInsensitive(ctx, toRoot, doltdb.TableName{Name: tableName})
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+	if !ok {
+		return diff.TableDelta{}, sql.ErrTableNotFound.New(tableName)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return diff.TableDelta{}, err
+	}
+	tblName := doltdb.TableName{Name: name}
+	return diff.TableDelta{
+		FromName:  tblName,
+		ToName:    tblName,
+		FromTable: tbl,
+		ToTable:   tbl,
+		FromSch:   sch,
+		ToSch:     sch,
+	}, nil
+}
+
+// evaluateArguments returns fromCommitVal, toCommitVal, dotCommitVal, tableName and the number of buckets.
+func (dk *DiffKeyRangesTableFunction) evaluateArguments() (interface{}, interface{}, interface{}, string, int, error) {
+	tableNameVal, err := dk.tableNameExpr.Eval(dk.ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", 0, err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return nil, nil, nil, "", 0, ErrInvalidTableName.New(dk.tableNameExpr.String())
+	}
+
+	buckets := diffKeyRangesDefaultBuckets
+	if dk.bucketsExpr != nil {
+		bucketsVal, err := dk.bucketsExpr.Eval(dk.ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", 0, err
+		}
+		n, _, err := types.Int64.Convert(bucketsVal)
+		if err != nil || n == nil || n.(int64) < 1 {
+			return nil, nil, nil, "", 0, sql.ErrInvalidArgumentDetails.New(dk.Name(), dk.bucketsExpr.String())
+		}
+		buckets = int(n.(int64))
+	}
+
+	if dk.dotCommitExpr != nil {
+		dotCommitVal, err := dk.dotCommitExpr.Eval(dk.ctx, nil)
+		if err != nil {
+			return nil, nil, nil, "", 0, err
+		}
+		return nil, nil, dotCommitVal, tableName, buckets, nil
+	}
+
+	fromCommitVal, err := dk.fromCommitExpr.Eval(dk.ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", 0, err
+	}
+	toCommitVal, err := dk.toCommitExpr.Eval(dk.ctx, nil)
+	if err != nil {
+		return nil, nil, nil, "", 0, err
+	}
+	return fromCommitVal, toCommitVal, nil, tableName, buckets, nil
+}
+
+// formatKeyRangeKey formats the primary key |key| as its comma separated values.
+func formatKeyRangeKey(keyDesc val.TupleDesc, key val.Tuple) string {
+	vals := make([]string, key.Count())
+	for i := range vals {
+		vals[i] = keyDesc.FormatValue(i, key.GetField(i))
+	}
+	return strings.Join(vals, ", ")
+}
@@ -170,10 +170,13 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 	// TODO: When we add support for joining on table functions, we'll need to evaluate this against the
 	//       specified row. That row is what has the left_table context in a join query.
 	//       This will expand the test cases we need to cover significantly.
-	fromCommitVal, toCommitVal, dotCommitVal, _, err := dtf.evaluateArguments()
+	fromCommitVal, toCommitVal, dotCommitVal, tableName, err := dtf.evaluateArguments()
 	if err != nil {
 		return nil, err
 	}
+	if err = dtables.CheckPrivilegesTableRead(ctx, tableName); err != nil {
+		return nil, err
+	}
 
 	sqledb, ok := dtf.database.(dsess.SqlDatabase)
 	if !ok {
@@ -255,31 +258,32 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 
 		if strings.Contains(dotStr, "...") {
 			refs := strings.Split(dotStr, "...")
+			fromStr, toStr := revisionOrHead(refs[0]), revisionOrHead(refs[1])
 
 			headRef, err := sess.CWBHeadRef(ctx, db.Name())
 			if err != nil {
 				return "", "", err
 			}
 
-			rightCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[0])
+			fromCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, mergeBaseRevision(fromStr))
 			if err != nil {
 				return "", "", err
 			}
 
-			leftCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, refs[1])
+			toCm, err := resolveCommit(ctx, db.DbData().Ddb, headRef, mergeBaseRevision(toStr))
 			if err != nil {
 				return "", "", err
 			}
 
-			mergeBase, err := merge.MergeBase(ctx, rightCm, leftCm)
+			mergeBase, err := merge.MergeBase(ctx, fromCm, toCm)
 			if err != nil {
 				return "", "", err
 			}
 
-			return mergeBase.String(), refs[1], nil
+			return mergeBase.String(), toStr, nil
 		} else {
 			refs := strings.Split(dotStr, "..")
-			return refs[0], refs[1], nil
+			return revisionOrHead(refs[0]), revisionOrHead(refs[1]), nil
 		}
 	}
 
@@ -296,6 +300,25 @@ func resolveCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{},
 	return fromStr, toStr, nil
 }
 
+// revisionOrHead returns |rev|, or HEAD if |rev| was omitted from one side of a two or three dot revision, as in
+// 'main...' or '..feature'.
+func revisionOrHead(rev string) string {
+	if len(rev) == 0 {
+		return "HEAD"
+	}
+	return rev
+}
+
+// mergeBaseRevision returns the commit used for |rev| when computing the merge base of a three dot revision. The
+// working set and staging area are not commits, so they share the merge base of the HEAD commit they are based on,
+// which lets 'main...WORKING' show everything changed on the current branch, including uncommitted changes.
+func mergeBaseRevision(rev string) string {
+	if rev == doltdb.Working || rev == doltdb.Staged {
+		return "HEAD"
+	}
+	return rev
+}
+
 // loadCommitStrings gets the to and from commit strings, using the common
 // ancestor as the from commit string for three dot diff
 func loadCommitStrings(ctx *sql.Context, fromRef, toRef, dotRef interface{}, db dsess.SqlDatabase) (string, string, error) {
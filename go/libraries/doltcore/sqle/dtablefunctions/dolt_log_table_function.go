@@ -48,6 +48,7 @@ type LogTableFunction struct {
 
 	minParents    int
 	showParents   bool
+	showGraph     bool
 	showSignature bool
 	decoration    string
 
@@ -145,6 +146,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, fmt.Sprintf("--%s", cli.ParentsFlag))
 	}
 
+	if ltf.showGraph {
+		options = append(options, fmt.Sprintf("--%s", cli.GraphFlag))
+	}
+
 	if ltf.showSignature {
 		options = append(options, fmt.Sprintf("--%s", cli.ShowSignatureFlag))
 	}
@@ -164,9 +169,12 @@ func (ltf *LogTableFunction) getOptionsString() string {
 func (ltf *LogTableFunction) Schema() sql.Schema {
 	logSchema := logTableSchema
 
-	if ltf.showParents {
+	if ltf.showParents || ltf.showGraph {
 		logSchema = append(logSchema, &sql.Column{Name: "parents", Type: types.Text})
 	}
+	if ltf.showGraph {
+		logSchema = append(logSchema, &sql.Column{Name: "lane", Type: types.Int32})
+	}
 	if shouldDecorateWithRefs(ltf.decoration) {
 		logSchema = append(logSchema, &sql.Column{Name: "refs", Type: types.Text})
 	}
@@ -265,6 +273,7 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 
 	ltf.minParents = minParents
 	ltf.showParents = apr.Contains(cli.ParentsFlag)
+	ltf.showGraph = apr.Contains(cli.GraphFlag)
 	ltf.showSignature = apr.Contains(cli.ShowSignatureFlag)
 
 	decorateOption := apr.GetValueOrDefault(cli.DecorateFlag, "auto")
@@ -598,6 +607,14 @@ func getCommitHashToRefs(ctx *sql.Context, ddb *doltdb.DoltDB, decoration string
 	return cHashToRefs, nil
 }
 
+// newLaneAssigner returns the LaneAssigner for a new row iter when the log is shown with --graph, and nil otherwise.
+func (ltf *LogTableFunction) newLaneAssigner() *commitwalk.LaneAssigner {
+	if !ltf.showGraph {
+		return nil
+	}
+	return commitwalk.NewLaneAssigner()
+}
+
 //------------------------------------
 // logTableFunctionRowIter
 //------------------------------------
@@ -608,6 +625,7 @@ var _ sql.RowIter = (*logTableFunctionRowIter)(nil)
 type logTableFunctionRowIter struct {
 	child         doltdb.CommitItr
 	showParents   bool
+	lanes         *commitwalk.LaneAssigner // assigns the lane column when the log is shown with --graph
 	showSignature bool
 	decoration    string
 	cHashToRefs   map[hash.Hash][]string
@@ -629,7 +647,8 @@ func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *d
 
 	return &logTableFunctionRowIter{
 		child:         child,
-		showParents:   ltf.showParents,
+		showParents:   ltf.showParents || ltf.showGraph,
+		lanes:         ltf.newLaneAssigner(),
 		showSignature: ltf.showSignature,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
@@ -670,7 +689,8 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 
 	return &logTableFunctionRowIter{
 		child:         child,
-		showParents:   ltf.showParents,
+		showParents:   ltf.showParents || ltf.showGraph,
+		lanes:         ltf.newLaneAssigner(),
 		showSignature: ltf.showSignature,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
@@ -767,11 +787,19 @@ func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
 	row := sql.NewRow(commitHash.String(), meta.Name, meta.Email, meta.Time(), meta.Description)
 
 	if itr.showParents {
-		prStr, err := getParentsString(ctx, commit)
+		parents, err := commit.ParentHashes(ctx)
 		if err != nil {
 			return nil, err
 		}
-		row = row.Append(sql.NewRow(prStr))
+		row = row.Append(sql.NewRow(getParentsString(parents)))
+
+		if itr.lanes != nil {
+			parentStrs := make([]string, len(parents))
+			for i, h := range parents {
+				parentStrs[i] = h.String()
+			}
+			row = row.Append(sql.NewRow(int32(itr.lanes.Next(commitHash.String(), parentStrs))))
+		}
 	}
 
 	if shouldDecorateWithRefs(itr.decoration) {
@@ -813,12 +841,7 @@ func getRefsString(branchNames []string, isHead bool) string {
 	return refStr
 }
 
-func getParentsString(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
-	parents, err := cm.ParentHashes(ctx)
-	if err != nil {
-		return "", err
-	}
-
+func getParentsString(parents []hash.Hash) string {
 	var prStr string
 	for i, h := range parents {
 		prStr += h.String()
@@ -827,7 +850,7 @@ func getParentsString(ctx *sql.Context, cm *doltdb.Commit) (string, error) {
 		}
 	}
 
-	return prStr, nil
+	return prStr
 }
 
 // Default ("auto") for the dolt_log table function is "no"
@@ -0,0 +1,283 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const mergePreviewDefaultRowCount = 10
+
+var _ sql.TableFunction = (*MergePreviewTableFunction)(nil)
+var _ sql.ExecSourceRel = (*MergePreviewTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*MergePreviewTableFunction)(nil)
+
+// MergePreviewTableFunction implements the dolt_merge_preview table function, which
+// reports what merging a branch would do to each table without merging it. It takes
+// the branch to merge, optionally preceded by the branch to merge it into, which
+// defaults to the current branch. Uncommitted changes are not part of the preview.
+type MergePreviewTableFunction struct {
+	ctx *sql.Context
+
+	baseExpr  sql.Expression
+	mergeExpr sql.Expression
+	database  sql.Database
+}
+
+var mergePreviewTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "diff_type", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "rows_added", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_modified", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_deleted", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "data_conflicts", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "schema_conflicts", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "constraint_violations", Type: types.Int64, Nullable: false},
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (mp *MergePreviewTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &MergePreviewTableFunction{
+		ctx:      ctx,
+		database: db,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func (mp *MergePreviewTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(mp.Schema())
+	numRows, _, err := mp.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (mp *MergePreviewTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return mergePreviewDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (mp *MergePreviewTableFunction) Database() sql.Database {
+	return mp.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (mp *MergePreviewTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	nmp := *mp
+	nmp.database = database
+	return &nmp, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (mp *MergePreviewTableFunction) Name() string {
+	return "dolt_merge_preview"
+}
+
+// Resolved implements the sql.Resolvable interface
+func (mp *MergePreviewTableFunction) Resolved() bool {
+	if mp.baseExpr != nil {
+		return mp.baseExpr.Resolved() && mp.mergeExpr.Resolved()
+	}
+	return mp.mergeExpr.Resolved()
+}
+
+func (mp *MergePreviewTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (mp *MergePreviewTableFunction) String() string {
+	if mp.baseExpr != nil {
+		return fmt.Sprintf("DOLT_MERGE_PREVIEW(%s, %s)", mp.baseExpr.String(), mp.mergeExpr.String())
+	}
+	return fmt.Sprintf("DOLT_MERGE_PREVIEW(%s)", mp.mergeExpr.String())
+}
+
+// Schema implements the sql.Node interface.
+func (mp *MergePreviewTableFunction) Schema() sql.Schema {
+	return mergePreviewTableSchema
+}
+
+// Children implements the sql.Node interface.
+func (mp *MergePreviewTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface.
+func (mp *MergePreviewTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return mp, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (mp *MergePreviewTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	tblNames, err := mp.database.GetTableNames(ctx)
+	if err != nil {
+		return false
+	}
+
+	var operations []sql.PrivilegedOperation
+	for _, tblName := range tblNames {
+		subject := sql.PrivilegeCheckSubject{Database: mp.database.Name(), Table: tblName}
+		operations = append(operations, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+	}
+
+	return opChecker.UserHasPrivileges(ctx, operations...)
+}
+
+// Expressions implements the sql.Expressioner interface.
+func (mp *MergePreviewTableFunction) Expressions() []sql.Expression {
+	if mp.baseExpr != nil {
+		return []sql.Expression{mp.baseExpr, mp.mergeExpr}
+	}
+	return []sql.Expression{mp.mergeExpr}
+}
+
+// WithExpressions implements the sql.Expressioner interface.
+func (mp *MergePreviewTableFunction) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) < 1 || len(exprs) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(mp.Name(), "1 or 2", len(exprs))
+	}
+
+	for _, expr := range exprs {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(mp.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(mp.Name(), expr.String())
+		}
+		if !types.IsText(expr.Type()) && !expression.IsBindVar(expr) {
+			return nil, sql.ErrInvalidArgumentDetails.New(mp.Name(), expr.String())
+		}
+	}
+
+	nmp := *mp
+	nmp.baseExpr = nil
+	if len(exprs) == 2 {
+		nmp.baseExpr = exprs[0]
+	}
+	nmp.mergeExpr = exprs[len(exprs)-1]
+	return &nmp, nil
+}
+
+// RowIter implements the sql.Node interface
+func (mp *MergePreviewTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqledb, ok := mp.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", mp.database)
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbName := sqledb.Name()
+
+	headRef, err := sess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	baseSpec := "HEAD"
+	if mp.baseExpr != nil {
+		if baseSpec, err = mp.evaluateArgument(ctx, mp.baseExpr); err != nil {
+			return nil, err
+		}
+	}
+	mergeSpec, err := mp.evaluateArgument(ctx, mp.mergeExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	ddb := sqledb.DbData().Ddb
+	baseCm, err := resolveCommit(ctx, ddb, headRef, baseSpec)
+	if err != nil {
+		return nil, err
+	}
+	mergeCm, err := resolveCommit(ctx, ddb, headRef, mergeSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	previews, err := merge.PreviewMerge(ctx, baseCm, mergeCm, dbState.EditOpts())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].Name.Less(previews[j].Name)
+	})
+
+	rows := make([]sql.Row, len(previews))
+	for i, p := range previews {
+		rows[i] = sql.Row{
+			p.Name.String(),
+			mergePreviewDiffType(p.Stats.Operation),
+			int64(p.Stats.Adds),
+			int64(p.Stats.Modifications),
+			int64(p.Stats.Deletes),
+			int64(p.Stats.DataConflicts),
+			int64(p.Stats.SchemaConflicts),
+			int64(p.Stats.ConstraintViolations),
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func (mp *MergePreviewTableFunction) evaluateArgument(ctx *sql.Context, expr sql.Expression) (string, error) {
+	val, err := expr.Eval(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	str, ok := val.(string)
+	if !ok || strings.TrimSpace(str) == "" {
+		return "", sql.ErrInvalidArgumentDetails.New(mp.Name(), expr.String())
+	}
+	return str, nil
+}
+
+func mergePreviewDiffType(op merge.TableMergeOp) string {
+	switch op {
+	case merge.TableAdded:
+		return "added"
+	case merge.TableRemoved:
+		return "removed"
+	case merge.TableModified:
+		return "modified"
+	default:
+		return "unmodified"
+	}
+}
@@ -162,7 +162,24 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 			}
 		}
 
+		if err = dtables.CheckPrivilegesTableRead(ctx, tableName); err != nil {
+			return nil, err
+		}
 		tableDeltas = []diff.TableDelta{delta}
+	} else {
+		// dolt_privileges is left out of patches of every table, unless the user can read it
+		var readable []diff.TableDelta
+		for _, delta := range tableDeltas {
+			name := delta.ToName.Name
+			if name == "" {
+				name = delta.FromName.Name
+			}
+			if dtables.IsPrivilegesTable(name) && dtables.CheckPrivilegesTableRead(ctx, name) != nil {
+				continue
+			}
+			readable = append(readable, delta)
+		}
+		tableDeltas = readable
 	}
 
 	includeSchemaDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), schemaChangePartitionKey)
@@ -69,6 +69,7 @@ var schemaDiffTableSchema = sql.Schema{
 	&sql.Column{Name: "to_table_name", Type: types.LongText, Nullable: false},     // 1
 	&sql.Column{Name: "from_create_statement", Type: types.Text, Nullable: false}, // 2
 	&sql.Column{Name: "to_create_statement", Type: types.Text, Nullable: false},   // 3
+	&sql.Column{Name: "migration_sql", Type: types.LongText, Nullable: false},     // 4
 }
 
 // NewInstance creates a new instance of TableFunction interface
@@ -334,11 +335,31 @@ func (ds *SchemaDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.R
 			continue
 		}
 
+		var migrationStmts []string
+		if isDbCollationDiff {
+			fromColl, err := fromRoot.GetCollation(ctx)
+			if err != nil {
+				return nil, err
+			}
+			toColl, err := toRoot.GetCollation(ctx)
+			if err != nil {
+				return nil, err
+			}
+			dbName := strings.TrimPrefix(toName.Name, diff.DBPrefix)
+			migrationStmts = []string{sqlfmt.AlterDatabaseCollateStmt(dbName, fromColl, toColl)}
+		} else {
+			migrationStmts, err = sqlfmt.GenerateSqlPatchSchemaStatements(ctx, toRoot, delta)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		row := sql.Row{
-			fromName.String(), // from_table_name
-			toName.String(),   // to_table_name
-			fromCreate,        // from_create_statement
-			toCreate,          // to_create_statement
+			fromName.String(),                  // from_table_name
+			toName.String(),                    // to_table_name
+			fromCreate,                         // from_create_statement
+			toCreate,                           // to_create_statement
+			strings.Join(migrationStmts, "\n"), // migration_sql
 		}
 		dataRows = append(dataRows, row)
 	}
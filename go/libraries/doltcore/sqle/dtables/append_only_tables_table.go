@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*AppendOnlyTablesTable)(nil)
+var _ sql.UpdatableTable = (*AppendOnlyTablesTable)(nil)
+var _ sql.DeletableTable = (*AppendOnlyTablesTable)(nil)
+var _ sql.InsertableTable = (*AppendOnlyTablesTable)(nil)
+var _ sql.ReplaceableTable = (*AppendOnlyTablesTable)(nil)
+var _ sql.IndexAddressableTable = (*AppendOnlyTablesTable)(nil)
+
+// AppendOnlyTablesTable is the system table that stores which tables are append-only on the branches whose names match
+// patterns. Only admins can write it, so that other users can't lift the restrictions it places on them.
+type AppendOnlyTablesTable struct {
+	versionedSystemTable
+}
+
+// appendOnlyTablesSchema returns the sql.Schema of the dolt_append_only_tables system table.
+func appendOnlyTablesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch_name", Type: sqlTypes.Text, Source: doltdb.AppendOnlyTablesTableName, PrimaryKey: true},
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.AppendOnlyTablesTableName, PrimaryKey: true},
+	}
+}
+
+// NewAppendOnlyTablesTable creates an AppendOnlyTablesTable for the database |dbName|, a revision qualified name, whose rows are stored in
+// |backingTable|.
+func NewAppendOnlyTablesTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	t := &AppendOnlyTablesTable{newVersionedSystemTable(doltdb.AppendOnlyTablesTableName, appendOnlyTablesSchema(), dbName, backingTable, schemaName)}
+	t.checkWrite = func(ctx *sql.Context) error {
+		return checkSuperPrivilege(ctx, doltdb.AppendOnlyTablesTableName)
+	}
+	return t
+}
+
+// NewEmptyAppendOnlyTablesTable creates an AppendOnlyTablesTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptyAppendOnlyTablesTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewAppendOnlyTablesTable(ctx, dbName, nil, schemaName)
+}
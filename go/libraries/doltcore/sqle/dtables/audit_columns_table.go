@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*AuditColumnsTable)(nil)
+var _ sql.UpdatableTable = (*AuditColumnsTable)(nil)
+var _ sql.DeletableTable = (*AuditColumnsTable)(nil)
+var _ sql.InsertableTable = (*AuditColumnsTable)(nil)
+var _ sql.ReplaceableTable = (*AuditColumnsTable)(nil)
+var _ sql.IndexAddressableTable = (*AuditColumnsTable)(nil)
+
+// AuditColumnsTable is the system table that stores the columns of tables which are maintained automatically when
+// their rows are written, such as the time a row was created or last updated.
+type AuditColumnsTable struct {
+	versionedSystemTable
+}
+
+// auditColumnRoleType is the type of the role column of the dolt_audit_columns system table.
+var auditColumnRoleType = sqlTypes.MustCreateEnumType(doltdb.AuditColumnRoleNames, sql.Collation_Default)
+
+// auditColumnsSchema returns the sql.Schema of the dolt_audit_columns system table.
+func auditColumnsSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.AuditColumnsTableName, PrimaryKey: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: doltdb.AuditColumnsTableName, PrimaryKey: true},
+		{Name: "role", Type: auditColumnRoleType, Source: doltdb.AuditColumnsTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// NewAuditColumnsTable creates an AuditColumnsTable for the database |dbName|, a revision qualified name, whose rows are stored in
+// |backingTable|.
+func NewAuditColumnsTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	return &AuditColumnsTable{newVersionedSystemTable(doltdb.AuditColumnsTableName, auditColumnsSchema(), dbName, backingTable, schemaName)}
+}
+
+// NewEmptyAuditColumnsTable creates an AuditColumnsTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptyAuditColumnsTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewAuditColumnsTable(ctx, dbName, nil, schemaName)
+}
@@ -0,0 +1,193 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// maxIndexedKeysPerCommit bounds the number of row keys recorded for the changes of a single commit to a table.
+// Commits that touch more rows than this are recorded as overflowed, and blame falls back to point lookups for them.
+const maxIndexedKeysPerCommit = 64 * 1024
+
+var errStopDiff = errors.New("change index entry overflowed")
+
+// touchedRows returns the rows of |tblName| changed by |cm| relative to its first parent. The changes of each commit
+// are kept by |ddb|, so that every later blame of the same history is answered with lookups into them instead of
+// re-diffing each commit, see doltdb.DoltDB.CommitChanges.
+func touchedRows(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tblName doltdb.TableName) (*doltdb.CommitChanges, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if changes, ok := ddb.CommitChanges(h, tblName.String()); ok {
+		return changes, nil
+	}
+
+	changes, err := diffCommitRows(ctx, cm, tblName)
+	if err != nil {
+		return nil, err
+	}
+	ddb.AddCommitChanges(h, tblName.String(), changes)
+	return changes, nil
+}
+
+// diffCommitRows computes the rows of |tblName| changed between |cm| and its first parent.
+func diffCommitRows(ctx context.Context, cm *doltdb.Commit, tblName doltdb.TableName) (*doltdb.CommitChanges, error) {
+	toTbl, err := tableAtCommit(ctx, cm, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if toTbl == nil || cm.NumParents() == 0 {
+		return &doltdb.CommitChanges{All: true}, nil
+	}
+
+	optCmt, err := cm.GetParent(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		// the history beyond a ghost commit is not available locally
+		return &doltdb.CommitChanges{All: true}, nil
+	}
+
+	fromTbl, err := tableAtCommit(ctx, parent, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if fromTbl == nil {
+		return &doltdb.CommitChanges{All: true}, nil
+	}
+
+	diffable, err := keysAreComparable(ctx, fromTbl, toTbl)
+	if err != nil {
+		return nil, err
+	}
+	if !diffable {
+		return &doltdb.CommitChanges{All: true}, nil
+	}
+
+	from, err := rowMapForTable(ctx, fromTbl)
+	if err != nil {
+		return nil, err
+	}
+	to, err := rowMapForTable(ctx, toTbl)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &doltdb.CommitChanges{Parent: parent, Keys: make(map[string]struct{})}
+	if from.HashOf() == to.HashOf() {
+		return changes, nil
+	}
+
+	err = prolly.DiffMaps(ctx, from, to, false, func(ctx context.Context, d tree.Diff) error {
+		if len(changes.Keys) >= maxIndexedKeysPerCommit {
+			changes.Overflow = true
+			changes.Keys = nil
+			return errStopDiff
+		}
+		changes.Keys[string(d.Key)] = struct{}{}
+		return nil
+	})
+	if err != nil && err != io.EOF && err != errStopDiff {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// rowChanged reports whether the row with key |k| differs between |from| and |to|. It is used for commits whose
+// changes overflowed the change index.
+func rowChanged(ctx context.Context, from, to prolly.Map, k val.Tuple) (bool, error) {
+	var fromVal, toVal val.Tuple
+	var fromOk, toOk bool
+	err := from.Get(ctx, k, func(_, v val.Tuple) error {
+		fromVal, fromOk = v, v != nil
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	err = to.Get(ctx, k, func(_, v val.Tuple) error {
+		toVal, toOk = v, v != nil
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if fromOk != toOk {
+		return true, nil
+	}
+	return string(fromVal) != string(toVal), nil
+}
+
+// keysAreComparable returns whether primary key tuples of |from| and |to| share an encoding, so that a key recorded
+// for one commit identifies the same row in another.
+func keysAreComparable(ctx context.Context, from, to *doltdb.Table) (bool, error) {
+	fromSch, err := from.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	toSch, err := to.GetSchema(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !schema.ArePrimaryKeySetsDiffable(from.Format(), fromSch, toSch) {
+		return false, nil
+	}
+
+	fromKd, _ := fromSch.GetMapDescriptors()
+	toKd, _ := toSch.GetMapDescriptors()
+	if fromKd.Count() != toKd.Count() {
+		return false, nil
+	}
+	for i := range fromKd.Types {
+		if fromKd.Types[i].Enc != toKd.Types[i].Enc {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// tableAtCommit returns the table named |tblName| in the root of |cm|, or nil if it does not exist there.
+func tableAtCommit(ctx context.Context, cm *doltdb.Commit, tblName doltdb.TableName) (*doltdb.Table, error) {
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, root, tblName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return tbl, nil
+}
+
+func rowMapForTable(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx), nil
+}
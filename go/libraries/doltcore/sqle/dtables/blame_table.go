@@ -0,0 +1,290 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var errUnblameableTable = errors.New("unable to generate blame view for table without primary key")
+
+var errBlameUnsupportedFormat = errors.New("dolt_blame is only supported for the __DOLT__ storage format")
+
+const blameDefaultRowCount = 1000
+
+var _ sql.Table = (*BlameTable)(nil)
+var _ sql.StatisticsTable = (*BlameTable)(nil)
+
+// BlameTable is a sql.Table implementation of the DOLT_BLAME system table, which shows the latest commit to modify
+// each row of a table. Rather than diffing the whole history of the table, blame walks the first-parent history of
+// the head commit and resolves each row with lookups into the change index, stopping as soon as every row has been
+// attributed to a commit.
+type BlameTable struct {
+	name      string
+	dbName    string
+	tableName doltdb.TableName
+	ddb       *doltdb.DoltDB
+	head      *doltdb.Commit
+	sqlSch    sql.Schema
+}
+
+// NewBlameTable creates a BlameTable for the table named |tblName| in |root|, blaming rows as of |head|.
+func NewBlameTable(ctx *sql.Context, dbName string, tblName doltdb.TableName, ddb *doltdb.DoltDB, root doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, errBlameUnsupportedFormat
+	}
+
+	var table *doltdb.Table
+	var err error
+	table, tblName, err = getTableInsensitiveOrError(ctx, root, tblName)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schema.IsKeyless(sch) {
+		return nil, errUnblameableTable
+	}
+
+	name := doltdb.DoltBlameViewPrefix + tblName.Name
+	sqlSch := make(sql.Schema, 0, sch.GetPKCols().Size()+5)
+	for _, pk := range sch.GetPKCols().GetColumns() {
+		sqlSch = append(sqlSch, &sql.Column{Name: pk.Name, Type: pk.TypeInfo.ToSqlType(), Source: name, Nullable: true, DatabaseSource: dbName})
+	}
+	sqlSch = append(sqlSch,
+		&sql.Column{Name: "commit", Type: gmstypes.MustCreateStringWithDefaults(sqltypes.VarChar, 1023), Source: name, Nullable: true, DatabaseSource: dbName},
+		&sql.Column{Name: "commit_date", Type: gmstypes.DatetimeMaxPrecision, Source: name, Nullable: true, DatabaseSource: dbName},
+		&sql.Column{Name: "committer", Type: gmstypes.Text, Source: name, DatabaseSource: dbName},
+		&sql.Column{Name: "email", Type: gmstypes.Text, Source: name, DatabaseSource: dbName},
+		&sql.Column{Name: "message", Type: gmstypes.Text, Source: name, DatabaseSource: dbName},
+	)
+
+	return &BlameTable{
+		name:      name,
+		dbName:    dbName,
+		tableName: tblName,
+		ddb:       ddb,
+		head:      head,
+		sqlSch:    sqlSch,
+	}, nil
+}
+
+// DataLength implements sql.StatisticsTable
+func (bt *BlameTable) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(bt.Schema())
+	numRows, _, err := bt.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+// RowCount implements sql.StatisticsTable
+func (bt *BlameTable) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return blameDefaultRowCount, false, nil
+}
+
+// Name implements sql.Table
+func (bt *BlameTable) Name() string {
+	return bt.name
+}
+
+// String implements sql.Table
+func (bt *BlameTable) String() string {
+	return bt.name
+}
+
+// Schema implements sql.Table
+func (bt *BlameTable) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Collation implements sql.Table
+func (bt *BlameTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements sql.Table
+func (bt *BlameTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements sql.Table
+func (bt *BlameTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	rows, err := bt.blame(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// blame attributes each row of the table at the head commit to the most recent first-parent commit that changed it.
+// Rows are returned in primary key order.
+func (bt *BlameTable) blame(ctx *sql.Context) ([]sql.Row, error) {
+	tbl, err := tableAtCommit(ctx, bt.head, bt.tableName)
+	if err != nil {
+		return nil, err
+	}
+	if tbl == nil {
+		// the table only exists in the working set
+		return nil, nil
+	}
+	rows, err := rowMapForTable(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []val.Tuple
+	unresolved := make(map[string]int)
+	iter, err := rows.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		k, _, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		k = val.Tuple(append([]byte(nil), k...))
+		unresolved[string(k)] = len(keys)
+		keys = append(keys, k)
+	}
+
+	blamed := make([]*doltdb.Commit, len(keys))
+	for cm := bt.head; cm != nil && len(unresolved) > 0; {
+		changes, err := touchedRows(ctx, bt.ddb, cm, bt.tableName)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case changes.All:
+			for k, i := range unresolved {
+				blamed[i] = cm
+				delete(unresolved, k)
+			}
+		case changes.Overflow:
+			if err = bt.resolveByLookup(ctx, cm, changes.Parent, keys, unresolved, blamed); err != nil {
+				return nil, err
+			}
+		case len(changes.Keys) < len(unresolved):
+			for k := range changes.Keys {
+				if i, ok := unresolved[k]; ok {
+					blamed[i] = cm
+					delete(unresolved, k)
+				}
+			}
+		default:
+			for k, i := range unresolved {
+				if _, ok := changes.Keys[k]; ok {
+					blamed[i] = cm
+					delete(unresolved, k)
+				}
+			}
+		}
+		cm = changes.Parent
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kd, _ := sch.GetMapDescriptors()
+	ns := tbl.NodeStore()
+	metas := make(map[hash.Hash]*datas.CommitMeta)
+	result := make([]sql.Row, 0, len(keys))
+	for i, k := range keys {
+		cm := blamed[i]
+		if cm == nil {
+			continue
+		}
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		meta, ok := metas[h]
+		if !ok {
+			if meta, err = cm.GetCommitMeta(ctx); err != nil {
+				return nil, err
+			}
+			metas[h] = meta
+		}
+
+		row := make(sql.Row, 0, len(bt.sqlSch))
+		for j := 0; j < kd.Count(); j++ {
+			f, err := tree.GetField(ctx, kd, j, k, ns)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, f)
+		}
+		row = append(row, h.String(), meta.Time(), meta.Name, meta.Email, meta.Description)
+		result = append(result, row)
+	}
+
+	return result, nil
+}
+
+// resolveByLookup attributes unresolved rows to |cm| by comparing each of them with |parent|. It is used when the
+// change index could not record every row |cm| touched.
+func (bt *BlameTable) resolveByLookup(ctx *sql.Context, cm, parent *doltdb.Commit, keys []val.Tuple, unresolved map[string]int, blamed []*doltdb.Commit) error {
+	toTbl, err := tableAtCommit(ctx, cm, bt.tableName)
+	if err != nil {
+		return err
+	}
+	fromTbl, err := tableAtCommit(ctx, parent, bt.tableName)
+	if err != nil {
+		return err
+	}
+	to, err := rowMapForTable(ctx, toTbl)
+	if err != nil {
+		return err
+	}
+	from, err := rowMapForTable(ctx, fromTbl)
+	if err != nil {
+		return err
+	}
+
+	for k, i := range unresolved {
+		changed, err := rowChanged(ctx, from, to, keys[i])
+		if err != nil {
+			return err
+		}
+		if changed {
+			blamed[i] = cm
+			delete(unresolved, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*ColumnMergeStrategiesTable)(nil)
+var _ sql.UpdatableTable = (*ColumnMergeStrategiesTable)(nil)
+var _ sql.DeletableTable = (*ColumnMergeStrategiesTable)(nil)
+var _ sql.InsertableTable = (*ColumnMergeStrategiesTable)(nil)
+var _ sql.ReplaceableTable = (*ColumnMergeStrategiesTable)(nil)
+var _ sql.IndexAddressableTable = (*ColumnMergeStrategiesTable)(nil)
+
+// ColumnMergeStrategiesTable is the system table that stores the strategies used to resolve columns modified
+// differently on both sides of a merge.
+type ColumnMergeStrategiesTable struct {
+	versionedSystemTable
+}
+
+// columnMergeStrategyType is the type of the strategy column of the dolt_column_merge_strategies system table.
+var columnMergeStrategyType = sqlTypes.MustCreateEnumType(doltdb.ColumnMergeStrategyNames, sql.Collation_Default)
+
+// columnMergeStrategiesSchema returns the sql.Schema of the dolt_column_merge_strategies system table.
+func columnMergeStrategiesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.ColumnMergeStrategiesTableName, PrimaryKey: true},
+		{Name: "column_name", Type: sqlTypes.Text, Source: doltdb.ColumnMergeStrategiesTableName, PrimaryKey: true},
+		{Name: "strategy", Type: columnMergeStrategyType, Source: doltdb.ColumnMergeStrategiesTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// NewColumnMergeStrategiesTable creates a ColumnMergeStrategiesTable for the database |dbName|, a revision qualified name, whose rows are stored in
+// |backingTable|.
+func NewColumnMergeStrategiesTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	return &ColumnMergeStrategiesTable{newVersionedSystemTable(doltdb.ColumnMergeStrategiesTableName, columnMergeStrategiesSchema(), dbName, backingTable, schemaName)}
+}
+
+// NewEmptyColumnMergeStrategiesTable creates a ColumnMergeStrategiesTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptyColumnMergeStrategiesTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewColumnMergeStrategiesTable(ctx, dbName, nil, schemaName)
+}
@@ -370,7 +370,7 @@ func (itr *prollyConflictRowIter) nextConflictVals(ctx *sql.Context) (c conf, er
 	b := xxh3.Hash128(append(ca.Key, c.h[:]...)).Bytes()
 	c.id = base64.RawStdEncoding.EncodeToString(b[:])
 
-	err = itr.loadTableMaps(ctx, ca.Metadata.BaseRootIsh, ca.TheirRootIsh)
+	err = itr.loadTableMaps(ctx, ca.Metadata, ca.TheirRootIsh)
 	if err != nil {
 		return conf{}, err
 	}
@@ -401,14 +401,16 @@ func (itr *prollyConflictRowIter) nextConflictVals(ctx *sql.Context) (c conf, er
 }
 
 // loadTableMaps loads the maps specified in the metadata if they are different from
-// the currently loaded maps. |baseHash| and |theirHash| are table hashes.
-func (itr *prollyConflictRowIter) loadTableMaps(ctx *sql.Context, baseHash, theirHash hash.Hash) error {
+// the currently loaded maps. |meta| holds the base hash, and |theirHash| is their root hash.
+func (itr *prollyConflictRowIter) loadTableMaps(ctx *sql.Context, meta prolly.ConflictMetadata, theirHash hash.Hash) error {
+	baseHash := meta.BaseRootIsh
 	if itr.baseHash.Compare(baseHash) != 0 {
 		rv, err := doltdb.LoadRootValueFromRootIshAddr(ctx, itr.vrw, itr.ns, baseHash)
 		if err != nil {
 			return err
 		}
-		baseTbl, ok, err := rv.GetTable(ctx, itr.tblName)
+		baseName := doltdb.TableName{Name: meta.BaseTableName(itr.tblName.Name), Schema: itr.tblName.Schema}
+		baseTbl, ok, err := rv.GetTable(ctx, baseName)
 		if err != nil {
 			return err
 		}
@@ -434,12 +436,13 @@ func (itr *prollyConflictRowIter) loadTableMaps(ctx *sql.Context, baseHash, thei
 			return err
 		}
 
-		theirTbl, ok, err := rv.GetTable(ctx, itr.tblName)
+		theirName := doltdb.TableName{Name: meta.TheirTableName(itr.tblName.Name), Schema: itr.tblName.Schema}
+		theirTbl, ok, err := rv.GetTable(ctx, theirName)
 		if err != nil {
 			return err
 		}
 		if !ok {
-			return fmt.Errorf("failed to find table %s in right root value", itr.tblName)
+			return fmt.Errorf("failed to find table %s in right root value", theirName)
 		}
 
 		idx, err := theirTbl.GetRowData(ctx)
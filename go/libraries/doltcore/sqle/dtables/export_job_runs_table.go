@@ -0,0 +1,90 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/exportjobs"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// ExportJobRunsTable is a sql.Table implementation that implements a system table which shows the most recent runs of
+// the export jobs of the database, as run by the server since it started.
+type ExportJobRunsTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*ExportJobRunsTable)(nil)
+
+// NewExportJobRunsTable creates an ExportJobRunsTable for the database |dbName|.
+func NewExportJobRunsTable(_ *sql.Context, dbName, tableName string) sql.Table {
+	return &ExportJobRunsTable{dbName: dbName, tableName: tableName}
+}
+
+// Name implements the interface sql.Table.
+func (et *ExportJobRunsTable) Name() string {
+	return et.tableName
+}
+
+// String implements the interface sql.Table.
+func (et *ExportJobRunsTable) String() string {
+	return et.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (et *ExportJobRunsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "job", Type: types.Text, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "started", Type: types.DatetimeMaxPrecision, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "finished", Type: types.DatetimeMaxPrecision, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "status", Type: types.Text, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "destination", Type: types.Text, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "row_count", Type: types.Uint64, Source: et.tableName, PrimaryKey: false, DatabaseSource: et.dbName},
+		{Name: "error", Type: types.Text, Source: et.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: et.dbName},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (et *ExportJobRunsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (et *ExportJobRunsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (et *ExportJobRunsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	baseName, _ := dsess.SplitRevisionDbName(et.dbName)
+	var rows []sql.Row
+	for _, run := range exportjobs.Runs() {
+		if !strings.EqualFold(run.Database, baseName) {
+			continue
+		}
+		status, errMsg := "success", interface{}(nil)
+		if !run.Succeeded() {
+			status, errMsg = "failure", run.Error
+		}
+		rows = append(rows, sql.Row{run.Job, run.Started, run.Finished, status, run.Destination, run.Rows, errMsg})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
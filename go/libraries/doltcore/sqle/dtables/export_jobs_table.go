@@ -0,0 +1,101 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/exportjobs"
+)
+
+var _ sql.Table = (*ExportJobsTable)(nil)
+var _ sql.UpdatableTable = (*ExportJobsTable)(nil)
+var _ sql.DeletableTable = (*ExportJobsTable)(nil)
+var _ sql.InsertableTable = (*ExportJobsTable)(nil)
+var _ sql.ReplaceableTable = (*ExportJobsTable)(nil)
+var _ sql.IndexAddressableTable = (*ExportJobsTable)(nil)
+
+// ExportJobsTable is the system table that stores the export jobs run on a schedule by sql-server, see
+// exportjobs.Scheduler. Jobs run queries and write files on the server, so only admins can write the table, and each
+// job runs as its definer, the user who last wrote it.
+type ExportJobsTable struct {
+	versionedSystemTable
+}
+
+// exportFormatType is the type of the format column of the dolt_export_jobs system table.
+var exportFormatType = sqlTypes.MustCreateEnumType(exportjobs.Formats, sql.Collation_Default)
+
+// exportJobsSchema returns the sql.Schema of the dolt_export_jobs system table.
+func exportJobsSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: true},
+		{Name: "schedule", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "query", Type: sqlTypes.LongText, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "destination", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: false},
+		{Name: "format", Type: exportFormatType, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "on_failure", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: true},
+		{Name: "definer", Type: sqlTypes.Text, Source: doltdb.ExportJobsTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// NewExportJobsTable creates an ExportJobsTable for the database |dbName|, a revision qualified name, whose rows are
+// stored in |backingTable|.
+func NewExportJobsTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	t := &ExportJobsTable{newVersionedSystemTable(doltdb.ExportJobsTableName, exportJobsSchema(), dbName, backingTable, schemaName)}
+	t.validateRow = validateExportJob
+	t.checkWrite = func(ctx *sql.Context) error {
+		return checkSuperPrivilege(ctx, doltdb.ExportJobsTableName)
+	}
+	t.completeRow = setExportJobDefiner
+	return t
+}
+
+// NewEmptyExportJobsTable creates an ExportJobsTable for the database |dbName|, a revision qualified name, which has
+// no backing table yet.
+func NewEmptyExportJobsTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewExportJobsTable(ctx, dbName, nil, schemaName)
+}
+
+// setExportJobDefiner returns the row |r| of dolt_export_jobs with its definer set to the user of |ctx|, whatever
+// definer was given.
+func setExportJobDefiner(ctx *sql.Context, r sql.Row) sql.Row {
+	r = r.Copy()
+	r[7] = exportjobs.Definer(ctx.Session.Client())
+	return r
+}
+
+// validateExportJob returns an error if the row |r| of dolt_export_jobs isn't a valid job: its schedule must parse,
+// it must export exactly one of a table and a query, and its notification URL, if any, must be an http(s) URL.
+func validateExportJob(r sql.Row) error {
+	name, _ := r[0].(string)
+	schedule, _ := r[1].(string)
+	if _, err := exportjobs.ParseSchedule(schedule); err != nil {
+		return fmt.Errorf("export job %s has an %w", name, err)
+	}
+	if (r[2] == nil || r[2] == "") == (r[3] == nil || r[3] == "") {
+		return fmt.Errorf("export job %s must have exactly one of table_name and query", name)
+	}
+	if onFailure, _ := r[6].(string); onFailure != "" {
+		if err := exportjobs.ValidateNotificationURL(onFailure); err != nil {
+			return fmt.Errorf("export job %s has an %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/federated"
+)
+
+var _ sql.Table = (*FederatedTablesTable)(nil)
+var _ sql.UpdatableTable = (*FederatedTablesTable)(nil)
+var _ sql.DeletableTable = (*FederatedTablesTable)(nil)
+var _ sql.InsertableTable = (*FederatedTablesTable)(nil)
+var _ sql.ReplaceableTable = (*FederatedTablesTable)(nil)
+var _ sql.IndexAddressableTable = (*FederatedTablesTable)(nil)
+
+// FederatedTablesTable is the system table that defines the federated tables of a database, whose rows are read from
+// tables on external MySQL servers, see federated.Table. Federated tables make the server connect to other servers,
+// so only admins can write the table, and its connections can't contain passwords, see federated.Connection.
+type FederatedTablesTable struct {
+	versionedSystemTable
+}
+
+// federatedTablesSchema returns the sql.Schema of the dolt_federated_tables system table.
+func federatedTablesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.FederatedTablesTableName, PrimaryKey: true},
+		{Name: "connection", Type: sqlTypes.Text, Source: doltdb.FederatedTablesTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// NewFederatedTablesTable creates a FederatedTablesTable for the database |dbName|, a revision qualified name, whose
+// rows are stored in |backingTable|.
+func NewFederatedTablesTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	t := &FederatedTablesTable{newVersionedSystemTable(doltdb.FederatedTablesTableName, federatedTablesSchema(), dbName, backingTable, schemaName)}
+	t.validateRow = validateFederatedTable
+	t.checkWrite = func(ctx *sql.Context) error {
+		return checkSuperPrivilege(ctx, doltdb.FederatedTablesTableName)
+	}
+	return t
+}
+
+// NewEmptyFederatedTablesTable creates a FederatedTablesTable for the database |dbName|, a revision qualified name,
+// which has no backing table yet.
+func NewEmptyFederatedTablesTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewFederatedTablesTable(ctx, dbName, nil, schemaName)
+}
+
+func (i *FederatedTablesTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	rows, err := i.versionedSystemTable.PartitionRows(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	return &redactedConnectionIter{RowIter: rows}, nil
+}
+
+// redactedConnectionIter redacts any password from the connections of the rows of dolt_federated_tables.
+type redactedConnectionIter struct {
+	sql.RowIter
+}
+
+func (itr *redactedConnectionIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.RowIter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if connection, ok := r[1].(string); ok {
+		r[1] = federated.RedactConnection(connection)
+	}
+	return r, nil
+}
+
+// validateFederatedTable returns an error if the row |r| of dolt_federated_tables isn't a valid federated table: its
+// name must not be that of a system table, and its connection must parse.
+func validateFederatedTable(r sql.Row) error {
+	name, _ := r[0].(string)
+	if name == "" || doltdb.HasDoltPrefix(name) {
+		return fmt.Errorf("invalid federated table name '%s'", name)
+	}
+	connection, _ := r[1].(string)
+	if _, err := federated.ParseConnection(connection); err != nil {
+		return fmt.Errorf("federated table %s has an %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/jobs"
+)
+
+// JobsTable is a sql.Table implementation that implements a system table which shows the jobs of the server, such as
+// clones started with dolt_clone and dolt_clone_async, with their progress. It shows the same jobs in every database.
+type JobsTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*JobsTable)(nil)
+
+// NewJobsTable creates a JobsTable for the database |dbName|.
+func NewJobsTable(_ *sql.Context, dbName, tableName string) sql.Table {
+	return &JobsTable{dbName: dbName, tableName: tableName}
+}
+
+// Name implements the interface sql.Table.
+func (jt *JobsTable) Name() string {
+	return jt.tableName
+}
+
+// String implements the interface sql.Table.
+func (jt *JobsTable) String() string {
+	return jt.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (jt *JobsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "job_id", Type: types.Uint64, Source: jt.tableName, PrimaryKey: true, DatabaseSource: jt.dbName},
+		{Name: "kind", Type: types.Text, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "database_name", Type: types.Text, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "status", Type: types.Text, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "started", Type: types.DatetimeMaxPrecision, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "finished", Type: types.DatetimeMaxPrecision, Source: jt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: jt.dbName},
+		{Name: "bytes_fetched", Type: types.Uint64, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "table_files", Type: types.Int64, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "table_files_remaining", Type: types.Int64, Source: jt.tableName, PrimaryKey: false, DatabaseSource: jt.dbName},
+		{Name: "error", Type: types.Text, Source: jt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: jt.dbName},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (jt *JobsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (jt *JobsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (jt *JobsTable) PartitionRows(_ *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	infos := jobs.Default().Jobs()
+	rows := make([]sql.Row, len(infos))
+	for i, j := range infos {
+		var finished, errMsg interface{}
+		if !j.Finished.IsZero() {
+			finished = j.Finished
+		}
+		if j.Error != "" {
+			errMsg = j.Error
+		}
+		rows[i] = sql.Row{j.ID, j.Kind, j.Database, string(j.Status), j.Started, finished,
+			j.Progress.BytesFetched, int64(j.Progress.TableFiles), int64(j.Progress.TableFilesRemaining), errMsg}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -39,11 +40,14 @@ type LogTable struct {
 	head              *doltdb.Commit
 	headHash          hash.Hash
 	headCommitClosure *prolly.CommitClosure
+	// filters are the filters on the commit metadata columns which are evaluated as the commit history is walked
+	filters []sql.Expression
 }
 
 var _ sql.Table = (*LogTable)(nil)
 var _ sql.StatisticsTable = (*LogTable)(nil)
 var _ sql.IndexAddressable = (*LogTable)(nil)
+var _ sql.IndexSearchable = (*LogTable)(nil)
 
 // NewLogTable creates a LogTable
 func NewLogTable(_ *sql.Context, dbName, tableName string, ddb *doltdb.DoltDB, head *doltdb.Commit) sql.Table {
@@ -111,7 +115,7 @@ func (dt *LogTable) PartitionRows(ctx *sql.Context, p sql.Partition) (sql.RowIte
 	case *doltdb.CommitPart:
 		return sql.RowsToRowIter(sql.NewRow(p.Hash().String(), p.Meta().Name, p.Meta().Email, p.Meta().Time(), p.Meta().Description)), nil
 	default:
-		return NewLogItr(ctx, dt.ddb, dt.head)
+		return NewLogItr(ctx, dt.ddb, dt.head, dt.Schema(), dt.filters)
 	}
 }
 
@@ -122,6 +126,9 @@ func (dt *LogTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
 // IndexedAccess implements sql.IndexAddressable
 func (dt *LogTable) IndexedAccess(lookup sql.IndexLookup) sql.IndexedTable {
 	nt := *dt
+	if idx, ok := lookup.Index.(*commitMetaIndex); ok {
+		nt.filters = idx.filters
+	}
 	return &nt
 }
 
@@ -130,6 +137,32 @@ func (dt *LogTable) PreciseMatch() bool {
 	return true
 }
 
+// SkipIndexCosting implements sql.IndexSearchable
+func (dt *LogTable) SkipIndexCosting() bool {
+	return false
+}
+
+// LookupForExpressions implements sql.IndexSearchable. Filters on the committer, email, date and message columns are
+// pushed down into the commit walk, so that commits which don't match them are skipped as the history is walked
+// instead of being returned as rows and filtered afterwards. Filters on the commit_hash column are left to the commit
+// hash index.
+func (dt *LogTable) LookupForExpressions(ctx *sql.Context, exprs ...sql.Expression) (sql.IndexLookup, *sql.FuncDepSet, sql.Expression, bool, error) {
+	if readsCommitHash(exprs) {
+		return sql.IndexLookup{}, nil, nil, false, nil
+	}
+	filters := commitMetaFilters(dt.tableName, exprs)
+	if len(filters) == 0 {
+		return sql.IndexLookup{}, nil, nil, false, nil
+	}
+
+	// the pushed down filters are also left in place, the commit walk only skips commits that can't match them
+	lookup := sql.IndexLookup{
+		Index:  newCommitMetaIndex(dt.dbName, dt.tableName, filters),
+		Ranges: sql.MySQLRangeCollection{},
+	}
+	return lookup, nil, expression.JoinAnd(exprs...), true, nil
+}
+
 func (dt *LogTable) LookupPartitions(ctx *sql.Context, lookup sql.IndexLookup) (sql.PartitionIter, error) {
 	if lookup.Index.ID() == index.CommitHashIndexId {
 		return dt.commitHashPartitionIter(ctx, lookup)
@@ -209,22 +242,34 @@ func (dt *LogTable) HeadHash() (hash.Hash, error) {
 
 // LogItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
 type LogItr struct {
-	child doltdb.CommitItr
+	child  doltdb.CommitItr
+	filter sql.Expression
 }
 
-// NewLogItr creates a LogItr from the current environment.
-func NewLogItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit) (*LogItr, error) {
+// NewLogItr creates a LogItr from the current environment. Only the commits which match |filters|, expressions over
+// the rows of a log table with the schema |sch|, are returned.
+func NewLogItr(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, sch sql.Schema, filters []sql.Expression) (*LogItr, error) {
 	h, err := head.HashOf()
 	if err != nil {
 		return nil, err
 	}
 
-	child, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, nil)
+	var filter sql.Expression
+	var matchFn func(*doltdb.OptionalCommit) (bool, error)
+	if len(filters) > 0 {
+		filter, err = bindCommitMetaFilters(sch, filters)
+		if err != nil {
+			return nil, err
+		}
+		matchFn = commitMetaMatchFn(ctx, filter)
+	}
+
+	child, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, []hash.Hash{h}, matchFn)
 	if err != nil {
 		return nil, err
 	}
 
-	return &LogItr{child}, nil
+	return &LogItr{child: child, filter: filter}, nil
 }
 
 // Next retrieves the next row. It will return io.EOF if it's the last row.
@@ -246,10 +291,13 @@ func (itr *LogItr) Next(ctx *sql.Context) (sql.Row, error) {
 		return nil, err
 	}
 
-	return sql.NewRow(h.String(), meta.Name, meta.Email, meta.Time(), meta.Description), nil
+	return commitMetaRow(h, meta), nil
 }
 
 // Close closes the iterator.
-func (itr *LogItr) Close(*sql.Context) error {
+func (itr *LogItr) Close(ctx *sql.Context) error {
+	if itr.filter != nil {
+		return closeFilter(ctx, itr.filter)
+	}
 	return nil
 }
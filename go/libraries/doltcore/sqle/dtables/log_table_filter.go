@@ -0,0 +1,245 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// CommitMetaIndexId is the id of the index used to push filters on the commit metadata columns of the dolt_log
+// system table down into its commit walk.
+const CommitMetaIndexId = "commit_meta"
+
+// logMetaColumns are the columns of the dolt_log system table which are read from the commit metadata.
+var logMetaColumns = map[string]struct{}{
+	"committer": {},
+	"email":     {},
+	"date":      {},
+	"message":   {},
+}
+
+// commitMetaFilters returns the filters of |exprs| which only read the commit metadata columns of the log table named
+// |tableName|: comparisons of the committer, email and date, and LIKE and REGEXP matches of the message. These can be
+// evaluated for each commit as the history is walked. Filters which read the commit_hash column are not returned,
+// those are served by the commit hash index instead.
+func commitMetaFilters(tableName string, exprs []sql.Expression) []sql.Expression {
+	var filters []sql.Expression
+	for _, e := range exprs {
+		switch e.(type) {
+		case *expression.Equals, *expression.NullSafeEquals, *expression.GreaterThan, *expression.GreaterThanOrEqual,
+			*expression.LessThan, *expression.LessThanOrEqual, *expression.Between, *expression.InTuple,
+			*expression.HashInTuple, *expression.Like, *function.RegexpLike:
+		default:
+			continue
+		}
+
+		var readsColumn bool
+		unsupported := transform.InspectExpr(e, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case *expression.GetField:
+				if _, ok := logMetaColumns[strings.ToLower(e.Name())]; !ok || !strings.EqualFold(e.Table(), tableName) {
+					return true
+				}
+				readsColumn = true
+			case *plan.Subquery, *expression.BindVar, *expression.ProcedureParam:
+				return true
+			case sql.NonDeterministicExpression:
+				return e.IsNonDeterministic()
+			}
+			return false
+		})
+		if readsColumn && !unsupported {
+			filters = append(filters, e)
+		}
+	}
+	return filters
+}
+
+// readsCommitHash returns whether any of |exprs| reads the commit_hash column.
+func readsCommitHash(exprs []sql.Expression) bool {
+	for _, e := range exprs {
+		found := transform.InspectExpr(e, func(e sql.Expression) bool {
+			gf, ok := e.(*expression.GetField)
+			return ok && strings.EqualFold(gf.Name(), "commit_hash")
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// bindCommitMetaFilters returns the conjunction of |filters| with their field indexes bound to the rows of a log table
+// with the schema |sch|. The field indexes of the filters are relative to the rows of the node they were pushed out
+// of, which aren't necessarily the rows of the table.
+func bindCommitMetaFilters(sch sql.Schema, filters []sql.Expression) (sql.Expression, error) {
+	filter, _, err := transform.Expr(expression.JoinAnd(filters...), func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+		if gf, ok := e.(*expression.GetField); ok {
+			return gf.WithIndex(sch.IndexOfColName(gf.Name())), transform.NewTree, nil
+		}
+		return e, transform.SameTree, nil
+	})
+	return filter, err
+}
+
+// commitMetaMatchFn returns a function which evaluates |filter| against the row of each commit of a commit walk, so
+// that only the commits which match it are returned.
+func commitMetaMatchFn(ctx *sql.Context, filter sql.Expression) func(*doltdb.OptionalCommit) (bool, error) {
+	return func(optCmt *doltdb.OptionalCommit) (bool, error) {
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return false, doltdb.ErrGhostCommitRuntimeFailure
+		}
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return false, err
+		}
+		res, err := sql.EvaluateCondition(ctx, filter, commitMetaRow(optCmt.Addr, meta))
+		if err != nil {
+			return false, err
+		}
+		return sql.IsTrue(res), nil
+	}
+}
+
+// closeFilter closes the expressions of |filter|, such as compiled regular expressions, which hold resources that must
+// be released once it is no longer evaluated.
+func closeFilter(ctx *sql.Context, filter sql.Expression) error {
+	var err error
+	transform.InspectExpr(filter, func(e sql.Expression) bool {
+		if c, ok := e.(sql.Closer); ok {
+			err = c.Close(ctx)
+		}
+		return err != nil
+	})
+	return err
+}
+
+func commitMetaRow(h hash.Hash, meta *datas.CommitMeta) sql.Row {
+	return sql.NewRow(h.String(), meta.Name, meta.Email, meta.Time(), meta.Description)
+}
+
+// commitMetaIndex is the index of a lookup into a log table which carries the filters on its commit metadata
+// columns. Its lookups have no ranges, the filters are evaluated as the commit history is walked.
+type commitMetaIndex struct {
+	dbName    string
+	tableName string
+	filters   []sql.Expression
+}
+
+var _ sql.Index = (*commitMetaIndex)(nil)
+
+func newCommitMetaIndex(dbName, tableName string, filters []sql.Expression) *commitMetaIndex {
+	return &commitMetaIndex{dbName: dbName, tableName: tableName, filters: filters}
+}
+
+// ID implements sql.Index
+func (idx *commitMetaIndex) ID() string {
+	return CommitMetaIndexId
+}
+
+// Database implements sql.Index
+func (idx *commitMetaIndex) Database() string {
+	return idx.dbName
+}
+
+// Table implements sql.Index
+func (idx *commitMetaIndex) Table() string {
+	return idx.tableName
+}
+
+// Expressions implements sql.Index, it returns the columns read by the filters of the index.
+func (idx *commitMetaIndex) Expressions() []string {
+	var exprs []string
+	seen := make(map[string]struct{})
+	for _, f := range idx.filters {
+		transform.InspectExpr(f, func(e sql.Expression) bool {
+			if gf, ok := e.(*expression.GetField); ok {
+				name := idx.tableName + "." + strings.ToLower(gf.Name())
+				if _, ok := seen[name]; !ok {
+					seen[name] = struct{}{}
+					exprs = append(exprs, name)
+				}
+			}
+			return false
+		})
+	}
+	return exprs
+}
+
+// IsUnique implements sql.Index
+func (idx *commitMetaIndex) IsUnique() bool {
+	return false
+}
+
+// IsSpatial implements sql.Index
+func (idx *commitMetaIndex) IsSpatial() bool {
+	return false
+}
+
+// IsFullText implements sql.Index
+func (idx *commitMetaIndex) IsFullText() bool {
+	return false
+}
+
+// IsVector implements sql.Index
+func (idx *commitMetaIndex) IsVector() bool {
+	return false
+}
+
+// Comment implements sql.Index
+func (idx *commitMetaIndex) Comment() string {
+	return ""
+}
+
+// IndexType implements sql.Index
+func (idx *commitMetaIndex) IndexType() string {
+	return "BTREE"
+}
+
+// IsGenerated implements sql.Index
+func (idx *commitMetaIndex) IsGenerated() bool {
+	return true
+}
+
+// ColumnExpressionTypes implements sql.Index
+func (idx *commitMetaIndex) ColumnExpressionTypes() []sql.ColumnExpressionType {
+	return nil
+}
+
+// CanSupport implements sql.Index, the lookups of a commitMetaIndex have no ranges.
+func (idx *commitMetaIndex) CanSupport(ranges ...sql.Range) bool {
+	return len(ranges) == 0
+}
+
+// CanSupportOrderBy implements sql.Index
+func (idx *commitMetaIndex) CanSupportOrderBy(sql.Expression) bool {
+	return false
+}
+
+// PrefixLengths implements sql.Index
+func (idx *commitMetaIndex) PrefixLengths() []uint16 {
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*MergeStrategiesTable)(nil)
+var _ sql.UpdatableTable = (*MergeStrategiesTable)(nil)
+var _ sql.DeletableTable = (*MergeStrategiesTable)(nil)
+var _ sql.InsertableTable = (*MergeStrategiesTable)(nil)
+var _ sql.ReplaceableTable = (*MergeStrategiesTable)(nil)
+var _ sql.IndexAddressableTable = (*MergeStrategiesTable)(nil)
+
+// MergeStrategiesTable is the system table that stores the strategies used to merge tables whose names match patterns.
+type MergeStrategiesTable struct {
+	versionedSystemTable
+}
+
+// mergeStrategyType is the type of the strategy column of the dolt_merge_strategies system table.
+var mergeStrategyType = sqlTypes.MustCreateEnumType(doltdb.MergeStrategyNames, sql.Collation_Default)
+
+// mergeStrategiesSchema returns the sql.Schema of the dolt_merge_strategies system table.
+func mergeStrategiesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "pattern", Type: sqlTypes.Text, Source: doltdb.MergeStrategiesTableName, PrimaryKey: true},
+		{Name: "strategy", Type: mergeStrategyType, Source: doltdb.MergeStrategiesTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+// NewMergeStrategiesTable creates a MergeStrategiesTable for the database |dbName|, a revision qualified name, whose rows are stored in
+// |backingTable|.
+func NewMergeStrategiesTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	return &MergeStrategiesTable{newVersionedSystemTable(doltdb.MergeStrategiesTableName, mergeStrategiesSchema(), dbName, backingTable, schemaName)}
+}
+
+// NewEmptyMergeStrategiesTable creates a MergeStrategiesTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptyMergeStrategiesTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewMergeStrategiesTable(ctx, dbName, nil, schemaName)
+}
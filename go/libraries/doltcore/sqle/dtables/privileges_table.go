@@ -0,0 +1,106 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// privilegesTablePrefixes are the prefixes of the system tables which read the rows of another table, such as its
+// history or diffs
+var privilegesTablePrefixes = []string{
+	"",
+	doltdb.DoltBlameViewPrefix,
+	doltdb.DoltHistoryTablePrefix,
+	doltdb.DoltDiffTablePrefix,
+	doltdb.DoltCommitDiffTablePrefix,
+	doltdb.DoltConfTablePrefix,
+	doltdb.DoltConstViolTablePrefix,
+	doltdb.DoltWorkspaceTablePrefix,
+}
+
+// IsPrivilegesTable returns whether |tableName| is dolt_privileges, or one of the system tables which read its rows.
+func IsPrivilegesTable(tableName string) bool {
+	lwr := strings.ToLower(tableName)
+	for _, prefix := range privilegesTablePrefixes {
+		if lwr == prefix+doltdb.PrivilegesTableName {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPrivilegesTableRead returns an error if |tableName| is dolt_privileges, or one of the system tables which read
+// its rows, and the user of |ctx| isn't an admin. dolt_privileges holds the password hashes of every user of the
+// server, so only users with the SUPER privilege can read it.
+func CheckPrivilegesTableRead(ctx *sql.Context, tableName string) error {
+	if !IsPrivilegesTable(tableName) {
+		return nil
+	}
+	return checkSuperPrivilege(ctx, tableName)
+}
+
+// checkSuperPrivilege returns an error if the user of |ctx| isn't an admin, and so can't access |tableName|.
+func checkSuperPrivilege(ctx *sql.Context, tableName string) error {
+	privs, counter := ctx.GetPrivilegeSet()
+	if counter == 0 {
+		return fmt.Errorf("unable to check user privileges for %s", tableName)
+	}
+	if !privs.Has(sql.PrivilegeType_Super) {
+		return sql.ErrPrivilegeCheckFailed.New(ctx.Session.Client().User)
+	}
+	return nil
+}
+
+// PrivilegesTable is dolt_privileges, or one of the system tables which read its rows, which can only be read by
+// admins, see CheckPrivilegesTableRead.
+type PrivilegesTable struct {
+	sql.Table
+	name string
+}
+
+var _ sql.Table = PrivilegesTable{}
+
+// NewPrivilegesTable returns |table|, resolved as |tableName|, as a PrivilegesTable.
+func NewPrivilegesTable(tableName string, table sql.Table) PrivilegesTable {
+	return PrivilegesTable{Table: table, name: tableName}
+}
+
+// Unrestricted returns the table, which can be read by any user. It isn't a sql.TableWrapper so that the table can't
+// be read by queries.
+func (t PrivilegesTable) Unrestricted() sql.Table {
+	return t.Table
+}
+
+// Partitions implements sql.Table
+func (t PrivilegesTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	if err := checkSuperPrivilege(ctx, t.name); err != nil {
+		return nil, err
+	}
+	return t.Table.Partitions(ctx)
+}
+
+// PartitionRows implements sql.Table
+func (t PrivilegesTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if err := checkSuperPrivilege(ctx, t.name); err != nil {
+		return nil, err
+	}
+	return t.Table.PartitionRows(ctx, partition)
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPrivilegesTableRead(t *testing.T) {
+	for _, name := range []string{"dolt_privileges", "DOLT_PRIVILEGES", "dolt_diff_dolt_privileges", "dolt_history_dolt_privileges"} {
+		assert.True(t, IsPrivilegesTable(name), name)
+	}
+	for _, name := range []string{"privileges", "my_dolt_privileges", "dolt_diff_t"} {
+		assert.False(t, IsPrivilegesTable(name), name)
+	}
+
+	ctx := sql.NewEmptyContext()
+	privs := mysql_db.NewPrivilegeSet()
+	privs.AddGlobalStatic(sql.PrivilegeType_Select)
+	ctx.Session.SetPrivilegeSet(privs, 1)
+	assert.NoError(t, CheckPrivilegesTableRead(ctx, "t"))
+	assert.True(t, sql.ErrPrivilegeCheckFailed.Is(CheckPrivilegesTableRead(ctx, "dolt_privileges")))
+	assert.True(t, sql.ErrPrivilegeCheckFailed.Is(CheckPrivilegesTableRead(ctx, "dolt_diff_dolt_privileges")))
+
+	privs.AddGlobalStatic(sql.PrivilegeType_Super)
+	assert.NoError(t, CheckPrivilegesTableRead(ctx, "dolt_privileges"))
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// QuotasTable is a sql.Table implementation that implements a system table which shows the disk quota of the
+// database, as configured by @@dolt_database_quotas, alongside the bytes the database uses.
+type QuotasTable struct {
+	dbName    string
+	tableName string
+	ddb       *doltdb.DoltDB
+}
+
+var _ sql.Table = (*QuotasTable)(nil)
+
+// NewQuotasTable creates a QuotasTable for the database |dbName|.
+func NewQuotasTable(_ *sql.Context, dbName, tableName string, ddb *doltdb.DoltDB) sql.Table {
+	return &QuotasTable{dbName: dbName, tableName: tableName, ddb: ddb}
+}
+
+// Name implements the interface sql.Table.
+func (qt *QuotasTable) Name() string {
+	return qt.tableName
+}
+
+// String implements the interface sql.Table.
+func (qt *QuotasTable) String() string {
+	return qt.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (qt *QuotasTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "database_name", Type: types.Text, Source: qt.tableName, PrimaryKey: true, DatabaseSource: qt.dbName},
+		{Name: "quota_bytes", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: qt.dbName},
+		{Name: "used_bytes", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, DatabaseSource: qt.dbName},
+		{Name: "pending_bytes", Type: types.Uint64, Source: qt.tableName, PrimaryKey: false, DatabaseSource: qt.dbName},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (qt *QuotasTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (qt *QuotasTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (qt *QuotasTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	persisted, pending, err := qt.ddb.StoreSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseName, _ := dsess.SplitRevisionDbName(qt.dbName)
+	var quota interface{}
+	if q, ok := dsess.QuotaFor(baseName); ok {
+		quota = q
+	}
+	return sql.RowsToRowIter(sql.Row{baseName, quota, persisted, pending}), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// ReplicationStatusTable is a sql.Table implementation that implements a system table which shows the status of the
+// push-on-write replication of the database to each of the remotes in @@dolt_replicate_to_remote.
+type ReplicationStatusTable struct {
+	dbName    string
+	tableName string
+	ddb       *doltdb.DoltDB
+}
+
+var _ sql.Table = (*ReplicationStatusTable)(nil)
+
+// NewReplicationStatusTable creates a ReplicationStatusTable for the database |dbName|.
+func NewReplicationStatusTable(_ *sql.Context, dbName, tableName string, ddb *doltdb.DoltDB) sql.Table {
+	return &ReplicationStatusTable{dbName: dbName, tableName: tableName, ddb: ddb}
+}
+
+// Name implements the interface sql.Table.
+func (rt *ReplicationStatusTable) Name() string {
+	return rt.tableName
+}
+
+// String implements the interface sql.Table.
+func (rt *ReplicationStatusTable) String() string {
+	return rt.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (rt *ReplicationStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "remote", Type: types.Text, Source: rt.tableName, PrimaryKey: true, DatabaseSource: rt.dbName},
+		{Name: "last_pushed_ref", Type: types.Text, Source: rt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: rt.dbName},
+		{Name: "last_pushed_commit", Type: types.Text, Source: rt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: rt.dbName},
+		{Name: "last_push_time", Type: types.DatetimeMaxPrecision, Source: rt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: rt.dbName},
+		{Name: "pending_refs", Type: types.Int64, Source: rt.tableName, PrimaryKey: false, DatabaseSource: rt.dbName},
+		{Name: "lag_seconds", Type: types.Float64, Source: rt.tableName, PrimaryKey: false, DatabaseSource: rt.dbName},
+		{Name: "failed_attempts", Type: types.Int64, Source: rt.tableName, PrimaryKey: false, DatabaseSource: rt.dbName},
+		{Name: "last_error", Type: types.Text, Source: rt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: rt.dbName},
+		{Name: "next_attempt_time", Type: types.DatetimeMaxPrecision, Source: rt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: rt.dbName},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (rt *ReplicationStatusTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (rt *ReplicationStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (rt *ReplicationStatusTable) PartitionRows(_ *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	statuses := rt.ddb.ReplicationStatuses()
+	rows := make([]sql.Row, len(statuses))
+	for i, s := range statuses {
+		var pushedRef, pushedCommit, pushTime, lastErr, nextAttempt interface{}
+		if !s.LastPushTime.IsZero() {
+			pushedRef, pushTime = s.LastPushedRef, s.LastPushTime
+			if !s.LastPushedCommit.IsEmpty() {
+				pushedCommit = s.LastPushedCommit.String()
+			}
+		}
+		if s.LastError != "" {
+			lastErr = s.LastError
+		}
+		if !s.NextAttempt.IsZero() {
+			nextAttempt = s.NextAttempt
+		}
+		rows[i] = sql.Row{s.Remote, pushedRef, pushedCommit, pushTime, int64(s.Pending), s.Lag.Seconds(), int64(s.FailedAttempts), lastErr, nextAttempt}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
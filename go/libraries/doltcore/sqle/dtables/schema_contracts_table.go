@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+var _ sql.Table = (*SchemaContractsTable)(nil)
+var _ sql.UpdatableTable = (*SchemaContractsTable)(nil)
+var _ sql.DeletableTable = (*SchemaContractsTable)(nil)
+var _ sql.InsertableTable = (*SchemaContractsTable)(nil)
+var _ sql.ReplaceableTable = (*SchemaContractsTable)(nil)
+var _ sql.IndexAddressableTable = (*SchemaContractsTable)(nil)
+
+// SchemaContractsTable is the system table that stores the schema compatibility policies enforced on pushes and merges
+// to the branches whose names match patterns.
+type SchemaContractsTable struct {
+	versionedSystemTable
+}
+
+// schemaPolicyType is the type of the policy column of the dolt_schema_contracts system table.
+var schemaPolicyType = sqlTypes.MustCreateEnumType(doltdb.SchemaPolicyNames, sql.Collation_Default)
+
+// schemaContractsSchema returns the sql.Schema of the dolt_schema_contracts system table.
+func schemaContractsSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch_name", Type: sqlTypes.Text, Source: doltdb.SchemaContractsTableName, PrimaryKey: true},
+		{Name: "table_name", Type: sqlTypes.Text, Source: doltdb.SchemaContractsTableName, PrimaryKey: true},
+		{Name: "policy", Type: schemaPolicyType, Source: doltdb.SchemaContractsTableName, PrimaryKey: true},
+	}
+}
+
+// NewSchemaContractsTable creates a SchemaContractsTable for the database |dbName|, a revision qualified name, whose rows are stored in
+// |backingTable|.
+func NewSchemaContractsTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string) sql.Table {
+	return &SchemaContractsTable{newVersionedSystemTable(doltdb.SchemaContractsTableName, schemaContractsSchema(), dbName, backingTable, schemaName)}
+}
+
+// NewEmptySchemaContractsTable creates a SchemaContractsTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptySchemaContractsTable(ctx *sql.Context, dbName string, schemaName string) sql.Table {
+	return NewSchemaContractsTable(ctx, dbName, nil, schemaName)
+}
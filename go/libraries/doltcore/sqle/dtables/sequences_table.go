@@ -0,0 +1,413 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
+)
+
+var _ sql.Table = (*SequencesTable)(nil)
+var _ sql.UpdatableTable = (*SequencesTable)(nil)
+var _ sql.DeletableTable = (*SequencesTable)(nil)
+var _ sql.InsertableTable = (*SequencesTable)(nil)
+var _ sql.ReplaceableTable = (*SequencesTable)(nil)
+var _ sql.IndexAddressableTable = (*SequencesTable)(nil)
+
+// SequencesTable is the system table that stores the sequences of a database. Each row is a sequence, created by
+// inserting it and dropped by deleting it, whose values are handed out by the nextval function. Since its state is
+// kept in this table, a sequence is versioned like the tables it's used with: it's advanced by transactions, and
+// branches advance their own copies of it. Values are handed out under a lock of the database's
+// globalstate.SequenceTracker, which tracks the last value of each sequence across transactions and branches, so that
+// no value is handed out twice, as for auto increment columns. See globalstate.SequenceTracker for how setval and
+// dropping a sequence on one branch affect the others.
+type SequencesTable struct {
+	versionedSystemTable
+	// tracker tracks the values handed out by the sequences across transactions and branches, if it isn't nil
+	tracker globalstate.SequenceTracker
+}
+
+// The columns of the dolt_sequences system table.
+const (
+	SequenceNameIdx = iota
+	SequenceStartValueIdx
+	SequenceMinValueIdx
+	SequenceMaxValueIdx
+	SequenceIncrementIdx
+	SequenceCycleIdx
+	SequenceCurrentValueIdx
+)
+
+// sequencesSchema returns the sql.Schema of the dolt_sequences system table.
+func sequencesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: sqlTypes.Text, Source: doltdb.SequencesTableName, PrimaryKey: true},
+		{Name: "start_value", Type: sqlTypes.Int64, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "min_value", Type: sqlTypes.Int64, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "max_value", Type: sqlTypes.Int64, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "increment", Type: sqlTypes.Int64, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "cycle", Type: sqlTypes.Boolean, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "current_value", Type: sqlTypes.Int64, Source: doltdb.SequencesTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// NewSequencesTable creates a SequencesTable for the database |dbName|, a revision qualified name, whose rows are
+// stored in |backingTable|.
+func NewSequencesTable(_ *sql.Context, dbName string, backingTable VersionableTable, schemaName string, tracker globalstate.SequenceTracker) sql.Table {
+	return &SequencesTable{
+		versionedSystemTable: newVersionedSystemTable(doltdb.SequencesTableName, sequencesSchema(), dbName, backingTable, schemaName),
+		tracker:              tracker,
+	}
+}
+
+// NewEmptySequencesTable creates a SequencesTable for the database |dbName|, a revision qualified name, which has no
+// backing table yet.
+func NewEmptySequencesTable(ctx *sql.Context, dbName string, schemaName string, tracker globalstate.SequenceTracker) sql.Table {
+	return NewSequencesTable(ctx, dbName, nil, schemaName, tracker)
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (it *SequencesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return newSequencesWriter(it, true)
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (it *SequencesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return newSequencesWriter(it, true)
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (it *SequencesTable) Inserter(*sql.Context) sql.RowInserter {
+	return newSequencesWriter(it, true)
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (it *SequencesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newSequencesWriter(it, true)
+}
+
+// ErrSequenceNotFound is returned for a sequence not in the dolt_sequences table.
+var ErrSequenceNotFound = errors.New("sequence not found")
+
+// NextValue advances the sequence named |name|, returning its next value. The value follows the last one handed out
+// by the sequence in any transaction or branch, and is written as the sequence's current value in the working set.
+func (it *SequencesTable) NextValue(ctx *sql.Context, name string) (int64, error) {
+	old, err := it.findSequence(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	next := func(last int64, ok bool) (int64, sql.Row, error) {
+		r := old
+		if ok {
+			if r, err = advanceSequenceTo(old, last); err != nil {
+				return 0, nil, err
+			}
+		}
+		return NextSequenceValue(r)
+	}
+
+	var updated sql.Row
+	var value int64
+	if it.tracker == nil {
+		value, updated, err = next(0, false)
+	} else {
+		value, err = it.tracker.Next(ctx, name, func(last int64, ok bool) (int64, error) {
+			// the tracker calls |next| again to reserve the values after the one handed out, whose rows aren't written
+			v, r, err := next(last, ok)
+			if updated == nil {
+				updated = r
+			}
+			return v, err
+		})
+	}
+	if err != nil {
+		return 0, err
+	}
+	return value, it.updateSequence(ctx, old, updated)
+}
+
+// SetValue sets the current value of the sequence named |name| to |value|, so that its next value follows it.
+func (it *SequencesTable) SetValue(ctx *sql.Context, name string, value int64) error {
+	old, err := it.findSequence(ctx, name)
+	if err != nil {
+		return err
+	}
+	updated := old.Copy()
+	updated[SequenceCurrentValueIdx] = value
+	if updated, err = normalizeSequence(updated); err != nil {
+		return err
+	}
+	if it.tracker != nil {
+		if err = it.tracker.Set(ctx, name, value); err != nil {
+			return err
+		}
+	}
+	return it.updateSequence(ctx, old, updated)
+}
+
+// advanceSequenceTo returns the sequence |r| with |last| as its current value, if |last| is further along the sequence
+// than its current value, which it is when another transaction or branch handed it out.
+func advanceSequenceTo(r sql.Row, last int64) (sql.Row, error) {
+	r, err := normalizeSequence(r)
+	if err != nil {
+		return nil, err
+	}
+	if last < r[SequenceMinValueIdx].(int64) || last > r[SequenceMaxValueIdx].(int64) {
+		// the sequence was changed since |last| was handed out
+		return r, nil
+	}
+	current, ok := r[SequenceCurrentValueIdx].(int64)
+	if !ok || (r[SequenceIncrementIdx].(int64) > 0) == (last > current) {
+		r[SequenceCurrentValueIdx] = last
+	}
+	return r, nil
+}
+
+// findSequence returns the row of the sequence named |name|.
+func (it *SequencesTable) findSequence(ctx *sql.Context, name string) (sql.Row, error) {
+	parts, err := it.Partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer parts.Close(ctx)
+	for {
+		part, err := parts.Next(ctx)
+		if err == io.EOF {
+			return nil, fmt.Errorf("%w: %s", ErrSequenceNotFound, name)
+		} else if err != nil {
+			return nil, err
+		}
+
+		rows, err := it.PartitionRows(ctx, part)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			r, err := rows.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				rows.Close(ctx)
+				return nil, err
+			}
+			if seqName, ok := r[SequenceNameIdx].(string); ok && strings.EqualFold(seqName, name) {
+				rows.Close(ctx)
+				return r, nil
+			}
+		}
+		if err = rows.Close(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// updateSequence replaces the row |old| of a sequence with |updated|, in a statement of its own.
+func (it *SequencesTable) updateSequence(ctx *sql.Context, old, updated sql.Row) error {
+	writer := newSequencesWriter(it, false)
+	writer.StatementBegin(ctx)
+	if err := writer.Update(ctx, old, updated); err != nil {
+		_ = writer.DiscardChanges(ctx, err)
+		_ = writer.Close(ctx)
+		return err
+	}
+	if err := writer.StatementComplete(ctx); err != nil {
+		_ = writer.Close(ctx)
+		return err
+	}
+	return writer.Close(ctx)
+}
+
+var _ sql.RowReplacer = (*sequencesWriter)(nil)
+var _ sql.RowUpdater = (*sequencesWriter)(nil)
+var _ sql.RowInserter = (*sequencesWriter)(nil)
+var _ sql.RowDeleter = (*sequencesWriter)(nil)
+
+// sequencesWriter writes the rows of dolt_sequences, checking that they're valid sequences and recording the writes in
+// the table's tracker.
+type sequencesWriter struct {
+	*versionedSystemTableWriter
+	it *SequencesTable
+	// track is whether the writes are recorded in the table's tracker, as they are for writes made by statements,
+	// while NextValue and SetValue record the values they hand out themselves
+	track bool
+}
+
+func newSequencesWriter(it *SequencesTable, track bool) *sequencesWriter {
+	return &sequencesWriter{versionedSystemTableWriter: newVersionedSystemTableWriter(&it.versionedSystemTable), it: it, track: track}
+}
+
+// trackWrite records the write of the row |r| of the sequence |name| in the table's tracker: the sequence's last
+// value becomes the one written, and a sequence without one, or which was deleted, starts over.
+func (iw *sequencesWriter) trackWrite(ctx *sql.Context, name interface{}, r sql.Row) error {
+	if !iw.track || iw.it.tracker == nil {
+		return nil
+	}
+	seqName, ok := name.(string)
+	if !ok {
+		return nil
+	}
+	if r != nil {
+		if last, ok := r[SequenceCurrentValueIdx].(int64); ok {
+			return iw.it.tracker.Set(ctx, seqName, last)
+		}
+	}
+	return iw.it.tracker.Drop(ctx, seqName)
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (iw *sequencesWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := iw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	r, err := normalizeSequence(r)
+	if err != nil {
+		return err
+	}
+	if err = iw.tableWriter.Insert(ctx, r); err != nil {
+		return err
+	}
+	return iw.trackWrite(ctx, r[SequenceNameIdx], r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (iw *sequencesWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := iw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	new, err := normalizeSequence(new)
+	if err != nil {
+		return err
+	}
+	if err = iw.tableWriter.Update(ctx, old, new); err != nil {
+		return err
+	}
+	if !strings.EqualFold(old[SequenceNameIdx].(string), new[SequenceNameIdx].(string)) {
+		if err = iw.trackWrite(ctx, old[SequenceNameIdx], nil); err != nil {
+			return err
+		}
+	} else if old[SequenceCurrentValueIdx] == new[SequenceCurrentValueIdx] {
+		return nil
+	}
+	return iw.trackWrite(ctx, new[SequenceNameIdx], new)
+}
+
+// normalizeSequence returns the row |r| of dolt_sequences with the defaults of its unset columns filled in, or an
+// error if it isn't a valid sequence. A sequence counts up by 1 from 1 unless given otherwise, and a descending
+// sequence, with a negative increment, counts down from -1.
+func normalizeSequence(r sql.Row) (sql.Row, error) {
+	r = r.Copy()
+	name, _ := r[SequenceNameIdx].(string)
+	if r[SequenceIncrementIdx] == nil {
+		r[SequenceIncrementIdx] = int64(1)
+	}
+	increment := r[SequenceIncrementIdx].(int64)
+	if increment == 0 {
+		return nil, fmt.Errorf("sequence %s must have a non-zero increment", name)
+	}
+	if r[SequenceMinValueIdx] == nil {
+		r[SequenceMinValueIdx] = int64(1)
+		if increment < 0 {
+			r[SequenceMinValueIdx] = int64(math.MinInt64)
+		}
+	}
+	if r[SequenceMaxValueIdx] == nil {
+		r[SequenceMaxValueIdx] = int64(math.MaxInt64)
+		if increment < 0 {
+			r[SequenceMaxValueIdx] = int64(-1)
+		}
+	}
+	minValue, maxValue := r[SequenceMinValueIdx].(int64), r[SequenceMaxValueIdx].(int64)
+	if minValue > maxValue {
+		return nil, fmt.Errorf("sequence %s has a min_value greater than its max_value", name)
+	}
+	if r[SequenceStartValueIdx] == nil {
+		r[SequenceStartValueIdx] = minValue
+		if increment < 0 {
+			r[SequenceStartValueIdx] = maxValue
+		}
+	}
+	if start := r[SequenceStartValueIdx].(int64); start < minValue || start > maxValue {
+		return nil, fmt.Errorf("sequence %s has a start_value outside of its min_value and max_value", name)
+	}
+	if r[SequenceCycleIdx] == nil {
+		r[SequenceCycleIdx] = int8(0)
+	}
+	if last, ok := r[SequenceCurrentValueIdx].(int64); ok && (last < minValue || last > maxValue) {
+		return nil, fmt.Errorf("sequence %s has a current_value outside of its min_value and max_value", name)
+	}
+	return r, nil
+}
+
+// NextSequenceValue returns the next value of the sequence |r|, a row of dolt_sequences, and the row with that value
+// as its current value. The first value of a sequence is its start value, and each following value is the current
+// value plus its increment. A sequence that runs past its min or max value starts over from the other if it cycles,
+// and otherwise fails.
+func NextSequenceValue(r sql.Row) (int64, sql.Row, error) {
+	r, err := normalizeSequence(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	name := r[SequenceNameIdx].(string)
+	minValue, maxValue := r[SequenceMinValueIdx].(int64), r[SequenceMaxValueIdx].(int64)
+	increment := r[SequenceIncrementIdx].(int64)
+
+	// the distances from the current value to the min and max values are computed unsigned, so that they can't overflow
+	var next int64
+	if last, ok := r[SequenceCurrentValueIdx].(int64); !ok {
+		next = r[SequenceStartValueIdx].(int64)
+	} else if increment > 0 && uint64(maxValue)-uint64(last) < uint64(increment) ||
+		increment < 0 && uint64(last)-uint64(minValue) < uint64(-increment) {
+		if r[SequenceCycleIdx].(int8) == 0 {
+			return 0, nil, fmt.Errorf("sequence %s has run out of values", name)
+		}
+		next = minValue
+		if increment < 0 {
+			next = maxValue
+		}
+	} else {
+		next = last + increment
+	}
+	r[SequenceCurrentValueIdx] = next
+	return next, r, nil
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (iw *sequencesWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := iw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if err := iw.tableWriter.Delete(ctx, r); err != nil {
+		return err
+	}
+	return iw.trackWrite(ctx, r[SequenceNameIdx], nil)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSequenceValue(t *testing.T) {
+	// name, start_value, min_value, max_value, increment, cycle, current_value
+	tests := []struct {
+		name     string
+		seq      sql.Row
+		expected []int64
+		err      string
+	}{
+		{
+			name:     "defaults",
+			seq:      sql.Row{"s", nil, nil, nil, nil, nil, nil},
+			expected: []int64{1, 2, 3},
+		},
+		{
+			name:     "descending",
+			seq:      sql.Row{"s", nil, nil, nil, int64(-5), nil, nil},
+			expected: []int64{-1, -6, -11},
+		},
+		{
+			name:     "cycles",
+			seq:      sql.Row{"s", int64(2), int64(1), int64(3), int64(1), int8(1), nil},
+			expected: []int64{2, 3, 1, 2},
+		},
+		{
+			name:     "continues from current value",
+			seq:      sql.Row{"s", int64(1), int64(1), int64(100), int64(10), int8(0), int64(50)},
+			expected: []int64{60, 70},
+		},
+		{
+			name:     "runs out at max int",
+			seq:      sql.Row{"s", nil, nil, nil, int64(2), nil, int64(math.MaxInt64 - 2)},
+			expected: []int64{math.MaxInt64},
+			err:      "sequence s has run out of values",
+		},
+		{
+			name: "runs out at min int",
+			seq:  sql.Row{"s", nil, nil, nil, int64(math.MinInt64), nil, int64(-1)},
+			err:  "sequence s has run out of values",
+		},
+		{
+			name:     "large increment near negative max",
+			seq:      sql.Row{"s", nil, int64(math.MinInt64), int64(math.MinInt64 + 5), int64(10), nil, nil},
+			expected: []int64{math.MinInt64},
+			err:      "sequence s has run out of values",
+		},
+		{
+			name: "zero increment",
+			seq:  sql.Row{"s", nil, nil, nil, int64(0), nil, nil},
+			err:  "sequence s must have a non-zero increment",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := test.seq
+			for _, expected := range test.expected {
+				var v int64
+				var err error
+				v, r, err = NextSequenceValue(r)
+				require.NoError(t, err)
+				assert.Equal(t, expected, v)
+			}
+			_, _, err := NextSequenceValue(r)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
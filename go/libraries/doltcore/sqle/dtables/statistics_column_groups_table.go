@@ -0,0 +1,125 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+const statsCorrelationColName = "correlation"
+
+// ColumnGroupStatistic is the combined statistic for a group of columns
+// declared in dolt_stats_column_groups.
+type ColumnGroupStatistic interface {
+	Columns() []string
+	RowCount() uint64
+	DistinctCount() uint64
+	NullCount() uint64
+	// Correlation is the ratio of the group's distinct count to the
+	// distinct count expected if its columns were independent.
+	Correlation() float64
+}
+
+type ColumnGroupStatsProvider interface {
+	GetTableColumnGroupStats(ctx *sql.Context, branch, db, schema, table string) ([]ColumnGroupStatistic, error)
+}
+
+// StatisticsColumnGroupsTable is a sql.Table implementation that implements a system table which shows the
+// statistics collected for column groups
+type StatisticsColumnGroupsTable struct {
+	dbName     string
+	schemaName string
+	branch     string
+	tableNames []string
+}
+
+var _ sql.Table = (*StatisticsColumnGroupsTable)(nil)
+
+// NewStatisticsColumnGroupsTable creates a StatisticsColumnGroupsTable
+func NewStatisticsColumnGroupsTable(_ *sql.Context, dbName, schemaName, branch string, tableNames []string) sql.Table {
+	return &StatisticsColumnGroupsTable{dbName: dbName, schemaName: schemaName, branch: branch, tableNames: tableNames}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatisticsColumnGroupsTableName
+func (st *StatisticsColumnGroupsTable) Name() string {
+	return doltdb.StatisticsColumnGroupsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StatisticsColumnGroupsTableName
+func (st *StatisticsColumnGroupsTable) String() string {
+	return doltdb.StatisticsColumnGroupsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the column groups system table.
+func (st *StatisticsColumnGroupsTable) Schema() sql.Schema {
+	return sql.Schema{
+		&sql.Column{Name: schema.StatsDbColName, Type: types.Text, PrimaryKey: true, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: schema.StatsTableColName, Type: types.Text, PrimaryKey: true, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: schema.StatsColumnsColName, Type: types.Text, PrimaryKey: true, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: schema.StatsRowCountColName, Type: types.Int64, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: schema.StatsDistinctCountColName, Type: types.Int64, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: schema.StatsNullCountColName, Type: types.Int64, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+		&sql.Column{Name: statsCorrelationColName, Type: types.Float64, Source: doltdb.StatisticsColumnGroupsTableName, DatabaseSource: st.dbName},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (st *StatisticsColumnGroupsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.  Currently the data is unpartitioned.
+func (st *StatisticsColumnGroupsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StatisticsColumnGroupsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	statsPro, ok := dSess.StatsProvider().(ColumnGroupStatsProvider)
+	if !ok {
+		return sql.RowsToRowIter(), nil
+	}
+
+	var rows []sql.Row
+	for _, table := range st.tableNames {
+		groups, err := statsPro.GetTableColumnGroupStats(ctx, st.branch, st.dbName, st.schemaName, table)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			rows = append(rows, sql.Row{
+				st.dbName,
+				table,
+				strings.Join(g.Columns(), ","),
+				int64(g.RowCount()),
+				int64(g.DistinctCount()),
+				int64(g.NullCount()),
+				g.Correlation(),
+			})
+		}
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
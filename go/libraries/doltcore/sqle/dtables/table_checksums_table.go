@@ -0,0 +1,99 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// TableChecksumsTable is a sql.Table implementation that implements a system table which shows an
+// order-independent checksum of the rows of each table, see doltdb.TableChecksum. Tables on different
+// branches or databases with the same schema and rows have the same checksum.
+type TableChecksumsTable struct {
+	dbName     string
+	tableName  string
+	schemaName string
+	revision   string
+	ddb        *doltdb.DoltDB
+	root       doltdb.RootValue
+}
+
+var _ sql.Table = (*TableChecksumsTable)(nil)
+
+// NewTableChecksumsTable creates a TableChecksumsTable for the tables of |root|, the root of the branch or other
+// revision |revision|, in the schema |schemaName|.
+func NewTableChecksumsTable(_ *sql.Context, dbName, tableName, schemaName, revision string, ddb *doltdb.DoltDB, root doltdb.RootValue) sql.Table {
+	return &TableChecksumsTable{dbName: dbName, tableName: tableName, schemaName: schemaName, revision: revision, ddb: ddb, root: root}
+}
+
+// Name implements the interface sql.Table.
+func (tct *TableChecksumsTable) Name() string {
+	return tct.tableName
+}
+
+// String implements the interface sql.Table.
+func (tct *TableChecksumsTable) String() string {
+	return tct.tableName
+}
+
+// Schema implements the interface sql.Table.
+func (tct *TableChecksumsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: tct.tableName, PrimaryKey: true, DatabaseSource: tct.dbName},
+		{Name: "checksum", Type: types.Text, Source: tct.tableName, PrimaryKey: false, DatabaseSource: tct.dbName},
+	}
+}
+
+// Collation implements the interface sql.Table.
+func (tct *TableChecksumsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (tct *TableChecksumsTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	names, err := tct.root.GetTableNames(ctx, tct.schemaName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	parts := make([]tableOfTablesPartition, len(names))
+	for i, name := range names {
+		parts[i] = tableOfTablesPartition(doltdb.TableName{Name: name, Schema: tct.schemaName})
+	}
+	return &tableOfTablesPartitionIter{tblNames: parts}, nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (tct *TableChecksumsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	tblName := decodeTableName(part.Key())
+	tbl, ok, err := tct.root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("table %s cannot be found", tblName)
+	}
+	sum, err := tct.ddb.TableChecksum(ctx, tct.revision, tblName, tbl)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(sql.Row{tblName.Name, sum.String()}), nil
+}
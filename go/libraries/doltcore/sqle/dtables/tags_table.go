@@ -73,6 +73,7 @@ func (tt *TagsTable) Schema() sql.Schema {
 		{Name: "email", Type: types.Text, Source: tt.tableName, PrimaryKey: false},
 		{Name: "date", Type: types.Datetime, Source: tt.tableName, PrimaryKey: false},
 		{Name: "message", Type: types.Text, Source: tt.tableName, PrimaryKey: false},
+		{Name: "signature", Type: types.Text, Source: tt.tableName, PrimaryKey: false, Nullable: true},
 	}
 }
 
@@ -119,7 +120,11 @@ func (itr *TagsItr) Next(ctx *sql.Context) (sql.Row, error) {
 	}()
 
 	twh := itr.tagsWithHash[itr.idx]
-	return sql.NewRow(twh.Tag.Name, twh.Hash.String(), twh.Tag.Meta.Name, twh.Tag.Meta.Email, twh.Tag.Meta.Time(), twh.Tag.Meta.Description), nil
+	var signature interface{}
+	if twh.Tag.Meta.Signature != "" {
+		signature = twh.Tag.Meta.Signature
+	}
+	return sql.NewRow(twh.Tag.Name, twh.Hash.String(), twh.Tag.Meta.Name, twh.Tag.Meta.Email, twh.Tag.Meta.Time(), twh.Tag.Meta.Description, signature), nil
 }
 
 // Close closes the iterator.
@@ -0,0 +1,306 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.Table = (*versionedSystemTable)(nil)
+var _ sql.UpdatableTable = (*versionedSystemTable)(nil)
+var _ sql.DeletableTable = (*versionedSystemTable)(nil)
+var _ sql.InsertableTable = (*versionedSystemTable)(nil)
+var _ sql.ReplaceableTable = (*versionedSystemTable)(nil)
+var _ sql.IndexAddressableTable = (*versionedSystemTable)(nil)
+
+// versionedSystemTable is a system table whose rows are stored in a table of the same name in the root, so that they
+// are versioned, branched and merged like the rows of any other table. The table reads as empty until the first
+// statement that writes to it creates its backing table. System tables of this kind embed it with their own schema.
+type versionedSystemTable struct {
+	name   string
+	schema sql.Schema
+	// dbName is the revision qualified name of the database the table belongs to
+	dbName       string
+	backingTable VersionableTable
+	schemaName   string
+	// validateRow, if set, returns an error for a row that can't be inserted into the table or updated to
+	validateRow func(r sql.Row) error
+	// checkWrite, if set, returns an error if the user of |ctx| isn't allowed to write the table
+	checkWrite func(ctx *sql.Context) error
+	// completeRow, if set, returns the row written for a row inserted into the table or updated to, with the columns
+	// set by the server rather than by the user, such as the user who wrote it, filled in
+	completeRow func(ctx *sql.Context, r sql.Row) sql.Row
+}
+
+func newVersionedSystemTable(name string, schema sql.Schema, dbName string, backingTable VersionableTable, schemaName string) versionedSystemTable {
+	return versionedSystemTable{
+		name:         name,
+		schema:       schema,
+		dbName:       dbName,
+		backingTable: backingTable,
+		schemaName:   schemaName,
+	}
+}
+
+func (t *versionedSystemTable) Name() string {
+	return t.name
+}
+
+func (t *versionedSystemTable) String() string {
+	return t.name
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the system table.
+func (t *versionedSystemTable) Schema() sql.Schema {
+	return t.schema
+}
+
+func (t *versionedSystemTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (t *versionedSystemTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
+	if t.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return t.backingTable.Partitions(ctx)
+}
+
+func (t *versionedSystemTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if t.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+	return t.backingTable.PartitionRows(ctx, partition)
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (t *versionedSystemTable) Replacer(*sql.Context) sql.RowReplacer {
+	return newVersionedSystemTableWriter(t)
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (t *versionedSystemTable) Updater(*sql.Context) sql.RowUpdater {
+	return newVersionedSystemTableWriter(t)
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (t *versionedSystemTable) Inserter(*sql.Context) sql.RowInserter {
+	return newVersionedSystemTableWriter(t)
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (t *versionedSystemTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newVersionedSystemTableWriter(t)
+}
+
+func (t *versionedSystemTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if t.backingTable == nil {
+		return t, nil
+	}
+	return t.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements sql.IndexAddressableTable. The table reports no indexes, so no lookup is ever built for
+// it, and there is no indexed table to return.
+func (t *versionedSystemTable) IndexedAccess(sql.IndexLookup) sql.IndexedTable {
+	return nil
+}
+
+// GetIndexes implements sql.IndexAddressableTable. The table has no indexes.
+func (t *versionedSystemTable) GetIndexes(*sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (t *versionedSystemTable) PreciseMatch() bool {
+	return true
+}
+
+var _ sql.RowReplacer = (*versionedSystemTableWriter)(nil)
+var _ sql.RowUpdater = (*versionedSystemTableWriter)(nil)
+var _ sql.RowInserter = (*versionedSystemTableWriter)(nil)
+var _ sql.RowDeleter = (*versionedSystemTableWriter)(nil)
+
+// versionedSystemTableWriter writes the rows of a versionedSystemTable to its backing table, creating the backing
+// table first if it doesn't exist yet.
+type versionedSystemTableWriter struct {
+	t                       *versionedSystemTable
+	errDuringStatementBegin error
+	tableWriter             dsess.TableWriter
+}
+
+func newVersionedSystemTableWriter(t *versionedSystemTable) *versionedSystemTableWriter {
+	return &versionedSystemTableWriter{t: t}
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (w *versionedSystemTableWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if w.t.completeRow != nil {
+		r = w.t.completeRow(ctx, r)
+	}
+	if w.t.validateRow != nil {
+		if err := w.t.validateRow(r); err != nil {
+			return err
+		}
+	}
+	return w.tableWriter.Insert(ctx, r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (w *versionedSystemTableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if w.t.completeRow != nil {
+		new = w.t.completeRow(ctx, new)
+	}
+	if w.t.validateRow != nil {
+		if err := w.t.validateRow(new); err != nil {
+			return err
+		}
+	}
+	return w.tableWriter.Update(ctx, old, new)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (w *versionedSystemTableWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return w.tableWriter.Delete(ctx, r)
+}
+
+// StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
+// in some way that it may be returned to in the case of an error.
+func (w *versionedSystemTableWriter) StatementBegin(ctx *sql.Context) {
+	w.errDuringStatementBegin = w.statementBegin(ctx)
+}
+
+func (w *versionedSystemTableWriter) statementBegin(ctx *sql.Context) error {
+	if w.t.checkWrite != nil {
+		if err := w.t.checkWrite(ctx); err != nil {
+			return err
+		}
+	}
+
+	dbName := w.t.dbName
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no root value found in session")
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("no root value found in session")
+	}
+
+	tname := doltdb.TableName{Name: w.t.name, Schema: w.t.schemaName}
+	found, err := roots.Working.HasTable(ctx, tname)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if dbState.WorkingSet() == nil {
+			return doltdb.ErrOperationNotSupportedInDetachedHead
+		}
+
+		sch := sql.NewPrimaryKeySchema(w.t.Schema())
+		doltSch, err := sqlutil.ToDoltSchema(ctx, roots.Working, tname, sch, roots.Head, sql.Collation_Default)
+		if err != nil {
+			return err
+		}
+
+		// underlying table doesn't exist. Record this, then create the table.
+		newRootValue, err := doltdb.CreateEmptyTable(ctx, roots.Working, tname, doltSch)
+		if err != nil {
+			return err
+		}
+
+		// We use WriteSession.SetWorkingSet instead of DoltSession.SetWorkingRoot because we want to avoid modifying the root
+		// until the end of the transaction, but we still want the WriteSession to be able to find the newly
+		// created table.
+		if ws := dbState.WriteSession(); ws != nil {
+			if err = ws.SetWorkingSet(ctx, dbState.WorkingSet().WithWorkingRoot(newRootValue)); err != nil {
+				return err
+			}
+		}
+
+		if err = dSess.SetWorkingRoot(ctx, dbName, newRootValue); err != nil {
+			return err
+		}
+	}
+
+	ws := dbState.WriteSession()
+	if ws == nil {
+		return doltdb.ErrOperationNotSupportedInDetachedHead
+	}
+	tableWriter, err := ws.GetTableWriter(ctx, tname, dbName, dSess.SetWorkingRoot, false)
+	if err != nil {
+		return err
+	}
+	w.tableWriter = tableWriter
+	tableWriter.StatementBegin(ctx)
+	return nil
+}
+
+// DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
+// should be discarded.
+func (w *versionedSystemTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.DiscardChanges(ctx, errorEncountered)
+	}
+	return nil
+}
+
+// StatementComplete is called after the last operation of the statement, indicating that it has successfully completed.
+// The mark set in StatementBegin may be removed, and a new one should be created on the next StatementBegin.
+func (w *versionedSystemTableWriter) StatementComplete(ctx *sql.Context) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.StatementComplete(ctx)
+	}
+	return nil
+}
+
+// Close finalizes the write operation, persisting the result.
+func (w *versionedSystemTableWriter) Close(ctx *sql.Context) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.Close(ctx)
+	}
+	return nil
+}
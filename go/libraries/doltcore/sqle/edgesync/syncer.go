@@ -0,0 +1,249 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgesync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// Config configures a Syncer.
+type Config struct {
+	// DeviceBranch is the branch of each database which sessions write to
+	DeviceBranch string
+	// CentralBranch is the branch of the remote which the device branch is merged into
+	CentralBranch string
+	// Remote is the remote the central branch is fetched from and pushed to
+	Remote string
+	// Interval is how often the databases are synced
+	Interval time.Duration
+}
+
+// Engine is the SQL engine databases are synced with.
+type Engine interface {
+	// NewLocalContext returns a new context with a new session, with the privileges of the server.
+	NewLocalContext(ctx context.Context) (*sql.Context, error)
+	// Query runs |query| in |ctx|.
+	Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
+}
+
+// Syncer syncs the databases of an engine in edge sync mode. Sessions write to the device branch of each database,
+// and the Syncer periodically commits the changes of the device branch, merges it into the central branch of the
+// remote and pushes the result, then merges the central branch back into the device branch. Columns changed on both
+// branches are resolved with the strategies in dolt_column_merge_strategies. Databases without the remote are skipped,
+// and a sync which fails, for example because the remote can't be reached, is retried at the next interval.
+type Syncer struct {
+	engine Engine
+	cfg    Config
+}
+
+// NewSyncer returns a Syncer of the databases of |engine|.
+func NewSyncer(engine Engine, cfg Config) *Syncer {
+	return &Syncer{engine: engine, cfg: cfg}
+}
+
+// Prepare creates the device branch of each database from its central branch, if it doesn't exist, and makes it the
+// default branch of the database, so that new sessions write to it.
+func (s *Syncer) Prepare(ctx context.Context) error {
+	dbs, err := s.Databases(ctx)
+	if err != nil {
+		return err
+	}
+	for _, db := range dbs {
+		if err := s.prepare(ctx, db); err != nil {
+			return fmt.Errorf("failed to prepare database %s for edge sync: %w", db, err)
+		}
+	}
+	return nil
+}
+
+func (s *Syncer) prepare(ctx context.Context, db string) error {
+	sqlCtx, err := s.engine.NewLocalContext(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = s.query(sqlCtx, "USE "+quoteIdentifier(db)); err != nil {
+		return err
+	}
+
+	rows, err := s.query(sqlCtx, "SELECT name FROM dolt_branches WHERE name = "+quoteString(s.cfg.DeviceBranch))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		if _, err = s.query(sqlCtx, fmt.Sprintf("CALL dolt_branch(%s, %s)", quoteString(s.cfg.DeviceBranch), quoteString(s.cfg.CentralBranch))); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.query(sqlCtx, fmt.Sprintf("SET @@GLOBAL.%s = %s", quoteIdentifier(dsess.DefaultBranchKey(db)), quoteString("refs/heads/"+s.cfg.DeviceBranch)))
+	return err
+}
+
+// Run syncs every database at each interval until |ctx| is done.
+func (s *Syncer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.SyncAll(ctx)
+	}
+}
+
+// SyncAll syncs every database with the remote, logging the databases which fail to sync.
+func (s *Syncer) SyncAll(ctx context.Context) {
+	dbs, err := s.Databases(ctx)
+	if err != nil {
+		logrus.Warnf("edge sync: failed to list databases: %s", err.Error())
+		return
+	}
+	for _, db := range dbs {
+		err := background.Run(ctx, background.Replication, func(ctx context.Context) error {
+			return s.Sync(ctx, db)
+		})
+		if err != nil {
+			logrus.Warnf("edge sync: failed to sync database %s, retrying in %s: %s", db, s.cfg.Interval, err.Error())
+		}
+	}
+}
+
+// Databases returns the databases which have the remote.
+func (s *Syncer) Databases(ctx context.Context) ([]string, error) {
+	sqlCtx, err := s.engine.NewLocalContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbRows, err := s.query(sqlCtx, "SHOW DATABASES")
+	if err != nil {
+		return nil, err
+	}
+
+	var dbs []string
+	for _, dbRow := range dbRows {
+		db, ok := dbRow[0].(string)
+		if !ok || isInternalDatabase(db) {
+			continue
+		}
+		if _, err = s.query(sqlCtx, "USE "+quoteIdentifier(db)); err != nil {
+			return nil, err
+		}
+		rows, err := s.query(sqlCtx, "SELECT name FROM dolt_remotes WHERE name = "+quoteString(s.cfg.Remote))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the remotes of database %s: %w", db, err)
+		}
+		if len(rows) > 0 {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs, nil
+}
+
+// Sync commits the changes of the device branch of |db|, merges the device branch into the central branch of the
+// remote and pushes both, then merges the central branch into the device branch.
+func (s *Syncer) Sync(ctx context.Context, db string) error {
+	sqlCtx, err := s.engine.NewLocalContext(ctx)
+	if err != nil {
+		return err
+	}
+	device, central, remote := quoteString(s.cfg.DeviceBranch), quoteString(s.cfg.CentralBranch), quoteString(s.cfg.Remote)
+
+	if err = s.use(sqlCtx, db, s.cfg.DeviceBranch); err != nil {
+		return err
+	}
+	if _, err = s.query(sqlCtx, "CALL dolt_commit('-A', '-m', "+quoteString("edge sync of "+s.cfg.DeviceBranch)+", '--skip-empty')"); err != nil {
+		return err
+	}
+	if _, err = s.query(sqlCtx, fmt.Sprintf("CALL dolt_fetch(%s, %s)", remote, central)); err != nil {
+		return err
+	}
+
+	if err = s.use(sqlCtx, db, s.cfg.CentralBranch); err != nil {
+		return err
+	}
+	if err = s.merge(sqlCtx, s.cfg.Remote+"/"+s.cfg.CentralBranch); err != nil {
+		return err
+	}
+	if err = s.merge(sqlCtx, s.cfg.DeviceBranch); err != nil {
+		return err
+	}
+	if _, err = s.query(sqlCtx, fmt.Sprintf("CALL dolt_push(%s, %s)", remote, central)); err != nil {
+		return err
+	}
+	if _, err = s.query(sqlCtx, fmt.Sprintf("CALL dolt_push(%s, %s)", remote, device)); err != nil {
+		return err
+	}
+
+	if err = s.use(sqlCtx, db, s.cfg.DeviceBranch); err != nil {
+		return err
+	}
+	return s.merge(sqlCtx, s.cfg.CentralBranch)
+}
+
+// use makes |branch| of |db| the current database of |ctx|.
+func (s *Syncer) use(ctx *sql.Context, db, branch string) error {
+	_, err := s.query(ctx, "USE "+quoteIdentifier(db+"/"+branch))
+	return err
+}
+
+// merge merges |branch| into the current branch of |ctx|, returning an error if the merge has conflicts.
+func (s *Syncer) merge(ctx *sql.Context, branch string) error {
+	rows, err := s.query(ctx, fmt.Sprintf("CALL dolt_merge(%s, '-m', %s)", quoteString(branch), quoteString("edge sync of "+branch)))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 1 && len(rows[0]) > 2 {
+		if conflicts, ok := rows[0][2].(int64); ok && conflicts > 0 {
+			return fmt.Errorf("merge of %s has conflicts, which must be resolved with dolt_column_merge_strategies or by hand", branch)
+		}
+	}
+	return nil
+}
+
+// query runs |query| and returns its rows.
+func (s *Syncer) query(ctx *sql.Context, query string) ([]sql.Row, error) {
+	_, iter, _, err := s.engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, iter)
+}
+
+func isInternalDatabase(dbName string) bool {
+	switch strings.ToLower(dbName) {
+	case "information_schema", "mysql", "performance_schema", "sys":
+		return true
+	}
+	return false
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'"
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edgesync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEngine records the queries it runs, and answers them with the rows of the first of its results whose
+// prefix they have. Only the databases in remotes have the remote.
+type recordingEngine struct {
+	queries []string
+	results map[string][]sql.Row
+	errs    map[string]error
+	remotes map[string]bool
+	current string
+}
+
+func (e *recordingEngine) NewLocalContext(ctx context.Context) (*sql.Context, error) {
+	return sql.NewContext(ctx), nil
+}
+
+func (e *recordingEngine) Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	e.queries = append(e.queries, query)
+	if strings.HasPrefix(query, "USE ") {
+		e.current = strings.Trim(query[len("USE "):], "`")
+	}
+	if strings.HasPrefix(query, "SELECT name FROM dolt_remotes") && !e.remotes[e.current] {
+		return nil, sql.RowsToRowIter(), nil, nil
+	}
+	for prefix, err := range e.errs {
+		if strings.HasPrefix(query, prefix) {
+			return nil, nil, nil, err
+		}
+	}
+	for prefix, rows := range e.results {
+		if strings.HasPrefix(query, prefix) {
+			return nil, sql.RowsToRowIter(rows...), nil, nil
+		}
+	}
+	return nil, sql.RowsToRowIter(), nil, nil
+}
+
+func newRecordingEngine() *recordingEngine {
+	return &recordingEngine{
+		results: map[string][]sql.Row{
+			"SHOW DATABASES":                {{"db"}, {"mysql"}, {"local_only"}},
+			"SELECT name FROM dolt_remotes": {{"origin"}},
+		},
+		errs:    make(map[string]error),
+		remotes: map[string]bool{"db": true},
+	}
+}
+
+var testConfig = Config{DeviceBranch: "device1", CentralBranch: "main", Remote: "origin", Interval: time.Minute}
+
+func TestPrepare(t *testing.T) {
+	e := newRecordingEngine()
+	require.NoError(t, NewSyncer(e, testConfig).Prepare(context.Background()))
+	assert.Equal(t, []string{
+		"SHOW DATABASES",
+		"USE `db`",
+		"SELECT name FROM dolt_remotes WHERE name = 'origin'",
+		"USE `local_only`",
+		"SELECT name FROM dolt_remotes WHERE name = 'origin'",
+		"USE `db`",
+		"SELECT name FROM dolt_branches WHERE name = 'device1'",
+		"CALL dolt_branch('device1', 'main')",
+		"SET @@GLOBAL.`db_default_branch` = 'refs/heads/device1'",
+	}, e.queries)
+
+	e = newRecordingEngine()
+	e.results["SELECT name FROM dolt_branches"] = []sql.Row{{"device1"}}
+	require.NoError(t, NewSyncer(e, testConfig).Prepare(context.Background()))
+	assert.NotContains(t, e.queries, "CALL dolt_branch('device1', 'main')")
+	assert.Contains(t, e.queries, "SET @@GLOBAL.`db_default_branch` = 'refs/heads/device1'")
+}
+
+func TestSync(t *testing.T) {
+	e := newRecordingEngine()
+	require.NoError(t, NewSyncer(e, testConfig).Sync(context.Background(), "db"))
+	assert.Equal(t, []string{
+		"USE `db/device1`",
+		"CALL dolt_commit('-A', '-m', 'edge sync of device1', '--skip-empty')",
+		"CALL dolt_fetch('origin', 'main')",
+		"USE `db/main`",
+		"CALL dolt_merge('origin/main', '-m', 'edge sync of origin/main')",
+		"CALL dolt_merge('device1', '-m', 'edge sync of device1')",
+		"CALL dolt_push('origin', 'main')",
+		"CALL dolt_push('origin', 'device1')",
+		"USE `db/device1`",
+		"CALL dolt_merge('main', '-m', 'edge sync of main')",
+	}, e.queries)
+}
+
+func TestSyncStopsAtFailures(t *testing.T) {
+	e := newRecordingEngine()
+	e.errs["CALL dolt_fetch"] = errors.New("remote unreachable")
+	err := NewSyncer(e, testConfig).Sync(context.Background(), "db")
+	assert.EqualError(t, err, "remote unreachable")
+	assert.Equal(t, "CALL dolt_fetch('origin', 'main')", e.queries[len(e.queries)-1])
+
+	e = newRecordingEngine()
+	e.results["CALL dolt_merge('device1'"] = []sql.Row{{"", int64(0), int64(1), "conflicts"}}
+	err = NewSyncer(e, testConfig).Sync(context.Background(), "db")
+	assert.ErrorContains(t, err, "merge of device1 has conflicts")
+	assert.NotContains(t, e.queries, "CALL dolt_push('origin', 'main')")
+}
+
+func TestQuoteString(t *testing.T) {
+	assert.Equal(t, `'it''s'`, quoteString("it's"))
+	assert.Equal(t, `'a\\b'`, quoteString(`a\b`))
+}
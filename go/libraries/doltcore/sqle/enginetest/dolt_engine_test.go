@@ -1146,6 +1146,57 @@ func TestMultiDbTransactionsPrepared(t *testing.T) {
 	RunMultiDbTransactionsPreparedTest(t, h)
 }
 
+// TestMultiDbCommitRestore tests that when writing the working set of one of the databases of a transaction which
+// wrote to more than one fails, the working sets already written are restored.
+func TestMultiDbCommitRestore(t *testing.T) {
+	harness := newDoltHarness(t)
+	harness.Setup(setup.MydbData)
+	engine := mustNewEngine(t, harness)
+	defer engine.Close()
+
+	for _, q := range []string{
+		"create database db1",
+		"create database db2",
+		"create table db1.t (x int primary key)",
+		"insert into db1.t values (1)",
+		"create table db2.t (x int primary key)",
+		"insert into db2.t values (1)",
+	} {
+		enginetest.RunQueryWithContext(t, engine, harness, nil, q)
+	}
+
+	query := func(ctx *sql.Context, q string) ([]sql.Row, error) {
+		_, iter, _, err := engine.Query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		return sql.RowIterToRows(ctx, iter)
+	}
+
+	ctx := enginetest.NewSession(harness)
+	for _, q := range []string{
+		"start transaction",
+		"insert into db1.t values (2)",
+		"insert into db2.t values (2)",
+	} {
+		_, err := query(ctx, q)
+		require.NoError(t, err)
+	}
+	reset := dsess.FailMultiDbCommitsForTesting(fmt.Errorf("write failed"))
+	_, err := query(ctx, "commit")
+	reset()
+	require.ErrorContains(t, err, "write failed")
+	require.False(t, dsess.ErrInconsistentDatabases.Is(err))
+
+	// neither database has the transaction's changes
+	readCtx := enginetest.NewSession(harness)
+	for _, q := range []string{"select * from db1.t", "select * from db2.t"} {
+		rows, err := query(readCtx, q)
+		require.NoError(t, err)
+		require.Equal(t, []sql.Row{{int32(1)}}, rows)
+	}
+}
+
 func TestConcurrentTransactions(t *testing.T) {
 	h := newDoltHarness(t)
 	defer h.Close()
@@ -1320,6 +1371,11 @@ func TestDoltTag(t *testing.T) {
 	RunDoltTagTests(t, h)
 }
 
+func TestDoltUpdateRefs(t *testing.T) {
+	h := newDoltEnginetestHarness(t)
+	RunDoltUpdateRefsTests(t, h)
+}
+
 func TestDoltRemote(t *testing.T) {
 	h := newDoltEnginetestHarness(t)
 	RunDoltRemoteTests(t, h)
@@ -1493,6 +1549,16 @@ func TestDiffSummaryTableFunctionPrepared(t *testing.T) {
 	RunDiffSummaryTableFunctionTestsPrepared(t, harness)
 }
 
+func TestDiffKeyRangesTableFunction(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDiffKeyRangesTableFunctionTests(t, harness)
+}
+
+func TestDiffKeyRangesTableFunctionPrepared(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDiffKeyRangesTableFunctionTestsPrepared(t, harness)
+}
+
 func TestPatchTableFunction(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunDoltPatchTableFunctionTests(t, harness)
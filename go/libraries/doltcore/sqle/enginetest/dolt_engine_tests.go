@@ -628,6 +628,14 @@ func RunMultiDbTransactionsTest(t *testing.T, h DoltEnginetestHarness) {
 		}()
 	}
 
+	for _, script := range MultiDbTransactionCommitTests {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestTransactionScript(t, h, script)
+		}()
+	}
+
 	for _, script := range MultiDbSavepointTests {
 		func() {
 			h := h.NewHarness(t)
@@ -1060,6 +1068,16 @@ func RunDoltTagTests(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunDoltUpdateRefsTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltUpdateRefsTestScripts {
+		func() {
+			h := h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func RunDoltRemoteTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range DoltRemoteTestScripts {
 		func() {
@@ -1242,6 +1260,28 @@ func RunDiffSummaryTableFunctionTestsPrepared(t *testing.T, harness DoltEnginete
 	}
 }
 
+func RunDiffKeyRangesTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range DiffKeyRangesTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
+func RunDiffKeyRangesTableFunctionTestsPrepared(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range DiffKeyRangesTableFunctionScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScriptPrepared(t, harness, test)
+		})
+	}
+}
+
 func RunDoltPatchTableFunctionTests(t *testing.T, harness DoltEnginetestHarness) {
 	for _, test := range PatchTableFunctionScriptTests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -253,6 +253,12 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 			return nil, err
 		}
 		e.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
+		e.Analyzer.Catalog.InfoSchema = sqle.NewInformationSchemaDatabase()
+		sqle.AddInvisibleIndexesRule(e.Analyzer)
+		sqle.AddZoneMapFiltersRule(e.Analyzer)
+		sqle.AddApproxCountDistinctRule(e.Analyzer)
+		e.Analyzer.Coster = statspro.NewColumnGroupCoster()
+		e.Parser = sqle.NewDoltParser(e.Parser)
 		d.engine = e
 
 		ctx := enginetest.NewContext(d)
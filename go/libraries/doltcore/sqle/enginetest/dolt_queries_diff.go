@@ -1138,6 +1138,72 @@ on a.to_pk = b.to_pk;`,
 			},
 		},
 	},
+	{
+		Name: "three dot diff with omitted revisions and the working set",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'base');",
+			"call dolt_branch('feature');",
+
+			"insert into t values (1, 'main');",
+			"call dolt_commit('-am', 'main change');",
+
+			"call dolt_checkout('feature');",
+			"insert into t values (2, 'feature');",
+			"call dolt_commit('-am', 'feature change');",
+			"insert into t values (3, 'uncommitted');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT to_pk, to_c1, from_pk, diff_type from dolt_diff('main...', 't');",
+				Expected: []sql.Row{
+					{2, "feature", nil, "added"},
+				},
+			},
+			{
+				Query: "SELECT to_pk, to_c1, from_pk, diff_type from dolt_diff('...main', 't');",
+				Expected: []sql.Row{
+					{1, "main", nil, "added"},
+				},
+			},
+			{
+				Query: "SELECT to_pk, to_c1, from_pk, diff_type from dolt_diff('main..', 't');",
+				Expected: []sql.Row{
+					{nil, nil, 1, "removed"},
+					{2, "feature", nil, "added"},
+				},
+			},
+			{
+				Query: "SELECT to_pk, to_c1, from_pk, diff_type from dolt_diff('main...WORKING', 't');",
+				Expected: []sql.Row{
+					{2, "feature", nil, "added"},
+					{3, "uncommitted", nil, "added"},
+				},
+			},
+			{
+				Query: "SELECT to_pk, to_c1, from_pk, diff_type from dolt_diff('main...STAGED', 't');",
+				Expected: []sql.Row{
+					{2, "feature", nil, "added"},
+				},
+			},
+			{
+				Query:    "SELECT table_name, rows_added, rows_deleted from dolt_diff_stat('main...WORKING');",
+				Expected: []sql.Row{{"t", 2, 0}},
+			},
+			{
+				Query:    "SELECT * from dolt_diff_summary('...main');",
+				Expected: []sql.Row{{"t", "t", "modified", true, false}},
+			},
+			{
+				Query: "SELECT statement from dolt_patch('main...WORKING', 't') order by statement_order;",
+				Expected: []sql.Row{
+					{"INSERT INTO `t` (`pk`,`c1`) VALUES (2,'feature');"},
+					{"INSERT INTO `t` (`pk`,`c1`) VALUES (3,'uncommitted');"},
+				},
+			},
+		},
+	},
 	{
 		Name: "schema modification: drop and recreate column with same type",
 		SetUpScript: []string{
@@ -3044,6 +3110,87 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 	},
 }
 
+var DiffKeyRangesTableFunctionScriptTests = []queries.ScriptTest{
+	{
+		Name: "dolt_diff_key_ranges counts changes per primary key range",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 0), (2, 0), (3, 0), (4, 0), (5, 0), (6, 0), (7, 0), (8, 0), (9, 0), (10, 0);",
+			"create table k (a int, b varchar(10), primary key (a, b));",
+			"insert into k values (1, 'x'), (2, 'y');",
+			"create table keyless (c int);",
+			"call dolt_commit('-Am', 'one');",
+			"update t set c = 1 where pk = 2;",
+			"delete from t where pk = 9;",
+			"insert into t values (11, 0), (12, 0);",
+			"insert into k values (1, 'z');",
+			"call dolt_commit('-am', 'two');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select * from dolt_diff_key_ranges('HEAD~1', 'HEAD', 't', 3);",
+				Expected: []sql.Row{
+					{0, "1", "3", 3, 0, 0, 1},
+					{1, "4", "7", 4, 0, 0, 0},
+					{2, "8", "12", 4, 2, 1, 0},
+				},
+			},
+			{
+				Query: "select * from dolt_diff_key_ranges('HEAD~1..HEAD', 't', 3);",
+				Expected: []sql.Row{
+					{0, "1", "3", 3, 0, 0, 1},
+					{1, "4", "7", 4, 0, 0, 0},
+					{2, "8", "12", 4, 2, 1, 0},
+				},
+			},
+			{
+				Query:    "select count(*), sum(row_count), sum(rows_added), sum(rows_deleted), sum(rows_modified) from dolt_diff_key_ranges('HEAD~1', 'HEAD', 't');",
+				Expected: []sql.Row{{10, 11.0, 2.0, 1.0, 1.0}},
+			},
+			{
+				Query: "select * from dolt_diff_key_ranges('HEAD~1', 'HEAD', 'k', 2);",
+				Expected: []sql.Row{
+					{0, "1, x", "1, x", 1, 0, 0, 0},
+					{1, "1, z", "2, y", 2, 1, 0, 0},
+				},
+			},
+			{
+				Query: "select * from dolt_diff_key_ranges('HEAD', 'WORKING', 'k', 1);",
+				Expected: []sql.Row{
+					{0, "1, x", "2, y", 3, 0, 0, 0},
+				},
+			},
+			{
+				Query:    "drop table t;",
+				Expected: []sql.Row{{gmstypes.NewOkResult(0)}},
+			},
+			{
+				Query: "select * from dolt_diff_key_ranges('HEAD', 'WORKING', 't', 2);",
+				Expected: []sql.Row{
+					{0, "1", "5", 5, 0, 5, 0},
+					{1, "6", "12", 6, 0, 6, 0},
+				},
+			},
+			{
+				Query:          "select * from dolt_diff_key_ranges('HEAD', 'WORKING', 'keyless');",
+				ExpectedErrStr: "failed to compute key ranges for table keyless: key ranges are only defined for tables with a primary key",
+			},
+			{
+				Query:       "select * from dolt_diff_key_ranges('HEAD', 'WORKING', 'nope');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				Query:       "select * from dolt_diff_key_ranges('HEAD', 'WORKING', 'k', 0);",
+				ExpectedErr: sql.ErrInvalidArgumentDetails,
+			},
+			{
+				Query:       "select * from dolt_diff_key_ranges('HEAD', 'WORKING');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+		},
+	},
+}
+
 var PatchTableFunctionScriptTests = []queries.ScriptTest{
 	{
 		Name: "invalid arguments",
@@ -5519,66 +5666,66 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1);",
 				Expected: []sql.Row{
-					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
-					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", "DROP TABLE `employees`;"},
+					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `vacations` TO `trips`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0);",
 				Expected: []sql.Row{
-					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
-					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", "DROP TABLE `inventory`;"},
+					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `trips` TO `vacations`;"},
 				},
 			},
 			// Compare diffs with explicit table names
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'employees');",
 				Expected: []sql.Row{
-					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
+					{"employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", "DROP TABLE `employees`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'employees');",
 				Expected: []sql.Row{
-					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"", "employees", "", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `employees` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'inventory');",
 				Expected: []sql.Row{
-					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"", "inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'inventory');",
 				Expected: []sql.Row{
-					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", ""},
+					{"inventory", "", "CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "", "DROP TABLE `inventory`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'trips');",
 				Expected: []sql.Row{
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `vacations` TO `trips`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'trips');",
 				Expected: []sql.Row{
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `trips` TO `vacations`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit0, @Commit1, 'vacations');",
 				Expected: []sql.Row{
-					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"vacations", "trips", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `vacations` TO `trips`;"},
 				},
 			},
 			{
 				Query: "select * from dolt_schema_diff(@Commit1, @Commit0, 'vacations');",
 				Expected: []sql.Row{
-					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;"},
+					{"trips", "vacations", "CREATE TABLE `trips` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "CREATE TABLE `vacations` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;", "RENAME TABLE `trips` TO `vacations`;"},
 				},
 			},
 			// Compare two different commits, get expected results
@@ -5590,6 +5737,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5601,6 +5749,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5612,6 +5761,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5623,6 +5773,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5634,6 +5785,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5645,6 +5797,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5656,6 +5809,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5667,6 +5821,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5678,6 +5833,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5689,6 +5845,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `quantity`;\nALTER TABLE `inventory` ADD `color` varchar(10);",
 					},
 				},
 			},
@@ -5701,6 +5858,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
@@ -5712,6 +5870,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
@@ -5723,6 +5882,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
@@ -5734,6 +5894,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
@@ -5745,6 +5906,7 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
@@ -5756,11 +5918,65 @@ var SchemaDiffTableFunctionScriptTests = []queries.ScriptTest{
 						"inventory",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `color` varchar(10),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 						"CREATE TABLE `inventory` (\n  `pk` int NOT NULL,\n  `name` varchar(50),\n  `quantity` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
+						"ALTER TABLE `inventory` DROP `color`;\nALTER TABLE `inventory` ADD `quantity` int;",
 					},
 				},
 			},
 		},
 	},
+	{
+		Name: "migration sql for column, index and foreign key changes",
+		SetUpScript: []string{
+			"create table parent (id int primary key, code varchar(10), unique key code_idx (code));",
+			"create table child (pk int primary key, parent_id int, v1 int, v2 varchar(10), key v1_idx (v1));",
+			"call dolt_commit('-Am', 'commit 0');",
+			"call dolt_branch('branch0');",
+
+			"alter table child add constraint fk_parent foreign key (parent_id) references parent (id);",
+			"alter table child drop index v1_idx;",
+			"alter table child modify column v1 bigint not null default 0;",
+			"alter table child rename column v2 to label;",
+			"alter table child add column v3 int comment 'added';",
+			"alter table child add unique index label_idx (label);",
+			"call dolt_commit('-Am', 'commit 1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "select to_table_name, migration_sql from dolt_schema_diff('branch0', 'main', 'child');",
+				Expected: []sql.Row{
+					{
+						"child",
+						"ALTER TABLE `child` DROP INDEX `v1_idx`;\n" +
+							"ALTER TABLE `child` MODIFY COLUMN `v1` bigint NOT NULL DEFAULT '0';\n" +
+							"ALTER TABLE `child` RENAME COLUMN `v2` TO `label`;\n" +
+							"ALTER TABLE `child` ADD `v3` int COMMENT 'added';\n" +
+							"ALTER TABLE `child` ADD INDEX `fk_parent`(`parent_id`);\n" +
+							"ALTER TABLE `child` ADD UNIQUE INDEX `label_idx`(`label`);\n" +
+							"ALTER TABLE `child` ADD CONSTRAINT `fk_parent` FOREIGN KEY (`parent_id`) REFERENCES `parent` (`id`);",
+					},
+				},
+			},
+			{
+				Query: "select to_table_name, migration_sql from dolt_schema_diff('main', 'branch0', 'child');",
+				Expected: []sql.Row{
+					{
+						"child",
+						"ALTER TABLE `child` DROP FOREIGN KEY `fk_parent`;\n" +
+							"ALTER TABLE `child` DROP INDEX `fk_parent`;\n" +
+							"ALTER TABLE `child` DROP INDEX `label_idx`;\n" +
+							"ALTER TABLE `child` MODIFY COLUMN `v1` int;\n" +
+							"ALTER TABLE `child` RENAME COLUMN `label` TO `v2`;\n" +
+							"ALTER TABLE `child` DROP `v3`;\n" +
+							"ALTER TABLE `child` ADD INDEX `v1_idx`(`v1`);",
+					},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_schema_diff('branch0', 'main', 'parent');",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
 	{
 		Name: "prepared table functions",
 		SetUpScript: []string{
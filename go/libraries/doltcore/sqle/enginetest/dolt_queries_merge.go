@@ -1612,6 +1612,123 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "merge a table renamed on the left with edits on the right",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY key, col1 int);",
+			"INSERT into t VALUES (1, 1), (2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"UPDATE t SET col1 = 20 WHERE pk = 2;",
+			"INSERT into t VALUES (3, 3);",
+			"CALL DOLT_COMMIT('-am', 'right');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"RENAME TABLE t TO t2;",
+			"INSERT into t2 VALUES (4, 4);",
+			"CALL DOLT_COMMIT('-Am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SHOW TABLES;",
+				Expected: []sql.Row{{"t2"}},
+			},
+			{
+				Query:    "SELECT * from t2;",
+				Expected: []sql.Row{{1, 1}, {2, 20}, {3, 3}, {4, 4}},
+			},
+		},
+	},
+	{
+		Name: "merge a table and column renamed on the right with edits on the left",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY key, col1 int);",
+			"INSERT into t VALUES (1, 1), (2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"RENAME TABLE t TO t2;",
+			"ALTER TABLE t2 RENAME COLUMN col1 TO col2;",
+			"CALL DOLT_COMMIT('-Am', 'right');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET col1 = 10 WHERE pk = 1;",
+			"INSERT into t VALUES (3, 3);",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SHOW TABLES;",
+				Expected: []sql.Row{{"t2"}},
+			},
+			{
+				Query:    "SELECT pk, col2 from t2;",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "merge a table renamed differently on both sides",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int PRIMARY key, col1 int);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"RENAME TABLE t TO t2;",
+			"CALL DOLT_COMMIT('-Am', 'right');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"RENAME TABLE t TO t3;",
+			"CALL DOLT_COMMIT('-Am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('other');",
+				ExpectedErrStr: "conflict: table 't' was renamed to 't3' in one commit and to 't2' in the other",
+			},
+		},
+	},
+	{
+		Name: "merge conflicts in a renamed table",
+		SetUpScript: []string{
+			"SET dolt_allow_commit_conflicts = on;",
+			"CREATE TABLE t (pk int PRIMARY key, col1 int);",
+			"INSERT into t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"UPDATE t SET col1 = -1 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+
+			"CALL DOLT_CHECKOUT('main');",
+			"RENAME TABLE t TO t2;",
+			"UPDATE t2 SET col1 = 10 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-Am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT base_pk, base_col1, our_pk, our_col1, their_pk, their_col1 from dolt_conflicts_t2;",
+				Expected: []sql.Row{{1, 1, 1, 10, 1, -1}},
+			},
+			{
+				Query:    "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 't2');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * from t2;",
+				Expected: []sql.Row{{1, -1}},
+			},
+		},
+	},
 	{
 		Name: "merge with new triggers defined",
 		SetUpScript: []string{
@@ -2528,6 +2645,1010 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "merge applies ON DELETE and ON UPDATE referential actions to rows from the other branch",
+		SetUpScript: []string{
+			"create table parent (id int primary key, code varchar(10), unique key (code));",
+			"create table child (id int primary key, pid int, pcode varchar(10), foreign key (pid) references parent(id) on delete cascade, foreign key (pcode) references parent(code) on update cascade on delete set null);",
+			"insert into parent values (1, 'a'), (2, 'b'), (3, 'c');",
+			"insert into child values (10, 1, null), (20, null, 'b'), (30, null, 'c');",
+			"call dolt_commit('-Am', 'create tables');",
+			"call dolt_branch('other');",
+			"delete from parent where id in (1, 3);",
+			"update parent set code = 'bb' where id = 2;",
+			"call dolt_commit('-am', 'change parents on main');",
+			"call dolt_checkout('other');",
+			"insert into child values (11, 1, null), (21, null, 'b'), (31, null, 'c');",
+			"call dolt_commit('-am', 'add children on other');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from child order by id;",
+				Expected: []sql.Row{{20, nil, "bb"}, {21, nil, "bb"}, {30, nil, nil}, {31, nil, nil}},
+			},
+			{
+				Query:    "select id from child where pcode = 'bb' order by id;",
+				Expected: []sql.Row{{20}, {21}},
+			},
+			{
+				Query:    "select count(*) from dolt_constraint_violations;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "merge cascades referential actions through multiple foreign keys",
+		SetUpScript: []string{
+			"set dolt_force_transaction_commit = on;",
+			"create table parent (id int primary key);",
+			"create table child (id int primary key, pid int, foreign key (pid) references parent(id) on delete cascade);",
+			"create table grandchild (id int primary key, cid int, foreign key (cid) references child(id) on delete set null);",
+			"create table restricted (id int primary key, pid int, foreign key (pid) references parent(id));",
+			"insert into parent values (1), (2);",
+			"call dolt_commit('-Am', 'create tables');",
+			"call dolt_branch('other');",
+			"delete from parent where id = 1;",
+			"call dolt_commit('-am', 'delete parent on main');",
+			"call dolt_checkout('other');",
+			"insert into child values (10, 1), (20, 2);",
+			"insert into grandchild values (100, 10), (200, 20);",
+			"insert into restricted values (1000, 1);",
+			"call dolt_commit('-am', 'add children on other');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select * from child order by id;",
+				Expected: []sql.Row{{20, 2}},
+			},
+			{
+				Query:    "select * from grandchild order by id;",
+				Expected: []sql.Row{{100, nil}, {200, 20}},
+			},
+			{
+				Query:    "select * from dolt_constraint_violations;",
+				Expected: []sql.Row{{"restricted", uint64(1)}},
+			},
+			{
+				Query:    "select id, pid from dolt_constraint_violations_restricted;",
+				Expected: []sql.Row{{1000, 1}},
+			},
+		},
+	},
+	{
+		Name: "merge records a foreign key violation when a referential action would break a unique index",
+		SetUpScript: []string{
+			"set dolt_force_transaction_commit = on;",
+			"create table parent (id int primary key, code varchar(10), unique key (code));",
+			"create table child (id int primary key, pcode varchar(10), unique key (pcode), foreign key (pcode) references parent(code) on update cascade);",
+			"insert into parent values (1, 'a'), (2, 'b');",
+			"call dolt_commit('-Am', 'create tables');",
+			"call dolt_branch('other');",
+			"update parent set code = 'c' where id = 1;",
+			"insert into child values (20, 'c');",
+			"call dolt_commit('-am', 'change parent on main');",
+			"call dolt_checkout('other');",
+			"insert into child values (10, 'a');",
+			"call dolt_commit('-am', 'add child on other');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select * from child order by id;",
+				Expected: []sql.Row{{10, "a"}, {20, "c"}},
+			},
+			{
+				Query:    "select * from dolt_constraint_violations;",
+				Expected: []sql.Row{{"child", uint64(1)}},
+			},
+			{
+				Query:    "select violation_type, id, pcode from dolt_constraint_violations_child;",
+				Expected: []sql.Row{{"foreign key", 10, "a"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies merges tables with ours, theirs and union strategies",
+		SetUpScript: []string{
+			"CREATE TABLE ours_t (pk int primary key, c int);",
+			"CREATE TABLE theirs_t (pk int primary key, c int);",
+			"CREATE TABLE union_t (pk int primary key, c int);",
+			"INSERT INTO ours_t VALUES (1, 1), (2, 2), (4, 4);",
+			"INSERT INTO theirs_t VALUES (1, 1), (2, 2), (4, 4);",
+			"INSERT INTO union_t VALUES (1, 1), (2, 2), (4, 4);",
+			"INSERT INTO dolt_merge_strategies VALUES ('ours_*', 'ours'), ('theirs_*', 'theirs'), ('union_*', 'union');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE ours_t SET c = 10 WHERE pk = 1;",
+			"UPDATE theirs_t SET c = 10 WHERE pk = 1;",
+			"UPDATE union_t SET c = 10 WHERE pk = 1;",
+			"DELETE FROM ours_t WHERE pk = 2;",
+			"DELETE FROM theirs_t WHERE pk = 2;",
+			"DELETE FROM union_t WHERE pk = 2;",
+			"INSERT INTO ours_t VALUES (3, 3);",
+			"INSERT INTO theirs_t VALUES (3, 3);",
+			"INSERT INTO union_t VALUES (3, 3);",
+			"UPDATE ours_t SET c = 40 WHERE pk = 4;",
+			"UPDATE theirs_t SET c = 40 WHERE pk = 4;",
+			"UPDATE union_t SET c = 40 WHERE pk = 4;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE ours_t SET c = 20 WHERE pk = 1;",
+			"UPDATE theirs_t SET c = 20 WHERE pk = 1;",
+			"UPDATE union_t SET c = 20 WHERE pk = 1;",
+			"UPDATE ours_t SET c = 22 WHERE pk = 2;",
+			"UPDATE theirs_t SET c = 22 WHERE pk = 2;",
+			"UPDATE union_t SET c = 22 WHERE pk = 2;",
+			"DELETE FROM ours_t WHERE pk = 4;",
+			"DELETE FROM theirs_t WHERE pk = 4;",
+			"DELETE FROM union_t WHERE pk = 4;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM ours_t ORDER BY pk;",
+				Expected: []sql.Row{{1, 20}, {2, 22}},
+			},
+			{
+				Query:    "SELECT * FROM theirs_t ORDER BY pk;",
+				Expected: []sql.Row{{1, 10}, {3, 3}, {4, 40}},
+			},
+			{
+				Query:    "SELECT * FROM union_t ORDER BY pk;",
+				Expected: []sql.Row{{1, 20}, {2, 22}, {3, 3}, {4, 40}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies manual strategy and unmatched tables record conflicts",
+		SetUpScript: []string{
+			"CREATE TABLE manual_t (pk int primary key, c int);",
+			"CREATE TABLE other_t (pk int primary key, c int);",
+			"INSERT INTO manual_t VALUES (1, 1);",
+			"INSERT INTO other_t VALUES (1, 1);",
+			"INSERT INTO dolt_merge_strategies VALUES ('manual_*', 'manual');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE manual_t SET c = 10;",
+			"UPDATE other_t SET c = 10;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE manual_t SET c = 20;",
+			"UPDATE other_t SET c = 20;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+			"SET @@autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT `table`, num_conflicts FROM dolt_conflicts ORDER BY `table`;",
+				Expected: []sql.Row{{"manual_t", uint64(1)}, {"other_t", uint64(1)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies row strategy records conflicts for rows modified on both sides",
+		SetUpScript: []string{
+			"CREATE TABLE row_t (pk int primary key, a int, b int);",
+			"CREATE TABLE cell_t (pk int primary key, a int, b int);",
+			"INSERT INTO row_t VALUES (1, 1, 1), (2, 2, 2);",
+			"INSERT INTO cell_t VALUES (1, 1, 1), (2, 2, 2);",
+			"INSERT INTO dolt_merge_strategies VALUES ('row_*', 'row');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE row_t SET b = 10 WHERE pk = 1;",
+			"UPDATE cell_t SET b = 10 WHERE pk = 1;",
+			"UPDATE row_t SET b = 20;",
+			"UPDATE cell_t SET b = 20 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE row_t SET a = 10 WHERE pk = 1;",
+			"UPDATE cell_t SET a = 10 WHERE pk = 1;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+			"SET @@autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT `table`, num_conflicts FROM dolt_conflicts;",
+				Expected: []sql.Row{{"row_t", uint64(1)}},
+			},
+			{
+				Query:    "SELECT our_pk, our_a, our_b, their_a, their_b FROM dolt_conflicts_row_t;",
+				Expected: []sql.Row{{1, 10, 1, 1, 20}},
+			},
+			{
+				Query:    "SELECT * FROM row_t ORDER BY pk;",
+				Expected: []sql.Row{{1, 10, 1}, {2, 2, 20}},
+			},
+			{
+				Query:    "SELECT * FROM cell_t ORDER BY pk;",
+				Expected: []sql.Row{{1, 10, 10}, {2, 2, 20}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies uses the most specific matching pattern",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk int primary key, c int);",
+			"CREATE TABLE t2 (pk int primary key, c int);",
+			"INSERT INTO t1 VALUES (1, 1);",
+			"INSERT INTO t2 VALUES (1, 1);",
+			"INSERT INTO dolt_merge_strategies VALUES ('t*', 'ours'), ('t1', 'theirs');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t1 SET c = 10;",
+			"UPDATE t2 SET c = 10;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t1 SET c = 20;",
+			"UPDATE t2 SET c = 20;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t1;",
+				Expected: []sql.Row{{1, 10}},
+			},
+			{
+				Query:    "SELECT * FROM t2;",
+				Expected: []sql.Row{{1, 20}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies errors on conflicting patterns and invalid strategies",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk int primary key, c int);",
+			"INSERT INTO t1 VALUES (1, 1);",
+			"INSERT INTO dolt_merge_strategies VALUES ('t*', 'ours'), ('*1', 'theirs');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t1 SET c = 10;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t1 SET c = 20;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('right');",
+				ExpectedErrStr: "the table t1 matches conflicting patterns in dolt_merge_strategies: *1 (theirs), t* (ours)",
+			},
+			{
+				Query:       "INSERT INTO dolt_merge_strategies VALUES ('t2', 'mine');",
+				ExpectedErr: types.ErrConvertingToEnum,
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_strategies writes to the branch of a revision qualified database",
+		SetUpScript: []string{
+			"CALL DOLT_BRANCH('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "INSERT INTO `mydb/other`.dolt_merge_strategies VALUES ('t*', 'ours');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_merge_strategies;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "SELECT * FROM `mydb/other`.dolt_merge_strategies;",
+				Expected: []sql.Row{{"t*", "ours"}},
+			},
+			{
+				Query:    "DELETE FROM `mydb/other`.dolt_merge_strategies;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM `mydb/other`.dolt_merge_strategies;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "dolt_column_merge_strategies resolves columns modified on both sides",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, counter int, high int, low double, mine varchar(10), yours varchar(10), other int);",
+			"INSERT INTO t VALUES (1, 10, 5, 5, 'a', 'a', 0);",
+			"INSERT INTO dolt_column_merge_strategies VALUES ('t', 'counter', 'sum'), ('T', 'HIGH', 'max'), ('t', 'low', 'min'), ('t', 'mine', 'ours'), ('t', 'yours', 'theirs');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET counter = counter + 3, high = 7, low = 4.5, mine = 'right', yours = 'right';",
+			"INSERT INTO t VALUES (2, 1, 1, 1, 'right', 'right', 1);",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET counter = counter + 5, high = 6, low = 3, mine = 'left', yours = 'left';",
+			"INSERT INTO t VALUES (2, 2, 2, 2, 'left', 'left', 1);",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 18, 7, 3.0, "left", "right", 0}, {2, 3, 2, 1.0, "left", "right", 1}},
+			},
+		},
+	},
+	{
+		Name: "dolt_column_merge_strategies leaves other columns conflicting",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, counter int, c int);",
+			"INSERT INTO t VALUES (1, 10, 0);",
+			"INSERT INTO dolt_column_merge_strategies VALUES ('t', 'counter', 'sum');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET counter = 11, c = 1;",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t SET counter = 12, c = 2;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+			"SET @@autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT our_counter, our_c, their_counter, their_c FROM dolt_conflicts_t;",
+				Expected: []sql.Row{{12, 2, 11, 1}},
+			},
+			{
+				Query:       "INSERT INTO dolt_column_merge_strategies VALUES ('t', 'c', 'average');",
+				ExpectedErr: types.ErrConvertingToEnum,
+			},
+		},
+	},
+	{
+		Name: "DOLT_MERGE --strategy merges every table with the given strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t1 (pk int primary key, c int);",
+			"CREATE TABLE t2 (pk int primary key, c int);",
+			"INSERT INTO t1 VALUES (1, 1), (2, 2);",
+			"INSERT INTO t2 VALUES (1, 1), (2, 2);",
+			"INSERT INTO dolt_merge_strategies VALUES ('t1', 'ours');",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t1 SET c = 10 WHERE pk = 1;",
+			"UPDATE t2 SET c = 10 WHERE pk = 1;",
+			"INSERT INTO t2 VALUES (3, 3);",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+			"UPDATE t1 SET c = 20 WHERE pk = 1;",
+			"UPDATE t2 SET c = 20 WHERE pk = 1;",
+			"DELETE FROM t2 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'left');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('--strategy', 'mine', 'right');",
+				ExpectedErrStr: "unknown merge strategy 'mine', expected one of ours, theirs, union, manual, row",
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--strategy', 'theirs', 'right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t1 ORDER BY pk;",
+				Expected: []sql.Row{{1, 10}, {2, 2}},
+			},
+			{
+				Query:    "SELECT * FROM t2 ORDER BY pk;",
+				Expected: []sql.Row{{1, 10}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "DOLT_MERGE --strategy with --no-ff on a merge that could fast-forward",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET c = 10;",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('--no-ff', '-s', 'ours', '-m', 'keep ours', '--author', 'A U Thor <author@example.com>', 'right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "SELECT committer, email, message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"A U Thor", "author@example.com", "keep ours"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "DOLT_MERGE --squash commits a fast-forward when given a message",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c int);",
+			"INSERT INTO t VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_CHECKOUT('-b', 'right');",
+			"UPDATE t SET c = 10;",
+			"CALL DOLT_COMMIT('-am', 'right 1');",
+			"INSERT INTO t VALUES (2, 2);",
+			"CALL DOLT_COMMIT('-am', 'right 2');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('--squash', '--no-commit', '-m', 'squashed', 'right');",
+				Expected: []sql.Row{{doltCommit, 1, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT table_name, staged FROM dolt_status;",
+				Expected: []sql.Row{{"t", true}},
+			},
+			{
+				Query:    "CALL DOLT_RESET('--hard');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('--squash', '-m', 'squashed', '--author', 'A U Thor <author@example.com>', 'right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT committer, email, message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"A U Thor", "author@example.com", "squashed"}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_commit_ancestors WHERE commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk;",
+				Expected: []sql.Row{{1, 10}, {2, 2}},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_preview reports a merge without merging",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c int);",
+			"CREATE TABLE u (a int primary key, b int unique);",
+			"INSERT INTO t VALUES (1, 1), (2, 2), (3, 3);",
+			"CALL DOLT_COMMIT('-Am', 'ancestor');",
+			"CALL DOLT_BRANCH('right');",
+			"UPDATE t SET c = 10 WHERE pk = 1;",
+			"INSERT INTO t VALUES (4, 4);",
+			"INSERT INTO u VALUES (1, 1);",
+			"CALL DOLT_COMMIT('-am', 'left');",
+			"CALL DOLT_CHECKOUT('right');",
+			"UPDATE t SET c = 20 WHERE pk = 1;",
+			"DELETE FROM t WHERE pk = 3;",
+			"INSERT INTO u VALUES (2, 1);",
+			"CREATE TABLE v (x int primary key);",
+			"INSERT INTO v VALUES (1), (2);",
+			"CALL DOLT_COMMIT('-Am', 'right');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_merge_preview('right');",
+				Expected: []sql.Row{
+					{"t", "modified", 0, 0, 1, 1, 0, 0},
+					{"u", "modified", 1, 0, 0, 0, 0, 2},
+					{"v", "added", 2, 0, 0, 0, 0, 0},
+				},
+			},
+			{
+				Query:    "SELECT count(*) FROM dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT is_merging FROM dolt_merge_status;",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query: "SELECT table_name, rows_added, rows_deleted, data_conflicts FROM dolt_merge_preview('right', 'main');",
+				Expected: []sql.Row{
+					{"t", 1, 0, 1},
+					{"u", 1, 0, 0},
+				},
+			},
+			{
+				// fast-forward merges are previewed from the commit merged in
+				Query: "SELECT * FROM dolt_merge_preview('HEAD~1', 'main');",
+				Expected: []sql.Row{
+					{"t", "modified", 1, 1, 0, 0, 0, 0},
+					{"u", "modified", 1, 0, 0, 0, 0, 0},
+				},
+			},
+			{
+				Query:    "SELECT * FROM dolt_merge_preview('main');",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "SELECT * FROM dolt_merge_preview('nope');",
+				ExpectedErrStr: "branch not found: nope",
+			},
+		},
+	},
+	{
+		Name: "dolt_schema_contracts rejects merges that drop columns or narrow types",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int, b varchar(20), e enum('x', 'y'));",
+			"INSERT INTO dolt_schema_contracts VALUES ('main', 't', 'no_column_drops'), ('main', 't', 'no_type_narrowing'), ('main', 't', 'enum_additions_only');",
+			"CALL DOLT_COMMIT('-Am', 'contracts');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"ALTER TABLE t DROP COLUMN a;",
+			"ALTER TABLE t MODIFY COLUMN b varchar(10);",
+			"ALTER TABLE t MODIFY COLUMN e enum('y', 'x');",
+			"CALL DOLT_COMMIT('-am', 'breaking changes');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "SELECT * FROM dolt_schema_contracts ORDER BY policy;",
+				Expected: []sql.Row{
+					{"main", "t", "no_column_drops"},
+					{"main", "t", "no_type_narrowing"},
+					{"main", "t", "enum_additions_only"},
+				},
+			},
+			{
+				Query: "CALL DOLT_MERGE('feature');",
+				ExpectedErrStr: "schema changes to branch main violate dolt_schema_contracts:\n" +
+					"\tt.a: column dropped (no_column_drops)\n" +
+					"\tt.b: type changed from varchar(20) to varchar(10) (no_type_narrowing)\n" +
+					"\tt.e: type changed from enum('x','y') to enum('y','x') (enum_additions_only)",
+			},
+			{
+				Query:    "SELECT column_name FROM information_schema.columns WHERE table_name = 't' ORDER BY ordinal_position;",
+				Expected: []sql.Row{{"pk"}, {"a"}, {"b"}, {"e"}},
+			},
+			{
+				Query:    "SELECT message FROM dolt_log LIMIT 1;",
+				Expected: []sql.Row{{"contracts"}},
+			},
+			{
+				// the contracts only apply to main
+				Query:    "CALL DOLT_CHECKOUT('-b', 'other');",
+				Expected: []sql.Row{{0, "Switched to branch 'other'"}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature');",
+				Expected: []sql.Row{{doltCommit, 1, 0, "merge successful"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_schema_contracts allows widening changes and enum additions",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, a int, b varchar(20), d decimal(5,2), e enum('x', 'y'));",
+			"CREATE TABLE m (pk int primary key);",
+			"INSERT INTO dolt_schema_contracts VALUES ('*', '*', 'no_column_drops'), ('*', '*', 'no_type_narrowing'), ('*', '*', 'enum_additions_only');",
+			"CALL DOLT_COMMIT('-Am', 'contracts');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"ALTER TABLE t MODIFY COLUMN a bigint;",
+			"ALTER TABLE t MODIFY COLUMN b varchar(50);",
+			"ALTER TABLE t MODIFY COLUMN d decimal(8,3);",
+			"ALTER TABLE t MODIFY COLUMN e enum('x', 'y', 'z');",
+			"ALTER TABLE t ADD COLUMN c int;",
+			"CREATE TABLE u (pk int primary key);",
+			"CALL DOLT_COMMIT('-Am', 'compatible changes');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO m VALUES (1);",
+			"CALL DOLT_COMMIT('-am', 'main change');",
+			"CALL DOLT_CHECKOUT('-b', 'drop');",
+			"DROP TABLE t;",
+			"CALL DOLT_COMMIT('-am', 'drop t');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO m VALUES (2);",
+			"CALL DOLT_COMMIT('-am', 'another main change');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('drop');",
+				ExpectedErrStr: "schema changes to branch main violate dolt_schema_contracts:\n\tt: table dropped (no_column_drops)",
+			},
+			{
+				Query:    "SELECT count(*) FROM information_schema.tables WHERE table_name = 't';",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "CALL DOLT_MERGE('feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT column_name FROM information_schema.columns WHERE table_name = 't' ORDER BY ordinal_position;",
+				Expected: []sql.Row{{"pk"}, {"a"}, {"b"}, {"d"}, {"e"}, {"c"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_append_only_tables rejects updates and deletes on matching branches",
+		SetUpScript: []string{
+			"CREATE TABLE ledger (id int primary key, amount int);",
+			"CREATE TABLE notes (id int primary key, note varchar(20));",
+			"INSERT INTO ledger VALUES (1, 10), (2, 20);",
+			"INSERT INTO notes VALUES (1, 'a');",
+			"INSERT INTO dolt_append_only_tables VALUES ('main', 'ledger');",
+			"CALL DOLT_COMMIT('-Am', 'append only ledger');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "SELECT * FROM dolt_append_only_tables;",
+				Expected: []sql.Row{{"main", "ledger"}},
+			},
+			{
+				Query:    "INSERT INTO ledger VALUES (3, 30);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "UPDATE ledger SET amount = 11 WHERE id = 1;",
+				ExpectedErrStr: "table ledger is append-only on branch main, its rows can't be updated or deleted",
+			},
+			{
+				Query:          "DELETE FROM ledger WHERE id = 1;",
+				ExpectedErrStr: "table ledger is append-only on branch main, its rows can't be updated or deleted",
+			},
+			{
+				Query:          "DELETE FROM ledger;",
+				ExpectedErrStr: "table ledger is append-only on branch main, its rows can't be updated or deleted",
+			},
+			{
+				Query:          "REPLACE INTO ledger VALUES (1, 12);",
+				ExpectedErrStr: "table ledger is append-only on branch main, its rows can't be updated or deleted",
+			},
+			{
+				Query:          "INSERT INTO ledger VALUES (1, 13) ON DUPLICATE KEY UPDATE amount = 13;",
+				ExpectedErrStr: "table ledger is append-only on branch main, its rows can't be updated or deleted",
+			},
+			{
+				Query:    "INSERT INTO ledger VALUES (4, 40) ON DUPLICATE KEY UPDATE amount = 40;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "UPDATE notes SET note = 'b' WHERE id = 1;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "SELECT * FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10}, {2, 20}, {3, 30}, {4, 40}},
+			},
+			{
+				// the table is only append-only on main
+				Query:    "CALL DOLT_CHECKOUT('-b', 'other');",
+				Expected: []sql.Row{{0, "Switched to branch 'other'"}},
+			},
+			{
+				Query:    "DELETE FROM ledger WHERE id = 1;",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_append_only_tables rejects merges that update or delete rows",
+		SetUpScript: []string{
+			"CREATE TABLE ledger (id int primary key, amount int);",
+			"INSERT INTO ledger VALUES (1, 10), (2, 20);",
+			"INSERT INTO dolt_append_only_tables VALUES ('main', 'ledger');",
+			"CALL DOLT_COMMIT('-Am', 'append only ledger');",
+			"CALL DOLT_CHECKOUT('-b', 'feature');",
+			"DELETE FROM ledger WHERE id = 1;",
+			"UPDATE ledger SET amount = 21 WHERE id = 2;",
+			"INSERT INTO ledger VALUES (3, 30);",
+			"CALL DOLT_COMMIT('-am', 'rewrite ledger');",
+			"CALL DOLT_CHECKOUT('main');",
+			"CALL DOLT_CHECKOUT('-b', 'appends');",
+			"INSERT INTO ledger VALUES (4, 40);",
+			"CALL DOLT_COMMIT('-am', 'append to ledger');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('feature');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1, updated rows: 1",
+			},
+			{
+				Query:    "SELECT * FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+			{
+				Query:    "INSERT INTO ledger VALUES (5, 50);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:            "CALL DOLT_COMMIT('-am', 'main append');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "CALL DOLT_MERGE('appends');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10}, {2, 20}, {4, 40}, {5, 50}},
+			},
+		},
+	},
+	{
+		Name: "dolt_append_only_tables rules are read from both sides of a change",
+		SetUpScript: []string{
+			"CREATE TABLE ledger (id int primary key, amount int);",
+			"INSERT INTO ledger VALUES (1, 10), (2, 20);",
+			"INSERT INTO dolt_append_only_tables VALUES ('main', 'ledger');",
+			"CALL DOLT_COMMIT('-Am', 'append only ledger');",
+			"CALL DOLT_CHECKOUT('-b', 'lift');",
+			"DELETE FROM dolt_append_only_tables;",
+			"CALL DOLT_COMMIT('-am', 'lift append only ledger');",
+			"DELETE FROM ledger WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'rewrite ledger');",
+			"CALL DOLT_CHECKOUT('main');",
+			"CALL DOLT_CHECKOUT('-b', 'dev');",
+			"CALL DOLT_CHECKOUT('-b', 'impose');",
+			"DELETE FROM ledger WHERE id = 2;",
+			"INSERT INTO dolt_append_only_tables VALUES ('dev', 'ledger');",
+			"CALL DOLT_COMMIT('-am', 'rewrite ledger and make it append only on dev');",
+			"CALL DOLT_CHECKOUT('dev');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('impose');",
+				ExpectedErrStr: "changes to branch dev violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:    "CALL DOLT_CHECKOUT('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				Query:          "CALL DOLT_MERGE('lift');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:    "SELECT * FROM dolt_append_only_tables;",
+				Expected: []sql.Row{{"main", "ledger"}},
+			},
+			{
+				Query:    "SELECT * FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10}, {2, 20}},
+			},
+		},
+	},
+	{
+		Name: "dolt_append_only_tables rejects statements and procedures that replace rows",
+		SetUpScript: []string{
+			"CREATE TABLE ledger (id int primary key, amount int, note varchar(20));",
+			"INSERT INTO ledger VALUES (1, 10, 'a'), (2, 20, 'b');",
+			"INSERT INTO dolt_append_only_tables VALUES ('main', 'ledger');",
+			"CALL DOLT_COMMIT('-Am', 'append only ledger');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"UPDATE ledger SET amount = 11 WHERE id = 1;",
+			"CALL DOLT_COMMIT('-am', 'update ledger');",
+			"CALL DOLT_CHECKOUT('main');",
+			"INSERT INTO ledger VALUES (3, 30, 'c');",
+			"CALL DOLT_COMMIT('-am', 'append to ledger');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "DROP TABLE ledger;",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: table dropped",
+			},
+			{
+				Query:          "CALL DOLT_RESET('--hard', 'HEAD~1');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:          "CALL DOLT_REVERT('HEAD');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:          "CALL DOLT_CHERRY_PICK('other');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: updated rows: 1",
+			},
+			{
+				Query:            "CALL DOLT_REBASE('-i', 'other');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:          "CALL DOLT_REBASE('--continue');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: updated rows: 1",
+			},
+			{
+				Query:            "CALL DOLT_REBASE('--abort');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "INSERT INTO ledger VALUES (4, 40, 'd');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "CALL DOLT_RESET('--hard');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:          "CALL DOLT_CHECKOUT('HEAD', 'ledger');",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: deleted rows: 1",
+			},
+			{
+				Query:    "SELECT id, amount FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10}, {2, 20}, {3, 30}, {4, 40}},
+			},
+			{
+				Query:          "ALTER TABLE ledger DROP COLUMN note;",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: dropped columns: note",
+			},
+			{
+				Query:          "ALTER TABLE ledger DROP PRIMARY KEY;",
+				ExpectedErrStr: "changes to branch main violate dolt_append_only_tables:\n\tledger: primary key changed",
+			},
+			{
+				Query:    "ALTER TABLE ledger MODIFY COLUMN amount bigint;",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "ALTER TABLE ledger ADD COLUMN category varchar(10) DEFAULT 'x';",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "SELECT * FROM ledger ORDER BY id;",
+				Expected: []sql.Row{{1, 10, "a", "x"}, {2, 20, "b", "x"}, {3, 30, "c", "x"}, {4, 40, "d", "x"}},
+			},
+		},
+	},
+	{
+		Name: "merge trigger and procedure definitions changed on both sides",
+		SetUpScript: []string{
+			"CREATE TABLE t (a int primary key, b int, c int);",
+			"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  SET new.b = 1;\n  SET new.a = new.a;\n  SET new.c = 1;\nEND",
+			"CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\n  SELECT 0;\n  SELECT 2;\nEND",
+			"CALL DOLT_COMMIT('-Am', 'init');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"DROP TRIGGER tr;",
+			"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  SET new.b = 2;\n  SET new.a = new.a;\n  SET new.c = 1;\nEND",
+			"DROP PROCEDURE p;",
+			"CREATE PROCEDURE p()\nBEGIN\n  SELECT 10;\n  SELECT 0;\n  SELECT 2;\nEND",
+			"CALL DOLT_COMMIT('-am', 'change first lines');",
+			"CALL DOLT_CHECKOUT('main');",
+			"DROP TRIGGER tr;",
+			"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  SET new.b = 1;\n  SET new.a = new.a;\n  SET new.c = 3;\nEND",
+			"DROP PROCEDURE p;",
+			"CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\n  SELECT 0;\n  SELECT 20;\nEND",
+			"CALL DOLT_COMMIT('-am', 'change last lines');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT fragment FROM dolt_schemas WHERE name = 'tr';",
+				Expected: []sql.Row{{"CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  SET new.b = 2;\n  SET new.a = new.a;\n  SET new.c = 3;\nEND"}},
+			},
+			{
+				Query:    "SELECT create_stmt FROM dolt_procedures WHERE name = 'p';",
+				Expected: []sql.Row{{"CREATE PROCEDURE p()\nBEGIN\n  SELECT 10;\n  SELECT 0;\n  SELECT 20;\nEND"}},
+			},
+			{
+				Query:    "INSERT INTO t (a) VALUES (1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "SELECT * FROM t;",
+				Expected: []sql.Row{{1, 2, 3}},
+			},
+		},
+	},
+	{
+		Name: "conflicting changes to a view definition",
+		SetUpScript: []string{
+			"CREATE TABLE t (a int primary key);",
+			"CREATE VIEW v AS SELECT 1 AS x;",
+			"CALL DOLT_COMMIT('-Am', 'init');",
+			"CALL DOLT_CHECKOUT('-b', 'other');",
+			"CREATE OR REPLACE VIEW v AS SELECT 2 AS x;",
+			"CALL DOLT_COMMIT('-am', 'view 2');",
+			"CALL DOLT_CHECKOUT('main');",
+			"CREATE OR REPLACE VIEW v AS SELECT 3 AS x;",
+			"CALL DOLT_COMMIT('-am', 'view 3');",
+			"SET @@autocommit = 0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT our_name, our_fragment, their_fragment FROM dolt_conflicts_dolt_schemas;",
+				Expected: []sql.Row{{"v", "CREATE OR REPLACE VIEW v AS SELECT 3 AS x", "CREATE OR REPLACE VIEW v AS SELECT 2 AS x"}},
+			},
+			{
+				Query:    "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 'dolt_schemas');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT * FROM v;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "merging sequences keeps the value further along",
+		SetUpScript: []string{
+			"create sequence s",
+			"select nextval('s')",
+			"call dolt_commit('-Am', 'new sequence')",
+			"call dolt_checkout('-b', 'side')",
+			"select nextval('s')",
+			"call dolt_commit('-am', 'side value')",
+			"call dolt_checkout('main')",
+			"select nextval('s'), nextval('s')",
+			"call dolt_commit('-am', 'main values')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('side')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select current_value from dolt_sequences",
+				Expected: []sql.Row{{int64(4)}},
+			},
+			{
+				Query:    "select nextval('s')",
+				Expected: []sql.Row{{int64(5)}},
+			},
+		},
+	},
 }
 
 var KeylessMergeCVsAndConflictsScripts = []queries.ScriptTest{
@@ -3875,7 +4996,7 @@ var SchemaConflictScripts = []queries.ScriptTest{
 					"CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c0` varchar(20),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 					"CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c0` datetime(6),\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
 					"CREATE TABLE `t` (\n  `pk` int NOT NULL,\n  `c0` int,\n  PRIMARY KEY (`pk`)\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin;",
-					"different column definitions for our column c0 and their column c0",
+					"different types for our column c0 and their column c0",
 				}},
 			},
 			{
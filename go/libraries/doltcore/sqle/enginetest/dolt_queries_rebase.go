@@ -36,8 +36,8 @@ var DoltRebaseScriptTests = []queries.ScriptTest{
 				Query:          "call dolt_rebase('--continue');",
 				ExpectedErrStr: "no rebase in progress",
 			}, {
-				Query:          "call dolt_rebase('main');",
-				ExpectedErrStr: "non-interactive rebases not currently supported",
+				Query:          "call dolt_rebase('doesnotexist');",
+				ExpectedErrStr: "branch not found: doesnotexist",
 			}, {
 				Query:          "call dolt_rebase('-i');",
 				ExpectedErrStr: "not enough args",
@@ -1611,6 +1611,149 @@ var DoltRebaseScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_rebase: non-interactive rebase",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0, 'zero');",
+			"call dolt_commit('-am', 'inserting row 0 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'one');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"update t set c1='uno' where pk=1;",
+			"call dolt_commit('-am', 'updating row 1 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_rebase('main');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0, "zero"}, {1, "uno"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"updating row 1 on branch1"},
+					{"inserting row 1 on branch1"},
+					{"inserting row 0 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select name from dolt_branches order by name;",
+				Expected: []sql.Row{{"branch1"}, {"main"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: non-interactive rebase with data conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'one');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'uno');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'dos');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+
+			"set @@autocommit=0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "call dolt_rebase('main');",
+				ExpectedErr: dprocedures.ErrRebaseDataConflict,
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"dolt_rebase_branch1"}},
+			},
+			{
+				Query:    "select * from dolt_conflicts;",
+				Expected: []sql.Row{{"t", uint64(1)}},
+			},
+			{
+				Query:    "CALL DOLT_CONFLICTS_RESOLVE('--theirs', 't');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_add('t');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "uno"}, {2, "dos"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 2 on branch1"},
+					{"inserting row 1 on branch1"},
+					{"inserting row 1 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: abort a non-interactive rebase with data conflicts",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'one');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'uno');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+
+			"set @@autocommit=0;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "call dolt_rebase('main');",
+				ExpectedErr: dprocedures.ErrRebaseDataConflict,
+			},
+			{
+				Query:    "call dolt_rebase('--abort');",
+				Expected: []sql.Row{{0, "Interactive rebase aborted"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "uno"}},
+			},
+		},
+	},
 }
 
 var DoltRebaseMultiSessionScriptTests = []queries.ScriptTest{
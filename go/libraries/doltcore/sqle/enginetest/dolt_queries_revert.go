@@ -71,19 +71,137 @@ var RevertScripts = []queries.ScriptTest{
 		},
 	},
 	{
-		Name: "dolt_revert() detects conflicts",
+		Name: "dolt_revert() leaves conflicts in progress",
 		SetUpScript: []string{
+			"SET @@autocommit=0;",
 			"create table test (pk int primary key, c0 int)",
+			"create table other (pk int primary key)",
 			"insert into test values (1,1),(2,2),(3,3);",
 			"call dolt_commit('-Am', 'seed table');",
 			"update test set c0 = 42 where pk = 2;",
+			"insert into other values (1);",
 			"call dolt_commit('-am', 'first change');",
 			"update test set c0 = 23 where pk = 2;",
 			"call dolt_commit('-am', 'second change');",
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "call dolt_revert('HEAD~1');",
+				Query:    "call dolt_revert('HEAD~1');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select is_merging, source, unmerged_tables from dolt_merge_status;",
+				Expected: []sql.Row{{true, "HEAD~1", "test"}},
+			},
+			{
+				Query:    "select base_c0, our_c0, their_c0 from dolt_conflicts_test;",
+				Expected: []sql.Row{{42, 23, 2}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{{"other", true, "modified"}, {"test", false, "conflict"}},
+			},
+			{
+				Query:          "call dolt_revert('HEAD');",
+				ExpectedErrStr: "You must commit any changes before using revert",
+			},
+			{
+				Query:          "call dolt_revert('--continue');",
+				ExpectedErrStr: "error: cannot continue the revert with unresolved conflicts",
+			},
+			{
+				Query:    "call dolt_conflicts_resolve('--theirs', 'test');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_revert('--continue');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{`Revert "first change"`}},
+			},
+			{
+				Query:    "select count(*) from dolt_commit_ancestors where commit_hash = hashof('HEAD');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select * from test order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select * from other;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select is_merging from dolt_merge_status;",
+				Expected: []sql.Row{{false}},
+			},
+		},
+	},
+	{
+		Name: "dolt_revert() --abort",
+		SetUpScript: []string{
+			"SET @@autocommit=0;",
+			"create table test (pk int primary key, c0 int)",
+			"insert into test values (1,1),(2,2),(3,3);",
+			"call dolt_commit('-Am', 'seed table');",
+			"update test set c0 = 42 where pk = 2;",
+			"call dolt_commit('-am', 'first change');",
+			"update test set c0 = 23 where pk = 2;",
+			"call dolt_commit('-am', 'second change');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_revert('--abort');",
+				ExpectedErrStr: "error: There is no revert in progress",
+			},
+			{
+				Query:          "call dolt_revert('--continue');",
+				ExpectedErrStr: "error: There is no revert in progress",
+			},
+			{
+				Query:    "call dolt_revert('HEAD~1');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "call dolt_revert('--abort');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select is_merging from dolt_merge_status;",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "select * from test order by pk;",
+				Expected: []sql.Row{{1, 1}, {2, 23}, {3, 3}},
+			},
+			{
+				Query:    "select message from dolt_log limit 1;",
+				Expected: []sql.Row{{"second change"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_revert() of multiple commits fails on conflicts",
+		SetUpScript: []string{
+			"create table test (pk int primary key, c0 int)",
+			"insert into test values (1,1),(2,2),(3,3);",
+			"call dolt_commit('-Am', 'seed table');",
+			"update test set c0 = 42 where pk = 2;",
+			"call dolt_commit('-am', 'first change');",
+			"update test set c0 = 23 where pk = 2;",
+			"call dolt_commit('-am', 'second change');",
+			"update test set c0 = 24 where pk = 3;",
+			"call dolt_commit('-am', 'third change');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_revert('HEAD', 'HEAD~2');",
 				ExpectedErrStr: "revert currently does not handle conflicts",
 			},
 		},
@@ -142,6 +260,7 @@ var RevertScripts = []queries.ScriptTest{
 	{
 		Name: "dolt_revert() detects not null violation (issue #4527)",
 		SetUpScript: []string{
+			"SET @@autocommit=0;",
 			"create table test2 (pk int primary key, c0 int)",
 			"insert into test2 values (1,1),(2,NULL),(3,3);",
 			"call dolt_commit('-Am', 'new table with NULL value');",
@@ -152,8 +271,16 @@ var RevertScripts = []queries.ScriptTest{
 		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
-				Query:          "call dolt_revert('head~1');",
-				ExpectedErrStr: "revert currently does not handle constraint violations",
+				Query:    "call dolt_revert('head~1');",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select violation_type, pk, c0 from dolt_constraint_violations_test2;",
+				Expected: []sql.Row{{"not null", 2, nil}},
+			},
+			{
+				Query:          "call dolt_revert('--continue');",
+				ExpectedErrStr: "error: cannot continue the revert with unresolved constraint violations",
 			},
 		},
 	},
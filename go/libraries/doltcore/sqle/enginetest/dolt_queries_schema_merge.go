@@ -1082,6 +1082,31 @@ var SchemaChangeTestsConstraints = []MergeScriptTest{
 			},
 		},
 	},
+	{
+		Name: "check constraint violation - default value for column added on other side",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"CREATE table t (pk int primary key, col1 int);",
+			"INSERT into t values (1, 0);",
+		},
+		RightSetUpScript: []string{
+			"alter table t add column col2 int default 0;",
+			"alter table t add constraint chk_col2 CHECK (col2 >= col1);",
+		},
+		LeftSetUpScript: []string{
+			"insert into t values (2, 5);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select violation_type, pk, col1, col2, violation_info like '%chk_col2%' from dolt_constraint_violations_t;",
+				Expected: []sql.Row{{"check constraint", 2, 5, 0, true}},
+			},
+		},
+	},
 	{
 		Name: "check constraint violation - deleting rows",
 		AncSetUpScript: []string{
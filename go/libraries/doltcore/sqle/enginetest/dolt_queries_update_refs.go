@@ -0,0 +1,151 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+var updateRefsSetupScript = []string{
+	"create table t (pk int primary key);",
+	"call dolt_commit('-Am', 'create table');",
+	"call dolt_branch('b1');",
+	"call dolt_branch('b2');",
+	"insert into t values (1);",
+	"call dolt_commit('-am', 'insert 1');",
+}
+
+var DoltUpdateRefsTestScripts = []queries.ScriptTest{
+	{
+		Name:        "dolt_update_refs: updates several branches",
+		SetUpScript: updateRefsSetupScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(distinct hash) from dolt_branches;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "call dolt_update_refs('b1', 'main', 'b2', 'main');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(distinct hash) from dolt_branches;",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select * from `mydb/b2`.t;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name:        "dolt_update_refs: updates nothing if any update isn't a fast-forward",
+		SetUpScript: append(updateRefsSetupScript, "call dolt_branch('-f', 'b2', 'main');"),
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_update_refs('b1', 'main', 'b2', 'main~1');",
+				ExpectedErrStr: "update is not a fast-forward: refs/heads/b2; use force to update it anyway",
+			},
+			{
+				Query:    "select name from dolt_branches where hash = (select hash from dolt_branches where name = 'main') order by name;",
+				Expected: []sql.Row{{"b2"}, {"main"}},
+			},
+			{
+				Query:    "call dolt_update_refs('--force', 'b1', 'main', 'b2', 'main~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_branches where hash = (select hash from dolt_branches where name = 'main') order by name;",
+				Expected: []sql.Row{{"b1"}, {"main"}},
+			},
+			{
+				Query:    "select * from `mydb/b2`.t;",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name:        "dolt_update_refs: creates branches",
+		SetUpScript: updateRefsSetupScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_update_refs('b1', 'main', 'b3', 'main~1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select name from dolt_branches where hash = (select hash from dolt_branches where name = 'b2') order by name;",
+				Expected: []sql.Row{{"b2"}, {"b3"}},
+			},
+		},
+	},
+	{
+		Name:        "dolt_update_refs: updates nothing if a branch has uncommitted changes",
+		SetUpScript: updateRefsSetupScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_checkout('b2');",
+				Expected: []sql.Row{{0, "Switched to branch 'b2'"}},
+			},
+			{
+				Query:    "insert into t values (2);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_checkout('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				Query:          "call dolt_update_refs('b1', 'main', 'b2', 'main');",
+				ExpectedErrStr: "cannot update a branch with uncommitted changes; no refs were updated",
+			},
+			{
+				Query:    "select count(distinct hash) from dolt_branches;",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select * from `mydb/b2`.t;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name:        "dolt_update_refs: invalid arguments",
+		SetUpScript: updateRefsSetupScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_update_refs('b1', 'main', 'b2');",
+				ExpectedErrStr: "error: invalid usage; dolt_update_refs takes pairs of a ref and a commit",
+			},
+			{
+				Query:          "call dolt_update_refs();",
+				ExpectedErrStr: "error: invalid usage; dolt_update_refs takes pairs of a ref and a commit",
+			},
+			{
+				Query:          "call dolt_update_refs('b1', 'main', 'refs/tags/v1', 'main');",
+				ExpectedErrStr: "cannot update refs/tags/v1: only branches and remote tracking branches can be updated",
+			},
+			{
+				Query:          "call dolt_update_refs('b1', 'main', 'b1', 'main~1');",
+				ExpectedErrStr: "UpdateHeads: dataset refs/heads/b1 is updated more than once",
+			},
+			{
+				Query:    "select count(distinct hash) from dolt_branches;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+}
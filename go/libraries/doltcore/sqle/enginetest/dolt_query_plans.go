@@ -75,6 +75,31 @@ var DoltCommitPlanTests = []queries.QueryPlanTest{
 			"     └─ name: dolt_log\n" +
 			"",
 	},
+	{
+		Query: "select * from dolt_log where committer = 'x' and date > '2020-01-01';",
+		ExpectedPlan: "Filter\n" +
+			" ├─ ((dolt_log.committer = 'x') AND (dolt_log.date > '2020-01-01'))\n" +
+			" └─ IndexedTableAccess(dolt_log)\n" +
+			"     └─ index: [dolt_log.committer,dolt_log.date]\n" +
+			"",
+	},
+	{
+		Query: "select * from dolt_log where message regexp 'x';",
+		ExpectedPlan: "Filter\n" +
+			" ├─ regexp_like(dolt_log.message,'x')\n" +
+			" └─ IndexedTableAccess(dolt_log)\n" +
+			"     └─ index: [dolt_log.message]\n" +
+			"",
+	},
+	{
+		Query: "select * from dolt_log where commit_hash = 'x' and committer = 'x';",
+		ExpectedPlan: "Filter\n" +
+			" ├─ (dolt_log.committer = 'x')\n" +
+			" └─ IndexedTableAccess(dolt_log)\n" +
+			"     ├─ index: [dolt_log.commit_hash]\n" +
+			"     └─ filters: [{[x, x]}]\n" +
+			"",
+	},
 	{
 		Query: "select * from dolt_diff order by commit_hash;",
 		ExpectedPlan: "Sort(dolt_diff.commit_hash ASC)\n" +
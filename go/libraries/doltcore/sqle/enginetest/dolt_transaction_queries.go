@@ -839,6 +839,541 @@ var DoltTransactionTests = []queries.TransactionTest{
 			},
 		},
 	},
+	{
+		Name: "schema change and backfill commit atomically, and a failed alter is undone",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 1), (2, 2), (3, -1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client a */ alter table t add column w int default 0, modify column v int unsigned",
+				ExpectedErrStr: "-1 out of range for int unsigned",
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, -1}},
+			},
+			{
+				Query:    "/* client a */ alter table t add column w int",
+				Expected: []sql.Row{{types.OkResult{}}},
+			},
+			{
+				Query:    "/* client a */ update t set w = v * 10",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 3, Info: plan.UpdateInfo{Matched: 3, Updated: 3}}}},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, -1}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from t order by pk",
+				Expected: []sql.Row{{1, 1, 10}, {2, 2, 20}, {3, -1, -10}},
+			},
+		},
+	},
+	{
+		Name: "failed alter on another branch is undone",
+		SetUpScript: []string{
+			"create table t (pk int primary key, v int)",
+			"insert into t values (1, 1), (2, 2)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('b1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:          "/* client a */ alter table `mydb/b1`.t add column w int default 1, add unique key (w)",
+				ExpectedErrStr: "duplicate unique key given: [1,2]",
+			},
+			{
+				Query:    "/* client a */ select * from `mydb/b1`.t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "/* client a */ alter table `mydb/b1`.t add column w int",
+				Expected: []sql.Row{{types.OkResult{}}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/b1`.t order by pk",
+				Expected: []sql.Row{{1, 1, nil}, {2, 2, nil}},
+			},
+			{
+				Query:    "/* client b */ show indexes from `mydb/b1`.t where key_name = 'w'",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "nested savepoints roll back working and staged changes on every branch",
+		SetUpScript: []string{
+			"create table t (x int primary key)",
+			"insert into t values (1)",
+			"call dolt_commit('-Am', 'new table')",
+			"call dolt_branch('b1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ savepoint sp1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ call dolt_add('t')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "/* client a */ insert into `mydb/b1`.t values (10)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ savepoint sp2",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (4)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ insert into `mydb/b1`.t values (11)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ savepoint sp3",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ rollback to sp2",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by x",
+				Expected: []sql.Row{{1}, {2}, {3}},
+			},
+			{
+				Query:    "/* client a */ select * from `mydb/b1`.t order by x",
+				Expected: []sql.Row{{1}, {10}},
+			},
+			{
+				Query:       "/* client a */ rollback to sp3",
+				ExpectedErr: sql.ErrSavepointDoesNotExist,
+			},
+			{
+				Query:    "/* client a */ select * from dolt_status",
+				Expected: []sql.Row{{"t", true, "modified"}},
+			},
+			{
+				Query:    "/* client a */ rollback to sp1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "/* client a */ select * from `mydb/b1`.t order by x",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ select * from dolt_status",
+				Expected: []sql.Row{{"t", false, "modified"}},
+			},
+			{
+				Query:    "/* client a */ savepoint sp2",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ release savepoint sp1",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:       "/* client a */ rollback to sp2",
+				ExpectedErr: sql.ErrSavepointDoesNotExist,
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from t order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "/* client b */ select * from `mydb/b1`.t order by x",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "serializable transactions fail to commit after a concurrent change to a branch they accessed",
+		SetUpScript: []string{
+			"create table t (x int primary key)",
+			"create table u (x int primary key)",
+			"insert into t values (1)",
+			"call dolt_commit('-Am', 'new tables')",
+			"call dolt_branch('b1')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set transaction isolation level serializable",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				// reads are pinned to the start of the transaction
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ insert into u select x + 10 from t",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "/* client a */ commit",
+				ExpectedErr: sql.ErrLockDeadlock,
+			},
+			{
+				Query:    "/* client a */ select * from u",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from `mydb/b1`.t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ insert into `mydb/b1`.t values (3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ insert into u values (1)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "/* client a */ commit",
+				ExpectedErr: sql.ErrLockDeadlock,
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into u select x + 10 from t",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				// only the branches accessed during this transaction are validated
+				Query:    "/* client b */ insert into `mydb/b1`.t values (4)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from u order by x",
+				Expected: []sql.Row{{11}, {12}},
+			},
+		},
+	},
+	{
+		Name: "read committed transactions run as repeatable read",
+		SetUpScript: []string{
+			"create table t (x int primary key)",
+			"insert into t values (1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set transaction isolation level read committed",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ show warnings",
+				Expected: []sql.Row{{"Warning", 1105, "transaction isolation level READ-COMMITTED runs as REPEATABLE-READ"}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				// the warning is given once, when the isolation level is set
+				Query:    "/* client a */ show warnings",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ insert into t values (2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+		},
+	},
+	{
+		Name: "repeatable read transactions merge concurrent changes to a branch they accessed",
+		SetUpScript: []string{
+			"create table t (x int primary key)",
+			"create table u (x int primary key)",
+			"insert into t values (1)",
+			"call dolt_commit('-Am', 'new tables')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set transaction isolation level repeatable read",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ insert into t values (2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client a */ insert into u select x + 10 from t",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by x",
+				Expected: []sql.Row{{1}, {2}},
+			},
+			{
+				Query:    "/* client a */ select * from u",
+				Expected: []sql.Row{{11}},
+			},
+		},
+	},
+	{
+		Name: "pessimistic locking makes writes to a row locked by another transaction wait for it",
+		SetUpScript: []string{
+			"create table t (pk int primary key, x int)",
+			"insert into t values (1, 1), (2, 2)",
+			"call dolt_commit('-Am', 'new table')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set @@dolt_pessimistic_locking = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client b */ set @@dolt_pessimistic_locking = 1, @@innodb_lock_wait_timeout = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ update t set x = 10 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:          "/* client b */ update t set x = 20 where pk = 1",
+				ExpectedErrStr: "Lock wait timeout exceeded; try restarting transaction (errno 1205) (sqlstate HY000)",
+			},
+			{
+				Query:    "/* client b */ update t set x = 20 where pk = 2",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ update t set x = 20 where pk = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select * from t order by pk",
+				Expected: []sql.Row{{1, 20}, {2, 20}},
+			},
+		},
+	},
+	{
+		Name: "sequences never hand out a value twice, across transactions or after a rollback",
+		SetUpScript: []string{
+			"create sequence s",
+			"call dolt_commit('-Am', 'new sequence')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select nextval('s')",
+				Expected: []sql.Row{{int64(1)}},
+			},
+			{
+				Query:    "/* client b */ select nextval('s')",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select current_value from dolt_sequences where name = 's'",
+				Expected: []sql.Row{{int64(2)}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ select nextval('s')",
+				Expected: []sql.Row{{int64(3)}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select nextval('s')",
+				Expected: []sql.Row{{int64(4)}},
+			},
+			{
+				Query:    "/* client b */ drop sequence s",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ create sequence s start with 10",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ select nextval('s')",
+				Expected: []sql.Row{{int64(10)}},
+			},
+		},
+	},
+	{
+		Name: "database quotas only charge a transaction for its own writes",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c varchar(1000))",
+			"insert into t values (1, 'one')",
+			"set @quota = (select used_bytes + 100000 from dolt_quotas)",
+			"set @@global.dolt_database_quotas = concat('mydb=', @quota)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "/* client b */ insert into t with recursive r(n) as (select 2 union all select n + 1 from r where n < 900) " +
+					"select n, repeat('b', 1000) from r",
+				Expected: []sql.Row{{types.NewOkResult(899)}},
+			},
+			{
+				// the chunks client b wrote aren't charged to client a
+				Query:    "/* client a */ insert into t values (1000, 'a')",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "/* client b */ commit",
+				ExpectedErr: dsess.ErrQuotaExceeded,
+			},
+			{
+				Query:    "/* client a */ select count(*) from t",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "/* client b */ set @@global.dolt_database_quotas = 'mydb=1B'",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:       "/* client a */ insert into t values (1001, 'a')",
+				ExpectedErr: dsess.ErrQuotaExceeded,
+			},
+			{
+				// deletes don't grow the database, so they're allowed over its quota
+				Query:    "/* client a */ delete from t where pk = 1000",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ select count(*) from t",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ set @@global.dolt_database_quotas = ''",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
 }
 
 var DoltConflictHandlingTests = []queries.TransactionTest{
@@ -3023,6 +3558,56 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 			"set autocommit = 0",
 			"create table db2.t1 (a int)",
 		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "insert into t1 values (1)",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1}},
+				},
+			},
+			{
+				Query: "insert into db2.t1 values (2)",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1}},
+				},
+			},
+			{
+				Query:    "commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from t1",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "select * from db2.t1",
+				Expected: []sql.Row{{2}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status",
+				Expected: []sql.Row{{"t1", false, "modified"}},
+			},
+			{
+				Query:    "select table_name, staged, status from db2.dolt_status",
+				Expected: []sql.Row{{"t1", false, "new table"}},
+			},
+		},
+	},
+	{
+		Name: "committing to more than one database at a time with dolt_transaction_commit",
+		SetUpScript: []string{
+			"create table t1 (a int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'new table')",
+			"create database db2",
+			"use db2",
+			"create table t1 (a int)",
+			"call dolt_add('.')",
+			"call dolt_commit('-am', 'new table')",
+			"use mydb",
+			"set autocommit = 0",
+			"set dolt_transaction_commit = 1",
+		},
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query: "insert into t1 values (1)",
@@ -3044,6 +3629,150 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 	},
 }
 
+// MultiDbTransactionCommitTests are tests of transactions which write to more than one database, whose working sets are
+// committed together.
+var MultiDbTransactionCommitTests = []queries.TransactionTest{
+	{
+		Name: "other transactions see the writes to every database or none",
+		SetUpScript: []string{
+			"create database db1",
+			"create database db2",
+			"create table db1.t (x int primary key, y int)",
+			"insert into db1.t values (1, 1)",
+			"create table db2.t (x int primary key, y int)",
+			"insert into db2.t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into db1.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ insert into db2.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from db1.t order by x",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t order by x",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client b */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from db1.t order by x",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t order by x",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+		},
+	},
+	{
+		Name: "merges with concurrent writes to every database",
+		SetUpScript: []string{
+			"create database db1",
+			"create database db2",
+			"create table db1.t (x int primary key, y int)",
+			"insert into db1.t values (1, 1)",
+			"create table db2.t (x int primary key, y int)",
+			"insert into db2.t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into db1.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ insert into db2.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ insert into db1.t values (3, 3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client b */ insert into db2.t values (3, 3)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ select * from db1.t order by x",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t order by x",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+		},
+	},
+	{
+		Name: "conflict in one database commits neither",
+		SetUpScript: []string{
+			"create database db1",
+			"create database db2",
+			"create table db1.t (x int primary key, y int)",
+			"insert into db1.t values (1, 1)",
+			"create table db2.t (x int primary key, y int)",
+			"insert into db2.t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into db1.t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ update db2.t set y = 2 where x = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "/* client b */ update db2.t set y = 3 where x = 1",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:          "/* client a */ commit",
+				ExpectedErrStr: sql.ErrLockDeadlock.New(dsess.ErrRetryTransaction.Error()).Error(),
+			},
+			{
+				Query:    "/* client b */ select * from db1.t order by x",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client b */ select * from db2.t order by x",
+				Expected: []sql.Row{{1, 3}},
+			},
+		},
+	},
+}
+
 var MultiDbSavepointTests = []queries.TransactionTest{
 	{
 		Name: "rollback to savepoint with multiple databases edited",
@@ -483,6 +483,62 @@ var DoltStatsIOTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		// approx_count_distinct reads the sketches of the statistics for
+		// full table scans, which are stale until the next analyze
+		Name: "approx count distinct",
+		SetUpScript: []string{
+			"CREATE table xy (x bigint primary key, y int, z int, key(y));",
+			"insert into xy select x, x % 100, x % 7 from (with recursive inputs(x) as (select 0 union select x+1 from inputs where x < 999) select * from inputs) dt;",
+			"analyze table xy",
+			"insert into xy select x, x, null from (with recursive inputs(x) as (select 1000 union select x+1 from inputs where x < 1099) select * from inputs) dt;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select approx_count_distinct(y) from xy",
+				Expected: []sql.Row{{int64(101)}},
+			},
+			{
+				Query:    "select approx_count_distinct(y), count(distinct y) from xy",
+				Expected: []sql.Row{{int64(205), 200}},
+			},
+			{
+				Query:    "select approx_count_distinct(y) from xy where x < 1000",
+				Expected: []sql.Row{{int64(101)}},
+			},
+			{
+				Query:    "select approx_count_distinct(z), approx_count_distinct(y, z) from xy",
+				Expected: []sql.Row{{int64(7), int64(700)}},
+			},
+			{
+				Query:    "select z, approx_count_distinct(y) + 1 from xy group by z order by z limit 2",
+				Expected: []sql.Row{{nil, int64(102)}, {0, int64(102)}},
+			},
+			{
+				Query:    "select approx_count_distinct(y) as n from (select y from xy where z = 0) dt",
+				Expected: []sql.Row{{int64(101)}},
+			},
+			{
+				Query:    "select approx_count_distinct(y) from xy where x < 0",
+				Expected: []sql.Row{{int64(0)}},
+			},
+			{
+				Query: "analyze table xy",
+			},
+			{
+				Query:    "select approx_count_distinct(y), approx_count_distinct(x) from xy",
+				Expected: []sql.Row{{int64(200), int64(1037)}},
+			},
+			{
+				Query:          "select approx_count_distinct()",
+				ExpectedErrStr: "function 'approx_count_distinct' expected 1 or more arguments, 0 received",
+			},
+			{
+				Query:          "select x from xy where approx_count_distinct(y) > 1",
+				ExpectedErrStr: "approx_count_distinct is only supported in the select list of a query",
+			},
+		},
+	},
 	{
 		Name: "incremental stats deletes manual analyze",
 		SetUpScript: []string{
@@ -719,6 +775,61 @@ var StatBranchTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "column group statistics",
+		SetUpScript: []string{
+			"CREATE table sales (id bigint primary key, region varchar(10), city varchar(10), amount int, key(region), key rc (region, city));",
+			"insert into sales values (1,'r1','c1',10), (2,'r1','c2',10), (3,'r2','c3',10), (4,'r2','c4',10), (5,'r1','c1',20), (6,'r1','c2',20), (7,'r2','c3',20), (8,'r2','c4',20), (9,'r1',NULL,10)",
+			"set @@GLOBAL.dolt_stats_column_groups = 'sales(region, city); mydb.sales(city,amount); other(a,b)';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select * from dolt_statistics_column_groups",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "analyze table sales",
+				Expected: []sql.Row{
+					{"sales", "analyze", "status", "OK"},
+				},
+			},
+			{
+				Query: "select table_name, columns, row_count, distinct_count, null_count, correlation from dolt_statistics_column_groups",
+				Expected: []sql.Row{
+					{"sales", "region,city", int64(9), int64(4), int64(1), 0.5},
+					{"sales", "city,amount", int64(9), int64(8), int64(1), 1.0},
+				},
+			},
+			{
+				Query: "select /*+ LOOKUP_JOIN(s1,s2) */ count(*) from sales s1 join sales s2 on s1.region = s2.region and s1.city = s2.city",
+				Expected: []sql.Row{
+					{16},
+				},
+			},
+			{
+				Query: "set @@GLOBAL.dolt_stats_column_groups = 'sales(region)';",
+			},
+			{
+				Query: "analyze table sales",
+				Expected: []sql.Row{
+					{"sales", "analyze", "Error", "invalid column group 'sales(region)': a group needs a table and at least two columns"},
+				},
+			},
+			{
+				Query: "set @@GLOBAL.dolt_stats_column_groups = '';",
+			},
+			{
+				Query: "analyze table sales",
+				Expected: []sql.Row{
+					{"sales", "analyze", "status", "OK"},
+				},
+			},
+			{
+				Query:    "select * from dolt_statistics_column_groups",
+				Expected: []sql.Row{},
+			},
+		},
+	},
 }
 
 var StatProcTests = []queries.ScriptTest{
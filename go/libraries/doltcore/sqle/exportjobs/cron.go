@@ -0,0 +1,172 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron schedule: the minutes at which an export job runs.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are whether the day of month and day of week fields are unrestricted. As in cron, when
+	// both are restricted a day matches if either matches.
+	domStar, dowStar bool
+}
+
+// cronField is the range of values of a field of a cron schedule.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	// day of week 7 is Sunday, like 0
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a cron schedule of five fields: minute, hour, day of month, month and day of week. Each field
+// is a comma separated list of values, ranges such as 1-5, or * for every value, each optionally followed by a step
+// such as */15. Months and days of the week may be given by their first three letters. The macros @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly are accepted too.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if expanded, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': expected 5 fields, found %d", spec, len(fields))
+	}
+
+	var s Schedule
+	var err error
+	if s.minute, err = parseCronField(fields[0], minuteField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+	}
+	if s.hour, err = parseCronField(fields[1], hourField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+	}
+	if s.dom, err = parseCronField(fields[2], domField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+	}
+	if s.month, err = parseCronField(fields[3], monthField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+	}
+	if s.dow, err = parseCronField(fields[4], dowField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField returns the set of values of |field|, as a bit set.
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s' for %s", stepStr, f.name)
+			}
+		}
+
+		var lo, hi int
+		switch {
+		case rng == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rng, "-"):
+			loStr, hiStr, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(hiStr); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range '%s' for %s", rng, f.name)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				// a value with a step, such as 5/15, runs from the value to the end of the range
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field, a number or a name.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value '%s' for %s, expected %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Matches returns whether the schedule runs at the minute of |t|.
+func (s Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 || s.hour&(1<<uint(t.Hour())) == 0 || s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
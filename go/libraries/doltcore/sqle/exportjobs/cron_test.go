@@ -0,0 +1,120 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// 2024-03-04 was a Monday
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec    string
+		matches []time.Time
+		misses  []time.Time
+	}{
+		{
+			spec:    "* * * * *",
+			matches: []time.Time{at(1, 1, 0, 0), at(12, 31, 23, 59)},
+		},
+		{
+			spec:    "*/15 9-17 * * *",
+			matches: []time.Time{at(3, 4, 9, 0), at(3, 4, 17, 45)},
+			misses:  []time.Time{at(3, 4, 9, 5), at(3, 4, 8, 0), at(3, 4, 18, 0)},
+		},
+		{
+			spec:    "5,35 0 * * *",
+			matches: []time.Time{at(3, 4, 0, 5), at(3, 4, 0, 35)},
+			misses:  []time.Time{at(3, 4, 0, 6), at(3, 4, 1, 5)},
+		},
+		{
+			spec:    "10/20 * * * *",
+			matches: []time.Time{at(3, 4, 1, 10), at(3, 4, 1, 30), at(3, 4, 1, 50)},
+			misses:  []time.Time{at(3, 4, 1, 0)},
+		},
+		{
+			spec:    "0 12 * jan-mar mon-fri",
+			matches: []time.Time{at(3, 4, 12, 0), at(1, 5, 12, 0)},
+			misses:  []time.Time{at(3, 3, 12, 0), at(4, 1, 12, 0)},
+		},
+		{
+			spec:    "0 0 * * 7",
+			matches: []time.Time{at(3, 3, 0, 0)},
+			misses:  []time.Time{at(3, 4, 0, 0)},
+		},
+		{
+			// both days restricted: either matching runs the job
+			spec:    "0 0 1 * mon",
+			matches: []time.Time{at(3, 1, 0, 0), at(3, 4, 0, 0)},
+			misses:  []time.Time{at(3, 2, 0, 0)},
+		},
+		{
+			spec:    "@daily",
+			matches: []time.Time{at(3, 4, 0, 0)},
+			misses:  []time.Time{at(3, 4, 0, 1), at(3, 4, 1, 0)},
+		},
+		{
+			spec:    "@hourly",
+			matches: []time.Time{at(3, 4, 0, 0), at(3, 4, 13, 0)},
+			misses:  []time.Time{at(3, 4, 13, 30)},
+		},
+		{
+			spec:    "@weekly",
+			matches: []time.Time{at(3, 3, 0, 0)},
+			misses:  []time.Time{at(3, 4, 0, 0)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			s, err := ParseSchedule(test.spec)
+			require.NoError(t, err)
+			for _, m := range test.matches {
+				assert.True(t, s.Matches(m), "expected a match at %s", m)
+			}
+			for _, m := range test.misses {
+				assert.False(t, s.Matches(m), "expected no match at %s", m)
+			}
+		})
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@sometimes",
+	} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, "expected an error for '%s'", spec)
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// ObjectStores open the buckets of object storage destinations, by the scheme of their URLs. A destination such as
+// gs://bucket/path/to/snapshot.csv is written to the key path/to/snapshot.csv of the blobstore opened for the bucket.
+var ObjectStores = map[string]func(ctx context.Context, bucket string) (blobstore.Blobstore, error){
+	"gs": func(ctx context.Context, bucket string) (blobstore.Blobstore, error) {
+		gcs, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return blobstore.NewGCSBlobstore(gcs, bucket, ""), nil
+	},
+}
+
+// expandDestination replaces the placeholders of |dest| for a run of |job| at |t|: {name} with the name of the job,
+// {database} with its database, {date} with the date of the run and {timestamp} with its time, both in UTC. Without
+// placeholders, each run overwrites the snapshot of the one before.
+func expandDestination(dest string, job Job, t time.Time) string {
+	return strings.NewReplacer(
+		"{name}", job.Name,
+		"{database}", job.Database,
+		"{date}", t.UTC().Format("2006-01-02"),
+		"{timestamp}", t.UTC().Format("20060102T150405Z"),
+	).Replace(dest)
+}
+
+// snapshotFormat returns the format of a snapshot written to |dest|, which is |format| if given, or otherwise given
+// by the extension of |dest|, defaulting to csv.
+func snapshotFormat(format, dest string) (string, error) {
+	if format == "" {
+		if strings.EqualFold(filepath.Ext(dest), ".json") {
+			return "json", nil
+		}
+		return "csv", nil
+	}
+	for _, f := range Formats {
+		if strings.EqualFold(f, format) {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format '%s', expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// snapshotWriter is the file a snapshot is written to before it's moved to its destination. Closing it more than
+// once is harmless, since the row writers close it when they're closed.
+type snapshotWriter struct {
+	*os.File
+	closed bool
+}
+
+func (w *snapshotWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.File.Close()
+}
+
+// writeSnapshot writes a snapshot to |dest| with |write|, so that it replaces any snapshot already there only once
+// written completely. |dest| is either a local path, optionally a file:// URL, or an object storage URL with a
+// scheme of ObjectStores. Local paths are relative to the export directory |dir|, see resolveLocalPath.
+func writeSnapshot(ctx context.Context, dir, dest string, write func(wr *snapshotWriter) error) error {
+	// a single letter scheme is a windows drive letter
+	u, err := url.Parse(dest)
+	if err == nil && len(u.Scheme) > 1 && u.Scheme != "file" {
+		openBucket, ok := ObjectStores[strings.ToLower(u.Scheme)]
+		if !ok {
+			return fmt.Errorf("unsupported export destination '%s'", dest)
+		}
+		return writeObject(ctx, openBucket, u, write)
+	}
+
+	path, err := resolveLocalPath(dir, strings.TrimPrefix(dest, "file://"))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	wr := &snapshotWriter{File: f}
+	err = write(wr)
+	if closeErr := wr.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// resolveLocalPath returns the path in the export directory |dir| of the local destination |dest|. |dest| must be a
+// relative path which stays in |dir|: it can't be absolute, contain .. or lead out of |dir| through a symlink. Local
+// destinations are refused if there is no export directory.
+func resolveLocalPath(dir, dest string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("export destination '%s' is a local path, but the server has no export directory", dest)
+	}
+	if filepath.IsAbs(dest) || filepath.VolumeName(dest) != "" || strings.HasPrefix(dest, "/") || strings.HasPrefix(dest, `\`) {
+		return "", fmt.Errorf("export destination '%s' must be relative to the export directory", dest)
+	}
+	for _, elem := range strings.FieldsFunc(dest, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return "", fmt.Errorf("export destination '%s' must not contain '..'", dest)
+		}
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("invalid export directory: %w", err)
+	}
+	path := filepath.Join(root, filepath.Clean(dest))
+	if path == root {
+		return "", fmt.Errorf("export destination '%s' must name a file", dest)
+	}
+
+	// the directories of |path| which already exist must stay in |root| once their symlinks are followed, those which
+	// don't will be created in them
+	existing := filepath.Dir(path)
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		existing = filepath.Dir(existing)
+	}
+	resolved, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("export destination '%s' leads out of the export directory", dest)
+	}
+	rel, err := filepath.Rel(existing, path)
+	if err != nil {
+		return "", err
+	}
+	path = filepath.Join(resolved, rel)
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return "", fmt.Errorf("export destination '%s' is a symlink", dest)
+	}
+	return path, nil
+}
+
+// writeObject writes a snapshot with |write| to a temporary file, then puts it to the object named by |u|.
+func writeObject(ctx context.Context, openBucket func(context.Context, string) (blobstore.Blobstore, error), u *url.URL, write func(wr *snapshotWriter) error) error {
+	f, err := os.CreateTemp("", "dolt-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	wr := &snapshotWriter{File: f}
+	err = write(wr)
+	if closeErr := wr.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	bs, err := openBucket(ctx, u.Host)
+	if err != nil {
+		return err
+	}
+	rd, err := os.Open(f.Name())
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	info, err := rd.Stat()
+	if err != nil {
+		return err
+	}
+	_, err = bs.Put(ctx, strings.TrimPrefix(u.Path, "/"), info.Size(), rd)
+	return err
+}
+
+// sqlRowWriter is a writer of query results, as implemented by the csv and json writers.
+type sqlRowWriter interface {
+	WriteSqlRow(ctx context.Context, r sql.Row) error
+	Close(ctx context.Context) error
+}
+
+// writeRows writes the rows of |iter|, of schema |sch|, to |wr| in |format|, returning the number of rows written.
+func writeRows(ctx *sql.Context, wr *snapshotWriter, format string, sch sql.Schema, iter sql.RowIter) (uint64, error) {
+	var rw sqlRowWriter
+	var err error
+	switch format {
+	case "json":
+		rw, err = json.NewJSONSqlWriter(wr, sch)
+	default:
+		rw, err = csv.NewCSVSqlWriter(wr, sch, csv.NewCSVInfo())
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var rows uint64
+	for {
+		r, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			_ = rw.Close(ctx)
+			return rows, err
+		}
+		if err := rw.WriteSqlRow(ctx, r); err != nil {
+			_ = rw.Close(ctx)
+			return rows, err
+		}
+		rows++
+	}
+
+	if rows == 0 && format == "json" {
+		// the json writer writes nothing at all without rows
+		if _, err := io.WriteString(wr, `{"rows": []}`); err != nil {
+			_ = rw.Close(ctx)
+			return 0, err
+		}
+	}
+	return rows, rw.Close(ctx)
+}
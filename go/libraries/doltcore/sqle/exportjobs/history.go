@@ -0,0 +1,132 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// maxHistory is the number of runs kept in the history of the server.
+const maxHistory = 1000
+
+// Run is a run of an export job.
+type Run struct {
+	Database string    `json:"database"`
+	Job      string    `json:"job"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// Destination is where the snapshot was written, with the placeholders of the job's destination replaced
+	Destination string `json:"destination"`
+	Rows        uint64 `json:"rows"`
+	// Error is the error the run failed with, or empty if it succeeded
+	Error string `json:"error,omitempty"`
+}
+
+// Succeeded returns whether the run succeeded.
+func (r Run) Succeeded() bool {
+	return r.Error == ""
+}
+
+// History is the history of the most recent runs of export jobs.
+type History struct {
+	mu    sync.Mutex
+	runs  []Run
+	limit int
+}
+
+// NewHistory returns a History of the most recent |limit| runs.
+func NewHistory(limit int) *History {
+	return &History{limit: limit}
+}
+
+var defaultHistory = NewHistory(maxHistory)
+
+func (h *History) add(run Run) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.runs = append(h.runs, run)
+	if len(h.runs) > h.limit {
+		h.runs = append([]Run(nil), h.runs[len(h.runs)-h.limit:]...)
+	}
+}
+
+// Runs returns the runs of the history, oldest first.
+func (h *History) Runs() []Run {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Run(nil), h.runs...)
+}
+
+// Runs returns the most recent runs of export jobs in this process, oldest first.
+func Runs() []Run {
+	return defaultHistory.Runs()
+}
+
+// notificationTimeout is how long posting the notification of a failed run may take.
+const notificationTimeout = 10 * time.Second
+
+// notificationClient posts notifications of failed runs. It doesn't follow redirects, which could lead it to URLs
+// that aren't notification URLs of the server.
+var notificationClient = &http.Client{
+	Timeout: notificationTimeout,
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// ValidateNotificationURL returns an error if |notificationURL| isn't an http or https URL with a host.
+func ValidateNotificationURL(notificationURL string) error {
+	u, err := url.Parse(notificationURL)
+	if err != nil {
+		return fmt.Errorf("invalid notification URL '%s': %w", notificationURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid notification URL '%s', it must be an http or https URL", notificationURL)
+	}
+	return nil
+}
+
+// postNotification posts |run|, which failed, as JSON to |url|. A redirect is an error.
+func postNotification(ctx context.Context, url string, run Run) error {
+	body, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := notificationClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
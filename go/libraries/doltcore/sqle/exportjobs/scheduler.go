@@ -0,0 +1,361 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// Formats are the formats export jobs write snapshots in, the values of the format column of dolt_export_jobs.
+var Formats = []string{"csv", "json"}
+
+// Job is an export job, a row of the dolt_export_jobs system table of a database.
+type Job struct {
+	// Database is the database whose dolt_export_jobs table the job is in
+	Database string
+	// Name is the name of the job, unique in its database
+	Name string
+	// Schedule is the cron schedule of the job, see ParseSchedule
+	Schedule string
+	// Table is the table exported, if the job exports a table
+	Table string
+	// Query is the query whose results are exported, if the job exports a query
+	Query string
+	// Destination is the path or object storage URL the snapshot is written to, see expandDestination
+	Destination string
+	// Format is the format of the snapshot, one of Formats, or empty to use the destination's extension
+	Format string
+	// OnFailure is a URL to which a notification of a failed run is posted, if any, see Config.NotificationURLs
+	OnFailure string
+	// Definer is the user who last wrote the job, as whom it runs, see Definer
+	Definer string
+}
+
+// Config configures what the export jobs run by a Scheduler can write to and notify.
+type Config struct {
+	// Directory is the directory snapshots with local destinations are written in, which their destinations are
+	// relative to and can't leave. Without one, jobs can only write to object storage.
+	Directory string
+	// NotificationURLs are the URLs notifications of failed runs can be posted to. The notification URLs of jobs which
+	// aren't one of them are ignored.
+	NotificationURLs []string
+}
+
+// Engine is the SQL engine export jobs are read from and run with.
+type Engine interface {
+	// NewLocalContext returns a new context with a new session, with the privileges of the server. Jobs are read with
+	// its privileges, but run with those of their definers.
+	NewLocalContext(ctx context.Context) (*sql.Context, error)
+	// Query runs |query| in |ctx|.
+	Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
+}
+
+// FailureHook is notified of each run of an export job which fails.
+type FailureHook func(ctx context.Context, run Run)
+
+// Scheduler runs the export jobs of every database of an engine at the minutes their schedules give. Jobs run as
+// background jobs of kind background.Export, and their runs are recorded in a History.
+type Scheduler struct {
+	engine  Engine
+	config  Config
+	history *History
+
+	mu    sync.Mutex
+	hooks []FailureHook
+	// running are the jobs running, by database and name, which aren't started again until they finish
+	running map[string]struct{}
+}
+
+// NewScheduler returns a Scheduler for the export jobs of the databases of |engine|, configured by |config|, which
+// records their runs in the history returned by Runs.
+func NewScheduler(engine Engine, config Config) *Scheduler {
+	return &Scheduler{
+		engine:  engine,
+		config:  config,
+		history: defaultHistory,
+		running: make(map[string]struct{}),
+	}
+}
+
+// AddFailureHook adds a hook notified of failed runs, in addition to the notification URLs of the jobs.
+func (s *Scheduler) AddFailureHook(hook FailureHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// Run runs the jobs due at the start of each minute until |ctx| is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		go s.RunDue(ctx, next)
+	}
+}
+
+// RunDue runs the jobs whose schedules match the minute of |t|, and waits for them to finish. Jobs still running
+// from an earlier minute are skipped.
+func (s *Scheduler) RunDue(ctx context.Context, t time.Time) {
+	jobs, err := s.Jobs(ctx)
+	if err != nil {
+		logrus.Warnf("failed to load export jobs: %s", err.Error())
+		return
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, job := range jobs {
+		schedule, err := ParseSchedule(job.Schedule)
+		if err != nil {
+			logrus.Warnf("export job %s of database %s: %s", job.Name, job.Database, err.Error())
+			continue
+		}
+		if !schedule.Matches(t) || !s.start(job) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			defer s.finish(job)
+			s.RunJob(ctx, job, t)
+		}(job)
+	}
+	wg.Wait()
+}
+
+func runningKey(job Job) string {
+	return strings.ToLower(job.Database) + "/" + strings.ToLower(job.Name)
+}
+
+// start records that |job| is running, returning false if it already was.
+func (s *Scheduler) start(job Job) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.running[runningKey(job)]; ok {
+		return false
+	}
+	s.running[runningKey(job)] = struct{}{}
+	return true
+}
+
+func (s *Scheduler) finish(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, runningKey(job))
+}
+
+// Jobs returns the export jobs of every database, from the dolt_export_jobs tables of their default branches.
+func (s *Scheduler) Jobs(ctx context.Context) ([]Job, error) {
+	sqlCtx, err := s.engine.NewLocalContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbRows, err := s.query(sqlCtx, "SHOW DATABASES")
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	for _, dbRow := range dbRows {
+		dbName, ok := dbRow[0].(string)
+		if !ok || isInternalDatabase(dbName) {
+			continue
+		}
+
+		rows, err := s.query(sqlCtx, fmt.Sprintf("SELECT name, schedule, table_name, query, destination, CAST(format AS CHAR), on_failure, definer FROM %s.%s",
+			quoteIdentifier(dbName), quoteIdentifier(doltdb.ExportJobsTableName)))
+		if sql.ErrTableNotFound.Is(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read the export jobs of database %s: %w", dbName, err)
+		}
+
+		for _, row := range rows {
+			jobs = append(jobs, Job{
+				Database:    dbName,
+				Name:        stringValue(row[0]),
+				Schedule:    stringValue(row[1]),
+				Table:       stringValue(row[2]),
+				Query:       stringValue(row[3]),
+				Destination: stringValue(row[4]),
+				Format:      stringValue(row[5]),
+				OnFailure:   stringValue(row[6]),
+				Definer:     stringValue(row[7]),
+			})
+		}
+	}
+	return jobs, nil
+}
+
+// Definer returns the definer of the export jobs written by |client|, as whom they run.
+func Definer(client sql.Client) string {
+	return fmt.Sprintf("`%s`@`%s`", client.User, client.Address)
+}
+
+// parseDefiner returns the client of the user and host of |definer|, see Definer.
+func parseDefiner(definer string) (sql.Client, error) {
+	user, host, ok := strings.Cut(definer, "@")
+	if !ok || len(user) < 2 || len(host) < 2 || user[0] != '`' || user[len(user)-1] != '`' || host[0] != '`' || host[len(host)-1] != '`' {
+		return sql.Client{}, fmt.Errorf("invalid definer '%s'", definer)
+	}
+	return sql.Client{User: user[1 : len(user)-1], Address: host[1 : len(host)-1]}, nil
+}
+
+func isInternalDatabase(dbName string) bool {
+	switch strings.ToLower(dbName) {
+	case "information_schema", "mysql", "performance_schema", "sys":
+		return true
+	}
+	return false
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func stringValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// query runs |query| and returns its rows.
+func (s *Scheduler) query(ctx *sql.Context, query string) ([]sql.Row, error) {
+	_, iter, _, err := s.engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, iter)
+}
+
+// RunJob runs |job| once, as of the time |t|, and returns its run, which is added to the history. If the run fails,
+// the job's notification URL and the failure hooks are notified.
+func (s *Scheduler) RunJob(ctx context.Context, job Job, t time.Time) Run {
+	run := Run{
+		Database: job.Database,
+		Job:      job.Name,
+		Started:  time.Now(),
+	}
+
+	err := background.Run(ctx, background.Export, func(ctx context.Context) error {
+		var err error
+		run.Destination, run.Rows, err = s.export(ctx, job, t)
+		return err
+	})
+	run.Finished = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+	}
+	s.history.add(run)
+
+	if err != nil {
+		logrus.Warnf("export job %s of database %s failed: %s", job.Name, job.Database, err.Error())
+		s.notify(ctx, job, run)
+	}
+	return run
+}
+
+// notify notifies the notification URL of |job| and the failure hooks of its failed |run|.
+func (s *Scheduler) notify(ctx context.Context, job Job, run Run) {
+	if job.OnFailure != "" && !slices.Contains(s.config.NotificationURLs, job.OnFailure) {
+		logrus.Warnf("not notifying %s of the failure of export job %s, since it isn't one of the server's export notification URLs", job.OnFailure, job.Name)
+	} else if job.OnFailure != "" {
+		if err := postNotification(ctx, job.OnFailure, run); err != nil {
+			logrus.Warnf("failed to notify %s of the failure of export job %s: %s", job.OnFailure, job.Name, err.Error())
+		}
+	}
+
+	s.mu.Lock()
+	hooks := append([]FailureHook(nil), s.hooks...)
+	s.mu.Unlock()
+	for _, hook := range hooks {
+		hook(ctx, run)
+	}
+}
+
+// export writes the snapshot of |job| as of |t|, returning where it was written and the number of rows written. The
+// job's query runs with the privileges of its definer.
+func (s *Scheduler) export(ctx context.Context, job Job, t time.Time) (string, uint64, error) {
+	if (job.Table == "") == (job.Query == "") {
+		return "", 0, fmt.Errorf("export job %s must have exactly one of table_name and query", job.Name)
+	}
+	query := job.Query
+	if job.Table != "" {
+		query = "SELECT * FROM " + quoteIdentifier(job.Table)
+	}
+
+	dest := expandDestination(job.Destination, job, t)
+	format, err := snapshotFormat(job.Format, dest)
+	if err != nil {
+		return dest, 0, err
+	}
+
+	definer, err := parseDefiner(job.Definer)
+	if err != nil {
+		return dest, 0, fmt.Errorf("export job %s has no valid definer to run as: %w", job.Name, err)
+	}
+
+	sqlCtx, err := s.engine.NewLocalContext(ctx)
+	if err != nil {
+		return dest, 0, err
+	}
+	sqlCtx.Session.SetClient(definer)
+	sqlCtx.SetCurrentDatabase(job.Database)
+
+	sch, iter, _, err := s.engine.Query(sqlCtx, query)
+	if err != nil {
+		return dest, 0, err
+	}
+
+	var rows uint64
+	err = writeSnapshot(ctx, s.config.Directory, dest, func(wr *snapshotWriter) error {
+		var err error
+		rows, err = writeRows(sqlCtx, wr, format, sch, iter)
+		return err
+	})
+	if closeErr := iter.Close(sqlCtx); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return dest, 0, err
+	}
+	return dest, rows, nil
+}
@@ -0,0 +1,306 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exportjobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+)
+
+// memoryEngine runs export jobs with an in memory engine.
+type memoryEngine struct {
+	*gms.Engine
+	pro *memory.DbProvider
+}
+
+func (e memoryEngine) NewLocalContext(ctx context.Context) (*sql.Context, error) {
+	return sql.NewContext(ctx, sql.WithSession(memory.NewSession(sql.NewBaseSession(), e.pro))), nil
+}
+
+func newMemoryEngine(t *testing.T, queries ...string) memoryEngine {
+	pro := memory.NewDBProvider(memory.NewDatabase("db"))
+	e := memoryEngine{Engine: gms.NewDefault(pro), pro: pro}
+
+	ctx, err := e.NewLocalContext(context.Background())
+	require.NoError(t, err)
+	ctx.SetCurrentDatabase("db")
+	queries = append([]string{
+		"CREATE TABLE dolt_export_jobs (name varchar(64) primary key, schedule text, table_name text, query text, destination text, format enum('csv', 'json'), on_failure text, definer text)",
+		"CREATE TABLE people (id int primary key, name varchar(20))",
+		"INSERT INTO people VALUES (1, 'Ada'), (2, 'Grace')",
+	}, queries...)
+	for _, q := range queries {
+		_, iter, _, err := e.Query(ctx, q)
+		require.NoError(t, err, q)
+		_, err = sql.RowIterToRows(ctx, iter)
+		require.NoError(t, err, q)
+	}
+	return e
+}
+
+func newTestScheduler(e Engine, config Config) *Scheduler {
+	s := NewScheduler(e, config)
+	s.history = NewHistory(10)
+	return s
+}
+
+// testDefiner is the definer of the jobs of the tests.
+const testDefiner = "`root`@`localhost`"
+
+var testTime = time.Date(2024, 3, 4, 12, 30, 0, 0, time.UTC)
+
+func TestRunDue(t *testing.T) {
+	dir := t.TempDir()
+	e := newMemoryEngine(t,
+		fmt.Sprintf("INSERT INTO dolt_export_jobs VALUES ('people', '30 12 * * *', 'people', NULL, '{name}-{date}.csv', NULL, NULL, '%s')", testDefiner),
+		fmt.Sprintf("INSERT INTO dolt_export_jobs VALUES ('ada', '*/15 * * * *', NULL, 'SELECT name FROM people WHERE id = 1', 'file://{database}/ada', 'json', NULL, '%s')", testDefiner),
+		fmt.Sprintf("INSERT INTO dolt_export_jobs VALUES ('later', '0 13 * * *', 'people', NULL, 'later.csv', NULL, NULL, '%s')", testDefiner),
+	)
+	s := newTestScheduler(e, Config{Directory: dir})
+
+	jobs, err := s.Jobs(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, jobs, 3)
+
+	s.RunDue(context.Background(), testTime)
+
+	data, err := os.ReadFile(filepath.Join(dir, "people-2024-03-04.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n1,Ada\n2,Grace\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "db", "ada"))
+	require.NoError(t, err)
+	var rows map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &rows))
+	assert.Equal(t, []map[string]interface{}{{"name": "Ada"}}, rows["rows"])
+
+	_, err = os.Stat(filepath.Join(dir, "later.csv"))
+	assert.True(t, os.IsNotExist(err))
+
+	runs := s.history.Runs()
+	require.Len(t, runs, 2)
+	for _, run := range runs {
+		assert.True(t, run.Succeeded(), run.Error)
+		assert.Equal(t, "db", run.Database)
+	}
+}
+
+func TestRunJobFailure(t *testing.T) {
+	notified := make(chan Run, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var run Run
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &run)
+		notified <- run
+	}))
+	defer srv.Close()
+
+	e := newMemoryEngine(t)
+	s := newTestScheduler(e, Config{Directory: t.TempDir(), NotificationURLs: []string{srv.URL}})
+	var hooked []Run
+	s.AddFailureHook(func(_ context.Context, run Run) {
+		hooked = append(hooked, run)
+	})
+
+	run := s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "broken",
+		Schedule:    "* * * * *",
+		Query:       "SELECT * FROM missing",
+		Destination: "broken.csv",
+		OnFailure:   srv.URL,
+		Definer:     testDefiner,
+	}, testTime)
+
+	assert.False(t, run.Succeeded())
+	assert.Contains(t, run.Error, "missing")
+	require.Len(t, hooked, 1)
+	assert.Equal(t, "broken", hooked[0].Job)
+	select {
+	case posted := <-notified:
+		assert.Equal(t, "broken", posted.Job)
+		assert.Equal(t, run.Error, posted.Error)
+	default:
+		t.Fatal("expected a notification of the failed run")
+	}
+	assert.Equal(t, []Run{run}, s.history.Runs())
+
+	// jobs can only notify the notification URLs of the server
+	run = s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "broken",
+		Query:       "SELECT * FROM missing",
+		Destination: "broken.csv",
+		OnFailure:   srv.URL + "/elsewhere",
+		Definer:     testDefiner,
+	}, testTime)
+	assert.False(t, run.Succeeded())
+	require.Len(t, hooked, 2)
+	select {
+	case posted := <-notified:
+		t.Fatalf("unexpected notification of %s", posted.Job)
+	default:
+	}
+}
+
+func TestPostNotificationRedirect(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	err := postNotification(context.Background(), srv.URL, Run{Job: "broken"})
+	assert.Error(t, err)
+	assert.False(t, redirected)
+
+	assert.NoError(t, ValidateNotificationURL("https://hooks.example.com/exports"))
+	assert.Error(t, ValidateNotificationURL("file:///etc/passwd"))
+	assert.Error(t, ValidateNotificationURL("gopher://hooks.example.com"))
+	assert.Error(t, ValidateNotificationURL("/exports"))
+}
+
+func TestRunJobDefiner(t *testing.T) {
+	s := newTestScheduler(newMemoryEngine(t), Config{Directory: t.TempDir()})
+	run := s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "people",
+		Table:       "people",
+		Destination: "people.csv",
+	}, testTime)
+	assert.Contains(t, run.Error, "no valid definer")
+
+	client, err := parseDefiner(Definer(sql.Client{User: "exporter", Address: "localhost"}))
+	require.NoError(t, err)
+	assert.Equal(t, sql.Client{User: "exporter", Address: "localhost"}, client)
+	_, err = parseDefiner("exporter")
+	assert.Error(t, err)
+}
+
+func TestLocalDestinations(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "escape")))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "real"), os.ModePerm))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "real"), filepath.Join(dir, "inside")))
+
+	path, err := resolveLocalPath(dir, "snapshots/people.csv")
+	require.NoError(t, err)
+	root, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "snapshots", "people.csv"), path)
+	path, err = resolveLocalPath(dir, "inside/people.csv")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "real", "people.csv"), path)
+
+	for _, dest := range []string{
+		filepath.Join(outside, "people.csv"),
+		"../people.csv",
+		"snapshots/../../people.csv",
+		"escape/people.csv",
+		"escape/new/people.csv",
+		".",
+	} {
+		_, err := resolveLocalPath(dir, dest)
+		assert.Error(t, err, dest)
+	}
+	_, err = resolveLocalPath("", "people.csv")
+	assert.Error(t, err)
+
+	// destinations which escape the directory once their placeholders are replaced are refused too
+	s := newTestScheduler(newMemoryEngine(t), Config{Directory: dir})
+	run := s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "../people",
+		Table:       "people",
+		Destination: "{name}.csv",
+		Definer:     testDefiner,
+	}, testTime)
+	assert.Contains(t, run.Error, "must not contain '..'")
+	_, err = os.Stat(filepath.Join(filepath.Dir(dir), "people.csv"))
+	assert.True(t, os.IsNotExist(err))
+
+	s = newTestScheduler(newMemoryEngine(t), Config{})
+	run = s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "people",
+		Table:       "people",
+		Destination: "people.csv",
+		Definer:     testDefiner,
+	}, testTime)
+	assert.Contains(t, run.Error, "no export directory")
+}
+
+func TestRunJobObjectStore(t *testing.T) {
+	bs := blobstore.NewInMemoryBlobstore("")
+	ObjectStores["mem"] = func(_ context.Context, bucket string) (blobstore.Blobstore, error) {
+		assert.Equal(t, "bucket", bucket)
+		return bs, nil
+	}
+	defer delete(ObjectStores, "mem")
+
+	s := newTestScheduler(newMemoryEngine(t), Config{})
+	run := s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "people",
+		Table:       "people",
+		Destination: "mem://bucket/snapshots/{timestamp}.csv",
+		Definer:     testDefiner,
+	}, testTime)
+	require.True(t, run.Succeeded(), run.Error)
+	assert.Equal(t, "mem://bucket/snapshots/20240304T123000Z.csv", run.Destination)
+	assert.Equal(t, uint64(2), run.Rows)
+
+	data, _, err := blobstore.GetBytes(context.Background(), bs, "snapshots/20240304T123000Z.csv", blobstore.AllRange)
+	require.NoError(t, err)
+	assert.Equal(t, "id,name\n1,Ada\n2,Grace\n", string(data))
+
+	run = s.RunJob(context.Background(), Job{
+		Database:    "db",
+		Name:        "people",
+		Table:       "people",
+		Destination: "s4://bucket/people.csv",
+		Definer:     testDefiner,
+	}, testTime)
+	assert.Contains(t, run.Error, "unsupported export destination")
+}
+
+func TestHistoryLimit(t *testing.T) {
+	h := NewHistory(2)
+	h.add(Run{Job: "a"})
+	h.add(Run{Job: "b"})
+	h.add(Run{Job: "c"})
+	assert.Equal(t, []Run{{Job: "b"}, {Job: "c"}}, h.Runs())
+}
@@ -0,0 +1,323 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package federated implements federated tables, whose rows are read from a table on an external MySQL server each
+// time they're scanned, like the tables of MySQL's FEDERATED storage engine. A database's federated tables are
+// defined by the rows of its dolt_federated_tables system table.
+package federated
+
+import (
+	gosql "database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+const (
+	defaultPort = 3306
+	dialTimeout = 10 * time.Second
+
+	// passwordEnvParam is the parameter of a connection string naming the environment variable of the password
+	passwordEnvParam = "password_env"
+)
+
+// Connection is the location of a table on an external MySQL server, parsed from a connection string of the form
+// mysql://user@host[:port]/database/table[?password_env=name], the form of the CONNECTION of a MySQL FEDERATED table.
+// Connection strings are stored in a versioned table, so they can't contain a password: the password of the user is
+// read from the environment variable named by password_env, whose name must start with DOLT_FEDERATED_PASSWORD_.
+type Connection struct {
+	User        string
+	PasswordEnv string
+	Host        string
+	Port        int
+	Database    string
+	Table       string
+}
+
+// ParseConnection parses the connection string |s| of a federated table.
+func ParseConnection(s string) (Connection, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		// the error of url.Parse quotes the whole string, including any password
+		return Connection{}, fmt.Errorf("invalid connection: %w", errors.Unwrap(err))
+	}
+	if u.Scheme != "mysql" {
+		return Connection{}, fmt.Errorf("invalid connection '%s': the scheme must be mysql", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return Connection{}, fmt.Errorf("invalid connection '%s': no host", u.Redacted())
+	}
+	if _, ok := u.User.Password(); ok {
+		return Connection{}, fmt.Errorf("invalid connection '%s': passwords can't be stored in a connection, use password_env to name an environment variable starting with %s", u.Redacted(), dconfig.EnvFederatedPasswordPrefix)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Connection{}, fmt.Errorf("invalid connection '%s': the path must be /database/table", u.Redacted())
+	}
+
+	c := Connection{
+		User:     u.User.Username(),
+		Host:     u.Hostname(),
+		Port:     defaultPort,
+		Database: parts[0],
+		Table:    parts[1],
+	}
+	if p := u.Port(); p != "" {
+		if c.Port, err = strconv.Atoi(p); err != nil {
+			return Connection{}, fmt.Errorf("invalid connection '%s': invalid port %s", u.Redacted(), p)
+		}
+	}
+	for param, vals := range u.Query() {
+		if param != passwordEnvParam || len(vals) != 1 {
+			return Connection{}, fmt.Errorf("invalid connection '%s': invalid parameter %s", u.Redacted(), param)
+		}
+		// only the environment variables meant for federated tables can be read, so that whoever defines a federated
+		// table can't send the server's other secrets to a server of their choosing
+		if !strings.HasPrefix(vals[0], dconfig.EnvFederatedPasswordPrefix) {
+			return Connection{}, fmt.Errorf("invalid connection '%s': the name of the environment variable of %s must start with %s", u.Redacted(), passwordEnvParam, dconfig.EnvFederatedPasswordPrefix)
+		}
+		c.PasswordEnv = vals[0]
+	}
+	return c, nil
+}
+
+// RedactConnection returns the connection string |s| with any password it contains redacted. Connections with
+// passwords can't be written, but may have been merged from elsewhere.
+func RedactConnection(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		// the string may still contain a password, which url.URL.Redacted can't find
+		return ""
+	}
+	return u.Redacted()
+}
+
+// String returns the connection string of |c|.
+func (c Connection) String() string {
+	u := url.URL{
+		Scheme: "mysql",
+		Host:   net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:   "/" + c.Database + "/" + c.Table,
+	}
+	if c.User != "" {
+		u.User = url.User(c.User)
+	}
+	if c.PasswordEnv != "" {
+		u.RawQuery = url.Values{passwordEnvParam: []string{c.PasswordEnv}}.Encode()
+	}
+	return u.String()
+}
+
+func (c Connection) dsn() string {
+	cfg := mysql.NewConfig()
+	cfg.User = c.User
+	if c.PasswordEnv != "" {
+		cfg.Passwd = os.Getenv(c.PasswordEnv)
+	}
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	cfg.DBName = c.Database
+	cfg.Timeout = dialTimeout
+	cfg.AllowNativePasswords = true
+	return cfg.FormatDSN()
+}
+
+var pools = struct {
+	mu  sync.Mutex
+	dbs map[string]*gosql.DB
+}{dbs: make(map[string]*gosql.DB)}
+
+// pool returns the connection pool to the server of |c|, which is shared by the federated tables on the server.
+func pool(c Connection) (*gosql.DB, error) {
+	dsn := c.dsn()
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+	if db, ok := pools.dbs[dsn]; ok {
+		return db, nil
+	}
+	db, err := gosql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetConnMaxIdleTime(time.Minute)
+	pools.dbs[dsn] = db
+	return db, nil
+}
+
+// Table is a read-only table whose rows are read from a table on an external MySQL server each time it's scanned.
+// Filters aren't pushed down to the server, so every scan reads the whole table.
+type Table struct {
+	name string
+	conn Connection
+	sch  sql.Schema
+}
+
+var _ sql.Table = (*Table)(nil)
+
+// NewTable returns the federated table |name| of the table at |conn|, whose schema is read from the server.
+func NewTable(ctx *sql.Context, name string, conn Connection) (*Table, error) {
+	db, err := pool(conn)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, "SELECT column_name, column_type, is_nullable, column_key FROM information_schema.columns "+
+		"WHERE table_schema = ? AND table_name = ? ORDER BY ordinal_position", conn.Database, conn.Table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the schema of federated table %s from %s: %w", name, conn, err)
+	}
+	defer rows.Close()
+
+	var sch sql.Schema
+	for rows.Next() {
+		var colName, colType, nullable, key string
+		if err := rows.Scan(&colName, &colType, &nullable, &key); err != nil {
+			return nil, err
+		}
+		typ, err := planbuilder.ParseColumnTypeString(colType)
+		if err != nil {
+			return nil, fmt.Errorf("federated table %s has column %s of unsupported type %s: %w", name, colName, colType, err)
+		}
+		sch = append(sch, &sql.Column{
+			Name:       colName,
+			Type:       typ,
+			Nullable:   strings.EqualFold(nullable, "YES"),
+			PrimaryKey: strings.EqualFold(key, "PRI"),
+			Source:     name,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(sch) == 0 {
+		return nil, fmt.Errorf("table of federated table %s not found at %s", name, conn)
+	}
+	return &Table{name: name, conn: conn, sch: sch}, nil
+}
+
+// Name implements sql.Table
+func (t *Table) Name() string {
+	return t.name
+}
+
+// String implements sql.Table
+func (t *Table) String() string {
+	return t.name
+}
+
+// Schema implements sql.Table
+func (t *Table) Schema() sql.Schema {
+	return t.sch
+}
+
+// Collation implements sql.Table
+func (t *Table) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Connection returns the location of the table's rows.
+func (t *Table) Connection() Connection {
+	return t.conn
+}
+
+type partition struct{}
+
+func (partition) Key() []byte {
+	return []byte("federated")
+}
+
+// Partitions implements sql.Table
+func (t *Table) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sql.PartitionsToPartitionIter(partition{}), nil
+}
+
+// PartitionRows implements sql.Table
+func (t *Table) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	db, err := pool(t.conn)
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]string, len(t.sch))
+	for i, col := range t.sch {
+		cols[i] = quoteIdentifier(col.Name)
+	}
+	// queries without arguments use the text protocol, whose values are all converted from their text
+	query := fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(cols, ", "), quoteIdentifier(t.conn.Database), quoteIdentifier(t.conn.Table))
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read federated table %s from %s: %w", t.name, t.conn, err)
+	}
+	return &rowIter{t: t, rows: rows, vals: make([]gosql.RawBytes, len(t.sch))}, nil
+}
+
+type rowIter struct {
+	t    *Table
+	rows *gosql.Rows
+	vals []gosql.RawBytes
+}
+
+func (itr *rowIter) Next(*sql.Context) (sql.Row, error) {
+	if !itr.rows.Next() {
+		if err := itr.rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read federated table %s from %s: %w", itr.t.name, itr.t.conn, err)
+		}
+		return nil, io.EOF
+	}
+	dest := make([]interface{}, len(itr.vals))
+	for i := range itr.vals {
+		dest[i] = &itr.vals[i]
+	}
+	if err := itr.rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	row := make(sql.Row, len(itr.vals))
+	for i, raw := range itr.vals {
+		if raw == nil {
+			continue
+		}
+		typ := itr.t.sch[i].Type
+		var v interface{} = string(raw)
+		if types.IsBinaryType(typ) || types.IsBit(typ) || types.IsGeometry(typ) {
+			v = append([]byte(nil), raw...)
+		}
+		converted, _, err := typ.Convert(v)
+		if err != nil {
+			return nil, fmt.Errorf("federated table %s: invalid value for column %s: %w", itr.t.name, itr.t.sch[i].Name, err)
+		}
+		row[i] = converted
+	}
+	return row, nil
+}
+
+func (itr *rowIter) Close(*sql.Context) error {
+	return itr.rows.Close()
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
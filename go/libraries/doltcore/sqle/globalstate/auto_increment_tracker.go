@@ -29,7 +29,11 @@ type AutoIncrementTracker interface {
 	// Current returns the current auto increment value for the given table.
 	Current(tableName string) uint64
 	// Next returns the next auto increment value for the given table, and increments the current value.
-	Next(tbl string, insertVal interface{}) (uint64, error)
+	Next(ctx *sql.Context, tbl string, insertVal interface{}) (uint64, error)
+	// Reserve reserves the next |count| values of the auto increment sequence for the given table, returning the first
+	// of them. The sequence continues after the values reserved, so they can be handed out by the caller without
+	// returning to the tracker for each one. Values reserved but never used are skipped.
+	Reserve(ctx *sql.Context, tbl string, count uint64) (uint64, error)
 	// AddNewTable adds a new table to the tracker, initializing the auto increment value to 1.
 	AddNewTable(tableName string)
 	// DropTable removes a table from the tracker.
@@ -46,4 +50,7 @@ type AutoIncrementTracker interface {
 	AcquireTableLock(ctx *sql.Context, tableName string) (func(), error)
 	// InitWithRoots fills the AutoIncrementTracker with values pulled from each root in order.
 	InitWithRoots(ctx context.Context, roots ...doltdb.Rootish) error
+	// Release durably records the current auto increment values, giving back any values reserved ahead of them so that
+	// they're generated after a restart. It's called when the database is closed.
+	Release(ctx context.Context) error
 }
@@ -16,11 +16,16 @@ package globalstate
 
 import "github.com/dolthub/go-mysql-server/sql"
 
-// GlobalState is just a holding interface for pieces of global state, of which the auto increment tracking info is
-// the only example at the moment.
+// GlobalState is just a holding interface for pieces of global state, such as the auto increment tracking info and
+// the row locks of pessimistic locking.
 type GlobalState interface {
 	// AutoIncrementTracker returns the auto increment tracker for this global state.
 	AutoIncrementTracker(ctx *sql.Context) (AutoIncrementTracker, error)
+	// SequenceTracker returns the tracker of the values handed out by the sequences of the database of this global
+	// state.
+	SequenceTracker() SequenceTracker
+	// RowLocks returns the row locks held by transactions writing to the database of this global state.
+	RowLocks() *RowLocks
 }
 
 // GlobalStateProvider is an optional interface for databases that provide global state tracking
@@ -0,0 +1,126 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalstate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrLockWaitTimeout = errors.New("Lock wait timeout exceeded; try restarting transaction")
+var ErrLockDeadlock = errors.New("deadlock found when trying to get lock")
+
+// RowLocks are the row locks held by the transactions writing to a database. Rows are only locked when sessions
+// enable pessimistic locking, in which case a transaction writing a row locks it until the transaction ends, and any
+// other transaction writing the same row waits for it to do so.
+//
+// Locks are owned by sessions, since a session runs one transaction at a time, and owners may be any comparable
+// value. A session which waited for a lock is responsible for reading the row again once it has it, since the
+// transaction which held it may have committed a change to it.
+type RowLocks struct {
+	mu sync.Mutex
+	// locks are the held locks, by key
+	locks map[string]*rowLock
+	// held are the keys of the locks held by each session
+	held map[any][]string
+	// waitsFor is the session holding the lock each waiting session is waiting for
+	waitsFor map[any]any
+}
+
+type rowLock struct {
+	owner    any
+	released chan struct{}
+}
+
+// NewRowLocks returns a new RowLocks with no locks held.
+func NewRowLocks() *RowLocks {
+	return &RowLocks{
+		locks:    make(map[string]*rowLock),
+		held:     make(map[any][]string),
+		waitsFor: make(map[any]any),
+	}
+}
+
+// Lock acquires the lock with the key |key| for the session |owner|, which keeps it until it calls ReleaseAll. If
+// another session holds the lock, waits up to |timeout| for it to be released, returning ErrLockWaitTimeout if it
+// isn't. Returns ErrLockDeadlock without waiting if the other session is waiting, directly or indirectly, on a lock
+// held by |owner|.
+func (rl *RowLocks) Lock(ctx context.Context, owner any, key string, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		rl.mu.Lock()
+		delete(rl.waitsFor, owner)
+		l, ok := rl.locks[key]
+		if !ok {
+			rl.locks[key] = &rowLock{owner: owner, released: make(chan struct{})}
+			rl.held[owner] = append(rl.held[owner], key)
+			rl.mu.Unlock()
+			return nil
+		} else if l.owner == owner {
+			rl.mu.Unlock()
+			return nil
+		}
+
+		for holder, waiting := l.owner, true; waiting; holder, waiting = rl.waitsFor[holder] {
+			if holder == owner {
+				rl.mu.Unlock()
+				return ErrLockDeadlock
+			}
+		}
+		rl.waitsFor[owner] = l.owner
+		rl.mu.Unlock()
+
+		select {
+		case <-l.released:
+		case <-timer.C:
+			rl.stopWaiting(owner)
+			return ErrLockWaitTimeout
+		case <-ctx.Done():
+			rl.stopWaiting(owner)
+			return ctx.Err()
+		}
+	}
+}
+
+func (rl *RowLocks) stopWaiting(owner any) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.waitsFor, owner)
+}
+
+// ReleaseAll releases every lock held by the session |owner|, when its transaction ends or its connection closes,
+// waking the sessions waiting for them.
+func (rl *RowLocks) ReleaseAll(owner any) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	for _, key := range rl.held[owner] {
+		if l, ok := rl.locks[key]; ok && l.owner == owner {
+			delete(rl.locks, key)
+			close(l.released)
+		}
+	}
+	delete(rl.held, owner)
+}
+
+// Held returns the number of locks held by the session |owner|.
+func (rl *RowLocks) Held(owner any) int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return len(rl.held[owner])
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowLocks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("waits for release", func(t *testing.T) {
+		rl := NewRowLocks()
+		require.NoError(t, rl.Lock(ctx, 1, "a", time.Second))
+		// locking again is a no-op
+		require.NoError(t, rl.Lock(ctx, 1, "a", time.Second))
+		assert.Equal(t, 1, rl.Held(1))
+
+		acquired := make(chan error)
+		go func() {
+			acquired <- rl.Lock(ctx, 2, "a", time.Minute)
+		}()
+		select {
+		case <-acquired:
+			t.Fatal("lock acquired while held by another session")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		rl.ReleaseAll(1)
+		require.NoError(t, <-acquired)
+		assert.Equal(t, 0, rl.Held(1))
+		assert.Equal(t, 1, rl.Held(2))
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		rl := NewRowLocks()
+		require.NoError(t, rl.Lock(ctx, 1, "a", time.Second))
+		require.NoError(t, rl.Lock(ctx, 2, "b", time.Second))
+		assert.Equal(t, ErrLockWaitTimeout, rl.Lock(ctx, 2, "a", 10*time.Millisecond))
+		// the timed out session no longer waits, so session 1 can wait on it
+		assert.Equal(t, ErrLockWaitTimeout, rl.Lock(ctx, 1, "b", 10*time.Millisecond))
+	})
+
+	t.Run("detects deadlocks", func(t *testing.T) {
+		rl := NewRowLocks()
+		require.NoError(t, rl.Lock(ctx, 1, "a", time.Second))
+		require.NoError(t, rl.Lock(ctx, 2, "b", time.Second))
+		require.NoError(t, rl.Lock(ctx, 3, "c", time.Second))
+
+		acquired := make(chan error, 2)
+		go func() {
+			acquired <- rl.Lock(ctx, 1, "b", time.Minute)
+		}()
+		go func() {
+			acquired <- rl.Lock(ctx, 2, "c", time.Minute)
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		// 3 -> 1 -> 2 -> 3
+		assert.Equal(t, ErrLockDeadlock, rl.Lock(ctx, 3, "a", time.Minute))
+		rl.ReleaseAll(3)
+		require.NoError(t, <-acquired)
+		rl.ReleaseAll(2)
+		require.NoError(t, <-acquired)
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalstate
+
+import "context"
+
+// SequenceTracker tracks the last value handed out by each sequence of a database, as AutoIncrementTracker does for
+// auto increment columns. A sequence's own last value is kept in its row of dolt_sequences, which is versioned, so the
+// tracker is what keeps concurrent transactions from handing out the same value. It's defined as an interface here
+// because implementations need to reach into session state, requiring a dependency on this package.
+//
+// Values are tracked per database, not per branch: a value handed out on any branch is never handed out again on any
+// other, so values stay unique when branches are merged. Setting a sequence with setval or dropping it changes what
+// the tracker hands out next on every branch, while the sequence's row on each other branch keeps its own value.
+type SequenceTracker interface {
+	// Next returns the next value of the sequence |name|, computed by |next| from the last value the tracker handed out
+	// for it, if it has handed any out. The sequence is locked until |next| returns, so no other value of it is handed
+	// out concurrently. |next| may be called again to reserve the values after the one returned.
+	Next(ctx context.Context, name string, next func(last int64, ok bool) (int64, error)) (int64, error)
+	// Set sets the last value handed out by the sequence |name| to |value|, as setval does.
+	Set(ctx context.Context, name string, value int64) error
+	// Drop forgets the sequence |name|, which was dropped, so that a sequence created with the same name starts over.
+	Drop(ctx context.Context, name string) error
+	// Release durably records the last values handed out, giving back values reserved but not handed out yet, when the
+	// database is closed.
+	Release(ctx context.Context) error
+}
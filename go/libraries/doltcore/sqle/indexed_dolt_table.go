@@ -169,7 +169,11 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		}
 	}
 
-	return t.lb.NewPartitionRowIter(ctx, part)
+	iter, err := t.lb.NewPartitionRowIter(ctx, part)
+	if err != nil {
+		return nil, err
+	}
+	return t.withLockedReads(ctx, iter)
 }
 
 // WithProjections implements sql.ProjectedTable
@@ -38,7 +38,7 @@ func newInformationSchemaDatabase(dbName string) sql.DatabaseSchema {
 		tables:     information_schema.GetInformationSchemaTables(),
 	}
 
-	isDb.tables[information_schema.StatisticsTableName] = information_schema.NewDefaultStats()
+	isDb.tables[information_schema.StatisticsTableName] = newStatisticsTable()
 
 	return isDb
 }
@@ -0,0 +1,131 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/information_schema"
+)
+
+// informationSchemaDatabase wraps the INFORMATION_SCHEMA database provided by go-mysql-server, replacing the tables
+// whose contents depend on metadata that only Dolt knows about.
+type informationSchemaDatabase struct {
+	sql.Database
+}
+
+var _ sql.Database = informationSchemaDatabase{}
+
+// NewInformationSchemaDatabase returns the INFORMATION_SCHEMA database used by Dolt engines. It should be assigned
+// to the analyzer's catalog in place of the default one.
+func NewInformationSchemaDatabase() sql.Database {
+	return informationSchemaDatabase{Database: information_schema.NewInformationSchemaDatabase()}
+}
+
+// GetTableInsensitive implements the sql.Database interface.
+func (db informationSchemaDatabase) GetTableInsensitive(ctx *sql.Context, tblName string) (sql.Table, bool, error) {
+	if strings.ToLower(tblName) == information_schema.StatisticsTableName {
+		return newStatisticsTable(), true, nil
+	}
+	return db.Database.GetTableInsensitive(ctx, tblName)
+}
+
+// newStatisticsTable returns go-mysql-server's INFORMATION_SCHEMA.STATISTICS table, with the IS_VISIBLE column of
+// each row filled from the Dolt schema of its table. go-mysql-server doesn't know about index visibility, and reports
+// every index as visible.
+func newStatisticsTable() sql.Table {
+	stats := information_schema.NewDefaultStats()
+	visibleIdx := stats.TableSchema.IndexOfColName("IS_VISIBLE")
+	return &information_schema.InformationSchemaTable{
+		TableName:   stats.TableName,
+		TableSchema: stats.TableSchema,
+		Reader: func(ctx *sql.Context, c sql.Catalog) (sql.RowIter, error) {
+			iter, err := stats.Reader(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			return &indexVisibilityIter{
+				RowIter:    iter,
+				catalog:    c,
+				visibleIdx: visibleIdx,
+				invisible:  make(map[[2]string]map[string]bool),
+			}, nil
+		},
+	}
+}
+
+// indexVisibilityIter fills the IS_VISIBLE column of the rows of INFORMATION_SCHEMA.STATISTICS.
+type indexVisibilityIter struct {
+	sql.RowIter
+	catalog    sql.Catalog
+	visibleIdx int
+	// invisible are the names of the invisible indexes of each table read, by database and table name
+	invisible map[[2]string]map[string]bool
+}
+
+// Next implements the sql.RowIter interface.
+func (it *indexVisibilityIter) Next(ctx *sql.Context) (sql.Row, error) {
+	row, err := it.RowIter.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// table_schema, table_name and index_name
+	dbName, _ := row[1].(string)
+	tableName, _ := row[2].(string)
+	indexName, _ := row[5].(string)
+	invisible, err := it.invisibleIndexes(ctx, dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if invisible[strings.ToLower(indexName)] {
+		row[it.visibleIdx] = "NO"
+	}
+	return row, nil
+}
+
+// invisibleIndexes returns the lower case names of the invisible indexes of the table |tableName| of the database
+// |dbName|.
+func (it *indexVisibilityIter) invisibleIndexes(ctx *sql.Context, dbName, tableName string) (map[string]bool, error) {
+	key := [2]string{dbName, tableName}
+	if invisible, ok := it.invisible[key]; ok {
+		return invisible, nil
+	}
+	invisible := make(map[string]bool)
+	it.invisible[key] = invisible
+
+	db, err := it.catalog.Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	tbl, _, err := it.catalog.DatabaseTable(ctx, db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	indexTable, ok := tbl.(sql.IndexAddressable)
+	if !ok {
+		return invisible, nil
+	}
+	indexes, err := indexTable.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range indexes {
+		if isInvisibleIndex(idx) {
+			invisible[strings.ToLower(idx.ID())] = true
+		}
+	}
+	return invisible, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+)
+
+func TestInformationSchemaStatisticsVisibility(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	root, err = ExecuteSql(dEnv, root, `create table t (pk int primary key, a int, b int, index idx_a (a), index idx_b (b));`)
+	require.NoError(t, err)
+
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: "t"})
+	require.NoError(t, err)
+	require.True(t, ok)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	_, err = sch.Indexes().SetIndexInvisible("idx_b", true)
+	require.NoError(t, err)
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, doltdb.TableName{Name: "t"}, tbl)
+	require.NoError(t, err)
+	require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+
+	rows, err := ExecuteSelect(dEnv, root, "select index_name, column_name, is_visible from information_schema.statistics where table_name = 't' order by 1")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{
+		{"idx_a", "a", "YES"},
+		{"idx_b", "b", "NO"},
+		{"PRIMARY", "pk", "YES"},
+	}, rows)
+}
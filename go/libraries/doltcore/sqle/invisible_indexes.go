@@ -0,0 +1,98 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// hideInvisibleIndexesId is the id of the hideInvisibleIndexes rule. The ids of go-mysql-server's rules are never
+// negative.
+const hideInvisibleIndexesId analyzer.RuleId = -1
+
+// AddInvisibleIndexesRule adds the rule hiding invisible indexes from the planner, see hideInvisibleIndexes, to the
+// rules |a| runs before it analyzes a query.
+func AddInvisibleIndexesRule(a *analyzer.Analyzer) {
+	for _, batch := range a.Batches {
+		if batch.Desc == "pre-analyzer" {
+			batch.Rules = append(batch.Rules, analyzer.Rule{Id: hideInvisibleIndexesId, Apply: hideInvisibleIndexes})
+			return
+		}
+	}
+}
+
+// hideInvisibleIndexes is an analyzer rule which hides the invisible indexes of the Dolt tables that a query reads or
+// writes, so that they're never used to plan the query. The indexes are still maintained when the tables are written,
+// and schema and SHOW statements still see them.
+func hideInvisibleIndexes(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *plan.Scope, sel analyzer.RuleSelector, qFlags *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	if plan.IsNoRowNode(n) {
+		return n, transform.SameTree, nil
+	}
+	return transform.NodeWithOpaque(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		rt, ok := n.(*plan.ResolvedTable)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		var table sql.Table
+		switch t := rt.Table.(type) {
+		case *AlterableDoltTable:
+			if !t.hideInvisibleIndexes {
+				nt := *t
+				nt.DoltTable = t.withInvisibleIndexesHidden()
+				table = &nt
+			}
+		case *WritableDoltTable:
+			if !t.hideInvisibleIndexes {
+				nt := *t
+				nt.DoltTable = t.withInvisibleIndexesHidden()
+				table = &nt
+			}
+		case *DoltTable:
+			if !t.hideInvisibleIndexes {
+				table = t.withInvisibleIndexesHidden()
+			}
+		}
+		if table == nil {
+			return n, transform.SameTree, nil
+		}
+		nt, err := rt.WithTable(table)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return nt, transform.NewTree, nil
+	})
+}
+
+// withInvisibleIndexesHidden returns a copy of the table whose GetIndexes doesn't return invisible indexes.
+func (t *DoltTable) withInvisibleIndexesHidden() *DoltTable {
+	nt := *t
+	nt.hideInvisibleIndexes = true
+	return &nt
+}
+
+// isInvisibleIndex returns whether |idx| is a Dolt index that has been marked invisible in its table's schema.
+func isInvisibleIndex(idx sql.Index) bool {
+	di, ok := idx.(index.DoltIndex)
+	if !ok || di.IsPrimaryKey() {
+		return false
+	}
+	schIdx := di.Schema().Indexes().GetByName(di.ID())
+	return schIdx != nil && schIdx.IsInvisible()
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the status of a job.
+type Status string
+
+const (
+	Running   Status = "running"
+	Succeeded Status = "succeeded"
+	Failed    Status = "failed"
+	Cancelled Status = "cancelled"
+)
+
+// Kinds of jobs.
+const (
+	Clone = "clone"
+)
+
+// ErrCancelled is the cause of the cancellation of a job cancelled with Cancel.
+var ErrCancelled = errors.New("job cancelled")
+
+// maxFinished is the number of finished jobs a Registry keeps.
+const maxFinished = 100
+
+// Progress is the progress of a job which fetches table files.
+type Progress struct {
+	// BytesFetched is the number of bytes of table files fetched so far
+	BytesFetched uint64
+	// TableFiles is the number of table files to fetch
+	TableFiles int
+	// TableFilesRemaining is the number of table files which haven't been fetched yet
+	TableFilesRemaining int
+}
+
+// Info describes a job at a point in time.
+type Info struct {
+	ID       uint64
+	Kind     string
+	Database string
+	Status   Status
+	Started  time.Time
+	// Finished is zero while the job is running
+	Finished time.Time
+	Progress Progress
+	// Error is the error of a job which failed or was cancelled
+	Error string
+}
+
+// Job is a long running operation, such as a clone, started from SQL. Jobs can be listed and cancelled from other
+// sessions while they run.
+type Job struct {
+	id     uint64
+	kind   string
+	db     string
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+
+	mu       sync.Mutex
+	started  time.Time
+	finished time.Time
+	progress Progress
+	err      error
+}
+
+// ID returns the id of |j|, unique in its Registry.
+func (j *Job) ID() uint64 {
+	return j.id
+}
+
+// SetProgress records the progress of |j|.
+func (j *Job) SetProgress(p Progress) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.progress = p
+}
+
+// Wait waits for |j| to finish and returns its error. If |ctx| is done first, the job is cancelled.
+func (j *Job) Wait(ctx context.Context) error {
+	select {
+	case <-j.done:
+	case <-ctx.Done():
+		j.cancel(context.Cause(ctx))
+		<-j.done
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Info returns the current state of |j|.
+func (j *Job) Info() Info {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	info := Info{
+		ID:       j.id,
+		Kind:     j.kind,
+		Database: j.db,
+		Status:   Running,
+		Started:  j.started,
+		Finished: j.finished,
+		Progress: j.progress,
+	}
+	if !j.finished.IsZero() {
+		info.Status = Succeeded
+		if errors.Is(j.err, ErrCancelled) || errors.Is(j.err, context.Canceled) {
+			info.Status = Cancelled
+		} else if j.err != nil {
+			info.Status = Failed
+		}
+	}
+	if j.err != nil {
+		info.Error = j.err.Error()
+	}
+	return info
+}
+
+// Registry runs jobs and keeps the running jobs and the most recently finished ones.
+type Registry struct {
+	mu     sync.Mutex
+	nextID uint64
+	jobs   map[uint64]*Job
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{nextID: 1, jobs: make(map[uint64]*Job)}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default returns the Registry of the process.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Start runs |f| as a new job of kind |kind| on database |db|, and returns the job without waiting for it to finish.
+// The job runs with a context derived from |ctx| which is cancelled when the job is cancelled.
+func (r *Registry) Start(ctx context.Context, kind, db string, f func(ctx context.Context, j *Job) error) *Job {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	r.mu.Lock()
+	j := &Job{
+		id:      r.nextID,
+		kind:    kind,
+		db:      db,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		started: time.Now(),
+	}
+	r.nextID++
+	r.jobs[j.id] = j
+	r.mu.Unlock()
+
+	go func() {
+		err := f(jobCtx, j)
+		if err != nil && jobCtx.Err() != nil {
+			err = fmt.Errorf("%w: %s", context.Cause(jobCtx), err.Error())
+		}
+		cancel(nil)
+
+		j.mu.Lock()
+		j.err = err
+		j.finished = time.Now()
+		j.mu.Unlock()
+		close(j.done)
+		r.prune()
+	}()
+	return j
+}
+
+// Cancel cancels the running job |id|.
+func (r *Registry) Cancel(id uint64) error {
+	r.mu.Lock()
+	j, ok := r.jobs[id]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("job %d not found", id)
+	}
+	select {
+	case <-j.done:
+		return fmt.Errorf("job %d is not running", id)
+	default:
+	}
+	j.cancel(ErrCancelled)
+	return nil
+}
+
+// Jobs returns the running jobs and the most recently finished ones, by id.
+func (r *Registry) Jobs() []Info {
+	r.mu.Lock()
+	jobs := make([]*Job, 0, len(r.jobs))
+	for _, j := range r.jobs {
+		jobs = append(jobs, j)
+	}
+	r.mu.Unlock()
+
+	infos := make([]Info, len(jobs))
+	for i, j := range jobs {
+		infos[i] = j.Info()
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// prune forgets the oldest finished jobs beyond the most recent maxFinished.
+func (r *Registry) prune() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var finished []uint64
+	for id, j := range r.jobs {
+		select {
+		case <-j.done:
+			finished = append(finished, id)
+		default:
+		}
+	}
+	if len(finished) <= maxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i] < finished[j]
+	})
+	for _, id := range finished[:len(finished)-maxFinished] {
+		delete(r.jobs, id)
+	}
+}
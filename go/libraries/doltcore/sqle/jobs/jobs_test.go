@@ -0,0 +1,97 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSucceeds(t *testing.T) {
+	r := NewRegistry()
+	j := r.Start(context.Background(), Clone, "db", func(ctx context.Context, j *Job) error {
+		j.SetProgress(Progress{BytesFetched: 10, TableFiles: 2, TableFilesRemaining: 1})
+		return nil
+	})
+	require.NoError(t, j.Wait(context.Background()))
+
+	infos := r.Jobs()
+	require.Len(t, infos, 1)
+	assert.Equal(t, uint64(1), infos[0].ID)
+	assert.Equal(t, Clone, infos[0].Kind)
+	assert.Equal(t, "db", infos[0].Database)
+	assert.Equal(t, Succeeded, infos[0].Status)
+	assert.False(t, infos[0].Finished.IsZero())
+	assert.Equal(t, Progress{BytesFetched: 10, TableFiles: 2, TableFilesRemaining: 1}, infos[0].Progress)
+	assert.Error(t, r.Cancel(1))
+}
+
+func TestJobFails(t *testing.T) {
+	r := NewRegistry()
+	j := r.Start(context.Background(), Clone, "db", func(ctx context.Context, j *Job) error {
+		return errors.New("remote not found")
+	})
+	assert.EqualError(t, j.Wait(context.Background()), "remote not found")
+	info := j.Info()
+	assert.Equal(t, Failed, info.Status)
+	assert.Equal(t, "remote not found", info.Error)
+}
+
+func TestCancelJob(t *testing.T) {
+	r := NewRegistry()
+	started := make(chan struct{})
+	j := r.Start(context.Background(), Clone, "db", func(ctx context.Context, j *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	assert.Equal(t, Running, j.Info().Status)
+	assert.Error(t, r.Cancel(j.ID()+1))
+	require.NoError(t, r.Cancel(j.ID()))
+
+	err := j.Wait(context.Background())
+	assert.ErrorIs(t, err, ErrCancelled)
+	assert.Equal(t, Cancelled, j.Info().Status)
+}
+
+func TestWaitCancelsJob(t *testing.T) {
+	r := NewRegistry()
+	j := r.Start(context.Background(), Clone, "db", func(ctx context.Context, j *Job) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, j.Wait(ctx))
+	assert.Equal(t, Cancelled, j.Info().Status)
+}
+
+func TestFinishedJobsArePruned(t *testing.T) {
+	r := NewRegistry()
+	for i := 0; i < maxFinished+5; i++ {
+		j := r.Start(context.Background(), Clone, "db", func(ctx context.Context, j *Job) error {
+			return nil
+		})
+		require.NoError(t, j.Wait(context.Background()))
+	}
+	infos := r.Jobs()
+	assert.Len(t, infos, maxFinished)
+	assert.Equal(t, uint64(6), infos[0].ID)
+}
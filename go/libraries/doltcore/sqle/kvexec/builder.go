@@ -22,11 +22,13 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
 	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -34,7 +36,13 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
-type Builder struct{}
+type Builder struct {
+	// inDDL is set for the builder of a DDL statement, which is already undone if it fails.
+	inDDL bool
+	// lockingReads is set for the builder of an UPDATE or DELETE run with pessimistic locking, whose rows must be read
+	// through their tables' row iterators so that they're locked as they're read.
+	lockingReads bool
+}
 
 var _ sql.NodeExecBuilder = (*Builder)(nil)
 
@@ -48,7 +56,27 @@ func (b Builder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, er
 	//  - compatible |val| encodings that we don't coerce
 	//  - filter/project ordering clash
 
+	if !b.inDDL && plan.IsDDLNode(n) {
+		if iter, err := sqle.NewAtomicDDLIter(ctx, n, r, rowexec.NewOverrideBuilder(Builder{inDDL: true})); err != nil || iter != nil {
+			return iter, err
+		}
+	}
+
+	if b.lockingReads {
+		return nil, nil
+	}
+	switch n.(type) {
+	case *plan.Update, *plan.DeleteFrom:
+		if dsess.PessimisticLockingEnabled(ctx) {
+			return rowexec.NewOverrideBuilder(Builder{inDDL: b.inDDL, lockingReads: true}).Build(ctx, n, r)
+		}
+	}
+
 	switch n := n.(type) {
+	case *plan.CreateTable:
+		return sqle.NewCreateTableIter(ctx, n)
+	case *plan.ShowCreateTable:
+		return sqle.NewShowCreateTableIter(ctx, n)
 	case *plan.JoinNode:
 		switch {
 		case n.Op.IsPartial() || len(r) != 0:
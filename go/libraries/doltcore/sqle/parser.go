@@ -0,0 +1,125 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// DoltParser is a sql.Parser which parses the statements that dolt adds to the grammar of the parser it wraps. Each
+// statement is parsed once by the wrapped parser, and its text is only scanned again when the parse shows that it's
+// one of dolt's statements:
+//
+//   - a statement the wrapped parser rejects is rewritten if it creates or drops a sequence, see
+//     rewriteSequenceStatement
+//   - an ALTER TABLE statement with a change the wrapped parser accepts but ignores is replaced with the node making
+//     the change, see newAlterTableStorage
+//   - tables queried FOR SYSTEM_TIME are expanded, see expandSystemTime
+type DoltParser struct {
+	sql.Parser
+}
+
+var _ sql.Parser = DoltParser{}
+
+// NewDoltParser returns a DoltParser which parses statements with |parser|.
+func NewDoltParser(parser sql.Parser) DoltParser {
+	return DoltParser{Parser: parser}
+}
+
+// ParseSimple implements sql.Parser.
+func (p DoltParser) ParseSimple(query string) (ast.Statement, error) {
+	stmt, err := p.Parser.ParseSimple(query)
+	if err != nil {
+		rewritten, end, ok, rerr := rewriteSequenceStatement(query, ast.ParserOptions{})
+		if rerr != nil {
+			return nil, rerr
+		} else if !ok {
+			return nil, err
+		}
+		return p.Parser.ParseSimple(rewritten + query[end:])
+	}
+	return p.extend(stmt, query, ast.ParserOptions{})
+}
+
+// Parse implements sql.Parser.
+func (p DoltParser) Parse(ctx *sql.Context, query string, multi bool) (ast.Statement, string, string, error) {
+	return p.ParseWithOptions(ctx, query, ';', multi, sql.LoadSqlMode(ctx).ParserOptions())
+}
+
+// ParseWithOptions implements sql.Parser.
+func (p DoltParser) ParseWithOptions(ctx context.Context, query string, delimiter rune, multi bool, options ast.ParserOptions) (ast.Statement, string, string, error) {
+	stmt, parsed, remainder, err := p.Parser.ParseWithOptions(ctx, query, delimiter, multi, options)
+	if err == nil {
+		stmt, err = p.extend(stmt, parsed, options)
+		return stmt, parsed, remainder, err
+	}
+
+	s := sql.RemoveSpaceAndDelimiter(query, delimiter)
+	rewritten, end, ok, rerr := rewriteSequenceStatement(s, options)
+	if rerr != nil {
+		return nil, s, "", rerr
+	} else if !ok {
+		return nil, parsed, remainder, err
+	}
+
+	if !multi {
+		stmt, _, _, err = p.Parser.ParseWithOptions(ctx, rewritten+s[end:], delimiter, false, options)
+		return stmt, s, "", err
+	}
+	stmt, _, _, err = p.Parser.ParseWithOptions(ctx, rewritten, delimiter, false, options)
+	remainder = ""
+	if end < len(s) {
+		// skips the statement's delimiter
+		remainder = s[end+1:]
+	}
+	return stmt, sql.RemoveSpaceAndDelimiter(s[:end], delimiter), remainder, err
+}
+
+// ParseOneWithOptions implements sql.Parser.
+func (p DoltParser) ParseOneWithOptions(ctx context.Context, query string, options ast.ParserOptions) (ast.Statement, int, error) {
+	stmt, ri, err := p.Parser.ParseOneWithOptions(ctx, query, options)
+	if err == nil {
+		parsed := query
+		if ri > 0 && ri <= len(query) {
+			parsed = query[:ri]
+		}
+		stmt, err = p.extend(stmt, parsed, options)
+		return stmt, ri, err
+	}
+
+	rewritten, end, ok, rerr := rewriteSequenceStatement(query, options)
+	if rerr != nil {
+		return nil, 0, rerr
+	} else if !ok {
+		return nil, ri, err
+	}
+	stmt, _, err = p.Parser.ParseOneWithOptions(ctx, rewritten, options)
+	if end < len(query) {
+		// the next statement starts after the statement's delimiter
+		end++
+	}
+	return stmt, end, err
+}
+
+// extend returns the statement to run for |stmt|, which the wrapped parser parsed from |query|.
+func (p DoltParser) extend(stmt ast.Statement, query string, options ast.ParserOptions) (ast.Statement, error) {
+	if ignoresAlterTableChange(stmt) {
+		return newAlterTableStorage(stmt.(*ast.AlterTable), query, options)
+	}
+	return expandSystemTime(stmt, query)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingParser is a sql.Parser which counts the statements it parses.
+type countingParser struct {
+	sql.Parser
+	parses int
+}
+
+func (p *countingParser) ParseWithOptions(ctx context.Context, query string, delimiter rune, multi bool, options ast.ParserOptions) (ast.Statement, string, string, error) {
+	p.parses++
+	return p.Parser.ParseWithOptions(ctx, query, delimiter, multi, options)
+}
+
+func (p *countingParser) ParseSimple(query string) (ast.Statement, error) {
+	p.parses++
+	return p.Parser.ParseSimple(query)
+}
+
+func TestDoltParserParsesOnce(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		parses   int
+	}{
+		{
+			query:    "select * from t where a = 1",
+			expected: "select * from t where a = 1",
+			parses:   1,
+		},
+		{
+			query:    "alter table t add column c int",
+			expected: "alter table t add column (\n\tc int\n)",
+			parses:   1,
+		},
+		{
+			query:    "alter table t engine = innodb",
+			expected: "ALTER TABLE `t` ENGINE = innodb",
+			parses:   1,
+		},
+		{
+			query:    "alter table t key_block_size = 4",
+			expected: "ALTER TABLE `t` KEY_BLOCK_SIZE = 4",
+			parses:   1,
+		},
+		{
+			query:    "create sequence s",
+			expected: "insert into dolt_sequences(`name`, start_value, min_value, max_value, increment, cycle) values ('s', null, null, null, null, null)",
+			parses:   2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			wrapped := &countingParser{Parser: sql.GlobalParser}
+			stmt, parsed, remainder, err := NewDoltParser(wrapped).ParseWithOptions(context.Background(), test.query+";", ';', false, ast.ParserOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ast.String(stmt))
+			assert.Equal(t, test.query, parsed)
+			assert.Empty(t, remainder)
+			assert.Equal(t, test.parses, wrapped.parses)
+		})
+	}
+}
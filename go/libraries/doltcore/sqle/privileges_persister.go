@@ -0,0 +1,197 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+// privilegesRowId is the primary key of the single row of dolt_privileges.
+const privilegesRowId = int64(1)
+
+// privilegesTupleKey is the key of the tuple privileges are loaded from
+const privilegesTupleKey = "privileges"
+
+// privilegesSchema is the schema of the dolt_privileges system table, which stores the serialized users, roles and
+// grants of a server in a single row.
+var privilegesSchema = sql.NewPrimaryKeySchema(sql.Schema{
+	{Name: "id", Type: types.Int64, Source: doltdb.PrivilegesTableName, PrimaryKey: true},
+	{Name: "privileges", Type: types.LongBlob, Source: doltdb.PrivilegesTableName, Nullable: true},
+})
+
+// PrivilegesTablePersister is a mysql_db.MySQLDbPersistence which stores the users, roles and grants of a server in
+// one of its databases, rather than in a privileges file. Privileges are loaded from a tuple of the database, which
+// isn't versioned, so that resetting, reverting or merging the database can't restore grants which were revoked.
+// Every change to privileges is also written to the dolt_privileges table of the database's default branch, the branch
+// privileges are loaded from when the tuple doesn't exist, as in a clone of the database.
+//
+// dolt_privileges holds the password hashes of every user, so it can only be read by admins, see
+// dtables.PrivilegesTable, and it's ignored, so it isn't committed, or pushed, unless dolt_ignore has a pattern which
+// doesn't ignore it. Once committed, its history can be audited, and it's copied by clone, push, pull and backups. It
+// can't be written by queries.
+type PrivilegesTablePersister struct {
+	dbName     string
+	newContext func(context.Context) (*sql.Context, error)
+}
+
+var _ mysql_db.MySQLDbPersistence = PrivilegesTablePersister{}
+
+// NewPrivilegesTablePersister returns a PrivilegesTablePersister which stores privileges in the database |dbName|,
+// and which loads them with contexts returned by |newContext|.
+func NewPrivilegesTablePersister(dbName string, newContext func(context.Context) (*sql.Context, error)) PrivilegesTablePersister {
+	return PrivilegesTablePersister{dbName: dbName, newContext: newContext}
+}
+
+// Persist implements mysql_db.MySQLDbPersistence.
+func (p PrivilegesTablePersister) Persist(ctx *sql.Context, data []byte) error {
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return fmt.Errorf("privileges can't be stored in database %s without a dolt session", p.dbName)
+	}
+	db, ok := sess.Provider().BaseDatabase(ctx, p.dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(p.dbName)
+	}
+	if err := db.DbData().Ddb.SetTuple(ctx, privilegesTupleKey, data); err != nil {
+		return err
+	}
+
+	// privileges are written to the branch they're loaded from, whichever branch the session has checked out
+	branch, err := dsess.DefaultHead(p.dbName, db)
+	if err != nil {
+		return err
+	}
+	return p.writePrivilegesTable(ctx, sess, dsess.RevisionDbName(p.dbName, branch), data)
+}
+
+// writePrivilegesTable writes |data| to the dolt_privileges table of the working set of the revision database |dbName|.
+func (p PrivilegesTablePersister) writePrivilegesTable(ctx *sql.Context, sess *dsess.DoltSession, dbName string, data []byte) (err error) {
+	dbState, ok, err := sess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	} else if dbState.WorkingSet() == nil || dbState.WriteSession() == nil {
+		return doltdb.ErrOperationNotSupportedInDetachedHead
+	}
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	tname := doltdb.TableName{Name: doltdb.PrivilegesTableName}
+	found, err := roots.Working.HasTable(ctx, tname)
+	if err != nil {
+		return err
+	}
+	if !found {
+		sch, err := sqlutil.ToDoltSchema(ctx, roots.Working, tname, privilegesSchema, roots.Head, sql.Collation_Default)
+		if err != nil {
+			return err
+		}
+		newRoot, err := doltdb.CreateEmptyTable(ctx, roots.Working, tname, sch)
+		if err != nil {
+			return err
+		}
+		// the write session must be able to find the new table before the session's working root is updated
+		if err = dbState.WriteSession().SetWorkingSet(ctx, dbState.WorkingSet().WithWorkingRoot(newRoot)); err != nil {
+			return err
+		}
+		if err = sess.SetWorkingRoot(ctx, dbName, newRoot); err != nil {
+			return err
+		}
+	}
+
+	tableWriter, err := dbState.WriteSession().GetTableWriter(ctx, tname, dbName, sess.SetWorkingRoot, false)
+	if err != nil {
+		return err
+	}
+	tableWriter.StatementBegin(ctx)
+	if err = tableWriter.Update(ctx, sql.Row{privilegesRowId, nil}, sql.Row{privilegesRowId, data}); err != nil {
+		_ = tableWriter.DiscardChanges(ctx, err)
+		_ = tableWriter.Close(ctx)
+		return err
+	}
+	if err = tableWriter.StatementComplete(ctx); err != nil {
+		_ = tableWriter.Close(ctx)
+		return err
+	}
+	return tableWriter.Close(ctx)
+}
+
+// LoadData returns the privileges stored in the database, or nil if none have been stored. Returns an error if the
+// database doesn't exist.
+func (p PrivilegesTablePersister) LoadData(ctx context.Context) ([]byte, error) {
+	sqlCtx, err := p.newContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	baseDb, ok := sess.Provider().BaseDatabase(sqlCtx, p.dbName)
+	if !ok {
+		return nil, fmt.Errorf("unable to load privileges from database %s: %w", p.dbName, sql.ErrDatabaseNotFound.New(p.dbName))
+	}
+	data, ok, err := baseDb.DbData().Ddb.GetTuple(sqlCtx, privilegesTupleKey)
+	if err != nil || ok {
+		return data, err
+	}
+
+	// the database's default branch, the branch privileges are written to
+	db, err := sess.Provider().Database(sqlCtx, p.dbName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load privileges from database %s: %w", p.dbName, err)
+	}
+	tbl, ok, err := db.GetTableInsensitive(sqlCtx, doltdb.PrivilegesTableName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if restricted, ok := tbl.(dtables.PrivilegesTable); ok {
+		tbl = restricted.Unrestricted()
+	}
+
+	partitions, err := tbl.Partitions(sqlCtx)
+	if err != nil {
+		return nil, err
+	}
+	iter := sql.NewTableRowIter(sqlCtx, tbl, partitions)
+	defer iter.Close(sqlCtx)
+	for {
+		r, err := iter.Next(sqlCtx)
+		if err == io.EOF {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if r[0] != privilegesRowId || r[1] == nil {
+			continue
+		}
+		data, _, err := types.LongBlob.Convert(r[1])
+		if err != nil {
+			return nil, err
+		}
+		return data.([]byte), nil
+	}
+}
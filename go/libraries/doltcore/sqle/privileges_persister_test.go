@@ -0,0 +1,78 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func TestPrivilegesTablePersister(t *testing.T) {
+	dEnv := CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	db, err := NewDatabase(context.Background(), "dolt", dEnv.DbData(), editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir})
+	require.NoError(t, err)
+	engine, ctx, err := NewTestEngine(dEnv, context.Background(), db)
+	require.NoError(t, err)
+	sess := dsess.DSessFromSess(ctx.Session)
+
+	p := NewPrivilegesTablePersister("dolt", func(ctx context.Context) (*sql.Context, error) {
+		return NewTestSQLCtxWithProvider(ctx, sess.Provider(), nil), nil
+	})
+	data, err := p.LoadData(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	_, iter, _, err := engine.Query(ctx, "call dolt_checkout('-b', 'other')")
+	require.NoError(t, err)
+	require.NoError(t, drainIter(ctx, iter))
+
+	// privileges are written to the default branch, not the branch the session has checked out
+	tx, err := sess.StartTransaction(ctx, sql.ReadWrite)
+	require.NoError(t, err)
+	ctx.SetTransaction(tx)
+	require.NoError(t, p.Persist(ctx, []byte("granted")))
+	require.NoError(t, sess.CommitTransaction(ctx, ctx.GetTransaction()))
+	hasPrivilegesTable := func(branch string) bool {
+		roots, ok := sess.GetRoots(ctx, dsess.RevisionDbName("dolt", branch))
+		require.True(t, ok)
+		found, err := roots.Working.HasTable(ctx, doltdb.TableName{Name: doltdb.PrivilegesTableName})
+		require.NoError(t, err)
+		return found
+	}
+	assert.True(t, hasPrivilegesTable("main"))
+	assert.False(t, hasPrivilegesTable("other"))
+
+	data, err = p.LoadData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("granted"), data)
+
+	// restoring an old version of dolt_privileges, as resetting or reverting it would, doesn't restore old grants
+	require.NoError(t, p.Persist(ctx, []byte("revoked")))
+	require.NoError(t, p.writePrivilegesTable(ctx, sess, dsess.RevisionDbName("dolt", "main"), []byte("granted")))
+	data, err = p.LoadData(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("revoked"), data)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// NewRemoteDatabase returns a read-only database named |name| served directly from the remote at |url|, without a
+// local copy of it. The chunks of the database are read from the remote as they're needed, and the most recently read
+// of them are kept in a cache of |cacheSize| bytes. The database serves the remote's branches as of when it was
+// opened, or last refreshed by RefreshRemoteDatabase. Its default branch is |defaultBranch| if the remote has it.
+func NewRemoteDatabase(ctx context.Context, name, url string, params map[string]string, dialer dbfactory.GRPCDialProvider, defaultBranch string, cacheSize uint64) (ReadOnlyDatabase, error) {
+	remote := env.NewRemote("origin", url, params)
+	// the remote's chunks are cached by the caching store instead, whose cache is bounded
+	remoteDB, err := remote.GetRemoteDBWithoutCaching(ctx, types.Format_Default, dialer)
+	if err != nil {
+		return ReadOnlyDatabase{}, fmt.Errorf("failed to open database %s from remote %s: %w", name, url, err)
+	}
+	cs := chunks.NewReadOnlyCachingStore(datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(remoteDB)), cacheSize)
+	ddb := doltdb.DoltDBFromCS(cs, name)
+
+	branch, err := attachedBranch(ctx, ddb, defaultBranch)
+	if err != nil {
+		_ = ddb.Close()
+		return ReadOnlyDatabase{}, err
+	}
+
+	rs := env.MemoryRepoState{DoltDB: ddb, Head: branch}
+	db, err := NewDatabase(ctx, name, env.DbData{Ddb: ddb, Rsw: rs, Rsr: rs}, editor.Options{})
+	if err != nil {
+		_ = ddb.Close()
+		return ReadOnlyDatabase{}, err
+	}
+	return ReadOnlyDatabase{Database: db}, nil
+}
+
+// RefreshRemoteDatabase brings the branches of |db|, a database returned by NewRemoteDatabase, up to date with its
+// remote. Transactions started after it returns see the refreshed branches.
+func RefreshRemoteDatabase(ctx context.Context, db dsess.SqlDatabase) error {
+	return db.DbData().Ddb.Rebase(ctx)
+}
+
+// RunRemoteDatabaseRefresh refreshes |db| from its remote every |interval| until |ctx| is done. Failed refreshes are
+// logged, and retried at the next interval.
+func RunRemoteDatabaseRefresh(ctx context.Context, db dsess.SqlDatabase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := RefreshRemoteDatabase(ctx, db); err != nil {
+				logrus.Warnf("failed to refresh database %s from its remote: %v", db.Name(), err)
+			}
+		}
+	}
+}
@@ -16,14 +16,17 @@ package sqle
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 type remotesrvStore struct {
@@ -65,7 +68,85 @@ func (s remotesrvStore) Get(ctx context.Context, path, _ string) (remotesrv.Remo
 	if !ok {
 		return nil, remotesrv.ErrUnimplemented
 	}
-	return rss, nil
+	if s.createDBs {
+		// Cluster replication writes were already accepted by the primary, so they aren't held to its contracts again.
+		return rss, nil
+	}
+	return schemaContractsStore{rss, sdb.DbData().Ddb}, nil
+}
+
+// schemaContractsStore rejects pushes that change the schema of a branch in a way that breaks the
+// dolt_schema_contracts of the branch.
+type schemaContractsStore struct {
+	remotesrv.RemoteSrvStore
+	ddb *doltdb.DoltDB
+}
+
+func (s schemaContractsStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	if !last.IsEmpty() {
+		if err := s.checkSchemaContracts(ctx, current, last); err != nil {
+			return false, err
+		}
+	}
+	return s.RemoteSrvStore.Commit(ctx, current, last)
+}
+
+// checkSchemaContracts checks every branch that exists at both |last| and |current| and points to a different commit
+// at |current| against the contracts of its commit at |last|.
+func (s schemaContractsStore) checkSchemaContracts(ctx context.Context, current, last hash.Hash) error {
+	lastDatasets, err := s.ddb.DatasetsByRootHash(ctx, last)
+	if err != nil {
+		return err
+	}
+	branchPrefix := ref.PrefixForType(ref.BranchRefType)
+	lastHeads := make(map[string]hash.Hash)
+	err = lastDatasets.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if strings.HasPrefix(id, branchPrefix) {
+			lastHeads[id] = addr
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	currentDatasets, err := s.ddb.DatasetsByRootHash(ctx, current)
+	if err != nil {
+		return err
+	}
+	var updated []ref.BranchRef
+	err = currentDatasets.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if lastAddr, ok := lastHeads[id]; ok && lastAddr != addr {
+			updated = append(updated, ref.NewBranchRef(strings.TrimPrefix(id, branchPrefix)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, branch := range updated {
+		before, err := s.branchRoot(ctx, branch, last)
+		if err != nil {
+			return err
+		}
+		after, err := s.branchRoot(ctx, branch, current)
+		if err != nil {
+			return err
+		}
+		if err = doltdb.CheckSchemaContracts(ctx, branch.GetPath(), before, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s schemaContractsStore) branchRoot(ctx context.Context, branch ref.BranchRef, root hash.Hash) (doltdb.RootValue, error) {
+	cm, err := s.ddb.ResolveCommitRefAtRoot(ctx, branch, root)
+	if err != nil {
+		return nil, err
+	}
+	return cm.GetRootValue(ctx)
 }
 
 // In the SQL context, the database provider that we use to expose the
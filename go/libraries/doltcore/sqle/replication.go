@@ -18,6 +18,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/sirupsen/logrus"
@@ -29,7 +32,29 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, logger io.Writer) (doltdb.CommitHook, error) {
+// asyncReplicationQueueDir is the directory in the .dolt directory of a database which keeps the queues of head
+// updates waiting to be pushed by asynchronous replication, one file per remote.
+const asyncReplicationQueueDir = "replication_queue"
+
+// ReplicationRemoteNames returns the names of the remotes in |val|, the value of @@dolt_replicate_to_remote, which is a
+// comma separated list of the remotes to replicate to.
+func ReplicationRemoteNames(val interface{}) ([]string, error) {
+	s, ok := val.(string)
+	if !ok {
+		return nil, sql.ErrInvalidSystemVariableValue.New(val)
+	}
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// getPushOnWriteHooks returns a hook replicating to each remote in @@dolt_replicate_to_remote. A remote which can't
+// be loaded doesn't prevent replicating to the others, and is given a hook which logs its error instead.
+func getPushOnWriteHooks(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, logger io.Writer) ([]doltdb.CommitHook, error) {
 	_, val, ok := sql.SystemVariables.GetGlobal(dsess.ReplicateToRemote)
 	if !ok {
 		return nil, sql.ErrUnknownSystemVariable.New(dsess.ReplicateToRemote)
@@ -37,11 +62,25 @@ func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dE
 		return nil, nil
 	}
 
-	remoteName, ok := val.(string)
-	if !ok {
-		return nil, sql.ErrInvalidSystemVariableValue.New(val)
+	remoteNames, err := ReplicationRemoteNames(val)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]doltdb.CommitHook, 0, len(remoteNames))
+	for _, remoteName := range remoteNames {
+		hook, err := getPushOnWriteHook(ctx, bThreads, dEnv, remoteName, logger)
+		if err != nil {
+			path, _ := dEnv.FS.Abs(".")
+			logrus.Errorf("error loading replication to remote %s for database at %s, replication disabled: %v", remoteName, path, err)
+			hook = doltdb.NewLogHook([]byte(err.Error() + "\n"))
+		}
+		hooks = append(hooks, hook)
 	}
+	return hooks, nil
+}
 
+func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, remoteName string, logger io.Writer) (doltdb.CommitHook, error) {
 	remotes, err := dEnv.GetRemotes()
 	if err != nil {
 		return nil, err
@@ -61,11 +100,22 @@ func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dE
 	if err != nil {
 		return nil, err
 	}
-	if _, val, ok = sql.SystemVariables.GetGlobal(dsess.AsyncReplication); ok && val == dsess.SysVarTrue {
-		return doltdb.NewAsyncPushOnWriteHook(bThreads, ddb, tmpDir, logger)
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.AsyncReplication); ok && val == dsess.SysVarTrue {
+		opts := doltdb.AsyncPushOptions{Remote: remoteName}
+		if doltDir := dEnv.GetDoltDir(); doltDir != "" {
+			opts.QueueFile = filepath.Join(doltDir, asyncReplicationQueueDir, remoteName+".json")
+		}
+		if _, val, ok := sql.SystemVariables.GetGlobal(dsess.AsyncReplicationMaxLagSecs); ok {
+			secs, ok := val.(int64)
+			if !ok {
+				return nil, sql.ErrInvalidSystemVariableValue.New(val)
+			}
+			opts.MaxLag = time.Duration(secs) * time.Second
+		}
+		return doltdb.NewAsyncPushOnWriteHook(ctx, bThreads, dEnv.DoltDB, ddb, tmpDir, logger, opts)
 	}
 
-	return doltdb.NewPushOnWriteHook(ddb, tmpDir), nil
+	return doltdb.NewRetryingPushOnWriteHook(bThreads, remoteName, ddb, tmpDir)
 }
 
 // GetCommitHooks creates a list of hooks to execute on database commit. Hooks that cannot be created because of an
@@ -73,13 +123,13 @@ func getPushOnWriteHook(ctx context.Context, bThreads *sql.BackgroundThreads, dE
 func GetCommitHooks(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, logger io.Writer) ([]doltdb.CommitHook, error) {
 	postCommitHooks := make([]doltdb.CommitHook, 0)
 
-	hook, err := getPushOnWriteHook(ctx, bThreads, dEnv, logger)
+	hooks, err := getPushOnWriteHooks(ctx, bThreads, dEnv, logger)
 	if err != nil {
 		path, _ := dEnv.FS.Abs(".")
 		logrus.Errorf("error loading replication for database at %s, replication disabled: %v", path, err)
 		postCommitHooks = append(postCommitHooks, doltdb.NewLogHook([]byte(err.Error()+"\n")))
-	} else if hook != nil {
-		postCommitHooks = append(postCommitHooks, hook)
+	} else {
+		postCommitHooks = append(postCommitHooks, hooks...)
 	}
 
 	for _, h := range postCommitHooks {
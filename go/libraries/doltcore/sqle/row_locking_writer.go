@@ -0,0 +1,363 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var rowLockPool = pool.NewBuffPool()
+
+// rowLockingTableWriter is a dsess.TableWriter which locks each row before writing it, for the rest of the
+// transaction of the session writing it. Used when @@dolt_pessimistic_locking is enabled, see dsess.DoltSession.LockRow.
+type rowLockingTableWriter struct {
+	dsess.TableWriter
+	dbName    string
+	tableName string
+	keys      *rowLockKeys
+	// lockReads is whether the rows of the table are locked as they're read by the statements using the writer, which
+	// is the case for UPDATE and DELETE, see lockingRowIter
+	lockReads bool
+}
+
+var _ dsess.TableWriter = rowLockingTableWriter{}
+
+// withRowLocks returns |te| wrapped in a rowLockingTableWriter if pessimistic locking is enabled for the session.
+func (t *WritableDoltTable) withRowLocks(ctx *sql.Context, te dsess.TableWriter, lockReads bool) dsess.TableWriter {
+	if !dsess.PessimisticLockingEnabled(ctx) {
+		return te
+	}
+	return rowLockingTableWriter{
+		TableWriter: te,
+		dbName:      t.db.RevisionQualifiedName(),
+		tableName:   t.Name(),
+		keys:        newRowLockKeys(t.sch, t.sch.GetPkOrdinals(), t.db.DbData().Ddb.NodeStore()),
+		lockReads:   lockReads,
+	}
+}
+
+func (w rowLockingTableWriter) lock(ctx *sql.Context, row sql.Row) error {
+	key, err := w.keys.key(ctx, row)
+	if err != nil {
+		return err
+	}
+	return dsess.DSessFromSess(ctx.Session).LockRow(ctx, w.dbName, w.tableName, key)
+}
+
+// StatementBegin implements sql.EditOpenerCloser
+func (w rowLockingTableWriter) StatementBegin(ctx *sql.Context) {
+	w.TableWriter.StatementBegin(ctx)
+	// errors are returned by the writes of the statement
+	_ = dsess.DSessFromSess(ctx.Session).BeginRowLockingStatement(ctx, w.dbName, w.tableName, w.lockReads)
+}
+
+// DiscardChanges implements sql.EditOpenerCloser
+func (w rowLockingTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	err := w.TableWriter.DiscardChanges(ctx, errorEncountered)
+	if endErr := dsess.DSessFromSess(ctx.Session).EndRowLockingStatement(ctx, w.dbName, w.tableName, true); err == nil {
+		err = endErr
+	}
+	return err
+}
+
+// StatementComplete implements sql.EditOpenerCloser
+func (w rowLockingTableWriter) StatementComplete(ctx *sql.Context) error {
+	err := w.TableWriter.StatementComplete(ctx)
+	if endErr := dsess.DSessFromSess(ctx.Session).EndRowLockingStatement(ctx, w.dbName, w.tableName, err != nil); err == nil {
+		err = endErr
+	}
+	return err
+}
+
+// Insert implements sql.RowInserter
+func (w rowLockingTableWriter) Insert(ctx *sql.Context, row sql.Row) error {
+	if err := w.lock(ctx, row); err != nil {
+		return err
+	}
+	return w.TableWriter.Insert(ctx, row)
+}
+
+// Update implements sql.RowUpdater
+func (w rowLockingTableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := w.lock(ctx, old); err != nil {
+		return err
+	}
+	if err := w.lock(ctx, new); err != nil {
+		return err
+	}
+	return w.TableWriter.Update(ctx, old, new)
+}
+
+// Delete implements sql.RowDeleter
+func (w rowLockingTableWriter) Delete(ctx *sql.Context, row sql.Row) error {
+	if err := w.lock(ctx, row); err != nil {
+		return err
+	}
+	return w.TableWriter.Delete(ctx, row)
+}
+
+// rowLockKeys builds the keys of the row locks of a table's rows. The key of a row is its primary key tuple, encoded
+// as it's stored except that collated strings are replaced by their collation weights, so that keys which are equal
+// under their columns' collations lock the same row. Rows of keyless tables are identified by their contents.
+type rowLockKeys struct {
+	// ordinals are the positions of the primary key columns in rows, or nil for keyless tables
+	ordinals []int
+	// collations are the collations of the primary key columns which compare strings by weight, rather than by byte
+	collations []sql.CollationID
+	kb         *val.TupleBuilder
+	ns         tree.NodeStore
+}
+
+// newRowLockKeys returns the rowLockKeys of rows of the table with the schema |sch|, whose primary key columns are at
+// |ordinals|, or nil if they aren't all in rows.
+func newRowLockKeys(sch schema.Schema, ordinals []int, ns tree.NodeStore) *rowLockKeys {
+	if schema.IsKeyless(sch) {
+		return &rowLockKeys{}
+	}
+	pkCols := sch.GetPKCols().GetColumns()
+	if len(ordinals) != len(pkCols) {
+		return nil
+	}
+	collations := make([]sql.CollationID, len(pkCols))
+	for i, col := range pkCols {
+		collations[i] = sql.Collation_Unspecified
+		if st, ok := col.TypeInfo.ToSqlType().(sql.StringType); ok && st.Collation() != sql.Collation_binary {
+			collations[i] = st.Collation()
+		}
+	}
+	return &rowLockKeys{
+		ordinals:   ordinals,
+		collations: collations,
+		kb:         val.NewTupleBuilder(sch.GetKeyDescriptor()),
+		ns:         ns,
+	}
+}
+
+// key returns the key of the lock of |row|.
+func (k *rowLockKeys) key(ctx *sql.Context, row sql.Row) (string, error) {
+	if k.ordinals == nil {
+		h, err := sql.HashOf(row)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(h, 16), nil
+	}
+
+	for i, ord := range k.ordinals {
+		v := row[ord]
+		if v == nil {
+			continue
+		}
+		if coll := k.collations[i]; coll != sql.Collation_Unspecified {
+			var weights bytes.Buffer
+			if err := coll.WriteWeightString(&weights, collatedString(v)); err != nil {
+				return "", err
+			}
+			k.kb.PutRaw(i, weights.Bytes())
+		} else if err := tree.PutField(ctx, k.ns, k.kb, i, v); err != nil {
+			return "", err
+		}
+	}
+	return string(k.kb.BuildPermissive(rowLockPool)), nil
+}
+
+func collatedString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// withLockedReads returns |iter|, the rows of a partition of |t|, wrapped in a lockingRowIter if the current
+// statement of the session locks the rows it reads from |t|.
+func (t *DoltTable) withLockedReads(ctx *sql.Context, iter sql.RowIter) (sql.RowIter, error) {
+	if !dsess.PessimisticLockingEnabled(ctx) {
+		return iter, nil
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	dbName := t.db.RevisionQualifiedName()
+	if !sess.LocksReads(ctx, dbName, t.Name()) {
+		return iter, nil
+	}
+
+	projections := t.projectedCols
+	if projections == nil || t.overriddenSchema != nil {
+		projections = t.sch.GetAllCols().Tags
+	}
+	positions := make(map[uint64]int, len(projections))
+	for i, tag := range projections {
+		positions[tag] = i
+	}
+	var ordinals []int
+	for _, tag := range t.sch.GetPKCols().Tags {
+		if pos, ok := positions[tag]; ok {
+			ordinals = append(ordinals, pos)
+		}
+	}
+	if schema.IsKeyless(t.sch) && !sameTags(projections, t.sch.GetAllCols().Tags) {
+		// keyless rows are locked by their contents, which the writer sees in full
+		return iter, nil
+	}
+	keys := newRowLockKeys(t.sch, ordinals, t.db.DbData().Ddb.NodeStore())
+	if keys == nil {
+		return iter, nil
+	}
+
+	rebases, err := sess.RowLockRebases(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	return &lockingRowIter{
+		RowIter:     iter,
+		t:           t,
+		sess:        sess,
+		dbName:      dbName,
+		keys:        keys,
+		projections: projections,
+		rebases:     rebases,
+	}, nil
+}
+
+func sameTags(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lockingRowIter locks each row of a table as it's read, and reads it again once it's locked if the session's working
+// set was rebased onto one committed by another transaction, so that the row is returned as the last transaction to
+// hold its lock left it, and skipped if that transaction deleted it. See dsess.DoltSession.LockRow.
+type lockingRowIter struct {
+	sql.RowIter
+	t           *DoltTable
+	sess        *dsess.DoltSession
+	dbName      string
+	keys        *rowLockKeys
+	projections []uint64
+	// rebases is the number of times the session's working set was rebased when the rows began to be read
+	rebases uint64
+}
+
+var _ sql.RowIter = (*lockingRowIter)(nil)
+
+// Next implements sql.RowIter
+func (it *lockingRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		row, err := it.RowIter.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		key, err := it.keys.key(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if err = it.sess.LockRow(ctx, it.dbName, it.t.Name(), key); err != nil {
+			return nil, err
+		}
+		rebases, err := it.sess.RowLockRebases(ctx, it.dbName)
+		if err != nil {
+			return nil, err
+		} else if rebases == it.rebases {
+			return row, nil
+		}
+
+		row, ok, err := it.reread(ctx, row)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			return row, nil
+		}
+	}
+}
+
+// reread returns |row| as it is in the session's working set, and whether it's still there.
+func (it *lockingRowIter) reread(ctx *sql.Context, row sql.Row) (sql.Row, bool, error) {
+	tbl, err := it.t.DoltTable(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	rows := durable.ProllyMapFromIndex(idx)
+	kd, vd := rows.Descriptors()
+
+	var key val.Tuple
+	if it.keys.ordinals == nil {
+		// a keyless row is still there if a row with the same contents is
+		kb := val.NewTupleBuilder(vd)
+		if err = tree.PutField(ctx, rows.NodeStore(), kb, 0, uint64(0)); err != nil {
+			return nil, false, err
+		}
+		for i, tag := range it.projections {
+			if err = tree.PutField(ctx, rows.NodeStore(), kb, sch.GetAllCols().TagToIdx[tag]+1, row[i]); err != nil {
+				return nil, false, err
+			}
+		}
+		key = val.HashTupleFromValue(rowLockPool, kb.Build(rowLockPool))
+	} else {
+		kb := val.NewTupleBuilder(kd)
+		for i, ord := range it.keys.ordinals {
+			if err = tree.PutField(ctx, rows.NodeStore(), kb, i, row[ord]); err != nil {
+				return nil, false, err
+			}
+		}
+		key = kb.Build(rowLockPool)
+	}
+
+	var found prolly.MapIter
+	err = rows.Get(ctx, key, func(k, v val.Tuple) error {
+		if k != nil {
+			found = prolly.NewPointLookup(k, v)
+		}
+		return nil
+	})
+	if err != nil || found == nil {
+		return nil, false, err
+	}
+	reread, err := index.NewProllyRowIterForSchema(sch, found, kd, vd, it.projections, rows.NodeStore()).Next(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return reread, true, nil
+}
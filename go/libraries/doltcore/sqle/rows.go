@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -192,7 +193,23 @@ func ProllyRowIterFromPartition(
 		partition.end = uint64(c)
 	}
 
-	iter, err := rows.FetchOrdinalRange(ctx, partition.start, partition.end)
+	ranges, err := zoneMapRanges(ctx, sch, rows, partition.zoneMapFilters)
+	if err != nil {
+		return nil, err
+	}
+	scanned := rows
+	if rows.Columnar() && projections != nil {
+		scanned = rows.ProjectFields(projectedFields(sch, projections, ranges))
+	}
+	if len(ranges) > 0 {
+		iter, err := scanned.IterValueRanges(ctx, partition.start, partition.end, ranges...)
+		if err != nil {
+			return nil, err
+		}
+		return index.NewProllyRowIterForMap(sch, rows, iter, projections), nil
+	}
+
+	iter, err := scanned.FetchOrdinalRange(ctx, partition.start, partition.end)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +217,27 @@ func ProllyRowIterFromPartition(
 	return index.NewProllyRowIterForMap(sch, rows, iter, projections), nil
 }
 
+// projectedFields returns the value fields of the rows of a table with schema |sch| read to project the columns
+// |projections| and to filter rows within |ranges|. Only these fields are read from tables storing their rows
+// column-wise.
+func projectedFields(sch schema.Schema, projections []uint64, ranges []prolly.ValueRange) []int {
+	_, valMap, _ := index.ProjectionMappingsForIndex(sch, projections)
+	fields := make([]int, 0, len(valMap)+len(ranges)+1)
+	if schema.IsKeyless(sch) {
+		// the cardinality of a keyless row is its first value field
+		fields = append(fields, 0)
+		for _, f := range valMap {
+			fields = append(fields, f+1)
+		}
+	} else {
+		fields = append(fields, valMap...)
+	}
+	for _, rng := range ranges {
+		fields = append(fields, rng.Field)
+	}
+	return fields
+}
+
 // SqlTableToRowIter returns a |sql.RowIter| for a full table scan for the given |table|. If
 // |columns| is not empty, only columns with names appearing in |columns| will
 // have non-|nil| values in the resulting |sql.Row|s. If |columns| is empty,
@@ -0,0 +1,252 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledpulls
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+)
+
+// ConflictPolicy is how a pull with conflicts is handled.
+type ConflictPolicy string
+
+const (
+	// Abort abandons a pull with conflicts, leaving the branch as it was.
+	Abort ConflictPolicy = "abort"
+	// PreferRemote resolves the conflicts of a pull with the remote's rows.
+	PreferRemote ConflictPolicy = "prefer-remote"
+	// PreferLocal resolves the conflicts of a pull with the local rows.
+	PreferLocal ConflictPolicy = "prefer-local"
+)
+
+// Config configures a Puller.
+type Config struct {
+	// Database is the database whose branches are pulled
+	Database string
+	// Remote is the remote the branches are pulled from
+	Remote string
+	// Branches are the branches pulled, each from the branch of the same name of the remote
+	Branches []string
+	// Interval is how often the branches are pulled
+	Interval time.Duration
+	// ConflictPolicy is how a pull with conflicts is handled
+	ConflictPolicy ConflictPolicy
+	// OnFailure is a URL to which a notification of a failed pull is posted, if any
+	OnFailure string
+}
+
+// Engine is the SQL engine branches are pulled with.
+type Engine interface {
+	// NewLocalContext returns a new context with a new session, with the privileges of the server.
+	NewLocalContext(ctx context.Context) (*sql.Context, error)
+	// Query runs |query| in |ctx|.
+	Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
+}
+
+// Failure is a failed pull, which is posted as JSON to the notification URL of its Puller.
+type Failure struct {
+	Database string    `json:"database"`
+	Remote   string    `json:"remote"`
+	Branch   string    `json:"branch"`
+	Time     time.Time `json:"time"`
+	Error    string    `json:"error"`
+}
+
+// Puller periodically pulls branches of a database from a remote, for mirrors which must track an upstream database.
+// A branch which doesn't exist yet is created from the remote's branch. A pull which fails, for example because the
+// remote can't be reached or because of conflicts under the Abort policy, leaves its branch as it was, is logged and
+// notified, and is retried at the next interval.
+type Puller struct {
+	engine Engine
+	cfg    Config
+}
+
+// NewPuller returns a Puller of the branches given by |cfg|.
+func NewPuller(engine Engine, cfg Config) *Puller {
+	return &Puller{engine: engine, cfg: cfg}
+}
+
+// Run pulls the branches at each interval until |ctx| is done.
+func (p *Puller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		p.PullAll(ctx)
+	}
+}
+
+// PullAll pulls every branch, logging and notifying the branches which fail to pull.
+func (p *Puller) PullAll(ctx context.Context) {
+	for _, branch := range p.cfg.Branches {
+		err := background.Run(ctx, background.Replication, func(ctx context.Context) error {
+			return p.Pull(ctx, branch)
+		})
+		if err != nil {
+			logrus.Warnf("scheduled pull: failed to pull branch %s of database %s from %s, retrying in %s: %s",
+				branch, p.cfg.Database, p.cfg.Remote, p.cfg.Interval, err.Error())
+			p.notify(ctx, Failure{
+				Database: p.cfg.Database,
+				Remote:   p.cfg.Remote,
+				Branch:   branch,
+				Time:     time.Now(),
+				Error:    err.Error(),
+			})
+		}
+	}
+}
+
+// Pull fetches |branch| from the remote and merges it into the local branch, handling conflicts with the conflict
+// policy. The merge is made in a single transaction, which is rolled back if the pull fails.
+func (p *Puller) Pull(ctx context.Context, branch string) (err error) {
+	sqlCtx, err := p.engine.NewLocalContext(ctx)
+	if err != nil {
+		return err
+	}
+	remote, remoteBranch := quoteString(p.cfg.Remote), p.cfg.Remote+"/"+branch
+
+	if _, err = p.query(sqlCtx, "USE "+quoteIdentifier(p.cfg.Database)); err != nil {
+		return err
+	}
+	if _, err = p.query(sqlCtx, fmt.Sprintf("CALL dolt_fetch(%s, %s)", remote, quoteString(branch))); err != nil {
+		return err
+	}
+	rows, err := p.query(sqlCtx, "SELECT name FROM dolt_branches WHERE name = "+quoteString(branch))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		_, err = p.query(sqlCtx, fmt.Sprintf("CALL dolt_branch(%s, %s)", quoteString(branch), quoteString(remoteBranch)))
+		return err
+	}
+
+	if _, err = p.query(sqlCtx, "USE "+quoteIdentifier(p.cfg.Database+"/"+branch)); err != nil {
+		return err
+	}
+	if _, err = p.query(sqlCtx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			if _, rbErr := p.query(sqlCtx, "ROLLBACK"); rbErr != nil {
+				logrus.Warnf("scheduled pull: failed to roll back pull of branch %s of database %s: %s", branch, p.cfg.Database, rbErr.Error())
+			}
+		}
+	}()
+
+	message := quoteString("scheduled pull of " + remoteBranch)
+	rows, err = p.query(sqlCtx, fmt.Sprintf("CALL dolt_merge(%s, '-m', %s)", quoteString(remoteBranch), message))
+	if err != nil {
+		return err
+	}
+	if hasConflicts(rows) {
+		var side string
+		switch p.cfg.ConflictPolicy {
+		case PreferRemote:
+			side = "--theirs"
+		case PreferLocal:
+			side = "--ours"
+		default:
+			return fmt.Errorf("pull of %s has conflicts, so it was abandoned", remoteBranch)
+		}
+		if _, err = p.query(sqlCtx, fmt.Sprintf("CALL dolt_conflicts_resolve('%s', '.')", side)); err != nil {
+			return err
+		}
+		if _, err = p.query(sqlCtx, fmt.Sprintf("CALL dolt_commit('-A', '-m', %s)", message)); err != nil {
+			return err
+		}
+	}
+	_, err = p.query(sqlCtx, "COMMIT")
+	return err
+}
+
+// hasConflicts returns whether the result |rows| of dolt_merge report conflicts.
+func hasConflicts(rows []sql.Row) bool {
+	if len(rows) == 1 && len(rows[0]) > 2 {
+		if conflicts, ok := rows[0][2].(int64); ok && conflicts > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// query runs |query| and returns its rows.
+func (p *Puller) query(ctx *sql.Context, query string) ([]sql.Row, error) {
+	_, iter, _, err := p.engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, iter)
+}
+
+const notificationTimeout = 10 * time.Second
+
+// notify posts |failure| as JSON to the notification URL, if there is one.
+func (p *Puller) notify(ctx context.Context, failure Failure) {
+	if p.cfg.OnFailure == "" {
+		return
+	}
+	if err := postNotification(ctx, p.cfg.OnFailure, failure); err != nil {
+		logrus.Warnf("scheduled pull: failed to notify %s of the failure to pull branch %s of database %s: %s",
+			p.cfg.OnFailure, failure.Branch, failure.Database, err.Error())
+	}
+}
+
+func postNotification(ctx context.Context, url string, failure Failure) error {
+	body, err := json.Marshal(failure)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'"
+}
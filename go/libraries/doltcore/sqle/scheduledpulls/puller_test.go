@@ -0,0 +1,138 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledpulls
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEngine records the queries it runs, and answers them with the rows of the first of its results whose
+// prefix they have.
+type recordingEngine struct {
+	queries []string
+	results map[string][]sql.Row
+	errs    map[string]error
+}
+
+func (e *recordingEngine) NewLocalContext(ctx context.Context) (*sql.Context, error) {
+	return sql.NewContext(ctx), nil
+}
+
+func (e *recordingEngine) Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
+	e.queries = append(e.queries, query)
+	for prefix, err := range e.errs {
+		if strings.HasPrefix(query, prefix) {
+			return nil, nil, nil, err
+		}
+	}
+	for prefix, rows := range e.results {
+		if strings.HasPrefix(query, prefix) {
+			return nil, sql.RowsToRowIter(rows...), nil, nil
+		}
+	}
+	return nil, sql.RowsToRowIter(), nil, nil
+}
+
+func newRecordingEngine() *recordingEngine {
+	return &recordingEngine{
+		results: map[string][]sql.Row{
+			"SELECT name FROM dolt_branches": {{"main"}},
+		},
+		errs: make(map[string]error),
+	}
+}
+
+func testConfig(policy ConflictPolicy) Config {
+	return Config{Database: "mirror", Remote: "origin", Branches: []string{"main"}, Interval: time.Minute, ConflictPolicy: policy}
+}
+
+func TestPull(t *testing.T) {
+	e := newRecordingEngine()
+	require.NoError(t, NewPuller(e, testConfig(Abort)).Pull(context.Background(), "main"))
+	assert.Equal(t, []string{
+		"USE `mirror`",
+		"CALL dolt_fetch('origin', 'main')",
+		"SELECT name FROM dolt_branches WHERE name = 'main'",
+		"USE `mirror/main`",
+		"START TRANSACTION",
+		"CALL dolt_merge('origin/main', '-m', 'scheduled pull of origin/main')",
+		"COMMIT",
+	}, e.queries)
+}
+
+func TestPullCreatesMissingBranch(t *testing.T) {
+	e := newRecordingEngine()
+	delete(e.results, "SELECT name FROM dolt_branches")
+	require.NoError(t, NewPuller(e, testConfig(Abort)).Pull(context.Background(), "main"))
+	assert.Equal(t, "CALL dolt_branch('main', 'origin/main')", e.queries[len(e.queries)-1])
+	assert.NotContains(t, e.queries, "START TRANSACTION")
+}
+
+func TestPullConflictPolicies(t *testing.T) {
+	conflicts := []sql.Row{{"", int64(0), int64(1), "conflicts"}}
+
+	e := newRecordingEngine()
+	e.results["CALL dolt_merge"] = conflicts
+	err := NewPuller(e, testConfig(Abort)).Pull(context.Background(), "main")
+	assert.ErrorContains(t, err, "pull of origin/main has conflicts")
+	assert.Equal(t, "ROLLBACK", e.queries[len(e.queries)-1])
+	assert.NotContains(t, e.queries, "COMMIT")
+
+	for policy, side := range map[ConflictPolicy]string{PreferRemote: "--theirs", PreferLocal: "--ours"} {
+		e = newRecordingEngine()
+		e.results["CALL dolt_merge"] = conflicts
+		require.NoError(t, NewPuller(e, testConfig(policy)).Pull(context.Background(), "main"))
+		assert.Equal(t, []string{
+			"CALL dolt_conflicts_resolve('" + side + "', '.')",
+			"CALL dolt_commit('-A', '-m', 'scheduled pull of origin/main')",
+			"COMMIT",
+		}, e.queries[len(e.queries)-3:])
+	}
+}
+
+func TestPullAllNotifiesFailures(t *testing.T) {
+	var failures []Failure
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var f Failure
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&f))
+		failures = append(failures, f)
+	}))
+	defer srv.Close()
+
+	e := newRecordingEngine()
+	e.errs["CALL dolt_fetch"] = errors.New("remote unreachable")
+	cfg := testConfig(Abort)
+	cfg.Branches = []string{"main", "release"}
+	cfg.OnFailure = srv.URL
+	NewPuller(e, cfg).PullAll(context.Background())
+
+	require.Len(t, failures, 2)
+	assert.Equal(t, "mirror", failures[0].Database)
+	assert.Equal(t, "origin", failures[0].Remote)
+	assert.Equal(t, "main", failures[0].Branch)
+	assert.Equal(t, "remote unreachable", failures[0].Error)
+	assert.Equal(t, "release", failures[1].Branch)
+}
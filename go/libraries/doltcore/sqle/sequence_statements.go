@@ -0,0 +1,262 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"strings"
+
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// rewriteSequenceStatement returns the statement of dolt_sequences equivalent to the first statement of |query|, and
+// the index in |query| of the end of the statement, which is either the index of its delimiter or the length of
+// |query|. It returns false if the statement doesn't create or drop a sequence. Sequences are rows of the
+// dolt_sequences table:
+//
+//	CREATE SEQUENCE [IF NOT EXISTS] name [START [WITH] n] [INCREMENT [BY] n]
+//	    [MINVALUE n | NO MINVALUE] [MAXVALUE n | NO MAXVALUE] [CYCLE | NO CYCLE] [CACHE n | NOCACHE]
+//	DROP SEQUENCE [IF EXISTS] name [, name] ...
+//
+// CREATE SEQUENCE inserts the sequence into dolt_sequences, and DROP SEQUENCE deletes sequences from it, so both are
+// part of the transaction that runs them. Options which aren't given take the defaults of dolt_sequences, and values
+// aren't cached, so CACHE is ignored. Dropping a sequence which doesn't exist isn't an error.
+func rewriteSequenceStatement(query string, options ast.ParserOptions) (string, int, bool, error) {
+	tokenizer := ast.NewStringTokenizer(query)
+	if options.AnsiQuotes {
+		tokenizer = ast.NewStringTokenizerForAnsiQuotes(query)
+	}
+	s := &sequenceStatementScanner{tokenizer: tokenizer}
+	s.next()
+	verb := s.tok
+	if verb != ast.CREATE && verb != ast.DROP {
+		return "", 0, false, nil
+	}
+	s.next()
+	if s.tok != ast.SEQUENCE {
+		return "", 0, false, nil
+	}
+	s.next()
+
+	var rewritten string
+	var err error
+	if verb == ast.CREATE {
+		rewritten, err = s.createSequence()
+	} else {
+		rewritten, err = s.dropSequence()
+	}
+	if err != nil {
+		return "", 0, true, err
+	}
+	if s.tok != 0 && s.tok != ';' {
+		return "", 0, true, s.syntaxError()
+	}
+
+	end := len(query)
+	if s.tok == ';' {
+		end = s.tokenizer.Position - 2
+	}
+	return rewritten, end, true, nil
+}
+
+// sequenceStatementScanner scans the tokens of a statement creating or dropping a sequence, or of another statement
+// that a DoltParser rewrites.
+type sequenceStatementScanner struct {
+	tokenizer *ast.Tokenizer
+	// tok and val are the current token and its value
+	tok int
+	val string
+}
+
+// next advances to the next token, skipping comments.
+func (s *sequenceStatementScanner) next() {
+	tok, val := s.tokenizer.Scan()
+	for tok == ast.COMMENT {
+		tok, val = s.tokenizer.Scan()
+	}
+	s.tok, s.val = tok, string(val)
+}
+
+// isWord returns whether the current token is the word |word|, which may or may not be a keyword.
+func (s *sequenceStatementScanner) isWord(word string) bool {
+	return s.tok != 0 && s.tok != ast.STRING && strings.EqualFold(s.val, word)
+}
+
+// skipWord advances past the current token if it's the word |word|, and returns whether it was.
+func (s *sequenceStatementScanner) skipWord(word string) bool {
+	if s.isWord(word) {
+		s.next()
+		return true
+	}
+	return false
+}
+
+// syntaxError returns an error for the current token.
+func (s *sequenceStatementScanner) syntaxError() error {
+	if s.tok == 0 {
+		return fmt.Errorf("syntax error at end of sequence statement")
+	}
+	return fmt.Errorf("syntax error in sequence statement near '%s'", s.val)
+}
+
+// ifExists advances past IF EXISTS or, if |not|, IF NOT EXISTS, and returns whether it was present.
+func (s *sequenceStatementScanner) ifExists(not bool) (bool, error) {
+	if s.tok != ast.IF {
+		return false, nil
+	}
+	s.next()
+	if not {
+		if s.tok != ast.NOT {
+			return false, s.syntaxError()
+		}
+		s.next()
+	}
+	if s.tok != ast.EXISTS {
+		return false, s.syntaxError()
+	}
+	s.next()
+	return true, nil
+}
+
+// name scans the name of a sequence, which may be qualified by the name of its database, and returns the name of the
+// dolt_sequences table the sequence is in and the sequence's name as a string literal.
+func (s *sequenceStatementScanner) name() (string, string, error) {
+	if s.tok != ast.ID {
+		return "", "", s.syntaxError()
+	}
+	table := ast.TableName{Name: ast.NewTableIdent(doltdb.SequencesTableName)}
+	name := s.val
+	s.next()
+	if s.tok == '.' {
+		s.next()
+		if s.tok != ast.ID {
+			return "", "", s.syntaxError()
+		}
+		table.DbQualifier = ast.NewTableIdent(name)
+		name = s.val
+		s.next()
+	}
+	return ast.String(table), ast.String(ast.NewStrVal([]byte(name))), nil
+}
+
+// number scans an integer, which may be signed, after an optional '='.
+func (s *sequenceStatementScanner) number() (string, error) {
+	if s.tok == '=' {
+		s.next()
+	}
+	var sign string
+	if s.tok == '-' || s.tok == '+' {
+		sign = string(rune(s.tok))
+		s.next()
+	}
+	if s.tok != ast.INTEGRAL {
+		return "", s.syntaxError()
+	}
+	n := sign + s.val
+	s.next()
+	return n, nil
+}
+
+// createSequence scans the rest of a CREATE SEQUENCE statement and returns the statement inserting the sequence.
+func (s *sequenceStatementScanner) createSequence() (string, error) {
+	ifNotExists, err := s.ifExists(true)
+	if err != nil {
+		return "", err
+	}
+	table, name, err := s.name()
+	if err != nil {
+		return "", err
+	}
+
+	start, minValue, maxValue, increment, cycle := "NULL", "NULL", "NULL", "NULL", "NULL"
+	for s.tok != 0 && s.tok != ';' {
+		switch {
+		case s.skipWord("start"):
+			s.skipWord("with")
+			start, err = s.number()
+		case s.skipWord("increment"):
+			s.skipWord("by")
+			increment, err = s.number()
+		case s.skipWord("minvalue"):
+			minValue, err = s.number()
+		case s.skipWord("maxvalue"):
+			maxValue, err = s.number()
+		case s.skipWord("cycle"):
+			cycle = "TRUE"
+		case s.skipWord("nominvalue"):
+			minValue = "NULL"
+		case s.skipWord("nomaxvalue"):
+			maxValue = "NULL"
+		case s.skipWord("nocycle"):
+			cycle = "FALSE"
+		case s.skipWord("no"):
+			switch {
+			case s.skipWord("minvalue"):
+				minValue = "NULL"
+			case s.skipWord("maxvalue"):
+				maxValue = "NULL"
+			case s.skipWord("cycle"):
+				cycle = "FALSE"
+			default:
+				err = s.syntaxError()
+			}
+		case s.skipWord("cache"):
+			_, err = s.number()
+		case s.skipWord("nocache"):
+		default:
+			err = s.syntaxError()
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	values := fmt.Sprintf("%s, %s, %s, %s, %s, %s", name, start, minValue, maxValue, increment, cycle)
+	columns := "name, start_value, min_value, max_value, increment, cycle"
+	if ifNotExists {
+		return fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM %s WHERE name = %s)",
+			table, columns, values, table, name), nil
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, columns, values), nil
+}
+
+// dropSequence scans the rest of a DROP SEQUENCE statement and returns the statement deleting the sequences. The
+// sequences must all be in the same database.
+func (s *sequenceStatementScanner) dropSequence() (string, error) {
+	if _, err := s.ifExists(false); err != nil {
+		return "", err
+	}
+
+	var table string
+	var names []string
+	for {
+		t, name, err := s.name()
+		if err != nil {
+			return "", err
+		}
+		if table != "" && !strings.EqualFold(t, table) {
+			return "", fmt.Errorf("DROP SEQUENCE can only drop the sequences of one database")
+		}
+		table = t
+		names = append(names, name)
+		if s.tok != ',' {
+			break
+		}
+		s.next()
+	}
+	return fmt.Sprintf("DELETE FROM %s WHERE name IN (%s)", table, strings.Join(names, ", ")), nil
+}
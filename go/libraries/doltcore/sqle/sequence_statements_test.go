@@ -0,0 +1,95 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceParser(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		err      string
+	}{
+		{
+			query:    "create table sequence (a int primary key)",
+			expected: "create table `sequence` (\n\ta int primary key\n)",
+		},
+		{
+			query:    "create sequence s",
+			expected: "insert into dolt_sequences(`name`, start_value, min_value, max_value, increment, cycle) values ('s', null, null, null, null, null)",
+		},
+		{
+			query:    "CREATE SEQUENCE db.s START WITH -10 INCREMENT BY 5 MINVALUE -100 NO MAXVALUE CYCLE CACHE 20;",
+			expected: "insert into db.dolt_sequences(`name`, start_value, min_value, max_value, increment, cycle) values ('s', -10, -100, null, 5, true)",
+		},
+		{
+			query:    "create sequence if not exists `my seq` start = 1 nominvalue maxvalue = 9 nocycle",
+			expected: "insert into dolt_sequences(`name`, start_value, min_value, max_value, increment, cycle) select 'my seq', 1, null, 9, null, false where not exists (select 1 from dolt_sequences where `name` = 'my seq')",
+		},
+		{
+			query:    "/* comment */ drop sequence if exists a, b",
+			expected: "delete from dolt_sequences where `name` in ('a', 'b')",
+		},
+		{
+			query: "create sequence s start with x",
+			err:   "syntax error in sequence statement near 'x'",
+		},
+		{
+			query: "create sequence s increment by",
+			err:   "syntax error at end of sequence statement",
+		},
+		{
+			query: "drop sequence a.s, b.s",
+			err:   "DROP SEQUENCE can only drop the sequences of one database",
+		},
+	}
+
+	parser := NewDoltParser(sql.GlobalParser)
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := parser.ParseSimple(test.query)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ast.String(stmt))
+		})
+	}
+}
+
+func TestSequenceParserMultipleStatements(t *testing.T) {
+	parser := NewDoltParser(sql.GlobalParser)
+	query := "create sequence s increment by 2; select nextval('s');"
+
+	stmt, parsed, remainder, err := parser.ParseWithOptions(context.Background(), query, ';', true, ast.ParserOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "create sequence s increment by 2", parsed)
+	assert.Equal(t, " select nextval('s')", remainder)
+	assert.IsType(t, &ast.Insert{}, stmt)
+
+	stmt, ri, err := parser.ParseOneWithOptions(context.Background(), query, ast.ParserOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, " select nextval('s');", query[ri:])
+	assert.IsType(t, &ast.Insert{}, stmt)
+}
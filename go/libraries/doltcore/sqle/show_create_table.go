@@ -16,13 +16,20 @@ package sqle
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 )
 
@@ -34,6 +41,7 @@ func PrepareCreateTableStmt(ctx context.Context, sqlDb dsess.SqlDatabase) (*sql.
 		return nil, nil, nil
 	}
 	engine := sqle.NewDefault(pro)
+	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(createTableBuilder{})
 
 	sess := dsess.DefaultSession(pro, writer.NewWriteSession)
 	sqlCtx := sql.NewContext(ctx, sql.WithSession(sess))
@@ -59,3 +67,253 @@ func GetCreateTableStmt(ctx *sql.Context, engine *sqle.Engine, tableName string)
 	}
 	return stmt + ";", nil
 }
+
+// createTableBuilder is a sql.NodeExecBuilder that builds CREATE TABLE and SHOW CREATE TABLE statements with
+// NewCreateTableIter and NewShowCreateTableIter, for engines that don't otherwise override the engine's exec builder.
+type createTableBuilder struct{}
+
+var _ sql.NodeExecBuilder = createTableBuilder{}
+
+// Build implements sql.NodeExecBuilder.
+func (createTableBuilder) Build(ctx *sql.Context, n sql.Node, _ sql.Row) (sql.RowIter, error) {
+	switch n := n.(type) {
+	case *plan.CreateTable:
+		return NewCreateTableIter(ctx, n)
+	case *plan.ShowCreateTable:
+		return NewShowCreateTableIter(ctx, n)
+	}
+	return nil, nil
+}
+
+// tableCommentSetter is implemented by the Dolt databases that can set the comment of an existing table.
+type tableCommentSetter interface {
+	setTableComment(ctx *sql.Context, tableName string, comment string) error
+}
+
+// NewCreateTableIter returns the row iterator for a CREATE TABLE statement, or nil if |n| should be handled by the
+// engine. The engine creates tables with a PRIMARY KEY clause through sql.IndexedTableCreator, which has no way to
+// pass the table comment, so the comment is set on the new table after the engine creates it. SHOW CREATE TABLE
+// always declares primary keys this way, so without this its table comments would not survive a round trip.
+func NewCreateTableIter(ctx *sql.Context, n *plan.CreateTable) (sql.RowIter, error) {
+	comment, _ := n.TableOpts["comment"].(string)
+	if comment == "" || n.Temporary() || n.Select() != nil || n.Like() != nil {
+		return nil, nil
+	}
+	hasPkIndex := false
+	for _, idxDef := range n.Indexes() {
+		hasPkIndex = hasPkIndex || idxDef.IsPrimary()
+	}
+	if !hasPkIndex {
+		return nil, nil
+	}
+
+	db := n.Db
+	if privDb, ok := db.(mysql_db.PrivilegedDatabase); ok {
+		db = privDb.Unwrap()
+	}
+	setter, ok := db.(tableCommentSetter)
+	if !ok {
+		return nil, nil
+	}
+	if _, exists, err := db.GetTableInsensitive(ctx, n.Name()); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, nil
+	}
+
+	iter, err := rowexec.DefaultBuilder.Build(ctx, n, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = setter.setTableComment(ctx, n.Name(), comment); err != nil {
+		return nil, err
+	}
+	return iter, nil
+}
+
+// NewShowCreateTableIter returns the row iterator for a SHOW CREATE TABLE statement, or nil if |n| does not show a
+// table and should be handled by the engine. The statement produced matches the engine's, except that it recreates
+// the schema of the table exactly: table metadata is read from beneath the wrapper the planner adds to tables with
+// virtual columns, comments are escaped, and invisible indexes are marked as such.
+func NewShowCreateTableIter(ctx *sql.Context, n *plan.ShowCreateTable) (sql.RowIter, error) {
+	rt, ok := n.Child.(*plan.ResolvedTable)
+	if !ok || n.IsView {
+		return nil, nil
+	}
+
+	indexes := n.Indexes
+	pkSchema := n.PrimaryKeySchema
+	checks := make([]sql.CheckDefinition, len(n.Checks()))
+	for i, check := range n.Checks() {
+		checks[i] = sql.CheckDefinition{Name: check.Name, CheckExpression: check.Expr.String(), Enforced: check.Enforced}
+	}
+
+	// The planner loads indexes, checks and the primary key from the table it resolved, which hides them behind the
+	// wrapper it adds to tables with virtual columns, so they're loaded from the underlying table instead.
+	table := sql.GetUnderlyingTable(rt.Table)
+	if _, ok := plan.FindVirtualColumnTable(rt.Table); ok {
+		var err error
+		if indexes, err = showCreateTableIndexes(ctx, table); err != nil {
+			return nil, err
+		}
+		if checkTable, ok := table.(sql.CheckTable); ok {
+			if checks, err = checkTable.GetChecks(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if pkTable, ok := table.(sql.PrimaryKeyTable); ok {
+			pkSchema = pkTable.PrimaryKeySchema()
+		}
+	}
+
+	stmt, err := createTableStatement(ctx, table, n.TargetSchema(), pkSchema, indexes, checks)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(sql.NewRow(rt.Name(), stmt)), nil
+}
+
+// showCreateTableIndexes returns the indexes of |table| that are declared in its CREATE TABLE statement.
+func showCreateTableIndexes(ctx *sql.Context, table sql.Table) ([]sql.Index, error) {
+	it, ok := table.(sql.IndexAddressableTable)
+	if !ok {
+		return nil, nil
+	}
+	all, err := it.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([]sql.Index, 0, len(all))
+	for _, idx := range all {
+		if !idx.IsGenerated() {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes, nil
+}
+
+// createTableStatement returns the CREATE TABLE statement for |table|.
+func createTableStatement(ctx *sql.Context, table sql.Table, sch sql.Schema, pkSchema sql.PrimaryKeySchema, indexes []sql.Index, checks []sql.CheckDefinition) (string, error) {
+	colStmts := make([]string, len(sch))
+	var pkOrdinals []int
+	if len(pkSchema.Schema) > 0 {
+		pkOrdinals = pkSchema.PkOrdinals
+	}
+
+	tableCollation := table.Collation()
+	for i, col := range sch {
+		var colDefault string
+		if col.Default != nil && col.Generated == nil {
+			colDefault = col.Default.String()
+			if colDefault != "NULL" && col.Default.IsLiteral() && !sqltypes.IsTime(col.Default.Type()) && !sqltypes.IsText(col.Default.Type()) {
+				v, err := col.Default.Eval(ctx, nil)
+				if err != nil {
+					return "", err
+				}
+				colDefault = fmt.Sprintf("'%v'", v)
+			}
+		}
+		var onUpdate string
+		if col.OnUpdate != nil {
+			onUpdate = col.OnUpdate.String()
+			if onUpdate != "NULL" && col.OnUpdate.IsLiteral() && !sqltypes.IsTime(col.OnUpdate.Type()) && !sqltypes.IsText(col.OnUpdate.Type()) {
+				v, err := col.OnUpdate.Eval(ctx, nil)
+				if err != nil {
+					return "", err
+				}
+				onUpdate = fmt.Sprintf("'%v'", v)
+			}
+		}
+
+		if col.PrimaryKey && len(pkSchema.Schema) == 0 {
+			pkOrdinals = append(pkOrdinals, i)
+		}
+
+		// The engine doesn't escape column comments, so the comment is added here
+		uncommented := *col
+		uncommented.Comment = ""
+		colStmts[i] = sql.GenerateCreateTableColumnDefinition(&uncommented, colDefault, onUpdate, tableCollation)
+		if col.Comment != "" {
+			colStmts[i] += " COMMENT " + sqlfmt.QuoteComment(col.Comment)
+		}
+	}
+
+	if len(pkOrdinals) > 0 {
+		pkCols := make([]string, len(pkOrdinals))
+		for i, ord := range pkOrdinals {
+			pkCols[i] = sch[ord].Name
+		}
+		colStmts = append(colStmts, sql.GenerateCreateTablePrimaryKeyDefinition(pkCols))
+	}
+
+	for _, idx := range indexes {
+		// The primary key may or may not be declared as an index by the table; don't print it twice.
+		if idx.ID() == "PRIMARY" {
+			continue
+		}
+
+		prefixLengths := idx.PrefixLengths()
+		var indexCols []string
+		for i, expr := range idx.Expressions() {
+			col := plan.GetColumnFromIndexExpr(expr, table)
+			if col != nil {
+				indexDef := sql.QuoteIdentifier(col.Name)
+				if len(prefixLengths) > i && prefixLengths[i] != 0 {
+					indexDef += fmt.Sprintf("(%v)", prefixLengths[i])
+				}
+				indexCols = append(indexCols, indexDef)
+			}
+		}
+
+		def := sql.GenerateCreateTableIndexDefinition(idx.IsUnique(), idx.IsSpatial(), idx.IsFullText(), idx.IsVector(), idx.ID(), indexCols, "")
+		if idx.Comment() != "" {
+			def += " COMMENT " + sqlfmt.QuoteComment(idx.Comment())
+		}
+		if isInvisibleIndex(idx) {
+			def += " /*!80000 INVISIBLE */"
+		}
+		colStmts = append(colStmts, def)
+	}
+
+	if fkt, ok := table.(sql.ForeignKeyTable); ok {
+		fks, err := fkt.GetDeclaredForeignKeys(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, fk := range fks {
+			onDelete := ""
+			if len(fk.OnDelete) > 0 && fk.OnDelete != sql.ForeignKeyReferentialAction_DefaultAction {
+				onDelete = string(fk.OnDelete)
+			}
+			onUpdate := ""
+			if len(fk.OnUpdate) > 0 && fk.OnUpdate != sql.ForeignKeyReferentialAction_DefaultAction {
+				onUpdate = string(fk.OnUpdate)
+			}
+			colStmts = append(colStmts, sql.GenerateCreateTableForiegnKeyDefinition(fk.Name, fk.Columns, fk.ParentTable, fk.ParentColumns, onDelete, onUpdate))
+		}
+	}
+
+	for _, check := range checks {
+		colStmts = append(colStmts, sql.GenerateCreateTableCheckConstraintClause(check.Name, check.CheckExpression, check.Enforced))
+	}
+
+	comment := ""
+	if commentedTable, ok := table.(sql.CommentedTable); ok {
+		// The engine escapes quotes in table comments, but not backslashes
+		comment = strings.ReplaceAll(commentedTable.Comment(), `\`, `\\`)
+	}
+
+	autoInc := ""
+	if ait, ok := table.(sql.AutoIncrementTable); ok {
+		next, err := ait.PeekNextAutoIncrementValue(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoAutoIncrementCol) {
+			return "", err
+		}
+		if next > 1 {
+			autoInc = fmt.Sprintf("%v", next)
+		}
+	}
+
+	collation := table.Collation()
+	return sql.GenerateCreateTableStatement(table.Name(), colStmts, autoInc, collation.CharacterSet().Name(), collation.Name(), comment), nil
+}
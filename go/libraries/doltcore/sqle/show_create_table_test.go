@@ -0,0 +1,236 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// showCreateTableRoundTripTypes covers every type identifier that can be created through SQL.
+var showCreateTableRoundTripTypes = []string{
+	"bit(1)",
+	"bit(64)",
+	"tinytext",
+	"text",
+	"mediumtext",
+	"longtext",
+	"text character set latin1 collate latin1_swedish_ci",
+	"date",
+	"datetime",
+	"datetime(6)",
+	"timestamp",
+	"timestamp(3)",
+	"decimal(10,2)",
+	"decimal(65,30)",
+	"enum('a','b','c')",
+	"enum('a','b') collate utf8mb4_bin",
+	"float",
+	"double",
+	"binary(16)",
+	"tinyint",
+	"tinyint(1)",
+	"smallint",
+	"mediumint",
+	"int",
+	"bigint",
+	"tinyint unsigned",
+	"smallint unsigned",
+	"mediumint unsigned",
+	"int unsigned",
+	"bigint unsigned",
+	"json",
+	"geometry",
+	"point",
+	"point srid 4326",
+	"linestring",
+	"polygon",
+	"multipoint",
+	"multilinestring",
+	"multipolygon",
+	"geometrycollection",
+	"set('a','b','c')",
+	"time",
+	"time(6)",
+	"varbinary(100)",
+	"tinyblob",
+	"blob",
+	"mediumblob",
+	"longblob",
+	"char(10)",
+	"varchar(100)",
+	"varchar(100) character set ascii collate ascii_bin",
+	"year",
+}
+
+// showCreateTableUnreachableTypes are type identifiers that no SQL column definition produces.
+var showCreateTableUnreachableTypes = map[typeinfo.Identifier]struct{}{
+	typeinfo.UnknownTypeIdentifier:  {},
+	typeinfo.BoolTypeIdentifier:     {},
+	typeinfo.TupleTypeIdentifier:    {},
+	typeinfo.UuidTypeIdentifier:     {},
+	typeinfo.ExtendedTypeIdentifier: {},
+}
+
+func TestShowCreateTableRoundTripTypes(t *testing.T) {
+	seen := make(map[typeinfo.Identifier]struct{})
+	for _, typ := range showCreateTableRoundTripTypes {
+		t.Run(typ, func(t *testing.T) {
+			sch := testShowCreateTableRoundTrip(t, "t", fmt.Sprintf("create table t (pk int primary key, c %s);", typ))
+			col, ok := sch.GetAllCols().GetByName("c")
+			require.True(t, ok)
+			seen[col.TypeInfo.GetTypeIdentifier()] = struct{}{}
+		})
+	}
+
+	for id := range typeinfo.Identifiers {
+		if _, ok := showCreateTableUnreachableTypes[id]; ok {
+			continue
+		}
+		assert.Contains(t, seen, id, "no round trip test for type %s", id)
+	}
+}
+
+func TestShowCreateTableRoundTripFeatures(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup string
+		query string
+	}{
+		{
+			name: "column options",
+			query: `create table t (
+  pk int primary key auto_increment comment 'the key',
+  a varchar(100) character set latin1 collate latin1_swedish_ci not null default 'x' comment 'a''s comment',
+  b decimal(10,3) default 1.5,
+  c datetime(6) default current_timestamp(6) on update current_timestamp(6),
+  d int default (pk + 1),
+  e json,
+  f float default null,
+  g int comment 'back\\slash \\'' quote'
+) comment='table \\ comment';`,
+		},
+		{
+			name:  "table collation",
+			query: "create table t (pk int primary key, a varchar(10), b varchar(10) collate utf8mb4_bin) collate=utf8mb4_0900_ai_ci;",
+		},
+		{
+			name: "generated columns",
+			query: `create table t (
+  pk int primary key,
+  a int,
+  b int generated always as (a + 1) stored,
+  c int generated always as (a * 2) virtual,
+  index (b),
+  index (c),
+  check (a > 0)
+) comment='generated';`,
+		},
+		{
+			name: "index options",
+			query: `create table t (
+  pk int primary key,
+  a varchar(100),
+  b int,
+  c text,
+  d point not null srid 0,
+  unique key ua (a(10), b) comment 'unique prefix',
+  key kb (b) comment 'it''s \\ plain',
+  fulltext key fc (c),
+  spatial key sd (d)
+);`,
+		},
+		{
+			name:  "foreign key actions",
+			setup: "create table p (pk int primary key, a int, unique key (a));",
+			query: `create table t (
+  pk int primary key,
+  a int,
+  b int,
+  key ka (a),
+  key kb (b),
+  constraint fk_a foreign key (a) references p (a) on delete set null on update cascade,
+  constraint fk_b foreign key (b) references p (pk) on delete cascade on update restrict
+);`,
+		},
+		{
+			name:  "check constraints",
+			query: "create table t (pk int primary key, a int, b int, constraint chk_a check (a > 0), check (a < b) not enforced);",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testShowCreateTableRoundTrip(t, "t", test.setup+"\n"+test.query, "p")
+		})
+	}
+}
+
+// testShowCreateTableRoundTrip runs |statements| in a new database, feeds the output of SHOW CREATE TABLE for
+// |tableName| and each of |dependencies| into a second database, and asserts that both databases have the same
+// schema for |tableName|. It returns that schema.
+func testShowCreateTableRoundTrip(t *testing.T, tableName string, statements string, dependencies ...string) schema.Schema {
+	ctx := context.Background()
+	src := dtestutils.CreateTestEnv()
+	defer src.DoltDB.Close()
+	dest := dtestutils.CreateTestEnv()
+	defer dest.DoltDB.Close()
+
+	srcRoot, err := src.WorkingRoot(ctx)
+	require.NoError(t, err)
+	srcRoot, err = ExecuteSql(src, srcRoot, statements)
+	require.NoError(t, err)
+
+	destRoot, err := dest.WorkingRoot(ctx)
+	require.NoError(t, err)
+	for _, name := range append(dependencies, tableName) {
+		if ok, err := srcRoot.HasTable(ctx, doltdb.TableName{Name: name}); err != nil || !ok {
+			require.NoError(t, err)
+			continue
+		}
+		rows, err := ExecuteSelect(src, srcRoot, fmt.Sprintf("show create table `%s`", name))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		destRoot, err = ExecuteSql(dest, destRoot, rows[0][1].(string)+";")
+		require.NoError(t, err, "statement:\n%s", rows[0][1])
+	}
+
+	srcSch, srcHash := tableSchemaAndHash(t, ctx, srcRoot, tableName)
+	destSch, destHash := tableSchemaAndHash(t, ctx, destRoot, tableName)
+	if !assert.Equal(t, srcHash, destHash, "schema of %s did not round trip through SHOW CREATE TABLE", tableName) {
+		assert.Equal(t, srcSch, destSch)
+	}
+	return srcSch
+}
+
+func tableSchemaAndHash(t *testing.T, ctx context.Context, root doltdb.RootValue, tableName string) (schema.Schema, string) {
+	tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: tableName})
+	require.NoError(t, err)
+	require.True(t, ok)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	h, err := tbl.GetSchemaHash(ctx)
+	require.NoError(t, err)
+	return sch, h.String()
+}
@@ -47,11 +47,14 @@ func QuoteTableName(n doltdb.TableName) string {
 	return "`" + n.String() + "`"
 }
 
-// QuoteComment quotes the given string with apostrophes, and escapes any contained within the string.
+// QuoteComment quotes the given string with apostrophes, and escapes any apostrophes and backslashes contained within
+// the string.
 func QuoteComment(s string) string {
-	return `'` + strings.ReplaceAll(s, `'`, `\'`) + `'`
+	return `'` + commentEscaper.Replace(s) + `'`
 }
 
+var commentEscaper = strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
 func RowAsInsertStmt(r row.Row, tableName string, tableSch schema.Schema) (string, error) {
 	var b strings.Builder
 	b.WriteString("INSERT INTO ")
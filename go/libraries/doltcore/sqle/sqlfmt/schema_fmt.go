@@ -16,6 +16,7 @@ package sqlfmt
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -93,6 +94,9 @@ func GenerateSqlPatchSchemaStatements(ctx *sql.Context, toRoot doltdb.RootValue,
 }
 
 // generateNonCreateNonDropTableSqlSchemaDiff returns any schema diff in SQL statements that is NEITHER 'CREATE TABLE' NOR 'DROP TABLE' statements.
+// The statements are the minimal sequence of ALTER TABLE statements that transforms the from schema into the to
+// schema, ordered so that each of them can be applied after the previous ones: foreign keys and indexes are dropped
+// before the columns they reference change, and added back once the columns they reference exist.
 // TODO: schema names
 func generateNonCreateNonDropTableSqlSchemaDiff(td diff.TableDelta, toSchemas map[doltdb.TableName]schema.Schema, fromSch, toSch schema.Schema) ([]string, error) {
 	if td.IsAdd() || td.IsDrop() {
@@ -110,63 +114,67 @@ func generateNonCreateNonDropTableSqlSchemaDiff(td diff.TableDelta, toSchemas ma
 		return ddlStatements, nil
 	}
 
+	// the table has already been renamed by the time any of the following statements run
+	tableName := td.ToName.Name
+	tableCollation := sql.CollationID(toSch.GetCollation())
+
+	fkDiffs := diff.DiffForeignKeys(td.FromFks, td.ToFks)
+	for _, fkDiff := range fkDiffs {
+		if fkDiff.DiffType == diff.SchDiffRemoved || fkDiff.DiffType == diff.SchDiffModified {
+			ddlStatements = append(ddlStatements, AlterTableDropForeignKeyStmt(td.ToName, fkDiff.From.Name))
+		}
+	}
+
+	idxDiffs := diff.DiffSchIndexes(fromSch, toSch)
+	// index collections are unordered, so sort the differences to produce the same statements on every call
+	sort.Slice(idxDiffs, func(i, j int) bool {
+		return indexDiffName(idxDiffs[i]) < indexDiffName(idxDiffs[j])
+	})
+	for _, idxDiff := range idxDiffs {
+		if idxDiff.DiffType == diff.SchDiffRemoved || idxDiff.DiffType == diff.SchDiffModified {
+			ddlStatements = append(ddlStatements, AlterTableDropIndexStmt(tableName, idxDiff.From))
+		}
+	}
+
 	colDiffs, unionTags := diff.DiffSchColumns(fromSch, toSch)
 	for _, tag := range unionTags {
 		cd := colDiffs[tag]
 		switch cd.DiffType {
 		case diff.SchDiffNone:
 		case diff.SchDiffAdded:
-			ddlStatements = append(ddlStatements, AlterTableAddColStmt(td.ToName.Name, GenerateCreateTableColumnDefinition(*cd.New, sql.CollationID(td.ToSch.GetCollation()))))
+			ddlStatements = append(ddlStatements, AlterTableAddColStmt(tableName, GenerateCreateTableColumnDefinition(*cd.New, tableCollation)))
 		case diff.SchDiffRemoved:
-			ddlStatements = append(ddlStatements, AlterTableDropColStmt(td.ToName.Name, cd.Old.Name))
+			ddlStatements = append(ddlStatements, AlterTableDropColStmt(tableName, cd.Old.Name))
 		case diff.SchDiffModified:
 			// Ignore any primary key set changes here
 			if cd.Old.IsPartOfPK != cd.New.IsPartOfPK {
 				continue
 			}
 			if cd.Old.Name != cd.New.Name {
-				ddlStatements = append(ddlStatements, AlterTableRenameColStmt(td.ToName.Name, cd.Old.Name, cd.New.Name))
+				ddlStatements = append(ddlStatements, AlterTableRenameColStmt(tableName, cd.Old.Name, cd.New.Name))
 			}
-			if !cd.Old.TypeInfo.Equals(cd.New.TypeInfo) {
-				ddlStatements = append(ddlStatements, AlterTableModifyColStmt(td.ToName.Name,
-					GenerateCreateTableColumnDefinition(*cd.New, sql.CollationID(td.ToSch.GetCollation()))))
+			if columnDefinitionChanged(*cd.Old, *cd.New, tableCollation) {
+				ddlStatements = append(ddlStatements, AlterTableModifyColStmt(tableName, GenerateCreateTableColumnDefinition(*cd.New, tableCollation)))
 			}
 		}
 	}
 
 	// Print changes between a primary key set change. It contains an ALTER TABLE DROP and an ALTER TABLE ADD
 	if !schema.ColCollsAreEqual(fromSch.GetPKCols(), toSch.GetPKCols()) {
-		ddlStatements = append(ddlStatements, AlterTableDropPks(td.ToName.Name))
+		ddlStatements = append(ddlStatements, AlterTableDropPks(tableName))
 		if toSch.GetPKCols().Size() > 0 {
-			ddlStatements = append(ddlStatements, AlterTableAddPrimaryKeys(td.ToName.Name, toSch.GetPKCols().GetColumnNames()))
+			ddlStatements = append(ddlStatements, AlterTableAddPrimaryKeys(tableName, toSch.GetPKCols().GetColumnNames()))
 		}
 	}
 
-	for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
-		switch idxDiff.DiffType {
-		case diff.SchDiffNone:
-		case diff.SchDiffAdded:
-			ddlStatements = append(ddlStatements, AlterTableAddIndexStmt(td.ToName.Name, idxDiff.To))
-		case diff.SchDiffRemoved:
-			ddlStatements = append(ddlStatements, AlterTableDropIndexStmt(td.FromName.Name, idxDiff.From))
-		case diff.SchDiffModified:
-			ddlStatements = append(ddlStatements, AlterTableDropIndexStmt(td.FromName.Name, idxDiff.From))
-			ddlStatements = append(ddlStatements, AlterTableAddIndexStmt(td.ToName.Name, idxDiff.To))
+	for _, idxDiff := range idxDiffs {
+		if idxDiff.DiffType == diff.SchDiffAdded || idxDiff.DiffType == diff.SchDiffModified {
+			ddlStatements = append(ddlStatements, AlterTableAddIndexStmt(tableName, idxDiff.To))
 		}
 	}
 
-	for _, fkDiff := range diff.DiffForeignKeys(td.FromFks, td.ToFks) {
-		switch fkDiff.DiffType {
-		case diff.SchDiffNone:
-		case diff.SchDiffAdded:
-			parentSch := toSchemas[fkDiff.To.ReferencedTableName]
-			ddlStatements = append(ddlStatements, AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
-		case diff.SchDiffRemoved:
-			from := fkDiff.From
-			ddlStatements = append(ddlStatements, AlterTableDropForeignKeyStmt(from.TableName, from.Name))
-		case diff.SchDiffModified:
-			from := fkDiff.From
-			ddlStatements = append(ddlStatements, AlterTableDropForeignKeyStmt(from.TableName, from.Name))
+	for _, fkDiff := range fkDiffs {
+		if fkDiff.DiffType == diff.SchDiffAdded || fkDiff.DiffType == diff.SchDiffModified {
 			parentSch := toSchemas[fkDiff.To.ReferencedTableName]
 			ddlStatements = append(ddlStatements, AlterTableAddForeignKeyStmt(fkDiff.To, toSch, parentSch))
 		}
@@ -176,12 +184,26 @@ func generateNonCreateNonDropTableSqlSchemaDiff(td diff.TableDelta, toSchemas ma
 	toCollation := toSch.GetCollation()
 	fromCollation := fromSch.GetCollation()
 	if toCollation != fromCollation {
-		ddlStatements = append(ddlStatements, AlterTableCollateStmt(td.ToName.Name, fromCollation, toCollation))
+		ddlStatements = append(ddlStatements, AlterTableCollateStmt(tableName, fromCollation, toCollation))
 	}
 
 	return ddlStatements, nil
 }
 
+func indexDiffName(d diff.IndexDifference) string {
+	if d.From != nil {
+		return d.From.Name()
+	}
+	return d.To.Name()
+}
+
+// columnDefinitionChanged returns whether the definition of column |from| differs from that of |to| in anything but
+// its name, which is changed separately with RENAME COLUMN.
+func columnDefinitionChanged(from, to schema.Column, tableCollation sql.CollationID) bool {
+	from.Name = to.Name
+	return GenerateCreateTableColumnDefinition(from, tableCollation) != GenerateCreateTableColumnDefinition(to, tableCollation)
+}
+
 // GenerateCreateTableColumnDefinition returns column definition for CREATE TABLE statement with no indentation
 func GenerateCreateTableColumnDefinition(col schema.Column, tableCollation sql.CollationID) string {
 	colStr := GenerateCreateTableIndentedColumnDefinition(col, tableCollation)
@@ -205,24 +227,35 @@ func GenerateCreateTableIndentedColumnDefinition(col schema.Column, tableCollati
 		onUpdateVal = sql.NewUnresolvedColumnDefaultValue(col.OnUpdate)
 	}
 
-	return sql.GenerateCreateTableColumnDefinition(
+	// The engine doesn't escape column comments, so the comment is added here
+	def := sql.GenerateCreateTableColumnDefinition(
 		&sql.Column{
 			Name:          col.Name,
 			Type:          col.TypeInfo.ToSqlType(),
 			Default:       defaultVal,
 			AutoIncrement: col.AutoIncrement,
 			Nullable:      col.IsNullable(),
-			Comment:       col.Comment,
 			Generated:     genVal,
 			Virtual:       col.Virtual,
 			OnUpdate:      onUpdateVal,
 		}, col.Default, col.OnUpdate, tableCollation)
+	if col.Comment != "" {
+		def += " COMMENT " + QuoteComment(col.Comment)
+	}
+	return def
 }
 
 // GenerateCreateTableIndexDefinition returns index definition for CREATE TABLE statement with indentation of 2 spaces
 func GenerateCreateTableIndexDefinition(index schema.Index) string {
-	return sql.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(), index.IsFullText(), false, index.Name(),
-		sql.QuoteIdentifiers(index.ColumnNames()), index.Comment())
+	def := sql.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(), index.IsFullText(), false, index.Name(),
+		sql.QuoteIdentifiers(index.ColumnNames()), "")
+	if index.Comment() != "" {
+		def += " COMMENT " + QuoteComment(index.Comment())
+	}
+	if index.IsInvisible() {
+		def += " /*!80000 INVISIBLE */"
+	}
+	return def
 }
 
 // GenerateCreateTableForeignKeyDefinition returns foreign key definition for CREATE TABLE statement with indentation of 2 spaces
@@ -366,7 +399,16 @@ func AlterTableAddIndexStmt(tableName string, idx schema.Index) string {
 	var b strings.Builder
 	b.WriteString("ALTER TABLE ")
 	b.WriteString(QuoteIdentifier(tableName))
-	b.WriteString(" ADD INDEX ")
+	switch {
+	case idx.IsUnique():
+		b.WriteString(" ADD UNIQUE INDEX ")
+	case idx.IsSpatial():
+		b.WriteString(" ADD SPATIAL INDEX ")
+	case idx.IsFullText():
+		b.WriteString(" ADD FULLTEXT INDEX ")
+	default:
+		b.WriteString(" ADD INDEX ")
+	}
 	b.WriteString(QuoteIdentifier(idx.Name()))
 	var cols []string
 	for _, cn := range idx.ColumnNames() {
@@ -472,8 +514,10 @@ func GenerateCreateTableStatement(tblName string, sch schema.Schema, fks []doltd
 		colStmts = append(colStmts, GenerateCreateTableCheckConstraintClause(check))
 	}
 
+	// The engine escapes quotes in table comments, but not backslashes
+	comment := strings.ReplaceAll(sch.GetComment(), `\`, `\\`)
 	coll := sql.CollationID(sch.GetCollation())
-	createTableStmt := sql.GenerateCreateTableStatement(tblName, colStmts, "", coll.CharacterSet().Name(), coll.Name(), sch.GetComment())
+	createTableStmt := sql.GenerateCreateTableStatement(tblName, colStmts, "", coll.CharacterSet().Name(), coll.Name(), comment)
 	return fmt.Sprintf("%s;", createTableStmt), nil
 }
 
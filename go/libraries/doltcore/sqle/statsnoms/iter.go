@@ -156,6 +156,7 @@ func (s *statsIter) Next(ctx *sql.Context) (sql.Row, error) {
 		createdAt,
 		mcvs[0], mcvs[1], mcvs[2], mcvs[3],
 		mcvCountsStr,
+		row[schema.StatsSketchTag],
 	}, nil
 }
 
@@ -15,6 +15,7 @@
 package statsnoms
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -146,9 +147,19 @@ func loadStats(ctx *sql.Context, db dsess.SqlDatabase, m prolly.Map) (map[sql.St
 			return nil, err
 		}
 
+		// buckets written before sketches were kept have no sketch
+		var sketch []byte
+		if v, ok := row[schema.StatsSketchTag].(string); ok {
+			sketch, err = base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		bucket := statspro.DoltBucket{
 			Chunk:   commit,
 			Created: createdAt,
+			Sketch:  sketch,
 			Bucket: &stats.Bucket{
 				RowCnt:      uint64(rowCount),
 				DistinctCnt: uint64(distinctCount),
@@ -16,6 +16,7 @@ package statsnoms
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"io"
 	"strings"
@@ -146,6 +147,9 @@ func putIndexRows(ctx context.Context, statsMap *prolly.MutableMap, dStats *stat
 			mcvCntsRow = append(mcvCntsRow, int(v))
 		}
 		valueBuilder.PutString(14, stats.StringifyKey(mcvCntsRow, mcvsTypes))
+		if sketch := statspro.DoltBucketSketch(h); sketch != nil {
+			valueBuilder.PutString(15, base64.StdEncoding.EncodeToString(sketch))
+		}
 
 		key := keyBuilder.Build(pool)
 		value := valueBuilder.Build(pool)
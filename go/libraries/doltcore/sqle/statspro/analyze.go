@@ -66,8 +66,9 @@ func (p *Provider) BootstrapDatabaseStats(ctx *sql.Context, db string) error {
 			}
 
 			if st, ok := sqlTable.(sql.StatisticsTable); ok {
-				cnt, ok, err := st.RowCount(ctx)
-				if ok && err == nil {
+				// keyless row counts are approximate, but close enough for the limit
+				cnt, _, err := st.RowCount(ctx)
+				if err == nil {
 					rows += cnt
 				}
 			}
@@ -210,6 +211,10 @@ func (p *Provider) RefreshTableStatsWithBranch(ctx *sql.Context, table sql.Table
 		}
 	}
 
+	if err := p.refreshColumnGroups(ctx, sqlTable, branch, dbName, schemaName, tableName); err != nil {
+		return err
+	}
+
 	p.UpdateStatus(dbName, fmt.Sprintf("refreshed %s", dbName))
 	return statDb.Flush(ctx, branch)
 }
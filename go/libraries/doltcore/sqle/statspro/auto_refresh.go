@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	types2 "github.com/dolthub/go-mysql-server/sql/types"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
@@ -91,7 +92,10 @@ func (p *Provider) InitAutoRefreshWithParams(ctxFactory func(ctx context.Context
 							return
 						}
 
-						if err := p.checkRefresh(sqlCtx, sqlDb, dbName, br, updateThresh); err != nil {
+						err = background.Run(sqlCtx, background.Stats, func(jobCtx context.Context) error {
+							return p.checkRefresh(sqlCtx.WithContext(jobCtx), sqlDb, dbName, br, updateThresh)
+						})
+						if err != nil {
 							sqlCtx.GetLogger().Debugf("statistics refresh error: %s", err.Error())
 							return
 						}
@@ -133,6 +137,11 @@ func (p *Provider) checkRefresh(ctx *sql.Context, sqlDb sql.Database, dbName, br
 	}
 
 	for _, table := range tables {
+		// Throttle the refresh when it runs as a background job.
+		if err := background.Yield(ctx); err != nil {
+			return err
+		}
+
 		if !p.TryLockForUpdate(branch, dbName, table) {
 			ctx.GetLogger().Debugf("statistics refresh: table is already being updated: %s/%s.%s", branch, dbName, table)
 			return fmt.Errorf("table already being updated: %s", table)
@@ -0,0 +1,359 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statspro
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// columnGroup is a set of columns declared in dolt_stats_column_groups
+// whose combined statistics are collected for a table.
+type columnGroup struct {
+	db    string
+	table string
+	cols  []string
+}
+
+// parseColumnGroups parses a dolt_stats_column_groups value. Groups are
+// separated by semicolons and take the form "[db.]table(col1,col2,...)".
+func parseColumnGroups(s string) ([]columnGroup, error) {
+	var groups []columnGroup
+	for _, def := range strings.Split(s, ";") {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+		open := strings.Index(def, "(")
+		if open <= 0 || !strings.HasSuffix(def, ")") {
+			return nil, fmt.Errorf("invalid column group '%s': expected the form table(col1,col2)", def)
+		}
+
+		var g columnGroup
+		name := strings.ToLower(strings.TrimSpace(def[:open]))
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			g.db, name = name[:i], name[i+1:]
+		}
+		g.table = name
+		for _, col := range strings.Split(def[open+1:len(def)-1], ",") {
+			col = strings.ToLower(strings.TrimSpace(col))
+			if col == "" {
+				return nil, fmt.Errorf("invalid column group '%s': empty column name", def)
+			}
+			g.cols = append(g.cols, col)
+		}
+		if g.table == "" || len(g.cols) < 2 {
+			return nil, fmt.Errorf("invalid column group '%s': a group needs a table and at least two columns", def)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// getColumnGroups returns the column groups declared for |table| in |db|.
+func getColumnGroups(db, table string) ([]columnGroup, error) {
+	_, val, _ := sql.SystemVariables.GetGlobal(dsess.DoltStatsColumnGroups)
+	s, _ := val.(string)
+	if s == "" {
+		return nil, nil
+	}
+	groups, err := parseColumnGroups(s)
+	if err != nil {
+		return nil, err
+	}
+	var ret []columnGroup
+	for _, g := range groups {
+		if g.table == strings.ToLower(table) && (g.db == "" || g.db == strings.ToLower(db)) {
+			ret = append(ret, g)
+		}
+	}
+	return ret, nil
+}
+
+// ColumnGroupStats are the combined statistics for a group of columns in
+// a table. Unlike index statistics they are not persisted, and are
+// recollected every time the table's statistics are refreshed.
+type ColumnGroupStats struct {
+	Db     string
+	Schema string
+	Table  string
+	Cols   []string
+	// Rows is the number of rows in the table
+	Rows uint64
+	// Distinct is the number of distinct non-NULL value tuples of the group
+	Distinct uint64
+	// Nulls is the number of rows with a NULL in any of the group's columns
+	Nulls uint64
+	// ColDistinct is the number of distinct non-NULL values of each column
+	ColDistinct []uint64
+}
+
+var _ dtables.ColumnGroupStatistic = (*ColumnGroupStats)(nil)
+
+func (s *ColumnGroupStats) Columns() []string {
+	return s.Cols
+}
+
+func (s *ColumnGroupStats) RowCount() uint64 {
+	return s.Rows
+}
+
+func (s *ColumnGroupStats) DistinctCount() uint64 {
+	return s.Distinct
+}
+
+func (s *ColumnGroupStats) NullCount() uint64 {
+	return s.Nulls
+}
+
+// Correlation is the ratio of the group's distinct count to the distinct
+// count expected if the columns were independent, which is the product of
+// the per-column distinct counts capped at the number of non-NULL rows.
+// Independent columns have a correlation near 1, and a column that is
+// determined by the others lowers it by a factor of its distinct count.
+func (s *ColumnGroupStats) Correlation() float64 {
+	if s.Distinct == 0 {
+		return 1
+	}
+	expected := 1.0
+	for _, d := range s.ColDistinct {
+		expected *= float64(d)
+	}
+	expected = math.Min(expected, float64(s.Rows-s.Nulls))
+	if expected <= 0 {
+		return 1
+	}
+	return math.Min(float64(s.Distinct)/expected, 1)
+}
+
+// columnGroupKey is the provider map key for a table's column group statistics.
+func columnGroupKey(branch, db, schema, table string) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s/%s/%s", branch, db, schema, table))
+}
+
+// refreshColumnGroups scans |sqlTable| to collect statistics for each
+// column group declared for it, replacing any previous group statistics.
+func (p *Provider) refreshColumnGroups(ctx *sql.Context, sqlTable sql.Table, branch, db, schema, table string) error {
+	groups, err := getColumnGroups(db, table)
+	if err != nil {
+		return err
+	}
+
+	key := columnGroupKey(branch, db, schema, table)
+	if len(groups) == 0 {
+		p.mu.Lock()
+		delete(p.colGroups, key)
+		p.mu.Unlock()
+		return nil
+	}
+
+	sch := sqlTable.Schema()
+	var ordinals [][]int
+	var stats []*ColumnGroupStats
+	for _, g := range groups {
+		ords := make([]int, len(g.cols))
+		for i, col := range g.cols {
+			ords[i] = sch.IndexOfColName(col)
+			if ords[i] < 0 {
+				ords = nil
+				break
+			}
+		}
+		if ords == nil {
+			ctx.Warn(0, "statistics column group %s(%s) references a column that does not exist", table, strings.Join(g.cols, ","))
+			continue
+		}
+		ordinals = append(ordinals, ords)
+		stats = append(stats, &ColumnGroupStats{
+			Db:          db,
+			Schema:      schema,
+			Table:       table,
+			Cols:        g.cols,
+			ColDistinct: make([]uint64, len(g.cols)),
+		})
+	}
+
+	groupSeen := make([]map[uint64]struct{}, len(stats))
+	colSeen := make([][]map[uint64]struct{}, len(stats))
+	for i := range stats {
+		groupSeen[i] = make(map[uint64]struct{})
+		colSeen[i] = make([]map[uint64]struct{}, len(ordinals[i]))
+		for j := range ordinals[i] {
+			colSeen[i][j] = make(map[uint64]struct{})
+		}
+	}
+
+	var rows uint64
+	err = scanTableRows(ctx, sqlTable, func(row sql.Row) error {
+		rows++
+		for i, ords := range ordinals {
+			vals := make(sql.Row, len(ords))
+			var hasNull bool
+			for j, ord := range ords {
+				vals[j] = row[ord]
+				if vals[j] == nil {
+					hasNull = true
+					continue
+				}
+				h, err := sql.HashOf(sql.Row{vals[j]})
+				if err != nil {
+					return err
+				}
+				colSeen[i][j][h] = struct{}{}
+			}
+			if hasNull {
+				stats[i].Nulls++
+				continue
+			}
+			h, err := sql.HashOf(vals)
+			if err != nil {
+				return err
+			}
+			groupSeen[i][h] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, s := range stats {
+		s.Rows = rows
+		s.Distinct = uint64(len(groupSeen[i]))
+		for j := range s.ColDistinct {
+			s.ColDistinct[j] = uint64(len(colSeen[i][j]))
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.colGroups[key] = stats
+	return nil
+}
+
+// scanTableRows calls |cb| with every row of |sqlTable|.
+func scanTableRows(ctx *sql.Context, sqlTable sql.Table, cb func(sql.Row) error) error {
+	parts, err := sqlTable.Partitions(ctx)
+	if err != nil {
+		return err
+	}
+	defer parts.Close(ctx)
+	for {
+		part, err := parts.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		iter, err := sqlTable.PartitionRows(ctx, part)
+		if err != nil {
+			return err
+		}
+		for {
+			row, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				iter.Close(ctx)
+				return err
+			}
+			if err := cb(row); err != nil {
+				iter.Close(ctx)
+				return err
+			}
+		}
+		if err := iter.Close(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// GetTableColumnGroupStats returns the column group statistics collected
+// for a table on |branch|.
+func (p *Provider) GetTableColumnGroupStats(ctx *sql.Context, branch, db, schema, table string) ([]dtables.ColumnGroupStatistic, error) {
+	if branch == "" {
+		dSess := dsess.DSessFromSess(ctx.Session)
+		var err error
+		branch, err = dSess.GetBranch()
+		if err != nil {
+			return nil, nil
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ret []dtables.ColumnGroupStatistic
+	for _, s := range p.colGroups[columnGroupKey(branch, db, schema, table)] {
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+// ColumnGroupDistinctCount returns the distinct count for the column group
+// of |table| covering exactly |cols|, if one has been collected. This is
+// the correlation-aware estimate for the number of distinct join keys on
+// those columns.
+func (p *Provider) ColumnGroupDistinctCount(ctx *sql.Context, db, schema, table string, cols []string) (uint64, bool) {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	branch, err := dSess.GetBranch()
+	if err != nil {
+		return 0, false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, s := range p.colGroups[columnGroupKey(branch, db, schema, table)] {
+		if sameColumns(s.Cols, cols) {
+			return s.Distinct, true
+		}
+	}
+	return 0, false
+}
+
+// dropColumnGroups removes the column group statistics for a branch of
+// |db|. The caller must hold |p.mu|.
+func (p *Provider) dropColumnGroups(branch, db string) {
+	prefix := strings.ToLower(fmt.Sprintf("%s/%s/", branch, db))
+	for key := range p.colGroups {
+		if strings.HasPrefix(key, prefix) {
+			delete(p.colGroups, key)
+		}
+	}
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int, len(a))
+	for _, c := range a {
+		seen[strings.ToLower(c)]++
+	}
+	for _, c := range b {
+		c = strings.ToLower(c)
+		if seen[c] == 0 {
+			return false
+		}
+		seen[c]--
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statspro
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColumnGroups(t *testing.T) {
+	groups, err := parseColumnGroups(" fact(a, B) ;mydb.Dim(x,y,z);")
+	require.NoError(t, err)
+	assert.Equal(t, []columnGroup{
+		{table: "fact", cols: []string{"a", "b"}},
+		{db: "mydb", table: "dim", cols: []string{"x", "y", "z"}},
+	}, groups)
+
+	groups, err = parseColumnGroups("")
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	for _, s := range []string{"fact", "fact(a)", "(a,b)", "fact(a,)", "fact(a,b"} {
+		_, err = parseColumnGroups(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestColumnGroupCorrelation(t *testing.T) {
+	tests := []struct {
+		name     string
+		stats    ColumnGroupStats
+		expected float64
+	}{
+		{
+			name:     "independent",
+			stats:    ColumnGroupStats{Rows: 100, Distinct: 50, ColDistinct: []uint64{10, 5}},
+			expected: 1,
+		},
+		{
+			name:     "functional dependency",
+			stats:    ColumnGroupStats{Rows: 100, Distinct: 10, ColDistinct: []uint64{10, 5}},
+			expected: .2,
+		},
+		{
+			name:     "capped by row count",
+			stats:    ColumnGroupStats{Rows: 20, Distinct: 10, ColDistinct: []uint64{10, 10}},
+			expected: .5,
+		},
+		{
+			name:     "nulls excluded",
+			stats:    ColumnGroupStats{Rows: 20, Nulls: 10, Distinct: 5, ColDistinct: []uint64{10, 10}},
+			expected: .5,
+		},
+		{
+			name:     "empty",
+			stats:    ColumnGroupStats{},
+			expected: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, tt.stats.Correlation(), 1e-9)
+		})
+	}
+}
+
+func TestColumnGroupLookupCost(t *testing.T) {
+	// 100 rows looked up in a table of 1000 rows with 10 distinct keys
+	// read 100 rows per lookup
+	assert.InDelta(t, 100+100*100*2.3, columnGroupLookupCost(100, 1000, 10), 1e-9)
+	// correlated key columns have fewer distinct keys, so each lookup
+	// returns more rows and the join costs more
+	assert.Greater(t, columnGroupLookupCost(100, 1000, 10), columnGroupLookupCost(100, 1000, 500))
+	// a lookup returns at least one row
+	assert.InDelta(t, 1+2.3, columnGroupLookupCost(0, 10, 100), 1e-9)
+}
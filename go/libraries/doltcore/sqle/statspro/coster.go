@@ -0,0 +1,105 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statspro
+
+import (
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/memo"
+)
+
+// The cost factors of memo's default coster, which the lookup join
+// costs below have to be comparable with.
+const (
+	seqIOCostFactor  = 1
+	randIOCostFactor = 1.3
+)
+
+// NewColumnGroupCoster returns a memo.Coster which costs a lookup join
+// whose lookup keys are exactly the columns of a column group with the
+// group's distinct count. The default coster assumes that each key column
+// halves the rows a lookup returns, which overestimates the selectivity of
+// correlated columns; the group's distinct count is the number of distinct
+// keys with the correlation of its columns accounted for. Every other
+// expression is costed by the default coster.
+func NewColumnGroupCoster() memo.Coster {
+	return &columnGroupCoster{Coster: memo.NewDefaultCoster()}
+}
+
+type columnGroupCoster struct {
+	memo.Coster
+}
+
+var _ memo.Coster = (*columnGroupCoster)(nil)
+
+func (c *columnGroupCoster) EstimateCost(ctx *sql.Context, n memo.RelExpr, s sql.StatsProvider) (float64, error) {
+	if lookup, ok := n.(*memo.LookupJoin); ok && !lookup.Injective && lookup.Op.IsLookup() {
+		if distinct, ok := lookupGroupDistinctCount(ctx, lookup, s); ok {
+			lRows := float64(lookup.Left.RelProps.GetStats().RowCount())
+			rRows := float64(lookup.Right.RelProps.GetStats().RowCount())
+			return columnGroupLookupCost(lRows, rRows, distinct), nil
+		}
+	}
+	return c.Coster.EstimateCost(ctx, n, s)
+}
+
+// columnGroupLookupCost is the cost of a lookup join which reads |lRows|
+// rows and looks each of them up in the |rRows| rows of a table with
+// |distinct| distinct lookup keys. Like the default coster, it's the cost
+// of reading the left table and randomly reading the rows the join returns.
+func columnGroupLookupCost(lRows, rRows float64, distinct uint64) float64 {
+	lRows = math.Max(1, lRows)
+	perKey := math.Max(1, rRows/float64(distinct))
+	return lRows*seqIOCostFactor + lRows*perKey*(randIOCostFactor+seqIOCostFactor)
+}
+
+// lookupGroupDistinctCount returns the distinct count of the column group
+// covering the key columns of |n|'s lookup, if the statistics provider is a
+// Provider which has collected one.
+func lookupGroupDistinctCount(ctx *sql.Context, n *memo.LookupJoin, s sql.StatsProvider) (uint64, bool) {
+	if catalog, ok := s.(*analyzer.Catalog); ok {
+		s = catalog.StatsProvider
+	}
+	prov, ok := s.(*Provider)
+	if !ok {
+		return 0, false
+	}
+
+	idx := n.Lookup.Index.SqlIdx()
+	keys := len(n.Lookup.Table.Expressions())
+	exprs := idx.Expressions()
+	if keys < 2 || keys > len(exprs) {
+		// column groups have at least two columns
+		return 0, false
+	}
+	tablePrefix := strings.ToLower(idx.Table()) + "."
+	cols := make([]string, keys)
+	for i, e := range exprs[:keys] {
+		cols[i] = strings.TrimPrefix(strings.ToLower(e), tablePrefix)
+	}
+
+	var schemaName string
+	if schTab, ok := n.Lookup.Table.TableNode.(sql.DatabaseSchemaTable); ok {
+		schemaName = strings.ToLower(schTab.DatabaseSchema().SchemaName())
+	}
+	distinct, ok := prov.ColumnGroupDistinctCount(ctx, n.Lookup.Table.Database().Name(), schemaName, idx.Table(), cols)
+	if !ok || distinct == 0 {
+		return 0, false
+	}
+	return distinct, true
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/stats"
 
+	"github.com/dolthub/dolt/go/libraries/utils/hll"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/val"
 )
@@ -205,6 +206,24 @@ func DoltStatsFromSql(stat sql.Statistic) (*DoltStats, error) {
 	return ret, nil
 }
 
+// DistinctSketch returns a sketch of the distinct non-NULL keys of the
+// statistic merged from the sketches of its buckets, or false if a bucket
+// has no sketch. Unlike DistinctCount, keys that cross bucket boundaries
+// are only counted once.
+func (s *DoltStats) DistinctSketch() (*hll.Sketch, bool, error) {
+	sketch := hll.New()
+	for _, b := range s.Hist {
+		db, ok := b.(DoltBucket)
+		if !ok || db.Sketch == nil {
+			return nil, false, nil
+		}
+		if err := sketch.MergeEncoded(db.Sketch); err != nil {
+			return nil, false, err
+		}
+	}
+	return sketch, true, nil
+}
+
 func (s *DoltStats) UpdateActive() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -221,6 +240,9 @@ type DoltBucket struct {
 	Bucket  *stats.Bucket
 	Chunk   hash.Hash
 	Created time.Time
+	// Sketch is the encoded hll.Sketch of the distinct non-NULL
+	// keys of the bucket, or nil if the bucket has no sketch.
+	Sketch []byte
 }
 
 func (d DoltBucket) RowCount() uint64 {
@@ -259,6 +281,10 @@ func DoltBucketCreated(b sql.HistogramBucket) time.Time {
 	return b.(DoltBucket).Created
 }
 
+func DoltBucketSketch(b sql.HistogramBucket) []byte {
+	return b.(DoltBucket).Sketch
+}
+
 var _ sql.HistogramBucket = (*DoltBucket)(nil)
 
 func DoltHistFromSql(hist sql.Histogram, types []sql.Type) (sql.Histogram, error) {
@@ -59,6 +59,7 @@ func NewProvider(pro *sqle.DoltDatabaseProvider, sf StatsFactory) *Provider {
 		analyzeCtxCancelers: make(map[string]context.CancelFunc),
 		status:              make(map[string]string),
 		lockedTables:        make(map[string]bool),
+		colGroups:           make(map[string][]*ColumnGroupStats),
 	}
 }
 
@@ -75,6 +76,7 @@ type Provider struct {
 	starter             sqle.InitDatabaseHook
 	status              map[string]string
 	lockedTables        map[string]bool
+	colGroups           map[string][]*ColumnGroupStats
 }
 
 // each database has one statistics table that is a collection of the
@@ -277,6 +279,7 @@ func (p *Provider) DropBranchDbStats(ctx *sql.Context, branch, db string, flush
 	defer p.mu.Unlock()
 
 	p.status[db] = "dropped"
+	p.dropColumnGroups(branch, db)
 
 	return statDb.DeleteBranchStats(ctx, branch, flush)
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/utils/hll"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -224,13 +225,16 @@ func newBucketBuilder(qual sql.StatQualifier, prefixLen int, tupleDesc val.Tuple
 		qual:      qual,
 		prefixLen: prefixLen,
 		mcvs:      new(mcvHeap),
+		sketch:    hll.New(),
 		tupleDesc: tupleDesc.PrefixDesc(prefixLen),
 	}
 }
 
 // bucketBuilder performs an aggregation on a sorted series of keys to
 // collect statistics for a single histogram bucket. DistinctCount is fuzzy,
-// we might double count a key that crosses bucket boundaries.
+// we might double count a key that crosses bucket boundaries. The sketch of
+// the non-NULL keys of a bucket can be merged with other buckets' sketches
+// to estimate distinct counts without double counting.
 type bucketBuilder struct {
 	qual      sql.StatQualifier
 	tupleDesc val.TupleDesc
@@ -240,6 +244,7 @@ type bucketBuilder struct {
 	distinct int
 	nulls    int
 	mcvs     *mcvHeap
+	sketch   *hll.Sketch
 
 	currentKey val.Tuple
 	currentCnt int
@@ -258,6 +263,7 @@ func (u *bucketBuilder) newBucket() {
 	u.nulls = 0
 	u.currentKey = nil
 	u.currentCnt = 0
+	u.sketch = hll.New()
 
 	oldMcvs := *u.mcvs
 	oldMcvs = oldMcvs[:0]
@@ -297,6 +303,7 @@ func (u *bucketBuilder) finalize(ctx context.Context, ns tree.NodeStore) (DoltBu
 			BoundVal:    upperBound,
 			NullCnt:     uint64(u.nulls),
 		},
+		Sketch: u.sketch.Encode(),
 	}, nil
 }
 
@@ -312,11 +319,8 @@ func (u *bucketBuilder) add(key val.Tuple) {
 
 	u.count++
 	u.globalCount++
-	for i := 0; i < u.prefixLen; i++ {
-		if key.FieldIsNull(i) {
-			u.nulls++
-			break
-		}
+	if u.hasNull(key) {
+		u.nulls++
 	}
 }
 
@@ -336,6 +340,19 @@ func (u *bucketBuilder) newKey(key val.Tuple) {
 	u.distinct++
 	u.currentCnt = 1
 	u.currentKey = key
+	if !u.hasNull(key) {
+		u.sketch.Add(key)
+	}
+}
+
+// hasNull returns whether a field of |key| is NULL.
+func (u *bucketBuilder) hasNull(key val.Tuple) bool {
+	for i := 0; i < u.prefixLen; i++ {
+		if key.FieldIsNull(i) {
+			return true
+		}
+	}
+	return false
 }
 
 // updateMcv updates the most common value heap when we've demarked the
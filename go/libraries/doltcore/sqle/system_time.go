@@ -0,0 +1,151 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+	"slices"
+
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// expandSystemTime replaces the tables of |stmt| queried over a period of time with subqueries of their history, and
+// converts the times of FOR SYSTEM_TIME AS OF clauses to DATETIMEs. |query| is the text |stmt| was parsed from. The
+// SQL:2011 temporal clauses let tools written against the standard query a table's history without dolt's system
+// tables:
+//
+//	t FOR SYSTEM_TIME BETWEEN a AND b          the rows of t in every commit in effect from a through b
+//	t FOR SYSTEM_TIME FROM a TO b              the rows of t in every commit in effect from a until b
+//	t FOR SYSTEM_TIME CONTAINED IN (a, b)      the rows of t in every commit made from a through b
+//	t FOR SYSTEM_TIME ALL                      the rows of t in every commit
+//
+// Each clause is expanded into a subquery of the dolt_history table of t, aliased as t, so each row has the
+// commit_hash, committer and commit_date columns of the commit it's from in addition to the columns of t. Times are
+// compared with the dates of commits, and the commit in effect at a time is the latest one made at or before it.
+// FOR SYSTEM_TIME AS OF queries t at the commit in effect at a time, so its value is converted to a DATETIME rather
+// than being resolved as a branch or commit like the value of AS OF. The FOR VERSION clauses are left as they are,
+// since their values are branches or commits rather than times.
+func expandSystemTime(stmt ast.Statement, query string) (ast.Statement, error) {
+	// FOR SYSTEM_TIME, FOR VERSION and AS OF clauses all parse to the same node, so |query| is scanned for the kind of
+	// each clause, which are matched with the nodes in the order they're walked
+	clauses := temporalClauses(query)
+	if !slices.Contains(clauses, forSystemTime) {
+		return stmt, nil
+	}
+	var tableExprs []*ast.AliasedTableExpr
+	err := ast.Walk(func(node ast.SQLNode) (bool, error) {
+		if tableExpr, ok := node.(*ast.AliasedTableExpr); ok && tableExpr.AsOf != nil {
+			tableExprs = append(tableExprs, tableExpr)
+		}
+		return true, nil
+	}, stmt)
+	if err != nil {
+		return nil, err
+	}
+	if len(tableExprs) != len(clauses) {
+		return nil, fmt.Errorf("FOR SYSTEM_TIME is not supported in this statement")
+	}
+
+	for i, tableExpr := range tableExprs {
+		if clauses[i] != forSystemTime {
+			continue
+		}
+		if tableExpr.AsOf.Time != nil {
+			tableExpr.AsOf.Time = &ast.ConvertExpr{Name: "convert", Expr: tableExpr.AsOf.Time, Type: &ast.ConvertType{Type: "datetime"}}
+			continue
+		}
+
+		tableName, ok := tableExpr.Expr.(ast.TableName)
+		if !ok {
+			return nil, fmt.Errorf("FOR SYSTEM_TIME is only supported for tables")
+		}
+		subquery, err := systemTimeSubquery(tableName, tableExpr.AsOf)
+		if err != nil {
+			return nil, err
+		}
+		if tableExpr.As.IsEmpty() {
+			tableExpr.As = tableName.Name
+		}
+		tableExpr.Expr = &ast.Subquery{Select: subquery}
+		tableExpr.AsOf = nil
+		tableExpr.Auth = ast.AuthInformation{AuthType: ast.AuthType_IGNORE}
+	}
+	return stmt, nil
+}
+
+// temporalClause is the kind of a clause that queries a table at or over a point in its history.
+type temporalClause int
+
+const (
+	// asOf is AS OF
+	asOf temporalClause = iota
+	// forSystemTime is FOR SYSTEM_TIME, whose points are times
+	forSystemTime
+	// forVersion is FOR VERSION, whose points are branches or commits like those of AS OF
+	forVersion
+)
+
+// temporalClauses returns the kinds of the temporal clauses of |query|, in the order they appear.
+func temporalClauses(query string) []temporalClause {
+	var clauses []temporalClause
+	tokenizer := ast.NewStringTokenizer(query)
+	var prev, prevPrev int
+	for {
+		tok, _ := tokenizer.Scan()
+		if tok == 0 || tok == ast.LEX_ERROR {
+			break
+		}
+		switch {
+		case tok == ast.FOR_SYSTEM_TIME:
+			clauses = append(clauses, forSystemTime)
+		case tok == ast.FOR_VERSION:
+			clauses = append(clauses, forVersion)
+		case tok == ast.OF && prev == ast.AS && prevPrev != ast.FOR_SYSTEM_TIME && prevPrev != ast.FOR_VERSION:
+			clauses = append(clauses, asOf)
+		}
+		prevPrev, prev = prev, tok
+	}
+	return clauses
+}
+
+// systemTimeSubquery returns the query of the history of |tableName| over the period of |asOf|.
+func systemTimeSubquery(tableName ast.TableName, asOf *ast.AsOf) (ast.SelectStatement, error) {
+	historyTable := tableName
+	historyTable.Name = ast.NewTableIdent(doltdb.DoltHistoryTablePrefix + tableName.Name.String())
+	logTable := tableName
+	logTable.Name = ast.NewTableIdent(doltdb.GetLogTableName())
+
+	query := fmt.Sprintf("SELECT * FROM %s", ast.String(historyTable))
+	if !asOf.All {
+		start, end := ast.String(asOf.Start), ast.String(asOf.End)
+		if !asOf.StartInclusive {
+			// the period begins with the commit in effect at its start
+			start = fmt.Sprintf("COALESCE((SELECT MAX(date) FROM %s WHERE date <= %s), %s)", ast.String(logTable), start, start)
+		}
+		endOp := "<"
+		if asOf.EndInclusive {
+			endOp = "<="
+		}
+		query += fmt.Sprintf(" WHERE %s >= %s AND %s %s %s", CommitDateCol, start, CommitDateCol, endOp, end)
+	}
+
+	stmt, err := ast.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.(ast.SelectStatement), nil
+}
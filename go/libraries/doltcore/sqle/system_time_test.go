@@ -0,0 +1,82 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	ast "github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemTimeParser(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+		err      string
+	}{
+		{
+			query:    "select * from t as of 'main'",
+			expected: "select * from t as of 'main'",
+		},
+		{
+			query:    "select * from t for system_time as of '2020-01-01'",
+			expected: "select * from t as of convert('2020-01-01', datetime)",
+		},
+		{
+			query:    "select * from t for system_time all",
+			expected: "select * from (select * from dolt_history_t) as t",
+		},
+		{
+			query:    "select * from db.t for system_time between '2020-01-01' and '2020-02-01' as x",
+			expected: "select * from (select * from db.dolt_history_t where commit_date >= COALESCE((select MAX(`date`) from db.dolt_log where `date` <= '2020-01-01'), '2020-01-01') and commit_date <= '2020-02-01') as x",
+		},
+		{
+			query:    "select * from t for system_time contained in ('2020-01-01', '2020-02-01')",
+			expected: "select * from (select * from dolt_history_t where commit_date >= '2020-01-01' and commit_date <= '2020-02-01') as t",
+		},
+		{
+			query:    "select * from t for system_time as of '2020-01-01' join u as of 'main'",
+			expected: "select * from t as of convert('2020-01-01', datetime) join u as of 'main'",
+		},
+		{
+			query:    "select * from t for version between 'v1' and 'v2'",
+			expected: "select * from t for system_time between 'v1' and 'v2'",
+		},
+		{
+			query:    "select * from t for version from 'v1' to 'v2' join u for system_time from '2020-01-01' to '2020-02-01'",
+			expected: "select * from t for system_time from 'v1' to 'v2' join (select * from dolt_history_u where commit_date >= COALESCE((select MAX(`date`) from dolt_log where `date` <= '2020-01-01'), '2020-01-01') and commit_date < '2020-02-01') as u",
+		},
+		{
+			query:    "select * from t for version as of 'main' join u for system_time as of '2020-01-01'",
+			expected: "select * from t as of 'main' join u as of convert('2020-01-01', datetime)",
+		},
+	}
+
+	parser := NewDoltParser(sql.GlobalParser)
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := parser.ParseSimple(test.query)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ast.String(stmt))
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
@@ -110,6 +111,14 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 		Default:           int8(0),
 	},
+	&sql.MysqlSystemVariable{ // If non-zero, commits wait while asynchronous replication lags by more seconds
+		Name:              dsess.AsyncReplicationMaxLagSecs,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.AsyncReplicationMaxLagSecs, 0, 24*60*60, false),
+		Default:           int64(0),
+	},
 	&sql.MysqlSystemVariable{ // If true, causes a Dolt commit to occur when you commit a transaction.
 		Name:              dsess.DoltCommitOnTransactionCommit,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -204,6 +213,13 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType(dsess.ShowSystemTables),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.SampleDataLength,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType(dsess.SampleDataLength),
+		Default: int8(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    "dolt_dont_merge_json",
 		Dynamic: true,
@@ -253,6 +269,85 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemStringType(dsess.DoltStatsBranches),
 		Default: "",
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltStatsColumnGroups,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.DoltStatsColumnGroups),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:          dsess.DoltBackgroundMaxJobs,
+		Dynamic:       true,
+		Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:          types.NewSystemIntType(dsess.DoltBackgroundMaxJobs, 0, math.MaxInt32, false),
+		Default:       int64(background.DefaultConfig().MaxJobs),
+		NotifyChanged: setBackgroundMaxJobs,
+	},
+	&sql.MysqlSystemVariable{
+		Name:          dsess.DoltBackgroundCPULimit,
+		Dynamic:       true,
+		Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:          types.NewSystemIntType(dsess.DoltBackgroundCPULimit, 1, 100, false),
+		Default:       int64(background.DefaultConfig().CPULimit),
+		NotifyChanged: setBackgroundCPULimit,
+	},
+	&sql.MysqlSystemVariable{
+		Name:          dsess.DoltBackgroundIOLimit,
+		Dynamic:       true,
+		Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:          types.NewSystemIntType(dsess.DoltBackgroundIOLimit, 0, math.MaxInt64, false),
+		Default:       int64(0),
+		NotifyChanged: setBackgroundIOLimit,
+	},
+	&sql.MysqlSystemVariable{
+		Name:          dsess.DoltBackgroundPriorities,
+		Dynamic:       true,
+		Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:          types.NewSystemStringType(dsess.DoltBackgroundPriorities),
+		Default:       "",
+		NotifyChanged: setBackgroundPriorities,
+	},
+	&sql.MysqlSystemVariable{
+		Name:          dsess.DoltDatabaseQuotas,
+		Dynamic:       true,
+		Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:          types.NewSystemStringType(dsess.DoltDatabaseQuotas),
+		Default:       "",
+		NotifyChanged: validateDatabaseQuotas,
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.DoltPessimisticLocking,
+		Dynamic:           true,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		SetVarHintApplies: false,
+		Type:              types.NewSystemBoolType(dsess.DoltPessimisticLocking),
+		Default:           int8(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.DoltAutoIncrementBatchSize,
+		Dynamic:           true,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.DoltAutoIncrementBatchSize, 1, math.MaxInt32, false),
+		Default:           int64(1),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DoltBinlogReplicaCommitInterval,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.DoltBinlogReplicaCommitInterval, 0, math.MaxInt32, false),
+		Default: int64(0),
+	},
+	// Replaces the engine's definition, which only allows the value 1 since the engine has no row locks
+	&sql.MysqlSystemVariable{
+		Name:              "innodb_lock_wait_timeout",
+		Dynamic:           true,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType("innodb_lock_wait_timeout", 1, 1073741824, false),
+		Default:           int64(50),
+	},
 }
 
 func AddDoltSystemVariables() {
@@ -337,6 +432,14 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 			Default:           int8(0),
 		},
+		&sql.MysqlSystemVariable{ // If non-zero, commits wait while asynchronous replication lags by more seconds
+			Name:              dsess.AsyncReplicationMaxLagSecs,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.AsyncReplicationMaxLagSecs, 0, 24*60*60, false),
+			Default:           int64(0),
+		},
 		&sql.MysqlSystemVariable{ // If true, causes a Dolt commit to occur when you commit a transaction.
 			Name:              dsess.DoltCommitOnTransactionCommit,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -431,6 +534,13 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType(dsess.ShowSystemTables),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.SampleDataLength,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType(dsess.SampleDataLength),
+			Default: int8(0),
+		},
 		&sql.MysqlSystemVariable{
 			Name:    "dolt_dont_merge_json",
 			Dynamic: true,
@@ -480,6 +590,85 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemStringType(dsess.DoltStatsBranches),
 			Default: "",
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltStatsColumnGroups,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.DoltStatsColumnGroups),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:          dsess.DoltBackgroundMaxJobs,
+			Dynamic:       true,
+			Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:          types.NewSystemIntType(dsess.DoltBackgroundMaxJobs, 0, math.MaxInt32, false),
+			Default:       int64(background.DefaultConfig().MaxJobs),
+			NotifyChanged: setBackgroundMaxJobs,
+		},
+		&sql.MysqlSystemVariable{
+			Name:          dsess.DoltBackgroundCPULimit,
+			Dynamic:       true,
+			Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:          types.NewSystemIntType(dsess.DoltBackgroundCPULimit, 1, 100, false),
+			Default:       int64(background.DefaultConfig().CPULimit),
+			NotifyChanged: setBackgroundCPULimit,
+		},
+		&sql.MysqlSystemVariable{
+			Name:          dsess.DoltBackgroundIOLimit,
+			Dynamic:       true,
+			Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:          types.NewSystemIntType(dsess.DoltBackgroundIOLimit, 0, math.MaxInt64, false),
+			Default:       int64(0),
+			NotifyChanged: setBackgroundIOLimit,
+		},
+		&sql.MysqlSystemVariable{
+			Name:          dsess.DoltBackgroundPriorities,
+			Dynamic:       true,
+			Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:          types.NewSystemStringType(dsess.DoltBackgroundPriorities),
+			Default:       "",
+			NotifyChanged: setBackgroundPriorities,
+		},
+		&sql.MysqlSystemVariable{
+			Name:          dsess.DoltDatabaseQuotas,
+			Dynamic:       true,
+			Scope:         sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:          types.NewSystemStringType(dsess.DoltDatabaseQuotas),
+			Default:       "",
+			NotifyChanged: validateDatabaseQuotas,
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.DoltPessimisticLocking,
+			Dynamic:           true,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.DoltPessimisticLocking),
+			Default:           int8(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.DoltAutoIncrementBatchSize,
+			Dynamic:           true,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.DoltAutoIncrementBatchSize, 1, math.MaxInt32, false),
+			Default:           int64(1),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DoltBinlogReplicaCommitInterval,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.DoltBinlogReplicaCommitInterval, 0, math.MaxInt32, false),
+			Default: int64(0),
+		},
+		// Replaces the engine's definition, which only allows the value 1 since the engine has no row locks
+		&sql.MysqlSystemVariable{
+			Name:              "innodb_lock_wait_timeout",
+			Dynamic:           true,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType("innodb_lock_wait_timeout", 1, 1073741824, false),
+			Default:           int64(50),
+		},
 		&sql.MysqlSystemVariable{
 			Name:    "signingkey",
 			Dynamic: true,
@@ -505,3 +694,47 @@ func ReadReplicaForcePull() bool {
 	}
 	return forcePull == dsess.SysVarTrue
 }
+
+// setBackgroundMaxJobs applies the dolt_background_max_jobs global to the background job manager.
+func setBackgroundMaxJobs(_ sql.SystemVariableScope, v sql.SystemVarValue) error {
+	background.Default().UpdateConfig(func(cfg *background.Config) {
+		cfg.MaxJobs = int(v.Val.(int64))
+	})
+	return nil
+}
+
+// setBackgroundCPULimit applies the dolt_background_cpu_limit global to the background job manager.
+func setBackgroundCPULimit(_ sql.SystemVariableScope, v sql.SystemVarValue) error {
+	background.Default().UpdateConfig(func(cfg *background.Config) {
+		cfg.CPULimit = int(v.Val.(int64))
+	})
+	return nil
+}
+
+// setBackgroundIOLimit applies the dolt_background_io_limit global to the background job manager.
+func setBackgroundIOLimit(_ sql.SystemVariableScope, v sql.SystemVarValue) error {
+	background.Default().UpdateConfig(func(cfg *background.Config) {
+		cfg.IOLimit = v.Val.(int64)
+	})
+	return nil
+}
+
+// setBackgroundPriorities applies the dolt_background_priorities global, a list such as "gc=low,stats=high", to the
+// background job manager.
+func setBackgroundPriorities(_ sql.SystemVariableScope, v sql.SystemVarValue) error {
+	priorities, err := background.ParsePriorities(v.Val.(string))
+	if err != nil {
+		return err
+	}
+	background.Default().UpdateConfig(func(cfg *background.Config) {
+		cfg.Priorities = priorities
+	})
+	return nil
+}
+
+// validateDatabaseQuotas validates the dolt_database_quotas global, a list such as "db1=10GB,*=1GB". Quotas are read
+// from the global when transactions commit.
+func validateDatabaseQuotas(_ sql.SystemVariableScope, v sql.SystemVarValue) error {
+	_, err := dsess.ParseQuotas(v.Val.(string))
+	return err
+}
@@ -47,6 +47,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -96,6 +98,12 @@ type DoltTable struct {
 
 	// overriddenSchema is set when the @@dolt_override_schema system var is in use
 	overriddenSchema schema.Schema
+
+	// hideInvisibleIndexes is set when the table is read or written by a query, see hideInvisibleIndexes
+	hideInvisibleIndexes bool
+
+	// zoneMapFilters are the filters of the table's scans that zone maps can skip rows for, see pushdownZoneMapFilters
+	zoneMapFilters []zoneMapFilter
 }
 
 func (t *DoltTable) TableName() doltdb.TableName {
@@ -159,7 +167,7 @@ func (t *DoltTable) LookupForExpressions(ctx *sql.Context, exprs ...sql.Expressi
 
 	lookups, ok := dbState.SessionCache().GetCachedStrictLookup(schKey)
 	if !ok {
-		indexes, err := t.GetIndexes(ctx)
+		indexes, err := t.getIndexes(ctx)
 		if err != nil {
 			return sql.IndexLookup{}, nil, nil, false, err
 		}
@@ -168,6 +176,9 @@ func (t *DoltTable) LookupForExpressions(ctx *sql.Context, exprs ...sql.Expressi
 	}
 
 	for _, lookup := range lookups {
+		if t.hideInvisibleIndexes && isInvisibleIndex(lookup.Idx) {
+			continue
+		}
 		if lookup.Cols.Intersection(colset).Len() == lookup.Cols.Len() {
 			// (1) assign lookup columns to range expressions in the appropriate
 			// order for the given lookup.
@@ -376,6 +387,21 @@ func (t *DoltTable) getRoot(ctx *sql.Context) (doltdb.RootValue, error) {
 
 // GetIndexes implements sql.IndexedTable
 func (t *DoltTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	indexes, err := t.getIndexes(ctx)
+	if err != nil || !t.hideInvisibleIndexes {
+		return indexes, err
+	}
+	visible := make([]sql.Index, 0, len(indexes))
+	for _, idx := range indexes {
+		if !isInvisibleIndex(idx) {
+			visible = append(visible, idx)
+		}
+	}
+	return visible, nil
+}
+
+// getIndexes returns all the indexes of the table, including invisible indexes.
+func (t *DoltTable) getIndexes(ctx *sql.Context) ([]sql.Index, error) {
 	// If a schema override is in place, we can't trust that the indexes stored with the data
 	// will match up to the overridden schema, so we disable indexes. We could improve this by
 	// adding schema mapping for the indexes.
@@ -477,21 +503,6 @@ func (t *DoltTable) DebugString() string {
 	return p.String()
 }
 
-// NumRows returns the unfiltered count of rows contained in the table
-func (t *DoltTable) numRows(ctx *sql.Context) (uint64, error) {
-	table, err := t.DoltTable(ctx)
-	if err != nil {
-		return 0, err
-	}
-
-	m, err := table.GetRowData(ctx)
-	if err != nil {
-		return 0, err
-	}
-
-	return m.Count()
-}
-
 // Format returns the NomsBinFormat for the underlying table
 func (t *DoltTable) Format() *types.NomsBinFormat {
 	return t.nbf
@@ -570,20 +581,54 @@ func (t *DoltTable) IsTemporary() bool {
 	return false
 }
 
-// DataLength implements the sql.StatisticsTable interface.
+// DataLength implements the sql.StatisticsTable interface. By default it's an estimate, the row count times the
+// average row width of the schema, rather than the size of the table in storage. When @@dolt_sample_data_length is
+// set, it's sampled from the chunks of the table's primary index instead, see doltdb.DoltDB.SampleDataLength.
 func (t *DoltTable) DataLength(ctx *sql.Context) (uint64, error) {
-	numBytesPerRow := schema.SchemaAvgLength(t.Schema())
-	numRows, err := t.numRows(ctx)
+	table, err := t.DoltTable(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := table.GetRowData(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sample, err := ctx.GetSessionVariable(ctx, dsess.SampleDataLength)
 	if err != nil {
 		return 0, err
 	}
-	return numBytesPerRow * numRows, nil
+	if sample.(int8) == 1 && types.IsFormat_DOLT(rows.Format()) {
+		sess := dsess.DSessFromSess(ctx.Session)
+		if ddb, ok := sess.GetDoltDB(ctx, t.db.RevisionQualifiedName()); ok {
+			return ddb.SampleDataLength(ctx, rows)
+		}
+	}
+
+	cnt, err := rows.Count()
+	if err != nil {
+		return 0, err
+	}
+	return cnt * schema.SchemaAvgLength(t.Schema()), nil
 }
 
-// RowCount implements the sql.StatisticsTable interface.
+// RowCount implements the sql.StatisticsTable interface. The row count is read from the root of the table's row
+// data in constant time. It's exact for tables with primary keys, which lets COUNT(*) without a predicate skip
+// reading the table. Keyless rows are counted once however many duplicates they have, so the count is approximate.
 func (t *DoltTable) RowCount(ctx *sql.Context) (uint64, bool, error) {
-	rows, err := t.numRows(ctx)
-	return rows, true, err
+	table, err := t.DoltTable(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	rows, err := table.GetRowData(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	cnt, err := rows.Count()
+	if err != nil {
+		return 0, false, err
+	}
+	return cnt, !schema.IsKeyless(t.sch), nil
 }
 
 func (t *DoltTable) PrimaryKeySchema() sql.PrimaryKeySchema {
@@ -623,10 +668,18 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 		}
 	}
 
+	if p, ok := partition.(doltTablePartition); ok && t.overriddenSchema == nil {
+		p.zoneMapFilters = t.zoneMapFilters
+		partition = p
+	}
+
 	originalRowIter, err := partitionRows(ctx, table, projCols, partition)
 	if err != nil {
 		return originalRowIter, err
 	}
+	if originalRowIter, err = t.withLockedReads(ctx, originalRowIter); err != nil {
+		return nil, err
+	}
 
 	if t.overriddenSchema != nil {
 		return newMappingRowIter(ctx, t, originalRowIter)
@@ -704,7 +757,11 @@ func (t *WritableDoltTable) Inserter(ctx *sql.Context) sql.RowInserter {
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
-	return te
+	te, err = t.withAuditColumns(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return t.withRowLocks(ctx, te, false)
 }
 
 func (t *WritableDoltTable) getTableEditor(ctx *sql.Context) (ed dsess.TableWriter, err error) {
@@ -964,7 +1021,11 @@ func (t *WritableDoltTable) Deleter(ctx *sql.Context) sql.RowDeleter {
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
-	return te
+	te, err = t.withAppendOnly(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return t.withRowLocks(ctx, te, true)
 }
 
 // Replacer implements sql.ReplaceableTable
@@ -976,7 +1037,15 @@ func (t *WritableDoltTable) Replacer(ctx *sql.Context) sql.RowReplacer {
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
-	return te
+	te, err = t.withAuditColumns(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	te, err = t.withAppendOnly(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return t.withRowLocks(ctx, te, false)
 }
 
 // Truncate implements sql.TruncateableTable
@@ -984,6 +1053,9 @@ func (t *WritableDoltTable) Truncate(ctx *sql.Context) (int, error) {
 	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
 		return 0, err
 	}
+	if err := t.checkAppendOnly(ctx); err != nil {
+		return 0, err
+	}
 	table, err := t.DoltTable.DoltTable(ctx)
 	if err != nil {
 		return 0, err
@@ -1130,7 +1202,15 @@ func (t *WritableDoltTable) Updater(ctx *sql.Context) sql.RowUpdater {
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
-	return te
+	te, err = t.withAuditColumns(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	te, err = t.withAppendOnly(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return t.withRowLocks(ctx, te, true)
 }
 
 // AutoIncrementSetter implements sql.AutoIncrementTable
@@ -1239,7 +1319,16 @@ func (t *WritableDoltTable) GetForeignKeyEditor(ctx *sql.Context) sql.ForeignKey
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
-	return te
+	// foreign key cascades update and delete the rows of child tables
+	te, err = t.withAuditColumns(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	te, err = t.withAppendOnly(ctx, te)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	return t.withRowLocks(ctx, te, false)
 }
 
 // GetDeclaredForeignKeys implements sql.ForeignKeyTable
@@ -1477,6 +1566,9 @@ type doltTablePartition struct {
 	start, end uint64
 
 	rowData durable.Index
+
+	// zoneMapFilters are the filters that the zone maps of rowData can skip rows for
+	zoneMapFilters []zoneMapFilter
 }
 
 func partitionsFromRows(ctx context.Context, rows durable.Index) ([]doltTablePartition, error) {
@@ -1771,6 +1863,8 @@ func (t *AlterableDoltTable) RewriteInserter(
 		return nil, err
 	}
 	newSch = schema.CopyChecksConstraints(oldSch, newSch)
+	newSch.SetTargetNodeSize(oldSch.GetTargetNodeSize())
+	newSch.SetStorageLayout(oldSch.GetStorageLayout())
 
 	isModifyColumn := newColumn != nil && oldColumn != nil
 	if isColumnDrop(oldSchema, newSchema) {
@@ -2032,6 +2126,7 @@ func modifyFulltextIndexForColumnDrop(index schema.Index, newSch schema.Schema,
 			IsSpatial:          false,
 			IsFullText:         true,
 			IsUserDefined:      index.IsUserDefined(),
+			IsInvisible:        index.IsInvisible(),
 			Comment:            index.Comment(),
 			FullTextProperties: index.FullTextProperties(),
 		})
@@ -2086,6 +2181,7 @@ func modifyIndexesForTableRewrite(ctx *sql.Context, oldSch schema.Schema, oldCol
 				IsSpatial:          index.IsSpatial(),
 				IsFullText:         index.IsFullText(),
 				IsUserDefined:      index.IsUserDefined(),
+				IsInvisible:        index.IsInvisible(),
 				Comment:            index.Comment(),
 				FullTextProperties: index.FullTextProperties(),
 			})
@@ -2535,6 +2631,232 @@ func (t *AlterableDoltTable) RenameIndex(ctx *sql.Context, fromIndexName string,
 	return t.updateFromRoot(ctx, newRoot)
 }
 
+// SetIndexInvisible marks the named index as invisible to (or visible to) the query planner. Invisible indexes are
+// still maintained and versioned along with the rest of the table. Indexes which are used by foreign keys, and
+// Full-Text and spatial indexes, can't be made invisible.
+func (t *AlterableDoltTable) SetIndexInvisible(ctx *sql.Context, indexName string, invisible bool) error {
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	idx, ok := t.sch.Indexes().GetByNameCaseInsensitive(indexName)
+	if !ok {
+		if strings.EqualFold(indexName, "primary") {
+			return fmt.Errorf("a primary key index can't be invisible")
+		}
+		return fmt.Errorf("`%s` does not exist as an index for this table", indexName)
+	}
+	if invisible {
+		if idx.IsFullText() || idx.IsSpatial() {
+			return fmt.Errorf("index `%s` can't be invisible, Full-Text and spatial indexes are always visible", idx.Name())
+		}
+		root, err := t.getRoot(ctx)
+		if err != nil {
+			return err
+		}
+		fkc, err := root.GetForeignKeyCollection(ctx)
+		if err != nil {
+			return err
+		}
+		declaredFks, referencedByFks := fkc.KeysForTable(t.TableName())
+		for _, fk := range declaredFks {
+			if strings.EqualFold(fk.TableIndex, idx.Name()) {
+				return fmt.Errorf("index `%s` can't be invisible, it's used by foreign key `%s`", idx.Name(), fk.Name)
+			}
+		}
+		for _, fk := range referencedByFks {
+			if strings.EqualFold(fk.ReferencedTableIndex, idx.Name()) {
+				return fmt.Errorf("index `%s` can't be invisible, it's used by foreign key `%s`", idx.Name(), fk.Name)
+			}
+		}
+	}
+
+	// the table's schema is only changed once the new schema has been written, by updateFromRoot
+	sch := t.sch.Copy()
+	if _, err := sch.Indexes().SetIndexInvisible(idx.Name(), invisible); err != nil {
+		return err
+	}
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	newTable, err := table.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), newTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.setRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
+// SetTargetNodeSize sets the target size, in bytes, of the leaf nodes of the table's primary index, and rechunks the
+// table's rows. A size of zero chooses the size from the table's row width. New tables use the default size, so the
+// size chosen from the row width is only used by tables that opt in to it.
+func (t *AlterableDoltTable) SetTargetNodeSize(ctx *sql.Context, size uint32) error {
+	if !types.IsFormat_DOLT(t.Format()) {
+		return fmt.Errorf("KEY_BLOCK_SIZE is not supported on storage format %s. Run `dolt migrate` to upgrade to the latest storage format.", t.Format().VersionString())
+	}
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+
+	// the table's schema is only changed once the new schema has been written, by updateFromRoot
+	sch := t.sch.Copy()
+	if size == 0 {
+		size = doltdb.TargetNodeSizeForSchema(sch)
+	}
+	// a schema without a size would keep the table's size, see durable.Table.SetSchema
+	sch.SetTargetNodeSize(tree.EffectiveTargetNodeSize(size))
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	newTable, err := table.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), newTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.setRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
+// SetStorageLayout sets the layout of the rows of the table's primary index, and rewrites the table's rows in it. Tables
+// stored column-wise store each column of the rows of a leaf node in a column segment of its own, so that scans reading
+// some of the columns of a wide table only read their segments.
+func (t *AlterableDoltTable) SetStorageLayout(ctx *sql.Context, layout schema.StorageLayout) error {
+	if !types.IsFormat_DOLT(t.Format()) {
+		return fmt.Errorf("ENGINE is not supported on storage format %s. Run `dolt migrate` to upgrade to the latest storage format.", t.Format().VersionString())
+	}
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+
+	// the table's schema is only changed once the new schema has been written, by updateFromRoot
+	sch := t.sch.Copy()
+	// a schema without a layout would keep the table's layout, see durable.Table.SetSchema
+	sch.SetStorageLayout(layout)
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	newTable, err := table.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), newTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.setRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
+// SetZoneMapColumns sets the columns whose min and max values are kept in the nodes of the table's primary index, and
+// rechunks the table's rows. Range scans of the table skip the nodes whose zone maps exclude a filter on the columns.
+// No columns removes the table's zone maps.
+func (t *AlterableDoltTable) SetZoneMapColumns(ctx *sql.Context, columns []string) error {
+	if !types.IsFormat_DOLT(t.Format()) {
+		return fmt.Errorf("zone maps are not supported on storage format %s. Run `dolt migrate` to upgrade to the latest storage format.", t.Format().VersionString())
+	}
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	if schema.IsKeyless(t.sch) {
+		return fmt.Errorf("zone maps are not supported on keyless table %s", t.Name())
+	}
+	if len(columns) > message.MaxZoneMapFields {
+		return fmt.Errorf("zone maps can be kept for at most %d columns", message.MaxZoneMapFields)
+	}
+
+	// the table's schema is only changed once the new schema has been written, by updateFromRoot
+	sch := t.sch.Copy()
+	tags := make([]uint64, 0, len(columns))
+	for _, name := range columns {
+		col, ok := sch.GetNonPKCols().GetByNameCaseInsensitive(name)
+		if !ok {
+			if _, ok = sch.GetPKCols().GetByNameCaseInsensitive(name); ok {
+				return fmt.Errorf("zone maps are not supported on primary key column %s", name)
+			}
+			return sql.ErrTableColumnNotFound.New(t.Name(), name)
+		}
+		sch.SetZoneMapColumns([]uint64{col.Tag})
+		if len(durable.ZoneMapFields(sch)) == 0 {
+			return fmt.Errorf("zone maps are not supported on column %s of type %s", col.Name, col.TypeInfo.ToSqlType().String())
+		}
+		for _, tag := range tags {
+			if tag == col.Tag {
+				return fmt.Errorf("duplicate zone map column %s", col.Name)
+			}
+		}
+		tags = append(tags, col.Tag)
+	}
+	// an empty list removes the zone maps, where a nil list would keep them, see durable.Table.SetSchema
+	sch.SetZoneMapColumns(tags)
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	newTable, err := table.UpdateSchema(ctx, sch)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), newTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.setRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
 // CreateFulltextIndex implements fulltext.IndexAlterableTable
 func (t *AlterableDoltTable) CreateFulltextIndex(ctx *sql.Context, idx sql.IndexDef, keyCols fulltext.KeyColumns, tableNames fulltext.IndexTableNames) error {
 	if !types.IsFormat_DOLT(t.Format()) {
@@ -23,6 +23,7 @@ import (
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
@@ -133,6 +134,11 @@ func NewTestEngine(dEnv *env.DoltEnv, ctx context.Context, db dsess.SqlDatabase)
 	}
 
 	engine := sqle.NewDefault(pro)
+	engine.Analyzer.Catalog.InfoSchema = NewInformationSchemaDatabase()
+	AddInvisibleIndexesRule(engine.Analyzer)
+	AddZoneMapFiltersRule(engine.Analyzer)
+	AddApproxCountDistinctRule(engine.Analyzer)
+	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(createTableBuilder{})
 
 	sqlCtx := NewTestSQLCtxWithProvider(ctx, pro, nil)
 	sqlCtx.SetCurrentDatabase(db.Name())
@@ -0,0 +1,77 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
+)
+
+// autoIncrementBatch hands out the auto increment values of a table writer. When the session's
+// dolt_auto_increment_batch_size is greater than 1, values are reserved from the tracker that many at a time, so that
+// bulk loads writing many rows, possibly in parallel, don't take the tracker's lock for every row. Values reserved but
+// not used by the writer, such as those left at the end of a statement, are skipped, leaving gaps in the sequence.
+type autoIncrementBatch struct {
+	// next is the next value to hand out, and end is the first value after those reserved
+	next, end uint64
+}
+
+// nextValue returns the next auto increment value for the table named using the provided value from an insert, as
+// AutoIncrementTracker.Next.
+func (b *autoIncrementBatch) nextValue(ctx *sql.Context, tracker globalstate.AutoIncrementTracker, tableName string, insertVal interface{}) (uint64, error) {
+	size := autoIncrementBatchSize(ctx)
+	if size <= 1 {
+		return tracker.Next(ctx, tableName, insertVal)
+	}
+
+	given, err := tracker.CoerceAutoIncrementValue(insertVal)
+	if err != nil {
+		return 0, err
+	}
+	if given != 0 {
+		// values up to the one given must not be handed out after it, as they wouldn't be without a batch
+		if given >= b.next && given < b.end {
+			b.next = given + 1
+		}
+		return tracker.Next(ctx, tableName, insertVal)
+	}
+
+	if b.next == b.end {
+		first, err := tracker.Reserve(ctx, tableName, size)
+		if err != nil {
+			return 0, err
+		}
+		b.next, b.end = first, first+size
+	}
+	val := b.next
+	b.next++
+	return val, nil
+}
+
+// autoIncrementBatchSize returns the number of auto increment values to reserve at a time in the session of |ctx|.
+func autoIncrementBatchSize(ctx *sql.Context) uint64 {
+	val, err := ctx.GetSessionVariable(ctx, dsess.DoltAutoIncrementBatchSize)
+	if err != nil {
+		// the variable isn't defined, as when dolt's system variables aren't loaded
+		return 1
+	}
+	size, ok := val.(int64)
+	if !ok || size < 1 {
+		return 1
+	}
+	return uint64(size)
+}
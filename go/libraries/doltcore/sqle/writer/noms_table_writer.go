@@ -54,6 +54,7 @@ type nomsTableWriter struct {
 	flusher     dsess.WriteSessionFlusher
 
 	autoInc                globalstate.AutoIncrementTracker
+	autoIncBatch           autoIncrementBatch
 	nextAutoIncrementValue map[string]uint64
 
 	setter         dsess.SessionRootSetter
@@ -129,7 +130,7 @@ func (te *nomsTableWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.R
 }
 
 func (te *nomsTableWriter) GetNextAutoIncrementValue(ctx *sql.Context, insertVal interface{}) (uint64, error) {
-	return te.autoInc.Next(te.tableName, insertVal)
+	return te.autoIncBatch.nextValue(ctx, te.autoInc, te.tableName, insertVal)
 }
 
 func (te *nomsTableWriter) SetAutoIncrementValue(ctx *sql.Context, val uint64) error {
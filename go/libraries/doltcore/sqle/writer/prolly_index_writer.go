@@ -24,6 +24,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -36,7 +37,13 @@ func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, schState *dses
 		return prollyIndexWriter{}, err
 	}
 
-	m := durable.ProllyMapFromIndex(idx)
+	m, err := durable.ProllyMapFromIndex(idx).
+		WithTargetNodeSize(schState.DoltSchema.GetTargetNodeSize()).
+		WithZoneMapFields(durable.ZoneMapFields(schState.DoltSchema)...)
+	if err != nil {
+		return prollyIndexWriter{}, err
+	}
+	m = m.WithColumnar(schState.DoltSchema.GetStorageLayout() == schema.ColumnarStorageLayout)
 
 	keyDesc, valDesc := m.Descriptors()
 
@@ -55,7 +62,7 @@ func getPrimaryKeylessProllyWriter(ctx context.Context, t *doltdb.Table, schStat
 		return prollyKeylessWriter{}, err
 	}
 
-	m := durable.ProllyMapFromIndex(idx)
+	m := durable.ProllyMapFromIndex(idx).WithColumnar(schState.DoltSchema.GetStorageLayout() == schema.ColumnarStorageLayout)
 
 	keyDesc, valDesc := m.Descriptors()
 
@@ -45,6 +45,7 @@ type prollyTableWriter struct {
 
 	aiCol                  schema.Column
 	aiTracker              globalstate.AutoIncrementTracker
+	aiBatch                autoIncrementBatch
 	nextAutoIncrementValue map[string]uint64
 	setAutoIncrement       bool
 
@@ -164,7 +165,7 @@ func (w *prollyTableWriter) Insert(ctx *sql.Context, sqlRow sql.Row) (err error)
 	w.setAutoIncrement = true
 
 	// TODO: need schema name in ai tracker
-	w.aiTracker.Next(w.tableName.Name, sqlRow)
+	w.aiTracker.Next(ctx, w.tableName.Name, sqlRow)
 	return nil
 }
 
@@ -201,7 +202,7 @@ func (w *prollyTableWriter) Update(ctx *sql.Context, oldRow sql.Row, newRow sql.
 
 // GetNextAutoIncrementValue implements TableWriter.
 func (w *prollyTableWriter) GetNextAutoIncrementValue(ctx *sql.Context, insertVal interface{}) (uint64, error) {
-	return w.aiTracker.Next(w.tableName.Name, insertVal)
+	return w.aiBatch.nextValue(ctx, w.aiTracker, w.tableName.Name, insertVal)
 }
 
 // SetAutoIncrementValue implements AutoIncrementSetter.
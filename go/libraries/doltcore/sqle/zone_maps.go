@@ -0,0 +1,365 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// pushdownZoneMapFiltersId is the id of the pushdownZoneMapFilters rule. The ids of go-mysql-server's rules are never
+// negative.
+const pushdownZoneMapFiltersId analyzer.RuleId = -2
+
+// AddZoneMapFiltersRule adds the rule pushing filters into the scans of tables with zone maps, see
+// pushdownZoneMapFilters, to the rules |a| runs after it has pushed filters down to the tables they filter.
+func AddZoneMapFiltersRule(a *analyzer.Analyzer) {
+	for _, batch := range a.Batches {
+		if batch.Desc == "post-analyzer" {
+			batch.Rules = append(batch.Rules, analyzer.Rule{Id: pushdownZoneMapFiltersId, Apply: pushdownZoneMapFilters})
+			return
+		}
+	}
+}
+
+// zoneMapFilter is a comparison of a zone map column with a literal, see pushdownZoneMapFilters.
+type zoneMapFilter struct {
+	tag   uint64
+	op    zoneMapOp
+	value interface{}
+}
+
+type zoneMapOp uint8
+
+const (
+	zoneMapEq zoneMapOp = iota
+	zoneMapLt
+	zoneMapLte
+	zoneMapGt
+	zoneMapGte
+)
+
+// flip returns the operator of the comparison with its operands swapped.
+func (op zoneMapOp) flip() zoneMapOp {
+	switch op {
+	case zoneMapLt:
+		return zoneMapGt
+	case zoneMapLte:
+		return zoneMapGte
+	case zoneMapGt:
+		return zoneMapLt
+	case zoneMapGte:
+		return zoneMapLte
+	default:
+		return op
+	}
+}
+
+// pushdownZoneMapFilters is an analyzer rule which hands the comparisons of zone map columns with literals in the
+// filters of full table scans to the Dolt tables they scan, which skip the nodes of their primary index whose zone maps
+// show they hold no matching rows, see durable.ZoneMapFields. The filters are kept, so rows of the nodes that are read
+// are still filtered by the query.
+func pushdownZoneMapFilters(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, scope *plan.Scope, sel analyzer.RuleSelector, qFlags *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	if plan.IsNoRowNode(n) {
+		return n, transform.SameTree, nil
+	}
+	return transform.NodeWithOpaque(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		f, ok := n.(*plan.Filter)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		child, alias := f.Child, ""
+		if ta, ok := child.(*plan.TableAlias); ok {
+			child, alias = ta.Child, ta.Name()
+		}
+		rt, ok := child.(*plan.ResolvedTable)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		dt := doltTableOf(rt.Table)
+		if dt == nil || len(dt.sch.GetZoneMapColumns()) == 0 || dt.overriddenSchema != nil || len(dt.zoneMapFilters) > 0 {
+			return n, transform.SameTree, nil
+		}
+		if alias == "" {
+			alias = rt.Name()
+		}
+		filters := zoneMapFiltersOf(dt.sch, alias, f.Expression)
+		if len(filters) == 0 {
+			return n, transform.SameTree, nil
+		}
+
+		var table sql.Table
+		switch t := rt.Table.(type) {
+		case *AlterableDoltTable:
+			nt := *t
+			nt.DoltTable = t.withZoneMapFilters(filters)
+			table = &nt
+		case *WritableDoltTable:
+			nt := *t
+			nt.DoltTable = t.withZoneMapFilters(filters)
+			table = &nt
+		case *DoltTable:
+			table = t.withZoneMapFilters(filters)
+		}
+		nrt, err := rt.WithTable(table)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		var nc sql.Node = nrt
+		if ta, ok := f.Child.(*plan.TableAlias); ok {
+			if nc, err = ta.WithChildren(nrt); err != nil {
+				return nil, transform.SameTree, err
+			}
+		}
+		nf, err := f.WithChildren(nc)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		return nf, transform.NewTree, nil
+	})
+}
+
+// doltTableOf returns the DoltTable of |t|, or nil if it isn't a Dolt table.
+func doltTableOf(t sql.Table) *DoltTable {
+	switch t := t.(type) {
+	case *AlterableDoltTable:
+		return t.DoltTable
+	case *WritableDoltTable:
+		return t.DoltTable
+	case *DoltTable:
+		return t
+	default:
+		return nil
+	}
+}
+
+// withZoneMapFilters returns a copy of the table whose scans skip the nodes excluded by |filters|.
+func (t *DoltTable) withZoneMapFilters(filters []zoneMapFilter) *DoltTable {
+	nt := *t
+	nt.zoneMapFilters = filters
+	return &nt
+}
+
+// zoneMapFiltersOf returns the comparisons of the zone map columns of |sch| with non-NULL literals that are conjuncts
+// of |filter|, which filters the table named |tableName|.
+func zoneMapFiltersOf(sch schema.Schema, tableName string, filter sql.Expression) []zoneMapFilter {
+	zoned := make(map[uint64]bool)
+	for _, tag := range sch.GetZoneMapColumns() {
+		zoned[tag] = true
+	}
+	column := func(e sql.Expression) (uint64, bool) {
+		gf, ok := e.(*expression.GetField)
+		if !ok || !strings.EqualFold(gf.Table(), tableName) {
+			return 0, false
+		}
+		col, ok := sch.GetAllCols().GetByNameCaseInsensitive(gf.Name())
+		if !ok || !zoned[col.Tag] {
+			return 0, false
+		}
+		return col.Tag, true
+	}
+	literal := func(e sql.Expression) (interface{}, bool) {
+		lit, ok := e.(*expression.Literal)
+		if !ok || lit.Value() == nil {
+			return nil, false
+		}
+		return lit.Value(), true
+	}
+
+	var filters []zoneMapFilter
+	for _, e := range expression.SplitConjunction(filter) {
+		var op zoneMapOp
+		switch e.(type) {
+		case *expression.Equals:
+			op = zoneMapEq
+		case *expression.LessThan:
+			op = zoneMapLt
+		case *expression.LessThanOrEqual:
+			op = zoneMapLte
+		case *expression.GreaterThan:
+			op = zoneMapGt
+		case *expression.GreaterThanOrEqual:
+			op = zoneMapGte
+		case *expression.Between:
+			b := e.(*expression.Between)
+			tag, ok := column(b.Val)
+			lower, lok := literal(b.Lower)
+			upper, uok := literal(b.Upper)
+			if ok && lok && uok {
+				filters = append(filters, zoneMapFilter{tag, zoneMapGte, lower}, zoneMapFilter{tag, zoneMapLte, upper})
+			}
+			continue
+		default:
+			continue
+		}
+		cmp := e.(expression.Comparer)
+		if tag, ok := column(cmp.Left()); ok {
+			if v, ok := literal(cmp.Right()); ok {
+				filters = append(filters, zoneMapFilter{tag, op, v})
+			}
+		} else if tag, ok := column(cmp.Right()); ok {
+			if v, ok := literal(cmp.Left()); ok {
+				filters = append(filters, zoneMapFilter{tag, op.flip(), v})
+			}
+		}
+	}
+	return filters
+}
+
+// zoneMapRanges returns the value ranges of the rows of |rows|, a primary index of a table with schema |sch|, that
+// can match |filters|. Filters on columns without zone maps, or whose literal can't be compared exactly with the values
+// of the column, are left to the query.
+func zoneMapRanges(ctx context.Context, sch schema.Schema, rows prolly.Map, filters []zoneMapFilter) ([]prolly.ValueRange, error) {
+	if len(filters) == 0 || schema.IsKeyless(sch) {
+		return nil, nil
+	}
+	zoned := make(map[int]bool)
+	for _, f := range durable.ZoneMapFields(sch) {
+		zoned[f] = true
+	}
+	fields := make(map[uint64]int)
+	i := 0
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		if zoned[i] {
+			fields[col.Tag] = i
+		}
+		i++
+	}
+
+	_, vd := rows.Descriptors()
+	var ranges []prolly.ValueRange
+	for _, f := range filters {
+		field, ok := fields[f.tag]
+		if !ok {
+			continue
+		}
+		col, _ := sch.GetAllCols().GetByTag(f.tag)
+		v, ok := zoneMapBound(col.TypeInfo.ToSqlType(), f.value)
+		if !ok {
+			continue
+		}
+		tb := val.NewTupleBuilder(vd)
+		if err := tree.PutField(ctx, rows.NodeStore(), tb, field, v); err != nil {
+			return nil, err
+		}
+		bound := tb.BuildPermissive(rows.Pool()).GetField(field)
+
+		rng := prolly.ValueRange{Field: field}
+		switch f.op {
+		case zoneMapEq:
+			rng.Lower, rng.Upper = bound, bound
+			rng.LowerInclusive, rng.UpperInclusive = true, true
+		case zoneMapLt:
+			rng.Upper = bound
+		case zoneMapLte:
+			rng.Upper, rng.UpperInclusive = bound, true
+		case zoneMapGt:
+			rng.Lower = bound
+		case zoneMapGte:
+			rng.Lower, rng.LowerInclusive = bound, true
+		}
+		ranges = append(ranges, rng)
+	}
+	return ranges, nil
+}
+
+// maxExactFloat is the largest magnitude below which every integer is exactly a float64. Integer columns are compared
+// with some literals as doubles, so larger values might compare equal to their neighbours.
+const maxExactFloat = 1 << 53
+
+// zoneMapBound returns |v| converted to the column type |typ|, if comparing the column with |v| orders the column's
+// values the same way as comparing them with the converted value does. It's false for values that the conversion
+// rounds or truncates, and for types whose values are compared in other ways.
+func zoneMapBound(typ sql.Type, v interface{}) (interface{}, bool) {
+	switch {
+	case sqltypes.IsInteger(typ):
+		if !exactInteger(v) {
+			return nil, false
+		}
+	case typ.Type() == sqltypes.Float64.Type():
+		switch v := v.(type) {
+		case float64:
+		case decimal.Decimal:
+			// doubles are compared with decimals as decimals
+			f, _ := v.Float64()
+			if !decimal.NewFromFloat(f).Equal(v) {
+				return nil, false
+			}
+		default:
+			if !exactInteger(v) {
+				return nil, false
+			}
+		}
+	case sqltypes.IsDateType(typ) || sqltypes.IsDatetimeType(typ):
+		// temporal values are compared as datetimes with the maximum precision
+		wide, _, err := sqltypes.DatetimeMaxPrecision.Convert(v)
+		if err != nil || wide == nil {
+			return nil, false
+		}
+		conv, _, err := typ.Convert(v)
+		if err != nil || conv == nil || !wide.(time.Time).Equal(conv.(time.Time)) {
+			return nil, false
+		}
+		return conv, true
+	default:
+		return nil, false
+	}
+	conv, inRange, err := typ.Convert(v)
+	if err != nil || inRange != sql.InRange || conv == nil {
+		return nil, false
+	}
+	return conv, true
+}
+
+// exactInteger returns whether |v| is an integer that's exactly a float64.
+func exactInteger(v interface{}) bool {
+	switch v := v.(type) {
+	case int8, int16, int32, uint8, uint16, uint32:
+		return true
+	case int:
+		return v > -maxExactFloat && v < maxExactFloat
+	case int64:
+		return v > -maxExactFloat && v < maxExactFloat
+	case uint:
+		return v < maxExactFloat
+	case uint64:
+		return v < maxExactFloat
+	case float64:
+		return v == math.Trunc(v) && math.Abs(v) < maxExactFloat
+	case decimal.Decimal:
+		return v.IsInteger() && v.Abs().LessThan(decimal.NewFromInt(maxExactFloat))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestZoneMapBound(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   sql.Type
+		value interface{}
+		bound interface{}
+	}{
+		{"int literal", sqltypes.Int32, int8(5), int32(5)},
+		{"integral decimal", sqltypes.Int64, decimal.NewFromInt(7), int64(7)},
+		{"fractional decimal", sqltypes.Int64, decimal.RequireFromString("7.5"), nil},
+		{"out of range", sqltypes.Int8, int64(1000), nil},
+		{"inexact double", sqltypes.Uint64, uint64(1) << 60, nil},
+		{"string", sqltypes.Int32, "5", nil},
+		{"double", sqltypes.Float64, 1.5, 1.5},
+		{"exact decimal", sqltypes.Float64, decimal.RequireFromString("0.25"), 0.25},
+		{"float", sqltypes.Float32, 1.5, nil},
+		{"date", sqltypes.Date, "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"date with time", sqltypes.Date, "2024-01-02 10:00:00", nil},
+		{"datetime", sqltypes.DatetimeMaxPrecision, "2024-01-02 10:00:00", time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{"rounded datetime", sqltypes.Datetime, "2024-01-02 10:00:00.5", nil},
+		{"timestamp", sqltypes.Timestamp, "2024-01-02 10:00:00", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bound, ok := zoneMapBound(test.typ, test.value)
+			assert.Equal(t, test.bound != nil, ok)
+			assert.Equal(t, test.bound, bound)
+		})
+	}
+}
+
+func TestZoneMapFiltersOf(t *testing.T) {
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("a", 1, types.IntKind, false),
+		schema.NewColumn("b", 2, types.IntKind, false),
+	))
+	sch.SetZoneMapColumns([]uint64{1})
+
+	a := expression.NewGetFieldWithTable(1, 1, sqltypes.Int64, "", "t", "a", true)
+	b := expression.NewGetFieldWithTable(2, 2, sqltypes.Int64, "", "t", "b", true)
+	other := expression.NewGetFieldWithTable(1, 1, sqltypes.Int64, "", "u", "a", true)
+	lit := func(v interface{}) sql.Expression { return expression.NewLiteral(v, sqltypes.Int64) }
+
+	filter := expression.JoinAnd(
+		expression.NewGreaterThan(a, lit(int64(1))),
+		expression.NewLessThanOrEqual(lit(int64(10)), a),
+		expression.NewBetween(a, lit(int64(2)), lit(int64(8))),
+		expression.NewEquals(b, lit(int64(3))),
+		expression.NewEquals(other, lit(int64(3))),
+		expression.NewEquals(a, expression.NewLiteral(nil, sqltypes.Null)),
+		expression.NewOr(expression.NewEquals(a, lit(int64(1))), expression.NewEquals(a, lit(int64(2)))),
+	)
+	assert.Equal(t, []zoneMapFilter{
+		{1, zoneMapGt, int64(1)},
+		{1, zoneMapGte, int64(10)},
+		{1, zoneMapGte, int64(2)},
+		{1, zoneMapLte, int64(8)},
+	}, zoneMapFiltersOf(sch, "t", filter))
+}
+
+func TestZoneMapFiltersSkipNodes(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(), editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir})
+	require.NoError(t, err)
+	engine, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+
+	for _, q := range []string{
+		"create table t (pk int primary key, v int);",
+		"insert into t with recursive cte(n) as (select 1 union all select n + 1 from cte where n < 9000) select n, n from cte;",
+	} {
+		_, iter, _, err := engine.Query(sqlCtx, q)
+		require.NoError(t, err)
+		_, err = sql.RowIterToRows(sqlCtx, iter)
+		require.NoError(t, err)
+	}
+	tbl, _, err := db.GetTableInsensitive(sqlCtx, "t")
+	require.NoError(t, err)
+	require.NoError(t, tbl.(*AlterableDoltTable).SetZoneMapColumns(sqlCtx, []string{"v"}))
+
+	n, err := engine.AnalyzeQuery(sqlCtx, "select * from t where v between 100 and 200")
+	require.NoError(t, err)
+	var dt *DoltTable
+	transform.Inspect(n, func(n sql.Node) bool {
+		if rt, ok := n.(*plan.ResolvedTable); ok {
+			table := rt.Table
+			if pt, ok := table.(*plan.ProcessTable); ok {
+				table = pt.Underlying()
+			}
+			dt = doltTableOf(table)
+		}
+		return true
+	})
+	require.NotNil(t, dt)
+	require.Len(t, dt.zoneMapFilters, 2)
+
+	table, err := dt.DoltTable(sqlCtx)
+	require.NoError(t, err)
+	sch, err := table.GetSchema(ctx)
+	require.NoError(t, err)
+	idx, err := table.GetRowData(ctx)
+	require.NoError(t, err)
+	rows := durable.ProllyMapFromIndex(idx)
+	ranges, err := zoneMapRanges(ctx, sch, rows, dt.zoneMapFilters)
+	require.NoError(t, err)
+	require.Len(t, ranges, 2)
+
+	iter, err := rows.IterValueRanges(ctx, 0, 9000, ranges...)
+	require.NoError(t, err)
+	var cnt int
+	for {
+		_, _, err = iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		cnt++
+	}
+	assert.Equal(t, 101, cnt)
+	assert.Greater(t, iter.SkippedSubtrees(), 0)
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// ErrConfigReloadUnsupported is returned by ReloadConfig when no running server can reload its config.
+var ErrConfigReloadUnsupported = errors.New("config can only be reloaded by a sql-server started with a config file")
+
+// ConfigReloader re-reads the config of the running server and applies it to the server.
+type ConfigReloader func(ctx *sql.Context) error
+
+var theReloader ConfigReloader
+
+// SetConfigReloader sets the function which reloads the config of the running server.
+func SetConfigReloader(reloader ConfigReloader) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	theReloader = reloader
+}
+
+// UnsetConfigReloader removes the function which reloads the config of the running server.
+func UnsetConfigReloader() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	theReloader = nil
+}
+
+// ReloadConfig reloads the config of the running server.
+func ReloadConfig(ctx *sql.Context) error {
+	mutex.Lock()
+	reloader := theReloader
+	mutex.Unlock()
+	if reloader == nil {
+		return ErrConfigReloadUnsupported
+	}
+	return reloader(ctx)
+}
@@ -15,6 +15,7 @@
 package table
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
@@ -24,11 +25,28 @@ import (
 type BadRow struct {
 	Row     row.Row
 	Details []string
+	// Location is where the row was read from, if known
+	Location *SourceLocation
 }
 
 // NewBadRow creates a BadRow instance with a given row and error details
 func NewBadRow(r row.Row, details ...string) *BadRow {
-	return &BadRow{r, details}
+	return &BadRow{Row: r, Details: details}
+}
+
+// WithLocation returns the BadRow with the location the row was read from.
+func (br *BadRow) WithLocation(loc SourceLocation) *BadRow {
+	br.Location = &loc
+	return br
+}
+
+// GetBadRowLocation returns where the row of the BadRow |err| was read from, if it's a BadRow and that's known.
+func GetBadRowLocation(err error) (SourceLocation, bool) {
+	var br *BadRow
+	if !errors.As(err, &br) || br.Location == nil {
+		return SourceLocation{}, false
+	}
+	return *br.Location, true
 }
 
 // IsBadRow takes an error and returns whether it is a BadRow
@@ -45,4 +45,12 @@ func TestBadRow(t *testing.T) {
 	if err.Error() != "details" {
 		t.Error("unexpected details")
 	}
+
+	_, ok := GetBadRowLocation(err)
+	assert.False(t, ok)
+
+	err = NewBadRow(emptyRow, "details").WithLocation(SourceLocation{Line: 2, Record: 1})
+	loc, ok := GetBadRowLocation(err)
+	assert.True(t, ok)
+	assert.Equal(t, SourceLocation{Line: 2, Record: 1}, loc)
 }
@@ -0,0 +1,88 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SourceLocation is where a row was read from in its input.
+type SourceLocation struct {
+	// File is the path of the file read, or empty if the input isn't a file
+	File string
+	// Line is the line of the input the row begins on, counting from 1, or 0 if the input isn't line oriented
+	Line int
+	// Record is the number of the row among the rows read, counting from 1
+	Record int
+	// Offset is the byte offset in the input of the start of the row, known only if HasOffset is true
+	Offset    int64
+	HasOffset bool
+}
+
+// String returns the location as it's given in error messages, such as "line 3 of data.csv, byte offset 45".
+func (loc SourceLocation) String() string {
+	var sb strings.Builder
+	if loc.Line > 0 {
+		fmt.Fprintf(&sb, "line %d", loc.Line)
+	} else {
+		fmt.Fprintf(&sb, "record %d", loc.Record)
+	}
+	if loc.File != "" {
+		fmt.Fprintf(&sb, " of %s", loc.File)
+	}
+	if loc.HasOffset {
+		fmt.Fprintf(&sb, ", byte offset %d", loc.Offset)
+	}
+	return sb.String()
+}
+
+// SourceLocator is implemented by readers which know where in their input each row was read from.
+type SourceLocator interface {
+	// Location returns the location of the row last read.
+	Location() SourceLocation
+}
+
+// RowLocations is a queue of the locations of rows sent from one stage of a pipeline to another, so that the stage
+// which receives them can find where a row it fails on came from. The sending stage pushes the location of each row it
+// sends, and the receiving stage pops one for each row it receives, in the same order.
+type RowLocations struct {
+	mu   sync.Mutex
+	locs []SourceLocation
+}
+
+// Push adds the location of a row sent to the end of the queue.
+func (rl *RowLocations) Push(loc SourceLocation) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.locs = append(rl.locs, loc)
+}
+
+// Pop removes the location of the row received from the front of the queue, returning false if it's empty or nil.
+func (rl *RowLocations) Pop() (SourceLocation, bool) {
+	if rl == nil {
+		return SourceLocation{}, false
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if len(rl.locs) == 0 {
+		return SourceLocation{}, false
+	}
+	loc := rl.locs[0]
+	rl.locs[0] = SourceLocation{}
+	rl.locs = rl.locs[1:]
+	return loc, true
+}
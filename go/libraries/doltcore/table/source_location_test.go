@@ -0,0 +1,46 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceLocationString(t *testing.T) {
+	assert.Equal(t, "line 3", SourceLocation{Line: 3, Record: 2}.String())
+	assert.Equal(t, "line 3, byte offset 45", SourceLocation{Line: 3, Record: 2, Offset: 45, HasOffset: true}.String())
+	assert.Equal(t, "line 1 of data.csv, byte offset 0", SourceLocation{File: "data.csv", Line: 1, Record: 1, HasOffset: true}.String())
+	assert.Equal(t, "record 7 of data.json", SourceLocation{File: "data.json", Record: 7}.String())
+}
+
+func TestRowLocations(t *testing.T) {
+	var rl *RowLocations
+	_, ok := rl.Pop()
+	assert.False(t, ok)
+
+	rl = &RowLocations{}
+	for i := 1; i <= 3; i++ {
+		rl.Push(SourceLocation{Line: i + 1, Record: i})
+	}
+	for i := 1; i <= 3; i++ {
+		loc, ok := rl.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, loc.Record)
+	}
+	_, ok = rl.Pop()
+	assert.False(t, ok)
+}
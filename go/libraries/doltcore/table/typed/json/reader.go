@@ -36,6 +36,7 @@ var ReadBufSize = 256 * 1024
 
 type JSONReader struct {
 	vrw        types.ValueReadWriter
+	lines      bool
 	closer     io.Closer
 	sch        schema.Schema
 	jsonStream *jstream.Decoder
@@ -58,15 +59,40 @@ func OpenJSONReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableF
 // UTF16LE or UTF16BE BOM at the first bytes read, then it is stripped and the
 // remaining contents of the reader are treated as that encoding.
 func NewJSONReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*JSONReader, error) {
+	return newJSONReader(vrw, r, sch, false)
+}
+
+// OpenJSONLReader opens a reader of the JSON lines file at |path|, which has a JSON object of each row on its own line.
+func OpenJSONLReader(vrw types.ValueReadWriter, path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLReader(vrw, r, sch)
+}
+
+// NewJSONLReader returns a reader of JSON lines, which has a JSON object of each row on its own line. Rows are decoded
+// as they are read, so a stream of any length can be read with bounded memory.
+func NewJSONLReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema) (*JSONReader, error) {
+	return newJSONReader(vrw, r, sch, true)
+}
+
+func newJSONReader(vrw types.ValueReadWriter, r io.ReadCloser, sch schema.Schema, lines bool) (*JSONReader, error) {
 	if sch == nil {
 		return nil, errors.New("schema must be provided to JsonReader")
 	}
 
 	textReader := transform.NewReader(r, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
 
-	decoder := jstream.NewDecoder(textReader, 2) // extract JSON values at a depth level of 1
+	// extract JSON values at a depth level of 1, or the top level values of JSON lines
+	emitDepth := 2
+	if lines {
+		emitDepth = 0
+	}
+	decoder := jstream.NewDecoder(textReader, emitDepth)
 
-	return &JSONReader{vrw: vrw, closer: r, sch: sch, jsonStream: decoder}, nil
+	return &JSONReader{vrw: vrw, lines: lines, closer: r, sch: sch, jsonStream: decoder}, nil
 }
 
 // Close should release resources being held
@@ -120,6 +146,9 @@ func (r *JSONReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
 
 	mapVal, ok := metaRow.Value.(map[string]interface{})
 	if !ok {
+		if r.lines {
+			return nil, fmt.Errorf("unexpected JSON format received, expected a json_row_object on each line")
+		}
 		return nil, fmt.Errorf("unexpected JSON format received, expected format: { \"rows\": [ json_row_objects... ] } ")
 	}
 
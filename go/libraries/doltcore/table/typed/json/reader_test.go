@@ -118,6 +118,22 @@ func TestReader(t *testing.T) {
 	})
 }
 
+func TestJSONLReader(t *testing.T) {
+	testJSONL := `{"id": 0, "first name": "tim", "last name": "sehn"}
+{"id": 1, "first name": "brian", "last name": "hendriks"}
+`
+
+	fs := filesys.EmptyInMemFS("/")
+	require.NoError(t, fs.WriteFile("file.jsonl", []byte(testJSONL), os.ModePerm))
+
+	testGoodJSON(t, func(vrw types.ValueReadWriter, sch schema.Schema) (*JSONReader, error) {
+		return OpenJSONLReader(vrw, "file.jsonl", fs, sch)
+	})
+	testGoodJSON(t, func(vrw types.ValueReadWriter, sch schema.Schema) (*JSONReader, error) {
+		return NewJSONLReader(vrw, io.NopCloser(bytes.NewBufferString(testJSONL)), sch)
+	})
+}
+
 func TestReaderBOMHandling(t *testing.T) {
 	testJSON := `{
 		"rows": [
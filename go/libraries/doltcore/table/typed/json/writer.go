@@ -71,6 +71,11 @@ func NewJSONSqlWriter(wr io.WriteCloser, sch sql.Schema) (*RowWriter, error) {
 	return w, nil
 }
 
+// NewJSONLWriter returns a new writer that encodes rows as JSON lines, with the JSON object of each row on its own line.
+func NewJSONLWriter(wr io.WriteCloser, outSch schema.Schema) (*RowWriter, error) {
+	return NewJSONWriterWithHeader(wr, outSch, "", "\n", "\n")
+}
+
 func NewJSONWriterWithHeader(wr io.WriteCloser, outSch schema.Schema, header, footer, separator string) (*RowWriter, error) {
 	bwr := bufio.NewWriterSize(wr, WriteBufSize)
 	return &RowWriter{
@@ -16,6 +16,7 @@ package parquet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -60,6 +61,42 @@ func OpenParquetReader(vrw types.ValueReadWriter, path string, sch schema.Schema
 	return NewParquetReader(vrw, fr, sch)
 }
 
+// NewParquetReaderFromReaderAt creates a ParquetReader of the |size| bytes of |r|, such as a parquet file which was
+// redirected to stdin.
+func NewParquetReaderFromReaderAt(vrw types.ValueReadWriter, r io.ReaderAt, size int64, sch schema.Schema) (*ParquetReader, error) {
+	return NewParquetReader(vrw, newReaderAtFile(r, size), sch)
+}
+
+// readerAtFile is a read only source.ParquetFile of an io.ReaderAt. Each file opened from it has its own offset, so
+// that columns can be read concurrently.
+type readerAtFile struct {
+	*io.SectionReader
+	r    io.ReaderAt
+	size int64
+}
+
+var _ source.ParquetFile = (*readerAtFile)(nil)
+
+func newReaderAtFile(r io.ReaderAt, size int64) *readerAtFile {
+	return &readerAtFile{SectionReader: io.NewSectionReader(r, 0, size), r: r, size: size}
+}
+
+func (f *readerAtFile) Open(string) (source.ParquetFile, error) {
+	return newReaderAtFile(f.r, f.size), nil
+}
+
+func (f *readerAtFile) Create(string) (source.ParquetFile, error) {
+	return nil, errors.New("cannot create a file from a reader")
+}
+
+func (f *readerAtFile) Write([]byte) (int, error) {
+	return 0, errors.New("cannot write to a reader")
+}
+
+func (f *readerAtFile) Close() error {
+	return nil
+}
+
 // NewParquetReader creates a ParquetReader from a given fileReader.
 // The ParquetFileInfo should describe the parquet file being read.
 func NewParquetReader(vrw types.ValueReadWriter, fr source.ParquetFile, sche schema.Schema) (*ParquetReader, error) {
@@ -24,11 +24,21 @@ type CSVFileInfo struct {
 	Columns []string
 	// EscapeQuotes says whether quotes should be escaped when parsing the csv
 	EscapeQuotes bool
+	// DecimalSep is the decimal separator written in decimal and floating point values, if not "."
+	DecimalSep string
+	// DateFormat is the DATE_FORMAT format string used to write date values, if not the default
+	DateFormat string
+	// DatetimeFormat is the DATE_FORMAT format string used to write datetime and timestamp values, if not the default
+	DatetimeFormat string
+	// WriteBOM says whether a UTF-8 byte order mark is written at the start of the csv
+	WriteBOM bool
+	// UseCRLF says whether lines are terminated with \r\n rather than \n when writing the csv
+	UseCRLF bool
 }
 
 // NewCSVInfo creates a new CSVInfo struct with default values
 func NewCSVInfo() *CSVFileInfo {
-	return &CSVFileInfo{",", true, nil, true}
+	return &CSVFileInfo{Delim: ",", HasHeaderLine: true, EscapeQuotes: true}
 }
 
 // SetDelim sets the Delim member and returns the CSVFileInfo
@@ -54,3 +64,33 @@ func (info *CSVFileInfo) SetEscapeQuotes(escapeQuotes bool) *CSVFileInfo {
 	info.EscapeQuotes = escapeQuotes
 	return info
 }
+
+// SetDecimalSep sets the DecimalSep member and returns the CSVFileInfo
+func (info *CSVFileInfo) SetDecimalSep(decimalSep string) *CSVFileInfo {
+	info.DecimalSep = decimalSep
+	return info
+}
+
+// SetDateFormat sets the DateFormat member and returns the CSVFileInfo
+func (info *CSVFileInfo) SetDateFormat(dateFormat string) *CSVFileInfo {
+	info.DateFormat = dateFormat
+	return info
+}
+
+// SetDatetimeFormat sets the DatetimeFormat member and returns the CSVFileInfo
+func (info *CSVFileInfo) SetDatetimeFormat(datetimeFormat string) *CSVFileInfo {
+	info.DatetimeFormat = datetimeFormat
+	return info
+}
+
+// SetWriteBOM sets the WriteBOM member and returns the CSVFileInfo
+func (info *CSVFileInfo) SetWriteBOM(writeBOM bool) *CSVFileInfo {
+	info.WriteBOM = writeBOM
+	return info
+}
+
+// SetUseCRLF sets the UseCRLF member and returns the CSVFileInfo
+func (info *CSVFileInfo) SetUseCRLF(useCRLF bool) *CSVFileInfo {
+	info.UseCRLF = useCRLF
+	return info
+}
@@ -58,9 +58,27 @@ type CSVReader struct {
 	delim           []byte
 	numLine         int
 	fieldsPerRecord int
+
+	// the input read so far, the file read, and where the record last read began
+	counted *countingReader
+	path    string
+	loc     table.SourceLocation
 }
 
 var _ table.SqlTableReader = (*CSVReader)(nil)
+var _ table.SourceLocator = (*CSVReader)(nil)
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
 
 // OpenCSVReader opens a reader at a given path within a given filesys.  The CSVFileInfo should describe the csv file
 // being opened.
@@ -71,7 +89,12 @@ func OpenCSVReader(nbf *types.NomsBinFormat, path string, fs filesys.ReadableFS,
 		return nil, err
 	}
 
-	return NewCSVReader(nbf, r, info)
+	rd, err := NewCSVReader(nbf, r, info)
+	if err != nil {
+		return nil, err
+	}
+	rd.path = path
+	return rd, nil
 }
 
 // NewCSVReader creates a CSVReader from a given ReadCloser.  The CSVFileInfo should describe the csv file being read.
@@ -92,8 +115,9 @@ func NewCSVReader(nbf *types.NomsBinFormat, r io.ReadCloser, info *CSVFileInfo)
 	}
 
 	textReader := transform.NewReader(r, textunicode.BOMOverride(transform.Nop))
+	counted := &countingReader{rd: textReader}
 
-	br := bufio.NewReaderSize(textReader, ReadBufSize)
+	br := bufio.NewReaderSize(counted, ReadBufSize)
 	colStrs, err := getColHeaders(br, info)
 
 	if err != nil {
@@ -103,6 +127,11 @@ func NewCSVReader(nbf *types.NomsBinFormat, r io.ReadCloser, info *CSVFileInfo)
 
 	_, sch := untyped.NewUntypedSchema(colStrs...)
 
+	numLine := 0
+	if info.HasHeaderLine {
+		numLine = 1
+	}
+
 	return &CSVReader{
 		closer:          r,
 		bRd:             br,
@@ -110,7 +139,9 @@ func NewCSVReader(nbf *types.NomsBinFormat, r io.ReadCloser, info *CSVFileInfo)
 		isDone:          false,
 		nbf:             nbf,
 		delim:           []byte(info.Delim),
+		numLine:         numLine,
 		fieldsPerRecord: sch.GetAllCols().Size(),
+		counted:         counted,
 	}, nil
 }
 
@@ -173,11 +204,11 @@ func (csvr *CSVReader) ReadRow(ctx context.Context) (row.Row, error) {
 		return nil, table.NewBadRow(nil,
 			fmt.Sprintf("csv reader's schema expects %d fields, but line only has %d values.", allCols.Size(), len(colVals)),
 			fmt.Sprintf("line: '%s'", out.String()),
-		)
+		).WithLocation(csvr.loc)
 	}
 
 	if err != nil {
-		return nil, table.NewBadRow(nil, err.Error())
+		return nil, table.NewBadRow(nil, err.Error()).WithLocation(csvr.loc)
 	}
 
 	taggedVals := make(row.TaggedValues)
@@ -228,11 +259,11 @@ func (csvr *CSVReader) ReadSqlRow(crx context.Context) (sql.Row, error) {
 
 		return rowValsToSQLRows(rowVals), table.NewBadRow(nil,
 			args...,
-		)
+		).WithLocation(csvr.loc)
 	}
 
 	if err != nil {
-		return rowValsToSQLRows(rowVals), table.NewBadRow(nil, err.Error())
+		return rowValsToSQLRows(rowVals), table.NewBadRow(nil, err.Error()).WithLocation(csvr.loc)
 	}
 
 	return rowValsToSQLRows(rowVals), nil
@@ -273,6 +304,20 @@ func (csvr *CSVReader) Close(ctx context.Context) error {
 	}
 }
 
+// Location implements table.SourceLocator. The byte offset is of the input after any byte order mark is removed and
+// UTF-16 input is decoded.
+func (csvr *CSVReader) Location() table.SourceLocation {
+	return csvr.loc
+}
+
+// offset returns the offset of the next byte to be read from the input, if the input is counted.
+func (csvr *CSVReader) offset() (int64, bool) {
+	if csvr.counted == nil {
+		return 0, false
+	}
+	return csvr.counted.n - int64(csvr.bRd.Buffered()), true
+}
+
 // Functions below this line are borrowed or adapted from encoding/csv/reader.go
 
 func validDelim(s string) bool {
@@ -336,7 +381,10 @@ func (csvr *CSVReader) csvReadRecords(dst []*string) ([]*string, error) {
 	recordStartline := csvr.numLine // Starting line for record
 
 	var err error
+	var offset int64
+	var hasOffset bool
 	for err == nil {
+		offset, hasOffset = csvr.offset()
 		rs.line, err = csvr.readLine()
 		if err == nil && len(rs.line) == lengthNL(rs.line) {
 			rs.line = nil
@@ -347,6 +395,13 @@ func (csvr *CSVReader) csvReadRecords(dst []*string) ([]*string, error) {
 	if err == io.EOF {
 		return nil, err
 	}
+	csvr.loc = table.SourceLocation{
+		File:      csvr.path,
+		Line:      csvr.numLine,
+		Record:    csvr.loc.Record + 1,
+		Offset:    offset,
+		HasOffset: hasOffset,
+	}
 
 	// nullString indicates whether to interpret an empty string as a NULL
 	// only empty strings escaped with double quotes will be non-null
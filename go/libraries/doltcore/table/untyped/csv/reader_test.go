@@ -188,3 +188,77 @@ func readTestRows(t *testing.T, input []byte, info *CSVFileInfo) ([]row.Row, int
 
 	return rows, badRows, err
 }
+
+func TestReaderLocation(t *testing.T) {
+	const root = "/"
+	const path = "/file.csv"
+
+	tests := []struct {
+		name     string
+		input    string
+		info     *CSVFileInfo
+		expected []table.SourceLocation
+		badRows  []int
+	}{
+		{
+			name:  "quoted newline and empty lines",
+			input: PersonDB3,
+			info:  NewCSVInfo(),
+			expected: []table.SourceLocation{
+				{File: path, Line: 3, Record: 1, Offset: 18, HasOffset: true},
+				{File: path, Line: 5, Record: 2, Offset: 52, HasOffset: true},
+				{File: path, Line: 7, Record: 3, Offset: 91, HasOffset: true},
+				{File: path, Line: 9, Record: 4, Offset: 111, HasOffset: true},
+			},
+		},
+		{
+			name:  "bad row",
+			input: PersonDBWithBadRow2,
+			info:  NewCSVInfo(),
+			expected: []table.SourceLocation{
+				{File: path, Line: 2, Record: 1, Offset: 17, HasOffset: true},
+				{File: path, Line: 3, Record: 2, Offset: 50, HasOffset: true},
+				{File: path, Line: 4, Record: 3, Offset: 75, HasOffset: true},
+				{File: path, Line: 5, Record: 4, Offset: 94, HasOffset: true},
+			},
+			badRows: []int{3},
+		},
+		{
+			name:  "no header",
+			input: PersonDBWithoutHeaders,
+			info:  NewCSVInfo().SetHasHeaderLine(false).SetColumns([]string{"name", "age", "title"}),
+			expected: []table.SourceLocation{
+				{File: path, Line: 1, Record: 1, Offset: 0, HasOffset: true},
+				{File: path, Line: 2, Record: 2, Offset: 33, HasOffset: true},
+				{File: path, Line: 3, Record: 3, Offset: 71, HasOffset: true},
+				{File: path, Line: 4, Record: 4, Offset: 90, HasOffset: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := filesys.NewInMemFS(nil, map[string][]byte{path: []byte(test.input)}, root)
+			csvR, err := OpenCSVReader(types.Format_Default, path, fs, test.info)
+			require.NoError(t, err)
+			defer csvR.Close(context.Background())
+
+			var locs []table.SourceLocation
+			for i := 0; ; i++ {
+				_, err := csvR.ReadSqlRow(context.Background())
+				if err == io.EOF {
+					break
+				}
+				locs = append(locs, csvR.Location())
+				if err != nil {
+					require.True(t, table.IsBadRow(err))
+					require.Contains(t, test.badRows, i)
+					loc, ok := table.GetBadRowLocation(err)
+					require.True(t, ok)
+					require.Equal(t, test.expected[i], loc)
+				}
+			}
+			require.Equal(t, test.expected, locs)
+		})
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"unicode/utf8"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
 	"github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -36,6 +38,10 @@ import (
 // writers create their own buffer's using the value of this variable at the time they create their buffers.
 const writeBufSize = 256 * 1024
 
+// utf8BOM is the byte order mark written at the start of a csv when CSVFileInfo.WriteBOM is set. Excel needs it to
+// read a csv as UTF-8.
+const utf8BOM = "\uFEFF"
+
 // CSVWriter implements TableWriter.  It writes rows as comma separated string values
 type CSVWriter struct {
 	wr      *bufio.Writer
@@ -44,22 +50,57 @@ type CSVWriter struct {
 	sch     schema.Schema
 	sqlSch  sql.Schema
 	useCRLF bool // True to use \r\n as the line terminator
+	// dateFmt and datetimeFmt format date and datetime values when the info has a DateFormat or DatetimeFormat
+	dateFmt     sql.Expression
+	datetimeFmt sql.Expression
+	sqlCtx      *sql.Context
 }
 
 var _ table.SqlRowWriter = (*CSVWriter)(nil)
 
+func newCSVWriter(wr io.WriteCloser, info *CSVFileInfo) (*CSVWriter, error) {
+	csvw := &CSVWriter{
+		wr:      bufio.NewWriterSize(wr, writeBufSize),
+		closer:  wr,
+		info:    info,
+		useCRLF: info.UseCRLF,
+	}
+	if info.DateFormat != "" {
+		csvw.dateFmt = newDateFormatter(info.DateFormat)
+	}
+	if info.DatetimeFormat != "" {
+		csvw.datetimeFmt = newDateFormatter(info.DatetimeFormat)
+	}
+	if csvw.dateFmt != nil || csvw.datetimeFmt != nil {
+		csvw.sqlCtx = sql.NewEmptyContext()
+	}
+
+	if info.WriteBOM {
+		if _, err := csvw.wr.WriteString(utf8BOM); err != nil {
+			wr.Close()
+			return nil, err
+		}
+	}
+
+	return csvw, nil
+}
+
+// newDateFormatter returns an expression which formats the first value of a row as DATE_FORMAT does with |format|.
+func newDateFormatter(format string) sql.Expression {
+	return function.NewDateFormat(expression.NewGetField(0, types.DatetimeMaxPrecision, "", true), expression.NewLiteral(format, types.LongText))
+}
+
 // NewCSVWriter writes rows to the given WriteCloser based on the Schema and CSVFileInfo provided
 func NewCSVWriter(wr io.WriteCloser, outSch schema.Schema, info *CSVFileInfo) (*CSVWriter, error) {
-	csvw := &CSVWriter{
-		wr:     bufio.NewWriterSize(wr, writeBufSize),
-		closer: wr,
-		info:   info,
-		sch:    outSch,
+	csvw, err := newCSVWriter(wr, info)
+	if err != nil {
+		return nil, err
 	}
+	csvw.sch = outSch
 
 	if info.HasHeaderLine {
 		colNames := make([]*string, 0, outSch.GetAllCols().Size())
-		err := outSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		err = outSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 			nm := col.Name
 			colNames = append(colNames, &nm)
 			return false, nil
@@ -83,12 +124,11 @@ func NewCSVWriter(wr io.WriteCloser, outSch schema.Schema, info *CSVFileInfo) (*
 
 // NewCSVSqlWriter writes rows to the given WriteCloser based on the sql schema and CSVFileInfo provided
 func NewCSVSqlWriter(wr io.WriteCloser, sch sql.Schema, info *CSVFileInfo) (*CSVWriter, error) {
-	csvw := &CSVWriter{
-		wr:     bufio.NewWriterSize(wr, writeBufSize),
-		closer: wr,
-		info:   info,
-		sqlSch: sch,
+	csvw, err := newCSVWriter(wr, info)
+	if err != nil {
+		return nil, err
 	}
+	csvw.sqlSch = sch
 
 	if info.HasHeaderLine {
 		colNames := make([]*string, len(sch))
@@ -97,7 +137,7 @@ func NewCSVSqlWriter(wr io.WriteCloser, sch sql.Schema, info *CSVFileInfo) (*CSV
 			colNames[i] = &nm
 		}
 
-		err := csvw.write(colNames)
+		err = csvw.write(colNames)
 		if err != nil {
 			wr.Close()
 			return nil, err
@@ -125,19 +165,31 @@ func (csvw *CSVWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
 	return csvw.write(colValStrs)
 }
 
-func toCsvString(colType sql.Type, val interface{}) (string, error) {
-	var v string
+func (csvw *CSVWriter) toCsvString(colType sql.Type, val interface{}) (string, error) {
 	// Due to BIT's unique output, we special-case writing the integer specifically for CSV
 	if _, ok := colType.(types.BitType); ok {
-		v = strconv.FormatUint(val.(uint64), 10)
-	} else {
-		var err error
-		v, err = sqlutil.SqlColToStr(colType, val)
-		if err != nil {
+		return strconv.FormatUint(val.(uint64), 10), nil
+	}
+
+	dateFmt := csvw.datetimeFmt
+	if types.IsDateType(colType) {
+		dateFmt = csvw.dateFmt
+	}
+	if dateFmt != nil && types.IsTime(colType) {
+		v, err := dateFmt.Eval(csvw.sqlCtx, sql.Row{val})
+		if err != nil || v == nil {
 			return "", err
 		}
+		return v.(string), nil
 	}
 
+	v, err := sqlutil.SqlColToStr(colType, val)
+	if err != nil {
+		return "", err
+	}
+	if csvw.info.DecimalSep != "" && (types.IsDecimal(colType) || types.IsFloat(colType)) {
+		v = strings.Replace(v, ".", csvw.info.DecimalSep, 1)
+	}
 	return v, nil
 }
 
@@ -148,7 +200,7 @@ func (csvw *CSVWriter) processRowWithSchema(r sql.Row) ([]*string, error) {
 			colValStrs[i] = nil
 		} else {
 			colType := csvw.sch.GetAllCols().GetByIndex(i).TypeInfo.ToSqlType()
-			v, err := toCsvString(colType, val)
+			v, err := csvw.toCsvString(colType, val)
 			if err != nil {
 				return nil, err
 			}
@@ -165,7 +217,7 @@ func (csvw *CSVWriter) processRowWithSqlSchema(r sql.Row) ([]*string, error) {
 			colValStrs[i] = nil
 		} else {
 			colType := csvw.sqlSch[i].Type
-			v, err := toCsvString(colType, val)
+			v, err := csvw.toCsvString(colType, val)
 			if err != nil {
 				return nil, err
 			}
@@ -18,8 +18,11 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
@@ -146,3 +149,47 @@ Andy Anderson|27|
 		t.Errorf(`%s != %s`, results, expected)
 	}
 }
+
+func TestWriterLocaleOptions(t *testing.T) {
+	const root = "/"
+	const path = "/file.csv"
+	const expected = "\uFEFFname;price;ratio;day;at\r\n" +
+		"Bill Billerson;12,50;0,25;31/01/2024;31.01.2024 13:45:07\r\n" +
+		"Rob Robertson;;;;\r\n"
+
+	sch := sql.Schema{
+		{Name: "name", Type: gmstypes.Text},
+		{Name: "price", Type: gmstypes.MustCreateDecimalType(10, 2)},
+		{Name: "ratio", Type: gmstypes.Float64},
+		{Name: "day", Type: gmstypes.Date},
+		{Name: "at", Type: gmstypes.Datetime},
+	}
+	at := time.Date(2024, 1, 31, 13, 45, 7, 0, time.UTC)
+	rows := []sql.Row{
+		{"Bill Billerson", decimal.RequireFromString("12.50"), 0.25, at, at},
+		{"Rob Robertson", nil, nil, nil, nil},
+	}
+
+	info := NewCSVInfo().SetDelim(";").SetDecimalSep(",").SetDateFormat("%d/%m/%Y").
+		SetDatetimeFormat("%d.%m.%Y %H:%i:%s").SetWriteBOM(true).SetUseCRLF(true)
+
+	fs := filesys.NewInMemFS(nil, nil, root)
+	writer, err := fs.OpenForWrite(path, os.ModePerm)
+	if err != nil {
+		t.Fatal("Could not open writer for CSVWriter", err)
+	}
+	csvWr, err := NewCSVSqlWriter(writer, sch, info)
+	if err != nil {
+		t.Fatal("Could not open CSVWriter", err)
+	}
+
+	writeToCSV(csvWr, rows, t)
+
+	results, err := fs.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(results) != expected {
+		t.Errorf("%q != %q", results, expected)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+// Category is a category of the anonymized metrics collected by dolt, which can be opted in or out of separately.
+type Category string
+
+const (
+	// CategoryUsage is which commands are run, and their attributes, such as the scheme of a remote's URL
+	CategoryUsage Category = "usage"
+	// CategoryPerformance is the timing and size of operations, such as the bytes downloaded from a remote
+	CategoryPerformance Category = "performance"
+	// CategoryErrors is the count of errors, such as failed RPCs to a remote
+	CategoryErrors Category = "errors"
+)
+
+// Categories are all the categories of metrics.
+var Categories = []Category{CategoryUsage, CategoryPerformance, CategoryErrors}
+
+var categoryConfigKeys = map[Category]string{
+	CategoryUsage:       config.MetricsUsage,
+	CategoryPerformance: config.MetricsPerformance,
+	CategoryErrors:      config.MetricsErrors,
+}
+
+var categoryDescriptions = map[Category]string{
+	CategoryUsage:       "which commands are run, and their attributes, such as the scheme of a remote's URL",
+	CategoryPerformance: "the timing and size of operations, such as the bytes downloaded from a remote",
+	CategoryErrors:      "the count of errors, such as failed requests to a remote",
+}
+
+// ConfigKey returns the config key which opts in or out of the category.
+func (c Category) ConfigKey() string {
+	return categoryConfigKeys[c]
+}
+
+// Description returns a description of the metrics of the category.
+func (c Category) Description() string {
+	return categoryDescriptions[c]
+}
+
+// MetricCategory returns the category of the metric |id|.
+func MetricCategory(id eventsapi.MetricID) Category {
+	switch id {
+	case eventsapi.MetricID_REMOTEAPI_RPC_ERROR:
+		return CategoryErrors
+	default:
+		return CategoryPerformance
+	}
+}
+
+// CategorySet is a set of categories of metrics.
+type CategorySet map[Category]bool
+
+// Empty returns whether no category is in the set.
+func (cs CategorySet) Empty() bool {
+	for _, enabled := range cs {
+		if enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// EnabledCategories returns the categories of metrics enabled by |cfg|. Every category is enabled unless the config
+// key |metrics.disabled| is true, or the config key of the category is false. It returns an error if any of those keys
+// isn't a boolean, in which case callers should treat every category as disabled.
+func EnabledCategories(cfg config.ReadableConfig) (CategorySet, error) {
+	disabled, err := strconv.ParseBool(cfg.GetStringOrDefault(config.MetricsDisabled, "false"))
+	if err != nil {
+		return CategorySet{}, fmt.Errorf("invalid value for %s: %w", config.MetricsDisabled, err)
+	}
+
+	enabled := CategorySet{}
+	for _, c := range Categories {
+		on, err := strconv.ParseBool(cfg.GetStringOrDefault(c.ConfigKey(), "true"))
+		if err != nil {
+			return CategorySet{}, fmt.Errorf("invalid value for %s: %w", c.ConfigKey(), err)
+		}
+		enabled[c] = on && !disabled
+	}
+	return enabled, nil
+}
+
+// FilterEvents returns |evts| with only the data of the categories in |enabled|. Metrics are removed unless their
+// category is enabled. Without CategoryUsage, the type and attributes of each event are removed, and events without
+// any metrics left are dropped.
+func FilterEvents(evts []*eventsapi.ClientEvent, enabled CategorySet) []*eventsapi.ClientEvent {
+	filtered := make([]*eventsapi.ClientEvent, 0, len(evts))
+	for _, evt := range evts {
+		var metrics []*eventsapi.ClientEventMetric
+		for _, m := range evt.Metrics {
+			if enabled[MetricCategory(m.MetricId)] {
+				metrics = append(metrics, m)
+			}
+		}
+
+		if enabled[CategoryUsage] {
+			if len(metrics) != len(evt.Metrics) {
+				evt = proto.Clone(evt).(*eventsapi.ClientEvent)
+				evt.Metrics = metrics
+			}
+			filtered = append(filtered, evt)
+		} else if len(metrics) > 0 {
+			filtered = append(filtered, &eventsapi.ClientEvent{
+				Id:        evt.Id,
+				StartTime: evt.StartTime,
+				EndTime:   evt.EndTime,
+				Metrics:   metrics,
+			})
+		}
+	}
+	return filtered
+}
+
+// FilteringEmitter is an Emitter which only emits the data of the enabled categories of metrics, as filtered by
+// FilterEvents. If no data is left, its underlying Emitter isn't called.
+type FilteringEmitter struct {
+	Emitter Emitter
+	Enabled CategorySet
+}
+
+var _ Emitter = FilteringEmitter{}
+
+// NewFilteringEmitter returns an Emitter which only emits the data of the categories in |enabled| to |emitter|. If no
+// category is enabled, it returns a NullEmitter.
+func NewFilteringEmitter(emitter Emitter, enabled CategorySet) Emitter {
+	if enabled.Empty() {
+		return NullEmitter{}
+	}
+	return FilteringEmitter{Emitter: emitter, Enabled: enabled}
+}
+
+func (fe FilteringEmitter) LogEvents(ctx context.Context, version string, evts []*eventsapi.ClientEvent) error {
+	evts = FilterEvents(evts, fe.Enabled)
+	if len(evts) == 0 {
+		return nil
+	}
+	return fe.Emitter.LogEvents(ctx, version, evts)
+}
+
+func (fe FilteringEmitter) LogEventsRequest(ctx context.Context, req *eventsapi.LogEventsRequest) error {
+	evts := FilterEvents(req.Events, fe.Enabled)
+	if len(evts) == 0 {
+		return nil
+	}
+	return fe.Emitter.LogEventsRequest(ctx, &eventsapi.LogEventsRequest{
+		MachineId: req.MachineId,
+		Extra:     req.Extra,
+		Version:   req.Version,
+		Platform:  req.Platform,
+		Events:    evts,
+		App:       req.App,
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+func TestEnabledCategories(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      map[string]string
+		expected CategorySet
+		err      bool
+	}{
+		{
+			name:     "defaults",
+			cfg:      map[string]string{},
+			expected: CategorySet{CategoryUsage: true, CategoryPerformance: true, CategoryErrors: true},
+		},
+		{
+			name:     "disabled",
+			cfg:      map[string]string{config.MetricsDisabled: "true", config.MetricsErrors: "true"},
+			expected: CategorySet{CategoryUsage: false, CategoryPerformance: false, CategoryErrors: false},
+		},
+		{
+			name:     "opt out of usage",
+			cfg:      map[string]string{config.MetricsUsage: "false"},
+			expected: CategorySet{CategoryUsage: false, CategoryPerformance: true, CategoryErrors: true},
+		},
+		{
+			name:     "opt out of all",
+			cfg:      map[string]string{config.MetricsUsage: "false", config.MetricsPerformance: "false", config.MetricsErrors: "false"},
+			expected: CategorySet{CategoryUsage: false, CategoryPerformance: false, CategoryErrors: false},
+		},
+		{
+			name: "invalid",
+			cfg:  map[string]string{config.MetricsErrors: "sometimes"},
+			err:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			enabled, err := EnabledCategories(config.NewMapConfig(test.cfg))
+			if test.err {
+				assert.Error(t, err)
+				assert.True(t, enabled.Empty())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, enabled)
+		})
+	}
+}
+
+func TestFilterEvents(t *testing.T) {
+	newEvents := func() []*eventsapi.ClientEvent {
+		clone := NewEvent(eventsapi.ClientEventType_CLONE)
+		clone.SetAttribute(eventsapi.AttributeID_REMOTE_URL_SCHEME, "https")
+		clone.AddMetric(NewCounter(eventsapi.MetricID_REMOTEAPI_RPC_ERROR))
+		clone.AddMetric(NewCounter(eventsapi.MetricID_BYTES_DOWNLOADED))
+		sql := NewEvent(eventsapi.ClientEventType_SQL)
+
+		collector := NewCollector("test", nil)
+		collector.CloseEventAndAdd(clone)
+		collector.CloseEventAndAdd(sql)
+		return collector.Close()
+	}
+
+	t.Run("all", func(t *testing.T) {
+		evts := newEvents()
+		filtered := FilterEvents(evts, CategorySet{CategoryUsage: true, CategoryPerformance: true, CategoryErrors: true})
+		assert.Equal(t, evts, filtered)
+	})
+
+	t.Run("no errors", func(t *testing.T) {
+		evts := newEvents()
+		filtered := FilterEvents(evts, CategorySet{CategoryUsage: true, CategoryPerformance: true})
+		require.Len(t, filtered, 2)
+		require.Len(t, filtered[0].Metrics, 1)
+		assert.Equal(t, eventsapi.MetricID_BYTES_DOWNLOADED, filtered[0].Metrics[0].MetricId)
+		assert.Len(t, filtered[0].Attributes, 1)
+		// the logged events are left as is
+		assert.Len(t, evts[0].Metrics, 2)
+	})
+
+	t.Run("no usage", func(t *testing.T) {
+		evts := newEvents()
+		filtered := FilterEvents(evts, CategorySet{CategoryErrors: true})
+		require.Len(t, filtered, 1)
+		assert.Equal(t, evts[0].Id, filtered[0].Id)
+		assert.Equal(t, eventsapi.ClientEventType_TYPE_UNSPECIFIED, filtered[0].Type)
+		assert.Empty(t, filtered[0].Attributes)
+		require.Len(t, filtered[0].Metrics, 1)
+		assert.Equal(t, eventsapi.MetricID_REMOTEAPI_RPC_ERROR, filtered[0].Metrics[0].MetricId)
+	})
+}
+
+type recordingEmitter struct {
+	NullEmitter
+	evts []*eventsapi.ClientEvent
+}
+
+func (re *recordingEmitter) LogEvents(ctx context.Context, version string, evts []*eventsapi.ClientEvent) error {
+	re.evts = append(re.evts, evts...)
+	return nil
+}
+
+func TestFilteringEmitter(t *testing.T) {
+	assert.Equal(t, NullEmitter{}, NewFilteringEmitter(&recordingEmitter{}, CategorySet{CategoryUsage: false}))
+
+	rec := &recordingEmitter{}
+	emitter := NewFilteringEmitter(rec, CategorySet{CategoryPerformance: true})
+
+	sql := NewEvent(eventsapi.ClientEventType_SQL)
+	collector := NewCollector("test", nil)
+	collector.CloseEventAndAdd(sql)
+	require.NoError(t, emitter.LogEvents(context.Background(), "test", collector.Close()))
+	assert.Nil(t, rec.evts)
+
+	push := NewEvent(eventsapi.ClientEventType_PUSH)
+	push.AddMetric(NewCounter(eventsapi.MetricID_BYTES_DOWNLOADED))
+	collector = NewCollector("test", nil)
+	collector.CloseEventAndAdd(push)
+	require.NoError(t, emitter.LogEvents(context.Background(), "test", collector.Close()))
+	require.Len(t, rec.evts, 1)
+	assert.Equal(t, eventsapi.ClientEventType_TYPE_UNSPECIFIED, rec.evts[0].Type)
+}
@@ -0,0 +1,259 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+)
+
+const (
+	defaultAdjustInterval = 500 * time.Millisecond
+
+	// Changes in throughput smaller than this fraction are treated as noise.
+	throughputTolerance = 0.05
+
+	// Above this system-wide CPU utilization, in percent, a pool is not grown.
+	highCPUUtilization = 90.0
+)
+
+// Pool identifies one of the worker pools whose concurrency is managed by a |Controller|. The name of a pool is
+// used to override its concurrency, see |LimitsFor|.
+type Pool string
+
+const (
+	// GC is the pool of threads which walk the refs of reachable chunks during garbage collection.
+	GC Pool = "gc"
+	// Fetch is the pool of threads which download chunks and table files from a remote.
+	Fetch Pool = "fetch"
+)
+
+// Limits bounds the number of workers of a pool that may run at once.
+type Limits struct {
+	// Min is the fewest workers the pool is scaled down to. It is at least 1.
+	Min int
+	// Max is the most workers the pool is scaled up to.
+	Max int
+	// Initial is the number of workers the pool starts with.
+	Initial int
+}
+
+func (l Limits) normalize() Limits {
+	if l.Min < 1 {
+		l.Min = 1
+	}
+	if l.Max < l.Min {
+		l.Max = l.Min
+	}
+	if l.Initial < l.Min {
+		l.Initial = l.Min
+	} else if l.Initial > l.Max {
+		l.Initial = l.Max
+	}
+	return l
+}
+
+// A |Controller| adapts the number of workers of a pool that run at once to the throughput the pool achieves. The
+// pool runs |Max| workers, and each of them calls |Acquire| before it takes on an item of work and |Release| when
+// it is done with it, reporting the units of work it completed, such as values or bytes. While |Run| is running,
+// the controller measures the pool's throughput at regular intervals and hill-climbs on it: it keeps changing the
+// limit in the same direction while throughput improves, reverses when throughput gets worse, and prefers fewer
+// workers when a change makes no difference. It does not add workers while the machine's CPUs are saturated.
+//
+// A pool whose concurrency is fixed by an override keeps its initial limit.
+type Controller struct {
+	limits   Limits
+	fixed    bool
+	interval time.Duration
+	cpuBusy  func() float64
+
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiting int
+	// Closed and replaced when a permit may have become available.
+	wakeCh chan struct{}
+
+	completed atomic.Int64
+
+	// Tuning state, only accessed by |Run|.
+	lastRate float64
+	dir      int
+}
+
+// NewController returns a |Controller| for the pool |pool| with the default limits |def|, which may be overridden
+// by the user, see |LimitsFor|.
+func NewController(pool Pool, def Limits) *Controller {
+	limits, fixed := LimitsFor(pool, def)
+	return &Controller{
+		limits:   limits,
+		fixed:    fixed,
+		interval: defaultAdjustInterval,
+		cpuBusy:  systemCPUBusy,
+		limit:    limits.Initial,
+		wakeCh:   make(chan struct{}),
+		dir:      1,
+	}
+}
+
+// Max returns the most workers that may run at once, which is the number of workers the pool should start.
+func (c *Controller) Max() int {
+	return c.limits.Max
+}
+
+// Limit returns the number of workers currently allowed to run at once.
+func (c *Controller) Limit() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit
+}
+
+// Acquire blocks until the calling worker is allowed to run, or until |ctx| is done.
+func (c *Controller) Acquire(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if c.active < c.limit {
+			c.active++
+			c.mu.Unlock()
+			return nil
+		}
+		c.waiting++
+		wakeCh := c.wakeCh
+		c.mu.Unlock()
+
+		select {
+		case <-wakeCh:
+			c.mu.Lock()
+			c.waiting--
+			c.mu.Unlock()
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.waiting--
+			c.mu.Unlock()
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// Record reports |units| units of work completed by a worker which holds a permit, for workers whose items of work
+// are long-running.
+func (c *Controller) Record(units int64) {
+	c.completed.Add(units)
+}
+
+// Release returns the permit of a worker which completed |units| units of work since it called |Acquire| and did
+// not already report with |Record|.
+func (c *Controller) Release(units int64) {
+	c.completed.Add(units)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.wake()
+}
+
+// wake signals the workers blocked in |Acquire|. Must be called with |mu| held.
+func (c *Controller) wake() {
+	if c.waiting > 0 {
+		close(c.wakeCh)
+		c.wakeCh = make(chan struct{})
+	}
+}
+
+func (c *Controller) setLimit(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n > c.limit {
+		c.limit = n
+		c.wake()
+	} else {
+		// Workers over the new limit finish their current item of work first.
+		c.limit = n
+	}
+}
+
+// Run adjusts the limit of the pool until |done| is closed or |ctx| is done. It returns immediately if the pool's
+// concurrency is fixed.
+func (c *Controller) Run(ctx context.Context, done <-chan struct{}) error {
+	if c.fixed || c.limits.Min == c.limits.Max {
+		return nil
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case now := <-ticker.C:
+			units := c.completed.Swap(0)
+			elapsed := now.Sub(last).Seconds()
+			last = now
+			if elapsed > 0 {
+				c.adjust(float64(units)/elapsed, c.cpuBusy())
+			}
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// adjust moves the limit one step, given the throughput |rate| the pool achieved at the current limit and the
+// system-wide CPU utilization |cpuBusy|.
+func (c *Controller) adjust(rate, cpuBusy float64) {
+	if rate == 0 {
+		// No work was completed, so there is nothing to learn from this interval.
+		return
+	}
+	prev := c.lastRate
+	c.lastRate = rate
+	switch {
+	case prev == 0:
+		// The first measurement; keep going in the initial direction.
+	case rate < prev*(1-throughputTolerance):
+		c.dir = -c.dir
+	case rate <= prev*(1+throughputTolerance):
+		c.dir = -1
+	}
+	if c.dir > 0 && cpuBusy >= highCPUUtilization {
+		return
+	}
+
+	limit := c.Limit()
+	step := limit / 4
+	if step < 1 {
+		step = 1
+	}
+	next := limit + c.dir*step
+	if next < c.limits.Min {
+		next = c.limits.Min
+	} else if next > c.limits.Max {
+		next = c.limits.Max
+	}
+	if next != limit {
+		c.setLimit(next)
+	}
+}
+
+func systemCPUBusy() float64 {
+	pcts, err := cpu.Percent(0, false)
+	if err != nil || len(pcts) == 0 {
+		return 0
+	}
+	return pcts[0]
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+func TestLimitsFor(t *testing.T) {
+	def := Limits{Min: 1, Max: 8, Initial: 4}
+	tests := []struct {
+		env    string
+		limits Limits
+		fixed  bool
+	}{
+		{"", def, false},
+		{"gc=3", Limits{Min: 3, Max: 3, Initial: 3}, true},
+		{"fetch=3", def, false},
+		{"gc=2-16", Limits{Min: 2, Max: 16, Initial: 4}, false},
+		{"gc=6-16", Limits{Min: 6, Max: 16, Initial: 6}, false},
+		{"gc=fixed", def, true},
+		{"*=2", Limits{Min: 2, Max: 2, Initial: 2}, true},
+		{"gc=5, *=2", Limits{Min: 5, Max: 5, Initial: 5}, true},
+		{"*=2,GC=5", Limits{Min: 5, Max: 5, Initial: 5}, true},
+		{"gc=0", def, false},
+		{"gc=8-2", def, false},
+		{"gc", def, false},
+		{"gc=lots", def, false},
+	}
+	for _, test := range tests {
+		t.Run(test.env, func(t *testing.T) {
+			t.Setenv(dconfig.EnvWorkerConcurrency, test.env)
+			limits, fixed := LimitsFor(GC, def)
+			assert.Equal(t, test.limits, limits)
+			assert.Equal(t, test.fixed, fixed)
+		})
+	}
+}
+
+func TestControllerAcquireRespectsLimit(t *testing.T) {
+	ctrl := NewController(GC, Limits{Min: 1, Max: 8, Initial: 2})
+	ctx := context.Background()
+
+	var running, maxRunning atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < ctrl.Max(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.NoError(t, ctrl.Acquire(ctx))
+				n := running.Add(1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				running.Add(-1)
+				ctrl.Release(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, maxRunning.Load(), int64(2))
+	assert.Equal(t, int64(8*20), ctrl.completed.Load())
+
+	// A canceled Acquire returns an error while every permit is held.
+	require.NoError(t, ctrl.Acquire(ctx))
+	require.NoError(t, ctrl.Acquire(ctx))
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, ctrl.Acquire(cctx))
+
+	// Raising the limit wakes waiting workers.
+	acquired := make(chan error)
+	go func() {
+		acquired <- ctrl.Acquire(ctx)
+	}()
+	ctrl.setLimit(3)
+	assert.NoError(t, <-acquired)
+}
+
+func TestControllerAdjust(t *testing.T) {
+	ctrl := NewController(GC, Limits{Min: 1, Max: 16, Initial: 4})
+
+	// grows while throughput improves
+	ctrl.adjust(100, 0)
+	assert.Equal(t, 5, ctrl.Limit())
+	ctrl.adjust(120, 0)
+	assert.Equal(t, 6, ctrl.Limit())
+
+	// no work completed, nothing changes
+	ctrl.adjust(0, 0)
+	assert.Equal(t, 6, ctrl.Limit())
+
+	// throughput got worse, so back off
+	ctrl.adjust(90, 0)
+	assert.Equal(t, 5, ctrl.Limit())
+
+	// throughput is unchanged, so prefer fewer workers
+	ctrl.adjust(91, 0)
+	assert.Equal(t, 4, ctrl.Limit())
+
+	// throughput got worse with fewer workers, so grow again, but not while the CPUs are saturated
+	ctrl.adjust(50, 95)
+	assert.Equal(t, 4, ctrl.Limit())
+	ctrl.adjust(25, 0)
+	assert.Equal(t, 3, ctrl.Limit())
+	ctrl.adjust(10, 0)
+	assert.Equal(t, 4, ctrl.Limit())
+
+	// the limit stays within bounds
+	rate := 100.0
+	for i := 0; i < 20; i++ {
+		rate *= 2
+		ctrl.adjust(rate, 0)
+	}
+	assert.Equal(t, 16, ctrl.Limit())
+	for i := 0; i < 20; i++ {
+		ctrl.adjust(rate, 0)
+	}
+	assert.Equal(t, 1, ctrl.Limit())
+}
+
+func TestControllerFixed(t *testing.T) {
+	t.Setenv(dconfig.EnvWorkerConcurrency, "gc=3")
+	ctrl := NewController(GC, Limits{Min: 1, Max: 16, Initial: 4})
+	assert.Equal(t, 3, ctrl.Max())
+	assert.Equal(t, 3, ctrl.Limit())
+
+	// Run returns immediately for fixed pools
+	assert.NoError(t, ctrl.Run(context.Background(), make(chan struct{})))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+// allPools is the name which overrides the concurrency of every pool that has no override of its own.
+const allPools = "*"
+
+// LimitsFor returns the limits of the pool |pool|, which are |def| unless they are overridden, and whether the
+// pool's concurrency is fixed rather than adapted to its throughput.
+//
+// Overrides are given in the DOLT_WORKER_CONCURRENCY environment variable as a comma separated list of
+// |pool=setting| entries, where the pool may be * to apply to every pool without an entry of its own. A setting is
+// one of:
+//   - N, to fix the pool at N workers
+//   - MIN-MAX, to adapt the pool between MIN and MAX workers
+//   - fixed, to keep the pool at its default initial size
+//
+// For example, DOLT_WORKER_CONCURRENCY=gc=2,fetch=4-32. Malformed entries are ignored.
+func LimitsFor(pool Pool, def Limits) (Limits, bool) {
+	def = def.normalize()
+	overrides := os.Getenv(dconfig.EnvWorkerConcurrency)
+	if overrides == "" {
+		return def, false
+	}
+
+	var setting string
+	var found bool
+	for _, entry := range strings.Split(overrides, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == string(pool) {
+			setting, found = strings.TrimSpace(value), true
+			break
+		} else if name == allPools && !found {
+			setting, found = strings.TrimSpace(value), true
+		}
+	}
+	if !found {
+		return def, false
+	}
+	return applyOverride(def, setting)
+}
+
+func applyOverride(def Limits, setting string) (Limits, bool) {
+	if strings.EqualFold(setting, "fixed") {
+		return def, true
+	}
+	if lo, hi, ok := strings.Cut(setting, "-"); ok {
+		min, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil || min < 1 {
+			return def, false
+		}
+		max, err := strconv.Atoi(strings.TrimSpace(hi))
+		if err != nil || max < min {
+			return def, false
+		}
+		return Limits{Min: min, Max: max, Initial: def.Initial}.normalize(), false
+	}
+	n, err := strconv.Atoi(setting)
+	if err != nil || n < 1 {
+		return def, false
+	}
+	return Limits{Min: n, Max: n, Initial: n}, true
+}
@@ -15,22 +15,33 @@
 package config
 
 var ConfigOptions = map[string]struct{}{
-	UserEmailKey:          {},
-	UserNameKey:           {},
-	UserCreds:             {},
-	DoltEditor:            {},
-	InitBranchName:        {},
-	RemotesApiHostKey:     {},
-	RemotesApiHostPortKey: {},
-	AddCredsUrlKey:        {},
-	DoltLabInsecureKey:    {},
-	MetricsDisabled:       {},
-	MetricsHost:           {},
-	MetricsPort:           {},
-	MetricsInsecure:       {},
-	PushAutoSetupRemote:   {},
-	ProfileKey:            {},
-	VersionCheckDisabled:  {},
+	UserEmailKey:                  {},
+	UserNameKey:                   {},
+	UserCreds:                     {},
+	DoltEditor:                    {},
+	InitBranchName:                {},
+	RemotesApiHostKey:             {},
+	RemotesApiHostPortKey:         {},
+	RemotesMaxDownloadRate:        {},
+	RemotesMaxUploadRate:          {},
+	RemotesMaxConcurrentDownloads: {},
+	RemotesCABundle:               {},
+	RemotesTLSMinVersion:          {},
+	AddCredsUrlKey:                {},
+	CredsHelper:                   {},
+	DoltLabInsecureKey:            {},
+	MetricsDisabled:               {},
+	MetricsUsage:                  {},
+	MetricsPerformance:            {},
+	MetricsErrors:                 {},
+	MetricsHost:                   {},
+	MetricsPort:                   {},
+	MetricsInsecure:               {},
+	PushAutoSetupRemote:           {},
+	ProfileKey:                    {},
+	VersionCheckDisabled:          {},
+	UpgradeChannel:                {},
+	UpgradeSigningKey:             {},
 }
 
 const UserEmailKey = "user.email"
@@ -47,12 +58,30 @@ const RemotesApiHostKey = "remotes.default_host"
 
 const RemotesApiHostPortKey = "remotes.default_port"
 
+const RemotesMaxDownloadRate = "remotes.max_download_rate"
+
+const RemotesMaxUploadRate = "remotes.max_upload_rate"
+
+const RemotesMaxConcurrentDownloads = "remotes.max_concurrent_downloads"
+
+const RemotesCABundle = "remotes.ca_bundle"
+
+const RemotesTLSMinVersion = "remotes.tls_min_version"
+
 const AddCredsUrlKey = "creds.add_url"
 
+const CredsHelper = "creds.helper"
+
 const DoltLabInsecureKey = "doltlab.insecure"
 
 const MetricsDisabled = "metrics.disabled"
 
+const MetricsUsage = "metrics.usage"
+
+const MetricsPerformance = "metrics.performance"
+
+const MetricsErrors = "metrics.errors"
+
 const MetricsHost = "metrics.host"
 
 const MetricsPort = "metrics.port"
@@ -65,6 +94,10 @@ const ProfileKey = "profile"
 
 const VersionCheckDisabled = "versioncheck.disabled"
 
+const UpgradeChannel = "upgrade.channel"
+
+const UpgradeSigningKey = "upgrade.signingkey"
+
 const SignCommitsKey = "commit.gpgsign"
 
 const GPGSigningKeyKey = "user.signingkey"
@@ -0,0 +1,212 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics captures crash reports for dolt. Reports are only ever written to the local filesystem, and are
+// redacted so that they don't contain user data: commands are recorded by name with the names of their flags, but not
+// their arguments or flag values, and quoted strings, paths, URL credentials and the values of key=value pairs are
+// removed from panic messages.
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// DirName is the name of the directory, inside of a .dolt directory, that crash reports are written to.
+const DirName = "diagnostics"
+
+const (
+	reportPrefix = "crash-"
+	reportExt    = ".json"
+	// MaxReports is the number of crash reports kept in a diagnostics directory. Older reports are deleted when a new
+	// one is written.
+	MaxReports = 10
+	// MaxRecentOps is the number of recent operations included in a crash report.
+	MaxRecentOps = 32
+)
+
+// Op is an operation run before a crash, such as a dolt command.
+type Op struct {
+	Time time.Time `json:"time"`
+	Name string    `json:"name"`
+}
+
+var recentOps = struct {
+	mu  sync.Mutex
+	ops []Op
+}{}
+
+// RecordOp records that the command |command| was run with |args|. Only the names of the flags in |args| are kept.
+func RecordOp(command string, args []string) {
+	name := strings.Join(append([]string{command}, flagNames(args)...), " ")
+
+	recentOps.mu.Lock()
+	defer recentOps.mu.Unlock()
+	recentOps.ops = append(recentOps.ops, Op{Time: time.Now().UTC(), Name: name})
+	if len(recentOps.ops) > MaxRecentOps {
+		recentOps.ops = recentOps.ops[len(recentOps.ops)-MaxRecentOps:]
+	}
+}
+
+// RecentOps returns the most recently recorded operations, oldest first.
+func RecentOps() []Op {
+	recentOps.mu.Lock()
+	defer recentOps.mu.Unlock()
+	return append([]Op(nil), recentOps.ops...)
+}
+
+// flagNames returns the flags in |args| without their values, e.g. --message for --message=msg. Arguments after a
+// "--" are not flags.
+func flagNames(args []string) []string {
+	var names []string
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+		if i := strings.IndexByte(arg, '='); i >= 0 {
+			arg = arg[:i]
+		}
+		names = append(names, arg)
+	}
+	return names
+}
+
+// Report is the diagnostic information captured when dolt crashes.
+type Report struct {
+	Time      time.Time `json:"time"`
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	RecentOps []Op      `json:"recent_ops"`
+}
+
+// NewReport returns a Report of the panic |recovered|, which was raised at |stack|, in dolt version |version|.
+func NewReport(version string, recovered interface{}, stack []byte) Report {
+	return Report{
+		Time:      time.Now().UTC(),
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Panic:     fmt.Sprintf("%T: %s", recovered, Redact(fmt.Sprint(recovered))),
+		Stack:     string(stack),
+		RecentOps: RecentOps(),
+	}
+}
+
+var quotedRegex = regexp.MustCompile("'[^']*'|\"[^\"]*\"|`[^`]*`")
+var pathRegex = regexp.MustCompile(`(?:[A-Za-z]:)?(?:[/\\][^\s/\\:'"]+){2,}[/\\]?`)
+var urlRegex = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://)(?:([^\s/?#@]*)@)?([^\s/?#]*)([/?#](?:[^\s]*[^\s.,;:!?)\]])?)?`)
+var keyValueRegex = regexp.MustCompile(`\b([A-Za-z_][\w.-]*)=[^\s,;&<][^\s,;&]*`)
+
+// Redact removes quoted strings, file paths, the credentials and paths of URLs, and the values of key=value pairs,
+// any of which may name or contain user data or secrets, from the message |msg|.
+func Redact(msg string) string {
+	msg = quotedRegex.ReplaceAllString(msg, "<redacted>")
+
+	// URLs are redacted separately, so that their paths aren't taken for file paths
+	var b strings.Builder
+	last := 0
+	for _, loc := range urlRegex.FindAllStringSubmatchIndex(msg, -1) {
+		b.WriteString(pathRegex.ReplaceAllString(msg[last:loc[0]], "<path>"))
+		b.WriteString(msg[loc[2]:loc[3]])
+		if loc[4] >= 0 {
+			b.WriteString("<redacted>@")
+		}
+		b.WriteString(msg[loc[6]:loc[7]])
+		if loc[8] >= 0 {
+			b.WriteString("/<path>")
+		}
+		last = loc[1]
+	}
+	b.WriteString(pathRegex.ReplaceAllString(msg[last:], "<path>"))
+
+	return keyValueRegex.ReplaceAllString(b.String(), "$1=<redacted>")
+}
+
+// WriteReport writes |r| to a new file in the directory |dir|, creating it if necessary, and returns the file's path.
+// Only the latest MaxReports reports in |dir| are kept.
+func WriteReport(fs filesys.Filesys, dir string, r Report) (string, error) {
+	if err := fs.MkDirs(dir); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, reportPrefix+r.Time.Format("20060102T150405.000000000")+reportExt)
+	if err := fs.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return "", err
+	}
+
+	reports, err := ListReports(fs, dir)
+	if err != nil {
+		return "", err
+	}
+	for len(reports) > MaxReports {
+		if err = fs.DeleteFile(reports[0]); err != nil {
+			return "", err
+		}
+		reports = reports[1:]
+	}
+
+	return path, nil
+}
+
+// ListReports returns the paths of the crash reports in |dir|, oldest first. It returns no reports if |dir| doesn't
+// exist.
+func ListReports(fs filesys.Filesys, dir string) ([]string, error) {
+	if exists, isDir := fs.Exists(dir); !exists || !isDir {
+		return nil, nil
+	}
+
+	var reports []string
+	err := fs.Iter(dir, false, func(path string, _ int64, isDir bool) (stop bool) {
+		name := filepath.Base(path)
+		if !isDir && strings.HasPrefix(name, reportPrefix) && strings.HasSuffix(name, reportExt) {
+			reports = append(reports, path)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// report names sort by the time they were written
+	sort.Slice(reports, func(i, j int) bool {
+		return filepath.Base(reports[i]) < filepath.Base(reports[j])
+	})
+	return reports, nil
+}
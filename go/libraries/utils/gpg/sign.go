@@ -20,7 +20,9 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/sync/errgroup"
@@ -149,6 +151,60 @@ func Verify(ctx context.Context, signature []byte) ([]byte, error) {
 	return errBuf.Bytes(), nil
 }
 
+// VerifyContent verifies a clear-signed signature, and returns the content which was signed along with the output of
+// gpg describing the signature
+func VerifyContent(ctx context.Context, signature []byte) ([]byte, []byte, error) {
+	args := []string{"--verify", "--output", "-"}
+	outBuf, errBuf, err := execGpgAndReadOutput(ctx, signature, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return outBuf.Bytes(), errBuf.Bytes(), nil
+}
+
+// VerifyContentSigners verifies a clear-signed signature, and returns the content which was signed along with the
+// fingerprints of the keys which made its valid signatures. The fingerprints are read from gpg's machine-readable
+// status output, rather than its description of the signature, which contains text from the key's user ids. For a
+// signature made by a subkey, the fingerprints of both the subkey and its primary key are returned.
+func VerifyContentSigners(ctx context.Context, signature []byte) ([]byte, []string, error) {
+	dir, err := os.MkdirTemp("", "dolt-gpg-verify-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// the content is written to a file so that stdout only holds status lines
+	contentPath := filepath.Join(dir, "content")
+	args := []string{"--batch", "--status-fd", "1", "--output", contentPath, "--verify"}
+	outBuf, _, err := execGpgAndReadOutput(ctx, signature, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := os.ReadFile(contentPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, validSignatureFingerprints(outBuf.String()), nil
+}
+
+// validSignatureFingerprints returns the key fingerprints of the VALIDSIG lines of gpg's status output |status|.
+func validSignatureFingerprints(status string) []string {
+	var fingerprints []string
+	for _, line := range strings.Split(status, "\n") {
+		// [GNUPG:] VALIDSIG <fpr> <date> <timestamp> <expire> <version> <reserved> <algo> <hash> <class> <primary fpr>
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" || fields[1] != "VALIDSIG" {
+			continue
+		}
+		fingerprints = append(fingerprints, fields[2])
+		if len(fields) > 11 && fields[11] != fields[2] {
+			fingerprints = append(fingerprints, fields[11])
+		}
+	}
+	return fingerprints
+}
+
 func listenToOut(ctx context.Context, eg *errgroup.Group, r io.Reader) *bytes.Buffer {
 	buf := bytes.NewBuffer(nil)
 	eg.Go(func() error {
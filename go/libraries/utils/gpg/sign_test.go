@@ -49,6 +49,22 @@ func TestSign(t *testing.T) {
 	require.NotNil(t, output)
 }
 
+func TestValidSignatureFingerprints(t *testing.T) {
+	status := `[GNUPG:] NEWSIG
+[GNUPG:] KEY_CONSIDERED 573DA8C6366D04E35CDB1A44E09A0B208F666373 0
+[GNUPG:] GOODSIG E09A0B208F666373 Test User (VALIDSIG 0000000000000000000000000000000000000000) <test@example.com>
+[GNUPG:] VALIDSIG 47A5B2B3D0E1F6C2A7B8C9D0E1F2A3B4C5D6E7F8 2024-08-22 1724436722 0 4 0 1 8 01 573DA8C6366D04E35CDB1A44E09A0B208F666373
+[GNUPG:] TRUST_UNDEFINED 0 pgp
+[GNUPG:] VALIDSIG 573DA8C6366D04E35CDB1A44E09A0B208F666373 2024-08-22 1724436722 0 4 0 1 8 01 573DA8C6366D04E35CDB1A44E09A0B208F666373
+`
+	require.Equal(t, []string{
+		"47A5B2B3D0E1F6C2A7B8C9D0E1F2A3B4C5D6E7F8",
+		"573DA8C6366D04E35CDB1A44E09A0B208F666373",
+		"573DA8C6366D04E35CDB1A44E09A0B208F666373",
+	}, validSignatureFingerprints(status))
+	require.Empty(t, validSignatureFingerprints("[GNUPG:] BADSIG E09A0B208F666373 Test User <test@example.com>\n"))
+}
+
 func TestDecodeAllPemBlocks(t *testing.T) {
 	pemBlock := `
 -----BEGIN PGP SIGNED MESSAGE-----
@@ -0,0 +1,146 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hll implements HyperLogLog sketches for estimating the number
+// of distinct values in a stream. Sketches of disjoint or overlapping
+// streams can be merged to estimate the distinct values of their union.
+package hll
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// Precision is the number of hash bits used to pick a register. With
+// 2^10 registers the standard error of an estimate is about 3%.
+const Precision = 10
+
+const numRegisters = 1 << Precision
+
+// Sketch is a HyperLogLog sketch. The zero value is not usable, use New.
+type Sketch struct {
+	regs []uint8
+}
+
+// New returns an empty Sketch.
+func New() *Sketch {
+	return &Sketch{regs: make([]uint8, numRegisters)}
+}
+
+// Add adds the value |data| to the sketch.
+func (s *Sketch) Add(data []byte) {
+	s.AddHash(xxhash.Sum64(data))
+}
+
+// AddHash adds a value with the 64-bit hash |h| to the sketch.
+func (s *Sketch) AddHash(h uint64) {
+	idx := h >> (64 - Precision)
+	rho := uint8(bits.LeadingZeros64(h<<Precision|1<<(Precision-1)) + 1)
+	if rho > s.regs[idx] {
+		s.regs[idx] = rho
+	}
+}
+
+// Merge merges |other| into the sketch.
+func (s *Sketch) Merge(other *Sketch) {
+	for i, r := range other.regs {
+		if r > s.regs[i] {
+			s.regs[i] = r
+		}
+	}
+}
+
+// Empty returns whether no values were added to the sketch.
+func (s *Sketch) Empty() bool {
+	for _, r := range s.regs {
+		if r != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Estimate returns the estimated number of distinct values added to the sketch.
+func (s *Sketch) Estimate() uint64 {
+	const m = float64(numRegisters)
+	alpha := 0.7213 / (1 + 1.079/m)
+
+	var sum float64
+	var zeros int
+	for _, r := range s.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Encode returns a compact encoding of the sketch that only holds its
+// non-empty registers, see Decode.
+func (s *Sketch) Encode() []byte {
+	buf := make([]byte, 0, 64)
+	for i, r := range s.regs {
+		if r != 0 {
+			buf = binary.BigEndian.AppendUint16(buf, uint16(i)<<6|uint16(r))
+		}
+	}
+	return buf
+}
+
+// Decode decodes a sketch encoded with Encode.
+func Decode(buf []byte) (*Sketch, error) {
+	s := New()
+	if err := s.MergeEncoded(buf); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// MergeEncoded merges a sketch encoded with Encode into the sketch
+// without decoding it first.
+func (s *Sketch) MergeEncoded(buf []byte) error {
+	if len(buf)%2 != 0 {
+		return fmt.Errorf("invalid sketch encoding of length %d", len(buf))
+	}
+	for i := 0; i < len(buf); i += 2 {
+		e := binary.BigEndian.Uint16(buf[i:])
+		idx, r := e>>6, uint8(e&0x3f)
+		if r > s.regs[idx] {
+			s.regs[idx] = r
+		}
+	}
+	return nil
+}
+
+// EstimateEncoded returns the estimated number of distinct values of
+// the union of the encoded sketches |bufs|.
+func EstimateEncoded(bufs ...[]byte) (uint64, error) {
+	s := New()
+	for _, b := range bufs {
+		if err := s.MergeEncoded(b); err != nil {
+			return 0, err
+		}
+	}
+	return s.Estimate(), nil
+}
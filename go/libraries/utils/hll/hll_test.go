@@ -0,0 +1,81 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hll
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSketchEstimate(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 100, 1_000, 10_000, 100_000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			s := New()
+			for i := 0; i < n; i++ {
+				// every value is added twice
+				s.Add([]byte(strconv.Itoa(i)))
+				s.Add([]byte(strconv.Itoa(i)))
+			}
+			assert.Equal(t, n == 0, s.Empty())
+			assert.InEpsilon(t, float64(n)+1, float64(s.Estimate())+1, 0.1)
+		})
+	}
+}
+
+func TestSketchMerge(t *testing.T) {
+	left, right, both := New(), New(), New()
+	for i := 0; i < 6_000; i++ {
+		v := []byte(strconv.Itoa(i))
+		if i < 4_000 {
+			left.Add(v)
+		}
+		if i >= 2_000 {
+			right.Add(v)
+		}
+		both.Add(v)
+	}
+	left.Merge(right)
+	assert.Equal(t, both.Estimate(), left.Estimate())
+	assert.InEpsilon(t, 6_000, float64(left.Estimate()), 0.1)
+}
+
+func TestSketchEncoding(t *testing.T) {
+	s := New()
+	for i := 0; i < 200; i++ {
+		s.Add([]byte(strconv.Itoa(i)))
+	}
+	buf := s.Encode()
+	assert.Less(t, len(buf), 2*200+1)
+
+	decoded, err := Decode(buf)
+	require.NoError(t, err)
+	assert.Equal(t, s.Estimate(), decoded.Estimate())
+
+	other := New()
+	for i := 100; i < 300; i++ {
+		other.Add([]byte(strconv.Itoa(i)))
+	}
+	est, err := EstimateEncoded(buf, other.Encode())
+	require.NoError(t, err)
+	s.Merge(other)
+	assert.Equal(t, s.Estimate(), est)
+
+	_, err = Decode([]byte{1})
+	assert.Error(t, err)
+	assert.Empty(t, New().Encode())
+}
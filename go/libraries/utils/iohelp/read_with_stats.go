@@ -53,19 +53,23 @@ func (rws *ReaderWithStats) Start(updateFunc func(ReadStats)) {
 			case <-rws.closeCh:
 				return
 			case <-timer.C:
-				read := atomic.LoadUint64(&rws.read)
-				elapsed := time.Since(rws.start)
-				var percent float64
-				if rws.size != 0 {
-					percent = float64(read) / float64(rws.size)
-				}
-				updateFunc(ReadStats{Read: read, Elapsed: elapsed, Percent: percent})
+				updateFunc(rws.Stats())
 				timer.Reset(updateFrequency)
 			}
 		}
 	}()
 }
 
+// Stats returns the number of bytes read so far, how long ago Start was called, and the fraction of the size read.
+func (rws *ReaderWithStats) Stats() ReadStats {
+	read := atomic.LoadUint64(&rws.read)
+	var percent float64
+	if rws.size != 0 {
+		percent = float64(read) / float64(rws.size)
+	}
+	return ReadStats{Read: read, Elapsed: time.Since(rws.start), Percent: percent}
+}
+
 func (rws *ReaderWithStats) Close() error {
 	close(rws.closeCh)
 
@@ -48,6 +48,11 @@ func newJWKS(provider JWTProvider) (*fetchedJWKS, error) {
 	return newFetchedJWKS(provider.URL)
 }
 
+// NewFetchedJWKS returns a KeyProvider of the keys of the JSON Web Key Set at |url|.
+func NewFetchedJWKS(url string) (KeyProvider, error) {
+	return newFetchedJWKS(url)
+}
+
 func newFetchedJWKS(url string) (*fetchedJWKS, error) {
 	ret := &fetchedJWKS{
 		URL:   url,
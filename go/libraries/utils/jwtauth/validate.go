@@ -29,7 +29,9 @@ type KeyProvider interface {
 
 var ErrKeyNotFound = errors.New("Key not found")
 
-func ValidateJWT(unparsed string, reqTime time.Time, keyProvider KeyProvider, expectedClaims jwt.Expected) (*Claims, error) {
+// ValidateJWT validates the signature and claims of the JWT |unparsed| and returns its claims. Its claims are also
+// decoded into each of |extra|, such as a map[string]interface{}, to read claims other than those of Claims.
+func ValidateJWT(unparsed string, reqTime time.Time, keyProvider KeyProvider, expectedClaims jwt.Expected, extra ...interface{}) (*Claims, error) {
 	parsed, err := jwt.ParseSigned(unparsed)
 	if err != nil {
 		return nil, err
@@ -55,7 +57,7 @@ func ValidateJWT(unparsed string, reqTime time.Time, keyProvider KeyProvider, ex
 	var claims Claims
 	claimsError := fmt.Errorf("ValidateJWT: KeyID: %v. Err: %w", keyID, ErrKeyNotFound)
 	for _, key := range keys {
-		claimsError = parsed.Claims(key.Key, &claims)
+		claimsError = parsed.Claims(key.Key, append([]interface{}{&claims}, extra...)...)
 		if claimsError == nil {
 			break
 		}
@@ -0,0 +1,138 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapauth
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ErrInvalidCredentials is returned by Bind when the server rejects the DN and password.
+var ErrInvalidCredentials = errors.New("invalid LDAP credentials")
+
+// ErrCleartext is returned by Dial for an ldap:// URL without StartTLS, over which passwords would be sent in
+// cleartext, unless AllowCleartext is set.
+var ErrCleartext = errors.New("LDAP connections must use an ldaps:// URL or StartTLS, since passwords are sent over them")
+
+// DialConfig configures the connection Dial makes to an LDAP server.
+type DialConfig struct {
+	// Timeout bounds the connection and each request made over it.
+	Timeout time.Duration
+	// TLSConfig configures TLS for ldaps:// URLs and StartTLS. Its ServerName defaults to the host of the URL.
+	TLSConfig *tls.Config
+	// StartTLS upgrades connections to ldap:// URLs to TLS before anything else is sent over them.
+	StartTLS bool
+	// AllowCleartext allows connections to ldap:// URLs without StartTLS.
+	AllowCleartext bool
+}
+
+// Client is a connection to an LDAP server, over which users are authenticated with simple binds and their
+// attributes are searched. A Client is not safe for concurrent use.
+type Client struct {
+	conn    *ldap.Conn
+	timeout time.Duration
+}
+
+// Dial connects to the LDAP server at |ldapURL|, an ldap:// or ldaps:// URL, as configured by |config|. Connections
+// to ldap:// URLs are refused with ErrCleartext unless they use StartTLS or allow cleartext.
+func Dial(ldapURL string, config DialConfig) (*Client, error) {
+	u, err := url.Parse(ldapURL)
+	if err != nil {
+		return nil, err
+	}
+	scheme := strings.ToLower(u.Scheme)
+	switch scheme {
+	case "ldap":
+		if !config.StartTLS && !config.AllowCleartext {
+			return nil, ErrCleartext
+		}
+	case "ldaps":
+		if config.StartTLS {
+			return nil, errors.New("StartTLS can't be used with an ldaps:// URL, which is already encrypted")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported LDAP URL scheme '%s', expected ldap or ldaps", u.Scheme)
+	}
+	u.Scheme = scheme
+
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	conn, err := ldap.DialURL(u.String(),
+		ldap.DialWithDialer(&net.Dialer{Timeout: config.Timeout}),
+		ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(config.Timeout)
+	if config.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return &Client{conn: conn, timeout: config.Timeout}, nil
+}
+
+// Bind authenticates as |dn| with |password| using a simple bind. An empty password is rejected without contacting
+// the server, since servers accept it as an unauthenticated bind for any DN.
+func (c *Client) Bind(dn, password string) error {
+	if password == "" {
+		return ErrInvalidCredentials
+	}
+	err := c.conn.Bind(dn, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return ErrInvalidCredentials
+	}
+	return err
+}
+
+// SearchAttribute returns the values of the attribute |attr| of the entry |dn|.
+func (c *Client) SearchAttribute(dn, attr string) ([]string, error) {
+	res, err := c.conn.Search(ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0,
+		int(c.timeout/time.Second), false, "(objectClass=*)", []string{attr}, nil))
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, entry := range res.Entries {
+		values = append(values, entry.GetEqualFoldAttributeValues(attr)...)
+	}
+	return values, nil
+}
+
+// Close unbinds and closes the connection.
+func (c *Client) Close() error {
+	_ = c.conn.Unbind()
+	return c.conn.Close()
+}
+
+// EscapeDN escapes |s| for use as an attribute value in a distinguished name, see RFC 4514.
+func EscapeDN(s string) string {
+	return ldap.EscapeDN(s)
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldapauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is an LDAP server which accepts simple binds with the passwords in |passwords| and returns the
+// attributes in |attributes| from searches. If |tlsConfig| isn't nil, it supports StartTLS.
+type fakeServer struct {
+	passwords  map[string]string
+	attributes map[string]map[string][]string
+	tlsConfig  *tls.Config
+}
+
+func (s fakeServer) serve(t *testing.T, l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(t, conn)
+	}
+}
+
+func (s fakeServer) handle(t *testing.T, conn net.Conn) {
+	defer func() { conn.Close() }()
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		id := msg.Children[0].Value.(int64)
+		reply := func(ops ...*ber.Packet) {
+			for _, op := range ops {
+				p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+				p.AppendChild(op)
+				_, err := conn.Write(p.Bytes())
+				require.NoError(t, err)
+			}
+		}
+		result := func(tag ber.Tag, code int) *ber.Packet {
+			p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+			p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+			p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+			return p
+		}
+
+		op := msg.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			pw, ok := s.passwords[op.Children[1].Data.String()]
+			if ok && pw == op.Children[2].Data.String() {
+				reply(result(ldap.ApplicationBindResponse, ldap.LDAPResultSuccess))
+			} else {
+				reply(result(ldap.ApplicationBindResponse, ldap.LDAPResultInvalidCredentials))
+			}
+		case ldap.ApplicationSearchRequest:
+			dn := op.Children[0].Data.String()
+			entry, ok := s.attributes[dn]
+			if !ok {
+				reply(result(ldap.ApplicationSearchResultDone, ldap.LDAPResultNoSuchObject))
+				continue
+			}
+			e := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+			e.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+			attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			for name, vals := range entry {
+				attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+				attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+				set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+				for _, v := range vals {
+					set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+				}
+				attr.AppendChild(set)
+				attrs.AppendChild(attr)
+			}
+			e.AppendChild(attrs)
+			reply(e, result(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationExtendedRequest:
+			if s.tlsConfig == nil {
+				reply(result(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+				continue
+			}
+			reply(result(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				// the client doesn't trust the certificate
+				return
+			}
+			conn = tlsConn
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	// httptest generates a certificate for 127.0.0.1
+	certServer := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer certServer.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(certServer.Certificate())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	aliceDN := "uid=alice,ou=people,dc=example,dc=com"
+	go fakeServer{
+		passwords: map[string]string{aliceDN: "secret"},
+		attributes: map[string]map[string][]string{
+			aliceDN: {"memberOf": {"cn=admins,dc=example,dc=com", "cn=devs,dc=example,dc=com"}},
+		},
+		tlsConfig: &tls.Config{Certificates: certServer.TLS.Certificates},
+	}.serve(t, l)
+	url := "ldap://" + l.Addr().String()
+
+	t.Run("cleartext refused", func(t *testing.T) {
+		_, err := Dial(url, DialConfig{Timeout: 5 * time.Second})
+		assert.ErrorIs(t, err, ErrCleartext)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := Dial(url, DialConfig{Timeout: 5 * time.Second, StartTLS: true})
+		assert.Error(t, err)
+	})
+
+	for name, config := range map[string]DialConfig{
+		"StartTLS":  {Timeout: 5 * time.Second, StartTLS: true, TLSConfig: &tls.Config{RootCAs: roots}},
+		"cleartext": {Timeout: 5 * time.Second, AllowCleartext: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, err := Dial(url, config)
+			require.NoError(t, err)
+			defer c.Close()
+
+			assert.ErrorIs(t, c.Bind(aliceDN, "wrong"), ErrInvalidCredentials)
+			assert.ErrorIs(t, c.Bind(aliceDN, ""), ErrInvalidCredentials)
+			require.NoError(t, c.Bind(aliceDN, "secret"))
+
+			groups, err := c.SearchAttribute(aliceDN, "memberof")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"cn=admins,dc=example,dc=com", "cn=devs,dc=example,dc=com"}, groups)
+
+			_, err = c.SearchAttribute("uid=bob,ou=people,dc=example,dc=com", "memberOf")
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = Dial("http://"+l.Addr().String(), DialConfig{Timeout: time.Second})
+	assert.Error(t, err)
+}
+
+func TestEscapeDN(t *testing.T) {
+	assert.Equal(t, "alice", EscapeDN("alice"))
+	assert.Equal(t, `a\,b=c\+d`, EscapeDN("a,b=c+d"))
+	assert.Equal(t, `\#a\ `, EscapeDN("#a "))
+	assert.Equal(t, `\\\"`, EscapeDN(`\"`))
+}
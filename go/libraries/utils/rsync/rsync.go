@@ -0,0 +1,368 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rsync implements rsync style delta encoding. The holder of a base file computes its Signature, the holder
+// of a target file computes a delta of the target against the Signature, and the holder of the base file applies the
+// delta to the base file to reconstruct the target file. Blocks of the target which are found in the base, at any
+// offset, are sent as references to the base instead of as literal bytes.
+package rsync
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// DefaultBlockSize is the block size of a Signature when none is requested.
+	DefaultBlockSize = 64 * 1024
+	// MinBlockSize is the smallest block size of a Signature.
+	MinBlockSize = 1024
+	// MaxBlockSize is the largest block size of a Signature.
+	MaxBlockSize = 1024 * 1024
+	// StrongLen is the length of the strong checksum of a block.
+	StrongLen = 8
+)
+
+// maxLiteralLen is the most bytes of a single literal in a delta.
+const maxLiteralLen = 1024 * 1024
+
+var deltaMagic = []byte("DRS1")
+
+const (
+	opEnd     = 0
+	opCopy    = 1
+	opLiteral = 2
+)
+
+var ErrInvalidDelta = errors.New("invalid delta")
+
+// Signature holds the checksums of each full block of a base file. A trailing partial block is not part of the
+// signature.
+type Signature struct {
+	BlockSize int
+	Weak      []uint32
+	Strong    [][StrongLen]byte
+}
+
+// ValidateBlockSize returns an error if |blockSize| is not a valid Signature block size.
+func ValidateBlockSize(blockSize int) error {
+	if blockSize < MinBlockSize || blockSize > MaxBlockSize {
+		return fmt.Errorf("block size %d is outside of the range [%d, %d]", blockSize, MinBlockSize, MaxBlockSize)
+	}
+	return nil
+}
+
+// ComputeSignature reads the base file from |r| and returns its Signature with blocks of |blockSize|.
+func ComputeSignature(r io.Reader, blockSize int) (*Signature, error) {
+	if err := ValidateBlockSize(blockSize); err != nil {
+		return nil, err
+	}
+	sig := &Signature{BlockSize: blockSize}
+	buf := make([]byte, blockSize)
+	for {
+		_, err := io.ReadFull(r, buf)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return sig, nil
+		} else if err != nil {
+			return nil, err
+		}
+		sig.Weak = append(sig.Weak, weakSum(buf))
+		sig.Strong = append(sig.Strong, strongSum(buf))
+	}
+}
+
+// NewSignature returns the Signature with |blockSize| of the checksums encoded by Signature.Checksums.
+func NewSignature(blockSize int, weak, strong []byte) (*Signature, error) {
+	if err := ValidateBlockSize(blockSize); err != nil {
+		return nil, err
+	}
+	if len(weak)%4 != 0 || len(strong)%StrongLen != 0 || len(weak)/4 != len(strong)/StrongLen {
+		return nil, fmt.Errorf("invalid signature: %d bytes of weak checksums and %d bytes of strong checksums", len(weak), len(strong))
+	}
+	n := len(weak) / 4
+	sig := &Signature{
+		BlockSize: blockSize,
+		Weak:      make([]uint32, n),
+		Strong:    make([][StrongLen]byte, n),
+	}
+	for i := 0; i < n; i++ {
+		sig.Weak[i] = binary.BigEndian.Uint32(weak[i*4:])
+		copy(sig.Strong[i][:], strong[i*StrongLen:])
+	}
+	return sig, nil
+}
+
+// Checksums returns the weak checksums of |s| as 4 byte big endian integers, and its strong checksums, concatenated.
+func (s *Signature) Checksums() (weak, strong []byte) {
+	weak = make([]byte, len(s.Weak)*4)
+	strong = make([]byte, 0, len(s.Strong)*StrongLen)
+	for i := range s.Weak {
+		binary.BigEndian.PutUint32(weak[i*4:], s.Weak[i])
+		strong = append(strong, s.Strong[i][:]...)
+	}
+	return weak, strong
+}
+
+// ComputeDelta reads the target file from |r| and writes its delta against the base file of |sig| to |w|.
+func ComputeDelta(sig *Signature, r io.Reader, w io.Writer) error {
+	bs := sig.BlockSize
+	if err := ValidateBlockSize(bs); err != nil {
+		return err
+	}
+	blocks := make(map[uint32][]int, len(sig.Weak))
+	for i, weak := range sig.Weak {
+		blocks[weak] = append(blocks[weak], i)
+	}
+
+	dw := &deltaWriter{w: bufio.NewWriter(w)}
+	dw.writeHeader(bs)
+
+	br := bufio.NewReaderSize(r, 2*bs)
+	var roller rollingSum
+	rolling := false
+	for dw.err == nil {
+		window, err := br.Peek(bs + 1)
+		if len(window) < bs {
+			if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+				return err
+			}
+			dw.literal(window)
+			break
+		}
+		if !rolling {
+			roller = newRollingSum(window[:bs])
+			rolling = true
+		}
+
+		if block, ok := findBlock(sig, blocks, roller.sum(), window[:bs]); ok {
+			dw.copyBlock(block)
+			if _, err := br.Discard(bs); err != nil {
+				return err
+			}
+			rolling = false
+			continue
+		}
+
+		dw.literal(window[:1])
+		if len(window) > bs {
+			roller.roll(window[0], window[bs], bs)
+		} else {
+			rolling = false
+		}
+		if _, err := br.Discard(1); err != nil {
+			return err
+		}
+	}
+	dw.end()
+	if dw.err != nil {
+		return dw.err
+	}
+	return dw.w.Flush()
+}
+
+// ApplyDelta applies the delta read from |delta| to |base| and writes the resulting target file to |w|. It returns
+// the length of the target file.
+func ApplyDelta(base io.ReaderAt, delta io.Reader, w io.Writer) (int64, error) {
+	dr := bufio.NewReader(delta)
+	magic := make([]byte, len(deltaMagic))
+	if _, err := io.ReadFull(dr, magic); err != nil || !bytes.Equal(magic, deltaMagic) {
+		return 0, fmt.Errorf("%w: bad header", ErrInvalidDelta)
+	}
+	bs, err := binary.ReadUvarint(dr)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+	}
+	if err := ValidateBlockSize(int(bs)); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+	}
+
+	var written int64
+	for {
+		op, err := dr.ReadByte()
+		if err != nil {
+			return written, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+		}
+		switch op {
+		case opEnd:
+			return written, nil
+		case opCopy:
+			start, err := binary.ReadUvarint(dr)
+			if err != nil {
+				return written, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+			count, err := binary.ReadUvarint(dr)
+			if err != nil {
+				return written, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+			length := int64(count * bs)
+			n, err := io.Copy(w, io.NewSectionReader(base, int64(start*bs), length))
+			written += n
+			if err != nil {
+				return written, err
+			}
+			if n != length {
+				return written, fmt.Errorf("%w: copy of blocks [%d, %d) is past the end of the base", ErrInvalidDelta, start, start+count)
+			}
+		case opLiteral:
+			length, err := binary.ReadUvarint(dr)
+			if err != nil {
+				return written, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+			if length > maxLiteralLen {
+				return written, fmt.Errorf("%w: literal of %d bytes", ErrInvalidDelta, length)
+			}
+			n, err := io.CopyN(w, dr, int64(length))
+			written += n
+			if err != nil {
+				return written, fmt.Errorf("%w: %v", ErrInvalidDelta, err)
+			}
+		default:
+			return written, fmt.Errorf("%w: unknown op %d", ErrInvalidDelta, op)
+		}
+	}
+}
+
+func findBlock(sig *Signature, blocks map[uint32][]int, weak uint32, data []byte) (int, bool) {
+	candidates, ok := blocks[weak]
+	if !ok {
+		return 0, false
+	}
+	strong := strongSum(data)
+	for _, i := range candidates {
+		if sig.Strong[i] == strong {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// deltaWriter writes the ops of a delta, coalescing adjacent copies and literals. The first error it encounters is
+// kept in |err|, after which it writes nothing.
+type deltaWriter struct {
+	w   *bufio.Writer
+	err error
+
+	literalBuf []byte
+	copyStart  int
+	copyCount  int
+}
+
+func (d *deltaWriter) writeHeader(blockSize int) {
+	d.write(deltaMagic)
+	d.writeUvarint(uint64(blockSize))
+}
+
+func (d *deltaWriter) copyBlock(block int) {
+	d.flushLiteral()
+	if d.copyCount > 0 && d.copyStart+d.copyCount == block {
+		d.copyCount++
+		return
+	}
+	d.flushCopy()
+	d.copyStart, d.copyCount = block, 1
+}
+
+func (d *deltaWriter) literal(p []byte) {
+	d.flushCopy()
+	d.literalBuf = append(d.literalBuf, p...)
+	if len(d.literalBuf) >= maxLiteralLen {
+		d.flushLiteral()
+	}
+}
+
+func (d *deltaWriter) end() {
+	d.flushCopy()
+	d.flushLiteral()
+	d.write([]byte{opEnd})
+}
+
+func (d *deltaWriter) flushCopy() {
+	if d.copyCount == 0 {
+		return
+	}
+	d.write([]byte{opCopy})
+	d.writeUvarint(uint64(d.copyStart))
+	d.writeUvarint(uint64(d.copyCount))
+	d.copyCount = 0
+}
+
+func (d *deltaWriter) flushLiteral() {
+	lit := d.literalBuf
+	for len(lit) > 0 {
+		n := len(lit)
+		if n > maxLiteralLen {
+			n = maxLiteralLen
+		}
+		d.write([]byte{opLiteral})
+		d.writeUvarint(uint64(n))
+		d.write(lit[:n])
+		lit = lit[n:]
+	}
+	d.literalBuf = d.literalBuf[:0]
+}
+
+func (d *deltaWriter) writeUvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	d.write(buf[:n])
+}
+
+func (d *deltaWriter) write(p []byte) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = d.w.Write(p)
+}
+
+// rollingSum is the rsync rolling checksum of a window of bytes, which can be updated in constant time as the window
+// slides forward by one byte.
+type rollingSum struct {
+	a, b uint32
+}
+
+func newRollingSum(p []byte) rollingSum {
+	var r rollingSum
+	l := uint32(len(p))
+	for i, c := range p {
+		r.a += uint32(c)
+		r.b += (l - uint32(i)) * uint32(c)
+	}
+	return r
+}
+
+// roll slides a window of |blockSize| bytes forward by one byte, removing |out| and adding |in|.
+func (r *rollingSum) roll(out, in byte, blockSize int) {
+	r.a = r.a - uint32(out) + uint32(in)
+	r.b = r.b - uint32(blockSize)*uint32(out) + r.a
+}
+
+func (r rollingSum) sum() uint32 {
+	return r.a&0xffff | r.b<<16
+}
+
+func weakSum(p []byte) uint32 {
+	return newRollingSum(p).sum()
+}
+
+func strongSum(p []byte) [StrongLen]byte {
+	var s [StrongLen]byte
+	sum := md5.Sum(p)
+	copy(s[:], sum[:])
+	return s
+}
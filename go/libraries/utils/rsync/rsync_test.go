@@ -0,0 +1,121 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rsync
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randBytes(r *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	r.Read(b)
+	return b
+}
+
+func roundTrip(t *testing.T, base, target []byte, blockSize int) int {
+	sig, err := ComputeSignature(bytes.NewReader(base), blockSize)
+	require.NoError(t, err)
+
+	weak, strong := sig.Checksums()
+	sig, err = NewSignature(blockSize, weak, strong)
+	require.NoError(t, err)
+
+	var delta bytes.Buffer
+	require.NoError(t, ComputeDelta(sig, bytes.NewReader(target), &delta))
+	deltaLen := delta.Len()
+
+	var out bytes.Buffer
+	n, err := ApplyDelta(bytes.NewReader(base), &delta, &out)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(target)), n)
+	assert.True(t, bytes.Equal(target, out.Bytes()))
+	return deltaLen
+}
+
+func TestRollingSum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := randBytes(r, 4096)
+	const bs = 1024
+	roller := newRollingSum(data[:bs])
+	for i := 1; i+bs <= len(data); i++ {
+		roller.roll(data[i-1], data[i+bs-1], bs)
+		require.Equal(t, weakSum(data[i:i+bs]), roller.sum(), "offset %d", i)
+	}
+}
+
+func TestDelta(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const bs = MinBlockSize
+	base := randBytes(r, 200*bs+17)
+
+	t.Run("identical", func(t *testing.T) {
+		deltaLen := roundTrip(t, base, base, bs)
+		assert.Less(t, deltaLen, 100)
+	})
+	t.Run("insertions and edits", func(t *testing.T) {
+		var target []byte
+		target = append(target, randBytes(r, 33)...)
+		target = append(target, base[:50*bs]...)
+		target = append(target, randBytes(r, 500)...)
+		target = append(target, base[60*bs+7:150*bs]...)
+		target = append(target, base[10*bs:20*bs]...)
+		target = append(target, base[170*bs:]...)
+		deltaLen := roundTrip(t, base, target, bs)
+		assert.Less(t, deltaLen, 8*bs)
+	})
+	t.Run("unrelated", func(t *testing.T) {
+		target := randBytes(r, 20*bs+5)
+		deltaLen := roundTrip(t, base, target, bs)
+		assert.Greater(t, deltaLen, len(target))
+	})
+	t.Run("empty", func(t *testing.T) {
+		roundTrip(t, base, nil, bs)
+		roundTrip(t, nil, base, bs)
+	})
+	t.Run("large literal", func(t *testing.T) {
+		target := randBytes(r, 3*maxLiteralLen+1)
+		roundTrip(t, base, target, bs)
+	})
+}
+
+func TestInvalidDelta(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	const bs = MinBlockSize
+	base := randBytes(r, 4*bs)
+	sig, err := ComputeSignature(bytes.NewReader(base), bs)
+	require.NoError(t, err)
+	var delta bytes.Buffer
+	require.NoError(t, ComputeDelta(sig, bytes.NewReader(base), &delta))
+
+	var out bytes.Buffer
+	_, err = ApplyDelta(bytes.NewReader(base[:2*bs]), bytes.NewReader(delta.Bytes()), &out)
+	assert.ErrorIs(t, err, ErrInvalidDelta)
+
+	_, err = ApplyDelta(bytes.NewReader(base), bytes.NewReader(delta.Bytes()[:delta.Len()-1]), &out)
+	assert.ErrorIs(t, err, ErrInvalidDelta)
+
+	_, err = ApplyDelta(bytes.NewReader(base), bytes.NewReader([]byte("garbage")), &out)
+	assert.ErrorIs(t, err, ErrInvalidDelta)
+
+	_, err = NewSignature(bs, make([]byte, 8), make([]byte, StrongLen))
+	assert.Error(t, err)
+	_, err = ComputeSignature(bytes.NewReader(base), 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfupdate replaces the running dolt binary with a released one. Releases are verified before they're
+// installed: the archive for the platform must match its SHA-256 checksum in the release's checksums file, which the
+// caller is responsible for verifying the signature of.
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	// StableChannel is the release channel of the latest full release.
+	StableChannel = "stable"
+	// PrereleaseChannel is the release channel of the latest release, including pre-releases.
+	PrereleaseChannel = "prerelease"
+
+	// ChecksumsAssetName is the name of the release asset listing the SHA-256 checksums of the release's other
+	// assets. It's clear-signed with gpg.
+	ChecksumsAssetName = "dolt-checksums.txt.asc"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+var ErrBinaryNotFound = errors.New("dolt binary not found in archive")
+
+// IsValidChannel returns whether |channel| is the name of a release channel.
+func IsValidChannel(channel string) bool {
+	return channel == StableChannel || channel == PrereleaseChannel
+}
+
+// ArchiveName returns the name of the release asset containing the dolt binary for the platform |goos|/|goarch|,
+// e.g. dolt-linux-amd64.tar.gz.
+func ArchiveName(goos, goarch string) string {
+	if goos == "windows" {
+		return fmt.Sprintf("dolt-%s-%s.zip", goos, goarch)
+	}
+	return fmt.Sprintf("dolt-%s-%s.tar.gz", goos, goarch)
+}
+
+// ParseChecksums parses |content|, in the format written by sha256sum, into a map from file name to hex encoded
+// SHA-256 checksum.
+func ParseChecksums(content []byte) (map[string]string, error) {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksum line '%s'", line)
+		}
+		sum, name := strings.ToLower(fields[0]), strings.TrimPrefix(fields[1], "*")
+		if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+			return nil, fmt.Errorf("invalid checksum for %s", name)
+		}
+		checksums[name] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
+// VerifyChecksum returns ErrChecksumMismatch if the SHA-256 checksum of the file at |path| isn't |expected|.
+func VerifyChecksum(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != strings.ToLower(expected) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+// binaryName is the name of the dolt binary inside of a release archive, which is in a bin directory.
+func binaryName(goos string) string {
+	if goos == "windows" {
+		return "dolt.exe"
+	}
+	return "dolt"
+}
+
+func isBinary(name, goos string) bool {
+	name = path.Clean(filepath.ToSlash(name))
+	return path.Base(name) == binaryName(goos) && path.Base(path.Dir(name)) == "bin"
+}
+
+// ExtractBinary writes the dolt binary for |goos| from the release archive at |archivePath| to |w|.
+func ExtractBinary(archivePath, goos string, w io.Writer) error {
+	if strings.HasSuffix(archivePath, ".zip") {
+		return extractFromZip(archivePath, goos, w)
+	}
+	return extractFromTarGz(archivePath, goos, w)
+}
+
+func extractFromTarGz(archivePath, goos string, w io.Writer) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return ErrBinaryNotFound
+		} else if err != nil {
+			return err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && isBinary(hdr.Name, goos) {
+			_, err = io.Copy(w, tr)
+			return err
+		}
+	}
+}
+
+func extractFromZip(archivePath, goos string, w io.Writer) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() || !isBinary(zf.Name, goos) {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, rc)
+		closeErr := rc.Close()
+		if err != nil {
+			return err
+		}
+		return closeErr
+	}
+	return ErrBinaryNotFound
+}
+
+// Install extracts the dolt binary from the release archive at |archivePath| and atomically replaces the binary at
+// |exePath| with it: |exePath| is either the old binary or the new one, never a partially written file.
+func Install(archivePath, exePath string) error {
+	exeDir := filepath.Dir(exePath)
+	// the new binary is written next to the old one so that it can be renamed over it
+	tmp, err := os.CreateTemp(exeDir, ".dolt-upgrade-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	err = ExtractBinary(archivePath, runtime.GOOS, tmp)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmpPath, 0755); err != nil {
+		return err
+	}
+
+	return replace(tmpPath, exePath)
+}
+
+// replace renames |newPath| over |exePath|. A running binary can't be replaced on Windows, but it can be renamed, so
+// there the old binary is moved aside first and left to be deleted by the next upgrade.
+func replace(newPath, exePath string) error {
+	if runtime.GOOS != "windows" {
+		return os.Rename(newPath, exePath)
+	}
+
+	oldPath := exePath + ".old"
+	_ = os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, exePath); err != nil {
+		_ = os.Rename(oldPath, exePath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveName(t *testing.T) {
+	assert.Equal(t, "dolt-linux-amd64.tar.gz", ArchiveName("linux", "amd64"))
+	assert.Equal(t, "dolt-darwin-arm64.tar.gz", ArchiveName("darwin", "arm64"))
+	assert.Equal(t, "dolt-windows-amd64.zip", ArchiveName("windows", "amd64"))
+}
+
+func TestParseChecksums(t *testing.T) {
+	sum := hex.EncodeToString(make([]byte, sha256.Size))
+	checksums, err := ParseChecksums([]byte(sum + "  dolt-linux-amd64.tar.gz\n\n" + sum + " *dolt-windows-amd64.zip\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"dolt-linux-amd64.tar.gz": sum, "dolt-windows-amd64.zip": sum}, checksums)
+
+	_, err = ParseChecksums([]byte("abc dolt-linux-amd64.tar.gz"))
+	assert.Error(t, err)
+	_, err = ParseChecksums([]byte(sum))
+	assert.Error(t, err)
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(p, []byte("dolt"), 0600))
+	sum := sha256.Sum256([]byte("dolt"))
+
+	assert.NoError(t, VerifyChecksum(p, hex.EncodeToString(sum[:])))
+	err := VerifyChecksum(p, hex.EncodeToString(make([]byte, sha256.Size)))
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+}
+
+func writeTarGz(t *testing.T, p string, files map[string]string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0755, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, os.WriteFile(p, buf.Bytes(), 0600))
+}
+
+func writeZip(t *testing.T, p string, files map[string]string) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, os.WriteFile(p, buf.Bytes(), 0600))
+}
+
+func TestExtractBinary(t *testing.T) {
+	dir := t.TempDir()
+
+	tgz := filepath.Join(dir, "dolt-linux-amd64.tar.gz")
+	writeTarGz(t, tgz, map[string]string{
+		"dolt-linux-amd64/LICENSES":  "licenses",
+		"dolt-linux-amd64/bin/dolt":  "new dolt",
+		"dolt-linux-amd64/bin/dolt2": "not dolt",
+	})
+	var out bytes.Buffer
+	require.NoError(t, ExtractBinary(tgz, "linux", &out))
+	assert.Equal(t, "new dolt", out.String())
+
+	zipPath := filepath.Join(dir, "dolt-windows-amd64.zip")
+	writeZip(t, zipPath, map[string]string{"dolt-windows-amd64/bin/dolt.exe": "new dolt.exe"})
+	out.Reset()
+	require.NoError(t, ExtractBinary(zipPath, "windows", &out))
+	assert.Equal(t, "new dolt.exe", out.String())
+
+	empty := filepath.Join(dir, "empty.tar.gz")
+	writeTarGz(t, empty, map[string]string{"dolt": "not in bin"})
+	assert.Equal(t, ErrBinaryNotFound, ExtractBinary(empty, "linux", &out))
+}
+
+func TestInstall(t *testing.T) {
+	dir := t.TempDir()
+	exePath := filepath.Join(dir, binaryName(runtime.GOOS))
+	require.NoError(t, os.WriteFile(exePath, []byte("old dolt"), 0755))
+
+	archivePath := filepath.Join(dir, ArchiveName(runtime.GOOS, runtime.GOARCH))
+	files := map[string]string{"dolt/bin/" + binaryName(runtime.GOOS): "new dolt"}
+	if runtime.GOOS == "windows" {
+		writeZip(t, archivePath, files)
+	} else {
+		writeTarGz(t, archivePath, files)
+	}
+
+	require.NoError(t, Install(archivePath, exePath))
+	data, err := os.ReadFile(exePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new dolt", string(data))
+
+	// a failed install leaves the old binary in place
+	require.NoError(t, os.WriteFile(archivePath, []byte("not an archive"), 0600))
+	assert.Error(t, Install(archivePath, exePath))
+	data, err = os.ReadFile(exePath)
+	require.NoError(t, err)
+	assert.Equal(t, "new dolt", string(data))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".dolt-upgrade-")
+	}
+}
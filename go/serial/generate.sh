@@ -23,6 +23,7 @@ fi
   blob.fbs \
   branchcontrol.fbs \
   collation.fbs \
+  columnsegment.fbs \
   commit.fbs \
   commitclosure.fbs \
   encoding.fbs \
@@ -119,6 +119,7 @@ func newBlobStoreTests() []BlobstoreTest {
 	tests = appendLocalTest(tests)
 	tests = appendGCSTest(tests)
 	tests = appendOCITest(tests)
+	tests = appendOCIRegistryTest(tests)
 
 	return tests
 }
@@ -0,0 +1,618 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType    = "application/vnd.oci.empty.v1+json"
+
+	// OCIRegistryArtifactType is the artifact type of the image manifests which hold Dolt databases.
+	OCIRegistryArtifactType = "application/vnd.dolthub.dolt.database.v1"
+	// OCIRegistryLayerMediaType is the media type of the layers which hold the blobs of a Dolt database.
+	OCIRegistryLayerMediaType = "application/vnd.dolthub.dolt.blob.v1"
+
+	ociTitleAnnotation = "org.opencontainers.image.title"
+
+	// DefaultOCIRegistryTag is the tag of a database in a registry when its reference doesn't name one.
+	DefaultOCIRegistryTag = "latest"
+)
+
+// The config of every image manifest is the empty JSON object.
+var ociEmptyConfig = []byte("{}")
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	ArtifactType  string          `json:"artifactType,omitempty"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// parts returns the layers of the blob keyed by |key|, in order.
+func (m *ociManifest) parts(key string) []ociDescriptor {
+	var parts []ociDescriptor
+	for _, l := range m.Layers {
+		if l.Annotations[ociTitleAnnotation] == key {
+			parts = append(parts, l)
+		}
+	}
+	return parts
+}
+
+// setParts replaces the layers of the blob keyed by |key| with |parts|.
+func (m *ociManifest) setParts(key string, parts []ociDescriptor) {
+	layers := make([]ociDescriptor, 0, len(m.Layers)+len(parts))
+	for _, l := range m.Layers {
+		if l.Annotations[ociTitleAnnotation] != key {
+			layers = append(layers, l)
+		}
+	}
+	for _, p := range parts {
+		p.Annotations = map[string]string{ociTitleAnnotation: key}
+		layers = append(layers, p)
+	}
+	m.Layers = layers
+}
+
+// partsVersion returns the version of a blob made of |parts|, which is the digest of its only layer, or a digest of
+// the digests of its layers.
+func partsVersion(parts []ociDescriptor) string {
+	if len(parts) == 1 {
+		return parts[0].Digest
+	}
+	h := sha256.New()
+	for _, p := range parts {
+		io.WriteString(h, p.Digest)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// OCIRegistryCredentials are the credentials used to authenticate with an OCI registry.
+type OCIRegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// OCIRegistryBlobstore provides an implementation of the Blobstore interface backed by a repository of an OCI
+// artifact registry, such as ghcr.io or Docker Hub. Each blob is stored as a layer of a single image manifest, which
+// is tagged with |tag| and annotates each layer with the key of its blob. A blob created with Concatenate refers to
+// the layers of its sources, so no data is uploaded again.
+//
+// Registries don't support conditional manifest updates, so CheckAndPut is only atomic with respect to the other
+// writers in this process.
+type OCIRegistryBlobstore struct {
+	client  *http.Client
+	baseURL string
+	host    string
+	repo    string
+	tag     string
+	creds   OCIRegistryCredentials
+
+	// mu serializes the updates of the image manifest.
+	mu sync.Mutex
+
+	authMu sync.Mutex
+	auth   string
+}
+
+var _ Blobstore = &OCIRegistryBlobstore{}
+
+// NewOCIRegistryBlobstore creates a new instance of an OCIRegistryBlobstore for the repository |repo| of the
+// registry at |host|. |ref| is the repository, optionally followed by a colon and a tag. The registry is accessed
+// over https unless |insecure| is true.
+func NewOCIRegistryBlobstore(client *http.Client, host, ref string, insecure bool, creds OCIRegistryCredentials) (*OCIRegistryBlobstore, error) {
+	repo, tag := strings.Trim(ref, "/"), DefaultOCIRegistryTag
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	if host == "" || repo == "" || tag == "" {
+		return nil, fmt.Errorf("invalid OCI registry reference '%s/%s'", host, ref)
+	}
+	if repo != strings.ToLower(repo) {
+		return nil, fmt.Errorf("invalid OCI registry repository '%s': repository names must be lowercase", repo)
+	}
+
+	scheme := "https"
+	if insecure {
+		scheme = "http"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &OCIRegistryBlobstore{
+		client:  client,
+		baseURL: scheme + "://" + host + "/v2/" + repo,
+		host:    host,
+		repo:    repo,
+		tag:     tag,
+		creds:   creds,
+	}, nil
+}
+
+func (bs *OCIRegistryBlobstore) Path() string {
+	return bs.host + "/" + bs.repo + ":" + bs.tag
+}
+
+func (bs *OCIRegistryBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	m, err := bs.getManifest(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(m.parts(key)) > 0, nil
+}
+
+func (bs *OCIRegistryBlobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	m, err := bs.getManifest(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	parts := m.parts(key)
+	if len(parts) == 0 {
+		return nil, "", NotFound{key}
+	}
+
+	var size int64
+	for _, p := range parts {
+		size += p.Size
+	}
+	if !br.isAllRange() {
+		br = br.positiveRange(size)
+	} else {
+		br = BlobRange{0, size}
+	}
+
+	// Each part which overlaps |br| is opened when the part before it has been read.
+	var readers []io.Reader
+	var closers []io.Closer
+	var off int64
+	for _, p := range parts {
+		digest, start, end := p.Digest, br.offset-off, br.offset+br.length-off
+		if start < 0 {
+			start = 0
+		}
+		if end > p.Size {
+			end = p.Size
+		}
+		if start < end {
+			readers = append(readers, &lazyPartReader{open: func() (io.ReadCloser, error) {
+				return bs.getBlob(ctx, digest, start, end-start)
+			}, closers: &closers})
+		}
+		off += p.Size
+	}
+	return &multiReadCloser{Reader: io.MultiReader(readers...), closers: &closers}, partsVersion(parts), nil
+}
+
+func (bs *OCIRegistryBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	desc, err := bs.uploadBlob(ctx, reader)
+	if err != nil {
+		return "", err
+	}
+	return bs.updateManifest(ctx, func(m *ociManifest) ([]ociDescriptor, error) {
+		return []ociDescriptor{desc}, nil
+	}, key)
+}
+
+func (bs *OCIRegistryBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	desc, err := bs.uploadBlob(ctx, reader)
+	if err != nil {
+		return "", err
+	}
+	return bs.updateManifest(ctx, func(m *ociManifest) ([]ociDescriptor, error) {
+		var ver string
+		if parts := m.parts(key); len(parts) > 0 {
+			ver = partsVersion(parts)
+		}
+		if ver != expectedVersion {
+			return nil, CheckAndPutError{key, expectedVersion, ver}
+		}
+		return []ociDescriptor{desc}, nil
+	}, key)
+}
+
+func (bs *OCIRegistryBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return bs.updateManifest(ctx, func(m *ociManifest) ([]ociDescriptor, error) {
+		var parts []ociDescriptor
+		for _, src := range sources {
+			srcParts := m.parts(src)
+			if len(srcParts) == 0 {
+				return nil, NotFound{src}
+			}
+			parts = append(parts, srcParts...)
+		}
+		return parts, nil
+	}, key)
+}
+
+// updateManifest replaces the layers of |key| in the image manifest with the layers returned by |update|.
+func (bs *OCIRegistryBlobstore) updateManifest(ctx context.Context, update func(m *ociManifest) ([]ociDescriptor, error), key string) (string, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	m, err := bs.getManifest(ctx)
+	if err != nil {
+		return "", err
+	}
+	parts, err := update(m)
+	if err != nil {
+		return "", err
+	}
+	if len(m.Layers) == 0 {
+		// The config blob must exist before the first manifest which refers to it is pushed.
+		if _, err = bs.uploadBlob(ctx, bytes.NewReader(ociEmptyConfig)); err != nil {
+			return "", err
+		}
+	}
+	m.setParts(key, parts)
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	resp, err := bs.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, bs.baseURL+"/manifests/"+bs.tag, bytes.NewReader(data))
+		if err == nil {
+			req.Header.Set("Content-Type", ociManifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return "", err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", bs.responseError(resp, "pushing manifest")
+	}
+	return partsVersion(parts), nil
+}
+
+// getManifest returns the image manifest of the blobstore, or an empty manifest if the tag doesn't exist yet.
+func (bs *OCIRegistryBlobstore) getManifest(ctx context.Context) (*ociManifest, error) {
+	resp, err := bs.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, bs.baseURL+"/manifests/"+bs.tag, nil)
+		if err == nil {
+			req.Header.Set("Accept", ociManifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return &ociManifest{
+			SchemaVersion: 2,
+			MediaType:     ociManifestMediaType,
+			ArtifactType:  OCIRegistryArtifactType,
+			Config:        ociDescriptor{MediaType: ociEmptyMediaType, Digest: blobDigest(ociEmptyConfig), Size: int64(len(ociEmptyConfig))},
+		}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, bs.responseError(resp, "fetching manifest")
+	}
+
+	var m ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("invalid manifest for %s: %w", bs.Path(), err)
+	}
+	if m.ArtifactType != OCIRegistryArtifactType {
+		return nil, fmt.Errorf("%s is not a Dolt database: unexpected artifact type '%s'", bs.Path(), m.ArtifactType)
+	}
+	return &m, nil
+}
+
+// getBlob returns |length| bytes of the blob |digest| starting at |offset|.
+func (bs *OCIRegistryBlobstore) getBlob(ctx context.Context, digest string, offset, length int64) (io.ReadCloser, error) {
+	resp, err := bs.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, bs.baseURL+"/blobs/"+digest, nil)
+		if err == nil {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusOK:
+		// The registry ignored the range, so the bytes before it are skipped.
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, length), resp.Body}, nil
+	default:
+		defer drainAndClose(resp)
+		return nil, bs.responseError(resp, "fetching blob "+digest)
+	}
+}
+
+// uploadBlob uploads the contents of |reader| as a blob, unless the registry already has it, and returns its
+// descriptor. The contents are spooled to a temporary file, since the digest of a blob is needed to upload it.
+func (bs *OCIRegistryBlobstore) uploadBlob(ctx context.Context, reader io.Reader) (ociDescriptor, error) {
+	f, err := os.CreateTemp("", "dolt_oci_blob_*")
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), reader)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	desc := ociDescriptor{MediaType: OCIRegistryLayerMediaType, Digest: "sha256:" + hex.EncodeToString(h.Sum(nil)), Size: size}
+
+	resp, err := bs.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodHead, bs.baseURL+"/blobs/"+desc.Digest, nil)
+	})
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	drainAndClose(resp)
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = bs.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost, bs.baseURL+"/blobs/uploads/", nil)
+	})
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	drainAndClose(resp)
+	if resp.StatusCode != http.StatusAccepted {
+		return ociDescriptor{}, bs.responseError(resp, "starting blob upload")
+	}
+	loc, err := resp.Location()
+	if err != nil {
+		return ociDescriptor{}, fmt.Errorf("invalid blob upload location from %s: %w", bs.host, err)
+	}
+	q := loc.Query()
+	q.Set("digest", desc.Digest)
+	loc.RawQuery = q.Encode()
+
+	resp, err = bs.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, loc.String(), io.NewSectionReader(f, 0, size))
+		if err == nil {
+			req.ContentLength = size
+			req.Header.Set("Content-Type", "application/octet-stream")
+		}
+		return req, err
+	})
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusCreated {
+		return ociDescriptor{}, bs.responseError(resp, "uploading blob "+desc.Digest)
+	}
+	return desc, nil
+}
+
+// do sends the request made by |newReq|. If the registry requires authentication, the blobstore authenticates and
+// the request is made and sent again.
+func (bs *OCIRegistryBlobstore) do(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, error) {
+	send := func() (*http.Response, error) {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		bs.authMu.Lock()
+		auth := bs.auth
+		bs.authMu.Unlock()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		return bs.client.Do(req)
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	drainAndClose(resp)
+	if err := bs.authenticate(ctx, challenge); err != nil {
+		return nil, err
+	}
+	return send()
+}
+
+// authenticate handles the |challenge| of a registry which responded with 401 Unauthorized, following the
+// token authentication flow of the distribution spec for Bearer challenges.
+func (bs *OCIRegistryBlobstore) authenticate(ctx context.Context, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if bs.creds.Username == "" {
+			return fmt.Errorf("%s requires credentials", bs.host)
+		}
+		bs.setAuth("Basic " + base64.StdEncoding.EncodeToString([]byte(bs.creds.Username+":"+bs.creds.Password)))
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("unsupported authentication challenge from %s: '%s'", bs.host, challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return fmt.Errorf("invalid authentication realm from %s: '%s'", bs.host, params["realm"])
+	}
+	q := realm.Query()
+	if service, ok := params["service"]; ok {
+		q.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + bs.repo + ":pull,push"
+	}
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if bs.creds.Username != "" {
+		req.SetBasicAuth(bs.creds.Username, bs.creds.Password)
+	}
+	resp, err := bs.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp)
+	if resp.StatusCode != http.StatusOK {
+		return bs.responseError(resp, "authenticating")
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return fmt.Errorf("invalid token response from %s: %w", realm.Host, err)
+	}
+	if tok.Token == "" {
+		tok.Token = tok.AccessToken
+	}
+	if tok.Token == "" {
+		return fmt.Errorf("no token in response from %s", realm.Host)
+	}
+	bs.setAuth("Bearer " + tok.Token)
+	return nil
+}
+
+func (bs *OCIRegistryBlobstore) setAuth(auth string) {
+	bs.authMu.Lock()
+	defer bs.authMu.Unlock()
+	bs.auth = auth
+}
+
+func (bs *OCIRegistryBlobstore) responseError(resp *http.Response, op string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	msg := resp.Status
+	if trimmed := strings.TrimSpace(string(body)); trimmed != "" {
+		msg += ": " + trimmed
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("error %s for %s: access denied (%s)", op, bs.Path(), msg)
+	}
+	return fmt.Errorf("error %s for %s: %s", op, bs.Path(), msg)
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header of the form `<scheme> key="value",key="value"`.
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		var k string
+		k, rest, _ = strings.Cut(rest, "=")
+		k = strings.ToLower(strings.TrimSpace(k))
+		var v string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				v, rest = rest[1:], ""
+			} else {
+				v, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			v, rest, _ = strings.Cut(rest, ",")
+		}
+		params[k] = v
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return scheme, params
+}
+
+func blobDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func drainAndClose(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
+
+// lazyPartReader opens a part of a blob when it is first read.
+type lazyPartReader struct {
+	open    func() (io.ReadCloser, error)
+	rc      io.ReadCloser
+	closers *[]io.Closer
+}
+
+func (r *lazyPartReader) Read(p []byte) (int, error) {
+	if r.rc == nil {
+		rc, err := r.open()
+		if err != nil {
+			return 0, err
+		}
+		r.rc = rc
+		*r.closers = append(*r.closers, rc)
+	}
+	n, err := r.rc.Read(p)
+	if n > 0 && err == io.EOF {
+		// The EOF is returned by the next Read, as readers of the other blobstores do.
+		err = nil
+	}
+	return n, err
+}
+
+type multiReadCloser struct {
+	io.Reader
+	closers *[]io.Closer
+}
+
+func (r *multiReadCloser) Close() error {
+	var errs []error
+	for _, c := range *r.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRegistryUser  = "user"
+	testRegistryPass  = "pass"
+	testRegistryToken = "token"
+)
+
+// testRegistry is a minimal OCI distribution registry which requires token authentication.
+type testRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   map[string]bool
+}
+
+func newTestRegistry() *httptest.Server {
+	reg := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, uploads: map[string]bool{}}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if user, pass, ok := r.BasicAuth(); !ok || user != testRegistryUser || pass != testRegistryPass {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"token": testRegistryToken})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+testRegistryToken {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.serve(w, r)
+	}))
+	return srv
+}
+
+func (reg *testRegistry) serve(w http.ResponseWriter, r *http.Request) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/manifests/"):
+		switch r.Method {
+		case http.MethodGet:
+			m, ok := reg.manifests[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			w.Write(m)
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			var m ociManifest
+			if err := json.Unmarshal(data, &m); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for _, d := range append(m.Layers, m.Config) {
+				if _, ok := reg.blobs[d.Digest]; !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+			}
+			reg.manifests[path] = data
+			w.WriteHeader(http.StatusCreated)
+		}
+	case strings.HasSuffix(path, "/blobs/uploads/") && r.Method == http.MethodPost:
+		id := uuid.New().String()
+		reg.uploads[id] = true
+		w.Header().Set("Location", "/v2/"+path+id)
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(path, "/blobs/uploads/") && r.Method == http.MethodPut:
+		id := path[strings.LastIndex(path, "/")+1:]
+		data, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if !reg.uploads[id] || digest != blobDigest(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(reg.uploads, id)
+		reg.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(path, "/blobs/"):
+		data, ok := reg.blobs[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func appendOCIRegistryTest(tests []BlobstoreTest) []BlobstoreTest {
+	srv := newTestRegistry()
+	host := strings.TrimPrefix(srv.URL, "http://")
+	bs, err := NewOCIRegistryBlobstore(srv.Client(), host, "org/db:"+uuid.New().String(), true, OCIRegistryCredentials{testRegistryUser, testRegistryPass})
+	if err != nil {
+		panic(err)
+	}
+	return append(tests, BlobstoreTest{"ociregistry", bs, 4, 4})
+}
+
+func TestNewOCIRegistryBlobstore(t *testing.T) {
+	tests := []struct {
+		ref  string
+		path string
+		err  bool
+	}{
+		{"org/db", "ghcr.io/org/db:latest", false},
+		{"/org/db", "ghcr.io/org/db:latest", false},
+		{"org/db:v1", "ghcr.io/org/db:v1", false},
+		{"org/sub/db:v1", "ghcr.io/org/sub/db:v1", false},
+		{"org/db:", "", true},
+		{"Org/DB", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.ref, func(t *testing.T) {
+			bs, err := NewOCIRegistryBlobstore(nil, "ghcr.io", test.ref, false, OCIRegistryCredentials{})
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.path, bs.Path())
+		})
+	}
+}
+
+func TestOCIRegistryBlobstoreAuth(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestRegistry()
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	bs, err := NewOCIRegistryBlobstore(srv.Client(), host, "org/db", true, OCIRegistryCredentials{testRegistryUser, "wrong"})
+	require.NoError(t, err)
+	_, err = bs.Exists(ctx, "key")
+	assert.ErrorContains(t, err, "access denied")
+
+	bs, err = NewOCIRegistryBlobstore(srv.Client(), host, "org/db", true, OCIRegistryCredentials{testRegistryUser, testRegistryPass})
+	require.NoError(t, err)
+	_, err = PutBytes(ctx, bs, "key", []byte("data"))
+	require.NoError(t, err)
+	ok, err := bs.Exists(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// A second repository in the same registry doesn't see the blob.
+	other, err := NewOCIRegistryBlobstore(srv.Client(), host, "org/db:other", true, OCIRegistryCredentials{testRegistryUser, testRegistryPass})
+	require.NoError(t, err)
+	ok, err = other.Exists(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:org/db:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://ghcr.io/token",
+		"service": "ghcr.io",
+		"scope":   "repository:org/db:pull,push",
+	}, params)
+
+	scheme, params = parseAuthChallenge(`Basic realm="registry"`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunks
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/sizecache"
+)
+
+// ErrReadOnlyChunkStore is returned by the commits to a ReadOnlyCachingStore.
+var ErrReadOnlyChunkStore = errors.New("cannot commit to a read-only chunk store")
+
+// ReadOnlyCachingStore is a ChunkStore implementation that wraps a ChunkStore, typically a remote one, for reading
+// only. The chunks most recently read from the wrapped store are kept in an LRU cache of bounded size, so that the
+// hot chunks of a database served from a remote aren't fetched again on every read. Since chunks are addressed by
+// their content, the cache remains valid when the store is rebased onto a new root.
+//
+// Reads of a database may write the transient chunks they compute, such as those of empty indexes, so the chunks Put
+// to the store are kept in memory and are visible to later reads. They can never be committed.
+type ReadOnlyCachingStore struct {
+	cs    ChunkStore
+	cache *sizecache.SizeCache
+
+	mu      sync.Mutex
+	pending map[hash.Hash]Chunk
+	hits    uint64
+	misses  uint64
+}
+
+var _ ChunkStore = &ReadOnlyCachingStore{}
+
+// ReadOnlyCachingStoreStats are the Stats of a ReadOnlyCachingStore.
+type ReadOnlyCachingStoreStats struct {
+	CacheHits   uint64
+	CacheMisses uint64
+	Delegate    interface{}
+}
+
+// NewReadOnlyCachingStore returns a ReadOnlyCachingStore reading from |cs|, whose cache holds up to |cacheSize|
+// bytes of chunk data.
+func NewReadOnlyCachingStore(cs ChunkStore, cacheSize uint64) *ReadOnlyCachingStore {
+	return &ReadOnlyCachingStore{
+		cs:      cs,
+		cache:   sizecache.New(cacheSize),
+		pending: make(map[hash.Hash]Chunk),
+	}
+}
+
+func (s *ReadOnlyCachingStore) cached(h hash.Hash) (Chunk, bool) {
+	v, ok := s.cache.Get(h)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, isPending := s.pending[h]; isPending {
+		return c, true
+	}
+	if !ok {
+		s.misses++
+		return EmptyChunk, false
+	}
+	s.hits++
+	return v.(Chunk), true
+}
+
+// has returns whether |h| is cached or pending.
+func (s *ReadOnlyCachingStore) has(h hash.Hash) bool {
+	if _, ok := s.cache.Get(h); ok {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.pending[h]
+	return ok
+}
+
+func (s *ReadOnlyCachingStore) add(c Chunk) {
+	if !c.IsEmpty() {
+		s.cache.Add(c.Hash(), uint64(len(c.Data())), c)
+	}
+}
+
+// Get the Chunk for the value of the hash in the store. If the hash is
+// absent from the store EmptyChunk is returned.
+func (s *ReadOnlyCachingStore) Get(ctx context.Context, h hash.Hash) (Chunk, error) {
+	if c, ok := s.cached(h); ok {
+		return c, nil
+	}
+	c, err := s.cs.Get(ctx, h)
+	if err != nil {
+		return EmptyChunk, err
+	}
+	s.add(c)
+	return c, nil
+}
+
+// GetMany gets the Chunks with |hashes| from the store. On return,
+// |foundChunks| will have been fully sent all chunks which have been
+// found. Any non-present chunks will silently be ignored.
+func (s *ReadOnlyCachingStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *Chunk)) error {
+	notCached := make(hash.HashSet)
+	for h := range hashes {
+		if c, ok := s.cached(h); ok {
+			found(ctx, &c)
+		} else {
+			notCached.Insert(h)
+		}
+	}
+	if len(notCached) == 0 {
+		return nil
+	}
+	return s.cs.GetMany(ctx, notCached, func(ctx context.Context, c *Chunk) {
+		s.add(*c)
+		found(ctx, c)
+	})
+}
+
+// Returns true iff the value at the address |h| is contained in the
+// store
+func (s *ReadOnlyCachingStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	if s.has(h) {
+		return true, nil
+	}
+	return s.cs.Has(ctx, h)
+}
+
+// Returns a new HashSet containing any members of |hashes| that are
+// absent from the store.
+func (s *ReadOnlyCachingStore) HasMany(ctx context.Context, hashes hash.HashSet) (absent hash.HashSet, err error) {
+	notCached := make(hash.HashSet)
+	for h := range hashes {
+		if !s.has(h) {
+			notCached.Insert(h)
+		}
+	}
+	if len(notCached) == 0 {
+		return notCached, nil
+	}
+	return s.cs.HasMany(ctx, notCached)
+}
+
+// Put keeps |c| in memory, where it's visible to subsequent Get and Has calls. It's never persisted.
+func (s *ReadOnlyCachingStore) Put(ctx context.Context, c Chunk, getAddrs GetAddrsCurry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[c.Hash()] = c
+	return nil
+}
+
+// Returns the NomsBinFormat with which this ChunkSource is compatible.
+func (s *ReadOnlyCachingStore) Version() string {
+	return s.cs.Version()
+}
+
+func (s *ReadOnlyCachingStore) AccessMode() ExclusiveAccessMode {
+	return ExclusiveAccessMode_ReadOnly
+}
+
+// Rebase brings this ChunkStore into sync with the persistent storage's
+// current root.
+func (s *ReadOnlyCachingStore) Rebase(ctx context.Context) error {
+	return s.cs.Rebase(ctx)
+}
+
+// Root returns the root of the database as of the time the ChunkStore
+// was opened or the most recent call to Rebase.
+func (s *ReadOnlyCachingStore) Root(ctx context.Context) (hash.Hash, error) {
+	return s.cs.Root(ctx)
+}
+
+// Commit returns ErrReadOnlyChunkStore.
+func (s *ReadOnlyCachingStore) Commit(ctx context.Context, current, last hash.Hash) (bool, error) {
+	return false, ErrReadOnlyChunkStore
+}
+
+// Stats returns the ReadOnlyCachingStoreStats of this ChunkStore.
+func (s *ReadOnlyCachingStore) Stats() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return ReadOnlyCachingStoreStats{
+		CacheHits:   s.hits,
+		CacheMisses: s.misses,
+		Delegate:    s.cs.Stats(),
+	}
+}
+
+// StatsSummary returns the summary of the stats of the wrapped ChunkStore.
+func (s *ReadOnlyCachingStore) StatsSummary() string {
+	return s.cs.StatsSummary()
+}
+
+// PersistGhostHashes returns ErrReadOnlyChunkStore.
+func (s *ReadOnlyCachingStore) PersistGhostHashes(ctx context.Context, refs hash.HashSet) error {
+	return ErrReadOnlyChunkStore
+}
+
+// Close tears down any resources in use by the implementation. After
+// Close(), the ChunkStore may not be used again. It is NOT SAFE to call
+// Close() concurrently with any other ChunkStore method; behavior is
+// undefined and probably crashy.
+func (s *ReadOnlyCachingStore) Close() error {
+	s.cache.Purge()
+	return s.cs.Close()
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestReadOnlyCachingStore(t *testing.T) {
+	ctx := context.Background()
+	storage := &TestStorage{}
+	view := storage.NewView()
+
+	c1, c2, c3 := NewChunk([]byte("abc")), NewChunk([]byte("def")), NewChunk([]byte("ghi"))
+	for _, c := range []Chunk{c1, c2, c3} {
+		require.NoError(t, view.Put(ctx, c, noopGetAddrs))
+	}
+
+	// the cache holds two of the chunks
+	cs := NewReadOnlyCachingStore(view, 6)
+
+	t.Run("reads are cached", func(t *testing.T) {
+		c, err := cs.Get(ctx, c1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c1.Data(), c.Data())
+		reads := view.Reads()
+
+		c, err = cs.Get(ctx, c1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c1.Data(), c.Data())
+		assert.Equal(t, reads, view.Reads())
+
+		var found []hash.Hash
+		err = cs.GetMany(ctx, hash.NewHashSet(c1.Hash(), c2.Hash()), func(_ context.Context, c *Chunk) {
+			found = append(found, c.Hash())
+		})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []hash.Hash{c1.Hash(), c2.Hash()}, found)
+		assert.Equal(t, reads+1, view.Reads())
+	})
+
+	t.Run("least recently read chunks are evicted", func(t *testing.T) {
+		_, err := cs.Get(ctx, c3.Hash())
+		require.NoError(t, err)
+		reads := view.Reads()
+
+		_, err = cs.Get(ctx, c1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, reads+1, view.Reads())
+	})
+
+	t.Run("absent chunks", func(t *testing.T) {
+		absent := NewChunk([]byte("jkl")).Hash()
+		c, err := cs.Get(ctx, absent)
+		require.NoError(t, err)
+		assert.True(t, c.IsEmpty())
+
+		ok, err := cs.Has(ctx, absent)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		missing, err := cs.HasMany(ctx, hash.NewHashSet(c1.Hash(), absent))
+		require.NoError(t, err)
+		assert.Equal(t, hash.NewHashSet(absent), missing)
+	})
+
+	t.Run("written chunks are readable but can't be committed", func(t *testing.T) {
+		c4 := NewChunk([]byte("mno"))
+		require.NoError(t, cs.Put(ctx, c4, noopGetAddrs))
+		c, err := cs.Get(ctx, c4.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c4.Data(), c.Data())
+		ok, err := cs.Has(ctx, c4.Hash())
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = view.Has(ctx, c4.Hash())
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, err = cs.Commit(ctx, c4.Hash(), hash.Hash{})
+		assert.ErrorIs(t, err, ErrReadOnlyChunkStore)
+	})
+}
@@ -37,6 +37,9 @@ type DatasetsMap interface {
 	Len() (uint64, error)
 
 	IterAll(ctx context.Context, cb func(id string, addr hash.Hash) error) error
+
+	// IterPrefix iterates over the datasets whose ids start with |prefix|
+	IterPrefix(ctx context.Context, prefix string, cb func(id string, addr hash.Hash) error) error
 }
 
 // Database provides versioned storage for noms values. While Values can be
@@ -158,6 +161,20 @@ type Database interface {
 	// is not provided, no working set update will be performed.
 	FastForward(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, workingSetPath string) (Dataset, error)
 
+	// UpdateHeads sets the heads of several datasets to new commits
+	// atomically: after it runs, either every dataset in |updates| points
+	// at its new head or none of them do. Each dataset must currently
+	// point at its update's PrevHeadAddr, or not exist if PrevHeadAddr is
+	// empty, or ErrMergeNeeded is returned and nothing is updated. Lineage
+	// isn't checked, so callers wanting fast-forward only updates must
+	// check that themselves before calling.
+	//
+	// If an update has a WorkingSetPath, that working set is reset to the
+	// new head, or created if it doesn't exist. An existing working set
+	// must have no changes from the dataset's current head, or
+	// ErrDirtyWorkspace is returned.
+	UpdateHeads(ctx context.Context, updates []HeadUpdate) error
+
 	// Stats may return some kind of struct that reports statistics about the
 	// ChunkStore that backs this Database instance. The type is
 	// implementation-dependent, and impls may return nil
@@ -182,6 +199,18 @@ type Database interface {
 	chunkStore() chunks.ChunkStore
 }
 
+// HeadUpdate is the update of the head of one dataset made by Database.UpdateHeads.
+type HeadUpdate struct {
+	// DatasetID is the dataset to update.
+	DatasetID string
+	// PrevHeadAddr is the address the dataset must currently point at, or the empty hash if it must not exist.
+	PrevHeadAddr hash.Hash
+	// NewHeadAddr is the address of the commit the dataset will point at.
+	NewHeadAddr hash.Hash
+	// WorkingSetPath is the working set of the dataset to reset to the new head, if it has one.
+	WorkingSetPath string
+}
+
 func NewDatabase(cs chunks.ChunkStore) Database {
 	vs := types.NewValueStore(cs)
 	ns := tree.NewNodeStore(cs)
@@ -25,6 +25,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/dolt/go/gen/fb/serial"
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -135,6 +136,10 @@ func (m refmapDatasetsMap) IterAll(ctx context.Context, cb func(string, hash.Has
 	return m.am.IterAll(ctx, cb)
 }
 
+func (m refmapDatasetsMap) IterPrefix(ctx context.Context, prefix string, cb func(string, hash.Hash) error) error {
+	return m.am.IterPrefix(ctx, prefix, cb)
+}
+
 type nomsDatasetsMap struct {
 	m types.Map
 }
@@ -150,6 +155,16 @@ func (m nomsDatasetsMap) IterAll(ctx context.Context, cb func(string, hash.Hash)
 	})
 }
 
+func (m nomsDatasetsMap) IterPrefix(ctx context.Context, prefix string, cb func(string, hash.Hash) error) error {
+	return m.m.IterFrom(ctx, types.String(prefix), func(k, v types.Value) (bool, error) {
+		id := string(k.(types.String))
+		if !strings.HasPrefix(id, prefix) {
+			return true, nil
+		}
+		return false, cb(id, v.(types.Ref).TargetHash())
+	})
+}
+
 // Datasets returns the Map of Datasets in the current root. If you intend to edit the map and commit changes back,
 // then you should fetch the current root, then call DatasetsInRoot with that hash. Otherwise another writer could
 // change the root value between when you get the root hash and call this method.
@@ -580,6 +595,138 @@ func (db *database) doFastForward(ctx context.Context, ds Dataset, newHeadAddr h
 	return err
 }
 
+func (db *database) UpdateHeads(ctx context.Context, updates []HeadUpdate) error {
+	newHeads := make([]types.Value, len(updates))
+	seen := make(map[string]struct{}, len(updates))
+	for i, u := range updates {
+		if _, ok := seen[u.DatasetID]; ok {
+			return fmt.Errorf("UpdateHeads: dataset %s is updated more than once", u.DatasetID)
+		}
+		seen[u.DatasetID] = struct{}{}
+
+		newHead, err := db.readHead(ctx, u.NewHeadAddr)
+		if err != nil {
+			return err
+		}
+		if newHead == nil {
+			return fmt.Errorf("UpdateHeads: new head address %v not found", u.NewHeadAddr)
+		}
+		if newHead.TypeName() != commitName {
+			return fmt.Errorf("UpdateHeads: target value of new head address %v is not a commit.", u.NewHeadAddr)
+		}
+		newHeads[i] = newHead.value()
+	}
+
+	return db.update(ctx, func(ctx context.Context, datasets types.Map) (types.Map, error) {
+		me := datasets.Edit()
+		for i, u := range updates {
+			var currAddr hash.Hash
+			curr, ok, err := datasets.MaybeGet(ctx, types.String(u.DatasetID))
+			if err != nil {
+				return types.Map{}, err
+			}
+			if ok {
+				currAddr = curr.(types.Ref).TargetHash()
+			}
+			if currAddr != u.PrevHeadAddr {
+				return types.Map{}, ErrMergeNeeded
+			}
+
+			vref, err := types.NewRef(newHeads[i], db.Format())
+			if err != nil {
+				return types.Map{}, err
+			}
+			ref, err := types.ToRefOfValue(vref, db.Format())
+			if err != nil {
+				return types.Map{}, err
+			}
+			me.Set(types.String(u.DatasetID), ref)
+		}
+		return me.Map(ctx)
+	}, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		ae := am.Editor()
+		for i, u := range updates {
+			curr, err := am.Get(ctx, u.DatasetID)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+			if curr != u.PrevHeadAddr {
+				return prolly.AddressMap{}, ErrMergeNeeded
+			}
+
+			if u.WorkingSetPath != "" {
+				newWSHash, err := db.resetCleanWorkingSet(ctx, am, u.WorkingSetPath, curr, newHeads[i])
+				if err != nil {
+					return prolly.AddressMap{}, err
+				}
+				err = ae.Update(ctx, u.WorkingSetPath, newWSHash)
+				if err != nil {
+					return prolly.AddressMap{}, err
+				}
+			}
+
+			err = ae.Update(ctx, u.DatasetID, u.NewHeadAddr)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
+		return ae.Flush(ctx)
+	})
+}
+
+// resetCleanWorkingSet writes a working set for the commit |newHead| to replace the working set at |workingSetPath|,
+// or to create it if it doesn't exist, and returns its address. It returns ErrDirtyWorkspace if the working set has
+// changes from the commit at |currHeadAddr|.
+func (db *database) resetCleanWorkingSet(ctx context.Context, am prolly.AddressMap, workingSetPath string, currHeadAddr hash.Hash, newHead types.Value) (hash.Hash, error) {
+	currWSHash, err := am.Get(ctx, workingSetPath)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+
+	if currWSHash != (hash.Hash{}) {
+		currWS, err := db.ReadValue(ctx, currWSHash)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		sm, ok := currWS.(types.SerialMessage)
+		if !ok {
+			return hash.Hash{}, errors.New("Modern Dolt Database required.")
+		}
+		msg, err := serial.TryGetRootAsWorkingSet(sm, serial.MessagePrefixSz)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+
+		stagedHash := hash.New(msg.StagedRootAddrBytes())
+		if stagedHash != hash.New(msg.WorkingRootAddrBytes()) {
+			return hash.Hash{}, ErrDirtyWorkspace
+		}
+		if currHeadAddr != (hash.Hash{}) {
+			currHead, err := db.ReadValue(ctx, currHeadAddr)
+			if err != nil {
+				return hash.Hash{}, err
+			}
+			currRootHash, err := GetCommitRootHash(currHead)
+			if err != nil {
+				return hash.Hash{}, err
+			}
+			if stagedHash != currRootHash {
+				return hash.Hash{}, ErrDirtyWorkspace
+			}
+		}
+	}
+
+	newRootHash, err := GetCommitRootHash(newHead)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	ref, err := db.WriteValue(ctx, types.SerialMessage(workingset_flatbuffer(newRootHash, &newRootHash, nil, nil, nil)))
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return ref.TargetHash(), nil
+}
+
 func (db *database) BuildNewCommit(ctx context.Context, ds Dataset, v types.Value, opts CommitOptions) (*Commit, error) {
 	if len(opts.Parents) == 0 {
 		headAddr, ok := ds.MaybeHeadAddr()
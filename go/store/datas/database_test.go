@@ -575,6 +575,54 @@ func (suite *DatabaseSuite) TestFastForward() {
 	suite.True(mustHeadValue(ds).Equals(c))
 }
 
+func (suite *DatabaseSuite) TestUpdateHeads() {
+	ctx := context.Background()
+
+	// ds1: |a| <- |b|, ds2: |c|
+	ds1, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	ds1, err = CommitValue(ctx, suite.db, ds1, types.String("a"))
+	suite.NoError(err)
+	aCommitAddr := mustHeadAddr(ds1)
+	ds1, err = CommitValue(ctx, suite.db, ds1, types.String("b"))
+	suite.NoError(err)
+	bCommitAddr := mustHeadAddr(ds1)
+
+	ds2, err := suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	ds2, err = CommitValue(ctx, suite.db, ds2, types.String("c"))
+	suite.NoError(err)
+	cCommitAddr := mustHeadAddr(ds2)
+
+	// a stale previous head for ds2 means neither dataset is updated
+	err = suite.db.UpdateHeads(ctx, []HeadUpdate{
+		{DatasetID: "ds1", PrevHeadAddr: bCommitAddr, NewHeadAddr: aCommitAddr},
+		{DatasetID: "ds2", PrevHeadAddr: aCommitAddr, NewHeadAddr: bCommitAddr},
+	})
+	suite.Equal(ErrMergeNeeded, err)
+	ds1, err = suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	suite.Equal(bCommitAddr, mustHeadAddr(ds1))
+
+	err = suite.db.UpdateHeads(ctx, []HeadUpdate{
+		{DatasetID: "ds1", PrevHeadAddr: bCommitAddr, NewHeadAddr: aCommitAddr},
+		{DatasetID: "ds2", PrevHeadAddr: cCommitAddr, NewHeadAddr: bCommitAddr},
+		{DatasetID: "ds3", NewHeadAddr: cCommitAddr},
+	})
+	suite.NoError(err)
+	for id, addr := range map[string]hash.Hash{"ds1": aCommitAddr, "ds2": bCommitAddr, "ds3": cCommitAddr} {
+		ds, err := suite.db.GetDataset(ctx, id)
+		suite.NoError(err)
+		suite.Equal(addr, mustHeadAddr(ds), id)
+	}
+
+	err = suite.db.UpdateHeads(ctx, []HeadUpdate{
+		{DatasetID: "ds1", PrevHeadAddr: aCommitAddr, NewHeadAddr: bCommitAddr},
+		{DatasetID: "ds1", PrevHeadAddr: aCommitAddr, NewHeadAddr: cCommitAddr},
+	})
+	suite.Error(err)
+}
+
 func (suite *DatabaseSuite) TestDatabaseHeightOfRefs() {
 	r1, err := suite.db.WriteValue(context.Background(), types.String("hello"))
 	suite.NoError(err)
@@ -212,6 +212,9 @@ type MergeState struct {
 	fromCommitSpec      string
 	unmergableTables    []string
 	isCherryPick        bool
+	isRevert            bool
+	// pendingCherryPickAddrs are the commits left to cherry-pick after |fromCommitAddr|
+	pendingCherryPickAddrs []hash.Hash
 
 	nomsMergeStateRef *types.Ref
 	nomsMergeState    *types.Struct
@@ -310,6 +313,20 @@ func (ms *MergeState) IsCherryPick(_ context.Context, vr types.ValueReader) (boo
 	return false, nil
 }
 
+func (ms *MergeState) IsRevert(_ context.Context, vr types.ValueReader) (bool, error) {
+	if vr.Format().UsesFlatbuffers() {
+		return ms.isRevert, nil
+	}
+	return false, nil
+}
+
+func (ms *MergeState) PendingCherryPickAddrs(_ context.Context, vr types.ValueReader) ([]hash.Hash, error) {
+	if vr.Format().UsesFlatbuffers() {
+		return ms.pendingCherryPickAddrs, nil
+	}
+	return nil, nil
+}
+
 func (ms *MergeState) UnmergableTables(ctx context.Context, vr types.ValueReader) ([]string, error) {
 	if vr.Format().UsesFlatbuffers() {
 		return ms.unmergableTables, nil
@@ -376,6 +393,7 @@ func (h serialTagHead) HeadTag() (*TagMeta, hash.Hash, error) {
 		Timestamp:     h.msg.TimestampMillis(),
 		Description:   string(h.msg.Desc()),
 		UserTimestamp: h.msg.UserTimestampMillis(),
+		Signature:     string(h.msg.Signature()),
 	}
 	return meta, addr, nil
 }
@@ -443,6 +461,12 @@ func (h serialWorkingSetHead) HeadWorkingSet() (*WorkingSetHead, error) {
 			ret.MergeState.unmergableTables[i] = string(mergeState.UnmergableTables(i))
 		}
 		ret.MergeState.isCherryPick = mergeState.IsCherryPick()
+		ret.MergeState.isRevert = mergeState.IsRevert()
+		pending := mergeState.PendingCherryPickAddrsBytes()
+		for len(pending) >= hash.ByteLen {
+			ret.MergeState.pendingCherryPickAddrs = append(ret.MergeState.pendingCherryPickAddrs, hash.New(pending[:hash.ByteLen]))
+			pending = pending[hash.ByteLen:]
+		}
 	}
 
 	rebaseState, err := h.msg.TryRebaseState(nil)
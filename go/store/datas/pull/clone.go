@@ -22,8 +22,8 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"golang.org/x/sync/errgroup"
-	"golang.org/x/sync/semaphore"
 
+	"github.com/dolthub/dolt/go/libraries/utils/concurrency"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -89,7 +89,20 @@ func mapTableFiles(tblFiles []chunks.TableFile) ([]string, map[string]chunks.Tab
 	return fileIds, fileIDtoTblFile, fileIDtoNumChunks
 }
 
-const concurrentTableFileDownloads = 3
+// Table files are downloaded by a |concurrency.Fetch| pool, which starts out downloading three files at once.
+var tableFileDownloadLimits = concurrency.Limits{Min: 1, Max: 16, Initial: 3}
+
+// fetchRecordingReader reports the bytes read from a table file to the |concurrency.Controller| of the download.
+type fetchRecordingReader struct {
+	io.ReadCloser
+	ctrl *concurrency.Controller
+}
+
+func (r fetchRecordingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.ctrl.Record(int64(n))
+	return n, err
+}
 
 func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chunks.ChunkStore, eventCh chan<- TableFileEvent) error {
 	root, sourceFiles, appendixFiles, err := srcTS.Sources(ctx)
@@ -112,21 +125,25 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 
 	report(TableFileEvent{EventType: Listed, TableFiles: tblFiles})
 
+	ctrl := concurrency.NewController(concurrency.Fetch, tableFileDownloadLimits)
 	download := func(ctx context.Context) error {
-		sem := semaphore.NewWeighted(concurrentTableFileDownloads)
+		done := make(chan struct{})
+		defer close(done)
+		go ctrl.Run(ctx, done)
+
 		eg, ctx := errgroup.WithContext(ctx)
 		for i := 0; i < len(desiredFiles); i++ {
 			if completed[i] {
 				continue
 			}
-			if err := sem.Acquire(ctx, 1); err != nil {
+			if err := ctrl.Acquire(ctx); err != nil {
 				// The errgroup ctx has been canceled. We will
 				// return the error from wg.Wait() below.
 				break
 			}
 			idx := i
 			eg.Go(func() (err error) {
-				defer sem.Release(1)
+				defer ctrl.Release(0)
 
 				fileID := desiredFiles[idx]
 				tblFile, ok := fileIDToTF[fileID]
@@ -136,12 +153,13 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 				}
 
 				report(TableFileEvent{EventType: DownloadStart, TableFiles: []chunks.TableFile{tblFile}})
+				var rdStats *iohelp.ReaderWithStats
 				err = sinkTS.WriteTableFile(ctx, tblFile.FileID(), tblFile.NumChunks(), nil, func() (io.ReadCloser, uint64, error) {
 					rd, contentLength, err := tblFile.Open(ctx)
 					if err != nil {
 						return nil, 0, err
 					}
-					rdStats := iohelp.NewReaderWithStats(rd, int64(contentLength))
+					rdStats = iohelp.NewReaderWithStats(rd, int64(contentLength))
 
 					rdStats.Start(func(s iohelp.ReadStats) {
 						report(TableFileEvent{
@@ -151,14 +169,19 @@ func clone(ctx context.Context, srcTS, sinkTS chunks.TableFileStore, sinkCS chun
 						})
 					})
 
-					return rdStats, contentLength, nil
+					return fetchRecordingReader{rdStats, ctrl}, contentLength, nil
 				})
 				if err != nil {
 					report(TableFileEvent{EventType: DownloadFailed, TableFiles: []chunks.TableFile{tblFile}})
 					return err
 				}
 
-				report(TableFileEvent{EventType: DownloadSuccess, TableFiles: []chunks.TableFile{tblFile}})
+				// The final stats of the download, since the periodic stats may not include all of it
+				success := TableFileEvent{EventType: DownloadSuccess, TableFiles: []chunks.TableFile{tblFile}}
+				if rdStats != nil {
+					success.Stats = []iohelp.ReadStats{rdStats.Stats()}
+				}
+				report(success)
 				completed[idx] = true
 				return nil
 			})
@@ -114,11 +114,14 @@ func newTag(ctx context.Context, db *database, commitAddr hash.Hash, meta *TagMe
 func tag_flatbuffer(commitAddr hash.Hash, meta *TagMeta) serial.Message {
 	builder := flatbuffers.NewBuilder(1024)
 	addroff := builder.CreateByteVector(commitAddr[:])
-	var nameOff, emailOff, descOff flatbuffers.UOffsetT
+	var nameOff, emailOff, descOff, sigOff flatbuffers.UOffsetT
 	if meta != nil {
 		nameOff = builder.CreateString(meta.Name)
 		emailOff = builder.CreateString(meta.Email)
 		descOff = builder.CreateString(meta.Description)
+		if len(meta.Signature) != 0 {
+			sigOff = builder.CreateString(meta.Signature)
+		}
 	}
 	serial.TagStart(builder)
 	serial.TagAddCommitAddr(builder, addroff)
@@ -128,6 +131,9 @@ func tag_flatbuffer(commitAddr hash.Hash, meta *TagMeta) serial.Message {
 		serial.TagAddDesc(builder, descOff)
 		serial.TagAddTimestampMillis(builder, meta.Timestamp)
 		serial.TagAddUserTimestampMillis(builder, meta.UserTimestamp)
+		if sigOff != 0 {
+			serial.TagAddSignature(builder, sigOff)
+		}
 	}
 	return serial.FinishMessage(builder, serial.TagEnd(builder), []byte(serial.TagFileID))
 }
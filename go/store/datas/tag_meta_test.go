@@ -19,7 +19,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -37,3 +40,28 @@ func TestTagMetaToAndFromNomsStruct(t *testing.T) {
 
 	t.Log(tm.String())
 }
+
+func TestTagMetaSignature(t *testing.T) {
+	tm := NewTagMeta("Bill Billerson", "bigbillieb@fake.horse", "This is a signed tag")
+	tm.Signature = "-----BEGIN PGP SIGNED MESSAGE-----"
+
+	st, err := tm.toNomsStruct(types.Format_Default)
+	require.NoError(t, err)
+	result, err := tagMetaFromNomsSt(st)
+	require.NoError(t, err)
+	assert.Equal(t, tm, result)
+
+	addr := hash.Of([]byte("commit"))
+	head, err := newSerialTagHead(tag_flatbuffer(addr, tm), hash.Hash{})
+	require.NoError(t, err)
+	result, commitAddr, err := head.HeadTag()
+	require.NoError(t, err)
+	assert.Equal(t, tm, result)
+	assert.Equal(t, addr, commitAddr)
+
+	// unsigned tags don't store a signature, so that they can be read by clients which don't support signatures
+	tm.Signature = ""
+	msg, err := serial.TryGetRootAsTag(tag_flatbuffer(addr, tm), serial.MessagePrefixSz)
+	require.NoError(t, err)
+	assert.EqualValues(t, serial.TagNumFields-1, msg.Table().NumFields())
+}
@@ -175,12 +175,24 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		fromaddroff := builder.CreateByteVector((*mergeState.fromCommitAddr)[:])
 		fromspecoff := builder.CreateString(mergeState.fromCommitSpec)
 		unmergableoff := SerializeStringVector(builder, mergeState.unmergableTables)
+		var pendingoff flatbuffers.UOffsetT
+		if len(mergeState.pendingCherryPickAddrs) > 0 {
+			pending := make([]byte, 0, len(mergeState.pendingCherryPickAddrs)*hash.ByteLen)
+			for _, addr := range mergeState.pendingCherryPickAddrs {
+				pending = append(pending, addr[:]...)
+			}
+			pendingoff = builder.CreateByteVector(pending)
+		}
 		serial.MergeStateStart(builder)
 		serial.MergeStateAddPreWorkingRootAddr(builder, prerootaddroff)
 		serial.MergeStateAddFromCommitAddr(builder, fromaddroff)
 		serial.MergeStateAddFromCommitSpecStr(builder, fromspecoff)
 		serial.MergeStateAddUnmergableTables(builder, unmergableoff)
 		serial.MergeStateAddIsCherryPick(builder, mergeState.isCherryPick)
+		serial.MergeStateAddIsRevert(builder, mergeState.isRevert)
+		if pendingoff != 0 {
+			serial.MergeStateAddPendingCherryPickAddrs(builder, pendingoff)
+		}
 		mergeStateOff = serial.MergeStateEnd(builder)
 	}
 
@@ -235,14 +247,18 @@ func NewMergeState(
 	commitSpecStr string,
 	unmergableTables []string,
 	isCherryPick bool,
+	isRevert bool,
+	pendingCherryPicks []hash.Hash,
 ) (*MergeState, error) {
 	if vrw.Format().UsesFlatbuffers() {
 		ms := &MergeState{
-			preMergeWorkingAddr: new(hash.Hash),
-			fromCommitAddr:      new(hash.Hash),
-			fromCommitSpec:      commitSpecStr,
-			unmergableTables:    unmergableTables,
-			isCherryPick:        isCherryPick,
+			preMergeWorkingAddr:    new(hash.Hash),
+			fromCommitAddr:         new(hash.Hash),
+			fromCommitSpec:         commitSpecStr,
+			unmergableTables:       unmergableTables,
+			isCherryPick:           isCherryPick,
+			isRevert:               isRevert,
+			pendingCherryPickAddrs: pendingCherryPicks,
 		}
 		*ms.preMergeWorkingAddr = preMergeWorking.TargetHash()
 		*ms.fromCommitAddr = commit.Addr()
@@ -258,6 +274,7 @@ func NewMergeState(
 		}
 		return &MergeState{
 			isCherryPick:      isCherryPick,
+			isRevert:          isRevert,
 			nomsMergeStateRef: &ref,
 			nomsMergeState:    &v,
 		}, nil
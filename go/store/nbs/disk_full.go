@@ -0,0 +1,82 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrDiskFull is returned when a write to the journal, a table file or the manifest fails because the disk is full.
+// The failed write is abandoned without leaving a partial table file or manifest behind, and without committing a
+// partial root hash record to the journal, so the database stays consistent.
+var ErrDiskFull = errors.New("disk full")
+
+var diskFullHooks struct {
+	mu    sync.Mutex
+	hooks map[*func(err error)]struct{}
+}
+
+// OnDiskFull registers |hook| to be called with the error of any write which fails because the disk is full. Hooks
+// are called synchronously from the failed write, and must not block. The returned function unregisters |hook|.
+func OnDiskFull(hook func(err error)) (unregister func()) {
+	diskFullHooks.mu.Lock()
+	defer diskFullHooks.mu.Unlock()
+	if diskFullHooks.hooks == nil {
+		diskFullHooks.hooks = make(map[*func(err error)]struct{})
+	}
+	key := &hook
+	diskFullHooks.hooks[key] = struct{}{}
+	return func() {
+		diskFullHooks.mu.Lock()
+		defer diskFullHooks.mu.Unlock()
+		delete(diskFullHooks.hooks, key)
+	}
+}
+
+// IsDiskFull returns whether |err| was caused by the disk being full.
+func IsDiskFull(err error) bool {
+	if errors.Is(err, ErrDiskFull) {
+		return true
+	}
+	for _, errno := range diskFullErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDiskFull returns |err| wrapped in ErrDiskFull if it was caused by the disk being full, after calling the
+// OnDiskFull hooks. Other errors are returned as they are.
+func checkDiskFull(err error) error {
+	if err == nil || !IsDiskFull(err) {
+		return err
+	}
+	if !errors.Is(err, ErrDiskFull) {
+		err = fmt.Errorf("%w: %w", ErrDiskFull, err)
+	}
+	diskFullHooks.mu.Lock()
+	hooks := make([]func(err error), 0, len(diskFullHooks.hooks))
+	for hook := range diskFullHooks.hooks {
+		hooks = append(hooks, *hook)
+	}
+	diskFullHooks.mu.Unlock()
+	for _, hook := range hooks {
+		hook(err)
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"errors"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDiskFull(t *testing.T) {
+	var called []error
+	unregister := OnDiskFull(func(err error) {
+		called = append(called, err)
+	})
+	defer unregister()
+
+	assert.NoError(t, checkDiskFull(nil))
+
+	other := &fs.PathError{Op: "write", Path: "journal", Err: syscall.EIO}
+	assert.Equal(t, other, checkDiskFull(other))
+	assert.False(t, IsDiskFull(other))
+	assert.Empty(t, called)
+
+	full := &fs.PathError{Op: "write", Path: "journal", Err: syscall.ENOSPC}
+	assert.True(t, IsDiskFull(full))
+	err := checkDiskFull(full)
+	assert.True(t, errors.Is(err, ErrDiskFull))
+	assert.True(t, errors.Is(err, syscall.ENOSPC))
+	require.Len(t, called, 1)
+	assert.Equal(t, err, called[0])
+
+	// errors which are already wrapped are not wrapped again
+	assert.Equal(t, err, checkDiskFull(err))
+	assert.Len(t, called, 2)
+
+	unregister()
+	_ = checkDiskFull(full)
+	assert.Len(t, called, 2)
+}
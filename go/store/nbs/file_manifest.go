@@ -389,6 +389,10 @@ func updateWithChecker(_ context.Context, dir string, mode updateMode, validate
 			if ferr == nil {
 				ferr = closeErr
 			}
+
+			if ferr != nil {
+				file.Remove(temp.Name())
+			}
 		}()
 
 		ferr = writeManifest(temp, newContents)
@@ -406,7 +410,7 @@ func updateWithChecker(_ context.Context, dir string, mode updateMode, validate
 	}()
 
 	if err != nil {
-		return manifestContents{}, err
+		return manifestContents{}, checkDiskFull(err)
 	}
 
 	defer file.Remove(tempManifestPath) // If we rename below, this will be a no-op
@@ -125,6 +125,10 @@ func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fil
 			if err == nil {
 				err = cerr
 			}
+			if err != nil {
+				// don't leave a partial table file behind to take up space
+				file.Remove(temp.Name())
+			}
 		}()
 
 		_, err = io.Copy(temp, r)
@@ -141,7 +145,7 @@ func (ftp *fsTablePersister) CopyTableFile(ctx context.Context, r io.Reader, fil
 	}()
 	defer f()
 	if err != nil {
-		return err
+		return checkDiskFull(err)
 	}
 
 	path := filepath.Join(ftp.dir, fileId)
@@ -160,7 +164,7 @@ func (ftp *fsTablePersister) TryMoveCmpChunkTableWriter(ctx context.Context, fil
 		ftp.toKeep[filepath.Clean(path)] = struct{}{}
 	}
 	defer ftp.removeMu.Unlock()
-	return w.FlushToFile(path)
+	return checkDiskFull(w.FlushToFile(path))
 }
 
 func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, data []byte, chunkCount uint32, stats *Stats) (cs chunkSource, err error) {
@@ -190,6 +194,10 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 			if ferr == nil {
 				ferr = closeErr
 			}
+			if ferr != nil {
+				// don't leave a partial table file behind to take up space
+				file.Remove(temp.Name())
+			}
 		}()
 
 		_, ferr = io.Copy(temp, bytes.NewReader(data))
@@ -206,7 +214,7 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 	}()
 	defer f()
 	if err != nil {
-		return nil, err
+		return nil, checkDiskFull(err)
 	}
 
 	newName := filepath.Join(ftp.dir, name.String())
@@ -256,6 +264,10 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 			if ferr == nil {
 				ferr = closeErr
 			}
+			if ferr != nil {
+				// don't leave a partial table file behind to take up space
+				file.Remove(temp.Name())
+			}
 		}()
 
 		for _, sws := range plan.sources.sws {
@@ -297,7 +309,7 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 	}()
 	defer f()
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, checkDiskFull(err)
 	}
 
 	path := filepath.Join(ftp.dir, name.String())
@@ -15,7 +15,9 @@
 package nbs
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"errors"
 	"fmt"
 	"io"
@@ -37,6 +39,48 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+
+	lazyFetcher LazyChunkFetcher
+}
+
+// LazyChunkFetcher fetches chunks which are missing from a partial clone from the remote it was cloned from. It
+// returns the chunks of |hashes| which it found, along with any other chunks it fetched because they are likely to
+// be read next.
+type LazyChunkFetcher func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error)
+
+// SetLazyChunkFetcher sets the fetcher of the chunks which are missing from |gcs| because it is a partial clone.
+// Chunks fetched by |f| are persisted to the new generation, so each of them is only fetched once.
+func (gcs *GenerationalNBS) SetLazyChunkFetcher(f LazyChunkFetcher) {
+	gcs.lazyFetcher = f
+}
+
+// fetchMissing fetches the chunks of |hashes| with the lazy chunk fetcher of |gcs|, if it has one, and persists them
+// in a new table file.
+func (gcs *GenerationalNBS) fetchMissing(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+	if gcs.lazyFetcher == nil || len(hashes) == 0 {
+		return nil, nil
+	}
+	fetched, err := gcs.lazyFetcher(ctx, hashes)
+	if err != nil || len(fetched) == 0 {
+		return nil, err
+	}
+
+	name, data, err := WriteChunks(fetched)
+	if err != nil {
+		return nil, err
+	}
+	contentHash := md5.Sum(data)
+	err = gcs.newGen.WriteTableFile(ctx, name, len(fetched), contentHash[:], func() (io.ReadCloser, uint64, error) {
+		return io.NopCloser(bytes.NewReader(data)), uint64(len(data)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = gcs.newGen.AddTableFilesToManifest(ctx, map[string]int{name: len(fetched)})
+	if err != nil {
+		return nil, err
+	}
+	return fetched, nil
 }
 
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
@@ -94,6 +138,18 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		}
 	}
 
+	if c.IsEmpty() && gcs.lazyFetcher != nil {
+		fetched, err := gcs.fetchMissing(ctx, hash.NewHashSet(h))
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+		for _, fc := range fetched {
+			if fc.Hash() == h {
+				return fc, nil
+			}
+		}
+	}
+
 	return c, nil
 }
 
@@ -134,6 +190,21 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
+	// Chunks skipped by a partial clone are fetched from its remote.
+	fetched, err := gcs.fetchMissing(ctx, notFound)
+	if err != nil {
+		return err
+	}
+	for i := range fetched {
+		if notFound.Has(fetched[i].Hash()) {
+			delete(notFound, fetched[i].Hash())
+			found(ctx, &fetched[i])
+		}
+	}
+	if len(notFound) == 0 {
+		return nil
+	}
+
 	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
 	// considers non-present chunks to be silently ignored, so we don't need to return an error here
 	if gcs.ghostGen == nil {
@@ -180,6 +251,20 @@ func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.H
 		return nil
 	}
 
+	fetched, err := gcs.fetchMissing(ctx, notFound)
+	if err != nil {
+		return err
+	}
+	for _, fc := range fetched {
+		if notFound.Has(fc.Hash()) {
+			delete(notFound, fc.Hash())
+			found(ctx, ChunkToCompressedChunk(fc))
+		}
+	}
+	if len(notFound) == 0 {
+		return nil
+	}
+
 	// We are definitely missing some chunks. Check if any are ghost chunks, mainly to give a better error message.
 	if gcs.ghostGen != nil {
 		// If any of the hashes are in the ghost store.
@@ -410,6 +495,21 @@ func (gcs *GenerationalNBS) Size(ctx context.Context) (uint64, error) {
 	return oldSize + newSize, nil
 }
 
+// PendingSize returns the number of bytes of chunks written to the store which are not yet persisted
+func (gcs *GenerationalNBS) PendingSize() uint64 {
+	return gcs.newGen.PendingSize()
+}
+
+// TableFileAddresses returns the addresses of the chunks in the table file with the id |fileId|, which may be in
+// either generation. Returns false if neither generation has such a table file.
+func (gcs *GenerationalNBS) TableFileAddresses(ctx context.Context, fileId string) (hash.HashSet, bool, error) {
+	addrs, ok, err := gcs.newGen.TableFileAddresses(ctx, fileId)
+	if err != nil || ok {
+		return addrs, ok, err
+	}
+	return gcs.oldGen.TableFileAddresses(ctx, fileId)
+}
+
 // WriteTableFile will read a table file from the provided reader and write it to the new gen TableFileStore
 func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
 	return gcs.newGen.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
@@ -171,3 +171,54 @@ func TestGenerationalCS(t *testing.T) {
 	putChunks(t, ctx, chnks, cs, inNew, 15, 16, 17, 18, 19)
 	requireChunks(t, ctx, chnks, cs, inOld, inNew)
 }
+
+func TestGenerationalCSLazyChunkFetcher(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	cs := NewGenerationalCS(oldGen, newGen, nil)
+	chnks := genChunks(t, 10, 1000)
+
+	remote := make(map[hash.Hash]chunks.Chunk)
+	for _, c := range chnks {
+		remote[c.Hash()] = c
+	}
+	var requests []hash.HashSet
+	cs.SetLazyChunkFetcher(func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+		requests = append(requests, hashes.Copy())
+		var fetched []chunks.Chunk
+		for h := range hashes {
+			if c, ok := remote[h]; ok {
+				fetched = append(fetched, c)
+			}
+		}
+		return fetched, nil
+	})
+
+	c, err := cs.Get(ctx, chnks[0].Hash())
+	require.NoError(t, err)
+	require.Equal(t, chnks[0].Data(), c.Data())
+	require.Len(t, requests, 1)
+
+	expected := hashesForChunks(chnks, map[int]bool{0: true, 1: true, 2: true})
+	received := foundHashes{}
+	require.NoError(t, cs.GetMany(ctx, expected, received.found))
+	require.Equal(t, expected, hash.HashSet(received))
+	require.Len(t, requests, 2)
+	require.Equal(t, hashesForChunks(chnks, map[int]bool{1: true, 2: true}), requests[1])
+
+	// Fetched chunks are persisted, so they are only fetched once.
+	received = foundHashes{}
+	require.NoError(t, cs.GetMany(ctx, expected, received.found))
+	require.Equal(t, expected, hash.HashSet(received))
+	require.Len(t, requests, 2)
+	has, err := newGen.Has(ctx, chnks[2].Hash())
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// Chunks the fetcher doesn't find are still missing.
+	missing := chunks.NewChunk([]byte("missing"))
+	c, err = cs.Get(ctx, missing.Hash())
+	require.NoError(t, err)
+	require.True(t, c.IsEmpty())
+}
@@ -66,6 +66,13 @@ type ChunkJournal struct {
 	// reflogRingBuffer holds the most recent roots written to the chunk journal so that they can be
 	// quickly loaded for reflog queries without having to re-read the journal file from disk.
 	reflogRingBuffer *reflogRingBuffer
+
+	// rollOver is the policy for rolling the journal over into a table file, and openedAt is the time the current
+	// journal file was opened. See NomsBlockStore.FlushJournal.
+	rollOver journalRollOverPolicy
+	openedAt time.Time
+	// retired is the writer of the journal last rolled over, kept open for reads of table sets from before then.
+	retired *journalWriter
 }
 
 var _ tablePersister = &ChunkJournal{}
@@ -80,10 +87,15 @@ func newChunkJournal(ctx context.Context, nbfVers, dir string, m *journalManifes
 		return nil, err
 	}
 
-	j := &ChunkJournal{path: path, backing: m, persister: p}
+	j := &ChunkJournal{path: path, backing: m, persister: p, rollOver: journalRollOverPolicyFromEnv()}
 	j.contents.nbfVers = nbfVers
 	j.reflogRingBuffer = newReflogRingBuffer(reflogBufferSize())
 
+	// a journal retired before the process last exited has been rolled over, and is no longer needed
+	if err = deleteRetiredJournalFiles(path); err != nil {
+		return nil, err
+	}
+
 	ok, err := fileExists(path)
 	if err != nil {
 		return nil, err
@@ -143,6 +155,7 @@ func (j *ChunkJournal) bootstrapJournalWriter(ctx context.Context) (err error) {
 		return err
 	}
 
+	j.openedAt = time.Now()
 	if !ok { // create new journal file
 		j.wr, err = createJournalWriter(ctx, j.path)
 		if err != nil {
@@ -355,6 +368,16 @@ func (j *ChunkJournal) Update(ctx context.Context, lastLock hash.Hash, next mani
 		}
 	}
 
+	// if |next| no longer has the journal, it has been rolled over into a table file and is replaced by a new journal
+	// on the next write. See NomsBlockStore.FlushJournal.
+	if containsJournalSpec(j.contents.specs) && !containsJournalSpec(next.specs) {
+		j.contents = next
+		if err := j.retireJournalWriter(ctx); err != nil {
+			return manifestContents{}, err
+		}
+		return j.contents, nil
+	}
+
 	if err := j.wr.commitRootHash(ctx, next.root); err != nil {
 		return manifestContents{}, err
 	}
@@ -464,8 +487,14 @@ func (j *ChunkJournal) maybeInit(ctx context.Context) (err error) {
 
 // Close implements io.Closer
 func (j *ChunkJournal) Close() (err error) {
+	if j.retired != nil {
+		err = closeRetiredJournalWriter(j.retired)
+		j.retired = nil
+	}
 	if j.wr != nil {
-		err = j.wr.Close()
+		if cerr := j.wr.Close(); err == nil {
+			err = cerr
+		}
 		// flush the latest root to the backing manifest
 		if !j.backing.readOnly() {
 			cerr := j.flushToBackingManifest(context.Background(), j.contents, &Stats{})
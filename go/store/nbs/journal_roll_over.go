@@ -0,0 +1,265 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// retiredJournalSuffix is appended to the names of the journal and journal index files after they are rolled over.
+const retiredJournalSuffix = ".retired"
+
+// ErrNoChunkJournal is returned by FlushJournal for a store which doesn't write a chunk journal.
+var ErrNoChunkJournal = errors.New("this database does not use a chunk journal")
+
+// journalRollOverPolicy is the policy for rolling the chunk journal over into a table file automatically. Every chunk
+// written since the journal was created is read from it when it's opened, so bounding the size and age of the journal
+// bounds the time taken to open the database and the disk used by the journal.
+type journalRollOverPolicy struct {
+	// maxSize is the size in bytes at which the journal is rolled over, or 0 to never roll it over for its size
+	maxSize int64
+	// maxAge is the time after which a journal with any chunks in it is rolled over, or 0 to never roll it over for
+	// its age
+	maxAge time.Duration
+}
+
+// journalRollOverPolicyFromEnv returns the journal roll over policy set by DOLT_JOURNAL_ROLL_OVER_SIZE, a number of
+// bytes, and DOLT_JOURNAL_ROLL_OVER_AGE, a duration such as "24h". The journal is never rolled over automatically if
+// neither is set.
+func journalRollOverPolicyFromEnv() (p journalRollOverPolicy) {
+	if size := os.Getenv(dconfig.EnvJournalRollOverSize); size != "" {
+		i, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || i < 0 {
+			logrus.Warnf("unable to parse a number of bytes for %s from %s", dconfig.EnvJournalRollOverSize, size)
+		} else {
+			p.maxSize = i
+		}
+	}
+	if age := os.Getenv(dconfig.EnvJournalRollOverAge); age != "" {
+		d, err := time.ParseDuration(age)
+		if err != nil || d < 0 {
+			logrus.Warnf("unable to parse a duration for %s from %s", dconfig.EnvJournalRollOverAge, age)
+		} else {
+			p.maxAge = d
+		}
+	}
+	return p
+}
+
+// rollOverRequired returns whether the journal written by |wr|, opened at |openedAt|, should be rolled over.
+func (p journalRollOverPolicy) rollOverRequired(wr *journalWriter, openedAt time.Time) bool {
+	if wr == nil {
+		return false
+	}
+	if p.maxSize > 0 && wr.currentSize() >= p.maxSize {
+		return true
+	}
+	return p.maxAge > 0 && time.Since(openedAt) >= p.maxAge && wr.recordCount() > 0
+}
+
+// FlushJournal rolls the chunk journal over into a table file: the chunks in the journal are written to a new table
+// file which replaces the journal in the manifest, and the journal is deleted. The next write to the store begins a
+// new journal. Returns ErrNoChunkJournal if this store doesn't write a chunk journal.
+func (nbs *NomsBlockStore) FlushJournal(ctx context.Context) (err error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if err = nbs.waitForGC(ctx); err != nil {
+		return err
+	}
+	if err = nbs.waitForSnapshot(ctx); err != nil {
+		return err
+	}
+
+	nbs.mm.LockForUpdate()
+	defer func() {
+		unlockErr := nbs.mm.UnlockForUpdate()
+		if err == nil {
+			err = unlockErr
+		}
+	}()
+	return nbs.flushJournal(ctx)
+}
+
+// rollOverJournalIfRequired rolls the chunk journal over into a table file if it's required by the journal's roll over
+// policy. Callers must hold |nbs.mu| and the manifest update lock.
+func (nbs *NomsBlockStore) rollOverJournalIfRequired(ctx context.Context) {
+	j, ok := nbs.p.(*ChunkJournal)
+	if !ok || !j.rollOver.rollOverRequired(j.wr, j.openedAt) {
+		return
+	}
+	if err := nbs.flushJournal(ctx); err != nil {
+		// the journal remains in use, and its roll over is attempted again on the next commit
+		logrus.Warnf("failed to roll over the chunk journal: %s", err.Error())
+	}
+}
+
+// flushJournal implements FlushJournal. Callers must hold |nbs.mu| and the manifest update lock.
+func (nbs *NomsBlockStore) flushJournal(ctx context.Context) error {
+	j, ok := nbs.p.(*ChunkJournal)
+	if !ok {
+		return ErrNoChunkJournal
+	} else if j.backing.readOnly() {
+		return errReadOnlyManifest
+	} else if j.wr == nil || !containsJournalSpec(nbs.upstream.specs) {
+		// nothing has been written to the journal
+		return nil
+	}
+
+	copier, err := newGarbageCollectionCopier()
+	if err != nil {
+		return err
+	}
+	if err = j.wr.copyChunks(ctx, copier.addChunk); err != nil {
+		_ = copier.writer.Remove()
+		return err
+	}
+	tableSpecs, err := copier.copyTablesToDir(ctx, j.persister)
+	if err != nil {
+		return err
+	}
+
+	specs := make([]tableSpec, 0, len(nbs.upstream.specs))
+	for _, spec := range nbs.upstream.specs {
+		if !isJournalAddr(spec.name) {
+			specs = append(specs, spec)
+		}
+	}
+	specs = append(specs, tableSpecs...)
+
+	newContents := manifestContents{
+		nbfVers:  nbs.upstream.nbfVers,
+		root:     nbs.upstream.root,
+		lock:     generateLockHash(nbs.upstream.root, specs, nbs.upstream.appendix, nil),
+		gcGen:    nbs.upstream.gcGen,
+		specs:    specs,
+		appendix: nbs.upstream.appendix,
+	}
+	upstream, err := nbs.mm.Update(ctx, nbs.upstream.lock, newContents, nbs.stats, nil)
+	if err != nil {
+		return err
+	} else if upstream.lock != newContents.lock {
+		return errors.New("concurrent manifest edit while flushing the chunk journal")
+	}
+
+	// chunks written to the journal since the last commit are in the new table file with the rest
+	tables := nbs.tables
+	tables.novel = make(chunkSourceSet, len(nbs.tables.novel))
+	for name, cs := range nbs.tables.novel {
+		if !isJournalAddr(name) {
+			tables.novel[name] = cs
+		}
+	}
+	ts, err := tables.rebase(ctx, upstream.specs, nbs.stats)
+	if err != nil {
+		return err
+	}
+	oldTables := nbs.tables
+	nbs.tables, nbs.upstream = ts, upstream
+	return oldTables.close()
+}
+
+// copyChunks calls |f| with every chunk in the journal.
+func (wr *journalWriter) copyChunks(ctx context.Context, f func(context.Context, CompressedChunk) error) error {
+	wr.lock.RLock()
+	defer wr.lock.RUnlock()
+
+	var err error
+	wr.ranges.novel.Iter(func(h hash.Hash, r Range) (stop bool) {
+		var cc CompressedChunk
+		if cc, err = wr.getCompressedChunkAtRange(r, h); err == nil {
+			err = f(ctx, cc)
+		}
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	wr.ranges.cached.Iter(func(a16 addr16, r Range) (stop bool) {
+		// cached ranges are keyed by a prefix of the chunk's address, so its address is computed from its data
+		var cc CompressedChunk
+		if cc, err = wr.getCompressedChunkAtRange(r, hash.Hash{}); err != nil {
+			return true
+		}
+		var data []byte
+		if data, err = snappy.Decode(nil, cc.CompressedData); err != nil {
+			return true
+		}
+		cc.H = hash.Of(data)
+		err = f(ctx, cc)
+		return err != nil
+	})
+	return err
+}
+
+// retireJournalWriter removes the current journal after it's been rolled over into a table file. The journal's writer
+// is kept open, and its files renamed, so that reads of table sets from before the journal was rolled over still
+// succeed. Where open files can't be renamed, the journal is closed and deleted immediately.
+func (j *ChunkJournal) retireJournalWriter(ctx context.Context) error {
+	curr := j.wr
+	j.wr = nil
+	if j.retired != nil {
+		if err := closeRetiredJournalWriter(j.retired); err != nil {
+			return err
+		}
+		j.retired = nil
+	}
+
+	idxPath := filepath.Join(filepath.Dir(curr.path), journalIndexFileName)
+	if err := os.Rename(curr.path, curr.path+retiredJournalSuffix); err != nil {
+		if err := curr.Close(); err != nil {
+			return err
+		}
+		return deleteJournalAndIndexFiles(ctx, curr.path)
+	}
+	if err := os.Rename(idxPath, idxPath+retiredJournalSuffix); err != nil && !os.IsNotExist(err) {
+		if err := curr.Close(); err != nil {
+			return err
+		}
+		return deleteJournalAndIndexFiles(ctx, curr.path+retiredJournalSuffix)
+	}
+	j.retired = curr
+	return nil
+}
+
+// closeRetiredJournalWriter closes |wr|, the writer of a journal which has been rolled over, and deletes its files.
+func closeRetiredJournalWriter(wr *journalWriter) error {
+	if err := wr.Close(); err != nil {
+		return err
+	}
+	return deleteRetiredJournalFiles(wr.path)
+}
+
+// deleteRetiredJournalFiles deletes the files of a journal retired from |path|, if they exist.
+func deleteRetiredJournalFiles(path string) error {
+	idxPath := filepath.Join(filepath.Dir(path), journalIndexFileName)
+	for _, p := range []string{path + retiredJournalSuffix, idxPath + retiredJournalSuffix} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
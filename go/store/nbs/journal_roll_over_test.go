@@ -0,0 +1,134 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func makeTestJournalingStore(t *testing.T, dir string) *NomsBlockStore {
+	cacheOnce.Do(makeGlobalCaches)
+	q := NewUnlimitedMemQuotaProvider()
+	nbf := types.Format_Default.VersionString()
+	st, err := NewLocalJournalingStore(context.Background(), nbf, dir, q)
+	require.NoError(t, err)
+	return st
+}
+
+// putAndCommit writes |n| new chunks to |st| and commits the last of them as its root.
+func putAndCommit(t *testing.T, st *NomsBlockStore, n int) []chunks.Chunk {
+	ctx := context.Background()
+	written := make([]chunks.Chunk, n)
+	for i := range written {
+		written[i] = chunks.NewChunk([]byte(fmt.Sprintf("chunk %d at %d", i, time.Now().UnixNano())))
+		require.NoError(t, st.Put(ctx, written[i], noopGetAddrs))
+	}
+	last, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Commit(ctx, written[n-1].Hash(), last)
+	require.NoError(t, err)
+	require.True(t, ok)
+	return written
+}
+
+func requireStoreChunks(t *testing.T, st *NomsBlockStore, expected []chunks.Chunk) {
+	for _, c := range expected {
+		actual, err := st.Get(context.Background(), c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), actual.Data())
+	}
+}
+
+func TestFlushJournal(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { file.RemoveAll(dir) })
+	journalPath := filepath.Join(dir, chunkJournalName)
+
+	st := makeTestJournalingStore(t, dir)
+	written := putAndCommit(t, st, 16)
+	assert.True(t, containsJournalSpec(st.upstream.specs))
+
+	require.NoError(t, st.FlushJournal(ctx))
+	assert.False(t, containsJournalSpec(st.upstream.specs))
+	assert.NoFileExists(t, journalPath)
+	requireStoreChunks(t, st, written)
+	root, err := st.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, written[15].Hash(), root)
+
+	// flushing without a journal does nothing
+	require.NoError(t, st.FlushJournal(ctx))
+
+	// writes after the flush begin a new journal
+	written = append(written, putAndCommit(t, st, 16)...)
+	assert.True(t, containsJournalSpec(st.upstream.specs))
+	assert.FileExists(t, journalPath)
+	requireStoreChunks(t, st, written)
+	require.NoError(t, st.Close())
+	assert.NoFileExists(t, journalPath+retiredJournalSuffix)
+
+	// the journal indexed when it's reopened is flushed too
+	st = makeTestJournalingStore(t, dir)
+	requireStoreChunks(t, st, written)
+	require.NoError(t, st.FlushJournal(ctx))
+	assert.False(t, containsJournalSpec(st.upstream.specs))
+	requireStoreChunks(t, st, written)
+	require.NoError(t, st.Close())
+
+	st = makeTestJournalingStore(t, dir)
+	defer st.Close()
+	requireStoreChunks(t, st, written)
+	root, err = st.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, written[31].Hash(), root)
+}
+
+func TestJournalRollOverPolicy(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { file.RemoveAll(dir) })
+
+	t.Setenv(dconfig.EnvJournalRollOverSize, "4096")
+	st := makeTestJournalingStore(t, dir)
+	defer st.Close()
+	assert.Equal(t, journalRollOverPolicy{maxSize: 4096}, st.ChunkJournal().rollOver)
+
+	written := putAndCommit(t, st, 4)
+	assert.True(t, containsJournalSpec(st.upstream.specs))
+	written = append(written, putAndCommit(t, st, 256)...)
+	assert.False(t, containsJournalSpec(st.upstream.specs))
+	requireStoreChunks(t, st, written)
+
+	t.Setenv(dconfig.EnvJournalRollOverSize, "")
+	t.Setenv(dconfig.EnvJournalRollOverAge, "1h")
+	assert.Equal(t, journalRollOverPolicy{maxAge: time.Hour}, journalRollOverPolicyFromEnv())
+	t.Setenv(dconfig.EnvJournalRollOverAge, "not a duration")
+	assert.Equal(t, journalRollOverPolicy{}, journalRollOverPolicyFromEnv())
+}
@@ -110,6 +110,15 @@ func TestReadRecordRanges(t *testing.T) {
 
 	ranges, err := jcs.getRecordRanges(ctx, gets)
 	require.NoError(t, err)
+	assert.Len(t, ranges, len(data))
+
+	// records are marked found, so later chunk sources don't report them again
+	for _, g := range gets {
+		assert.True(t, g.found)
+	}
+	again, err := jcs.getRecordRanges(ctx, gets)
+	require.NoError(t, err)
+	assert.Empty(t, again)
 
 	for h, rng := range ranges {
 		b, err := jcs.get(ctx, h, &Stats{})
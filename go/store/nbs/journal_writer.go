@@ -56,8 +56,14 @@ const (
 
 var (
 	journalAddr = hash.Parse(chunkJournalAddr)
+
+	// syncJournal syncs the journal file to disk. Tests replace it to fail syncs.
+	syncJournal = (*os.File).Sync
 )
 
+// ErrJournalFailed is returned by writes to a chunk journal after syncing it has failed, until it's reopened.
+var ErrJournalFailed = errors.New("chunk journal failed to sync to disk, and must be reopened before it can be written")
+
 func isJournalAddr(h hash.Hash) bool {
 	return h == journalAddr
 }
@@ -168,6 +174,10 @@ type journalWriter struct {
 	batchCrc    uint32
 	maxNovel    int
 
+	// failed is the error with which syncing the journal failed, if it has. Once a sync has failed, which writes
+	// reached the disk is unknown, even if a later sync succeeds, so nothing more is written to the journal.
+	failed error
+
 	lock sync.RWMutex
 }
 
@@ -414,6 +424,9 @@ func (wr *journalWriter) getRange(ctx context.Context, h hash.Hash) (rng Range,
 func (wr *journalWriter) writeCompressedChunk(ctx context.Context, cc CompressedChunk) error {
 	wr.lock.Lock()
 	defer wr.lock.Unlock()
+	if wr.failed != nil {
+		return wr.failedErr()
+	}
 	recordLen, payloadOff := chunkRecordSize(cc)
 	rng := Range{
 		Offset: uint64(wr.offset()) + uint64(payloadOff),
@@ -429,7 +442,7 @@ func (wr *journalWriter) writeCompressedChunk(ctx context.Context, cc Compressed
 
 	a := toAddr16(cc.H)
 	if err := writeIndexLookup(wr.indexWriter, lookup{a: a, r: rng}); err != nil {
-		return err
+		return checkDiskFull(err)
 	}
 	wr.batchCrc = crc32.Update(wr.batchCrc, crcTable, a[:])
 
@@ -464,23 +477,41 @@ func (wr *journalWriter) commitRootHash(ctx context.Context, root hash.Hash) err
 
 func (wr *journalWriter) commitRootHashUnlocked(ctx context.Context, root hash.Hash) error {
 	defer trace.StartRegion(ctx, "commit-root").End()
+	if wr.failed != nil {
+		return wr.failedErr()
+	}
 
 	buf, err := wr.getBytes(ctx, rootHashRecordSize())
 	if err != nil {
 		return err
 	}
+	prev := wr.currentRoot
 	wr.currentRoot = root
 	n := writeRootHashRecord(buf, root)
 	if err = wr.flush(ctx); err != nil {
+		// drop the root hash record, so that a later flush
+		// cannot commit |root| after this commit has failed
+		wr.buf = wr.buf[:len(wr.buf)-int(n)]
+		wr.currentRoot = prev
 		return err
 	}
 	func() {
 		defer trace.StartRegion(ctx, "sync").End()
 
-		err = wr.journal.Sync()
+		err = syncJournal(wr.journal)
 	}()
 	if err != nil {
-		return err
+		// the root hash record may or may not have reached the disk.
+		// rewind over it and zero it out, best effort, so that journal
+		// bootstrapping won't see it. The kernel may have dropped any of
+		// the pages written since the last sync, so the journal is failed,
+		// and it's bootstrapped from what's on disk when it's reopened
+		wr.off -= int64(n)
+		_, _ = wr.journal.WriteAt(make([]byte, n), wr.off)
+		wr.currentRoot = prev
+		wr.failed = checkDiskFull(err)
+		logrus.Errorf("chunk journal %s failed to sync, refusing writes until it's reopened: %s", wr.path, err.Error())
+		return wr.failedErr()
 	}
 
 	wr.unsyncd = 0
@@ -499,7 +530,7 @@ func (wr *journalWriter) commitRootHashUnlocked(ctx context.Context, root hash.H
 func (wr *journalWriter) flushIndexRecord(ctx context.Context, root hash.Hash, end int64) (err error) {
 	defer trace.StartRegion(ctx, "flushIndexRecord").End()
 	if err := writeJournalIndexMeta(wr.indexWriter, root, wr.indexed, end, wr.batchCrc); err != nil {
-		return err
+		return checkDiskFull(err)
 	}
 	wr.batchCrc = 0
 	wr.ranges = wr.ranges.flatten(ctx)
@@ -549,11 +580,19 @@ func (wr *journalWriter) getBytes(ctx context.Context, n int) (buf []byte, err e
 	return
 }
 
+// failedErr returns the error of writes to the journal after it has failed.
+func (wr *journalWriter) failedErr() error {
+	return fmt.Errorf("%w: %w", ErrJournalFailed, wr.failed)
+}
+
 // flush writes buffered data into the journal file.
 func (wr *journalWriter) flush(ctx context.Context) (err error) {
 	defer trace.StartRegion(ctx, "flush journal").End()
+	if wr.failed != nil {
+		return wr.failedErr()
+	}
 	if _, err = wr.journal.WriteAt(wr.buf, wr.off); err != nil {
-		return err
+		return checkDiskFull(err)
 	}
 	wr.off += int64(len(wr.buf))
 	wr.buf = wr.buf[:0]
@@ -635,14 +674,19 @@ func (wr *journalWriter) Close() (err error) {
 		return nil
 	}
 
-	if err = wr.flush(context.Background()); err != nil {
-		return err
+	if wr.failed == nil {
+		if err = wr.flush(context.Background()); err != nil {
+			return err
+		}
 	}
 	if wr.index != nil {
 		_ = wr.indexWriter.Flush()
 		_ = wr.index.Close()
 	}
-	if cerr := wr.journal.Sync(); cerr != nil {
+	if wr.failed != nil {
+		// nothing written since the journal failed is synced
+		err = wr.failedErr()
+	} else if cerr := wr.journal.Sync(); cerr != nil {
 		err = cerr
 	}
 	if cerr := wr.journal.Close(); cerr != nil {
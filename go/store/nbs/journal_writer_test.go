@@ -17,6 +17,7 @@ package nbs
 import (
 	"context"
 	"encoding/base32"
+	"errors"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -236,6 +237,81 @@ func TestJournalWriterBootstrap(t *testing.T) {
 	}
 }
 
+func TestJournalWriterFailedCommitRootHash(t *testing.T) {
+	ctx := context.Background()
+	path := newTestFilePath(t)
+	j := newTestJournalWriter(t, path)
+	data := randomCompressedChunks(16)
+	var first, second hash.Hash
+	for _, cc := range data {
+		require.NoError(t, j.writeCompressedChunk(ctx, cc))
+		first, second = second, cc.Hash()
+	}
+	require.NoError(t, j.commitRootHash(ctx, first))
+	off := j.offset()
+
+	// writes to a read only file fail, as they would on a full disk
+	journal := j.journal
+	ro, err := os.Open(path)
+	require.NoError(t, err)
+	j.journal = ro
+	require.Error(t, j.commitRootHash(ctx, second))
+	assert.Equal(t, first, j.currentRoot)
+	assert.Equal(t, off, j.offset())
+	require.NoError(t, ro.Close())
+
+	j.journal = journal
+	require.NoError(t, j.commitRootHash(ctx, second))
+	require.NoError(t, j.Close())
+
+	j, _, err = openJournalWriter(ctx, path)
+	require.NoError(t, err)
+	reflogBuffer := newReflogRingBuffer(10)
+	last, err := j.bootstrapJournal(ctx, reflogBuffer)
+	require.NoError(t, err)
+	assert.Equal(t, second, last)
+	assertExpectedIterationOrder(t, reflogBuffer, []string{first.String(), second.String()})
+	validateAllLookups(t, j, data)
+}
+
+func TestJournalWriterFailedSync(t *testing.T) {
+	ctx := context.Background()
+	path := newTestFilePath(t)
+	j := newTestJournalWriter(t, path)
+	data := randomCompressedChunks(16)
+	var first, second hash.Hash
+	for _, cc := range data {
+		require.NoError(t, j.writeCompressedChunk(ctx, cc))
+		first, second = second, cc.Hash()
+	}
+	require.NoError(t, j.commitRootHash(ctx, first))
+
+	syncErr := errors.New("sync failed")
+	syncJournal = func(*os.File) error { return syncErr }
+	err := j.commitRootHash(ctx, second)
+	syncJournal = (*os.File).Sync
+	require.ErrorIs(t, err, ErrJournalFailed)
+	require.ErrorIs(t, err, syncErr)
+	assert.Equal(t, first, j.currentRoot)
+
+	// once a sync has failed, the journal refuses writes, even though syncs succeed again
+	assert.ErrorIs(t, j.commitRootHash(ctx, second), ErrJournalFailed)
+	for _, cc := range randomCompressedChunks(1) {
+		assert.ErrorIs(t, j.writeCompressedChunk(ctx, cc), ErrJournalFailed)
+	}
+	assert.ErrorIs(t, j.Close(), ErrJournalFailed)
+
+	// reopening the journal bootstraps it from what's on disk, and it can be written again
+	j, _, err = openJournalWriter(ctx, path)
+	require.NoError(t, err)
+	last, err := j.bootstrapJournal(ctx, newReflogRingBuffer(10))
+	require.NoError(t, err)
+	assert.Equal(t, first, last)
+	validateAllLookups(t, j, data)
+	require.NoError(t, j.commitRootHash(ctx, second))
+	require.NoError(t, j.Close())
+}
+
 func validateAllLookups(t *testing.T, j *journalWriter, data map[hash.Hash]CompressedChunk) {
 	// move |data| to addr16-keyed map
 	prefixMap := make(map[addr16]CompressedChunk, len(data))
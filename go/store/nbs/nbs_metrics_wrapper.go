@@ -51,6 +51,14 @@ func (nbsMW *NBSMetricWrapper) Size(ctx context.Context) (uint64, error) {
 	return nbsMW.nbs.Size(ctx)
 }
 
+func (nbsMW *NBSMetricWrapper) PendingSize() uint64 {
+	return nbsMW.nbs.PendingSize()
+}
+
+func (nbsMW *NBSMetricWrapper) TableFileAddresses(ctx context.Context, fileId string) (hash.HashSet, bool, error) {
+	return nbsMW.nbs.TableFileAddresses(ctx, fileId)
+}
+
 // WriteTableFile will read a table file from the provided reader and write it to the TableFileStore
 func (nbsMW *NBSMetricWrapper) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
 	return nbsMW.nbs.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
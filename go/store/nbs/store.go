@@ -45,6 +45,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
 	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -104,6 +105,8 @@ type NomsBlockStore struct {
 	// keeperFunc is set when |gcInProgress| and appends to the GC sweep queue
 	// or blocks on GC finalize
 	keeperFunc func(hash.Hash) bool
+	// snapshotInProgress is set between BeginSnapshot and EndSnapshot, while updates of the manifest wait
+	snapshotInProgress bool
 
 	mtSize   uint64
 	putCount uint64
@@ -222,6 +225,10 @@ func (nbs *NomsBlockStore) UpdateManifest(ctx context.Context, updates map[hash.
 	if err != nil {
 		return
 	}
+	err = nbs.waitForSnapshot(ctx)
+	if err != nil {
+		return
+	}
 
 	err = nbs.checkAllManifestUpdatesExist(ctx, updates)
 	if err != nil {
@@ -310,6 +317,10 @@ func (nbs *NomsBlockStore) UpdateManifestWithAppendix(ctx context.Context, updat
 	if err != nil {
 		return
 	}
+	err = nbs.waitForSnapshot(ctx)
+	if err != nil {
+		return
+	}
 
 	err = nbs.checkAllManifestUpdatesExist(ctx, updates)
 	if err != nil {
@@ -1191,6 +1202,10 @@ func (nbs *NomsBlockStore) commit(ctx context.Context, current, last hash.Hash,
 		return true, nil
 	}
 
+	if err = nbs.waitForSnapshot(ctx); err != nil {
+		return false, err
+	}
+
 	nbs.mm.LockForUpdate()
 	defer func() {
 		unlockErr := nbs.mm.UnlockForUpdate()
@@ -1202,6 +1217,7 @@ func (nbs *NomsBlockStore) commit(ctx context.Context, current, last hash.Hash,
 
 	for {
 		if err := nbs.updateManifest(ctx, current, last, checker); err == nil {
+			nbs.rollOverJournalIfRequired(ctx)
 			return true, nil
 		} else if err == errOptimisticLockFailedRoot || err == errLastRootMismatch {
 			return false, nil
@@ -1479,6 +1495,50 @@ func (nbs *NomsBlockStore) Size(ctx context.Context) (uint64, error) {
 	return size, nil
 }
 
+// PendingSize returns the number of bytes of chunks which have been written to the store, but which are not yet
+// part of a table file or the journal, and so are not counted by |Size|.
+func (nbs *NomsBlockStore) PendingSize() uint64 {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if nbs.mt == nil {
+		return 0
+	}
+	return nbs.mt.totalData
+}
+
+// TableFileAddresses returns the addresses of the chunks in the table file, or journal, with the id |fileId|. Returns
+// false if the store has no such table file.
+func (nbs *NomsBlockStore) TableFileAddresses(ctx context.Context, fileId string) (hash.HashSet, bool, error) {
+	addr, ok := hash.MaybeParse(fileId)
+	if !ok {
+		return nil, false, nil
+	}
+
+	nbs.mu.Lock()
+	css, err := nbs.chunkSourcesByAddr()
+	var cs chunkSource
+	if err == nil {
+		if cs, ok = css[addr]; ok {
+			// keep the source open while it is read outside of the lock
+			cs, err = cs.clone()
+		}
+	}
+	nbs.mu.Unlock()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	defer cs.close()
+
+	addrs := hash.NewHashSet()
+	err = cs.iterateAllChunks(ctx, func(c chunks.Chunk) {
+		addrs.Insert(c.Hash())
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return addrs, true, nil
+}
+
 func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[hash.Hash]chunkSource, error) {
 	css := make(map[hash.Hash]chunkSource, len(nbs.tables.upstream)+len(nbs.tables.novel))
 	for _, cs := range nbs.tables.upstream {
@@ -1580,6 +1640,8 @@ func (nbs *NomsBlockStore) BeginGC(keeper func(hash.Hash) bool) error {
 	defer nbs.cond.L.Unlock()
 	if nbs.gcInProgress {
 		return errors.New("gc already in progress")
+	} else if nbs.snapshotInProgress {
+		return ErrSnapshotInProgress
 	}
 	nbs.gcInProgress = true
 	nbs.keeperFunc = keeper
@@ -1708,6 +1770,7 @@ LOOP:
 			mu := new(sync.Mutex)
 			hashset := hash.NewHashSet(hs...)
 			found := 0
+			var copied int64
 			err := src.GetManyCompressed(ctx, hashset, func(ctx context.Context, c CompressedChunk) {
 				mu.Lock()
 				defer mu.Unlock()
@@ -1715,6 +1778,7 @@ LOOP:
 					return
 				}
 				found += 1
+				copied += int64(c.CompressedSize())
 				addErr = gcc.addChunk(ctx, c)
 			})
 			if err != nil {
@@ -1726,6 +1790,10 @@ LOOP:
 			if found != len(hashset) {
 				return nil, fmt.Errorf("dangling references requested during GC. GC not successful. %v", hashset)
 			}
+			// Throttle the copy when GC runs as a background job.
+			if err := background.ReportIO(ctx, copied); err != nil {
+				return nil, err
+			}
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		}
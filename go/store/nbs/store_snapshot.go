@@ -0,0 +1,166 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrSnapshotInProgress is returned by BeginSnapshot when a snapshot of the store is already in progress.
+	ErrSnapshotInProgress = errors.New("a snapshot of this database is already in progress")
+	// ErrNoSnapshotInProgress is returned by EndSnapshot when no snapshot of the store is in progress.
+	ErrNoSnapshotInProgress = errors.New("no snapshot of this database is in progress")
+	// ErrSnapshotUnsupported is returned by BeginSnapshot for stores whose files aren't on the local filesystem.
+	ErrSnapshotUnsupported = errors.New("snapshots are only supported for databases stored on the local filesystem")
+)
+
+// SnapshotFile is a file of a store which must be copied to take a backup of it while a snapshot is in progress.
+type SnapshotFile struct {
+	// Path is the absolute path of the file.
+	Path string
+	// Size is the number of bytes of the file to copy. The chunk journal may be appended to during a snapshot, but
+	// the bytes appended aren't needed to open the copy.
+	Size int64
+}
+
+// SnapshotStore is a chunk store whose files can be copied consistently, for filesystem-level backups, while it's in
+// use.
+type SnapshotStore interface {
+	// BeginSnapshot quiesces the updates of the store's manifest, so that the set of files making up the store, and
+	// their contents, don't change until EndSnapshot is called, and returns those files. Commits, garbage collection
+	// and other updates of the manifest wait for the end of the snapshot.
+	BeginSnapshot(ctx context.Context) ([]SnapshotFile, error)
+	// EndSnapshot ends the snapshot begun by BeginSnapshot.
+	EndSnapshot() error
+}
+
+var _ SnapshotStore = &NomsBlockStore{}
+var _ SnapshotStore = &GenerationalNBS{}
+
+// BeginSnapshot implements SnapshotStore.
+func (nbs *NomsBlockStore) BeginSnapshot(ctx context.Context) ([]SnapshotFile, error) {
+	dir, ok := nbs.Path()
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if err := nbs.waitForGC(ctx); err != nil {
+		return nil, err
+	}
+	if nbs.snapshotInProgress {
+		return nil, ErrSnapshotInProgress
+	}
+
+	// an empty store has no manifest
+	files := make([]SnapshotFile, 0, len(nbs.upstream.specs)+1)
+	if f, ok, err := snapshotFile(filepath.Join(dir, manifestFileName)); err != nil {
+		return nil, err
+	} else if ok {
+		files = append(files, f)
+	}
+	for _, spec := range nbs.upstream.specs {
+		name := spec.name.String()
+		if isJournalAddr(spec.name) {
+			name = chunkJournalName
+		}
+		f, ok, err := snapshotFile(filepath.Join(dir, name))
+		if err == nil && !ok {
+			f, ok, err = snapshotFile(filepath.Join(dir, name+archiveFileSuffix))
+		}
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errors.New("table file " + name + " of the manifest not found in " + dir)
+		}
+		files = append(files, f)
+	}
+
+	nbs.snapshotInProgress = true
+	return files, nil
+}
+
+// EndSnapshot implements SnapshotStore.
+func (nbs *NomsBlockStore) EndSnapshot() error {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if !nbs.snapshotInProgress {
+		return ErrNoSnapshotInProgress
+	}
+	nbs.snapshotInProgress = false
+	nbs.cond.Broadcast()
+	return nil
+}
+
+// waitForSnapshot waits for the end of a snapshot in progress, so that the manifest can be updated. Callers must hold
+// |nbs.mu|.
+func (nbs *NomsBlockStore) waitForSnapshot(ctx context.Context) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			nbs.cond.Broadcast()
+		case <-stop:
+		}
+	}()
+	for nbs.snapshotInProgress && ctx.Err() == nil {
+		nbs.cond.Wait()
+	}
+	return ctx.Err()
+}
+
+// snapshotFile returns the SnapshotFile for the file at |path|, and whether it exists.
+func snapshotFile(path string) (SnapshotFile, bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return SnapshotFile{}, false, nil
+	} else if err != nil {
+		return SnapshotFile{}, false, err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return SnapshotFile{}, false, err
+	}
+	return SnapshotFile{Path: abs, Size: info.Size()}, true, nil
+}
+
+// BeginSnapshot implements SnapshotStore for both generations of the store.
+func (gcs *GenerationalNBS) BeginSnapshot(ctx context.Context) ([]SnapshotFile, error) {
+	oldFiles, err := gcs.oldGen.BeginSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := gcs.newGen.BeginSnapshot(ctx)
+	if err != nil {
+		_ = gcs.oldGen.EndSnapshot()
+		return nil, err
+	}
+	return append(newFiles, oldFiles...), nil
+}
+
+// EndSnapshot implements SnapshotStore for both generations of the store.
+func (gcs *GenerationalNBS) EndSnapshot() error {
+	err := gcs.newGen.EndSnapshot()
+	if oldErr := gcs.oldGen.EndSnapshot(); err == nil {
+		err = oldErr
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+func TestStoreSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { file.RemoveAll(dir) })
+
+	st := makeTestJournalingStore(t, dir)
+	defer st.Close()
+	written := putAndCommit(t, st, 16)
+	require.NoError(t, st.FlushJournal(ctx))
+	written = append(written, putAndCommit(t, st, 16)...)
+
+	files, err := st.BeginSnapshot(ctx)
+	require.NoError(t, err)
+	names := make([]string, len(files))
+	for i, f := range files {
+		info, err := os.Stat(f.Path)
+		require.NoError(t, err)
+		assert.Equal(t, info.Size(), f.Size)
+		names[i] = filepath.Base(f.Path)
+	}
+	// the manifest, the table file of the flushed journal and the new journal
+	assert.Len(t, names, 3)
+	assert.Contains(t, names, manifestFileName)
+	assert.Contains(t, names, chunkJournalName)
+
+	_, err = st.BeginSnapshot(ctx)
+	assert.ErrorIs(t, err, ErrSnapshotInProgress)
+	assert.ErrorIs(t, st.BeginGC(nil), ErrSnapshotInProgress)
+
+	// commits wait for the end of the snapshot
+	committed := make(chan []chunks.Chunk)
+	go func() {
+		committed <- putAndCommit(t, st, 4)
+	}()
+	select {
+	case <-committed:
+		t.Fatal("commit completed during a snapshot")
+	case <-time.After(100 * time.Millisecond):
+	}
+	manifest, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	require.NoError(t, err)
+
+	// a commit waiting for a snapshot can be cancelled
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	root, err := st.Root(ctx)
+	require.NoError(t, err)
+	_, err = st.Commit(cancelled, written[0].Hash(), root)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	after, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, manifest, after)
+
+	require.NoError(t, st.EndSnapshot())
+	written = append(written, <-committed...)
+	requireStoreChunks(t, st, written)
+	assert.ErrorIs(t, st.EndSnapshot(), ErrNoSnapshotInProgress)
+}
@@ -127,6 +127,45 @@ func (c AddressMap) IterAll(ctx context.Context, cb func(name string, address ha
 	return nil
 }
 
+// IterPrefix calls |cb| for each name in the map that starts with |prefix|, in order. Only the nodes of the map that
+// contain those names are read.
+func (c AddressMap) IterPrefix(ctx context.Context, prefix string, cb func(name string, address hash.Hash) error) error {
+	iter, err := c.addresses.IterKeyRange(ctx, stringSlice(prefix), prefixSuccessor(prefix))
+	if err != nil {
+		return err
+	}
+
+	var n stringSlice
+	var a address
+	for {
+		n, a, err = iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = cb(string(n), hash.New(a)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prefixSuccessor returns the smallest name that is greater than every name starting with |prefix|, or nil if there
+// is no such name.
+func prefixSuccessor(prefix string) stringSlice {
+	succ := []byte(prefix)
+	for i := len(succ) - 1; i >= 0; i-- {
+		if succ[i] < 0xff {
+			succ[i]++
+			return succ[:i+1]
+		}
+	}
+	return nil
+}
+
 func (c AddressMap) Editor() AddressMapEditor {
 	return AddressMapEditor{
 		addresses: c.addresses.Mutate(),
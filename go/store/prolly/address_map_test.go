@@ -19,6 +19,7 @@ import (
 	"context"
 	"math/rand"
 	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,6 +61,55 @@ func TestAddressMap(t *testing.T) {
 	})
 }
 
+func TestAddressMapIterPrefix(t *testing.T) {
+	ctx := context.Background()
+	ns := tree.NewTestNodeStore()
+	addr, err := ns.Write(ctx, tree.NewEmptyTestNode())
+	require.NoError(t, err)
+
+	empty, err := NewEmptyAddressMap(ns)
+	require.NoError(t, err)
+	editor := empty.Editor()
+
+	var expected []string
+	for i := 0; i < 1000; i++ {
+		for _, ns := range []string{"refs/heads/", "refs/heads/team/", "refs/heads/team0/", "refs/tags/"} {
+			name := ns + strconv.Itoa(i)
+			require.NoError(t, editor.Add(ctx, name, addr))
+			if ns == "refs/heads/team/" {
+				expected = append(expected, name)
+			}
+		}
+	}
+	require.NoError(t, editor.Add(ctx, "\xff\xff", addr))
+	am, err := editor.Flush(ctx)
+	require.NoError(t, err)
+	sort.Strings(expected)
+
+	var actual []string
+	err = am.IterPrefix(ctx, "refs/heads/team/", func(name string, a hash.Hash) error {
+		assert.Equal(t, addr, a)
+		actual = append(actual, name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	actual = nil
+	err = am.IterPrefix(ctx, "\xff", func(name string, _ hash.Hash) error {
+		actual = append(actual, name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"\xff\xff"}, actual)
+
+	err = am.IterPrefix(ctx, "refs/remotes/", func(name string, _ hash.Hash) error {
+		t.Errorf("unexpected name %s", name)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
 type addrPair struct {
 	n []byte
 	h hash.Hash
@@ -495,6 +495,28 @@ type ConflictArtifact struct {
 type ConflictMetadata struct {
 	// BaseRootIsh is the target hash of the working set holding the base value for the conflict.
 	BaseRootIsh hash.Hash `json:"bc"`
+	// BaseTable is the name of the table in the base root, when it differs from the name of the conflicted table
+	// because the table was renamed.
+	BaseTable string `json:"bt,omitempty"`
+	// TheirTable is the name of the table in their root, when it differs from the name of the conflicted table
+	// because the table was renamed.
+	TheirTable string `json:"tt,omitempty"`
+}
+
+// BaseTableName returns the name that the conflicted table |tblName| has in the base root.
+func (m ConflictMetadata) BaseTableName(tblName string) string {
+	if m.BaseTable != "" {
+		return m.BaseTable
+	}
+	return tblName
+}
+
+// TheirTableName returns the name that the conflicted table |tblName| has in their root.
+func (m ConflictMetadata) TheirTableName(tblName string) string {
+	if m.TheirTable != "" {
+		return m.TheirTable
+	}
+	return tblName
 }
 
 // ConstraintViolationMeta is the json metadata for foreign key constraint violations
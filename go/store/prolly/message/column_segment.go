@@ -0,0 +1,241 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"context"
+
+	fb "github.com/dolthub/flatbuffers/v23/go"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// These constants are mirrored from serial.ColumnSegment.Items()
+	// and serial.ColumnSegment.Offsets() respectively.
+	// They are only as stable as the flatbuffers schema that define them.
+	columnSegmentItemBytesVOffset fb.VOffsetT = 4
+	columnSegmentOffsetsVOffset   fb.VOffsetT = 6
+)
+
+var columnSegmentFileID = []byte(serial.ColumnSegmentFileID)
+
+// ColumnSerializer is a Serializer that can store the values of leaf
+// nodes column-wise: the ith field of every value of a leaf is stored
+// in the leaf's ith column segment, a chunk of its own, and the leaf
+// stores the addresses of its column segments in place of its values.
+// Readers that only need some fields of the values of a leaf only read
+// the column segments of those fields. See ProllyMapSerializer.WithColumnar.
+type ColumnSerializer interface {
+	Serializer
+
+	// Columnar returns whether the serializer stores the values
+	// of leaf nodes column-wise.
+	Columnar() bool
+
+	// SerializeColumns serializes a leaf node whose values are
+	// stored column-wise, and the column segments of its values.
+	SerializeColumns(keys, values [][]byte) (leaf serial.Message, segments []serial.Message)
+}
+
+var _ ColumnSerializer = ProllyMapSerializer{}
+
+// WithColumnar returns a copy of the serializer which stores the values
+// of leaf nodes column-wise if |columnar| is set.
+func (s ProllyMapSerializer) WithColumnar(columnar bool) ProllyMapSerializer {
+	s.columnar = columnar
+	return s
+}
+
+// Columnar implements ColumnSerializer.
+func (s ProllyMapSerializer) Columnar() bool {
+	return s.columnar
+}
+
+// SerializeColumns implements ColumnSerializer. A leaf has a column
+// segment for each field of its widest value, the fields missing from
+// the end of narrower values being NULL, as they are in val.Tuple.
+// Leaves whose values have no fields are serialized row-wise.
+func (s ProllyMapSerializer) SerializeColumns(keys, values [][]byte) (serial.Message, []serial.Message) {
+	var fields int
+	for _, v := range values {
+		if n := val.Tuple(v).Count(); n > fields {
+			fields = n
+		}
+	}
+
+	addrFields := make([]bool, fields)
+	val.IterAddressFields(s.valDesc, func(i int, _ val.Type) {
+		if i < fields {
+			addrFields[i] = true
+		}
+	})
+
+	if fields == 0 {
+		// values without fields have no column segments
+		return s.Serialize(keys, values, nil, 0), nil
+	}
+
+	segments := make([]serial.Message, fields)
+	items := make([][]byte, len(values))
+	for i := range segments {
+		for j, v := range values {
+			items[j] = val.Tuple(v).GetField(i)
+		}
+		segments[i] = s.serializeColumnSegment(items, addrFields[i])
+	}
+
+	keySz := 0
+	for _, k := range keys {
+		keySz += len(k)
+	}
+	bufSz := keySz + len(keys)*2 + len(segments)*hash.ByteLen + 100 + serial.MessagePrefixSz
+	b := getFlatbufferBuilder(s.pool, bufSz)
+
+	keyTups := writeItemBytes(b, keys, keySz)
+	serial.ProllyTreeNodeStartKeyOffsetsVector(b, len(keys)+1)
+	keyOffs := writeItemOffsets(b, keys, keySz)
+
+	serial.ProllyTreeNodeStartColumnSegmentsVector(b, len(segments)*hash.ByteLen)
+	for i := len(segments) - 1; i >= 0; i-- {
+		addr := hash.Of(segments[i])
+		for j := hash.ByteLen - 1; j >= 0; j-- {
+			b.PrependByte(addr[j])
+		}
+	}
+	segArr := b.EndVector(len(segments) * hash.ByteLen)
+
+	serial.ProllyTreeNodeStart(b)
+	serial.ProllyTreeNodeAddKeyItems(b, keyTups)
+	serial.ProllyTreeNodeAddKeyOffsets(b, keyOffs)
+	serial.ProllyTreeNodeAddTreeCount(b, uint64(len(keys)))
+	serial.ProllyTreeNodeAddColumnSegments(b, segArr)
+	serial.ProllyTreeNodeAddKeyType(b, serial.ItemTypeTupleFormatAlpha)
+	serial.ProllyTreeNodeAddValueType(b, serial.ItemTypeTupleFormatAlpha)
+	serial.ProllyTreeNodeAddTreeLevel(b, 0)
+
+	return serial.FinishMessage(b, serial.ProllyTreeNodeEnd(b), prollyMapFileID), segments
+}
+
+// serializeColumnSegment serializes the column segment of the field |items|,
+// which are chunk addresses if |addrs| is set.
+func (s ProllyMapSerializer) serializeColumnSegment(items [][]byte, addrs bool) serial.Message {
+	sz := 0
+	for _, item := range items {
+		sz += len(item)
+	}
+	bufSz := sz + len(items)*2 + 100 + serial.MessagePrefixSz
+	if addrs {
+		bufSz += len(items) * 2
+	}
+	b := getFlatbufferBuilder(s.pool, bufSz)
+
+	itemBytes := writeItemBytes(b, items, sz)
+	serial.ColumnSegmentStartOffsetsVector(b, len(items)+1)
+	itemOffs := writeItemOffsets(b, items, sz)
+
+	var addrOffs fb.UOffsetT
+	if addrs {
+		cnt := 0
+		for _, item := range items {
+			if isAddress(item) {
+				cnt++
+			}
+		}
+		serial.ColumnSegmentStartAddressOffsetsVector(b, cnt)
+		off := sz
+		for i := len(items) - 1; i >= 0; i-- {
+			off -= len(items[i])
+			if isAddress(items[i]) {
+				b.PrependUint16(uint16(off))
+			}
+		}
+		addrOffs = b.EndVector(cnt)
+	}
+
+	serial.ColumnSegmentStart(b)
+	serial.ColumnSegmentAddItems(b, itemBytes)
+	serial.ColumnSegmentAddOffsets(b, itemOffs)
+	if addrs {
+		serial.ColumnSegmentAddAddressOffsets(b, addrOffs)
+	}
+	return serial.FinishMessage(b, serial.ColumnSegmentEnd(b), columnSegmentFileID)
+}
+
+func isAddress(item []byte) bool {
+	return len(item) > 0 && !hash.New(item).IsEmpty()
+}
+
+// GetColumnSegments returns the addresses of the column segments of the
+// leaf node |msg|, or nil if its values aren't stored column-wise.
+func GetColumnSegments(msg serial.Message) ([]hash.Hash, error) {
+	if serial.GetFileID(msg) != serial.ProllyTreeNodeFileID {
+		return nil, nil
+	}
+	var pm serial.ProllyTreeNode
+	if err := serial.InitProllyTreeNodeRoot(&pm, msg, serial.MessagePrefixSz); err != nil {
+		return nil, err
+	}
+	arr := pm.ColumnSegmentsBytes()
+	if len(arr) == 0 {
+		return nil, nil
+	}
+	addrs := make([]hash.Hash, len(arr)/hash.ByteLen)
+	for i := range addrs {
+		addrs[i] = hash.New(arr[i*hash.ByteLen : (i+1)*hash.ByteLen])
+	}
+	return addrs, nil
+}
+
+func getColumnSegmentItems(msg serial.Message) (keys, values ItemAccess, level, count uint16, err error) {
+	var cs serial.ColumnSegment
+	err = serial.InitColumnSegmentRoot(&cs, msg, serial.MessagePrefixSz)
+	if err != nil {
+		return
+	}
+	values.bufStart = lookupVectorOffset(columnSegmentItemBytesVOffset, cs.Table())
+	values.bufLen = uint16(cs.ItemsLength())
+	values.offStart = lookupVectorOffset(columnSegmentOffsetsVOffset, cs.Table())
+	values.offLen = uint16(cs.OffsetsLength() * uint16Size)
+	count = (values.offLen / 2) - 1
+	return
+}
+
+func walkColumnSegmentAddresses(ctx context.Context, msg serial.Message, cb func(ctx context.Context, addr hash.Hash) error) error {
+	var cs serial.ColumnSegment
+	err := serial.InitColumnSegmentRoot(&cs, msg, serial.MessagePrefixSz)
+	if err != nil {
+		return err
+	}
+	items := cs.ItemsBytes()
+	for i := 0; i < cs.AddressOffsetsLength(); i++ {
+		o := cs.AddressOffsets(i)
+		if err := cb(ctx, hash.New(items[o:o+addrSize])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getColumnSegmentCount(msg serial.Message) (int, error) {
+	var cs serial.ColumnSegment
+	err := serial.InitColumnSegmentRoot(&cs, msg, serial.MessagePrefixSz)
+	if err != nil {
+		return 0, err
+	}
+	return cs.OffsetsLength() - 1, nil
+}
@@ -65,6 +65,9 @@ func UnpackFields(msg serial.Message) (fileId string, keys, values ItemAccess, l
 	case serial.BlobFileID:
 		keys, values, level, count, err = getBlobKeysAndValues(msg)
 		return
+	case serial.ColumnSegmentFileID:
+		keys, values, level, count, err = getColumnSegmentItems(msg)
+		return
 	default:
 		panic(fmt.Sprintf("unknown message id %s", serial.GetFileID(msg)))
 	}
@@ -83,6 +86,8 @@ func WalkAddresses(ctx context.Context, msg serial.Message, cb func(ctx context.
 		return walkCommitClosureAddresses(ctx, msg, cb)
 	case serial.BlobFileID:
 		return walkBlobAddresses(ctx, msg, cb)
+	case serial.ColumnSegmentFileID:
+		return walkColumnSegmentAddresses(ctx, msg, cb)
 	default:
 		panic(fmt.Sprintf("unknown message id %s", id))
 	}
@@ -101,6 +106,8 @@ func GetTreeCount(msg serial.Message) (int, error) {
 		return getCommitClosureTreeCount(msg)
 	case serial.BlobFileID:
 		return getBlobTreeCount(msg)
+	case serial.ColumnSegmentFileID:
+		return getColumnSegmentCount(msg)
 	default:
 		panic(fmt.Sprintf("unknown message id %s", id))
 	}
@@ -119,6 +126,8 @@ func GetSubtrees(msg serial.Message) ([]uint64, error) {
 		return getCommitClosureSubtrees(msg)
 	case serial.BlobFileID:
 		return getBlobSubtrees(msg)
+	case serial.ColumnSegmentFileID:
+		return nil, nil
 	default:
 		panic(fmt.Sprintf("unknown message id %s", id))
 	}
@@ -45,21 +45,47 @@ func NewProllyMapSerializer(valueDesc val.TupleDesc, pool pool.BuffPool) ProllyM
 }
 
 type ProllyMapSerializer struct {
-	valDesc val.TupleDesc
-	pool    pool.BuffPool
+	valDesc    val.TupleDesc
+	pool       pool.BuffPool
+	nodeSize   uint32
+	zoneFields []uint16
+	columnar   bool
 }
 
 var _ Serializer = ProllyMapSerializer{}
 
+// WithTargetNodeSize returns a copy of the serializer whose trees are
+// chunked to leaf nodes of |size| bytes. Zero selects the default size.
+func (s ProllyMapSerializer) WithTargetNodeSize(size uint32) ProllyMapSerializer {
+	s.nodeSize = size
+	return s
+}
+
+// TargetNodeSize returns the target leaf node size, or zero for the default.
+func (s ProllyMapSerializer) TargetNodeSize() uint32 {
+	return s.nodeSize
+}
+
 func (s ProllyMapSerializer) Serialize(keys, values [][]byte, subtrees []uint64, level int) serial.Message {
+	return s.serialize(keys, values, subtrees, nil, level)
+}
+
+func (s ProllyMapSerializer) serialize(keys, values [][]byte, subtrees []uint64, zones [][]byte, level int) serial.Message {
 	var (
-		keyTups, keyOffs fb.UOffsetT
-		valTups, valOffs fb.UOffsetT
-		valAddrOffs      fb.UOffsetT
-		refArr, cardArr  fb.UOffsetT
+		keyTups, keyOffs   fb.UOffsetT
+		valTups, valOffs   fb.UOffsetT
+		valAddrOffs        fb.UOffsetT
+		refArr, cardArr    fb.UOffsetT
+		zoneFlds           fb.UOffsetT
+		zoneTups, zoneOffs fb.UOffsetT
 	)
 
 	keySz, valSz, bufSz := estimateProllyMapSize(keys, values, subtrees, s.valDesc.AddressFieldCount())
+	zoneSz, hasZones := zonesSize(zones)
+	hasZones = hasZones && level > 0 && len(zones) > 0 && s.KeepsZoneMaps()
+	if hasZones {
+		bufSz += zoneSz + len(zones)*2 + len(s.zoneFields)*2
+	}
 	b := getFlatbufferBuilder(s.pool, bufSz)
 
 	// serialize keys and offStart
@@ -81,6 +107,17 @@ func (s ProllyMapSerializer) Serialize(keys, values [][]byte, subtrees []uint64,
 		// serialize child refs and subtree counts for internal nodes
 		refArr = writeItemBytes(b, values, valSz)
 		cardArr = writeCountArray(b, subtrees)
+		// serialize zone maps of subtrees, if any
+		if hasZones {
+			zoneTups = writeItemBytes(b, zones, zoneSz)
+			serial.ProllyTreeNodeStartZoneOffsetsVector(b, len(zones)+1)
+			zoneOffs = writeItemOffsets(b, zones, zoneSz)
+			serial.ProllyTreeNodeStartZoneFieldsVector(b, len(s.zoneFields))
+			for i := len(s.zoneFields) - 1; i >= 0; i-- {
+				b.PrependUint16(s.zoneFields[i])
+			}
+			zoneFlds = b.EndVector(len(s.zoneFields))
+		}
 	}
 
 	// populate the node's vtable
@@ -96,6 +133,11 @@ func (s ProllyMapSerializer) Serialize(keys, values [][]byte, subtrees []uint64,
 		serial.ProllyTreeNodeAddAddressArray(b, refArr)
 		serial.ProllyTreeNodeAddSubtreeCounts(b, cardArr)
 		serial.ProllyTreeNodeAddTreeCount(b, sumSubtrees(subtrees))
+		if hasZones {
+			serial.ProllyTreeNodeAddZoneFields(b, zoneFlds)
+			serial.ProllyTreeNodeAddZoneItems(b, zoneTups)
+			serial.ProllyTreeNodeAddZoneOffsets(b, zoneOffs)
+		}
 	}
 	serial.ProllyTreeNodeAddKeyType(b, serial.ItemTypeTupleFormatAlpha)
 	serial.ProllyTreeNodeAddValueType(b, serial.ItemTypeTupleFormatAlpha)
@@ -119,7 +161,11 @@ func getProllyMapKeysAndValues(msg serial.Message) (keys, values ItemAccess, lev
 	level = uint16(pm.TreeLevel())
 
 	vv := pm.ValueItemsBytes()
-	if vv != nil {
+	if pm.ColumnSegmentsLength() > 0 {
+		// the values of leaf nodes stored column-wise
+		// are read from their column segments
+		return
+	} else if vv != nil {
 		values.bufStart = lookupVectorOffset(prollyMapValueItemBytesVOffset, pm.Table())
 		values.bufLen = uint16(pm.ValueItemsLength())
 		values.offStart = lookupVectorOffset(prollyMapValueOffsetsVOffset, pm.Table())
@@ -146,6 +192,14 @@ func walkProllyMapAddresses(ctx context.Context, msg serial.Message, cb func(ctx
 		}
 	}
 
+	segs := pm.ColumnSegmentsBytes()
+	for i := 0; i < len(segs)/hash.ByteLen; i++ {
+		addr := hash.New(segs[i*addrSize : (i+1)*addrSize])
+		if err := cb(ctx, addr); err != nil {
+			return err
+		}
+	}
+
 	cnt := pm.ValueAddressOffsetsLength()
 	arr2 := pm.ValueItemsBytes()
 	for i := 0; i < cnt; i++ {
@@ -0,0 +1,214 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"fmt"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MaxZoneMapFields is the maximum number of value fields that a
+// prolly map can keep zone maps for. Together with the fixed-width
+// encodings allowed for zone map fields, it bounds the size of the
+// zone maps of an internal node below |MaxVectorOffset|.
+const MaxZoneMapFields = 4
+
+// ZoneSerializer is a Serializer that keeps zone maps in internal nodes.
+// A zone map holds the min and max value of some value fields within a
+// subtree, so that readers can skip subtrees that hold no values in a
+// range. See ProllyMapSerializer.WithZoneMapFields.
+type ZoneSerializer interface {
+	Serializer
+
+	// KeepsZoneMaps returns whether the serializer writes zone maps.
+	KeepsZoneMaps() bool
+
+	// SerializeWithZones serializes an internal node whose subtrees
+	// have the zone maps |zones|.
+	SerializeWithZones(keys, values [][]byte, subtrees []uint64, zones [][]byte, level int) serial.Message
+
+	// NodeZone returns the zone map of a node. Leaf node zone maps are
+	// computed from their |values|, internal node zone maps from the
+	// |zones| of their subtrees. An empty zone map is unknown.
+	NodeZone(values, zones [][]byte, level int) []byte
+
+	// SubtreeZone returns the zone map of the |i|th subtree of the
+	// internal node |msg|, or nil if the node has no zone map for it.
+	SubtreeZone(msg serial.Message, i int) []byte
+}
+
+var _ ZoneSerializer = ProllyMapSerializer{}
+
+// ValidateZoneMapFields returns an error if zone maps can't be kept for
+// the value |fields| of |desc|. Zone maps are kept for up to
+// |MaxZoneMapFields| fields with fixed-width encodings.
+func ValidateZoneMapFields(desc val.TupleDesc, fields []int) error {
+	if len(fields) > MaxZoneMapFields {
+		return fmt.Errorf("zone maps can be kept for at most %d fields", MaxZoneMapFields)
+	}
+	for i, f := range fields {
+		if f < 0 || f >= desc.Count() {
+			return fmt.Errorf("zone map field %d is out of range", f)
+		}
+		if !zoneMapEncoding(desc.Types[f].Enc) {
+			return fmt.Errorf("zone maps are not supported for field %d", f)
+		}
+		for _, g := range fields[:i] {
+			if f == g {
+				return fmt.Errorf("duplicate zone map field %d", f)
+			}
+		}
+	}
+	return nil
+}
+
+func zoneMapEncoding(enc val.Encoding) bool {
+	switch enc {
+	case val.Int8Enc, val.Uint8Enc, val.Int16Enc, val.Uint16Enc,
+		val.Int32Enc, val.Uint32Enc, val.Int64Enc, val.Uint64Enc,
+		val.Float32Enc, val.Float64Enc, val.Bit64Enc, val.YearEnc,
+		val.DateEnc, val.TimeEnc, val.DatetimeEnc, val.EnumEnc, val.SetEnc:
+		return true
+	default:
+		return false
+	}
+}
+
+// WithZoneMapFields returns a copy of the serializer that keeps zone
+// maps for the value |fields| in internal nodes. |fields| must be
+// validated with ValidateZoneMapFields.
+func (s ProllyMapSerializer) WithZoneMapFields(fields []int) ProllyMapSerializer {
+	s.zoneFields = nil
+	for _, f := range fields {
+		s.zoneFields = append(s.zoneFields, uint16(f))
+	}
+	return s
+}
+
+// KeepsZoneMaps implements ZoneSerializer.
+func (s ProllyMapSerializer) KeepsZoneMaps() bool {
+	return len(s.zoneFields) > 0
+}
+
+// SerializeWithZones implements ZoneSerializer.
+func (s ProllyMapSerializer) SerializeWithZones(keys, values [][]byte, subtrees []uint64, zones [][]byte, level int) serial.Message {
+	return s.serialize(keys, values, subtrees, zones, level)
+}
+
+// NodeZone implements ZoneSerializer. The zone map is a tuple holding
+// the min and max of each zone map field, in the order of the fields.
+// The min and max of a field are NULL when the field is NULL in every row.
+func (s ProllyMapSerializer) NodeZone(values, zones [][]byte, level int) []byte {
+	bounds := make([][]byte, 2*len(s.zoneFields))
+	if level == 0 {
+		for _, v := range values {
+			for j, f := range s.zoneFields {
+				s.widenZone(bounds, j, val.Tuple(v).GetField(int(f)), val.Tuple(v).GetField(int(f)))
+			}
+		}
+	} else {
+		for _, z := range zones {
+			if len(z) == 0 {
+				return nil
+			}
+			for j := range s.zoneFields {
+				s.widenZone(bounds, j, val.Tuple(z).GetField(2*j), val.Tuple(z).GetField(2*j+1))
+			}
+		}
+	}
+	return val.NewTuple(s.pool, bounds...)
+}
+
+// widenZone widens the bounds of the |j|th zone map field to include |min| and |max|.
+func (s ProllyMapSerializer) widenZone(bounds [][]byte, j int, min, max []byte) {
+	if min == nil {
+		return
+	}
+	f := int(s.zoneFields[j])
+	typ := s.valDesc.Types[f]
+	cmp := s.valDesc.Comparator()
+	if bounds[2*j] == nil || cmp.CompareValues(f, min, bounds[2*j], typ) < 0 {
+		bounds[2*j] = min
+	}
+	if bounds[2*j+1] == nil || cmp.CompareValues(f, max, bounds[2*j+1], typ) > 0 {
+		bounds[2*j+1] = max
+	}
+}
+
+// SubtreeZone implements ZoneSerializer. Zone maps kept for other fields
+// than this serializer's are ignored.
+func (s ProllyMapSerializer) SubtreeZone(msg serial.Message, i int) []byte {
+	if serial.GetFileID(msg) != serial.ProllyTreeNodeFileID {
+		return nil
+	}
+	var pm serial.ProllyTreeNode
+	if err := serial.InitProllyTreeNodeRoot(&pm, msg, serial.MessagePrefixSz); err != nil {
+		return nil
+	}
+	if pm.ZoneFieldsLength() != len(s.zoneFields) || pm.ZoneOffsetsLength() <= i+1 {
+		return nil
+	}
+	for j, f := range s.zoneFields {
+		if pm.ZoneFields(j) != f {
+			return nil
+		}
+	}
+	return subtreeZone(&pm, i)
+}
+
+// GetSubtreeZones returns the zone map fields of the internal prolly tree
+// node |msg| and the zone maps of its subtrees, if it has zone maps. The
+// zone map of a subtree is a tuple holding the min and max of each field.
+func GetSubtreeZones(msg serial.Message) (fields []int, zones [][]byte, err error) {
+	if serial.GetFileID(msg) != serial.ProllyTreeNodeFileID {
+		return nil, nil, nil
+	}
+	var pm serial.ProllyTreeNode
+	if err = serial.InitProllyTreeNodeRoot(&pm, msg, serial.MessagePrefixSz); err != nil {
+		return nil, nil, err
+	}
+	if pm.ZoneFieldsLength() == 0 || pm.ZoneOffsetsLength() == 0 {
+		return nil, nil, nil
+	}
+	fields = make([]int, pm.ZoneFieldsLength())
+	for j := range fields {
+		fields[j] = int(pm.ZoneFields(j))
+	}
+	zones = make([][]byte, pm.ZoneOffsetsLength()-1)
+	for i := range zones {
+		zones[i] = subtreeZone(&pm, i)
+	}
+	return fields, zones, nil
+}
+
+func subtreeZone(pm *serial.ProllyTreeNode, i int) []byte {
+	start, stop := pm.ZoneOffsets(i), pm.ZoneOffsets(i+1)
+	if start == stop {
+		return nil
+	}
+	return pm.ZoneItemsBytes()[start:stop]
+}
+
+// zonesSize returns the size of |zones|, or false if they can't be
+// stored in a node.
+func zonesSize(zones [][]byte) (int, bool) {
+	var sz int
+	for _, z := range zones {
+		sz += len(z)
+	}
+	return sz, sz <= int(MaxVectorOffset)
+}
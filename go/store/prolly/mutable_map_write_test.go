@@ -17,6 +17,7 @@ package prolly
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"testing"
 
@@ -500,6 +501,115 @@ func testInternalNodeSplits(t *testing.T) {
 	}
 }
 
+func TestMutableMapTargetNodeSize(t *testing.T) {
+	ctx := context.Background()
+	tuples := ascendingTuplesWithStepAndStart(10_000, 1, 0)
+	empty := mustProllyMapFromTuples(t, mutKeyDesc, mutValDesc, nil)
+
+	write := func(t *testing.T, m Map, tuples [][2]val.Tuple) Map {
+		mut := m.Mutate()
+		for _, tup := range tuples {
+			require.NoError(t, mut.Put(ctx, tup[0], tup[1]))
+		}
+		m, err := mut.Map(ctx)
+		require.NoError(t, err)
+		return m
+	}
+
+	dflt := write(t, empty, tuples)
+	small := write(t, empty.WithTargetNodeSize(1024), tuples)
+	assert.Equal(t, uint32(1024), small.TargetNodeSize())
+	assert.NotEqual(t, dflt.HashOf(), small.HashOf())
+
+	cnt, err := small.Count()
+	require.NoError(t, err)
+	assert.Equal(t, len(tuples), cnt)
+
+	// the layout of a sized map depends only on its contents
+	half := write(t, empty.WithTargetNodeSize(1024), tuples[:len(tuples)/2])
+	assert.Equal(t, uint32(1024), half.TargetNodeSize())
+	assert.Equal(t, small.HashOf(), write(t, half, tuples[len(tuples)/2:]).HashOf())
+
+	dfltSized := write(t, empty.WithTargetNodeSize(4096), tuples)
+	assert.Equal(t, dflt.HashOf(), dfltSized.HashOf())
+
+	// rechunking a map gives the layout it would have had if it was written with the new size
+	rechunked, err := RechunkMap(ctx, dflt, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1024), rechunked.TargetNodeSize())
+	assert.Equal(t, small.HashOf(), rechunked.HashOf())
+	rechunked, err = RechunkMap(ctx, small, 0)
+	require.NoError(t, err)
+	assert.Equal(t, dflt.HashOf(), rechunked.HashOf())
+}
+
+func TestMutableMapColumnar(t *testing.T) {
+	ctx := context.Background()
+	tuples := ascendingTuplesWithStepAndStart(10_000, 1, 0)
+	empty := mustProllyMapFromTuples(t, mutKeyDesc, mutValDesc, nil)
+
+	write := func(t *testing.T, m Map, tuples [][2]val.Tuple) Map {
+		mut := m.Mutate()
+		for _, tup := range tuples {
+			require.NoError(t, mut.Put(ctx, tup[0], tup[1]))
+		}
+		m, err := mut.Map(ctx)
+		require.NoError(t, err)
+		return m
+	}
+	remove := func(t *testing.T, m Map, tuples [][2]val.Tuple) Map {
+		mut := m.Mutate()
+		for _, tup := range tuples {
+			require.NoError(t, mut.Delete(ctx, tup[0]))
+		}
+		m, err := mut.Map(ctx)
+		require.NoError(t, err)
+		return m
+	}
+	values := func(t *testing.T, m Map) (values []int64) {
+		iter, err := m.IterAll(ctx)
+		require.NoError(t, err)
+		for {
+			_, v, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			i, ok := mutValDesc.GetInt64(0, v)
+			require.True(t, ok)
+			values = append(values, i)
+		}
+		return
+	}
+
+	dflt := write(t, empty, tuples)
+	columnar := write(t, empty.WithColumnar(true), tuples)
+	assert.True(t, columnar.Columnar())
+	assert.NotEqual(t, dflt.HashOf(), columnar.HashOf())
+	assert.Equal(t, values(t, dflt), values(t, columnar))
+
+	// the layout of a columnar map depends only on its contents
+	half := write(t, empty.WithColumnar(true), tuples[:len(tuples)/2])
+	assert.Equal(t, columnar.HashOf(), write(t, half, tuples[len(tuples)/2:]).HashOf())
+
+	// edits keep the layout, and a map edited down to a single
+	// node stores its values row-wise like any other map
+	updates := ascendingTuplesWithStepAndStart(100, 97, 0)
+	assert.Equal(t, values(t, write(t, dflt, updates)), values(t, write(t, columnar, updates)))
+	rest := remove(t, columnar, tuples[10:])
+	assert.True(t, rest.Node().IsLeaf())
+	assert.Equal(t, remove(t, dflt, tuples[10:]).HashOf(), rest.HashOf())
+
+	// rechunking a map gives the layout it would have had if it was written with it
+	rechunked, err := RechunkMap(ctx, dflt.WithColumnar(true), 0)
+	require.NoError(t, err)
+	assert.True(t, rechunked.Columnar())
+	assert.Equal(t, columnar.HashOf(), rechunked.HashOf())
+	rechunked, err = RechunkMap(ctx, columnar.WithColumnar(false), 0)
+	require.NoError(t, err)
+	assert.Equal(t, dflt.HashOf(), rechunked.HashOf())
+}
+
 // utilities
 
 func ascendingIntMap(t *testing.T, count int) Map {
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -359,12 +360,35 @@ func (t *ImmutableTree) load(ctx context.Context) error {
 		return err
 	}
 
-	return WalkNodes(ctx, n, t.ns, func(ctx context.Context, n Node) error {
-		if n.IsLeaf() {
-			t.buf = append(t.buf, n.GetValue(0)...)
+	// Each level of the tree is read with a single ReadMany, so that reading
+	// a large blob from a remote takes one request per level rather than one
+	// request per leaf.
+	level := []Node{n}
+	for len(level) > 0 && !level[0].IsLeaf() {
+		var addrs hash.HashSlice
+		for _, nd := range level {
+			err = walkAddresses(ctx, nd, func(ctx context.Context, addr hash.Hash) error {
+				addrs = append(addrs, addr)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
 		}
-		return nil
-	})
+		level, err = t.ns.ReadMany(ctx, addrs)
+		if err != nil {
+			return err
+		}
+	}
+
+	t.buf = []byte{}
+	for _, nd := range level {
+		if nd.bytes() == nil {
+			return fmt.Errorf("missing chunk in blob %s", t.Addr.String())
+		}
+		t.buf = append(t.buf, nd.GetValue(0)...)
+	}
+	return nil
 }
 
 func (t *ImmutableTree) bytes(ctx context.Context) ([]byte, error) {
@@ -61,7 +61,7 @@ func newChunker[S message.Serializer](ctx context.Context, cur *cursor, level in
 	// |cur| will be nil if this is a new Node, implying this is a new tree, or the tree has grown in height relative
 	// to its original chunked form.
 
-	splitter := defaultSplitterFactory(uint8(level % 256))
+	splitter := splitterFactoryFor(serializer)(uint8(level % 256))
 	builder := newNodeBuilder(serializer, level)
 
 	sc := &chunker[S]{
@@ -102,7 +102,8 @@ func (tc *chunker[S]) processPrefix(ctx context.Context) (err error) {
 		_, err = tc.append(ctx,
 			tc.cur.CurrentKey(),
 			tc.cur.currentValue(),
-			sz)
+			sz,
+			tc.currentZone())
 
 		// todo(andy): seek to correct chunk
 		//  currently when inserting tuples between chunks
@@ -126,7 +127,7 @@ func (tc *chunker[S]) processPrefix(ctx context.Context) (err error) {
 
 // AddPair adds a val.Tuple pair to the chunker.
 func (tc *chunker[S]) AddPair(ctx context.Context, key, value Item) error {
-	_, err := tc.append(ctx, Item(key), Item(value), 1)
+	_, err := tc.append(ctx, Item(key), Item(value), 1, nil)
 	return err
 }
 
@@ -135,7 +136,7 @@ func (tc *chunker[S]) UpdatePair(ctx context.Context, key, value Item) error {
 	if err := tc.skip(ctx); err != nil {
 		return err
 	}
-	_, err := tc.append(ctx, Item(key), Item(value), 1)
+	_, err := tc.append(ctx, Item(key), Item(value), 1, nil)
 	return err
 }
 
@@ -189,7 +190,7 @@ func (tc *chunker[S]) advanceTo(ctx context.Context, next *cursor) error {
 	if err != nil {
 		return err
 	}
-	split, err := tc.append(ctx, tc.cur.CurrentKey(), tc.cur.currentValue(), sz)
+	split, err := tc.append(ctx, tc.cur.CurrentKey(), tc.cur.currentValue(), sz, tc.currentZone())
 	if err != nil {
 		return err
 	}
@@ -207,7 +208,7 @@ func (tc *chunker[S]) advanceTo(ctx context.Context, next *cursor) error {
 		if err != nil {
 			return err
 		}
-		split, err = tc.append(ctx, tc.cur.CurrentKey(), tc.cur.currentValue(), sz)
+		split, err = tc.append(ctx, tc.cur.CurrentKey(), tc.cur.currentValue(), sz, tc.currentZone())
 		if err != nil {
 			return err
 		}
@@ -255,6 +256,19 @@ func (tc *chunker[S]) advanceTo(ctx context.Context, next *cursor) error {
 	return nil
 }
 
+// currentZone returns the zone map of the subtree at the chunker's cursor, if
+// the chunker keeps zone maps and the cursor's node has one for the subtree.
+func (tc *chunker[S]) currentZone() Item {
+	if tc.isLeaf() {
+		return nil
+	}
+	zs, ok := any(tc.serializer).(message.ZoneSerializer)
+	if !ok || !zs.KeepsZoneMaps() {
+		return nil
+	}
+	return zs.SubtreeZone(tc.cur.nd.msg, tc.cur.idx)
+}
+
 func (tc *chunker[S]) skip(ctx context.Context) error {
 	err := tc.cur.advance(ctx)
 	return err
@@ -264,7 +278,7 @@ func (tc *chunker[S]) skip(ctx context.Context) error {
 // that chunks are well-formed. Key-value pairs are appended atomically a chunk boundary
 // may be made before or after the pair, but not between them. Returns true if chunk boundary
 // was split.
-func (tc *chunker[S]) append(ctx context.Context, key, value Item, subtree uint64) (bool, error) {
+func (tc *chunker[S]) append(ctx context.Context, key, value Item, subtree uint64, zone Item) (bool, error) {
 	// When adding new key-value pairs to an in-progress chunk, we must enforce 3 invariants
 	// (1) Key-value pairs are stored in the same Node.
 	// (2) The total Size of a Node's data cannot exceed |MaxVectorOffset|.
@@ -296,7 +310,7 @@ func (tc *chunker[S]) append(ctx context.Context, key, value Item, subtree uint6
 		}
 	}
 
-	tc.builder.addItems(key, value, subtree)
+	tc.builder.addItems(key, value, subtree, zone)
 
 	err := tc.splitter.Append(key, value)
 	if err != nil {
@@ -324,7 +338,7 @@ func (tc *chunker[S]) appendToParent(ctx context.Context, novel novelNode) (bool
 		}
 	}
 
-	return tc.parent.append(ctx, novel.lastKey, novel.addr[:], novel.treeCount)
+	return tc.parent.append(ctx, novel.lastKey, novel.addr[:], novel.treeCount, novel.zone)
 }
 
 func (tc *chunker[S]) handleChunkBoundary(ctx context.Context) error {
@@ -406,6 +420,9 @@ func (tc *chunker[S]) Done(ctx context.Context) (Node, error) {
 	// (2) This in an internal Node of the tree which contains multiple references to child nodes. In either case,
 	//     this is the canonical root of the tree.
 	if tc.isLeaf() || tc.builder.count() > 1 {
+		// roots are read without a NodeStore, so
+		// a leaf root stores its values row-wise
+		tc.builder.columnar = false
 		novel, err := writeNewNode(ctx, tc.ns, tc.builder)
 		return novel.node, err
 	}
@@ -428,7 +445,8 @@ func (tc *chunker[S]) finalizeCursor(ctx context.Context) (err error) {
 		ok, err = tc.append(ctx,
 			tc.cur.CurrentKey(),
 			tc.cur.currentValue(),
-			sz)
+			sz,
+			tc.currentZone())
 		if err != nil {
 			return err
 		}
@@ -477,7 +495,7 @@ func getCanonicalRoot[S message.Serializer](ctx context.Context, ns NodeStore, b
 	cnt := builder.count()
 	assertTrue(cnt == 1, "in-progress chunk must be non-canonical to call getCanonicalRoot")
 
-	nd, err := builder.build()
+	nd, _, _, err := builder.build()
 	if err != nil {
 		return Node{}, err
 	}
@@ -489,10 +507,24 @@ func getCanonicalRoot[S message.Serializer](ctx context.Context, ns NodeStore, b
 			return Node{}, err
 		}
 
-		if child.IsLeaf() || child.count > 1 {
+		if child.IsLeaf() && child.columns != nil {
+			return rowLeafRoot(ctx, ns, builder.serializer, child)
+		} else if child.IsLeaf() || child.count > 1 {
 			return child, nil
 		}
 
 		mt = child.getAddress(0)
 	}
 }
+
+// rowLeafRoot returns the leaf root |nd|, whose values are stored
+// column-wise, with its values stored row-wise, see Done.
+func rowLeafRoot[S message.Serializer](ctx context.Context, ns NodeStore, serializer S, nd Node) (Node, error) {
+	bld := newNodeBuilder(serializer, 0)
+	bld.columnar = false
+	for i := 0; i < nd.Count(); i++ {
+		bld.addItems(nd.GetKey(i), nd.GetValue(i), 1, nil)
+	}
+	novel, err := writeNewNode(ctx, ns, bld)
+	return novel.node, err
+}
@@ -53,6 +53,12 @@ type Node struct {
 	// because it requires a malloc.
 	subtrees *subtreeCounts
 
+	// columns are the values of a leaf Node whose
+	// values are stored column-wise, assembled from
+	// its column segments when the Node is read.
+	// see message.ColumnSerializer
+	columns *[]Item
+
 	// msg is the underlying buffer for the Node
 	// encoded as a Flatbuffers message.
 	msg serial.Message
@@ -61,12 +67,21 @@ type Node struct {
 type AddressCb func(ctx context.Context, addr hash.Hash) error
 
 func WalkAddresses(ctx context.Context, nd Node, ns NodeStore, cb AddressCb) error {
+	// the column segments of leaf nodes are walked like child nodes
+	var segments hash.HashSet
+	if nd.IsLeaf() {
+		addrs, err := message.GetColumnSegments(nd.msg)
+		if err != nil {
+			return err
+		}
+		segments = hash.NewHashSet(addrs...)
+	}
 	return walkAddresses(ctx, nd, func(ctx context.Context, addr hash.Hash) error {
 		if err := cb(ctx, addr); err != nil {
 			return err
 		}
 
-		if nd.IsLeaf() {
+		if nd.IsLeaf() && !segments.Has(addr) {
 			return nil
 		}
 
@@ -160,6 +175,9 @@ func (nd Node) GetKey(i int) Item {
 
 // GetValue returns the |ith| value of this node.
 func (nd Node) GetValue(i int) Item {
+	if nd.columns != nil {
+		return (*nd.columns)[i]
+	}
 	return nd.values.GetItem(i, nd.msg)
 }
 
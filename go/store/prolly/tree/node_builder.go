@@ -28,15 +28,25 @@ type novelNode struct {
 	addr      hash.Hash
 	lastKey   Item
 	treeCount uint64
+	// zone is the zone map of the node's subtree,
+	// if its serializer keeps zone maps
+	zone Item
 }
 
 func writeNewNode[S message.Serializer](ctx context.Context, ns NodeStore, bld *nodeBuilder[S]) (novelNode, error) {
 
-	node, err := bld.build()
+	node, zone, segments, err := bld.build()
 	if err != nil {
 		return novelNode{}, err
 	}
 
+	// column segments are written before the leaf referencing them
+	for _, seg := range segments {
+		if _, err = ns.Write(ctx, seg); err != nil {
+			return novelNode{}, err
+		}
+	}
+
 	addr, err := ns.Write(ctx, node)
 	if err != nil {
 		return novelNode{}, err
@@ -59,6 +69,7 @@ func writeNewNode[S message.Serializer](ctx context.Context, ns NodeStore, bld *
 		node:      node,
 		lastKey:   lastKey,
 		treeCount: uint64(cnt),
+		zone:      zone,
 	}, nil
 }
 
@@ -67,6 +78,8 @@ func newNodeBuilder[S message.Serializer](serializer S, level int) (nb *nodeBuil
 		level:      level,
 		serializer: serializer,
 	}
+	nb.zoned = level > 0 && nb.keepsZoneMaps()
+	nb.columnar = level == 0 && nb.storesColumns()
 	return
 }
 
@@ -75,6 +88,15 @@ type nodeBuilder[S message.Serializer] struct {
 	size, level  int
 	subtrees     subtreeCounts
 	serializer   S
+	// zones are the zone maps of subtrees of internal
+	// nodes, if |serializer| keeps zone maps
+	zones [][]byte
+	// zoned is set for builders of internal nodes
+	// whose serializer keeps zone maps
+	zoned bool
+	// columnar is set for builders of leaf nodes whose
+	// serializer stores their values column-wise
+	columnar bool
 }
 
 func (nb *nodeBuilder[S]) hasCapacity(key, value Item) bool {
@@ -82,37 +104,97 @@ func (nb *nodeBuilder[S]) hasCapacity(key, value Item) bool {
 	return sum <= int(message.MaxVectorOffset)
 }
 
-func (nb *nodeBuilder[S]) addItems(key, value Item, subtree uint64) {
+func (nb *nodeBuilder[S]) addItems(key, value Item, subtree uint64, zone Item) {
 	if nb.keys == nil {
 		nb.keys = getItemSlices()
 		nb.values = getItemSlices()
 		nb.subtrees = getSubtreeSlice()
+		if nb.zoned {
+			nb.zones = getItemSlices()
+		}
 	}
 	nb.keys = append(nb.keys, key)
 	nb.values = append(nb.values, value)
 	nb.size += len(key) + len(value)
 	nb.subtrees = append(nb.subtrees, subtree)
+	if nb.zoned {
+		// pooled slices may be nil, so |zoned| is checked rather than |zones|
+		nb.zones = append(nb.zones, zone)
+	}
+}
+
+// keepsZoneMaps returns whether the builder's serializer keeps zone maps.
+func (nb *nodeBuilder[S]) keepsZoneMaps() bool {
+	zs, ok := any(nb.serializer).(message.ZoneSerializer)
+	return ok && zs.KeepsZoneMaps()
+}
+
+// storesColumns returns whether the builder's serializer stores
+// the values of leaf nodes column-wise.
+func (nb *nodeBuilder[S]) storesColumns() bool {
+	cs, ok := any(nb.serializer).(message.ColumnSerializer)
+	return ok && cs.Columnar()
 }
 
 func (nb *nodeBuilder[S]) count() int {
 	return len(nb.keys)
 }
 
-func (nb *nodeBuilder[S]) build() (node Node, err error) {
-	msg := nb.serializer.Serialize(nb.keys, nb.values, nb.subtrees, nb.level)
+// build serializes the pending items into a Node. If the builder's serializer
+// keeps zone maps, the zone map of the Node's subtree is also returned. If the
+// builder is |columnar|, the column segments of the leaf Node are returned, and
+// must be written before it.
+func (nb *nodeBuilder[S]) build() (node Node, zone Item, segments []Node, err error) {
+	if nb.columnar {
+		return nb.buildColumns()
+	}
+	var msg []byte
+	if zs, ok := any(nb.serializer).(message.ZoneSerializer); ok && zs.KeepsZoneMaps() {
+		zone = zs.NodeZone(nb.values, nb.zones, nb.level)
+		msg = zs.SerializeWithZones(nb.keys, nb.values, nb.subtrees, nb.zones, nb.level)
+	} else {
+		msg = nb.serializer.Serialize(nb.keys, nb.values, nb.subtrees, nb.level)
+	}
 	nb.recycleBuffers()
 	nb.size = 0
 	node, _, err = NodeFromBytes(msg)
 	return
 }
 
+func (nb *nodeBuilder[S]) buildColumns() (node Node, zone Item, segments []Node, err error) {
+	if zs, ok := any(nb.serializer).(message.ZoneSerializer); ok && zs.KeepsZoneMaps() {
+		zone = zs.NodeZone(nb.values, nb.zones, nb.level)
+	}
+	cs := any(nb.serializer).(message.ColumnSerializer)
+	msg, segs := cs.SerializeColumns(nb.keys, nb.values)
+	segments = make([]Node, len(segs))
+	for i := range segs {
+		if segments[i], _, err = NodeFromBytes(segs[i]); err != nil {
+			return
+		}
+	}
+	if node, _, err = NodeFromBytes(msg); err != nil {
+		return
+	}
+	if len(segments) > 0 {
+		node = copyValues(node, nb.values)
+	}
+	nb.recycleBuffers()
+	nb.size = 0
+	return
+}
+
 func (nb *nodeBuilder[S]) recycleBuffers() {
 	putItemSlices(nb.keys[:0])
 	putItemSlices(nb.values[:0])
 	putSubtreeSlice(nb.subtrees[:0])
+	if nb.zoned {
+		putItemSlices(nb.zones[:0])
+	}
 	nb.keys = nil
 	nb.values = nil
 	nb.subtrees = nil
+	nb.zones = nil
 }
 
 // todo(andy): replace with NodeStore.Pool()
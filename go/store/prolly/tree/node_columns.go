@@ -0,0 +1,148 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// fieldProjector is a NodeStore which can read leaf nodes with only some of
+// the fields of their values, see ProjectFields.
+type fieldProjector interface {
+	projectFields(fields []int) NodeStore
+}
+
+// ProjectFields returns a read-only NodeStore which reads the leaf nodes of |ns|
+// whose values are stored column-wise with only the value fields |fields|, the
+// other fields of their values being NULL. Only the column segments of |fields|
+// are read. Leaf nodes whose values are stored row-wise are read whole. If |ns|
+// cannot project fields, it is returned unchanged.
+func ProjectFields(ns NodeStore, fields []int) NodeStore {
+	if p, ok := ns.(fieldProjector); ok {
+		return p.projectFields(fields)
+	}
+	return ns
+}
+
+// readColumns assembles the values of the leaf |nodes| whose values are stored
+// column-wise from the column segments of their |fields|, or from all of their
+// column segments if |fields| is nil. The segments of all |nodes| are read from
+// |ns| at once.
+func readColumns(ctx context.Context, ns NodeStore, nodes []Node, fields []int) error {
+	segs := make([][]hash.Hash, len(nodes))
+	var reads hash.HashSlice
+	for i, nd := range nodes {
+		if !nd.IsLeaf() || nd.columns != nil {
+			continue
+		}
+		addrs, err := message.GetColumnSegments(nd.msg)
+		if err != nil {
+			return err
+		} else if addrs == nil {
+			continue
+		}
+		if fields != nil {
+			addrs = projectSegments(addrs, fields)
+		}
+		segs[i] = addrs
+		for _, addr := range addrs {
+			if !addr.IsEmpty() {
+				reads = append(reads, addr)
+			}
+		}
+	}
+	if len(reads) == 0 {
+		return nil
+	}
+
+	read, err := ns.ReadMany(ctx, reads)
+	if err != nil {
+		return err
+	}
+	found := make(map[hash.Hash]Node, len(read))
+	for i := range read {
+		found[reads[i]] = read[i]
+	}
+
+	for i := range nodes {
+		if segs[i] == nil {
+			continue
+		}
+		columns := make([]Node, len(segs[i]))
+		for j, addr := range segs[i] {
+			if !addr.IsEmpty() {
+				columns[j] = found[addr]
+			}
+		}
+		nodes[i] = assembleValues(ns, nodes[i], columns)
+	}
+	return nil
+}
+
+// projectSegments returns the addresses of the column segments of |fields|,
+// and empty addresses for the other fields.
+func projectSegments(addrs []hash.Hash, fields []int) []hash.Hash {
+	projected := make([]hash.Hash, len(addrs))
+	for _, f := range fields {
+		if f < len(addrs) {
+			projected[f] = addrs[f]
+		}
+	}
+	return projected
+}
+
+// assembleValues returns |nd| with its values assembled from the column
+// segments |columns|. Fields whose segment is a zero Node are NULL.
+func assembleValues(ns NodeStore, nd Node, columns []Node) Node {
+	values := make([]Item, nd.count)
+	fields := make([][]byte, len(columns))
+	for i := range values {
+		for j := range columns {
+			fields[j] = nil
+			if columns[j].msg == nil {
+				continue
+			}
+			// empty items are NULL fields
+			if f := columns[j].GetValue(i); len(f) > 0 {
+				fields[j] = f
+			}
+		}
+		values[i] = Item(val.NewTuple(ns.Pool(), fields...))
+	}
+	nd.columns = &values
+	return nd
+}
+
+// copyValues returns |nd| with the |values| it was serialized from, which
+// its column segments assemble to, since value tuples are canonical.
+func copyValues(nd Node, values [][]byte) Node {
+	sz := 0
+	for _, v := range values {
+		sz += len(v)
+	}
+	buf := make([]byte, 0, sz)
+	items := make([]Item, len(values))
+	for i, v := range values {
+		start := len(buf)
+		buf = append(buf, v...)
+		items[i] = buf[start:len(buf):len(buf)]
+	}
+	nd.columns = &items
+	return nd
+}
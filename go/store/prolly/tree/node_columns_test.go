@@ -0,0 +1,154 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var columnarValDesc = val.NewTupleDescriptor(
+	val.Type{Enc: val.Int64Enc, Nullable: true},
+	val.Type{Enc: val.StringEnc, Nullable: true},
+	val.Type{Enc: val.Int64Enc, Nullable: true},
+)
+
+func TestColumnarLeaves(t *testing.T) {
+	ctx := context.Background()
+	ts := &chunks.TestStorage{}
+	cs := ts.NewViewWithFormat(types.Format_DOLT.VersionString())
+	// each cold store reads the chunks written to |cs| without a warm cache
+	cold := func() nodeStore {
+		return nodeStore{store: cs, cache: newChunkCache(1 << 22), bp: sharedPool, bbp: &blobBuilderPool}
+	}
+	items := columnarItems(10_000)
+	serializer := message.NewProllyMapSerializer(columnarValDesc, sharedPool)
+
+	build := func(t *testing.T, serializer message.ProllyMapSerializer, items [][2]Item) Node {
+		chkr, err := newEmptyChunker(ctx, cold(), serializer)
+		require.NoError(t, err)
+		for _, item := range items {
+			require.NoError(t, chkr.AddPair(ctx, item[0], item[1]))
+		}
+		root, err := chkr.Done(ctx)
+		require.NoError(t, err)
+		return root
+	}
+	firstLeaf := func(t *testing.T, nd Node) Node {
+		for !nd.IsLeaf() {
+			var err error
+			nd, err = cold().Read(ctx, nd.getAddress(0))
+			require.NoError(t, err)
+		}
+		return nd
+	}
+	rows := build(t, serializer, items)
+	columns := build(t, serializer.WithColumnar(true), items)
+	require.False(t, columns.IsLeaf())
+
+	t.Run("leaves are read from their column segments", func(t *testing.T) {
+		validateTreeItems(t, cold(), columns, items)
+
+		segments, err := message.GetColumnSegments(firstLeaf(t, columns).msg)
+		require.NoError(t, err)
+		assert.Len(t, segments, 3)
+		segments, err = message.GetColumnSegments(firstLeaf(t, rows).msg)
+		require.NoError(t, err)
+		assert.Nil(t, segments)
+	})
+
+	t.Run("leaves are chunked the same way in both layouts", func(t *testing.T) {
+		assert.NotEqual(t, rows.HashOf(), columns.HashOf())
+		require.Equal(t, rows.Count(), columns.Count())
+		for i := 0; i < rows.Count(); i++ {
+			assert.Equal(t, rows.GetKey(i), columns.GetKey(i))
+		}
+	})
+
+	t.Run("projected fields", func(t *testing.T) {
+		ns := cold()
+		projected := ns.projectFields([]int{1})
+		i := 0
+		err := iterTree(ctx, projected, columns, func(actual Item) error {
+			if i%2 == 1 {
+				expected := val.Tuple(items[i/2][1])
+				tup := val.Tuple(actual)
+				assert.Nil(t, tup.GetField(0))
+				assert.Equal(t, expected.GetField(1), tup.GetField(1))
+				assert.Nil(t, tup.GetField(2))
+			}
+			i++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, len(items)*2, i)
+
+		// projected leaves aren't cached
+		validateTreeItems(t, ns, columns, items)
+
+		_, err = projected.Write(ctx, columns)
+		assert.Error(t, err)
+	})
+
+	t.Run("column segments are walked", func(t *testing.T) {
+		segments, err := message.GetColumnSegments(firstLeaf(t, columns).msg)
+		require.NoError(t, err)
+		require.NotEmpty(t, segments)
+		walked := hash.NewHashSet()
+		err = WalkAddresses(ctx, columns, cold(), func(ctx context.Context, addr hash.Hash) error {
+			walked.Insert(addr)
+			return nil
+		})
+		require.NoError(t, err)
+		for _, addr := range segments {
+			assert.True(t, walked.Has(addr))
+		}
+	})
+
+	t.Run("leaf roots store their values row-wise", func(t *testing.T) {
+		small := items[:10]
+		assert.Equal(t, build(t, serializer, small).HashOf(), build(t, serializer.WithColumnar(true), small).HashOf())
+	})
+}
+
+func columnarItems(count int) (items [][2]Item) {
+	kb := val.NewTupleBuilder(keyDesc)
+	vb := val.NewTupleBuilder(columnarValDesc)
+	items = make([][2]Item, count)
+	for i := range items {
+		kb.PutInt64(0, int64(i))
+		vb.PutInt64(0, int64(i))
+		if i%3 != 0 {
+			vb.PutString(1, fmt.Sprintf("value %d", i))
+		}
+		if i%2 != 0 {
+			// values without the last field are shorter
+			vb.PutInt64(2, int64(-i))
+		}
+		items[i][0] = Item(kb.Build(sharedPool))
+		items[i][1] = Item(vb.Build(sharedPool))
+	}
+	return
+}
@@ -324,7 +324,7 @@ func currentCursorItems(cur *cursor) (key, value Item) {
 	} else {
 		key = cur.nd.keys.GetItem(cur.idx, cur.nd.msg)
 	}
-	value = cur.nd.values.GetItem(cur.idx, cur.nd.msg)
+	value = cur.nd.GetValue(cur.idx)
 	return
 }
 
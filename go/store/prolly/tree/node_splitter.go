@@ -58,6 +58,21 @@ type splitterFactory func(level uint8) nodeSplitter
 
 var defaultSplitterFactory splitterFactory = newKeySplitter
 
+// targetNodeSizer is implemented by message.Serializers that chunk leaf
+// nodes to a target size other than the default. A zero size selects the
+// default splitter.
+type targetNodeSizer interface {
+	TargetNodeSize() uint32
+}
+
+// splitterFactoryFor returns the splitterFactory to use with |serializer|.
+func splitterFactoryFor(serializer any) splitterFactory {
+	if s, ok := serializer.(targetNodeSizer); ok && s.TargetNodeSize() != 0 {
+		return leafSizedKeySplitterFactory(s.TargetNodeSize())
+	}
+	return defaultSplitterFactory
+}
+
 // nodeSplitter decides where Item streams should be split into chunks.
 type nodeSplitter interface {
 	// Append provides more nodeItems to the splitter. Splitter's make chunk
@@ -175,30 +190,55 @@ type keySplitter struct {
 	crossedBoundary bool
 
 	salt uint64
+
+	// minSize, maxSize and target bound and shape the chunk size distribution
+	minSize, maxSize uint32
+	target           float64
 }
 
 func newKeySplitter(level uint8) nodeSplitter {
 	return &keySplitter{
-		salt: levelSalt[level],
+		salt:    levelSalt[level],
+		minSize: minChunkSize,
+		maxSize: maxChunkSize,
+		target:  targetSize,
 	}
 }
 
 var _ splitterFactory = newKeySplitter
 
+// leafSizedKeySplitterFactory returns a splitterFactory for keySplitters that
+// chunk leaf nodes to |target| bytes. Internal nodes hold keys and addresses
+// rather than rows, and are chunked to the default size.
+func leafSizedKeySplitterFactory(target uint32) splitterFactory {
+	target = clampTargetNodeSize(target)
+	return func(level uint8) nodeSplitter {
+		if level != 0 || target == uint32(targetSize) {
+			return newKeySplitter(level)
+		}
+		return &keySplitter{
+			salt:    levelSalt[level],
+			minSize: target / (uint32(targetSize) / minChunkSize),
+			maxSize: target * (maxChunkSize / uint32(targetSize)),
+			target:  float64(target),
+		}
+	}
+}
+
 func (ks *keySplitter) Append(key, value Item) error {
 	thisSize := uint32(len(key) + len(value))
 	ks.size += thisSize
 
-	if ks.size < minChunkSize {
+	if ks.size < ks.minSize {
 		return nil
 	}
-	if ks.size > maxChunkSize {
+	if ks.size > ks.maxSize {
 		ks.crossedBoundary = true
 		return nil
 	}
 
 	h := xxHash32(key, ks.salt)
-	ks.crossedBoundary = weibullCheck(ks.size, thisSize, h)
+	ks.crossedBoundary = weibullCheckWithScale(ks.size, thisSize, h, ks.target)
 	return nil
 }
 
@@ -241,11 +281,16 @@ const (
 // treated as a uniform random number between [0,1),
 // is less than this percentage.
 func weibullCheck(size, thisSize, hash uint32) bool {
+	return weibullCheckWithScale(size, thisSize, hash, L)
+}
+
+// weibullCheckWithScale is weibullCheck for a distribution with scale |l|.
+func weibullCheckWithScale(size, thisSize, hash uint32, l float64) bool {
 	startx := float64(size - thisSize)
-	start := -math.Expm1(-math.Pow(startx/L, K))
+	start := -math.Expm1(-math.Pow(startx/l, K))
 
 	endx := float64(size)
-	end := -math.Expm1(-math.Pow(endx/L, K))
+	end := -math.Expm1(-math.Pow(endx/l, K))
 
 	p := float64(hash) / maxUint32
 	d := 1 - start
@@ -256,6 +301,50 @@ func weibullCheck(size, thisSize, hash uint32) bool {
 	return p < target
 }
 
+const (
+	// MinTargetNodeSize and MaxTargetNodeSize bound the target size of
+	// leaf nodes. Chunks may grow to four times their target size, and
+	// item offsets within a node are encoded as uint16s.
+	MinTargetNodeSize uint32 = 1 << 10
+	MaxTargetNodeSize uint32 = 1 << 13
+
+	// referenceRowWidth is the row width, in bytes, that is chunked to
+	// the default target size by TargetNodeSizeForRowWidth.
+	referenceRowWidth = 128
+)
+
+// TargetNodeSizeForRowWidth returns a target leaf node size for rows that
+// are |rowWidth| bytes wide on average. Wide rows get smaller nodes, which
+// keeps diffs and point reads from touching many unrelated rows, while
+// narrow rows get larger nodes, which keeps trees shallow. The size scales
+// with the inverse square root of the row width, is rounded to a power of
+// two, and is bounded by MinTargetNodeSize and MaxTargetNodeSize.
+func TargetNodeSizeForRowWidth(rowWidth uint32) uint32 {
+	if rowWidth == 0 {
+		return uint32(targetSize)
+	}
+	sz := targetSize * math.Sqrt(referenceRowWidth/float64(rowWidth))
+	return clampTargetNodeSize(uint32(1) << uint32(math.Round(math.Log2(sz))))
+}
+
+// EffectiveTargetNodeSize returns the target leaf node size that maps
+// with a target size of |size| are chunked to. Zero is the default size.
+func EffectiveTargetNodeSize(size uint32) uint32 {
+	if size == 0 {
+		return uint32(targetSize)
+	}
+	return clampTargetNodeSize(size)
+}
+
+func clampTargetNodeSize(sz uint32) uint32 {
+	if sz < MinTargetNodeSize {
+		return MinTargetNodeSize
+	} else if sz > MaxTargetNodeSize {
+		return MaxTargetNodeSize
+	}
+	return sz
+}
+
 func xxHash32(b []byte, salt uint64) uint32 {
 	return uint32(xxh3.HashSeed(b, salt))
 }
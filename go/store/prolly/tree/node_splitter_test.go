@@ -16,6 +16,7 @@ package tree
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/bits"
@@ -108,7 +109,7 @@ func makeProllyTreeWithSizes(t *testing.T, fact splitterFactory, scale, keySz, v
 
 	for i := 0; i < scale; i++ {
 		k, v := pro.Next()
-		_, err = chunker.append(ctx, k, v, 1)
+		_, err = chunker.append(ctx, k, v, 1, nil)
 		require.NoError(t, err)
 	}
 
@@ -179,3 +180,73 @@ func roundLog2(sz uint32) (lg uint32) {
 	}
 	return
 }
+
+func TestTargetNodeSizeForRowWidth(t *testing.T) {
+	tests := []struct {
+		width    uint32
+		expected uint32
+	}{
+		{width: 0, expected: 4096},
+		{width: 8, expected: MaxTargetNodeSize},
+		{width: 32, expected: 8192},
+		{width: 128, expected: 4096},
+		{width: 512, expected: 2048},
+		{width: 2048, expected: 1024},
+		{width: 1 << 20, expected: MinTargetNodeSize},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("row width %d", tt.width), func(t *testing.T) {
+			assert.Equal(t, tt.expected, TargetNodeSizeForRowWidth(tt.width))
+		})
+	}
+}
+
+func TestTargetNodeSize(t *testing.T) {
+	ctx := context.Background()
+	ns := NewTestNodeStore()
+	items := make([][2]Item, 20_000)
+	for i := range items {
+		k, v := make(Item, 8), make(Item, 24)
+		binary.BigEndian.PutUint64(k, uint64(i))
+		testRand.Read(v)
+		items[i] = [2]Item{k, v}
+	}
+
+	build := func(t *testing.T, size uint32) Node {
+		serializer := message.NewProllyMapSerializer(val.TupleDesc{}, ns.Pool()).WithTargetNodeSize(size)
+		chunker, err := newEmptyChunker(ctx, ns, serializer)
+		require.NoError(t, err)
+		for _, item := range items {
+			_, err = chunker.append(ctx, item[0], item[1], 1, nil)
+			require.NoError(t, err)
+		}
+		root, err := chunker.Done(ctx)
+		require.NoError(t, err)
+		return root
+	}
+	meanLeafSize := func(t *testing.T, root Node) float64 {
+		var sum, cnt int
+		err := WalkNodes(ctx, root, ns, func(ctx context.Context, nd Node) error {
+			if nd.IsLeaf() {
+				sum += nd.Size()
+				cnt++
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return float64(sum) / float64(cnt)
+	}
+
+	dflt := build(t, 0)
+	t.Run("default target size matches the default splitter", func(t *testing.T) {
+		assert.Equal(t, dflt.HashOf(), build(t, uint32(targetSize)).HashOf())
+	})
+	t.Run("leaf size follows the target size", func(t *testing.T) {
+		small := meanLeafSize(t, build(t, MinTargetNodeSize))
+		large := meanLeafSize(t, build(t, MaxTargetNodeSize))
+		mid := meanLeafSize(t, dflt)
+		assert.Less(t, small, mid)
+		assert.Less(t, mid, large)
+		assert.Less(t, large, float64(MaxTargetNodeSize*(maxChunkSize/uint32(targetSize))))
+	})
+}
@@ -16,6 +16,7 @@ package tree
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/dolthub/dolt/go/store/prolly/message"
@@ -56,6 +57,10 @@ type nodeStore struct {
 	cache nodeCache
 	bp    pool.BuffPool
 	bbp   *sync.Pool
+	// fields are the value fields read from leaf
+	// nodes stored column-wise, or nil for all.
+	// see ProjectFields
+	fields []int
 }
 
 var _ NodeStore = nodeStore{}
@@ -97,7 +102,14 @@ func (ns nodeStore) Read(ctx context.Context, ref hash.Hash) (Node, error) {
 	if err != nil {
 		return Node{}, err
 	}
-	ns.cache.insert(ref, n)
+	nodes := []Node{n}
+	if err = readColumns(ctx, ns, nodes, ns.fields); err != nil {
+		return Node{}, err
+	}
+	n = nodes[0]
+	if ns.caches(n) {
+		ns.cache.insert(ref, n)
+	}
 
 	return n, nil
 }
@@ -134,19 +146,43 @@ func (ns nodeStore) ReadMany(ctx context.Context, addrs hash.HashSlice) ([]Node,
 		return nil, err
 	}
 
+	read := make([]Node, 0, len(gets))
+	readAddrs := make(hash.HashSlice, 0, len(gets))
+	for addr := range gets {
+		if n, ok := found[addr]; ok {
+			read = append(read, n)
+			readAddrs = append(readAddrs, addr)
+		}
+	}
+	if err = readColumns(ctx, ns, read, ns.fields); err != nil {
+		return nil, err
+	}
+	for i := range read {
+		found[readAddrs[i]] = read[i]
+	}
+
 	var ok bool
 	nodes := make([]Node, len(addrs))
 	for i, addr := range addrs {
 		nodes[i], ok = found[addr]
-		if ok {
+		if ok && ns.caches(nodes[i]) {
 			ns.cache.insert(addr, nodes[i])
 		}
 	}
 	return nodes, nil
 }
 
+// caches returns whether |nd| is cached when it's read. Leaf nodes read
+// with only some of the fields of their values aren't cached.
+func (ns nodeStore) caches(nd Node) bool {
+	return ns.fields == nil || nd.columns == nil
+}
+
 // Write implements NodeStore.
 func (ns nodeStore) Write(ctx context.Context, nd Node) (hash.Hash, error) {
+	if ns.fields != nil {
+		return hash.Hash{}, errors.New("cannot write to a NodeStore reading projected fields")
+	}
 	c := chunks.NewChunk(nd.bytes())
 	assertTrue(c.Size() > 0, "cannot write empty chunk to ChunkStore")
 
@@ -169,6 +205,12 @@ func (ns nodeStore) Write(ctx context.Context, nd Node) (hash.Hash, error) {
 	return c.Hash(), nil
 }
 
+// projectFields implements fieldProjector.
+func (ns nodeStore) projectFields(fields []int) NodeStore {
+	ns.fields = fields
+	return ns
+}
+
 // Pool implements NodeStore.
 func (ns nodeStore) Pool() pool.BuffPool {
 	return ns.bp
@@ -0,0 +1,151 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// defaultLeafSampleRate is the fraction, one in N, of leaf chunks a SizeEstimator reads.
+const defaultLeafSampleRate = 16
+
+// SizeEstimator estimates the bytes of storage used by the chunks of a set of prolly trees. The internal nodes of
+// each tree, which hold the address of every leaf, are read in full, but only a sample of the leaves are, along with
+// the out-of-band values they reference. The size of the leaves which aren't read is extrapolated from the sample.
+//
+// Chunks are counted once, however many trees reference them, and chunks of trees passed to |Exclude| aren't counted
+// at all, so that the estimator can measure the storage unique to some trees.
+type SizeEstimator struct {
+	ns         NodeStore
+	sampleRate uint32
+
+	excluded hash.HashSet
+	seen     hash.HashSet
+
+	internalBytes uint64
+	leaves        uint64
+	sampledLeaves uint64
+	sampledBytes  uint64
+}
+
+// NewSizeEstimator returns a SizeEstimator which reads chunks from |ns|.
+func NewSizeEstimator(ns NodeStore) *SizeEstimator {
+	return &SizeEstimator{
+		ns:         ns,
+		sampleRate: defaultLeafSampleRate,
+		excluded:   hash.NewHashSet(),
+		seen:       hash.NewHashSet(),
+	}
+}
+
+// NewExactSizeEstimator returns a SizeEstimator which reads chunks from |ns|, and reads every leaf rather than a
+// sample of them, for measuring small sets of chunks exactly.
+func NewExactSizeEstimator(ns NodeStore) *SizeEstimator {
+	e := NewSizeEstimator(ns)
+	e.sampleRate = 1
+	return e
+}
+
+// Exclude marks every chunk of the tree rooted at |nd| as not to be counted. Only the internal nodes of the tree
+// are read, so out-of-band values referenced only by its leaves are not excluded.
+func (e *SizeEstimator) Exclude(ctx context.Context, nd Node) error {
+	addr := nd.HashOf()
+	if e.excluded.Has(addr) {
+		return nil
+	}
+	e.excluded.Insert(addr)
+	if nd.IsLeaf() {
+		return nil
+	}
+	return walkAddresses(ctx, nd, func(ctx context.Context, addr hash.Hash) error {
+		if nd.Level() == 1 {
+			e.excluded.Insert(addr)
+			return nil
+		}
+		child, err := e.ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		return e.Exclude(ctx, child)
+	})
+}
+
+// Add counts the chunks of the tree rooted at |nd| which haven't already been counted or excluded.
+func (e *SizeEstimator) Add(ctx context.Context, nd Node) error {
+	addr := nd.HashOf()
+	if e.excluded.Has(addr) || e.seen.Has(addr) {
+		return nil
+	}
+	e.seen.Insert(addr)
+	if nd.IsLeaf() {
+		// the leaf has already been read, so count it exactly
+		e.leaves++
+		return e.sampleLeaf(ctx, nd)
+	}
+	e.internalBytes += uint64(nd.Size())
+	return walkAddresses(ctx, nd, func(ctx context.Context, addr hash.Hash) error {
+		if nd.Level() > 1 {
+			child, err := e.ns.Read(ctx, addr)
+			if err != nil {
+				return err
+			}
+			return e.Add(ctx, child)
+		}
+		if e.excluded.Has(addr) || e.seen.Has(addr) {
+			return nil
+		}
+		e.seen.Insert(addr)
+		e.leaves++
+		if e.sampledLeaves > 0 && binary.BigEndian.Uint32(addr[:4])%e.sampleRate != 0 {
+			return nil
+		}
+		leaf, err := e.ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		return e.sampleLeaf(ctx, leaf)
+	})
+}
+
+// sampleLeaf adds the bytes of |leaf|, and of the out-of-band values it references, to the sample.
+func (e *SizeEstimator) sampleLeaf(ctx context.Context, leaf Node) error {
+	e.sampledLeaves++
+	e.sampledBytes += uint64(leaf.Size())
+	return walkAddresses(ctx, leaf, func(ctx context.Context, addr hash.Hash) error {
+		if e.excluded.Has(addr) || e.seen.Has(addr) {
+			return nil
+		}
+		e.seen.Insert(addr)
+		value, err := e.ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		return WalkNodes(ctx, value, e.ns, func(ctx context.Context, nd Node) error {
+			e.sampledBytes += uint64(nd.Size())
+			return nil
+		})
+	})
+}
+
+// Bytes returns the estimated bytes of the chunks counted so far.
+func (e *SizeEstimator) Bytes() uint64 {
+	if e.sampledLeaves == 0 {
+		return e.internalBytes
+	}
+	return e.internalBytes + e.leaves*e.sampledBytes/e.sampledLeaves
+}
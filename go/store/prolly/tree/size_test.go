@@ -0,0 +1,65 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeEstimator(t *testing.T) {
+	ctx := context.Background()
+
+	root, _, ns := randomTree(t, 20_000)
+	require.Greater(t, root.Level(), 0)
+	var exact uint64
+	require.NoError(t, WalkNodes(ctx, root, ns, func(ctx context.Context, nd Node) error {
+		exact += uint64(nd.Size())
+		return nil
+	}))
+
+	e := NewSizeEstimator(ns)
+	require.NoError(t, e.Add(ctx, root))
+	assert.InEpsilon(t, exact, e.Bytes(), 0.25)
+
+	e = NewExactSizeEstimator(ns)
+	require.NoError(t, e.Add(ctx, root))
+	assert.Equal(t, exact, e.Bytes())
+
+	// counting the same tree again adds nothing
+	require.NoError(t, e.Add(ctx, root))
+	assert.InEpsilon(t, exact, e.Bytes(), 0.25)
+
+	// a single leaf is counted exactly
+	leaf := root
+	for !leaf.IsLeaf() {
+		var err error
+		leaf, err = ns.Read(ctx, leaf.getAddress(0))
+		require.NoError(t, err)
+	}
+	e = NewSizeEstimator(ns)
+	require.NoError(t, e.Add(ctx, leaf))
+	assert.Equal(t, uint64(leaf.Size()), e.Bytes())
+
+	// an excluded tree isn't counted
+	e = NewSizeEstimator(ns)
+	require.NoError(t, e.Exclude(ctx, root))
+	require.NoError(t, e.Add(ctx, root))
+	require.NoError(t, e.Add(ctx, leaf))
+	assert.Equal(t, uint64(0), e.Bytes())
+}
@@ -333,6 +333,11 @@ func (v nodeStoreValidator) PutBlobBuilder(bb *BlobBuilder) {
 	v.bbp.Put(bb)
 }
 
+func (v nodeStoreValidator) projectFields(fields []int) NodeStore {
+	v.ns = ProjectFields(v.ns, fields)
+	return v
+}
+
 func (v nodeStoreValidator) Format() *types.NomsBinFormat {
 	return v.ns.Format()
 }
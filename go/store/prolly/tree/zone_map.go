@@ -0,0 +1,136 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"io"
+
+	"github.com/dolthub/dolt/go/store/prolly/message"
+)
+
+// ZoneSkipFn decides whether a subtree can be skipped from its zone
+// map |zone|, which holds the min and max of the value |fields|.
+type ZoneSkipFn func(fields []int, zone Item) bool
+
+// GetZoneMapFields returns the value fields that the internal node |nd|
+// keeps zone maps for, or nil if it has none.
+func GetZoneMapFields(nd Node) ([]int, error) {
+	if nd.IsLeaf() {
+		return nil, nil
+	}
+	fields, _, err := message.GetSubtreeZones(nd.msg)
+	return fields, err
+}
+
+// ZonePrunedIter iterates the leaf items of an ordinal range of a tree in
+// order, skipping the subtrees that a ZoneSkipFn rejects by their zone
+// maps. Subtrees without zone maps are never skipped.
+type ZonePrunedIter struct {
+	ns    NodeStore
+	skip  ZoneSkipFn
+	stack []zoneFrame
+	// ord is the ordinal of the next leaf item, and start
+	// and stop are the bounds of the ordinal range
+	ord, start, stop uint64
+	// skipped counts the subtrees skipped so far
+	skipped int
+}
+
+type zoneFrame struct {
+	nd     Node
+	idx    int
+	fields []int
+	zones  [][]byte
+}
+
+// NewZonePrunedIter returns a ZonePrunedIter over the leaf items of the
+// tree rooted at |root| whose ordinals are in [|start|, |stop|).
+func NewZonePrunedIter(ns NodeStore, root Node, skip ZoneSkipFn, start, stop uint64) (*ZonePrunedIter, error) {
+	it := &ZonePrunedIter{ns: ns, skip: skip, start: start, stop: stop}
+	if root.empty() || start >= stop {
+		return it, nil
+	}
+	if err := it.push(root); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+func (it *ZonePrunedIter) push(nd Node) error {
+	frame := zoneFrame{nd: nd}
+	if !nd.IsLeaf() {
+		var err error
+		if frame.nd, err = nd.loadSubtrees(); err != nil {
+			return err
+		}
+		frame.fields, frame.zones, err = message.GetSubtreeZones(nd.msg)
+		if err != nil {
+			return err
+		}
+	}
+	it.stack = append(it.stack, frame)
+	return nil
+}
+
+// Next returns the next leaf item that was not skipped, or io.EOF.
+func (it *ZonePrunedIter) Next(ctx context.Context) (key, value Item, err error) {
+	for len(it.stack) > 0 && it.ord < it.stop {
+		top := &it.stack[len(it.stack)-1]
+		if top.idx >= top.nd.Count() {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		i := top.idx
+		top.idx++
+
+		if top.nd.IsLeaf() {
+			ord := it.ord
+			it.ord++
+			if ord < it.start {
+				continue
+			}
+			return top.nd.GetKey(i), top.nd.GetValue(i), nil
+		}
+
+		cnt, err := top.nd.getSubtreeCount(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		if it.ord+cnt <= it.start {
+			// the subtree ends before the range
+			it.ord += cnt
+			continue
+		}
+		if i < len(top.zones) && len(top.zones[i]) > 0 && it.skip(top.fields, top.zones[i]) {
+			it.ord += cnt
+			it.skipped++
+			continue
+		}
+		child, err := fetchChild(ctx, it.ns, top.nd.getAddress(i))
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = it.push(child); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, io.EOF
+}
+
+// Skipped returns the number of subtrees skipped so far.
+func (it *ZonePrunedIter) Skipped() int {
+	return it.skipped
+}
@@ -33,6 +33,15 @@ type Map struct {
 	tuples  tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]
 	keyDesc val.TupleDesc
 	valDesc val.TupleDesc
+	// nodeSize is the target leaf node size used when the map is
+	// edited, or zero for the default
+	nodeSize uint32
+	// zoneFields are the value fields that internal nodes keep
+	// zone maps for when the map is edited
+	zoneFields []int
+	// columnar is set if leaf nodes store their values
+	// column-wise when the map is edited
+	columnar bool
 }
 
 // NewMap creates an empty prolly Tree Map
@@ -93,7 +102,7 @@ func NewMapFromTupleIter(ctx context.Context, ns tree.NodeStore, keyDesc, valDes
 
 func MutateMapWithTupleIter(ctx context.Context, m Map, iter TupleIter) (Map, error) {
 	fn := tree.ApplyMutations[val.Tuple, val.TupleDesc, message.ProllyMapSerializer]
-	s := message.NewProllyMapSerializer(m.valDesc, m.tuples.NodeStore.Pool())
+	s := m.serializer()
 
 	root, err := fn(ctx, m.tuples.NodeStore, m.tuples.Root, m.keyDesc, s, mutationIter{iter: iter})
 	if err != nil {
@@ -106,8 +115,11 @@ func MutateMapWithTupleIter(ctx context.Context, m Map, iter TupleIter) (Map, er
 			NodeStore: m.tuples.NodeStore,
 			Order:     m.tuples.Order,
 		},
-		keyDesc: m.keyDesc,
-		valDesc: m.valDesc,
+		keyDesc:    m.keyDesc,
+		valDesc:    m.valDesc,
+		nodeSize:   m.nodeSize,
+		zoneFields: m.zoneFields,
+		columnar:   m.columnar,
 	}, nil
 }
 
@@ -167,7 +179,7 @@ func makeDiffCallBack(from, to Map, innerCb tree.DiffFn) tree.DiffFn {
 }
 
 func MergeMaps(ctx context.Context, left, right, base Map, cb tree.CollisionFn) (Map, tree.MergeStats, error) {
-	serializer := message.NewProllyMapSerializer(left.valDesc, base.NodeStore().Pool())
+	serializer := left.serializer()
 	// TODO: MergeMaps does not properly detect merge conflicts when one side adds a NULL to the end of its tuple.
 	// To fix this, accurate values of `leftSchemaChanged` and `rightSchemaChanged` must be computed.
 	// However, since `MergeMaps` is not currently called, fixing this is not a priority.
@@ -179,9 +191,12 @@ func MergeMaps(ctx context.Context, left, right, base Map, cb tree.CollisionFn)
 	}
 
 	return Map{
-		tuples:  tuples,
-		keyDesc: base.keyDesc,
-		valDesc: base.valDesc,
+		tuples:     tuples,
+		keyDesc:    base.keyDesc,
+		valDesc:    base.valDesc,
+		nodeSize:   left.nodeSize,
+		zoneFields: left.zoneFields,
+		columnar:   left.columnar,
 	}, stats, nil
 }
 
@@ -191,6 +206,142 @@ func VisitMapLevelOrder(ctx context.Context, m Map, cb func(h hash.Hash) (int64,
 	return tree.VisitMapLevelOrder(ctx, m.tuples, cb)
 }
 
+// WithTargetNodeSize returns a copy of the map that chunks its leaf nodes
+// to |size| bytes when edited. Zero selects the default size. Existing
+// nodes are only rechunked where edits touch them, so the size should
+// stay fixed for the lifetime of a map for its layout to be canonical.
+// See tree.TargetNodeSizeForRowWidth for choosing a size.
+func (m Map) WithTargetNodeSize(size uint32) Map {
+	m.nodeSize = size
+	return m
+}
+
+// TargetNodeSize returns the target leaf node size used when the map is
+// edited, or zero for the default.
+func (m Map) TargetNodeSize() uint32 {
+	return m.nodeSize
+}
+
+// RechunkMap returns a copy of |m| whose leaf nodes are all chunked to
+// |size| bytes, see WithTargetNodeSize, whose internal nodes all keep
+// zone maps for the zone map fields of |m|, see WithZoneMapFields, and
+// whose leaf nodes all store their values column-wise if |m| does, see
+// WithColumnar. It rewrites the whole map, so it is only needed when the
+// target size, the zone map fields or the layout of an existing map change.
+func RechunkMap(ctx context.Context, m Map, size uint32) (Map, error) {
+	serializer := m.WithTargetNodeSize(size).serializer()
+	ch, err := tree.NewEmptyChunker(ctx, m.NodeStore(), serializer)
+	if err != nil {
+		return Map{}, err
+	}
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return Map{}, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return Map{}, err
+		}
+		if err = ch.AddPair(ctx, tree.Item(k), tree.Item(v)); err != nil {
+			return Map{}, err
+		}
+	}
+
+	root, err := ch.Done(ctx)
+	if err != nil {
+		return Map{}, err
+	}
+	rechunked := NewMap(root, m.NodeStore(), m.keyDesc, m.valDesc).WithTargetNodeSize(size)
+	rechunked.zoneFields = m.zoneFields
+	rechunked.columnar = m.columnar
+	return rechunked, nil
+}
+
+// WithZoneMapFields returns a copy of the map whose internal nodes keep
+// zone maps for the value |fields| when the map is edited. A zone map is
+// the min and max of the fields within a subtree, which IterValueRanges
+// uses to skip subtrees. Nodes written without zone maps, such as the
+// nodes written before zone maps were kept, are never skipped, so the
+// fields should stay fixed for the lifetime of a map for its layout to
+// be canonical. Zone maps can be kept for up to message.MaxZoneMapFields
+// fields with fixed-width encodings.
+func (m Map) WithZoneMapFields(fields ...int) (Map, error) {
+	if err := message.ValidateZoneMapFields(m.valDesc, fields); err != nil {
+		return Map{}, err
+	}
+	m.zoneFields = fields
+	return m, nil
+}
+
+// ZoneMapFields returns the value fields that internal nodes keep zone
+// maps for when the map is edited.
+func (m Map) ZoneMapFields() []int {
+	return m.zoneFields
+}
+
+// KeepsZoneMaps returns whether the stored nodes of |m| keep zone maps
+// for exactly the zone map fields of |m|. A map whose root was written
+// without them must be rechunked with RechunkMap to get them.
+func (m Map) KeepsZoneMaps() (bool, error) {
+	root := m.tuples.Root
+	if root.IsLeaf() {
+		// a single node has no subtrees to keep zone maps for
+		return true, nil
+	}
+	fields, err := tree.GetZoneMapFields(root)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) != len(m.zoneFields) {
+		return false, nil
+	}
+	for i := range fields {
+		if fields[i] != m.zoneFields[i] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// WithColumnar returns a copy of the map whose leaf nodes store their
+// values column-wise when the map is edited if |columnar| is set: each
+// value field of a leaf is stored in a column segment of its own, so
+// reading some of the fields of a map, see ProjectFields, only reads the
+// segments of those fields. Leaf nodes are chunked the same way in both
+// layouts, and a map whose root is a leaf stores its values row-wise.
+// Existing nodes are only rewritten where edits touch them, so the layout
+// should stay fixed for the lifetime of a map for it to be canonical.
+func (m Map) WithColumnar(columnar bool) Map {
+	m.columnar = columnar
+	return m
+}
+
+// Columnar returns whether leaf nodes store their values column-wise
+// when the map is edited.
+func (m Map) Columnar() bool {
+	return m.columnar
+}
+
+// ProjectFields returns a read-only copy of the map which only reads the
+// value |fields| of leaf nodes storing their values column-wise, the other
+// fields of their values being NULL. Leaf nodes storing their values
+// row-wise are read whole.
+func (m Map) ProjectFields(fields []int) Map {
+	m.tuples.NodeStore = tree.ProjectFields(m.tuples.NodeStore, fields)
+	return m
+}
+
+func (m Map) serializer() message.ProllyMapSerializer {
+	return message.NewProllyMapSerializer(m.valDesc, m.tuples.NodeStore.Pool()).
+		WithTargetNodeSize(m.nodeSize).
+		WithZoneMapFields(m.zoneFields).
+		WithColumnar(m.columnar)
+}
+
 // NodeStore returns the map's NodeStore
 func (m Map) NodeStore() tree.NodeStore {
 	return m.tuples.NodeStore
@@ -72,19 +72,20 @@ func newMutableMap(m Map) *MutableMap {
 		keyDesc:    m.keyDesc,
 		valDesc:    m.valDesc,
 		maxPending: defaultMaxPending,
-		flusher:    ProllyFlusher{},
+		flusher:    ProllyFlusher{nodeSize: m.nodeSize, zoneFields: m.zoneFields, columnar: m.columnar},
 	}
 }
 
 // newMutableMapWithDescriptors returns a new MutableMap with the key and value TupleDescriptors overridden to the
 // values specified in |kd| and |vd|. This is useful if you are rewriting the data in a map to change its schema.
+// Zone maps are not kept for the rewritten map, since their fields refer to the original value TupleDescriptor.
 func newMutableMapWithDescriptors(m Map, kd, vd val.TupleDesc) *MutableMap {
 	return &MutableMap{
 		tuples:     m.tuples.Mutate(),
 		keyDesc:    kd,
 		valDesc:    vd,
 		maxPending: defaultMaxPending,
-		flusher:    ProllyFlusher{},
+		flusher:    ProllyFlusher{nodeSize: m.nodeSize, columnar: m.columnar},
 	}
 }
 
@@ -305,10 +306,24 @@ func debugFormat(ctx context.Context, m *MutableMap) (string, error) {
 	return sb.String(), nil
 }
 
-type ProllyFlusher struct{}
+type ProllyFlusher struct {
+	// nodeSize is the target leaf node size of the flushed map
+	nodeSize uint32
+	// zoneFields are the value fields of the flushed map with zone maps
+	zoneFields []int
+	// columnar is set if the flushed map stores its values column-wise
+	columnar bool
+}
 
 func (f ProllyFlusher) GetDefaultSerializer(ctx context.Context, mut *GenericMutableMap[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]]) message.Serializer {
-	return message.NewProllyMapSerializer(mut.valDesc, mut.NodeStore().Pool())
+	return f.serializer(mut)
+}
+
+func (f ProllyFlusher) serializer(mut *GenericMutableMap[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]]) message.ProllyMapSerializer {
+	return message.NewProllyMapSerializer(mut.valDesc, mut.NodeStore().Pool()).
+		WithTargetNodeSize(f.nodeSize).
+		WithZoneMapFields(f.zoneFields).
+		WithColumnar(f.columnar)
 }
 
 func (f ProllyFlusher) Map(ctx context.Context, mut *GenericMutableMap[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]]) (Map, error) {
@@ -318,17 +333,19 @@ func (f ProllyFlusher) Map(ctx context.Context, mut *GenericMutableMap[Map, tree
 		return Map{}, err
 	}
 	return Map{
-		tuples:  treeMap,
-		keyDesc: mut.keyDesc,
-		valDesc: mut.valDesc,
+		tuples:     treeMap,
+		keyDesc:    mut.keyDesc,
+		valDesc:    mut.valDesc,
+		nodeSize:   f.nodeSize,
+		zoneFields: f.zoneFields,
+		columnar:   f.columnar,
 	}, nil
 }
 
 var _ MutableMapFlusher[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]] = ProllyFlusher{}
 
 func (f ProllyFlusher) ApplyMutations(ctx context.Context, m *GenericMutableMap[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]]) (tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc], error) {
-	serializer := message.NewProllyMapSerializer(m.valDesc, m.NodeStore().Pool())
-	return f.ApplyMutationsWithSerializer(ctx, serializer, m)
+	return f.ApplyMutationsWithSerializer(ctx, f.serializer(m), m)
 }
 
 func (f ProllyFlusher) ApplyMutationsWithSerializer(
@@ -0,0 +1,141 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prolly
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ValueRange is a range of values of a value field of a Map. NULL
+// values are never within a ValueRange.
+type ValueRange struct {
+	// Field is the value field of the range.
+	Field int
+	// Lower and Upper are the encoded bounds of the range,
+	// or nil when the range is unbounded on that side.
+	Lower, Upper []byte
+	// LowerInclusive and UpperInclusive are whether the
+	// bounds are within the range.
+	LowerInclusive, UpperInclusive bool
+}
+
+// IterValueRanges returns an iterator over the rows of the map with
+// ordinals in [|start|, |stop|), in key order, whose value fields are
+// within every range of |ranges|. Subtrees whose zone maps show they hold
+// no values within one of the ranges are skipped without being read, see
+// Map.WithZoneMapFields. Rows of other subtrees are filtered one by one,
+// so the results are the same with or without zone maps.
+func (m Map) IterValueRanges(ctx context.Context, start, stop uint64, ranges ...ValueRange) (*ValueRangeIter, error) {
+	for _, rng := range ranges {
+		if rng.Field < 0 || rng.Field >= m.valDesc.Count() {
+			return nil, fmt.Errorf("value range field %d is out of range", rng.Field)
+		}
+	}
+	iter := &ValueRangeIter{ranges: ranges, desc: m.valDesc}
+	var err error
+	iter.tree, err = tree.NewZonePrunedIter(m.tuples.NodeStore, m.tuples.Root, iter.skipZone, start, stop)
+	if err != nil {
+		return nil, err
+	}
+	return iter, nil
+}
+
+// ValueRangeIter iterates the rows of a Map within a set of ValueRanges.
+type ValueRangeIter struct {
+	tree   *tree.ZonePrunedIter
+	ranges []ValueRange
+	desc   val.TupleDesc
+}
+
+var _ MapIter = &ValueRangeIter{}
+
+// Next returns the next row within the ranges, or io.EOF.
+func (it *ValueRangeIter) Next(ctx context.Context) (val.Tuple, val.Tuple, error) {
+	for {
+		k, v, err := it.tree.Next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if it.contains(val.Tuple(v)) {
+			return val.Tuple(k), val.Tuple(v), nil
+		}
+	}
+}
+
+// SkippedSubtrees returns the number of subtrees skipped so far by their zone maps.
+func (it *ValueRangeIter) SkippedSubtrees() int {
+	return it.tree.Skipped()
+}
+
+// contains returns whether the value tuple |v| is within every range.
+func (it *ValueRangeIter) contains(v val.Tuple) bool {
+	for _, rng := range it.ranges {
+		f := v.GetField(rng.Field)
+		if f == nil {
+			return false
+		}
+		if rng.Lower != nil {
+			cmp := it.compare(rng.Field, f, rng.Lower)
+			if cmp < 0 || (cmp == 0 && !rng.LowerInclusive) {
+				return false
+			}
+		}
+		if rng.Upper != nil {
+			cmp := it.compare(rng.Field, f, rng.Upper)
+			if cmp > 0 || (cmp == 0 && !rng.UpperInclusive) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// skipZone returns whether a subtree with the zone map |zone| holds no
+// values within one of the ranges.
+func (it *ValueRangeIter) skipZone(fields []int, zone tree.Item) bool {
+	for _, rng := range it.ranges {
+		for j, f := range fields {
+			if f != rng.Field {
+				continue
+			}
+			min, max := val.Tuple(zone).GetField(2*j), val.Tuple(zone).GetField(2*j+1)
+			if min == nil {
+				// every value of the subtree is NULL
+				return true
+			}
+			if rng.Upper != nil {
+				cmp := it.compare(f, min, rng.Upper)
+				if cmp > 0 || (cmp == 0 && !rng.UpperInclusive) {
+					return true
+				}
+			}
+			if rng.Lower != nil {
+				cmp := it.compare(f, max, rng.Lower)
+				if cmp < 0 || (cmp == 0 && !rng.LowerInclusive) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (it *ValueRangeIter) compare(field int, left, right []byte) int {
+	return it.desc.Comparator().CompareValues(field, left, right, it.desc.Types[field])
+}
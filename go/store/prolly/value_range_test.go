@@ -0,0 +1,156 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prolly
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+func TestMapZoneMaps(t *testing.T) {
+	ctx := context.Background()
+	tuples := ascendingTuplesWithStepAndStart(10_000, 1, 0)
+	empty := mustProllyMapFromTuples(t, mutKeyDesc, mutValDesc, nil)
+
+	write := func(t *testing.T, m Map, tuples [][2]val.Tuple) Map {
+		mut := m.Mutate()
+		for _, tup := range tuples {
+			require.NoError(t, mut.Put(ctx, tup[0], tup[1]))
+		}
+		m, err := mut.Map(ctx)
+		require.NoError(t, err)
+		return m
+	}
+	withZones := func(t *testing.T, m Map) Map {
+		m, err := m.WithZoneMapFields(0)
+		require.NoError(t, err)
+		return m
+	}
+	valueRange := func(t *testing.T, m Map, lo, hi int64) (keys []int64, skipped int) {
+		_, lower := makePut(0, lo)
+		_, upper := makePut(0, hi)
+		cnt, err := m.Count()
+		require.NoError(t, err)
+		iter, err := m.IterValueRanges(ctx, 0, uint64(cnt), ValueRange{
+			Field:          0,
+			Lower:          mutValDesc.GetField(0, lower),
+			Upper:          mutValDesc.GetField(0, upper),
+			LowerInclusive: true,
+			UpperInclusive: true,
+		})
+		require.NoError(t, err)
+		for {
+			k, _, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			key, _ := mutKeyDesc.GetInt64(0, k)
+			keys = append(keys, key)
+		}
+		return keys, iter.SkippedSubtrees()
+	}
+
+	dflt := write(t, empty, tuples)
+	zoned := write(t, withZones(t, empty), tuples)
+	assert.Equal(t, []int{0}, zoned.ZoneMapFields())
+	assert.NotEqual(t, dflt.HashOf(), zoned.HashOf())
+
+	// the layout of a zoned map depends only on its contents
+	half := write(t, withZones(t, empty), tuples[:len(tuples)/2])
+	assert.Equal(t, zoned.HashOf(), write(t, half, tuples[len(tuples)/2:]).HashOf())
+
+	t.Run("prunes subtrees outside the range", func(t *testing.T) {
+		keys, skipped := valueRange(t, zoned, 2000, 2100)
+		assert.Len(t, keys, 101)
+		assert.Equal(t, int64(2000), keys[0])
+		assert.Greater(t, skipped, 0)
+
+		dfltKeys, dfltSkipped := valueRange(t, dflt, 2000, 2100)
+		assert.Equal(t, keys, dfltKeys)
+		assert.Equal(t, 0, dfltSkipped)
+	})
+
+	t.Run("zone maps are kept through edits", func(t *testing.T) {
+		var edits [][2]val.Tuple
+		for i := int64(0); i < 100; i++ {
+			k, v := makePut(i*97, 20_000)
+			edits = append(edits, [2]val.Tuple{k, v})
+		}
+		edited := write(t, zoned, edits)
+		keys, skipped := valueRange(t, edited, 20_000, 20_000)
+		assert.Len(t, keys, 100)
+		assert.Greater(t, skipped, 0)
+
+		keys, _ = valueRange(t, edited, 0, 96)
+		assert.Equal(t, []int64{1, 2, 3}, keys[:3])
+		assert.Len(t, keys, 96)
+	})
+
+	t.Run("ordinal ranges are pruned independently", func(t *testing.T) {
+		_, lower := makePut(0, 2000)
+		rng := ValueRange{Field: 0, Lower: mutValDesc.GetField(0, lower), LowerInclusive: true}
+		var keys []int64
+		for _, bounds := range [][2]uint64{{0, 2500}, {2500, 5000}, {5000, 10_000}} {
+			iter, err := zoned.IterValueRanges(ctx, bounds[0], bounds[1], rng)
+			require.NoError(t, err)
+			for {
+				k, _, err := iter.Next(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				key, _ := mutKeyDesc.GetInt64(0, k)
+				keys = append(keys, key)
+			}
+			if bounds[0] == 0 {
+				assert.Greater(t, iter.SkippedSubtrees(), 0)
+			}
+		}
+		require.Len(t, keys, 8000)
+		assert.Equal(t, int64(2000), keys[0])
+		assert.Equal(t, int64(9999), keys[len(keys)-1])
+	})
+
+	t.Run("rechunking adds zone maps to every node", func(t *testing.T) {
+		rechunked, err := RechunkMap(ctx, withZones(t, dflt), 0)
+		require.NoError(t, err)
+		assert.Equal(t, zoned.HashOf(), rechunked.HashOf())
+		assert.Equal(t, []int{0}, rechunked.ZoneMapFields())
+
+		keeps, err := withZones(t, dflt).KeepsZoneMaps()
+		require.NoError(t, err)
+		assert.False(t, keeps)
+		keeps, err = rechunked.KeepsZoneMaps()
+		require.NoError(t, err)
+		assert.True(t, keeps)
+		keeps, err = dflt.KeepsZoneMaps()
+		require.NoError(t, err)
+		assert.True(t, keeps)
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		_, err := empty.WithZoneMapFields(1)
+		assert.Error(t, err)
+		_, err = empty.WithZoneMapFields(0, 0)
+		assert.Error(t, err)
+	})
+}
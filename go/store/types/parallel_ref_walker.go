@@ -19,6 +19,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/dolthub/dolt/go/libraries/utils/concurrency"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -31,17 +32,24 @@ type parallelRefWalker struct {
 	ctx         context.Context
 	eg          *errgroup.Group
 	concurrency int
+	ctrl        *concurrency.Controller
 	nbf         *NomsBinFormat
 	work        chan parallelRefWalkerWork
+	done        chan struct{}
 }
 
 func (w *parallelRefWalker) goWork() error {
 	for {
+		if err := w.ctrl.Acquire(w.ctx); err != nil {
+			return err
+		}
 		select {
 		case <-w.ctx.Done():
+			w.ctrl.Release(0)
 			return w.ctx.Err()
 		case work, ok := <-w.work:
 			if !ok {
+				w.ctrl.Release(0)
 				return nil
 			}
 			var res []hash.Hash
@@ -51,9 +59,11 @@ func (w *parallelRefWalker) goWork() error {
 					return nil
 				})
 				if err != nil {
+					w.ctrl.Release(0)
 					return err
 				}
 			}
+			w.ctrl.Release(int64(len(work.vals)))
 			select {
 			case work.res <- res:
 				break
@@ -140,13 +150,15 @@ func (w *parallelRefWalker) GetRefSet(visited hash.HashSet, vals ValueSlice) (ha
 
 func (w *parallelRefWalker) Close() error {
 	close(w.work)
+	close(w.done)
 	return w.eg.Wait()
 }
 
 // |parallelRefWalker| provides a way to walk the |Ref|s in a |ValueSlice|
 // using background worker threads to exploit hardware parallelism in cases
 // where walking the merkle-DAG can become CPU bound. Construct a
-// |parallelRefWalker| with a configured level of |concurrency| and then call
+// |parallelRefWalker| with a |concurrency.Controller|, which decides how many
+// of its worker threads run at once, and then call
 // |GetRefs(hash.HashSet, ValueSlice)| with the |ValueSlice| to get back a
 // slice of |hash.Hash| for all the |Ref|s which appear in the values of
 // |ValueSlice|. |GetRefs| will not return any |Ref|s which already appear in
@@ -160,17 +172,23 @@ func (w *parallelRefWalker) Close() error {
 // |ctx| provided to |newParallelRefWalker| is canceled or exceeds its
 // deadline. |GetRefs| must not be called on |parallelRefWalker| after |Close|
 // is called.
-func newParallelRefWalker(ctx context.Context, nbf *NomsBinFormat, concurrency int) *parallelRefWalker {
+func newParallelRefWalker(ctx context.Context, nbf *NomsBinFormat, ctrl *concurrency.Controller) *parallelRefWalker {
 	eg, ctx := errgroup.WithContext(ctx)
+	n := ctrl.Max()
 	res := &parallelRefWalker{
 		ctx,
 		eg,
-		concurrency,
+		n,
+		ctrl,
 		nbf,
-		make(chan parallelRefWalkerWork, concurrency),
+		make(chan parallelRefWalkerWork, n),
+		make(chan struct{}),
 	}
-	for i := 0; i < concurrency; i++ {
+	for i := 0; i < n; i++ {
 		res.eg.Go(res.goWork)
 	}
+	res.eg.Go(func() error {
+		return ctrl.Run(ctx, res.done)
+	})
 	return res
 }
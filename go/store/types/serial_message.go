@@ -342,6 +342,14 @@ func (sm SerialMessage) HumanReadableStringAtIndentationLevel(level int) string
 
 		_ = OutputProllyNodeBytes(ret, serial.Message(sm))
 
+		level -= 1
+		printWithIndendationLevel(level, ret, "}")
+		return ret.String()
+	case serial.ColumnSegmentFileID:
+		ret := &strings.Builder{}
+		printWithIndendationLevel(level, ret, "{\n")
+		level++
+		_ = OutputColumnSegmentBytes(ret, level, serial.Message(sm))
 		level -= 1
 		printWithIndendationLevel(level, ret, "}")
 		return ret.String()
@@ -419,6 +427,20 @@ func OutputBlobNodeBytes(w *strings.Builder, indentationLevel int, msg serial.Me
 	return nil
 }
 
+func OutputColumnSegmentBytes(w *strings.Builder, indentationLevel int, msg serial.Message) error {
+	fileId, _, values, _, count, err := message.UnpackFields(msg)
+	if err != nil {
+		return err
+	}
+	if fileId != serial.ColumnSegmentFileID {
+		return fmt.Errorf("unexpected file ID, expected %s, got %s", serial.ColumnSegmentFileID, fileId)
+	}
+	for i := 0; i < int(count); i++ {
+		printWithIndendationLevel(indentationLevel, w, "%s\n", hex.EncodeToString(values.GetItem(i, msg)))
+	}
+	return nil
+}
+
 func OutputProllyNodeBytes(w io.Writer, msg serial.Message) error {
 	fileId, keys, values, treeLevel, count, err := message.UnpackFields(msg)
 	if fileId != serial.ProllyTreeNodeFileID {
@@ -440,6 +462,15 @@ func OutputProllyNodeBytes(w io.Writer, msg serial.Message) error {
 		addresses[i] = node.ValueItemsBytes()[offset : offset+20]
 	}
 
+	// the values of leaves stored column-wise are in their column segments
+	segments := node.ColumnSegmentsBytes()
+	columnar := len(segments) > 0
+	for i := 0; i < len(segments)/hash.ByteLen; i++ {
+		ref := hash.New(segments[i*hash.ByteLen : (i+1)*hash.ByteLen])
+		w.Write([]byte("\n    column segment: #"))
+		w.Write([]byte(ref.String()))
+	}
+
 	for i := 0; i < int(count); i++ {
 		k := keys.GetItem(i, msg)
 		kt := val.Tuple(k)
@@ -453,7 +484,9 @@ func OutputProllyNodeBytes(w io.Writer, msg serial.Message) error {
 			w.Write([]byte(hex.EncodeToString(kt.GetField(j))))
 		}
 
-		if isLeaf {
+		if columnar {
+			w.Write([]byte(" }"))
+		} else if isLeaf {
 			v := values.GetItem(i, msg)
 			vt := val.Tuple(v)
 
@@ -586,6 +619,13 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 			if err = cb(hash.New(mergeState.FromCommitAddrBytes())); err != nil {
 				return err
 			}
+			pending := mergeState.PendingCherryPickAddrsBytes()
+			for len(pending) >= hash.ByteLen {
+				if err = cb(hash.New(pending[:hash.ByteLen])); err != nil {
+					return err
+				}
+				pending = pending[hash.ByteLen:]
+			}
 		}
 	case serial.RootValueFileID:
 		var msg serial.RootValue
@@ -698,10 +738,10 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 				return err
 			}
 		}
-	case serial.TableSchemaFileID, serial.ForeignKeyCollectionFileID:
+	case serial.TableSchemaFileID, serial.ForeignKeyCollectionFileID, serial.TupleFileID:
 		// no further references from these file types
 		return nil
-	case serial.ProllyTreeNodeFileID, serial.AddressMapFileID, serial.MergeArtifactsFileID, serial.BlobFileID, serial.CommitClosureFileID:
+	case serial.ProllyTreeNodeFileID, serial.AddressMapFileID, serial.MergeArtifactsFileID, serial.BlobFileID, serial.CommitClosureFileID, serial.ColumnSegmentFileID:
 		return message.WalkAddresses(context.TODO(), serial.Message(sm), func(ctx context.Context, addr hash.Hash) error {
 			return cb(addr)
 		})
@@ -30,6 +30,8 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/background"
+	"github.com/dolthub/dolt/go/libraries/utils/concurrency"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/d"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -747,11 +749,9 @@ func (lvs *ValueStore) gc(ctx context.Context,
 		}
 	}
 
-	concurrency := runtime.GOMAXPROCS(0) - 1
-	if concurrency < 1 {
-		concurrency = 1
-	}
-	walker := newParallelRefWalker(ctx, lvs.nbf, concurrency)
+	procs := runtime.GOMAXPROCS(0)
+	ctrl := concurrency.NewController(concurrency.GC, concurrency.Limits{Min: 1, Max: procs, Initial: procs - 1})
+	walker := newParallelRefWalker(ctx, lvs.nbf, ctrl)
 
 	eg.Go(func() error {
 		defer walker.Close()
@@ -837,6 +837,11 @@ func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 
 				toVisit[i] = hashes
 				toVisitCount += len(hashes)
+
+				// Throttle the walk when GC runs as a background job.
+				if err := background.Yield(ctx); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -0,0 +1,41 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, j json);"
+    dolt sql -q "INSERT INTO t VALUES (1, '{\"a\": 1}');"
+    dolt commit -Am "added t"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-inspect: prints a commit and its children" {
+    h=$(dolt sql -r csv -q "select commit_hash from dolt_log limit 1" | tail -n 1)
+    run dolt admin inspect "$h"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Hash: #$h" ]] || false
+    [[ "$output" =~ "Desc: added t" ]] || false
+    [[ "$output" =~ "Children: 3" ]] || false
+}
+
+@test "admin-inspect: --no-children omits references" {
+    h=$(dolt sql -r csv -q "select commit_hash from dolt_log limit 1" | tail -n 1)
+    run dolt admin inspect --no-children "$h"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Desc: added t" ]] || false
+    [[ ! "$output" =~ "Children:" ]] || false
+}
+
+@test "admin-inspect: errors on bad or missing hashes" {
+    run dolt admin inspect notahash
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid hash" ]] || false
+
+    run dolt admin inspect 00000000000000000000000000000000
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not found" ]] || false
+}
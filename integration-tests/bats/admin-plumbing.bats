@@ -0,0 +1,71 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt commit -Am "added t"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-plumbing: update-ref creates, verifies and deletes refs" {
+    h=$(dolt sql -r csv -q "select hashof('main')" | tail -n 1)
+    dolt admin update-ref refs/heads/other "$h"
+    run dolt branch
+    [[ "$output" =~ "other" ]] || false
+
+    run dolt admin update-ref --old 00000000000000000000000000000000 refs/heads/other "$h"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "expected 00000000000000000000000000000000" ]] || false
+
+    dolt admin update-ref --old "$h" -d refs/heads/other
+    run dolt branch
+    [[ ! "$output" =~ "other" ]] || false
+
+    run dolt admin update-ref other "$h"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "not a fully qualified ref" ]] || false
+}
+
+@test "admin-plumbing: cat-chunk and hash-object round trip" {
+    h=$(dolt sql -r csv -q "select hashof('main')" | tail -n 1)
+    dolt admin cat-chunk "$h" > chunk.bin
+    run dolt admin hash-object chunk.bin
+    [ "$status" -eq 0 ]
+    [ "$output" = "$h" ]
+
+    h2=$(printf 'raw bytes' | dolt admin hash-object -w)
+    run dolt admin cat-chunk "$h2"
+    [ "$status" -eq 0 ]
+    [ "$output" = "raw bytes" ]
+
+    run dolt admin cat-chunk --size "$h2"
+    [ "$output" = "9" ]
+}
+
+@test "admin-plumbing: find-referrers lists the commits which reference a chunk" {
+    dolt sql -q "INSERT INTO t VALUES (1), (2);"
+    dolt commit -am "added rows"
+    h=$(dolt sql -r csv -q "select dolt_hashof_table('t')" | tail -n 1)
+    dolt tag v1
+    dolt sql -q "DROP TABLE t;"
+    dolt commit -am "dropped t"
+
+    run dolt admin find-referrers "$h"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "refs/heads/main, refs/tags/v1" ]] || false
+    [[ "$output" =~ "added rows" ]] || false
+
+    run dolt admin find-referrers 00000000000000000000000000000000
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "no commits or working sets reference these chunks" ]] || false
+
+    run dolt admin find-referrers --table-file 00000000000000000000000000000000
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "table file 00000000000000000000000000000000 not found" ]] || false
+}
@@ -850,3 +850,48 @@ SQL
     [ $status -eq 0 ]
     [[ "$output" =~ "4" ]] || false
 }
+
+@test "auto_increment: values generated by rolled back transactions are not generated again after a restart" {
+    dolt sql -q "insert into test (c0) values (1), (2);"
+    dolt sql <<SQL
+set autocommit = 0;
+insert into test (c0) values (3), (4);
+rollback;
+SQL
+
+    # the persisted values survive garbage collection
+    dolt gc
+
+    dolt sql -q "insert into test (c0) values (5);"
+    run dolt sql -q "select pk from test where c0 = 5" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "5" ]] || false
+
+    run dolt sql -q "show create table test"
+    [ $status -eq 0 ]
+    [[ "$output" =~ "AUTO_INCREMENT=6" ]] || false
+}
+
+@test "auto_increment: deleted values are not generated again after a restart" {
+    dolt sql -q "insert into test (c0) values (1), (2), (3);"
+    dolt sql -q "delete from test where pk = 3;"
+
+    dolt sql -q "insert into test (c0) values (4);"
+    run dolt sql -q "select pk from test where c0 = 4" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "auto_increment: lowering the auto increment value is kept after a restart" {
+    dolt sql <<SQL
+set autocommit = 0;
+insert into test (c0) values (1), (2);
+rollback;
+SQL
+
+    dolt sql -q "alter table test auto_increment = 1;"
+    dolt sql -q "insert into test (c0) values (3);"
+    run dolt sql -q "select pk from test where c0 = 3" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
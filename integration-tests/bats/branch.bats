@@ -300,3 +300,37 @@ teardown() {
     [ $status -eq "1" ]
     [[ "$output" =~ "is an invalid branch name" ]] || false
 }
+
+@test "branch: --list filters branches by pattern" {
+    dolt branch team/a
+    dolt branch team/feature/x
+    dolt branch teamx
+    dolt branch other/y
+
+    run dolt branch --list 'team/*'
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "team/a" ]] || false
+    [[ "$output" =~ "team/feature/x" ]] || false
+
+    run dolt branch --list 'team*' 'other/?'
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [[ "$output" =~ "teamx" ]] || false
+    [[ "$output" =~ "other/y" ]] || false
+    [[ ! "$output" =~ "main" ]] || false
+
+    run dolt branch --list '*/feature/*'
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "team/feature/x" ]] || false
+
+    run dolt branch --list main
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "* main" ]] || false
+
+    run dolt branch --list 'team/['
+    [ $status -eq 1 ]
+    [[ "$output" =~ "is not a valid branch pattern" ]] || false
+}
@@ -0,0 +1,50 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "bugreport: packages crash reports and the environment" {
+    mkdir -p .dolt/diagnostics
+    echo '{"panic": "string: boom"}' > .dolt/diagnostics/crash-20240101T000000.000000000.json
+    echo 'not a report' > .dolt/diagnostics/notes.txt
+
+    run dolt bugreport -o report.tar.gz
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Packaged 1 crash report(s)." ]] || false
+    [[ "$output" =~ "Nothing has been uploaded" ]] || false
+
+    run tar tzf report.tar.gz
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "environment.json" ]] || false
+    [[ "$output" =~ "repo/crash-20240101T000000.000000000.json" ]] || false
+    [[ ! "$output" =~ "notes.txt" ]] || false
+
+    run tar xzf report.tar.gz -O environment.json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"in_repo": true' ]] || false
+    [[ "$output" =~ "user.name" ]] || false
+    [[ ! "$output" =~ "Bats Tests" ]] || false
+
+    [ -f .dolt/diagnostics/crash-20240101T000000.000000000.json ]
+}
+
+@test "bugreport: --clear deletes packaged crash reports" {
+    mkdir -p .dolt/diagnostics
+    echo '{"panic": "string: boom"}' > .dolt/diagnostics/crash-20240101T000000.000000000.json
+
+    run dolt bugreport --clear -o report.tar.gz
+    [ "$status" -eq 0 ]
+    [ -f report.tar.gz ]
+    [ ! -f .dolt/diagnostics/crash-20240101T000000.000000000.json ]
+
+    run dolt bugreport -o report2.tar.gz
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No crash reports were found" ]] || false
+}
@@ -68,6 +68,75 @@ teardown() {
     [[ "$output" =~ "8,u" ]] || false
 }
 
+@test "cherry-pick: list and range of commits" {
+    dolt checkout main
+    run dolt cherry-pick branch1 branch1~2
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "1,a" ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "3,c" ]] || false
+
+    run dolt log --oneline -n 2
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "Inserted 1" ]] || false
+    [[ "${lines[1]}" =~ "Inserted 3" ]] || false
+
+    dolt reset --hard HEAD~2
+    run dolt cherry-pick main..branch1
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "1,a" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "3,c" ]] || false
+
+    run dolt log --oneline -n 3
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "Inserted 3" ]] || false
+    [[ "${lines[1]}" =~ "Inserted 2" ]] || false
+    [[ "${lines[2]}" =~ "Inserted 1" ]] || false
+}
+
+@test "cherry-pick: continue a range of commits after resolving conflicts" {
+    dolt checkout main
+    dolt sql -q "INSERT INTO test VALUES (2, 'z')"
+    dolt commit -am "Inserted 2 on main"
+
+    run dolt cherry-pick --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "There is no cherry-pick in progress" ]] || false
+
+    run dolt cherry-pick main..branch1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "Unable to apply commit cleanly due to conflicts or constraint violations" ]] || false
+    [[ "$output" =~ "dolt cherry-pick --continue" ]] || false
+
+    run dolt cherry-pick --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+
+    dolt conflicts resolve --theirs test
+    run dolt cherry-pick --continue
+    [ "$status" -eq "0" ]
+
+    run dolt sql -q "SELECT * FROM test" -r csv
+    [[ "$output" =~ "1,a" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "3,c" ]] || false
+
+    run dolt log --oneline -n 3
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "Inserted 3" ]] || false
+    [[ "${lines[1]}" =~ "Inserted 2" ]] || false
+    [[ "${lines[2]}" =~ "Inserted 1" ]] || false
+
+    run dolt status
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "nothing to commit" ]] || false
+}
+
 @test "cherry-pick: too far back" {
     dolt checkout main
     run dolt cherry-pick branch1~10
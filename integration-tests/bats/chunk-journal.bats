@@ -35,3 +35,58 @@ teardown() {
     [ -s ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
     [ -s ".dolt/noms/journal.idx" ]
 }
+
+@test "chunk-journal: dolt admin flush-journal rolls the journal over into a table file" {
+    dolt sql -q "create table t (pk int primary key, c0 text);"
+    dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "new table t"
+    [ -s ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
+
+    dolt admin flush-journal
+    [ ! -e ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt sql -q "insert into t values (3, 'three');"
+    [ -s ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "new table t" ]] || false
+}
+
+@test "chunk-journal: journal rolls over at DOLT_JOURNAL_ROLL_OVER_SIZE" {
+    dolt sql -q "create table t (pk int primary key, c0 text);"
+    [ -s ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
+
+    DOLT_JOURNAL_ROLL_OVER_SIZE=1 dolt sql -q "insert into t values (1, 'one');"
+    [ ! -e ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]
+
+    run dolt sql -q "select c0 from t where pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "one" ]] || false
+}
+
+@test "chunk-journal: dolt_snapshot_begin returns the files to back up" {
+    dolt sql -q "create table t (pk int primary key, c0 text);"
+    dolt commit -Am "new table t"
+
+    run dolt sql -r csv -q "call dolt_snapshot_begin('30');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "path,size" ]] || false
+    [[ "$output" =~ ".dolt/noms/manifest" ]] || false
+    [[ "$output" =~ ".dolt/noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv" ]] || false
+
+    run dolt sql -q "call dolt_snapshot_begin();"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already in progress" ]] || false
+
+    run dolt sql -q "call dolt_snapshot_begin('0');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid timeout" ]] || false
+
+    run dolt sql -q "call dolt_snapshot_end();"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no snapshot of this database is in progress" ]] || false
+}
@@ -201,3 +201,37 @@ teardown() {
     [ $status -eq 0 ]
     [[ "$output" =~ "1.0.0" ]] || false
 }
+
+@test "commit_tags: create an annotated tag" {
+    run dolt tag -a v1
+    [ $status -ne 0 ]
+    [[ "$output" =~ "Aborting tag due to empty tag message" ]] || false
+
+    dolt tag -a -m "release v1" v1 HEAD^
+    run dolt tag -v
+    [ $status -eq 0 ]
+    [[ "$output" =~ "release v1" ]] || false
+
+    run dolt sql -q "call dolt_tag('-a', 'v2')"
+    [ $status -ne 0 ]
+    [[ "$output" =~ "annotated and signed tags require a tag message" ]] || false
+
+    run dolt sql -q "select tag_name, signature is null from dolt_tags" -r csv
+    [ $status -eq 0 ]
+    [[ "$output" =~ "v1,true" ]] || false
+}
+
+@test "commit_tags: verify an unsigned tag" {
+    dolt tag -m "release v1" v1
+    run dolt tag --verify v1
+    [ $status -ne 0 ]
+    [[ "$output" =~ "tag v1 is not signed" ]] || false
+
+    run dolt tag --verify v2
+    [ $status -ne 0 ]
+    [[ "$output" =~ "tag v2 not found" ]] || false
+
+    run dolt tag -s -m "release v2" v2
+    [ $status -ne 0 ]
+    [[ "$output" =~ "no signing key" ]] || false
+}
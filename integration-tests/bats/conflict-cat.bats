@@ -77,7 +77,7 @@ SQL
     [[ "$output" =~ "| their_schema" ]] || false
     [[ "$output" =~ "| base_schema" ]] || false
     [[ "$output" =~ "| description" ]] || false
-    [[ "$output" =~ "different column definitions for our column age and their column age" ]] || false
+    [[ "$output" =~ "different types for our column age and their column age" ]] || false
     [[ "$output" =~ "\`age\` bigint," ]] || false
     [[ "$output" =~ "\`age\` float," ]] || false
     [[ "$output" =~ "\`age\` int DEFAULT '0'," ]] || false
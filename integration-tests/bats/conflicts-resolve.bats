@@ -25,7 +25,7 @@ teardown() {
 @test "conflicts-resolve: call with no arguments, errors" {
     run dolt conflicts resolve
     [ $status -eq 1 ]
-    [[ $output =~ "--ours or --theirs must be supplied" ]] || false
+    [[ $output =~ "--ours, --theirs, --expr or --script must be supplied" ]] || false
 }
 
 @test "conflicts-resolve: call without specifying table, errors" {
@@ -231,3 +231,142 @@ teardown() {
     [ $status -eq 0 ]
     [[ $output =~ "main" ]] || false
 }
+
+@test "conflicts-resolve: resolve with an expression" {
+    dolt sql -q "create table t (id int primary key, amount int, note varchar(20))"
+    dolt sql -q "insert into t values (1,10,'a'), (2,10,'b'), (3,10,'c')"
+    dolt add .
+    dolt commit -am "base"
+    dolt checkout -b other
+    dolt sql -q "update t set amount = amount + 5, note = 'other' where id < 3"
+    dolt sql -q "delete from t where id = 3"
+    dolt commit -am "other"
+    dolt checkout main
+    dolt sql -q "update t set amount = amount + 1, note = 'main'"
+    dolt commit -am "main"
+
+    run dolt merge other
+    [ $status -eq 1 ]
+    [[ $output =~ "Automatic merge failed" ]] || false
+
+    run dolt conflicts resolve --expr "amount = ours.amount + theirs.amount - base.amount" t
+    [ $status -eq 0 ]
+
+    run dolt sql -q "select * from dolt_conflicts" -r csv
+    [ $status -eq 0 ]
+    [[ ! $output =~ "t," ]] || false
+
+    run dolt sql -q "select * from t order by id" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "1,16,main" ]] || false
+    [[ $output =~ "2,16,main" ]] || false
+    [[ $output =~ "3,,main" ]] || false
+}
+
+@test "conflicts-resolve: resolve with a script file" {
+    basic_conflict
+    dolt merge other || true
+
+    cat > resolve.sql <<SQL
+t = concat(ours.t, '+', theirs.t);
+SQL
+    run dolt conflicts resolve --script resolve.sql .
+    [ $status -eq 0 ]
+
+    run dolt sql -q "select * from t" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "1,main+other" ]] || false
+
+    run dolt status
+    [[ $output =~ "All conflicts and constraint violations fixed" ]] || false
+}
+
+@test "conflicts-resolve: invalid resolution scripts, errors" {
+    basic_conflict
+    dolt merge other || true
+
+    run dolt conflicts resolve --expr "i = 2" t
+    [ $status -eq 1 ]
+    [[ $output =~ "cannot assign primary key column i" ]] || false
+
+    run dolt conflicts resolve --expr "nope = 2" t
+    [ $status -eq 1 ]
+    [[ $output =~ "column nope does not exist" ]] || false
+
+    run dolt conflicts resolve --expr "t = 'x' where i = 1" t
+    [ $status -eq 1 ]
+    [[ $output =~ "expected a list of column assignments" ]] || false
+
+    run dolt conflicts resolve --expr "t = 'x'" --ours t
+    [ $status -eq 1 ]
+    [[ $output =~ "specify only one from" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_conflicts_t" -r csv
+    [[ $output =~ "1" ]] || false
+}
+
+@test "conflicts-resolve: export conflicts to a file and import the resolutions" {
+    dolt sql -q "create table t (i int primary key, t text)"
+    dolt sql -q "insert into t values (1,'base'),(2,'base'),(3,'base'),(4,'base')"
+    dolt commit -Am "init commit"
+    dolt checkout -b other
+    dolt sql -q "update t set t = 'other'"
+    dolt commit -am "other commit"
+    dolt checkout main
+    dolt sql -q "update t set t = 'main'"
+    dolt commit -am "main commit"
+    dolt merge other || true
+
+    run dolt conflicts export t conflicts.csv
+    [ $status -eq 0 ]
+    [[ $output =~ "Exported 4 conflicts of table t to conflicts.csv" ]] || false
+    run head -n 1 conflicts.csv
+    [[ $output =~ "dolt_conflict_id,resolution,our_diff_type,their_diff_type,base_i,base_t,our_i,our_t,their_i,their_t,resolved_i,resolved_t" ]] || false
+
+    # i = 1 keeps ours, i = 2 takes theirs, i = 3 is edited, i = 4 is deleted
+    awk -F, 'BEGIN { OFS = "," }
+        $5 == 1 { $2 = "ours" }
+        $5 == 2 { $2 = "theirs" }
+        $5 == 3 { $2 = "custom"; $12 = "edited" }
+        $5 == 4 { $2 = "delete" }
+        { print }' conflicts.csv > resolved.csv
+
+    run dolt conflicts import t resolved.csv
+    [ $status -eq 0 ]
+    [[ $output =~ "Resolved 4 conflicts of table t, 0 remaining" ]] || false
+
+    run dolt sql -q "select * from t order by i" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "1,main" ]] || false
+    [[ $output =~ "2,other" ]] || false
+    [[ $output =~ "3,edited" ]] || false
+    [[ ! $output =~ "4," ]] || false
+
+    run dolt status
+    [[ $output =~ "All conflicts and constraint violations fixed" ]] || false
+
+    run dolt conflicts import t resolved.csv
+    [ $status -eq 1 ]
+    [[ $output =~ "not found, it may have been resolved already" ]] || false
+}
+
+@test "conflicts-resolve: import of invalid resolutions resolves no conflicts" {
+    basic_conflict
+    dolt merge other || true
+    dolt conflicts export t conflicts.csv
+
+    sed 's/^\([^,]*\),,/\1,mine,/' conflicts.csv > resolved.csv
+    run dolt conflicts import t resolved.csv
+    [ $status -eq 1 ]
+    [[ $output =~ "invalid resolution 'mine'" ]] || false
+
+    sed 's/^\([^,]*\),,\(.*\),1,main$/\1,custom,\2,2,main/' conflicts.csv > resolved.csv
+    run dolt conflicts import t resolved.csv
+    [ $status -eq 1 ]
+    [[ $output =~ "primary key of the custom resolution" ]] || false
+
+    run dolt sql -q "select count(*) from dolt_conflicts_t" -r csv
+    [[ $output =~ "1" ]] || false
+    run dolt sql -q "select * from t" -r csv
+    [[ $output =~ "1,main" ]] || false
+}
@@ -491,6 +491,56 @@ SQL
     [[ ! "$output" =~ "- | 2" ]] || false
 }
 
+@test "diff: three dot diff with omitted revisions and the working set" {
+    # TODO: remove this once dolt checkout is migrated
+    if [ "$SQL_ENGINE" = "remote-engine" ]; then
+      skip "This test relies on dolt checkout, which has not been migrated yet."
+    fi
+
+    dolt checkout main
+    dolt sql -q 'insert into test values (0,0,0,0,0,0)'
+    dolt add .
+    dolt commit -m table
+    dolt branch branch1
+    dolt sql -q 'insert into test values (1,1,1,1,1,1)'
+    dolt commit -am "main row"
+    dolt checkout branch1
+    dolt sql -q 'insert into test values (2,2,2,2,2,2)'
+    dolt commit -am "branch row"
+    dolt sql -q 'insert into test values (3,3,3,3,3,3)'
+
+    # omitted revisions default to HEAD
+    run dolt diff main...
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "| 1" ]] || false
+    [[ "$output" =~ "+ | 2" ]] || false
+    [[ ! "$output" =~ "| 3" ]] || false
+
+    run dolt diff ...main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "+ | 1" ]] || false
+    [[ ! "$output" =~ "| 2" ]] || false
+
+    # the working set shares the merge base of HEAD
+    run dolt diff main...WORKING
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "| 1" ]] || false
+    [[ "$output" =~ "+ | 2" ]] || false
+    [[ "$output" =~ "+ | 3" ]] || false
+
+    run dolt diff --merge-base main WORKING
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "| 1" ]] || false
+    [[ "$output" =~ "+ | 2" ]] || false
+    [[ "$output" =~ "+ | 3" ]] || false
+
+    run dolt sql -r csv -q "select to_pk, diff_type from dolt_diff('main...WORKING', 'test')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,added" ]] || false
+    [[ "$output" =~ "3,added" ]] || false
+    [[ ! "$output" =~ "1," ]] || false
+}
+
 @test "diff: data and schema changes" {
     dolt sql <<SQL
 drop table test;
@@ -1600,6 +1650,48 @@ EOF
     [ "$status" -ne 0 ]
 }
 
+@test "diff: with sample" {
+    dolt sql <<SQL
+CREATE TABLE big (pk int PRIMARY KEY, c int);
+INSERT INTO big WITH RECURSIVE s(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM s WHERE n < 1000) SELECT n, n FROM s;
+SQL
+    dolt add .
+    dolt commit -m "big table"
+    dolt sql -q "update big set c = c + 1 where pk % 3 = 0"
+    dolt sql -q "delete from big where pk % 7 = 0"
+
+    run dolt diff --sample 5 -r sql big
+    [ "$status" -eq 0 ]
+    [ "$(echo "$output" | grep -c '^UPDATE\|^DELETE')" -eq 5 ]
+    first="$output"
+
+    # the sample of a diff is always the same
+    run dolt diff --sample 5 -r sql big
+    [ "$status" -eq 0 ]
+    [ "$output" = "$first" ]
+
+    run dolt diff --sample 5000 -r sql big
+    [ "$status" -eq 0 ]
+    [ "$(echo "$output" | grep -c '^UPDATE\|^DELETE')" -eq 428 ]
+
+    # a different diff is sampled differently
+    dolt sql -q "update big set c = 0 where pk = 1"
+    run dolt diff --sample 5 -r sql big
+    [ "$status" -eq 0 ]
+    [ "$output" != "$first" ]
+
+    run dolt diff --sample 0 big
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "|" ]] || false
+
+    run dolt diff --sample 5 --limit 5
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--sample cannot be combined with --limit" ]] || false
+
+    run dolt diff --sample -1
+    [ "$status" -ne 0 ]
+}
+
 @test "diff: allowed across primary key renames" {
     dolt sql <<SQL
 CREATE TABLE t1 (pk int PRIMARY KEY, col1 int);
@@ -82,6 +82,81 @@ if rows[2] != "9,8,7,6,5,4".split(","):
 '
 }
 
+@test "export-tables: export to stdout with format and compression" {
+    dolt sql -q "insert into test_int values (0, 1, 2, 3, 4, 5)"
+    dolt table export --format jsonl test_int - > export.jsonl
+    run cat export.jsonl
+    [ "$output" = '{"pk":0,"c1":1,"c2":2,"c3":3,"c4":4,"c5":5}' ]
+    [ ! -f stream ]
+
+    dolt table export --format csv --compress gzip test_int - > export.csv.gz
+    run gzip -dc export.csv.gz
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0,1,2,3,4,5" ]
+
+    # the compression and format of a file are inferred from its extensions
+    dolt table export test_int inferred.csv.gz
+    run gzip -dc inferred.csv.gz
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "pk,c1,c2,c3,c4,c5" ]
+
+    run dolt table export --compress lz4 test_int -
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'lz4' is not a valid compression" ]] || false
+
+    run dolt table export --format parquet test_int -
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "Cannot export this format to stdout" ]] || false
+}
+
+@test "export-tables: export tables as a tar stream" {
+    dolt sql -q "insert into test_int values (0, 1, 2, 3, 4, 5)"
+    dolt sql -q "insert into test_string values ('a', 'b', 'c', 'd', 'e', 'f')"
+    dolt table export --tar --compress gzip test_int,test_string - > export.tar.gz
+    run tar -tzf export.tar.gz
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "test_int.csv" ]
+    [ "${lines[1]}" = "test_string.csv" ]
+    run tar -xzOf export.tar.gz test_string.csv
+    [[ "$output" =~ "a,b,c,d,e,f" ]] || false
+
+    run dolt table export --tar --format json test_int,test_string export.tar
+    [ "$status" -eq 0 ]
+    run tar -tf export.tar
+    [ "${lines[0]}" = "test_int.json" ]
+
+    run dolt table export --tar test_int export.tar
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "export.tar already exists" ]] || false
+}
+
+@test "export-tables: export csv with locale options" {
+    dolt sql -q "create table prices (id int primary key, price decimal(10,2), ratio double, d date, ts datetime)"
+    dolt sql -q "insert into prices values (1, 1234.5, 0.25, '2024-01-31', '2024-01-31 13:45:07'), (2, null, null, null, null)"
+
+    dolt table export --delim ';' --decimal-separator , --date-format %d.%m.%Y --datetime-format '%d.%m.%Y %H:%i' --bom --crlf prices prices.csv
+    [ "$(head -c 3 prices.csv | od -An -tx1 | tr -d ' ')" = "efbbbf" ]
+    run od -c prices.csv
+    [[ "$output" =~ "\r  \n" ]] || false
+    run tail -c +4 prices.csv
+    [[ "${lines[0]}" =~ "id;price;ratio;d;ts" ]] || false
+    [[ "${lines[1]}" =~ "1;1234,50;0,25;31.01.2024;31.01.2024 13:45" ]] || false
+    [[ "${lines[2]}" =~ "2;;;;" ]] || false
+
+    # a decimal separator that is also the delimiter is quoted
+    run dolt table export --decimal-separator , prices -
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '1,"1234,50","0,25",2024-01-31,2024-01-31 13:45:07' ]] || false
+
+    run dolt table export --decimal-separator ';;' prices -
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--decimal-separator must be a single character" ]] || false
+
+    run dolt table export --bom prices prices.json
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--bom is only supported when exporting to csv or psv" ]] || false
+}
+
 @test "export-tables: dolt table export" {
     dolt sql -q "insert into test_int values (0, 1, 2, 3, 4, 5)"
     run dolt table export test_int export.csv
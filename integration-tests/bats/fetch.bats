@@ -360,6 +360,54 @@ teardown() {
     [[ "$output" =~ "unknown remote" ]] || false
 }
 
+@test "fetch: fetch branch namespace" {
+    cd repo1
+    dolt branch team/a
+    dolt branch team/feature/x
+    dolt branch other/y
+    dolt push origin team/a team/feature/x other/y
+
+    cd ../repo2
+
+    setup_remote_server
+
+    dolt fetch origin 'refs/heads/team/*:refs/remotes/origin/team/*'
+
+    run dolt branch -r --list 'origin/team/*'
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "remotes/origin/team/a" ]] || false
+    [[ "$output" =~ "remotes/origin/team/feature/x" ]] || false
+
+    run dolt branch -r
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "other/y" ]] || false
+
+    run dolt fetch origin 'refs/heads/nope/*:refs/remotes/origin/nope/*'
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid ref spec" ]] || false
+}
+
+@test "fetch: fetch branch pattern without a destination" {
+    cd repo1
+    dolt branch release/1
+    dolt branch release/2
+    dolt branch stale/a
+    dolt push origin release/1 release/2 stale/a
+
+    cd ../repo2
+
+    setup_remote_server
+
+    dolt fetch origin 'refs/heads/release/*'
+
+    run dolt branch -r
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/release/1" ]] || false
+    [[ "$output" =~ "remotes/origin/release/2" ]] || false
+    [[ ! "$output" =~ "stale/a" ]] || false
+}
+
 @test "fetch: fetch unknown ref fails" {
     cd repo2
 
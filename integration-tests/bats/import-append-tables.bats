@@ -112,13 +112,44 @@ CSV
     [ "$status" -eq 1 ]
     [[ "$output" =~ "An error occurred while moving data" ]] || false
     [[ "$output" =~ "cause: value other is not valid for this Enum" ]] || false
-    [[ "$output" =~ "A bad row was encountered inserting into table shirts (on line 3):" ]] || false    # table name
+    [[ "$output" =~ "A bad row was encountered inserting into table shirts (on line 3, byte offset 45):" ]] || false    # table name
     [[ "$output" =~ "name: shirt2" ]] || false                                              # column names
     [[ "$output" =~ "size: other" ]] || false
     [[ "$output" =~ "color: green" ]] || false
     [[ "$output" =~ "Errors during import can be ignored using '--continue'" ]] || false
 }
 
+@test "import-append-tables: import error message gives the file, line and byte offset of the bad row" {
+    dolt sql -q "CREATE TABLE shirts (name VARCHAR(40), size ENUM('x-small', 'small', 'medium', 'large', 'x-large'), color ENUM('red', 'blue'));"
+    cat <<CSV > shirts.csv
+name, size, color
+"shirt1", "x-small", "red"
+
+"shirt
+2", "small", "red"
+"shirt3", "other", "green"
+CSV
+    run dolt table import -a shirts shirts.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "A bad row was encountered inserting into table shirts (on line 6 of shirts.csv, byte offset 72):" ]] || false
+    [[ "$output" =~ "name: shirt3" ]] || false
+
+    cat <<CSV > shirts.csv
+name, size, color
+"shirt
+1", "small", "red", "x"
+"shirt2", "small", "red"
+CSV
+    run dolt table import -a shirts shirts.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "A bad row was encountered inserting into table shirts (on line 2 of shirts.csv, byte offset 18):" ]] || false
+
+    run dolt table import -a --continue shirts shirts.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "(line 2 of shirts.csv, byte offset 18)" ]] || false
+    [[ "$output" =~ "Lines skipped: 1" ]] || false
+}
+
 @test "import-append-tables: different schema warning lists differing columns" {
     dolt sql -q "CREATE TABLE t (pk int primary key, col1 int);"
     run dolt table import -a t <<CSV
@@ -143,3 +174,25 @@ CSV
     [ "$status" -eq 1 ]
     [[ "$output" =~ "fatal: --all-text is only supported for create operations" ]] || false
 }
+
+@test "import-append-tables: --format json reports bad rows and exits 4" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, col1 int);"
+    dolt sql -q "insert into t values (1, 1)"
+    cat <<CSV > rows.csv
+pk,col1
+1,1
+2,3
+CSV
+
+    run bash -c "dolt table import -a t --continue --format json rows.csv 2>/dev/null"
+    [ "$status" -eq 4 ]
+    [[ "$output" =~ '{"status":"bad_rows","table":"t","rows_processed":1,"rows_added":1,"rows_modified":0,"rows_unchanged":0,"bad_rows":1}' ]] || false
+
+    cat <<CSV > rows.csv
+pk,col1
+3,3
+CSV
+    run bash -c "dolt table import -a t --format json rows.csv 2>/dev/null"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"status":"success"' ]] || false
+}
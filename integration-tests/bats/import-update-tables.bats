@@ -1486,3 +1486,49 @@ DELIM
     [ "$status" -eq 1 ]
     [[ "$output" =~ "fatal: --all-text is only supported for create operations" ]] || false
 }
+
+@test "import-update-tables: update table from stdin" {
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY, v varchar(20));"
+
+    run bash -c "printf 'pk,v\n1,a\n2,b\n' | dolt table import -u t -"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
+
+    run bash -c "printf '{\"pk\":3,\"v\":\"c\"}\n{\"pk\":4,\"v\":\"d\"}\n' | dolt table import -u --file-type jsonl t -"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 2, Modifications: 0, Had No Effect: 0" ]] || false
+
+    dolt table export t t.parquet
+    dolt sql -q "DELETE FROM t;"
+    run bash -c "dolt table import -u --file-type parquet t - < t.parquet"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 4, Additions: 4, Modifications: 0, Had No Effect: 0" ]] || false
+
+    run bash -c "cat t.parquet | dolt table import -u --file-type parquet t -"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "parquet files can't be read from a pipe" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM t ORDER BY pk;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4,d" ]] || false
+}
+
+@test "import-update-tables: imports maintain dolt_audit_columns" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, v varchar(20), created datetime, modified datetime, modified_by varchar(64));"
+    dolt sql -q "INSERT INTO t VALUES (1, 'a', '2000-01-01', '2000-01-01', 'nobody');"
+    dolt sql -q "INSERT INTO dolt_audit_columns VALUES ('t', 'created', 'created_at'), ('t', 'modified', 'updated_at'), ('t', 'modified_by', 'updated_by');"
+
+    printf 'pk,v\n1,b\n2,c\n' > audit.csv
+    run dolt table import -u t audit.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 2, Additions: 1, Modifications: 1, Had No Effect: 0" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk, v, created = '2000-01-01', modified > '2000-01-01', modified_by = 'nobody' FROM t ORDER BY pk;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,b,true,true,false" ]] || false
+    [[ "$output" =~ "2,c,false,true,false" ]] || false
+
+    run dolt sql -r csv -q "SELECT count(*) FROM t WHERE created IS NULL OR modified IS NULL OR modified_by IS NULL;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
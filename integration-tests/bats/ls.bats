@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 22 ]
+    [ "${#lines[@]}" -eq 28 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_commits" ]] || false
@@ -83,6 +83,12 @@ teardown() {
     [[ "$output" =~ "dolt_commit_diff_table_two" ]] || false
     [[ "$output" =~ "dolt_workspace_table_one" ]] || false
     [[ "$output" =~ "dolt_workspace_table_two" ]] || false
+    [[ "$output" =~ "dolt_statistics_column_groups" ]] || false
+    [[ "$output" =~ "dolt_table_checksums" ]] || false
+    [[ "$output" =~ "dolt_quotas" ]] || false
+    [[ "$output" =~ "dolt_export_job_runs" ]] || false
+    [[ "$output" =~ "dolt_replication_status" ]] || false
+    [[ "$output" =~ "dolt_jobs" ]] || false
 }
 
 @test "ls: --all shows tables in working set and system tables" {
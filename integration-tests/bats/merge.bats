@@ -1074,12 +1074,20 @@ SQL
     dolt add .
     dolt commit -am "rename test1"
 
-    run dolt merge merge_branch
-    log_status_eq 1
-    [[ "$output" =~ "conflict: table with same name deleted and modified" ]] || false
+    dolt merge merge_branch -m "merge"
+    run dolt ls
+    [[ "$output" =~ "new_name" ]] || false
+    [[ ! "$output" =~ "test1" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM new_name"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0,1,2" ]] || false
 }
 
 @test "merge: ourRoot renames, theirRoot modifies the schema" {
+    dolt sql -q "INSERT INTO test1 VALUES (0,1,2)"
+    dolt commit -am "add pk 0 to test1"
+
     dolt checkout -b merge_branch
     dolt sql -q "ALTER TABLE test1 DROP COLUMN c2;"
     dolt commit -am "modify test1"
@@ -1089,22 +1097,16 @@ SQL
     dolt add .
     dolt commit -am "rename test1"
 
-    run dolt merge merge_branch
-    log_status_eq 1
-    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test" ]] || false
-
-    run dolt conflicts cat .
-    [[ "$output" =~ "+------------+-------------------------------------------------------------------+-------------------------------------------------------------------+--------------------------------------------------------+" ]] || false
-    [[ "$output" =~ "| our_schema | their_schema                                                      | base_schema                                                       | description                                            |" ]] || false
-    [[ "$output" =~ "+------------+-------------------------------------------------------------------+-------------------------------------------------------------------+--------------------------------------------------------+" ]] || false
-    [[ "$output" =~ '| <deleted>  | CREATE TABLE `test1` (                                            | CREATE TABLE `test1` (                                            | cannot merge a table deletion with schema modification |' ]] || false
-    [[ "$output" =~ '|            |   `pk` int NOT NULL,                                              |   `pk` int NOT NULL,                                              |                                                        |' ]] || false
-    [[ "$output" =~ '|            |   `c1` int,                                                       |   `c1` int,                                                       |                                                        |' ]] || false
-    [[ "$output" =~ '|            |   PRIMARY KEY (`pk`)                                              |   `c2` int,                                                       |                                                        |' ]] || false
-    [[ "$output" =~ '|            | ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin; |   PRIMARY KEY (`pk`)                                              |                                                        |' ]] || false
-    [[ "$output" =~ "|            |                                                                   | ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_bin; |                                                        |" ]] || false
-    [[ "$output" =~ "+------------+-------------------------------------------------------------------+-------------------------------------------------------------------+--------------------------------------------------------+" ]] || false
+    dolt merge merge_branch -m "merge"
+    run dolt ls
+    [[ "$output" =~ "new_name" ]] || false
+    [[ ! "$output" =~ "test1" ]] || false
 
+    run dolt sql -r csv -q "SELECT * FROM new_name"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pk,c1" ]] || false
+    [[ ! "$output" =~ "c2" ]] || false
+    [[ "$output" =~ "0,1" ]] || false
 }
 
 @test "merge: ourRoot modifies, theirRoot renames" {
@@ -1117,9 +1119,14 @@ SQL
     dolt sql -q "INSERT INTO test1 VALUES (0,1,2)"
     dolt commit -am "add pk 0 to test1"
 
-    run dolt merge merge_branch
-    log_status_eq 1
-    [[ "$output" =~ "conflict: table with same name deleted and modified" ]] || false
+    dolt merge merge_branch -m "merge"
+    run dolt ls
+    [[ "$output" =~ "new_name" ]] || false
+    [[ ! "$output" =~ "test1" ]] || false
+
+    run dolt sql -r csv -q "SELECT * FROM new_name"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0,1,2" ]] || false
 }
 
 @test "merge: ourRoot modifies the schema, theirRoot renames" {
@@ -1132,10 +1139,54 @@ SQL
     dolt sql -q "ALTER TABLE test1 DROP COLUMN c2;"
     dolt commit -am "modify test1"
 
+    dolt merge merge_branch -m "merge"
+    run dolt ls
+    [[ "$output" =~ "new_name" ]] || false
+    [[ ! "$output" =~ "test1" ]] || false
+
+    run dolt schema show new_name
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "c2" ]] || false
+}
+
+@test "merge: both roots rename a table differently" {
+    dolt checkout -b merge_branch
+    dolt sql -q "ALTER TABLE test1 RENAME TO their_name"
+    dolt commit -Am "rename test1"
+
+    dolt checkout main
+    dolt sql -q "ALTER TABLE test1 RENAME TO our_name"
+    dolt commit -Am "rename test1"
+
     run dolt merge merge_branch
     log_status_eq 1
-    [[ "$output" =~ "cannot create column pk on table new_name" ]] || false
-    [[ "$output" =~ "already used in table test1" ]] || false
+    [[ "$output" =~ "table 'test1' was renamed to 'our_name' in one commit and to 'their_name' in the other" ]] || false
+}
+
+@test "merge: renamed table with conflicts" {
+    dolt sql -q "INSERT INTO test1 VALUES (0,1,2)"
+    dolt commit -am "add pk 0 to test1"
+
+    dolt checkout -b merge_branch
+    dolt sql -q "UPDATE test1 SET c1 = 10 WHERE pk = 0"
+    dolt commit -am "update test1"
+
+    dolt checkout main
+    dolt sql -q "ALTER TABLE test1 RENAME TO new_name"
+    dolt sql -q "UPDATE new_name SET c1 = 20 WHERE pk = 0"
+    dolt commit -Am "rename and update test1"
+
+    run dolt merge merge_branch
+    log_status_eq 1
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in new_name" ]] || false
+
+    run dolt sql -r csv -q "SELECT base_c1, our_c1, their_c1 FROM dolt_conflicts_new_name"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,20,10" ]] || false
+
+    dolt conflicts resolve --theirs new_name
+    run dolt sql -r csv -q "SELECT * FROM new_name"
+    [[ "$output" =~ "0,10,2" ]] || false
 }
 
 @test "merge: dolt merge commits successful non-fast-forward merge" {
@@ -1360,3 +1411,116 @@ SQL
     run dolt merge b1
     log_status_eq 0
 }
+
+@test "merge: --dry-run reports the merge without merging" {
+    dolt checkout -b other
+    dolt sql -q "insert into test1 values (1, 1, 1), (2, 2, 2)"
+    dolt commit -am "added rows on other"
+    dolt checkout main
+    dolt sql -q "insert into test1 values (1, 10, 10); insert into test2 values (1, 1, 1)"
+    dolt commit -am "added rows on main"
+    main_head=$(get_head_commit)
+
+    run dolt merge --dry-run other
+    log_status_eq 1
+    [[ "$output" =~ "| test1      | modified  | 1          | 0             | 0            | 1" ]] || false
+    [[ "$output" =~ "Merge would leave conflicts or constraint violations." ]] || false
+
+    [ "$(get_head_commit)" = "$main_head" ]
+    run dolt status
+    log_status_eq 0
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+    ! [[ "$output" =~ "merge" ]] || false
+
+    dolt sql -q "delete from test1 where pk = 1"
+    dolt commit -am "removed conflicting row"
+    run dolt merge --dry-run other
+    log_status_eq 0
+    [[ "$output" =~ "| test1      | modified  | 2          | 0             | 0            | 0" ]] || false
+
+    run dolt merge --dry-run main
+    log_status_eq 0
+    [[ "$output" =~ "Merge would not change any tables." ]] || false
+}
+
+@test "merge: --strategy merges every table with the given strategy" {
+    dolt sql -q "insert into test1 values (1, 1, 1); insert into test2 values (1, 1, 1)"
+    dolt commit -am "added rows"
+    dolt checkout -b other
+    dolt sql -q "update test1 set c1 = 10; update test2 set c1 = 10"
+    dolt commit -am "updated rows on other"
+    dolt checkout main
+    dolt sql -q "update test1 set c1 = 20; update test2 set c1 = 20"
+    dolt commit -am "updated rows on main"
+
+    run dolt merge --strategy mine other
+    log_status_eq 1
+    [[ "$output" =~ "unknown merge strategy 'mine'" ]] || false
+
+    run dolt merge --strategy ours -m "keep ours" other
+    log_status_eq 0
+
+    run dolt sql -q "select c1 from test1 union all select c1 from test2" -r csv
+    log_status_eq 0
+    [ "${lines[1]}" = "20" ]
+    [ "${lines[2]}" = "20" ]
+
+    run dolt log -n 1
+    log_status_eq 0
+    [[ "$output" =~ "keep ours" ]] || false
+    [[ "$output" =~ "Merge:" ]] || false
+}
+
+@test "merge: --format json prints the result and exits with the documented codes" {
+    dolt checkout -b other
+    dolt sql -q "insert into test1 values (1, 1, 1)"
+    dolt commit -am "added row on other"
+
+    dolt checkout -b ff main
+    run bash -c "dolt merge --format json other 2>/dev/null"
+    log_status_eq 0
+    [[ "$output" =~ '"status":"success"' ]] || false
+    [[ "$output" =~ '"fast_forward":true' ]] || false
+    [[ "$output" =~ '{"table":"test1","operation":"modified","rows_added":1,' ]] || false
+    [ "${#lines[@]}" -eq 1 ]
+
+    run bash -c "dolt merge --format json other 2>/dev/null"
+    log_status_eq 0
+    [[ "$output" =~ '"up_to_date":true' ]] || false
+
+    dolt checkout main
+    dolt sql -q "insert into test1 values (1, 2, 2)"
+    dolt commit -am "added row on main"
+
+    run bash -c "dolt merge --format json other 2>/dev/null"
+    log_status_eq 2
+    [[ "$output" =~ '"status":"conflicts"' ]] || false
+    [[ "$output" =~ '"data_conflicts":1' ]] || false
+    dolt merge --abort
+
+    run dolt merge --format xml other
+    log_status_eq 1
+    [[ "$output" =~ "invalid --format: xml" ]] || false
+}
+
+@test "merge: --format json exits 3 for constraint violations" {
+    dolt sql <<SQL
+create table parent (pk int primary key);
+create table child (pk int primary key, p int, foreign key (p) references parent(pk));
+insert into parent values (1);
+SQL
+    dolt commit -Am "added parent and child"
+
+    dolt checkout -b other
+    dolt sql -q "delete from parent where pk = 1"
+    dolt commit -am "deleted parent"
+
+    dolt checkout main
+    dolt sql -q "insert into child values (1, 1)"
+    dolt commit -am "added child"
+
+    run bash -c "dolt merge --format json -m merge other 2>/dev/null"
+    log_status_eq 3
+    [[ "$output" =~ '"status":"constraint_violations"' ]] || false
+    [[ "$output" =~ '"constraint_violations":1' ]] || false
+}
@@ -59,7 +59,10 @@ teardown() {
     [[ "$output" =~ "gc - Cleans up unreferenced data from the repository." ]] || false
     [[ "$output" =~ "filter-branch - Edits the commit history using the provided query." ]] || false
     [[ "$output" =~ "merge-base - Find the common ancestor of two commits." ]] || false
+    [[ "$output" =~ "symbolic-ref - Read, modify and delete the symbolic refs of a database." ]] || false
     [[ "$output" =~ "version - Displays the version for the Dolt binary." ]] || false
+    [[ "$output" =~ "bugreport - Package diagnostics for a bug report." ]] || false
+    [[ "$output" =~ "upgrade - Upgrade dolt to the latest release." ]] || false
     [[ "$output" =~ "dump - Export all tables in the working set into a file." ]] || false
 }
 
@@ -0,0 +1,137 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test (pk int primary key, v int);"
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt add -A && dolt commit -m "commit A"
+    dolt branch staging
+    dolt branch prod
+
+    dolt checkout staging
+    dolt sql -q "INSERT INTO test VALUES (2, 2);"
+    dolt commit -am "commit B"
+    dolt checkout main
+
+    cat > checks.sql <<SQL
+select * from test where v < 0;
+select pk, count(*)
+  from test group by pk having count(*) > 1;
+SQL
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "promote: fast-forward, validate and tag" {
+    run dolt promote --validate checks.sql --tag release-1 staging prod
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Promoted staging into prod (fast-forward)" ]] || false
+    [[ "$output" =~ "2 validation queries passed" ]] || false
+    [[ "$output" =~ "Tagged release-1" ]] || false
+
+    staging=$(dolt sql -r csv -q "select dolt_hashof('staging')" | tail -n 1)
+    run dolt sql -r csv -q "select dolt_hashof('prod'), dolt_hashof('release-1')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$staging,$staging" ]] || false
+
+    run dolt tag -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Promote staging into prod" ]] || false
+    [[ "$output" =~ "merge: fast-forward" ]] || false
+    [[ "$output" =~ "validated by 2 queries of checks.sql" ]] || false
+    [[ "$output" =~ "select pk, count(*) from test group by pk having count(*) > 1" ]] || false
+
+    # the temporary branch of the promotion is deleted
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "dolt_promote" ]] || false
+
+    run dolt promote staging prod
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "nothing to promote, prod already contains staging" ]] || false
+}
+
+@test "promote: merge commit" {
+    dolt checkout prod
+    dolt sql -q "INSERT INTO test VALUES (3, 3);"
+    dolt commit -am "hotfix"
+    dolt checkout main
+
+    run dolt promote --ff-only staging prod
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "prod can't be fast-forwarded to staging" ]] || false
+
+    run dolt promote -m "release 2" --tag release-2 staging prod
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Promoted staging into prod (merge)" ]] || false
+
+    run dolt log -n 1 prod
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Merge:" ]] || false
+    [[ "$output" =~ "release 2" ]] || false
+
+    run dolt sql -r csv -q "select pk from test as of 'prod' order by pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1
+2
+3" ]] || false
+}
+
+@test "promote: failed validation leaves the target unchanged" {
+    dolt checkout staging
+    dolt sql -q "INSERT INTO test VALUES (4, -4);"
+    dolt commit -am "bad row"
+    dolt checkout main
+    prod=$(dolt sql -r csv -q "select dolt_hashof('prod')" | tail -n 1)
+
+    run dolt promote --validate checks.sql --tag release-1 staging prod
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "validation query returned 1 rows: select * from test where v < 0" ]] || false
+
+    run dolt sql -r csv -q "select dolt_hashof('prod')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$prod" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "release-1" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "dolt_promote" ]] || false
+}
+
+@test "promote: merge must be clean" {
+    dolt checkout prod
+    dolt sql -q "INSERT INTO test VALUES (2, 20);"
+    dolt commit -am "conflicting change"
+    dolt checkout main
+    prod=$(dolt sql -r csv -q "select dolt_hashof('prod')" | tail -n 1)
+
+    run dolt promote staging prod
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "merging staging into prod has conflicts or constraint violations" ]] || false
+
+    run dolt sql -r csv -q "select dolt_hashof('prod')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$prod" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "dolt_promote" ]] || false
+}
+
+@test "promote: target must be a clean branch" {
+    run dolt promote staging not-a-branch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "branch not found: not-a-branch" ]] || false
+
+    dolt sql -q "call dolt_checkout('prod'); INSERT INTO test VALUES (5, 5);"
+    run dolt promote staging prod
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "prod has uncommitted changes" ]] || false
+}
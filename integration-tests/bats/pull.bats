@@ -58,6 +58,22 @@ teardown() {
     [[ "$output" =~ "Second commit" ]] || false
 }
 
+@test "pull: pull --format json prints the result" {
+    cd repo2
+
+    setup_remote_server
+
+    run bash -c "dolt pull --format json origin 2>/dev/null"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ '"status":"success","up_to_date":false,"fast_forward":true' ]] || false
+    [[ "$output" =~ '{"table":"t1","operation":"added","rows_added":0,' ]] || false
+
+    run bash -c "dolt pull --format json origin 2>/dev/null"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"up_to_date":true' ]] || false
+}
+
 @test "pull: pull custom remote" {
     cd repo2
 
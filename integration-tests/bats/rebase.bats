@@ -42,10 +42,45 @@ setupCustomEditorScript() {
     [[ "$output" =~ "no rebase in progress" ]] || false
 }
 
-@test "rebase: -i flag required" {
-    run dolt rebase b1
+@test "rebase: non-interactive rebase" {
+    dolt checkout b1
+    run dolt rebase main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* b1" ]] || false
+    ! [[ "$output" =~ "dolt_rebase_b1" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "b1 commit 1" ]] || false
+    [[ "${lines[1]}" =~ "main commit 2" ]] || false
+}
+
+@test "rebase: non-interactive rebase stops on data conflicts" {
+    dolt checkout b1
+    dolt sql -q "INSERT INTO t1 VALUES (1,2);"
+    dolt commit -am "b1 commit 2"
+
+    run dolt rebase main
     [ "$status" -eq 1 ]
-    [[ "$output" =~ "non-interactive rebases not currently supported" ]] || false
+    [[ "$output" =~ "data conflict detected while rebasing commit" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* dolt_rebase_b1" ]] || false
+
+    dolt conflicts resolve --theirs t1
+    dolt add t1
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt sql -q "SELECT c FROM t1 WHERE pk = 1;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
 }
 
 @test "rebase: bad args" {
@@ -75,3 +75,33 @@ teardown() {
         [[ "$output" =~ "only valid for aws remotes" ]] || false
     fi
 }
+
+@test "remote-cmd: check file remote" {
+    mkdir -p "$BATS_TMPDIR/check-remote-$$"
+    dolt remote add origin "file://$BATS_TMPDIR/check-remote-$$"
+    dolt push origin main
+
+    run dolt remote check origin
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin: ok" ]] || false
+    [[ "$output" =~ "capabilities: read, write" ]] || false
+
+    run dolt remote check --format json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"name":"origin"' ]] || false
+    [[ "$output" =~ '"status":"ok"' ]] || false
+
+    rm -rf "$BATS_TMPDIR/check-remote-$$"
+}
+
+@test "remote-cmd: check unreachable remote" {
+    dolt remote add origin http://localhost:1/org/db
+
+    run dolt remote check
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "origin: unreachable" ]] || false
+
+    run dolt remote check other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote: 'other'" ]] || false
+}
@@ -176,3 +176,115 @@ SQL
     [ ! -d test-repo ]
     cd ..
 }
+
+@test "remotes-file-system: clone checks out the default branch set by symbolic-ref" {
+    dolt branch aaa
+    dolt branch trunk
+    mkdir remote1
+    dolt remote add origin file://remote1
+    dolt push origin main aaa trunk
+
+    run dolt symbolic-ref --remote origin HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "ref HEAD is not a symbolic ref" ]] || false
+
+    run dolt symbolic-ref --remote origin HEAD missing
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "ref refs/heads/missing does not exist" ]] || false
+
+    dolt symbolic-ref --remote origin HEAD trunk
+    run dolt symbolic-ref --remote origin --short HEAD
+    [ "$status" -eq 0 ]
+    [ "$output" = "trunk" ]
+
+    # the local database's HEAD is unchanged
+    run dolt symbolic-ref -q HEAD
+    [ "$status" -eq 1 ]
+    [ "$output" = "" ]
+
+    cd dolt-repo-clones
+    dolt clone file://../remote1 default-repo
+    cd default-repo
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* trunk" ]] || false
+    cd ..
+
+    # --branch overrides the default branch
+    dolt clone -b aaa file://../remote1 aaa-repo
+    cd aaa-repo
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* aaa" ]] || false
+    cd ../..
+
+    # a HEAD referring to a deleted branch falls back to main
+    dolt push origin :trunk
+    cd dolt-repo-clones
+    dolt clone file://../remote1 fallback-repo
+    cd fallback-repo
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* main" ]] || false
+}
+
+@test "remotes-file-system: symbolic-ref sets, follows renames of, and deletes HEAD" {
+    dolt branch trunk
+    dolt symbolic-ref HEAD trunk
+    run dolt symbolic-ref HEAD
+    [ "$status" -eq 0 ]
+    [ "$output" = "refs/heads/trunk" ]
+
+    dolt branch -m trunk stem
+    run dolt symbolic-ref refs/symbolic/HEAD
+    [ "$status" -eq 0 ]
+    [ "$output" = "refs/heads/stem" ]
+
+    # the checked out branch is unchanged
+    run dolt branch --show-current
+    [ "$output" = "main" ]
+
+    dolt symbolic-ref -d HEAD
+    run dolt symbolic-ref -d HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "symbolic ref HEAD does not exist" ]] || false
+}
+
+@test "remotes-file-system: clone with --refs only fetches matching branches" {
+    dolt branch release/1
+    dolt branch release/2
+    dolt branch stale/a
+    dolt tag v1
+    mkdir remote1
+    dolt remote add origin file://remote1
+    dolt push origin main release/1 release/2 stale/a v1
+
+    cd dolt-repo-clones
+    dolt clone --refs 'refs/heads/release/*' file://../remote1 release-repo
+    cd release-repo
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* release/1" ]] || false
+    [[ "$output" =~ "remotes/origin/release/1" ]] || false
+    [[ "$output" =~ "remotes/origin/release/2" ]] || false
+    [[ ! "$output" =~ "stale/a" ]] || false
+    [[ ! "$output" =~ "remotes/origin/main" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+
+    run dolt sql -q "select fetch_specs from dolt_remotes" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/release/*:refs/remotes/origin/release/*" ]] || false
+
+    # later fetches only bring in the cloned branch namespace
+    dolt fetch
+    run dolt branch -r
+    [[ ! "$output" =~ "stale/a" ]] || false
+
+    run dolt clone --refs 'refs/tags/*' file://../../remote1 tags-repo
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid fetch spec" ]] || false
+}
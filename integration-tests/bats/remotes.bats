@@ -1935,6 +1935,42 @@ SQL
     [[ "$output" =~ "test_table" ]] || false
 }
 
+@test "remotes: dolt_clone runs as a job listed in dolt_jobs" {
+    repoDir="$BATS_TMPDIR/dolt-repo-$$"
+
+    tempDir=$(mktemp -d)
+    cd $tempDir
+    mkdir remote
+    mkdir repo1
+
+    cd repo1
+    dolt init
+    dolt sql -q "create table t (pk int primary key); insert into t values (1), (2);"
+    dolt commit -Am "create t"
+    dolt remote add origin file://../remote
+    dolt push origin main
+
+    cd $repoDir
+    run dolt sql -r csv -q "call dolt_clone('file://$tempDir/remote', 'cloned');"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "status" ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -r csv -q "call dolt_clone_async('file://$tempDir/remote', 'cloned_async'); select sleep(2); select job_id, kind, database_name, status, bytes_fetched > 0, table_files_remaining from dolt_jobs;"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "job_id" ]
+    [ "${lines[1]}" = "1" ]
+    [[ "$output" =~ "1,clone,cloned_async,succeeded,true,0" ]] || false
+
+    run dolt sql -q "use cloned_async; select count(*) from t;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "call dolt_job_cancel(100);"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "job 100 not found" ]] || false
+}
+
 @test "remotes: fetch --prune deletes remote refs not on remote" {
     mkdir remote
     mkdir repo1
@@ -76,6 +76,44 @@ teardown() {
     [[ "$output" =~ "t1" ]] || false
 }
 
+@test "replication: push to multiple remotes" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote "backup1, remote1"
+    run dolt sql -q "create table t1 (a int primary key); call dolt_commit('-Am', 'cm'); select remote, last_pushed_ref, pending_refs, failed_attempts, last_error from dolt_replication_status order by remote;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "backup1,refs/heads/main,0,0," ]] || false
+    [[ "$output" =~ "remote1,refs/heads/main,0,0," ]] || false
+
+    cd ..
+    dolt clone file://./bac1 repo2
+    dolt clone file://./rem1 repo3
+
+    cd repo2
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+
+    cd ../repo3
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+}
+
+@test "replication: a remote which fails to load does not disable the others" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote "unknown,backup1"
+    run dolt sql -q "create table t1 (a int primary key); call dolt_commit('-Am', 'cm');"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remote not found: 'unknown'" ]] || false
+
+    cd ..
+    dolt clone file://./bac1 repo2
+    cd repo2
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+}
+
 @test "replication: push on cli engine commit" {
     cd repo1
     dolt config --local --add sqlserver.global.dolt_replicate_to_remote backup1
@@ -903,6 +941,25 @@ SQL
     [[ "$output" =~ "t1" ]] || false
 }
 
+@test "replication: async push with bounded lag reports its status" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote remote1
+    dolt config --local --add sqlserver.global.dolt_async_replication 1
+    dolt config --local --add sqlserver.global.dolt_async_replication_max_lag_secs 5
+
+    run dolt sql -q "create table t1 (a int primary key); call dolt_commit('-Am', 'cm'); select sleep(1); select remote, last_pushed_ref, pending_refs, failed_attempts from dolt_replication_status;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remote1,refs/heads/main,0,0" ]] || false
+    [ ! -f .dolt/replication_queue/remote1.json ]
+
+    cd ..
+    dolt clone file://./rem1 repo2
+    cd repo2
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t1" ]] || false
+}
+
 @test "replication: local clone" {
     run dolt clone file://./repo1/.dolt/noms repo2
     [ "$status" -eq 0 ]
@@ -945,3 +1002,48 @@ SQL
     [ "$status" -eq 0 ]
     [[ "$output" =~ "1,1" ]] || false 
 }
+
+@test "replication: dolt_verify_replica reports divergence from the source remote" {
+    cd repo1
+    dolt sql -q "create table t (pk int primary key, c int); insert into t values (1, 1), (2, 2);"
+    dolt commit -Am "created t"
+
+    run dolt sql -r csv -q "call dolt_verify_replica('remote1')"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature,,branch missing on source," ]] || false
+    [[ "$output" =~ "main,,head differs," ]] || false
+    [[ "$output" =~ "main,t,table missing on source,," ]] || false
+
+    dolt push remote1 main
+    dolt push remote1 feature
+    run dolt sql -r csv -q "call dolt_verify_replica('remote1')"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    # the same rows in a different commit only diverge in the head
+    dolt commit --amend -m "created t again"
+    run dolt sql -r csv -q "call dolt_verify_replica('remote1')"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "main,,head differs," ]] || false
+    dolt push -f remote1 main
+
+    cd ..
+    dolt clone file://./rem1 repo2
+    cd repo2
+    dolt sql -q "update t set c = 20 where pk = 2"
+    dolt commit -am "changed a row"
+    dolt push origin main
+
+    cd ../repo1
+    dolt config --local --add sqlserver.global.dolt_read_replica_remote remote1
+    run dolt sql -r csv -q "call dolt_verify_replica()"
+    [ "$status" -eq 0 ]
+    [ $(echo "$output" | grep -c "^main,") -eq 2 ]
+    [[ "$output" =~ "main,,head differs," ]] || false
+    [[ "$output" =~ "main,t,checksum differs," ]] || false
+
+    run dolt sql -q "call dolt_verify_replica('nope')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown remote" ]] || false
+}
@@ -71,6 +71,59 @@ teardown() {
     [[ "$output" =~ "conflict" ]] || false
 }
 
+@test "revert: --continue after resolving conflicts" {
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt add -A
+    dolt commit -m "Inserted 4"
+    dolt sql -q "REPLACE INTO test VALUES (4, 5)"
+    dolt add -A
+    dolt commit -m "Updated 4"
+    run dolt revert HEAD~1
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "dolt revert --continue" ]] || false
+
+    run dolt revert --continue
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "unresolved conflicts" ]] || false
+
+    dolt conflicts resolve --ours test
+    dolt revert --continue
+
+    run dolt log -n 1
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ 'Revert "Inserted 4"' ]] || false
+    [[ ! "$output" =~ "Merge:" ]] || false
+
+    run dolt sql -q "SELECT * FROM test WHERE pk = 4" -r=csv
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ "4,5" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+}
+
+@test "revert: --abort" {
+    dolt sql -q "INSERT INTO test VALUES (4, 4)"
+    dolt add -A
+    dolt commit -m "Inserted 4"
+    dolt sql -q "REPLACE INTO test VALUES (4, 5)"
+    dolt add -A
+    dolt commit -m "Updated 4"
+    run dolt revert HEAD~1
+    [ "$status" -eq "1" ]
+
+    dolt revert --abort
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    run dolt log -n 1
+    [[ "$output" =~ "Updated 4" ]] || false
+
+    run dolt revert --abort
+    [ "$status" -eq "1" ]
+    [[ "$output" =~ "no revert in progress" ]] || false
+}
+
 @test "revert: constraint violations" {
     dolt sql <<"SQL"
 CREATE TABLE parent (pk BIGINT PRIMARY KEY, v1 BIGINT, INDEX(v1));
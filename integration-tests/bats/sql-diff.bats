@@ -889,4 +889,55 @@ EOF
     run dolt diff --stat -r sql
     [ "$status" -eq 1 ]
     [[ "$output" =~ "diff stats are not supported for sql output" ]] || false
-}
\ No newline at end of file
+}
+@test "sql-diff: sql-patch output reconciles schema and data changes" {
+    dolt checkout -b firstbranch
+    dolt sql <<SQL
+create table t (pk int primary key, c1 int);
+insert into t values (1, 1), (2, 2), (3, 3);
+SQL
+    dolt add -A && dolt commit -m "create t"
+
+    dolt checkout -b newbranch
+    dolt sql <<SQL
+alter table t add column c2 varchar(20);
+update t set c2 = 'two' where pk = 2;
+delete from t where pk = 3;
+insert into t values (4, 4, 'four');
+create table u (pk int primary key);
+insert into u values (1);
+SQL
+    dolt add -A && dolt commit -m "change t, add u"
+
+    run dolt diff --format sql-patch firstbranch newbranch
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "SET FOREIGN_KEY_CHECKS=0;" ]
+    [[ "$output" =~ "ALTER TABLE \`t\` ADD \`c2\` varchar(20);" ]] || false
+    [ "${lines[-1]}" = "SET FOREIGN_KEY_CHECKS=1;" ]
+
+    dolt diff -r sql-patch firstbranch newbranch > patch.sql
+    dolt checkout firstbranch
+    dolt sql < patch.sql
+    dolt add -A && dolt commit -m "applied patch"
+
+    run dolt diff -r sql firstbranch newbranch
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "sql-diff: sql-patch output fails when primary key sets differ" {
+    dolt sql -q "create table t (pk int primary key, c1 int);"
+    dolt sql -q "insert into t values (1, 1);"
+    dolt add -A && dolt commit -m "create t"
+    dolt sql -q "alter table t drop primary key;"
+    dolt sql -q "alter table t add primary key (c1);"
+
+    run dolt diff -r sql-patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "cannot generate a sql patch" ]] || false
+    [[ "$output" =~ "table 't'" ]] || false
+
+    run dolt diff -r sql-patch --stat
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--stat cannot be combined with sql-patch output" ]] || false
+}
@@ -112,3 +112,72 @@ seed_repos_with_tables_with_use_statements() {
         USE repo2;
         call dolt_fetch();" -r csv
 }
+
+@test "sql-multi-db: attach and detach a database at a local path" {
+    seed_repos_with_tables_with_use_statements
+    # not a database of the data dir itself
+    mkdir -p external/other
+    cd external/other
+    dolt init
+    dolt sql -q "CREATE TABLE t (pk BIGINT PRIMARY KEY, c1 VARCHAR(10)); INSERT INTO t VALUES (2,'two');"
+    dolt commit -Am "create t"
+    dolt sql -q "INSERT INTO t VALUES (3,'three');"
+    cd ../..
+
+    run dolt --data-dir ./ sql -r csv -b -q "
+        CALL dolt_attach('$PWD/external/other', 'ext');
+        SELECT r2_t1.pk, r2_t1.c1, t.c1 FROM repo2.r2_t1 JOIN ext.t ON r2_t1.pk = t.pk ORDER BY 1;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,200,two" ]] || false
+    [[ "$output" =~ "3,300,three" ]] || false
+
+    run dolt --data-dir ./ sql -b -q "CALL dolt_attach('$PWD/external/other', 'ext'); INSERT INTO ext.t VALUES (4,'four');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    run dolt --data-dir ./ sql -b -q "CALL dolt_attach('$PWD/external/other', 'ext'); DROP DATABASE ext;"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "use dolt_detach" ]] || false
+
+    run dolt --data-dir ./ sql -b -q "CALL dolt_attach('$PWD/external/other', 'repo1');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "exists" ]] || false
+
+    run dolt --data-dir ./ sql -b -q "CALL dolt_attach('$PWD/repo1', 'ext');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "already served as database repo1" ]] || false
+
+    run dolt --data-dir ./ sql -r csv -b -q "
+        CALL dolt_attach('$PWD/external/other', 'ext');
+        CALL dolt_detach('ext');
+        SHOW DATABASES;"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "ext" ]] || false
+
+    run dolt --data-dir ./ sql -b -q "CALL dolt_detach('repo1');"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "database repo1 is not attached" ]] || false
+}
+
+@test "sql-multi-db: attach a remote database" {
+    mkdir remote1
+    cd repo1
+    dolt sql -q "CREATE TABLE t (pk BIGINT PRIMARY KEY); INSERT INTO t VALUES (1),(2);"
+    dolt commit -Am "create t"
+    dolt remote add origin file://../remote1
+    dolt push origin main
+    cd ..
+
+    run dolt --data-dir ./repo2 sql -r csv -b -q "
+        CALL dolt_attach('file://$PWD/remote1', 'rem');
+        SELECT count(*) FROM rem.t;
+        USE rem;
+        SELECT message FROM dolt_log LIMIT 1;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ "$output" =~ "create t" ]] || false
+
+    run dolt --data-dir ./repo2 sql -b -q "CALL dolt_attach('file://$PWD/remote1', 'rem'); INSERT INTO rem.t VALUES (3);"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "read-only" ]] || false
+}
@@ -209,6 +209,54 @@ behavior:
     [[ "$output" =~ "privs.db" ]] || false
 }
 
+@test "sql-privs: yaml specifies privilege database" {
+    make_test_repo
+    echo "privilege_database: test_db" > server.yaml
+
+    start_sql_server_with_config test_db server.yaml
+
+    dolt sql -q "create user new_user; grant select on *.* to new_user;"
+
+    # dolt_privileges holds password hashes, so only admins can read it
+    run dolt sql -q "select count(*) from dolt_privileges" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "1" ]] || false
+    run dolt -u new_user sql -q "select * from dolt_privileges"
+    [ $status -ne 0 ]
+    run dolt -u new_user sql -q "select * from dolt_diff_dolt_privileges"
+    [ $status -ne 0 ]
+
+    # and it isn't committed, or pushed, unless dolt_ignore doesn't ignore it
+    run dolt sql -q "call dolt_add('-A'); select count(*) from dolt_status where table_name = 'dolt_privileges' and staged = true" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "0" ]] || false
+    dolt sql -q "insert into dolt_ignore values ('dolt_privileges', false); call dolt_commit('-Am', 'create new_user');"
+
+    run dolt sql -q "select message from dolt_log limit 1" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "create new_user" ]] || false
+
+    run dolt sql -q "insert into dolt_privileges values (2, 'x')"
+    [ $status -ne 0 ]
+
+    # privileges are written to the branch they're loaded from, whichever branch is checked out
+    dolt sql -q "call dolt_checkout('-b', 'other'); create user other_user;"
+
+    # resetting dolt_privileges doesn't restore the grants it had
+    dolt sql -q "drop user new_user; call dolt_commit('-am', 'drop new_user'); call dolt_reset('--hard', 'HEAD~1');"
+
+    stop_sql_server
+    start_sql_server_with_config test_db server.yaml
+
+    run dolt sql -q "select user from mysql.user"
+    [ $status -eq 0 ]
+    [[ $output =~ other_user ]] || false
+    ! [[ $output =~ new_user ]] || false
+
+    run ls -a
+    ! [[ "$output" =~ ".doltcfg" ]] || false
+}
+
 @test "sql-privs: can read json privilege files and convert them" {
     make_test_repo
     cp $BATS_TEST_DIRNAME/privs.json .
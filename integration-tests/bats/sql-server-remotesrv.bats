@@ -684,3 +684,43 @@ GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
     [[ "$output" =~ "main" ]] || false
 }
 
+
+@test "sql-server-remotesrv: push that breaks dolt_schema_contracts is rejected" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table names (name varchar(10) primary key, age int);'
+    dolt sql -q "insert into dolt_schema_contracts values ('main', 'names', 'no_column_drops'), ('main', 'names', 'no_type_narrowing');"
+    dolt add -A
+    dolt commit -m 'initial names.'
+
+    APIPORT=$( definePORT )
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args -u "$SQL_USER" -p "$DOLT_REMOTE_PASSWORD" --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u $SQL_USER
+    cd cloned_db
+
+    dolt sql -q 'alter table names drop column age;'
+    dolt sql -q 'alter table names modify name varchar(5);'
+    dolt commit -am 'break the contract'
+
+    run dolt push origin --user $SQL_USER main:main
+    [[ "$status" -ne 0 ]] || false
+    [[ "$output" =~ "schema changes to branch main violate dolt_schema_contracts" ]] || false
+    [[ "$output" =~ "names.age: column dropped (no_column_drops)" ]] || false
+    [[ "$output" =~ "names.name: type changed from varchar(10) to varchar(5) (no_type_narrowing)" ]] || false
+
+    # the contracts only apply to main
+    run dolt push origin --user $SQL_USER main:other
+    [[ "$status" -eq 0 ]] || false
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_again -u $SQL_USER
+    cd cloned_again
+    run dolt sql -q 'show create table names;'
+    [[ "$output" =~ "age" ]] || false
+    [[ "$output" =~ "varchar(10)" ]] || false
+}
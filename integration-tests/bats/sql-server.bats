@@ -222,6 +222,200 @@ user_session_vars:
     [[ "$output" =~ "0" ]] || false
 }
 
+@test "sql-server: reload config on SIGHUP and dolt_reload_config()" {
+    skiponwindows "SIGHUP is not supported on Windows"
+
+    echo "system_variables:
+  max_allowed_packet: 2000000" > server.yaml
+    start_sql_server_with_config "" server.yaml
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT @@max_allowed_packet, @@dolt_log_level;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2000000" ]] || false
+    [[ "$output" =~ "debug" ]] || false
+
+    # start_sql_server_with_config runs the server with the config in .cliconfig.yaml
+    sed -i.bak 's/log_level: debug/log_level: info/; s/max_allowed_packet: 2000000/max_allowed_packet: 3000000/' .cliconfig.yaml
+    mkdir repo3
+    cd repo3
+    dolt init
+    dolt sql -q "create table t (i int primary key); insert into t values (1), (2);"
+    cd ..
+    kill -HUP $SERVER_PID
+    sleep 1
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT @@max_allowed_packet, @@dolt_log_level;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3000000" ]] || false
+    [[ "$output" =~ "info" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT count(*) FROM repo3.t;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    echo "user_limits:
+- name: dolt
+  max_rows_per_query: 1" >> .cliconfig.yaml
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "CALL dolt_reload_config();"
+    [ "$status" -eq 0 ]
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT * FROM repo3.t;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "max_rows_per_query" ]] || false
+
+    # an invalid config isn't applied
+    echo "log_level: nope" >> .cliconfig.yaml
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "CALL dolt_reload_config();"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "bad configuration" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT @@dolt_log_level;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "info" ]] || false
+}
+
+@test "sql-server: serve a read-only database from a remote" {
+    skiponwindows "Missing dependencies"
+
+    # the source of the remote is outside of the server's data directory
+    tempDir=$(mktemp -d)
+    mkdir $tempDir/src $tempDir/remote
+    cd $tempDir/src
+    dolt init
+    dolt sql -q "create table t (i int primary key, v varchar(10)); insert into t values (1, 'one');"
+    dolt commit -Am "add t"
+    dolt remote add origin file:///$tempDir/remote
+    dolt push origin main
+    cd $BATS_TMPDIR/dolt-repo-$$
+
+    echo "remote_databases:
+  - name: replica
+    remote_url: file:///$tempDir/remote
+    refresh_interval_millis: 100
+    cache_size_mb: 1" > server.yaml
+    start_sql_server_with_config "" server.yaml
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT * FROM replica.t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,one" ]] || false
+    [[ ! "$output" =~ "2,two" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "INSERT INTO replica.t VALUES (3, 'three');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read-only" ]] || false
+
+    # new commits and branches pushed to the remote are served once the database is refreshed
+    cd $tempDir/src
+    dolt sql -q "insert into t values (2, 'two');"
+    dolt commit -am "add a row"
+    dolt branch other
+    dolt push origin main
+    dolt push origin other
+    cd $BATS_TMPDIR/dolt-repo-$$
+    sleep 1
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT * FROM replica.t;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2,two" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q 'SELECT count(*) FROM `replica/other`.t; SELECT count(*) FROM replica.dolt_log;' -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "USE replica; SELECT * FROM dolt_status;"
+    [ "$status" -eq 0 ]
+}
+
+@test "sql-server: user-defined functions implemented by WebAssembly modules" {
+    skiponwindows "Missing dependencies"
+
+    # a module exporting add(i64, i64) i64
+    printf '\x00\x61\x73\x6d\x01\x00\x00\x00\x01\x07\x01\x60\x02\x7e\x7e\x01\x7e\x03\x02\x01\x00\x07\x07\x01\x03\x61\x64\x64\x00\x00\x0a\x09\x01\x07\x00\x20\x00\x20\x01\x7c\x0b' > add.wasm
+
+    echo "user_defined_functions:
+  - name: wasm_add
+    module_path: $(pwd)/add.wasm
+    export: add
+    version: 1.0.0" > server.yaml
+    start_sql_server_with_config "" server.yaml
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT wasm_add(40, 2), wasm_add(NULL, 2);" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "42," ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt sql -q "SELECT wasm_add(1);"
+    [ "$status" -ne 0 ]
+}
+
+@test "sql-server: dolt_wait_for_changes returns changes made by other sessions" {
+    skiponwindows "Missing dependencies"
+
+    cd repo1
+    dolt sql -q "create table t (pk int primary key); call dolt_commit('-Am', 'create t');"
+    start_sql_server_with_args "--user dolt"
+
+    dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -r csv -q "call dolt_subscribe('main', 't'); call dolt_wait_for_changes(30);" > waiter.txt 2>&1 &
+    waiter=$!
+    sleep 2
+
+    dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -q "insert into t values (1);"
+    wait $waiter
+    run cat waiter.txt
+    [[ "$output" =~ "repo1,main,t," ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -r csv -q "call dolt_subscribe('main'); call dolt_wait_for_changes(0);"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "repo1,main" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -q "call dolt_subscribe('missing');"
+    [ "$status" -ne 0 ]
+}
+
+@test "sql-server: edge_sync merges the device branch into the central branch of the remote" {
+    skiponwindows "Missing dependencies"
+
+    mkdir remote
+    cd repo1
+    dolt sql -q "create table t (pk int primary key, qty int); insert into t values (1, 10);"
+    dolt sql -q "insert into dolt_column_merge_strategies values ('t', 'qty', 'sum');"
+    dolt commit -Am "create t"
+    dolt remote add origin file://$(pwd)/../remote
+    dolt push origin main
+    cd ..
+    dolt clone file://$(pwd)/remote central
+
+    cd central
+    dolt sql -q "update t set qty = qty + 100 where pk = 1"
+    dolt commit -am "central change"
+    dolt push origin main
+
+    cd ../repo1
+    echo "
+edge_sync:
+  device_branch: device1
+  interval_millis: 500
+" > server.yaml
+    start_sql_server_with_config "" server.yaml
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -r csv -q "select active_branch();"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "device1" ]] || false
+
+    dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -q "update t set qty = qty + 5 where pk = 1; commit;"
+    sleep 3
+
+    cd ../central
+    dolt pull origin main
+    run dolt sql -r csv -q "select qty from t where pk = 1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "115" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=dolt --use-db=repo1 sql -r csv -q "select qty from t where pk = 1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "115" ]] || false
+}
+
 @test "sql-server: read-only mode" {
     skiponwindows "Missing dependencies"
 
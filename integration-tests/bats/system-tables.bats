@@ -612,6 +612,11 @@ SQL
       [[ "$output" =~ "| test            | test          | CREATE TABLE \`test\` (                                             | CREATE TABLE \`test\` (                                             |" ]] || false
       [[ "$output" =~ "|                 |               |   \`c3\` varchar(10),                                               |   \`c2\` int,                                                       |" ]] || false
 
+      run dolt sql -r csv -q "select migration_sql from dolt_schema_diff('branch1', 'branch2');"
+      [ "$status" -eq 0 ]
+      [ "${lines[1]}" = '"ALTER TABLE `test` DROP `c2`;' ]
+      [ "${lines[2]}" = 'ALTER TABLE `test` ADD `c3` varchar(10);"' ]
+
       run dolt sql -q "select * from dolt_schema_diff('branch1', 'branch1');"
       [ "$status" -eq 0 ]
       [ "$output" = "" ]
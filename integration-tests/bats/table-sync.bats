@@ -0,0 +1,160 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE people (id int primary key, first_name varchar(20), last_name varchar(20));"
+    dolt add .
+    dolt commit -m "create people"
+
+    mkdir -p sync/data
+    cat <<CSV > sync/data/people.csv
+id,first,last_name
+1,Ada,Lovelace
+2,Alan,Turing
+CSV
+    cat <<CSV > sync/data/orders.csv
+id,item
+10,book
+11,lamp
+CSV
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "table-sync: imports and exports the tables of a manifest in one commit" {
+    cat <<YAML > sync/manifest.yaml
+message: Nightly sync
+tables:
+  - table: people
+    import: data/people.csv
+    mappings:
+      first: first_name
+  - table: orders
+    import: data/orders.csv
+    mode: create
+    pk: [id]
+  - table: people
+    export: out/people.json
+YAML
+
+    run dolt table sync sync/manifest.yaml
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Sync completed successfully." ]] || false
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Nightly sync" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt sql -q "select first_name from people where id = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Ada" ]] || false
+
+    run dolt sql -q "select count(*) from orders" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    [ -f sync/out/people.json ]
+    run cat sync/out/people.json
+    [[ "$output" =~ "Lovelace" ]] || false
+}
+
+@test "table-sync: --message overrides the message of the manifest" {
+    cat <<YAML > sync/manifest.yaml
+message: Nightly sync
+tables:
+  - table: people
+    import: data/people.csv
+    mappings:
+      first: first_name
+YAML
+
+    run dolt table sync -m "manual sync" sync/manifest.yaml
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "manual sync" ]] || false
+    [[ ! "$output" =~ "Nightly sync" ]] || false
+}
+
+@test "table-sync: --skip-commit leaves the imported tables in the working set" {
+    cat <<YAML > sync/manifest.yaml
+tables:
+  - table: people
+    import: data/people.csv
+    mappings:
+      first: first_name
+YAML
+
+    run dolt table sync --skip-commit sync/manifest.yaml
+    [ "$status" -eq 0 ]
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "create people" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "people" ]] || false
+}
+
+@test "table-sync: no tables are changed if an import fails" {
+    cat <<CSV > sync/data/bad.csv
+id,first_name
+three,Grace
+CSV
+    cat <<YAML > sync/manifest.yaml
+tables:
+  - table: people
+    import: data/people.csv
+    mappings:
+      first: first_name
+  - table: people
+    import: data/bad.csv
+YAML
+
+    run dolt table sync sync/manifest.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "error: sync failed, no tables were changed" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt sql -q "select count(*) from people" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+}
+
+@test "table-sync: invalid manifests are rejected" {
+    cat <<YAML > sync/manifest.yaml
+tables:
+  - table: people
+    import: data/people.csv
+    colour: blue
+YAML
+
+    run dolt table sync sync/manifest.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid manifest" ]] || false
+
+    cat <<YAML > sync/manifest.yaml
+tables:
+  - table: people
+    export: out/people.csv
+    pk: [id]
+YAML
+
+    run dolt table sync sync/manifest.yaml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "pk is not supported for the export of table people" ]] || false
+    [ ! -f sync/out/people.csv ]
+}